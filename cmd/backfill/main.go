@@ -0,0 +1,81 @@
+// Command backfill copies the current state of all reservations and payments from the databases
+// to the data warehouse via the admin API of a running server with WAREHOUSE_PROVIDER set.
+//
+// Usage:
+//
+//	ADMIN_TOKEN=... backfill [-server http://localhost:8080] [-tables reservations,payments]
+//
+// Running it again only adds the records that changed since, because rows are keyed by ID and update time.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+func main() {
+	client := &http.Client{Timeout: 30 * time.Minute}
+	if err := run(os.Args[1:], os.Stdout, client, env.Get("ADMIN_TOKEN", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "backfill:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, triggers the backfill and prints the copied records.
+func run(args []string, stdout io.Writer, client *http.Client, token string) error {
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	server := flags.String("server", env.Get("SERVER_URL", "http://localhost:8080"), "base URL of the server")
+	tables := flags.String("tables", "", "comma-separated tables to copy (reservations, payments); default all")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New("usage: backfill [-server url] [-tables reservations,payments]")
+	}
+	if token == "" {
+		return errors.New("ADMIN_TOKEN not set")
+	}
+
+	target := strings.TrimSuffix(*server, "/") + "/admin/warehouse/backfill"
+	if *tables != "" {
+		target += "?" + url.Values{"tables": {*tables}}.Encode()
+	}
+	req, err := http.NewRequest(http.MethodPost, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call server: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.New("server has no warehouse configured (WAREHOUSE_PROVIDER) or ADMIN_TOKEN is not set there")
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("server answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var report inbound.HttpAdminWarehouseBackfillResponse
+	if err := json.Unmarshal(body, &report); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	fmt.Fprintf(stdout, "Copied %d reservations and %d payments to the warehouse\n", report.Reservations, report.Payments)
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// createTestAdminServer answers backfills with one reservation and two payments
+// and records the last query.
+func createTestAdminServer(t *testing.T, query *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/warehouse/backfill" {
+			http.NotFound(w, r)
+			return
+		}
+		*query = r.URL.RawQuery
+		_ = json.NewEncoder(w).Encode(inbound.HttpAdminWarehouseBackfillResponse{Reservations: 1, Payments: 2})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// run Tests
+// ============================================================================
+
+func Test_Run_Should_Print_Copied_Records(t *testing.T) {
+	// Arrange
+	var query string
+	server := createTestAdminServer(t, &query)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "all tables must be copied", query, "")
+	assert.That(t, "output must count the records", out.String(), "Copied 1 reservations and 2 payments to the warehouse\n")
+}
+
+func Test_Run_With_Tables_Should_Send_Tables(t *testing.T) {
+	// Arrange
+	var query string
+	server := createTestAdminServer(t, &query)
+
+	// Act
+	err := run([]string{"-server", server.URL, "-tables", "payments"}, &bytes.Buffer{}, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "tables must be sent", query, "tables=payments")
+}
+
+func Test_Run_Without_Token_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	err := run(nil, &bytes.Buffer{}, http.DefaultClient, "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_Run_With_Wrong_Token_Should_Fail(t *testing.T) {
+	// Arrange
+	var query string
+	server := createTestAdminServer(t, &query)
+
+	// Act
+	err := run([]string{"-server", server.URL}, &bytes.Buffer{}, server.Client(), "wrong")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
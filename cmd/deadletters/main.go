@@ -0,0 +1,199 @@
+// Command deadletters lists the events whose handlers failed and replays them against those handlers
+// once the cause is fixed.
+//
+// Usage:
+//
+//	ADMIN_TOKEN=... deadletters [-server http://localhost:8080] list [-topic payment.captured] [-status dead|replayed] [-json]
+//	ADMIN_TOKEN=... deadletters [-server http://localhost:8080] replay <id>...
+//	ADMIN_TOKEN=... deadletters [-server http://localhost:8080] replay -all [-topic payment.captured]
+//
+// The dead letters are read from GET /admin/dead-letters and replayed with POST /admin/dead-letters/{id}/replay.
+// replay -all replays every dead letter still dead, oldest first, and reports the ones that fail again.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+const usage = "usage: deadletters [-server url] list [-topic t] [-status dead|replayed] [-json] | replay <id>... | replay -all [-topic t]"
+
+func main() {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if err := run(os.Args[1:], os.Stdout, client, env.Get("ADMIN_TOKEN", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "deadletters:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments and lists or replays the dead letters.
+func run(args []string, stdout io.Writer, client *http.Client, token string) error {
+	flags := flag.NewFlagSet("deadletters", flag.ContinueOnError)
+	server := flags.String("server", env.Get("SERVER_URL", "http://localhost:8080"), "base URL of the server")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New(usage)
+	}
+	if token == "" {
+		return errors.New("ADMIN_TOKEN not set")
+	}
+
+	api := &adminAPI{client: client, server: strings.TrimSuffix(*server, "/"), token: token}
+	switch flags.Arg(0) {
+	case "list":
+		return runList(flags.Args()[1:], stdout, api)
+	case "replay":
+		return runReplay(flags.Args()[1:], stdout, api)
+	default:
+		return errors.New(usage)
+	}
+}
+
+// runList prints the dead letters of the topic and status.
+func runList(args []string, stdout io.Writer, api *adminAPI) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	topic := flags.String("topic", "", "only list the dead letters of this topic")
+	status := flags.String("status", "", "only list the dead letters with this status (dead or replayed)")
+	raw := flags.Bool("json", false, "print the dead letters as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New(usage)
+	}
+
+	letters, err := api.deadLetters(*topic, *status)
+	if err != nil {
+		return err
+	}
+	if *raw {
+		return json.NewEncoder(stdout).Encode(letters)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSUBSCRIPTION\tSTATUS\tATTEMPTS\tFAILED AT\tERROR")
+	for _, letter := range letters {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", letter.ID, letter.Subscription, letter.Status, letter.Attempts, letter.FailedAt, letter.Error)
+	}
+	return tw.Flush()
+}
+
+// runReplay replays the dead letters with the IDs, or every dead one with -all.
+// It replays them all even if some fail, and fails if any did.
+func runReplay(args []string, stdout io.Writer, api *adminAPI) error {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	all := flags.Bool("all", false, "replay every dead letter still dead")
+	topic := flags.String("topic", "", "with -all, only replay the dead letters of this topic")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	ids := flags.Args()
+	switch {
+	case *all && len(ids) > 0, !*all && len(ids) == 0, !*all && *topic != "":
+		return errors.New(usage)
+	case *all:
+		letters, err := api.deadLetters(*topic, "dead")
+		if err != nil {
+			return err
+		}
+		// The list is newest first; the events are replayed in the order they failed.
+		for i := len(letters) - 1; i >= 0; i-- {
+			ids = append(ids, letters[i].ID)
+		}
+	}
+
+	failed := 0
+	for _, id := range ids {
+		letter, err := api.replay(id)
+		if err != nil {
+			failed++
+			fmt.Fprintf(stdout, "%s: %v\n", id, err)
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: %s (%s)\n", id, letter.Status, letter.Subscription)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d replays failed", failed, len(ids))
+	}
+	return nil
+}
+
+// adminAPI calls the dead letter endpoints of the admin API.
+type adminAPI struct {
+	client *http.Client
+	server string
+	token  string
+}
+
+// deadLetters reads the dead letters of the topic and status.
+func (a *adminAPI) deadLetters(topic, status string) ([]inbound.HttpDeadLetter, error) {
+	query := url.Values{}
+	if topic != "" {
+		query.Set("topic", topic)
+	}
+	if status != "" {
+		query.Set("status", status)
+	}
+	target := a.server + "/admin/dead-letters"
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var letters []inbound.HttpDeadLetter
+	if err := a.call(http.MethodGet, target, &letters); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// replay replays the dead letter and returns it updated.
+func (a *adminAPI) replay(id string) (inbound.HttpDeadLetter, error) {
+	var letter inbound.HttpDeadLetter
+	err := a.call(http.MethodPost, a.server+"/admin/dead-letters/"+url.PathEscape(id)+"/replay", &letter)
+	return letter, err
+}
+
+// call sends the request and decodes the JSON response into v.
+// A failed replay answers 502 with the dead letter; its new error is returned.
+func (a *adminAPI) call(method, target string, v any) error {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call server: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.Unmarshal(body, v); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		return nil
+	case http.StatusBadGateway:
+		var letter inbound.HttpDeadLetter
+		if json.Unmarshal(body, &letter) == nil && letter.Error != "" {
+			return fmt.Errorf("replay failed: %s", letter.Error)
+		}
+	}
+	return fmt.Errorf("server answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// testAdminServer answers the dead letter endpoints with two dead letters, newest first.
+// Replaying dl-2 fails again.
+type testAdminServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	replayed []string
+	query    string
+}
+
+// createTestAdminServer starts the admin server.
+func createTestAdminServer(t *testing.T) *testAdminServer {
+	t.Helper()
+	letters := []inbound.HttpDeadLetter{
+		{ID: "dl-2", Topic: "payment.captured", Subscription: "payment.captured#1", Status: "dead", Attempts: 1, FailedAt: "2026-07-02T10:00:00Z", Error: "mail server down"},
+		{ID: "dl-1", Topic: "payment.captured", Subscription: "payment.captured#1", Status: "dead", Attempts: 1, FailedAt: "2026-07-01T10:00:00Z", Error: "mail server down"},
+	}
+	s := &testAdminServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/dead-letters", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.query = r.URL.RawQuery
+		s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(letters)
+	})
+	mux.HandleFunc("POST /admin/dead-letters/{id}/replay", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		s.replayed = append(s.replayed, id)
+		s.mu.Unlock()
+		switch id {
+		case "dl-1":
+			_ = json.NewEncoder(w).Encode(inbound.HttpDeadLetter{ID: id, Subscription: "payment.captured#1", Status: "replayed", Attempts: 2})
+		case "dl-2":
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(inbound.HttpDeadLetter{ID: id, Subscription: "payment.captured#1", Status: "dead", Attempts: 2, Error: "still down"})
+		default:
+			http.Error(w, "dead letter not found", http.StatusNotFound)
+		}
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// ============================================================================
+// run Tests
+// ============================================================================
+
+func Test_Run_List_Should_Print_Table_With_Filters(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "list", "-topic", "payment.captured", "-status", "dead"}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "filters must be sent", server.query, "status=dead&topic=payment.captured")
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.That(t, "table must have a header and two rows", len(lines), 3)
+	assert.That(t, "row must start with the ID and subscription", strings.Fields(lines[1])[:3], []string{"dl-2", "payment.captured#1", "dead"})
+}
+
+func Test_Run_Replay_Should_Replay_Each_ID(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "replay", "dl-1"}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "output must report the replay", out.String(), "dl-1: replayed (payment.captured#1)\n")
+}
+
+func Test_Run_Replay_All_Should_Replay_Oldest_First_And_Report_Failures(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "replay", "-all"}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must report the failed replay", err != nil && strings.Contains(err.Error(), "1 of 2"), true)
+	assert.That(t, "dead letters must be replayed oldest first", server.replayed, []string{"dl-1", "dl-2"})
+	assert.That(t, "only dead letters must be listed", server.query, "status=dead")
+	assert.That(t, "output must show the new error", strings.Contains(out.String(), "dl-2: replay failed: still down"), true)
+}
+
+func Test_Run_Replay_Without_IDs_Should_Fail(t *testing.T) {
+	// Act
+	err := run([]string{"replay"}, &bytes.Buffer{}, http.DefaultClient, "secret")
+
+	// Assert
+	assert.That(t, "err must be the usage", err != nil && strings.HasPrefix(err.Error(), "usage:"), true)
+}
+
+func Test_Run_Without_Token_Should_Fail(t *testing.T) {
+	// Act
+	err := run([]string{"list"}, &bytes.Buffer{}, http.DefaultClient, "")
+
+	// Assert
+	assert.That(t, "err must mention the token", err != nil && strings.Contains(err.Error(), "ADMIN_TOKEN"), true)
+}
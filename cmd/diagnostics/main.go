@@ -0,0 +1,97 @@
+// Command diagnostics downloads the diagnostic bundle of a running server during an incident:
+// the settings without secrets, the health of the dependencies, the metrics, the queue depths,
+// the failed booking sagas, a goroutine dump and a heap profile in one zip archive.
+//
+// Usage:
+//
+//	ADMIN_TOKEN=... diagnostics [-server http://localhost:8080] [-out diagnostics.zip] [-cpu 10s]
+//
+// The bundle is read from GET /internal/diagnostics/bundle of the instance the server URL reaches,
+// so behind a load balancer each replica must be addressed directly.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+)
+
+func main() {
+	client := &http.Client{Timeout: 2 * time.Minute}
+	if err := run(os.Args[1:], os.Stdout, client, env.Get("ADMIN_TOKEN", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "diagnostics:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, downloads the bundle and prints where it was saved.
+func run(args []string, stdout io.Writer, client *http.Client, token string) error {
+	flags := flag.NewFlagSet("diagnostics", flag.ContinueOnError)
+	server := flags.String("server", env.Get("SERVER_URL", "http://localhost:8080"), "base URL of the server")
+	out := flags.String("out", "", "file of the bundle; default diagnostics-<time>.zip")
+	cpu := flags.Duration("cpu", 0, "add a CPU profile of this duration (at most 30s)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New("usage: diagnostics [-server url] [-out file] [-cpu duration]")
+	}
+	if token == "" {
+		return errors.New("ADMIN_TOKEN not set")
+	}
+	if *out == "" {
+		*out = "diagnostics-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	}
+
+	target := strings.TrimSuffix(*server, "/") + "/internal/diagnostics/bundle"
+	if *cpu > 0 {
+		target += "?" + url.Values{"cpu": {cpu.String()}}.Encode()
+	}
+	size, err := download(client, target, token, *out)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "saved %s (%d bytes)\n", *out, size)
+	return nil
+}
+
+// download writes the bundle to the file; the file is removed if the download fails.
+func download(client *http.Client, target, token, path string) (int64, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return 0, fmt.Errorf("server answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	size, err := io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, fmt.Errorf("failed to download bundle: %w", err)
+	}
+	return size, nil
+}
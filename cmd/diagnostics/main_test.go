@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+)
+
+// createTestBundleServer answers with a bundle and records the query of the request.
+func createTestBundleServer(t *testing.T, query *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/internal/diagnostics/bundle" {
+			http.NotFound(w, r)
+			return
+		}
+		*query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write([]byte("PK-bundle"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// run Tests
+// ============================================================================
+
+func Test_Run_Should_Save_Bundle(t *testing.T) {
+	// Arrange
+	var query string
+	server := createTestBundleServer(t, &query)
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "-out", path, "-cpu", "5s"}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	data, _ := os.ReadFile(path)
+	assert.That(t, "bundle must be saved", string(data), "PK-bundle")
+	assert.That(t, "cpu profile must be requested", query, "cpu=5s")
+	assert.That(t, "path must be printed", strings.Contains(out.String(), path), true)
+}
+
+func Test_Run_With_Wrong_Token_Should_Fail_Without_File(t *testing.T) {
+	// Arrange
+	var query string
+	server := createTestBundleServer(t, &query)
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+
+	// Act
+	err := run([]string{"-server", server.URL, "-out", path}, &bytes.Buffer{}, server.Client(), "wrong")
+
+	// Assert
+	assert.That(t, "err must mention the status", err != nil && strings.Contains(err.Error(), "401"), true)
+	_, statErr := os.Stat(path)
+	assert.That(t, "file must not exist", os.IsNotExist(statErr), true)
+}
+
+func Test_Run_Without_Token_Should_Fail(t *testing.T) {
+	// Act
+	err := run(nil, &bytes.Buffer{}, http.DefaultClient, "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,128 @@
+// Command routes prints the routes mounted by a running server, with method, path, required
+// authentication and handler, for documentation and security reviews.
+//
+// Usage:
+//
+//	ADMIN_TOKEN=... routes [-server http://localhost:8080] [-auth none] [-markdown|-json]
+//
+// The routes are read from GET /internal/routes, so only the routes of the server's configuration are listed.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+func main() {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if err := run(os.Args[1:], os.Stdout, client, env.Get("ADMIN_TOKEN", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "routes:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, fetches the routes and prints them.
+func run(args []string, stdout io.Writer, client *http.Client, token string) error {
+	flags := flag.NewFlagSet("routes", flag.ContinueOnError)
+	server := flags.String("server", env.Get("SERVER_URL", "http://localhost:8080"), "base URL of the server")
+	auth := flags.String("auth", "", "only list routes with this authentication (e.g. none, session, bearer, admin_token)")
+	markdown := flags.Bool("markdown", false, "print a markdown table")
+	raw := flags.Bool("json", false, "print the routes as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New("usage: routes [-server url] [-auth scheme] [-markdown|-json]")
+	}
+	if token == "" {
+		return errors.New("ADMIN_TOKEN not set")
+	}
+
+	routes, err := fetchRoutes(client, strings.TrimSuffix(*server, "/"), token)
+	if err != nil {
+		return err
+	}
+	if *auth != "" {
+		filtered := routes[:0]
+		for _, route := range routes {
+			if string(route.Auth) == *auth {
+				filtered = append(filtered, route)
+			}
+		}
+		routes = filtered
+	}
+	switch {
+	case *raw:
+		return json.NewEncoder(stdout).Encode(routes)
+	case *markdown:
+		printMarkdown(stdout, routes)
+	default:
+		printTable(stdout, routes)
+	}
+	return nil
+}
+
+// fetchRoutes reads the mounted routes from the admin API.
+func fetchRoutes(client *http.Client, server, token string) ([]inbound.RouteInfo, error) {
+	req, err := http.NewRequest(http.MethodGet, server+"/internal/routes", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call server: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routes: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var routes []inbound.RouteInfo
+	if err := json.Unmarshal(body, &routes); err != nil {
+		return nil, fmt.Errorf("failed to parse routes: %w", err)
+	}
+	return routes, nil
+}
+
+// printTable prints the routes aligned in columns.
+func printTable(w io.Writer, routes []inbound.RouteInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tAUTH\tHANDLER")
+	for _, route := range routes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", method(route), route.Path, route.Auth, route.Handler)
+	}
+	_ = tw.Flush()
+}
+
+// printMarkdown prints the routes as a markdown table for the documentation.
+func printMarkdown(w io.Writer, routes []inbound.RouteInfo) {
+	fmt.Fprintln(w, "| Method | Path | Auth | Handler |")
+	fmt.Fprintln(w, "|--------|------|------|---------|")
+	for _, route := range routes {
+		fmt.Fprintf(w, "| %s | `%s` | %s | `%s` |\n", method(route), route.Path, route.Auth, route.Handler)
+	}
+}
+
+// method returns the method of the route, or ANY if it matches every method.
+func method(route inbound.RouteInfo) string {
+	if route.Method == "" {
+		return "ANY"
+	}
+	return route.Method
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// createTestAdminServer answers with a public and an admin route.
+func createTestAdminServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]inbound.RouteInfo{
+			{Method: "GET", Path: "/admin/nps", Auth: inbound.RouteAuthAdminToken, Handler: "inbound.HttpAdminNPS"},
+			{Method: "", Path: "/static/", Auth: inbound.RouteAuthNone, Handler: "http.FileServerFS"},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// run Tests
+// ============================================================================
+
+func Test_Run_Should_Print_Table(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.That(t, "table must have a header and two rows", len(lines), 3)
+	assert.That(t, "row must list the admin route", strings.Fields(lines[1]), []string{"GET", "/admin/nps", "admin_token", "inbound.HttpAdminNPS"})
+	assert.That(t, "routes of every method must show ANY", strings.Fields(lines[2])[0], "ANY")
+}
+
+func Test_Run_With_Markdown_And_Auth_Flags_Should_Print_Filtered_Table(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "-markdown", "-auth", "none"}, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "output must be the filtered markdown table", out.String(), "| Method | Path | Auth | Handler |\n|--------|------|------|---------|\n| ANY | `/static/` | none | `http.FileServerFS` |\n")
+}
+
+func Test_Run_With_Wrong_Token_Should_Fail(t *testing.T) {
+	// Arrange
+	server := createTestAdminServer(t)
+
+	// Act
+	err := run([]string{"-server", server.URL}, &bytes.Buffer{}, server.Client(), "wrong")
+
+	// Assert
+	assert.That(t, "err must mention the status", err != nil && strings.Contains(err.Error(), "401"), true)
+}
+
+func Test_Run_Without_Token_Should_Fail(t *testing.T) {
+	// Act
+	err := run(nil, &bytes.Buffer{}, http.DefaultClient, "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
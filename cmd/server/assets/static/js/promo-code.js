@@ -0,0 +1,60 @@
+// Promo code - shows the discount of the entered code in the reservation form before submitting.
+// Fetches GET /ui/promotions/check when the code or the stay changes and marks the code invalid
+// if it cannot be used. The server checks the code again on submit.
+(function () {
+  'use strict';
+
+  var code = document.getElementById('promo_code');
+  var hint = document.getElementById('promo_code_hint');
+  var room = document.getElementById('room_id');
+  var checkIn = document.getElementById('check_in');
+  var checkOut = document.getElementById('check_out');
+  if (!code || !hint) {
+    return;
+  }
+
+  var pending = 0;
+
+  function show(message, valid) {
+    code.setCustomValidity(valid ? '' : message);
+    hint.textContent = message;
+  }
+
+  function check() {
+    var value = code.value.trim();
+    var request = ++pending;
+    if (!value) {
+      show('', true);
+      return;
+    }
+    var url = '/ui/promotions/check?code=' + encodeURIComponent(value);
+    if (room && checkIn && checkOut && room.value && checkIn.value && checkOut.value) {
+      url += '&room_id=' + encodeURIComponent(room.value) + '&check_in=' + checkIn.value + '&check_out=' + checkOut.value;
+    }
+    fetch(url, { credentials: 'same-origin' })
+      .then(function (response) {
+        return response.ok ? response.json() : null;
+      })
+      .then(function (result) {
+        if (!result || request !== pending) {
+          return;
+        }
+        var message = result.message;
+        if (result.valid && result.total) {
+          message += ': you save ' + result.discount + ', ' + result.total + ' in total.';
+        }
+        show(message, result.valid);
+      })
+      .catch(function () {
+        // Without the check the server still rejects invalid codes on submit.
+      });
+  }
+
+  code.addEventListener('change', check);
+  [room, checkIn, checkOut].forEach(function (input) {
+    if (input) {
+      input.addEventListener('change', check);
+    }
+  });
+  check();
+})();
@@ -0,0 +1,134 @@
+// Installed guest portal - registers the service worker, offers the install prompt and push notifications,
+// and makes pages served from the cache read-only while offline.
+// The buttons stay hidden unless the browser supports them: #install-app waits for beforeinstallprompt,
+// #enable-push for GET /ui/push/key, which answers 404 if push is not configured.
+(function () {
+  'use strict';
+
+  if (!('serviceWorker' in navigator)) {
+    return;
+  }
+  navigator.serviceWorker.register('/sw.js').catch(function (err) {
+    console.warn('Service Worker registration failed', err);
+  });
+
+  // Offline: show the notice and disable every form and htmx action, since changes cannot be sent.
+  var notice = document.getElementById('offline-notice');
+  function updateOnline() {
+    var offline = !navigator.onLine;
+    if (notice) {
+      notice.hidden = !offline;
+    }
+    document.querySelectorAll('main form, main [hx-post], main [hx-delete]').forEach(function (el) {
+      el.inert = offline;
+    });
+  }
+  window.addEventListener('online', updateOnline);
+  window.addEventListener('offline', updateOnline);
+  updateOnline();
+
+  // Install prompt: the browser fires beforeinstallprompt once the portal is installable.
+  var install = document.getElementById('install-app');
+  var deferredPrompt = null;
+  window.addEventListener('beforeinstallprompt', function (event) {
+    event.preventDefault();
+    deferredPrompt = event;
+    if (install) {
+      install.hidden = false;
+    }
+  });
+  if (install) {
+    install.addEventListener('click', function () {
+      if (!deferredPrompt) {
+        return;
+      }
+      deferredPrompt.prompt();
+      deferredPrompt.userChoice.finally(function () {
+        deferredPrompt = null;
+        install.hidden = true;
+      });
+    });
+  }
+  window.addEventListener('appinstalled', function () {
+    if (install) {
+      install.hidden = true;
+    }
+  });
+
+  // Push notifications: subscribe with the server's VAPID key and register the subscription.
+  var push = document.getElementById('enable-push');
+  if (!push || !('PushManager' in window) || !('Notification' in window)) {
+    return;
+  }
+
+  function keyBytes(base64url) {
+    var base64 = (base64url + '='.repeat((4 - (base64url.length % 4)) % 4)).replace(/-/g, '+').replace(/_/g, '/');
+    var raw = atob(base64);
+    var bytes = new Uint8Array(raw.length);
+    for (var i = 0; i < raw.length; i++) {
+      bytes[i] = raw.charCodeAt(i);
+    }
+    return bytes;
+  }
+
+  function sendSubscription(method, subscription) {
+    return fetch('/ui/push/subscriptions', {
+      method: method,
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(subscription),
+      credentials: 'same-origin'
+    }).then(function (response) {
+      if (!response.ok && response.status !== 404) {
+        throw new Error('push subscription failed: ' + response.status);
+      }
+    });
+  }
+
+  function showState(subscribed) {
+    push.hidden = false;
+    push.textContent = subscribed ? 'Turn Off Notifications' : 'Turn On Notifications';
+    push.setAttribute('aria-pressed', subscribed ? 'true' : 'false');
+  }
+
+  fetch('/ui/push/key', { credentials: 'same-origin' })
+    .then(function (response) {
+      return response.ok ? response.json() : null;
+    })
+    .then(function (key) {
+      if (!key || !key.public_key) {
+        return;
+      }
+      navigator.serviceWorker.ready.then(function (registration) {
+        registration.pushManager.getSubscription().then(function (current) {
+          showState(current !== null);
+        });
+
+        push.addEventListener('click', function () {
+          push.disabled = true;
+          registration.pushManager.getSubscription()
+            .then(function (current) {
+              if (current) {
+                return sendSubscription('DELETE', current.toJSON())
+                  .then(function () { return current.unsubscribe(); })
+                  .then(function () { showState(false); });
+              }
+              return Notification.requestPermission().then(function (permission) {
+                if (permission !== 'granted') {
+                  return;
+                }
+                return registration.pushManager
+                  .subscribe({ userVisibleOnly: true, applicationServerKey: keyBytes(key.public_key) })
+                  .then(function (subscription) { return sendSubscription('POST', subscription.toJSON()); })
+                  .then(function () { showState(true); });
+              });
+            })
+            .catch(function (err) {
+              console.warn('Push subscription failed', err);
+            })
+            .finally(function () {
+              push.disabled = false;
+            });
+        });
+      });
+    });
+})();
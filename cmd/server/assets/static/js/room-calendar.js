@@ -0,0 +1,70 @@
+// Room calendar - rejects booked nights in the reservation form before submitting.
+// Fetches GET /ui/rooms/{id}/calendar when the room or the dates change and marks the
+// check-out date invalid if the stay covers a booked night. The server checks again on submit.
+(function () {
+  'use strict';
+
+  var room = document.getElementById('room_id');
+  var checkIn = document.getElementById('check_in');
+  var checkOut = document.getElementById('check_out');
+  var hint = document.getElementById('room_calendar_hint');
+  if (!room || !checkIn || !checkOut || !hint) {
+    return;
+  }
+
+  var booked = {};
+
+  function bookedNights() {
+    if (!checkIn.value || !checkOut.value) {
+      return [];
+    }
+    var nights = [];
+    var day = new Date(checkIn.value + 'T00:00:00Z');
+    var end = new Date(checkOut.value + 'T00:00:00Z');
+    for (; day < end; day.setUTCDate(day.getUTCDate() + 1)) {
+      var date = day.toISOString().slice(0, 10);
+      if (booked[date]) {
+        nights.push(date);
+      }
+    }
+    return nights;
+  }
+
+  function validate() {
+    var nights = bookedNights();
+    var message = nights.length ? 'The room is already booked on ' + nights.join(', ') + '.' : '';
+    checkOut.setCustomValidity(message);
+    hint.textContent = message;
+  }
+
+  function load() {
+    booked = {};
+    validate();
+    if (!room.value) {
+      return;
+    }
+    var url = '/ui/rooms/' + encodeURIComponent(room.value) + '/calendar';
+    if (checkIn.value) {
+      url += '?from=' + checkIn.value;
+    }
+    fetch(url, { credentials: 'same-origin' })
+      .then(function (response) {
+        return response.ok ? response.json() : { days: [] };
+      })
+      .then(function (calendar) {
+        calendar.days.forEach(function (day) {
+          if (!day.available) {
+            booked[day.date] = true;
+          }
+        });
+        validate();
+      })
+      .catch(function () {
+        // Without the calendar the server still rejects booked dates on submit.
+      });
+  }
+
+  room.addEventListener('change', load);
+  checkIn.addEventListener('change', load);
+  checkOut.addEventListener('change', validate);
+})();
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// This file contains the runtime configuration reload.
+// Ops change non-secret settings in CONFIG_FILE (env file format, e.g. a ConfigMap
+// mounted by Helm) and send SIGHUP or call POST /admin/config/reload. The file
+// overrides the environment. On reload every changed section is validated before
+// any is applied, so an invalid file changes nothing. Settings outside the
+// hot-reloadable sections only take effect after a restart and are reported as such.
+
+// configLookup returns the value of a setting and whether it is set.
+type configLookup func(key string) (string, bool)
+
+// configSection is a hot-reloadable part of the configuration.
+type configSection struct {
+	name string
+	keys []string
+	// prepare validates the settings and returns the function applying them.
+	prepare func(lookup configLookup) (apply func(), err error)
+}
+
+// configReloader re-reads CONFIG_FILE and applies the changed sections.
+type configReloader struct {
+	path     string
+	env      map[string]string // process environment without the file
+	started  map[string]string // file at startup
+	current  map[string]string // file in effect
+	sections []configSection
+	mu       sync.Mutex
+}
+
+// newConfigReloader reads the config file (if any) and exports its settings to the
+// process environment, so the settings that are not reloadable are read at startup too.
+func newConfigReloader(path string) (*configReloader, error) {
+	r := &configReloader{path: path, env: make(map[string]string)}
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			r.env[key] = value
+		}
+	}
+	file, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range file {
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("failed to apply config file: %w", err)
+		}
+	}
+	r.started, r.current = file, file
+	return r, nil
+}
+
+// lookup returns the settings in effect.
+func (r *configReloader) lookup(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookupIn(r.current)(key)
+}
+
+// settings returns all settings in effect: the environment overridden by the file.
+func (r *configReloader) settings() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	settings := maps.Clone(r.env)
+	maps.Copy(settings, r.current)
+	return settings
+}
+
+// lookupIn returns the settings of the file, falling back to the environment.
+func (r *configReloader) lookupIn(file map[string]string) configLookup {
+	return func(key string) (string, bool) {
+		if value, ok := file[key]; ok {
+			return value, true
+		}
+		value, ok := r.env[key]
+		return value, ok
+	}
+}
+
+// read reads the config file; without a file only the environment is used.
+func (r *configReloader) read() (map[string]string, error) {
+	if r.path == "" {
+		return map[string]string{}, nil
+	}
+	return outbound.ReadConfigFile(r.path)
+}
+
+// Reload re-reads the config file, applies the changed sections and reports the changes.
+func (r *configReloader) Reload(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	current, next := r.lookupIn(r.current), r.lookupIn(file)
+
+	var changes []string
+	var applies []func()
+	reloadable := make(map[string]bool)
+	for _, section := range r.sections {
+		var changed []string
+		for _, key := range section.keys {
+			reloadable[key] = true
+			before, _ := current(key)
+			after, _ := next(key)
+			if before != after {
+				changed = append(changed, fmt.Sprintf("%s: %s %q -> %q", section.name, key, before, after))
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		apply, err := section.prepare(next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", section.name, err)
+		}
+		applies = append(applies, apply)
+		changes = append(changes, changed...)
+	}
+
+	// Values are not reported here, because the file may also hold secrets.
+	started := r.lookupIn(r.started)
+	keys := append(slices.Collect(maps.Keys(r.started)), slices.Collect(maps.Keys(file))...)
+	slices.Sort(keys)
+	for _, key := range slices.Compact(keys) {
+		before, _ := started(key)
+		after, _ := next(key)
+		if !reloadable[key] && before != after {
+			changes = append(changes, key+": restart required")
+		}
+	}
+
+	for _, apply := range applies {
+		apply()
+	}
+	r.current = file
+	return changes, nil
+}
+
+// reloadable registers a config section parsed by parse and applied by apply on reload.
+func reloadable[T any](r *configReloader, name string, keys []string, parse func(configLookup) (T, error), apply func(T)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sections = append(r.sections, configSection{
+		name: name,
+		keys: keys,
+		prepare: func(lookup configLookup) (func(), error) {
+			value, err := parse(lookup)
+			if err != nil {
+				return nil, err
+			}
+			return func() { apply(value) }, nil
+		},
+	})
+}
+
+// configInt returns an integer setting of at least minimum.
+// Unlike env.Get, an invalid value is an error instead of falling back to the default.
+func configInt(lookup configLookup, key string, def, minimum int) (int, error) {
+	value, ok := lookup(key)
+	if !ok || value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minimum {
+		return 0, fmt.Errorf("%s must be an integer of at least %d: %q", key, minimum, value)
+	}
+	return n, nil
+}
+
+// configString returns a string setting.
+func configString(lookup configLookup, key, def string) string {
+	if value, ok := lookup(key); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// logSettings are the log levels and sampling rates (LOGGING_*).
+type logSettings struct {
+	defaultLevel string
+	levels       string
+	sampling     string
+}
+
+// logSettingsKeys are the settings of the log levels.
+var logSettingsKeys = []string{"LOGGING_LEVEL", "LOGGING_LEVELS", "LOGGING_SAMPLING"}
+
+// parseLogSettings reads and validates the log levels and sampling rates.
+func parseLogSettings(lookup configLookup) (logSettings, error) {
+	settings := logSettings{
+		defaultLevel: configString(lookup, "LOGGING_LEVEL", "INFO"),
+		levels:       configString(lookup, "LOGGING_LEVELS", ""),
+		sampling:     configString(lookup, "LOGGING_SAMPLING", "availability=100"),
+	}
+	// Validate with a throwaway registry, so a reload never applies half of the settings.
+	if _, err := outbound.NewLogLevels(io.Discard, settings.defaultLevel, settings.levels, settings.sampling); err != nil {
+		return logSettings{}, err
+	}
+	return settings, nil
+}
+
+// tierPolicyKeys are the settings of the VIP tiers.
+var tierPolicyKeys = []string{"VIP_GOLD_STAYS", "VIP_PLATINUM_STAYS", "VIP_GOLD_DISCOUNT", "VIP_PLATINUM_DISCOUNT"}
+
+// parseTierPolicy reads the VIP tier thresholds and discounts.
+func parseTierPolicy(lookup configLookup) (profile.TierPolicy, error) {
+	policy := profile.DefaultTierPolicy()
+	goldPerks, platinumPerks := policy.Perks[profile.TierGold], policy.Perks[profile.TierPlatinum]
+	var err error
+	if policy.GoldStays, err = configInt(lookup, "VIP_GOLD_STAYS", policy.GoldStays, 0); err != nil {
+		return profile.TierPolicy{}, err
+	}
+	if policy.PlatinumStays, err = configInt(lookup, "VIP_PLATINUM_STAYS", policy.PlatinumStays, 0); err != nil {
+		return profile.TierPolicy{}, err
+	}
+	if goldPerks.DiscountPercent, err = configInt(lookup, "VIP_GOLD_DISCOUNT", goldPerks.DiscountPercent, 0); err != nil {
+		return profile.TierPolicy{}, err
+	}
+	if platinumPerks.DiscountPercent, err = configInt(lookup, "VIP_PLATINUM_DISCOUNT", platinumPerks.DiscountPercent, 0); err != nil {
+		return profile.TierPolicy{}, err
+	}
+	if goldPerks.DiscountPercent > 100 || platinumPerks.DiscountPercent > 100 {
+		return profile.TierPolicy{}, fmt.Errorf("VIP discounts must not exceed 100 percent")
+	}
+	policy.Perks[profile.TierGold], policy.Perks[profile.TierPlatinum] = goldPerks, platinumPerks
+	return policy, nil
+}
+
+// referralPolicyKeys are the settings of the referral program.
+var referralPolicyKeys = []string{"REFERRAL_REWARD_KIND", "REFERRAL_REWARD_CREDIT", "REFERRAL_REWARD_POINTS", "REFERRAL_MONTHLY_LIMIT"}
+
+// parseReferralPolicy reads the referral reward and limit.
+func parseReferralPolicy(lookup configLookup) (referral.Policy, error) {
+	policy := referral.DefaultPolicy()
+	kind, err := referral.ParseRewardKind(configString(lookup, "REFERRAL_REWARD_KIND", string(referral.RewardCredit)))
+	if err != nil {
+		return referral.Policy{}, err
+	}
+	policy.Reward = referral.Reward{Kind: kind}
+	switch kind {
+	case referral.RewardCredit:
+		credit, err := configInt(lookup, "REFERRAL_REWARD_CREDIT", 2500, 0)
+		if err != nil {
+			return referral.Policy{}, err
+		}
+		policy.Reward.Credit = shared.NewMoney(int64(credit), "USD")
+	case referral.RewardPoints:
+		if policy.Reward.Points, err = configInt(lookup, "REFERRAL_REWARD_POINTS", 500, 0); err != nil {
+			return referral.Policy{}, err
+		}
+	}
+	if policy.MonthlyLimit, err = configInt(lookup, "REFERRAL_MONTHLY_LIMIT", policy.MonthlyLimit, 0); err != nil {
+		return referral.Policy{}, err
+	}
+	return policy, nil
+}
+
+// loyaltyPolicyKeys are the settings of the loyalty program.
+var loyaltyPolicyKeys = []string{"LOYALTY_POINTS_PER_UNIT", "LOYALTY_POINT_VALUE"}
+
+// parseLoyaltyPolicy reads how many points a stay earns and what a point is worth.
+func parseLoyaltyPolicy(lookup configLookup) (loyalty.Policy, error) {
+	policy := loyalty.DefaultPolicy()
+	var err error
+	if policy.PointsPerUnit, err = configInt(lookup, "LOYALTY_POINTS_PER_UNIT", policy.PointsPerUnit, 0); err != nil {
+		return loyalty.Policy{}, err
+	}
+	value, err := configInt(lookup, "LOYALTY_POINT_VALUE", int(policy.PointValue), 1)
+	if err != nil {
+		return loyalty.Policy{}, err
+	}
+	policy.PointValue = int64(value)
+	return policy, nil
+}
+
+// ratePolicyKeys are the settings of the room rates.
+var ratePolicyKeys = []string{"RATE_CURRENCY", "ROOM_TYPES", "RATE_RULES", "RATE_RESTRICTIONS"}
+
+// parseRatePolicy reads the room types, pricing rules and restrictions of the price calendar.
+// Without ROOM_TYPES the rooms of the reservation form are sold at their fixed nightly prices.
+func parseRatePolicy(lookup configLookup) (reservation.RatePolicy, error) {
+	policy, err := reservation.ParseRatePolicy(
+		configString(lookup, "RATE_CURRENCY", "USD"),
+		configString(lookup, "ROOM_TYPES", ""),
+		configString(lookup, "RATE_RULES", ""),
+		configString(lookup, "RATE_RESTRICTIONS", ""),
+	)
+	if err != nil {
+		return reservation.RatePolicy{}, err
+	}
+	if len(policy.RoomTypes) == 0 {
+		policy.RoomTypes = reservation.DefaultRatePolicy().RoomTypes
+	}
+	return policy, nil
+}
+
+// parseRoomLayout reads the floors, elevators and connecting doors of the rooms the room allocation
+// scores the guests' preferences against. Without ROOM_LAYOUT the rooms of the reservation form are used.
+func parseRoomLayout(lookup configLookup) (reservation.RoomLayout, error) {
+	layout := reservation.DefaultRoomLayout()
+	highFloor, err := configInt(lookup, "ROOM_HIGH_FLOOR", layout.HighFloor, 0)
+	if err != nil {
+		return reservation.RoomLayout{}, err
+	}
+	if value := configString(lookup, "ROOM_LAYOUT", ""); value != "" {
+		return reservation.ParseRoomLayout(value, highFloor)
+	}
+	layout.HighFloor = highFloor
+	return layout, nil
+}
+
+// parseDeepLinks reads the companion apps the emails link to: DEEP_LINK_SCHEME and DEEP_LINK_DOMAIN
+// for every property, DEEP_LINKS for the properties with their own app, and the app identities of the
+// association files. It returns nil if no app is configured, so the emails only link the web UI.
+func parseDeepLinks(lookup configLookup, uiURL string) (*outbound.DeepLinks, error) {
+	app, err := outbound.ParseDeepLinkApp(configString(lookup, "DEEP_LINK_SCHEME", ""), configString(lookup, "DEEP_LINK_DOMAIN", ""))
+	if err != nil {
+		return nil, err
+	}
+	apps, err := outbound.ParseDeepLinkApps(configString(lookup, "DEEP_LINKS", ""))
+	if err != nil {
+		return nil, err
+	}
+	appleAppID := configString(lookup, "APPLE_APP_ID", "")
+	androidPackage := configString(lookup, "ANDROID_APP_PACKAGE", "")
+	if app == (outbound.DeepLinkApp{}) && len(apps) == 0 && appleAppID == "" && androidPackage == "" {
+		return nil, nil
+	}
+	var fingerprints []string
+	for fingerprint := range strings.SplitSeq(configString(lookup, "ANDROID_APP_FINGERPRINTS", ""), ",") {
+		if fingerprint = strings.TrimSpace(fingerprint); fingerprint != "" {
+			fingerprints = append(fingerprints, strings.ToUpper(fingerprint))
+		}
+	}
+	if androidPackage != "" && len(fingerprints) == 0 {
+		return nil, fmt.Errorf("%w: ANDROID_APP_FINGERPRINTS required for ANDROID_APP_PACKAGE", outbound.ErrInvalidDeepLinks)
+	}
+	return outbound.NewDeepLinks(uiURL, app).
+		WithProperties(apps).
+		WithApps(appleAppID, androidPackage, fingerprints), nil
+}
+
+// roomBaseRates returns the base rate of the room type of every room, which pricing
+// charges for the rooms without a rate plan.
+func roomBaseRates(policy reservation.RatePolicy) map[pricing.RoomID]pricing.Money {
+	rates := make(map[pricing.RoomID]pricing.Money)
+	for _, roomType := range policy.RoomTypes {
+		for _, roomID := range roomType.RoomIDs {
+			rates[pricing.RoomID(roomID)] = roomType.BaseRate
+		}
+	}
+	return rates
+}
+
+// parseProperties reads the properties of the chain. Without PROPERTIES there is one property,
+// named after PROPERTY_NAME, that owns every room and keeps the timezone of the server.
+func parseProperties(lookup configLookup) (*property.Catalog, error) {
+	if value := configString(lookup, "PROPERTIES", ""); value != "" {
+		properties, err := property.ParseProperties(value)
+		if err != nil {
+			return nil, err
+		}
+		return property.NewCatalog(properties...)
+	}
+	return property.NewCatalog(property.Property{
+		ID:       property.DefaultPropertyID,
+		Name:     configString(lookup, "PROPERTY_NAME", configString(lookup, "APP_NAME", "Hotel Booking")),
+		Address:  configString(lookup, "PROPERTY_ADDRESS", ""),
+		Timezone: configString(lookup, "PROPERTY_TIMEZONE", "Local"),
+	})
+}
+
+// staffRolesKeys are the settings of the staff roles.
+var staffRolesKeys = []string{"STAFF_ROLES"}
+
+// parseStaffRoles reads the staff roles and their scopes.
+// By default front desk staff handle reservations and quote stays, finance handles payments, managers may do all
+// and admins may also use the admin endpoints.
+func parseStaffRoles(lookup configLookup) (staff.RolePolicy, error) {
+	all := []string{reservation.ScopeRead, reservation.ScopeWrite, payment.ScopeRead, payment.ScopeWrite, pricing.ScopeRead, property.ScopeRead}
+	return staff.ParseRolePolicy(configString(lookup, "STAFF_ROLES", strings.Join([]string{
+		"front_desk=" + strings.Join([]string{reservation.ScopeRead, reservation.ScopeWrite, pricing.ScopeRead, property.ScopeRead}, " "),
+		"finance=" + payment.ScopeRead + " " + payment.ScopeWrite,
+		"manager=" + strings.Join(all, " "),
+		"admin=" + shared.PermissionAdmin + " " + strings.Join(all, " "),
+	}, ";")))
+}
+
+// rolePermissionsKeys are the settings of the OIDC roles.
+var rolePermissionsKeys = []string{"ROLE_PERMISSIONS"}
+
+// parseRolePermissions reads the OIDC roles and their permissions.
+// By default guests book and manage their own stays, staff also read payments, admins and mcp-admin agents
+// may do all, admins also use the admin endpoints, and mcp-readonly agents may read all.
+func parseRolePermissions(lookup configLookup) (shared.RolePermissions, error) {
+	read := []string{reservation.ScopeRead, payment.ScopeRead, pricing.ScopeRead, property.ScopeRead, loyalty.ScopeRead}
+	all := append([]string{reservation.ScopeWrite, payment.ScopeWrite}, read...)
+	return shared.ParseRolePermissions(configString(lookup, "ROLE_PERMISSIONS", strings.Join([]string{
+		"guest=" + strings.Join([]string{shared.PermissionUI, reservation.ScopeRead, reservation.ScopeWrite, pricing.ScopeRead, property.ScopeRead, loyalty.ScopeRead}, " "),
+		"staff=" + strings.Join([]string{shared.PermissionUI, reservation.ScopeRead, reservation.ScopeWrite, payment.ScopeRead, pricing.ScopeRead, property.ScopeRead, loyalty.ScopeRead}, " "),
+		"admin=" + shared.PermissionUI + " " + shared.PermissionAdmin + " " + strings.Join(all, " "),
+		"mcp-readonly=" + strings.Join(read, " "),
+		"mcp-admin=" + strings.Join(all, " "),
+	}, ";")))
+}
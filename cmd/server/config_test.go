@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestConfigReloader writes the config file and registers the VIP tier section.
+// The settings of the file are restored in the environment after the test.
+func createTestConfigReloader(t *testing.T, content string) (*configReloader, string, *[]profile.TierPolicy) {
+	t.Helper()
+	for _, key := range append(slices.Clone(tierPolicyKeys), "APP_NAME") {
+		t.Setenv(key, "")
+	}
+	path := filepath.Join(t.TempDir(), "config.env")
+	writeTestConfig(t, path, content)
+	reloader, err := newConfigReloader(path)
+	assert.That(t, "error must be nil", err, nil)
+	var applied []profile.TierPolicy
+	reloadable(reloader, "vip_tiers", tierPolicyKeys, parseTierPolicy, func(policy profile.TierPolicy) {
+		applied = append(applied, policy)
+	})
+	return reloader, path, &applied
+}
+
+func writeTestConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ============================================================================
+// Config Reload Tests
+// ============================================================================
+
+func Test_NewConfigReloader_Should_Export_File_To_Environment(t *testing.T) {
+	// Arrange & Act
+	_, _, _ = createTestConfigReloader(t, "APP_NAME=\"Seaside Hotel\"\n")
+
+	// Assert
+	assert.That(t, "file must override the environment", os.Getenv("APP_NAME"), "Seaside Hotel")
+}
+
+func Test_ConfigReloader_Reload_Should_Apply_Changed_Sections(t *testing.T) {
+	// Arrange
+	reloader, path, applied := createTestConfigReloader(t, "VIP_GOLD_STAYS=5\nAPP_NAME=Hotel\n")
+	writeTestConfig(t, path, "VIP_GOLD_STAYS=3\nAPP_NAME=Seaside\n")
+
+	// Act
+	changes, err := reloader.Reload(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "changes must be reported", changes, []string{
+		`vip_tiers: VIP_GOLD_STAYS "5" -> "3"`,
+		"APP_NAME: restart required",
+	})
+	assert.That(t, "policy must be applied once", len(*applied), 1)
+	assert.That(t, "gold threshold must be changed", (*applied)[0].GoldStays, 3)
+}
+
+func Test_ConfigReloader_Reload_Without_Changes_Should_Apply_Nothing(t *testing.T) {
+	// Arrange
+	reloader, _, applied := createTestConfigReloader(t, "VIP_GOLD_STAYS=5\n")
+
+	// Act
+	changes, err := reloader.Reload(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "no changes must be reported", len(changes), 0)
+	assert.That(t, "nothing must be applied", len(*applied), 0)
+}
+
+func Test_ConfigReloader_Reload_With_Invalid_Value_Should_Change_Nothing(t *testing.T) {
+	// Arrange
+	reloader, path, applied := createTestConfigReloader(t, "VIP_GOLD_STAYS=5\n")
+	writeTestConfig(t, path, "VIP_GOLD_STAYS=five\n")
+
+	// Act
+	_, err := reloader.Reload(context.Background())
+	value, _ := reloader.lookup("VIP_GOLD_STAYS")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "nothing must be applied", len(*applied), 0)
+	assert.That(t, "previous value must stay in effect", value, "5")
+}
+
+func Test_ParseReferralPolicy_With_Negative_Limit_Should_Return_Error(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "REFERRAL_MONTHLY_LIMIT" {
+			return "-1", true
+		}
+		return "", false
+	}
+
+	// Act
+	_, err := parseReferralPolicy(lookup)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_ParseLoyaltyPolicy_With_Worthless_Points_Should_Return_Error(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "LOYALTY_POINT_VALUE" {
+			return "0", true
+		}
+		return "", false
+	}
+
+	// Act
+	_, err := parseLoyaltyPolicy(lookup)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_ParseRatePolicy_Without_Room_Types_Should_Use_Default_Rooms(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "RATE_RULES" {
+			return "weekend=15 fri sat", true
+		}
+		return "", false
+	}
+
+	// Act
+	policy, err := parseRatePolicy(lookup)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "room types must be the default", len(policy.RoomTypes), 3)
+	assert.That(t, "rule must be parsed", policy.Rules[0].Name, "weekend")
+}
+
+func Test_ParseRoomLayout_Without_Layout_Should_Use_Default_Rooms_With_High_Floor(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "ROOM_HIGH_FLOOR" {
+			return "3", true
+		}
+		return "", false
+	}
+
+	// Act
+	layout, err := parseRoomLayout(lookup)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "rooms must be the default", len(layout.Rooms), 5)
+	assert.That(t, "high floor must be configured", layout.HighFloor, 3)
+}
+
+func Test_ParseDeepLinks_Without_App_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) { return "", false }
+
+	// Act
+	deepLinks, err := parseDeepLinks(lookup, "http://localhost:8080/ui")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "deep links must be nil", deepLinks == nil, true)
+}
+
+func Test_ParseDeepLinks_With_Android_Package_Without_Fingerprints_Should_Return_Error(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "ANDROID_APP_PACKAGE" {
+			return "com.example.hotel", true
+		}
+		return "", false
+	}
+
+	// Act
+	_, err := parseDeepLinks(lookup, "http://localhost:8080/ui")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidDeepLinks", errors.Is(err, outbound.ErrInvalidDeepLinks), true)
+}
+
+func Test_RoomBaseRates_Should_Map_Every_Room_To_Its_Room_Type_Rate(t *testing.T) {
+	// Arrange
+	policy := reservation.DefaultRatePolicy()
+
+	// Act
+	rates := roomBaseRates(policy)
+
+	// Assert
+	assert.That(t, "every room must have a rate", len(rates), 5)
+	assert.That(t, "suite must have the suite rate", rates["room-301"], shared.NewMoney(24900, "USD"))
+}
+
+func Test_ParseProperties_Without_Properties_Should_Own_Every_Room_By_Default_Property(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) {
+		if key == "PROPERTY_NAME" {
+			return "Hotel Berlin", true
+		}
+		return "", false
+	}
+
+	// Act
+	catalog, err := parseProperties(lookup)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "default property must be named", catalog.Default().Name, "Hotel Berlin")
+	assert.That(t, "default property must own every room", catalog.PropertyOf("room-301"), property.DefaultPropertyID)
+}
+
+func Test_ParseRolePermissions_Without_Setting_Should_Grant_Default_Permissions(t *testing.T) {
+	// Arrange
+	lookup := func(key string) (string, bool) { return "", false }
+
+	// Act
+	permissions, err := parseRolePermissions(lookup)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	readOnly, _ := permissions.Grant([]shared.Role{shared.RoleMCPReadOnly})
+	assert.That(t, "mcp-readonly must read only", readOnly, []string{"loyalty:read", "payments:read", "pricing:read", "properties:read", "reservations:read"})
+	guest, _ := permissions.Grant([]shared.Role{shared.RoleGuest})
+	assert.That(t, "guests must not read payments", slices.Contains(guest, payment.ScopeRead), false)
+	assert.That(t, "guests must use the UI", slices.Contains(guest, shared.PermissionUI), true)
+}
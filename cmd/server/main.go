@@ -4,23 +4,50 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/env"
 	"github.com/andygeiss/cloud-native-utils/logging"
 	"github.com/andygeiss/cloud-native-utils/mcp"
 	"github.com/andygeiss/cloud-native-utils/messaging"
 	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
 	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
 	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
-	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
@@ -31,7 +58,12 @@ var efs embed.FS
 func buildMCPServer(
 	reservationService *reservation.Service,
 	availabilityChecker reservation.AvailabilityChecker,
+	rates *reservation.Rates,
 	paymentService *payment.Service,
+	financialService *payment.FinancialService,
+	pricingService *pricing.Service,
+	loyaltyService *loyalty.Service,
+	properties *property.Catalog,
 ) *mcp.Server {
 	server := mcp.NewServer(
 		env.Get("APP_SHORTNAME", "mcp-server"),
@@ -40,107 +72,1727 @@ func buildMCPServer(
 
 	// Register tools from each bounded context.
 	reservation.RegisterTools(server, reservationService, availabilityChecker)
+	reservation.RegisterTripPlanningTools(server, availabilityChecker, rates, outbound.NewPropertyRooms(properties))
+	property.RegisterTools(server, properties)
 	payment.RegisterTools(server, paymentService)
+	if financialService != nil {
+		payment.RegisterFinancialTools(server, financialService)
+	}
+	if pricingService != nil {
+		pricing.RegisterTools(server, pricingService)
+	}
+	if loyaltyService != nil {
+		loyalty.RegisterTools(server, loyaltyService)
+	}
 
 	return server
 }
 
+// buildMCPResources registers the documents agents can read without a tool call:
+// the FAQ, the room catalog, the cancellation policy and, if the payment sandbox can be switched, its test cards.
+func buildMCPResources(contentPages *outbound.ContentPages, rates *reservation.Rates, pricingService *pricing.Service, paymentSandbox inbound.PaymentSandbox) *inbound.MCPResources {
+	resources := inbound.NewMCPResources()
+	resources.Register(inbound.MCPResource{
+		URI:         "content://faq",
+		Name:        "FAQ",
+		Description: "Frequently asked questions of guests, as shown on /ui/pages/faq",
+		MimeType:    "text/markdown",
+		Read: func(ctx context.Context) (string, error) {
+			return contentPages.Markdown("faq")
+		},
+	})
+	resources.Register(inbound.NewRoomCatalogResource(rates, pricingService))
+	resources.Register(inbound.NewCancellationPolicyResource())
+	if paymentSandbox != nil {
+		resources.Register(inbound.NewTestCardsResource(paymentSandbox))
+	}
+
+	return resources
+}
+
+// countSwept adapts a reservation sweep to a scheduler job, which reports how many reservations it moved.
+func countSwept(sweep func(ctx context.Context, now time.Time) ([]reservation.ReservationID, error)) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		ids, err := sweep(ctx, now)
+		return len(ids), err
+	}
+}
+
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply the pending migrations of both databases and exit")
+	migrateDown := flag.Int("migrate-down", 0, "revert the latest `n` migrations of both databases and exit")
+	flag.Parse()
+
 	// Create a new context with a cancel function.
 	ctx, cancel := service.Context()
 	defer cancel()
 
-	// Create a new logger.
-	// We use the logging.NewJsonLogger function from the cloud-native-utils/logging package.
-	logger := logging.NewJsonLogger()
-
-	// Initialize Reservation Database connection.
-	reservationDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		env.Get("RESERVATION_DB_HOST", "localhost"),
-		env.Get("RESERVATION_DB_PORT", "5432"),
-		env.Get("RESERVATION_DB_USER", "reservation"),
-		env.Get("RESERVATION_DB_PASSWORD", "reservation_secret"),
-		env.Get("RESERVATION_DB_NAME", "reservation_db"),
-		env.Get("RESERVATION_DB_SSLMODE", "disable"),
-	)
-	reservationDB, err := sql.Open("pgx", reservationDSN)
+	// Read the optional config file, which overrides the environment.
+	// Non-secret settings can be changed there and reloaded via SIGHUP or /admin/config/reload.
+	config, err := newConfigReloader(env.Get("CONFIG_FILE", ""))
 	if err != nil {
-		logger.Error("failed to connect to reservation database", "error", err)
+		logging.NewJsonLogger().Error("failed to read config file", "error", err)
 		os.Exit(1)
 	}
-	defer reservationDB.Close()
 
-	// Initialize Payment Database connection.
-	paymentDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		env.Get("PAYMENT_DB_HOST", "localhost"),
-		env.Get("PAYMENT_DB_PORT", "5433"),
-		env.Get("PAYMENT_DB_USER", "payment"),
-		env.Get("PAYMENT_DB_PASSWORD", "payment_secret"),
-		env.Get("PAYMENT_DB_NAME", "payment_db"),
-		env.Get("PAYMENT_DB_SSLMODE", "disable"),
-	)
-	paymentDB, err := sql.Open("pgx", paymentDSN)
+	// Create the JSON loggers with a level per component.
+	// Levels can be changed at runtime via the admin endpoint /admin/log-levels.
+	// Availability checks are high-volume, so their debug logs are sampled by default.
+	logConfig, err := parseLogSettings(config.lookup)
+	if err != nil {
+		logging.NewJsonLogger().Error("failed to configure logging", "error", err)
+		os.Exit(1)
+	}
+	logLevels, err := outbound.NewLogLevels(os.Stdout, logConfig.defaultLevel, logConfig.levels, logConfig.sampling)
+	if err != nil {
+		logging.NewJsonLogger().Error("failed to configure logging", "error", err)
+		os.Exit(1)
+	}
+	reloadable(config, "logging", logSettingsKeys, parseLogSettings, func(settings logSettings) {
+		_ = logLevels.Reset(settings.defaultLevel, settings.levels, settings.sampling)
+	})
+	logger := logLevels.Logger("server")
+	// Emails, phone and card numbers are masked in the logs and the MCP tool results.
+	// Full output helps in local development, but never in production, even if disabled by mistake.
+	masking := env.Get("PII_MASKING_ENABLED", true)
+	if appEnv := env.Get("APP_ENV", "production"); !masking && appEnv == "production" {
+		logger.Warn("personal data is always masked in production", "app_env", appEnv)
+		masking = true
+	}
+	logLevels.SetMasking(masking)
+	// Code without a logger of its own, like the error boundary of the views, logs via the default logger.
+	slog.SetDefault(logLevels.Logger("view"))
+
+	// Wait for the dependencies with retries and exponential backoff instead of exiting immediately,
+	// because container orchestration may start them after the server.
+	startup := startupConfig{
+		timeout:      env.Get("STARTUP_TIMEOUT", 60*time.Second),
+		initialDelay: env.Get("STARTUP_RETRY_INITIAL_DELAY", 500*time.Millisecond),
+		maxDelay:     env.Get("STARTUP_RETRY_MAX_DELAY", 10*time.Second),
+	}
+	startupCtx, cancelStartup := context.WithTimeout(ctx, startup.timeout)
+	defer cancelStartup()
+
+	// STORAGE_BACKEND=memory keeps all data in memory instead of the reservation and payment databases,
+	// so the application runs without Postgres, e.g. for local development. Everything is lost on restart.
+	// STORAGE_BACKEND=sqlite keeps both databases in files for small deployments and demos on a single instance.
+	var reservationDB, paymentDB *sql.DB
+	storageBackend := env.Get("STORAGE_BACKEND", "postgres")
+	switch storageBackend {
+	case "memory":
+		logger.Warn("STORAGE_BACKEND is memory, all data is lost on restart")
+	case "sqlite":
+		reservationDB, err = openSQLite(logger, "reservation", env.Get("SQLITE_RESERVATION_PATH", "data/reservation.db"))
+		if err != nil {
+			logger.Error("failed to open reservation database", "error", err)
+			os.Exit(1)
+		}
+		defer reservationDB.Close()
+		paymentDB, err = openSQLite(logger, "payment", env.Get("SQLITE_PAYMENT_PATH", "data/payment.db"))
+		if err != nil {
+			logger.Error("failed to open payment database", "error", err)
+			os.Exit(1)
+		}
+		defer paymentDB.Close()
+	case "postgres":
+		// Initialize Reservation Database connection.
+		reservationDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			env.Get("RESERVATION_DB_HOST", "localhost"),
+			env.Get("RESERVATION_DB_PORT", "5432"),
+			env.Get("RESERVATION_DB_USER", "reservation"),
+			env.Get("RESERVATION_DB_PASSWORD", "reservation_secret"),
+			env.Get("RESERVATION_DB_NAME", "reservation_db"),
+			env.Get("RESERVATION_DB_SSLMODE", "disable"),
+		)
+		reservationDB, err = sql.Open("pgx", reservationDSN)
+		if err != nil {
+			logger.Error("failed to connect to reservation database", "error", err)
+			os.Exit(1)
+		}
+		defer reservationDB.Close()
+		if _, err := waitFor(startupCtx, logger, startup, "reservation database", pingDatabase(reservationDB)); err != nil {
+			logger.Error("failed to connect to reservation database", "error", err)
+			os.Exit(1)
+		}
+
+		// Initialize Payment Database connection.
+		paymentDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			env.Get("PAYMENT_DB_HOST", "localhost"),
+			env.Get("PAYMENT_DB_PORT", "5433"),
+			env.Get("PAYMENT_DB_USER", "payment"),
+			env.Get("PAYMENT_DB_PASSWORD", "payment_secret"),
+			env.Get("PAYMENT_DB_NAME", "payment_db"),
+			env.Get("PAYMENT_DB_SSLMODE", "disable"),
+		)
+		paymentDB, err = sql.Open("pgx", paymentDSN)
+		if err != nil {
+			logger.Error("failed to connect to payment database", "error", err)
+			os.Exit(1)
+		}
+		defer paymentDB.Close()
+		if _, err := waitFor(startupCtx, logger, startup, "payment database", pingDatabase(paymentDB)); err != nil {
+			logger.Error("failed to connect to payment database", "error", err)
+			os.Exit(1)
+		}
+	default:
+		logger.Error("unknown storage backend", "backend", storageBackend)
+		os.Exit(1)
+	}
+
+	// The schema of both databases is migrated on startup from the migrations embedded in the binary,
+	// so deployments without the Docker init scripts work. -migrate-only stops after it, e.g. in a deploy job,
+	// and -migrate-down reverts the latest migrations and stops.
+	if reservationDB == nil && (*migrateOnly || *migrateDown > 0) {
+		logger.Error("migrations need a database", "backend", storageBackend)
+		os.Exit(1)
+	}
+	if reservationDB != nil {
+		for database, db := range map[string]*sql.DB{"reservation": reservationDB, "payment": paymentDB} {
+			if err := migrateDatabase(startupCtx, logger, db, database, *migrateDown); err != nil {
+				logger.Error("failed to migrate database", "database", database, "error", err)
+				os.Exit(1)
+			}
+		}
+	}
+	if *migrateOnly || *migrateDown > 0 {
+		return
+	}
+
+	// Outbound HTTP calls (weather, OIDC discovery) share one client factory with timeouts,
+	// retries of idempotent calls, proxy and TLS settings; calls are counted per destination.
+	httpClientConfig := outbound.DefaultHTTPClientConfig()
+	httpClientConfig.Timeout = env.Get("HTTP_CLIENT_TIMEOUT", httpClientConfig.Timeout)
+	httpClientConfig.MaxRetries = env.Get("HTTP_CLIENT_MAX_RETRIES", httpClientConfig.MaxRetries)
+	httpClientConfig.RetryDelay = env.Get("HTTP_CLIENT_RETRY_DELAY", httpClientConfig.RetryDelay)
+	httpClientConfig.RetryMaxDelay = env.Get("HTTP_CLIENT_RETRY_MAX_DELAY", httpClientConfig.RetryMaxDelay)
+	httpClientConfig.ProxyURL = env.Get("HTTP_CLIENT_PROXY", "")
+	httpClientConfig.CAFile = env.Get("HTTP_CLIENT_CA_FILE", "")
+	httpClients, err := outbound.NewHTTPClients(httpClientConfig, logLevels.Logger("http_client"))
 	if err != nil {
-		logger.Error("failed to connect to payment database", "error", err)
+		logger.Error("failed to configure http clients", "error", err)
 		os.Exit(1)
 	}
-	defer paymentDB.Close()
 
 	// Shared event dispatcher using Kafka for distributed event messaging.
-	dispatcher := messaging.NewExternalDispatcher()
+	// The dispatcher connects lazily, so we check that a broker is reachable first.
+	if _, err := waitFor(startupCtx, logger, startup, "kafka", pingKafka(env.Get("KAFKA_BROKERS", "localhost:9092"))); err != nil {
+		logger.Error("failed to connect to kafka", "error", err)
+		os.Exit(1)
+	}
+	var dispatcher messaging.Dispatcher = messaging.NewExternalDispatcher()
+
+	// Faults can be injected into the payment gateway, the repositories and the dispatcher
+	// to test the saga and the retries. Never in production, even if enabled by mistake.
+	var faults *outbound.FaultInjector
+	if env.Get("FAULT_INJECTION_ENABLED", false) {
+		if appEnv := env.Get("APP_ENV", "production"); appEnv == "production" {
+			logger.Warn("fault injection is disabled in production", "app_env", appEnv)
+		} else {
+			faults, err = outbound.NewFaultInjector(env.Get("FAULT_INJECTION", ""))
+			if err != nil {
+				logger.Error("failed to parse faults", "error", err)
+				os.Exit(1)
+			}
+			dispatcher = outbound.NewFaultyDispatcher(dispatcher, faults)
+			logger.Warn("fault injection is enabled", "app_env", appEnv, "faults", faults.Faults())
+		}
+	}
+
+	// A virtual clock lets end-to-end tests advance time for the jobs and the reservation rules
+	// via /admin/clock instead of waiting for no-shows and check-outs. Never in production, even if enabled by mistake.
+	var testClock inbound.TestClock
+	if env.Get("TEST_CLOCK_ENABLED", false) {
+		if appEnv := env.Get("APP_ENV", "production"); appEnv == "production" {
+			logger.Warn("test clock is disabled in production", "app_env", appEnv)
+		} else {
+			clock := outbound.NewTestClock()
+			shared.SetClock(clock.Now)
+			testClock = clock
+			logger.Warn("test clock is enabled", "app_env", appEnv)
+		}
+	}
+
+	// Trace the requests through the domain services, the databases, the events and the outbound
+	// HTTP calls if an OTLP collector is configured. The spans are exported in batches;
+	// the client of the exporter is created before the clients are traced, so exports are not traced.
+	var tracer *outbound.Tracer
+	if endpoint := env.Get("OTEL_EXPORTER_OTLP_ENDPOINT", ""); endpoint != "" {
+		tracerConfig := outbound.DefaultTracerConfig()
+		tracerConfig.ServiceName = env.Get("OTEL_SERVICE_NAME", tracerConfig.ServiceName)
+		tracerConfig.SampleRatio = env.Get("OTEL_TRACES_SAMPLER_ARG", tracerConfig.SampleRatio)
+		tracerConfig.Headers, err = outbound.ParseOTLPHeaders(env.Get("OTEL_EXPORTER_OTLP_HEADERS", ""))
+		if err != nil {
+			logger.Error("failed to configure tracing", "error", err)
+			os.Exit(1)
+		}
+		tracer, err = outbound.NewTracer(httpClients.Client("tracing"), endpoint, tracerConfig, logLevels.Logger("tracing"))
+		if err != nil {
+			logger.Error("failed to configure tracing", "error", err)
+			os.Exit(1)
+		}
+		tracer.Start(ctx)
+		httpClients.WithTracer(tracer)
+		// Outside of the fault injection, so injected faults show up in the traces.
+		dispatcher = outbound.NewTracingDispatcher(dispatcher, tracer)
+	}
+	// A typed nil pointer must not be passed as interface, it would not compare to nil.
+	var serviceTracer shared.Tracer
+	if tracer != nil {
+		serviceTracer = tracer
+	}
+
+	// The domain services count their outcomes for the Prometheus scrape of /metrics.
+	metrics := outbound.NewMetrics().
+		Describe(reservation.MetricReservationsCreated, "Reservations created.").
+		Describe(reservation.MetricReservationsCancelled, "Reservations cancelled, by the status they were cancelled from.").
+		Describe(reservation.MetricRoomPreferences, "Room preferences of new reservations, by preference and whether the room fulfills them.").
+		Describe(payment.MetricPaymentFailures, "Failed payment authorizations and captures, by error code.").
+		Describe(ledger.MetricPayoutAlerts, "Captures not paid out in time and payouts below the captured amount, by kind.").
+		Describe(orchestration.MetricSagaDuration, "Duration of the booking sagas from start to end, by outcome.", 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30).
+		Describe(inbound.MetricAPIRequests, "Requests of the JSON API, by version, route and status.").
+		Describe(inbound.MetricAPIRequestDuration, "Duration of the JSON API requests, by version and route.", 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5).
+		Describe(outbound.MetricDeadLetters, "Events dead-lettered because their handler failed, by topic.").
+		Describe(outbound.MetricAvailabilityQueries, "Availability queries, by kind and whether a concurrent identical query served them.")
+
+	// Events whose handlers fail are recorded and published to <topic>.dlq instead of being dropped;
+	// staff list them on /admin/dead-letters and replay them against the handler that failed.
+	var deadLetters *outbound.DeadLetterDispatcher
+	if env.Get("EVENT_DEAD_LETTERS_ENABLED", true) {
+		deadLetterRepo, err := outbound.NewTableAccess[string, shared.DeadLetter](reservationDB, "event_dead_letter_kv_store")
+		if err != nil {
+			logger.Error("failed to create dead letter repository", "error", err)
+			os.Exit(1)
+		}
+		if err := deadLetterRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize dead letter repository", "error", err)
+			os.Exit(1)
+		}
+		deadLetters = outbound.NewDeadLetterDispatcher(dispatcher, deadLetterRepo, logLevels.Logger("events")).WithMetrics(metrics)
+		dispatcher = deadLetters
+	}
+
+	// Every change of a reservation or payment is recorded with its caller and the state before and
+	// after in its own table; staff query it on /admin/audit.
+	var auditLog *outbound.AuditLog
+	if env.Get("AUDIT_LOG_ENABLED", true) {
+		auditRepo, err := outbound.NewTableAccess[string, shared.AuditEntry](reservationDB, "audit_log_kv_store")
+		if err != nil {
+			logger.Error("failed to create audit log repository", "error", err)
+			os.Exit(1)
+		}
+		if err := auditRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize audit log repository", "error", err)
+			os.Exit(1)
+		}
+		auditLog = outbound.NewAuditLog(auditRepo, logLevels.Logger("audit"))
+	}
 
 	// Initialize reservation bounded context using PostgresAccess from cloud-native-utils.
-	// Schema is created by Docker init scripts (migrations/reservation/init.sql).
-	reservationRepo := resource.NewPostgresAccess[reservation.ReservationID, reservation.Reservation](reservationDB)
-	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(reservationRepo)
+	// Schema is created by the migrations on startup (migrations/reservation).
+	var reservationRepo reservation.ReservationRepository = outbound.NewInMemoryReservationRepository()
+	switch storageBackend {
+	case "postgres":
+		reservationRepo = resource.NewPostgresAccess[reservation.ReservationID, reservation.Reservation](reservationDB)
+	case "sqlite":
+		reservationRepo = outbound.NewSQLiteReservationRepository(reservationDB)
+	}
+	if faults != nil {
+		reservationRepo = outbound.NewFaultyAccess(reservationRepo, faults, outbound.FaultTargetReservationRepository)
+	}
+	if tracer != nil {
+		reservationRepo = outbound.NewTracedAccess(reservationRepo, tracer, dbSystem(storageBackend), "reservation_db.kv_store")
+	}
+	// The checkout holds the room for ROOM_HOLD_TTL while the guest confirms the price, so nobody else
+	// can book it meanwhile. The availability checkers count the holds; the room_holds job releases expired ones.
+	var roomHoldRepo reservation.RoomHoldRepository
+	roomHoldTTL := env.Get("ROOM_HOLD_TTL", 15*time.Minute)
+	if roomHoldTTL > 0 {
+		holdRepo, err := outbound.NewTableAccess[reservation.ReservationID, reservation.RoomHold](reservationDB, "room_hold_kv_store")
+		if err != nil {
+			logger.Error("failed to create room hold repository", "error", err)
+			os.Exit(1)
+		}
+		if err := holdRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize room hold repository", "error", err)
+			os.Exit(1)
+		}
+		roomHoldRepo = holdRepo
+	}
+	// Identical concurrent availability queries are coalesced into a single database read.
+	repositoryChecker := outbound.NewRepositoryAvailabilityChecker(reservationRepo).WithCheckoutHolds(roomHoldRepo)
+	availabilityChecker := outbound.NewCoalescingAvailabilityChecker(repositoryChecker, logLevels.Logger("availability")).WithMetrics(metrics)
 	reservationPublisher := outbound.NewEventPublisher(dispatcher)
-	reservationService := reservation.NewService(reservationRepo, availabilityChecker, reservationPublisher)
+	// Every reservation records the exchange rate of its amount to the currency of record,
+	// and payments are only captured at a snapshot younger than FX_MAX_AGE.
+	currencyOfRecord := env.Get("CURRENCY_OF_RECORD", "USD")
+	exchangeRates, err := outbound.ParseExchangeRates(env.Get("FX_RATES", ""))
+	if err != nil {
+		logger.Error("failed to parse exchange rates", "error", err)
+		os.Exit(1)
+	}
+	// The properties (hotels) of the chain own the rooms; every reservation records the property of its room.
+	properties, err := parseProperties(config.lookup)
+	if err != nil {
+		logger.Error("failed to configure properties", "error", err)
+		os.Exit(1)
+	}
+	reservationService := reservation.NewService(reservationRepo, availabilityChecker, reservationPublisher).
+		WithProperties(outbound.NewPropertyRooms(properties)).
+		WithMetrics(metrics).
+		WithTracer(serviceTracer).
+		WithExchangeRates(outbound.NewStaticExchangeRates(currencyOfRecord, exchangeRates), currencyOfRecord).
+		WithCheckoutHolds(roomHoldRepo, roomHoldTTL).
+		WithOccupancyVersions(repositoryChecker)
+	if auditLog != nil {
+		reservationService.WithAuditLog(auditLog)
+	}
+	// Bookings of a room are serialized from the availability check until the reservation is persisted,
+	// so concurrent requests cannot double-book it. The check under the lock must not be coalesced.
+	roomLockWait := env.Get("ROOM_LOCK_WAIT", 5*time.Second)
+	defaultRoomLocks := "postgres"
+	if storageBackend != "postgres" {
+		defaultRoomLocks = "local"
+	}
+	// Promo code and loyalty point redemptions and invoice numbers take the same kind of lock per code, guest and jurisdiction.
+	roomLocks := env.Get("ROOM_LOCKS", defaultRoomLocks)
+	switch locks := roomLocks; locks {
+	case "none":
+	case "postgres":
+		if storageBackend != "postgres" {
+			logger.Error("room locks in postgres require STORAGE_BACKEND postgres", "locks", locks)
+			os.Exit(1)
+		}
+		reservationService.WithRoomLocks(outbound.NewPostgresRoomLocks(reservationDB, roomLockWait), outbound.NewRepositoryAvailabilityChecker(reservationRepo).WithCheckoutHolds(roomHoldRepo))
+	case "local":
+		reservationService.WithRoomLocks(outbound.NewLocalRoomLocks(roomLockWait), outbound.NewRepositoryAvailabilityChecker(reservationRepo).WithCheckoutHolds(roomHoldRepo))
+	default:
+		logger.Error("unknown room locks", "locks", locks)
+		os.Exit(1)
+	}
+
+	// Lobby kiosks check guests in while offline and sync on reconnect; the results are kept so a
+	// kiosk sending its queue again gets the same answers (see /api/v1/kiosk, requires OIDC).
+	if env.Get("KIOSK_SYNC_ENABLED", true) {
+		kioskRepo, err := outbound.NewTableAccess[string, reservation.KioskResult](reservationDB, "kiosk_operation_kv_store")
+		if err != nil {
+			logger.Error("failed to create kiosk operation repository", "error", err)
+			os.Exit(1)
+		}
+		if err := kioskRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize kiosk operation repository", "error", err)
+			os.Exit(1)
+		}
+		reservationService.WithKioskOperations(kioskRepo)
+	}
+
+	// Reservations get a human-friendly confirmation number of the property's scheme, e.g. BER-2025-00123.
+	// The claims table refuses a number taken by another replica, so numbers are unique across instances.
+	if format := env.Get("CONFIRMATION_NUMBER_FORMAT", ""); format != "" {
+		scheme, err := reservation.ParseNumberingScheme(format)
+		if err != nil {
+			logger.Error("failed to parse confirmation number format", "error", err)
+			os.Exit(1)
+		}
+		numberClaimRepo, err := outbound.NewTableAccess[string, reservation.NumberClaim](reservationDB, "confirmation_number_kv_store")
+		if err != nil {
+			logger.Error("failed to create confirmation number repository", "error", err)
+			os.Exit(1)
+		}
+		if err := numberClaimRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize confirmation number repository", "error", err)
+			os.Exit(1)
+		}
+		reservationService.WithConfirmationNumbers(scheme, numberClaimRepo)
+	}
+
+	// Nightly rates of the room types for the price calendar; a reload drops the cached calendars.
+	ratePolicy, err := parseRatePolicy(config.lookup)
+	if err != nil {
+		logger.Error("failed to configure room rates", "error", err)
+		os.Exit(1)
+	}
+	rates := reservation.NewRates(ratePolicy)
+
+	// The guests' room preferences are scored against the floors, elevators and connecting doors of
+	// the rooms; the booking offers the room of the chosen type that fulfills them best.
+	roomLayout, err := parseRoomLayout(config.lookup)
+	if err != nil {
+		logger.Error("failed to configure room layout", "error", err)
+		os.Exit(1)
+	}
+	reservationService.WithRoomAllocation(roomLayout, rates)
+
+	// Initialize pricing bounded context in its own table of the reservation database.
+	// Bookings charge the quote of the room's rate plan; rooms without a plan are sold at the base rate of their room type.
+	ratePlanRepo, err := outbound.NewTableAccess[pricing.RoomID, pricing.RatePlan](reservationDB, "rate_plan_kv_store")
+	if err != nil {
+		logger.Error("failed to create rate plan repository", "error", err)
+		os.Exit(1)
+	}
+	if err := ratePlanRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize rate plan repository", "error", err)
+		os.Exit(1)
+	}
+	pricingService := pricing.NewService(ratePlanRepo)
+	pricingService.SetDefaultRates(roomBaseRates(ratePolicy))
+
+	// The checkout locks the quoted price for PRICE_LOCK_TTL, so a rate change while the guest
+	// confirms does not change the charge. Expired locks are pruned by the price_locks job.
+	if priceLockTTL := env.Get("PRICE_LOCK_TTL", 15*time.Minute); priceLockTTL > 0 {
+		priceLockRepo, err := outbound.NewTableAccess[pricing.PriceLockID, pricing.PriceLock](reservationDB, "price_lock_kv_store")
+		if err != nil {
+			logger.Error("failed to create price lock repository", "error", err)
+			os.Exit(1)
+		}
+		if err := priceLockRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize price lock repository", "error", err)
+			os.Exit(1)
+		}
+		pricingService.WithPriceLocks(priceLockRepo, priceLockTTL)
+	}
+	reloadable(config, "room_rates", ratePolicyKeys, parseRatePolicy, func(policy reservation.RatePolicy) {
+		rates.SetPolicy(policy)
+		pricingService.SetDefaultRates(roomBaseRates(policy))
+	})
+
+	// Initialize household bounded context in its own table of the reservation database,
+	// because PostgresAccess reads all rows of kv_store and would mix households into the reservations.
+	householdRepo, err := outbound.NewTableAccess[household.HouseholdID, household.Household](reservationDB, "household_kv_store")
+	if err != nil {
+		logger.Error("failed to create household repository", "error", err)
+		os.Exit(1)
+	}
+	if err := householdRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize household repository", "error", err)
+		os.Exit(1)
+	}
+	householdService := household.NewService(householdRepo)
 
 	// Initialize payment bounded context using PostgresAccess from cloud-native-utils.
-	paymentRepo := resource.NewPostgresAccess[payment.PaymentID, payment.Payment](paymentDB)
-	paymentGateway := outbound.NewMockPaymentGateway()
+	var paymentRepo payment.PaymentRepository = outbound.NewInMemoryPaymentRepository()
+	switch storageBackend {
+	case "postgres":
+		paymentRepo = resource.NewPostgresAccess[payment.PaymentID, payment.Payment](paymentDB)
+	case "sqlite":
+		paymentRepo = outbound.NewSQLitePaymentRepository(paymentDB)
+	}
+	// The gateway is the sandbox, scripted by the test cards. Outside of production, the card that payments
+	// are charged to can be switched at runtime, so QA and agent demos exercise the failure paths deterministically.
+	sandboxGateway := outbound.NewMockPaymentGateway()
+	var paymentSandbox inbound.PaymentSandbox
+	if env.Get("PAYMENT_SANDBOX_ENABLED", false) {
+		if appEnv := env.Get("APP_ENV", "production"); appEnv == "production" {
+			logger.Warn("payment sandbox switch is disabled in production", "app_env", appEnv)
+		} else {
+			if err := sandboxGateway.UseTestCard(env.Get("PAYMENT_SANDBOX_CARD", "")); err != nil {
+				logger.Error("failed to configure payment sandbox", "error", err)
+				os.Exit(1)
+			}
+			paymentSandbox = sandboxGateway
+			logger.Warn("payment sandbox switch is enabled", "app_env", appEnv, "card", sandboxGateway.TestCard().Number)
+		}
+	}
+	var paymentGateway payment.PaymentGateway = sandboxGateway
+	if faults != nil {
+		paymentRepo = outbound.NewFaultyAccess(paymentRepo, faults, outbound.FaultTargetPaymentRepository)
+		paymentGateway = outbound.NewFaultyPaymentGateway(paymentGateway, faults)
+	}
+	if tracer != nil {
+		paymentRepo = outbound.NewTracedAccess(paymentRepo, tracer, dbSystem(storageBackend), "payment_db.kv_store")
+	}
 	paymentPublisher := outbound.NewEventPublisher(dispatcher)
-	paymentService := payment.NewService(paymentRepo, paymentGateway, paymentPublisher)
+	paymentService := payment.NewService(paymentRepo, paymentGateway, paymentPublisher).
+		WithMetrics(metrics).
+		WithTracer(serviceTracer).
+		WithFXGuard(currencyOfRecord, env.Get("FX_MAX_AGE", 24*time.Hour)).
+		WithReservationViewers(outbound.NewReservationViewers(reservationService))
+	if auditLog != nil {
+		paymentService.WithAuditLog(auditLog)
+	}
+
+	// The financial summary nets the room charges and folio adjustments against the payments
+	// of a reservation; it is shared by the reservation detail page, the JSON API and MCP.
+	adjustmentRepo, err := outbound.NewTableAccess[payment.AdjustmentID, payment.Adjustment](paymentDB, "payment_adjustment_kv_store")
+	if err != nil {
+		logger.Error("failed to create adjustment repository", "error", err)
+		os.Exit(1)
+	}
+	if err := adjustmentRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize adjustment repository", "error", err)
+		os.Exit(1)
+	}
+	financialService := payment.NewFinancialService(paymentService, adjustmentRepo, outbound.NewReservationRoomCharges(reservationService))
+
+	// The ledger posts every money movement as a balanced double-entry journal entry for finance.
+	// Entries are append-only and hash-chained; payouts and corrections are posted on /admin/ledger.
+	// Payout reports of the gateway are linked to the captures they settle; a capture not paid out
+	// within LEDGER_PAYOUT_DELAY, or a payout below the captured amount, raises an alert.
+	var ledgerService *ledger.Service
+	ledgerPayoutDelay := env.Get("LEDGER_PAYOUT_DELAY", 72*time.Hour)
+	if env.Get("LEDGER_ENABLED", true) {
+		ledgerEntryRepo, err := outbound.NewTableAccess[ledger.EntryKey, ledger.Entry](paymentDB, "ledger_entry_kv_store")
+		if err != nil {
+			logger.Error("failed to create ledger entry repository", "error", err)
+			os.Exit(1)
+		}
+		if err := ledgerEntryRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize ledger entry repository", "error", err)
+			os.Exit(1)
+		}
+		ledgerSourceRepo, err := outbound.NewTableAccess[ledger.SourceKey, ledger.Sequence](paymentDB, "ledger_source_kv_store")
+		if err != nil {
+			logger.Error("failed to create ledger source repository", "error", err)
+			os.Exit(1)
+		}
+		if err := ledgerSourceRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize ledger source repository", "error", err)
+			os.Exit(1)
+		}
+		settlementRepo, err := outbound.NewTableAccess[ledger.PayoutID, ledger.Settlement](paymentDB, "ledger_settlement_kv_store")
+		if err != nil {
+			logger.Error("failed to create ledger settlement repository", "error", err)
+			os.Exit(1)
+		}
+		if err := settlementRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize ledger settlement repository", "error", err)
+			os.Exit(1)
+		}
+		ledgerService = ledger.NewService(ledgerEntryRepo, ledgerSourceRepo).
+			WithSettlements(settlementRepo).
+			WithMetrics(metrics)
+		if err := inbound.SubscribeLedgerEvents(ctx, dispatcher, paymentService, ledgerService); err != nil {
+			logger.Error("failed to subscribe ledger to events", "error", err)
+			os.Exit(1)
+		}
+	}
 
 	// Initialize orchestration layer.
-	notificationService := outbound.NewMockNotificationService(logger)
-	bookingService := orchestration.NewBookingService(reservationService, paymentService, notificationService)
+	// Show the property location with a map and directions on reservation details and confirmations.
+	// The static map provider brings its own API key; without a provider only the address and links are shown.
+	var propertyMaps *outbound.PropertyMaps
+	propertyLocation := outbound.PropertyLocation{
+		Name:      env.Get("PROPERTY_NAME", env.Get("APP_NAME", "Hotel Booking")),
+		Address:   env.Get("PROPERTY_ADDRESS", ""),
+		Latitude:  env.Get("PROPERTY_LATITUDE", 0.0),
+		Longitude: env.Get("PROPERTY_LONGITUDE", 0.0),
+	}
+	if propertyLocation.Address != "" || propertyLocation.Latitude != 0 || propertyLocation.Longitude != 0 {
+		mapProvider, err := outbound.NewStaticMapProvider(env.Get("MAP_PROVIDER", "none"), env.Get("MAP_API_KEY", ""))
+		if err != nil {
+			logger.Error("failed to configure map provider", "error", err)
+			os.Exit(1)
+		}
+		propertyMaps = outbound.NewPropertyMaps(propertyLocation, mapProvider)
+	}
+
+	// Show a weather forecast for the stay dates on the reservation detail page.
+	// Forecasts are cached and the provider is called at most once per WEATHER_MIN_INTERVAL;
+	// if it is unavailable, the widget is hidden.
+	var weather inbound.WeatherForecaster
+	switch provider := env.Get("WEATHER_PROVIDER", "none"); provider {
+	case "none":
+	case "open-meteo":
+		if propertyLocation.Latitude == 0 && propertyLocation.Longitude == 0 {
+			logger.Warn("weather forecast disabled, PROPERTY_LATITUDE and PROPERTY_LONGITUDE are not set")
+			break
+		}
+		weather = outbound.NewWeatherForecasts(
+			outbound.NewOpenMeteoWeather(httpClients.Client("weather"), env.Get("WEATHER_API_URL", "https://api.open-meteo.com")),
+			propertyLocation,
+			logLevels.Logger("weather"),
+			env.Get("WEATHER_CACHE_TTL", time.Hour),
+			env.Get("WEATHER_MIN_INTERVAL", 2*time.Second),
+			env.Get("WEATHER_TIMEOUT", 3*time.Second),
+		)
+	default:
+		logger.Error("failed to configure weather provider", "error", "unknown provider "+provider)
+		os.Exit(1)
+	}
+
+	// Emails link to the reservation pages below the UI URL the guest is redirected to after login.
+	// Emails are queued in their own table and sent by priority at the provider's rate limit,
+	// so bursts (e.g. survey invitations after a busy checkout day) do not exceed the provider's quota.
+	emailQueueRepo, err := outbound.NewTableAccess[string, outbound.Email](reservationDB, "email_queue_kv_store")
+	if err != nil {
+		logger.Error("failed to create email queue repository", "error", err)
+		os.Exit(1)
+	}
+	if err := emailQueueRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize email queue repository", "error", err)
+		os.Exit(1)
+	}
+	var emailProvider outbound.EmailProvider
+	switch provider := env.Get("EMAIL_PROVIDER", "log"); provider {
+	case "log":
+		emailProvider = outbound.NewLogEmailProvider(logLevels.Logger("email"))
+	case "smtp":
+		emailProvider, err = outbound.NewSMTPEmailProvider(outbound.SMTPConfig{
+			Host:     env.Get("SMTP_HOST", ""),
+			Port:     env.Get("SMTP_PORT", 587),
+			Username: env.Get("SMTP_USERNAME", ""),
+			Password: env.Get("SMTP_PASSWORD", ""),
+			From:     env.Get("EMAIL_FROM", "Hotel Booking <noreply@localhost>"),
+		})
+	case "sendgrid":
+		emailProvider, err = outbound.NewSendGridEmailProvider(httpClients.Client("email"), env.Get("SENDGRID_API_URL", "https://api.sendgrid.com"), env.Get("SENDGRID_API_KEY", ""), env.Get("EMAIL_FROM", "Hotel Booking <noreply@localhost>"))
+	default:
+		logger.Error("failed to configure email provider", "error", "unknown provider "+provider)
+		os.Exit(1)
+	}
+	if err != nil {
+		logger.Error("failed to configure email provider", "error", err)
+		os.Exit(1)
+	}
+	emailRateLimits, err := outbound.ParseEmailRateLimits(env.Get("EMAIL_RATE_LIMITS", "log=14"))
+	if err != nil {
+		logger.Error("failed to parse email rate limits", "error", err)
+		os.Exit(1)
+	}
+	emailQueueConfig := outbound.DefaultEmailQueueConfig()
+	emailQueueConfig.RatePerSecond = emailRateLimits[emailProvider.Name()]
+	emailQueueConfig.MaxAttempts = env.Get("EMAIL_MAX_ATTEMPTS", emailQueueConfig.MaxAttempts)
+	emailQueueConfig.RetryDelay = env.Get("EMAIL_RETRY_DELAY", emailQueueConfig.RetryDelay)
+	emailQueueConfig.MaxQueued = env.Get("EMAIL_MAX_QUEUED", emailQueueConfig.MaxQueued)
+	emailQueue := outbound.NewEmailQueue(emailQueueRepo, emailProvider, emailQueueConfig, logLevels.Logger("email"))
+
+	// Every status change of a queued email is recorded in the guest's communication history,
+	// which outlives the queue; staff see it on /admin/reservations/{id} and resend failed emails.
+	communicationRepo, err := outbound.NewTableAccess[string, shared.Communication](reservationDB, "communication_kv_store")
+	if err != nil {
+		logger.Error("failed to create communication repository", "error", err)
+		os.Exit(1)
+	}
+	if err := communicationRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize communication repository", "error", err)
+		os.Exit(1)
+	}
+	communications := outbound.NewCommunicationHistory(communicationRepo, emailQueue, logLevels.Logger("email"))
+	emailQueue.OnStatus(communications.Record)
+	emailQueue.Start(ctx)
+
+	// Emails of guests without a language preference are written and formatted in DEFAULT_LOCALE.
+	defaultLocale := shared.ResolveLocale(env.Get("DEFAULT_LOCALE", string(shared.DefaultLocale)))
+	// Confirmations, cancellations, receipts and check-in welcomes get an HTML body from the embedded email templates.
+	emailTemplates := templating.NewEngine(efs)
+	emailTemplates.Parse("assets/emails/*.tmpl")
+	// Confirmations carry the stay as calendar event; guests download all their stays at /ui/reservations/calendar.ics.
+	calendar := outbound.NewICalendar(env.Get("PROPERTY_NAME", env.Get("APP_NAME", "Hotel Booking")), env.Get("REDIRECT_URL", "http://localhost:8080/ui"))
+	if propertyMaps != nil {
+		calendar.WithLocation(propertyMaps.PropertyAddress())
+	}
+	// Confirmations, cancellations and check-in welcomes open the reservation in the companion app if it is installed.
+	deepLinks, err := parseDeepLinks(config.lookup, env.Get("REDIRECT_URL", "http://localhost:8080/ui"))
+	if err != nil {
+		logger.Error("failed to configure deep links", "error", err)
+		os.Exit(1)
+	}
+	notificationService := outbound.NewMockNotificationService(logLevels.Logger("notification"), env.Get("REDIRECT_URL", "http://localhost:8080/ui"), propertyMaps).
+		WithOutbox(emailQueue).
+		WithLocale(defaultLocale).
+		WithTemplates(emailTemplates, env.Get("PROPERTY_NAME", env.Get("APP_NAME", "Hotel Booking"))).
+		WithReservations(reservationService).
+		WithCalendar(calendar)
+	if deepLinks != nil {
+		notificationService.WithDeepLinks(deepLinks)
+	}
+
+	// Guests still checked in after CHECKOUT_TIME on their check-out day are offered the next night at its price if
+	// the room is free, for OVERSTAY_OFFER_TTL; otherwise the front desk is alerted (OVERSTAY_HANDLING_ENABLED).
+	// The auto_complete job detects them; an accepted night is charged on the folio as an adjustment.
+	overstaysEnabled := env.Get("OVERSTAY_HANDLING_ENABLED", true)
+	if overstaysEnabled {
+		overstayPolicy, err := reservation.NewOverstayPolicy(env.Get("CHECKOUT_TIME", "11:00"), env.Get("OVERSTAY_OFFER_TTL", 2*time.Hour))
+		if err != nil {
+			logger.Error("failed to configure overstay handling", "error", err)
+			os.Exit(1)
+		}
+		reservationService.WithOverstays(overstayPolicy, outbound.NewPricingNightPrices(pricingService), notificationService)
+		if err := inbound.SubscribeExtensionCharges(ctx, dispatcher, financialService); err != nil {
+			logger.Error("failed to subscribe extension charges to events", "error", err)
+			os.Exit(1)
+		}
+	}
+	// Confirmations, cancellations, receipts and check-in welcomes are also pushed to the browsers and apps guests subscribed;
+	// every push is recorded in the communication history with the engagement the devices report.
+	// The messages carry a token signed with PUSH_TOKEN_SECRET the devices report the engagement with.
+	// Without a configured secret a random one is used, so reports of messages sent before a restart are rejected.
+	pushTokenSecret := env.Get("PUSH_TOKEN_SECRET", "")
+	if pushTokenSecret == "" {
+		logger.Warn("PUSH_TOKEN_SECRET not set, push engagement reports are rejected after a restart")
+		pushTokenSecret = security.GenerateID()
+	}
+	pushTokens := outbound.NewPushEngagementTokens(pushTokenSecret)
+	pushNotifications := outbound.NewPushNotificationService(notificationService, env.Get("REDIRECT_URL", "http://localhost:8080/ui"), logLevels.Logger("notification")).
+		WithEngagementTokens(pushTokens).
+		WithLocale(defaultLocale).
+		WithReservations(reservationService).
+		WithHistory(communications)
+
+	// Every booking runs as a saga whose steps and compensations are recorded in its own table;
+	// saga.started/completed/compensated/failed are published for monitoring.
+	sagaRepo, err := outbound.NewTableAccess[orchestration.SagaID, orchestration.Saga](reservationDB, "booking_saga_kv_store")
+	if err != nil {
+		logger.Error("failed to create saga repository", "error", err)
+		os.Exit(1)
+	}
+	if err := sagaRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize saga repository", "error", err)
+		os.Exit(1)
+	}
+	bookingService := orchestration.NewBookingService(reservationService, paymentService, pushNotifications).
+		WithSagaLog(sagaRepo, outbound.NewEventPublisher(dispatcher)).
+		WithMetrics(metrics).
+		WithTracer(serviceTracer)
+
+	// Payments are captured right after authorization, or only when the guest checks in.
+	// A declined capture at check-in is retried with doubling backoff before the stay is cancelled.
+	switch capture := env.Get("PAYMENT_CAPTURE", "authorization"); capture {
+	case "authorization":
+	case "check_in":
+		bookingService.WithCaptureOnCheckIn(orchestration.CaptureRetry{
+			Attempts: env.Get("PAYMENT_CAPTURE_ATTEMPTS", 4),
+			Backoff:  env.Get("PAYMENT_CAPTURE_BACKOFF", 2*time.Second),
+		})
+	default:
+		logger.Error("unknown payment capture", "capture", capture)
+		os.Exit(1)
+	}
+
+	// Initialize survey bounded context in its own table of the reservation database.
+	// Guests get an NPS survey after checkout; the rolling NPS is reported per property on /admin/dashboard.
+	surveyRepo, err := outbound.NewTableAccess[survey.SurveyID, survey.Survey](reservationDB, "survey_kv_store")
+	if err != nil {
+		logger.Error("failed to create survey repository", "error", err)
+		os.Exit(1)
+	}
+	if err := surveyRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize survey repository", "error", err)
+		os.Exit(1)
+	}
+	surveyService := survey.NewService(surveyRepo, notificationService, propertyLocation.Name)
+
+	// Initialize guest profile bounded context; the merge audit trail has its own table.
+	// Duplicate guest profiles are detected and merged by staff on /admin/duplicates and /admin/merges.
+	mergeRepo, err := outbound.NewTableAccess[profile.MergeID, profile.Merge](reservationDB, "profile_merge_kv_store")
+	if err != nil {
+		logger.Error("failed to create profile merge repository", "error", err)
+		os.Exit(1)
+	}
+	if err := mergeRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize profile merge repository", "error", err)
+		os.Exit(1)
+	}
+	// VIP tiers tagged by staff are stored in their own table; tiers earned by completed stays are derived.
+	tierRepo, err := outbound.NewTableAccess[profile.GuestID, profile.TierAssignment](reservationDB, "profile_tier_kv_store")
+	if err != nil {
+		logger.Error("failed to create profile tier repository", "error", err)
+		os.Exit(1)
+	}
+	if err := tierRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize profile tier repository", "error", err)
+		os.Exit(1)
+	}
+	// The email language chosen by guests when booking is stored in its own table as well.
+	languageRepo, err := outbound.NewTableAccess[profile.GuestID, profile.LanguagePreference](reservationDB, "profile_language_kv_store")
+	if err != nil {
+		logger.Error("failed to create profile language repository", "error", err)
+		os.Exit(1)
+	}
+	if err := languageRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize profile language repository", "error", err)
+		os.Exit(1)
+	}
+	tierPolicy, err := parseTierPolicy(config.lookup)
+	if err != nil {
+		logger.Error("failed to configure VIP tiers", "error", err)
+		os.Exit(1)
+	}
+	profileService := profile.NewService(outbound.NewReservationGuestDirectory(reservationService), mergeRepo, tierRepo, tierPolicy).
+		WithLanguages(languageRepo)
+	// Guests register their browsers (Web Push, signed with the VAPID key) and apps (FCM) for push notifications.
+	// A public key alone only registers browsers, e.g. while the sender runs elsewhere.
+	var pushBackends []outbound.PushBackend
+	pushTTL := env.Get("PUSH_TTL", 24*time.Hour)
+	vapidKey := env.Get("PUSH_VAPID_PUBLIC_KEY", "")
+	if privateKey := env.Get("PUSH_VAPID_PRIVATE_KEY", ""); privateKey != "" {
+		// The endpoints come from the browsers, so internal addresses are refused.
+		webPush, err := outbound.NewWebPushBackend(httpClients.PublicClient("push"), privateKey, env.Get("PUSH_VAPID_SUBJECT", ""), pushTTL)
+		if err != nil {
+			logger.Error("failed to configure Web Push", "error", err)
+			os.Exit(1)
+		}
+		if vapidKey != "" && vapidKey != webPush.PublicKey() {
+			logger.Error("failed to configure Web Push", "error", "PUSH_VAPID_PUBLIC_KEY does not match PUSH_VAPID_PRIVATE_KEY")
+			os.Exit(1)
+		}
+		vapidKey = webPush.PublicKey()
+		pushBackends = append(pushBackends, webPush)
+	}
+	if project := env.Get("FCM_PROJECT", ""); project != "" {
+		fcm, err := outbound.NewFCMBackend(httpClients.Client("fcm"), env.Get("FCM_API_URL", "https://fcm.googleapis.com"),
+			env.Get("FCM_TOKEN_URL", outbound.DefaultBigQueryTokenURL), project, pushTTL)
+		if err != nil {
+			logger.Error("failed to configure FCM", "error", err)
+			os.Exit(1)
+		}
+		pushBackends = append(pushBackends, fcm)
+	}
+	if vapidKey != "" || len(pushBackends) > 0 {
+		if vapidKey != "" {
+			if vapidKey, err = profile.ParseVAPIDPublicKey(vapidKey); err != nil {
+				logger.Error("failed to configure push notifications", "error", err)
+				os.Exit(1)
+			}
+		}
+		pushRepo, err := outbound.NewTableAccess[profile.PushSubscriptionID, profile.PushSubscription](reservationDB, "profile_push_kv_store")
+		if err != nil {
+			logger.Error("failed to create push subscription repository", "error", err)
+			os.Exit(1)
+		}
+		if err := pushRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize push subscription table", "error", err)
+			os.Exit(1)
+		}
+		profileService.WithPushSubscriptions(pushRepo, vapidKey)
+	}
+	pushNotifications.WithProfiles(profileService).WithBackends(pushBackends...)
+	// Confirmations, cancellations and receipts are sent in the language of the guest.
+	notificationService.WithProfiles(profileService)
+	reloadable(config, "vip_tiers", tierPolicyKeys, parseTierPolicy, profileService.SetTierPolicy)
+
+	// Initialize referral bounded context; codes and referrals have their own tables.
+	// Referrers earn the reward when the referred stay is completed; guests follow it on /ui/referrals.
+	referralCodeRepo, err := outbound.NewTableAccess[referral.Code, referral.ReferralCode](reservationDB, "referral_code_kv_store")
+	if err != nil {
+		logger.Error("failed to create referral code repository", "error", err)
+		os.Exit(1)
+	}
+	if err := referralCodeRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize referral code repository", "error", err)
+		os.Exit(1)
+	}
+	referralRepo, err := outbound.NewTableAccess[referral.ReferralID, referral.Referral](reservationDB, "referral_kv_store")
+	if err != nil {
+		logger.Error("failed to create referral repository", "error", err)
+		os.Exit(1)
+	}
+	if err := referralRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize referral repository", "error", err)
+		os.Exit(1)
+	}
+	referralPolicy, err := parseReferralPolicy(config.lookup)
+	if err != nil {
+		logger.Error("failed to configure referral reward", "error", err)
+		os.Exit(1)
+	}
+	referralService := referral.NewService(referralCodeRepo, referralRepo, outbound.NewReservationBookingHistory(reservationService), notificationService, referralPolicy)
+	reloadable(config, "referrals", referralPolicyKeys, parseReferralPolicy, referralService.SetPolicy)
+
+	// Initialize waitlist bounded context in its own table of the reservation database.
+	// Guests join the waitlist of a booked room on the reservation form; a cancellation offers the room
+	// to the first guest waiting for it, and nobody else can book it during the hold (WAITLIST_HOLD).
+	var waitlistService *waitlist.Service
+	if env.Get("WAITLIST_ENABLED", true) {
+		waitlistRepo, err := outbound.NewTableAccess[waitlist.EntryID, waitlist.Entry](reservationDB, "waitlist_kv_store")
+		if err != nil {
+			logger.Error("failed to create waitlist repository", "error", err)
+			os.Exit(1)
+		}
+		if err := waitlistRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize waitlist repository", "error", err)
+			os.Exit(1)
+		}
+		waitlistService = waitlist.NewService(waitlistRepo, outbound.NewReservationRoomAvailability(outbound.NewRepositoryAvailabilityChecker(reservationRepo)), notificationService, env.Get("WAITLIST_HOLD", waitlist.DefaultHold))
+		reservationService.WithRoomHolds(outbound.NewWaitlistRoomHolds(waitlistService))
+	}
+
+	// Initialize webhook bounded context; endpoints and their recent deliveries have their own tables.
+	// Integrators debug their receivers with test events and redeliveries on /admin/webhooks.
+	webhookEndpointRepo, err := outbound.NewTableAccess[webhook.EndpointID, webhook.Endpoint](reservationDB, "webhook_endpoint_kv_store")
+	if err != nil {
+		logger.Error("failed to create webhook endpoint repository", "error", err)
+		os.Exit(1)
+	}
+	if err := webhookEndpointRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize webhook endpoint repository", "error", err)
+		os.Exit(1)
+	}
+	webhookDeliveryRepo, err := outbound.NewTableAccess[webhook.DeliveryID, webhook.Delivery](reservationDB, "webhook_delivery_kv_store")
+	if err != nil {
+		logger.Error("failed to create webhook delivery repository", "error", err)
+		os.Exit(1)
+	}
+	if err := webhookDeliveryRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize webhook delivery repository", "error", err)
+		os.Exit(1)
+	}
+	webhookService := webhook.NewService(webhookEndpointRepo, webhookDeliveryRepo, outbound.NewHTTPWebhookSender(httpClients.Client("webhook")))
+
+	// Integrator endpoints, e.g. of channel managers, receive the reservation and payment events.
+	// Failed deliveries are retried with backoff by the webhook_retries job and dead-lettered after
+	// the last attempt; staff replay or discard them on /admin/webhooks/dead-letters.
+	if env.Get("WEBHOOK_DISPATCH_ENABLED", true) {
+		webhookRetryRepo, err := outbound.NewTableAccess[webhook.DeliveryID, webhook.Retry](reservationDB, "webhook_retry_kv_store")
+		if err != nil {
+			logger.Error("failed to create webhook retry repository", "error", err)
+			os.Exit(1)
+		}
+		if err := webhookRetryRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize webhook retry repository", "error", err)
+			os.Exit(1)
+		}
+		webhookService.WithRetries(webhookRetryRepo, webhook.RetryPolicy{
+			MaxAttempts: env.Get("WEBHOOK_MAX_ATTEMPTS", webhook.DefaultRetryPolicy().MaxAttempts),
+			Backoff:     env.Get("WEBHOOK_RETRY_BACKOFF", webhook.DefaultRetryPolicy().Backoff),
+		})
+		if err := inbound.SubscribeWebhookEvents(ctx, dispatcher, webhookService); err != nil {
+			logger.Error("failed to subscribe webhooks to events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Guests connect their reservations to their own automations on /ui/profile.
+	// Their URLs are untrusted, so they are called with a client refusing internal addresses.
+	if env.Get("GUEST_WEBHOOKS_ENABLED", true) {
+		guestWebhookRepo, err := outbound.NewTableAccess[webhook.EndpointID, webhook.Endpoint](reservationDB, "guest_webhook_kv_store")
+		if err != nil {
+			logger.Error("failed to create guest webhook repository", "error", err)
+			os.Exit(1)
+		}
+		if err := guestWebhookRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize guest webhook repository", "error", err)
+			os.Exit(1)
+		}
+		webhookService.WithGuestEndpoints(guestWebhookRepo, outbound.NewHTTPWebhookSender(httpClients.PublicClient("guest_webhook")))
+		if err := inbound.SubscribeGuestWebhookEvents(ctx, dispatcher, reservationService, webhookService); err != nil {
+			logger.Error("failed to subscribe guest webhooks to events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Channel managers follow the availability changes per room and night (inventory.changed,
+	// /api/v1/inventory/changes) instead of syncing full calendars. The changes are derived from the
+	// room calendars of the reservation context whenever a reservation books or releases nights.
+	var inventoryService *inventory.Service
+	if env.Get("INVENTORY_FEED_ENABLED", true) {
+		inventoryChangeRepo, err := outbound.NewTableAccess[inventory.ChangeKey, inventory.Change](reservationDB, "inventory_change_kv_store")
+		if err != nil {
+			logger.Error("failed to create inventory change repository", "error", err)
+			os.Exit(1)
+		}
+		if err := inventoryChangeRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize inventory change repository", "error", err)
+			os.Exit(1)
+		}
+		inventoryDayRepo, err := outbound.NewTableAccess[inventory.DayKey, inventory.Day](reservationDB, "inventory_day_kv_store")
+		if err != nil {
+			logger.Error("failed to create inventory day repository", "error", err)
+			os.Exit(1)
+		}
+		if err := inventoryDayRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize inventory day repository", "error", err)
+			os.Exit(1)
+		}
+		inventoryService = inventory.NewService(inventoryChangeRepo, inventoryDayRepo, outbound.NewReservationOccupancy(reservationService), outbound.NewEventPublisher(dispatcher))
+		if err := inbound.SubscribeInventoryEvents(ctx, dispatcher, reservationService, inventoryService); err != nil {
+			logger.Error("failed to subscribe inventory to events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Guests earn loyalty points for the amount paid for every completed stay and redeem them on the reservation
+	// form (LOYALTY_*). The point transactions have their own table; cancelling a reservation gives back its points.
+	var loyaltyService *loyalty.Service
+	if env.Get("LOYALTY_ENABLED", true) {
+		loyaltyRepo, err := outbound.NewTableAccess[loyalty.TransactionID, loyalty.Transaction](reservationDB, "loyalty_transaction_kv_store")
+		if err != nil {
+			logger.Error("failed to create loyalty transaction repository", "error", err)
+			os.Exit(1)
+		}
+		if err := loyaltyRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize loyalty transaction repository", "error", err)
+			os.Exit(1)
+		}
+		loyaltyPolicy, err := parseLoyaltyPolicy(config.lookup)
+		if err != nil {
+			logger.Error("failed to configure loyalty points", "error", err)
+			os.Exit(1)
+		}
+		loyaltyService = loyalty.NewService(loyaltyRepo, outbound.NewEventPublisher(dispatcher), loyaltyPolicy)
+		switch roomLocks {
+		case "postgres":
+			loyaltyService.WithGuestLocks(outbound.NewPostgresLoyaltyLocks(reservationDB, roomLockWait))
+		case "local":
+			loyaltyService.WithGuestLocks(outbound.NewLocalLoyaltyLocks(roomLockWait))
+		}
+		reloadable(config, "loyalty", loyaltyPolicyKeys, parseLoyaltyPolicy, loyaltyService.SetPolicy)
+		if err := inbound.SubscribeLoyaltyEvents(ctx, dispatcher, reservationService, loyaltyService); err != nil {
+			logger.Error("failed to subscribe loyalty to events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Admins hand out promo codes that guests enter on the reservation form (PROMOTIONS_ENABLED). The codes and
+	// their redemptions have their own tables; cancelling a reservation gives back the use of its code.
+	// Code checks on the form are limited per IP address, so codes cannot be enumerated.
+	var promotionService *promotion.Service
+	var promoCheckLimiter *inbound.RateLimiter
+	if env.Get("PROMOTIONS_ENABLED", true) {
+		promotionRepo, err := outbound.NewTableAccess[promotion.Code, promotion.Promotion](reservationDB, "promotion_kv_store")
+		if err != nil {
+			logger.Error("failed to create promotion repository", "error", err)
+			os.Exit(1)
+		}
+		if err := promotionRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize promotion repository", "error", err)
+			os.Exit(1)
+		}
+		redemptionRepo, err := outbound.NewTableAccess[promotion.ReservationID, promotion.Redemption](reservationDB, "promotion_redemption_kv_store")
+		if err != nil {
+			logger.Error("failed to create promotion redemption repository", "error", err)
+			os.Exit(1)
+		}
+		if err := redemptionRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize promotion redemption repository", "error", err)
+			os.Exit(1)
+		}
+		promotionService = promotion.NewService(promotionRepo, redemptionRepo)
+		switch roomLocks {
+		case "postgres":
+			promotionService.WithCodeLocks(outbound.NewPostgresPromotionLocks(reservationDB, roomLockWait))
+		case "local":
+			promotionService.WithCodeLocks(outbound.NewLocalPromotionLocks(roomLockWait))
+		}
+		promoCheckLimiter = inbound.NewRateLimiter(env.Get("PROMO_CHECK_LIMIT", 30), env.Get("PROMO_CHECK_WINDOW", 15*time.Minute))
+		if err := inbound.SubscribePromotionEvents(ctx, dispatcher, promotionService); err != nil {
+			logger.Error("failed to subscribe promotions to events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Completed stays are invoiced by the jurisdiction of their property (INVOICE_JURISDICTIONS): each numbers its
+	// invoices in its own sequence and sets the seller, tax numbers, VAT, city tax and legal footer. Empty disables invoicing.
+	var invoiceService *invoice.Service
+	if value := env.Get("INVOICE_JURISDICTIONS", ""); value != "" {
+		jurisdictions, err := invoice.ParseJurisdictions(value)
+		if err != nil {
+			logger.Error("failed to configure invoice jurisdictions", "error", err)
+			os.Exit(1)
+		}
+		invoiceRepo, err := outbound.NewTableAccess[invoice.ReservationID, invoice.Invoice](reservationDB, "invoice_kv_store")
+		if err != nil {
+			logger.Error("failed to create invoice repository", "error", err)
+			os.Exit(1)
+		}
+		if err := invoiceRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize invoice repository", "error", err)
+			os.Exit(1)
+		}
+		invoiceService = invoice.NewService(invoiceRepo, outbound.NewReservationInvoiceStays(reservationService, financialService), jurisdictions)
+		switch roomLocks {
+		case "postgres":
+			invoiceService.WithNumberLocks(outbound.NewPostgresInvoiceLocks(reservationDB, roomLockWait))
+		case "local":
+			invoiceService.WithNumberLocks(outbound.NewLocalInvoiceLocks(roomLockWait))
+		}
+		if err := inbound.SubscribeInvoices(ctx, dispatcher, invoiceService); err != nil {
+			logger.Error("failed to subscribe invoices to events", "error", err)
+			os.Exit(1)
+		}
+	}
 
 	// Register cross-context event handlers.
-	eventHandlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
+	eventHandlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService, surveyService, referralService, waitlistService)
 	if err := eventHandlers.RegisterHandlers(ctx, dispatcher); err != nil {
 		logger.Error("failed to register event handlers", "error", err)
 		os.Exit(1)
 	}
 
-	// Initialize OIDC provider for MCP token verification.
-	// This connects to Keycloak to validate Bearer tokens for the MCP endpoint.
-	// Reuses the existing OIDC_ISSUER environment variable for consistency.
-	oidcIssuer := env.Get("OIDC_ISSUER", "http://localhost:8180/realms/local")
-	provider, err := oidc.NewProvider(ctx, oidcIssuer)
+	// Copy reservation and payment events to the data warehouse for analytics if enabled.
+	// The sink batches the flattened events and adds columns for new fields; the backfill of the
+	// current state of the databases is triggered with cmd/backfill (POST /admin/warehouse/backfill).
+	var warehouse inbound.WarehouseRecorder
+	var warehouseTarget outbound.Warehouse
+	switch provider := env.Get("WAREHOUSE_PROVIDER", "none"); provider {
+	case "none":
+	case "clickhouse":
+		warehouseTarget, err = outbound.NewClickHouseWarehouse(httpClients.Client("warehouse"),
+			env.Get("CLICKHOUSE_URL", "http://localhost:8123"),
+			env.Get("CLICKHOUSE_DATABASE", "default"),
+			env.Get("CLICKHOUSE_USERNAME", ""),
+			env.Get("CLICKHOUSE_PASSWORD", ""),
+		)
+	case "bigquery":
+		warehouseTarget, err = outbound.NewBigQueryWarehouse(httpClients.Client("warehouse"),
+			env.Get("BIGQUERY_API_URL", "https://bigquery.googleapis.com"),
+			env.Get("BIGQUERY_TOKEN_URL", outbound.DefaultBigQueryTokenURL),
+			env.Get("BIGQUERY_PROJECT", ""),
+			env.Get("BIGQUERY_DATASET", ""),
+		)
+	default:
+		logger.Error("failed to configure warehouse", "error", "unknown provider "+provider)
+		os.Exit(1)
+	}
+	if err != nil {
+		logger.Error("failed to configure warehouse", "error", err)
+		os.Exit(1)
+	}
+	if warehouseTarget != nil {
+		warehouseConfig := outbound.DefaultWarehouseSinkConfig()
+		warehouseConfig.BatchSize = env.Get("WAREHOUSE_BATCH_SIZE", warehouseConfig.BatchSize)
+		warehouseConfig.FlushInterval = env.Get("WAREHOUSE_FLUSH_INTERVAL", warehouseConfig.FlushInterval)
+		warehouseConfig.MaxBuffered = env.Get("WAREHOUSE_MAX_BUFFERED", warehouseConfig.MaxBuffered)
+		warehouseSink := outbound.NewWarehouseSink(warehouseTarget, warehouseConfig, logLevels.Logger("warehouse"))
+		if err := inbound.SubscribeWarehouseEvents(ctx, dispatcher, warehouseSink); err != nil {
+			logger.Error("failed to subscribe warehouse to events", "error", err)
+			os.Exit(1)
+		}
+		warehouseSink.Start(ctx)
+		warehouse = warehouseSink
+	}
+
+	// Initialize the token verifier for the MCP endpoint.
+	// Each trusted issuer maps to a principal type (guest realm vs. staff realm).
+	// By default the MCP client of OIDC_ISSUER is trusted as staff, as before.
+	// The OIDC providers are discovered lazily and rediscovered periodically,
+	// so the server starts without them and picks up rotated signing keys without a restart.
+	trustedIssuers, err := outbound.ParseTrustedIssuers(env.Get("OIDC_TRUSTED_ISSUERS",
+		"staff|"+env.Get("OIDC_ISSUER", "http://localhost:8180/realms/local")+"|"+env.Get("MCP_CLIENT_ID", "hotel-booking-mcp"),
+	))
+	if err != nil {
+		logger.Error("failed to parse trusted issuers", "error", err)
+		os.Exit(1)
+	}
+	verifier := outbound.NewMultiIssuerTokenVerifier(outbound.OIDCClientContext(ctx, httpClients.Client("oidc")), trustedIssuers, logLevels.Logger("oidc"),
+		env.Get("OIDC_MIN_REFRESH_DELAY", 30*time.Second),
+	)
+	verifier.Start(ctx, env.Get("OIDC_REFRESH_INTERVAL", 15*time.Minute))
+
+	// Old versions of the JSON API are deprecated and sunset by date, e.g. API_DEPRECATIONS=v1=2027-01-01.
+	// Deprecated versions answer with the Deprecation, Sunset and Link headers, sunset versions with 410 Gone.
+	apiVersions, err := inbound.ParseAPIVersionPolicy(inbound.APIVersions,
+		env.Get("API_DEPRECATIONS", ""), env.Get("API_SUNSETS", ""), env.Get("API_DEPRECATION_LINK", ""),
+	)
+	if err != nil {
+		logger.Error("failed to parse API version policy", "error", err)
+		os.Exit(1)
+	}
+	apiVersions.WithMetrics(metrics)
+
+	// Register the service accounts allowed to call /mcp with client-credentials tokens.
+	// By default the MCP client may use all tools, as before.
+	serviceAccounts, err := outbound.ParseServiceAccounts(env.Get("SERVICE_ACCOUNTS",
+		env.Get("MCP_CLIENT_ID", "hotel-booking-mcp")+"="+strings.Join([]string{
+			reservation.ScopeRead, reservation.ScopeWrite, payment.ScopeRead, payment.ScopeWrite, pricing.ScopeRead, property.ScopeRead,
+		}, " "),
+	))
+	if err != nil {
+		logger.Error("failed to parse service accounts", "error", err)
+		os.Exit(1)
+	}
+
+	// Staff accounts are provisioned by HR via SCIM (/scim/v2, SCIM_TOKEN) in their own table.
+	// Provisioned staff are limited to the scopes of their roles; unprovisioned staff to their OIDC roles.
+	staffRepo, err := outbound.NewTableAccess[staff.MemberID, staff.StaffMember](reservationDB, "staff_kv_store")
+	if err != nil {
+		logger.Error("failed to create staff repository", "error", err)
+		os.Exit(1)
+	}
+	if err := staffRepo.Init(startupCtx); err != nil {
+		logger.Error("failed to initialize staff repository", "error", err)
+		os.Exit(1)
+	}
+	staffRoles, err := parseStaffRoles(config.lookup)
+	if err != nil {
+		logger.Error("failed to parse staff roles", "error", err)
+		os.Exit(1)
+	}
+	staffService := staff.NewService(staffRepo, staffRoles)
+	reloadable(config, "staff_roles", staffRolesKeys, parseStaffRoles, staffService.SetRolePolicy)
+
+	// Map the OIDC roles of tokens and sessions to permissions.
+	// Tokens without a known role get the role of their realm (guest or staff), like UI sessions; unknown roles grant nothing.
+	var roleAuthorizer *inbound.RoleAuthorizer
+	if env.Get("ROLE_AUTHORIZATION_ENABLED", true) {
+		rolePermissions, err := parseRolePermissions(config.lookup)
+		if err != nil {
+			logger.Error("failed to parse role permissions", "error", err)
+			os.Exit(1)
+		}
+		roleAuthorizer = inbound.NewRoleAuthorizer(rolePermissions)
+		reloadable(config, "role_permissions", rolePermissionsKeys, parseRolePermissions, roleAuthorizer.SetPermissions)
+	}
+
+	// Sign reservation share invitation links.
+	// Without a configured secret a random one is used, so pending invitations do not survive a restart.
+	shareLinkSecret := env.Get("SHARE_LINK_SECRET", "")
+	if shareLinkSecret == "" {
+		logger.Warn("SHARE_LINK_SECRET not set, share invitation links are invalidated on restart")
+		shareLinkSecret = security.GenerateID()
+	}
+	shareLinks := outbound.NewShareLinks(shareLinkSecret, env.Get("SHARE_LINK_TTL", 7*24*time.Hour))
+
+	// Store generated files (profiles, exports, invoices) on the local disk or in an S3-compatible bucket.
+	// Files are downloaded via signed, expiring links; expired files are deleted per key prefix.
+	var blobStorage outbound.BlobStorage
+	switch provider := env.Get("BLOB_STORAGE", "local"); provider {
+	case "local":
+		blobStorage = outbound.NewLocalBlobStorage(env.Get("BLOB_DIR", "data"))
+	case "s3":
+		blobStorage, err = outbound.NewS3BlobStorage(httpClients.Client("blob"), outbound.S3Config{
+			Endpoint:        env.Get("BLOB_S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:          env.Get("BLOB_S3_REGION", "us-east-1"),
+			Bucket:          env.Get("BLOB_S3_BUCKET", ""),
+			AccessKeyID:     env.Get("BLOB_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: env.Get("BLOB_S3_SECRET_ACCESS_KEY", ""),
+		})
+		if err != nil {
+			logger.Error("failed to configure blob storage", "error", err)
+			os.Exit(1)
+		}
+	default:
+		logger.Error("failed to configure blob storage", "error", "unknown storage "+provider)
+		os.Exit(1)
+	}
+	blobRetention, err := outbound.ParseBlobRetention(env.Get("BLOB_RETENTION", "profiles/=168h"))
 	if err != nil {
-		logger.Error("failed to initialize OIDC provider", "error", err)
+		logger.Error("failed to parse blob retention", "error", err)
 		os.Exit(1)
 	}
+	outbound.NewBlobRetention(blobStorage, blobRetention, logLevels.Logger("blob")).Start(ctx, time.Hour)
+	blobURLSecret := env.Get("BLOB_URL_SECRET", "")
+	if blobURLSecret == "" {
+		logger.Warn("BLOB_URL_SECRET not set, download links are invalidated on restart")
+		blobURLSecret = security.GenerateID()
+	}
+	blobDownloads := outbound.NewBlobDownloads(blobStorage, blobURLSecret, env.Get("BLOB_URL_TTL", 15*time.Minute))
 
-	// Configure token verifier for MCP client.
-	// Uses a separate client ID for machine-to-machine MCP authentication.
-	mcpClientID := env.Get("MCP_CLIENT_ID", "hotel-booking-mcp")
-	verifier := provider.Verifier(&oidc.Config{ClientID: mcpClientID})
+	// Keep the document wallet of the reservations: the metadata in its own table of the reservation
+	// database, the files in the blob storage below documents/. Uploads are checked against the limits
+	// and scanned by the service at DOCUMENT_SCAN_URL if set. Documents are purged DOCUMENT_RETENTION
+	// after the stay ended by the document_retention job.
+	var documentService *document.Service
+	documentRetention := env.Get("DOCUMENT_RETENTION", 90*24*time.Hour)
+	if env.Get("DOCUMENTS_ENABLED", true) {
+		documentRepo, err := outbound.NewTableAccess[document.DocumentID, document.Document](reservationDB, "document_kv_store")
+		if err != nil {
+			logger.Error("failed to create document repository", "error", err)
+			os.Exit(1)
+		}
+		if err := documentRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize document repository", "error", err)
+			os.Exit(1)
+		}
+		documentLimits := document.DefaultLimits()
+		documentLimits.MaxSize = int64(env.Get("DOCUMENT_MAX_SIZE", int(documentLimits.MaxSize)))
+		documentLimits.MaxPerReservation = env.Get("DOCUMENT_MAX_COUNT", documentLimits.MaxPerReservation)
+		if types := env.Get("DOCUMENT_TYPES", ""); types != "" {
+			documentLimits.AllowedTypes = nil
+			for t := range strings.SplitSeq(types, ",") {
+				documentLimits.AllowedTypes = append(documentLimits.AllowedTypes, document.MediaType(t))
+			}
+		}
+		var virusScanner document.VirusScanner = outbound.NoopVirusScanner{}
+		if scanURL := env.Get("DOCUMENT_SCAN_URL", ""); scanURL != "" {
+			virusScanner = outbound.NewHTTPVirusScanner(httpClients.Client("virus_scan"), scanURL)
+		} else {
+			logger.Warn("DOCUMENT_SCAN_URL not set, documents are stored without a virus scan")
+		}
+		documentService = document.NewService(documentRepo, blobStorage, virusScanner, documentLimits)
+	}
+
+	// Sample the reservations for data science (SAMPLE_EXPORT_ENABLED): a share of them, SAMPLE_RATE, with
+	// pseudonyms instead of IDs and without contact data, served by /admin/reservations/sample and stored
+	// below SAMPLE_PREFIX in the blob storage by the reservation_sample job. The pseudonyms are derived from
+	// SAMPLE_SECRET, which therefore has to stay the same for samples to be joined over time.
+	var reservationSampler *inbound.ReservationSampler
+	if env.Get("SAMPLE_EXPORT_ENABLED", false) {
+		sampleSecret := env.Get("SAMPLE_SECRET", "")
+		if sampleSecret == "" {
+			logger.Error("failed to configure reservation sample", "error", "SAMPLE_SECRET is required")
+			os.Exit(1)
+		}
+		reservationSampler, err = inbound.NewReservationSampler(env.Get("SAMPLE_RATE", 0.1), sampleSecret)
+		if err != nil {
+			logger.Error("failed to configure reservation sample", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Initialize report bounded context (REPORTS_ENABLED): managers subscribe to the occupancy or revenue
+	// report of a property on /admin/report-subscriptions; the report_subscriptions job emails the due reports.
+	var reportService *report.Service
+	if env.Get("REPORTS_ENABLED", true) {
+		reportSubscriptionRepo, err := outbound.NewTableAccess[report.SubscriptionID, report.Subscription](reservationDB, "report_subscription_kv_store")
+		if err != nil {
+			logger.Error("failed to create report subscription repository", "error", err)
+			os.Exit(1)
+		}
+		if err := reportSubscriptionRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize report subscription repository", "error", err)
+			os.Exit(1)
+		}
+		reportService = report.NewService(reportSubscriptionRepo, outbound.NewReservationStays(reservationService, rates, properties), properties, currencyOfRecord)
+	}
+
+	// Run the periodic jobs: no-shows after the check-in day, overstays after check-out time and completion after the check-out day,
+	// expiry of unpaid reservations, pruning of expired price locks and room holds, webhook retries, the ledger
+	// reconciliation, the payout alerts, the document retention, the expiry of waitlist holds, the reservation sample
+	// and the report emails.
+	// Only one replica should run them (SCHEDULER_ENABLED).
+	var scheduler *inbound.Scheduler
+	if env.Get("SCHEDULER_ENABLED", true) {
+		defaultJobs := "no_show=15m,auto_complete=15m,expire_pending=1m,price_locks=15m,webhook_retries=1m"
+		if reservationService.HoldsRooms() {
+			defaultJobs += ",room_holds=1m"
+		}
+		if ledgerService != nil {
+			defaultJobs += ",ledger_sync=1h,payout_check=1h"
+		}
+		if documentService != nil {
+			defaultJobs += ",document_retention=24h"
+		}
+		if waitlistService != nil {
+			defaultJobs += ",waitlist_offers=1m"
+		}
+		if reservationSampler != nil {
+			defaultJobs += ",reservation_sample=24h"
+		}
+		if reportService != nil {
+			defaultJobs += ",report_subscriptions=15m"
+		}
+		jobIntervals, err := inbound.ParseJobIntervals(env.Get("SCHEDULER_JOBS", defaultJobs))
+		if err != nil {
+			logger.Error("failed to parse scheduler jobs", "error", err)
+			os.Exit(1)
+		}
+		pendingExpiry := env.Get("PENDING_EXPIRY", 30*time.Minute)
+		jobs := map[string]func(ctx context.Context, now time.Time) (int, error){
+			"no_show":       countSwept(reservationService.MarkNoShows),
+			"auto_complete": countSwept(reservationService.CompleteDepartedStays),
+			"expire_pending": countSwept(func(ctx context.Context, now time.Time) ([]reservation.ReservationID, error) {
+				return reservationService.ExpireUnpaidReservations(ctx, now, pendingExpiry)
+			}),
+			"price_locks":     pricingService.PruneExpiredLocks,
+			"room_holds":      reservationService.ExpireRoomHolds,
+			"webhook_retries": webhookService.RetryDue,
+		}
+		if overstaysEnabled {
+			jobs["auto_complete"] = inbound.CheckOutStays(reservationService, logLevels.Logger("overstay"))
+		}
+		if ledgerService != nil {
+			jobs["ledger_sync"] = inbound.SyncLedger(paymentService, financialService, ledgerService)
+			jobs["payout_check"] = inbound.CheckPayouts(ledgerService, ledgerPayoutDelay, logLevels.Logger("ledger"))
+		}
+		if documentService != nil {
+			jobs["document_retention"] = inbound.PurgeDocuments(documentService, reservationService, documentRetention)
+		}
+		if waitlistService != nil {
+			jobs["waitlist_offers"] = waitlistService.ExpireOffers
+		}
+		if reservationSampler != nil {
+			jobs["reservation_sample"] = inbound.ExportReservationSample(reservationService, reservationSampler, blobStorage, env.Get("SAMPLE_PREFIX", "samples"))
+		}
+		if reportService != nil {
+			jobs["report_subscriptions"] = inbound.SendReports(reportService, notificationService)
+		}
+		scheduler = inbound.NewScheduler(logLevels.Logger("scheduler"))
+		for name, every := range jobIntervals {
+			run, ok := jobs[name]
+			if !ok {
+				logger.Error("failed to parse scheduler jobs", "error", "unknown job "+name)
+				os.Exit(1)
+			}
+			scheduler.Register(inbound.Job{Name: name, Every: every, Run: run})
+		}
+		scheduler.Start(ctx)
+	}
 
 	// Build the MCP server with all tools registered.
-	mcpServer := buildMCPServer(reservationService, availabilityChecker, paymentService)
+	mcpServer := buildMCPServer(reservationService, availabilityChecker, rates, paymentService, financialService, pricingService, loyaltyService, properties)
+
+	// Render the guest-facing content pages (FAQ, policies, directions) from markdown.
+	// The embedded defaults can be overridden page by page with the files in CONTENT_DIR.
+	contentDefaults, err := fs.Sub(efs, "assets/content")
+	if err != nil {
+		logger.Error("failed to load content pages", "error", err)
+		os.Exit(1)
+	}
+	contentLayers := []fs.FS{contentDefaults}
+	if dir := env.Get("CONTENT_DIR", ""); dir != "" {
+		contentLayers = append(contentLayers, os.DirFS(dir))
+	}
+	contentPages := outbound.NewContentPages(contentLayers...)
+
+	// Expose the FAQ, the rooms and the cancellation policy as MCP resources,
+	// so agents can ground their answers without tool round-trips.
+	mcpResources := buildMCPResources(contentPages, rates, pricingService, paymentSandbox)
+
+	// Limit the MCP tool calls per client, so a runaway agent cannot starve the others.
+	// Agents see their remaining quota in every tool result and are warned before they are rejected.
+	mcpQuotaConfig := inbound.DefaultMCPQuotaConfig()
+	mcpQuotaConfig.Limit = env.Get("MCP_QUOTA_LIMIT", mcpQuotaConfig.Limit)
+	mcpQuotaConfig.Window = env.Get("MCP_QUOTA_WINDOW", mcpQuotaConfig.Window)
+	mcpQuotaConfig.WarnAt = env.Get("MCP_QUOTA_WARN_AT", mcpQuotaConfig.WarnAt)
+
+	// Limit the requests to the JSON API, GraphQL and MCP per client with a token bucket,
+	// so an agent or integration hammering the booking system is rejected before it reaches the services.
+	requestLimitConfig := inbound.DefaultRequestLimitConfig()
+	requestLimitConfig.Rate = env.Get("REQUEST_LIMIT_RATE", requestLimitConfig.Rate)
+	requestLimitConfig.Burst = env.Get("REQUEST_LIMIT_BURST", requestLimitConfig.Burst)
+
+	// Let guests without an account find their booking by confirmation code and email or last name.
+	// Management links are signed with the share link secret but bound to their own purpose,
+	// so a share invitation is never a management link. Lookups are limited per IP address.
+	var manageLinks inbound.ShareLinkSigner
+	var lookupLimiter *inbound.RateLimiter
+	var captcha inbound.CaptchaVerifier
+	if env.Get("BOOKING_LOOKUP_ENABLED", true) {
+		manageLinks = outbound.NewShareLinks(shareLinkSecret, env.Get("MANAGE_LINK_TTL", 24*time.Hour)).WithPurpose("manage")
+		lookupLimiter = inbound.NewRateLimiter(env.Get("BOOKING_LOOKUP_LIMIT", 10), env.Get("BOOKING_LOOKUP_WINDOW", 15*time.Minute))
+		// An optional CAPTCHA (Cloudflare Turnstile or hCaptcha) guards the lookup against automated guessing.
+		if provider := env.Get("CAPTCHA_PROVIDER", ""); provider != "" {
+			siteverify, err := outbound.NewSiteverifyCaptcha(httpClients.Client("captcha"), provider,
+				env.Get("CAPTCHA_VERIFY_URL", ""), env.Get("CAPTCHA_SITE_KEY", ""), env.Get("CAPTCHA_SECRET", ""))
+			if err != nil {
+				logger.Error("failed to create captcha verifier", "error", err)
+				os.Exit(1)
+			}
+			captcha = siteverify
+		}
+	}
+
+	// Document the published events for consumers on /api/events/catalog and /ui/events/catalog.
+	eventCatalog := inbound.NewEventCatalog()
+	if err := eventCatalog.Register("reservation", reservation.ExampleEvents()...); err != nil {
+		logger.Error("failed to register reservation events", "error", err)
+		os.Exit(1)
+	}
+	if err := eventCatalog.Register("payment", payment.ExampleEvents()...); err != nil {
+		logger.Error("failed to register payment events", "error", err)
+		os.Exit(1)
+	}
+	if err := eventCatalog.Register("orchestration", orchestration.ExampleEvents()...); err != nil {
+		logger.Error("failed to register orchestration events", "error", err)
+		os.Exit(1)
+	}
+	if inventoryService != nil {
+		if err := eventCatalog.Register("inventory", inventory.ExampleEvents()...); err != nil {
+			logger.Error("failed to register inventory events", "error", err)
+			os.Exit(1)
+		}
+	}
+	if loyaltyService != nil {
+		if err := eventCatalog.Register("loyalty", loyalty.ExampleEvents()...); err != nil {
+			logger.Error("failed to register loyalty events", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// A typed nil pointer must not be passed as interface, it would not compare to nil.
+	var propertyMap inbound.PropertyMap
+	if propertyMaps != nil {
+		propertyMap = propertyMaps
+	}
+	var faultController inbound.FaultController
+	if faults != nil {
+		faultController = faults
+	}
+	var httpTracer inbound.HTTPTracer
+	if tracer != nil {
+		httpTracer = tracer
+	}
+	var deadLetterQueue inbound.DeadLetterQueue
+	if deadLetters != nil {
+		deadLetterQueue = deadLetters
+	}
+	var auditTrail inbound.AuditTrail
+	if auditLog != nil {
+		auditTrail = auditLog
+	}
+	var appAssociations inbound.AppAssociations
+	if deepLinks != nil {
+		appAssociations = deepLinks
+	}
+
+	// The readiness probe verifies the dependencies; only the critical ones take the instance
+	// out of the load balancer, the others are reported as degraded.
+	critical := make(map[string]bool)
+	for name := range strings.SplitSeq(env.Get("READINESS_CRITICAL", "reservation_database,payment_database,kafka"), ",") {
+		critical[strings.TrimSpace(name)] = true
+	}
+	readiness := inbound.NewReadiness(env.Get("READINESS_TIMEOUT", 2*time.Second), env.Get("READINESS_CACHE_TTL", 5*time.Second))
+	if reservationDB != nil {
+		readiness.Register(readinessCheck("reservation_database", critical["reservation_database"], pingDatabase(reservationDB)))
+		readiness.Register(readinessCheck("payment_database", critical["payment_database"], pingDatabase(paymentDB)))
+	}
+	readiness.Register(readinessCheck("kafka", critical["kafka"], pingKafka(env.Get("KAFKA_BROKERS", "localhost:9092"))))
+	readiness.Register(readinessCheck("oidc_issuer", critical["oidc_issuer"], pingOIDC(httpClients.Client("oidc"), env.Get("OIDC_ISSUER", "http://localhost:8180/realms/local"))))
+
+	// The public status page shows the health of the components guests notice, derived from the readiness
+	// checks they depend on, and the incidents ops announce via /admin/incidents (incident_kv_store).
+	var incidentService *incident.Service
+	if env.Get("STATUS_PAGE_ENABLED", true) {
+		incidentRepo, err := outbound.NewTableAccess[incident.IncidentID, incident.Incident](reservationDB, "incident_kv_store")
+		if err != nil {
+			logger.Error("failed to create incident repository", "error", err)
+			os.Exit(1)
+		}
+		if err := incidentRepo.Init(startupCtx); err != nil {
+			logger.Error("failed to initialize incident repository", "error", err)
+			os.Exit(1)
+		}
+		incidentService = incident.NewService(incidentRepo)
+	}
+	statusComponents := inbound.StatusComponentChecks{
+		incident.ComponentBookingEngine: {"reservation_database", "kafka"},
+		incident.ComponentPayments:      {"payment_database", "kafka"},
+		incident.ComponentNotifications: {"kafka"},
+	}
+
+	// Ops download the state of this instance during incidents as one bundle: the settings without secrets,
+	// the health of the dependencies, the metrics, the queue depths and the bookings whose saga failed.
+	diagnostics := inbound.NewDiagnostics(env.Get("APP_VERSION", "1.0.0"))
+	diagnostics.Register(inbound.DiagnosticJSON("config.json", func(ctx context.Context) (any, error) {
+		return inbound.RedactSettings(config.settings()), nil
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("health.json", func(ctx context.Context) (any, error) {
+		return readiness.Check(ctx), nil
+	}))
+	diagnostics.Register(inbound.DiagnosticSection{Name: "metrics.prom", Write: func(ctx context.Context, w io.Writer) error {
+		return metrics.WritePrometheus(w)
+	}})
+	diagnostics.Register(inbound.DiagnosticJSON("http_clients.json", func(ctx context.Context) (any, error) {
+		return httpClients.Metrics(), nil
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("log_levels.json", func(ctx context.Context) (any, error) {
+		return logLevels.Levels(), nil
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("email_queue.json", func(ctx context.Context) (any, error) {
+		return emailQueue.Pending(ctx)
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("failed_sagas.json", func(ctx context.Context) (any, error) {
+		return bookingService.FailedSagas(ctx, 100)
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("webhook_dead_letters.json", func(ctx context.Context) (any, error) {
+		return webhookService.DeadLetters(ctx)
+	}))
+	if faults != nil {
+		diagnostics.Register(inbound.DiagnosticJSON("faults.json", func(ctx context.Context) (any, error) {
+			return faults.Faults(), nil
+		}))
+	}
 
 	// Create router with all dependencies via RouterConfig.
 	mux := inbound.Route(inbound.RouterConfig{
-		Ctx:                ctx,
-		EFS:                efs,
-		Logger:             logger,
-		ReservationService: reservationService,
-		MCPServer:          mcpServer,
-		Verifier:           verifier,
+		APIVersions:          apiVersions,
+		AdminToken:           env.Get("ADMIN_TOKEN", ""),
+		AppAssociations:      appAssociations,
+		Blobs:                blobDownloads,
+		Calendar:             calendar,
+		Captcha:              captcha,
+		ConfigReloader:       config,
+		Communications:       communications,
+		ContentPages:         contentPages,
+		Ctx:                  ctx,
+		DeadLetters:          deadLetterQueue,
+		AuditTrail:           auditTrail,
+		Diagnostics:          diagnostics,
+		DocumentService:      documentService,
+		EFS:                  efs,
+		EmailPreviews:        notificationService,
+		EventCatalog:         eventCatalog,
+		Faults:               faultController,
+		PaymentSandbox:       paymentSandbox,
+		FinancialService:     financialService,
+		LedgerService:        ledgerService,
+		LedgerPayoutDelay:    ledgerPayoutDelay,
+		HTTPClients:          httpClients,
+		HouseholdInvitations: notificationService,
+		HouseholdService:     householdService,
+		IncidentService:      incidentService,
+		InventoryService:     inventoryService,
+		InvoiceService:       invoiceService,
+		Logger:               logLevels.Logger("http"),
+		LogLevels:            logLevels,
+		LoyaltyService:       loyaltyService,
+		PaymentService:       paymentService,
+		PricingService:       pricingService,
+		ProfileService:       profileService,
+		PromoCheckLimiter:    promoCheckLimiter,
+		PromotionService:     promotionService,
+		Properties:           properties,
+		PropertyMap:          propertyMap,
+		PushEngagements:      communications,
+		PushTokens:           pushTokens,
+		QRCodes:              outbound.NewQRCodes(4),
+		Rates:                rates,
+		Readiness:            readiness,
+		ReferralService:      referralService,
+		ReportService:        reportService,
+		RequestLimiter:       inbound.NewRequestLimiter(requestLimitConfig),
+		Roles:                roleAuthorizer,
+		ReservationService:   reservationService,
+		ReservationSampler:   reservationSampler,
+		LookupLimiter:        lookupLimiter,
+		ManageLinkSender:     notificationService,
+		ManageLinks:          manageLinks,
+		MCPOperations:        inbound.NewMCPOperations(env.Get("MCP_OPERATION_TIMEOUT", inbound.DefaultMCPOperationTimeout)),
+		MCPQuota:             inbound.NewMCPQuota(mcpQuotaConfig),
+		MCPResources:         mcpResources,
+		MCPServer:            mcpServer,
+		Metrics:              metrics,
+		MetricsToken:         env.Get("METRICS_TOKEN", ""),
+		Scheduler:            scheduler,
+		ScimToken:            env.Get("SCIM_TOKEN", ""),
+		ServiceAccounts:      serviceAccounts,
+		ShareInvitations:     notificationService,
+		ShareLinks:           shareLinks,
+		StatusComponents:     statusComponents,
+		StaffService:         staffService,
+		SurveyService:        surveyService,
+		TestClock:            testClock,
+		Tracer:               httpTracer,
+		UnmaskedOutput:       !masking,
+		Verifier:             verifier,
+		WaitlistService:      waitlistService,
+		Warehouse:            warehouse,
+		Weather:              weather,
+		WebhookService:       webhookService,
 	})
 
+	// Start the continuous profiler if enabled.
+	// CPU and heap profiles are written periodically, so production issues can be diagnosed later.
+	if env.Get("PROFILER_ENABLED", false) {
+		profiler := outbound.NewContinuousProfiler(
+			outbound.NewBlobProfileStore(blobStorage, env.Get("PROFILER_DIR", "profiles")),
+			logLevels.Logger("profiler"),
+			env.Get("PROFILER_INTERVAL", 10*time.Minute),
+			env.Get("PROFILER_CPU_DURATION", 30*time.Second),
+		)
+		profiler.Start(ctx)
+	}
+
+	// Reload the config file on SIGHUP, e.g. after Helm updated the ConfigMap.
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(hangups)
+				return
+			case <-hangups:
+				changes, err := config.Reload(ctx)
+				if err != nil {
+					logger.Error("failed to reload config", "trigger", "sighup", "error", err)
+					continue
+				}
+				logger.Info("config reloaded", "audit", true, "trigger", "sighup", "changes", changes)
+			}
+		}
+	}()
+
 	srv := web.NewServer(mux)
 	defer func() { _ = srv.Close() }()
 
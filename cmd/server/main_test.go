@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
@@ -148,6 +150,46 @@ func Benchmark_Server_Integration_Login_Page_Should_Render_Fast(b *testing.B) {
 	}
 }
 
+func Benchmark_Server_Integration_Login_Page_Uncompressed_Should_Render_Fast(b *testing.B) {
+	benchmarkLoginPageWithEncoding(b, "identity")
+}
+
+func Benchmark_Server_Integration_Login_Page_Gzip_Should_Render_Fast(b *testing.B) {
+	benchmarkLoginPageWithEncoding(b, "gzip")
+}
+
+// benchmarkLoginPageWithEncoding measures the latency impact of response compression.
+func benchmarkLoginPageWithEncoding(b *testing.B, encoding string) {
+	b.Helper()
+	ctx := context.Background()
+	logger := logging.NewJsonLogger()
+	reservationService := createBenchReservationService()
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                ctx,
+		EFS:                efs,
+		Logger:             logger,
+		ReservationService: reservationService,
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Disable transparent decompression so the Accept-Encoding header is sent as-is.
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{DisableCompression: true},
+	}
+
+	for b.Loop() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/ui/login", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		resp, _ := client.Do(req)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+}
+
 func Benchmark_Server_Integration_MCP_Tools_List_Should_Be_Fast(b *testing.B) {
 	ctx := context.Background()
 	logger := logging.NewJsonLogger()
@@ -156,7 +198,8 @@ func Benchmark_Server_Integration_MCP_Tools_List_Should_Be_Fast(b *testing.B) {
 	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(newMockReservationRepository())
 
 	// Build MCP server with tools registered.
-	mcpServer := buildMCPServer(reservationService, availabilityChecker, paymentService)
+	properties, _ := property.NewCatalog(property.Property{ID: property.DefaultPropertyID, Name: "Hotel", Timezone: "UTC"})
+	mcpServer := buildMCPServer(reservationService, availabilityChecker, reservation.NewRates(reservation.DefaultRatePolicy()), paymentService, nil, nil, nil, properties)
 
 	mux := inbound.Route(inbound.RouterConfig{
 		Ctx:                ctx,
@@ -389,6 +432,10 @@ func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *pay
 	return nil
 }
 
+func (m *mockNotificationService) SendCheckInWelcome(ctx context.Context, r *reservation.Reservation) error {
+	return nil
+}
+
 func createBenchBookingService() *orchestration.BookingService {
 	reservationService := createBenchReservationService()
 	paymentService := createBenchPaymentService()
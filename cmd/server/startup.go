@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/segmentio/kafka-go"
+)
+
+// This file contains the startup dependency checks.
+// Container orchestrators start services in parallel, so Postgres and Kafka
+// may not be reachable yet when the server starts. Instead of exiting
+// immediately, each dependency is retried with exponential backoff until it
+// becomes available or the overall startup timeout is reached.
+// Keycloak is not checked here; the MCP token verifier discovers it lazily.
+// The same checks, and one of Keycloak, back the readiness probe while the server runs.
+
+// startupConfig configures the retries of the startup dependency checks.
+type startupConfig struct {
+	timeout      time.Duration // hard limit for all checks together
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+// waitFor calls fn until it succeeds, doubling the delay between attempts up to maxDelay.
+// It gives up when the context is done, which includes the startup timeout.
+func waitFor[T any](ctx context.Context, logger *slog.Logger, cfg startupConfig, name string, fn func(ctx context.Context) (T, error)) (T, error) {
+	delay := cfg.initialDelay
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil {
+			logger.Info("dependency available", "dependency", name, "attempt", attempt, "elapsed", time.Since(start).String())
+			return result, nil
+		}
+
+		logger.Warn("dependency not available yet",
+			"dependency", name,
+			"attempt", attempt,
+			"retry_in", delay.String(),
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, fmt.Errorf("%s not available after %d attempts: %w", name, attempt, errors.Join(ctx.Err(), err))
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, cfg.maxDelay)
+	}
+}
+
+// pingDatabase returns a check that verifies the database accepts connections.
+func pingDatabase(db *sql.DB) func(ctx context.Context) (struct{}, error) {
+	return func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, db.PingContext(ctx)
+	}
+}
+
+// openSQLite opens the SQLite database file of the database (reservation or payment).
+func openSQLite(logger *slog.Logger, database, file string) (*sql.DB, error) {
+	db, err := outbound.OpenSQLite(file)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("sqlite database opened", "database", database, "file", file)
+	return db, nil
+}
+
+// migrateDatabase applies the pending migrations of the database (reservation or payment),
+// or reverts the latest down of them if down is positive.
+func migrateDatabase(ctx context.Context, logger *slog.Logger, db *sql.DB, database string, down int) error {
+	migrator, err := outbound.NewMigrator(db, database)
+	if err != nil {
+		return err
+	}
+	if down > 0 {
+		reverted, err := migrator.Down(ctx, down)
+		logger.Warn("migrations reverted", "database", database, "versions", reverted)
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if len(applied) > 0 {
+		logger.Info("migrations applied", "database", database, "versions", applied)
+	}
+	return err
+}
+
+// dbSystem returns the database system of the storage backend for the spans of the repositories.
+func dbSystem(storageBackend string) string {
+	if storageBackend == "sqlite" {
+		return "sqlite"
+	}
+	return "postgresql"
+}
+
+// pingKafka returns a check that verifies at least one of the brokers answers metadata requests.
+func pingKafka(brokers string) func(ctx context.Context) (struct{}, error) {
+	return func(ctx context.Context) (struct{}, error) {
+		var errs []error
+		for broker := range strings.SplitSeq(brokers, ",") {
+			conn, err := kafka.DialContext(ctx, "tcp", strings.TrimSpace(broker))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			_, err = conn.Brokers()
+			_ = conn.Close()
+			if err == nil {
+				return struct{}{}, nil
+			}
+			errs = append(errs, err)
+		}
+		return struct{}{}, errors.Join(errs...)
+	}
+}
+
+// pingOIDC checks that the discovery document of the OIDC issuer can be fetched.
+func pingOIDC(client *http.Client, issuer string) func(ctx context.Context) (struct{}, error) {
+	return func(ctx context.Context) (struct{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+		if err != nil {
+			return struct{}{}, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return struct{}{}, err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return struct{}{}, fmt.Errorf("oidc discovery returned status %d", resp.StatusCode)
+		}
+		return struct{}{}, nil
+	}
+}
+
+// readinessCheck adapts a dependency check to the readiness probe.
+func readinessCheck(name string, critical bool, ping func(ctx context.Context) (struct{}, error)) inbound.ReadinessCheck {
+	return inbound.ReadinessCheck{Name: name, Critical: critical, Check: func(ctx context.Context) error {
+		_, err := ping(ctx)
+		return err
+	}}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+)
+
+// ============================================================================
+// Startup Check Tests
+// ============================================================================
+
+func Test_WaitFor_With_Transient_Failures_Should_Retry_Until_Success(t *testing.T) {
+	// Arrange
+	cfg := startupConfig{timeout: time.Second, initialDelay: time.Millisecond, maxDelay: 2 * time.Millisecond}
+	attempts := 0
+	check := func(ctx context.Context) (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", errors.New("connection refused")
+		}
+		return "ok", nil
+	}
+
+	// Act
+	result, err := waitFor(context.Background(), slog.Default(), cfg, "test", check)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "result must be ok", result, "ok")
+	assert.That(t, "attempts must be 3", attempts, 3)
+}
+
+func Test_WaitFor_With_Timeout_Should_Return_Error(t *testing.T) {
+	// Arrange
+	cfg := startupConfig{timeout: 20 * time.Millisecond, initialDelay: time.Millisecond, maxDelay: 5 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+	check := func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, errors.New("connection refused")
+	}
+
+	// Act
+	_, err := waitFor(ctx, slog.Default(), cfg, "test", check)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "error must wrap deadline exceeded", errors.Is(err, context.DeadlineExceeded), true)
+}
+
+func Test_PingOIDC_With_Discovery_Document_Should_Succeed(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/local/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"issuer":"local"}`))
+	}))
+	defer srv.Close()
+
+	// Act
+	_, err := pingOIDC(srv.Client(), srv.URL+"/realms/local/")(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
+func Test_PingOIDC_Without_Discovery_Document_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	// Act
+	_, err := pingOIDC(srv.Client(), srv.URL+"/realms/local")(context.Background())
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,141 @@
+// Command simulate replays the recent bookings against proposed pricing rules and a cancellation
+// policy via the admin API of a running server and prints the revenue delta and the affected bookings.
+//
+// Usage:
+//
+//	ADMIN_TOKEN=... simulate [-server http://localhost:8080] [-months 6] [-json] scenario.json
+//
+// The scenario file has the body of POST /admin/simulations; "-" reads it from stdin.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/env"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+func main() {
+	client := &http.Client{Timeout: 2 * time.Minute}
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, client, env.Get("ADMIN_TOKEN", "")); err != nil {
+		fmt.Fprintln(os.Stderr, "simulate:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, posts the scenario and prints the report.
+func run(args []string, stdin io.Reader, stdout io.Writer, client *http.Client, token string) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	server := flags.String("server", env.Get("SERVER_URL", "http://localhost:8080"), "base URL of the server")
+	months := flags.Int("months", 0, "months of bookings to replay (overrides the scenario file)")
+	raw := flags.Bool("json", false, "print the report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: simulate [-server url] [-months n] [-json] scenario.json")
+	}
+	if token == "" {
+		return errors.New("ADMIN_TOKEN not set")
+	}
+
+	var scenario []byte
+	var err error
+	if path := flags.Arg(0); path == "-" {
+		scenario, err = io.ReadAll(stdin)
+	} else {
+		scenario, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read scenario: %w", err)
+	}
+	var req inbound.HttpAdminSimulationRequest
+	if err := json.Unmarshal(scenario, &req); err != nil {
+		return fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if *months > 0 {
+		req.Months = *months
+	}
+
+	report, body, err := simulate(client, strings.TrimSuffix(*server, "/"), token, req)
+	if err != nil {
+		return err
+	}
+	if *raw {
+		_, err := stdout.Write(body)
+		return err
+	}
+	printReport(stdout, report)
+	return nil
+}
+
+// simulate posts the scenario to the admin API and returns the decoded and the raw report.
+func simulate(client *http.Client, server, token string, req inbound.HttpAdminSimulationRequest) (inbound.HttpAdminSimulationResponse, []byte, error) {
+	var report inbound.HttpAdminSimulationResponse
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return report, nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, server+"/admin/simulations", bytes.NewReader(payload))
+	if err != nil {
+		return report, nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return report, nil, fmt.Errorf("failed to call server: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return report, nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return report, nil, fmt.Errorf("server answered %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, &report); err != nil {
+		return report, nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	return report, body, nil
+}
+
+// printReport prints the totals and a table of the affected bookings.
+func printReport(w io.Writer, report inbound.HttpAdminSimulationResponse) {
+	fmt.Fprintf(w, "Bookings created %s to %s: %d (%d affected, %d skipped in other currencies)\n",
+		report.From, report.To, report.Bookings, report.Affected, report.Skipped)
+	fmt.Fprintf(w, "Current revenue:  %s\n", formatMoney(report.CurrentRevenue))
+	fmt.Fprintf(w, "Proposed revenue: %s\n", formatMoney(report.ProposedRevenue))
+	fmt.Fprintf(w, "Revenue delta:    %s\n", formatMoney(report.RevenueDelta))
+	if len(report.Impacts) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESERVATION\tROOM\tCHECK-IN\tNIGHTS\tSTATUS\tCURRENT\tPROPOSED\tDELTA\tREASONS")
+	for _, impact := range report.Impacts {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			impact.ReservationID, impact.RoomID, impact.CheckIn, impact.Nights, impact.Status,
+			formatMoney(impact.Current), formatMoney(impact.Proposed), formatMoney(impact.Delta),
+			strings.Join(impact.Reasons, "; "))
+	}
+	_ = tw.Flush()
+}
+
+// formatMoney formats the amount like the rest of the application, e.g. 99.00 USD.
+func formatMoney(m inbound.APIMoney) string {
+	return shared.NewMoney(m.Amount, m.Currency).FormatAmount()
+}
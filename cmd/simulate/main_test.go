@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// createTestAdminServer answers simulations with a report of one affected booking
+// and records the last request.
+func createTestAdminServer(t *testing.T, got *inbound.HttpAdminSimulationRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(got)
+		_ = json.NewEncoder(w).Encode(inbound.HttpAdminSimulationResponse{
+			From: "2026-04-16", To: "2026-10-16", Bookings: 2, Affected: 1,
+			CurrentRevenue:  inbound.APIMoney{Amount: 50000, Currency: "USD"},
+			ProposedRevenue: inbound.APIMoney{Amount: 54000, Currency: "USD"},
+			RevenueDelta:    inbound.APIMoney{Amount: 4000, Currency: "USD"},
+			Impacts: []inbound.HttpAdminBookingImpact{{
+				ReservationID: "res-001", RoomID: "room-101", CheckIn: "2026-05-01", Nights: 3, Status: "completed",
+				Current:  inbound.APIMoney{Amount: 30000, Currency: "USD"},
+				Proposed: inbound.APIMoney{Amount: 34000, Currency: "USD"},
+				Delta:    inbound.APIMoney{Amount: 4000, Currency: "USD"},
+				Reasons:  []string{"weekend (+20%) on 2 of 3 nights"},
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ============================================================================
+// run Tests
+// ============================================================================
+
+func Test_Run_Should_Print_Report(t *testing.T) {
+	// Arrange
+	var got inbound.HttpAdminSimulationRequest
+	server := createTestAdminServer(t, &got)
+	scenario := strings.NewReader(`{"months":6,"rules":[{"name":"weekend","weekdays":["fri","sat"],"percent":20}]}`)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "-months", "3", "-"}, scenario, &out, server.Client(), "secret")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "months flag must override the scenario", got.Months, 3)
+	assert.That(t, "rules must be sent", got.Rules[0].Name, "weekend")
+	assert.That(t, "report must show the delta", strings.Contains(out.String(), "Revenue delta:    40.00 USD"), true)
+	assert.That(t, "report must list the booking", strings.Contains(out.String(), "weekend (+20%) on 2 of 3 nights"), true)
+}
+
+func Test_Run_With_JSON_Flag_Should_Print_Raw_Report(t *testing.T) {
+	// Arrange
+	var got inbound.HttpAdminSimulationRequest
+	server := createTestAdminServer(t, &got)
+	var out bytes.Buffer
+
+	// Act
+	err := run([]string{"-server", server.URL, "-json", "-"}, strings.NewReader(`{}`), &out, server.Client(), "secret")
+
+	// Assert
+	var report inbound.HttpAdminSimulationResponse
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "output must be json", json.Unmarshal(out.Bytes(), &report), nil)
+	assert.That(t, "affected must be 1", report.Affected, 1)
+}
+
+func Test_Run_With_Wrong_Token_Should_Fail(t *testing.T) {
+	// Arrange
+	var got inbound.HttpAdminSimulationRequest
+	server := createTestAdminServer(t, &got)
+
+	// Act
+	err := run([]string{"-server", server.URL, "-"}, strings.NewReader(`{}`), &bytes.Buffer{}, server.Client(), "wrong")
+
+	// Assert
+	assert.That(t, "err must mention the status", err != nil && strings.Contains(err.Error(), "401"), true)
+}
+
+func Test_Run_Without_Token_Should_Fail(t *testing.T) {
+	// Act
+	err := run([]string{"-"}, strings.NewReader(`{}`), &bytes.Buffer{}, http.DefaultClient, "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,443 @@
+package inbound_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ============================================================================
+// Accessibility Checks
+// ============================================================================
+
+// a11yViolations checks rendered HTML against a subset of the axe rules and returns
+// the violations as "rule: element" messages. Fragments (HTMX partials) skip the
+// document rules (lang, title, one h1).
+//
+// Rules:
+//   - html-has-lang, document-title, page-has-heading-one (documents only)
+//   - image-alt: images need an alt attribute (empty for decorative images)
+//   - label: form fields need a label, aria-label, aria-labelledby or title
+//   - button-name, link-name: buttons and links need a text or aria-label
+//   - heading-order: heading levels increase by one at most
+//   - duplicate-id: ids are unique, labels and aria references depend on it
+func a11yViolations(body string) []string {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return []string{"parse: " + err.Error()}
+	}
+	isDocument := strings.Contains(strings.ToLower(body[:min(len(body), 512)]), "<html")
+
+	var violations []string
+	report := func(rule string, n *html.Node) {
+		violations = append(violations, fmt.Sprintf("%s: %s", rule, describeNode(n)))
+	}
+
+	labelled := make(map[string]bool) // ids referenced by <label for>
+	ids := make(map[string]bool)
+	var headings []*html.Node
+	var title string
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if id := attr(n, "id"); id != "" {
+			if ids[id] {
+				report("duplicate-id", n)
+			}
+			ids[id] = true
+		}
+		switch n.DataAtom {
+		case atom.Label:
+			if id := attr(n, "for"); id != "" {
+				labelled[id] = true
+			}
+		case atom.Title:
+			if !hasAncestor(n, atom.Svg) {
+				title = textContent(n)
+			}
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			headings = append(headings, n)
+		}
+	}
+
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode || hasAttr(n, "aria-hidden") && attr(n, "aria-hidden") == "true" {
+			continue
+		}
+		switch n.DataAtom {
+		case atom.Html:
+			if isDocument && strings.TrimSpace(attr(n, "lang")) == "" {
+				report("html-has-lang", n)
+			}
+		case atom.Img:
+			if !hasAttr(n, "alt") && !hasAriaName(n) && !slices.Contains([]string{"none", "presentation"}, attr(n, "role")) {
+				report("image-alt", n)
+			}
+		case atom.Input, atom.Select, atom.Textarea:
+			if n.DataAtom == atom.Input && slices.Contains([]string{"hidden", "submit", "button", "reset", "image"}, strings.ToLower(attr(n, "type"))) {
+				continue
+			}
+			if !hasAriaName(n) && attr(n, "title") == "" && !labelled[attr(n, "id")] && !hasAncestor(n, atom.Label) {
+				report("label", n)
+			}
+		case atom.Button:
+			if textContent(n) == "" && !hasAriaName(n) && attr(n, "title") == "" {
+				report("button-name", n)
+			}
+		case atom.A:
+			if hasAttr(n, "href") && textContent(n) == "" && !hasAriaName(n) && attr(n, "title") == "" {
+				report("link-name", n)
+			}
+		}
+	}
+
+	if isDocument {
+		if strings.TrimSpace(title) == "" {
+			violations = append(violations, "document-title: <title> missing or empty")
+		}
+		if !slices.ContainsFunc(headings, func(h *html.Node) bool { return h.DataAtom == atom.H1 }) {
+			violations = append(violations, "page-has-heading-one: no <h1>")
+		}
+	}
+	previous := 0
+	for _, h := range headings {
+		level := int(h.Data[1] - '0')
+		if previous > 0 && level > previous+1 {
+			report(fmt.Sprintf("heading-order (h%d after h%d)", level, previous), h)
+		}
+		previous = level
+	}
+	return violations
+}
+
+// assertAccessible fails the test with every accessibility violation of the rendered HTML.
+func assertAccessible(t *testing.T, body string) {
+	t.Helper()
+	violations := a11yViolations(body)
+	for _, v := range violations {
+		t.Errorf("accessibility violation: %s", v)
+	}
+}
+
+// attr returns the value of the attribute, or an empty string.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether the element has the attribute, even if it is empty.
+func hasAttr(n *html.Node, key string) bool {
+	return slices.ContainsFunc(n.Attr, func(a html.Attribute) bool { return a.Key == key })
+}
+
+// hasAriaName reports whether the element is named by aria-label or aria-labelledby.
+func hasAriaName(n *html.Node) bool {
+	return strings.TrimSpace(attr(n, "aria-label")) != "" || attr(n, "aria-labelledby") != ""
+}
+
+// hasAncestor reports whether the element is inside an element of the kind.
+func hasAncestor(n *html.Node, kind atom.Atom) bool {
+	for p := range n.Ancestors() {
+		if p.DataAtom == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent returns the text a screen reader announces for the element:
+// its text and the alt text and aria-labels of its children.
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	for c := range n.Descendants() {
+		switch {
+		case c.Type == html.TextNode:
+			b.WriteString(c.Data)
+		case c.Type == html.ElementNode && c.DataAtom == atom.Img:
+			b.WriteString(attr(c, "alt"))
+		case c.Type == html.ElementNode:
+			b.WriteString(attr(c, "aria-label"))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// describeNode renders the start tag of the element for violation messages.
+func describeNode(n *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + n.Data)
+	for _, a := range n.Attr {
+		if slices.Contains([]string{"id", "name", "type", "href", "src", "class"}, a.Key) {
+			fmt.Fprintf(&b, " %s=%q", a.Key, a.Val)
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// ============================================================================
+// a11yViolations Tests
+// ============================================================================
+
+func Test_A11yViolations_With_Accessible_Document_Should_Return_Nothing(t *testing.T) {
+	// Arrange
+	body := `<!doctype html><html lang="en"><head><title>Booking</title></head><body>
+		<h1>Book a room</h1><h2>Guest</h2>
+		<img src="/logo.png" alt="">
+		<label for="name">Name</label><input id="name" name="name">
+		<label>Email <input type="email" name="email"></label>
+		<input type="hidden" name="csrf">
+		<button type="submit">Book</button>
+		<a href="/ui" aria-label="Home"><svg></svg></a>
+	</body></html>`
+
+	// Act
+	violations := a11yViolations(body)
+
+	// Assert
+	assert.That(t, "violations must be empty", len(violations), 0)
+}
+
+func Test_A11yViolations_Should_Report_Missing_Lang_Title_And_H1(t *testing.T) {
+	// Arrange
+	body := `<!doctype html><html><head></head><body><h2>Details</h2></body></html>`
+
+	// Act
+	violations := a11yViolations(body)
+
+	// Assert
+	assert.That(t, "violations must be 3", len(violations), 3)
+	assert.That(t, "lang must be reported", strings.HasPrefix(violations[0], "html-has-lang"), true)
+}
+
+func Test_A11yViolations_Should_Report_Image_Without_Alt(t *testing.T) {
+	// Act
+	violations := a11yViolations(`<div><img src="/qr.png"></div>`)
+
+	// Assert
+	assert.That(t, "violations must be 1", len(violations), 1)
+	assert.That(t, "image-alt must be reported", strings.HasPrefix(violations[0], "image-alt"), true)
+}
+
+func Test_A11yViolations_Should_Report_Unlabelled_Fields(t *testing.T) {
+	// Act
+	violations := a11yViolations(`<form><input id="check_in" type="date"><select name="room"></select><textarea></textarea></form>`)
+
+	// Assert
+	assert.That(t, "violations must be 3", len(violations), 3)
+}
+
+func Test_A11yViolations_Should_Report_Empty_Buttons_And_Links(t *testing.T) {
+	// Act
+	violations := a11yViolations(`<div><button><svg></svg></button><a href="/ui"></a></div>`)
+
+	// Assert
+	assert.That(t, "violations must be 2", len(violations), 2)
+}
+
+func Test_A11yViolations_Should_Report_Skipped_Heading_Level(t *testing.T) {
+	// Act
+	violations := a11yViolations(`<section><h2>Stay</h2><h4>Guests</h4></section>`)
+
+	// Assert
+	assert.That(t, "violations must be 1", len(violations), 1)
+	assert.That(t, "heading-order must be reported", strings.HasPrefix(violations[0], "heading-order"), true)
+}
+
+func Test_A11yViolations_Should_Report_Duplicate_IDs(t *testing.T) {
+	// Act
+	violations := a11yViolations(`<div><p id="total">1</p><p id="total">2</p></div>`)
+
+	// Assert
+	assert.That(t, "violations must be 1", len(violations), 1)
+}
+
+// ============================================================================
+// Booking Flow Accessibility Tests
+// ============================================================================
+
+// createA11yTestEngine parses the templates shipped with the server, not the testdata stubs,
+// so the checks cover the HTML guests actually get.
+func createA11yTestEngine(t *testing.T) *templating.Engine {
+	t.Helper()
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+	e := templating.NewEngine(os.DirFS("../../../cmd/server"))
+	e.Parse("assets/templates/*.tmpl")
+	return e
+}
+
+// renderA11yPage serves the request with the handler and returns the rendered HTML.
+func renderA11yPage(t *testing.T, handler http.HandlerFunc, req *http.Request) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	return rec.Body.String()
+}
+
+func Test_Accessibility_Login_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewLogin(e), httptest.NewRequest(http.MethodGet, "/ui/login", nil))
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Index_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/", nil), "test-session-123", "test@example.com")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewIndex(e), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Reservation_Form(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil), "test-session-123", "test@example.com")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewReservationForm(e, nil, nil, nil), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Reservations_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	repo := newMockReservationRepository()
+	res := createTestReservation("res-001", "user-subject-456", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	_ = repo.Create(context.Background(), res.ID, *res)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewReservations(e, createReservationsTestService(repo), nil), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Reservation_Detail(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	repo := newMockReservationRepository()
+	res := createTestReservation("res-001", "user-subject-456", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	_ = repo.Create(context.Background(), res.ID, *res)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil), "test-session-123", "test@example.com")
+	req.SetPathValue("id", "res-001")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewReservationDetail(e, createDetailTestService(repo), nil), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Error_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+
+	// Act
+	rec := httptest.NewRecorder()
+	inbound.HttpViewError(e)(rec, httptest.NewRequest(http.MethodGet, "/ui/error?code=404", nil))
+
+	// Assert
+	assertAccessible(t, rec.Body.String())
+}
+
+func Test_Accessibility_Reservation_Financials_Fragment(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewReservationFinancials(e, svc, createFinancialTestService(svc), nil), financialsRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Reservation_Invoice_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	invoices := createInvoiceTestService(svc)
+	_, _ = invoices.Issue(context.Background(), "res-001")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewReservationInvoice(e, svc, invoices), invoiceRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Profile_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	svc := createTestGuestWebhookService(http.StatusOK)
+	_, _ = svc.RegisterGuestEndpoint(context.Background(), "gep-1", "user-subject-456", "https://hooks.example.com", "secret", nil)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/profile", nil), "test-session-123", "test@example.com")
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewProfile(e, svc), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Booking_Lookup_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewBookingLookup(e, &mockCaptcha{}), httptest.NewRequest(http.MethodGet, "/ui/lookup", nil))
+
+	// Assert
+	assertAccessible(t, body)
+}
+
+func Test_Accessibility_Booking_Manage_Page(t *testing.T) {
+	// Arrange
+	e := createA11yTestEngine(t)
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+	req := httptest.NewRequest(http.MethodGet, "/ui/lookup/manage?token="+links.Sign("res-001", "guest@example.com"), nil)
+
+	// Act
+	body := renderA11yPage(t, inbound.HttpViewBookingManage(e, createDetailTestService(repo), links), req)
+
+	// Assert
+	assertAccessible(t, body)
+}
@@ -0,0 +1,59 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// PurgeDocuments returns the document_retention job: it purges the documents of reservations that
+// ended (completed, cancelled or no-show) longer than retention ago, and of reservations that no longer exist.
+// A reservation ends at the later of its check-out and its last change, so a cancelled future stay
+// keeps its documents until the stay would have ended. It returns the number of purged documents.
+func PurgeDocuments(documentService *document.Service, reservationService *reservation.Service, retention time.Duration) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		ids, err := documentService.Reservations(ctx)
+		if err != nil || len(ids) == 0 {
+			return 0, err
+		}
+		// All reservations are read at once, so a failing read never looks like a deleted reservation.
+		all, err := reservationService.ListReservations(ctx)
+		if err != nil {
+			return 0, err
+		}
+		reservations := make(map[reservation.ReservationID]*reservation.Reservation, len(all))
+		for i := range all {
+			reservations[all[i].ID] = &all[i]
+		}
+		var errs []error
+		purged := 0
+		for _, id := range ids {
+			if res, ok := reservations[id]; ok && !documentsExpired(res, now, retention) {
+				continue
+			}
+			n, err := documentService.Purge(ctx, id)
+			purged += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return purged, errors.Join(errs...)
+	}
+}
+
+// documentsExpired reports whether the reservation ended longer than retention before now.
+func documentsExpired(res *reservation.Reservation, now time.Time, retention time.Duration) bool {
+	switch res.Status {
+	case reservation.StatusCompleted, reservation.StatusCancelled, reservation.StatusNoShow:
+	default:
+		return false
+	}
+	ended := res.DateRange.CheckOut
+	if res.UpdatedAt.After(ended) {
+		ended = res.UpdatedAt
+	}
+	return now.Sub(ended) > retention
+}
@@ -0,0 +1,46 @@
+package inbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PurgeDocuments Tests
+// ============================================================================
+
+func Test_PurgeDocuments_Should_Purge_Ended_And_Deleted_Reservations_Only(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	now := time.Now()
+	checkIn := now.AddDate(0, 0, 7)
+	ended := createTestReservation("res-ended", "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+	ended.Status = reservation.StatusCompleted
+	ended.DateRange = reservation.NewDateRange(now.AddDate(0, 0, -102), now.AddDate(0, 0, -100))
+	ended.UpdatedAt = now.AddDate(0, 0, -100)
+	repo.put(ended.ID, *ended)
+	upcoming := createTestReservation("res-upcoming", "guest@example.com", "room-102", checkIn, checkIn.AddDate(0, 0, 2))
+	repo.put(upcoming.ID, *upcoming)
+	documents := createTestDocumentService(t)
+	ctx := context.Background()
+	for _, id := range []shared.ReservationID{"res-ended", "res-upcoming", "res-deleted"} {
+		_, _ = documents.Upload(ctx, document.Upload{ReservationID: id, Kind: document.KindGuest, Name: "visa.pdf", ContentType: "application/pdf", Data: []byte("%PDF")})
+	}
+	job := inbound.PurgeDocuments(documents, createDetailTestService(repo), 90*24*time.Hour)
+
+	// Act
+	purged, err := job(ctx, now)
+
+	// Assert
+	remaining, _ := documents.Reservations(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "ended and deleted reservations must be purged", purged, 2)
+	assert.That(t, "upcoming reservation must keep its documents", remaining, []shared.ReservationID{"res-upcoming"})
+}
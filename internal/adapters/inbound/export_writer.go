@@ -0,0 +1,213 @@
+package inbound
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// exportFlushEvery is the number of rows after which the CSV writer flushes to the response.
+const exportFlushEvery = 1000
+
+// ExportColumn describes a column of an export.
+// Numeric columns are written as numbers to spreadsheets, so they can be summed without conversion.
+type ExportColumn struct {
+	Name    string
+	Numeric bool
+}
+
+// ExportWriter writes the rows of a table export one by one to an underlying writer,
+// so an export is streamed to the client instead of being built in memory.
+// Close must be called after the last row to complete the file.
+type ExportWriter interface {
+	ContentType() string
+	Extension() string
+	WriteHeader(columns []ExportColumn) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+// NewExportWriter returns the writer of the format (csv or xlsx).
+func NewExportWriter(format string, w io.Writer) (ExportWriter, error) {
+	switch format {
+	case "", "csv":
+		return NewCSVExportWriter(w), nil
+	case "xlsx":
+		return NewXLSXExportWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q, use csv or xlsx", format)
+	}
+}
+
+// CSVExportWriter writes an export as RFC 4180 CSV.
+type CSVExportWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+// NewCSVExportWriter creates a new CSV export writer.
+func NewCSVExportWriter(w io.Writer) *CSVExportWriter {
+	return &CSVExportWriter{w: csv.NewWriter(w)}
+}
+
+// ContentType returns the media type of CSV.
+func (c *CSVExportWriter) ContentType() string { return "text/csv; charset=utf-8" }
+
+// Extension returns the file extension of CSV.
+func (c *CSVExportWriter) Extension() string { return ".csv" }
+
+// WriteHeader writes the column names as the first record.
+func (c *CSVExportWriter) WriteHeader(columns []ExportColumn) error {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return c.w.Write(names)
+}
+
+// WriteRow writes a record and flushes every exportFlushEvery rows.
+// Values that a spreadsheet would run as a formula are prefixed with an apostrophe,
+// because names and emails are entered by guests.
+func (c *CSVExportWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeCSVFormula(value)
+	}
+	if err := c.w.Write(escaped); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%exportFlushEvery == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+// Close flushes the remaining records.
+func (c *CSVExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeCSVFormula prefixes values starting with a formula character with an apostrophe.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// xlsxStaticParts are the parts of a workbook with a single sheet, apart from the sheet itself.
+var xlsxStaticParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// XLSXExportWriter writes an export as an Excel workbook with a single sheet.
+// The workbook is a zip archive whose sheet entry is written row by row,
+// so it is streamed like CSV; strings are inline, there is no shared string table.
+type XLSXExportWriter struct {
+	zip     *zip.Writer
+	sheet   io.Writer
+	numeric []bool
+	rows    int
+}
+
+// NewXLSXExportWriter creates a new XLSX export writer.
+func NewXLSXExportWriter(w io.Writer) *XLSXExportWriter {
+	return &XLSXExportWriter{zip: zip.NewWriter(w)}
+}
+
+// ContentType returns the media type of XLSX.
+func (x *XLSXExportWriter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// Extension returns the file extension of XLSX.
+func (x *XLSXExportWriter) Extension() string { return ".xlsx" }
+
+// WriteHeader writes the static parts of the workbook, opens the sheet and writes the column names as the first row.
+func (x *XLSXExportWriter) WriteHeader(columns []ExportColumn) error {
+	for _, part := range xlsxStaticParts {
+		entry, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return err
+		}
+	}
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	if _, err := io.WriteString(x.sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+
+	names := make([]string, len(columns))
+	x.numeric = make([]bool, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+		x.numeric[i] = column.Numeric
+	}
+	return x.writeRow(names, nil)
+}
+
+// WriteRow writes a row; values of numeric columns are written as numbers unless they are empty.
+func (x *XLSXExportWriter) WriteRow(values []string) error {
+	return x.writeRow(values, x.numeric)
+}
+
+// writeRow writes the cells of a row.
+func (x *XLSXExportWriter) writeRow(values []string, numeric []bool) error {
+	if x.sheet == nil {
+		return errors.New("xlsx export: WriteHeader must be called first")
+	}
+	x.rows++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		if i < len(numeric) && numeric[i] && value != "" {
+			b.WriteString(`<c><v>`)
+			_ = xml.EscapeText(&b, []byte(value))
+			b.WriteString(`</v></c>`)
+			continue
+		}
+		b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(&b, []byte(value))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+// Close completes the sheet and the archive.
+func (x *XLSXExportWriter) Close() error {
+	if x.sheet != nil {
+		if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
@@ -0,0 +1,74 @@
+package inbound_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// CSVExportWriter Tests
+// ============================================================================
+
+func Test_CSVExportWriter_Should_Escape_Formulas(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	export := inbound.NewCSVExportWriter(&buf)
+
+	// Act
+	_ = export.WriteHeader([]inbound.ExportColumn{{Name: "name"}, {Name: "amount", Numeric: true}})
+	_ = export.WriteRow([]string{"=HYPERLINK(\"x\")", "120.50"})
+	err := export.Close()
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "csv must match", buf.String(), "name,amount\n\"'=HYPERLINK(\"\"x\"\")\",120.50\n")
+}
+
+// ============================================================================
+// XLSXExportWriter Tests
+// ============================================================================
+
+func Test_XLSXExportWriter_Should_Write_Workbook_With_Numeric_Cells(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	export := inbound.NewXLSXExportWriter(&buf)
+
+	// Act
+	_ = export.WriteHeader([]inbound.ExportColumn{{Name: "name"}, {Name: "amount", Numeric: true}})
+	_ = export.WriteRow([]string{"Jane <Doe>", "120.50"})
+	err := export.Close()
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.That(t, "archive must be readable", err, nil)
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		rc, _ := file.Open()
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		parts[file.Name] = string(data)
+	}
+	_, ok := parts["[Content_Types].xml"]
+	assert.That(t, "content types must exist", ok, true)
+	_, ok = parts["xl/workbook.xml"]
+	assert.That(t, "workbook must exist", ok, true)
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.That(t, "sheet must contain the escaped name", strings.Contains(sheet, "Jane &lt;Doe&gt;"), true)
+	assert.That(t, "sheet must contain the numeric amount", strings.Contains(sheet, "<c><v>120.50</v></c>"), true)
+	assert.That(t, "sheet must be complete", strings.HasSuffix(sheet, "</sheetData></worksheet>"), true)
+}
+
+func Test_NewExportWriter_With_Unknown_Format_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := inbound.NewExportWriter("pdf", io.Discard)
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
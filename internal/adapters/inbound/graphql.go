@@ -0,0 +1,486 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// graphQLMaxDepth limits the nesting of a query, including the fields of its fragments,
+// so a client cannot make the server resolve arbitrarily deep selections.
+const graphQLMaxDepth = 10
+
+// graphQLMaxFields limits the fields of a query with its fragments expanded, so a few fragments
+// that spread each other twice cannot make the server resolve exponentially many fields.
+const graphQLMaxFields = 1000
+
+// graphQLMaxTokens limits the tokens of a query document, so parsing a huge document stops early.
+const graphQLMaxTokens = 10000
+
+// GraphQLResolver resolves a field of a source object; the source of root fields is nil.
+// Objects are returned as values the fields of their type resolve, lists as slices,
+// scalars as strings, ints, floats or bools; nil is null.
+type GraphQLResolver func(ctx context.Context, source any, args map[string]any) (any, error)
+
+// GraphQLArgument describes an argument of a field with its SDL type, e.g. "String!".
+type GraphQLArgument struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// GraphQLField describes a field of an object type.
+// Object is the type of the value, or of the list elements, for object fields and nil for scalars.
+type GraphQLField struct {
+	Name        string
+	Type        string // SDL type, e.g. "[Reservation!]!"
+	Description string
+	Args        []GraphQLArgument
+	Object      *GraphQLObject
+	Resolve     GraphQLResolver
+}
+
+// GraphQLObject describes an object type with its fields in SDL order.
+type GraphQLObject struct {
+	Name        string
+	Description string
+	Fields      []*GraphQLField
+}
+
+// field returns the field with the name, or nil.
+func (o *GraphQLObject) field(name string) *GraphQLField {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// GraphQLSchema is a read-only GraphQL schema: a query type whose fields are resolved by Go functions.
+// It supports queries with arguments, variables, aliases, fragments, @include and @skip and __typename;
+// there are no mutations, subscriptions, interfaces or introspection, the SDL is published instead.
+// Queries are parsed and validated against the SDL by gqlparser and executed by the resolvers.
+type GraphQLSchema struct {
+	Query *GraphQLObject
+
+	once   sync.Once
+	schema *ast.Schema // the SDL loaded on the first query
+	err    error
+}
+
+// GraphQLRequest is the body of a GraphQL request.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQL response. Path names the field that failed, if any,
+// and Locations the place in the query of an invalid one;
+// the code extension classifies it like the errors of MCP tools (e.g. FORBIDDEN, NOT_FOUND).
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// GraphQLLocation is a line and column of the query, both starting at 1.
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLResponse is the body of a GraphQL response.
+// Data is nil if the request could not be executed, e.g. because the query is invalid.
+type GraphQLResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// Execute parses, validates and executes the request.
+// Invalid requests return only errors; a field that fails is null and reported with its path,
+// so the other fields are still returned.
+func (s *GraphQLSchema) Execute(ctx context.Context, req GraphQLRequest) GraphQLResponse {
+	schema, err := s.loadSchema()
+	if err != nil {
+		return GraphQLResponse{Errors: []GraphQLError{{Message: err.Error(), Extensions: map[string]any{"code": MCPErrorInternal}}}}
+	}
+	doc, err := parser.ParseQueryWithTokenLimit(&ast.Source{Input: req.Query}, graphQLMaxTokens)
+	if err != nil {
+		return graphQLRequestError(err)
+	}
+	// The limits are checked before the validation, which would otherwise walk cyclic fragments.
+	fragments := make(map[string]*ast.FragmentDefinition, len(doc.Fragments))
+	for _, f := range doc.Fragments {
+		fragments[f.Name] = f
+	}
+	if err := checkGraphQLLimits(doc, fragments); err != nil {
+		return graphQLRequestError(err)
+	}
+	if errs := validator.ValidateWithRules(schema, doc, nil); len(errs) > 0 {
+		resp := GraphQLResponse{}
+		for _, err := range errs {
+			resp.Errors = append(resp.Errors, graphQLRequestError(err).Errors...)
+		}
+		return resp
+	}
+	op, err := graphQLOperation(doc, req.OperationName)
+	if err != nil {
+		return graphQLRequestError(err)
+	}
+	vars, err := validator.VariableValues(schema, op, req.Variables)
+	if err != nil {
+		return graphQLRequestError(err)
+	}
+
+	e := &graphQLExecutor{ctx: context.WithValue(ctx, graphQLCacheKey{}, map[string]any{}), fragments: fragments, vars: vars}
+	data := e.selectionSet(s.Query, nil, op.SelectionSet, nil)
+	return GraphQLResponse{Data: data, Errors: e.errors}
+}
+
+// graphQLRequestError returns the response of a request that could not be executed,
+// with the location in the query of errors reported by gqlparser.
+func graphQLRequestError(err error) GraphQLResponse {
+	gqlErr := GraphQLError{Message: err.Error(), Extensions: map[string]any{"code": MCPErrorValidation}}
+	var parsed *gqlerror.Error
+	if errors.As(err, &parsed) {
+		gqlErr.Message = parsed.Message
+		for _, l := range parsed.Locations {
+			gqlErr.Locations = append(gqlErr.Locations, GraphQLLocation{Line: l.Line, Column: l.Column})
+		}
+	}
+	return GraphQLResponse{Errors: []GraphQLError{gqlErr}}
+}
+
+// loadSchema returns the SDL loaded for the validation of queries; it is loaded once.
+func (s *GraphQLSchema) loadSchema() (*ast.Schema, error) {
+	s.once.Do(func() {
+		s.schema, s.err = gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: s.SDL()})
+		if s.err != nil {
+			s.err = fmt.Errorf("invalid schema: %w", s.err)
+		}
+	})
+	return s.schema, s.err
+}
+
+// graphQLOperation returns the operation to execute: the one named, or the only one of the document.
+func graphQLOperation(doc *ast.QueryDocument, name string) (*ast.OperationDefinition, error) {
+	if name == "" && len(doc.Operations) > 1 {
+		return nil, errors.New("operationName is required for a document with several operations")
+	}
+	op := doc.Operations.ForName(name)
+	if op == nil {
+		return nil, fmt.Errorf("unknown operation %q", name)
+	}
+	if op.Operation != ast.Query {
+		return nil, fmt.Errorf("%s operations are not supported, the API is read-only", op.Operation)
+	}
+	return op, nil
+}
+
+// SDL returns the schema in the GraphQL schema definition language, for the clients' code generators.
+func (s *GraphQLSchema) SDL() string {
+	var b strings.Builder
+	seen := map[string]bool{}
+	var write func(o *GraphQLObject)
+	write = func(o *GraphQLObject) {
+		if seen[o.Name] {
+			return
+		}
+		seen[o.Name] = true
+		if o.Description != "" {
+			fmt.Fprintf(&b, "%q\n", o.Description)
+		}
+		fmt.Fprintf(&b, "type %s {\n", o.Name)
+		for _, f := range o.Fields {
+			if f.Description != "" {
+				fmt.Fprintf(&b, "  %q\n", f.Description)
+			}
+			b.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				args := make([]string, len(f.Args))
+				for i, a := range f.Args {
+					args[i] = a.Name + ": " + a.Type
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + f.Type + "\n")
+		}
+		b.WriteString("}\n\n")
+		for _, f := range o.Fields {
+			if f.Object != nil {
+				write(f.Object)
+			}
+		}
+	}
+	write(s.Query)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// graphQLCacheKey is the context key of the values cached for one request, see graphQLCached.
+type graphQLCacheKey struct{}
+
+// graphQLCached returns the value of the key loaded once per request, e.g. all payments
+// for the payments of every reservation in a list. Fields are resolved one after another,
+// so the cache needs no lock.
+func graphQLCached[T any](ctx context.Context, key string, load func() (T, error)) (T, error) {
+	cache, ok := ctx.Value(graphQLCacheKey{}).(map[string]any)
+	if !ok {
+		return load()
+	}
+	if value, ok := cache[key].(T); ok {
+		return value, nil
+	}
+	value, err := load()
+	if err == nil {
+		cache[key] = value
+	}
+	return value, err
+}
+
+// ============================================================================
+// Execution
+// ============================================================================
+
+// graphQLMap is a JSON object that keeps the order of the selected fields.
+type graphQLMap []graphQLEntry
+
+// graphQLEntry is a field of a graphQLMap.
+type graphQLEntry struct {
+	key   string
+	value any
+}
+
+// MarshalJSON writes the fields in selection order.
+func (m graphQLMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, entry := range m {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(entry.key)
+		b.Write(key)
+		b.WriteByte(':')
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// graphQLExecutor executes an operation and collects the errors of its fields.
+type graphQLExecutor struct {
+	ctx       context.Context
+	fragments map[string]*ast.FragmentDefinition
+	vars      map[string]any
+	errors    []GraphQLError
+}
+
+// selectionSet resolves the selected fields of the object.
+func (e *graphQLExecutor) selectionSet(object *GraphQLObject, source any, selections ast.SelectionSet, path []any) graphQLMap {
+	fields := e.collectFields(selections, nil)
+	result := make(graphQLMap, 0, len(fields))
+	for _, sel := range fields {
+		key := sel.Alias
+		fieldPath := append(slices.Clone(path), key)
+		if sel.Name == "__typename" {
+			result = append(result, graphQLEntry{key, object.Name})
+			continue
+		}
+		field := object.field(sel.Name)
+		value, err := e.resolve(field, sel, source)
+		if err != nil {
+			e.fail(err, fieldPath)
+			result = append(result, graphQLEntry{key, nil})
+			continue
+		}
+		result = append(result, graphQLEntry{key, e.complete(field, sel, value, fieldPath)})
+	}
+	return result
+}
+
+// resolve resolves the field of the source with the arguments of the selection.
+func (e *graphQLExecutor) resolve(field *GraphQLField, sel *ast.Field, source any) (any, error) {
+	args := make(map[string]any, len(sel.Arguments))
+	for _, arg := range sel.Arguments {
+		value, err := arg.Value.Value(e.vars)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidGraphQLArgument, arg.Name, err)
+		}
+		args[arg.Name] = value
+	}
+	return field.Resolve(e.ctx, source, args)
+}
+
+// complete resolves the selections of an object or the elements of a list.
+func (e *graphQLExecutor) complete(field *GraphQLField, sel *ast.Field, value any, path []any) any {
+	v := reflect.ValueOf(value)
+	if value == nil || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Slice) && v.IsNil() {
+		return nil
+	}
+	if v.Kind() == reflect.Slice {
+		list := make([]any, v.Len())
+		for i := range list {
+			list[i] = e.complete(field, sel, v.Index(i).Interface(), append(slices.Clone(path), i))
+		}
+		return list
+	}
+	if field.Object == nil {
+		return value
+	}
+	return e.selectionSet(field.Object, value, sel.SelectionSet, path)
+}
+
+// fail records the error of the field at the path.
+func (e *graphQLExecutor) fail(err error, path []any) {
+	e.errors = append(e.errors, GraphQLError{
+		Message:    err.Error(),
+		Path:       path,
+		Extensions: map[string]any{"code": classifyToolError(err)},
+	})
+}
+
+// collectFields returns the fields of the selections with the fragments expanded and
+// the fields skipped by @include or @skip left out. Fields of the same response key are merged.
+// The expanded fields are bounded by graphQLMaxFields, which checkGraphQLLimits enforced.
+func (e *graphQLExecutor) collectFields(selections ast.SelectionSet, fields []*ast.Field) []*ast.Field {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *ast.FragmentSpread:
+			if e.included(sel.Directives) {
+				fields = e.collectFields(e.fragments[sel.Name].SelectionSet, fields)
+			}
+		case *ast.InlineFragment:
+			if e.included(sel.Directives) {
+				fields = e.collectFields(sel.SelectionSet, fields)
+			}
+		case *ast.Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			i := slices.IndexFunc(fields, func(f *ast.Field) bool { return f.Alias == sel.Alias })
+			if i < 0 {
+				fields = append(fields, sel)
+				continue
+			}
+			merged := *fields[i]
+			merged.SelectionSet = append(slices.Clone(merged.SelectionSet), sel.SelectionSet...)
+			fields[i] = &merged
+		}
+	}
+	return fields
+}
+
+// included evaluates the @include and @skip directives.
+func (e *graphQLExecutor) included(directives ast.DirectiveList) bool {
+	for _, d := range directives {
+		var condition any
+		if arg := d.Arguments.ForName("if"); arg != nil {
+			condition, _ = arg.Value.Value(e.vars)
+		}
+		b, _ := condition.(bool)
+		if (d.Name == "include" && !b) || (d.Name == "skip" && b) {
+			return false
+		}
+	}
+	return true
+}
+
+// ============================================================================
+// Limits
+// ============================================================================
+
+// graphQLShape is the size of a selection set with its fragments expanded:
+// the fields it resolves and the levels they are nested in.
+type graphQLShape struct {
+	fields int
+	levels int
+}
+
+// checkGraphQLLimits checks the depth and the number of fields of every operation with the fragments
+// expanded. Each fragment is measured once and its shape is reused for all of its spreads, so the check
+// is linear in the document, while the expanded query may grow exponentially. Cyclic spreads are refused.
+func checkGraphQLLimits(doc *ast.QueryDocument, fragments map[string]*ast.FragmentDefinition) error {
+	shapes := map[string]graphQLShape{}
+	measuring := map[string]bool{}
+	var measure func(selections ast.SelectionSet) (graphQLShape, error)
+	measure = func(selections ast.SelectionSet) (graphQLShape, error) {
+		shape := graphQLShape{levels: 1}
+		for _, sel := range selections {
+			var inner graphQLShape
+			switch sel := sel.(type) {
+			case *ast.Field:
+				inner.fields = 1
+				if len(sel.SelectionSet) > 0 {
+					child, err := measure(sel.SelectionSet)
+					if err != nil {
+						return graphQLShape{}, err
+					}
+					inner.fields += child.fields
+					inner.levels = 1 + child.levels
+				}
+			case *ast.InlineFragment:
+				child, err := measure(sel.SelectionSet)
+				if err != nil {
+					return graphQLShape{}, err
+				}
+				inner = child
+			case *ast.FragmentSpread:
+				fragment, ok := fragments[sel.Name]
+				if !ok {
+					continue // reported by the validation
+				}
+				if measuring[sel.Name] {
+					return graphQLShape{}, fmt.Errorf("fragment %q spreads itself", sel.Name)
+				}
+				child, ok := shapes[sel.Name]
+				if !ok {
+					measuring[sel.Name] = true
+					var err error
+					child, err = measure(fragment.SelectionSet)
+					if err != nil {
+						return graphQLShape{}, err
+					}
+					measuring[sel.Name] = false
+					shapes[sel.Name] = child
+				}
+				inner = child
+			}
+			// The count saturates above the limit, so it cannot overflow.
+			shape.fields = min(shape.fields+inner.fields, graphQLMaxFields+1)
+			shape.levels = max(shape.levels, inner.levels)
+		}
+		return shape, nil
+	}
+	for _, op := range doc.Operations {
+		shape, err := measure(op.SelectionSet)
+		if err != nil {
+			return err
+		}
+		if shape.levels > graphQLMaxDepth {
+			return fmt.Errorf("query is nested deeper than %d levels", graphQLMaxDepth)
+		}
+		if shape.fields > graphQLMaxFields {
+			return fmt.Errorf("query selects more than %d fields with its fragments expanded", graphQLMaxFields)
+		}
+	}
+	return nil
+}
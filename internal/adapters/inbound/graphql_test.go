@@ -0,0 +1,155 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createGraphQLTestSchema returns a schema of books: books(genre) with title and author { name },
+// and failing, which always fails.
+func createGraphQLTestSchema() *inbound.GraphQLSchema {
+	type book struct{ title, genre, author string }
+	books := []book{{"Dune", "scifi", "Herbert"}, {"Emma", "classic", "Austen"}}
+	author := &inbound.GraphQLObject{Name: "Author", Fields: []*inbound.GraphQLField{
+		{Name: "name", Type: "String!", Resolve: func(_ context.Context, source any, _ map[string]any) (any, error) { return source, nil }},
+	}}
+	bookType := &inbound.GraphQLObject{Name: "Book", Fields: []*inbound.GraphQLField{
+		{Name: "title", Type: "String!", Resolve: func(_ context.Context, source any, _ map[string]any) (any, error) { return source.(book).title, nil }},
+		{Name: "author", Type: "Author!", Object: author, Resolve: func(_ context.Context, source any, _ map[string]any) (any, error) { return source.(book).author, nil }},
+	}}
+	return &inbound.GraphQLSchema{Query: &inbound.GraphQLObject{Name: "Query", Fields: []*inbound.GraphQLField{
+		{Name: "books", Type: "[Book!]!", Object: bookType, Args: []inbound.GraphQLArgument{{Name: "genre", Type: "String"}},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				genre, _ := args["genre"].(string)
+				var result []book
+				for _, b := range books {
+					if genre == "" || b.genre == genre {
+						result = append(result, b)
+					}
+				}
+				return result, nil
+			}},
+		{Name: "failing", Type: "String", Resolve: func(context.Context, any, map[string]any) (any, error) { return nil, errors.New("boom") }},
+	}}}
+}
+
+func executeGraphQL(query string, vars map[string]any) (string, inbound.GraphQLResponse) {
+	resp := createGraphQLTestSchema().Execute(context.Background(), inbound.GraphQLRequest{Query: query, Variables: vars})
+	data, _ := json.Marshal(resp.Data)
+	return string(data), resp
+}
+
+// ============================================================================
+// GraphQLSchema Tests
+// ============================================================================
+
+func Test_GraphQLSchema_Execute_Should_Resolve_Aliases_Fragments_And_Variables_In_Order(t *testing.T) {
+	// Arrange
+	query := `query Books($genre: String, $withAuthor: Boolean!) {
+		classics: books(genre: $genre) { ...titles author @include(if: $withAuthor) { name } }
+		all: books { __typename title }
+	}
+	fragment titles on Book { title }`
+
+	// Act
+	data, resp := executeGraphQL(query, map[string]any{"genre": "classic", "withAuthor": true})
+
+	// Assert
+	assert.That(t, "errors must be empty", len(resp.Errors), 0)
+	assert.That(t, "data must keep the selection order", data,
+		`{"classics":[{"title":"Emma","author":{"name":"Austen"}}],"all":[{"__typename":"Book","title":"Dune"},{"__typename":"Book","title":"Emma"}]}`)
+}
+
+func Test_GraphQLSchema_Execute_With_Failing_Field_Should_Return_Other_Fields(t *testing.T) {
+	// Arrange
+	query := `{ failing books { title } }`
+
+	// Act
+	data, resp := executeGraphQL(query, nil)
+
+	// Assert
+	assert.That(t, "data must contain the other fields", data, `{"failing":null,"books":[{"title":"Dune"},{"title":"Emma"}]}`)
+	assert.That(t, "one error must be returned", len(resp.Errors), 1)
+	assert.That(t, "error must name the field", resp.Errors[0].Path, []any{"failing"})
+}
+
+func Test_GraphQLSchema_Execute_With_Invalid_Query_Should_Return_Error_Without_Data(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":          `{ books { isbn } }`,
+		"missing selection":      `{ books }`,
+		"unknown argument":       `{ books(year: 1815) { title } }`,
+		"undeclared variable":    `{ books(genre: $genre) { title } }`,
+		"mutation":               `mutation { books { title } }`,
+		"syntax error":           `{ books { title }`,
+		"fragment on other type": `{ books { ...names } } fragment names on Author { name }`,
+		"too deep via fragment":  `{ books { ...loop } } fragment loop on Book { title ...loop }`,
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, resp := executeGraphQL(query, nil)
+
+			// Assert
+			assert.That(t, "data must be nil", resp.Data, nil)
+			assert.That(t, "one error must be returned", len(resp.Errors), 1)
+		})
+	}
+}
+
+func Test_GraphQLSchema_Execute_With_Exponential_Fragments_Should_Return_Error(t *testing.T) {
+	// Arrange
+	var b strings.Builder
+	b.WriteString("{ books { ...f30 } } fragment f0 on Book { title }")
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&b, " fragment f%d on Book { ...f%d ...f%d }", i, i-1, i-1)
+	}
+
+	// Act
+	_, resp := executeGraphQL(b.String(), nil)
+
+	// Assert
+	assert.That(t, "data must be nil", resp.Data, nil)
+	assert.That(t, "one error must be returned", len(resp.Errors), 1)
+	assert.That(t, "error must name the limit", resp.Errors[0].Message, "query selects more than 1000 fields with its fragments expanded")
+}
+
+func Test_GraphQLSchema_Execute_With_Invalid_Query_Should_Return_Location(t *testing.T) {
+	// Act
+	_, resp := executeGraphQL("{\n  books { isbn }\n}", nil)
+
+	// Assert
+	assert.That(t, "one error must be returned", len(resp.Errors), 1)
+	assert.That(t, "error must point at the field", resp.Errors[0].Locations, []inbound.GraphQLLocation{{Line: 2, Column: 11}})
+}
+
+func Test_GraphQLSchema_SDL_Should_Describe_All_Types(t *testing.T) {
+	// Act
+	sdl := createGraphQLTestSchema().SDL()
+
+	// Assert
+	assert.That(t, "sdl must describe the types", sdl, `type Query {
+  books(genre: String): [Book!]!
+  failing: String
+}
+
+type Book {
+  title: String!
+  author: Author!
+}
+
+type Author {
+  name: String!
+}
+`)
+}
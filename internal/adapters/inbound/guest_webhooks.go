@@ -0,0 +1,41 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// SubscribeGuestWebhookEvents subscribes to the reservation lifecycle events and sends them to the
+// endpoints of the guests' own automations. The receiving guest is the current owner of the
+// reservation as stored, not the guest named in the event, so guests only get events of their own reservations.
+func SubscribeGuestWebhookEvents(ctx context.Context, dispatcher messaging.Dispatcher, reservationService *reservation.Service, webhookService *webhook.Service) error {
+	for _, topic := range webhook.GuestTopics {
+		fn := func(msg messaging.Message) (messaging.MessageState, error) {
+			var evt struct {
+				ReservationID shared.ReservationID `json:"reservation_id"`
+			}
+			if err := json.Unmarshal(msg.Data, &evt); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			res, err := reservationService.GetReservation(ctx, evt.ReservationID)
+			if err != nil {
+				return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+			}
+			if _, err := webhookService.DeliverGuestEvent(ctx, string(res.GuestID), webhookEventID(msg), msg.Topic, msg.Data); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			return messaging.MessageStateCompleted, nil
+		}
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(fn)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// SubscribeGuestWebhookEvents Tests
+// ============================================================================
+
+func Test_SubscribeGuestWebhookEvents_Should_Deliver_To_Owner_Of_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createTestGuestWebhookService(http.StatusOK)
+	ctx := context.Background()
+	owner, _ := svc.RegisterGuestEndpoint(ctx, "gep-1", "owner-subject", "https://hooks.example.com/owner", "secret", nil)
+	_, _ = svc.PingGuestEndpoint(ctx, "d-1", "owner-subject", owner.ID)
+	other, _ := svc.RegisterGuestEndpoint(ctx, "gep-2", "other-subject", "https://hooks.example.com/other", "secret", nil)
+	_, _ = svc.PingGuestEndpoint(ctx, "d-2", "other-subject", other.ID)
+	dispatcher := messaging.NewInternalDispatcher()
+	_ = inbound.SubscribeGuestWebhookEvents(ctx, dispatcher, createFormTestService(repo), svc)
+
+	// Act
+	// The guest named in the event is ignored; the stored owner receives it.
+	err := dispatcher.Publish(ctx, messaging.NewMessage("reservation.cancelled", []byte(`{"reservation_id":"res-001","guest_id":"other-subject","reason":"test"}`)))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	ownerDeliveries, _ := svc.Deliveries(ctx, owner.ID, 0)
+	otherDeliveries, _ := svc.Deliveries(ctx, other.ID, 0)
+	assert.That(t, "owner must receive ping and event", len(ownerDeliveries), 2)
+	assert.That(t, "other guest must only receive the ping", len(otherDeliveries), 1)
+	assert.That(t, "event must be the cancellation", ownerDeliveries[0].Topic, "reservation.cancelled")
+}
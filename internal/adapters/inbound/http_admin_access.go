@@ -0,0 +1,131 @@
+package inbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the access to the admin endpoints (/admin, /internal, /debug/pprof).
+// Automation presents the admin token; administrators present their OIDC token or sign in,
+// and need shared.PermissionAdmin from their roles or their staff account.
+// Signed-in administrators send the CSRF token of their session with every change,
+// because the browser sends the session cookie with cross-site form posts as well.
+
+// csrfKey signs the CSRF tokens. Sessions live in the memory of the process, so a key per process suffices.
+var csrfKey = security.GenerateKey()
+
+// The CSRF token is sent in a form field by the consoles and in a header by scripts.
+const (
+	csrfField  = "csrf_token"
+	csrfHeader = "X-CSRF-Token"
+)
+
+// CSRFToken returns the CSRF token of the session of the request or an empty string for anonymous requests.
+// It must be wrapped by web.WithAuth.
+func CSRFToken(r *http.Request) string {
+	sessionID, _ := r.Context().Value(web.ContextSessionID).(string)
+	if sessionID == "" {
+		return ""
+	}
+	h := hmac.New(sha256.New, csrfKey[:])
+	h.Write([]byte(sessionID))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// WithCSRF rejects changes (every method but GET, HEAD and OPTIONS) that do not carry the CSRF token
+// of the session with 403 Forbidden. The token is read from the X-CSRF-Token header or, for form posts,
+// the csrf_token field; other bodies are left unread for the handler. It must be wrapped by web.WithAuth.
+func WithCSRF(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next(w, r)
+			return
+		}
+		token := r.Header.Get(csrfHeader)
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); token == "" && mediaType == "application/x-www-form-urlencoded" {
+			token = r.PostFormValue(csrfField)
+		}
+		expected := CSRFToken(r)
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// WithSessionPrincipal stores the principal of a signed-in user, so the role and staff directory
+// middlewares resolve its permissions like those of a token. Sessions carry no roles, so the role
+// is derived from the issuer that signed the user in (see WithUIPermission).
+// Anonymous users and issuers the token verifier does not trust get no principal.
+// It must be wrapped by web.WithAuth.
+func WithSessionPrincipal(issuers PrincipalTypeResolver, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		issuer, _ := ctx.Value(web.ContextIssuer).(string)
+		principalType, ok := issuers.PrincipalType(issuer)
+		if issuer == "" || !ok {
+			next(w, r)
+			return
+		}
+		email, _ := ctx.Value(web.ContextEmail).(string)
+		subject, _ := ctx.Value(web.ContextSubject).(string)
+		next(w, r.WithContext(shared.ContextWithPrincipal(ctx, shared.Principal{
+			Type:    principalType,
+			Issuer:  issuer,
+			Subject: subject,
+			Email:   email,
+			Roles:   []shared.Role{sessionRole(principalType)},
+		})))
+	}
+}
+
+// WithAdminPermission rejects requests without a principal with 401 Unauthorized and requests whose
+// principal lacks shared.PermissionAdmin with 403 Forbidden. Unlike WithPermission, principals that are
+// not restricted by scopes do not pass: the admin permission is only granted explicitly.
+func WithAdminPermission(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := shared.PrincipalFromContext(r.Context())
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		restricted := principal.RoleBased || principal.Managed || principal.Type == shared.PrincipalService
+		if !restricted || !principal.HasScope(shared.PermissionAdmin) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// WithAdminAccess allows requests carrying the admin token as Bearer token, e.g. of automation,
+// and administrators with shared.PermissionAdmin: other Bearer tokens are authenticated by bearer,
+// requests without one by session. Both must store the principal and resolve its permissions;
+// session must also check the CSRF token (WithCSRF).
+func WithAdminAccess(token string, bearer, session Middleware, next http.HandlerFunc) http.HandlerFunc {
+	byBearer := bearer(WithAdminPermission(next))
+	bySession := session(WithAdminPermission(next))
+	return func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case ok && token != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1:
+			next(w, r)
+		case ok:
+			byBearer(w, r)
+		default:
+			bySession(w, r)
+		}
+	}
+}
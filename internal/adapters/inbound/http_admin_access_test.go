@@ -0,0 +1,164 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Admin Access Test Helpers
+// ============================================================================
+
+// testAdminPermissions lets admins and staff use the admin endpoints, guests only the UI.
+func testAdminPermissions() *inbound.RoleAuthorizer {
+	return inbound.NewRoleAuthorizer(shared.RolePermissions{
+		shared.RoleAdmin: {shared.PermissionAdmin},
+		shared.RoleStaff: {shared.PermissionAdmin},
+		shared.RoleGuest: {shared.PermissionUI},
+	})
+}
+
+// testAdminAccess returns the admin middleware with a token, roles for Bearer tokens and sessions with CSRF tokens.
+// The Bearer middleware keeps the principal of the request, as WithTokenAuth would have stored it.
+func testAdminAccess(next http.HandlerFunc) http.HandlerFunc {
+	authorizer := testAdminPermissions()
+	bearer := func(next http.HandlerFunc) http.HandlerFunc { return inbound.WithRoles(authorizer, next) }
+	session := func(next http.HandlerFunc) http.HandlerFunc {
+		return inbound.WithSessionPrincipal(mockIssuers{}, inbound.WithRoles(authorizer, inbound.WithCSRF(next)))
+	}
+	return inbound.WithAdminAccess("secret", bearer, session, next)
+}
+
+// adminSessionRequest returns a request of a user signed in by the issuer with the session.
+func adminSessionRequest(method, issuer, sessionID string, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, "/admin/webhooks", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx := context.WithValue(req.Context(), web.ContextIssuer, issuer)
+	ctx = context.WithValue(ctx, web.ContextSessionID, sessionID)
+	return req.WithContext(ctx)
+}
+
+// ============================================================================
+// WithAdminAccess Tests
+// ============================================================================
+
+func Test_WithAdminAccess_With_Admin_Token_Should_Call_Next(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "handler must be called", called, true)
+}
+
+func Test_WithAdminAccess_With_Token_Of_Admin_Role_Should_Call_Next(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	req := requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Roles: []shared.Role{shared.RoleAdmin}})
+	req.Header.Set("Authorization", "Bearer oidc-token")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "handler must be called", called, true)
+}
+
+func Test_WithAdminAccess_With_Token_Without_Admin_Permission_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	guest := requestWithPrincipal(shared.Principal{Type: shared.PrincipalGuest, Roles: []shared.Role{shared.RoleGuest}})
+	guest.Header.Set("Authorization", "Bearer oidc-token")
+	unknown := requestWithPrincipal(shared.Principal{Type: shared.PrincipalGuest, Roles: []shared.Role{"offline_access"}})
+	unknown.Header.Set("Authorization", "Bearer oidc-token")
+	guestRec, unknownRec := httptest.NewRecorder(), httptest.NewRecorder()
+
+	// Act
+	handler(guestRec, guest)
+	handler(unknownRec, unknown)
+
+	// Assert
+	assert.That(t, "role without the permission must be rejected", guestRec.Code, http.StatusForbidden)
+	assert.That(t, "unknown role must get the role of its issuer", unknownRec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
+
+func Test_WithAdminAccess_Without_Credentials_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) {})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, adminSessionRequest(http.MethodGet, "", "", nil))
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+	assert.That(t, "challenge must name the admin realm", rec.Header().Get("WWW-Authenticate"), `Bearer realm="admin"`)
+}
+
+func Test_WithAdminAccess_With_Session_And_CSRF_Token_Should_Call_Next(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	token := inbound.CSRFToken(adminSessionRequest(http.MethodGet, "https://sso.example.com/realms/staff", "session-1", nil))
+	req := adminSessionRequest(http.MethodPost, "https://sso.example.com/realms/staff", "session-1", url.Values{"csrf_token": {token}})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "handler must be called", called, true)
+}
+
+func Test_WithAdminAccess_With_Session_Without_CSRF_Token_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	otherSession := inbound.CSRFToken(adminSessionRequest(http.MethodGet, "https://sso.example.com/realms/staff", "session-2", nil))
+	missing, foreign := httptest.NewRecorder(), httptest.NewRecorder()
+
+	// Act
+	handler(missing, adminSessionRequest(http.MethodPost, "https://sso.example.com/realms/staff", "session-1", nil))
+	handler(foreign, adminSessionRequest(http.MethodPost, "https://sso.example.com/realms/staff", "session-1", url.Values{"csrf_token": {otherSession}}))
+
+	// Assert
+	assert.That(t, "missing token must be rejected", missing.Code, http.StatusForbidden)
+	assert.That(t, "token of another session must be rejected", foreign.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
+
+func Test_WithAdminAccess_With_Guest_Session_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	handler := testAdminAccess(func(w http.ResponseWriter, r *http.Request) { called = true })
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, adminSessionRequest(http.MethodGet, "https://sso.example.com/realms/guests", "session-1", nil))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
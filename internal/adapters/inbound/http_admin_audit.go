@@ -0,0 +1,117 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Page sizes of the audit log query.
+const (
+	auditDefaultLimit = 100
+	auditMaxLimit     = 1000
+)
+
+// AuditTrail queries the audit log of the state-changing operations.
+type AuditTrail interface {
+	Entries(ctx context.Context, filter shared.AuditFilter) ([]shared.AuditEntry, error)
+}
+
+// HttpAuditEntry specifies the JSON of an audit entry.
+type HttpAuditEntry struct {
+	ID        string          `json:"id"`
+	At        string          `json:"at"` // RFC 3339
+	Actor     string          `json:"actor"`
+	Subject   string          `json:"subject,omitempty"`
+	ClientID  string          `json:"client_id,omitempty"`
+	Aggregate string          `json:"aggregate"`
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"` // null for creations
+	After     json.RawMessage `json:"after"`
+}
+
+// HttpAdminAudit returns the audit entries, newest first, as JSON. The query parameters aggregate
+// (reservation, payment or adjustment), entity_id, actor (e.g. staff:frontdesk@example.com), action,
+// from and to (RFC 3339 or YYYY-MM-DD, to is exclusive) filter them; limit (default: 100, max: 1000)
+// caps their number.
+func HttpAdminAudit(trail AuditTrail) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := shared.AuditFilter{
+			Aggregate: query.Get("aggregate"),
+			EntityID:  query.Get("entity_id"),
+			Actor:     query.Get("actor"),
+			Action:    query.Get("action"),
+		}
+		var err error
+		if filter.From, err = parseAuditTime(query.Get("from")); err != nil {
+			http.Error(w, "from must be RFC 3339 or YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		if filter.To, err = parseAuditTime(query.Get("to")); err != nil {
+			http.Error(w, "to must be RFC 3339 or YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		limit, err := optionalUint(query.Get("limit"))
+		if err != nil {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = auditDefaultLimit
+		if limit > 0 {
+			filter.Limit = int(min(limit, auditMaxLimit))
+		}
+
+		entries, err := trail.Entries(r.Context(), filter)
+		if errors.Is(err, shared.ErrInvalidAuditFilter) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to read audit entries", http.StatusInternalServerError)
+			return
+		}
+
+		resp := []HttpAuditEntry{}
+		for _, entry := range entries {
+			resp = append(resp, HttpAuditEntry{
+				ID:        entry.ID,
+				At:        entry.At.UTC().Format(time.RFC3339Nano),
+				Actor:     entry.Actor,
+				Subject:   entry.Subject,
+				ClientID:  entry.ClientID,
+				Aggregate: entry.Aggregate,
+				EntityID:  entry.EntityID,
+				Action:    entry.Action,
+				Before:    orJSONNull(entry.Before),
+				After:     orJSONNull(entry.After),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// parseAuditTime parses an RFC 3339 time or a date, which is midnight UTC; empty is the zero time.
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// orJSONNull returns null for a missing state, since an empty json.RawMessage cannot be encoded.
+func orJSONNull(state json.RawMessage) json.RawMessage {
+	if len(state) == 0 {
+		return json.RawMessage("null")
+	}
+	return state
+}
@@ -0,0 +1,112 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Audit Test Helpers
+// ============================================================================
+
+// mockAuditTrail returns its entries and records the filter of the last query.
+type mockAuditTrail struct {
+	entries []shared.AuditEntry
+	filter  shared.AuditFilter
+}
+
+func (m *mockAuditTrail) Entries(ctx context.Context, filter shared.AuditFilter) ([]shared.AuditEntry, error) {
+	m.filter = filter
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return m.entries, nil
+}
+
+// createTestAuditTrail returns a trail with the cancellation of a reservation by a staff member.
+func createTestAuditTrail() *mockAuditTrail {
+	return &mockAuditTrail{entries: []shared.AuditEntry{{
+		ID:        "audit-1",
+		At:        time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC),
+		Actor:     "staff:frontdesk@example.com",
+		Subject:   "sub-1",
+		Aggregate: "reservation",
+		EntityID:  "res-001",
+		Action:    shared.AuditCancel,
+		Before:    json.RawMessage(`{"Status":"confirmed"}`),
+		After:     json.RawMessage(`{"Status":"cancelled"}`),
+	}}}
+}
+
+// ============================================================================
+// HttpAdminAudit Tests
+// ============================================================================
+
+func Test_HttpAdminAudit_Should_Return_Filtered_Entries(t *testing.T) {
+	// Arrange
+	trail := createTestAuditTrail()
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit?aggregate=reservation&entity_id=res-001&actor=staff:frontdesk@example.com&action=cancel&from=2026-07-01&to=2026-07-02T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminAudit(trail)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp []inbound.HttpAuditEntry
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "entry must be returned", len(resp), 1)
+	assert.That(t, "state before must be embedded", string(resp[0].Before), `{"Status":"confirmed"}`)
+	assert.That(t, "filter must be passed", trail.filter, shared.AuditFilter{
+		Aggregate: "reservation",
+		EntityID:  "res-001",
+		Actor:     "staff:frontdesk@example.com",
+		Action:    shared.AuditCancel,
+		From:      time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC),
+		Limit:     100,
+	})
+}
+
+func Test_HttpAdminAudit_Should_Return_Null_State_Before_Creation(t *testing.T) {
+	// Arrange
+	trail := createTestAuditTrail()
+	trail.entries[0].Action, trail.entries[0].Before = shared.AuditCreate, nil
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminAudit(trail)(rec, httptest.NewRequest(http.MethodGet, "/admin/audit?limit=5000", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var resp []map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "state before must be null", resp[0]["before"], nil)
+	assert.That(t, "limit must be capped", trail.filter.Limit, 1000)
+}
+
+func Test_HttpAdminAudit_Should_Return_400_For_Invalid_Queries(t *testing.T) {
+	for name, query := range map[string]string{
+		"from":     "from=yesterday",
+		"to":       "to=2026-13-01",
+		"limit":    "limit=-1",
+		"reversed": "from=2026-07-02&to=2026-07-01",
+	} {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		inbound.HttpAdminAudit(createTestAuditTrail())(rec, httptest.NewRequest(http.MethodGet, "/admin/audit?"+query, nil))
+
+		// Assert
+		assert.That(t, "status code for invalid "+name+" must be 400", rec.Code, http.StatusBadRequest)
+	}
+}
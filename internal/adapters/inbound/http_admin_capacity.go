@@ -0,0 +1,242 @@
+package inbound
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Limits of the aggregated booking history.
+const (
+	capacityDefaultMonths = 12
+	capacityMaxMonths     = 36
+)
+
+// Size of the occupancy bar charts in SVG user units.
+const (
+	capacityChartHeight = 120
+	capacityBarWidth    = 40
+	capacityBarGap      = 16
+)
+
+// HttpCapacityBucket is the occupancy of a room type on a group of nights, e.g. all Saturdays.
+type HttpCapacityBucket struct {
+	Name            string `json:"name"`
+	Nights          int    `json:"nights"`
+	RoomNights      int    `json:"room_nights"`
+	SoldNights      int    `json:"sold_nights"`
+	Occupancy       int    `json:"occupancy"`          // percent
+	SellOuts        int    `json:"sell_outs"`          // nights on which every room was booked
+	SellOutLeadDays int    `json:"sell_out_lead_days"` // median days between the booking of the last room and the night
+}
+
+// HttpCapacityRoomType is the occupancy of a room type in total, by weekday and by season.
+type HttpCapacityRoomType struct {
+	RoomType string               `json:"room_type"`
+	Rooms    int                  `json:"rooms"`
+	Total    HttpCapacityBucket   `json:"total"`
+	Weekdays []HttpCapacityBucket `json:"weekdays"` // Monday first
+	Seasons  []HttpCapacityBucket `json:"seasons"`  // winter, spring, summer, autumn
+}
+
+// HttpAdminCapacityResponse specifies the JSON body of a capacity report.
+type HttpAdminCapacityResponse struct {
+	From      string                 `json:"from"` // YYYY-MM-DD, first night
+	To        string                 `json:"to"`   // YYYY-MM-DD, exclusive
+	RoomTypes []HttpCapacityRoomType `json:"room_types"`
+}
+
+// CapacityBarView is a bar of an occupancy chart.
+type CapacityBarView struct {
+	X               int
+	Y               int
+	Height          int
+	LabelX          int
+	Label           string
+	Occupancy       int
+	SellOuts        int
+	SellOutLeadDays int
+}
+
+// CapacityChartView represents the charts of a room type for the view.
+type CapacityChartView struct {
+	RoomType        string
+	Rooms           int
+	Occupancy       int
+	SellOuts        int
+	SellOutLeadDays int
+	Weekdays        []CapacityBarView
+	Seasons         []CapacityBarView
+}
+
+// HttpAdminCapacityChartResponse specifies the view data for the capacity planning page.
+type HttpAdminCapacityChartResponse struct {
+	AppName         string
+	Title           string
+	From            string
+	To              string
+	Months          int
+	ChartHeight     int
+	WeekdaysViewBox string
+	SeasonsViewBox  string
+	RoomTypes       []CapacityChartView
+}
+
+// HttpAdminCapacity returns the occupancy of the room types over the last months (query parameter,
+// default 12) by weekday and season, with their sell-outs and lead times, as JSON.
+// The optional property parameter limits the report to the rooms of a property if properties is not nil.
+func HttpAdminCapacity(reservationService *reservation.Service, rates *reservation.Rates, properties *property.Catalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, _, ok := capacityReport(w, r, reservationService, rates, properties)
+		if !ok {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(buildCapacityResponse(report))
+	}
+}
+
+// HttpAdminCapacityChart renders the capacity report as bar charts of the occupancy per room type.
+func HttpAdminCapacityChart(e *templating.Engine, reservationService *reservation.Service, rates *reservation.Rates, properties *property.Catalog) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Capacity Planning"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, months, ok := capacityReport(w, r, reservationService, rates, properties)
+		if !ok {
+			return
+		}
+		data := HttpAdminCapacityChartResponse{
+			AppName:         appName,
+			Title:           title,
+			From:            report.From.Format(time.DateOnly),
+			To:              report.To.AddDate(0, 0, -1).Format(time.DateOnly),
+			Months:          months,
+			ChartHeight:     capacityChartHeight,
+			WeekdaysViewBox: capacityViewBox(7),
+			SeasonsViewBox:  capacityViewBox(len(reservation.CapacitySeasons)),
+		}
+		for _, t := range report.RoomTypes {
+			data.RoomTypes = append(data.RoomTypes, CapacityChartView{
+				RoomType:        string(t.RoomType),
+				Rooms:           t.Rooms,
+				Occupancy:       t.Total.Occupancy,
+				SellOuts:        t.Total.SellOuts,
+				SellOutLeadDays: t.Total.SellOutLeadDays,
+				Weekdays:        capacityBars(t.Weekdays, 3),
+				Seasons:         capacityBars(t.Seasons, 0),
+			})
+		}
+
+		HttpView(e, "admin_capacity", data)(w, r)
+	}
+}
+
+// capacityReport reads the months and property query parameters and builds the report of the nights up to today.
+// It answers the request with an error and returns false if that fails.
+func capacityReport(w http.ResponseWriter, r *http.Request, reservationService *reservation.Service, rates *reservation.Rates, properties *property.Catalog) (*reservation.CapacityReport, int, bool) {
+	months := capacityDefaultMonths
+	if value := r.URL.Query().Get("months"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > capacityMaxMonths {
+			http.Error(w, fmt.Sprintf("months must be between 1 and %d", capacityMaxMonths), http.StatusBadRequest)
+			return nil, 0, false
+		}
+		months = n
+	}
+	roomTypes := rates.RoomTypes()
+	if propertyID := r.URL.Query().Get("property"); propertyID != "" && properties != nil {
+		if _, err := properties.Property(property.PropertyID(propertyID)); err != nil {
+			http.Error(w, "Property not found", http.StatusNotFound)
+			return nil, 0, false
+		}
+		roomTypes = propertyRoomTypes(roomTypes, properties, property.PropertyID(propertyID))
+	}
+	to := time.Now()
+	report, err := reservationService.CapacityReport(r.Context(), roomTypes, to.AddDate(0, -months, 0), to)
+	if err != nil {
+		http.Error(w, "failed to build capacity report", http.StatusInternalServerError)
+		return nil, 0, false
+	}
+	return report, months, true
+}
+
+// propertyRoomTypes limits the room types to the rooms of the property; types without such rooms are left out.
+func propertyRoomTypes(roomTypes []reservation.RoomType, properties *property.Catalog, id property.PropertyID) []reservation.RoomType {
+	var scoped []reservation.RoomType
+	for _, t := range roomTypes {
+		t.RoomIDs = slices.DeleteFunc(slices.Clone(t.RoomIDs), func(room reservation.RoomID) bool {
+			return properties.PropertyOf(property.RoomID(room)) != id
+		})
+		if len(t.RoomIDs) > 0 {
+			scoped = append(scoped, t)
+		}
+	}
+	return scoped
+}
+
+// buildCapacityResponse converts the report to its JSON body.
+func buildCapacityResponse(report *reservation.CapacityReport) HttpAdminCapacityResponse {
+	resp := HttpAdminCapacityResponse{
+		From:      report.From.Format(time.DateOnly),
+		To:        report.To.Format(time.DateOnly),
+		RoomTypes: []HttpCapacityRoomType{},
+	}
+	for _, t := range report.RoomTypes {
+		resp.RoomTypes = append(resp.RoomTypes, HttpCapacityRoomType{
+			RoomType: string(t.RoomType),
+			Rooms:    t.Rooms,
+			Total:    HttpCapacityBucket(t.Total),
+			Weekdays: capacityBuckets(t.Weekdays),
+			Seasons:  capacityBuckets(t.Seasons),
+		})
+	}
+	return resp
+}
+
+// capacityBuckets converts the buckets to their JSON representation.
+func capacityBuckets(buckets []reservation.CapacityBucket) []HttpCapacityBucket {
+	out := make([]HttpCapacityBucket, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, HttpCapacityBucket(b))
+	}
+	return out
+}
+
+// capacityBars lays out a bar per bucket; the y axis spans 0 (bottom) to 100% occupancy (top).
+// Labels are shortened to labelLength characters, e.g. Mon; 0 keeps them.
+func capacityBars(buckets []reservation.CapacityBucket, labelLength int) []CapacityBarView {
+	bars := make([]CapacityBarView, 0, len(buckets))
+	for i, b := range buckets {
+		label := b.Name
+		if labelLength > 0 && len(label) > labelLength {
+			label = label[:labelLength]
+		}
+		height := b.Occupancy * capacityChartHeight / 100
+		x := i * (capacityBarWidth + capacityBarGap)
+		bars = append(bars, CapacityBarView{
+			X:               x,
+			Y:               capacityChartHeight - height,
+			Height:          height,
+			LabelX:          x + capacityBarWidth/2,
+			Label:           label,
+			Occupancy:       b.Occupancy,
+			SellOuts:        b.SellOuts,
+			SellOutLeadDays: b.SellOutLeadDays,
+		})
+	}
+	return bars
+}
+
+// capacityViewBox returns the view box of a chart with the bars and room for the labels below.
+func capacityViewBox(bars int) string {
+	return fmt.Sprintf("0 0 %d %d", bars*(capacityBarWidth+capacityBarGap)-capacityBarGap, capacityChartHeight+20)
+}
@@ -0,0 +1,118 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// createCapacityTestService creates a reservation service in which both standard rooms
+// of createTestRates were booked for the night a week ago, the last one 5 days before.
+func createCapacityTestService(t *testing.T) *reservation.Service {
+	t.Helper()
+	repo := newMockReservationRepository()
+	night := time.Now().AddDate(0, 0, -7)
+	for i, roomID := range []string{"room-101", "room-102"} {
+		id := []string{"res-001", "res-002"}[i]
+		// Reservations cannot be created in the past; move the stay there afterwards.
+		res := createTestReservation(id, "guest@example.com", roomID, time.Now().AddDate(0, 0, 1), time.Now().AddDate(0, 0, 2))
+		res.DateRange = reservation.NewDateRange(night, night.AddDate(0, 0, 1))
+		res.Status = reservation.StatusCompleted
+		res.CreatedAt = night.AddDate(0, 0, -30+25*i)
+		repo.put(res.ID, *res)
+	}
+	return createReservationsTestService(repo)
+}
+
+// ============================================================================
+// HttpAdminCapacity Tests
+// ============================================================================
+
+func Test_HttpAdminCapacity_Should_Return_Report_As_JSON(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCapacity(createCapacityTestService(t), createTestRates(t), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/capacity?months=1", nil))
+
+	// Assert
+	var report inbound.HttpAdminCapacityResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err == nil, true)
+	assert.That(t, "room type must be standard", report.RoomTypes[0].RoomType, "standard")
+	assert.That(t, "one night must be sold out", report.RoomTypes[0].Total.SellOuts, 1)
+	assert.That(t, "lead time must be that of the last room", report.RoomTypes[0].Total.SellOutLeadDays, 5)
+	assert.That(t, "weekdays must start on monday", report.RoomTypes[0].Weekdays[0].Name, "Monday")
+}
+
+func Test_HttpAdminCapacity_With_Invalid_Months_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCapacity(createCapacityTestService(t), createTestRates(t), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/capacity?months=37", nil))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminCapacity_With_Unknown_Property_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCapacity(createCapacityTestService(t), createTestRates(t), createTestProperties(t))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/capacity?property=ham", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpAdminCapacity_With_Property_Should_Report_Its_Rooms_Only(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCapacity(createCapacityTestService(t), createTestRates(t), createTestProperties(t))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/capacity?months=1&property=muc", nil))
+
+	// Assert
+	var report inbound.HttpAdminCapacityResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "standard rooms of other properties must be left out", slices.ContainsFunc(report.RoomTypes, func(rt inbound.HttpCapacityRoomType) bool { return rt.RoomType == "standard" }), false)
+}
+
+// ============================================================================
+// HttpAdminCapacityChart Tests
+// ============================================================================
+
+func Test_HttpAdminCapacityChart_Should_Render_Room_Types(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpAdminCapacityChart(e, createCapacityTestService(t), createTestRates(t), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/capacity/chart", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain room type", strings.Contains(body, "<h2>standard</h2>"), true)
+	assert.That(t, "body must contain sell-outs", strings.Contains(body, `<p class="sell-outs">1</p>`), true)
+	assert.That(t, "body must contain short weekday labels", strings.Contains(body, "<title>Mon</title>"), true)
+}
@@ -0,0 +1,105 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// TestClock is the virtual clock of end-to-end tests in staging, which the jobs and the reservation rules consult.
+// outbound.TestClock implements it.
+type TestClock interface {
+	Now() time.Time
+	Offset() time.Duration
+	Advance(d time.Duration) error
+	Reset()
+}
+
+// HttpTestClock is the JSON document of the test clock and of the jobs run after advancing it.
+type HttpTestClock struct {
+	Now    time.Time   `json:"now"`
+	Offset string      `json:"offset"`
+	Jobs   []JobStatus `json:"jobs,omitempty"`
+}
+
+// HttpAdminAdvanceClockRequest specifies how far to advance the test clock and the jobs to run right after,
+// e.g. {"by": "26h", "run": ["no_show"]}, so a scenario step does not wait for the job intervals.
+type HttpAdminAdvanceClockRequest struct {
+	By  string   `json:"by"`
+	Run []string `json:"run"`
+}
+
+// HttpAdminGetClock returns the time of the test clock as JSON.
+func HttpAdminGetClock(clock TestClock) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(testClockDocument(clock, nil))
+	}
+}
+
+// HttpAdminAdvanceClock advances the test clock and runs the requested jobs at the new time, in the given order.
+// Unknown jobs are rejected before the clock moves. A failed run is reported in its status.
+func HttpAdminAdvanceClock(clock TestClock, scheduler *Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminAdvanceClockRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		by, err := time.ParseDuration(req.By)
+		if err != nil {
+			http.Error(w, "invalid duration, e.g. 26h", http.StatusBadRequest)
+			return
+		}
+		for _, name := range req.Run {
+			if !hasJob(scheduler, name) {
+				http.Error(w, ErrJobNotFound.Error()+": "+name, http.StatusNotFound)
+				return
+			}
+		}
+		if err := clock.Advance(by); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jobs := make([]JobStatus, 0, len(req.Run))
+		for _, name := range req.Run {
+			status, err := scheduler.Run(r.Context(), name)
+			if errors.Is(err, ErrJobRunning) {
+				status = JobStatus{Name: name, Running: true, LastError: err.Error()}
+			}
+			jobs = append(jobs, status)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(testClockDocument(clock, jobs))
+	}
+}
+
+// HttpAdminResetClock returns the test clock to the wall clock.
+func HttpAdminResetClock(clock TestClock) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clock.Reset()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(testClockDocument(clock, nil))
+	}
+}
+
+// hasJob reports whether the scheduler has a job with the name; without a scheduler there is none.
+func hasJob(scheduler *Scheduler, name string) bool {
+	if scheduler == nil {
+		return false
+	}
+	for _, status := range scheduler.Jobs() {
+		if status.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// testClockDocument returns the document of the test clock.
+func testClockDocument(clock TestClock, jobs []JobStatus) HttpTestClock {
+	return HttpTestClock{Now: clock.Now(), Offset: clock.Offset().String(), Jobs: jobs}
+}
@@ -0,0 +1,117 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createClockTestMux returns a mux with the test clock set as process clock and a no_show job recording its time.
+func createClockTestMux(t *testing.T, ranAt *time.Time) http.Handler {
+	t.Helper()
+	clock := outbound.NewTestClock()
+	shared.SetClock(clock.Now)
+	t.Cleanup(func() { shared.SetClock(nil) })
+	scheduler := inbound.NewScheduler(slog.Default())
+	scheduler.Register(inbound.Job{Name: "no_show", Every: 15 * time.Minute, Run: func(ctx context.Context, now time.Time) (int, error) {
+		*ranAt = now
+		return 1, nil
+	}})
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+		Scheduler:          scheduler,
+		TestClock:          clock,
+	})
+}
+
+// clockRequest returns an admin request to the test clock.
+func clockRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+// ============================================================================
+// /admin/clock Tests
+// ============================================================================
+
+func Test_Route_Admin_AdvanceClock_Should_Run_Jobs_At_Virtual_Time(t *testing.T) {
+	// Arrange
+	var ranAt time.Time
+	mux := createClockTestMux(t, &ranAt)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, clockRequest(http.MethodPost, "/admin/clock/advance", `{"by": "26h", "run": ["no_show"]}`))
+
+	// Assert
+	var doc inbound.HttpTestClock
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &doc), nil)
+	assert.That(t, "offset must be returned", doc.Offset, "26h0m0s")
+	assert.That(t, "job status must be returned", doc.Jobs[0].LastCount, 1)
+	assert.That(t, "job must run at the virtual time", ranAt.After(time.Now().Add(25*time.Hour)), true)
+	assert.That(t, "process clock must be advanced", shared.Now().After(time.Now().Add(25*time.Hour)), true)
+}
+
+func Test_Route_Admin_AdvanceClock_With_Unknown_Job_Should_Return_404_And_Keep_Time(t *testing.T) {
+	// Arrange
+	var ranAt time.Time
+	mux := createClockTestMux(t, &ranAt)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, clockRequest(http.MethodPost, "/admin/clock/advance", `{"by": "26h", "run": ["unknown"]}`))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+	assert.That(t, "clock must not move", shared.Now().Before(time.Now().Add(time.Hour)), true)
+}
+
+func Test_Route_Admin_AdvanceClock_Backwards_Should_Return_400(t *testing.T) {
+	// Arrange
+	var ranAt time.Time
+	mux := createClockTestMux(t, &ranAt)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, clockRequest(http.MethodPost, "/admin/clock/advance", `{"by": "-1h"}`))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_Route_Admin_ResetClock_Should_Return_To_Wall_Clock(t *testing.T) {
+	// Arrange
+	var ranAt time.Time
+	mux := createClockTestMux(t, &ranAt)
+	mux.ServeHTTP(httptest.NewRecorder(), clockRequest(http.MethodPost, "/admin/clock/advance", `{"by": "48h"}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, clockRequest(http.MethodDelete, "/admin/clock", ""))
+
+	// Assert
+	var doc inbound.HttpTestClock
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &doc), nil)
+	assert.That(t, "offset must be zero", doc.Offset, "0s")
+}
@@ -0,0 +1,144 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CommunicationHistory provides the messages sent to guests and resends failed ones.
+// outbound.CommunicationHistory implements it.
+type CommunicationHistory interface {
+	ForReservation(ctx context.Context, reservationID string) ([]shared.Communication, error)
+	Resend(ctx context.Context, id string) (shared.Communication, error)
+}
+
+// CommunicationView represents a message sent to a guest for the view.
+type CommunicationView struct {
+	ID         string
+	Channel    string
+	Template   string
+	Recipient  string
+	Subject    string
+	Status     string
+	Attempts   int
+	Error      string
+	ResendOf   string
+	QueuedAt   string
+	SentAt     string // empty until sent
+	Engagement string // of push notifications, e.g. "clicked 2026-01-02 10:00:00"
+	Resendable bool
+}
+
+// HttpAdminReservationResponse specifies the view data for the admin reservation view.
+// The resend forms carry the CSRF token of the session, so signed-in administrators can submit them.
+type HttpAdminReservationResponse struct {
+	AppName        string
+	Title          string
+	CSRFToken      string
+	Tab            string // "details" or "communication"
+	Reservation    ReservationDetailView
+	Communications []CommunicationView
+}
+
+// HttpAdminReservation defines an HTTP handler function for rendering a reservation for staff,
+// given in the path by ID or confirmation number.
+// The "tab" query parameter selects the details (default) or the communication history.
+func HttpAdminReservation(e *templating.Engine, reservationService *reservation.Service, history CommunicationHistory) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := reservationService.FindReservation(r.Context(), r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		reservationID := string(res.ID)
+
+		communications, err := history.ForReservation(r.Context(), reservationID)
+		if err != nil {
+			http.Error(w, "Failed to load communication history", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpAdminReservationResponse{
+			AppName:     appName,
+			Title:       appName + " - Reservation " + reservationID,
+			CSRFToken:   CSRFToken(r),
+			Tab:         "details",
+			Reservation: buildReservationDetailView(res, requestLocale(r)),
+		}
+		if r.URL.Query().Get("tab") == "communication" {
+			data.Tab = "communication"
+		}
+		for _, c := range communications {
+			view := CommunicationView{
+				ID:         c.ID,
+				Channel:    c.Channel,
+				Template:   c.Template,
+				Recipient:  c.Recipient,
+				Subject:    c.Subject,
+				Status:     c.Status,
+				Attempts:   c.Attempts,
+				Error:      c.Error,
+				ResendOf:   c.ResendOf,
+				QueuedAt:   c.QueuedAt.Format("2006-01-02 15:04:05"),
+				Resendable: c.Resendable(),
+			}
+			if !c.SentAt.IsZero() {
+				view.SentAt = c.SentAt.Format("2006-01-02 15:04:05")
+			}
+			switch {
+			case !c.ClickedAt.IsZero():
+				view.Engagement = "clicked " + c.ClickedAt.Format("2006-01-02 15:04:05")
+			case !c.DeliveredAt.IsZero():
+				view.Engagement = "delivered " + c.DeliveredAt.Format("2006-01-02 15:04:05")
+			}
+			data.Communications = append(data.Communications, view)
+		}
+
+		HttpView(e, "admin_reservation", data)(w, r)
+	}
+}
+
+// HttpAdminResendCommunication handles the POST request to resend the failed message given in the path.
+// It redirects to the communication tab of the linked reservation.
+func HttpAdminResendCommunication(history CommunicationHistory, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		resent, err := history.Resend(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, shared.ErrCommunicationNotFound):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case errors.Is(err, shared.ErrCommunicationNotResendable):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, "Failed to resend communication", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		logger.Info("communication resent",
+			"audit", true,
+			"communication_id", resent.ID,
+			"resend_of", id,
+			"reservation_id", resent.ReservationID,
+		)
+
+		target := "/admin/reservations/" + url.PathEscape(resent.ReservationID) + "?tab=communication"
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", target)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,152 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// mockCommunicationHistory returns the communications and resends by marking them as queued copies.
+type mockCommunicationHistory struct {
+	communications []shared.Communication
+}
+
+func (m *mockCommunicationHistory) ForReservation(ctx context.Context, reservationID string) ([]shared.Communication, error) {
+	var matched []shared.Communication
+	for _, c := range m.communications {
+		if c.ReservationID == reservationID {
+			matched = append(matched, c)
+		}
+	}
+	return matched, nil
+}
+
+func (m *mockCommunicationHistory) Resend(ctx context.Context, id string) (shared.Communication, error) {
+	for _, c := range m.communications {
+		if c.ID != id {
+			continue
+		}
+		if !c.Resendable() {
+			return shared.Communication{}, shared.ErrCommunicationNotResendable
+		}
+		resent := c
+		resent.ID, resent.Status, resent.ResendOf = "c-resent", "queued", c.ID
+		m.communications = append(m.communications, resent)
+		return resent, nil
+	}
+	return shared.Communication{}, shared.ErrCommunicationNotFound
+}
+
+func createTestCommunicationHistory() *mockCommunicationHistory {
+	return &mockCommunicationHistory{communications: []shared.Communication{
+		{ID: "c-1", Channel: "email", Template: "reservation_confirmation", ReservationID: "res-001", Status: "sent", QueuedAt: time.Now()},
+		{ID: "c-2", Channel: "email", Template: "cancellation_notice", ReservationID: "res-001", Status: "failed", Error: "mailbox unavailable", QueuedAt: time.Now()},
+	}}
+}
+
+// ============================================================================
+// HttpAdminReservation Tests
+// ============================================================================
+
+func Test_HttpAdminReservation_With_Communication_Tab_Should_Render_History(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	res := createTestReservation("res-001", "guest@example.com", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	repo.put(shared.ReservationID("res-001"), *res)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/reservations/{id}", inbound.HttpAdminReservation(e, createReservationsTestService(repo), createTestCommunicationHistory()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/res-001?tab=communication", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "sent message must be listed", strings.Contains(body, "reservation_confirmation sent</p>"), true)
+	assert.That(t, "failed message must be resendable", strings.Contains(body, "cancellation_notice failed resendable"), true)
+}
+
+func Test_HttpAdminReservation_Should_Show_Arrival_Details_To_Staff(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	res := createTestReservation("res-001", "guest@example.com", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	arrival, _ := reservation.NewArrivalDetails("Jane Doe", "+1 555 0100", "spouse", "23:15")
+	arrival.BusinessCustomer, _ = reservation.NewBusinessCustomer("Acme GmbH", "DE123456789")
+	res.SetArrivalDetails(arrival)
+	repo.put(shared.ReservationID("res-001"), *res)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/reservations/{id}", inbound.HttpAdminReservation(e, createReservationsTestService(repo), createTestCommunicationHistory()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/res-001", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "arrival time must be shown", strings.Contains(body, `<p class="arrival">23:15</p>`), true)
+	assert.That(t, "emergency contact must be shown", strings.Contains(body, "Jane Doe - +1 555 0100 - spouse"), true)
+	assert.That(t, "business customer must be shown", strings.Contains(body, `<p class="business-customer">Acme GmbH DE123456789</p>`), true)
+}
+
+func Test_HttpAdminReservation_With_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/reservations/{id}", inbound.HttpAdminReservation(e, createTestReservationService(t), createTestCommunicationHistory()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/missing", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminResendCommunication Tests
+// ============================================================================
+
+func Test_HttpAdminResendCommunication_Should_Redirect_To_Communication_Tab(t *testing.T) {
+	// Arrange
+	history := createTestCommunicationHistory()
+
+	// Act
+	rec := postForm("POST /admin/communications/{id}/resend", "/admin/communications/c-2/resend", inbound.HttpAdminResendCommunication(history, slog.Default()), nil)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must show the communication tab", rec.Header().Get("Location"), "/admin/reservations/res-001?tab=communication")
+	assert.That(t, "resend must be queued", len(history.communications), 3)
+}
+
+func Test_HttpAdminResendCommunication_With_Sent_Message_Should_Return_409(t *testing.T) {
+	// Arrange
+	history := createTestCommunicationHistory()
+
+	// Act
+	rec := postForm("POST /admin/communications/{id}/resend", "/admin/communications/c-1/resend", inbound.HttpAdminResendCommunication(history, slog.Default()), nil)
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
@@ -0,0 +1,46 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ConfigReloader re-reads the runtime configuration and applies the hot-reloadable sections.
+// It returns a description of every changed setting; an invalid configuration changes nothing.
+type ConfigReloader interface {
+	Reload(ctx context.Context) ([]string, error)
+}
+
+// HttpAdminReloadConfigResponse reports the outcome of a config reload.
+type HttpAdminReloadConfigResponse struct {
+	Changed    []string  `json:"changed"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
+
+// HttpAdminReloadConfig reloads the runtime configuration and returns the changes as JSON.
+// Every reload is logged as audit event; an invalid configuration is rejected with 422.
+func HttpAdminReloadConfig(reloader ConfigReloader, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		changes, err := reloader.Reload(r.Context())
+		if err != nil {
+			logger.Warn("config reload rejected", "trigger", "admin", "error", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if changes == nil {
+			changes = []string{}
+		}
+
+		logger.Info("config reloaded",
+			"audit", true,
+			"trigger", "admin",
+			"changes", changes,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HttpAdminReloadConfigResponse{Changed: changes, ReloadedAt: time.Now().UTC()})
+	}
+}
@@ -0,0 +1,95 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockConfigReloader struct {
+	changes []string
+	err     error
+	calls   int
+}
+
+func (m *mockConfigReloader) Reload(ctx context.Context) ([]string, error) {
+	m.calls++
+	return m.changes, m.err
+}
+
+// ============================================================================
+// HttpAdminReloadConfig Tests
+// ============================================================================
+
+func Test_Route_Admin_ReloadConfig_With_Token_Should_Report_Changes(t *testing.T) {
+	// Arrange
+	reloader := &mockConfigReloader{changes: []string{`vip_tiers: VIP_GOLD_STAYS "5" -> "3"`}}
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		ConfigReloader:     reloader,
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/admin/config/reload", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var body inbound.HttpAdminReloadConfigResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "changes must be reported", body.Changed, reloader.changes)
+	assert.That(t, "reload time must be set", body.ReloadedAt.IsZero(), false)
+}
+
+func Test_Route_Admin_ReloadConfig_Without_Token_Should_Not_Reload(t *testing.T) {
+	// Arrange
+	reloader := &mockConfigReloader{}
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		ConfigReloader:     reloader,
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/admin/config/reload", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+	assert.That(t, "config must not be reloaded", reloader.calls, 0)
+}
+
+func Test_HttpAdminReloadConfig_With_Invalid_Config_Should_Return_422(t *testing.T) {
+	// Arrange
+	reloader := &mockConfigReloader{err: errors.New("invalid vip_tiers config")}
+	handler := inbound.HttpAdminReloadConfig(reloader, slog.Default())
+	req := httptest.NewRequest(http.MethodPost, "/admin/config/reload", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,153 @@
+package inbound
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// Size of the NPS trend chart in SVG user units.
+const (
+	npsChartWidth  = 600
+	npsChartHeight = 200
+)
+
+// NPSChartPoint is a point of the NPS trend chart.
+type NPSChartPoint struct {
+	X         int
+	Y         int
+	Label     string
+	Score     int
+	Responses int
+}
+
+// NPSReportView represents the NPS report of a property for the view.
+type NPSReportView struct {
+	Property     string
+	Score        int
+	Responses    int
+	Promoters    int
+	Passives     int
+	Detractors   int
+	Sent         int
+	ResponseRate int
+	Polyline     string // SVG polyline points of the weeks with responses
+	Points       []NPSChartPoint
+	Comments     []NPSCommentView
+}
+
+// NPSCommentView represents a survey comment for the view.
+type NPSCommentView struct {
+	Date          string
+	Score         int
+	Category      string
+	Comment       string
+	ReservationID string
+}
+
+// HttpAdminDashboardResponse specifies the view data for the admin dashboard.
+type HttpAdminDashboardResponse struct {
+	AppName      string
+	Title        string
+	ChartViewBox string
+	ChartWidth   int
+	ChartZeroY   int
+	WindowDays   int
+	NPS          []NPSReportView
+}
+
+// HttpAdminDashboard defines an HTTP handler function for rendering the admin dashboard
+// with the rolling NPS per property and its trend.
+func HttpAdminDashboard(e *templating.Engine, surveyService *survey.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Admin Dashboard"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		reports, err := surveyService.Report(r.Context(), time.Now(), survey.DefaultReportWindow, survey.DefaultReportStep, survey.DefaultReportPoints)
+		if err != nil {
+			http.Error(w, "Failed to load NPS report", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpAdminDashboardResponse{
+			AppName: appName,
+			Title:   title,
+			// The view box has a margin, so the points on the edges are not clipped.
+			ChartViewBox: fmt.Sprintf("-10 -10 %d %d", npsChartWidth+20, npsChartHeight+20),
+			ChartWidth:   npsChartWidth,
+			ChartZeroY:   npsChartHeight / 2,
+			WindowDays:   int(survey.DefaultReportWindow.Hours() / 24),
+		}
+		for _, report := range reports {
+			data.NPS = append(data.NPS, buildNPSReportView(report))
+		}
+
+		HttpView(e, "admin_dashboard", data)(w, r)
+	}
+}
+
+// HttpAdminNPS returns the rolling NPS trend of every property as JSON.
+func HttpAdminNPS(surveyService *survey.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reports, err := surveyService.Report(r.Context(), time.Now(), survey.DefaultReportWindow, survey.DefaultReportStep, survey.DefaultReportPoints)
+		if err != nil {
+			http.Error(w, "Failed to load NPS report", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reports)
+	}
+}
+
+// buildNPSReportView converts a report to the view and lays out the trend chart.
+// The y axis spans the NPS range from -100 (bottom) to 100 (top).
+func buildNPSReportView(report survey.PropertyReport) NPSReportView {
+	current := report.Current()
+	view := NPSReportView{
+		Property:     report.Property,
+		Score:        current.Score,
+		Responses:    current.Responses,
+		Promoters:    current.Promoters,
+		Passives:     current.Passives,
+		Detractors:   current.Detractors,
+		Sent:         report.Sent,
+		ResponseRate: report.ResponseRate(),
+	}
+
+	var polyline []string
+	for i, p := range report.Trend {
+		x := 0
+		if len(report.Trend) > 1 {
+			x = i * npsChartWidth / (len(report.Trend) - 1)
+		}
+		y := (100 - p.Score) * npsChartHeight / 200
+		view.Points = append(view.Points, NPSChartPoint{
+			X:         x,
+			Y:         y,
+			Label:     p.End.Format("2006-01-02"),
+			Score:     p.Score,
+			Responses: p.Responses,
+		})
+		if p.Responses > 0 {
+			polyline = append(polyline, fmt.Sprintf("%d,%d", x, y))
+		}
+	}
+	view.Polyline = strings.Join(polyline, " ")
+
+	for _, s := range report.RecentComments {
+		view.Comments = append(view.Comments, NPSCommentView{
+			Date:          s.AnsweredAt.Format("2006-01-02"),
+			Score:         s.Score,
+			Category:      string(s.Category()),
+			Comment:       s.Comment,
+			ReservationID: string(s.ReservationID),
+		})
+	}
+	return view
+}
@@ -0,0 +1,84 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// HttpAdminDashboard Tests
+// ============================================================================
+
+func Test_HttpAdminDashboard_Should_Render_NPS_And_Comments(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc := createTestSurveyService()
+	_, _ = svc.SubmitAnswer(context.Background(), "nps-res-001", "guest-001", 10, "Great view")
+	handler := inbound.HttpAdminDashboard(e, svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain property", strings.Contains(body, "<h2>Test Hotel</h2>"), true)
+	assert.That(t, "body must contain nps", strings.Contains(body, `<p class="nps">100</p>`), true)
+	assert.That(t, "body must contain trend point at the top right", strings.Contains(body, `points="600,0"`), true)
+	assert.That(t, "body must contain comment", strings.Contains(body, "Great view"), true)
+}
+
+// ============================================================================
+// HttpAdminNPS Tests
+// ============================================================================
+
+func Test_HttpAdminNPS_Should_Return_Report_As_JSON(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService()
+	_, _ = svc.SubmitAnswer(context.Background(), "nps-res-001", "guest-001", 3, "")
+	handler := inbound.HttpAdminNPS(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/nps", nil))
+
+	// Assert
+	var reports []struct {
+		Property string
+		Trend    []struct{ Score int }
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &reports)
+	assert.That(t, "body must be json", err == nil, true)
+	assert.That(t, "property must match", reports[0].Property, "Test Hotel")
+	assert.That(t, "current nps must be -100", reports[0].Trend[len(reports[0].Trend)-1].Score, -100)
+}
+
+func Test_Route_Admin_Dashboard_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+		SurveyService:      createTestSurveyService(),
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil))
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
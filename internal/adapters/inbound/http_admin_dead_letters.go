@@ -0,0 +1,108 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DeadLetterQueue lists the events whose handlers failed and replays them against those handlers.
+type DeadLetterQueue interface {
+	DeadLetters(ctx context.Context, topic, status string) ([]shared.DeadLetter, error)
+	Replay(ctx context.Context, id string) (shared.DeadLetter, error)
+}
+
+// HttpDeadLetter specifies the JSON of a dead-lettered event.
+type HttpDeadLetter struct {
+	ID           string `json:"id"`
+	Topic        string `json:"topic"`
+	Subscription string `json:"subscription"`
+	Data         string `json:"data"` // payload of the event, usually JSON
+	Error        string `json:"error"`
+	Status       string `json:"status"` // dead or replayed
+	Attempts     int    `json:"attempts"`
+	FailedAt     string `json:"failed_at"`             // RFC 3339
+	ReplayedAt   string `json:"replayed_at,omitempty"` // RFC 3339, of the last replay
+}
+
+// newHttpDeadLetter converts the dead letter for the JSON response.
+func newHttpDeadLetter(letter shared.DeadLetter) HttpDeadLetter {
+	resp := HttpDeadLetter{
+		ID:           letter.ID,
+		Topic:        letter.Topic,
+		Subscription: letter.Subscription,
+		Data:         string(letter.Data),
+		Error:        letter.Error,
+		Status:       letter.Status,
+		Attempts:     letter.Attempts,
+		FailedAt:     letter.FailedAt.UTC().Format(time.RFC3339),
+	}
+	if !letter.ReplayedAt.IsZero() {
+		resp.ReplayedAt = letter.ReplayedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// HttpAdminDeadLetters returns the dead-lettered events, newest first, as JSON.
+// The topic and status (dead or replayed) query parameters filter them.
+func HttpAdminDeadLetters(queue DeadLetterQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		letters, err := queue.DeadLetters(r.Context(), r.URL.Query().Get("topic"), r.URL.Query().Get("status"))
+		if errors.Is(err, shared.ErrInvalidDeadLetterStatus) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to read dead letters", http.StatusInternalServerError)
+			return
+		}
+
+		resp := []HttpDeadLetter{}
+		for _, letter := range letters {
+			resp = append(resp, newHttpDeadLetter(letter))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// HttpAdminReplayDeadLetter delivers the dead-lettered event in the path again to the handler that failed
+// and returns the updated dead letter as JSON. A replay that fails again answers 502 with the dead letter,
+// whose error is the new one.
+func HttpAdminReplayDeadLetter(queue DeadLetterQueue, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		letter, err := queue.Replay(r.Context(), id)
+		status := http.StatusOK
+		switch {
+		case errors.Is(err, shared.ErrDeadLetterNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, shared.ErrDeadLetterReplayed), errors.Is(err, shared.ErrDeadLetterNoHandler):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, shared.ErrDeadLetterReplayFailed):
+			status = http.StatusBadGateway
+		case err != nil:
+			http.Error(w, "failed to replay dead letter", http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("dead letter replayed",
+			"audit", true,
+			"dead_letter_id", id,
+			"topic", letter.Topic,
+			"subscription", letter.Subscription,
+			"status", letter.Status,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(newHttpDeadLetter(letter))
+	}
+}
@@ -0,0 +1,144 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Dead Letter Test Helpers
+// ============================================================================
+
+type mockDeadLetterQueue struct {
+	letters   []shared.DeadLetter
+	replayErr error
+	topic     string
+	status    string
+}
+
+func (m *mockDeadLetterQueue) DeadLetters(ctx context.Context, topic, status string) ([]shared.DeadLetter, error) {
+	m.topic, m.status = topic, status
+	if status == "lost" {
+		return nil, shared.ErrInvalidDeadLetterStatus
+	}
+	return m.letters, nil
+}
+
+func (m *mockDeadLetterQueue) Replay(ctx context.Context, id string) (shared.DeadLetter, error) {
+	for _, letter := range m.letters {
+		if letter.ID == id {
+			return letter, m.replayErr
+		}
+	}
+	return shared.DeadLetter{}, shared.ErrDeadLetterNotFound
+}
+
+// createTestDeadLetterQueue returns a queue with a dead letter of payment.captured.
+func createTestDeadLetterQueue() *mockDeadLetterQueue {
+	return &mockDeadLetterQueue{letters: []shared.DeadLetter{{
+		ID:           "dl-1",
+		Topic:        "payment.captured",
+		Subscription: "payment.captured#2",
+		Data:         []byte(`{"reservation_id":"res-001"}`),
+		Error:        "mail server down",
+		Status:       shared.DeadLetterDead,
+		Attempts:     1,
+		FailedAt:     time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC),
+	}}}
+}
+
+// replayDeadLetter posts the replay of the dead letter.
+func replayDeadLetter(queue inbound.DeadLetterQueue, id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/dead-letters/"+id+"/replay", nil)
+	req.SetPathValue("id", id)
+	rec := httptest.NewRecorder()
+	inbound.HttpAdminReplayDeadLetter(queue, slog.Default())(rec, req)
+	return rec
+}
+
+// ============================================================================
+// HttpAdminDeadLetters Tests
+// ============================================================================
+
+func Test_HttpAdminDeadLetters_Should_Return_Filtered_Dead_Letters(t *testing.T) {
+	// Arrange
+	queue := createTestDeadLetterQueue()
+	req := httptest.NewRequest(http.MethodGet, "/admin/dead-letters?topic=payment.captured&status=dead", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminDeadLetters(queue)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "filters must be passed", queue.topic+" "+queue.status, "payment.captured dead")
+	var letters []inbound.HttpDeadLetter
+	_ = json.Unmarshal(rec.Body.Bytes(), &letters)
+	assert.That(t, "dead letter must be returned", len(letters), 1)
+	assert.That(t, "data must be the event JSON", letters[0].Data, `{"reservation_id":"res-001"}`)
+	assert.That(t, "failure time must be RFC 3339", letters[0].FailedAt, "2026-07-01T10:00:00Z")
+}
+
+func Test_HttpAdminDeadLetters_With_Invalid_Status_Should_Return_400(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/admin/dead-letters?status=lost", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminDeadLetters(createTestDeadLetterQueue())(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAdminReplayDeadLetter Tests
+// ============================================================================
+
+func Test_HttpAdminReplayDeadLetter_Should_Return_Dead_Letter(t *testing.T) {
+	// Arrange
+	queue := createTestDeadLetterQueue()
+	queue.letters[0].Status = shared.DeadLetterReplayed
+
+	// Act
+	rec := replayDeadLetter(queue, "dl-1")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var letter inbound.HttpDeadLetter
+	_ = json.Unmarshal(rec.Body.Bytes(), &letter)
+	assert.That(t, "status must be replayed", letter.Status, shared.DeadLetterReplayed)
+}
+
+func Test_HttpAdminReplayDeadLetter_Should_Map_Errors_To_Status_Codes(t *testing.T) {
+	for _, tc := range []struct {
+		id   string
+		err  error
+		code int
+	}{
+		{"unknown", nil, http.StatusNotFound},
+		{"dl-1", shared.ErrDeadLetterReplayed, http.StatusConflict},
+		{"dl-1", shared.ErrDeadLetterNoHandler, http.StatusConflict},
+		{"dl-1", fmt.Errorf("%w: mail server down", shared.ErrDeadLetterReplayFailed), http.StatusBadGateway},
+	} {
+		// Arrange
+		queue := createTestDeadLetterQueue()
+		queue.replayErr = tc.err
+
+		// Act
+		rec := replayDeadLetter(queue, tc.id)
+
+		// Assert
+		assert.That(t, fmt.Sprintf("status code for %v must be %d", tc.err, tc.code), rec.Code, tc.code)
+	}
+}
@@ -0,0 +1,104 @@
+package inbound
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HttpAdminPurgeDocumentsResponse reports how many documents a purge removed.
+type HttpAdminPurgeDocumentsResponse struct {
+	Purged int `json:"purged"`
+}
+
+// HttpAdminReservationDocuments lists the documents of the reservation as JSON, oldest first.
+func HttpAdminReservationDocuments(documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		docs, err := documentService.List(r.Context(), shared.ReservationID(r.PathValue("id")))
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		if docs == nil {
+			docs = []document.Document{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(docs)
+	}
+}
+
+// HttpAdminUploadReservationDocument attaches a hotel document, e.g. a registration form, from the
+// file field of a multipart form and answers 201 Created with the document.
+// Hotel documents are subject to the same limits and scan as the files of guests.
+func HttpAdminUploadReservationDocument(reservationService *reservation.Service, documentService *document.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+
+		upload, err := readDocumentUpload(w, r, documentService.Limits())
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		upload.ReservationID = res.ID
+		upload.Kind = document.KindHotel
+		upload.UploadedBy = "admin"
+		doc, err := documentService.Upload(ctx, upload)
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+
+		logger.Info("reservation document uploaded",
+			"audit", true,
+			"reservation_id", res.ID,
+			"document_id", doc.ID,
+			"size", doc.Size,
+			"scan", doc.Scan,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(doc)
+	}
+}
+
+// HttpAdminDownloadReservationDocument serves a document of the reservation as attachment.
+func HttpAdminDownloadReservationDocument(documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		doc, data, err := documentService.Open(r.Context(), shared.ReservationID(r.PathValue("id")), document.DocumentID(r.PathValue("document")))
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		writeDocument(w, doc, data)
+	}
+}
+
+// HttpAdminPurgeReservationDocuments deletes all documents of the reservation, e.g. for an erasure request
+// of the guest, and answers with the number of documents removed.
+func HttpAdminPurgeReservationDocuments(documentService *document.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservationID := shared.ReservationID(r.PathValue("id"))
+		purged, err := documentService.Purge(r.Context(), reservationID)
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+
+		logger.Info("reservation documents purged",
+			"audit", true,
+			"reservation_id", reservationID,
+			"purged", purged,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HttpAdminPurgeDocumentsResponse{Purged: purged})
+	}
+}
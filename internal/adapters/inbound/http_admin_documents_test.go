@@ -0,0 +1,76 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+)
+
+// ============================================================================
+// HttpAdminUploadReservationDocument Tests
+// ============================================================================
+
+func Test_HttpAdminUploadReservationDocument_Should_Store_Hotel_Document(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	req := multipartDocumentRequest(t, "/admin/reservations/res-001/documents", "registration.pdf", []byte("%PDF-1.7 registration"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminUploadReservationDocument(svc, documents, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, req)
+
+	// Assert
+	var doc document.Document
+	_ = json.NewDecoder(rec.Body).Decode(&doc)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "document must be provided by the hotel", doc.Kind, document.KindHotel)
+	assert.That(t, "scan must be skipped without scanner", doc.Scan, document.ScanSkipped)
+}
+
+func Test_HttpAdminUploadReservationDocument_For_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	req := multipartDocumentRequest(t, "/admin/reservations/res-404/documents", "registration.pdf", []byte("%PDF-1.7 registration"))
+	req.SetPathValue("id", "res-404")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminUploadReservationDocument(svc, createTestDocumentService(t), slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminPurgeReservationDocuments Tests
+// ============================================================================
+
+func Test_HttpAdminPurgeReservationDocuments_Should_Remove_All_Documents(t *testing.T) {
+	// Arrange
+	documents := createTestDocumentService(t)
+	uploadDocument(t, documents, document.KindHotel)
+	uploadDocument(t, documents, document.KindGuest)
+	req := httptest.NewRequest(http.MethodDelete, "/admin/reservations/res-001/documents", nil)
+	req.SetPathValue("id", "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminPurgeReservationDocuments(documents, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, req)
+
+	// Assert
+	var resp inbound.HttpAdminPurgeDocumentsResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	remaining, _ := documents.List(context.Background(), "res-001")
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "both documents must be purged", resp.Purged, 2)
+	assert.That(t, "no document must remain", len(remaining), 0)
+}
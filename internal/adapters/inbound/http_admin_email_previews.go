@@ -0,0 +1,42 @@
+package inbound
+
+import (
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// EmailPreviewer renders the localized guest emails with sample data.
+// outbound.MockNotificationService implements it.
+type EmailPreviewer interface {
+	PreviewEmail(template string, locale shared.Locale) (subject string, language string, html string, err error)
+}
+
+// HttpAdminEmailPreview renders the email template in the path with sample data as HTML,
+// written for a guest who prefers the lang query parameter (e.g. de or fr-FR).
+// Without lang the preview shows the email of a guest without a preference.
+// The language the email was written in after the fallbacks is returned in Content-Language.
+func HttpAdminEmailPreview(previews EmailPreviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var locale shared.Locale
+		if lang := r.URL.Query().Get("lang"); lang != "" {
+			parsed, ok := shared.ParseLocale(lang)
+			if !ok {
+				http.Error(w, "unsupported language "+lang, http.StatusBadRequest)
+				return
+			}
+			locale = parsed
+		}
+
+		subject, language, body, err := previews.PreviewEmail(r.PathValue("template"), locale)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Language", language)
+		w.Header().Set("X-Email-Subject", subject)
+		_, _ = w.Write([]byte(body))
+	}
+}
@@ -0,0 +1,80 @@
+package inbound_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// mockEmailPreviewer renders the template name and locale; the language is the locale's.
+type mockEmailPreviewer struct {
+	locale shared.Locale
+}
+
+func (m *mockEmailPreviewer) PreviewEmail(template string, locale shared.Locale) (string, string, string, error) {
+	if template != "reservation_confirmation" {
+		return "", "", "", errors.New("unknown email template")
+	}
+	m.locale = locale
+	language := locale.Language()
+	if language == "" {
+		language = "en"
+	}
+	return "Subject", language, "<p>" + template + "</p>", nil
+}
+
+func getEmailPreview(previewer inbound.EmailPreviewer, target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/emails/{template}/preview", inbound.HttpAdminEmailPreview(previewer))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+// ============================================================================
+// HttpAdminEmailPreview Tests
+// ============================================================================
+
+func Test_HttpAdminEmailPreview_Should_Render_Template_In_Language(t *testing.T) {
+	// Arrange
+	previewer := &mockEmailPreviewer{}
+
+	// Act
+	rec := getEmailPreview(previewer, "/admin/emails/reservation_confirmation/preview?lang=de")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "language must be resolved to a locale", previewer.locale, shared.Locale("de-DE"))
+	assert.That(t, "content language must be set", rec.Header().Get("Content-Language"), "de")
+	assert.That(t, "subject must be set", rec.Header().Get("X-Email-Subject"), "Subject")
+	assert.That(t, "body must be the html", rec.Body.String(), "<p>reservation_confirmation</p>")
+}
+
+func Test_HttpAdminEmailPreview_With_Unsupported_Language_Should_Return_400(t *testing.T) {
+	// Arrange & Act
+	rec := getEmailPreview(&mockEmailPreviewer{}, "/admin/emails/reservation_confirmation/preview?lang=tlh")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminEmailPreview_With_Unknown_Template_Should_Return_404(t *testing.T) {
+	// Arrange & Act
+	rec := getEmailPreview(&mockEmailPreviewer{}, "/admin/emails/survey_invitation/preview")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// Keep the outbound notification service in sync with the port.
+var _ inbound.EmailPreviewer = (*outbound.MockNotificationService)(nil)
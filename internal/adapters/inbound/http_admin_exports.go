@@ -0,0 +1,155 @@
+package inbound
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// reservationExportColumns are the columns of the reservation export.
+var reservationExportColumns = []ExportColumn{
+	{Name: "id"},
+	{Name: "confirmation_code"},
+	{Name: "status"},
+	{Name: "room"},
+	{Name: "check_in"},
+	{Name: "check_out"},
+	{Name: "nights", Numeric: true},
+	{Name: "guest_name"},
+	{Name: "guest_email"},
+	{Name: "guest_phone"},
+	{Name: "guests", Numeric: true},
+	{Name: "amount", Numeric: true},
+	{Name: "currency"},
+	{Name: "created_at"},
+	{Name: "cancellation_reason"},
+	{Name: "property"}, // last, so spreadsheets of earlier exports keep their columns
+}
+
+// HttpAdminExportReservations streams all reservations with guest, room, status and amount
+// as CSV or Excel file for the back office (format: csv or xlsx, default csv).
+// The optional from and to query parameters (YYYY-MM-DD) limit the export to the check-ins in that range, both inclusive,
+// and the optional property parameter to the reservations of a property.
+// Rows are written as they are formatted; once the first row is sent, a failure can only be logged.
+func HttpAdminExportReservations(reservationService *reservation.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		from, to := query.Get("from"), query.Get("to")
+		if !validExportDate(w, from, "from") || !validExportDate(w, to, "to") {
+			return
+		}
+		export, err := NewExportWriter(query.Get("format"), w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		reservations, err := reservationService.ListReservations(ctx)
+		if err != nil {
+			http.Error(w, "Failed to list reservations", http.StatusInternalServerError)
+			return
+		}
+		sort.Slice(reservations, func(i, j int) bool {
+			a, b := reservations[i], reservations[j]
+			if !a.DateRange.CheckIn.Equal(b.DateRange.CheckIn) {
+				return a.DateRange.CheckIn.Before(b.DateRange.CheckIn)
+			}
+			return a.ID < b.ID
+		})
+
+		filename := "reservations-" + time.Now().UTC().Format("2006-01-02") + export.Extension()
+		w.Header().Set("Content-Type", export.ContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		if propertyID := query.Get("property"); propertyID != "" {
+			reservations = slices.DeleteFunc(reservations, func(res reservation.Reservation) bool {
+				return reservationService.PropertyOf(&res) != reservation.PropertyID(propertyID)
+			})
+		}
+		rows, err := writeReservationExport(export, reservations, from, to, reservationService.PropertyOf)
+		if err == nil {
+			err = export.Close()
+		}
+		if err != nil {
+			logger.Error("reservation export failed", "error", err, "rows", rows)
+			return
+		}
+
+		// Exports contain the contact data of guests, so they are audited like other admin actions.
+		logger.Info("reservations exported",
+			"audit", true,
+			"format", export.Extension()[1:],
+			"from", from,
+			"to", to,
+			"rows", rows,
+		)
+	}
+}
+
+// writeReservationExport writes the header and a row per reservation that checks in between from and to.
+// It returns the number of rows written.
+// Dates are compared as YYYY-MM-DD strings; an empty from or to leaves the range open.
+func writeReservationExport(export ExportWriter, reservations []reservation.Reservation, from, to string, propertyOf func(*reservation.Reservation) reservation.PropertyID) (int, error) {
+	if err := export.WriteHeader(reservationExportColumns); err != nil {
+		return 0, err
+	}
+	rows := 0
+	for i := range reservations {
+		res := &reservations[i]
+		checkIn := res.DateRange.CheckIn.Format("2006-01-02")
+		if (from != "" && checkIn < from) || (to != "" && checkIn > to) {
+			continue
+		}
+
+		var guest reservation.GuestInfo
+		if len(res.Guests) > 0 {
+			guest = res.Guests[0]
+		}
+		email := guest.Email
+		if email == "" {
+			email = res.GuestEmail
+		}
+		if err := export.WriteRow([]string{
+			string(res.ID),
+			res.ConfirmationCode(),
+			string(res.Status),
+			string(res.RoomID),
+			checkIn,
+			res.DateRange.CheckOut.Format("2006-01-02"),
+			strconv.Itoa(res.Nights()),
+			guest.Name,
+			email,
+			guest.PhoneNumber,
+			strconv.Itoa(len(res.Guests)),
+			res.TotalAmount.Decimal(),
+			res.TotalAmount.Currency,
+			res.CreatedAt.UTC().Format(time.RFC3339),
+			res.CancellationReason,
+			string(propertyOf(res)),
+		}); err != nil {
+			return rows, err
+		}
+		rows++
+	}
+	return rows, nil
+}
+
+// validExportDate checks an optional date query parameter; it writes 400 Bad Request if it is invalid.
+func validExportDate(w http.ResponseWriter, value, name string) bool {
+	if value == "" {
+		return true
+	}
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		http.Error(w, "Invalid "+name+" date, use YYYY-MM-DD", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
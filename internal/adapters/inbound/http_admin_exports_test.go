@@ -0,0 +1,117 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+var testExportLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func createExportTestRepository() *mockReservationRepository {
+	repo := newMockReservationRepository()
+	first := time.Date(2030, 3, 10, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"res-001", "res-002", "res-003"} {
+		checkIn := first.AddDate(0, 0, 10*i)
+		res := createTestReservation(id, "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+		repo.put(res.ID, *res)
+	}
+	return repo
+}
+
+// ============================================================================
+// HttpAdminExportReservations Tests
+// ============================================================================
+
+func Test_HttpAdminExportReservations_Should_Stream_CSV_Sorted_By_Check_In(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminExportReservations(createDetailTestService(createExportTestRepository()), testExportLogger)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/export", nil))
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be csv", rec.Header().Get("Content-Type"), "text/csv; charset=utf-8")
+	assert.That(t, "must be an attachment", strings.HasPrefix(rec.Header().Get("Content-Disposition"), "attachment; filename=reservations-"), true)
+	assert.That(t, "must have header and three rows", len(lines), 4)
+	assert.That(t, "header must name the columns", strings.HasPrefix(lines[0], "id,confirmation_code,status,room,check_in"), true)
+	assert.That(t, "first row must be the earliest check-in", strings.HasPrefix(lines[1], "res-001,"), true)
+	assert.That(t, "row must contain guest and amount", strings.Contains(lines[1], ",Test Guest,guest@example.com,'+1234567890,1,198.00,USD,"), true)
+}
+
+func Test_HttpAdminExportReservations_With_Range_Should_Export_Check_Ins_In_Range(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminExportReservations(createDetailTestService(createExportTestRepository()), testExportLogger)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/export?from=2030-03-20&to=2030-03-20", nil))
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.That(t, "must have header and one row", len(lines), 2)
+	assert.That(t, "row must be the reservation in range", strings.HasPrefix(lines[1], "res-002,"), true)
+}
+
+func Test_HttpAdminExportReservations_With_Property_Should_Export_Its_Reservations_Only(t *testing.T) {
+	// Arrange
+	repo := createExportTestRepository()
+	res, _ := repo.Read(context.Background(), "res-002")
+	res.PropertyID = "muc"
+	repo.put(res.ID, *res)
+	handler := inbound.HttpAdminExportReservations(createDetailTestService(repo), testExportLogger)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/export?property=muc", nil))
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.That(t, "must have header and one row", len(lines), 2)
+	assert.That(t, "row must be the reservation at the property", strings.HasPrefix(lines[1], "res-002,"), true)
+	assert.That(t, "property must be the last column", strings.HasSuffix(lines[1], ",muc"), true)
+}
+
+func Test_HttpAdminExportReservations_As_XLSX_Should_Return_Workbook(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminExportReservations(createDetailTestService(createExportTestRepository()), testExportLogger)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/export?format=xlsx", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be xlsx", rec.Header().Get("Content-Type"), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	assert.That(t, "body must be a zip archive", strings.HasPrefix(rec.Body.String(), "PK"), true)
+}
+
+func Test_HttpAdminExportReservations_With_Invalid_Parameters_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminExportReservations(createDetailTestService(createExportTestRepository()), testExportLogger)
+
+	for _, query := range []string{"?format=pdf", "?from=03/10/2030", "?to=tomorrow"} {
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/export"+query, nil))
+
+		// Assert
+		assert.That(t, "status code must be 400 for "+query, rec.Code, http.StatusBadRequest)
+	}
+}
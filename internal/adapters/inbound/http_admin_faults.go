@@ -0,0 +1,54 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// FaultController reads and changes the faults injected into the outbound ports at runtime.
+// outbound.FaultInjector implements it.
+type FaultController interface {
+	Faults() map[string]string
+	SetFault(target, spec string) error
+	Reset()
+}
+
+// HttpAdminFaultRequest specifies the body of a fault change,
+// e.g. {"fault": "delay=2s,latency=0.5,error=0.1"}; an empty fault stops the injection.
+type HttpAdminFaultRequest struct {
+	Fault string `json:"fault"`
+}
+
+// HttpAdminGetFaults returns the injected fault of every target as JSON.
+func HttpAdminGetFaults(faults FaultController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(faults.Faults())
+	}
+}
+
+// HttpAdminSetFault changes the fault of the target given in the path.
+func HttpAdminSetFault(faults FaultController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminFaultRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := faults.SetFault(r.PathValue("target"), req.Fault); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(faults.Faults())
+	}
+}
+
+// HttpAdminResetFaults stops injecting faults into all targets.
+func HttpAdminResetFaults(faults FaultController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		faults.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
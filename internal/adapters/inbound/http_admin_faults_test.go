@@ -0,0 +1,97 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+var _ inbound.FaultController = (*outbound.FaultInjector)(nil)
+
+func createFaultsTestMux(t *testing.T, faults inbound.FaultController) http.Handler {
+	t.Helper()
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Faults:             faults,
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+}
+
+// ============================================================================
+// /admin/faults Tests
+// ============================================================================
+
+func Test_HttpAdminSetFault_Should_Change_Fault_Of_Target(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("")
+	mux := createFaultsTestMux(t, faults)
+	req := httptest.NewRequest(http.MethodPut, "/admin/faults/payment_gateway", strings.NewReader(`{"fault":"delay=2s,latency=0.5,error=0.1"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var got map[string]string
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &got), nil)
+	assert.That(t, "fault must be set", got["payment_gateway"], "delay=2s,latency=0.5,error=0.1")
+}
+
+func Test_HttpAdminSetFault_With_Invalid_Fault_Should_Return_400(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("")
+	mux := createFaultsTestMux(t, faults)
+	req := httptest.NewRequest(http.MethodPut, "/admin/faults/payment_gateway", strings.NewReader(`{"fault":"error=2"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminResetFaults_Should_Clear_All_Faults(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("events:drop=1;payment_gateway:error=1")
+	mux := createFaultsTestMux(t, faults)
+	req := httptest.NewRequest(http.MethodDelete, "/admin/faults", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "events must have no fault", faults.Faults()["events"], "")
+	assert.That(t, "gateway must have no fault", faults.Faults()["payment_gateway"], "")
+}
+
+func Test_Route_Without_Faults_Should_Not_Mount_Fault_Endpoints(t *testing.T) {
+	// Arrange
+	mux := createFaultsTestMux(t, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/faults", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
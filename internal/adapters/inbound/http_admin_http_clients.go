@@ -0,0 +1,20 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HTTPClientMetrics reports the calls of the outbound HTTP clients per destination.
+type HTTPClientMetrics interface {
+	Metrics() map[string]map[string]int64
+}
+
+// HttpAdminHTTPClients returns the requests, retries, failures and total latency
+// of the outbound HTTP clients per destination as JSON.
+func HttpAdminHTTPClients(metrics HTTPClientMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(metrics.Metrics())
+	}
+}
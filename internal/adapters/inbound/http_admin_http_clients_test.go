@@ -0,0 +1,54 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockHTTPClientMetrics struct {
+	metrics map[string]map[string]int64
+}
+
+func (m *mockHTTPClientMetrics) Metrics() map[string]map[string]int64 {
+	return m.metrics
+}
+
+// ============================================================================
+// HttpAdminHTTPClients Tests
+// ============================================================================
+
+func Test_Route_Admin_HTTPClients_With_Token_Should_Return_Metrics(t *testing.T) {
+	// Arrange
+	metrics := &mockHTTPClientMetrics{metrics: map[string]map[string]int64{"weather": {"requests": 3, "retries": 1}}}
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		HTTPClients:        metrics,
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodGet, "/admin/http-clients", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var body map[string]map[string]int64
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "weather retries must be reported", body["weather"]["retries"], int64(1))
+}
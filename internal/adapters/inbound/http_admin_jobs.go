@@ -0,0 +1,35 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// HttpAdminJobs returns the status of every scheduled job as JSON.
+func HttpAdminJobs(scheduler *Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(scheduler.Jobs())
+	}
+}
+
+// HttpAdminRunJob runs the job given in the path now and returns its status as JSON,
+// e.g. to expire unpaid reservations right after a payment outage.
+func HttpAdminRunJob(scheduler *Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := scheduler.Run(r.Context(), r.PathValue("name"))
+		switch {
+		case errors.Is(err, ErrJobNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, ErrJobRunning):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		// A failed run is reported in the status; it may have changed some records already.
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
@@ -0,0 +1,100 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createJobsTestMux(t *testing.T) http.Handler {
+	t.Helper()
+	scheduler := inbound.NewScheduler(slog.Default())
+	scheduler.Register(inbound.Job{Name: "no_show", Every: 15 * time.Minute, Run: func(ctx context.Context, now time.Time) (int, error) {
+		return 1, nil
+	}})
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+		Scheduler:          scheduler,
+	})
+}
+
+// ============================================================================
+// /admin/jobs Tests
+// ============================================================================
+
+func Test_Route_Admin_Jobs_Should_List_Jobs(t *testing.T) {
+	// Arrange
+	mux := createJobsTestMux(t)
+	req := httptest.NewRequest(http.MethodGet, "/admin/jobs", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var jobs []inbound.JobStatus
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &jobs), nil)
+	assert.That(t, "job must be listed", jobs[0].Name, "no_show")
+	assert.That(t, "interval must be listed", jobs[0].Every, "15m0s")
+}
+
+func Test_Route_Admin_RunJob_Should_Run_Job(t *testing.T) {
+	// Arrange
+	mux := createJobsTestMux(t)
+	req := httptest.NewRequest(http.MethodPost, "/admin/jobs/no_show/run", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var status inbound.JobStatus
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &status), nil)
+	assert.That(t, "count must be returned", status.LastCount, 1)
+}
+
+func Test_Route_Admin_RunJob_With_Unknown_Job_Should_Return_404(t *testing.T) {
+	// Arrange
+	mux := createJobsTestMux(t)
+	req := httptest.NewRequest(http.MethodPost, "/admin/jobs/unknown/run", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_Route_Admin_Jobs_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	mux := createJobsTestMux(t)
+	req := httptest.NewRequest(http.MethodGet, "/admin/jobs", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
@@ -0,0 +1,318 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HttpAdminLedgerPayoutRequest specifies the JSON body recording a payout of the gateway.
+type HttpAdminLedgerPayoutRequest struct {
+	ID     string   `json:"id"`     // payout ID of the gateway, e.g. po_123
+	Amount APIMoney `json:"amount"` // gross amount settled
+	Fees   int64    `json:"fees"`   // withheld by the gateway, in the currency of the amount
+}
+
+// HttpAdminLedgerReversalRequest specifies the JSON body reversing an entry.
+type HttpAdminLedgerReversalRequest struct {
+	Reason string `json:"reason"`
+}
+
+// HttpAdminPayoutReportRequest specifies a payout report of the gateway in JSON.
+type HttpAdminPayoutReportRequest struct {
+	ID          string   `json:"id"`           // payout ID of the gateway, e.g. po_123
+	ArrivalDate string   `json:"arrival_date"` // YYYY-MM-DD
+	Amount      APIMoney `json:"amount"`       // gross amount settled
+	Fees        int64    `json:"fees"`         // withheld by the gateway, in the currency of the amount
+	PaymentIDs  []string `json:"payment_ids"`  // captured payments the payout settles
+}
+
+// HttpAdminPayoutReportsResponse lists the settlements of the imported reports
+// and the payouts that were imported before.
+type HttpAdminPayoutReportsResponse struct {
+	Imported []ledger.Settlement `json:"imported"`
+	Skipped  []ledger.PayoutID   `json:"skipped"`
+}
+
+// HttpAdminLedgerEntries returns the journal entries as JSON, oldest first;
+// the reservation_id query parameter limits them to one reservation.
+func HttpAdminLedgerEntries(ledgerService *ledger.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entries, err := ledgerService.Entries(r.Context(), ledger.ReservationID(r.URL.Query().Get("reservation_id")))
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+	}
+}
+
+// HttpAdminLedgerAccounts returns the balance of every account with postings per currency as JSON,
+// as of the start of the as_of date (YYYY-MM-DD, UTC) or now.
+func HttpAdminLedgerAccounts(ledgerService *ledger.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		asOf, ok := ledgerAsOf(w, r)
+		if !ok {
+			return
+		}
+		balances, err := ledgerService.Balances(r.Context(), asOf)
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(balances)
+	}
+}
+
+// HttpAdminLedgerTrialBalance returns the trial balance per currency as JSON,
+// as of the start of the as_of date (YYYY-MM-DD, UTC) or now.
+func HttpAdminLedgerTrialBalance(ledgerService *ledger.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		asOf, ok := ledgerAsOf(w, r)
+		if !ok {
+			return
+		}
+		trials, err := ledgerService.TrialBalance(r.Context(), asOf)
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		if trials == nil {
+			trials = []ledger.TrialBalance{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(trials)
+	}
+}
+
+// HttpAdminLedgerVerify checks the hash chain of the journal and returns the result as JSON.
+// A broken chain answers 409 Conflict, so monitoring can alert on the status code.
+func HttpAdminLedgerVerify(ledgerService *ledger.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v, err := ledgerService.Verify(r.Context())
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !v.Valid {
+			w.WriteHeader(http.StatusConflict)
+		}
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+// HttpAdminRecordLedgerPayout posts a payout of the gateway to the bank account from the JSON body
+// and answers 201 Created with the entry.
+func HttpAdminRecordLedgerPayout(ledgerService *ledger.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminLedgerPayoutRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		entry, err := ledgerService.RecordPayout(r.Context(), ledger.Payout{
+			ID:     req.ID,
+			Amount: shared.NewMoney(req.Amount.Amount, req.Amount.Currency),
+			Fees:   shared.NewMoney(req.Fees, req.Amount.Currency),
+		})
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+
+		logger.Info("ledger payout recorded",
+			"audit", true,
+			"payout_id", req.ID,
+			"sequence", entry.Sequence,
+			"amount", entry.Amount().FormatAmount(),
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(entry)
+	}
+}
+
+// HttpAdminReverseLedgerEntry posts the reversal of the entry with the sequence given in the path
+// and answers 201 Created with the reversal. Entries are never changed; this is how mistakes are corrected.
+func HttpAdminReverseLedgerEntry(ledgerService *ledger.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		seq, err := strconv.ParseUint(r.PathValue("sequence"), 10, 64)
+		if err != nil || seq == 0 {
+			http.Error(w, "invalid sequence", http.StatusBadRequest)
+			return
+		}
+		var req HttpAdminLedgerReversalRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil || req.Reason == "" {
+			http.Error(w, "reason is required", http.StatusBadRequest)
+			return
+		}
+		entry, err := ledgerService.Reverse(r.Context(), ledger.Sequence(seq), req.Reason)
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+
+		logger.Info("ledger entry reversed",
+			"audit", true,
+			"reversed_sequence", seq,
+			"sequence", entry.Sequence,
+			"reason", req.Reason,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(entry)
+	}
+}
+
+// HttpAdminImportPayoutReports imports payout reports of the gateway, as a JSON array of
+// HttpAdminPayoutReportRequest or as CSV (Content-Type text/csv, see ParsePayoutReportsCSV).
+// Each payout is posted and linked to the payments it settles; reports imported before are skipped,
+// so a report can be uploaded again after a failure.
+func HttpAdminImportPayoutReports(ledgerService *ledger.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := http.MaxBytesReader(w, r.Body, 4*1024*1024)
+		var reports []ledger.PayoutReport
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+			parsed, err := ParsePayoutReportsCSV(body)
+			if err != nil {
+				http.Error(w, "invalid payout report: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			reports = parsed
+		} else {
+			var req []HttpAdminPayoutReportRequest
+			if err := json.NewDecoder(body).Decode(&req); err != nil {
+				http.Error(w, "invalid request body", http.StatusBadRequest)
+				return
+			}
+			for _, report := range req {
+				arrival, err := time.Parse(time.DateOnly, report.ArrivalDate)
+				if err != nil {
+					http.Error(w, "arrival_date must be a date (YYYY-MM-DD)", http.StatusBadRequest)
+					return
+				}
+				reports = append(reports, ledger.PayoutReport{
+					ID:          ledger.PayoutID(report.ID),
+					ArrivalDate: arrival,
+					Amount:      shared.NewMoney(report.Amount.Amount, report.Amount.Currency),
+					Fees:        shared.NewMoney(report.Fees, report.Amount.Currency),
+					PaymentIDs:  report.PaymentIDs,
+				})
+			}
+		}
+
+		resp := HttpAdminPayoutReportsResponse{Imported: []ledger.Settlement{}, Skipped: []ledger.PayoutID{}}
+		for _, report := range reports {
+			settlement, err := ledgerService.ImportPayout(r.Context(), report)
+			if errors.Is(err, ledger.ErrAlreadyImported) {
+				resp.Skipped = append(resp.Skipped, report.ID)
+				continue
+			}
+			if err != nil {
+				ledgerError(w, fmt.Errorf("payout %s: %w", report.ID, err))
+				return
+			}
+			logger.Info("payout report imported",
+				"audit", true,
+				"payout_id", settlement.PayoutID,
+				"sequence", settlement.Sequence,
+				"status", settlement.Status,
+				"difference", settlement.Difference.FormatAmount(),
+			)
+			resp.Imported = append(resp.Imported, *settlement)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// HttpAdminLedgerSettlements returns the settlements of the imported payouts as JSON, latest first:
+// the amount expected from the settled payments next to the amounts reported and received.
+func HttpAdminLedgerSettlements(ledgerService *ledger.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		settlements, err := ledgerService.Settlements(r.Context())
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		if settlements == nil {
+			settlements = []ledger.Settlement{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(settlements)
+	}
+}
+
+// HttpAdminLedgerOutstandingPayouts returns the captures no payout settles yet as JSON, oldest first,
+// with the date by which the gateway should have paid them out (capture plus delay).
+func HttpAdminLedgerOutstandingPayouts(ledgerService *ledger.Service, delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		outstanding, err := ledgerService.Outstanding(r.Context(), time.Now(), delay)
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		if outstanding == nil {
+			outstanding = []ledger.OutstandingCapture{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(outstanding)
+	}
+}
+
+// HttpAdminLedgerPayoutAlerts returns the late captures and short payouts as JSON.
+// It answers 409 Conflict if there are any, so monitoring can alert on the status code.
+func HttpAdminLedgerPayoutAlerts(ledgerService *ledger.Service, delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		alerts, err := ledgerService.CheckPayouts(r.Context(), time.Now(), delay)
+		if err != nil {
+			ledgerError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if len(alerts.Late) > 0 || len(alerts.Short) > 0 {
+			w.WriteHeader(http.StatusConflict)
+		}
+		_ = json.NewEncoder(w).Encode(alerts)
+	}
+}
+
+// ledgerAsOf reads the as_of query parameter; it answers 400 and returns false if it is malformed.
+func ledgerAsOf(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	value := r.URL.Query().Get("as_of")
+	if value == "" {
+		return time.Time{}, true
+	}
+	asOf, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		http.Error(w, "as_of must be a date (YYYY-MM-DD)", http.StatusBadRequest)
+		return time.Time{}, false
+	}
+	return asOf, true
+}
+
+// ledgerError maps ledger errors to HTTP status codes.
+func ledgerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ledger.ErrEntryNotFound), errors.Is(err, ledger.ErrSettlementsDisabled):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ledger.ErrAlreadyPosted):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ledger.ErrInvalidPayout), errors.Is(err, ledger.ErrInvalidPayoutReport), errors.Is(err, ledger.ErrInvalidEntry),
+		errors.Is(err, ledger.ErrUnbalancedEntry), errors.Is(err, ledger.ErrUnknownAccount):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "ledger request failed", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,185 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// createLedgerWithCapture creates a ledger with the authorization and capture of 100.00 USD for res-001.
+func createLedgerWithCapture() *ledger.Service {
+	svc := createTestLedgerService()
+	_, _ = svc.RecordPayment(context.Background(), ledger.PaymentMovements{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		Amount:        shared.NewMoney(10000, "USD"),
+		Authorized:    true,
+		Captured:      true,
+	})
+	return svc
+}
+
+// ============================================================================
+// HttpAdminLedgerTrialBalance Tests
+// ============================================================================
+
+func Test_HttpAdminLedgerTrialBalance_Should_Return_Balanced_Report(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminLedgerTrialBalance(createLedgerWithCapture())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/ledger/trial-balance", nil))
+
+	// Assert
+	var trials []ledger.TrialBalance
+	err := json.Unmarshal(rec.Body.Bytes(), &trials)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err, nil)
+	assert.That(t, "must have one currency", len(trials), 1)
+	assert.That(t, "trial balance must balance", trials[0].Balanced, true)
+	assert.That(t, "debits must be the capture", trials[0].Debits, int64(10000))
+}
+
+func Test_HttpAdminLedgerTrialBalance_With_Invalid_Date_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminLedgerTrialBalance(createLedgerWithCapture())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/ledger/trial-balance?as_of=yesterday", nil))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAdminRecordLedgerPayout Tests
+// ============================================================================
+
+func Test_HttpAdminRecordLedgerPayout_Should_Return_201_Then_409(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminRecordLedgerPayout(createLedgerWithCapture(), slog.Default())
+	body := `{"id":"po-001","amount":{"amount":10000,"currency":"USD"},"fees":290}`
+	first := httptest.NewRecorder()
+	second := httptest.NewRecorder()
+
+	// Act
+	handler(first, httptest.NewRequest(http.MethodPost, "/admin/ledger/payouts", strings.NewReader(body)))
+	handler(second, httptest.NewRequest(http.MethodPost, "/admin/ledger/payouts", strings.NewReader(body)))
+
+	// Assert
+	var entry ledger.Entry
+	_ = json.Unmarshal(first.Body.Bytes(), &entry)
+	assert.That(t, "status code must be 201", first.Code, http.StatusCreated)
+	assert.That(t, "payout must be entry 3", entry.Sequence, ledger.Sequence(3))
+	assert.That(t, "repeated payout must be 409", second.Code, http.StatusConflict)
+}
+
+// ============================================================================
+// HttpAdminReverseLedgerEntry Tests
+// ============================================================================
+
+func Test_HttpAdminReverseLedgerEntry_Unknown_Entry_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminReverseLedgerEntry(createLedgerWithCapture(), slog.Default())
+	req := httptest.NewRequest(http.MethodPost, "/admin/ledger/entries/42/reverse", strings.NewReader(`{"reason":"duplicate"}`))
+	req.SetPathValue("sequence", "42")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminLedgerVerify Tests
+// ============================================================================
+
+func Test_HttpAdminLedgerVerify_Intact_Journal_Should_Return_200(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminLedgerVerify(createLedgerWithCapture())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/ledger/verify", nil))
+
+	// Assert
+	var v ledger.Verification
+	_ = json.Unmarshal(rec.Body.Bytes(), &v)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "journal must be valid", v.Valid, true)
+	assert.That(t, "head must be entry 2", v.Head, ledger.Sequence(2))
+}
+
+// ============================================================================
+// HttpAdminImportPayoutReports Tests
+// ============================================================================
+
+func Test_HttpAdminImportPayoutReports_Should_Import_Then_Skip(t *testing.T) {
+	// Arrange
+	svc := createLedgerWithCapture()
+	handler := inbound.HttpAdminImportPayoutReports(svc, slog.Default())
+	body := `[{"id":"po_1","arrival_date":"2030-05-03","amount":{"amount":10000,"currency":"USD"},"fees":290,"payment_ids":["pay-001"]}]`
+	first := httptest.NewRecorder()
+	second := httptest.NewRecorder()
+
+	// Act
+	handler(first, httptest.NewRequest(http.MethodPost, "/admin/ledger/payout-reports", strings.NewReader(body)))
+	handler(second, httptest.NewRequest(http.MethodPost, "/admin/ledger/payout-reports", strings.NewReader(body)))
+
+	// Assert
+	var imported, skipped inbound.HttpAdminPayoutReportsResponse
+	_ = json.Unmarshal(first.Body.Bytes(), &imported)
+	_ = json.Unmarshal(second.Body.Bytes(), &skipped)
+	assert.That(t, "status code must be 200", first.Code, http.StatusOK)
+	assert.That(t, "payout must be imported", len(imported.Imported), 1)
+	assert.That(t, "payout must match the capture", imported.Imported[0].Status, ledger.SettlementMatched)
+	assert.That(t, "repeated payout must be skipped", skipped.Skipped, []ledger.PayoutID{"po_1"})
+}
+
+func Test_HttpAdminImportPayoutReports_CSV_Short_Payout_Should_Raise_Alert(t *testing.T) {
+	// Arrange
+	svc := createLedgerWithCapture()
+	importer := inbound.HttpAdminImportPayoutReports(svc, slog.Default())
+	req := httptest.NewRequest(http.MethodPost, "/admin/ledger/payout-reports", strings.NewReader(
+		"payout_id,arrival_date,currency,amount,fees,payment_id\npo_1,2030-05-03,USD,9000,290,pay-001\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	alerts := httptest.NewRecorder()
+
+	// Act
+	importer(httptest.NewRecorder(), req)
+	inbound.HttpAdminLedgerPayoutAlerts(svc, 72*time.Hour)(alerts, httptest.NewRequest(http.MethodGet, "/admin/ledger/payout-alerts", nil))
+
+	// Assert
+	var body ledger.PayoutAlerts
+	_ = json.Unmarshal(alerts.Body.Bytes(), &body)
+	assert.That(t, "status code must be 409", alerts.Code, http.StatusConflict)
+	assert.That(t, "short payout must be reported", len(body.Short), 1)
+	assert.That(t, "difference must be the missing amount", body.Short[0].Difference.Amount, int64(-1000))
+}
+
+func Test_HttpAdminImportPayoutReports_Without_Payments_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminImportPayoutReports(createLedgerWithCapture(), slog.Default())
+	body := `[{"id":"po_1","arrival_date":"2030-05-03","amount":{"amount":10000,"currency":"USD"},"fees":290}]`
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/ledger/payout-reports", strings.NewReader(body)))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
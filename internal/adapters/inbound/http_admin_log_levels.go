@@ -0,0 +1,45 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LogLevelController reads and changes the log levels of components at runtime.
+type LogLevelController interface {
+	Levels() map[string]string
+	SetLevel(component, level string) error
+}
+
+// HttpAdminLogLevelsRequest specifies the body of a log level change.
+type HttpAdminLogLevelsRequest struct {
+	Level string `json:"level"`
+}
+
+// HttpAdminGetLogLevels returns the current log level of every component as JSON.
+func HttpAdminGetLogLevels(levels LogLevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(levels.Levels())
+	}
+}
+
+// HttpAdminSetLogLevel changes the log level of the component given in the path.
+func HttpAdminSetLogLevel(levels LogLevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		component := r.PathValue("component")
+
+		var req HttpAdminLogLevelsRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := levels.SetLevel(component, req.Level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(levels.Levels())
+	}
+}
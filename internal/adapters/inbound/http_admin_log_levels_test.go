@@ -0,0 +1,94 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockLogLevels struct {
+	levels map[string]string
+}
+
+func (m *mockLogLevels) Levels() map[string]string {
+	return m.levels
+}
+
+func (m *mockLogLevels) SetLevel(component, level string) error {
+	if level != "DEBUG" && level != "INFO" {
+		return errors.New("invalid log level")
+	}
+	m.levels[component] = level
+	return nil
+}
+
+// ============================================================================
+// HttpAdminLogLevels Tests
+// ============================================================================
+
+func Test_HttpAdminGetLogLevels_Should_Return_Levels(t *testing.T) {
+	// Arrange
+	levels := &mockLogLevels{levels: map[string]string{"http": "INFO"}}
+	handler := inbound.HttpAdminGetLogLevels(levels)
+	req := httptest.NewRequest(http.MethodGet, "/admin/log-levels", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	var body map[string]string
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "http level must be INFO", body["http"], "INFO")
+}
+
+func Test_Route_Admin_SetLogLevel_With_Token_Should_Change_Level(t *testing.T) {
+	// Arrange
+	levels := &mockLogLevels{levels: map[string]string{"availability": "INFO"}}
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		LogLevels:          levels,
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodPut, "/admin/log-levels/availability", strings.NewReader(`{"level":"DEBUG"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "level must be changed", levels.levels["availability"], "DEBUG")
+}
+
+func Test_HttpAdminSetLogLevel_With_Invalid_Level_Should_Return_400(t *testing.T) {
+	// Arrange
+	levels := &mockLogLevels{levels: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /admin/log-levels/{component}", inbound.HttpAdminSetLogLevel(levels))
+	req := httptest.NewRequest(http.MethodPut, "/admin/log-levels/http", strings.NewReader(`{"level":"LOUD"}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
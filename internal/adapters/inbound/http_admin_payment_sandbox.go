@@ -0,0 +1,78 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// MCPResourceTestCards is the URI of the MCP resource listing the test cards of the payment sandbox.
+const MCPResourceTestCards = "payments://test-cards"
+
+// PaymentSandbox reads and switches the card the sandbox gateway charges payments to at runtime.
+// outbound.MockPaymentGateway implements it.
+type PaymentSandbox interface {
+	TestCard() payment.TestCard
+	UseTestCard(number string) error
+}
+
+// HttpPaymentSandbox is the JSON document of the payment sandbox:
+// the card used for payments without a test card number and the catalog of the test cards.
+type HttpPaymentSandbox struct {
+	Card      string             `json:"card"`
+	TestCards []payment.TestCard `json:"test_cards"`
+}
+
+// HttpAdminPaymentSandboxRequest specifies the body of a card switch,
+// e.g. {"card": "4000000000000002"}; an empty card restores the approving card.
+type HttpAdminPaymentSandboxRequest struct {
+	Card string `json:"card"`
+}
+
+// HttpAdminGetPaymentSandbox returns the sandbox card and the test cards as JSON.
+func HttpAdminGetPaymentSandbox(sandbox PaymentSandbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(paymentSandboxDocument(sandbox))
+	}
+}
+
+// HttpAdminSetPaymentSandboxCard switches the card the payments without a test card number are charged to.
+func HttpAdminSetPaymentSandboxCard(sandbox PaymentSandbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminPaymentSandboxRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := sandbox.UseTestCard(req.Card); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(paymentSandboxDocument(sandbox))
+	}
+}
+
+// NewTestCardsResource returns the payments://test-cards resource, so agents running demos
+// know which card numbers decline, require authentication or are slow.
+func NewTestCardsResource(sandbox PaymentSandbox) MCPResource {
+	return MCPResource{
+		URI:         MCPResourceTestCards,
+		Name:        "Test cards",
+		Description: "Card numbers of the payment sandbox and their behaviors (approve, decline, sca_required, slow), with the card used for payments without one",
+		MimeType:    "application/json",
+		Read: func(ctx context.Context) (string, error) {
+			data, err := json.Marshal(paymentSandboxDocument(sandbox))
+			return string(data), err
+		},
+	}
+}
+
+// paymentSandboxDocument returns the document of the sandbox.
+func paymentSandboxDocument(sandbox PaymentSandbox) HttpPaymentSandbox {
+	return HttpPaymentSandbox{Card: sandbox.TestCard().Number, TestCards: payment.TestCards}
+}
@@ -0,0 +1,111 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+var _ inbound.PaymentSandbox = (*outbound.MockPaymentGateway)(nil)
+
+func createPaymentSandboxTestMux(t *testing.T, sandbox inbound.PaymentSandbox) http.Handler {
+	t.Helper()
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		PaymentSandbox:     sandbox,
+		ReservationService: createTestReservationService(t),
+	})
+}
+
+// ============================================================================
+// /admin/payment-sandbox Tests
+// ============================================================================
+
+func Test_HttpAdminGetPaymentSandbox_Should_List_Test_Cards(t *testing.T) {
+	// Arrange
+	mux := createPaymentSandboxTestMux(t, outbound.NewMockPaymentGateway())
+	req := httptest.NewRequest(http.MethodGet, "/admin/payment-sandbox", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var got inbound.HttpPaymentSandbox
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &got), nil)
+	assert.That(t, "card must approve", got.Card, payment.TestCards[0].Number)
+	assert.That(t, "all test cards must be listed", len(got.TestCards), len(payment.TestCards))
+}
+
+func Test_HttpAdminSetPaymentSandboxCard_Should_Switch_Card(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	mux := createPaymentSandboxTestMux(t, gateway)
+	req := httptest.NewRequest(http.MethodPut, "/admin/payment-sandbox", strings.NewReader(`{"card":"4000000000000002"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "gateway must decline", gateway.TestCard().Behavior, payment.TestCardDecline)
+}
+
+func Test_HttpAdminSetPaymentSandboxCard_With_Unknown_Card_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createPaymentSandboxTestMux(t, outbound.NewMockPaymentGateway())
+	req := httptest.NewRequest(http.MethodPut, "/admin/payment-sandbox", strings.NewReader(`{"card":"4111111111111111"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_Route_Without_PaymentSandbox_Should_Not_Serve_Payment_Sandbox(t *testing.T) {
+	// Arrange
+	mux := createPaymentSandboxTestMux(t, nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/payment-sandbox", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_NewTestCardsResource_Should_Return_Catalog(t *testing.T) {
+	// Arrange
+	resource := inbound.NewTestCardsResource(outbound.NewMockPaymentGateway())
+
+	// Act
+	text, err := resource.Read(context.Background())
+
+	// Assert
+	var got inbound.HttpPaymentSandbox
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "text must be JSON", json.Unmarshal([]byte(text), &got), nil)
+	assert.That(t, "uri must be payments://test-cards", resource.URI, inbound.MCPResourceTestCards)
+	assert.That(t, "catalog must contain the slow card", got.TestCards[3].Behavior, payment.TestCardSlow)
+}
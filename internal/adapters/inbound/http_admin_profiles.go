@@ -0,0 +1,136 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// HttpAdminMergeProfilesRequest specifies the body of a profile merge.
+type HttpAdminMergeProfilesRequest struct {
+	SurvivorID  string   `json:"survivor_id"`
+	DuplicateID string   `json:"duplicate_id"`
+	Reasons     []string `json:"reasons"`
+	MergedBy    string   `json:"merged_by"`
+}
+
+// HttpAdminDuplicates returns the probable duplicate guest profiles as JSON, the most likely first.
+// The optional threshold query parameter (0 to 1) overrides the default minimum score.
+func HttpAdminDuplicates(profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		threshold := profile.DefaultDuplicateThreshold
+		if value := r.URL.Query().Get("threshold"); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				http.Error(w, "threshold must be between 0 and 1", http.StatusBadRequest)
+				return
+			}
+			threshold = parsed
+		}
+
+		candidates, err := profileService.FindDuplicates(r.Context(), threshold)
+		if err != nil {
+			http.Error(w, "failed to find duplicates", http.StatusInternalServerError)
+			return
+		}
+		if candidates == nil {
+			candidates = []profile.DuplicateCandidate{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(candidates)
+	}
+}
+
+// HttpAdminMerges returns the merge audit trail as JSON, the latest merge first.
+func HttpAdminMerges(profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		merges, err := profileService.Merges(r.Context())
+		if err != nil {
+			http.Error(w, "failed to list merges", http.StatusInternalServerError)
+			return
+		}
+		if merges == nil {
+			merges = []profile.Merge{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(merges)
+	}
+}
+
+// HttpAdminMergeProfiles merges the duplicate into the survivor profile by moving its reservations.
+// Every merge is logged as audit event and recorded, so it can be undone.
+func HttpAdminMergeProfiles(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminMergeProfilesRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.MergedBy == "" {
+			req.MergedBy = "admin"
+		}
+
+		merge, err := profileService.MergeProfiles(r.Context(), profile.MergeID(security.GenerateID()),
+			profile.GuestID(req.SurvivorID), profile.GuestID(req.DuplicateID), req.Reasons, req.MergedBy)
+		if err != nil {
+			switch {
+			case errors.Is(err, profile.ErrMissingProfile), errors.Is(err, profile.ErrSameProfile):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, profile.ErrNothingToMerge):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			default:
+				http.Error(w, "failed to merge profiles", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		logger.Info("guest profiles merged",
+			"audit", true,
+			"merge_id", merge.ID,
+			"survivor_id", merge.SurvivorID,
+			"duplicate_id", merge.DuplicateID,
+			"reservations", len(merge.ReservationIDs),
+			"merged_by", merge.MergedBy,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(merge)
+	}
+}
+
+// HttpAdminUndoMerge moves the reservations of a merge back to the duplicate profile.
+func HttpAdminUndoMerge(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		merge, err := profileService.UndoMerge(r.Context(), profile.MergeID(r.PathValue("id")))
+		if err != nil {
+			switch {
+			case errors.Is(err, profile.ErrMergeNotFound):
+				http.Error(w, "merge not found", http.StatusNotFound)
+			case errors.Is(err, profile.ErrMergeUndone), errors.Is(err, profile.ErrMergeSuperseded):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, "failed to undo merge", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		logger.Info("guest profile merge undone",
+			"audit", true,
+			"merge_id", merge.ID,
+			"survivor_id", merge.SurvivorID,
+			"duplicate_id", merge.DuplicateID,
+			"reservations", len(merge.ReservationIDs),
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(merge)
+	}
+}
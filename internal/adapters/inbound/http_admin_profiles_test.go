@@ -0,0 +1,155 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// createTestProfileService creates a profile service with a guest who booked twice under a typo email.
+func createTestProfileService(t *testing.T) (*profile.Service, *reservation.Service) {
+	t.Helper()
+	reservationService := createTestReservationService(t)
+	ctx := context.Background()
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	amount := shared.NewMoney(20000, "EUR")
+	_, _ = reservationService.CreateReservation(ctx, "res-001", "guest-main", "room-101", dateRange, amount,
+		[]reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john.doe@example.com", "")})
+	_, _ = reservationService.CreateReservation(ctx, "res-002", "guest-main", "room-102", dateRange, amount,
+		[]reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john.doe@example.com", "")})
+	_, _ = reservationService.CreateReservation(ctx, "res-003", "guest-dup", "room-103", dateRange, amount,
+		[]reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "jonh.doe@example.com", "")})
+	directory := outbound.NewReservationGuestDirectory(reservationService)
+	return profile.NewService(directory, resource.NewInMemoryAccess[profile.MergeID, profile.Merge](), resource.NewInMemoryAccess[profile.GuestID, profile.TierAssignment](), profile.DefaultTierPolicy()), reservationService
+}
+
+// ============================================================================
+// HttpAdminDuplicates Tests
+// ============================================================================
+
+func Test_HttpAdminDuplicates_Should_Return_Candidates_As_JSON(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService(t)
+	handler := inbound.HttpAdminDuplicates(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/duplicates", nil))
+
+	// Assert
+	var candidates []profile.DuplicateCandidate
+	err := json.Unmarshal(rec.Body.Bytes(), &candidates)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err == nil, true)
+	assert.That(t, "must find 1 candidate", len(candidates), 1)
+	assert.That(t, "survivor must have more reservations", candidates[0].Survivor.GuestID, profile.GuestID("guest-main"))
+}
+
+func Test_HttpAdminDuplicates_With_Invalid_Threshold_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService(t)
+	handler := inbound.HttpAdminDuplicates(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/duplicates?threshold=2", nil))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAdminMergeProfiles Tests
+// ============================================================================
+
+func Test_HttpAdminMergeProfiles_Should_Relink_Reservations(t *testing.T) {
+	// Arrange
+	svc, reservationService := createTestProfileService(t)
+	handler := inbound.HttpAdminMergeProfiles(svc, slog.Default())
+	rec := httptest.NewRecorder()
+	body := `{"survivor_id":"guest-main","duplicate_id":"guest-dup","reasons":["similar email"]}`
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/merges", strings.NewReader(body)))
+
+	// Assert
+	var merge profile.Merge
+	_ = json.Unmarshal(rec.Body.Bytes(), &merge)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "merged by must default to admin", merge.MergedBy, "admin")
+	res, _ := reservationService.GetReservation(context.Background(), "res-003")
+	assert.That(t, "reservation must be owned by survivor", res.IsOwnedBy("guest-main"), true)
+}
+
+func Test_HttpAdminMergeProfiles_Into_Itself_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService(t)
+	handler := inbound.HttpAdminMergeProfiles(svc, slog.Default())
+	rec := httptest.NewRecorder()
+	body := `{"survivor_id":"guest-main","duplicate_id":"guest-main"}`
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/merges", strings.NewReader(body)))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAdminUndoMerge Tests
+// ============================================================================
+
+func Test_HttpAdminUndoMerge_Should_Restore_Reservations(t *testing.T) {
+	// Arrange
+	svc, reservationService := createTestProfileService(t)
+	ctx := context.Background()
+	merge, _ := svc.MergeProfiles(ctx, "merge-001", "guest-main", "guest-dup", nil, "admin")
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/merges/{id}/undo", inbound.HttpAdminUndoMerge(svc, slog.Default()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/merges/"+string(merge.ID)+"/undo", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	res, _ := reservationService.GetReservation(ctx, "res-003")
+	assert.That(t, "reservation must be owned by duplicate again", res.IsOwnedBy("guest-dup"), true)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/merges/"+string(merge.ID)+"/undo", nil))
+	assert.That(t, "second undo must return 409", rec.Code, http.StatusConflict)
+}
+
+func Test_Route_Admin_Merges_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	svc, reservationService := createTestProfileService(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ProfileService:     svc,
+		ReservationService: reservationService,
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/merges", strings.NewReader(`{}`)))
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
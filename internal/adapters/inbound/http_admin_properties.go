@@ -0,0 +1,17 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+)
+
+// HttpAdminProperties returns the properties of the chain with address, timezone and rooms as JSON,
+// the default property first. Their IDs scope the admin views (property query parameter).
+func HttpAdminProperties(properties *property.Catalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(properties.Properties())
+	}
+}
@@ -0,0 +1,34 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+)
+
+// ============================================================================
+// HttpAdminProperties Tests
+// ============================================================================
+
+func Test_HttpAdminProperties_Should_Return_Default_Property_First(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminProperties(createTestProperties(t))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/properties", nil))
+
+	// Assert
+	var properties []property.Property
+	err := json.Unmarshal(rec.Body.Bytes(), &properties)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err, nil)
+	assert.That(t, "both properties must be returned", len(properties), 2)
+	assert.That(t, "default property must be first", properties[0].ID, property.PropertyID("ber"))
+	assert.That(t, "address must be returned", properties[0].Address, "Unter den Linden 1")
+}
@@ -0,0 +1,134 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HttpAdminSeason specifies a season of a rate plan.
+type HttpAdminSeason struct {
+	Name    string `json:"name"`
+	From    string `json:"from"` // MM-DD
+	To      string `json:"to"`   // MM-DD, may be before From to span the turn of the year
+	Percent int    `json:"percent"`
+}
+
+// HttpAdminStayDiscount specifies a length-of-stay discount of a rate plan.
+type HttpAdminStayDiscount struct {
+	MinNights int `json:"min_nights"`
+	Percent   int `json:"percent"`
+}
+
+// HttpAdminRatePlan specifies the JSON body of a rate plan.
+type HttpAdminRatePlan struct {
+	RoomID           string                  `json:"room_id"`
+	BaseRate         int64                   `json:"base_rate"` // minor units
+	Currency         string                  `json:"currency"`
+	Seasons          []HttpAdminSeason       `json:"seasons"`
+	WeekendSurcharge int                     `json:"weekend_surcharge"`
+	WeekendNights    []string                `json:"weekend_nights,omitempty"` // e.g. ["fri", "sat"]
+	StayDiscounts    []HttpAdminStayDiscount `json:"stay_discounts"`
+	UpdatedAt        *time.Time              `json:"updated_at,omitempty"` // nil for the default plan of a room
+}
+
+// HttpAdminRatePlans returns the rate plans of all rooms as JSON.
+// Rooms without a stored plan are listed with the plan of their default rate.
+func HttpAdminRatePlans(pricingService *pricing.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		plans, err := pricingService.ListRatePlans(r.Context())
+		if err != nil {
+			http.Error(w, "failed to list rate plans", http.StatusInternalServerError)
+			return
+		}
+		resp := make([]HttpAdminRatePlan, 0, len(plans))
+		for _, plan := range plans {
+			resp = append(resp, buildAdminRatePlan(plan))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// HttpAdminSaveRatePlan replaces the rate plan of the room given in the path and returns it as JSON.
+// Reservations that were already made keep their price.
+func HttpAdminSaveRatePlan(pricingService *pricing.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminRatePlan
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		plan := pricing.RatePlan{
+			RoomID:           pricing.RoomID(r.PathValue("room")),
+			BaseRate:         shared.NewMoney(req.BaseRate, strings.ToUpper(req.Currency)),
+			WeekendSurcharge: req.WeekendSurcharge,
+		}
+		for _, s := range req.Seasons {
+			plan.Seasons = append(plan.Seasons, pricing.Season{Name: s.Name, From: s.From, To: s.To, Percent: s.Percent})
+		}
+		for _, d := range req.StayDiscounts {
+			plan.StayDiscounts = append(plan.StayDiscounts, pricing.StayDiscount{MinNights: d.MinNights, Percent: d.Percent})
+		}
+		for _, name := range req.WeekendNights {
+			day, ok := parseWeekday(name)
+			if !ok {
+				http.Error(w, "unknown weekend night "+name, http.StatusBadRequest)
+				return
+			}
+			plan.WeekendNights = append(plan.WeekendNights, day)
+		}
+
+		saved, err := pricingService.SaveRatePlan(r.Context(), plan)
+		if err != nil {
+			if errors.Is(err, pricing.ErrInvalidRatePlan) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "failed to save rate plan", http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("rate plan saved",
+			"audit", true,
+			"room_id", saved.RoomID,
+			"base_rate", saved.BaseRate.Amount,
+			"currency", saved.BaseRate.Currency,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(buildAdminRatePlan(*saved))
+	}
+}
+
+// buildAdminRatePlan converts a rate plan to its JSON body.
+func buildAdminRatePlan(plan pricing.RatePlan) HttpAdminRatePlan {
+	resp := HttpAdminRatePlan{
+		RoomID:           string(plan.RoomID),
+		BaseRate:         plan.BaseRate.Amount,
+		Currency:         plan.BaseRate.Currency,
+		Seasons:          []HttpAdminSeason{},
+		WeekendSurcharge: plan.WeekendSurcharge,
+		StayDiscounts:    []HttpAdminStayDiscount{},
+	}
+	for _, s := range plan.Seasons {
+		resp.Seasons = append(resp.Seasons, HttpAdminSeason{Name: s.Name, From: s.From, To: s.To, Percent: s.Percent})
+	}
+	for _, d := range plan.StayDiscounts {
+		resp.StayDiscounts = append(resp.StayDiscounts, HttpAdminStayDiscount{MinNights: d.MinNights, Percent: d.Percent})
+	}
+	for _, day := range plan.WeekendNights {
+		resp.WeekendNights = append(resp.WeekendNights, strings.ToLower(day.String()))
+	}
+	if !plan.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &plan.UpdatedAt
+	}
+	return resp
+}
@@ -0,0 +1,106 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestPricingService prices room-101 and room-201 at their fixed prices.
+func createTestPricingService() *pricing.Service {
+	service := pricing.NewService(resource.NewInMemoryAccess[pricing.RoomID, pricing.RatePlan]())
+	service.SetDefaultRates(map[pricing.RoomID]pricing.Money{
+		"room-101": shared.NewMoney(9900, "USD"),
+		"room-201": shared.NewMoney(14900, "USD"),
+	})
+	return service
+}
+
+func putRatePlan(service *pricing.Service, room, body string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /admin/rate-plans/{room}", inbound.HttpAdminSaveRatePlan(service, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/rate-plans/"+room, strings.NewReader(body)))
+	return rec
+}
+
+// ============================================================================
+// HttpAdminRatePlans Tests
+// ============================================================================
+
+func Test_HttpAdminRatePlans_Should_List_Default_Plans(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminRatePlans(createTestPricingService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/rate-plans", nil))
+
+	// Assert
+	var plans []inbound.HttpAdminRatePlan
+	_ = json.Unmarshal(rec.Body.Bytes(), &plans)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "both rooms must be listed", len(plans), 2)
+	assert.That(t, "base rate must be in cents", plans[0].BaseRate, int64(9900))
+	assert.That(t, "default plan must not have an update time", plans[0].UpdatedAt == nil, true)
+}
+
+// ============================================================================
+// HttpAdminSaveRatePlan Tests
+// ============================================================================
+
+func Test_HttpAdminSaveRatePlan_Should_Store_Plan_For_Room_In_Path(t *testing.T) {
+	// Arrange
+	service := createTestPricingService()
+	body := `{"base_rate":12000,"currency":"usd","seasons":[{"name":"summer","from":"07-01","to":"08-31","percent":20}],"weekend_surcharge":10,"weekend_nights":["fri","sat"],"stay_discounts":[{"min_nights":7,"percent":10}]}`
+
+	// Act
+	rec := putRatePlan(service, "room-101", body)
+
+	// Assert
+	var saved inbound.HttpAdminRatePlan
+	_ = json.Unmarshal(rec.Body.Bytes(), &saved)
+	plan, _ := service.RatePlan(context.Background(), "room-101")
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "room must be taken from the path", saved.RoomID, "room-101")
+	assert.That(t, "weekend nights must be returned", saved.WeekendNights, []string{"friday", "saturday"})
+	assert.That(t, "base rate must be stored", plan.BaseRate, shared.NewMoney(12000, "USD"))
+	assert.That(t, "season must be stored", plan.Seasons[0].Name, "summer")
+}
+
+func Test_HttpAdminSaveRatePlan_With_Invalid_Plan_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createTestPricingService()
+
+	// Act
+	rec := putRatePlan(service, "room-101", `{"base_rate":12000,"currency":"USD","stay_discounts":[{"min_nights":7,"percent":150}]}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminSaveRatePlan_With_Unknown_Weekend_Night_Should_Return_400(t *testing.T) {
+	// Arrange
+	service := createTestPricingService()
+
+	// Act
+	rec := putRatePlan(service, "room-101", `{"base_rate":12000,"currency":"USD","weekend_nights":["funday"]}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
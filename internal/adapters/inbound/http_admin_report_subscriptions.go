@@ -0,0 +1,222 @@
+package inbound
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+)
+
+// ReportPropertyView represents a property to pick in the report subscription console.
+type ReportPropertyView struct {
+	ID       string
+	Name     string
+	Selected bool
+}
+
+// ReportSubscriptionView represents a report subscription for the view.
+type ReportSubscriptionView struct {
+	ID         string
+	Type       string
+	Schedule   string
+	Format     string
+	Recipients string
+	NextRunAt  string
+	LastSentAt string // "" until the first report was sent
+}
+
+// HttpAdminReportSubscriptionsResponse specifies the view data for the report subscription console.
+// The forms carry the CSRF token of the session, so signed-in administrators can submit them.
+type HttpAdminReportSubscriptionsResponse struct {
+	AppName       string
+	Title         string
+	CSRFToken     string
+	Property      ReportPropertyView
+	Properties    []ReportPropertyView
+	Subscriptions []ReportSubscriptionView
+	Types         []report.Type
+	Frequencies   []report.Frequency
+	Weekdays      []string
+	Formats       []report.Format
+	Timezone      string
+}
+
+// HttpAdminReportSubscriptions defines an HTTP handler function for rendering the report subscription
+// console of the property given by the "property" query parameter (default: the default property).
+func HttpAdminReportSubscriptions(e *templating.Engine, reportService *report.Service, properties *property.Catalog) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Report Subscriptions"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		selected := properties.Default()
+		if id := r.URL.Query().Get("property"); id != "" {
+			p, err := properties.Property(property.PropertyID(id))
+			if err != nil {
+				http.Error(w, "Property not found", http.StatusNotFound)
+				return
+			}
+			selected = p
+		}
+		subs, err := reportService.List(r.Context(), selected.ID)
+		if err != nil {
+			http.Error(w, "Failed to load report subscriptions", http.StatusInternalServerError)
+			return
+		}
+
+		loc := properties.Location(selected.ID)
+		data := HttpAdminReportSubscriptionsResponse{
+			AppName:     appName,
+			Title:       title,
+			CSRFToken:   CSRFToken(r),
+			Property:    ReportPropertyView{ID: string(selected.ID), Name: selected.Name, Selected: true},
+			Types:       report.Types,
+			Frequencies: report.Frequencies,
+			Formats:     report.Formats,
+			Timezone:    loc.String(),
+		}
+		for _, p := range properties.Properties() {
+			data.Properties = append(data.Properties, ReportPropertyView{ID: string(p.ID), Name: p.Name, Selected: p.ID == selected.ID})
+		}
+		// The week starts on Monday, when most managers want their weekly report.
+		for i := range 7 {
+			data.Weekdays = append(data.Weekdays, time.Weekday((i+1)%7).String())
+		}
+		for _, sub := range subs {
+			emails := make([]string, len(sub.Recipients))
+			for i, recipient := range sub.Recipients {
+				emails[i] = recipient.Email
+			}
+			view := ReportSubscriptionView{
+				ID:         string(sub.ID),
+				Type:       string(sub.Type),
+				Schedule:   sub.Schedule.String(),
+				Format:     string(sub.Format),
+				Recipients: strings.Join(emails, ", "),
+				NextRunAt:  sub.NextRunAt.In(loc).Format("2006-01-02 15:04"),
+			}
+			if !sub.LastSentAt.IsZero() {
+				view.LastSentAt = sub.LastSentAt.In(loc).Format("2006-01-02 15:04")
+			}
+			data.Subscriptions = append(data.Subscriptions, view)
+		}
+
+		HttpView(e, "admin_report_subscriptions", data)(w, r)
+	}
+}
+
+// HttpAdminCreateReportSubscription handles the POST request to subscribe managers to a report with the
+// form values "property", "type", "frequency", "weekday" (e.g. Monday), "day", "hour", "format" and
+// "recipients" (separated by commas, spaces or lines).
+func HttpAdminCreateReportSubscription(reportService *report.Service, properties *property.Catalog, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		propertyID := property.PropertyID(r.FormValue("property"))
+		if _, err := properties.Property(propertyID); err != nil {
+			http.Error(w, "Property not found", http.StatusNotFound)
+			return
+		}
+		schedule, err := parseReportSchedule(r)
+		if err != nil {
+			reportSubscriptionError(w, err)
+			return
+		}
+		recipients := strings.FieldsFunc(r.FormValue("recipients"), func(c rune) bool {
+			return c == ',' || c == ';' || c == ' ' || c == '\n' || c == '\r' || c == '\t'
+		})
+		sub, err := reportService.Subscribe(r.Context(), report.SubscriptionID(security.GenerateID()), propertyID,
+			report.Type(r.FormValue("type")), schedule, report.Format(r.FormValue("format")), recipients)
+		if err != nil {
+			reportSubscriptionError(w, err)
+			return
+		}
+
+		logger.Info("report subscription created",
+			"audit", true,
+			"subscription_id", sub.ID,
+			"property_id", sub.PropertyID,
+			"report_type", sub.Type,
+			"schedule", sub.Schedule.String(),
+			"recipients", len(sub.Recipients),
+		)
+		reportSubscriptionsRedirect(w, r, propertyID)
+	}
+}
+
+// HttpAdminDeleteReportSubscription handles the POST request to delete the subscription given in the path.
+func HttpAdminDeleteReportSubscription(reportService *report.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := report.SubscriptionID(r.PathValue("id"))
+		sub, err := reportService.Get(r.Context(), id)
+		if err == nil {
+			err = reportService.Delete(r.Context(), id)
+		}
+		if err != nil {
+			reportSubscriptionError(w, err)
+			return
+		}
+
+		logger.Info("report subscription deleted",
+			"audit", true,
+			"subscription_id", sub.ID,
+			"property_id", sub.PropertyID,
+		)
+		reportSubscriptionsRedirect(w, r, sub.PropertyID)
+	}
+}
+
+// parseReportSchedule reads the schedule from the form; the fields the frequency does not use are ignored.
+func parseReportSchedule(r *http.Request) (report.Schedule, error) {
+	schedule := report.Schedule{Frequency: report.Frequency(r.FormValue("frequency"))}
+	hour, err := strconv.Atoi(r.FormValue("hour"))
+	if err != nil {
+		return report.Schedule{}, report.ErrInvalidSchedule
+	}
+	schedule.Hour = hour
+	switch schedule.Frequency {
+	case report.FrequencyWeekly:
+		schedule.Weekday = -1
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(day.String(), r.FormValue("weekday")) {
+				schedule.Weekday = day
+			}
+		}
+	case report.FrequencyMonthly:
+		if schedule.Day, err = strconv.Atoi(r.FormValue("day")); err != nil {
+			return report.Schedule{}, report.ErrInvalidSchedule
+		}
+	}
+	return schedule, schedule.Validate()
+}
+
+// reportSubscriptionError answers validation errors with 400, unknown subscriptions with 404
+// and all other errors with 500.
+func reportSubscriptionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, report.ErrInvalidType), errors.Is(err, report.ErrInvalidFormat), errors.Is(err, report.ErrInvalidSchedule),
+		errors.Is(err, report.ErrInvalidRecipient), errors.Is(err, report.ErrNoRecipients), errors.Is(err, report.ErrMissingProperty):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, report.ErrSubscriptionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "report subscription action failed", http.StatusInternalServerError)
+	}
+}
+
+// reportSubscriptionsRedirect redirects back to the console of the property.
+func reportSubscriptionsRedirect(w http.ResponseWriter, r *http.Request, propertyID property.PropertyID) {
+	target := "/admin/report-subscriptions?property=" + url.QueryEscape(string(propertyID))
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", target)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
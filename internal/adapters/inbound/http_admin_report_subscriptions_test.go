@@ -0,0 +1,211 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+)
+
+// ============================================================================
+// Report Subscription Test Helpers
+// ============================================================================
+
+// emptyStays is a property with ten rooms and no stays.
+type emptyStays struct{}
+
+func (emptyStays) Stays(ctx context.Context, propertyID report.PropertyID, from, to time.Time) ([]report.Stay, error) {
+	return nil, nil
+}
+
+func (emptyStays) Rooms(propertyID report.PropertyID) int { return 10 }
+
+// createTestReportService returns a report service for the properties ber and muc.
+func createTestReportService(t *testing.T) (*report.Service, *property.Catalog) {
+	t.Helper()
+	catalog, err := property.NewCatalog(
+		property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "Europe/Berlin"},
+		property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "Europe/Berlin"},
+	)
+	assert.That(t, "err must be nil", err, nil)
+	return report.NewService(resource.NewInMemoryAccess[report.SubscriptionID, report.Subscription](), emptyStays{}, catalog, "EUR"), catalog
+}
+
+// subscribeTestReport subscribes a manager to the weekly occupancy report of the property.
+func subscribeTestReport(t *testing.T, svc *report.Service, propertyID report.PropertyID) *report.Subscription {
+	t.Helper()
+	schedule := report.Schedule{Frequency: report.FrequencyWeekly, Weekday: time.Monday, Hour: 8}
+	sub, err := svc.Subscribe(context.Background(), "sub-"+report.SubscriptionID(propertyID), propertyID, report.TypeOccupancy, schedule, report.FormatHTML, []string{"manager@example.com"})
+	assert.That(t, "err must be nil", err, nil)
+	return sub
+}
+
+// ============================================================================
+// HttpAdminReportSubscriptions Tests
+// ============================================================================
+
+func Test_HttpAdminReportSubscriptions_Should_Render_Subscriptions_Of_Property(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, catalog := createTestReportService(t)
+	subscribeTestReport(t, svc, "ber")
+	subscribeTestReport(t, svc, "muc")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminReportSubscriptions(e, svc, catalog)(rec, httptest.NewRequest(http.MethodGet, "/admin/report-subscriptions?property=muc", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "property must be shown with its timezone", strings.Contains(body, "Hotel Munich Europe/Berlin"), true)
+	assert.That(t, "subscription must be listed", strings.Contains(body, "occupancy weekly on Monday at 08:00 html manager@example.com"), true)
+	assert.That(t, "only the subscription of the property must be listed", strings.Count(body, `class="subscription"`), 1)
+}
+
+func Test_HttpAdminReportSubscriptions_With_Session_Should_Render_CSRF_Token(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, catalog := createTestReportService(t)
+	req := adminSessionRequest(http.MethodGet, "https://sso.example.com/realms/staff", "session-1", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminReportSubscriptions(e, svc, catalog)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "forms must carry the CSRF token of the session", strings.Contains(rec.Body.String(), `value="`+inbound.CSRFToken(req)+`"`), true)
+}
+
+func Test_HttpAdminReportSubscriptions_With_Unknown_Property_Should_Return_404(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, catalog := createTestReportService(t)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminReportSubscriptions(e, svc, catalog)(rec, httptest.NewRequest(http.MethodGet, "/admin/report-subscriptions?property=ham", nil))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminCreateReportSubscription Tests
+// ============================================================================
+
+func Test_HttpAdminCreateReportSubscription_Should_Subscribe_And_Redirect(t *testing.T) {
+	// Arrange
+	svc, catalog := createTestReportService(t)
+	form := url.Values{
+		"property": {"muc"}, "type": {"revenue"}, "frequency": {"monthly"}, "weekday": {"Monday"}, "day": {"1"}, "hour": {"7"},
+		"format": {"xlsx"}, "recipients": {"gm@example.com,\nowner@example.com"},
+	}
+
+	// Act
+	rec := postForm("POST /admin/report-subscriptions", "/admin/report-subscriptions", inbound.HttpAdminCreateReportSubscription(svc, catalog, slog.Default()), form)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "redirect must show the property", rec.Header().Get("Location"), "/admin/report-subscriptions?property=muc")
+	subs, _ := svc.List(context.Background(), "muc")
+	assert.That(t, "subscription must be created", len(subs), 1)
+	assert.That(t, "schedule must be monthly", subs[0].Schedule, report.Schedule{Frequency: report.FrequencyMonthly, Day: 1, Hour: 7})
+	assert.That(t, "recipients must be split", len(subs[0].Recipients), 2)
+}
+
+func Test_HttpAdminCreateReportSubscription_Should_Return_400_For_Invalid_Forms(t *testing.T) {
+	for name, form := range map[string]url.Values{
+		"weekday":   {"property": {"ber"}, "type": {"occupancy"}, "frequency": {"weekly"}, "weekday": {"Someday"}, "hour": {"8"}, "format": {"html"}, "recipients": {"gm@example.com"}},
+		"hour":      {"property": {"ber"}, "type": {"occupancy"}, "frequency": {"daily"}, "hour": {"noon"}, "format": {"html"}, "recipients": {"gm@example.com"}},
+		"recipient": {"property": {"ber"}, "type": {"occupancy"}, "frequency": {"daily"}, "hour": {"8"}, "format": {"html"}, "recipients": {"gm"}},
+		"format":    {"property": {"ber"}, "type": {"occupancy"}, "frequency": {"daily"}, "hour": {"8"}, "format": {"pdf"}, "recipients": {"gm@example.com"}},
+	} {
+		// Arrange
+		svc, catalog := createTestReportService(t)
+
+		// Act
+		rec := postForm("POST /admin/report-subscriptions", "/admin/report-subscriptions", inbound.HttpAdminCreateReportSubscription(svc, catalog, slog.Default()), form)
+
+		// Assert
+		assert.That(t, "status code for invalid "+name+" must be 400", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// ============================================================================
+// HttpAdminDeleteReportSubscription Tests
+// ============================================================================
+
+func Test_HttpAdminDeleteReportSubscription_Should_Delete_And_Redirect(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService(t)
+	sub := subscribeTestReport(t, svc, "muc")
+	handler := inbound.HttpAdminDeleteReportSubscription(svc, slog.Default())
+
+	// Act
+	rec := postForm("POST /admin/report-subscriptions/{id}/delete", "/admin/report-subscriptions/"+string(sub.ID)+"/delete", handler, nil)
+	again := postForm("POST /admin/report-subscriptions/{id}/delete", "/admin/report-subscriptions/"+string(sub.ID)+"/delete", handler, nil)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "redirect must show the property", rec.Header().Get("Location"), "/admin/report-subscriptions?property=muc")
+	assert.That(t, "deleted subscription must return 404", again.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpViewReportUnsubscribe Tests
+// ============================================================================
+
+func Test_HttpViewReportUnsubscribe_Should_Ask_To_Confirm(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, _ := createTestReportService(t)
+	token := subscribeTestReport(t, svc, "ber").Recipients[0].Token
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ui/reports/unsubscribe/{token}", inbound.HttpViewReportUnsubscribe(e, svc))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ui/reports/unsubscribe/"+token, nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "page must name the report", strings.Contains(rec.Body.String(), "manager@example.com receives the occupancy report of ber weekly on Monday at 08:00"), true)
+	subs, _ := svc.List(context.Background(), "ber")
+	assert.That(t, "opening the link must not unsubscribe", len(subs), 1)
+}
+
+func Test_HttpReportUnsubscribe_Should_Unsubscribe_Once(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, _ := createTestReportService(t)
+	token := subscribeTestReport(t, svc, "ber").Recipients[0].Token
+	handler := inbound.HttpReportUnsubscribe(e, svc, slog.Default())
+
+	// Act
+	rec := postForm("POST /ui/reports/unsubscribe/{token}", "/ui/reports/unsubscribe/"+token, handler, nil)
+	again := postForm("POST /ui/reports/unsubscribe/{token}", "/ui/reports/unsubscribe/"+token, handler, nil)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "page must confirm", strings.Contains(rec.Body.String(), "manager@example.com unsubscribed"), true)
+	assert.That(t, "used link must return 404", again.Code, http.StatusNotFound)
+	assert.That(t, "used link must say so", strings.Contains(again.Body.String(), "Not subscribed"), true)
+}
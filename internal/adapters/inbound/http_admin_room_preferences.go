@@ -0,0 +1,116 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// roomPreferencesDefaultDays is how many days before and after today the preference report covers by default.
+const roomPreferencesDefaultDays = 90
+
+// roomPreferenceLabels name the room preferences for guests and staff.
+var roomPreferenceLabels = map[reservation.RoomPreference]string{
+	reservation.PreferHighFloor:        "High floor",
+	reservation.PreferAwayFromElevator: "Away from elevator",
+	reservation.PreferAdjoining:        "Adjoining rooms",
+}
+
+// HttpRoomPreferenceFulfillment is how often a room preference was requested and fulfilled.
+type HttpRoomPreferenceFulfillment struct {
+	Preference string `json:"preference"`
+	Requested  int    `json:"requested"`
+	Fulfilled  int    `json:"fulfilled"`
+	Rate       int    `json:"rate"` // percent of the requests fulfilled
+}
+
+// HttpAdminRoomPreferencesResponse specifies the JSON body of a preference fulfillment report.
+type HttpAdminRoomPreferencesResponse struct {
+	From         string                          `json:"from"` // YYYY-MM-DD, first check-in day
+	To           string                          `json:"to"`   // YYYY-MM-DD, exclusive
+	Reservations int                             `json:"reservations"`
+	WeightedRate int                             `json:"weighted_rate"` // percent of the requested weight fulfilled
+	Preferences  []HttpRoomPreferenceFulfillment `json:"preferences"`
+}
+
+// HttpAdminRoomPreferences returns how often the room preferences of the stays arriving from the from
+// to the to query parameter (YYYY-MM-DD, exclusive; default 90 days before and after today) were fulfilled, as JSON.
+func HttpAdminRoomPreferences(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		today := time.Now()
+		from, ok := parseReportDate(w, r, "from", today.AddDate(0, 0, -roomPreferencesDefaultDays))
+		if !ok {
+			return
+		}
+		to, ok := parseReportDate(w, r, "to", today.AddDate(0, 0, roomPreferencesDefaultDays))
+		if !ok {
+			return
+		}
+		report, err := reservationService.PreferenceReport(r.Context(), from, to)
+		if errors.Is(err, reservation.ErrInvalidPreferencePeriod) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to build preference report", http.StatusInternalServerError)
+			return
+		}
+
+		resp := HttpAdminRoomPreferencesResponse{
+			From:         report.From.Format(time.DateOnly),
+			To:           report.To.Format(time.DateOnly),
+			Reservations: report.Reservations,
+			WeightedRate: report.WeightedRate,
+			Preferences:  []HttpRoomPreferenceFulfillment{},
+		}
+		for _, p := range report.Preferences {
+			resp.Preferences = append(resp.Preferences, HttpRoomPreferenceFulfillment{
+				Preference: string(p.Preference),
+				Requested:  p.Requested,
+				Fulfilled:  p.Fulfilled,
+				Rate:       p.Rate,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// parseReportDate reads the date query parameter; it answers the request with an error and returns false if it is malformed.
+func parseReportDate(w http.ResponseWriter, r *http.Request, name string, def time.Time) (time.Time, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, true
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		http.Error(w, name+" must be a date (YYYY-MM-DD)", http.StatusBadRequest)
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// roomPreferenceDescriptions describes the preferences of the reservation and whether its room fulfills them,
+// e.g. "High floor (fulfilled)"; preferences of reservations made without room allocation are listed as requested.
+func roomPreferenceDescriptions(res *reservation.Reservation) []string {
+	var descriptions []string
+	for _, pref := range reservation.RoomPreferenceKinds {
+		if res.RoomPreferences[pref] == 0 {
+			continue
+		}
+		description := roomPreferenceLabels[pref]
+		switch {
+		case res.PreferencesMet == nil:
+		case slices.Contains(res.PreferencesMet, pref):
+			description += " (fulfilled)"
+		default:
+			description += " (not available)"
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
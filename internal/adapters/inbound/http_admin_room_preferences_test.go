@@ -0,0 +1,69 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// createPreferenceTestService creates a reservation service with two stays next week asking for a high
+// floor, of which one got it, and one also asking for a room away from the elevator, which it did not get.
+func createPreferenceTestService() *reservation.Service {
+	repo := newMockReservationRepository()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	for i, id := range []string{"res-001", "res-002"} {
+		res := createTestReservation(id, "guest@example.com", "room-201", checkIn, checkIn.AddDate(0, 0, 2))
+		res.RoomPreferences = reservation.RoomPreferences{reservation.PreferHighFloor: 2}
+		res.PreferencesMet = []reservation.RoomPreference{}
+		if i == 0 {
+			res.RoomPreferences[reservation.PreferAwayFromElevator] = 2
+			res.PreferencesMet = []reservation.RoomPreference{reservation.PreferHighFloor}
+		}
+		repo.put(res.ID, *res)
+	}
+	return createReservationsTestService(repo)
+}
+
+// ============================================================================
+// HttpAdminRoomPreferences Tests
+// ============================================================================
+
+func Test_HttpAdminRoomPreferences_Should_Return_Fulfillment_As_JSON(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminRoomPreferences(createPreferenceTestService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/room-preferences", nil))
+
+	// Assert
+	var report inbound.HttpAdminRoomPreferencesResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err == nil, true)
+	assert.That(t, "both reservations must be counted", report.Reservations, 2)
+	assert.That(t, "high floor must come first", report.Preferences[0].Preference, "high_floor")
+	assert.That(t, "high floor rate must be 50%", report.Preferences[0].Rate, 50)
+	assert.That(t, "away from elevator must never be fulfilled", report.Preferences[1].Fulfilled, 0)
+	assert.That(t, "weighted rate must be 2 of 6", report.WeightedRate, 33)
+}
+
+func Test_HttpAdminRoomPreferences_With_Invalid_Period_Should_Return_400(t *testing.T) {
+	for _, query := range []string{"?from=01.07.2026", "?from=2026-07-01&to=2026-07-01"} {
+		// Arrange
+		handler := inbound.HttpAdminRoomPreferences(createPreferenceTestService())
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler(rec, httptest.NewRequest(http.MethodGet, "/admin/room-preferences"+query, nil))
+
+		// Assert
+		assert.That(t, "status code must be 400 for "+query, rec.Code, http.StatusBadRequest)
+	}
+}
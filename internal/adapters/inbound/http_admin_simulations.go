@@ -0,0 +1,177 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Limits of the replayed booking history.
+const (
+	simulationDefaultMonths = 6
+	simulationMaxMonths     = 36
+)
+
+// HttpAdminSimulationRequest specifies the body of a pricing simulation:
+// the proposed rules are replayed against the bookings of the last Months months.
+type HttpAdminSimulationRequest struct {
+	Months       int                          `json:"months"`   // default 6
+	Currency     string                       `json:"currency"` // default USD
+	Rules        []HttpAdminPricingRule       `json:"rules"`
+	Cancellation *HttpAdminCancellationPolicy `json:"cancellation,omitempty"`
+}
+
+// HttpAdminPricingRule is a proposed pricing rule, e.g. {"name": "weekend", "weekdays": ["fri", "sat"], "percent": 15}.
+type HttpAdminPricingRule struct {
+	Name      string   `json:"name"`
+	RoomIDs   []string `json:"room_ids,omitempty"`
+	Weekdays  []string `json:"weekdays,omitempty"` // monday or mon, ...
+	MinNights int      `json:"min_nights,omitempty"`
+	Percent   int      `json:"percent"`
+}
+
+// HttpAdminCancellationPolicy is a proposed cancellation policy.
+type HttpAdminCancellationPolicy struct {
+	NoticeHours int `json:"notice_hours"`
+	FeePercent  int `json:"fee_percent"`
+}
+
+// HttpAdminSimulationResponse specifies the JSON body of a simulation report.
+type HttpAdminSimulationResponse struct {
+	From            string                   `json:"from"` // YYYY-MM-DD
+	To              string                   `json:"to"`   // YYYY-MM-DD
+	Bookings        int                      `json:"bookings"`
+	Affected        int                      `json:"affected"`
+	Skipped         int                      `json:"skipped"`
+	CurrentRevenue  APIMoney                 `json:"current_revenue"`
+	ProposedRevenue APIMoney                 `json:"proposed_revenue"`
+	RevenueDelta    APIMoney                 `json:"revenue_delta"`
+	Impacts         []HttpAdminBookingImpact `json:"impacts"`
+}
+
+// HttpAdminBookingImpact is an affected booking of a simulation report.
+type HttpAdminBookingImpact struct {
+	ReservationID string   `json:"reservation_id"`
+	RoomID        string   `json:"room_id"`
+	CheckIn       string   `json:"check_in"` // YYYY-MM-DD
+	Nights        int      `json:"nights"`
+	Status        string   `json:"status"`
+	Current       APIMoney `json:"current"`
+	Proposed      APIMoney `json:"proposed"`
+	Delta         APIMoney `json:"delta"`
+	Reasons       []string `json:"reasons"`
+}
+
+// HttpAdminSimulatePricing replays the bookings of the last months against proposed pricing rules
+// and a cancellation policy and returns the revenue delta and the affected bookings as JSON.
+// Nothing is changed, so revenue managers can try rules before enabling them.
+func HttpAdminSimulatePricing(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminSimulationRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Months == 0 {
+			req.Months = simulationDefaultMonths
+		}
+		if req.Months < 0 || req.Months > simulationMaxMonths {
+			http.Error(w, fmt.Sprintf("months must be between 1 and %d", simulationMaxMonths), http.StatusBadRequest)
+			return
+		}
+		scenario, err := buildPricingScenario(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		to := time.Now()
+		report, err := reservationService.SimulatePricing(r.Context(), scenario, to.AddDate(0, -req.Months, 0), to)
+		if err != nil {
+			if errors.Is(err, reservation.ErrInvalidScenario) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "failed to simulate pricing", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(buildSimulationResponse(report))
+	}
+}
+
+// buildPricingScenario converts the request to a scenario of the reservation context.
+func buildPricingScenario(req HttpAdminSimulationRequest) (reservation.PricingScenario, error) {
+	scenario := reservation.PricingScenario{Currency: req.Currency}
+	if scenario.Currency == "" {
+		scenario.Currency = "USD"
+	}
+	for _, rule := range req.Rules {
+		pricingRule := reservation.PricingRule{Name: rule.Name, MinNights: rule.MinNights, Percent: rule.Percent}
+		for _, id := range rule.RoomIDs {
+			pricingRule.RoomIDs = append(pricingRule.RoomIDs, reservation.RoomID(id))
+		}
+		for _, name := range rule.Weekdays {
+			weekday, ok := parseWeekday(name)
+			if !ok {
+				return scenario, fmt.Errorf("unknown weekday %q in rule %q", name, rule.Name)
+			}
+			pricingRule.Weekdays = append(pricingRule.Weekdays, weekday)
+		}
+		scenario.Rules = append(scenario.Rules, pricingRule)
+	}
+	if c := req.Cancellation; c != nil {
+		scenario.Cancellation = &reservation.CancellationPolicy{
+			NoticePeriod: time.Duration(c.NoticeHours) * time.Hour,
+			FeePercent:   c.FeePercent,
+		}
+	}
+	return scenario, nil
+}
+
+// parseWeekday parses an English weekday name or its three-letter abbreviation.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// buildSimulationResponse converts the report to its JSON representation.
+func buildSimulationResponse(report *reservation.SimulationReport) HttpAdminSimulationResponse {
+	resp := HttpAdminSimulationResponse{
+		From:            report.From.Format("2006-01-02"),
+		To:              report.To.Format("2006-01-02"),
+		Bookings:        report.Bookings,
+		Affected:        report.Affected,
+		Skipped:         report.Skipped,
+		CurrentRevenue:  APIMoney{Amount: report.CurrentRevenue.Amount, Currency: report.CurrentRevenue.Currency},
+		ProposedRevenue: APIMoney{Amount: report.ProposedRevenue.Amount, Currency: report.ProposedRevenue.Currency},
+		RevenueDelta:    APIMoney{Amount: report.RevenueDelta.Amount, Currency: report.RevenueDelta.Currency},
+		Impacts:         make([]HttpAdminBookingImpact, 0, len(report.Impacts)),
+	}
+	for _, impact := range report.Impacts {
+		resp.Impacts = append(resp.Impacts, HttpAdminBookingImpact{
+			ReservationID: string(impact.ReservationID),
+			RoomID:        string(impact.RoomID),
+			CheckIn:       impact.CheckIn.Format("2006-01-02"),
+			Nights:        impact.Nights,
+			Status:        string(impact.Status),
+			Current:       APIMoney{Amount: impact.Current.Amount, Currency: impact.Current.Currency},
+			Proposed:      APIMoney{Amount: impact.Proposed.Amount, Currency: impact.Proposed.Currency},
+			Delta:         APIMoney{Amount: impact.Delta.Amount, Currency: impact.Delta.Currency},
+			Reasons:       impact.Reasons,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,100 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// createSimulationTestService creates a reservation service with a 2-night booking of 20000 USD.
+func createSimulationTestService(t *testing.T) *reservation.Service {
+	t.Helper()
+	reservationService := createTestReservationService(t)
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	_, _ = reservationService.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", dateRange, shared.NewMoney(20000, "USD"),
+		[]reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")})
+	return reservationService
+}
+
+func postSimulation(handler http.HandlerFunc, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/simulations", strings.NewReader(body)))
+	return rec
+}
+
+// ============================================================================
+// HttpAdminSimulatePricing Tests
+// ============================================================================
+
+func Test_HttpAdminSimulatePricing_Should_Return_Report_As_JSON(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminSimulatePricing(createSimulationTestService(t))
+
+	// Act
+	rec := postSimulation(handler, `{"months":3,"rules":[{"name":"summer","percent":10}]}`)
+
+	// Assert
+	var report inbound.HttpAdminSimulationResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", err == nil, true)
+	assert.That(t, "bookings must be 1", report.Bookings, 1)
+	assert.That(t, "revenue delta must be 10%", report.RevenueDelta, inbound.APIMoney{Amount: 2000, Currency: "USD"})
+	assert.That(t, "impact must name the reservation", report.Impacts[0].ReservationID, "res-001")
+}
+
+func Test_HttpAdminSimulatePricing_Should_Accept_Weekday_Names(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminSimulatePricing(createSimulationTestService(t))
+
+	// Act
+	rec := postSimulation(handler, `{"rules":[{"name":"all week","weekdays":["mon","Tuesday","wed","thu","fri","sat","sun"],"percent":-50}]}`)
+
+	// Assert
+	var report inbound.HttpAdminSimulationResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "revenue delta must be -50%", report.RevenueDelta.Amount, int64(-10000))
+}
+
+func Test_HttpAdminSimulatePricing_With_Unknown_Weekday_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminSimulatePricing(createSimulationTestService(t))
+
+	// Act
+	rec := postSimulation(handler, `{"rules":[{"name":"weekend","weekdays":["caturday"],"percent":10}]}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminSimulatePricing_With_Invalid_Scenario_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminSimulatePricing(createSimulationTestService(t))
+
+	// Act
+	rec := postSimulation(handler, `{"cancellation":{"notice_hours":48,"fee_percent":120}}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminSimulatePricing_With_Too_Many_Months_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminSimulatePricing(createSimulationTestService(t))
+
+	// Act
+	rec := postSimulation(handler, `{"months":120}`)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
@@ -0,0 +1,158 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HttpAdminAssignTierRequest specifies the body of a manual tier change.
+type HttpAdminAssignTierRequest struct {
+	Tier       string `json:"tier"` // "gold", "platinum" or "" to remove the manual tier
+	Note       string `json:"note"`
+	AssignedBy string `json:"assigned_by"`
+}
+
+// VIPGuestView represents a guest with a VIP tier for the view.
+type VIPGuestView struct {
+	GuestID        string
+	Tier           string
+	Source         string
+	CompletedStays int
+	Perks          []string
+}
+
+// HttpAdminTiersResponse specifies the view data for the VIP guests page.
+type HttpAdminTiersResponse struct {
+	AppName string
+	Title   string
+	Guests  []VIPGuestView
+}
+
+// HttpAdminTiers defines an HTTP handler function for rendering the VIP guests with their tier badges.
+func HttpAdminTiers(e *templating.Engine, profileService *profile.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - VIP Guests"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		tiers, err := profileService.Tiers(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to load VIP guests", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpAdminTiersResponse{
+			AppName: appName,
+			Title:   title,
+		}
+		for _, status := range tiers {
+			data.Guests = append(data.Guests, VIPGuestView{
+				GuestID:        string(status.GuestID),
+				Tier:           string(status.Tier),
+				Source:         string(status.Source),
+				CompletedStays: status.CompletedStays,
+				Perks:          perkLabels(bookingPerks(status), requestLocale(r)),
+			})
+		}
+
+		HttpView(e, "admin_tiers", data)(w, r)
+	}
+}
+
+// HttpAdminAssignTier tags the guest given in the path with a tier and returns the effective tier as JSON.
+func HttpAdminAssignTier(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminAssignTierRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.AssignedBy == "" {
+			req.AssignedBy = "admin"
+		}
+
+		guestID := profile.GuestID(r.PathValue("guest"))
+		status, err := profileService.AssignTier(r.Context(), guestID, profile.Tier(req.Tier), req.Note, req.AssignedBy)
+		if err != nil {
+			if errors.Is(err, profile.ErrInvalidTier) || errors.Is(err, profile.ErrMissingProfile) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "failed to assign tier", http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("guest tier assigned",
+			"audit", true,
+			"guest_id", guestID,
+			"tier", req.Tier,
+			"assigned_by", req.AssignedBy,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
+
+// guestPerks returns the perks of the guest's effective tier.
+// Perks are a benefit, not a precondition: without a profile service or on errors the guest books without them.
+func guestPerks(ctx context.Context, profileService *profile.Service, guestID reservation.GuestID) reservation.Perks {
+	if profileService == nil {
+		return reservation.Perks{}
+	}
+	status, err := profileService.TierOf(ctx, profile.GuestID(guestID))
+	if err != nil {
+		return reservation.Perks{}
+	}
+	return bookingPerks(*status)
+}
+
+// storeGuestLanguage stores the language as the guest's preferred language before booking,
+// so the confirmation is already written in it. Like perks, the preference is not a precondition:
+// without a profile service, without a language or on errors the guest books as before.
+func storeGuestLanguage(ctx context.Context, profileService *profile.Service, guestID reservation.GuestID, language string) {
+	if profileService == nil || language == "" {
+		return
+	}
+	_, _ = profileService.SetLanguage(ctx, profile.GuestID(guestID), language)
+}
+
+// bookingPerks converts a tier status to the perks applied to a reservation.
+func bookingPerks(status profile.TierStatus) reservation.Perks {
+	if status.Tier == profile.TierNone {
+		return reservation.Perks{}
+	}
+	return reservation.Perks{
+		Tier:            string(status.Tier),
+		LateCheckout:    status.Perks.LateCheckout,
+		UpgradePriority: status.Perks.UpgradePriority,
+		DiscountPercent: status.Perks.DiscountPercent,
+	}
+}
+
+// perkLabels describes the perks for the views.
+func perkLabels(perks reservation.Perks, locale shared.Locale) []string {
+	var labels []string
+	if perks.LateCheckout {
+		labels = append(labels, "Free late checkout")
+	}
+	if perks.UpgradePriority {
+		labels = append(labels, "Upgrade priority")
+	}
+	switch {
+	case perks.Discount.Amount > 0:
+		labels = append(labels, fmt.Sprintf("%d%% discount (%s saved)", perks.DiscountPercent, perks.Discount.FormatIn(locale)))
+	case perks.DiscountPercent > 0:
+		labels = append(labels, fmt.Sprintf("%d%% discount", perks.DiscountPercent))
+	}
+	return labels
+}
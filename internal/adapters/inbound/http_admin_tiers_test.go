@@ -0,0 +1,122 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// HttpAdminTiers Tests
+// ============================================================================
+
+func Test_HttpAdminTiers_Should_Render_Tier_Badges(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, _ := createTestProfileService(t)
+	_, _ = svc.AssignTier(context.Background(), "guest-main", profile.TierPlatinum, "Owner's friend", "admin")
+	handler := inbound.HttpAdminTiers(e, svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/tiers", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain badge", strings.Contains(body, `<span class="tier-badge tier-badge--platinum">platinum</span> manual`), true)
+	assert.That(t, "body must contain perks", strings.Contains(body, "Upgrade priority"), true)
+}
+
+// ============================================================================
+// HttpAdminAssignTier Tests
+// ============================================================================
+
+func Test_HttpAdminAssignTier_Should_Tag_Guest(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /admin/tiers/{guest}", inbound.HttpAdminAssignTier(svc, slog.Default()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/tiers/guest-main", strings.NewReader(`{"tier":"gold"}`)))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	status, _ := svc.TierOf(context.Background(), "guest-main")
+	assert.That(t, "tier must be gold", status.Tier, profile.TierGold)
+}
+
+func Test_HttpAdminAssignTier_With_Unknown_Tier_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /admin/tiers/{guest}", inbound.HttpAdminAssignTier(svc, slog.Default()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/tiers/guest-main", strings.NewReader(`{"tier":"diamond"}`)))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// VIP Perks Tests
+// ============================================================================
+
+func Test_HttpCreateReservation_With_VIP_Guest_Should_Apply_Perks(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	reservationService := createFormTestService(repo)
+	profileService := profile.NewService(outbound.NewReservationGuestDirectory(reservationService),
+		resource.NewInMemoryAccess[profile.MergeID, profile.Merge](),
+		resource.NewInMemoryAccess[profile.GuestID, profile.TierAssignment](),
+		profile.DefaultTierPolicy())
+	_, _ = profileService.AssignTier(context.Background(), "user-subject-456", profile.TierGold, "", "admin")
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ProfileService: profileService, ReservationService: reservationService})
+
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 9).Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	var res reservation.Reservation
+	for _, r := range repo.all() {
+		res = r
+	}
+	assert.That(t, "tier must be recorded", res.Perks.Tier, "gold")
+	assert.That(t, "late checkout must be granted", res.Perks.LateCheckout, true)
+	assert.That(t, "total of 2 nights must be discounted by 5%", res.TotalAmount, shared.NewMoney(18810, "USD"))
+}
@@ -0,0 +1,198 @@
+package inbound
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// This file contains the JSON API of the webhooks, so channel managers can be onboarded by scripts
+// instead of the console: integrator endpoints and the dead-lettered events.
+
+// HttpWebhookEndpoint represents an integrator endpoint in the JSON API.
+type HttpWebhookEndpoint struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Topics    []string  `json:"topics"` // empty subscribes to all
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HttpAdminWebhookEndpointRequest specifies the body of an endpoint registration,
+// e.g. {"url": "https://cm.example.com/hooks", "topics": ["reservation.created"]}.
+// A random secret is generated if none is given.
+type HttpAdminWebhookEndpointRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Topics []string `json:"topics"`
+}
+
+// HttpWebhookDeadLetter represents an event whose delivery failed on every attempt.
+type HttpWebhookDeadLetter struct {
+	ID         string    `json:"id"`
+	EndpointID string    `json:"endpoint_id"`
+	EventID    string    `json:"event_id"`
+	Topic      string    `json:"topic"`
+	Payload    string    `json:"payload"`
+	Attempts   int       `json:"attempts"`
+	LastStatus int       `json:"last_status"`
+	LastError  string    `json:"last_error"`
+	DeadAt     time.Time `json:"dead_at"`
+}
+
+// HttpWebhookDelivery represents the outcome of a delivery in the JSON API.
+type HttpWebhookDelivery struct {
+	ID           string    `json:"id"`
+	EndpointID   string    `json:"endpoint_id"`
+	EventID      string    `json:"event_id"`
+	Topic        string    `json:"topic"`
+	RedeliveryOf string    `json:"redelivery_of,omitempty"`
+	StatusCode   int       `json:"status_code"`
+	LatencyMs    int64     `json:"latency_ms"`
+	Error        string    `json:"error,omitempty"`
+	AttemptedAt  time.Time `json:"attempted_at"`
+	Succeeded    bool      `json:"succeeded"`
+}
+
+// HttpAdminListWebhookEndpoints returns the integrator endpoints as JSON, oldest first.
+func HttpAdminListWebhookEndpoints(webhookService *webhook.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoints, err := webhookService.Endpoints(r.Context())
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+		out := make([]HttpWebhookEndpoint, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			out = append(out, webhookEndpointJSON(&endpoint))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+}
+
+// HttpAdminCreateWebhookEndpoint registers an integrator endpoint from the JSON body
+// and answers 201 Created with the endpoint, including its secret.
+func HttpAdminCreateWebhookEndpoint(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminWebhookEndpointRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Secret == "" {
+			req.Secret = security.GenerateID()
+		}
+		endpoint, err := webhookService.RegisterEndpoint(r.Context(), webhook.EndpointID(security.GenerateID()), req.URL, req.Secret, req.Topics)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook endpoint registered",
+			"audit", true,
+			"endpoint_id", endpoint.ID,
+			"url", endpoint.URL,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(webhookEndpointJSON(endpoint))
+	}
+}
+
+// HttpAdminWebhookDeadLetters returns the dead-lettered events as JSON, most recently dead first.
+func HttpAdminWebhookDeadLetters(webhookService *webhook.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deadLetters, err := webhookService.DeadLetters(r.Context())
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+		out := make([]HttpWebhookDeadLetter, 0, len(deadLetters))
+		for _, d := range deadLetters {
+			out = append(out, HttpWebhookDeadLetter{
+				ID:         string(d.ID),
+				EndpointID: string(d.EndpointID),
+				EventID:    d.EventID,
+				Topic:      d.Topic,
+				Payload:    d.Payload,
+				Attempts:   d.Attempts,
+				LastStatus: d.LastStatus,
+				LastError:  d.LastError,
+				DeadAt:     d.DeadAt.UTC(),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+}
+
+// HttpAdminReplayWebhookDeadLetter sends the dead-lettered event given in the path once more
+// and returns the delivery; the dead letter is removed if it succeeded.
+func HttpAdminReplayWebhookDeadLetter(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deadLetterID := webhook.DeliveryID(r.PathValue("id"))
+		delivery, err := webhookService.ReplayDeadLetter(r.Context(), webhook.DeliveryID(security.GenerateID()), deadLetterID)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook dead letter replayed",
+			"audit", true,
+			"endpoint_id", delivery.EndpointID,
+			"delivery_id", delivery.ID,
+			"dead_letter_id", deadLetterID,
+			"status", delivery.StatusCode,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HttpWebhookDelivery{
+			ID:           string(delivery.ID),
+			EndpointID:   string(delivery.EndpointID),
+			EventID:      delivery.EventID,
+			Topic:        delivery.Topic,
+			RedeliveryOf: string(delivery.RedeliveryOf),
+			StatusCode:   delivery.StatusCode,
+			LatencyMs:    delivery.Latency.Milliseconds(),
+			Error:        delivery.Error,
+			AttemptedAt:  delivery.AttemptedAt.UTC(),
+			Succeeded:    delivery.Succeeded(),
+		})
+	}
+}
+
+// HttpAdminDiscardWebhookDeadLetter deletes the dead-lettered event given in the path.
+func HttpAdminDiscardWebhookDeadLetter(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deadLetterID := webhook.DeliveryID(r.PathValue("id"))
+		if err := webhookService.DiscardDeadLetter(r.Context(), deadLetterID); err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook dead letter discarded",
+			"audit", true,
+			"dead_letter_id", deadLetterID,
+		)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// webhookEndpointJSON returns the endpoint for the JSON API.
+func webhookEndpointJSON(endpoint *webhook.Endpoint) HttpWebhookEndpoint {
+	topics := endpoint.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return HttpWebhookEndpoint{
+		ID:        string(endpoint.ID),
+		URL:       endpoint.URL,
+		Secret:    endpoint.Secret,
+		Topics:    topics,
+		CreatedAt: endpoint.CreatedAt.UTC(),
+	}
+}
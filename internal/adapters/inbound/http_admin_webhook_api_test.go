@@ -0,0 +1,130 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// HttpAdminCreateWebhookEndpoint Tests
+// ============================================================================
+
+func Test_HttpAdminCreateWebhookEndpoint_Should_Return_Endpoint_With_Secret(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t)
+	handler := inbound.HttpAdminCreateWebhookEndpoint(svc, slog.Default())
+	body := `{"url": "https://cm.example.com/hooks", "topics": ["reservation.created"]}`
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/endpoints", strings.NewReader(body)))
+
+	// Assert
+	var endpoint inbound.HttpWebhookEndpoint
+	_ = json.NewDecoder(rec.Body).Decode(&endpoint)
+	assert.That(t, "status must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "url must match", endpoint.URL, "https://cm.example.com/hooks")
+	assert.That(t, "secret must be generated", endpoint.Secret != "", true)
+	endpoints, _ := svc.Endpoints(context.Background())
+	assert.That(t, "endpoint must be stored", len(endpoints), 2)
+}
+
+func Test_HttpAdminCreateWebhookEndpoint_With_Unknown_Topic_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t)
+	handler := inbound.HttpAdminCreateWebhookEndpoint(svc, slog.Default())
+	body := `{"url": "https://cm.example.com/hooks", "topics": ["room.cleaned"]}`
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/endpoints", strings.NewReader(body)))
+
+	// Assert
+	assert.That(t, "status must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Dead Letter Tests
+// ============================================================================
+
+// createTestDeadLetter returns a webhook service without retries left and a dead-lettered event.
+func createTestDeadLetter(t *testing.T) *webhook.Service {
+	t.Helper()
+	svc := webhook.NewService(
+		resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](),
+		resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Delivery](),
+		&mockWebhookSender{status: http.StatusInternalServerError},
+	).WithRetries(resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Retry](), webhook.RetryPolicy{MaxAttempts: 1, Backoff: time.Minute})
+	_, err := svc.RegisterEndpoint(context.Background(), "ep-1", "https://example.com/hooks", "secret", nil)
+	assert.That(t, "err must be nil", err, nil)
+	_, err = svc.DeliverEvent(context.Background(), "evt-1", "payment.captured", json.RawMessage(`{}`))
+	assert.That(t, "err must be nil", err, nil)
+	return svc
+}
+
+func Test_HttpAdminWebhookDeadLetters_Should_Return_Dead_Letters(t *testing.T) {
+	// Arrange
+	svc := createTestDeadLetter(t)
+	handler := inbound.HttpAdminWebhookDeadLetters(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks/dead-letters", nil))
+
+	// Assert
+	var deadLetters []inbound.HttpWebhookDeadLetter
+	_ = json.NewDecoder(rec.Body).Decode(&deadLetters)
+	assert.That(t, "status must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "one dead letter must be listed", len(deadLetters), 1)
+	assert.That(t, "dead letter must record the status", deadLetters[0].LastStatus, http.StatusInternalServerError)
+}
+
+func Test_HttpAdminDiscardWebhookDeadLetter_Should_Delete_Dead_Letter(t *testing.T) {
+	// Arrange
+	svc := createTestDeadLetter(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /admin/webhooks/dead-letters/{id}", inbound.HttpAdminDiscardWebhookDeadLetter(svc, slog.Default()))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/webhooks/dead-letters/ep-1-evt-1", nil))
+	again := httptest.NewRecorder()
+	mux.ServeHTTP(again, httptest.NewRequest(http.MethodDelete, "/admin/webhooks/dead-letters/ep-1-evt-1", nil))
+
+	// Assert
+	assert.That(t, "status must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "status of the second discard must be 404", again.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// SubscribeWebhookEvents Tests
+// ============================================================================
+
+func Test_SubscribeWebhookEvents_Should_Deliver_Events_To_Endpoints(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestWebhookService(t)
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	_ = inbound.SubscribeWebhookEvents(ctx, dispatcher, svc)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage("payment.captured", []byte(`{"payment_id":"pay-1","reservation_id":"res-1"}`)))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	deliveries, _ := svc.Deliveries(ctx, endpoint.ID, 0)
+	assert.That(t, "endpoint must receive the event", len(deliveries), 1)
+	assert.That(t, "event must be the capture", deliveries[0].Topic, "payment.captured")
+}
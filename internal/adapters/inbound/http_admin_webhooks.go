@@ -0,0 +1,220 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// webhookConsoleDeliveries is the number of recent deliveries shown per endpoint.
+const webhookConsoleDeliveries = 20
+
+// WebhookEndpointView represents a webhook endpoint for the view.
+type WebhookEndpointView struct {
+	ID       string
+	URL      string
+	Secret   string
+	Topics   string
+	Selected bool
+}
+
+// WebhookDeliveryView represents a webhook delivery for the view.
+type WebhookDeliveryView struct {
+	ID           string
+	EventID      string
+	Topic        string
+	Payload      string
+	StatusCode   int
+	LatencyMs    int64
+	Error        string
+	Test         bool
+	RedeliveryOf string
+	AttemptedAt  string
+	Succeeded    bool
+}
+
+// HttpAdminWebhooksResponse specifies the view data for the webhook test console.
+// The forms carry the CSRF token of the session, so signed-in administrators can submit them.
+type HttpAdminWebhooksResponse struct {
+	AppName    string
+	Title      string
+	CSRFToken  string
+	Endpoints  []WebhookEndpointView
+	Selected   *WebhookEndpointView
+	Deliveries []WebhookDeliveryView
+	Topics     []string
+}
+
+// HttpAdminWebhooks defines an HTTP handler function for rendering the webhook test console.
+// It shows the recent deliveries of the endpoint given by the "endpoint" query parameter (default: the first).
+func HttpAdminWebhooks(e *templating.Engine, webhookService *webhook.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Webhooks"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoints, err := webhookService.Endpoints(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to load webhook endpoints", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpAdminWebhooksResponse{
+			AppName:   appName,
+			Title:     title,
+			CSRFToken: CSRFToken(r),
+			Topics:    webhook.Topics,
+		}
+		selectedID := r.URL.Query().Get("endpoint")
+		if selectedID == "" && len(endpoints) > 0 {
+			selectedID = string(endpoints[0].ID)
+		}
+		for _, endpoint := range endpoints {
+			data.Endpoints = append(data.Endpoints, WebhookEndpointView{
+				ID:       string(endpoint.ID),
+				URL:      endpoint.URL,
+				Secret:   endpoint.Secret,
+				Topics:   strings.Join(endpoint.Topics, ", "),
+				Selected: string(endpoint.ID) == selectedID,
+			})
+		}
+		for i := range data.Endpoints {
+			if data.Endpoints[i].Selected {
+				data.Selected = &data.Endpoints[i]
+			}
+		}
+
+		if data.Selected != nil {
+			deliveries, err := webhookService.Deliveries(r.Context(), webhook.EndpointID(data.Selected.ID), webhookConsoleDeliveries)
+			if err != nil {
+				http.Error(w, "Failed to load webhook deliveries", http.StatusInternalServerError)
+				return
+			}
+			for _, d := range deliveries {
+				data.Deliveries = append(data.Deliveries, WebhookDeliveryView{
+					ID:           string(d.ID),
+					EventID:      d.EventID,
+					Topic:        d.Topic,
+					Payload:      indentJSON(d.Payload),
+					StatusCode:   d.StatusCode,
+					LatencyMs:    d.Latency.Milliseconds(),
+					Error:        d.Error,
+					Test:         d.Test,
+					RedeliveryOf: string(d.RedeliveryOf),
+					AttemptedAt:  d.AttemptedAt.Format("2006-01-02 15:04:05"),
+					Succeeded:    d.Succeeded(),
+				})
+			}
+		}
+
+		HttpView(e, "admin_webhooks", data)(w, r)
+	}
+}
+
+// HttpAdminRegisterWebhook handles the POST request to register an endpoint with the form values
+// "url", "secret" and "topics" (repeated). A random secret is generated if none is given.
+func HttpAdminRegisterWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secret := r.FormValue("secret")
+		if secret == "" {
+			secret = security.GenerateID()
+		}
+		endpoint, err := webhookService.RegisterEndpoint(r.Context(), webhook.EndpointID(security.GenerateID()), r.FormValue("url"), secret, r.Form["topics"])
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook endpoint registered",
+			"audit", true,
+			"endpoint_id", endpoint.ID,
+			"url", endpoint.URL,
+		)
+		webhookConsoleRedirect(w, r, endpoint.ID)
+	}
+}
+
+// HttpAdminSendTestWebhook handles the POST request to send a test event of the form value "topic"
+// to the endpoint given in the path.
+func HttpAdminSendTestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpointID := webhook.EndpointID(r.PathValue("id"))
+		delivery, err := webhookService.SendTestEvent(r.Context(), webhook.DeliveryID(security.GenerateID()), endpointID, r.FormValue("topic"))
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook test event sent",
+			"audit", true,
+			"endpoint_id", endpointID,
+			"delivery_id", delivery.ID,
+			"topic", delivery.Topic,
+			"status", delivery.StatusCode,
+		)
+		webhookConsoleRedirect(w, r, endpointID)
+	}
+}
+
+// HttpAdminRedeliverWebhook handles the POST request to send the delivery given in the path again.
+func HttpAdminRedeliverWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deliveryID := webhook.DeliveryID(r.PathValue("id"))
+		delivery, err := webhookService.Redeliver(r.Context(), webhook.DeliveryID(security.GenerateID()), deliveryID)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info("webhook redelivered",
+			"audit", true,
+			"endpoint_id", delivery.EndpointID,
+			"delivery_id", delivery.ID,
+			"redelivery_of", deliveryID,
+			"status", delivery.StatusCode,
+		)
+		webhookConsoleRedirect(w, r, delivery.EndpointID)
+	}
+}
+
+// webhookError answers validation errors with 400, unknown endpoints, deliveries and dead letters with 404
+// and all other errors with 500.
+func webhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrMissingSecret), errors.Is(err, webhook.ErrUnknownTopic),
+		errors.Is(err, webhook.ErrNotPublicURL), errors.Is(err, webhook.ErrGuestDisabled):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, webhook.ErrEndpointNotFound), errors.Is(err, webhook.ErrDeliveryNotFound), errors.Is(err, webhook.ErrDeadLetterNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "webhook action failed", http.StatusInternalServerError)
+	}
+}
+
+// webhookConsoleRedirect redirects back to the console showing the endpoint.
+func webhookConsoleRedirect(w http.ResponseWriter, r *http.Request, endpointID webhook.EndpointID) {
+	target := "/admin/webhooks?endpoint=" + url.QueryEscape(string(endpointID))
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", target)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// indentJSON pretty-prints a JSON payload for the view, or returns it unchanged if it is no valid JSON.
+func indentJSON(payload string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(payload), "", "  "); err != nil {
+		return payload
+	}
+	return buf.String()
+}
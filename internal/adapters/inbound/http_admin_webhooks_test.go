@@ -0,0 +1,173 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// mockWebhookSender answers every delivery with the status.
+type mockWebhookSender struct {
+	status int
+}
+
+func (m *mockWebhookSender) Send(ctx context.Context, endpoint *webhook.Endpoint, delivery *webhook.Delivery) (int, error) {
+	return m.status, nil
+}
+
+// createTestWebhookService returns a webhook service with one registered endpoint.
+func createTestWebhookService(t *testing.T) (*webhook.Service, *webhook.Endpoint) {
+	t.Helper()
+	svc := webhook.NewService(
+		resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](),
+		resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Delivery](),
+		&mockWebhookSender{status: http.StatusOK},
+	)
+	endpoint, err := svc.RegisterEndpoint(context.Background(), "ep-1", "https://example.com/hooks", "secret", nil)
+	assert.That(t, "err must be nil", err, nil)
+	return svc, endpoint
+}
+
+// postForm sends the form values to the handler registered for the pattern.
+func postForm(pattern, target string, handler http.HandlerFunc, form url.Values) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, handler)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// ============================================================================
+// HttpAdminWebhooks Tests
+// ============================================================================
+
+func Test_HttpAdminWebhooks_Should_Render_Recent_Deliveries(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, endpoint := createTestWebhookService(t)
+	_, _ = svc.SendTestEvent(context.Background(), "d-1", endpoint.ID, "payment.captured")
+	handler := inbound.HttpAdminWebhooks(e, svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "first endpoint must be selected", strings.Contains(body, "https://example.com/hooks selected"), true)
+	assert.That(t, "body must contain delivery", strings.Contains(body, "payment.captured 200 test"), true)
+}
+
+func Test_HttpAdminWebhooks_With_Session_Should_Render_CSRF_Token(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc, _ := createTestWebhookService(t)
+	handler := inbound.HttpAdminWebhooks(e, svc)
+	req := adminSessionRequest(http.MethodGet, "https://sso.example.com/realms/staff", "session-1", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "forms must carry the CSRF token of the session", strings.Contains(rec.Body.String(), `value="`+inbound.CSRFToken(req)+`"`), true)
+}
+
+// ============================================================================
+// HttpAdminRegisterWebhook Tests
+// ============================================================================
+
+func Test_HttpAdminRegisterWebhook_Should_Redirect_To_Endpoint(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t)
+	form := url.Values{"url": {"https://partner.example.com/hooks"}, "topics": {"reservation.created", "reservation.cancelled"}}
+
+	// Act
+	rec := postForm("POST /admin/webhooks", "/admin/webhooks", inbound.HttpAdminRegisterWebhook(svc, slog.Default()), form)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	endpoints, _ := svc.Endpoints(context.Background())
+	assert.That(t, "endpoint must be registered", len(endpoints), 2)
+	assert.That(t, "topics must be stored", endpoints[1].Topics, []string{"reservation.created", "reservation.cancelled"})
+	assert.That(t, "secret must be generated", endpoints[1].Secret != "", true)
+}
+
+func Test_HttpAdminRegisterWebhook_With_Invalid_URL_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t)
+
+	// Act
+	rec := postForm("POST /admin/webhooks", "/admin/webhooks", inbound.HttpAdminRegisterWebhook(svc, slog.Default()), url.Values{"url": {"not a url"}})
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAdminSendTestWebhook Tests
+// ============================================================================
+
+func Test_HttpAdminSendTestWebhook_Should_Record_Delivery(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestWebhookService(t)
+
+	// Act
+	rec := postForm("POST /admin/webhooks/{id}/test", "/admin/webhooks/ep-1/test", inbound.HttpAdminSendTestWebhook(svc, slog.Default()), url.Values{"topic": {"reservation.confirmed"}})
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must show the endpoint", rec.Header().Get("Location"), "/admin/webhooks?endpoint=ep-1")
+	deliveries, _ := svc.Deliveries(context.Background(), endpoint.ID, 10)
+	assert.That(t, "delivery must be recorded", len(deliveries), 1)
+}
+
+func Test_HttpAdminSendTestWebhook_With_Unknown_Endpoint_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t)
+
+	// Act
+	rec := postForm("POST /admin/webhooks/{id}/test", "/admin/webhooks/missing/test", inbound.HttpAdminSendTestWebhook(svc, slog.Default()), url.Values{"topic": {"reservation.confirmed"}})
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminRedeliverWebhook Tests
+// ============================================================================
+
+func Test_HttpAdminRedeliverWebhook_Should_Record_Redelivery(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestWebhookService(t)
+	original, _ := svc.SendTestEvent(context.Background(), "d-1", endpoint.ID, "payment.failed")
+
+	// Act
+	rec := postForm("POST /admin/webhooks/deliveries/{id}/redeliver", "/admin/webhooks/deliveries/d-1/redeliver", inbound.HttpAdminRedeliverWebhook(svc, slog.Default()), nil)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	deliveries, _ := svc.Deliveries(context.Background(), endpoint.ID, 10)
+	assert.That(t, "redelivery must be recorded", len(deliveries), 2)
+	assert.That(t, "newest delivery must be the redelivery", deliveries[0].RedeliveryOf, original.ID)
+}
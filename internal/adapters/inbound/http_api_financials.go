@@ -0,0 +1,96 @@
+package inbound
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// APIFinancialLine represents a line of the financial summary in the JSON API.
+type APIFinancialLine struct {
+	Kind        string     `json:"kind"`
+	Description string     `json:"description,omitempty"`
+	Amount      APIMoney   `json:"amount"`
+	At          *time.Time `json:"at,omitempty"` // nil for the room charges
+}
+
+// APIFinancialSummary represents the financial summary of a reservation in the JSON API.
+// Balance is positive if the guest still owes money and negative if the guest is owed a refund.
+type APIFinancialSummary struct {
+	ReservationID string             `json:"reservation_id"`
+	RoomCharges   APIMoney           `json:"room_charges"`
+	Adjustments   APIMoney           `json:"adjustments"`
+	TotalCharges  APIMoney           `json:"total_charges"`
+	Authorized    APIMoney           `json:"authorized"`
+	Captured      APIMoney           `json:"captured"`
+	GiftCards     APIMoney           `json:"gift_cards"`
+	Refunded      APIMoney           `json:"refunded"`
+	NetPaid       APIMoney           `json:"net_paid"`
+	Balance       APIMoney           `json:"balance"`
+	Lines         []APIFinancialLine `json:"lines"`
+}
+
+// HttpAPIGetReservationFinancials returns the financial summary of the reservation given in the path as JSON.
+// Guests may read the summary of every reservation they may view.
+func HttpAPIGetReservationFinancials(reservationService *reservation.Service, financials *payment.FinancialService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, _ := currentGuest(ctx)
+		if guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		id, err := shared.ParseReservationID(r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_id", Message: err.Error()})
+			return
+		}
+		res, err := reservationService.GetReservation(ctx, id)
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, APIError{Code: "not_found", Message: "Reservation not found"})
+			return
+		}
+		if !canViewReservation(ctx, res, guestID) {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: "Access denied"})
+			return
+		}
+
+		summary, err := financials.Summary(ctx, res.ID)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to build financial summary"})
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, buildAPIFinancialSummary(summary))
+	}
+}
+
+// buildAPIFinancialSummary converts a financial summary to its JSON API representation.
+func buildAPIFinancialSummary(s *payment.FinancialSummary) APIFinancialSummary {
+	money := func(m shared.Money) APIMoney { return APIMoney{Amount: m.Amount, Currency: m.Currency} }
+	data := APIFinancialSummary{
+		ReservationID: string(s.ReservationID),
+		RoomCharges:   money(s.RoomCharges),
+		Adjustments:   money(s.Adjustments),
+		TotalCharges:  money(s.TotalCharges),
+		Authorized:    money(s.Authorized),
+		Captured:      money(s.Captured),
+		GiftCards:     money(s.GiftCards),
+		Refunded:      money(s.Refunded),
+		NetPaid:       money(s.NetPaid),
+		Balance:       money(s.Balance),
+		Lines:         make([]APIFinancialLine, 0, len(s.Lines)),
+	}
+	for _, l := range s.Lines {
+		line := APIFinancialLine{Kind: l.Kind, Description: l.Description, Amount: money(l.Amount)}
+		if !l.At.IsZero() {
+			at := l.At
+			line.At = &at
+		}
+		data.Lines = append(data.Lines, line)
+	}
+	return data
+}
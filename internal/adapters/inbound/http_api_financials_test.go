@@ -0,0 +1,63 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+)
+
+// ============================================================================
+// HttpAPIGetReservationFinancials Tests
+// ============================================================================
+
+func Test_HttpAPIGetReservationFinancials_Should_Return_Balance_In_Cents(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	svc := createReservationsTestService(repo)
+	handler := inbound.HttpAPIGetReservationFinancials(svc, createFinancialTestService(svc))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}/financials", handler, http.MethodGet, "/api/v1/reservations/res-001/financials", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.APIFinancialSummary
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "total charges must include the adjustment", body.TotalCharges, inbound.APIMoney{Amount: 31200, Currency: "USD"})
+	assert.That(t, "net paid must be the capture", body.NetPaid, inbound.APIMoney{Amount: 10000, Currency: "USD"})
+	assert.That(t, "balance must be outstanding", body.Balance, inbound.APIMoney{Amount: 21200, Currency: "USD"})
+	assert.That(t, "room charges must be the first line", body.Lines[0].Kind, payment.LineRoomCharges)
+	assert.That(t, "room charges line must have no date", body.Lines[0].At == nil, true)
+}
+
+func Test_HttpAPIGetReservationFinancials_Of_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createReservationsTestService(repo)
+	handler := inbound.HttpAPIGetReservationFinancials(svc, createFinancialTestService(svc))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}/financials", handler, http.MethodGet, "/api/v1/reservations/res-001/financials", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpAPIGetReservationFinancials_Of_Unknown_Reservation_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createReservationsTestService(newMockReservationRepository())
+	handler := inbound.HttpAPIGetReservationFinancials(svc, createFinancialTestService(svc))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}/financials", handler, http.MethodGet, "/api/v1/reservations/missing/financials", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,142 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+)
+
+// inventorySnapshotDays is the default number of nights of an inventory snapshot.
+const inventorySnapshotDays = 60
+
+// APIInventoryChange represents a change of the availability of a room for one night.
+type APIInventoryChange struct {
+	Sequence  uint64 `json:"sequence"`
+	RoomID    string `json:"room_id"`
+	Date      string `json:"date"` // YYYY-MM-DD
+	Available bool   `json:"available"`
+	ChangedAt string `json:"changed_at"` // RFC 3339
+}
+
+// HttpAPIInventoryChangesResponse specifies the JSON body of a page of the inventory change feed.
+// Next is the cursor of the following page; it equals Head once the client caught up.
+type HttpAPIInventoryChangesResponse struct {
+	Changes []APIInventoryChange `json:"changes"`
+	Next    uint64               `json:"next"`
+	Head    uint64               `json:"head"`
+}
+
+// APIInventoryNight represents the availability of a room for one night.
+type APIInventoryNight struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Available bool   `json:"available"`
+}
+
+// HttpAPIInventorySnapshotResponse specifies the JSON body of the availability of a room,
+// consistent with the change feed up to Sequence.
+type HttpAPIInventorySnapshotResponse struct {
+	RoomID   string              `json:"room_id"`
+	Sequence uint64              `json:"sequence"`
+	Nights   []APIInventoryNight `json:"nights"`
+}
+
+// HttpAPIInventoryChanges returns the inventory changes after the cursor given as query parameter
+// "after" (default: 0, the beginning of the feed), at most "limit" (default: 100, max: 1000), as JSON.
+// Channel managers poll it with the returned "next" and use it to fill gaps in the Kafka topic.
+func HttpAPIInventoryChanges(inventoryService *inventory.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		after, err := optionalUint(query.Get("after"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_cursor", Message: "after must be a sequence number"})
+			return
+		}
+		limit, err := optionalUint(query.Get("limit"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_limit", Message: "limit must be a positive number"})
+			return
+		}
+
+		page, err := inventoryService.Changes(r.Context(), inventory.Sequence(after), int(min(limit, inventory.MaxPageSize)))
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to read inventory changes"})
+			return
+		}
+
+		data := HttpAPIInventoryChangesResponse{
+			Changes: make([]APIInventoryChange, 0, len(page.Changes)),
+			Next:    after,
+			Head:    uint64(page.Head),
+		}
+		for _, change := range page.Changes {
+			data.Changes = append(data.Changes, APIInventoryChange{
+				Sequence:  uint64(change.Sequence),
+				RoomID:    string(change.RoomID),
+				Date:      change.Date,
+				Available: change.Available,
+				ChangedAt: change.ChangedAt.Format(time.RFC3339),
+			})
+			data.Next = uint64(change.Sequence)
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// HttpAPIInventorySnapshot returns the availability of the room in the path for "days" nights
+// (default: 60, max: 366) from "from" (YYYY-MM-DD, default: today) as JSON.
+// Channel managers resync a room with it after a gap: they replace their state with the nights
+// and follow the change feed after the returned sequence.
+func HttpAPIInventorySnapshot(inventoryService *inventory.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		from := time.Now().UTC()
+		if value := query.Get("from"); value != "" {
+			parsed, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_from", Message: "from must be YYYY-MM-DD"})
+				return
+			}
+			from = parsed
+		}
+		days := inventorySnapshotDays
+		if value := query.Get("days"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_days", Message: "days must be a number"})
+				return
+			}
+			days = parsed
+		}
+
+		snapshot, err := inventoryService.Snapshot(r.Context(), inventory.RoomID(r.PathValue("id")), from, days)
+		if errors.Is(err, inventory.ErrInvalidSpan) {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_days", Message: err.Error()})
+			return
+		}
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to read the inventory"})
+			return
+		}
+
+		data := HttpAPIInventorySnapshotResponse{
+			RoomID:   string(snapshot.RoomID),
+			Sequence: uint64(snapshot.Sequence),
+			Nights:   make([]APIInventoryNight, 0, len(snapshot.Days)),
+		}
+		for _, day := range snapshot.Days {
+			data.Nights = append(data.Nights, APIInventoryNight{Date: day.Date.Format(time.DateOnly), Available: day.Available})
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// optionalUint parses an optional non-negative query parameter; empty is 0.
+func optionalUint(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
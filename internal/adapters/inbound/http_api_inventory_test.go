@@ -0,0 +1,126 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestInventoryService returns an inventory service on the occupancy of the reservations in repo
+// and the dispatcher its changes are published to.
+func createTestInventoryService(repo *mockReservationRepository) (*inventory.Service, messaging.Dispatcher) {
+	dispatcher := messaging.NewInternalDispatcher()
+	svc := inventory.NewService(
+		resource.NewInMemoryAccess[inventory.ChangeKey, inventory.Change](),
+		resource.NewInMemoryAccess[inventory.DayKey, inventory.Day](),
+		outbound.NewReservationOccupancy(createFormTestService(repo)),
+		outbound.NewEventPublisher(dispatcher),
+	)
+	return svc, dispatcher
+}
+
+// ============================================================================
+// HttpAPIInventoryChanges Tests
+// ============================================================================
+
+func Test_HttpAPIInventoryChanges_Should_Return_Changes_After_Cursor(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc, _ := createTestInventoryService(repo)
+	res := repo.get("res-001")
+	_, _ = svc.Refresh(context.Background(), inventory.RoomID(res.RoomID), res.DateRange.CheckIn, res.DateRange.CheckOut)
+	handler := inbound.HttpAPIInventoryChanges(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/changes", handler, http.MethodGet, "/api/v1/inventory/changes?after=1&limit=1", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIInventoryChangesResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "one change must be returned", len(body.Changes), 1)
+	assert.That(t, "change must follow the cursor", body.Changes[0].Sequence, uint64(2))
+	assert.That(t, "change must be for the room", body.Changes[0].RoomID, "room-101")
+	assert.That(t, "night must be unavailable", body.Changes[0].Available, false)
+	assert.That(t, "next must be the last returned sequence", body.Next, uint64(2))
+	assert.That(t, "head must be the three booked nights", body.Head, uint64(3))
+}
+
+func Test_HttpAPIInventoryChanges_Without_Changes_Should_Keep_Cursor(t *testing.T) {
+	// Arrange
+	svc, _ := createTestInventoryService(newMockReservationRepository())
+	handler := inbound.HttpAPIInventoryChanges(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/changes", handler, http.MethodGet, "/api/v1/inventory/changes?after=7", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIInventoryChangesResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "changes must be empty", len(body.Changes), 0)
+	assert.That(t, "next must be the cursor", body.Next, uint64(7))
+}
+
+func Test_HttpAPIInventoryChanges_With_Invalid_Cursor_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestInventoryService(newMockReservationRepository())
+	handler := inbound.HttpAPIInventoryChanges(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/changes", handler, http.MethodGet, "/api/v1/inventory/changes?after=-1", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// HttpAPIInventorySnapshot Tests
+// ============================================================================
+
+func Test_HttpAPIInventorySnapshot_Should_Return_Nights_With_Sequence(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc, _ := createTestInventoryService(repo)
+	handler := inbound.HttpAPIInventorySnapshot(svc)
+	from := repo.get("res-001").DateRange.CheckIn.Format(time.DateOnly)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/rooms/{id}", handler, http.MethodGet, "/api/v1/inventory/rooms/room-101?from="+from+"&days=4", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIInventorySnapshotResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "four nights must be returned", len(body.Nights), 4)
+	assert.That(t, "first night must be booked", body.Nights[0], inbound.APIInventoryNight{Date: from, Available: false})
+	assert.That(t, "check-out night must be available", body.Nights[3].Available, true)
+	assert.That(t, "sequence must include the booked nights", body.Sequence, uint64(3))
+}
+
+func Test_HttpAPIInventorySnapshot_With_Too_Many_Days_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc, _ := createTestInventoryService(newMockReservationRepository())
+	handler := inbound.HttpAPIInventorySnapshot(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/rooms/{id}", handler, http.MethodGet, "/api/v1/inventory/rooms/room-101?days=400", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
@@ -0,0 +1,150 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HttpAPIKioskArrivalsResponse specifies the JSON body of the arrivals a kiosk downloads for the day.
+type HttpAPIKioskArrivalsResponse struct {
+	PropertyID  string           `json:"property_id,omitempty"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	Arrivals    []APIReservation `json:"arrivals"`
+}
+
+// APIKioskOperation is a check-in a kiosk queued while offline. The kiosk generates the ID
+// when the guest acts and keeps it when it sends the operation again.
+type APIKioskOperation struct {
+	ID            string    `json:"id"`
+	Action        string    `json:"action"` // "check_in"
+	ReservationID string    `json:"reservation_id"`
+	PerformedAt   time.Time `json:"performed_at"` // RFC 3339, by the kiosk's clock
+}
+
+// HttpAPIKioskSyncRequest specifies the JSON body of the operations a kiosk syncs on reconnect.
+type HttpAPIKioskSyncRequest struct {
+	KioskID    string              `json:"kiosk_id"`
+	PropertyID string              `json:"property_id,omitempty"` // restricts the operations to the property's reservations
+	Operations []APIKioskOperation `json:"operations"`
+}
+
+// APIKioskResult is how the server resolved a kiosk operation: "applied", "conflict" or "rejected".
+type APIKioskResult struct {
+	OperationID   string     `json:"operation_id"`
+	ReservationID string     `json:"reservation_id"`
+	Outcome       string     `json:"outcome"`
+	Reason        string     `json:"reason,omitempty"`
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`
+	CheckedInBy   string     `json:"checked_in_by,omitempty"`
+}
+
+// HttpAPIKioskSyncResponse specifies the JSON body of the results, in the order they were resolved.
+type HttpAPIKioskSyncResponse struct {
+	Results []APIKioskResult `json:"results"`
+}
+
+// HttpAPIKioskArrivals returns the reservations checking in today at the property given as query
+// parameter "property_id" (default: all properties) as JSON, for kiosks to check guests in offline.
+// Guests get 403; service accounts and managed staff need the reservations:read scope.
+func HttpAPIKioskArrivals(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if err := kioskAccess(ctx, reservation.ScopeRead); err != nil {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: err.Error()})
+			return
+		}
+
+		propertyID := r.URL.Query().Get("property_id")
+		now := time.Now()
+		arrivals, err := reservationService.ExpectedArrivals(ctx, reservation.PropertyID(propertyID), now)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to list arrivals"})
+			return
+		}
+		data := HttpAPIKioskArrivalsResponse{PropertyID: propertyID, GeneratedAt: now.UTC(), Arrivals: make([]APIReservation, 0, len(arrivals))}
+		for _, res := range arrivals {
+			data.Arrivals = append(data.Arrivals, buildAPIReservation(res))
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// HttpAPIKioskSync applies the check-ins a kiosk queued offline from the JSON body and answers 200 with
+// the result of every operation. Operations synced before are answered with their stored result, so the
+// kiosk may send its whole queue again after a lost response. Guests get 403; service accounts and
+// managed staff need the reservations:write scope.
+func HttpAPIKioskSync(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if err := kioskAccess(ctx, reservation.ScopeWrite); err != nil {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: err.Error()})
+			return
+		}
+
+		var req HttpAPIKioskSyncRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_json", Message: err.Error()})
+			return
+		}
+		if req.KioskID == "" {
+			writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid sync", Fields: map[string]string{"kiosk_id": "is required"}})
+			return
+		}
+
+		ops := make([]reservation.KioskOperation, 0, len(req.Operations))
+		for _, op := range req.Operations {
+			ops = append(ops, reservation.KioskOperation{
+				ID:            op.ID,
+				Action:        reservation.KioskAction(op.Action),
+				ReservationID: reservation.ReservationID(op.ReservationID),
+				PerformedAt:   op.PerformedAt,
+			})
+		}
+		results, err := reservationService.SyncKiosk(ctx, req.KioskID, reservation.PropertyID(req.PropertyID), ops)
+		if err != nil {
+			if errors.Is(err, reservation.ErrRoomBusy) {
+				w.Header().Set("Retry-After", "1")
+				writeAPIError(w, http.StatusConflict, APIError{Code: "room_busy", Message: err.Error()})
+				return
+			}
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to sync kiosk operations"})
+			return
+		}
+		data := HttpAPIKioskSyncResponse{Results: make([]APIKioskResult, 0, len(results))}
+		for _, result := range results {
+			data.Results = append(data.Results, buildAPIKioskResult(result))
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// kioskAccess returns an error unless the caller is staff or a service account with the scope.
+func kioskAccess(ctx context.Context, scope string) error {
+	if err := shared.RequireStaff(ctx); err != nil {
+		return err
+	}
+	return shared.RequireScope(ctx, scope)
+}
+
+func buildAPIKioskResult(result reservation.KioskResult) APIKioskResult {
+	data := APIKioskResult{
+		OperationID:   result.OperationID,
+		ReservationID: string(result.ReservationID),
+		Outcome:       string(result.Outcome),
+		Reason:        result.Reason,
+		CheckedInBy:   result.CheckedInBy,
+	}
+	if !result.CheckedInAt.IsZero() {
+		at := result.CheckedInAt.UTC()
+		data.CheckedInAt = &at
+	}
+	return data
+}
@@ -0,0 +1,139 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createKioskTestService creates a reservation service syncing kiosks with a confirmed reservation
+// res-001 checking in today.
+func createKioskTestService(repo *mockReservationRepository) *reservation.Service {
+	today := time.Now().UTC()
+	res := createTestReservation("res-001", "guest@example.com", "room-101", today, today.AddDate(0, 0, 2))
+	res.Status = reservation.StatusConfirmed
+	repo.put(shared.ReservationID("res-001"), *res)
+	return createReservationsTestService(repo).
+		WithKioskOperations(resource.NewInMemoryAccess[string, reservation.KioskResult]())
+}
+
+// serveKiosk serves the request as the principal.
+func serveKiosk(handler http.HandlerFunc, method, target, body string, principal shared.Principal) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(shared.ContextWithPrincipal(req.Context(), principal))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+var kioskPrincipal = shared.Principal{Type: shared.PrincipalService, ClientID: "lobby-kiosk", Scopes: []string{reservation.ScopeRead, reservation.ScopeWrite}}
+
+func kioskSyncBody(operationID string) string {
+	return `{"kiosk_id":"kiosk-1","operations":[{"id":"` + operationID + `","action":"check_in","reservation_id":"res-001","performed_at":"` + time.Now().UTC().Format(time.RFC3339) + `"}]}`
+}
+
+// ============================================================================
+// HttpAPIKioskArrivals Tests
+// ============================================================================
+
+func Test_HttpAPIKioskArrivals_Should_Return_Arrivals_Of_Today(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskArrivals(createKioskTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodGet, "/api/v1/kiosk/arrivals", "", kioskPrincipal)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIKioskArrivalsResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "one guest must arrive", len(body.Arrivals), 1)
+	assert.That(t, "arrival must be confirmed", body.Arrivals[0].Status, string(reservation.StatusConfirmed))
+}
+
+func Test_HttpAPIKioskArrivals_As_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskArrivals(createKioskTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodGet, "/api/v1/kiosk/arrivals", "", shared.Principal{Type: shared.PrincipalGuest, Subject: "guest-1"})
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+// ============================================================================
+// HttpAPIKioskSync Tests
+// ============================================================================
+
+func Test_HttpAPIKioskSync_Should_Check_In_Guest(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	handler := inbound.HttpAPIKioskSync(createKioskTestService(repo))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", kioskSyncBody("op-1"), kioskPrincipal)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIKioskSyncResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "operation must be applied", body.Results[0].Outcome, "applied")
+	assert.That(t, "check-in must be attributed to the kiosk", body.Results[0].CheckedInBy, "service:lobby-kiosk")
+	res, _ := repo.Read(context.Background(), "res-001")
+	assert.That(t, "reservation must be active", res.Status, reservation.StatusActive)
+}
+
+func Test_HttpAPIKioskSync_After_Check_In_Elsewhere_Should_Report_Conflict(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskSync(createKioskTestService(newMockReservationRepository()))
+	_ = serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", kioskSyncBody("op-1"), kioskPrincipal)
+
+	// Act
+	rec := serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", kioskSyncBody("op-2"), kioskPrincipal)
+
+	// Assert
+	var body inbound.HttpAPIKioskSyncResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "operation must conflict", body.Results[0].Outcome, "conflict")
+	assert.That(t, "conflict must tell when the guest was checked in", body.Results[0].CheckedInAt != nil, true)
+}
+
+func Test_HttpAPIKioskSync_Without_Write_Scope_Should_Return_403(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskSync(createKioskTestService(newMockReservationRepository()))
+	readOnly := shared.Principal{Type: shared.PrincipalService, ClientID: "lobby-kiosk", Scopes: []string{reservation.ScopeRead}}
+
+	// Act
+	rec := serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", kioskSyncBody("op-1"), readOnly)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpAPIKioskSync_Without_Kiosk_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskSync(createKioskTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", `{"operations":[]}`, kioskPrincipal)
+
+	// Assert
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+}
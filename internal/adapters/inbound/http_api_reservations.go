@@ -0,0 +1,356 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// apiMaxBodyBytes limits the size of JSON request bodies.
+const apiMaxBodyBytes = 64 << 10
+
+// APIMoney represents an amount in the smallest currency unit (e.g. cents).
+type APIMoney struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// APIGuest represents a guest of a reservation.
+type APIGuest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// APIEmergencyContact is the person to call if something happens to a guest during the stay.
+type APIEmergencyContact struct {
+	Name         string `json:"name"`
+	Phone        string `json:"phone"`
+	Relationship string `json:"relationship,omitempty"`
+}
+
+// APIBusinessCustomer is the company a stay is billed to.
+type APIBusinessCustomer struct {
+	Company string `json:"company"`
+	VATID   string `json:"vat_id,omitempty"` // e.g. DE123456789
+}
+
+// APIReservation represents a reservation in the JSON API.
+type APIReservation struct {
+	ID                 string               `json:"id"`
+	ConfirmationCode   string               `json:"confirmation_code"` // confirmation number, or the code derived from the ID
+	RoomID             string               `json:"room_id"`
+	CheckIn            string               `json:"check_in"`  // YYYY-MM-DD
+	CheckOut           string               `json:"check_out"` // YYYY-MM-DD
+	Nights             int                  `json:"nights"`
+	Status             string               `json:"status"`
+	TotalAmount        APIMoney             `json:"total_amount"`
+	Tier               string               `json:"tier,omitempty"`
+	CancellationReason string               `json:"cancellation_reason,omitempty"`
+	Guests             []APIGuest           `json:"guests"`
+	EmergencyContact   *APIEmergencyContact `json:"emergency_contact,omitempty"`
+	ArrivalTime        string               `json:"arrival_time,omitempty"` // HH:MM on the check-in day
+	BusinessCustomer   *APIBusinessCustomer `json:"business_customer,omitempty"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+// HttpAPIReservationsResponse specifies the JSON body of the reservation list.
+type HttpAPIReservationsResponse struct {
+	Reservations []APIReservation `json:"reservations"`
+}
+
+// HttpAPICreateReservationRequest specifies the JSON body to create a reservation.
+type HttpAPICreateReservationRequest struct {
+	RoomID   string     `json:"room_id"`
+	CheckIn  string     `json:"check_in"`  // YYYY-MM-DD
+	CheckOut string     `json:"check_out"` // YYYY-MM-DD
+	Guests   []APIGuest `json:"guests"`
+	// Optional arrival details.
+	EmergencyContact *APIEmergencyContact `json:"emergency_contact,omitempty"`
+	ArrivalTime      string               `json:"arrival_time,omitempty"` // HH:MM on the check-in day
+	// Optional company the stay is billed to; its VAT ID is checked against the format of its country.
+	BusinessCustomer *APIBusinessCustomer `json:"business_customer,omitempty"`
+	// Optional preferred language of the guest's emails, e.g. "de"; stored for all future emails.
+	Language string `json:"language,omitempty"`
+}
+
+// APIError is the error of a failed JSON API request.
+// Fields maps request fields to their validation messages.
+type APIError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// HttpAPIErrorResponse specifies the JSON body of a failed JSON API request.
+type HttpAPIErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// HttpAPIListReservations returns the reservations of the authenticated guest as JSON.
+func HttpAPIListReservations(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, _ := currentGuest(ctx)
+		if guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		reservations, err := reservationService.ListReservationsByGuest(ctx, guestID)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to list reservations"})
+			return
+		}
+		data := HttpAPIReservationsResponse{Reservations: make([]APIReservation, 0, len(reservations))}
+		for _, res := range reservations {
+			data.Reservations = append(data.Reservations, buildAPIReservation(res))
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// HttpAPIGetReservation returns the reservation given in the path by ID or confirmation number as JSON.
+// Guests may read their own, shared and household reservations, like in the UI.
+func HttpAPIGetReservation(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, _ := currentGuest(ctx)
+		if guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		id, err := shared.ParseReservationID(r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_id", Message: err.Error()})
+			return
+		}
+		res, err := reservationService.FindReservation(ctx, string(id))
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, APIError{Code: "not_found", Message: "Reservation not found"})
+			return
+		}
+		if !canViewReservation(ctx, res, guestID) {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: "Access denied"})
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, buildAPIReservation(res))
+	}
+}
+
+// HttpAPICreateReservation creates a reservation for the authenticated guest from the JSON body.
+// It answers 201 with the reservation and its URL in the Location header, 422 with the invalid
+// fields if validation fails and 409 if the room is not available or being booked by another request.
+// The perks of the guest's VIP tier are applied if profileService is not nil.
+// The stay is priced with its rate plan if pricingService is not nil.
+// The optional language is stored as the guest's preferred language if profileService is not nil.
+func HttpAPICreateReservation(reservationService *reservation.Service, pricingService *pricing.Service, profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, _ := currentGuest(ctx)
+		if guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		var req HttpAPICreateReservationRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_json", Message: err.Error()})
+			return
+		}
+
+		checkIn, checkOut, arrival, fields := validateAPICreateReservation(req)
+		if len(fields) > 0 {
+			writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: fields})
+			return
+		}
+
+		totalAmount, err := quoteStay(ctx, pricingService, req.RoomID, checkIn, checkOut)
+		if err != nil {
+			switch {
+			case errors.Is(err, pricing.ErrInvalidStay):
+				writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: map[string]string{"check_out": reservation.ErrInvalidDateRange.Error()}})
+			case errors.Is(err, pricing.ErrNoRatePlan):
+				writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: map[string]string{"room_id": "room has no price"}})
+			default:
+				writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to price reservation"})
+			}
+			return
+		}
+		guests := make([]reservation.GuestInfo, 0, len(req.Guests))
+		for _, g := range req.Guests {
+			guests = append(guests, reservation.NewGuestInfo(g.Name, g.Email, g.Phone))
+		}
+
+		storeGuestLanguage(ctx, profileService, guestID, req.Language)
+		perks := guestPerks(ctx, profileService, guestID)
+		res, err := reservationService.CreateReservationWithPerks(ctx, shared.NewReservationID(), guestID, reservation.RoomID(req.RoomID), reservation.NewDateRange(checkIn, checkOut), totalAmount, guests, perks, arrival)
+		if err != nil {
+			switch {
+			case errors.Is(err, reservation.ErrRoomNotAvailable):
+				writeAPIError(w, http.StatusConflict, APIError{Code: "room_not_available", Message: err.Error()})
+			case errors.Is(err, reservation.ErrRoomBusy):
+				w.Header().Set("Retry-After", "1")
+				writeAPIError(w, http.StatusConflict, APIError{Code: "room_busy", Message: err.Error()})
+			case errors.Is(err, reservation.ErrCheckInPast):
+				writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: map[string]string{"check_in": reservation.ErrCheckInPast.Error()}})
+			case errors.Is(err, reservation.ErrInvalidDateRange):
+				writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: map[string]string{"check_out": reservation.ErrInvalidDateRange.Error()}})
+			case errors.Is(err, reservation.ErrMinimumStay):
+				writeAPIError(w, http.StatusUnprocessableEntity, APIError{Code: "validation_failed", Message: "Invalid reservation", Fields: map[string]string{"check_out": reservation.ErrMinimumStay.Error()}})
+			default:
+				writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to create reservation"})
+			}
+			return
+		}
+
+		w.Header().Set("Location", "/api/v1/reservations/"+string(res.ID))
+		writeAPIJSON(w, http.StatusCreated, buildAPIReservation(res))
+	}
+}
+
+// HttpAPICancelReservation cancels the reservation given in the path by ID or confirmation number.
+// It answers 204, or 409 if the reservation can no longer be cancelled.
+func HttpAPICancelReservation(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, _ := currentGuest(ctx)
+		if guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		id, err := shared.ParseReservationID(r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_id", Message: err.Error()})
+			return
+		}
+		res, err := reservationService.FindReservation(ctx, string(id))
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, APIError{Code: "not_found", Message: "Reservation not found"})
+			return
+		}
+		if !canManageReservation(ctx, res, guestID) {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: "Access denied"})
+			return
+		}
+
+		if err := reservationService.CancelReservation(ctx, res.ID, "Cancelled by guest"); err != nil {
+			for _, rule := range []error{reservation.ErrAlreadyCancelled, reservation.ErrCannotCancelNearCheckIn, reservation.ErrCannotCancelActive, reservation.ErrCannotCancelCompleted, reservation.ErrInvalidStateTransition} {
+				if errors.Is(err, rule) {
+					writeAPIError(w, http.StatusConflict, APIError{Code: "cannot_cancel", Message: rule.Error()})
+					return
+				}
+			}
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to cancel reservation"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validateAPICreateReservation checks the request and returns the parsed dates, the arrival details and the invalid fields.
+func validateAPICreateReservation(req HttpAPICreateReservationRequest) (time.Time, time.Time, reservation.ArrivalDetails, map[string]string) {
+	fields := make(map[string]string)
+	if req.RoomID == "" {
+		fields["room_id"] = "required"
+	} else if _, ok := getRoomPrices()[req.RoomID]; !ok {
+		fields["room_id"] = "unknown room"
+	}
+	checkIn, err := time.Parse("2006-01-02", req.CheckIn)
+	if err != nil {
+		fields["check_in"] = "must be a date (YYYY-MM-DD)"
+	}
+	checkOut, err := time.Parse("2006-01-02", req.CheckOut)
+	if err != nil {
+		fields["check_out"] = "must be a date (YYYY-MM-DD)"
+	}
+	if len(req.Guests) == 0 {
+		fields["guests"] = "at least one guest required"
+	}
+	for _, g := range req.Guests {
+		if g.Name == "" || g.Email == "" {
+			fields["guests"] = "every guest needs a name and email"
+		}
+	}
+	var contact APIEmergencyContact
+	if req.EmergencyContact != nil {
+		contact = *req.EmergencyContact
+	}
+	arrival, err := reservation.NewArrivalDetails(contact.Name, contact.Phone, contact.Relationship, req.ArrivalTime)
+	switch {
+	case errors.Is(err, reservation.ErrIncompleteEmergencyContact):
+		fields["emergency_contact"] = "needs a name and phone"
+	case errors.Is(err, reservation.ErrInvalidArrivalTime):
+		fields["arrival_time"] = "must be a time (HH:MM)"
+	}
+	if c := req.BusinessCustomer; c != nil {
+		arrival.BusinessCustomer, err = reservation.NewBusinessCustomer(c.Company, c.VATID)
+		switch {
+		case errors.Is(err, reservation.ErrMissingCompany):
+			fields["business_customer"] = "needs a company with the VAT ID"
+		case errors.Is(err, reservation.ErrInvalidVATID):
+			fields["business_customer"] = "VAT ID does not match the format of its country"
+		}
+	}
+	if _, ok := shared.ParseLocale(req.Language); req.Language != "" && !ok {
+		fields["language"] = "unsupported language"
+	}
+	return checkIn, checkOut, arrival, fields
+}
+
+// buildAPIReservation converts a reservation to its JSON API representation.
+func buildAPIReservation(res *reservation.Reservation) APIReservation {
+	guests := make([]APIGuest, 0, len(res.Guests))
+	for _, g := range res.Guests {
+		guests = append(guests, APIGuest{Name: g.Name, Email: g.Email, Phone: g.PhoneNumber})
+	}
+	var contact *APIEmergencyContact
+	if c := res.EmergencyContact; c != nil {
+		contact = &APIEmergencyContact{Name: c.Name, Phone: c.PhoneNumber, Relationship: c.Relationship}
+	}
+	var business *APIBusinessCustomer
+	if c := res.BusinessCustomer; c != nil {
+		business = &APIBusinessCustomer{Company: c.Company, VATID: c.VATID}
+	}
+	return APIReservation{
+		ID:                 string(res.ID),
+		ConfirmationCode:   res.ConfirmationCode(),
+		RoomID:             string(res.RoomID),
+		CheckIn:            res.DateRange.CheckIn.Format("2006-01-02"),
+		CheckOut:           res.DateRange.CheckOut.Format("2006-01-02"),
+		Nights:             res.Nights(),
+		Status:             string(res.Status),
+		TotalAmount:        APIMoney{Amount: res.TotalAmount.Amount, Currency: res.TotalAmount.Currency},
+		Tier:               res.Perks.Tier,
+		CancellationReason: res.CancellationReason,
+		Guests:             guests,
+		EmergencyContact:   contact,
+		ArrivalTime:        res.ArrivalTime,
+		BusinessCustomer:   business,
+		CreatedAt:          res.CreatedAt,
+		UpdatedAt:          res.UpdatedAt,
+	}
+}
+
+// writeAPIJSON writes the body as JSON with the status.
+func writeAPIJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeAPIError writes the error as JSON with the status.
+func writeAPIError(w http.ResponseWriter, status int, apiErr APIError) {
+	writeAPIJSON(w, status, HttpAPIErrorResponse{Error: apiErr})
+}
@@ -0,0 +1,368 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// serveAPI sends the request to the handler registered for the pattern as the authenticated guest.
+func serveAPI(pattern string, handler http.HandlerFunc, method, target, body, guestEmail string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, handler)
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, addAuthContext(req, "session-123", guestEmail))
+	return rec
+}
+
+// createAPITestReservation stores a reservation of the guest (subject "user-subject-456", see addAuthContext).
+func createAPITestReservation(repo *mockReservationRepository, id string, checkIn time.Time) {
+	res := createTestReservation(id, "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	res.GuestID = reservation.GuestID("user-subject-456")
+	repo.put(shared.ReservationID(id), *res)
+}
+
+// ============================================================================
+// HttpAPIListReservations Tests
+// ============================================================================
+
+func Test_HttpAPIListReservations_Should_Return_Guest_Reservations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	handler := inbound.HttpAPIListReservations(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations", handler, http.MethodGet, "/api/v1/reservations", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIReservationsResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "one reservation must be returned", len(body.Reservations), 1)
+	assert.That(t, "amount must be in cents", body.Reservations[0].TotalAmount, inbound.APIMoney{Amount: 29700, Currency: "USD"})
+}
+
+// ============================================================================
+// HttpAPICreateReservation Tests
+// ============================================================================
+
+func Test_HttpAPICreateReservation_Should_Return_201_With_Location(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(repo), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 14).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 16).Format("2006-01-02")
+	body := `{"room_id":"room-201","check_in":"` + checkIn + `","check_out":"` + checkOut + `","guests":[{"name":"Test Guest","email":"guest@example.com"}]}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	var res inbound.APIReservation
+	_ = json.NewDecoder(rec.Body).Decode(&res)
+	assert.That(t, "location must point to the reservation", rec.Header().Get("Location"), "/api/v1/reservations/"+res.ID)
+	assert.That(t, "id must be a typed reservation ID", strings.HasPrefix(res.ID, shared.ReservationIDPrefix), true)
+	assert.That(t, "status must be pending", res.Status, "pending")
+	assert.That(t, "reservation must be stored", repo.count(), 1)
+}
+
+func Test_HttpAPICreateReservation_With_Pricing_Should_Charge_Rate_Plan_Quote(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	pricingService := createTestPricingService()
+	_, _ = pricingService.SaveRatePlan(context.Background(), pricing.RatePlan{
+		RoomID:        "room-201",
+		BaseRate:      shared.NewMoney(10000, "USD"),
+		StayDiscounts: []pricing.StayDiscount{{MinNights: 2, Percent: 10}},
+	})
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(repo), pricingService, nil)
+	checkIn := time.Now().AddDate(0, 0, 14).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 16).Format("2006-01-02")
+	body := `{"room_id":"room-201","check_in":"` + checkIn + `","check_out":"` + checkOut + `","guests":[{"name":"Test Guest","email":"guest@example.com"}]}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var res inbound.APIReservation
+	_ = json.NewDecoder(rec.Body).Decode(&res)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "amount must be the discounted quote", res.TotalAmount, inbound.APIMoney{Amount: 18000, Currency: "USD"})
+}
+
+func Test_HttpAPICreateReservation_With_Pricing_And_Unpriced_Room_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), createTestPricingService(), nil)
+	checkIn := time.Now().AddDate(0, 0, 14).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 16).Format("2006-01-02")
+	body := `{"room_id":"room-301","check_in":"` + checkIn + `","check_out":"` + checkOut + `","guests":[{"name":"Test Guest","email":"guest@example.com"}]}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var resp inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	assert.That(t, "room must be named", resp.Error.Fields["room_id"], "room has no price")
+}
+
+func Test_HttpAPICreateReservation_With_Invalid_Fields_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", `{"room_id":"room-999","check_in":"tomorrow","guests":[]}`, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	var body inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "code must be validation_failed", body.Error.Code, "validation_failed")
+	assert.That(t, "invalid fields must be listed", len(body.Error.Fields), 4)
+	assert.That(t, "room must be unknown", body.Error.Fields["room_id"], "unknown room")
+}
+
+func Test_HttpAPICreateReservation_With_Unsupported_Language_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 14).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 16).Format("2006-01-02")
+	body := `{"room_id":"room-201","check_in":"` + checkIn + `","check_out":"` + checkOut + `","guests":[{"name":"Test Guest","email":"guest@example.com"}],"language":"tlh"}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var resp inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	assert.That(t, "language must be named", resp.Error.Fields["language"], "unsupported language")
+}
+
+func Test_HttpAPICreateReservation_With_Booked_Room_Should_Return_409(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	createAPITestReservation(repo, "res-001", checkIn)
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(repo), nil, nil)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 1).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}]}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+	assert.That(t, "body must contain the code", strings.Contains(rec.Body.String(), `"room_not_available"`), true)
+}
+
+func Test_HttpAPICreateReservation_When_Room_Busy_Should_Return_409_With_Retry_After(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	locks := outbound.NewLocalRoomLocks(time.Millisecond)
+	_, _ = locks.Lock(context.Background(), "room-101")
+	service := createReservationsTestService(repo).WithRoomLocks(locks, outbound.NewRepositoryAvailabilityChecker(repo))
+	handler := inbound.HttpAPICreateReservation(service, nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 1).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}]}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+	assert.That(t, "body must contain the code", strings.Contains(rec.Body.String(), `"room_busy"`), true)
+	assert.That(t, "retry after must be set", rec.Header().Get("Retry-After"), "1")
+}
+
+func Test_HttpAPICreateReservation_With_Arrival_Details_Should_Return_Them(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 2).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}],"arrival_time":"21:45","emergency_contact":{"name":"Jane Doe","phone":"+1 555 0100"}}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var res inbound.APIReservation
+	_ = json.NewDecoder(rec.Body).Decode(&res)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "arrival time must be returned", res.ArrivalTime, "21:45")
+	assert.That(t, "emergency contact must be returned", *res.EmergencyContact, inbound.APIEmergencyContact{Name: "Jane Doe", Phone: "+1 555 0100"})
+}
+
+func Test_HttpAPICreateReservation_With_Invalid_Arrival_Time_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 2).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}],"arrival_time":"late"}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var resp inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	assert.That(t, "arrival time must be invalid", resp.Error.Fields["arrival_time"], "must be a time (HH:MM)")
+}
+
+func Test_HttpAPICreateReservation_With_Business_Customer_Should_Return_It(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 2).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}],"business_customer":{"company":"Acme SARL","vat_id":"FR40303265045"}}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var res inbound.APIReservation
+	_ = json.NewDecoder(rec.Body).Decode(&res)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "business customer must be returned", *res.BusinessCustomer, inbound.APIBusinessCustomer{Company: "Acme SARL", VATID: "FR40303265045"})
+}
+
+func Test_HttpAPICreateReservation_With_Invalid_VAT_ID_Should_Return_422(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 7)
+	body := `{"room_id":"room-101","check_in":"` + checkIn.Format("2006-01-02") + `","check_out":"` + checkIn.AddDate(0, 0, 2).Format("2006-01-02") + `","guests":[{"name":"Test Guest","email":"guest@example.com"}],"business_customer":{"company":"Acme SARL","vat_id":"FR123"}}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	var resp inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	assert.That(t, "business customer must be invalid", resp.Error.Fields["business_customer"], "VAT ID does not match the format of its country")
+}
+
+func Test_HttpAPICreateReservation_With_Malformed_JSON_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", `{"room_id":`, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "content type must be JSON", rec.Header().Get("Content-Type"), "application/json")
+}
+
+// ============================================================================
+// HttpAPIGetReservation Tests
+// ============================================================================
+
+func Test_HttpAPIGetReservation_By_Confirmation_Number_Should_Return_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	claims := resource.NewInMemoryAccess[string, reservation.NumberClaim]()
+	_ = claims.Create(context.Background(), "BER-2025-00123", reservation.NumberClaim{Number: "BER-2025-00123", ReservationID: "res-001"})
+	scheme, _ := reservation.ParseNumberingScheme("BER-{YYYY}-{SEQ:5}")
+	handler := inbound.HttpAPIGetReservation(createReservationsTestService(repo).WithConfirmationNumbers(scheme, claims))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}", handler, http.MethodGet, "/api/v1/reservations/ber-2025-00123", "", "guest@example.com")
+
+	// Assert
+	var body inbound.APIReservation
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "reservation must be found", body.ID, "res-001")
+}
+
+func Test_HttpAPIGetReservation_With_Unknown_ID_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetReservation(createReservationsTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}", handler, http.MethodGet, "/api/v1/reservations/missing", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpAPIGetReservation_With_Malformed_ID_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetReservation(createReservationsTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}", handler, http.MethodGet, "/api/v1/reservations/res_01ABC", "", "guest@example.com")
+
+	// Assert
+	var body inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "error code must be invalid_id", body.Error.Code, "invalid_id")
+	assert.That(t, "message must explain the format", strings.Contains(body.Error.Message, "26-character ULID"), true)
+}
+
+// ============================================================================
+// HttpAPICancelReservation Tests
+// ============================================================================
+
+func Test_HttpAPICancelReservation_Should_Return_204(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	handler := inbound.HttpAPICancelReservation(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("DELETE /api/v1/reservations/{id}", handler, http.MethodDelete, "/api/v1/reservations/res-001", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "reservation must be cancelled", repo.get("res-001").Status, reservation.StatusCancelled)
+}
+
+func Test_HttpAPICancelReservation_Near_Check_In_Should_Return_409(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().Add(12*time.Hour))
+	handler := inbound.HttpAPICancelReservation(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("DELETE /api/v1/reservations/{id}", handler, http.MethodDelete, "/api/v1/reservations/res-001", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+	assert.That(t, "body must contain the rule", strings.Contains(rec.Body.String(), reservation.ErrCannotCancelNearCheckIn.Error()), true)
+}
+
+func Test_HttpAPICancelReservation_Of_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	res := createTestReservation("res-001", "other@example.com", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	repo.put("res-001", *res)
+	handler := inbound.HttpAPICancelReservation(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("DELETE /api/v1/reservations/{id}", handler, http.MethodDelete, "/api/v1/reservations/res-001", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
@@ -0,0 +1,94 @@
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// APIDayPrice represents the nightly rate of a room type for one day.
+// Restrictions are only set on restricted days.
+type APIDayPrice struct {
+	Date              string   `json:"date"` // YYYY-MM-DD
+	Rate              APIMoney `json:"rate"`
+	Rules             []string `json:"rules,omitempty"`
+	Restricted        bool     `json:"restricted"`
+	MinStay           int      `json:"min_stay,omitempty"`
+	ClosedToArrival   bool     `json:"closed_to_arrival,omitempty"`
+	ClosedToDeparture bool     `json:"closed_to_departure,omitempty"`
+}
+
+// HttpAPIRoomPricesResponse specifies the JSON body of the price calendar of a room type.
+type HttpAPIRoomPricesResponse struct {
+	RoomType string        `json:"room_type"`
+	Month    string        `json:"month"` // YYYY-MM
+	Rooms    []string      `json:"rooms"`
+	Days     []APIDayPrice `json:"days"`
+}
+
+// HttpAPIGetRoomPrices returns the nightly rates of the room type in the path for every day
+// of the month given as query parameter (YYYY-MM, default: the current month) as JSON.
+func HttpAPIGetRoomPrices(rates *reservation.Rates) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		month, err := roomPricesMonth(r)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, APIError{Code: "invalid_month", Message: "month must be YYYY-MM"})
+			return
+		}
+		calendar, err := rates.PriceCalendar(reservation.RoomTypeID(r.PathValue("id")), month)
+		if errors.Is(err, reservation.ErrRoomTypeNotFound) {
+			writeAPIError(w, http.StatusNotFound, APIError{Code: "not_found", Message: "Room type not found"})
+			return
+		}
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, APIError{Code: "internal", Message: "Failed to price the room type"})
+			return
+		}
+
+		data := HttpAPIRoomPricesResponse{
+			RoomType: string(calendar.RoomType.ID),
+			Month:    calendar.Month.Format("2006-01"),
+			Rooms:    make([]string, 0, len(calendar.RoomType.RoomIDs)),
+			Days:     make([]APIDayPrice, 0, len(calendar.Days)),
+		}
+		for _, room := range calendar.RoomType.RoomIDs {
+			data.Rooms = append(data.Rooms, string(room))
+		}
+		for _, day := range calendar.Days {
+			data.Days = append(data.Days, APIDayPrice{
+				Date:              day.Date.Format(time.DateOnly),
+				Rate:              APIMoney{Amount: day.Rate.Amount, Currency: day.Rate.Currency},
+				Rules:             day.Rules,
+				Restricted:        !day.Restriction.IsZero(),
+				MinStay:           day.Restriction.MinStay,
+				ClosedToArrival:   day.Restriction.ClosedToArrival,
+				ClosedToDeparture: day.Restriction.ClosedToDeparture,
+			})
+		}
+		writeAPIJSON(w, http.StatusOK, data)
+	}
+}
+
+// RoomPricesVersion returns the version of a price calendar for WithWeakETag.
+// It changes with every rate change, so clients revalidate cached calendars cheaply.
+func RoomPricesVersion(rates *reservation.Rates) ETagVersionFunc {
+	return func(r *http.Request) (string, error) {
+		month, err := roomPricesMonth(r)
+		if err != nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%x-%s-%d", r.PathValue("id"), month.Format("2006-01"), rates.Version()), nil
+	}
+}
+
+// roomPricesMonth returns the month of the query parameter, or the current month.
+func roomPricesMonth(r *http.Request) (time.Time, error) {
+	value := r.URL.Query().Get("month")
+	if value == "" {
+		return time.Now().UTC(), nil
+	}
+	return time.Parse("2006-01", value)
+}
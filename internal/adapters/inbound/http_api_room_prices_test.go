@@ -0,0 +1,100 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestRates(t *testing.T) *reservation.Rates {
+	t.Helper()
+	policy, err := reservation.ParseRatePolicy("USD", "standard=10000 room-101 room-102", "weekend=15 fri sat", "sat=min2 cta")
+	if err != nil {
+		t.Fatalf("failed to parse rate policy: %v", err)
+	}
+	return reservation.NewRates(policy)
+}
+
+// ============================================================================
+// HttpAPIGetRoomPrices Tests
+// ============================================================================
+
+func Test_HttpAPIGetRoomPrices_Should_Return_Rate_Per_Day(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetRoomPrices(createTestRates(t))
+
+	// Act
+	rec := serveAPI("GET /api/v1/room-types/{id}/prices", handler, http.MethodGet, "/api/v1/room-types/standard/prices?month=2026-06", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body inbound.HttpAPIRoomPricesResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "month must be june", body.Month, "2026-06")
+	assert.That(t, "rooms must be listed", body.Rooms, []string{"room-101", "room-102"})
+	assert.That(t, "june must have 30 days", len(body.Days), 30)
+	assert.That(t, "monday must have the base rate", body.Days[0], inbound.APIDayPrice{Date: "2026-06-01", Rate: inbound.APIMoney{Amount: 10000, Currency: "USD"}})
+	assert.That(t, "saturday must be restricted", body.Days[5], inbound.APIDayPrice{
+		Date:            "2026-06-06",
+		Rate:            inbound.APIMoney{Amount: 11500, Currency: "USD"},
+		Rules:           []string{"weekend"},
+		Restricted:      true,
+		MinStay:         2,
+		ClosedToArrival: true,
+	})
+}
+
+func Test_HttpAPIGetRoomPrices_With_Unknown_Room_Type_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetRoomPrices(createTestRates(t))
+
+	// Act
+	rec := serveAPI("GET /api/v1/room-types/{id}/prices", handler, http.MethodGet, "/api/v1/room-types/penthouse/prices", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpAPIGetRoomPrices_With_Invalid_Month_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetRoomPrices(createTestRates(t))
+
+	// Act
+	rec := serveAPI("GET /api/v1/room-types/{id}/prices", handler, http.MethodGet, "/api/v1/room-types/standard/prices?month=june", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAPIGetRoomPrices_After_Rate_Change_Should_Change_ETag(t *testing.T) {
+	// Arrange
+	rates := createTestRates(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/room-types/{id}/prices", inbound.WithWeakETag(inbound.RoomPricesVersion(rates), inbound.HttpAPIGetRoomPrices(rates)))
+	first := httptest.NewRecorder()
+	mux.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/api/v1/room-types/standard/prices?month=2026-06", nil))
+	etag := first.Header().Get("ETag")
+
+	// Act
+	cached := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/room-types/standard/prices?month=2026-06", nil)
+	req.Header.Set("If-None-Match", etag)
+	mux.ServeHTTP(cached, req)
+	rates.SetPolicy(reservation.DefaultRatePolicy())
+	changed := httptest.NewRecorder()
+	mux.ServeHTTP(changed, req)
+
+	// Assert
+	assert.That(t, "etag must be set", etag != "", true)
+	assert.That(t, "unchanged calendar must be 304", cached.Code, http.StatusNotModified)
+	assert.That(t, "changed calendar must be 200", changed.Code, http.StatusOK)
+}
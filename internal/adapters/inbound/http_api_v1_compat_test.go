@@ -0,0 +1,292 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+)
+
+// The v1 compatibility suite locks the response shapes of the JSON API v1 in testdata/api/v1.
+// A shape is a JSON document whose values name the types ("string", "number", "boolean"),
+// whose arrays hold the shape of their items, and whose keys end with "?" if the field may be
+// omitted; the key "*" stands for any key of a map. A failing test here means existing clients
+// break: removed, renamed or retyped fields belong in the next version. Additive fields are
+// compatible, but they must be added to the shape, so every change to v1 is deliberate.
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// assertV1Shape asserts that the JSON body of the response has the shape in testdata/api/v1.
+func assertV1Shape(t *testing.T, shapeFile string, rec *httptest.ResponseRecorder) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/api/v1/" + shapeFile)
+	if err != nil {
+		t.Fatalf("failed to read shape: %v", err)
+	}
+	var shape, body any
+	if err := json.Unmarshal(data, &shape); err != nil {
+		t.Fatalf("failed to parse shape %s: %v", shapeFile, err)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+	assert.That(t, "body must have the v1 shape of "+shapeFile, shapeMismatches("$", shape, body), []string(nil))
+}
+
+// shapeMismatches returns where the value differs from the shape, e.g. "$.reservations[0].nights: want number".
+func shapeMismatches(path string, shape, value any) []string {
+	switch shape := shape.(type) {
+	case string:
+		var ok bool
+		switch shape {
+		case "string":
+			_, ok = value.(string)
+		case "number":
+			_, ok = value.(float64)
+		case "boolean":
+			_, ok = value.(bool)
+		}
+		if !ok {
+			return []string{fmt.Sprintf("%s: want %s, got %T", path, shape, value)}
+		}
+		return nil
+	case []any:
+		items, ok := value.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: want array, got %T", path, value)}
+		}
+		var mismatches []string
+		for i, item := range items {
+			mismatches = append(mismatches, shapeMismatches(fmt.Sprintf("%s[%d]", path, i), shape[0], item)...)
+		}
+		return mismatches
+	case map[string]any:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: want object, got %T", path, value)}
+		}
+		var mismatches []string
+		if item, ok := shape["*"]; ok {
+			for key, field := range fields {
+				mismatches = append(mismatches, shapeMismatches(path+"."+key, item, field)...)
+			}
+			slices.Sort(mismatches)
+			return mismatches
+		}
+		for key, fieldShape := range shape {
+			name, optional := strings.CutSuffix(key, "?")
+			field, present := fields[name]
+			switch {
+			case present:
+				mismatches = append(mismatches, shapeMismatches(path+"."+name, fieldShape, field)...)
+			case !optional:
+				mismatches = append(mismatches, path+"."+name+": missing")
+			}
+		}
+		for name := range fields {
+			if _, ok := shape[name]; !ok {
+				if _, ok := shape[name+"?"]; !ok {
+					mismatches = append(mismatches, path+"."+name+": not in the v1 shape")
+				}
+			}
+		}
+		slices.Sort(mismatches)
+		return mismatches
+	}
+	return []string{fmt.Sprintf("%s: invalid shape %v", path, shape)}
+}
+
+// ============================================================================
+// Shape Checker Tests
+// ============================================================================
+
+func Test_ShapeMismatches_Should_Report_Missing_Unknown_And_Retyped_Fields(t *testing.T) {
+	// Arrange
+	var shape, body any
+	_ = json.Unmarshal([]byte(`{"id": "string", "nights": "number", "tier?": "string"}`), &shape)
+	_ = json.Unmarshal([]byte(`{"nights": "2", "room": "room-101"}`), &body)
+
+	// Act
+	mismatches := shapeMismatches("$", shape, body)
+
+	// Assert
+	assert.That(t, "every difference must be reported", mismatches, []string{
+		"$.id: missing",
+		"$.nights: want number, got string",
+		"$.room: not in the v1 shape",
+	})
+}
+
+// ============================================================================
+// Reservation Shape Tests
+// ============================================================================
+
+func Test_V1_ListReservations_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	handler := inbound.HttpAPIListReservations(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations", handler, http.MethodGet, "/api/v1/reservations", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "reservations.json", rec)
+}
+
+func Test_V1_GetReservation_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	handler := inbound.HttpAPIGetReservation(createReservationsTestService(repo))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}", handler, http.MethodGet, "/api/v1/reservations/res-001", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "reservation.json", rec)
+}
+
+func Test_V1_CreateReservation_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+	checkIn := time.Now().AddDate(0, 0, 14).Format("2006-01-02")
+	checkOut := time.Now().AddDate(0, 0, 16).Format("2006-01-02")
+	body := `{"room_id":"room-201","check_in":"` + checkIn + `","check_out":"` + checkOut + `",` +
+		`"guests":[{"name":"Test Guest","email":"guest@example.com","phone":"+49 30 1234567"}],` +
+		`"emergency_contact":{"name":"Emergency Contact","phone":"+49 30 7654321","relationship":"sister"},"arrival_time":"18:30"}`
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", body, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assertV1Shape(t, "reservation.json", rec)
+}
+
+func Test_V1_GetReservationFinancials_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	svc := createReservationsTestService(repo)
+	handler := inbound.HttpAPIGetReservationFinancials(svc, createFinancialTestService(svc))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}/financials", handler, http.MethodGet, "/api/v1/reservations/res-001/financials", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "financials.json", rec)
+}
+
+func Test_V1_Error_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetReservation(createReservationsTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveAPI("GET /api/v1/reservations/{id}", handler, http.MethodGet, "/api/v1/reservations/missing", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+	assertV1Shape(t, "error.json", rec)
+}
+
+func Test_V1_Validation_Error_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPICreateReservation(createReservationsTestService(newMockReservationRepository()), nil, nil)
+
+	// Act
+	rec := serveAPI("POST /api/v1/reservations", handler, http.MethodPost, "/api/v1/reservations", `{"room_id":"room-201","guests":[]}`, "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 422", rec.Code, http.StatusUnprocessableEntity)
+	assertV1Shape(t, "error.json", rec)
+}
+
+// ============================================================================
+// Channel Manager Shape Tests
+// ============================================================================
+
+func Test_V1_GetRoomPrices_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIGetRoomPrices(createTestRates(t))
+
+	// Act
+	rec := serveAPI("GET /api/v1/room-types/{id}/prices", handler, http.MethodGet, "/api/v1/room-types/standard/prices?month=2026-06", "", "guest@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "room_prices.json", rec)
+}
+
+func Test_V1_InventoryChanges_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc, _ := createTestInventoryService(repo)
+	res := repo.get("res-001")
+	_, _ = svc.Refresh(context.Background(), inventory.RoomID(res.RoomID), res.DateRange.CheckIn, res.DateRange.CheckOut)
+	handler := inbound.HttpAPIInventoryChanges(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/changes", handler, http.MethodGet, "/api/v1/inventory/changes", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "inventory_changes.json", rec)
+}
+
+func Test_V1_InventorySnapshot_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	svc, _ := createTestInventoryService(newMockReservationRepository())
+	handler := inbound.HttpAPIInventorySnapshot(svc)
+
+	// Act
+	rec := serveAPI("GET /api/v1/inventory/rooms/{id}", handler, http.MethodGet, "/api/v1/inventory/rooms/room-101?days=4", "", "channel@example.com")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "inventory_snapshot.json", rec)
+}
+
+// ============================================================================
+// Kiosk Shape Tests
+// ============================================================================
+
+func Test_V1_KioskArrivals_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskArrivals(createKioskTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodGet, "/api/v1/kiosk/arrivals", "", kioskPrincipal)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "kiosk_arrivals.json", rec)
+}
+
+func Test_V1_KioskSync_Should_Keep_Shape(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAPIKioskSync(createKioskTestService(newMockReservationRepository()))
+
+	// Act
+	rec := serveKiosk(handler, http.MethodPost, "/api/v1/kiosk/sync", kioskSyncBody("op-1"), kioskPrincipal)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assertV1Shape(t, "kiosk_sync.json", rec)
+}
@@ -0,0 +1,241 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the end-of-life policy of the JSON API. Every endpoint lives below its version,
+// e.g. /api/v1/reservations; clients may leave the version out of the path (/api/reservations) and name
+// it in the API-Version header instead, or get the current version. Old versions are deprecated and
+// sunset by configuration: deprecated versions answer with the Deprecation, Sunset and Link headers
+// (RFC 9745, RFC 8594), sunset versions with 410 Gone.
+
+// APIVersionHeader names the version of the JSON API in requests and responses.
+const APIVersionHeader = "API-Version"
+
+// APIVersions are the versions of the JSON API, oldest first; the last is the current version.
+var APIVersions = []string{"v1"}
+
+// Metrics recorded by the JSON API if configured via APIVersionPolicy.WithMetrics.
+const (
+	MetricAPIRequests        = "hotel_api_requests_total"           // by version, route and status
+	MetricAPIRequestDuration = "hotel_api_request_duration_seconds" // by version and route
+)
+
+// Errors returned for an invalid version policy.
+var (
+	ErrUnknownAPIVersion   = errors.New("unknown API version")
+	ErrInvalidAPISunset    = errors.New("API version must be deprecated before its sunset")
+	ErrCurrentAPIVersion   = errors.New("current API version cannot be sunset")
+	ErrInvalidAPIDateEntry = errors.New("API version dates must be given as version=YYYY-MM-DD")
+)
+
+// APIVersionLifecycle is the end of life of a version: it is deprecated from Deprecated on and
+// answers with 410 Gone from Sunset on. A zero time means not planned.
+type APIVersionLifecycle struct {
+	Deprecated time.Time
+	Sunset     time.Time
+}
+
+// APIVersionPolicy knows the versions of the JSON API and their end of life.
+type APIVersionPolicy struct {
+	versions   []string
+	lifecycles map[string]APIVersionLifecycle
+	link       string // migration guide, sent as Link with rel="deprecation"
+	metrics    shared.Metrics
+	now        func() time.Time
+}
+
+// NewAPIVersionPolicy creates a policy for the versions, oldest first, without any end of life planned.
+func NewAPIVersionPolicy(versions []string) *APIVersionPolicy {
+	return &APIVersionPolicy{versions: versions, lifecycles: map[string]APIVersionLifecycle{}, now: time.Now}
+}
+
+// ParseAPIVersionPolicy creates a policy for the versions with the deprecation and sunset dates given
+// as comma-separated version=YYYY-MM-DD pairs, e.g. "v1=2027-01-01", and the URL of the migration guide.
+// A version must be deprecated before its sunset, and the current version cannot be sunset.
+func ParseAPIVersionPolicy(versions []string, deprecations, sunsets, link string) (*APIVersionPolicy, error) {
+	policy := NewAPIVersionPolicy(versions)
+	policy.link = link
+	deprecated, err := policy.parseDates(deprecations)
+	if err != nil {
+		return nil, err
+	}
+	sunset, err := policy.parseDates(sunsets)
+	if err != nil {
+		return nil, err
+	}
+	for version, at := range deprecated {
+		policy.lifecycles[version] = APIVersionLifecycle{Deprecated: at}
+	}
+	for version, at := range sunset {
+		lifecycle, ok := policy.lifecycles[version]
+		if !ok || !lifecycle.Deprecated.Before(at) {
+			return nil, ErrInvalidAPISunset
+		}
+		if version == policy.Current() {
+			return nil, ErrCurrentAPIVersion
+		}
+		lifecycle.Sunset = at
+		policy.lifecycles[version] = lifecycle
+	}
+	return policy, nil
+}
+
+// parseDates parses the version=YYYY-MM-DD pairs; the dates start at midnight UTC.
+func (p *APIVersionPolicy) parseDates(s string) (map[string]time.Time, error) {
+	dates := map[string]time.Time{}
+	for entry := range strings.SplitSeq(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		version, date, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, ErrInvalidAPIDateEntry
+		}
+		version = normalizeAPIVersion(version)
+		if !p.Known(version) {
+			return nil, ErrUnknownAPIVersion
+		}
+		at, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+		if err != nil {
+			return nil, ErrInvalidAPIDateEntry
+		}
+		dates[version] = at
+	}
+	return dates, nil
+}
+
+// WithMetrics counts the requests of the JSON API and observes their durations in the metrics.
+func (p *APIVersionPolicy) WithMetrics(metrics shared.Metrics) *APIVersionPolicy {
+	p.metrics = metrics
+	return p
+}
+
+// WithClock sets the clock the deprecation and sunset dates are compared to, e.g. a fixed time in tests.
+func (p *APIVersionPolicy) WithClock(now func() time.Time) *APIVersionPolicy {
+	p.now = now
+	return p
+}
+
+// Current returns the current version.
+func (p *APIVersionPolicy) Current() string {
+	return p.versions[len(p.versions)-1]
+}
+
+// Known reports whether the version exists, sunset or not.
+func (p *APIVersionPolicy) Known(version string) bool {
+	return slices.Contains(p.versions, version)
+}
+
+// Lifecycle returns the end of life of the version; zero if none is planned.
+func (p *APIVersionPolicy) Lifecycle(version string) APIVersionLifecycle {
+	return p.lifecycles[version]
+}
+
+// normalizeAPIVersion accepts versions as v1, V1 or 1.
+func normalizeAPIVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
+
+// isAPIVersion reports whether the path segment looks like a version, e.g. v1 or v12.
+func isAPIVersion(segment string) bool {
+	digits, ok := strings.CutPrefix(segment, "v")
+	if !ok || digits == "" {
+		return false
+	}
+	_, err := strconv.Atoi(digits)
+	return err == nil
+}
+
+// WithAPIVersion serves the endpoints of a version of the JSON API. Responses carry the version in the
+// API-Version header and, once it is deprecated, the Deprecation, Sunset and Link headers; from its
+// sunset on, the version answers 410 Gone. Requests naming another version in the header than in the
+// path are rejected, so a client never gets the shape of a version it did not ask for.
+func WithAPIVersion(policy *APIVersionPolicy, version string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		rec := &tracingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if policy.metrics == nil {
+				return
+			}
+			policy.metrics.IncCounter(MetricAPIRequests, "version", version, "route", r.Pattern, "status", strconv.Itoa(rec.status))
+			policy.metrics.ObserveHistogram(MetricAPIRequestDuration, time.Since(started).Seconds(), "version", version, "route", r.Pattern)
+		}()
+
+		header := rec.Header()
+		header.Set(APIVersionHeader, version)
+		lifecycle := policy.Lifecycle(version)
+		now := policy.now()
+		if !lifecycle.Deprecated.IsZero() && !now.Before(lifecycle.Deprecated) {
+			header.Set("Deprecation", "@"+strconv.FormatInt(lifecycle.Deprecated.Unix(), 10))
+			if policy.link != "" {
+				header.Add("Link", "<"+policy.link+`>; rel="deprecation"`)
+			}
+		}
+		if !lifecycle.Sunset.IsZero() {
+			header.Set("Sunset", lifecycle.Sunset.UTC().Format(http.TimeFormat))
+			if !now.Before(lifecycle.Sunset) {
+				writeAPIError(rec, http.StatusGone, APIError{
+					Code:    "version_sunset",
+					Message: "API " + version + " was retired on " + lifecycle.Sunset.Format("2006-01-02") + "; use " + policy.Current(),
+				})
+				return
+			}
+		}
+
+		if requested := r.Header.Get(APIVersionHeader); requested != "" && normalizeAPIVersion(requested) != version {
+			writeAPIError(rec, http.StatusBadRequest, APIError{
+				Code:    "version_mismatch",
+				Message: "API-Version " + requested + " does not match the version " + version + " of the path",
+			})
+			return
+		}
+		next(rec, r)
+	}
+}
+
+// HttpAPIVersionNegotiation serves the JSON API without a version in the path, e.g. /api/reservations:
+// it hands the request to the endpoint of the version named in the API-Version header, or of the
+// current version without the header. The endpoint authenticates and authorizes the request as usual.
+func HttpAPIVersionNegotiation(policy *APIVersionPolicy, mux http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rest := r.PathValue("path")
+		// Paths with a version reach this handler only if the version or the endpoint does not exist.
+		if first, _, _ := strings.Cut(rest, "/"); isAPIVersion(first) {
+			writeAPIError(w, http.StatusNotFound, APIError{Code: "not_found", Message: "no such endpoint"})
+			return
+		}
+
+		version := policy.Current()
+		if requested := r.Header.Get(APIVersionHeader); requested != "" {
+			version = normalizeAPIVersion(requested)
+			if !policy.Known(version) {
+				writeAPIError(w, http.StatusBadRequest, APIError{
+					Code:    "unsupported_version",
+					Message: "API-Version " + requested + " is not supported; supported are " + strings.Join(policy.versions, ", "),
+				})
+				return
+			}
+		}
+
+		versioned := r.Clone(r.Context())
+		versioned.URL.Path = "/api/" + version + "/" + rest
+		versioned.URL.RawPath = ""
+		versioned.Header.Set(APIVersionHeader, version)
+		w.Header().Add("Vary", APIVersionHeader)
+		mux.ServeHTTP(w, versioned)
+	}
+}
@@ -0,0 +1,234 @@
+package inbound_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestVersionPolicy returns a policy for v1 and v2 with v1 deprecated on 2027-01-01 and sunset
+// on 2027-07-01, at the given day.
+func createTestVersionPolicy(t *testing.T, day string) *inbound.APIVersionPolicy {
+	t.Helper()
+	policy, err := inbound.ParseAPIVersionPolicy([]string{"v1", "v2"}, "v1=2027-01-01", "v1=2027-07-01", "https://docs.example.com/api/migrate-v2")
+	if err != nil {
+		t.Fatalf("failed to parse version policy: %v", err)
+	}
+	now, _ := time.Parse("2006-01-02", day)
+	return policy.WithClock(func() time.Time { return now })
+}
+
+// serveVersioned serves the request on a mux with the v1 and v2 ping endpoints and the negotiation
+// of the version of /api/ping.
+func serveVersioned(policy *inbound.APIVersionPolicy, target, version string) *httptest.ResponseRecorder {
+	ping := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/ping", inbound.WithAPIVersion(policy, "v1", ping))
+	mux.HandleFunc("GET /api/v2/ping", inbound.WithAPIVersion(policy, "v2", ping))
+	mux.HandleFunc("/api/{path...}", inbound.HttpAPIVersionNegotiation(policy, mux))
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if version != "" {
+		req.Header.Set(inbound.APIVersionHeader, version)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// apiErrorCode returns the code of the JSON API error in the response.
+func apiErrorCode(rec *httptest.ResponseRecorder) string {
+	var body inbound.HttpAPIErrorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	return body.Error.Code
+}
+
+// ============================================================================
+// ParseAPIVersionPolicy Tests
+// ============================================================================
+
+func Test_ParseAPIVersionPolicy_Should_Reject_Invalid_Policies(t *testing.T) {
+	versions := []string{"v1", "v2"}
+	for _, tc := range []struct {
+		deprecations, sunsets string
+		want                  error
+	}{
+		{"v3=2027-01-01", "", inbound.ErrUnknownAPIVersion},
+		{"v1=01.01.2027", "", inbound.ErrInvalidAPIDateEntry},
+		{"", "v1=2027-07-01", inbound.ErrInvalidAPISunset},
+		{"v1=2027-07-01", "v1=2027-01-01", inbound.ErrInvalidAPISunset},
+		{"v2=2027-01-01", "v2=2027-07-01", inbound.ErrCurrentAPIVersion},
+	} {
+		// Act
+		_, err := inbound.ParseAPIVersionPolicy(versions, tc.deprecations, tc.sunsets, "")
+
+		// Assert
+		assert.That(t, "policy "+tc.deprecations+" / "+tc.sunsets+" must be rejected", err, tc.want)
+	}
+}
+
+func Test_ParseAPIVersionPolicy_Should_Accept_Versions_Without_Prefix(t *testing.T) {
+	// Act
+	policy, err := inbound.ParseAPIVersionPolicy([]string{"v1", "v2"}, "1=2027-01-01", "", "")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "v1 must be deprecated", policy.Lifecycle("v1").Deprecated, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "v2 must be current", policy.Current(), "v2")
+}
+
+// ============================================================================
+// WithAPIVersion Tests
+// ============================================================================
+
+func Test_WithAPIVersion_Before_Deprecation_Should_Only_Name_Version(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2026-10-17")
+
+	// Act
+	rec := serveVersioned(policy, "/api/v1/ping", "")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "version must be named", rec.Header().Get(inbound.APIVersionHeader), "v1")
+	assert.That(t, "version must not be deprecated yet", rec.Header().Get("Deprecation"), "")
+	assert.That(t, "sunset must be announced", rec.Header().Get("Sunset"), "Thu, 01 Jul 2027 00:00:00 GMT")
+}
+
+func Test_WithAPIVersion_Deprecated_Version_Should_Send_Deprecation_Headers(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2027-03-01")
+
+	// Act
+	rec := serveVersioned(policy, "/api/v1/ping", "")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "deprecation must be the date as unix time", rec.Header().Get("Deprecation"), "@1798761600")
+	assert.That(t, "sunset must be an HTTP date", rec.Header().Get("Sunset"), "Thu, 01 Jul 2027 00:00:00 GMT")
+	assert.That(t, "link must point to the migration guide", rec.Header().Get("Link"), `<https://docs.example.com/api/migrate-v2>; rel="deprecation"`)
+}
+
+func Test_WithAPIVersion_Current_Version_Should_Not_Be_Deprecated(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2027-03-01")
+
+	// Act
+	rec := serveVersioned(policy, "/api/v2/ping", "")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "version must be named", rec.Header().Get(inbound.APIVersionHeader), "v2")
+	assert.That(t, "deprecation must be absent", rec.Header().Get("Deprecation"), "")
+	assert.That(t, "sunset must be absent", rec.Header().Get("Sunset"), "")
+}
+
+func Test_WithAPIVersion_After_Sunset_Should_Return_410(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2027-07-01")
+
+	// Act
+	rec := serveVersioned(policy, "/api/v1/ping", "")
+
+	// Assert
+	assert.That(t, "status code must be 410", rec.Code, http.StatusGone)
+	assert.That(t, "error code must be version_sunset", apiErrorCode(rec), "version_sunset")
+}
+
+func Test_WithAPIVersion_With_Other_Version_Header_Should_Return_400(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2026-10-17")
+
+	// Act
+	rec := serveVersioned(policy, "/api/v1/ping", "v2")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "error code must be version_mismatch", apiErrorCode(rec), "version_mismatch")
+}
+
+func Test_WithAPIVersion_Should_Count_Requests_Per_Version(t *testing.T) {
+	// Arrange
+	metrics := outbound.NewMetrics()
+	policy := createTestVersionPolicy(t, "2027-07-01").WithMetrics(metrics)
+	serveVersioned(policy, "/api/v1/ping", "")
+	serveVersioned(policy, "/api/v2/ping", "")
+
+	// Act
+	var buf bytes.Buffer
+	_ = metrics.WritePrometheus(&buf)
+
+	// Assert
+	body := buf.String()
+	assert.That(t, "sunset request must be counted", strings.Contains(body, `hotel_api_requests_total{version="v1",route="GET /api/v1/ping",status="410"} 1`), true)
+	assert.That(t, "current request must be counted", strings.Contains(body, `hotel_api_requests_total{version="v2",route="GET /api/v2/ping",status="200"} 1`), true)
+	assert.That(t, "duration must be observed", strings.Contains(body, `hotel_api_request_duration_seconds_count{version="v2",route="GET /api/v2/ping"} 1`), true)
+}
+
+// ============================================================================
+// HttpAPIVersionNegotiation Tests
+// ============================================================================
+
+func Test_HttpAPIVersionNegotiation_Without_Header_Should_Serve_Current_Version(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2026-10-17")
+
+	// Act
+	rec := serveVersioned(policy, "/api/ping", "")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "current version must serve the request", rec.Body.String(), "/api/v2/ping")
+	assert.That(t, "response must vary by version", rec.Header().Get("Vary"), inbound.APIVersionHeader)
+}
+
+func Test_HttpAPIVersionNegotiation_With_Header_Should_Serve_Requested_Version(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2027-03-01")
+
+	// Act
+	rec := serveVersioned(policy, "/api/ping", "1")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "requested version must serve the request", rec.Body.String(), "/api/v1/ping")
+	assert.That(t, "version must be named", rec.Header().Get(inbound.APIVersionHeader), "v1")
+	assert.That(t, "deprecation must be announced", rec.Header().Get("Deprecation"), "@1798761600")
+}
+
+func Test_HttpAPIVersionNegotiation_With_Unknown_Version_Should_Return_400(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2026-10-17")
+
+	// Act
+	rec := serveVersioned(policy, "/api/ping", "v9")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "error code must be unsupported_version", apiErrorCode(rec), "unsupported_version")
+}
+
+func Test_HttpAPIVersionNegotiation_Unknown_Endpoint_Should_Return_404(t *testing.T) {
+	// Arrange
+	policy := createTestVersionPolicy(t, "2026-10-17")
+
+	// Act
+	rec := serveVersioned(policy, "/api/pong", "")
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+	assert.That(t, "error code must be not_found", apiErrorCode(rec), "not_found")
+}
@@ -0,0 +1,40 @@
+package inbound
+
+import (
+	"net/http"
+)
+
+// AppAssociations provides the files that let iOS and Android open the links of the emails in the
+// companion app. They return nil if the app of the platform is not configured.
+type AppAssociations interface {
+	AppleAppSiteAssociation() []byte
+	AssetLinks() []byte
+}
+
+// HttpAppleAppSiteAssociation serves the apple-app-site-association file, which iOS fetches when
+// the app is installed to learn which links of the domain it opens. Without an Apple app it is 404.
+func HttpAppleAppSiteAssociation(associations AppAssociations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveAppAssociation(w, r, associations.AppleAppSiteAssociation())
+	}
+}
+
+// HttpAssetLinks serves the Digital Asset Links file (assetlinks.json), which Android verifies
+// before it opens the links of the domain in the app. Without an Android app it is 404.
+func HttpAssetLinks(associations AppAssociations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveAppAssociation(w, r, associations.AssetLinks())
+	}
+}
+
+// serveAppAssociation writes the file as JSON. The platforms fetch it without redirects or
+// authentication, so it must be served directly with a 200.
+func serveAppAssociation(w http.ResponseWriter, r *http.Request, data []byte) {
+	if data == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,59 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// App Association Test Helpers
+// ============================================================================
+
+type mockAppAssociations struct {
+	apple   []byte
+	android []byte
+}
+
+func (m *mockAppAssociations) AppleAppSiteAssociation() []byte { return m.apple }
+
+func (m *mockAppAssociations) AssetLinks() []byte { return m.android }
+
+// ============================================================================
+// HttpAppleAppSiteAssociation Tests
+// ============================================================================
+
+func Test_HttpAppleAppSiteAssociation_Should_Serve_JSON(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAppleAppSiteAssociation(&mockAppAssociations{apple: []byte(`{"applinks":{}}`)})
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/apple-app-site-association", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be JSON", rec.Header().Get("Content-Type"), "application/json")
+	assert.That(t, "body must be the file", rec.Body.String(), `{"applinks":{}}`)
+}
+
+// ============================================================================
+// HttpAssetLinks Tests
+// ============================================================================
+
+func Test_HttpAssetLinks_Without_Android_App_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAssetLinks(&mockAppAssociations{apple: []byte(`{}`)})
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/assetlinks.json", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
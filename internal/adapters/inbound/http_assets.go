@@ -0,0 +1,158 @@
+package inbound
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// This file contains the content-hash fingerprinting of static assets.
+// Templates reference assets like "/static/css/base.css". At startup we hash
+// every file under assets/static and rewrite those references in the templates
+// to "/static/css/base.css?v=<hash>", so browsers can cache them forever and
+// still pick up changes on the next deployment.
+
+// AssetFingerprints maps static asset URLs to their content hashes.
+type AssetFingerprints struct {
+	hashes map[string]string
+}
+
+// NewAssetFingerprints hashes all files under assets/static of the given fs.FS.
+func NewAssetFingerprints(efs fs.FS) (*AssetFingerprints, error) {
+	hashes := make(map[string]string)
+	err := fs.WalkDir(efs, "assets/static", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(efs, p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		url := "/" + strings.TrimPrefix(p, "assets/")
+		hashes[url] = hex.EncodeToString(sum[:])[:12]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AssetFingerprints{hashes: hashes}, nil
+}
+
+// Hash returns the content hash for the asset URL or an empty string if unknown.
+func (a *AssetFingerprints) Hash(url string) string {
+	return a.hashes[url]
+}
+
+// URL returns the fingerprinted URL for the asset or the URL unchanged if unknown.
+func (a *AssetFingerprints) URL(url string) string {
+	hash, ok := a.hashes[url]
+	if !ok {
+		return url
+	}
+	return url + "?v=" + hash
+}
+
+// Rewrite replaces all quoted asset references in the content with fingerprinted URLs.
+func (a *AssetFingerprints) Rewrite(content []byte) []byte {
+	for url := range a.hashes {
+		content = bytes.ReplaceAll(content, []byte(`"`+url+`"`), []byte(`"`+a.URL(url)+`"`))
+	}
+	return content
+}
+
+// TemplateFS returns a read-only view of efs in which the templates under assets/templates
+// have all asset references rewritten to their fingerprinted URLs.
+// The templates are rewritten once here; the result is meant to be parsed at startup by the templating engine.
+func (a *AssetFingerprints) TemplateFS(efs fs.FS) (fs.FS, error) {
+	files := make(map[string][]byte)
+	matches, err := fs.Glob(efs, "assets/templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range matches {
+		data, err := fs.ReadFile(efs, name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = a.Rewrite(data)
+	}
+	return rewrittenFS{FS: efs, files: files}, nil
+}
+
+// rewrittenFS serves the rewritten content of its files and everything else, e.g. directories, from the wrapped fs.FS.
+type rewrittenFS struct {
+	fs.FS
+	files map[string][]byte
+}
+
+// Open opens the named file, with its rewritten content if there is one.
+func (r rewrittenFS) Open(name string) (fs.File, error) {
+	file, err := r.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := r.files[name]
+	if !ok {
+		return file, nil
+	}
+	info, err := file.Stat()
+	_ = file.Close()
+	if err != nil {
+		return nil, err
+	}
+	return &rewrittenFile{Reader: bytes.NewReader(data), info: rewrittenFileInfo{FileInfo: info, size: int64(len(data))}}, nil
+}
+
+// rewrittenFile is an open file with rewritten content.
+type rewrittenFile struct {
+	*bytes.Reader
+	info rewrittenFileInfo
+}
+
+func (f *rewrittenFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *rewrittenFile) Close() error               { return nil }
+
+// rewrittenFileInfo reports the size of the rewritten content instead of the original one.
+type rewrittenFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i rewrittenFileInfo) Size() int64 { return i.size }
+
+// HttpStaticAssets serves the static assets under /static/ with negotiated compression.
+// Requests carrying a matching fingerprint get far-future cache headers, all others
+// must revalidate, so stale URLs never pin an outdated asset in the browser.
+func HttpStaticAssets(efs fs.FS, fingerprints *AssetFingerprints) http.HandlerFunc {
+	staticFS, err := fs.Sub(efs, "assets")
+	if err != nil {
+		panic(err)
+	}
+	next := WithCompression(http.FileServerFS(staticFS).ServeHTTP)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		hash := fingerprints.Hash(path.Clean(r.URL.Path))
+
+		// Strong ETag derived from the content hash.
+		// http.FileServer answers If-None-Match with 304 when the ETag header is set.
+		if hash != "" {
+			w.Header().Set("ETag", `"`+hash+`"`)
+		}
+
+		version := r.URL.Query().Get("v")
+		if version != "" && version == hash {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,153 @@
+package inbound_test
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// AssetFingerprints Tests
+// ============================================================================
+
+func Test_NewAssetFingerprints_Should_Hash_Static_Files(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+
+	// Act
+	fingerprints, err := inbound.NewAssetFingerprints(efs)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "hash must not be empty", fingerprints.Hash("/static/css/test.css") != "", true)
+}
+
+func Test_AssetFingerprints_URL_Should_Append_Version(t *testing.T) {
+	// Arrange
+	fingerprints, _ := inbound.NewAssetFingerprints(getRouterTestFS(t))
+	hash := fingerprints.Hash("/static/css/test.css")
+
+	// Act
+	url := fingerprints.URL("/static/css/test.css")
+
+	// Assert
+	assert.That(t, "url must contain hash", url, "/static/css/test.css?v="+hash)
+}
+
+func Test_AssetFingerprints_URL_With_Unknown_Asset_Should_Return_Unchanged(t *testing.T) {
+	// Arrange
+	fingerprints, _ := inbound.NewAssetFingerprints(getRouterTestFS(t))
+
+	// Act
+	url := fingerprints.URL("/static/css/unknown.css")
+
+	// Assert
+	assert.That(t, "url must be unchanged", url, "/static/css/unknown.css")
+}
+
+func Test_AssetFingerprints_Rewrite_Should_Replace_Quoted_References(t *testing.T) {
+	// Arrange
+	fingerprints, _ := inbound.NewAssetFingerprints(getRouterTestFS(t))
+	content := []byte(`<link rel="stylesheet" href="/static/css/test.css" />`)
+
+	// Act
+	rewritten := string(fingerprints.Rewrite(content))
+
+	// Assert
+	assert.That(t, "reference must be fingerprinted", containsString(rewritten, fingerprints.URL("/static/css/test.css")), true)
+}
+
+func Test_AssetFingerprints_TemplateFS_Should_Contain_Templates(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+
+	// Act
+	templateFS, err := fingerprints.TemplateFS(efs)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	matches, _ := fs.Glob(templateFS, "assets/templates/*.tmpl")
+	assert.That(t, "templates must be present", len(matches) > 0, true)
+}
+
+func Test_AssetFingerprints_TemplateFS_Should_Serve_Rewritten_Templates(t *testing.T) {
+	// Arrange
+	efs := fstest.MapFS{
+		"assets/static/css/test.css":  &fstest.MapFile{Data: []byte("body {}")},
+		"assets/templates/index.tmpl": &fstest.MapFile{Data: []byte(`<link href="/static/css/test.css" />`)},
+	}
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+	templateFS, _ := fingerprints.TemplateFS(efs)
+
+	// Act
+	data, err := fs.ReadFile(templateFS, "assets/templates/index.tmpl")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "reference must be fingerprinted", string(data), `<link href="`+fingerprints.URL("/static/css/test.css")+`" />`)
+}
+
+// ============================================================================
+// HttpStaticAssets Tests
+// ============================================================================
+
+func Test_HttpStaticAssets_With_Matching_Version_Should_Set_Immutable_Cache(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+	handler := inbound.HttpStaticAssets(efs, fingerprints)
+	req := httptest.NewRequest(http.MethodGet, fingerprints.URL("/static/css/test.css"), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "cache control must be immutable", rec.Header().Get("Cache-Control"), "public, max-age=31536000, immutable")
+}
+
+func Test_HttpStaticAssets_Without_Version_Should_Require_Revalidation(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+	handler := inbound.HttpStaticAssets(efs, fingerprints)
+	req := httptest.NewRequest(http.MethodGet, "/static/css/test.css", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "cache control must be no-cache", rec.Header().Get("Cache-Control"), "no-cache")
+}
+
+// ============================================================================
+// HttpCachedView Tests
+// ============================================================================
+
+func Test_HttpCachedView_Should_Render_Same_Content_Twice(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(viewTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	data := inbound.HttpViewLoginResponse{AppName: "CachedApp", Title: "Cached"}
+	handler := inbound.HttpCachedView(e, "login", data)
+
+	// Act
+	first := httptest.NewRecorder()
+	handler(first, httptest.NewRequest(http.MethodGet, "/ui/login", nil))
+	second := httptest.NewRecorder()
+	handler(second, httptest.NewRequest(http.MethodGet, "/ui/login", nil))
+
+	// Assert
+	assert.That(t, "body must contain app name", containsString(first.Body.String(), "CachedApp"), true)
+	assert.That(t, "bodies must match", second.Body.String(), first.Body.String())
+}
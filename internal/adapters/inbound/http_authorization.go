@@ -0,0 +1,123 @@
+package inbound
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// roleClaims are the claims carrying the roles of a token: the roles claim and Keycloak's realm roles.
+type roleClaims struct {
+	Roles       []string `json:"roles"`
+	RealmAccess struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
+}
+
+// roles returns the roles of both claims.
+func (c roleClaims) roles() []shared.Role {
+	var roles []shared.Role
+	for _, role := range append(c.Roles, c.RealmAccess.Roles...) {
+		roles = append(roles, shared.Role(role))
+	}
+	return roles
+}
+
+// RoleAuthorizer grants principals the permissions of their OIDC roles.
+// The permissions can be replaced at runtime, e.g. by a config reload.
+type RoleAuthorizer struct {
+	permissions shared.RolePermissions
+	mu          sync.RWMutex // guards permissions
+}
+
+// NewRoleAuthorizer creates an authorizer granting the permissions of the roles.
+func NewRoleAuthorizer(permissions shared.RolePermissions) *RoleAuthorizer {
+	return &RoleAuthorizer{permissions: permissions}
+}
+
+// SetPermissions replaces the roles and their permissions.
+// Permissions are resolved on every request, so callers get the new permissions of their roles immediately.
+func (a *RoleAuthorizer) SetPermissions(permissions shared.RolePermissions) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.permissions = permissions
+}
+
+// Grant returns the permissions of the roles and whether any of them is known.
+func (a *RoleAuthorizer) Grant(roles []shared.Role) ([]string, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.permissions.Grant(roles)
+}
+
+// WithRoles limits principals to the permissions of their roles, so shared.RequireScope rejects tool calls
+// and requests their roles do not permit. Principals without a known role, e.g. with only the default roles
+// of the identity provider, get the role of their issuer like signed-in users (see WithUIPermission),
+// and no permissions if the policy does not know that role either. Service accounts without a known role
+// are left to WithServiceAccount. It must be wrapped by WithTokenAuth and run before WithServiceAccount and
+// WithStaffDirectory, whose scopes take precedence over the roles.
+func WithRoles(authorizer *RoleAuthorizer, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := shared.PrincipalFromContext(r.Context())
+		if !ok {
+			next(w, r)
+			return
+		}
+		permissions, known := authorizer.Grant(principal.Roles)
+		if !known {
+			if principal.Type == shared.PrincipalService {
+				next(w, r)
+				return
+			}
+			permissions, _ = authorizer.Grant([]shared.Role{sessionRole(principal.Type)})
+		}
+		principal.Scopes = permissions
+		principal.RoleBased = true
+		next(w, r.WithContext(shared.ContextWithPrincipal(r.Context(), principal)))
+	}
+}
+
+// WithPermission rejects JSON API requests whose principal lacks the permission with 403 Forbidden.
+// Principals that are not restricted by scopes pass (see shared.RequireScope).
+func WithPermission(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := shared.RequireScope(r.Context(), permission); err != nil {
+			writeAPIError(w, http.StatusForbidden, APIError{Code: "forbidden", Message: err.Error()})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// WithUIPermission rejects UI requests of signed-in users whose role lacks shared.PermissionUI with 403 Forbidden.
+// Sessions carry no roles, so the role is derived from the issuer that signed the user in:
+// the staff realm is the staff role, the guest realm and issuers the token verifier does not trust
+// the guest role. A role the policy does not know grants nothing. Anonymous requests pass, the handlers
+// redirect them to the login. It must be wrapped by web.WithAuth.
+func WithUIPermission(authorizer *RoleAuthorizer, issuers PrincipalTypeResolver, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		issuer, _ := r.Context().Value(web.ContextIssuer).(string)
+		if issuer == "" {
+			next(w, r)
+			return
+		}
+		principalType, _ := issuers.PrincipalType(issuer)
+		permissions, _ := authorizer.Grant([]shared.Role{sessionRole(principalType)})
+		if !slices.Contains(permissions, shared.PermissionUI) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// sessionRole returns the role of a user signed in by an issuer of the principal type.
+func sessionRole(principalType shared.PrincipalType) shared.Role {
+	if principalType == shared.PrincipalStaff {
+		return shared.RoleStaff
+	}
+	return shared.RoleGuest
+}
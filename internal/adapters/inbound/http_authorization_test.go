@@ -0,0 +1,183 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Authorization Test Helpers
+// ============================================================================
+
+// testRolePermissions lets guests use the UI and read reservations, staff only read reservations.
+func testRolePermissions() *inbound.RoleAuthorizer {
+	return inbound.NewRoleAuthorizer(shared.RolePermissions{
+		shared.RoleGuest:       {shared.PermissionUI, "reservations:read"},
+		shared.RoleStaff:       {"reservations:read"},
+		shared.RoleMCPReadOnly: {"payments:read", "reservations:read"},
+	})
+}
+
+// mockIssuers trusts the guest realm as guests and the staff realm as staff.
+type mockIssuers struct{}
+
+func (mockIssuers) PrincipalType(issuer string) (shared.PrincipalType, bool) {
+	switch issuer {
+	case "https://sso.example.com/realms/guests":
+		return shared.PrincipalGuest, true
+	case "https://sso.example.com/realms/staff":
+		return shared.PrincipalStaff, true
+	}
+	return "", false
+}
+
+// sessionRequest returns a UI request of a user signed in by the issuer.
+func sessionRequest(issuer string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
+	return req.WithContext(context.WithValue(req.Context(), web.ContextIssuer, issuer))
+}
+
+// ============================================================================
+// WithRoles Tests
+// ============================================================================
+
+func Test_WithRoles_With_Known_Role_Should_Limit_To_Role_Permissions(t *testing.T) {
+	// Arrange
+	var readErr, writeErr error
+	handler := inbound.WithRoles(testRolePermissions(), func(w http.ResponseWriter, r *http.Request) {
+		readErr = shared.RequireScope(r.Context(), "payments:read")
+		writeErr = shared.RequireScope(r.Context(), "reservations:write")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Roles: []shared.Role{"offline_access", shared.RoleMCPReadOnly}}))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "permission of the role must be granted", readErr, nil)
+	assert.That(t, "permission outside the role must be denied", writeErr != nil, true)
+}
+
+func Test_WithRoles_Without_Known_Role_Should_Get_Role_Of_Issuer(t *testing.T) {
+	// Arrange
+	var readErr, writeErr error
+	handler := inbound.WithRoles(testRolePermissions(), func(w http.ResponseWriter, r *http.Request) {
+		readErr = shared.RequireScope(r.Context(), "reservations:read")
+		writeErr = shared.RequireScope(r.Context(), "reservations:write")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Roles: []shared.Role{"offline_access"}}))
+
+	// Assert
+	assert.That(t, "permission of the staff role must be granted", readErr, nil)
+	assert.That(t, "permission outside the staff role must be denied", writeErr != nil, true)
+}
+
+func Test_WithRoles_Without_Known_Role_Of_Caller_Or_Issuer_Should_Grant_Nothing(t *testing.T) {
+	// Arrange
+	var scopeErr error
+	authorizer := inbound.NewRoleAuthorizer(shared.RolePermissions{shared.RoleMCPReadOnly: {"reservations:read"}})
+	handler := inbound.WithRoles(authorizer, func(w http.ResponseWriter, r *http.Request) {
+		scopeErr = shared.RequireScope(r.Context(), "reservations:read")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff}))
+
+	// Assert
+	assert.That(t, "caller without a known role must be restricted", scopeErr != nil, true)
+}
+
+// ============================================================================
+// WithPermission Tests
+// ============================================================================
+
+func Test_WithPermission_Without_Permission_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	handler := inbound.WithRoles(testRolePermissions(), inbound.WithPermission("reservations:write", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalGuest, Roles: []shared.Role{shared.RoleGuest}}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+	assert.That(t, "body must carry the error code", containsString(rec.Body.String(), `"forbidden"`), true)
+}
+
+func Test_WithPermission_With_Staff_Token_Without_Roles_Should_Return_403_On_Write(t *testing.T) {
+	// Arrange
+	called := false
+	handler := inbound.WithRoles(testRolePermissions(), inbound.WithPermission("reservations:write", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
+
+// ============================================================================
+// WithUIPermission Tests
+// ============================================================================
+
+func Test_WithUIPermission_With_Role_Without_UI_Permission_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	handler := inbound.WithUIPermission(testRolePermissions(), mockIssuers{}, func(w http.ResponseWriter, r *http.Request) { called = true })
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, sessionRequest("https://sso.example.com/realms/staff"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
+
+func Test_WithUIPermission_With_Guest_Or_Anonymous_User_Should_Pass(t *testing.T) {
+	// Arrange
+	calls := 0
+	handler := inbound.WithUIPermission(testRolePermissions(), mockIssuers{}, func(w http.ResponseWriter, r *http.Request) { calls++ })
+
+	// Act
+	handler(httptest.NewRecorder(), sessionRequest("https://sso.example.com/realms/guests"))
+	handler(httptest.NewRecorder(), sessionRequest(""))
+
+	// Assert
+	assert.That(t, "both requests must be handled", calls, 2)
+}
+
+func Test_WithUIPermission_Without_Known_Role_Should_Return_403(t *testing.T) {
+	// Arrange
+	called := false
+	authorizer := inbound.NewRoleAuthorizer(shared.RolePermissions{shared.RoleMCPReadOnly: {"reservations:read"}})
+	handler := inbound.WithUIPermission(authorizer, mockIssuers{}, func(w http.ResponseWriter, r *http.Request) { called = true })
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, sessionRequest("https://sso.example.com/realms/guests"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
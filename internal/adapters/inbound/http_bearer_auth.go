@@ -0,0 +1,137 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// TokenVerifier verifies raw Bearer tokens.
+// *oidc.IDTokenVerifier, outbound.ResilientTokenVerifier and outbound.MultiIssuerTokenVerifier implement it.
+type TokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) (*oidc.IDToken, error)
+}
+
+// PrincipalTypeResolver maps a trusted issuer to the principal type of its tokens.
+// Verifiers implementing it let WithTokenAuth store a shared.Principal in the context.
+type PrincipalTypeResolver interface {
+	PrincipalType(issuer string) (shared.PrincipalType, bool)
+}
+
+// clientCredentialsClaims are the claims Keycloak sets on client-credentials tokens.
+type clientCredentialsClaims struct {
+	ClientID          string `json:"client_id"`
+	AuthorizedParty   string `json:"azp"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// clientID returns the OAuth client ID if the token was issued via the client-credentials flow.
+// Tokens of human users (authorization code flow) return an empty string.
+func (c clientCredentialsClaims) clientID() string {
+	if c.ClientID != "" {
+		return c.ClientID
+	}
+	if strings.HasPrefix(c.PreferredUsername, "service-account-") {
+		return c.AuthorizedParty
+	}
+	return ""
+}
+
+// bearerAuthRetryAfter is the Retry-After hint in seconds when the identity provider is unavailable.
+const bearerAuthRetryAfter = 10
+
+// WithTokenAuth is the resilient counterpart of web.WithBearerAuth.
+// Invalid tokens are rejected with 401, but if the identity provider is temporarily
+// unavailable the request is answered with 503 and a Retry-After hint, so clients
+// retry instead of discarding their (valid) token.
+// The context is populated with the same keys as web.WithBearerAuth.
+func WithTokenAuth(verifier TokenVerifier, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rawToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || rawToken == "" {
+			writeBearerAuthError(w, http.StatusUnauthorized, "Missing or invalid Bearer token")
+			return
+		}
+
+		idToken, err := verifier.Verify(r.Context(), rawToken)
+		if err != nil {
+			var temporary interface{ Temporary() bool }
+			if errors.As(err, &temporary) && temporary.Temporary() {
+				w.Header().Set("Retry-After", strconv.Itoa(bearerAuthRetryAfter))
+				writeBearerAuthError(w, http.StatusServiceUnavailable, "Identity provider unavailable, retry later")
+				return
+			}
+			writeBearerAuthError(w, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		var claims web.IdentityTokenClaims
+		if err := idToken.Claims(&claims); err != nil {
+			writeBearerAuthError(w, http.StatusUnauthorized, "Failed to parse token claims")
+			return
+		}
+		var clientClaims clientCredentialsClaims
+		_ = idToken.Claims(&clientClaims)
+		var roles roleClaims
+		_ = idToken.Claims(&roles)
+
+		ctx := r.Context()
+		ctx = context.WithValue(ctx, web.ContextEmail, claims.Email)
+		ctx = context.WithValue(ctx, web.ContextIssuer, claims.Issuer)
+		ctx = context.WithValue(ctx, web.ContextName, claims.Name)
+		ctx = context.WithValue(ctx, web.ContextSubject, claims.Subject)
+		ctx = context.WithValue(ctx, web.ContextVerified, claims.Verified)
+
+		// Derive the principal type from the issuer, e.g. guest realm vs. staff realm.
+		if resolver, ok := verifier.(PrincipalTypeResolver); ok {
+			principalType, ok := resolver.PrincipalType(claims.Issuer)
+			if !ok {
+				writeBearerAuthError(w, http.StatusForbidden, "Untrusted token issuer")
+				return
+			}
+			ctx = shared.ContextWithPrincipal(ctx, shared.Principal{
+				Type:     principalType,
+				Issuer:   claims.Issuer,
+				Subject:  claims.Subject,
+				Email:    claims.Email,
+				ClientID: clientClaims.clientID(),
+				Roles:    roles.roles(),
+			})
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Frame-Options", "DENY")
+
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// writeBearerAuthError writes a JSON-RPC 2.0 error response, as expected by MCP clients.
+func writeBearerAuthError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(mcp.NewErrorResponse(nil, mcp.ErrorCodeInvalidRequest, message))
+}
+
+// WithStaffOnly rejects requests whose principal is not staff with 403 Forbidden.
+// It must be wrapped by WithTokenAuth with a PrincipalTypeResolver, otherwise every request is rejected.
+func WithStaffOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := shared.PrincipalFromContext(r.Context())
+		if !ok || principal.Type != shared.PrincipalStaff {
+			writeBearerAuthError(w, http.StatusForbidden, "Staff token required")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,112 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockTokenVerifier struct {
+	err error
+}
+
+func (m *mockTokenVerifier) Verify(ctx context.Context, rawToken string) (*oidc.IDToken, error) {
+	return nil, m.err
+}
+
+type temporaryTestError struct{}
+
+func (temporaryTestError) Error() string   { return "identity provider unavailable" }
+func (temporaryTestError) Temporary() bool { return true }
+
+// ============================================================================
+// WithTokenAuth Tests
+// ============================================================================
+
+func Test_WithTokenAuth_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := inbound.WithTokenAuth(&mockTokenVerifier{}, func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_WithTokenAuth_With_Invalid_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := inbound.WithTokenAuth(&mockTokenVerifier{err: errors.New("invalid signature")}, func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+	assert.That(t, "body must be JSON-RPC", containsString(rec.Body.String(), `"jsonrpc":"2.0"`), true)
+}
+
+func Test_WithTokenAuth_With_Unavailable_Provider_Should_Return_503_With_Retry_After(t *testing.T) {
+	// Arrange
+	handler := inbound.WithTokenAuth(&mockTokenVerifier{err: temporaryTestError{}}, func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 503", rec.Code, http.StatusServiceUnavailable)
+	assert.That(t, "retry after must be set", rec.Header().Get("Retry-After") != "", true)
+}
+
+// ============================================================================
+// WithStaffOnly Tests
+// ============================================================================
+
+func Test_WithStaffOnly_With_Guest_Principal_Should_Return_403(t *testing.T) {
+	// Arrange
+	handler := inbound.WithStaffOnly(func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req = req.WithContext(shared.ContextWithPrincipal(req.Context(), shared.Principal{Type: shared.PrincipalGuest}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_WithStaffOnly_With_Staff_Principal_Should_Call_Next(t *testing.T) {
+	// Arrange
+	handler := inbound.WithStaffOnly(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req = req.WithContext(shared.ContextWithPrincipal(req.Context(), shared.Principal{Type: shared.PrincipalStaff}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+// BlobDownloadLinks signs and resolves the download links of generated files (profiles, exports, invoices).
+type BlobDownloadLinks interface {
+	Sign(key string) string
+	Open(ctx context.Context, token string) (string, []byte, error)
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// HttpAdminBlob is a stored file with a signed download link.
+type HttpAdminBlob struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+// HttpAdminBlobs lists the stored files below the prefix query parameter with signed download links as JSON.
+func HttpAdminBlobs(links BlobDownloadLinks) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keys, err := links.Keys(r.Context(), r.URL.Query().Get("prefix"))
+		if err != nil {
+			http.Error(w, "failed to list files", http.StatusInternalServerError)
+			return
+		}
+
+		blobs := make([]HttpAdminBlob, 0, len(keys))
+		for _, key := range keys {
+			blobs = append(blobs, HttpAdminBlob{Key: key, URL: absoluteURL(r, "/blobs/"+links.Sign(key))})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(blobs)
+	}
+}
+
+// HttpDownloadBlob serves the file of a signed download link as attachment.
+// The signed token is the authorization, so the link can be handed to an integrator or guest.
+func HttpDownloadBlob(links BlobDownloadLinks, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, data, err := links.Open(r.Context(), r.PathValue("token"))
+		if err != nil {
+			logger.Warn("blob download failed", "error", err)
+			http.Error(w, "File not found or link expired", http.StatusNotFound)
+			return
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(key))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(key)}))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Cache-Control", "private, no-store")
+		_, _ = w.Write(data)
+	}
+}
@@ -0,0 +1,113 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockBlobDownloadLinks struct {
+	blobs map[string]string
+}
+
+func (m *mockBlobDownloadLinks) Sign(key string) string {
+	return "signed-" + key
+}
+
+func (m *mockBlobDownloadLinks) Open(ctx context.Context, token string) (string, []byte, error) {
+	key := strings.TrimPrefix(token, "signed-")
+	data, ok := m.blobs[key]
+	if !ok || key == token {
+		return "", nil, errors.New("invalid or expired download link")
+	}
+	return key, []byte(data), nil
+}
+
+func (m *mockBlobDownloadLinks) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for key := range m.blobs {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// ============================================================================
+// HttpDownloadBlob Tests
+// ============================================================================
+
+func Test_HttpDownloadBlob_With_Signed_Token_Should_Serve_Attachment(t *testing.T) {
+	// Arrange
+	links := &mockBlobDownloadLinks{blobs: map[string]string{"exports/guests.csv": "a,b"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /blobs/{token}", inbound.HttpDownloadBlob(links, slog.Default()))
+	req := httptest.NewRequest(http.MethodGet, "/blobs/signed-exports%2Fguests.csv", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be the file", rec.Body.String(), "a,b")
+	assert.That(t, "content type must follow the extension", strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv"), true)
+	assert.That(t, "file must be an attachment", rec.Header().Get("Content-Disposition"), "attachment; filename=guests.csv")
+}
+
+func Test_HttpDownloadBlob_With_Invalid_Token_Should_Return_404(t *testing.T) {
+	// Arrange
+	links := &mockBlobDownloadLinks{blobs: map[string]string{"exports/guests.csv": "a,b"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /blobs/{token}", inbound.HttpDownloadBlob(links, slog.Default()))
+	req := httptest.NewRequest(http.MethodGet, "/blobs/tampered", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpAdminBlobs Tests
+// ============================================================================
+
+func Test_Route_Admin_Blobs_With_Token_Should_List_Signed_Links(t *testing.T) {
+	// Arrange
+	links := &mockBlobDownloadLinks{blobs: map[string]string{"profiles/cpu.pprof": "cpu", "exports/guests.csv": "a,b"}}
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Blobs:              links,
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodGet, "/admin/blobs?prefix=profiles/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	var body []inbound.HttpAdminBlob
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "one file must be listed", len(body), 1)
+	assert.That(t, "link must be signed", body[0].URL, "http://example.com/blobs/signed-profiles/cpu.pprof")
+}
@@ -0,0 +1,248 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CaptchaVerifier checks the CAPTCHA answer of a public form, e.g. of Cloudflare Turnstile or hCaptcha.
+// outbound.SiteverifyCaptcha implements it.
+type CaptchaVerifier interface {
+	Widget() (scriptURL, class, siteKey string)
+	Verify(ctx context.Context, form url.Values, remoteIP string) error
+}
+
+// ManageLinkSender sends the management link of a reservation to the email on file.
+// outbound.MockNotificationService implements it.
+type ManageLinkSender interface {
+	SendManageLink(ctx context.Context, res *reservation.Reservation, link string) error
+}
+
+// lookupFailedMessage is shown for every lookup that finds nothing, so it does not tell
+// whether the code or the email or last name was wrong.
+const lookupFailedMessage = "We could not find a booking with these details. Please check the confirmation code and the email or last name."
+
+// CaptchaWidgetView represents the CAPTCHA widget of a public form for the view.
+type CaptchaWidgetView struct {
+	ScriptURL string
+	Class     string
+	SiteKey   string
+}
+
+// BookingLookupView represents a reservation found by the booking lookup for the view.
+// It is limited to what the confirmation code already tells: no names, contact data or amounts.
+type BookingLookupView struct {
+	ConfirmationCode string
+	RoomID           string
+	CheckIn          string
+	CheckOut         string
+	Nights           int
+	Guests           int
+	Status           string
+	StatusClass      string
+}
+
+// HttpViewBookingLookupResponse specifies the view data for the booking lookup page.
+type HttpViewBookingLookupResponse struct {
+	AppName    string
+	Title      string
+	Code       string             // entered confirmation code, kept for another try or the link request
+	Identifier string             // entered email or last name
+	Error      string             // empty unless the lookup failed
+	Booking    *BookingLookupView // nil until a booking was found
+	LinkSentTo string             // masked email the management link was sent to; empty if none was sent
+	Captcha    *CaptchaWidgetView // nil if no CAPTCHA is configured
+}
+
+// HttpViewBookingManageResponse specifies the view data for the management page opened via an emailed link.
+type HttpViewBookingManageResponse struct {
+	AppName     string
+	Title       string
+	Token       string
+	Reservation ReservationDetailView
+}
+
+// newBookingLookupResponse returns the view data of the lookup page with the CAPTCHA widget, if any.
+func newBookingLookupResponse(appName string, captcha CaptchaVerifier) HttpViewBookingLookupResponse {
+	data := HttpViewBookingLookupResponse{
+		AppName: appName,
+		Title:   appName + " - Find Your Booking",
+	}
+	if captcha != nil {
+		scriptURL, class, siteKey := captcha.Widget()
+		data.Captcha = &CaptchaWidgetView{ScriptURL: scriptURL, Class: class, SiteKey: siteKey}
+	}
+	return data
+}
+
+// HttpViewBookingLookup defines an HTTP handler function for rendering the public booking lookup form.
+// Guests who booked at the desk or through a channel manager have no account to sign in with.
+func HttpViewBookingLookup(e *templating.Engine, captcha CaptchaVerifier) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		HttpView(e, "booking_lookup", newBookingLookupResponse(appName, captcha))(w, r)
+	}
+}
+
+// HttpLookupBooking handles the POST request of the booking lookup form.
+// A match shows the limited view of the booking, from which the guest requests the management link.
+func HttpLookupBooking(e *templating.Engine, reservationService *reservation.Service, captcha CaptchaVerifier) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, res, ok := lookupBooking(w, r, reservationService, captcha, appName)
+		if !ok {
+			return
+		}
+		if res != nil {
+			data.Booking = buildBookingLookupView(res)
+		}
+		HttpView(e, "booking_lookup", data)(w, r)
+	}
+}
+
+// HttpRequestManageLink handles the POST request for the management link of a booking found by the lookup.
+// The link is sent to the email on the booking only, so knowing the code and last name is not enough to manage it.
+func HttpRequestManageLink(e *templating.Engine, reservationService *reservation.Service, links ShareLinkSigner, sender ManageLinkSender, captcha CaptchaVerifier) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, res, ok := lookupBooking(w, r, reservationService, captcha, appName)
+		if !ok {
+			return
+		}
+		if res == nil {
+			HttpView(e, "booking_lookup", data)(w, r)
+			return
+		}
+
+		email := res.Guests[0].Email
+		link := absoluteURL(r, "/ui/lookup/manage?token="+url.QueryEscape(links.Sign(res.ID, email)))
+		if err := sender.SendManageLink(r.Context(), res, link); err != nil {
+			http.Error(w, "Failed to send the link", http.StatusInternalServerError)
+			return
+		}
+
+		data.Booking = buildBookingLookupView(res)
+		data.LinkSentTo = shared.MaskPII(email)
+		HttpView(e, "booking_lookup", data)(w, r)
+	}
+}
+
+// lookupBooking verifies the CAPTCHA and looks up the booking of the form.
+// A booking that is not found returns a nil reservation and the data with the error message;
+// ok is false if a response was written already.
+func lookupBooking(w http.ResponseWriter, r *http.Request, reservationService *reservation.Service, captcha CaptchaVerifier, appName string) (HttpViewBookingLookupResponse, *reservation.Reservation, bool) {
+	data := newBookingLookupResponse(appName, captcha)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return data, nil, false
+	}
+	data.Code = r.FormValue("code")
+	data.Identifier = r.FormValue("identifier")
+
+	if captcha != nil {
+		if err := captcha.Verify(r.Context(), r.PostForm, remoteIP(r)); err != nil {
+			data.Error = "Please confirm that you are not a robot."
+			return data, nil, true
+		}
+	}
+
+	res, err := reservationService.LookupReservation(r.Context(), data.Code, data.Identifier)
+	if errors.Is(err, reservation.ErrLookupFailed) || (err == nil && len(res.Guests) == 0) {
+		data.Error = lookupFailedMessage
+		return data, nil, true
+	}
+	if err != nil {
+		http.Error(w, "Failed to look up the booking", http.StatusInternalServerError)
+		return data, nil, false
+	}
+	return data, res, true
+}
+
+// buildBookingLookupView returns the limited view of the booking.
+func buildBookingLookupView(res *reservation.Reservation) *BookingLookupView {
+	return &BookingLookupView{
+		ConfirmationCode: res.ConfirmationCode(),
+		RoomID:           string(res.RoomID),
+		CheckIn:          res.DateRange.CheckIn.Format("2006-01-02"),
+		CheckOut:         res.DateRange.CheckOut.Format("2006-01-02"),
+		Nights:           res.Nights(),
+		Guests:           len(res.Guests),
+		Status:           string(res.Status),
+		StatusClass:      reservationStatusClass(res.Status),
+	}
+}
+
+// HttpViewBookingManage defines an HTTP handler function for rendering a booking opened via its management link.
+// The signed token stands in for the sign-in: it names the reservation and the email it was sent to.
+func HttpViewBookingManage(e *templating.Engine, reservationService *reservation.Service, links ShareLinkSigner) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		res, _, ok := manageLinkReservation(w, r, reservationService, links, token)
+		if !ok {
+			return
+		}
+
+		data := HttpViewBookingManageResponse{
+			AppName:     appName,
+			Title:       appName + " - Booking " + res.ConfirmationCode(),
+			Token:       token,
+			Reservation: buildReservationDetailView(res, requestLocale(r)),
+		}
+
+		HttpView(e, "booking_manage", data)(w, r)
+	}
+}
+
+// HttpCancelBookingByLink handles the POST request to cancel a booking opened via its management link.
+// The cancellation is attributed to the guest the link was sent to.
+func HttpCancelBookingByLink(reservationService *reservation.Service, links ShareLinkSigner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.FormValue("token")
+		res, email, ok := manageLinkReservation(w, r, reservationService, links, token)
+		if !ok {
+			return
+		}
+
+		if err := reservationService.CancelReservation(withGuestPrincipal(r.Context(), res.GuestID, email), res.ID, "Cancelled by guest"); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Use HX-Redirect header for HTMX requests to trigger a full page navigation
+		location := "/ui/lookup/manage?token=" + url.QueryEscape(token)
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", location)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, location, http.StatusSeeOther)
+	}
+}
+
+// manageLinkReservation returns the reservation and email of a valid management link token.
+// The link is only valid while its email is still the primary guest's, so a changed contact revokes it.
+func manageLinkReservation(w http.ResponseWriter, r *http.Request, reservationService *reservation.Service, links ShareLinkSigner, token string) (*reservation.Reservation, string, bool) {
+	id, email, err := links.Verify(token)
+	if err != nil {
+		http.Error(w, "Invalid or expired link, please request a new one", http.StatusForbidden)
+		return nil, "", false
+	}
+	res, err := reservationService.GetReservation(r.Context(), id)
+	if err != nil || len(res.Guests) == 0 || res.Guests[0].Email != email {
+		http.Error(w, "Invalid or expired link, please request a new one", http.StatusForbidden)
+		return nil, "", false
+	}
+	return res, email, true
+}
@@ -0,0 +1,253 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockManageLinkSender struct {
+	res  *reservation.Reservation
+	link string
+}
+
+func (m *mockManageLinkSender) SendManageLink(ctx context.Context, res *reservation.Reservation, link string) error {
+	m.res = res
+	m.link = link
+	return nil
+}
+
+type mockCaptcha struct {
+	err error
+}
+
+func (m *mockCaptcha) Widget() (string, string, string) {
+	return "https://captcha.example.com/api.js", "captcha-widget", "site-key"
+}
+
+func (m *mockCaptcha) Verify(ctx context.Context, form url.Values, remoteIP string) error {
+	return m.err
+}
+
+func createLookupTestEngine(t *testing.T) *templating.Engine {
+	t.Helper()
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	return e
+}
+
+// createLookupTestReservation stores a reservation of "Test Guest" <guest@example.com> and returns its confirmation code.
+func createLookupTestReservation(repo *mockReservationRepository) string {
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	res := createTestReservation("res-001", "guest@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	repo.put(res.ID, *res)
+	return res.ConfirmationCode()
+}
+
+func lookupRequest(path string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// ============================================================================
+// HttpLookupBooking Tests
+// ============================================================================
+
+func Test_HttpLookupBooking_With_Code_And_Last_Name_Should_Show_Limited_View(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	code := createLookupTestReservation(repo)
+	handler := inbound.HttpLookupBooking(e, createDetailTestService(repo), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup", url.Values{"code": {code}, "identifier": {"guest"}}))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the code", strings.Contains(body, "Code: "+code), true)
+	assert.That(t, "body must contain the room", strings.Contains(body, "Room: room-101"), true)
+	assert.That(t, "body must offer the link", strings.Contains(body, `action="/ui/lookup/link"`), true)
+	assert.That(t, "body must not contain the email", strings.Contains(body, "guest@example.com"), false)
+}
+
+func Test_HttpLookupBooking_With_Wrong_Identifier_Should_Show_Error(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	code := createLookupTestReservation(repo)
+	handler := inbound.HttpLookupBooking(e, createDetailTestService(repo), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup", url.Values{"code": {code}, "identifier": {"other@example.com"}}))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "body must contain the error", strings.Contains(body, `class="error"`), true)
+	assert.That(t, "body must not show a booking", strings.Contains(body, "Room: room-101"), false)
+}
+
+func Test_HttpLookupBooking_With_Failed_Captcha_Should_Not_Look_Up(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	code := createLookupTestReservation(repo)
+	handler := inbound.HttpLookupBooking(e, createDetailTestService(repo), &mockCaptcha{err: errors.New("rejected")})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup", url.Values{"code": {code}, "identifier": {"guest"}}))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "body must contain the error", strings.Contains(body, `class="error"`), true)
+	assert.That(t, "body must show the captcha again", strings.Contains(body, `class="captcha-widget"`), true)
+	assert.That(t, "body must not show a booking", strings.Contains(body, "Room: room-101"), false)
+}
+
+// ============================================================================
+// HttpRequestManageLink Tests
+// ============================================================================
+
+func Test_HttpRequestManageLink_Should_Send_Signed_Link_To_Email_On_File(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	code := createLookupTestReservation(repo)
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+	sender := &mockManageLinkSender{}
+	handler := inbound.HttpRequestManageLink(e, createDetailTestService(repo), links, sender, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup/link", url.Values{"code": {code}, "identifier": {"Guest"}}))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "link must be sent", sender.res.ID, shared.ReservationID("res-001"))
+	link, _ := url.Parse(sender.link)
+	id, email, err := links.Verify(link.Query().Get("token"))
+	assert.That(t, "token must be valid", err, nil)
+	assert.That(t, "token must name the reservation", id, shared.ReservationID("res-001"))
+	assert.That(t, "token must name the email on file", email, "guest@example.com")
+	assert.That(t, "body must show the masked email", strings.Contains(rec.Body.String(), "g***@example.com"), true)
+}
+
+func Test_HttpRequestManageLink_With_Wrong_Code_Should_Send_Nothing(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	sender := &mockManageLinkSender{}
+	handler := inbound.HttpRequestManageLink(e, createDetailTestService(repo), outbound.NewShareLinks("secret", time.Hour), sender, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup/link", url.Values{"code": {"0000-0000"}, "identifier": {"Guest"}}))
+
+	// Assert
+	assert.That(t, "no link must be sent", sender.link, "")
+	assert.That(t, "body must contain the error", strings.Contains(rec.Body.String(), `class="error"`), true)
+}
+
+// ============================================================================
+// HttpViewBookingManage Tests
+// ============================================================================
+
+func Test_HttpViewBookingManage_With_Valid_Token_Should_Show_Full_Details(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+	token := links.Sign("res-001", "guest@example.com")
+	handler := inbound.HttpViewBookingManage(e, createDetailTestService(repo), links)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/lookup/manage?token="+url.QueryEscape(token), nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the guest email", strings.Contains(body, "guest@example.com"), true)
+	assert.That(t, "body must offer the cancellation", strings.Contains(body, `action="/ui/lookup/manage/cancel"`), true)
+}
+
+func Test_HttpViewBookingManage_With_Share_Token_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	token := outbound.NewShareLinks("secret", time.Hour).Sign("res-001", "guest@example.com")
+	handler := inbound.HttpViewBookingManage(e, createDetailTestService(repo), outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/lookup/manage?token="+url.QueryEscape(token), nil))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpViewBookingManage_After_Email_Change_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := createLookupTestEngine(t)
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+	token := links.Sign("res-001", "previous@example.com")
+	handler := inbound.HttpViewBookingManage(e, createDetailTestService(repo), links)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/lookup/manage?token="+url.QueryEscape(token), nil))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+// ============================================================================
+// HttpCancelBookingByLink Tests
+// ============================================================================
+
+func Test_HttpCancelBookingByLink_Should_Cancel_As_Guest(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createLookupTestReservation(repo)
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+	token := links.Sign("res-001", "guest@example.com")
+	handler := inbound.HttpCancelBookingByLink(createDetailTestService(repo), links)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, lookupRequest("/ui/lookup/manage/cancel", url.Values{"token": {token}}))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	res := repo.get("res-001")
+	assert.That(t, "reservation must be cancelled", res.Status, reservation.StatusCancelled)
+	history := res.History
+	assert.That(t, "cancellation must be attributed to the guest", history[len(history)-1].Actor, "guest:guest@example.com")
+}
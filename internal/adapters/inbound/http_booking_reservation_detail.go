@@ -3,6 +3,7 @@ package inbound
 import (
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
@@ -17,9 +18,57 @@ type GuestInfoView struct {
 	PhoneNumber string
 }
 
+// BusinessCustomerView represents the company a reservation is billed to for the view.
+type BusinessCustomerView struct {
+	Company string
+	VATID   string
+}
+
+// EmergencyContactView represents the emergency contact of a reservation for the view.
+type EmergencyContactView struct {
+	Name         string
+	PhoneNumber  string
+	Relationship string
+}
+
+// ShareGrantView represents a share grant for the view.
+type ShareGrantView struct {
+	Email    string
+	Role     string
+	Accepted bool
+}
+
+// PropertyMap provides the location of the property with a map image and directions links.
+// outbound.PropertyMaps implements it.
+type PropertyMap interface {
+	PropertyName() string
+	PropertyAddress() string
+	StaticMapURL(width, height int) string
+	DirectionsURLs() map[string]string
+}
+
+// PropertyLocationView represents the property location for the view.
+type PropertyLocationView struct {
+	Name       string
+	Address    string
+	MapURL     string            // empty if no static map provider is configured
+	Directions map[string]string // map service name to URL
+}
+
+// StatusChangeView represents a status change of the reservation's history for the view.
+// Actor is the kind of actor only (guest, staff, service or system), so guests do not see staff emails.
+type StatusChangeView struct {
+	From   string
+	To     string
+	At     string
+	Actor  string
+	Reason string
+}
+
 // ReservationDetailView represents a reservation for the detail view.
 type ReservationDetailView struct {
 	ID                 string
+	ConfirmationCode   string // quoted by guests without an account to find the booking (/ui/lookup)
 	RoomID             string
 	CheckIn            string
 	CheckOut           string
@@ -28,9 +77,20 @@ type ReservationDetailView struct {
 	TotalAmount        string
 	CreatedAt          string
 	CancellationReason string
+	ArrivalTime        string                // estimated arrival on the check-in day (HH:MM), empty if unknown
+	EmergencyContact   *EmergencyContactView // nil if none was given; shown to staff and the owner only
+	BusinessCustomer   *BusinessCustomerView // nil for private guests
+	Tier               string                // VIP tier at booking time, empty for regular guests
+	Perks              []string              // descriptions of the VIP perks
+	PromoCode          string                // applied promo code with its discount, e.g. "SUMMER25 (-$20.00)"; empty if none
+	RoomPreferences    []string              // room preferences of the guest and whether the room fulfills them
 	Guests             []GuestInfoView
+	Shares             []ShareGrantView
+	History            []StatusChangeView  // oldest first
+	ExtensionOffer     *ExtensionOfferView // open offer to stay another night after check-out time; shown to guests who may manage the reservation
 	Nights             int
 	CanCancel          bool
+	IsOwner            bool
 }
 
 // HttpViewReservationDetailResponse specifies the view data for the reservation detail.
@@ -39,9 +99,10 @@ type HttpViewReservationDetailResponse struct {
 	Title       string
 	SessionID   string
 	Reservation ReservationDetailView
+	Location    *PropertyLocationView // nil if no property location is configured
 }
 
-func buildReservationDetailView(res *reservation.Reservation) ReservationDetailView {
+func buildReservationDetailView(res *reservation.Reservation, locale shared.Locale) ReservationDetailView {
 	guests := make([]GuestInfoView, 0, len(res.Guests))
 	for _, g := range res.Guests {
 		guests = append(guests, GuestInfoView{
@@ -51,32 +112,78 @@ func buildReservationDetailView(res *reservation.Reservation) ReservationDetailV
 		})
 	}
 
+	var contact *EmergencyContactView
+	if c := res.EmergencyContact; c != nil {
+		contact = &EmergencyContactView{Name: c.Name, PhoneNumber: c.PhoneNumber, Relationship: c.Relationship}
+	}
+	var business *BusinessCustomerView
+	if c := res.BusinessCustomer; c != nil {
+		business = &BusinessCustomerView{Company: c.Company, VATID: c.VATID}
+	}
+
+	changes := res.StatusHistory()
+	history := make([]StatusChangeView, 0, len(changes))
+	for _, c := range changes {
+		actor, _, _ := strings.Cut(c.Actor, ":")
+		history = append(history, StatusChangeView{
+			From:   string(c.From),
+			To:     string(c.To),
+			At:     c.At.Format("2006-01-02 15:04"),
+			Actor:  actor,
+			Reason: c.Reason,
+		})
+	}
+
+	var extension *ExtensionOfferView
+	if offer := res.ExtensionOffer(shared.Now()); offer != nil {
+		extension = &ExtensionOfferView{Amount: offer.Amount.FormatIn(locale), ExpiresAt: offer.ExpiresAt.In(res.DateRange.Location()).Format("15:04")}
+	}
+
 	return ReservationDetailView{
 		Guests:             guests,
+		History:            history,
 		ID:                 string(res.ID),
+		ConfirmationCode:   res.ConfirmationCode(),
 		RoomID:             string(res.RoomID),
 		CheckIn:            res.DateRange.CheckIn.Format("2006-01-02"),
 		CheckOut:           res.DateRange.CheckOut.Format("2006-01-02"),
 		Status:             string(res.Status),
 		StatusClass:        reservationStatusClass(res.Status),
-		TotalAmount:        res.TotalAmount.FormatAmount(),
+		TotalAmount:        res.TotalAmount.FormatIn(locale),
 		CreatedAt:          res.CreatedAt.Format("2006-01-02 15:04"),
 		CancellationReason: res.CancellationReason,
+		ArrivalTime:        res.ArrivalTime,
+		EmergencyContact:   contact,
+		BusinessCustomer:   business,
+		Tier:               res.Perks.Tier,
+		Perks:              perkLabels(res.Perks, locale),
+		PromoCode:          promoCodeLabel(res.Perks.Promotion, locale),
+		RoomPreferences:    roomPreferenceDescriptions(res),
+		ExtensionOffer:     extension,
 		Nights:             res.Nights(),
 		CanCancel:          res.CanBeCancelled(),
 	}
 }
 
+// promoCodeLabel describes the promo code applied to a reservation with its discount, or is empty if none was.
+func promoCodeLabel(promo reservation.AppliedPromotion, locale shared.Locale) string {
+	if promo.Code == "" {
+		return ""
+	}
+	return promo.Code + " (-" + promo.Discount.FormatIn(locale) + ")"
+}
+
 // HttpViewReservationDetail defines an HTTP handler function for rendering a single reservation.
-func HttpViewReservationDetail(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+// The property location is shown with a map and directions links if propertyMap is not nil.
+func HttpViewReservationDetail(e *templating.Engine, reservationService *reservation.Service, propertyMap PropertyMap) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
 		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
@@ -93,7 +200,7 @@ func HttpViewReservationDetail(e *templating.Engine, reservationService *reserva
 			return
 		}
 
-		if string(res.GuestID) != email {
+		if !canViewReservation(ctx, res, guestID) {
 			http.Error(w, "Access denied", http.StatusForbidden)
 			return
 		}
@@ -102,7 +209,35 @@ func HttpViewReservationDetail(e *templating.Engine, reservationService *reserva
 			AppName:     appName,
 			Title:       appName + " - Reservation " + reservationID,
 			SessionID:   sessionID,
-			Reservation: buildReservationDetailView(res),
+			Reservation: buildReservationDetailView(res, requestLocale(r)),
+		}
+
+		// Co-travelers with a view grant must not cancel or extend; only the owner manages the share grants.
+		if !canManageReservation(ctx, res, guestID) {
+			data.Reservation.CanCancel = false
+			data.Reservation.ExtensionOffer = nil
+		}
+		if res.IsOwnedBy(guestID) {
+			data.Reservation.IsOwner = true
+			for _, g := range res.Shares {
+				data.Reservation.Shares = append(data.Reservation.Shares, ShareGrantView{
+					Email:    g.Email,
+					Role:     string(g.Role),
+					Accepted: g.IsAccepted(),
+				})
+			}
+		} else {
+			// The emergency contact is personal data of the owner.
+			data.Reservation.EmergencyContact = nil
+		}
+
+		if propertyMap != nil {
+			data.Location = &PropertyLocationView{
+				Name:       propertyMap.PropertyName(),
+				Address:    propertyMap.PropertyAddress(),
+				MapURL:     propertyMap.StaticMapURL(600, 300),
+				Directions: propertyMap.DirectionsURLs(),
+			}
 		}
 
 		HttpView(e, "reservation_detail", data)(w, r)
@@ -116,8 +251,8 @@ func HttpCancelReservation(reservationService *reservation.Service) http.Handler
 
 		// Check authentication
 		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		guestID, email := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
@@ -136,13 +271,13 @@ func HttpCancelReservation(reservationService *reservation.Service) http.Handler
 			return
 		}
 
-		if string(res.GuestID) != email {
+		if !canManageReservation(ctx, res, guestID) {
 			http.Error(w, "Access denied", http.StatusForbidden)
 			return
 		}
 
 		// Cancel the reservation
-		err = reservationService.CancelReservation(ctx, shared.ReservationID(reservationID), "Cancelled by guest")
+		err = reservationService.CancelReservation(withGuestPrincipal(ctx, guestID, email), shared.ReservationID(reservationID), "Cancelled by guest")
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
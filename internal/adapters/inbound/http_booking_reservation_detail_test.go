@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,7 +50,7 @@ func Test_HttpViewReservationDetail_Without_Session_Should_Redirect_To_Login(t *
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
 	req.SetPathValue("id", "res-001")
 	rec := httptest.NewRecorder()
@@ -74,7 +75,7 @@ func Test_HttpViewReservationDetail_Without_Reservation_ID_Should_Return_400(t *
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -97,7 +98,7 @@ func Test_HttpViewReservationDetail_With_NonExistent_Reservation_Should_Return_4
 	repo := newMockReservationRepository()
 	service := createDetailTestService(repo)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/nonexistent", nil)
 	req.SetPathValue("id", "nonexistent")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -125,9 +126,9 @@ func Test_HttpViewReservationDetail_With_Other_User_Reservation_Should_Return_40
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "other@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.put(shared.ReservationID("res-001"), *res)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -154,10 +155,10 @@ func Test_HttpViewReservationDetail_With_Valid_Session_Should_Return_200(t *test
 	// Create reservation for current user
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -184,10 +185,10 @@ func Test_HttpViewReservationDetail_Should_Render_Reservation_Data(t *testing.T)
 	// Create reservation for current user
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
 
-	handler := inbound.HttpViewReservationDetail(e, service)
+	handler := inbound.HttpViewReservationDetail(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
 	req.SetPathValue("id", "res-001")
 	req = addAuthContext(req, "test-session-123", "test@example.com")
@@ -207,6 +208,39 @@ func Test_HttpViewReservationDetail_Should_Render_Reservation_Data(t *testing.T)
 // HttpCancelReservation Tests
 // ============================================================================
 
+func Test_HttpViewReservationDetail_With_Property_Map_Should_Render_Location(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	repo.put(shared.ReservationID("res-001"), *res)
+
+	provider, _ := outbound.NewStaticMapProvider("google", "key")
+	maps := outbound.NewPropertyMaps(outbound.PropertyLocation{Name: "Harbor Hotel", Address: "1 Harbor Road"}, provider)
+	handler := inbound.HttpViewReservationDetail(e, service, maps)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain address", strings.Contains(body, "Harbor Hotel - 1 Harbor Road"), true)
+	assert.That(t, "body must contain map image", strings.Contains(body, "https://maps.googleapis.com/maps/api/staticmap?"), true)
+	assert.That(t, "body must contain directions", strings.Contains(body, "https://maps.apple.com/?daddr="), true)
+}
+
 func Test_HttpCancelReservation_Without_Session_Should_Return_401(t *testing.T) {
 	// Arrange
 	t.Setenv("APP_NAME", "TestApp")
@@ -280,7 +314,7 @@ func Test_HttpCancelReservation_With_Other_User_Reservation_Should_Return_403(t
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
 	res := createTestReservation("res-001", "other@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	repo.put(shared.ReservationID("res-001"), *res)
 
 	handler := inbound.HttpCancelReservation(service)
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
@@ -306,8 +340,8 @@ func Test_HttpCancelReservation_With_Valid_Reservation_Should_Redirect(t *testin
 	// Create reservation for current user (far enough in future to be cancellable)
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
 
 	handler := inbound.HttpCancelReservation(service)
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
@@ -335,8 +369,8 @@ func Test_HttpCancelReservation_Should_Update_Reservation_Status(t *testing.T) {
 	// Create reservation for current user
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
 
 	handler := inbound.HttpCancelReservation(service)
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
@@ -348,10 +382,46 @@ func Test_HttpCancelReservation_Should_Update_Reservation_Status(t *testing.T) {
 	handler(rec, req)
 
 	// Assert
-	updatedRes := repo.reservations[shared.ReservationID("res-001")]
+	updatedRes := repo.get(shared.ReservationID("res-001"))
 	assert.That(t, "reservation status must be cancelled", updatedRes.Status, reservation.StatusCancelled)
 }
 
+func Test_HttpCancelReservation_Should_Show_Guest_Cancellation_In_Status_History(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
+
+	cancel := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	cancel.SetPathValue("id", "res-001")
+	inbound.HttpCancelReservation(service)(httptest.NewRecorder(), addAuthContext(cancel, "test-session-123", "test@example.com"))
+
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewReservationDetail(e, service, nil)(rec, req)
+
+	// Assert
+	cancelled := repo.get(shared.ReservationID("res-001"))
+	changes := cancelled.StatusHistory()
+	assert.That(t, "cancellation must be attributed to the guest", changes[len(changes)-1].Actor, "guest:test@example.com")
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must list the cancellation", containsString(string(body), "pending - cancelled - guest - Cancelled by guest"), true)
+}
+
 // ============================================================================
 // Unit Tests for View Logic
 // ============================================================================
@@ -0,0 +1,267 @@
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DocumentView represents a document of the wallet for the view.
+type DocumentView struct {
+	ID         string
+	Name       string
+	Size       string
+	UploadedAt string
+	URL        string
+	Deletable  bool // guest documents of guests who may manage the reservation
+}
+
+// HttpViewReservationDocumentsResponse specifies the view data for the document wallet widget.
+type HttpViewReservationDocumentsResponse struct {
+	ReservationID string
+	Hotel         []DocumentView // provided by the hotel, e.g. registration forms
+	Guest         []DocumentView // attached by the guest and co-travelers
+	CanUpload     bool
+	Accept        string // allowed media types for the file input
+	Types         string // allowed file types for guests, e.g. "PDF, JPEG, PNG"
+	MaxSize       string
+}
+
+// HttpViewReservationDocuments defines an HTTP handler function for rendering the document wallet of a reservation.
+// Everyone who may view the reservation sees and downloads its documents; those who may manage it
+// also upload and delete guest documents. The widget is loaded by HTMX after the detail page.
+// It answers 204 No Content if documentService is nil, which leaves the page unchanged.
+func HttpViewReservationDocuments(e *templating.Engine, reservationService *reservation.Service, documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, manage, ok := documentReservation(w, r, reservationService, false)
+		if !ok {
+			return
+		}
+		if documentService == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		docs, err := documentService.List(r.Context(), res.ID)
+		if err != nil {
+			http.Error(w, "Failed to list documents", http.StatusInternalServerError)
+			return
+		}
+
+		limits := documentService.Limits()
+		data := HttpViewReservationDocumentsResponse{
+			ReservationID: string(res.ID),
+			CanUpload:     manage,
+			Accept:        strings.Join(limits.AllowedTypes, ","),
+			Types:         documentTypes(limits.AllowedTypes),
+			MaxSize:       formatDocumentSize(limits.MaxSize),
+		}
+		for _, doc := range docs {
+			view := DocumentView{
+				ID:         string(doc.ID),
+				Name:       doc.Name,
+				Size:       formatDocumentSize(doc.Size),
+				UploadedAt: doc.UploadedAt.Format("2006-01-02"),
+				URL:        "/ui/reservations/" + string(res.ID) + "/documents/" + string(doc.ID),
+			}
+			if doc.Kind == document.KindHotel {
+				data.Hotel = append(data.Hotel, view)
+				continue
+			}
+			view.Deletable = manage
+			data.Guest = append(data.Guest, view)
+		}
+
+		HttpView(e, "reservation_documents", data)(w, r)
+	}
+}
+
+// HttpUploadReservationDocument handles the multipart POST request to attach a file to a reservation.
+// Only guests who may manage the reservation upload documents.
+func HttpUploadReservationDocument(reservationService *reservation.Service, documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, ok := documentReservation(w, r, reservationService, true)
+		if !ok {
+			return
+		}
+
+		upload, err := readDocumentUpload(w, r, documentService.Limits())
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		guestID, _ := currentGuest(r.Context())
+		upload.ReservationID = res.ID
+		upload.Kind = document.KindGuest
+		upload.UploadedBy = string(guestID)
+		if _, err := documentService.Upload(r.Context(), upload); err != nil {
+			documentError(w, err)
+			return
+		}
+
+		redirectToReservation(w, r, res.ID)
+	}
+}
+
+// HttpDownloadReservationDocument serves a document of the reservation as attachment.
+func HttpDownloadReservationDocument(reservationService *reservation.Service, documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, ok := documentReservation(w, r, reservationService, false)
+		if !ok {
+			return
+		}
+
+		doc, data, err := documentService.Open(r.Context(), res.ID, document.DocumentID(r.PathValue("document")))
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		writeDocument(w, doc, data)
+	}
+}
+
+// HttpDeleteReservationDocument handles the POST request to delete a guest document.
+// Documents provided by the hotel cannot be deleted by guests.
+func HttpDeleteReservationDocument(reservationService *reservation.Service, documentService *document.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, ok := documentReservation(w, r, reservationService, true)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		doc, err := documentService.Get(ctx, res.ID, document.DocumentID(r.PathValue("document")))
+		if err != nil {
+			documentError(w, err)
+			return
+		}
+		if doc.Kind != document.KindGuest {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		if err := documentService.Delete(ctx, res.ID, doc.ID); err != nil {
+			documentError(w, err)
+			return
+		}
+
+		redirectToReservation(w, r, res.ID)
+	}
+}
+
+// documentReservation returns the reservation of the request if the session guest may view it,
+// and whether they may also manage it. Otherwise it answers the request and returns false;
+// with manage set, guests who may only view the reservation are denied, too.
+func documentReservation(w http.ResponseWriter, r *http.Request, reservationService *reservation.Service, manage bool) (*reservation.Reservation, bool, bool) {
+	ctx := r.Context()
+
+	sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+	guestID, _ := currentGuest(ctx)
+	if sessionID == "" || guestID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false, false
+	}
+
+	res, err := reservationService.GetReservation(ctx, shared.ReservationID(r.PathValue("id")))
+	if err != nil {
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return nil, false, false
+	}
+	canManage := canManageReservation(ctx, res, guestID)
+	if !canManage && (manage || !canViewReservation(ctx, res, guestID)) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return nil, false, false
+	}
+	return res, canManage, true
+}
+
+// readDocumentUpload reads the file field of a multipart form. The body is limited to the maximum
+// document size plus room for the form, and the content type is detected from the content,
+// because the type declared by the browser is not trusted.
+func readDocumentUpload(w http.ResponseWriter, r *http.Request, limits document.Limits) (document.Upload, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, limits.MaxSize+64*1024)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		return document.Upload{}, err
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return document.Upload{}, err
+	}
+	defer func() { _ = file.Close() }()
+	data, err := io.ReadAll(io.LimitReader(file, limits.MaxSize+1))
+	if err != nil {
+		return document.Upload{}, err
+	}
+	return document.Upload{
+		Name:        header.Filename,
+		ContentType: http.DetectContentType(data),
+		Data:        data,
+	}, nil
+}
+
+// writeDocument serves the document as attachment. Content sniffing is disabled, so browsers
+// never render an uploaded file as a page of this site.
+func writeDocument(w http.ResponseWriter, doc *document.Document, data []byte) {
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.Name}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, _ = w.Write(data)
+}
+
+// documentError maps document errors to HTTP status codes.
+func documentError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, document.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, document.ErrTooLarge), errors.As(err, &tooLarge):
+		http.Error(w, document.ErrTooLarge.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, document.ErrTypeNotAllowed):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, document.ErrTooMany):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, document.ErrInfected):
+		http.Error(w, document.ErrInfected.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, document.ErrScanFailed):
+		http.Error(w, document.ErrScanFailed.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, document.ErrEmpty), errors.Is(err, http.ErrMissingFile), errors.Is(err, http.ErrNotMultipart):
+		http.Error(w, "a non-empty file is required", http.StatusBadRequest)
+	default:
+		http.Error(w, "document request failed", http.StatusInternalServerError)
+	}
+}
+
+// documentTypes names the media types for guests by their subtype, e.g. "PDF, JPEG" for application/pdf and image/jpeg.
+func documentTypes(mediaTypes []string) string {
+	names := make([]string, 0, len(mediaTypes))
+	for _, t := range mediaTypes {
+		_, subtype, _ := strings.Cut(t, "/")
+		names = append(names, strings.ToUpper(subtype))
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatDocumentSize formats a size in bytes for guests, e.g. "1.5 MB".
+func formatDocumentSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%d KB", size>>10)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
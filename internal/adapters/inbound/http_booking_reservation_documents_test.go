@@ -0,0 +1,225 @@
+package inbound_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestDocumentService(t *testing.T) *document.Service {
+	t.Helper()
+	repo := resource.NewInMemoryAccess[document.DocumentID, document.Document]()
+	return document.NewService(repo, outbound.NewLocalBlobStorage(t.TempDir()), outbound.NoopVirusScanner{}, document.DefaultLimits())
+}
+
+// createDocumentTestReservation creates res-001 owned by owner-subject and shared for viewing with viewer-subject.
+func createDocumentTestReservation(repo *mockReservationRepository) *reservation.Service {
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	ctx := context.Background()
+	_, _ = svc.ShareReservation(ctx, "res-001", "viewer@example.com", reservation.ShareRoleView)
+	_, _ = svc.AcceptShare(ctx, "res-001", "viewer@example.com", "viewer-subject")
+	return svc
+}
+
+func uploadDocument(t *testing.T, svc *document.Service, kind document.Kind) *document.Document {
+	t.Helper()
+	doc, err := svc.Upload(context.Background(), document.Upload{
+		ReservationID: "res-001",
+		Kind:          kind,
+		Name:          "form.pdf",
+		ContentType:   "application/pdf",
+		Data:          []byte("%PDF-1.7 form"),
+	})
+	if err != nil {
+		t.Fatalf("failed to upload document: %v", err)
+	}
+	return doc
+}
+
+func multipartDocumentRequest(t *testing.T, target, name string, data []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", name)
+	_, _ = part.Write(data)
+	_ = mw.Close()
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.SetPathValue("id", "res-001")
+	return req
+}
+
+// ============================================================================
+// HttpViewReservationDocuments Tests
+// ============================================================================
+
+func Test_HttpViewReservationDocuments_For_Viewer_Should_List_Without_Upload(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	uploadDocument(t, documents, document.KindHotel)
+	uploadDocument(t, documents, document.KindGuest)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/documents", nil)
+	req.SetPathValue("id", "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewReservationDocuments(e, svc, documents)(rec, addGuestContext(req, "viewer-subject", "viewer@example.com"))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must list the hotel document", strings.Contains(body, "hotel - form.pdf"), true)
+	assert.That(t, "body must list the guest document", strings.Contains(body, "guest - form.pdf"), true)
+	assert.That(t, "viewer must not delete", strings.Contains(body, "deletable"), false)
+	assert.That(t, "viewer must not upload", strings.Contains(body, "multipart/form-data"), false)
+}
+
+func Test_HttpViewReservationDocuments_Without_Service_Should_Return_No_Content(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/documents", nil)
+	req.SetPathValue("id", "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewReservationDocuments(e, svc, nil)(rec, addGuestContext(req, "owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+}
+
+// ============================================================================
+// HttpUploadReservationDocument Tests
+// ============================================================================
+
+func Test_HttpUploadReservationDocument_By_Owner_Should_Store_Detected_Type(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	req := multipartDocumentRequest(t, "/ui/reservations/res-001/documents", `C:\visa letter.pdf`, []byte("%PDF-1.7 visa"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpUploadReservationDocument(svc, documents)(rec, addGuestContext(req, "owner-subject", "owner@example.com"))
+
+	// Assert
+	docs, _ := documents.List(context.Background(), "res-001")
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "document must be stored", len(docs), 1)
+	assert.That(t, "name must be cleaned", docs[0].Name, "visa letter.pdf")
+	assert.That(t, "type must be detected", docs[0].ContentType, "application/pdf")
+	assert.That(t, "uploader must be the guest", docs[0].UploadedBy, "owner-subject")
+}
+
+func Test_HttpUploadReservationDocument_With_Disallowed_Type_Should_Return_415(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	req := multipartDocumentRequest(t, "/ui/reservations/res-001/documents", "invoice.pdf", []byte("<html><script>alert(1)</script></html>"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpUploadReservationDocument(svc, createTestDocumentService(t))(rec, addGuestContext(req, "owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 415", rec.Code, http.StatusUnsupportedMediaType)
+}
+
+func Test_HttpUploadReservationDocument_By_Viewer_Should_Return_403(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	req := multipartDocumentRequest(t, "/ui/reservations/res-001/documents", "visa.pdf", []byte("%PDF-1.7 visa"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpUploadReservationDocument(svc, createTestDocumentService(t))(rec, addGuestContext(req, "viewer-subject", "viewer@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+// ============================================================================
+// HttpDownloadReservationDocument Tests
+// ============================================================================
+
+func Test_HttpDownloadReservationDocument_By_Viewer_Should_Serve_Attachment(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	doc := uploadDocument(t, documents, document.KindHotel)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/documents/"+string(doc.ID), nil)
+	req.SetPathValue("id", "res-001")
+	req.SetPathValue("document", string(doc.ID))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDownloadReservationDocument(svc, documents)(rec, addGuestContext(req, "viewer-subject", "viewer@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be the content", rec.Body.String(), "%PDF-1.7 form")
+	assert.That(t, "file must be an attachment", rec.Header().Get("Content-Disposition"), `attachment; filename=form.pdf`)
+	assert.That(t, "sniffing must be disabled", rec.Header().Get("X-Content-Type-Options"), "nosniff")
+}
+
+func Test_HttpDownloadReservationDocument_By_Stranger_Should_Return_403(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	doc := uploadDocument(t, documents, document.KindGuest)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/documents/"+string(doc.ID), nil)
+	req.SetPathValue("id", "res-001")
+	req.SetPathValue("document", string(doc.ID))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDownloadReservationDocument(svc, documents)(rec, addGuestContext(req, "stranger-subject", "stranger@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+// ============================================================================
+// HttpDeleteReservationDocument Tests
+// ============================================================================
+
+func Test_HttpDeleteReservationDocument_Of_Hotel_Document_Should_Return_403(t *testing.T) {
+	// Arrange
+	svc := createDocumentTestReservation(newMockReservationRepository())
+	documents := createTestDocumentService(t)
+	doc := uploadDocument(t, documents, document.KindHotel)
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/documents/"+string(doc.ID)+"/delete", nil)
+	req.SetPathValue("id", "res-001")
+	req.SetPathValue("document", string(doc.ID))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDeleteReservationDocument(svc, documents)(rec, addGuestContext(req, "owner-subject", "owner@example.com"))
+
+	// Assert
+	_, err := documents.Get(context.Background(), shared.ReservationID("res-001"), doc.ID)
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "document must be kept", err, nil)
+}
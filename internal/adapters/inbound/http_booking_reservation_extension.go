@@ -0,0 +1,77 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ExtensionOfferView represents the open offer to stay another night for the view.
+type ExtensionOfferView struct {
+	Amount    string
+	ExpiresAt string
+}
+
+// HttpAcceptExtension handles the POST request of an overstaying guest to stay another night at the offered price.
+func HttpAcceptExtension(reservationService *reservation.Service) http.HandlerFunc {
+	return httpDecideExtension(reservationService, reservationService.AcceptExtension)
+}
+
+// HttpDeclineExtension handles the POST request of an overstaying guest to decline the offered night.
+func HttpDeclineExtension(reservationService *reservation.Service) http.HandlerFunc {
+	return httpDecideExtension(reservationService, reservationService.DeclineExtension)
+}
+
+// httpDecideExtension answers the extension offer of a reservation the guest may manage and returns to its detail page.
+// An offer that is gone, or a night booked meanwhile, is a conflict.
+func httpDecideExtension(reservationService *reservation.Service, decide func(ctx context.Context, id shared.ReservationID) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, email := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		reservationID := r.PathValue("id")
+		if reservationID == "" {
+			http.Error(w, "Reservation ID required", http.StatusBadRequest)
+			return
+		}
+
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+
+		if !canManageReservation(ctx, res, guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		err = decide(withGuestPrincipal(ctx, guestID, email), res.ID)
+		if errors.Is(err, reservation.ErrNoExtensionOffer) || errors.Is(err, reservation.ErrRoomNotAvailable) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to answer the extension offer", http.StatusInternalServerError)
+			return
+		}
+
+		link := "/ui/reservations/" + reservationID
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", link)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, link, http.StatusSeeOther)
+	}
+}
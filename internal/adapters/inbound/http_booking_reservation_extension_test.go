@@ -0,0 +1,123 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Extension Test Helpers
+// ============================================================================
+
+// storeExtensionOffer stores the checked-in stay of storeActiveStay with an open offer of the night after it.
+func storeExtensionOffer(repo *mockReservationRepository) time.Time {
+	checkOut := storeActiveStay(repo)
+	res := repo.get("res-001")
+	res.Overstays = []reservation.Overstay{{
+		CheckOut:   checkOut,
+		DetectedAt: time.Now(),
+		Outcome:    reservation.OverstayOffered,
+		Amount:     shared.NewMoney(9900, "USD"),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}}
+	repo.put("res-001", res)
+	return checkOut
+}
+
+func extensionRequest(action, email string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/extension/"+action, nil)
+	req.SetPathValue("id", "res-001")
+	return addAuthContext(req, "test-session-123", email)
+}
+
+// ============================================================================
+// HttpAcceptExtension / HttpDeclineExtension Tests
+// ============================================================================
+
+func Test_HttpAcceptExtension_Should_Extend_Stay_And_Redirect(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checkOut := storeExtensionOffer(repo)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAcceptExtension(createDetailTestService(repo))(rec, extensionRequest("accept", "test@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the reservation", rec.Header().Get("Location"), "/ui/reservations/res-001")
+	assert.That(t, "check-out must be a day later", repo.get("res-001").DateRange.CheckOut, checkOut.AddDate(0, 0, 1))
+}
+
+func Test_HttpAcceptExtension_Without_Offer_Should_Return_409(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	storeActiveStay(repo)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAcceptExtension(createDetailTestService(repo))(rec, extensionRequest("accept", "test@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+func Test_HttpAcceptExtension_With_Other_User_Reservation_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	storeExtensionOffer(repo)
+	rec := httptest.NewRecorder()
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/extension/accept", nil)
+	req.SetPathValue("id", "res-001")
+	inbound.HttpAcceptExtension(createDetailTestService(repo))(rec, addGuestContext(req, "other-subject", "other@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "offer must stay open", repo.get("res-001").Overstays[0].Outcome, reservation.OverstayOffered)
+}
+
+func Test_HttpDeclineExtension_Should_Decline_Offer(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checkOut := storeExtensionOffer(repo)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDeclineExtension(createDetailTestService(repo))(rec, extensionRequest("decline", "test@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 303 (redirect)", rec.Code, http.StatusSeeOther)
+	assert.That(t, "offer must be declined", repo.get("res-001").Overstays[0].Outcome, reservation.OverstayDeclined)
+	assert.That(t, "check-out must stay", repo.get("res-001").DateRange.CheckOut, checkOut)
+}
+
+func Test_HttpViewReservationDetail_Should_Show_Extension_Offer(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	storeExtensionOffer(repo)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
+	req.SetPathValue("id", "res-001")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewReservationDetail(e, createDetailTestService(repo), nil)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "offer must be shown", strings.Contains(rec.Body.String(), "Extension: $99.00"), true)
+}
@@ -0,0 +1,105 @@
+package inbound
+
+import (
+	"net/http"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// FinancialLineView represents a line of the financial summary for the view.
+type FinancialLineView struct {
+	Kind        string
+	Description string
+	Amount      string
+	Date        string // empty for the room charges
+}
+
+// HttpViewReservationFinancialsResponse specifies the view data for the financial summary widget.
+type HttpViewReservationFinancialsResponse struct {
+	RoomCharges  string
+	Adjustments  string
+	TotalCharges string
+	Authorized   string
+	Captured     string
+	GiftCards    string
+	Refunded     string
+	NetPaid      string
+	Balance      string
+	Owed         bool // the guest still owes money
+	Lines        []FinancialLineView
+	InvoiceURL   string // page of the invoice of the completed stay; empty if it has not been invoiced
+}
+
+// HttpViewReservationFinancials defines an HTTP handler function for rendering the financial summary of a reservation.
+// The widget is loaded by HTMX after the detail page like the weather widget.
+// It answers 204 No Content if financials is nil, which leaves the page unchanged.
+// Invoiced stays link their invoice; invoices may be nil if invoicing is disabled.
+func HttpViewReservationFinancials(e *templating.Engine, reservationService *reservation.Service, financials *payment.FinancialService, invoices *invoice.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		if !canViewReservation(ctx, res, guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		if financials == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		summary, err := financials.Summary(ctx, res.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		data := buildFinancialsView(summary, requestLocale(r))
+		if invoices != nil {
+			if _, err := invoices.Invoice(ctx, res.ID); err == nil {
+				data.InvoiceURL = "/ui/reservations/" + string(res.ID) + "/invoice"
+			}
+		}
+		HttpView(e, "reservation_financials", data)(w, r)
+	}
+}
+
+// buildFinancialsView converts a financial summary to its view data.
+func buildFinancialsView(s *payment.FinancialSummary, locale shared.Locale) HttpViewReservationFinancialsResponse {
+	data := HttpViewReservationFinancialsResponse{
+		RoomCharges:  s.RoomCharges.FormatIn(locale),
+		Adjustments:  s.Adjustments.FormatIn(locale),
+		TotalCharges: s.TotalCharges.FormatIn(locale),
+		Authorized:   s.Authorized.FormatIn(locale),
+		Captured:     s.Captured.FormatIn(locale),
+		GiftCards:    s.GiftCards.FormatIn(locale),
+		Refunded:     s.Refunded.FormatIn(locale),
+		NetPaid:      s.NetPaid.FormatIn(locale),
+		Balance:      s.Balance.FormatIn(locale),
+		Owed:         s.Balance.Amount > 0,
+	}
+	for _, l := range s.Lines {
+		view := FinancialLineView{Kind: l.Kind, Description: l.Description, Amount: l.Amount.FormatIn(locale)}
+		if !l.At.IsZero() {
+			view.Date = l.At.Format("2006-01-02")
+		}
+		data.Lines = append(data.Lines, view)
+	}
+	return data
+}
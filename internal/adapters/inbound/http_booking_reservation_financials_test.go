@@ -0,0 +1,139 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createFinancialTestService creates a financial service with a captured payment of 100.00 USD
+// and a minibar charge of 15.00 USD for res-001, whose room charges are 297.00 USD.
+func createFinancialTestService(reservationService *reservation.Service) *payment.FinancialService {
+	ctx := context.Background()
+	paymentService := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	_, _ = paymentService.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	_ = paymentService.CapturePayment(ctx, "pay-001")
+	financials := payment.NewFinancialService(paymentService, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), outbound.NewReservationRoomCharges(reservationService))
+	_, _ = financials.AddAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(1500, "USD"), "Minibar")
+	return financials
+}
+
+func financialsRequest(subject, email string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/financials", nil)
+	req.SetPathValue("id", "res-001")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// HttpViewReservationFinancials Tests
+// ============================================================================
+
+func Test_HttpViewReservationFinancials_Should_Render_Summary(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	handler := inbound.HttpViewReservationFinancials(e, svc, createFinancialTestService(svc), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, financialsRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the adjustment", strings.Contains(body, "adjustment - Minibar - $15.00"), true)
+	assert.That(t, "body must contain the balance", strings.Contains(body, "Balance: $212.00"), true)
+}
+
+func Test_HttpViewReservationFinancials_Should_Format_Amounts_In_The_Accepted_Language(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	handler := inbound.HttpViewReservationFinancials(e, svc, createFinancialTestService(svc), nil)
+	req := financialsRequest("owner-subject", "owner@example.com")
+	req.Header.Set("Accept-Language", "fr-CH;q=0.5, de-DE, en;q=0.8")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "body must contain the balance in German", strings.Contains(rec.Body.String(), "Balance: 212,00\u00a0$"), true)
+}
+
+func Test_HttpViewReservationFinancials_Without_Service_Should_Return_204(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationFinancials(e, createDetailTestService(repo), nil, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, financialsRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+}
+
+func Test_HttpViewReservationFinancials_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	handler := inbound.HttpViewReservationFinancials(e, svc, createFinancialTestService(svc), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, financialsRequest("other-subject", "other@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpViewReservationFinancials_Of_Invoiced_Stay_Should_Link_Invoice(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	invoices := createInvoiceTestService(svc)
+	handler := inbound.HttpViewReservationFinancials(e, svc, createFinancialTestService(svc), invoices)
+	before := httptest.NewRecorder()
+	handler(before, financialsRequest("owner-subject", "owner@example.com"))
+	_, _ = invoices.Issue(context.Background(), "res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, financialsRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "stay without invoice must not link one", strings.Contains(before.Body.String(), "/invoice"), false)
+	assert.That(t, "invoiced stay must link its invoice", strings.Contains(rec.Body.String(), `href="/ui/reservations/res-001/invoice"`), true)
+}
@@ -1,15 +1,26 @@
 package inbound
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/andygeiss/cloud-native-utils/security"
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
 )
 
 // RoomOption represents a room option for the form dropdown.
@@ -19,25 +30,93 @@ type RoomOption struct {
 	Price string
 }
 
+// PropertyOption represents a property option for the form dropdown.
+type PropertyOption struct {
+	ID       string
+	Name     string
+	Address  string
+	Selected bool
+}
+
 // HttpViewReservationFormResponse specifies the view data for the reservation form.
 type HttpViewReservationFormResponse struct {
-	AppName    string
-	Title      string
-	SessionID  string
-	MinDate    string
-	GuestName  string
-	GuestEmail string
-	Error      string
-	Rooms      []RoomOption
+	AppName      string
+	Title        string
+	SessionID    string
+	MinDate      string
+	GuestName    string
+	GuestEmail   string
+	ReferralCode string
+	PromoCode    string
+	Promotions   bool // set if the guest may enter a promo code
+	Error        string
+	PropertyID   string           // property whose rooms are offered; empty without properties
+	Properties   []PropertyOption // empty unless the chain has more than one property
+	Rooms        []RoomOption
+	RoomID       string             // preselected room, e.g. held for the guest on the waitlist
+	CheckIn      string             // prefilled check-in date
+	CheckOut     string             // prefilled check-out date
+	Review       *ReservationReview // set while the guest confirms the locked price
+	Waitlist     *WaitlistJoin      // set if the room is booked and the guest may join its waitlist
+	Loyalty      *LoyaltyWidget     // set if the guest may redeem loyalty points
+}
+
+// ReservationReview is the checkout step that shows the locked price of the stay before booking it.
+type ReservationReview struct {
+	PriceLockID string
+	RoomHoldID  string // set if the room is held for the guest
+	RoomName    string
+	CheckIn     string
+	CheckOut    string
+	Total       string
+	LockedUntil string
+	HeldUntil   string      // set if the room is held for the guest
+	Notice      string      // why the guest is asked again, e.g. because the lock expired
+	Fields      []FormField // the submitted form, posted again with the lock
 }
 
-func getDefaultRooms() []RoomOption {
-	return []RoomOption{
-		{ID: "room-101", Name: "Standard Room 101", Price: "$99.00"},
-		{ID: "room-102", Name: "Standard Room 102", Price: "$99.00"},
-		{ID: "room-201", Name: "Deluxe Room 201", Price: "$149.00"},
-		{ID: "room-202", Name: "Deluxe Room 202", Price: "$149.00"},
-		{ID: "room-301", Name: "Suite 301", Price: "$249.00"},
+// FormField is a submitted form value that is carried to the next step as a hidden input.
+type FormField struct {
+	Name  string
+	Value string
+}
+
+// getDefaultRooms returns the rooms with their nightly price formatted in the locale.
+func getDefaultRooms(locale shared.Locale) []RoomOption {
+	rooms := []RoomOption{
+		{ID: "room-101", Name: "Standard Room 101"},
+		{ID: "room-102", Name: "Standard Room 102"},
+		{ID: "room-201", Name: "Deluxe Room 201"},
+		{ID: "room-202", Name: "Deluxe Room 202"},
+		{ID: "room-301", Name: "Suite 301"},
+	}
+	prices := getRoomPrices()
+	for i := range rooms {
+		rooms[i].Price = shared.NewMoney(prices[rooms[i].ID], "USD").FormatIn(locale)
+	}
+	return rooms
+}
+
+// selectProperty returns the property the guest selected with the property_id parameter, or the default property.
+// The rooms are limited to those of the property, and the earliest check-in is today at the property.
+// Without properties every room is offered.
+func selectProperty(data *HttpViewReservationFormResponse, properties *property.Catalog, selected string) {
+	if properties == nil {
+		return
+	}
+	current, err := properties.Property(property.PropertyID(selected))
+	if err != nil {
+		current = properties.Default()
+	}
+	data.PropertyID = string(current.ID)
+	data.MinDate = time.Now().In(current.Location()).Format("2006-01-02")
+	data.Rooms = slices.DeleteFunc(data.Rooms, func(room RoomOption) bool {
+		return properties.PropertyOf(property.RoomID(room.ID)) != current.ID
+	})
+	if all := properties.Properties(); len(all) > 1 {
+		for _, p := range all {
+			data.Properties = append(data.Properties, PropertyOption{ID: string(p.ID), Name: p.Name, Address: p.Address, Selected: p.ID == current.ID})
+		}
 	}
 }
 
@@ -51,8 +130,26 @@ func getRoomPrices() map[string]int64 {
 	}
 }
 
+// quoteStay returns the price of the stay: the total of the quote of the room's rate plan,
+// or the fixed nightly price of the room for every night if pricingService is nil.
+func quoteStay(ctx context.Context, pricingService *pricing.Service, roomID string, checkIn, checkOut time.Time) (shared.Money, error) {
+	if pricingService == nil {
+		nights := int64(checkOut.Sub(checkIn).Hours() / 24)
+		return shared.NewMoney(getRoomPrices()[roomID]*nights, "USD"), nil
+	}
+	quote, err := pricingService.Quote(ctx, pricing.RoomID(roomID), checkIn, checkOut)
+	if err != nil {
+		return shared.Money{}, err
+	}
+	return quote.Total, nil
+}
+
 // HttpViewReservationForm defines an HTTP handler function for rendering the new reservation form.
-func HttpViewReservationForm(e *templating.Engine) http.HandlerFunc {
+// If properties is not nil, the guest selects the property first (property_id) and is offered its rooms.
+// The room and dates are prefilled from the room_id, check_in and check_out parameters, e.g. from the waitlist.
+// If loyaltyService is not nil, the guest may redeem loyalty points for the booking.
+// If promotionService is not nil, the guest may enter a promo code, prefilled from the promo parameter.
+func HttpViewReservationForm(e *templating.Engine, properties *property.Catalog, loyaltyService *loyalty.Service, promotionService *promotion.Service) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
 	title := appName + " - New Reservation"
 
@@ -68,14 +165,25 @@ func HttpViewReservationForm(e *templating.Engine) http.HandlerFunc {
 
 		name, _ := ctx.Value(web.ContextName).(string)
 
+		// Shared referral links prefill the code with the ref query parameter, campaign links the promo code with promo
 		data := HttpViewReservationFormResponse{
-			Rooms:      getDefaultRooms(),
-			AppName:    appName,
-			Title:      title,
-			SessionID:  sessionID,
-			MinDate:    time.Now().Format("2006-01-02"),
-			GuestName:  name,
-			GuestEmail: email,
+			Rooms:        getDefaultRooms(requestLocale(r)),
+			AppName:      appName,
+			Title:        title,
+			SessionID:    sessionID,
+			MinDate:      time.Now().Format("2006-01-02"),
+			GuestName:    name,
+			GuestEmail:   email,
+			ReferralCode: r.URL.Query().Get("ref"),
+			PromoCode:    r.URL.Query().Get("promo"),
+			Promotions:   promotionService != nil,
+			RoomID:       r.URL.Query().Get("room_id"),
+			CheckIn:      r.URL.Query().Get("check_in"),
+			CheckOut:     r.URL.Query().Get("check_out"),
+		}
+		selectProperty(&data, properties, r.URL.Query().Get("property_id"))
+		if guestID, _ := currentGuest(ctx); guestID != "" {
+			data.Loyalty = loyaltyWidget(ctx, loyaltyService, guestID, requestLocale(r))
 		}
 
 		HttpView(e, "reservation_form", data)(w, r)
@@ -83,12 +191,18 @@ func HttpViewReservationForm(e *templating.Engine) http.HandlerFunc {
 }
 
 type reservationFormInput struct {
-	checkIn    time.Time
-	checkOut   time.Time
-	roomID     string
-	guestName  string
-	guestEmail string
-	guestPhone string
+	checkIn      time.Time
+	checkOut     time.Time
+	roomID       string
+	propertyID   string
+	guestName    string
+	guestEmail   string
+	guestPhone   string
+	referralCode string
+	promoCode    string
+	redeemPoints int
+	language     string
+	arrival      reservation.ArrivalDetails
 }
 
 func parseReservationForm(r *http.Request) (*reservationFormInput, string) {
@@ -121,18 +235,96 @@ func parseReservationForm(r *http.Request) (*reservationFormInput, string) {
 		return nil, "Invalid room selected"
 	}
 
+	redeemPoints := 0
+	if value := r.FormValue("redeem_points"); value != "" {
+		points, err := strconv.Atoi(value)
+		if err != nil || points < 0 {
+			return nil, "Loyalty points must be a whole number"
+		}
+		redeemPoints = points
+	}
+
+	language := r.FormValue("language")
+	if _, ok := shared.ParseLocale(language); language != "" && !ok {
+		return nil, "Email language is not supported"
+	}
+
+	arrival, err := reservation.NewArrivalDetails(r.FormValue("emergency_name"), r.FormValue("emergency_phone"), r.FormValue("emergency_relationship"), r.FormValue("arrival_time"))
+	if err != nil {
+		return nil, "Arrival details: " + err.Error()
+	}
+	arrival.RoomPreferences, err = parseRoomPreferences(r)
+	if err != nil {
+		return nil, "Room preferences: " + err.Error()
+	}
+	arrival.BusinessCustomer, err = reservation.NewBusinessCustomer(r.FormValue("company"), r.FormValue("vat_id"))
+	if err != nil {
+		return nil, "Business booking: " + err.Error()
+	}
+
 	return &reservationFormInput{
-		checkIn:    checkIn,
-		checkOut:   checkOut,
-		roomID:     roomID,
-		guestName:  guestName,
-		guestEmail: guestEmail,
-		guestPhone: guestPhone,
+		checkIn:      checkIn,
+		checkOut:     checkOut,
+		roomID:       roomID,
+		propertyID:   r.FormValue("property_id"),
+		guestName:    guestName,
+		guestEmail:   guestEmail,
+		guestPhone:   guestPhone,
+		referralCode: r.FormValue("referral_code"),
+		promoCode:    strings.TrimSpace(r.FormValue("promo_code")),
+		redeemPoints: redeemPoints,
+		language:     language,
+		arrival:      arrival,
 	}, ""
 }
 
+// parseRoomPreferences reads the weight of every room preference from its pref_<preference> field,
+// e.g. pref_high_floor=2; empty fields and 0 leave the preference out.
+func parseRoomPreferences(r *http.Request) (reservation.RoomPreferences, error) {
+	weights := make(map[reservation.RoomPreference]int)
+	for _, pref := range reservation.RoomPreferenceKinds {
+		value := r.FormValue("pref_" + string(pref))
+		if value == "" {
+			continue
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, reservation.ErrInvalidRoomPreference
+		}
+		weights[pref] = weight
+	}
+	return reservation.NewRoomPreferences(weights)
+}
+
+// ReservationFormConfig holds the services the reservation form books with.
+type ReservationFormConfig struct {
+	LoyaltyService     *loyalty.Service   // Optional: nil disables redeeming loyalty points
+	PricingService     *pricing.Service   // Optional: nil charges the fixed room prices
+	ProfileService     *profile.Service   // Optional: nil disables VIP perks and the preferred language
+	PromotionService   *promotion.Service // Optional: nil disables promo codes
+	Properties         *property.Catalog  // Optional: nil books any room without a property
+	ReferralService    *referral.Service  // Optional: nil disables referral codes
+	ReservationService *reservation.Service
+	WaitlistService    *waitlist.Service // Optional: nil does not offer the waitlist of booked rooms
+}
+
 // HttpCreateReservation handles the POST request to create a new reservation.
-func HttpCreateReservation(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+// The perks of the guest's VIP tier are applied if ProfileService is set.
+// An optional referral code is checked before booking and attributed afterwards if ReferralService is set.
+// The stay is priced with its rate plan if PricingService is set. If it locks prices, the first
+// submission locks the quote and shows it for confirmation; the booking charges the locked price, and a
+// lock that expired or was taken for other details is replaced by a new quote the guest confirms again.
+// If ReservationService holds rooms, the first submission also holds the room for the guest until
+// they confirm, and the booking is made under the ID of the hold.
+// If the guest gives room preferences, the first submission swaps the room for the available room of its
+// type that fulfills them best; the review and the booking keep that room.
+// The optional email language is stored as the guest's preferred language if ProfileService is set.
+// If Properties is set, the room must belong to the selected property.
+// If WaitlistService is set, a guest who finds the room booked is offered to join its waitlist.
+// If PromotionService is set, the promo code the guest entered is taken off the price after the tier discount.
+// If LoyaltyService is set, the loyalty points the guest redeems are taken off what is left to pay.
+// The use of the code and the points are given back if the booking fails.
+func HttpCreateReservation(e *templating.Engine, config ReservationFormConfig) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
 	title := appName + " - New Reservation"
 
@@ -140,42 +332,246 @@ func HttpCreateReservation(e *templating.Engine, reservationService *reservation
 		ctx := r.Context()
 
 		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
+		// renderFormError shows the form with the error, the promo code entered and the guest's loyalty balance.
+		renderFormError := func(errMsg, guestName, guestEmail, referralCode string) {
+			data := reservationFormWithError(r, config.Properties, appName, title, sessionID, errMsg, guestName, guestEmail, referralCode)
+			data.PromoCode, data.Promotions = r.FormValue("promo_code"), config.PromotionService != nil
+			data.Loyalty = loyaltyWidget(ctx, config.LoyaltyService, guestID, requestLocale(r))
+			HttpView(e, "reservation_form", data)(w, r)
+		}
+
 		input, errMsg := parseReservationForm(r)
+		if errMsg == "" && config.Properties != nil && input.propertyID != "" {
+			if err := config.Properties.CheckRoom(property.PropertyID(input.propertyID), property.RoomID(input.roomID)); err != nil {
+				errMsg = "Room is not available at the selected property"
+			}
+		}
 		if errMsg != "" {
-			renderReservationFormWithError(e, w, r, appName, title, sessionID, errMsg, r.FormValue("guest_name"), r.FormValue("guest_email"))
+			renderFormError(errMsg, r.FormValue("guest_name"), r.FormValue("guest_email"), r.FormValue("referral_code"))
 			return
 		}
 
-		nights := int(input.checkOut.Sub(input.checkIn).Hours() / 24)
-		totalAmount := shared.NewMoney(getRoomPrices()[input.roomID]*int64(nights), "USD")
+		if prefs := input.arrival.RoomPreferences; len(prefs) > 0 && r.FormValue("price_lock") == "" {
+			allocation, err := config.ReservationService.AllocateRoom(ctx, guestID, reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut), prefs)
+			if err != nil {
+				renderFormError(err.Error(), input.guestName, input.guestEmail, input.referralCode)
+				return
+			}
+			input.roomID = string(allocation.RoomID)
+		}
+
+		accountEmail, _ := ctx.Value(web.ContextEmail).(string)
+		referralCode := referral.NormalizeCode(input.referralCode)
+		if config.ReferralService != nil && referralCode != "" {
+			if _, err := config.ReferralService.Validate(ctx, referralCode, referral.GuestID(guestID), accountEmail); err != nil {
+				renderFormError("Referral code: "+err.Error(), input.guestName, input.guestEmail, input.referralCode)
+				return
+			}
+		}
+
+		// renderBookingError shows the form with the error; a guest who finds the room booked may join its waitlist.
+		renderBookingError := func(err error) {
+			data := reservationFormWithError(r, config.Properties, appName, title, sessionID, err.Error(), input.guestName, input.guestEmail, input.referralCode)
+			data.PromoCode, data.Promotions = input.promoCode, config.PromotionService != nil
+			data.Loyalty = loyaltyWidget(ctx, config.LoyaltyService, guestID, requestLocale(r))
+			if config.WaitlistService != nil && errors.Is(err, reservation.ErrRoomNotAvailable) {
+				data.Waitlist = &WaitlistJoin{
+					RoomID:   input.roomID,
+					CheckIn:  input.checkIn.Format("2006-01-02"),
+					CheckOut: input.checkOut.Format("2006-01-02"),
+				}
+			}
+			HttpView(e, "reservation_form", data)(w, r)
+		}
+		// review holds the room, if rooms are held, and shows the locked price for confirmation.
+		review := func(expired *pricing.PriceLock, verifyErr error) {
+			var hold *reservation.RoomHold
+			if config.ReservationService.HoldsRooms() {
+				var err error
+				hold, err = config.ReservationService.HoldRoom(ctx, shared.NewReservationID(), guestID, reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut))
+				if err != nil {
+					renderBookingError(err)
+					return
+				}
+			}
+			renderPriceReview(e, w, r, config.Properties, appName, title, sessionID, config.PricingService, input, hold, expired, verifyErr)
+		}
+
+		var lock *pricing.PriceLock
+		var totalAmount shared.Money
+		if config.PricingService != nil && config.PricingService.LocksPrices() {
+			lockID := pricing.PriceLockID(r.FormValue("price_lock"))
+			if lockID == "" {
+				review(nil, nil)
+				return
+			}
+			var err error
+			lock, err = config.PricingService.VerifyPriceLock(ctx, lockID, sessionID, pricing.RoomID(input.roomID), input.checkIn, input.checkOut)
+			if err != nil {
+				review(lock, err)
+				return
+			}
+			totalAmount = lock.Total
+		} else {
+			var err error
+			totalAmount, err = quoteStay(ctx, config.PricingService, input.roomID, input.checkIn, input.checkOut)
+			if err != nil {
+				renderFormError(err.Error(), input.guestName, input.guestEmail, input.referralCode)
+				return
+			}
+		}
 		guests := []reservation.GuestInfo{reservation.NewGuestInfo(input.guestName, input.guestEmail, input.guestPhone)}
 
-		_, err := reservationService.CreateReservation(ctx, shared.ReservationID(security.GenerateID()), reservation.GuestID(email), reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut), totalAmount, guests)
+		// A booking under the ID of the guest's hold takes the held room.
+		id := reservation.ReservationID(r.FormValue("room_hold"))
+		if id == "" {
+			id = shared.NewReservationID()
+		}
+		storeGuestLanguage(ctx, config.ProfileService, guestID, input.language)
+		perks := guestPerks(ctx, config.ProfileService, guestID)
+		// giveBack releases the promo code and refunds the points applied to a booking that was not made.
+		giveBack := func() {
+			if perks.Promotion.Code != "" {
+				_, _ = config.PromotionService.Release(context.WithoutCancel(ctx), id)
+			}
+			if perks.Redemption.Amount > 0 {
+				_, _ = config.LoyaltyService.RefundRedemption(context.WithoutCancel(ctx), id)
+			}
+		}
+		payable := shared.NewMoney(totalAmount.Amount-perks.DiscountOn(totalAmount).Amount, totalAmount.Currency)
+		if config.PromotionService != nil && input.promoCode != "" {
+			redemption, err := config.PromotionService.Redeem(ctx, input.promoCode, promotion.GuestID(guestID), id, payable)
+			if err != nil {
+				renderFormError("Promo code: "+err.Error(), input.guestName, input.guestEmail, input.referralCode)
+				return
+			}
+			perks.Promotion = reservation.AppliedPromotion{Code: string(redemption.Code), Discount: redemption.Discount}
+			payable = shared.NewMoney(payable.Amount-redemption.Discount.Amount, payable.Currency)
+		}
+		if config.LoyaltyService != nil && input.redeemPoints > 0 {
+			redemption, err := config.LoyaltyService.Redeem(ctx, loyalty.GuestID(guestID), id, input.redeemPoints, payable)
+			if err != nil {
+				giveBack()
+				renderFormError("Loyalty points: "+err.Error(), input.guestName, input.guestEmail, input.referralCode)
+				return
+			}
+			perks.Redemption = redemption.Value
+		}
+		res, err := config.ReservationService.CreateReservationWithPerks(withGuestPrincipal(ctx, guestID, accountEmail), id, guestID, reservation.RoomID(input.roomID), reservation.NewDateRange(input.checkIn, input.checkOut), totalAmount, guests, perks, input.arrival)
 		if err != nil {
-			renderReservationFormWithError(e, w, r, appName, title, sessionID, err.Error(), input.guestName, input.guestEmail)
+			giveBack()
+			renderBookingError(err)
 			return
 		}
 
+		if lock != nil {
+			_ = config.PricingService.ReleasePriceLock(ctx, lock.ID)
+		}
+
+		// The code was valid a moment ago; losing the attribution to a concurrent booking
+		// must not fail the reservation that was just made.
+		if config.ReferralService != nil && referralCode != "" {
+			_, _ = config.ReferralService.Attribute(ctx, referralCode, res.ID, referral.GuestID(guestID), accountEmail)
+		}
+
 		http.Redirect(w, r, "/ui/reservations", http.StatusSeeOther)
 	}
 }
 
-func renderReservationFormWithError(e *templating.Engine, w http.ResponseWriter, r *http.Request, appName, title, sessionID, errMsg, guestName, guestEmail string) {
+// priceLockNotice explains why the guest has to confirm the price again. The previous lock
+// is only known if it expired, so the expired and the new price can be compared.
+func priceLockNotice(expired, lock *pricing.PriceLock, verifyErr error, locale shared.Locale) string {
+	switch {
+	case expired != nil && expired.Total == lock.Total:
+		return "Your price hold expired. The price has not changed; please confirm it again."
+	case expired != nil:
+		return "Your price hold expired and the price changed from " + expired.Total.FormatIn(locale) + " to " + lock.Total.FormatIn(locale) + ". Please confirm the new price."
+	case errors.Is(verifyErr, pricing.ErrPriceLockMismatch):
+		return "The stay changed since the price was quoted. Please confirm the price of the new stay."
+	default:
+		return "Your price hold is no longer valid. Please confirm the current price."
+	}
+}
+
+// renderPriceReview locks the quote of the stay for the session and renders it for confirmation,
+// with the submitted form and the hold of the room, if any, as hidden fields. Without a price, the form
+// is shown with the error instead. verifyErr is the reason the previous lock was refused, if any, and
+// expired the lock if it expired.
+func renderPriceReview(e *templating.Engine, w http.ResponseWriter, r *http.Request, properties *property.Catalog, appName, title, sessionID string, pricingService *pricing.Service, input *reservationFormInput, hold *reservation.RoomHold, expired *pricing.PriceLock, verifyErr error) {
+	lock, err := pricingService.LockPrice(r.Context(), sessionID, pricing.RoomID(input.roomID), input.checkIn, input.checkOut)
+	if err != nil {
+		renderReservationFormWithError(e, w, r, properties, appName, title, sessionID, err.Error(), input.guestName, input.guestEmail, input.referralCode)
+		return
+	}
+
+	locale := requestLocale(r)
+	notice := ""
+	if verifyErr != nil {
+		notice = priceLockNotice(expired, lock, verifyErr, locale)
+	}
+	review := &ReservationReview{
+		PriceLockID: string(lock.ID),
+		RoomName:    input.roomID,
+		CheckIn:     input.checkIn.Format("2006-01-02"),
+		CheckOut:    input.checkOut.Format("2006-01-02"),
+		Total:       lock.Total.FormatIn(locale),
+		LockedUntil: lock.ExpiresAt.UTC().Format("15:04 MST"),
+		Notice:      notice,
+	}
+	if hold != nil {
+		review.RoomHoldID = string(hold.ReservationID)
+		review.HeldUntil = hold.ExpiresAt.UTC().Format("15:04 MST")
+	}
+	for _, room := range getDefaultRooms(locale) {
+		if room.ID == input.roomID {
+			review.RoomName = room.Name
+		}
+	}
+	for name, values := range r.PostForm {
+		if name == "price_lock" || name == "room_hold" || len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		if name == "room_id" {
+			value = input.roomID // the room allocated for the preferences
+		}
+		review.Fields = append(review.Fields, FormField{Name: name, Value: value})
+	}
+	slices.SortFunc(review.Fields, func(a, b FormField) int { return strings.Compare(a.Name, b.Name) })
+
 	data := HttpViewReservationFormResponse{
-		Rooms:      getDefaultRooms(),
-		AppName:    appName,
-		Title:      title,
-		SessionID:  sessionID,
-		MinDate:    time.Now().Format("2006-01-02"),
-		GuestName:  guestName,
-		GuestEmail: guestEmail,
-		Error:      errMsg,
+		AppName:   appName,
+		Title:     title,
+		SessionID: sessionID,
+		Review:    review,
 	}
 	HttpView(e, "reservation_form", data)(w, r)
 }
+
+func renderReservationFormWithError(e *templating.Engine, w http.ResponseWriter, r *http.Request, properties *property.Catalog, appName, title, sessionID, errMsg, guestName, guestEmail, referralCode string) {
+	data := reservationFormWithError(r, properties, appName, title, sessionID, errMsg, guestName, guestEmail, referralCode)
+	HttpView(e, "reservation_form", data)(w, r)
+}
+
+// reservationFormWithError returns the view data of the reservation form showing the error.
+func reservationFormWithError(r *http.Request, properties *property.Catalog, appName, title, sessionID, errMsg, guestName, guestEmail, referralCode string) HttpViewReservationFormResponse {
+	data := HttpViewReservationFormResponse{
+		Rooms:        getDefaultRooms(requestLocale(r)),
+		AppName:      appName,
+		Title:        title,
+		SessionID:    sessionID,
+		MinDate:      time.Now().Format("2006-01-02"),
+		GuestName:    guestName,
+		GuestEmail:   guestEmail,
+		ReferralCode: referralCode,
+		Error:        errMsg,
+	}
+	selectProperty(&data, properties, r.FormValue("property_id"))
+	return data
+}
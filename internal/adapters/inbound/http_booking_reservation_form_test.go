@@ -1,6 +1,7 @@
 package inbound_test
 
 import (
+	"context"
 	"embed"
 	"io"
 	"net/http"
@@ -12,10 +13,15 @@ import (
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // ============================================================================
@@ -47,7 +53,7 @@ func Test_HttpViewReservationForm_Without_Session_Should_Redirect_To_Login(t *te
 	e := templating.NewEngine(formTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewReservationForm(e)
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil)
 	rec := httptest.NewRecorder()
 
@@ -68,7 +74,7 @@ func Test_HttpViewReservationForm_With_Valid_Session_Should_Return_200(t *testin
 	e := templating.NewEngine(formTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewReservationForm(e)
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -88,7 +94,7 @@ func Test_HttpViewReservationForm_Should_Render_App_Name(t *testing.T) {
 	e := templating.NewEngine(formTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewReservationForm(e)
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -110,7 +116,7 @@ func Test_HttpViewReservationForm_Should_Render_Guest_Email(t *testing.T) {
 	e := templating.NewEngine(formTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewReservationForm(e)
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -132,7 +138,7 @@ func Test_HttpViewReservationForm_Should_Render_Rooms(t *testing.T) {
 	e := templating.NewEngine(formTestAssets)
 	e.Parse("testdata/assets/templates/*.tmpl")
 
-	handler := inbound.HttpViewReservationForm(e)
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -161,7 +167,7 @@ func Test_HttpCreateReservation_Without_Session_Should_Redirect_To_Login(t *test
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", nil)
 	rec := httptest.NewRecorder()
 
@@ -185,7 +191,7 @@ func Test_HttpCreateReservation_With_Missing_Fields_Should_Show_Error(t *testing
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 
 	// Create request with empty form
 	form := url.Values{}
@@ -215,7 +221,7 @@ func Test_HttpCreateReservation_With_Invalid_Room_Should_Show_Error(t *testing.T
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 
 	// Create request with invalid room
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -253,7 +259,7 @@ func Test_HttpCreateReservation_With_Valid_Data_Should_Redirect_To_Reservations(
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 
 	// Create request with valid data
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -291,7 +297,7 @@ func Test_HttpCreateReservation_Should_Create_Reservation_In_Repository(t *testi
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 
 	// Create request with valid data
 	checkIn := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
@@ -313,7 +319,196 @@ func Test_HttpCreateReservation_Should_Create_Reservation_In_Repository(t *testi
 	handler(rec, req)
 
 	// Assert
-	assert.That(t, "repository must have 1 reservation", len(repo.reservations), 1)
+	assert.That(t, "repository must have 1 reservation", repo.count(), 1)
+}
+
+func Test_HttpCreateReservation_With_Arrival_Details_Should_Store_Them(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: createFormTestService(repo)})
+	form := url.Values{
+		"room_id":         {"room-101"},
+		"check_in":        {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":       {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":      {"Test Guest"},
+		"guest_email":     {"test@example.com"},
+		"arrival_time":    {"22:30"},
+		"emergency_name":  {"Jane Doe"},
+		"emergency_phone": {"+1 555 0100"},
+		"company":         {"Acme GmbH"},
+		"vat_id":          {"DE 123 456 789"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	for _, res := range repo.all() {
+		assert.That(t, "arrival time must be stored", res.ArrivalTime, "22:30")
+		assert.That(t, "emergency contact must be stored", res.EmergencyContact.PhoneNumber, "+1 555 0100")
+		assert.That(t, "business customer must be stored", *res.BusinessCustomer, reservation.BusinessCustomer{Company: "Acme GmbH", VATID: "DE123456789"})
+	}
+}
+
+func Test_HttpCreateReservation_With_Room_Preferences_Should_Allocate_Best_Room_Of_Type(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	service := createFormTestService(repo).WithRoomAllocation(reservation.DefaultRoomLayout(), reservation.NewRates(reservation.DefaultRatePolicy()))
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
+	form := url.Values{
+		"room_id":                 {"room-101"},
+		"check_in":                {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":               {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":              {"Test Guest"},
+		"guest_email":             {"test@example.com"},
+		"pref_away_from_elevator": {"3"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	for _, res := range repo.all() {
+		assert.That(t, "standard room away from the elevator must be booked", res.RoomID, reservation.RoomID("room-102"))
+		assert.That(t, "preference must be fulfilled", res.PreferencesMet, []reservation.RoomPreference{reservation.PreferAwayFromElevator})
+	}
+}
+
+func Test_HttpCreateReservation_With_Invalid_Room_Preference_Should_Show_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: createFormTestService(repo)})
+	form := url.Values{
+		"room_id":         {"room-101"},
+		"check_in":        {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":       {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":      {"Test Guest"},
+		"guest_email":     {"test@example.com"},
+		"pref_high_floor": {"9"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "error must be shown", strings.Contains(rec.Body.String(), "Room preferences"), true)
+	assert.That(t, "repository must be empty", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_With_Incomplete_Emergency_Contact_Should_Show_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: createFormTestService(repo)})
+	form := url.Values{
+		"room_id":        {"room-101"},
+		"check_in":       {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":      {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":     {"Test Guest"},
+		"guest_email":    {"test@example.com"},
+		"emergency_name": {"Jane Doe"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "error must be shown", strings.Contains(rec.Body.String(), "emergency contact needs a name and a phone number"), true)
+	assert.That(t, "reservation must not be created", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_With_Invalid_VAT_ID_Should_Show_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: createFormTestService(repo)})
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+		"company":     {"Acme GmbH"},
+		"vat_id":      {"DE1234"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "error must be shown", strings.Contains(rec.Body.String(), "VAT ID does not match the format of its country"), true)
+	assert.That(t, "reservation must not be created", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_With_Language_Should_Store_Preference(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	reservationService := createFormTestService(newMockReservationRepository())
+	profileService := profile.NewService(outbound.NewReservationGuestDirectory(reservationService),
+		resource.NewInMemoryAccess[profile.MergeID, profile.Merge](),
+		resource.NewInMemoryAccess[profile.GuestID, profile.TierAssignment](),
+		profile.DefaultTierPolicy()).
+		WithLanguages(resource.NewInMemoryAccess[profile.GuestID, profile.LanguagePreference]())
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ProfileService: profileService, ReservationService: reservationService})
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 9).Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+		"language":    {"fr"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/new", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	locale, ok := profileService.LanguageOf(context.Background(), "user-subject-456")
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "preference must be stored", ok, true)
+	assert.That(t, "locale must be french", locale, shared.Locale("fr-FR"))
 }
 
 func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Error(t *testing.T) {
@@ -327,7 +522,7 @@ func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Err
 	repo := newMockReservationRepository()
 	service := createFormTestService(repo)
 
-	handler := inbound.HttpCreateReservation(e, service)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: service})
 
 	// Create request with invalid date format
 	form := url.Values{
@@ -352,6 +547,180 @@ func Test_HttpCreateReservation_With_Invalid_CheckIn_Date_Format_Should_Show_Err
 	assert.That(t, "body must contain error message", containsString(bodyStr, "Invalid check-in date"), true)
 }
 
+// ============================================================================
+// HttpCreateReservation Price Lock Tests
+// ============================================================================
+
+// checkoutForm returns the reservation form of room-101 for three nights in a week.
+func checkoutForm() url.Values {
+	return url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+	}
+}
+
+// postPriceLockForm posts the checkout form with the price lock if it is not empty.
+func postPriceLockForm(handler http.HandlerFunc, priceLock string) *httptest.ResponseRecorder {
+	form := checkoutForm()
+	if priceLock != "" {
+		form.Set("price_lock", priceLock)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+// lockedPriceID returns the price lock of the review page.
+func lockedPriceID(t *testing.T, body string) string {
+	t.Helper()
+	return reviewField(t, body, "price_lock")
+}
+
+// reviewField returns the value of the hidden field of the review page.
+func reviewField(t *testing.T, body, name string) string {
+	t.Helper()
+	_, rest, ok := strings.Cut(body, `name="`+name+`" value="`)
+	if !ok {
+		t.Fatalf("review must contain %s", name)
+	}
+	value, _, _ := strings.Cut(rest, `"`)
+	return value
+}
+
+func Test_HttpCreateReservation_With_Price_Locks_Should_Show_Locked_Price_First(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	pricingService := createTestPricingService().WithPriceLocks(resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock](), 15*time.Minute)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PricingService: pricingService, ReservationService: createFormTestService(repo)})
+
+	// Act
+	rec := postPriceLockForm(handler, "")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "review must show the total", strings.Contains(rec.Body.String(), "$297.00"), true)
+	assert.That(t, "form must be carried along", strings.Contains(rec.Body.String(), `name="guest_name" value="Test Guest"`), true)
+	assert.That(t, "no reservation must be created yet", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_With_Price_Lock_Should_Charge_Locked_Price_After_Rate_Change(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	pricingService := createTestPricingService().WithPriceLocks(resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock](), 15*time.Minute)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PricingService: pricingService, ReservationService: createFormTestService(repo)})
+	lockID := lockedPriceID(t, postPriceLockForm(handler, "").Body.String())
+	_, _ = pricingService.SaveRatePlan(context.Background(), pricing.RatePlan{RoomID: "room-101", BaseRate: shared.NewMoney(20000, "USD")})
+
+	// Act
+	rec := postPriceLockForm(handler, lockID)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "repository must have 1 reservation", repo.count(), 1)
+	for _, res := range repo.all() {
+		assert.That(t, "total must be the locked price", res.TotalAmount, shared.NewMoney(29700, "USD"))
+	}
+}
+
+func Test_HttpCreateReservation_With_Expired_Price_Lock_Should_Quote_Again(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	locks := resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock]()
+	pricingService := createTestPricingService().WithPriceLocks(locks, 15*time.Minute)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PricingService: pricingService, ReservationService: createFormTestService(repo)})
+	lockID := pricing.PriceLockID(lockedPriceID(t, postPriceLockForm(handler, "").Body.String()))
+	lock, _ := locks.Read(context.Background(), lockID)
+	lock.ExpiresAt = time.Now().Add(-time.Minute)
+	_ = locks.Update(context.Background(), lockID, *lock)
+	_, _ = pricingService.SaveRatePlan(context.Background(), pricing.RatePlan{RoomID: "room-101", BaseRate: shared.NewMoney(20000, "USD")})
+
+	// Act
+	rec := postPriceLockForm(handler, string(lockID))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "notice must show the price change", strings.Contains(rec.Body.String(), "from $297.00 to $600.00"), true)
+	assert.That(t, "a new lock must be issued", lockedPriceID(t, rec.Body.String()) != string(lockID), true)
+	assert.That(t, "no reservation must be created", repo.count(), 0)
+}
+
+// ============================================================================
+// HttpCreateReservation Room Hold Tests
+// ============================================================================
+
+// createHoldingFormTestService creates a reservation service that holds rooms during checkout.
+func createHoldingFormTestService(repo *mockReservationRepository) *reservation.Service {
+	holds := resource.NewInMemoryAccess[reservation.ReservationID, reservation.RoomHold]()
+	availabilityChecker := outbound.NewRepositoryAvailabilityChecker(repo).WithCheckoutHolds(holds)
+	eventPublisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	return reservation.NewService(repo, availabilityChecker, eventPublisher).WithCheckoutHolds(holds, 15*time.Minute)
+}
+
+// postGuestCheckoutForm posts the checkout form as the guest with the additional fields.
+func postGuestCheckoutForm(handler http.HandlerFunc, guestID string, fields map[string]string) *httptest.ResponseRecorder {
+	form := checkoutForm()
+	for name, value := range fields {
+		form.Set(name, value)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addGuestContext(req, guestID, guestID+"@example.com")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func Test_HttpCreateReservation_With_Room_Holds_Should_Refuse_Room_Held_For_Other_Guest(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	pricingService := createTestPricingService().WithPriceLocks(resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock](), 15*time.Minute)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PricingService: pricingService, ReservationService: createHoldingFormTestService(newMockReservationRepository())})
+	first := postGuestCheckoutForm(handler, "guest-a", nil)
+
+	// Act
+	rec := postGuestCheckoutForm(handler, "guest-b", nil)
+
+	// Assert
+	assert.That(t, "review must hold the room", reviewField(t, first.Body.String(), "room_hold") != "", true)
+	assert.That(t, "review must show the hold", strings.Contains(first.Body.String(), "Room held until"), true)
+	assert.That(t, "second guest must be refused", strings.Contains(rec.Body.String(), reservation.ErrRoomNotAvailable.Error()), true)
+	assert.That(t, "second guest must get no hold", strings.Contains(rec.Body.String(), `name="room_hold"`), false)
+}
+
+func Test_HttpCreateReservation_With_Room_Hold_Should_Book_Held_Room(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	pricingService := createTestPricingService().WithPriceLocks(resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock](), 15*time.Minute)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PricingService: pricingService, ReservationService: createHoldingFormTestService(repo)})
+	body := postGuestCheckoutForm(handler, "guest-a", nil).Body.String()
+	holdID := reviewField(t, body, "room_hold")
+
+	// Act
+	rec := postGuestCheckoutForm(handler, "guest-a", map[string]string{"price_lock": lockedPriceID(t, body), "room_hold": holdID})
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "reservation must be made under the hold", repo.count(), 1)
+	for _, res := range repo.all() {
+		assert.That(t, "reservation ID must be the hold ID", string(res.ID), holdID)
+	}
+}
+
 // ============================================================================
 // Unit Tests for Room Configuration
 // ============================================================================
@@ -422,3 +791,100 @@ func Test_GetRoomPrices_Should_Have_Correct_Standard_Price(t *testing.T) {
 	assert.That(t, "room-101 price must be 9900", prices["room-101"], int64(9900))
 	assert.That(t, "room-102 price must be 9900", prices["room-102"], int64(9900))
 }
+
+// ============================================================================
+// Property Selection Tests
+// ============================================================================
+
+// createTestProperties creates the properties ber (default, with every unlisted room) and muc (room-201, room-202).
+func createTestProperties(t *testing.T) *property.Catalog {
+	t.Helper()
+	catalog, err := property.NewCatalog(
+		property.Property{ID: "ber", Name: "Hotel Berlin", Address: "Unter den Linden 1", Timezone: "Europe/Berlin"},
+		property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "Europe/Berlin", Rooms: []property.RoomID{"room-201", "room-202"}},
+	)
+	if err != nil {
+		t.Fatalf("failed to create properties: %v", err)
+	}
+	return catalog
+}
+
+func Test_HttpViewReservationForm_With_Selected_Property_Should_Offer_Its_Rooms_Only(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewReservationForm(e, createTestProperties(t), nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new?property_id=muc", nil)
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must select the property", strings.Contains(body, `<option value="muc" selected>`), true)
+	assert.That(t, "body must offer the rooms of the property", strings.Contains(body, "room-201"), true)
+	assert.That(t, "body must not offer rooms of other properties", strings.Contains(body, "room-101"), false)
+}
+
+func Test_HttpCreateReservation_With_Room_Of_Other_Property_Should_Show_Error(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{Properties: createTestProperties(t), ReservationService: createFormTestService(repo)})
+	form := url.Values{
+		"property_id": {"muc"},
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":  {"John Doe"},
+		"guest_email": {"john@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	all, _ := repo.ReadAll(context.Background())
+	assert.That(t, "status code must be 200 (form re-rendered with error)", rec.Code, http.StatusOK)
+	assert.That(t, "body must explain the error", strings.Contains(rec.Body.String(), "Room is not available at the selected property"), true)
+	assert.That(t, "no reservation must be created", len(all), 0)
+}
+
+func Test_HttpCreateReservation_With_Properties_Should_Record_Property(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	properties := createTestProperties(t)
+	service := createFormTestService(repo).WithProperties(outbound.NewPropertyRooms(properties))
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{Properties: properties, ReservationService: service})
+	form := url.Values{
+		"property_id": {"muc"},
+		"room_id":     {"room-201"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":  {"John Doe"},
+		"guest_email": {"john@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	all, _ := repo.ReadAll(context.Background())
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "reservation must be created", len(all), 1)
+	assert.That(t, "reservation must be at the property", all[0].PropertyID, shared.PropertyID("muc"))
+}
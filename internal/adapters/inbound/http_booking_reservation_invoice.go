@@ -0,0 +1,125 @@
+package inbound
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// InvoiceLineView represents a charge or a payment of an invoice for the view.
+type InvoiceLineView struct {
+	Description string
+	Amount      string
+}
+
+// HttpViewReservationInvoiceResponse specifies the view data for the printable invoice.
+type HttpViewReservationInvoiceResponse struct {
+	AppName            string
+	Title              string
+	ReservationID      string
+	Number             string
+	IssuedAt           string
+	Seller             string
+	TaxNumbers         []string
+	CustomerName       string
+	Company            string // empty for private guests
+	VATID              string // VAT ID of the company; empty if it gave none
+	ConfirmationNumber string
+	CheckIn            string
+	CheckOut           string
+	Charges            []InvoiceLineView
+	CityTax            *InvoiceLineView // nil if the jurisdiction levies none
+	Deposits           []InvoiceLineView
+	Net                string
+	VATRate            string // e.g. 7%; empty if reverse charged
+	VAT                string
+	Total              string
+	Paid               string
+	Due                string
+	Notes              []string
+	Footer             string
+}
+
+// HttpViewReservationInvoice defines an HTTP handler function for rendering the invoice of a completed stay
+// in the print layout. Stays are invoiced when they are completed; before that it answers 404 Not Found.
+func HttpViewReservationInvoice(e *templating.Engine, reservationService *reservation.Service, invoices *invoice.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		if !canViewReservation(ctx, res, guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		inv, err := invoices.Invoice(ctx, res.ID)
+		if err != nil {
+			http.Error(w, "Invoice not found", http.StatusNotFound)
+			return
+		}
+
+		data := buildInvoiceView(inv, requestLocale(r))
+		data.AppName = appName
+		data.Title = appName + " - Invoice " + inv.Number
+		HttpView(e, "reservation_invoice", data)(w, r)
+	}
+}
+
+// buildInvoiceView converts an invoice to its view data.
+func buildInvoiceView(inv *invoice.Invoice, locale shared.Locale) HttpViewReservationInvoiceResponse {
+	lines := func(lines []invoice.Line) []InvoiceLineView {
+		var views []InvoiceLineView
+		for _, l := range lines {
+			views = append(views, InvoiceLineView{Description: l.Description, Amount: l.Amount.FormatIn(locale)})
+		}
+		return views
+	}
+	data := HttpViewReservationInvoiceResponse{
+		ReservationID:      string(inv.ReservationID),
+		Number:             inv.Number,
+		IssuedAt:           inv.IssuedAt.Format("2006-01-02"),
+		Seller:             inv.Seller,
+		TaxNumbers:         inv.TaxNumbers,
+		CustomerName:       inv.Customer.Name,
+		Company:            inv.Customer.Company,
+		VATID:              inv.Customer.VATID,
+		ConfirmationNumber: inv.ConfirmationNumber,
+		CheckIn:            inv.CheckIn.Format("2006-01-02"),
+		CheckOut:           inv.CheckOut.Format("2006-01-02"),
+		Charges:            lines(inv.Charges),
+		Deposits:           lines(inv.Deposits),
+		Net:                inv.Net.FormatIn(locale),
+		VAT:                inv.VAT.FormatIn(locale),
+		Total:              inv.Total.FormatIn(locale),
+		Paid:               inv.Paid.FormatIn(locale),
+		Due:                inv.Due.FormatIn(locale),
+		Notes:              inv.Notes,
+		Footer:             inv.Footer,
+	}
+	if !inv.ReverseCharge {
+		data.VATRate = fmt.Sprintf("%g%%", float64(inv.VATRate)/100)
+	}
+	if inv.CityTax != nil {
+		data.CityTax = &InvoiceLineView{Description: inv.CityTax.Description, Amount: inv.CityTax.Amount.FormatIn(locale)}
+	}
+	return data
+}
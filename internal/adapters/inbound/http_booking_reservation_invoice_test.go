@@ -0,0 +1,101 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createInvoiceTestService creates an invoice service for the stays of the reservation service, with the charges
+// and payments of createFinancialTestService and a default jurisdiction with 10% VAT and a city tax of 2.00.
+func createInvoiceTestService(reservationService *reservation.Service) *invoice.Service {
+	jurisdictions, _ := invoice.ParseJurisdictions("us=US|INV-|Hotel Inc., Springfield|EIN 12-3456789|10%|2.00||Thank you for staying with us")
+	stays := outbound.NewReservationInvoiceStays(reservationService, createFinancialTestService(reservationService))
+	return invoice.NewService(resource.NewInMemoryAccess[invoice.ReservationID, invoice.Invoice](), stays, jurisdictions)
+}
+
+func invoiceRequest(subject, email string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/invoice", nil)
+	req.SetPathValue("id", "res-001")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// HttpViewReservationInvoice Tests
+// ============================================================================
+
+func Test_HttpViewReservationInvoice_By_Owner_Should_Render_Invoice(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	invoices := createInvoiceTestService(svc)
+	_, _ = invoices.Issue(context.Background(), "res-001")
+	handler := inbound.HttpViewReservationInvoice(e, svc, invoices)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, invoiceRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain the number", strings.Contains(body, "Invoice INV-000001"), true)
+	assert.That(t, "body must contain the tax number", strings.Contains(body, "EIN 12-3456789"), true)
+	assert.That(t, "body must contain the totals", strings.Contains(body, "Net: $283.64 VAT: $28.36 Total: $318.00 Due: $218.00"), true)
+	assert.That(t, "body must contain the city tax", strings.Contains(body, "City tax: $6.00"), true)
+	assert.That(t, "body must contain the footer", strings.Contains(body, "Thank you for staying with us"), true)
+}
+
+func Test_HttpViewReservationInvoice_Before_Completion_Should_Return_404(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	handler := inbound.HttpViewReservationInvoice(e, svc, createInvoiceTestService(svc))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, invoiceRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpViewReservationInvoice_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createDetailTestService(repo)
+	invoices := createInvoiceTestService(svc)
+	_, _ = invoices.Issue(context.Background(), "res-001")
+	handler := inbound.HttpViewReservationInvoice(e, svc, invoices)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, invoiceRequest("other-subject", "other@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
@@ -0,0 +1,80 @@
+package inbound
+
+import (
+	"html/template"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// QRCodeRenderer renders data as a QR code image in SVG markup.
+// outbound.QRCodes implements it.
+type QRCodeRenderer interface {
+	SVG(data string) (string, error)
+}
+
+// HttpViewReservationPrintResponse specifies the view data for the printable reservation summary.
+type HttpViewReservationPrintResponse struct {
+	AppName              string
+	Title                string
+	Reservation          ReservationDetailView
+	CancellationDeadline string
+	DetailURL            string
+	QRCode               template.HTML // empty if no QRCodeRenderer is configured
+}
+
+// HttpViewReservationPrint defines an HTTP handler function for rendering a print-friendly reservation summary.
+// The QR code links to the reservation detail page, so the printout can be scanned at the front desk.
+func HttpViewReservationPrint(e *templating.Engine, reservationService *reservation.Service, qrCodes QRCodeRenderer) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		reservationID := r.PathValue("id")
+		if reservationID == "" {
+			http.Error(w, "Reservation ID required", http.StatusBadRequest)
+			return
+		}
+
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+
+		if !canViewReservation(ctx, res, guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		data := HttpViewReservationPrintResponse{
+			AppName:              appName,
+			Title:                appName + " - Reservation " + reservationID,
+			Reservation:          buildReservationDetailView(res, requestLocale(r)),
+			CancellationDeadline: res.CancellationDeadline().Format("2006-01-02 15:04"),
+			DetailURL:            absoluteURL(r, "/ui/reservations/"+reservationID),
+		}
+
+		// A missing QR code must not prevent printing the confirmation.
+		// The SVG markup is generated by the renderer and only encodes the URL, so it is safe to embed.
+		if qrCodes != nil {
+			if svg, err := qrCodes.SVG(data.DetailURL); err == nil {
+				data.QRCode = template.HTML(svg)
+			}
+		}
+
+		HttpView(e, "reservation_print", data)(w, r)
+	}
+}
@@ -0,0 +1,90 @@
+package inbound_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type failingQRCodes struct{}
+
+func (failingQRCodes) SVG(data string) (string, error) {
+	return "", errors.New("qr code failed")
+}
+
+func printRequest(subject, email string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/print", nil)
+	req.SetPathValue("id", "res-001")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// HttpViewReservationPrint Tests
+// ============================================================================
+
+func Test_HttpViewReservationPrint_By_Owner_Should_Render_Summary_With_QR_Code(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationPrint(e, createDetailTestService(repo), outbound.NewQRCodes(2))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, printRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain room", strings.Contains(body, "room-101"), true)
+	assert.That(t, "body must contain detail url", strings.Contains(body, "http://example.com/ui/reservations/res-001"), true)
+	assert.That(t, "body must contain unescaped svg", strings.Contains(body, "<svg"), true)
+}
+
+func Test_HttpViewReservationPrint_When_QR_Code_Fails_Should_Render_Without_It(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationPrint(e, createDetailTestService(repo), failingQRCodes{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, printRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must not contain svg", strings.Contains(rec.Body.String(), "<svg"), false)
+}
+
+func Test_HttpViewReservationPrint_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationPrint(e, createDetailTestService(repo), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, printRequest("other-subject", "other@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
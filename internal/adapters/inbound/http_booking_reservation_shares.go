@@ -0,0 +1,147 @@
+package inbound
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ShareLinkSigner signs and verifies the tokens of share invitation links.
+// outbound.ShareLinks implements it.
+type ShareLinkSigner interface {
+	Sign(id shared.ReservationID, email string) string
+	Verify(token string) (shared.ReservationID, string, error)
+}
+
+// ShareInvitationSender sends the invitation link to a co-traveler.
+// outbound.MockNotificationService implements it.
+type ShareInvitationSender interface {
+	SendShareInvitation(ctx context.Context, res *reservation.Reservation, email, link string) error
+}
+
+// HttpShareReservation handles the POST request to invite a co-traveler to a reservation.
+// Only the owner may share a reservation.
+func HttpShareReservation(reservationService *reservation.Service, links ShareLinkSigner, invitations ShareInvitationSender) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		reservationID := r.PathValue("id")
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		if !res.IsOwnedBy(guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		inviteeEmail := r.FormValue("email")
+		res, err = reservationService.ShareReservation(ctx, res.ID, inviteeEmail, reservation.ShareRole(r.FormValue("role")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		link := absoluteURL(r, "/ui/shares/accept?token="+url.QueryEscape(links.Sign(res.ID, inviteeEmail)))
+		if err := invitations.SendShareInvitation(ctx, res, inviteeEmail, link); err != nil {
+			http.Error(w, "Failed to send invitation", http.StatusInternalServerError)
+			return
+		}
+
+		redirectToReservation(w, r, res.ID)
+	}
+}
+
+// HttpRevokeShare handles the POST request to remove the access of a co-traveler.
+// Only the owner may revoke a share grant.
+func HttpRevokeShare(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		reservationID := r.PathValue("id")
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(reservationID))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		if !res.IsOwnedBy(guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		if _, err := reservationService.RevokeShare(ctx, res.ID, r.FormValue("email")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		redirectToReservation(w, r, res.ID)
+	}
+}
+
+// HttpAcceptShare handles the GET request of an invitation link.
+// The share grant is bound to the signed-in guest account, which can then see the reservation.
+func HttpAcceptShare(reservationService *reservation.Service, links ShareLinkSigner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		reservationID, inviteeEmail, err := links.Verify(r.URL.Query().Get("token"))
+		if err != nil {
+			http.Error(w, "Invalid or expired invitation link", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := reservationService.AcceptShare(ctx, reservationID, inviteeEmail, guestID); err != nil {
+			http.Error(w, "Invitation is no longer valid", http.StatusForbidden)
+			return
+		}
+
+		http.Redirect(w, r, "/ui/reservations/"+string(reservationID), http.StatusSeeOther)
+	}
+}
+
+// absoluteURL returns the absolute URL of the path for links in emails, based on the request host.
+func absoluteURL(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + path
+}
+
+// redirectToReservation redirects to the reservation detail page.
+// HTMX requests get an HX-Redirect header to trigger a full page navigation.
+func redirectToReservation(w http.ResponseWriter, r *http.Request, id shared.ReservationID) {
+	location := "/ui/reservations/" + string(id)
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
@@ -0,0 +1,209 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockShareInvitations struct {
+	email string
+	link  string
+}
+
+func (m *mockShareInvitations) SendShareInvitation(ctx context.Context, res *reservation.Reservation, email, link string) error {
+	m.email = email
+	m.link = link
+	return nil
+}
+
+func addGuestContext(req *http.Request, subject, email string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, web.ContextSessionID, "session-"+subject)
+	ctx = context.WithValue(ctx, web.ContextEmail, email)
+	ctx = context.WithValue(ctx, web.ContextSubject, subject)
+	return req.WithContext(ctx)
+}
+
+func createSharedTestReservation(repo *mockReservationRepository, owner reservation.GuestID) {
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	res := createTestReservation("res-001", "owner@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	res.GuestID = owner
+	repo.put(shared.ReservationID("res-001"), *res)
+}
+
+func shareRequest(subject, email string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/shares", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "res-001")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// HttpShareReservation Tests
+// ============================================================================
+
+func Test_HttpShareReservation_By_Owner_Should_Send_Signed_Invitation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	links := outbound.NewShareLinks("secret", time.Hour)
+	invitations := &mockShareInvitations{}
+	handler := inbound.HttpShareReservation(service, links, invitations)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, shareRequest("owner-subject", "owner@example.com", url.Values{"email": {"friend@example.com"}, "role": {"view"}}))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "invitation must be sent to friend", invitations.email, "friend@example.com")
+	link, _ := url.Parse(invitations.link)
+	id, email, err := links.Verify(link.Query().Get("token"))
+	assert.That(t, "link must be valid", err, nil)
+	assert.That(t, "link must reference reservation", id, shared.ReservationID("res-001"))
+	assert.That(t, "link must reference invitee", email, "friend@example.com")
+}
+
+func Test_HttpShareReservation_By_Non_Owner_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpShareReservation(service, outbound.NewShareLinks("secret", time.Hour), &mockShareInvitations{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, shareRequest("other-subject", "other@example.com", url.Values{"email": {"friend@example.com"}, "role": {"manage"}}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+// ============================================================================
+// HttpAcceptShare Tests
+// ============================================================================
+
+func Test_HttpAcceptShare_With_Valid_Link_Should_Grant_Access(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	_, _ = service.ShareReservation(context.Background(), "res-001", "friend@example.com", reservation.ShareRoleView)
+	links := outbound.NewShareLinks("secret", time.Hour)
+	handler := inbound.HttpAcceptShare(service, links)
+	req := httptest.NewRequest(http.MethodGet, "/ui/shares/accept?token="+url.QueryEscape(links.Sign("res-001", "friend@example.com")), nil)
+	req = addGuestContext(req, "friend-subject", "friend@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be reservation", rec.Header().Get("Location"), "/ui/reservations/res-001")
+	res, _ := repo.Read(context.Background(), "res-001")
+	assert.That(t, "friend can view", res.CanView("friend-subject"), true)
+	assert.That(t, "friend cannot manage", res.CanManage("friend-subject"), false)
+}
+
+func Test_HttpAcceptShare_With_Invalid_Link_Should_Return_400(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	handler := inbound.HttpAcceptShare(service, outbound.NewShareLinks("secret", time.Hour))
+	req := httptest.NewRequest(http.MethodGet, "/ui/shares/accept?token=forged", nil)
+	req = addGuestContext(req, "friend-subject", "friend@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Share Enforcement Tests
+// ============================================================================
+
+func Test_HttpCancelReservation_With_View_Share_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	_, _ = service.ShareReservation(context.Background(), "res-001", "friend@example.com", reservation.ShareRoleView)
+	_, _ = service.AcceptShare(context.Background(), "res-001", "friend@example.com", "friend-subject")
+	handler := inbound.HttpCancelReservation(service)
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "friend-subject", "friend@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpCancelReservation_With_Manage_Share_Should_Cancel(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	_, _ = service.ShareReservation(context.Background(), "res-001", "friend@example.com", reservation.ShareRoleManage)
+	_, _ = service.AcceptShare(context.Background(), "res-001", "friend@example.com", "friend-subject")
+	handler := inbound.HttpCancelReservation(service)
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "friend-subject", "friend@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	res, _ := repo.Read(context.Background(), "res-001")
+	assert.That(t, "reservation must be cancelled", res.Status, reservation.StatusCancelled)
+}
+
+func Test_HttpRevokeShare_By_Owner_Should_Remove_Access(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createDetailTestService(repo)
+	createSharedTestReservation(repo, "owner-subject")
+	_, _ = service.ShareReservation(context.Background(), "res-001", "friend@example.com", reservation.ShareRoleView)
+	_, _ = service.AcceptShare(context.Background(), "res-001", "friend@example.com", "friend-subject")
+	handler := inbound.HttpRevokeShare(service)
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/shares/revoke", strings.NewReader("email=friend%40example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "owner-subject", "owner@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	res, _ := repo.Read(context.Background(), "res-001")
+	assert.That(t, "friend cannot view", res.CanView("friend-subject"), false)
+}
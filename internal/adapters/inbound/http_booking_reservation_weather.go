@@ -0,0 +1,82 @@
+package inbound
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// WeatherForecaster provides the forecast at the property for the stay dates.
+// outbound.WeatherForecasts implements it.
+type WeatherForecaster interface {
+	Forecast(ctx context.Context, checkIn, checkOut time.Time) ([]shared.DailyForecast, error)
+}
+
+// WeatherDayView represents the forecast of one day for the view.
+type WeatherDayView struct {
+	Date                string
+	Summary             string
+	TempMin             string
+	TempMax             string
+	PrecipitationChance int
+}
+
+// HttpViewReservationWeatherResponse specifies the view data for the weather widget.
+type HttpViewReservationWeatherResponse struct {
+	Days []WeatherDayView
+}
+
+// HttpViewReservationWeather defines an HTTP handler function for rendering the weather widget of a reservation.
+// The widget is loaded by HTMX after the detail page, so a slow provider never delays the page.
+// It answers 204 No Content if no forecast is available, which leaves the page unchanged.
+func HttpViewReservationWeather(e *templating.Engine, reservationService *reservation.Service, forecaster WeatherForecaster) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		res, err := reservationService.GetReservation(ctx, shared.ReservationID(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, "Reservation not found", http.StatusNotFound)
+			return
+		}
+		if !canViewReservation(ctx, res, guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		if forecaster == nil || res.Status == reservation.StatusCancelled || res.Status == reservation.StatusCompleted || res.Status == reservation.StatusNoShow {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		forecasts, err := forecaster.Forecast(ctx, res.DateRange.CheckIn, res.DateRange.CheckOut)
+		if err != nil || len(forecasts) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		data := HttpViewReservationWeatherResponse{}
+		for _, f := range forecasts {
+			data.Days = append(data.Days, WeatherDayView{
+				Date:                f.Date.Format("Mon, Jan 2"),
+				Summary:             f.Summary,
+				TempMin:             fmt.Sprintf("%.0f°C", f.TempMinC),
+				TempMax:             fmt.Sprintf("%.0f°C", f.TempMaxC),
+				PrecipitationChance: f.PrecipitationChance,
+			})
+		}
+
+		HttpView(e, "reservation_weather", data)(w, r)
+	}
+}
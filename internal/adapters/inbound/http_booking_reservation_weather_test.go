@@ -0,0 +1,106 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockWeatherForecaster struct {
+	err error
+}
+
+func (m *mockWeatherForecaster) Forecast(ctx context.Context, checkIn, checkOut time.Time) ([]shared.DailyForecast, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []shared.DailyForecast{{Date: checkIn, Summary: "Clear sky", TempMinC: 4.4, TempMaxC: 12.6, PrecipitationChance: 10}}, nil
+}
+
+func weatherRequest(subject, email string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001/weather", nil)
+	req.SetPathValue("id", "res-001")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// HttpViewReservationWeather Tests
+// ============================================================================
+
+func Test_HttpViewReservationWeather_Should_Render_Forecast(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationWeather(e, createDetailTestService(repo), &mockWeatherForecaster{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, weatherRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain forecast", strings.Contains(rec.Body.String(), "Clear sky - 4°C / 13°C - 10%"), true)
+}
+
+func Test_HttpViewReservationWeather_When_Provider_Unavailable_Should_Return_204(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationWeather(e, createDetailTestService(repo), &mockWeatherForecaster{err: errors.New("down")})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, weatherRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+}
+
+func Test_HttpViewReservationWeather_Without_Forecaster_Should_Return_204(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationWeather(e, createDetailTestService(repo), nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, weatherRequest("owner-subject", "owner@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+}
+
+func Test_HttpViewReservationWeather_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	handler := inbound.HttpViewReservationWeather(e, createDetailTestService(repo), &mockWeatherForecaster{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, weatherRequest("other-subject", "other@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
@@ -1,36 +1,73 @@
 package inbound
 
 import (
+	"context"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // ReservationListItem represents a reservation item for the list view.
 type ReservationListItem struct {
-	ID          string
-	RoomID      string
-	CheckIn     string
-	CheckOut    string
-	Status      string
-	StatusClass string
-	TotalAmount string
-	CanCancel   bool
+	ID               string
+	ConfirmationCode string
+	RoomID           string
+	CheckIn          string
+	CheckOut         string
+	Status           string
+	StatusClass      string
+	TotalAmount      string
+	Role             string // share role for reservations shared with the guest, empty if owned
+	OwnerEmail       string
+	CanCancel        bool
 }
 
 // HttpViewReservationsResponse specifies the view data for the reservations list.
 type HttpViewReservationsResponse struct {
-	AppName      string
-	Title        string
-	SessionID    string
-	Reservations []ReservationListItem
+	AppName               string
+	Title                 string
+	SessionID             string
+	Filter                string // "", "mine", "shared" or "household"
+	Query                 string // confirmation number or room searched for; empty lists all
+	HasHousehold          bool
+	Loyalty               *LoyaltyWidget // set if guests earn loyalty points
+	Reservations          []ReservationListItem
+	SharedReservations    []ReservationListItem
+	HouseholdReservations []ReservationListItem
+}
+
+// LoyaltyWidget shows the loyalty point balance of the guest and what it takes off the next booking.
+type LoyaltyWidget struct {
+	Balance int
+	Value   string
+}
+
+// loyaltyWidget returns the balance of the guest, or nil if loyaltyService is nil or the balance cannot be read.
+// The value is shown in dollars, the currency rooms are offered in.
+func loyaltyWidget(ctx context.Context, loyaltyService *loyalty.Service, guestID reservation.GuestID, locale shared.Locale) *LoyaltyWidget {
+	if loyaltyService == nil {
+		return nil
+	}
+	account, err := loyaltyService.AccountOf(ctx, loyalty.GuestID(guestID))
+	if err != nil {
+		return nil
+	}
+	return &LoyaltyWidget{
+		Balance: account.Balance,
+		Value:   loyaltyService.Policy().ValueOf(account.Balance, "USD").FormatIn(locale),
+	}
 }
 
 // HttpViewReservations defines an HTTP handler function for rendering the reservations list.
-func HttpViewReservations(e *templating.Engine, reservationService *reservation.Service) http.HandlerFunc {
+// The loyalty point balance of the guest is shown if loyaltyService is not nil.
+func HttpViewReservations(e *templating.Engine, reservationService *reservation.Service, loyaltyService *loyalty.Service) http.HandlerFunc {
 	appName := os.Getenv("APP_NAME")
 	title := appName + " - Reservations"
 
@@ -39,46 +76,167 @@ func HttpViewReservations(e *templating.Engine, reservationService *reservation.
 
 		// Check authentication
 		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
-		email, _ := ctx.Value(web.ContextEmail).(string)
-		if sessionID == "" || email == "" {
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
 			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
 			return
 		}
 
-		// Get reservations for the current user (using email as guest ID)
-		guestID := reservation.GuestID(email)
-		reservations, err := reservationService.ListReservationsByGuest(ctx, guestID)
-		if err != nil {
-			// If repository doesn't exist yet, treat as empty list
-			reservations = []*reservation.Reservation{}
-		}
-
-		// Convert domain reservations to view items
-		items := make([]ReservationListItem, 0, len(reservations))
-		for _, res := range reservations {
-			items = append(items, ReservationListItem{
-				ID:          string(res.ID),
-				RoomID:      string(res.RoomID),
-				CheckIn:     res.DateRange.CheckIn.Format("2006-01-02"),
-				CheckOut:    res.DateRange.CheckOut.Format("2006-01-02"),
-				Status:      string(res.Status),
-				StatusClass: reservationStatusClass(res.Status),
-				TotalAmount: res.TotalAmount.FormatAmount(),
-				CanCancel:   res.CanBeCancelled(),
-			})
-		}
-
+		// The list can be filtered to one group: own, shared with me, or household members' reservations.
+		filter := r.URL.Query().Get("filter")
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		h := householdFromContext(ctx)
+		locale := requestLocale(r)
 		data := HttpViewReservationsResponse{
 			AppName:      appName,
 			Title:        title,
 			SessionID:    sessionID,
-			Reservations: items,
+			Filter:       filter,
+			Query:        query,
+			HasHousehold: h != nil,
+			Loyalty:      loyaltyWidget(ctx, loyaltyService, guestID, locale),
+		}
+
+		// Get reservations for the current user (owned by the OIDC subject)
+		if filter == "" || filter == "mine" {
+			reservations, err := reservationService.ListReservationsByGuest(ctx, guestID)
+			if err != nil {
+				// If repository doesn't exist yet, treat as empty list
+				reservations = []*reservation.Reservation{}
+			}
+			data.Reservations = searchReservationListItems(buildReservationListItems(ctx, reservations, guestID, locale), query)
+		}
+
+		// Reservations shared with the current user by their owners
+		if filter == "" || filter == "shared" {
+			sharedReservations, err := reservationService.ListReservationsSharedWith(ctx, guestID)
+			if err != nil {
+				sharedReservations = []*reservation.Reservation{}
+			}
+			data.SharedReservations = searchReservationListItems(buildReservationListItems(ctx, sharedReservations, guestID, locale), query)
+		}
+
+		// Reservations of the other members of the current user's household
+		if h != nil && (filter == "" || filter == "household") {
+			var memberIDs []reservation.GuestID
+			for _, id := range h.MemberIDs() {
+				if reservation.GuestID(id) != guestID {
+					memberIDs = append(memberIDs, reservation.GuestID(id))
+				}
+			}
+			householdReservations, err := reservationService.ListReservationsByGuests(ctx, memberIDs)
+			if err != nil {
+				householdReservations = []*reservation.Reservation{}
+			}
+			data.HouseholdReservations = searchReservationListItems(buildReservationListItems(ctx, householdReservations, guestID, locale), query)
 		}
 
 		HttpView(e, "reservations", data)(w, r)
 	}
 }
 
+// buildReservationListItems converts reservations to list items as seen by the guest.
+func buildReservationListItems(ctx context.Context, reservations []*reservation.Reservation, guestID reservation.GuestID, locale shared.Locale) []ReservationListItem {
+	items := make([]ReservationListItem, 0, len(reservations))
+	for _, res := range reservations {
+		items = append(items, buildReservationListItem(ctx, res, guestID, locale))
+	}
+	return items
+}
+
+// searchReservationListItems returns the items whose confirmation code contains the query, read like
+// the lookup reads codes, or whose room is the query. An empty query returns all items.
+func searchReservationListItems(items []ReservationListItem, query string) []ReservationListItem {
+	if query == "" {
+		return items
+	}
+	// Dashes are dropped, so a part of a number such as "00123" is found as well.
+	searchKey := func(code string) string {
+		return strings.ReplaceAll(reservation.NormalizeConfirmationCode(code), "-", "")
+	}
+	key := searchKey(query)
+	found := make([]ReservationListItem, 0, len(items))
+	for _, item := range items {
+		if strings.Contains(searchKey(item.ConfirmationCode), key) || strings.EqualFold(item.RoomID, query) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+// buildReservationListItem converts a reservation to a list item as seen by the guest.
+func buildReservationListItem(ctx context.Context, res *reservation.Reservation, guestID reservation.GuestID, locale shared.Locale) ReservationListItem {
+	item := ReservationListItem{
+		ID:               string(res.ID),
+		ConfirmationCode: res.ConfirmationCode(),
+		RoomID:           string(res.RoomID),
+		CheckIn:          res.DateRange.CheckIn.Format("2006-01-02"),
+		CheckOut:         res.DateRange.CheckOut.Format("2006-01-02"),
+		Status:           string(res.Status),
+		StatusClass:      reservationStatusClass(res.Status),
+		TotalAmount:      res.TotalAmount.FormatIn(locale),
+		OwnerEmail:       res.GuestEmail,
+		CanCancel:        res.CanBeCancelled() && (res.CanManage(guestID) || householdAllows(ctx, guestID, res.GuestID, true)),
+	}
+	if !res.IsOwnedBy(guestID) {
+		for _, g := range res.Shares {
+			if g.GuestID == guestID {
+				item.Role = string(g.Role)
+			}
+		}
+	}
+	return item
+}
+
+// currentGuest returns the guest account (OIDC subject) and email of the session.
+// Ownership is decided by the subject; the email is display data only.
+func currentGuest(ctx context.Context) (reservation.GuestID, string) {
+	subject, _ := ctx.Value(web.ContextSubject).(string)
+	email, _ := ctx.Value(web.ContextEmail).(string)
+	return reservation.GuestID(subject), email
+}
+
+// withGuestPrincipal returns ctx with the session guest as principal unless a principal is set,
+// so changes made on their behalf, e.g. in the status history of a reservation, are attributed to them.
+func withGuestPrincipal(ctx context.Context, guestID reservation.GuestID, email string) context.Context {
+	if _, ok := shared.PrincipalFromContext(ctx); ok {
+		return ctx
+	}
+	return shared.ContextWithPrincipal(ctx, shared.Principal{Type: shared.PrincipalGuest, Subject: string(guestID), Email: email})
+}
+
+// WithLegacyReservationClaim hands the reservations still owned by the guest's email address
+// (created before ownership switched to the OIDC subject) to the guest account.
+// It runs on the page the sign-in redirects to, once per guest and process, and only if the identity
+// provider verified the email, since anyone presenting an unverified email claim would get its reservations.
+// It must be wrapped by web.WithAuth.
+func WithLegacyReservationClaim(reservationService *reservation.Service, next http.HandlerFunc) http.HandlerFunc {
+	var claimed sync.Map // guest IDs whose legacy reservations were claimed
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		guestID, email := currentGuest(ctx)
+		verified, _ := ctx.Value(web.ContextVerified).(bool)
+		if _, done := claimed.Load(guestID); guestID != "" && email != "" && verified && !done {
+			if _, err := reservationService.ClaimLegacyReservations(withGuestPrincipal(ctx, guestID, email), guestID, email); err == nil {
+				claimed.Store(guestID, struct{}{})
+			}
+		}
+		next(w, r)
+	}
+}
+
+// canViewReservation checks if the guest owns the reservation, holds an accepted share grant,
+// or shares a household with the owner (see WithHousehold).
+func canViewReservation(ctx context.Context, res *reservation.Reservation, guestID reservation.GuestID) bool {
+	return res.CanView(guestID) || householdAllows(ctx, guestID, res.GuestID, false)
+}
+
+// canManageReservation checks if the guest owns the reservation, holds an accepted manage grant,
+// or is a household member with a role that may manage the owner's reservations.
+func canManageReservation(ctx context.Context, res *reservation.Reservation, guestID reservation.GuestID) bool {
+	return res.CanManage(guestID) || householdAllows(ctx, guestID, res.GuestID, true)
+}
+
 // reservationStatusClass returns the CSS class for a reservation status.
 func reservationStatusClass(status reservation.ReservationStatus) string {
 	switch status {
@@ -0,0 +1,50 @@
+package inbound
+
+import (
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CalendarRenderer renders reservations as iCalendar. outbound.ICalendar implements it.
+type CalendarRenderer interface {
+	Calendar(name string, locale shared.Locale, reservations []*reservation.Reservation) []byte
+}
+
+// HttpReservationsCalendar returns the upcoming and current stays of the guest, i.e. the confirmed and
+// active reservations, as iCalendar file for calendar apps. Pending, cancelled and past reservations
+// are left out, so importing the file again removes stays that no longer take place.
+func HttpReservationsCalendar(reservationService *reservation.Service, calendar CalendarRenderer) http.HandlerFunc {
+	name := os.Getenv("APP_NAME") + " Reservations"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		reservations, err := reservationService.ListReservationsByGuest(ctx, guestID)
+		if err != nil {
+			http.Error(w, "Failed to list reservations", http.StatusInternalServerError)
+			return
+		}
+		stays := slices.DeleteFunc(reservations, func(res *reservation.Reservation) bool {
+			return res.Status != reservation.StatusConfirmed && res.Status != reservation.StatusActive
+		})
+		slices.SortFunc(stays, func(a, b *reservation.Reservation) int {
+			return a.DateRange.CheckIn.Compare(b.DateRange.CheckIn)
+		})
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="reservations.ics"`)
+		w.Header().Set("Cache-Control", "private, no-store")
+		_, _ = w.Write(calendar.Calendar(name, requestLocale(r), stays))
+	}
+}
@@ -0,0 +1,61 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// HttpReservationsCalendar Tests
+// ============================================================================
+
+func Test_HttpReservationsCalendar_Should_Return_Confirmed_Stays_Of_The_Guest(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	createAPITestReservation(repo, "res-002", time.Now().AddDate(0, 0, 21))
+	createAPITestReservation(repo, "res-003", time.Now().AddDate(0, 0, 35))
+	for id, status := range map[reservation.ReservationID]reservation.ReservationStatus{
+		"res-001": reservation.StatusConfirmed,
+		"res-002": reservation.StatusCancelled,
+	} {
+		res := repo.get(id)
+		res.Status = status
+		repo.put(id, res)
+	}
+	handler := inbound.HttpReservationsCalendar(createReservationsTestService(repo), outbound.NewICalendar("Seaside Hotel", "http://localhost:8080/ui"))
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations/calendar.ics", nil), "session-123", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be iCalendar", rec.Header().Get("Content-Type"), "text/calendar; charset=utf-8")
+	assert.That(t, "confirmed stay must be included", strings.Contains(body, "UID:res-001@localhost"), true)
+	assert.That(t, "cancelled stay must be left out", strings.Contains(body, "UID:res-002@localhost"), false)
+	assert.That(t, "pending reservation must be left out", strings.Contains(body, "UID:res-003@localhost"), false)
+}
+
+func Test_HttpReservationsCalendar_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpReservationsCalendar(createReservationsTestService(newMockReservationRepository()), outbound.NewICalendar("Seaside Hotel", "http://localhost:8080/ui"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/reservations/calendar.ics", nil))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the login", rec.Header().Get("Location"), "/ui/login")
+}
@@ -52,13 +52,23 @@ func createTestReservation(id, guestEmail, roomID string, checkIn, checkOut time
 	return r
 }
 
+// authTestSubject is the subject of the guest signed in by addAuthContext.
+const authTestSubject = "user-subject-456"
+
+// createOwnedTestReservation creates a reservation of test@example.com owned by the guest signed in by addAuthContext.
+func createOwnedTestReservation(id, roomID string, checkIn, checkOut time.Time) *reservation.Reservation {
+	r := createTestReservation(id, "test@example.com", roomID, checkIn, checkOut)
+	r.GuestID = authTestSubject
+	return r
+}
+
 func addAuthContext(req *http.Request, sessionID, email string) *http.Request {
 	ctx := req.Context()
 	ctx = context.WithValue(ctx, web.ContextSessionID, sessionID)
 	ctx = context.WithValue(ctx, web.ContextEmail, email)
 	ctx = context.WithValue(ctx, web.ContextIssuer, "https://issuer.example.com")
 	ctx = context.WithValue(ctx, web.ContextName, "Test User")
-	ctx = context.WithValue(ctx, web.ContextSubject, "user-subject-456")
+	ctx = context.WithValue(ctx, web.ContextSubject, authTestSubject)
 	ctx = context.WithValue(ctx, web.ContextVerified, true)
 	return req.WithContext(ctx)
 }
@@ -78,7 +88,7 @@ func Test_HttpViewReservations_Without_Session_Should_Redirect_To_Login(t *testi
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	rec := httptest.NewRecorder()
 
@@ -102,7 +112,7 @@ func Test_HttpViewReservations_With_Empty_Session_Should_Redirect_To_Login(t *te
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "", "")
 	rec := httptest.NewRecorder()
@@ -125,7 +135,7 @@ func Test_HttpViewReservations_With_Valid_Session_Should_Return_200(t *testing.T
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -148,7 +158,7 @@ func Test_HttpViewReservations_With_Valid_Session_Should_Render_App_Name(t *test
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -173,7 +183,7 @@ func Test_HttpViewReservations_With_Valid_Session_Should_Render_Session_ID(t *te
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -201,10 +211,10 @@ func Test_HttpViewReservations_With_Reservations_Should_Render_Reservation_List(
 	// Create a test reservation
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	repo.put(shared.ReservationID("res-001"), *res)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -233,12 +243,12 @@ func Test_HttpViewReservations_Should_Only_Show_Current_User_Reservations(t *tes
 	// Create reservations for different users
 	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
 	checkOut := checkIn.AddDate(0, 0, 3)
-	res1 := createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkOut)
+	res1 := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
 	res2 := createTestReservation("res-002", "other@example.com", "room-102", checkIn, checkOut)
-	repo.reservations[shared.ReservationID("res-001")] = *res1
-	repo.reservations[shared.ReservationID("res-002")] = *res2
+	repo.put(shared.ReservationID("res-001"), *res1)
+	repo.put(shared.ReservationID("res-002"), *res2)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -253,6 +263,71 @@ func Test_HttpViewReservations_Should_Only_Show_Current_User_Reservations(t *tes
 	assert.That(t, "body must not contain other user's reservation", containsString(bodyStr, "res-002"), false)
 }
 
+func Test_HttpViewReservations_With_Query_Should_Only_Show_Matching_Confirmation_Numbers(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(reservationsTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createReservationsTestService(repo)
+
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	res1 := createOwnedTestReservation("res-001", "room-101", checkIn, checkOut)
+	res1.ConfirmationNumber = "BER-2025-00123"
+	res2 := createOwnedTestReservation("res-002", "room-102", checkIn, checkOut)
+	res2.ConfirmationNumber = "BER-2025-00124"
+	repo.put(shared.ReservationID("res-001"), *res1)
+	repo.put(shared.ReservationID("res-002"), *res2)
+
+	handler := inbound.HttpViewReservations(e, service, nil)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations?q=2025-00123", nil)
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must contain the matching number", containsString(bodyStr, "BER-2025-00123"), true)
+	assert.That(t, "body must not contain other numbers", containsString(bodyStr, "BER-2025-00124"), false)
+}
+
+func Test_HttpViewReservations_Should_Show_Reservations_Owned_By_Subject_After_Email_Change(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	e := templating.NewEngine(reservationsTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	repo := newMockReservationRepository()
+	service := createReservationsTestService(repo)
+
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	res := createTestReservation("res-001", "old@example.com", "room-101", checkIn, checkOut)
+	res.GuestID = reservation.GuestID("user-subject-456")
+	repo.put(shared.ReservationID("res-001"), *res)
+
+	handler := inbound.HttpViewReservations(e, service, nil)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
+	req = addAuthContext(req, "test-session-123", "new@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain reservation owned by subject", containsString(string(body), "res-001"), true)
+}
+
 func Test_HttpViewReservations_With_No_Reservations_Should_Return_200(t *testing.T) {
 	// Arrange
 	t.Setenv("APP_NAME", "TestApp")
@@ -264,7 +339,7 @@ func Test_HttpViewReservations_With_No_Reservations_Should_Return_200(t *testing
 	repo := newMockReservationRepository()
 	service := createReservationsTestService(repo)
 
-	handler := inbound.HttpViewReservations(e, service)
+	handler := inbound.HttpViewReservations(e, service, nil)
 	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
 	req = addAuthContext(req, "test-session-123", "test@example.com")
 	rec := httptest.NewRecorder()
@@ -276,6 +351,42 @@ func Test_HttpViewReservations_With_No_Reservations_Should_Return_200(t *testing
 	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
 }
 
+// ============================================================================
+// WithLegacyReservationClaim Tests
+// ============================================================================
+
+// serveLegacyClaim serves a request of the guest of addAuthContext through the claim middleware
+// with a reservation still owned by test@example.com and returns its owner afterwards.
+func serveLegacyClaim(t *testing.T, verified bool) reservation.GuestID {
+	t.Helper()
+	repo := newMockReservationRepository()
+	checkIn := time.Now().AddDate(0, 0, 7)
+	repo.put("res-001", *createTestReservation("res-001", "test@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 3)))
+	handler := inbound.WithLegacyReservationClaim(createDetailTestService(repo), func(w http.ResponseWriter, r *http.Request) {})
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/", nil), "test-session-123", "test@example.com")
+	req = req.WithContext(context.WithValue(req.Context(), web.ContextVerified, verified))
+
+	handler(httptest.NewRecorder(), req)
+
+	return repo.get("res-001").GuestID
+}
+
+func Test_WithLegacyReservationClaim_With_Verified_Email_Should_Claim_Reservations(t *testing.T) {
+	// Act
+	owner := serveLegacyClaim(t, true)
+
+	// Assert
+	assert.That(t, "reservation must be owned by the subject", owner, reservation.GuestID(authTestSubject))
+}
+
+func Test_WithLegacyReservationClaim_With_Unverified_Email_Should_Not_Claim_Reservations(t *testing.T) {
+	// Act
+	owner := serveLegacyClaim(t, false)
+
+	// Assert
+	assert.That(t, "reservation must stay owned by the email", owner, reservation.GuestID("test@example.com"))
+}
+
 // ============================================================================
 // ReservationStatusClass Tests
 // ============================================================================
@@ -0,0 +1,93 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HttpRoomCalendarDay is the availability of a room for the night starting on Date (YYYY-MM-DD).
+type HttpRoomCalendarDay struct {
+	Date      string `json:"date"`
+	Available bool   `json:"available"`
+}
+
+// HttpRoomCalendarResponse is the per-night availability of a room used by the reservation form.
+type HttpRoomCalendarResponse struct {
+	RoomID string                `json:"room_id"`
+	From   string                `json:"from"`
+	Days   []HttpRoomCalendarDay `json:"days"`
+}
+
+// HttpRoomCalendar returns the availability of the room given in the path as JSON,
+// so the reservation form can reject booked dates before submitting.
+// The span starts at the query parameter from (YYYY-MM-DD, default today) and covers
+// the query parameter days (default reservation.DefaultCalendarDays) nights.
+// The response carries a weak ETag of the room's occupancy, so unchanged calendars are answered with 304
+// before the calendar is built.
+func HttpRoomCalendar(reservationService *reservation.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if sessionID, _ := ctx.Value(web.ContextSessionID).(string); sessionID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		roomID := r.PathValue("id")
+		if _, ok := getRoomPrices()[roomID]; !ok {
+			http.Error(w, "room not found", http.StatusNotFound)
+			return
+		}
+		from := time.Now()
+		if value := r.URL.Query().Get("from"); value != "" {
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				http.Error(w, "invalid from date", http.StatusBadRequest)
+				return
+			}
+			from = parsed
+		}
+		days := reservation.DefaultCalendarDays
+		if value := r.URL.Query().Get("days"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "invalid days", http.StatusBadRequest)
+				return
+			}
+			days = parsed
+		}
+
+		version := func(r *http.Request) (string, error) {
+			return reservationService.RoomCalendarVersion(r.Context(), reservation.RoomID(roomID), from, days)
+		}
+		WithWeakETag(version, func(w http.ResponseWriter, r *http.Request) {
+			calendar, err := reservationService.GetRoomCalendar(r.Context(), reservation.RoomID(roomID), from, days)
+			if errors.Is(err, reservation.ErrInvalidCalendarSpan) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				http.Error(w, "failed to read calendar", http.StatusInternalServerError)
+				return
+			}
+			writeAPIJSON(w, http.StatusOK, buildRoomCalendarResponse(calendar))
+		})(w, r)
+	}
+}
+
+// buildRoomCalendarResponse converts a room calendar to its JSON representation.
+func buildRoomCalendarResponse(calendar *reservation.RoomCalendar) HttpRoomCalendarResponse {
+	data := HttpRoomCalendarResponse{
+		RoomID: string(calendar.RoomID),
+		From:   calendar.From.Format("2006-01-02"),
+		Days:   make([]HttpRoomCalendarDay, 0, len(calendar.Days)),
+	}
+	for _, day := range calendar.Days {
+		data.Days = append(data.Days, HttpRoomCalendarDay{Date: day.Date.Format("2006-01-02"), Available: day.Available})
+	}
+	return data
+}
@@ -0,0 +1,125 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createRoomCalendarTestHandler creates the handler with room-101 booked from June 2nd to 5th, 2030.
+func createRoomCalendarTestHandler() http.HandlerFunc {
+	repo := newMockReservationRepository()
+	checkIn := time.Date(2030, 6, 2, 0, 0, 0, 0, time.UTC)
+	res := createTestReservation("res-001", "owner@example.com", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	repo.put(shared.ReservationID("res-001"), *res)
+	return inbound.HttpRoomCalendar(createDetailTestService(repo).WithOccupancyVersions(outbound.NewRepositoryAvailabilityChecker(repo)))
+}
+
+func roomCalendarRequest(roomID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/rooms/"+roomID+"/calendar?"+query, nil)
+	req.SetPathValue("id", roomID)
+	return addGuestContext(req, "guest-subject", "guest@example.com")
+}
+
+// ============================================================================
+// HttpRoomCalendar Tests
+// ============================================================================
+
+func Test_HttpRoomCalendar_Should_Return_Availability_Per_Night(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, roomCalendarRequest("room-101", "from=2030-06-01&days=7"))
+
+	// Assert
+	var data inbound.HttpRoomCalendarResponse
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must be json", json.Unmarshal(rec.Body.Bytes(), &data), nil)
+	assert.That(t, "calendar must have 7 days", len(data.Days), 7)
+	assert.That(t, "night before check-in must be available", data.Days[0], inbound.HttpRoomCalendarDay{Date: "2030-06-01", Available: true})
+	assert.That(t, "night of check-in must be booked", data.Days[1], inbound.HttpRoomCalendarDay{Date: "2030-06-02", Available: false})
+	assert.That(t, "night of check-out must be available", data.Days[4].Available, true)
+	assert.That(t, "response must have a weak etag", rec.Header().Get("ETag") != "" && rec.Header().Get("ETag")[:2] == "W/", true)
+}
+
+func Test_HttpRoomCalendar_With_Matching_ETag_Should_Return_304(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	first := httptest.NewRecorder()
+	handler(first, roomCalendarRequest("room-101", "from=2030-06-01&days=7"))
+	req := roomCalendarRequest("room-101", "from=2030-06-01&days=7")
+	req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 304", rec.Code, http.StatusNotModified)
+	assert.That(t, "body must be empty", rec.Body.Len(), 0)
+}
+
+func Test_HttpRoomCalendar_Should_Change_ETag_With_Occupancy(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	booked := httptest.NewRecorder()
+	free := httptest.NewRecorder()
+
+	// Act
+	handler(booked, roomCalendarRequest("room-101", "from=2030-06-01&days=7"))
+	handler(free, roomCalendarRequest("room-102", "from=2030-06-01&days=7"))
+
+	// Assert
+	assert.That(t, "etags must differ", booked.Header().Get("ETag") != free.Header().Get("ETag"), true)
+}
+
+func Test_HttpRoomCalendar_With_Unknown_Room_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, roomCalendarRequest("room-999", ""))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpRoomCalendar_With_Invalid_Span_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, roomCalendarRequest("room-101", "days=1000"))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpRoomCalendar_Without_Session_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := createRoomCalendarTestHandler()
+	req := httptest.NewRequest(http.MethodGet, "/ui/rooms/room-101/calendar", nil)
+	req.SetPathValue("id", "room-101")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
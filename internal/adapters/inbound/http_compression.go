@@ -0,0 +1,265 @@
+package inbound
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// This file contains the negotiated response compression middleware.
+// Responses are buffered until they reach a minimum size, so small responses
+// (redirects, HTMX fragments, errors) are sent uncompressed without overhead.
+// Only textual content types are compressed; Server-Sent Events are never
+// compressed because they rely on immediate flushing.
+// efficiency.WithCompression of cloud-native-utils is not used: it gzips every
+// response, including images, event streams and empty bodies, so the server has
+// this one compression path for the views, the APIs and the static assets.
+
+// compressionMinSize is the minimum response size in bytes before compression is applied.
+const compressionMinSize = 1024
+
+// compressionEncoder creates a compressing writer for a content encoding.
+type compressionEncoder func(w io.Writer) io.WriteCloser
+
+// compressionEncoders lists the supported encodings in order of server preference.
+// Brotli is not part of the standard library; add it here once a dependency is accepted.
+var compressionEncoders = []struct {
+	name    string
+	encoder compressionEncoder
+}{
+	{name: "gzip", encoder: newPooledGzipWriter},
+	{name: "deflate", encoder: func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	}},
+}
+
+// gzipWriterPool reuses gzip writers; allocating one per response dominates the latency otherwise.
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// pooledGzipWriter returns its gzip writer to the pool on Close.
+type pooledGzipWriter struct {
+	*gzip.Writer
+}
+
+// newPooledGzipWriter takes a gzip writer from the pool and resets it to w.
+func newPooledGzipWriter(w io.Writer) io.WriteCloser {
+	gz, _ := gzipWriterPool.Get().(*gzip.Writer)
+	gz.Reset(w)
+	return &pooledGzipWriter{Writer: gz}
+}
+
+// Close flushes the gzip stream and returns the writer to the pool.
+func (p *pooledGzipWriter) Close() error {
+	err := p.Writer.Close()
+	gzipWriterPool.Put(p.Writer)
+	return err
+}
+
+// compressibleContentTypes lists the content type prefixes that are worth compressing.
+var compressibleContentTypes = []string{
+	"text/html",
+	"text/css",
+	"text/plain",
+	"text/csv",
+	"text/calendar",
+	"text/javascript",
+	"application/javascript",
+	"application/json",
+	"application/manifest+json",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// negotiateEncoding selects the preferred supported encoding from an Accept-Encoding header.
+// Encodings with a quality value of zero are treated as refused.
+func negotiateEncoding(header string) (string, compressionEncoder) {
+	accepted := make(map[string]float64)
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		accepted[strings.ToLower(name)] = quality
+	}
+
+	for _, candidate := range compressionEncoders {
+		quality, ok := accepted[candidate.name]
+		if !ok {
+			quality, ok = accepted["*"]
+		}
+		if ok && quality > 0 {
+			return candidate.name, candidate.encoder
+		}
+	}
+	return "", nil
+}
+
+// isCompressible returns true if the content type should be compressed.
+func isCompressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range compressibleContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionResponseWriter buffers the response until the compression decision can be made.
+type compressionResponseWriter struct {
+	http.ResponseWriter
+
+	encoding string
+	encoder  compressionEncoder
+	writer   io.WriteCloser
+	buf      []byte
+	status   int
+	decided  bool
+}
+
+// WriteHeader records the status code; headers are sent once the compression decision is made.
+func (c *compressionResponseWriter) WriteHeader(code int) {
+	if c.status != 0 {
+		return
+	}
+	c.status = code
+
+	// Responses without a body and event streams are passed through immediately.
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified ||
+		strings.HasPrefix(c.Header().Get("Content-Type"), "text/event-stream") {
+		_ = c.decide(false)
+	}
+}
+
+// Write buffers data until the minimum size is reached.
+func (c *compressionResponseWriter) Write(p []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.decided {
+		if c.writer != nil {
+			return c.writer.Write(p)
+		}
+		return c.ResponseWriter.Write(p)
+	}
+
+	c.buf = append(c.buf, p...)
+	if len(c.buf) >= compressionMinSize {
+		if err := c.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends buffered data immediately; used by streaming handlers.
+func (c *compressionResponseWriter) Flush() {
+	if !c.decided {
+		_ = c.decide(len(c.buf) >= compressionMinSize)
+	}
+	if f, ok := c.writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports protocol upgrades by delegating to the underlying writer.
+func (c *compressionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := c.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (c *compressionResponseWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// close finishes the response, flushing any buffered data.
+func (c *compressionResponseWriter) close() error {
+	if c.status == 0 {
+		// The handler did not write anything.
+		return nil
+	}
+	if !c.decided {
+		if err := c.decide(false); err != nil {
+			return err
+		}
+	}
+	if c.writer != nil {
+		return c.writer.Close()
+	}
+	return nil
+}
+
+// decide sends the headers and the buffered data, compressed if allowed and requested.
+func (c *compressionResponseWriter) decide(compress bool) error {
+	c.decided = true
+	h := c.Header()
+	h.Add("Vary", "Accept-Encoding")
+
+	// The content type would be sniffed by net/http on the first write; we need it earlier.
+	if h.Get("Content-Type") == "" && len(c.buf) > 0 {
+		h.Set("Content-Type", http.DetectContentType(c.buf))
+	}
+
+	if compress && h.Get("Content-Encoding") == "" && isCompressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", c.encoding)
+		h.Del("Content-Length")
+		c.ResponseWriter.WriteHeader(c.status)
+		c.writer = c.encoder(c.ResponseWriter)
+		_, err := c.writer.Write(c.buf)
+		c.buf = nil
+		return err
+	}
+
+	c.ResponseWriter.WriteHeader(c.status)
+	if len(c.buf) == 0 {
+		return nil
+	}
+	_, err := c.ResponseWriter.Write(c.buf)
+	c.buf = nil
+	return err
+}
+
+// WithCompression compresses responses using the encoding negotiated via Accept-Encoding.
+// HEAD and range requests, as well as Server-Sent Events, are passed through unchanged.
+func WithCompression(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead || r.Header.Get("Range") != "" ||
+			strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			next(w, r)
+			return
+		}
+
+		encoding, encoder := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoder == nil {
+			next(w, r)
+			return
+		}
+
+		cw := &compressionResponseWriter{
+			ResponseWriter: w,
+			encoding:       encoding,
+			encoder:        encoder,
+		}
+		defer func() { _ = cw.close() }()
+
+		next(cw, r)
+	}
+}
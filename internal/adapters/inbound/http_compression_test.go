@@ -0,0 +1,143 @@
+package inbound_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func largeHTMLHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte("<html><body>" + strings.Repeat("<p>hotel booking</p>", 200) + "</body></html>"))
+}
+
+// ============================================================================
+// WithCompression Tests
+// ============================================================================
+
+func Test_WithCompression_With_Gzip_Accepted_Should_Compress_Large_HTML(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(largeHTMLHandler)
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be gzip", rec.Header().Get("Content-Encoding"), "gzip")
+	gz, err := gzip.NewReader(rec.Body)
+	assert.That(t, "gzip reader error must be nil", err, nil)
+	body, _ := io.ReadAll(gz)
+	assert.That(t, "body must be decompressible", strings.Contains(string(body), "hotel booking"), true)
+}
+
+func Test_WithCompression_With_Deflate_Preferred_Should_Use_Deflate(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(largeHTMLHandler)
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0, deflate")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be deflate", rec.Header().Get("Content-Encoding"), "deflate")
+}
+
+func Test_WithCompression_Without_Accept_Encoding_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(largeHTMLHandler)
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body must be plain", strings.Contains(rec.Body.String(), "hotel booking"), true)
+}
+
+func Test_WithCompression_With_Small_Response_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<p>small</p>"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body must be plain", rec.Body.String(), "<p>small</p>")
+}
+
+func Test_WithCompression_With_Binary_Content_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(make([]byte, 4096))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/static/img/icon.png", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+	assert.That(t, "body length must match", rec.Body.Len(), 4096)
+}
+
+func Test_WithCompression_With_Event_Stream_Should_Not_Compress(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Repeat("data: ping\n\n", 200)))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "content encoding must be empty", rec.Header().Get("Content-Encoding"), "")
+}
+
+func Test_WithCompression_With_Redirect_Should_Keep_Status_And_Location(t *testing.T) {
+	// Arrange
+	handler := inbound.WithCompression(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be login", rec.Header().Get("Location"), "/ui/login")
+}
@@ -0,0 +1,62 @@
+package inbound
+
+import (
+	"html/template"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+)
+
+// ContentPageRenderer provides the rendered guest-facing content pages (FAQ, policies, directions).
+// outbound.ContentPages implements it.
+type ContentPageRenderer interface {
+	RenderPage(slug string) (title string, html string, err error)
+}
+
+// HttpViewContentPageResponse specifies the view data for a content page.
+type HttpViewContentPageResponse struct {
+	AppName   string
+	Content   template.HTML
+	PageTitle string
+	SessionID string
+	Slug      string
+	Title     string
+}
+
+// HttpViewContentPage defines an HTTP handler function for rendering a content page by its slug.
+// Content pages are public, so guests can read the FAQ before they sign in.
+func HttpViewContentPage(e *templating.Engine, pages ContentPageRenderer) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		slug := r.PathValue("slug")
+		pageTitle, content, err := pages.RenderPage(slug)
+		if err != nil {
+			http.Error(w, "Page not found", http.StatusNotFound)
+			return
+		}
+
+		// The session is optional; it only switches the navigation between login and logout.
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		email, _ := ctx.Value(web.ContextEmail).(string)
+		if email == "" {
+			sessionID = ""
+		}
+
+		data := HttpViewContentPageResponse{
+			AppName: appName,
+			// The HTML is rendered from markdown with raw HTML escaped, so it is safe to embed.
+			Content:   template.HTML(content),
+			PageTitle: pageTitle,
+			SessionID: sessionID,
+			Slug:      slug,
+			Title:     appName + " - " + pageTitle,
+		}
+
+		HttpView(e, "content_page", data)(w, r)
+	}
+}
@@ -0,0 +1,84 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestContentPages() *outbound.ContentPages {
+	return outbound.NewContentPages(fstest.MapFS{
+		"faq.md": {Data: []byte("# FAQ\n\nCheck-in is from **3 PM**.\n\n<script>x</script>")},
+	})
+}
+
+func contentPageRequest(slug string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/ui/pages/"+slug, nil)
+	req.SetPathValue("slug", slug)
+	return req
+}
+
+// ============================================================================
+// HttpViewContentPage Tests
+// ============================================================================
+
+func Test_HttpViewContentPage_Without_Session_Should_Render_Page(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewContentPage(e, createTestContentPages())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, contentPageRequest("faq"))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain title", strings.Contains(body, "<title>TestApp - FAQ</title>"), true)
+	assert.That(t, "body must contain rendered markdown", strings.Contains(body, "<strong>3 PM</strong>"), true)
+	assert.That(t, "body must escape raw html", strings.Contains(body, "<script>"), false)
+	assert.That(t, "body must contain sign in link", strings.Contains(body, "/ui/login"), true)
+}
+
+func Test_HttpViewContentPage_With_Session_Should_Render_Logout_Link(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewContentPage(e, createTestContentPages())
+	req := addAuthContext(contentPageRequest("faq"), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain logout link", strings.Contains(rec.Body.String(), "/auth/logout/test-session-123"), true)
+}
+
+func Test_HttpViewContentPage_With_Unknown_Slug_Should_Return_404(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewContentPage(e, createTestContentPages())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, contentPageRequest("missing"))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -9,11 +9,13 @@ import (
 
 // HttpViewErrorResponse specifies the view data for error pages.
 type HttpViewErrorResponse struct {
-	AppName      string
-	Title        string
-	ErrorTitle   string
-	ErrorMessage string
-	ErrorDetails string
+	AppName       string
+	Title         string
+	ErrorTitle    string
+	ErrorMessage  string
+	ErrorDetails  string
+	CorrelationID string // set by the error boundary of the views, quoted by guests to support
+	RetryURL      string // set by the error boundary for GET requests; escaped
 }
 
 // HttpViewError defines an HTTP handler function for rendering the error template.
@@ -0,0 +1,50 @@
+package inbound
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ETagVersionFunc returns the version of the resource behind a request.
+// An empty version disables ETag handling for that request.
+type ETagVersionFunc func(r *http.Request) (string, error)
+
+// WithWeakETag adds a weak ETag derived from the resource version and answers
+// matching If-None-Match requests with 304 Not Modified without calling next.
+// Weak ETags are used because the representation may differ in encoding (e.g. compression)
+// while the underlying data is semantically the same.
+func WithWeakETag(version ETagVersionFunc, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		v, err := version(r)
+		if err != nil || v == "" {
+			next(w, r)
+			return
+		}
+
+		etag := `W/"` + v + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "private, no-cache")
+
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// etagMatches implements the weak comparison of If-None-Match (RFC 9110, section 13.1.2).
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	opaque := strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package inbound_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// WithWeakETag Tests
+// ============================================================================
+
+func staticVersion(v string) inbound.ETagVersionFunc {
+	return func(r *http.Request) (string, error) { return v, nil }
+}
+
+func Test_WithWeakETag_Should_Set_Weak_ETag(t *testing.T) {
+	// Arrange
+	handler := inbound.WithWeakETag(staticVersion("v1"), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/calendar", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "etag must be weak", rec.Header().Get("ETag"), `W/"v1"`)
+}
+
+func Test_WithWeakETag_With_Matching_If_None_Match_Should_Return_304(t *testing.T) {
+	// Arrange
+	called := false
+	handler := inbound.WithWeakETag(staticVersion("v1"), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	req := httptest.NewRequest(http.MethodGet, "/calendar", nil)
+	req.Header.Set("If-None-Match", `"other", W/"v1"`)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 304", rec.Code, http.StatusNotModified)
+	assert.That(t, "next must not be called", called, false)
+}
+
+func Test_WithWeakETag_With_Stale_If_None_Match_Should_Call_Next(t *testing.T) {
+	// Arrange
+	handler := inbound.WithWeakETag(staticVersion("v2"), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/calendar", nil)
+	req.Header.Set("If-None-Match", `W/"v1"`)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+}
+
+func Test_WithWeakETag_With_Version_Error_Should_Skip_ETag(t *testing.T) {
+	// Arrange
+	version := func(r *http.Request) (string, error) { return "", errors.New("db down") }
+	handler := inbound.WithWeakETag(version, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/calendar", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "etag must be empty", rec.Header().Get("ETag"), "")
+}
+
+// ============================================================================
+// Static Asset ETag Tests
+// ============================================================================
+
+func Test_HttpStaticAssets_Should_Set_Strong_ETag(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+	handler := inbound.HttpStaticAssets(efs, fingerprints)
+	req := httptest.NewRequest(http.MethodGet, "/static/css/test.css", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "etag must be strong", rec.Header().Get("ETag"), `"`+fingerprints.Hash("/static/css/test.css")+`"`)
+}
+
+func Test_HttpStaticAssets_With_Matching_If_None_Match_Should_Return_304(t *testing.T) {
+	// Arrange
+	efs := getRouterTestFS(t)
+	fingerprints, _ := inbound.NewAssetFingerprints(efs)
+	handler := inbound.HttpStaticAssets(efs, fingerprints)
+	req := httptest.NewRequest(http.MethodGet, "/static/css/test.css", nil)
+	req.Header.Set("If-None-Match", `"`+fingerprints.Hash("/static/css/test.css")+`"`)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 304", rec.Code, http.StatusNotModified)
+}
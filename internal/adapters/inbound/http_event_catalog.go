@@ -0,0 +1,218 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/templating"
+)
+
+// EventCatalogEntry documents a published event topic.
+// The schema is a JSON Schema generated from the event type, the example is an encoded example event.
+type EventCatalogEntry struct {
+	Topic    string           `json:"topic"`
+	Producer string           `json:"producer"`
+	Schema   map[string]any   `json:"schema"`
+	Example  json.RawMessage  `json:"example"`
+	Fields   []EventFieldView `json:"-"`
+}
+
+// EventFieldView represents a field of an event payload for the view; nested fields are dotted.
+type EventFieldView struct {
+	Name     string
+	Type     string
+	Required bool
+}
+
+// EventCatalog is the registry of the published events, generated from example events
+// registered by the producing bounded contexts.
+type EventCatalog struct {
+	mu      sync.RWMutex
+	entries map[string]EventCatalogEntry
+}
+
+// NewEventCatalog creates an empty event catalog.
+func NewEventCatalog() *EventCatalog {
+	return &EventCatalog{entries: make(map[string]EventCatalogEntry)}
+}
+
+// Register adds the example events of the producing context, replacing entries with the same topic.
+func (c *EventCatalog) Register(producer string, examples ...event.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range examples {
+		example, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		t := reflect.TypeOf(e)
+		schema := jsonSchema(t)
+		schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+		schema["title"] = e.Topic()
+		c.entries[e.Topic()] = EventCatalogEntry{
+			Topic:    e.Topic(),
+			Producer: producer,
+			Schema:   schema,
+			Example:  example,
+			Fields:   schemaFields("", t),
+		}
+	}
+	return nil
+}
+
+// Entries returns all entries sorted by topic.
+func (c *EventCatalog) Entries() []EventCatalogEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]EventCatalogEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Topic < entries[j].Topic })
+	return entries
+}
+
+// HttpEventCatalogResponse specifies the JSON body of the event catalog.
+type HttpEventCatalogResponse struct {
+	Events []EventCatalogEntry `json:"events"`
+}
+
+// HttpEventCatalog returns the event catalog as JSON.
+func HttpEventCatalog(catalog *EventCatalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HttpEventCatalogResponse{Events: catalog.Entries()})
+	}
+}
+
+// EventCatalogEntryView represents an event topic for the view.
+type EventCatalogEntryView struct {
+	Topic    string
+	Producer string
+	Fields   []EventFieldView
+	Example  string
+}
+
+// HttpViewEventCatalogResponse specifies the view data for the event catalog page.
+type HttpViewEventCatalogResponse struct {
+	AppName string
+	Title   string
+	Events  []EventCatalogEntryView
+}
+
+// HttpViewEventCatalog defines an HTTP handler function for rendering the event catalog for humans.
+func HttpViewEventCatalog(e *templating.Engine, catalog *EventCatalog) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Event Catalog"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := HttpViewEventCatalogResponse{
+			AppName: appName,
+			Title:   title,
+		}
+		for _, entry := range catalog.Entries() {
+			data.Events = append(data.Events, EventCatalogEntryView{
+				Topic:    entry.Topic,
+				Producer: entry.Producer,
+				Fields:   entry.Fields,
+				Example:  indentJSON(string(entry.Example)),
+			})
+		}
+		HttpView(e, "event_catalog", data)(w, r)
+	}
+}
+
+// timeType is encoded as RFC 3339 string by encoding/json.
+var timeType = reflect.TypeFor[time.Time]()
+
+// jsonField is an exported struct field with its JSON name.
+type jsonField struct {
+	name     string
+	required bool
+	field    reflect.StructField
+}
+
+// jsonFields returns the fields of the struct as encoding/json encodes them.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name: name, required: !strings.Contains(opts, "omitempty"), field: f})
+	}
+	return fields
+}
+
+// jsonSchema returns the JSON Schema of the type.
+func jsonSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		for _, f := range jsonFields(t) {
+			properties[f.name] = jsonSchema(f.field.Type)
+			if f.required {
+				required = append(required, f.name)
+			}
+		}
+		return map[string]any{"type": "object", "properties": properties, "required": required}
+	default:
+		return map[string]any{}
+	}
+}
+
+// schemaFields flattens the fields of the struct type for the view, in declaration order.
+func schemaFields(prefix string, t reflect.Type) []EventFieldView {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var fields []EventFieldView
+	for _, f := range jsonFields(t) {
+		ft := f.field.Type
+		schema := jsonSchema(ft)
+		typ, _ := schema["type"].(string)
+		if format, ok := schema["format"].(string); ok {
+			typ += " (" + format + ")"
+		}
+		fields = append(fields, EventFieldView{Name: prefix + f.name, Type: typ, Required: f.required})
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			fields = append(fields, schemaFields(prefix+f.name+".", ft)...)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,100 @@
+package inbound_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestEventCatalog returns a catalog with the reservation and payment events.
+func createTestEventCatalog(t *testing.T) *inbound.EventCatalog {
+	t.Helper()
+	catalog := inbound.NewEventCatalog()
+	assert.That(t, "err must be nil", catalog.Register("reservation", reservation.ExampleEvents()...), nil)
+	assert.That(t, "err must be nil", catalog.Register("payment", payment.ExampleEvents()...), nil)
+	return catalog
+}
+
+// ============================================================================
+// EventCatalog Tests
+// ============================================================================
+
+func Test_EventCatalog_Register_Should_Generate_Schema(t *testing.T) {
+	// Arrange
+	catalog := createTestEventCatalog(t)
+
+	// Act
+	entries := catalog.Entries()
+
+	// Assert
+	assert.That(t, "catalog must contain all topics", len(entries), 12)
+	var created inbound.EventCatalogEntry
+	for _, entry := range entries {
+		if entry.Topic == reservation.EventTopicCreated {
+			created = entry
+		}
+	}
+	properties := created.Schema["properties"].(map[string]any)
+	assert.That(t, "check_in must be date-time", properties["check_in"], map[string]any{"type": "string", "format": "date-time"})
+	assert.That(t, "guest_tier must be optional", created.Schema["required"], []string{"reservation_id", "guest_id", "room_id", "check_in", "check_out", "total_amount"})
+	assert.That(t, "producer must be recorded", created.Producer, "reservation")
+}
+
+// ============================================================================
+// HttpEventCatalog Tests
+// ============================================================================
+
+func Test_HttpEventCatalog_Should_Return_Topics_With_Examples(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpEventCatalog(createTestEventCatalog(t))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/events/catalog", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var body struct {
+		Events []struct {
+			Topic   string         `json:"topic"`
+			Example map[string]any `json:"example"`
+		} `json:"events"`
+	}
+	err := json.NewDecoder(rec.Body).Decode(&body)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "events must be sorted by topic", body.Events[0].Topic, payment.EventTopicAdjusted)
+	assert.That(t, "example must be the encoded event", body.Events[0].Example["reason"], "Minibar")
+}
+
+// ============================================================================
+// HttpViewEventCatalog Tests
+// ============================================================================
+
+func Test_HttpViewEventCatalog_Should_Render_Fields(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewEventCatalog(e, createTestEventCatalog(t))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/events/catalog", nil))
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain topic", strings.Contains(body, "reservation.cancelled (reservation)"), true)
+	assert.That(t, "body must contain nested fields", strings.Contains(body, "total_amount.Amount: integer required"), true)
+}
@@ -0,0 +1,400 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Page sizes of the list fields of the GraphQL API.
+const (
+	graphQLDefaultPageSize = 100
+	graphQLMaxPageSize     = 1000
+)
+
+// ErrInvalidGraphQLArgument is returned if an argument of a GraphQL field is out of range or unknown.
+var ErrInvalidGraphQLArgument = errors.New("invalid argument")
+
+// graphQLRoom is a room of a room type in the GraphQL API.
+type graphQLRoom struct {
+	ID       reservation.RoomID
+	Type     reservation.RoomTypeID
+	BaseRate shared.Money
+}
+
+// HttpGraphQL answers GraphQL queries as POST with a JSON body {query, operationName, variables}
+// or as GET with the same query parameters. A GET without query returns the schema (SDL).
+// Requests that cannot be executed are answered with 400; failed fields are null and listed in errors.
+func HttpGraphQL(schema *GraphQLSchema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if guestID, _ := currentGuest(ctx); guestID == "" {
+			writeAPIError(w, http.StatusUnauthorized, APIError{Code: "unauthorized", Message: "Token without subject"})
+			return
+		}
+
+		var req GraphQLRequest
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			if query.Get("query") == "" {
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				_, _ = w.Write([]byte(schema.SDL()))
+				return
+			}
+			req.Query, req.OperationName = query.Get("query"), query.Get("operationName")
+			if vars := query.Get("variables"); vars != "" {
+				if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+					writeAPIJSON(w, http.StatusBadRequest, graphQLRequestError(fmt.Errorf("invalid variables: %w", err)))
+					return
+				}
+			}
+		default:
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes)).Decode(&req); err != nil {
+				writeAPIJSON(w, http.StatusBadRequest, graphQLRequestError(fmt.Errorf("invalid request body: %w", err)))
+				return
+			}
+		}
+
+		resp := schema.Execute(ctx, req)
+		status := http.StatusOK
+		if resp.Data == nil {
+			status = http.StatusBadRequest
+		}
+		writeAPIJSON(w, status, resp)
+	}
+}
+
+// NewBookingGraphQLSchema returns the GraphQL read API of reservations, payments and rooms.
+// Access follows the JSON API and the MCP tools: guests read their own, shared and household reservations,
+// staff and service accounts read all with the reservations:read scope. Some fields are authorized on their own:
+// payments need payments:read and the gateway transaction ID is for staff only, so a caller without
+// the permission gets null and a FORBIDDEN error for that field and the rest of the query.
+// paymentService and rates may be nil, which leaves out the payments and rooms.
+func NewBookingGraphQLSchema(reservationService *reservation.Service, paymentService *payment.Service, rates *reservation.Rates) *GraphQLSchema {
+	money := &GraphQLObject{
+		Name:        "Money",
+		Description: "An amount in the smallest currency unit, e.g. cents.",
+		Fields: []*GraphQLField{
+			graphQLValueField("amount", "Int!", "", func(m shared.Money) any { return int(m.Amount) }),
+			graphQLValueField("currency", "String!", "ISO 4217 code", func(m shared.Money) any { return m.Currency }),
+			graphQLValueField("decimal", "String!", "The amount in the currency unit, e.g. 149.00", func(m shared.Money) any { return m.Decimal() }),
+		},
+	}
+	guest := &GraphQLObject{
+		Name: "Guest",
+		Fields: []*GraphQLField{
+			graphQLValueField("name", "String!", "", func(g reservation.GuestInfo) any { return g.Name }),
+			graphQLValueField("email", "String!", "", func(g reservation.GuestInfo) any { return g.Email }),
+			graphQLValueField("phone", "String", "", func(g reservation.GuestInfo) any { return graphQLOptional(g.PhoneNumber) }),
+		},
+	}
+	res := &GraphQLObject{
+		Name: "Reservation",
+		Fields: []*GraphQLField{
+			graphQLValueField("id", "ID!", "", func(r *reservation.Reservation) any { return string(r.ID) }),
+			graphQLValueField("confirmationCode", "String!", "Confirmation number, or the code derived from the ID", func(r *reservation.Reservation) any { return r.ConfirmationCode() }),
+			graphQLValueField("status", "String!", "pending, confirmed, active, completed, cancelled or no_show", func(r *reservation.Reservation) any { return string(r.Status) }),
+			graphQLValueField("roomId", "String!", "", func(r *reservation.Reservation) any { return string(r.RoomID) }),
+			graphQLValueField("checkIn", "String!", "YYYY-MM-DD", func(r *reservation.Reservation) any { return r.DateRange.CheckIn.Format("2006-01-02") }),
+			graphQLValueField("checkOut", "String!", "YYYY-MM-DD", func(r *reservation.Reservation) any { return r.DateRange.CheckOut.Format("2006-01-02") }),
+			graphQLValueField("nights", "Int!", "", func(r *reservation.Reservation) any { return r.Nights() }),
+			graphQLObjectField("totalAmount", "Money!", "", money, func(r *reservation.Reservation) any { return r.TotalAmount }),
+			graphQLObjectField("guests", "[Guest!]!", "Primary guest first", guest, func(r *reservation.Reservation) any { return r.Guests }),
+			graphQLValueField("cancellationReason", "String", "", func(r *reservation.Reservation) any { return graphQLOptional(r.CancellationReason) }),
+			graphQLValueField("createdAt", "String!", "RFC 3339", func(r *reservation.Reservation) any { return r.CreatedAt.UTC().Format(time.RFC3339) }),
+			graphQLValueField("updatedAt", "String!", "RFC 3339", func(r *reservation.Reservation) any { return r.UpdatedAt.UTC().Format(time.RFC3339) }),
+		},
+	}
+	query := &GraphQLObject{
+		Name: "Query",
+		Fields: []*GraphQLField{
+			{
+				Name:        "reservations",
+				Type:        "[Reservation!]!",
+				Description: "Reservations by check-in, then ID. Guests always get their own; staff filter by guest or get all.",
+				Args: []GraphQLArgument{
+					{Name: "guestId", Type: "String"},
+					{Name: "guestEmail", Type: "String"},
+					{Name: "status", Type: "String"},
+					{Name: "from", Type: "String", Description: "First check-in date (YYYY-MM-DD), inclusive"},
+					{Name: "to", Type: "String", Description: "Last check-in date (YYYY-MM-DD), inclusive"},
+					{Name: "first", Type: "Int"},
+				},
+				Object:  res,
+				Resolve: resolveGraphQLReservations(reservationService),
+			},
+			{
+				Name:        "reservation",
+				Type:        "Reservation",
+				Description: "A reservation by ID or confirmation number",
+				Args:        []GraphQLArgument{{Name: "id", Type: "ID!"}},
+				Object:      res,
+				Resolve:     resolveGraphQLReservation(reservationService),
+			},
+		},
+	}
+
+	if paymentService != nil {
+		pay := &GraphQLObject{
+			Name: "Payment",
+			Fields: []*GraphQLField{
+				graphQLValueField("id", "ID!", "", func(p *payment.Payment) any { return string(p.ID) }),
+				graphQLValueField("reservationId", "ID!", "", func(p *payment.Payment) any { return string(p.ReservationID) }),
+				graphQLValueField("status", "String!", "pending, authorized, captured, failed or refunded", func(p *payment.Payment) any { return string(p.Status) }),
+				graphQLValueField("method", "String!", "", func(p *payment.Payment) any { return p.PaymentMethod }),
+				graphQLObjectField("amount", "Money!", "", money, func(p *payment.Payment) any { return p.Amount }),
+				{
+					Name:        "transactionId",
+					Type:        "String",
+					Description: "Reference of the payment gateway (staff only)",
+					Resolve: func(ctx context.Context, source any, _ map[string]any) (any, error) {
+						if !graphQLStaff(ctx) {
+							return nil, shared.ErrStaffOnly
+						}
+						return graphQLOptional(source.(*payment.Payment).TransactionID), nil
+					},
+				},
+				graphQLValueField("createdAt", "String!", "RFC 3339", func(p *payment.Payment) any { return p.CreatedAt.UTC().Format(time.RFC3339) }),
+			},
+		}
+		res.Fields = append(res.Fields, &GraphQLField{
+			Name:        "payments",
+			Type:        "[Payment!]",
+			Description: "Payments of the reservation, oldest first (requires payments:read)",
+			Object:      pay,
+			Resolve: func(ctx context.Context, source any, _ map[string]any) (any, error) {
+				if err := shared.RequireScope(ctx, payment.ScopeRead); err != nil {
+					return nil, err
+				}
+				return graphQLPayments(ctx, paymentService, source.(*reservation.Reservation).ID, "")
+			},
+		})
+		query.Fields = append(query.Fields, &GraphQLField{
+			Name:        "payments",
+			Type:        "[Payment!]!",
+			Description: "Payments, oldest first (staff only, requires payments:read)",
+			Args: []GraphQLArgument{
+				{Name: "reservationId", Type: "ID"},
+				{Name: "status", Type: "String"},
+				{Name: "first", Type: "Int"},
+			},
+			Object: pay,
+			Resolve: func(ctx context.Context, _ any, args map[string]any) (any, error) {
+				if !graphQLStaff(ctx) {
+					return nil, shared.ErrStaffOnly
+				}
+				if err := shared.RequireScope(ctx, payment.ScopeRead); err != nil {
+					return nil, err
+				}
+				first, err := graphQLPageSize(args)
+				if err != nil {
+					return nil, err
+				}
+				reservationID, _ := args["reservationId"].(string)
+				status, _ := args["status"].(string)
+				payments, err := graphQLPayments(ctx, paymentService, reservation.ReservationID(reservationID), payment.PaymentStatus(status))
+				if err != nil {
+					return nil, err
+				}
+				return payments[:min(first, len(payments))], nil
+			},
+		})
+	}
+
+	if rates != nil {
+		room := &GraphQLObject{
+			Name: "Room",
+			Fields: []*GraphQLField{
+				graphQLValueField("id", "ID!", "", func(r graphQLRoom) any { return string(r.ID) }),
+				graphQLValueField("type", "String!", "Room type, e.g. deluxe", func(r graphQLRoom) any { return string(r.Type) }),
+				graphQLObjectField("baseRate", "Money!", "Per night, before rules", money, func(r graphQLRoom) any { return r.BaseRate }),
+			},
+		}
+		query.Fields = append(query.Fields, &GraphQLField{
+			Name:        "rooms",
+			Type:        "[Room!]!",
+			Description: "The rooms of every room type",
+			Object:      room,
+			Resolve: func(ctx context.Context, _ any, _ map[string]any) (any, error) {
+				var rooms []graphQLRoom
+				for _, roomType := range rates.RoomTypes() {
+					for _, id := range roomType.RoomIDs {
+						rooms = append(rooms, graphQLRoom{ID: id, Type: roomType.ID, BaseRate: roomType.BaseRate})
+					}
+				}
+				return rooms, nil
+			},
+		})
+	}
+
+	return &GraphQLSchema{Query: query}
+}
+
+// resolveGraphQLReservations resolves the reservations query.
+func resolveGraphQLReservations(reservationService *reservation.Service) GraphQLResolver {
+	return func(ctx context.Context, _ any, args map[string]any) (any, error) {
+		guestID, _ := args["guestId"].(string)
+		guestEmail, _ := args["guestEmail"].(string)
+		status, _ := args["status"].(string)
+		from, _ := args["from"].(string)
+		to, _ := args["to"].(string)
+		for _, date := range []string{from, to} {
+			if _, err := time.Parse("2006-01-02", date); date != "" && err != nil {
+				return nil, err
+			}
+		}
+		first, err := graphQLPageSize(args)
+		if err != nil {
+			return nil, err
+		}
+
+		staff := graphQLStaff(ctx)
+		if staff {
+			if err := shared.RequireScope(ctx, reservation.ScopeRead); err != nil {
+				return nil, err
+			}
+		}
+
+		var reservations []*reservation.Reservation
+		switch {
+		case !staff:
+			// Guests always get their own reservations, like with list_reservations.
+			guest, _ := currentGuest(ctx)
+			reservations, err = reservationService.ListReservationsByGuest(ctx, guest)
+		case guestID != "":
+			reservations, err = reservationService.ListReservationsByGuest(ctx, reservation.GuestID(guestID))
+		case guestEmail != "":
+			reservations, err = reservationService.ListReservationsByGuestEmail(ctx, guestEmail)
+		default:
+			var all []reservation.Reservation
+			all, err = reservationService.ListReservations(ctx)
+			for i := range all {
+				reservations = append(reservations, &all[i])
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		matching := make([]*reservation.Reservation, 0, len(reservations))
+		for _, res := range reservations {
+			checkIn := res.DateRange.CheckIn.Format("2006-01-02")
+			if (status != "" && string(res.Status) != status) || (from != "" && checkIn < from) || (to != "" && checkIn > to) {
+				continue
+			}
+			matching = append(matching, res)
+		}
+		sort.Slice(matching, func(i, j int) bool {
+			a, b := matching[i], matching[j]
+			if !a.DateRange.CheckIn.Equal(b.DateRange.CheckIn) {
+				return a.DateRange.CheckIn.Before(b.DateRange.CheckIn)
+			}
+			return a.ID < b.ID
+		})
+		return matching[:min(first, len(matching))], nil
+	}
+}
+
+// resolveGraphQLReservation resolves the reservation query.
+func resolveGraphQLReservation(reservationService *reservation.Service) GraphQLResolver {
+	return func(ctx context.Context, _ any, args map[string]any) (any, error) {
+		raw, _ := args["id"].(string)
+		id, err := shared.ParseReservationID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if graphQLStaff(ctx) {
+			if err := shared.RequireScope(ctx, reservation.ScopeRead); err != nil {
+				return nil, err
+			}
+		}
+		res, err := reservationService.FindReservation(ctx, string(id))
+		if err != nil {
+			return nil, err
+		}
+		if guestID, _ := currentGuest(ctx); !graphQLStaff(ctx) && !canViewReservation(ctx, res, guestID) {
+			return nil, reservation.ErrNotReservationOwner
+		}
+		return res, nil
+	}
+}
+
+// graphQLPayments returns the payments of the reservation, or all if the ID is empty, with the status if given.
+// All payments are read once per request, so the payments of every reservation in a list cost one read.
+func graphQLPayments(ctx context.Context, paymentService *payment.Service, reservationID reservation.ReservationID, status payment.PaymentStatus) ([]*payment.Payment, error) {
+	all, err := graphQLCached(ctx, "payments", func() ([]payment.Payment, error) {
+		payments, err := paymentService.ListPayments(ctx)
+		sort.Slice(payments, func(i, j int) bool { return payments[i].CreatedAt.Before(payments[j].CreatedAt) })
+		return payments, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	payments := make([]*payment.Payment, 0)
+	for i := range all {
+		if (reservationID == "" || all[i].ReservationID == reservationID) && (status == "" || all[i].Status == status) {
+			payments = append(payments, &all[i])
+		}
+	}
+	return payments, nil
+}
+
+// graphQLStaff returns true if the caller is staff or a service account.
+// Callers without a principal are guests, because a token alone does not tell its realm.
+func graphQLStaff(ctx context.Context) bool {
+	p, ok := shared.PrincipalFromContext(ctx)
+	return ok && p.Type != shared.PrincipalGuest
+}
+
+// graphQLPageSize returns the first argument, or the default page size if it is not given.
+func graphQLPageSize(args map[string]any) (int, error) {
+	var first int
+	switch value := args["first"].(type) {
+	case nil:
+		return graphQLDefaultPageSize, nil
+	case int64: // literals
+		first = int(value)
+	case float64: // variables are decoded from JSON
+		first = int(value)
+	}
+	if first < 1 || first > graphQLMaxPageSize {
+		return 0, fmt.Errorf("%w: first must be 1 to %d", ErrInvalidGraphQLArgument, graphQLMaxPageSize)
+	}
+	return first, nil
+}
+
+// graphQLOptional returns nil for an empty string, which is null in the response.
+func graphQLOptional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// graphQLValueField returns a scalar field whose value is read from a source of type S.
+func graphQLValueField[S any](name, typ, description string, value func(S) any) *GraphQLField {
+	return &GraphQLField{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+		Resolve: func(_ context.Context, source any, _ map[string]any) (any, error) {
+			return value(source.(S)), nil
+		},
+	}
+}
+
+// graphQLObjectField returns a field of the object type whose value is read from a source of type S.
+func graphQLObjectField[S any](name, typ, description string, object *GraphQLObject, value func(S) any) *GraphQLField {
+	field := graphQLValueField(name, typ, description, value)
+	field.Object = object
+	return field
+}
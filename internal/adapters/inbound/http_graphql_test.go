@@ -0,0 +1,141 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createGraphQLTestHandler stores res-001 of the guest (see createAPITestReservation) with a captured payment
+// and res-002 of another guest, and returns the GraphQL handler.
+func createGraphQLTestHandler() http.HandlerFunc {
+	ctx := context.Background()
+	repo := newMockReservationRepository()
+	createAPITestReservation(repo, "res-001", time.Now().AddDate(0, 0, 7))
+	other := createTestReservation("res-002", "other@example.com", "room-201", time.Now().AddDate(0, 0, 3), time.Now().AddDate(0, 0, 5))
+	repo.put(other.ID, *other)
+	paymentService := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	_, _ = paymentService.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(29700, "USD"), "credit_card")
+	_ = paymentService.CapturePayment(ctx, "pay-001")
+	schema := inbound.NewBookingGraphQLSchema(createReservationsTestService(repo), paymentService, reservation.NewRates(reservation.DefaultRatePolicy()))
+	return inbound.HttpGraphQL(schema)
+}
+
+// postGraphQL sends the query as the authenticated guest, or as the principal if given.
+func postGraphQL(handler http.HandlerFunc, query string, principal *shared.Principal) (*httptest.ResponseRecorder, map[string]any) {
+	body, _ := json.Marshal(inbound.GraphQLRequest{Query: query})
+	req := addAuthContext(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))), "session-123", "guest@example.com")
+	if principal != nil {
+		req = req.WithContext(shared.ContextWithPrincipal(req.Context(), *principal))
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	var resp map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+// ============================================================================
+// HttpGraphQL Tests
+// ============================================================================
+
+func Test_HttpGraphQL_As_Guest_Should_Return_Own_Reservations_Only(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+
+	// Act
+	rec, resp := postGraphQL(handler, `{ reservations(guestId: "other@example.com") { id totalAmount { amount } payments { status } } }`, nil)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	data, _ := json.Marshal(resp["data"])
+	assert.That(t, "only the own reservation must be returned", string(data), `{"reservations":[{"id":"res-001","payments":[{"status":"captured"}],"totalAmount":{"amount":29700}}]}`)
+}
+
+func Test_HttpGraphQL_With_Literal_Page_Size_Should_Return_Reservations(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+
+	// Act
+	rec, resp := postGraphQL(handler, `{ reservations(first: 1) { id } }`, nil)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	data, _ := json.Marshal(resp["data"])
+	assert.That(t, "first page must be returned", string(data), `{"reservations":[{"id":"res-001"}]}`)
+}
+
+func Test_HttpGraphQL_As_Guest_Should_Deny_Transaction_ID(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+
+	// Act
+	_, resp := postGraphQL(handler, `{ reservation(id: "res-001") { payments { id transactionId } } }`, nil)
+
+	// Assert
+	data, _ := json.Marshal(resp["data"])
+	errs, _ := json.Marshal(resp["errors"])
+	assert.That(t, "transaction ID must be null", string(data), `{"reservation":{"payments":[{"id":"pay-001","transactionId":null}]}}`)
+	assert.That(t, "error must be forbidden", strings.Contains(string(errs), `"code":"FORBIDDEN"`), true)
+	assert.That(t, "error must name the field", strings.Contains(string(errs), `["reservation","payments",0,"transactionId"]`), true)
+}
+
+func Test_HttpGraphQL_As_Service_Account_Without_Payment_Scope_Should_Deny_Payments_Only(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+	principal := &shared.Principal{Type: shared.PrincipalService, Subject: "reporting", Scopes: []string{reservation.ScopeRead}}
+
+	// Act
+	_, resp := postGraphQL(handler, `{ reservations(status: "pending") { id payments { id } } }`, principal)
+
+	// Assert
+	data, _ := json.Marshal(resp["data"])
+	errs, _ := json.Marshal(resp["errors"])
+	assert.That(t, "all reservations must be returned by check-in", string(data), `{"reservations":[{"id":"res-002","payments":null},{"id":"res-001","payments":null}]}`)
+	assert.That(t, "errors must be forbidden", strings.Count(string(errs), `"code":"FORBIDDEN"`), 2)
+}
+
+func Test_HttpGraphQL_Get_Without_Query_Should_Return_Schema(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/graphql", nil), "session-123", "guest@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "schema must contain the queries", strings.Contains(body, "reservations(guestId: String, guestEmail: String, status: String, from: String, to: String, first: Int): [Reservation!]!"), true)
+	assert.That(t, "schema must contain the rooms", strings.Contains(body, "type Room {"), true)
+}
+
+func Test_HttpGraphQL_With_Invalid_Query_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := createGraphQLTestHandler()
+
+	// Act
+	rec, resp := postGraphQL(handler, `{ reservations { secret } }`, nil)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	assert.That(t, "data must be absent", resp["data"], nil)
+}
@@ -0,0 +1,236 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// HouseholdInvitationSender notifies an invited email about a household invitation.
+// outbound.MockNotificationService implements it.
+type HouseholdInvitationSender interface {
+	SendHouseholdInvitation(ctx context.Context, h *household.Household, email, link string) error
+}
+
+// HouseholdMemberView represents a household member for the view.
+type HouseholdMemberView struct {
+	GuestID string
+	Email   string
+	Role    string
+	IsSelf  bool
+}
+
+// HouseholdInvitationView represents a pending household invitation for the view.
+type HouseholdInvitationView struct {
+	HouseholdID   string
+	HouseholdName string
+	Email         string
+	Role          string
+}
+
+// HouseholdView represents the household of the current guest for the view.
+type HouseholdView struct {
+	ID          string
+	Name        string
+	Members     []HouseholdMemberView
+	Invitations []HouseholdInvitationView
+	IsAdmin     bool
+}
+
+// HttpViewHouseholdResponse specifies the view data for the household page.
+type HttpViewHouseholdResponse struct {
+	AppName     string
+	Title       string
+	SessionID   string
+	Household   *HouseholdView
+	Invitations []HouseholdInvitationView // invitations for the current guest
+}
+
+// householdKey is the context key for the household of the current guest.
+type householdKey struct{}
+
+// WithHousehold loads the household of the signed-in guest into the context,
+// so the reservation access checks can grant access to other members' reservations.
+// It must be wrapped by web.WithAuth.
+func WithHousehold(householdService *household.Service, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guestID, _ := currentGuest(r.Context())
+		if guestID != "" {
+			if h, err := householdService.HouseholdOf(r.Context(), household.GuestID(guestID)); err == nil && h != nil {
+				r = r.WithContext(context.WithValue(r.Context(), householdKey{}, h))
+			}
+		}
+		next(w, r)
+	}
+}
+
+// householdFromContext returns the household of the current guest, or nil.
+func householdFromContext(ctx context.Context) *household.Household {
+	h, _ := ctx.Value(householdKey{}).(*household.Household)
+	return h
+}
+
+// householdAllows checks if the household of the current guest grants access to the owner's reservations.
+func householdAllows(ctx context.Context, guestID, owner reservation.GuestID, manage bool) bool {
+	h := householdFromContext(ctx)
+	return h != nil && guestID != owner && h.CanAccess(household.GuestID(guestID), household.GuestID(owner), manage)
+}
+
+// HttpViewHousehold defines an HTTP handler function for rendering the household membership page.
+func HttpViewHousehold(e *templating.Engine, householdService *household.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Household"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, email := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		data := HttpViewHouseholdResponse{
+			AppName:   appName,
+			Title:     title,
+			SessionID: sessionID,
+		}
+
+		h, err := householdService.HouseholdOf(ctx, household.GuestID(guestID))
+		if err != nil {
+			http.Error(w, "Failed to load household", http.StatusInternalServerError)
+			return
+		}
+		if h != nil {
+			data.Household = buildHouseholdView(h, household.GuestID(guestID))
+		} else {
+			invited, _ := householdService.InvitationsFor(ctx, email)
+			for _, inv := range invited {
+				i, _ := inv.Invitation(email)
+				data.Invitations = append(data.Invitations, HouseholdInvitationView{
+					HouseholdID:   string(inv.ID),
+					HouseholdName: inv.Name,
+					Email:         i.Email,
+					Role:          string(i.Role),
+				})
+			}
+		}
+
+		HttpView(e, "household", data)(w, r)
+	}
+}
+
+// buildHouseholdView converts a household to the view as seen by the guest.
+func buildHouseholdView(h *household.Household, guestID household.GuestID) *HouseholdView {
+	view := &HouseholdView{
+		ID:      string(h.ID),
+		Name:    h.Name,
+		IsAdmin: h.IsAdmin(guestID),
+	}
+	for _, m := range h.Members {
+		view.Members = append(view.Members, HouseholdMemberView{
+			GuestID: string(m.GuestID),
+			Email:   m.Email,
+			Role:    string(m.Role),
+			IsSelf:  m.GuestID == guestID,
+		})
+	}
+	for _, i := range h.Invitations {
+		view.Invitations = append(view.Invitations, HouseholdInvitationView{
+			HouseholdID:   string(h.ID),
+			HouseholdName: h.Name,
+			Email:         i.Email,
+			Role:          string(i.Role),
+		})
+	}
+	return view
+}
+
+// HttpCreateHousehold handles the POST request to create a household with the current guest as admin.
+func HttpCreateHousehold(householdService *household.Service) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, email string) error {
+		_, err := householdService.CreateHousehold(r.Context(), household.HouseholdID(security.GenerateID()), r.FormValue("name"), guestID, email)
+		return err
+	})
+}
+
+// HttpInviteHouseholdMember handles the POST request to invite an email to the household (admins only).
+func HttpInviteHouseholdMember(householdService *household.Service, invitations HouseholdInvitationSender) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, _ string) error {
+		inviteeEmail := r.FormValue("email")
+		h, err := householdService.Invite(r.Context(), household.HouseholdID(r.FormValue("household_id")), guestID, inviteeEmail, household.MemberRole(r.FormValue("role")))
+		if err != nil {
+			return err
+		}
+		return invitations.SendHouseholdInvitation(r.Context(), h, inviteeEmail, absoluteURL(r, "/ui/household"))
+	})
+}
+
+// HttpAcceptHouseholdInvitation handles the POST request to accept an invitation for the current guest's email.
+func HttpAcceptHouseholdInvitation(householdService *household.Service) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, email string) error {
+		_, err := householdService.AcceptInvitation(r.Context(), household.HouseholdID(r.FormValue("household_id")), email, guestID)
+		return err
+	})
+}
+
+// HttpCancelHouseholdInvitation handles the POST request to withdraw a pending invitation (admins only).
+func HttpCancelHouseholdInvitation(householdService *household.Service) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, _ string) error {
+		_, err := householdService.CancelInvitation(r.Context(), household.HouseholdID(r.FormValue("household_id")), guestID, r.FormValue("email"))
+		return err
+	})
+}
+
+// HttpSetHouseholdMemberRole handles the POST request to change the role of a member (admins only).
+func HttpSetHouseholdMemberRole(householdService *household.Service) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, _ string) error {
+		_, err := householdService.SetMemberRole(r.Context(), household.HouseholdID(r.FormValue("household_id")), guestID, household.GuestID(r.FormValue("guest_id")), household.MemberRole(r.FormValue("role")))
+		return err
+	})
+}
+
+// HttpRemoveHouseholdMember handles the POST request to remove a member or leave the household.
+func HttpRemoveHouseholdMember(householdService *household.Service) http.HandlerFunc {
+	return householdAction(func(r *http.Request, guestID household.GuestID, _ string) error {
+		_, err := householdService.RemoveMember(r.Context(), household.HouseholdID(r.FormValue("household_id")), guestID, household.GuestID(r.FormValue("guest_id")))
+		return err
+	})
+}
+
+// householdAction wraps a household change of the signed-in guest and redirects back to the household page.
+// Permission errors are answered with 403, all other errors with 400.
+func householdAction(action func(r *http.Request, guestID household.GuestID, email string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, _ := r.Context().Value(web.ContextSessionID).(string)
+		guestID, email := currentGuest(r.Context())
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := action(r, household.GuestID(guestID), email); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, household.ErrAdminOnly) {
+				status = http.StatusForbidden
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", "/ui/household")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, "/ui/household", http.StatusSeeOther)
+	}
+}
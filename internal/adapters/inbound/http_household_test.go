@@ -0,0 +1,212 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockHouseholdInvitations struct {
+	email string
+	link  string
+}
+
+func (m *mockHouseholdInvitations) SendHouseholdInvitation(ctx context.Context, h *household.Household, email, link string) error {
+	m.email = email
+	m.link = link
+	return nil
+}
+
+func createTestHouseholdService() *household.Service {
+	return household.NewService(resource.NewInMemoryAccess[household.HouseholdID, household.Household]())
+}
+
+// createTestHousehold creates a household with owner-subject as admin and member-subject with the given role.
+func createTestHousehold(t *testing.T, svc *household.Service, memberRole household.MemberRole) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := svc.CreateHousehold(ctx, "hh-001", "Doe Family", "owner-subject", "owner@example.com"); err != nil {
+		t.Fatalf("failed to create household: %v", err)
+	}
+	if _, err := svc.Invite(ctx, "hh-001", "owner-subject", "member@example.com", memberRole); err != nil {
+		t.Fatalf("failed to invite member: %v", err)
+	}
+	if _, err := svc.AcceptInvitation(ctx, "hh-001", "member@example.com", "member-subject"); err != nil {
+		t.Fatalf("failed to accept invitation: %v", err)
+	}
+}
+
+func householdRequest(path, subject, email string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return addGuestContext(req, subject, email)
+}
+
+// ============================================================================
+// Household Management Tests
+// ============================================================================
+
+func Test_HttpCreateHousehold_Should_Make_Guest_Admin(t *testing.T) {
+	// Arrange
+	svc := createTestHouseholdService()
+	handler := inbound.HttpCreateHousehold(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, householdRequest("/ui/household", "owner-subject", "owner@example.com", url.Values{"name": {"Doe Family"}}))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	h, _ := svc.HouseholdOf(context.Background(), "owner-subject")
+	assert.That(t, "household must exist", h != nil, true)
+	assert.That(t, "guest must be admin", h.IsAdmin("owner-subject"), true)
+}
+
+func Test_HttpInviteHouseholdMember_By_Admin_Should_Send_Invitation(t *testing.T) {
+	// Arrange
+	svc := createTestHouseholdService()
+	_, _ = svc.CreateHousehold(context.Background(), "hh-001", "Doe Family", "owner-subject", "owner@example.com")
+	invitations := &mockHouseholdInvitations{}
+	handler := inbound.HttpInviteHouseholdMember(svc, invitations)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, householdRequest("/ui/household/invitations", "owner-subject", "owner@example.com",
+		url.Values{"household_id": {"hh-001"}, "email": {"kid@example.com"}, "role": {"viewer"}}))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "invitation must be sent to kid", invitations.email, "kid@example.com")
+	assert.That(t, "link must point to the household page", strings.HasSuffix(invitations.link, "/ui/household"), true)
+}
+
+func Test_HttpInviteHouseholdMember_By_Non_Admin_Should_Return_403(t *testing.T) {
+	// Arrange
+	svc := createTestHouseholdService()
+	createTestHousehold(t, svc, household.RoleManager)
+	handler := inbound.HttpInviteHouseholdMember(svc, &mockHouseholdInvitations{})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, householdRequest("/ui/household/invitations", "member-subject", "member@example.com",
+		url.Values{"household_id": {"hh-001"}, "email": {"kid@example.com"}, "role": {"viewer"}}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpAcceptHouseholdInvitation_Should_Add_Member(t *testing.T) {
+	// Arrange
+	svc := createTestHouseholdService()
+	_, _ = svc.CreateHousehold(context.Background(), "hh-001", "Doe Family", "owner-subject", "owner@example.com")
+	_, _ = svc.Invite(context.Background(), "hh-001", "owner-subject", "kid@example.com", household.RoleViewer)
+	handler := inbound.HttpAcceptHouseholdInvitation(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, householdRequest("/ui/household/invitations/accept", "kid-subject", "kid@example.com", url.Values{"household_id": {"hh-001"}}))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	h, _ := svc.HouseholdOf(context.Background(), "kid-subject")
+	assert.That(t, "guest must be a member", h != nil, true)
+}
+
+func Test_HttpViewHousehold_Should_Render_Members(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc := createTestHouseholdService()
+	createTestHousehold(t, svc, household.RoleViewer)
+	handler := inbound.HttpViewHousehold(e, svc)
+	req := addGuestContext(httptest.NewRequest(http.MethodGet, "/ui/household", nil), "owner-subject", "owner@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain household name", strings.Contains(rec.Body.String(), "Doe Family"), true)
+	assert.That(t, "body must contain member", strings.Contains(rec.Body.String(), "member@example.com - viewer"), true)
+}
+
+// ============================================================================
+// Household Reservation Access Tests
+// ============================================================================
+
+func Test_HttpViewReservationDetail_By_Household_Member_Should_Return_200(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createTestHouseholdService()
+	createTestHousehold(t, svc, household.RoleViewer)
+	handler := inbound.WithHousehold(svc, inbound.HttpViewReservationDetail(e, createDetailTestService(repo), nil))
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-001", nil)
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "member-subject", "member@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+}
+
+func Test_HttpCancelReservation_By_Household_Viewer_Should_Return_403(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createTestHouseholdService()
+	createTestHousehold(t, svc, household.RoleViewer)
+	handler := inbound.WithHousehold(svc, inbound.HttpCancelReservation(createDetailTestService(repo)))
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "member-subject", "member@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpCancelReservation_By_Household_Manager_Should_Cancel(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc := createTestHouseholdService()
+	createTestHousehold(t, svc, household.RoleManager)
+	handler := inbound.WithHousehold(svc, inbound.HttpCancelReservation(createDetailTestService(repo)))
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations/res-001/cancel", nil)
+	req.SetPathValue("id", "res-001")
+	req = addGuestContext(req, "member-subject", "member@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	res, _ := repo.Read(context.Background(), "res-001")
+	assert.That(t, "reservation must be cancelled", res.Status, reservation.StatusCancelled)
+}
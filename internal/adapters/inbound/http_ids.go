@@ -0,0 +1,19 @@
+package inbound
+
+import (
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// WithValidReservationID answers 400 Bad Request with the validation hint if the {id} path value
+// is not a well-formed reservation ID, so malformed links fail before any lookup.
+func WithValidReservationID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := shared.ParseReservationID(r.PathValue("id")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,34 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+func serveReservationID(target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ui/reservations/{id}", inbound.WithValidReservationID(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+// ============================================================================
+// WithValidReservationID Tests
+// ============================================================================
+
+func Test_WithValidReservationID_Should_Call_Next_For_Typed_And_Legacy_IDs(t *testing.T) {
+	assert.That(t, "typed id must pass", serveReservationID("/ui/reservations/res_01J9Z3M5Q8X7T6V4R2N0B1C3D5").Code, http.StatusOK)
+	assert.That(t, "legacy id must pass", serveReservationID("/ui/reservations/res-001").Code, http.StatusOK)
+}
+
+func Test_WithValidReservationID_With_Malformed_ID_Should_Return_400(t *testing.T) {
+	assert.That(t, "malformed ulid must be rejected", serveReservationID("/ui/reservations/res_01ABC").Code, http.StatusBadRequest)
+	assert.That(t, "special characters must be rejected", serveReservationID("/ui/reservations/res%20001").Code, http.StatusBadRequest)
+}
@@ -0,0 +1,258 @@
+package inbound
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file contains the diagnostic bundle ops download during incidents instead of
+// gathering the state of an instance by hand. Each part of the bundle is a section,
+// registered in main.go by the adapter that owns the state; a failing section is
+// recorded in the manifest, so the rest of the bundle still helps.
+
+// Limits of the diagnostic bundle.
+const (
+	diagnosticSectionTimeout = 10 * time.Second // per section, so a hanging database does not block the bundle
+	maxDiagnosticCPUProfile  = 30 * time.Second
+)
+
+// redacted replaces the values of secrets in the bundle.
+const redacted = "REDACTED"
+
+// DiagnosticSection is a file of the diagnostic bundle, e.g. metrics.prom.
+type DiagnosticSection struct {
+	Name  string // file name in the archive
+	Write func(ctx context.Context, w io.Writer) error
+}
+
+// DiagnosticJSON returns a section with the value read as indented JSON.
+func DiagnosticJSON(name string, read func(ctx context.Context) (any, error)) DiagnosticSection {
+	return DiagnosticSection{
+		Name: name,
+		Write: func(ctx context.Context, w io.Writer) error {
+			value, err := read(ctx)
+			if err != nil {
+				return err
+			}
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(value)
+		},
+	}
+}
+
+// Diagnostics is the registry of the sections of the diagnostic bundle.
+// The runtime, goroutine dump and heap profile are always included.
+type Diagnostics struct {
+	version  string
+	started  time.Time
+	mu       sync.RWMutex
+	sections []DiagnosticSection
+}
+
+// NewDiagnostics creates the diagnostics of the application version, with the runtime sections.
+func NewDiagnostics(version string) *Diagnostics {
+	d := &Diagnostics{version: version, started: time.Now()}
+	d.Register(DiagnosticJSON("runtime.json", func(ctx context.Context) (any, error) { return d.runtime(), nil }))
+	d.Register(DiagnosticSection{Name: "goroutines.txt", Write: func(ctx context.Context, w io.Writer) error {
+		return pprof.Lookup("goroutine").WriteTo(w, 2)
+	}})
+	d.Register(DiagnosticSection{Name: "heap.pprof", Write: func(ctx context.Context, w io.Writer) error {
+		return pprof.Lookup("heap").WriteTo(w, 0)
+	}})
+	return d
+}
+
+// Register adds a section; sections are written in the order of registration.
+func (d *Diagnostics) Register(section DiagnosticSection) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sections = append(d.sections, section)
+}
+
+// Sections returns the registered sections.
+func (d *Diagnostics) Sections() []DiagnosticSection {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]DiagnosticSection(nil), d.sections...)
+}
+
+// DiagnosticRuntime is the runtime.json section.
+type DiagnosticRuntime struct {
+	Version    string    `json:"version"`
+	GoVersion  string    `json:"go_version"`
+	StartedAt  time.Time `json:"started_at"`
+	Uptime     string    `json:"uptime"`
+	Goroutines int       `json:"goroutines"`
+	CPUs       int       `json:"cpus"`
+	HeapAlloc  uint64    `json:"heap_alloc_bytes"`
+	HeapSys    uint64    `json:"heap_sys_bytes"`
+	NumGC      uint32    `json:"num_gc"`
+}
+
+// runtime describes the process.
+func (d *Diagnostics) runtime() DiagnosticRuntime {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return DiagnosticRuntime{
+		Version:    d.version,
+		GoVersion:  runtime.Version(),
+		StartedAt:  d.started.UTC(),
+		Uptime:     time.Since(d.started).Round(time.Second).String(),
+		Goroutines: runtime.NumGoroutine(),
+		CPUs:       runtime.NumCPU(),
+		HeapAlloc:  mem.HeapAlloc,
+		HeapSys:    mem.HeapSys,
+		NumGC:      mem.NumGC,
+	}
+}
+
+// DiagnosticManifest is the manifest.json of a bundle, written last.
+type DiagnosticManifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Version   string            `json:"version"`
+	Files     []string          `json:"files"`
+	Errors    map[string]string `json:"errors,omitempty"` // by section
+}
+
+// HttpInternalDiagnosticsBundle streams the diagnostic bundle as zip archive:
+// every section as a file and a manifest with the sections that failed.
+// The optional cpu query parameter (e.g. 10s, at most 30s) adds a CPU profile of that duration.
+func HttpInternalDiagnosticsBundle(diagnostics *Diagnostics, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sections := diagnostics.Sections()
+		if value := r.URL.Query().Get("cpu"); value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil || duration <= 0 || duration > maxDiagnosticCPUProfile {
+				http.Error(w, "Invalid cpu duration, use e.g. 10s (at most 30s)", http.StatusBadRequest)
+				return
+			}
+			sections = append(sections, cpuProfileSection(duration))
+		}
+
+		now := time.Now().UTC()
+		filename := "diagnostics-" + now.Format("20060102-150405") + ".zip"
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		archive := zip.NewWriter(w)
+		manifest := DiagnosticManifest{CreatedAt: now, Version: diagnostics.version, Errors: make(map[string]string)}
+		for _, section := range sections {
+			entry, err := archive.Create(section.Name)
+			if err != nil {
+				logger.Error("diagnostic bundle failed", "error", err, "section", section.Name)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), diagnosticSectionTimeout+cpuProfileDuration(section))
+			err = section.Write(ctx, entry)
+			cancel()
+			if err != nil {
+				// The file may be incomplete; the manifest tells why.
+				manifest.Errors[section.Name] = err.Error()
+			}
+			manifest.Files = append(manifest.Files, section.Name)
+		}
+		entry, err := archive.Create("manifest.json")
+		if err == nil {
+			encoder := json.NewEncoder(entry)
+			encoder.SetIndent("", "  ")
+			err = encoder.Encode(manifest)
+		}
+		if err == nil {
+			err = archive.Close()
+		}
+		if err != nil {
+			logger.Error("diagnostic bundle failed", "error", err)
+			return
+		}
+
+		// Bundles contain the state of the instance, so they are audited like other admin actions.
+		logger.Info("diagnostic bundle downloaded",
+			"audit", true,
+			"files", len(manifest.Files),
+			"failed", len(manifest.Errors),
+		)
+	}
+}
+
+// cpuProfileSectionName is the file of the optional CPU profile.
+const cpuProfileSectionName = "cpu.pprof"
+
+// cpuProfileSection records a CPU profile of the duration.
+// Only one CPU profile can run at a time, so it fails while the continuous profiler records one.
+func cpuProfileSection(duration time.Duration) DiagnosticSection {
+	return DiagnosticSection{Name: cpuProfileSectionName, Write: func(ctx context.Context, w io.Writer) error {
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}
+}
+
+// cpuProfileDuration returns the extra time the CPU profile section needs.
+func cpuProfileDuration(section DiagnosticSection) time.Duration {
+	if section.Name == cpuProfileSectionName {
+		return maxDiagnosticCPUProfile
+	}
+	return 0
+}
+
+// secretKeyParts are the parts of setting names whose values are secrets, e.g. OIDC_CLIENT_SECRET.
+var secretKeyParts = []string{"SECRET", "TOKEN", "PASSWORD", "KEY", "DSN", "CREDENTIALS", "HEADERS", "AUTH"}
+
+// keywordPassword matches passwords in keyword/value connection strings, e.g. "password=secret".
+var keywordPassword = regexp.MustCompile(`(?i)(password=)\S+`)
+
+// RedactSettings returns the settings with the values of secrets replaced, for the diagnostic bundle:
+// settings whose name has a secret part (SECRET, TOKEN, PASSWORD, KEY, ...) are redacted completely,
+// passwords in URLs and connection strings of other settings are redacted in place.
+func RedactSettings(settings map[string]string) map[string]string {
+	safe := make(map[string]string, len(settings))
+	for key, value := range settings {
+		safe[key] = redactSetting(key, value)
+	}
+	return safe
+}
+
+// redactSetting returns the value of the setting without secrets.
+func redactSetting(key, value string) string {
+	if value == "" {
+		return value
+	}
+	for part := range strings.SplitSeq(strings.ToUpper(key), "_") {
+		for _, secret := range secretKeyParts {
+			if part == secret || part == secret+"S" {
+				return redacted
+			}
+		}
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+			value = u.String()
+		}
+	}
+	return keywordPassword.ReplaceAllString(value, "${1}"+redacted)
+}
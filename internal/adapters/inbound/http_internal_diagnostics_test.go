@@ -0,0 +1,135 @@
+package inbound_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+func createDiagnosticsTestMux(t *testing.T, diagnostics *inbound.Diagnostics) http.Handler {
+	t.Helper()
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		Diagnostics:        diagnostics,
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+}
+
+// readTestBundle returns the files of a zip archive by name.
+func readTestBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("bundle is no zip archive: %v", err)
+	}
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		rc, _ := file.Open()
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+// ============================================================================
+// /internal/diagnostics/bundle Tests
+// ============================================================================
+
+func Test_HttpInternalDiagnosticsBundle_Should_Return_Sections_And_Manifest(t *testing.T) {
+	// Arrange
+	diagnostics := inbound.NewDiagnostics("1.2.3")
+	diagnostics.Register(inbound.DiagnosticJSON("queues.json", func(ctx context.Context) (any, error) {
+		return map[string]int{"email": 3}, nil
+	}))
+	diagnostics.Register(inbound.DiagnosticJSON("health.json", func(ctx context.Context) (any, error) {
+		return nil, errors.New("database down")
+	}))
+	mux := createDiagnosticsTestMux(t, diagnostics)
+	req := httptest.NewRequest(http.MethodGet, "/internal/diagnostics/bundle", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be zip", rec.Header().Get("Content-Type"), "application/zip")
+	files := readTestBundle(t, rec.Body.Bytes())
+	var manifest inbound.DiagnosticManifest
+	assert.That(t, "manifest must be JSON", json.Unmarshal([]byte(files["manifest.json"]), &manifest), nil)
+	assert.That(t, "manifest must list the sections", manifest.Files, []string{"runtime.json", "goroutines.txt", "heap.pprof", "queues.json", "health.json"})
+	assert.That(t, "failed section must be recorded", manifest.Errors["health.json"], "database down")
+	assert.That(t, "section must be written", files["queues.json"], "{\n  \"email\": 3\n}\n")
+	assert.That(t, "goroutines must be dumped", len(files["goroutines.txt"]) > 0, true)
+}
+
+func Test_HttpInternalDiagnosticsBundle_Without_Admin_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	mux := createDiagnosticsTestMux(t, inbound.NewDiagnostics("1.2.3"))
+	req := httptest.NewRequest(http.MethodGet, "/internal/diagnostics/bundle", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_HttpInternalDiagnosticsBundle_With_Invalid_CPU_Duration_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createDiagnosticsTestMux(t, inbound.NewDiagnostics("1.2.3"))
+	req := httptest.NewRequest(http.MethodGet, "/internal/diagnostics/bundle?cpu=5m", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// RedactSettings Tests
+// ============================================================================
+
+func Test_RedactSettings_Should_Redact_Secrets(t *testing.T) {
+	// Arrange
+	settings := map[string]string{
+		"OIDC_CLIENT_SECRET":         "s3cret",
+		"ADMIN_TOKEN":                "t0ken",
+		"OTEL_EXPORTER_OTLP_HEADERS": "authorization=Basic abc",
+		"DATABASE_URL":               "postgres://app:pa55@db:5432/reservations",
+		"LEGACY_DB":                  "host=db user=app password=pa55",
+		"APP_ENV":                    "staging",
+		"MCP_QUOTA_LIMIT":            "",
+	}
+
+	// Act
+	safe := inbound.RedactSettings(settings)
+
+	// Assert
+	assert.That(t, "secret must be redacted", safe["OIDC_CLIENT_SECRET"], "REDACTED")
+	assert.That(t, "token must be redacted", safe["ADMIN_TOKEN"], "REDACTED")
+	assert.That(t, "headers must be redacted", safe["OTEL_EXPORTER_OTLP_HEADERS"], "REDACTED")
+	assert.That(t, "url password must be redacted", safe["DATABASE_URL"], "postgres://app:REDACTED@db:5432/reservations")
+	assert.That(t, "keyword password must be redacted", safe["LEGACY_DB"], "host=db user=app password=REDACTED")
+	assert.That(t, "other settings must be kept", safe["APP_ENV"], "staging")
+	assert.That(t, "empty settings must be kept", safe["MCP_QUOTA_LIMIT"], "")
+}
@@ -0,0 +1,14 @@
+package inbound
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HttpInternalRoutes returns the mounted routes with method, path, authentication and handler as JSON.
+func HttpInternalRoutes(routes *RouteRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(routes.Routes())
+	}
+}
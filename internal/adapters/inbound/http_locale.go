@@ -0,0 +1,41 @@
+package inbound
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// requestLocale returns the locale of the language the client prefers most (Accept-Language),
+// or shared.DefaultLocale without a preference.
+func requestLocale(r *http.Request) shared.Locale {
+	best, bestWeight := "", 0.0
+	for part := range strings.SplitSeq(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if tag != "" && tag != "*" && weight > bestWeight {
+			best, bestWeight = tag, weight
+		}
+	}
+	if best == "" {
+		return shared.DefaultLocale
+	}
+	return shared.ResolveLocale(best)
+}
+
+// WithLocale stores the locale negotiated from Accept-Language in the request context,
+// so domain code like the MCP tools can format amounts with shared.LocaleFromContext.
+func WithLocale(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(shared.ContextWithLocale(r.Context(), requestLocale(r))))
+	}
+}
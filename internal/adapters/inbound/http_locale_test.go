@@ -0,0 +1,42 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// localeOf returns the locale WithLocale stores for the Accept-Language header.
+func localeOf(acceptLanguage string) shared.Locale {
+	var got shared.Locale
+	handler := inbound.WithLocale(func(w http.ResponseWriter, r *http.Request) {
+		got = shared.LocaleFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	handler(httptest.NewRecorder(), req)
+	return got
+}
+
+// ============================================================================
+// WithLocale Tests
+// ============================================================================
+
+func Test_WithLocale_Should_Store_The_Preferred_Locale(t *testing.T) {
+	assert.That(t, "highest weight must win", localeOf("en;q=0.8, de-DE, fr;q=0.9"), shared.Locale("de-DE"))
+}
+
+func Test_WithLocale_Should_Resolve_Unsupported_Regions_By_Language(t *testing.T) {
+	assert.That(t, "de-AT must resolve to de-DE", localeOf("de-AT"), shared.Locale("de-DE"))
+}
+
+func Test_WithLocale_Without_Header_Should_Store_Default_Locale(t *testing.T) {
+	assert.That(t, "locale must be the default", localeOf(""), shared.DefaultLocale)
+	assert.That(t, "wildcard must be the default", localeOf("*"), shared.DefaultLocale)
+}
@@ -26,7 +26,6 @@ func HttpViewLogin(e *templating.Engine) http.HandlerFunc {
 		Title:   title,
 	}
 
-	return func(w http.ResponseWriter, r *http.Request) {
-		HttpView(e, "login", data)(w, r)
-	}
+	// The login page does not depend on the request, so we render it only once.
+	return HttpCachedView(e, "login", data)
 }
@@ -28,9 +28,12 @@ func HttpViewManifest(e *templating.Engine) http.HandlerFunc {
 		ShortName:   appName,
 	}
 
+	// The manifest does not depend on the request, so we render it only once.
+	view := HttpCachedView(e, "manifest", data)
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set the content type to application/manifest+json for PWA manifest.
 		w.Header().Set("Content-Type", "application/manifest+json")
-		HttpView(e, "manifest", data)(w, r)
+		view(w, r)
 	}
 }
@@ -0,0 +1,26 @@
+package inbound
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// MetricsExporter writes the metrics of the domain services in the Prometheus text format.
+// outbound.Metrics implements it.
+type MetricsExporter interface {
+	WritePrometheus(w io.Writer) error
+}
+
+// HttpMetrics serves the metrics for a Prometheus scrape.
+func HttpMetrics(metrics MetricsExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if err := metrics.WritePrometheus(&buf); err != nil {
+			http.Error(w, "failed to write metrics", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,54 @@
+package inbound_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+var _ inbound.MetricsExporter = (*outbound.Metrics)(nil)
+
+type failingMetricsExporter struct{}
+
+func (failingMetricsExporter) WritePrometheus(w io.Writer) error {
+	return errors.New("disk full")
+}
+
+// ============================================================================
+// HttpMetrics Tests
+// ============================================================================
+
+func Test_HttpMetrics_Should_Serve_Prometheus_Text_Format(t *testing.T) {
+	// Arrange
+	metrics := outbound.NewMetrics()
+	metrics.IncCounter("hotel_reservations_created_total")
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpMetrics(metrics)(rec, req)
+
+	// Assert
+	assert.That(t, "status must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "content type must be the text format", rec.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8")
+	assert.That(t, "body must contain the counter", strings.Contains(rec.Body.String(), "hotel_reservations_created_total 1\n"), true)
+}
+
+func Test_HttpMetrics_When_Export_Fails_Should_Return_500(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpMetrics(failingMetricsExporter{})(rec, req)
+
+	// Assert
+	assert.That(t, "status must be 500", rec.Code, http.StatusInternalServerError)
+}
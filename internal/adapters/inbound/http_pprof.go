@@ -0,0 +1,71 @@
+package inbound
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	httppprof "net/http/pprof"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+)
+
+// This file contains the profiling endpoints and the request ID middleware.
+// The pprof endpoints are only registered with the other admin endpoints,
+// and every request must present the admin token or the admin permission (see WithAdminAccess).
+// Request IDs are attached as pprof labels, so CPU profiles captured by the
+// continuous profiler can be filtered by request (go tool pprof -tagfocus).
+
+// requestIDHeader is the header used to propagate request IDs.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRequestID assigns a request ID (reusing a valid incoming X-Request-ID header),
+// returns it in the response and attaches it as a pprof label for the duration of the request.
+func WithRequestID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" || len(id) > 64 || strings.ContainsAny(id, " \t\r\n") {
+			id = security.GenerateID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+		labels := pprof.Labels("request_id", id, "route", r.Pattern)
+		pprof.Do(ctx, labels, func(ctx context.Context) {
+			next(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// WithAdminToken only allows requests carrying the admin token as Bearer token.
+// The comparison runs in constant time to avoid leaking the token through timing.
+func WithAdminToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// RoutePprof registers the pprof endpoints under /debug/pprof/, guarded by the admin middleware.
+func RoutePprof(routes *RouteRegistry, admin Middleware) {
+	routes.HandleFunc("GET /debug/pprof/", RouteAuthAdminToken, httppprof.Index, admin)
+	routes.HandleFunc("GET /debug/pprof/cmdline", RouteAuthAdminToken, httppprof.Cmdline, admin)
+	routes.HandleFunc("GET /debug/pprof/profile", RouteAuthAdminToken, httppprof.Profile, admin)
+	routes.HandleFunc("GET /debug/pprof/symbol", RouteAuthAdminToken, httppprof.Symbol, admin)
+	routes.HandleFunc("GET /debug/pprof/trace", RouteAuthAdminToken, httppprof.Trace, admin)
+}
@@ -0,0 +1,126 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// WithRequestID Tests
+// ============================================================================
+
+func Test_WithRequestID_Without_Header_Should_Generate_ID(t *testing.T) {
+	// Arrange
+	var seen string
+	handler := inbound.WithRequestID(func(w http.ResponseWriter, r *http.Request) {
+		seen = inbound.RequestIDFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "request id must not be empty", seen != "", true)
+	assert.That(t, "response header must match context", rec.Header().Get("X-Request-ID"), seen)
+}
+
+func Test_WithRequestID_With_Header_Should_Reuse_ID(t *testing.T) {
+	// Arrange
+	var seen string
+	handler := inbound.WithRequestID(func(w http.ResponseWriter, r *http.Request) {
+		seen = inbound.RequestIDFromContext(r.Context())
+	})
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "request id must be reused", seen, "req-123")
+}
+
+// ============================================================================
+// WithAdminToken Tests
+// ============================================================================
+
+func Test_WithAdminToken_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	handler := inbound.WithAdminToken("secret", func(w http.ResponseWriter, r *http.Request) {})
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_WithAdminToken_With_Valid_Token_Should_Call_Next(t *testing.T) {
+	// Arrange
+	handler := inbound.WithAdminToken("secret", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 418", rec.Code, http.StatusTeapot)
+}
+
+// ============================================================================
+// Route Pprof Tests
+// ============================================================================
+
+func Test_Route_Pprof_Without_AdminToken_Should_Return_404(t *testing.T) {
+	// Arrange
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_Route_Pprof_With_AdminToken_Should_Return_Index(t *testing.T) {
+	// Arrange
+	mux := inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must list profiles", containsString(rec.Body.String(), "heap"), true)
+}
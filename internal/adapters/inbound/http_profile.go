@@ -0,0 +1,175 @@
+package inbound
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// GuestWebhookView represents an endpoint of the guest's own automation for the view.
+// The secret is never shown again after registration.
+type GuestWebhookView struct {
+	ID           string
+	URL          string
+	Topics       string
+	Status       string
+	StatusClass  string
+	Disabled     bool
+	LastDelivery string // time and status code of the latest delivery, e.g. "2026-05-01 12:00:00 (No Content)"
+}
+
+// HttpViewProfileResponse specifies the view data for the profile page.
+type HttpViewProfileResponse struct {
+	AppName   string
+	Title     string
+	SessionID string
+	Email     string
+	Webhooks  []GuestWebhookView
+	Topics    []string
+}
+
+// HttpViewProfile defines an HTTP handler function for rendering the profile page of the guest
+// with the webhook endpoints of their own automations.
+func HttpViewProfile(e *templating.Engine, webhookService *webhook.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Profile"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, email := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		endpoints, err := webhookService.GuestEndpoints(ctx, string(guestID))
+		if err != nil {
+			http.Error(w, "Failed to load webhooks", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpViewProfileResponse{
+			AppName:   appName,
+			Title:     title,
+			SessionID: sessionID,
+			Email:     email,
+			Topics:    webhook.GuestTopics,
+		}
+		for _, endpoint := range endpoints {
+			view := GuestWebhookView{
+				ID:       string(endpoint.ID),
+				URL:      endpoint.URL,
+				Topics:   strings.Join(endpoint.Topics, ", "),
+				Disabled: endpoint.Disabled,
+			}
+			switch {
+			case endpoint.Disabled:
+				view.Status, view.StatusClass = "disabled", "secondary"
+			case endpoint.VerifiedAt.IsZero():
+				view.Status, view.StatusClass = "unverified", "warning"
+			default:
+				view.Status, view.StatusClass = "active", "success"
+			}
+			if deliveries, err := webhookService.Deliveries(ctx, endpoint.ID, 1); err == nil && len(deliveries) > 0 {
+				view.LastDelivery = deliveries[0].AttemptedAt.Format("2006-01-02 15:04:05")
+				if deliveries[0].Error != "" {
+					view.LastDelivery += " (no response)"
+				} else {
+					view.LastDelivery += " (" + http.StatusText(deliveries[0].StatusCode) + ")"
+				}
+			}
+			data.Webhooks = append(data.Webhooks, view)
+		}
+
+		HttpView(e, "profile", data)(w, r)
+	}
+}
+
+// HttpRegisterGuestWebhook handles the POST request to register an endpoint of the guest's own automation
+// with the form values "url", "secret" and "topics" (repeated), and pings it right away.
+func HttpRegisterGuestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return guestWebhookAction(logger, "guest webhook registered", func(r *http.Request, guestID string) (webhook.EndpointID, error) {
+		_ = r.ParseForm()
+		endpoint, err := webhookService.RegisterGuestEndpoint(r.Context(), webhook.EndpointID(security.GenerateID()), guestID, r.FormValue("url"), r.FormValue("secret"), r.Form["topics"])
+		if err != nil {
+			return "", err
+		}
+		_, err = webhookService.PingGuestEndpoint(r.Context(), webhook.DeliveryID(security.GenerateID()), guestID, endpoint.ID)
+		return endpoint.ID, err
+	})
+}
+
+// HttpPingGuestWebhook handles the POST request to ping the endpoint given in the path again,
+// which verifies it if it answers with 2xx.
+func HttpPingGuestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return guestWebhookAction(logger, "guest webhook pinged", func(r *http.Request, guestID string) (webhook.EndpointID, error) {
+		id := webhook.EndpointID(r.PathValue("id"))
+		_, err := webhookService.PingGuestEndpoint(r.Context(), webhook.DeliveryID(security.GenerateID()), guestID, id)
+		return id, err
+	})
+}
+
+// HttpDisableGuestWebhook handles the POST request to stop sending events to the endpoint given in the path.
+func HttpDisableGuestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return guestWebhookAction(logger, "guest webhook disabled", func(r *http.Request, guestID string) (webhook.EndpointID, error) {
+		id := webhook.EndpointID(r.PathValue("id"))
+		_, err := webhookService.SetGuestEndpointDisabled(r.Context(), guestID, id, true)
+		return id, err
+	})
+}
+
+// HttpEnableGuestWebhook handles the POST request to send events to the disabled endpoint given in the path again.
+func HttpEnableGuestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return guestWebhookAction(logger, "guest webhook enabled", func(r *http.Request, guestID string) (webhook.EndpointID, error) {
+		id := webhook.EndpointID(r.PathValue("id"))
+		_, err := webhookService.SetGuestEndpointDisabled(r.Context(), guestID, id, false)
+		return id, err
+	})
+}
+
+// HttpDeleteGuestWebhook handles the POST request to delete the endpoint given in the path.
+func HttpDeleteGuestWebhook(webhookService *webhook.Service, logger *slog.Logger) http.HandlerFunc {
+	return guestWebhookAction(logger, "guest webhook deleted", func(r *http.Request, guestID string) (webhook.EndpointID, error) {
+		id := webhook.EndpointID(r.PathValue("id"))
+		return id, webhookService.DeleteGuestEndpoint(r.Context(), guestID, id)
+	})
+}
+
+// guestWebhookAction wraps a change of the signed-in guest's webhooks, logs it for the audit
+// and redirects back to the profile page. Errors are answered like on the webhook console.
+func guestWebhookAction(logger *slog.Logger, message string, action func(r *http.Request, guestID string) (webhook.EndpointID, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, _ := r.Context().Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(r.Context())
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		id, err := action(r, string(guestID))
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		logger.Info(message,
+			"audit", true,
+			"guest_id", guestID,
+			"endpoint_id", id,
+		)
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", "/ui/profile")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, "/ui/profile", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,132 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestGuestWebhookService returns a webhook service with guest endpoints answered with the status.
+func createTestGuestWebhookService(status int) *webhook.Service {
+	return webhook.NewService(
+		resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](),
+		resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Delivery](),
+		&mockWebhookSender{status: http.StatusOK},
+	).WithGuestEndpoints(resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](), &mockWebhookSender{status: status})
+}
+
+// postGuestForm sends the form values of the guest to the handler registered for the pattern.
+func postGuestForm(pattern, target string, handler http.HandlerFunc, subject string, form url.Values) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, handler)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, addGuestContext(req, subject, subject+"@example.com"))
+	return rec
+}
+
+var testProfileLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// ============================================================================
+// HttpViewProfile Tests
+// ============================================================================
+
+func Test_HttpViewProfile_Should_List_Own_Webhooks_Only(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	svc := createTestGuestWebhookService(http.StatusNoContent)
+	ctx := context.Background()
+	own, _ := svc.RegisterGuestEndpoint(ctx, "gep-1", "guest-1", "https://hooks.example.com/mine", "secret", nil)
+	_, _ = svc.PingGuestEndpoint(ctx, "d-1", "guest-1", own.ID)
+	_, _ = svc.RegisterGuestEndpoint(ctx, "gep-2", "guest-2", "https://hooks.example.com/theirs", "secret", nil)
+	req := addGuestContext(httptest.NewRequest(http.MethodGet, "/ui/profile", nil), "guest-1", "guest-1@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewProfile(e, svc)(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "own webhook must be active", strings.Contains(body, "https://hooks.example.com/mine active"), true)
+	assert.That(t, "last delivery must be shown", strings.Contains(body, "(No Content)"), true)
+	assert.That(t, "webhooks of other guests must not be shown", strings.Contains(body, "theirs"), false)
+}
+
+// ============================================================================
+// Guest Webhook Action Tests
+// ============================================================================
+
+func Test_HttpRegisterGuestWebhook_Should_Register_And_Ping(t *testing.T) {
+	// Arrange
+	svc := createTestGuestWebhookService(http.StatusOK)
+	form := url.Values{"url": {"https://hooks.example.com/zap"}, "secret": {"s3cret"}, "topics": {"reservation.confirmed"}}
+
+	// Act
+	rec := postGuestForm("POST /ui/profile/webhooks", "/ui/profile/webhooks", inbound.HttpRegisterGuestWebhook(svc, testProfileLogger), "guest-1", form)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	endpoints, _ := svc.GuestEndpoints(context.Background(), "guest-1")
+	assert.That(t, "endpoint must be registered", len(endpoints), 1)
+	assert.That(t, "endpoint must be verified by the ping", endpoints[0].Active(), true)
+	assert.That(t, "topics must be stored", endpoints[0].Topics, []string{"reservation.confirmed"})
+}
+
+func Test_HttpRegisterGuestWebhook_With_Internal_URL_Should_Return_400(t *testing.T) {
+	// Arrange
+	svc := createTestGuestWebhookService(http.StatusOK)
+	form := url.Values{"url": {"https://127.0.0.1:8080/admin"}, "secret": {"s3cret"}}
+
+	// Act
+	rec := postGuestForm("POST /ui/profile/webhooks", "/ui/profile/webhooks", inbound.HttpRegisterGuestWebhook(svc, testProfileLogger), "guest-1", form)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpDisableGuestWebhook_Of_Other_Guest_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createTestGuestWebhookService(http.StatusOK)
+	endpoint, _ := svc.RegisterGuestEndpoint(context.Background(), "gep-1", "guest-1", "https://hooks.example.com", "secret", nil)
+
+	// Act
+	rec := postGuestForm("POST /ui/profile/webhooks/{id}/disable", "/ui/profile/webhooks/gep-1/disable", inbound.HttpDisableGuestWebhook(svc, testProfileLogger), "guest-2", nil)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+	stored, _ := svc.GuestEndpoint(context.Background(), "guest-1", endpoint.ID)
+	assert.That(t, "endpoint must stay enabled", stored.Disabled, false)
+}
+
+func Test_HttpDisableGuestWebhook_Should_Disable_Endpoint(t *testing.T) {
+	// Arrange
+	svc := createTestGuestWebhookService(http.StatusOK)
+	endpoint, _ := svc.RegisterGuestEndpoint(context.Background(), "gep-1", "guest-1", "https://hooks.example.com", "secret", nil)
+
+	// Act
+	rec := postGuestForm("POST /ui/profile/webhooks/{id}/disable", "/ui/profile/webhooks/gep-1/disable", inbound.HttpDisableGuestWebhook(svc, testProfileLogger), "guest-1", nil)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	stored, _ := svc.GuestEndpoint(context.Background(), "guest-1", endpoint.ID)
+	assert.That(t, "endpoint must be disabled", stored.Disabled, true)
+}
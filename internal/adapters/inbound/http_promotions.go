@@ -0,0 +1,247 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the promo code check of the reservation form and the admin API of the promo codes.
+
+// HttpPromoCodeCheck is the answer of the promo code check. Codes that cannot be used are valid false
+// with the reason as message; with a stay, the discount and the total after it are included.
+type HttpPromoCodeCheck struct {
+	Code     string `json:"code"`
+	Valid    bool   `json:"valid"`
+	Message  string `json:"message"`            // what the code gives, e.g. "25% off", or why it cannot be used
+	Discount string `json:"discount,omitempty"` // formatted in the guest's locale
+	Total    string `json:"total,omitempty"`    // price of the stay after the tier discount and the code
+}
+
+// HttpPromotion represents a promo code in the admin API.
+type HttpPromotion struct {
+	Code            string     `json:"code"`
+	Kind            string     `json:"kind"`
+	Percent         int        `json:"percent,omitempty"`
+	Amount          int64      `json:"amount,omitempty"` // in the smallest unit of the currency
+	Currency        string     `json:"currency,omitempty"`
+	ValidFrom       *time.Time `json:"valid_from,omitempty"`
+	ValidUntil      *time.Time `json:"valid_until,omitempty"`
+	MaxUses         int        `json:"max_uses"`           // 0 is unlimited
+	MaxUsesPerGuest int        `json:"max_uses_per_guest"` // 0 is unlimited
+	Uses            int        `json:"uses"`
+	Active          bool       `json:"active"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// HttpAdminCreatePromotionRequest specifies the body of a new promo code, e.g.
+// {"code": "SUMMER25", "kind": "percent", "percent": 25, "valid_until": "2026-09-01T00:00:00Z", "max_uses": 500}
+// or {"code": "WELCOME", "kind": "fixed", "amount": 2000, "currency": "USD", "max_uses_per_guest": 1}.
+type HttpAdminCreatePromotionRequest struct {
+	Code            string     `json:"code"`
+	Kind            string     `json:"kind"`
+	Percent         int        `json:"percent"`
+	Amount          int64      `json:"amount"`
+	Currency        string     `json:"currency"` // default USD
+	ValidFrom       *time.Time `json:"valid_from"`
+	ValidUntil      *time.Time `json:"valid_until"`
+	MaxUses         int        `json:"max_uses"`
+	MaxUsesPerGuest int        `json:"max_uses_per_guest"`
+}
+
+// HttpCheckPromoCode checks the promo code given by the code parameter for the signed-in guest, so the
+// reservation form can show the discount before submitting. If room_id, check_in and check_out describe
+// a stay, the discount is computed on its price after the guest's tier discount, as the booking will be.
+// The booking checks the code again.
+func HttpCheckPromoCode(promotionService *promotion.Service, pricingService *pricing.Service, profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if sessionID, _ := ctx.Value(web.ContextSessionID).(string); sessionID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		guestID, _ := currentGuest(ctx)
+		code := promotion.NormalizeCode(r.URL.Query().Get("code"))
+		if code == "" {
+			http.Error(w, "code is required", http.StatusBadRequest)
+			return
+		}
+
+		locale := requestLocale(r)
+		var price shared.Money
+		checkIn, errIn := time.Parse("2006-01-02", r.URL.Query().Get("check_in"))
+		checkOut, errOut := time.Parse("2006-01-02", r.URL.Query().Get("check_out"))
+		roomID := r.URL.Query().Get("room_id")
+		if _, ok := getRoomPrices()[roomID]; ok && errIn == nil && errOut == nil && checkOut.After(checkIn) {
+			if total, err := quoteStay(ctx, pricingService, roomID, checkIn, checkOut); err == nil {
+				price = shared.NewMoney(total.Amount-guestPerks(ctx, profileService, guestID).DiscountOn(total).Amount, total.Currency)
+			}
+		}
+
+		check := HttpPromoCodeCheck{Code: string(code)}
+		promo, discount, err := promotionService.Validate(ctx, string(code), promotion.GuestID(guestID), price)
+		switch {
+		case err == nil:
+			check.Valid = true
+			check.Message = promotionLabel(promo, locale)
+			if price.Amount > 0 {
+				check.Discount = discount.FormatIn(locale)
+				check.Total = shared.NewMoney(price.Amount-discount.Amount, price.Currency).FormatIn(locale)
+			}
+		case isPromotionRejection(err):
+			check.Message = err.Error()
+		default:
+			http.Error(w, "promo code check failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(check)
+	}
+}
+
+// HttpAdminPromotions returns all promo codes with their uses as JSON, sorted by code.
+func HttpAdminPromotions(promotionService *promotion.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		usages, err := promotionService.List(r.Context())
+		if err != nil {
+			promotionError(w, err)
+			return
+		}
+		out := make([]HttpPromotion, 0, len(usages))
+		for _, u := range usages {
+			out = append(out, promotionJSON(&u.Promotion, u.Uses))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+}
+
+// HttpAdminCreatePromotion creates a promo code from the JSON body and answers 201 Created with it.
+func HttpAdminCreatePromotion(promotionService *promotion.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminCreatePromotionRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Currency == "" {
+			req.Currency = "USD"
+		}
+		terms := promotion.Terms{
+			Kind:            promotion.DiscountKind(req.Kind),
+			Percent:         req.Percent,
+			Amount:          shared.NewMoney(req.Amount, req.Currency),
+			MaxUses:         req.MaxUses,
+			MaxUsesPerGuest: req.MaxUsesPerGuest,
+		}
+		if req.ValidFrom != nil {
+			terms.ValidFrom = *req.ValidFrom
+		}
+		if req.ValidUntil != nil {
+			terms.ValidUntil = *req.ValidUntil
+		}
+		promo, err := promotionService.Create(r.Context(), req.Code, terms)
+		if err != nil {
+			promotionError(w, err)
+			return
+		}
+
+		logger.Info("promo code created",
+			"audit", true,
+			"code", promo.Code,
+			"kind", promo.Terms.Kind,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(promotionJSON(promo, 0))
+	}
+}
+
+// HttpAdminDeactivatePromotion withdraws the promo code given in the path; bookings made with it keep their discount.
+func HttpAdminDeactivatePromotion(promotionService *promotion.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		promo, err := promotionService.Deactivate(r.Context(), r.PathValue("code"))
+		if err != nil {
+			promotionError(w, err)
+			return
+		}
+
+		logger.Info("promo code deactivated",
+			"audit", true,
+			"code", promo.Code,
+		)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// promotionLabel describes what a promo code gives, e.g. "25% off" or "$20.00 off".
+func promotionLabel(promo *promotion.Promotion, locale shared.Locale) string {
+	if promo.Terms.Kind == promotion.KindFixed {
+		return promo.Terms.Amount.FormatIn(locale) + " off"
+	}
+	return fmt.Sprintf("%d%% off", promo.Terms.Percent)
+}
+
+// promotionJSON returns the promo code for the admin API.
+func promotionJSON(promo *promotion.Promotion, uses int) HttpPromotion {
+	out := HttpPromotion{
+		Code:            string(promo.Code),
+		Kind:            string(promo.Terms.Kind),
+		Percent:         promo.Terms.Percent,
+		MaxUses:         promo.Terms.MaxUses,
+		MaxUsesPerGuest: promo.Terms.MaxUsesPerGuest,
+		Uses:            uses,
+		Active:          promo.Active,
+		CreatedAt:       promo.CreatedAt.UTC(),
+	}
+	if promo.Terms.Kind == promotion.KindFixed {
+		out.Amount, out.Currency = promo.Terms.Amount.Amount, promo.Terms.Amount.Currency
+	}
+	if !promo.Terms.ValidFrom.IsZero() {
+		from := promo.Terms.ValidFrom.UTC()
+		out.ValidFrom = &from
+	}
+	if !promo.Terms.ValidUntil.IsZero() {
+		until := promo.Terms.ValidUntil.UTC()
+		out.ValidUntil = &until
+	}
+	return out
+}
+
+// isPromotionRejection reports whether the error says why the guest cannot use a code, rather than a failure.
+func isPromotionRejection(err error) bool {
+	for _, rejection := range []error{
+		promotion.ErrUnknownCode, promotion.ErrInactive, promotion.ErrNotYetValid, promotion.ErrExpired,
+		promotion.ErrUsageLimit, promotion.ErrGuestUsageLimit, promotion.ErrCurrencyMismatch,
+	} {
+		if errors.Is(err, rejection) {
+			return true
+		}
+	}
+	return false
+}
+
+// promotionError writes the HTTP error of a promo code admin action.
+func promotionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, promotion.ErrInvalidCode), errors.Is(err, promotion.ErrInvalidKind), errors.Is(err, promotion.ErrInvalidPercent),
+		errors.Is(err, promotion.ErrInvalidAmount), errors.Is(err, promotion.ErrInvalidWindow), errors.Is(err, promotion.ErrInvalidLimit):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, promotion.ErrCodeTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, promotion.ErrPromotionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "promotion action failed", http.StatusInternalServerError)
+	}
+}
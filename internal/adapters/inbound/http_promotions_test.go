@@ -0,0 +1,263 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestPromotionService returns a promotion service with the 25% code SUMMER25.
+func createTestPromotionService() *promotion.Service {
+	promotionService := promotion.NewService(
+		resource.NewInMemoryAccess[promotion.Code, promotion.Promotion](),
+		resource.NewInMemoryAccess[promotion.ReservationID, promotion.Redemption](),
+	)
+	_, _ = promotionService.Create(context.Background(), "SUMMER25", promotion.Terms{Kind: promotion.KindPercent, Percent: 25})
+	return promotionService
+}
+
+// promoBookingRequest posts a booking of room-101 for three nights next week with the promo code.
+func promoBookingRequest(code string) *http.Request {
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":   {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+		"promo_code":  {code},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return addAuthContext(req, "test-session-123", "test@example.com")
+}
+
+// promoCheckRequest checks the promo code for a stay in room-101 for three nights next week.
+func promoCheckRequest(code string) *http.Request {
+	query := url.Values{
+		"code":      {code},
+		"room_id":   {"room-101"},
+		"check_in":  {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out": {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/ui/promotions/check?"+query.Encode(), nil)
+	return addAuthContext(req, "test-session-123", "test@example.com")
+}
+
+// ============================================================================
+// HttpCheckPromoCode Tests
+// ============================================================================
+
+func Test_HttpCheckPromoCode_Valid_Code_Should_Return_Discount_And_Total(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpCheckPromoCode(createTestPromotionService(), nil, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoCheckRequest("summer25"))
+
+	// Assert
+	var check inbound.HttpPromoCodeCheck
+	_ = json.NewDecoder(rec.Body).Decode(&check)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "code must be normalized", check.Code, "SUMMER25")
+	assert.That(t, "code must be valid", check.Valid, true)
+	assert.That(t, "message must describe the discount", check.Message, "25% off")
+	assert.That(t, "discount must be a quarter of the stay", check.Discount, "$74.25")
+	assert.That(t, "total must be reduced", check.Total, "$222.75")
+}
+
+func Test_HttpCheckPromoCode_Unknown_Code_Should_Be_Invalid(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpCheckPromoCode(createTestPromotionService(), nil, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoCheckRequest("WINTER10"))
+
+	// Assert
+	var check inbound.HttpPromoCodeCheck
+	_ = json.NewDecoder(rec.Body).Decode(&check)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "code must be invalid", check.Valid, false)
+	assert.That(t, "message must give the reason", check.Message, promotion.ErrUnknownCode.Error())
+}
+
+func Test_HttpCheckPromoCode_Without_Code_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpCheckPromoCode(createTestPromotionService(), nil, nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoCheckRequest(" "))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Applying Promo Codes When Booking Tests
+// ============================================================================
+
+func Test_HttpCreateReservation_Promo_Code_Should_Reduce_Total(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	promotionService := createTestPromotionService()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PromotionService: promotionService, ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoBookingRequest("summer25"))
+
+	// Assert
+	usages, _ := promotionService.List(context.Background())
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "repository must have 1 reservation", repo.count(), 1)
+	for _, res := range repo.all() {
+		assert.That(t, "discount must be taken off the total", res.TotalAmount, shared.NewMoney(22275, "USD"))
+		assert.That(t, "promo code must be recorded", res.Perks.Promotion, reservation.AppliedPromotion{Code: "SUMMER25", Discount: shared.NewMoney(7425, "USD")})
+	}
+	assert.That(t, "use must be counted", usages[0].Uses, 1)
+}
+
+func Test_HttpCreateReservation_Unknown_Promo_Code_Should_Show_Error(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PromotionService: createTestPromotionService(), ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoBookingRequest("WINTER10"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "error must be shown", strings.Contains(rec.Body.String(), promotion.ErrUnknownCode.Error()), true)
+	assert.That(t, "code must be shown again", strings.Contains(rec.Body.String(), "Promo Code: WINTER10"), true)
+	assert.That(t, "no reservation must be created", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_Failing_Booking_Should_Release_Promo_Code(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "other-guest")
+	promotionService := createTestPromotionService()
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{PromotionService: promotionService, ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, promoBookingRequest("SUMMER25"))
+
+	// Assert
+	usages, _ := promotionService.List(context.Background())
+	assert.That(t, "room must be booked by the other guest", repo.count(), 1)
+	assert.That(t, "use must be given back", usages[0].Uses, 0)
+}
+
+// ============================================================================
+// Promo Code Admin API Tests
+// ============================================================================
+
+func Test_HttpAdminCreatePromotion_Should_Return_201(t *testing.T) {
+	// Arrange
+	promotionService := createTestPromotionService()
+	handler := inbound.HttpAdminCreatePromotion(promotionService, slog.Default())
+	body := `{"code": "welcome", "kind": "fixed", "amount": 2000, "max_uses_per_guest": 1}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/promotions", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	var created inbound.HttpPromotion
+	_ = json.NewDecoder(rec.Body).Decode(&created)
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "code must be normalized", created.Code, "WELCOME")
+	assert.That(t, "currency must default to USD", created.Currency, "USD")
+	assert.That(t, "code must be active", created.Active, true)
+}
+
+func Test_HttpAdminCreatePromotion_Invalid_Percent_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCreatePromotion(createTestPromotionService(), slog.Default())
+	req := httptest.NewRequest(http.MethodPost, "/admin/promotions", strings.NewReader(`{"code": "HALF", "kind": "percent", "percent": 150}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminCreatePromotion_Existing_Code_Should_Return_409(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminCreatePromotion(createTestPromotionService(), slog.Default())
+	req := httptest.NewRequest(http.MethodPost, "/admin/promotions", strings.NewReader(`{"code": "SUMMER25", "kind": "percent", "percent": 10}`))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+func Test_HttpAdminDeactivatePromotion_Unknown_Code_Should_Return_404(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminDeactivatePromotion(createTestPromotionService(), slog.Default())
+	req := httptest.NewRequest(http.MethodDelete, "/admin/promotions/WINTER10", nil)
+	req.SetPathValue("code", "WINTER10")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// SubscribePromotionEvents Tests
+// ============================================================================
+
+func Test_SubscribePromotionEvents_Cancelled_Reservation_Should_Release_Promo_Code(t *testing.T) {
+	// Arrange
+	promotionService := createTestPromotionService()
+	dispatcher := messaging.NewInternalDispatcher()
+	ctx := context.Background()
+	_, _ = promotionService.Redeem(ctx, "SUMMER25", "user-subject-456", "res-001", shared.NewMoney(29700, "USD"))
+	_ = inbound.SubscribePromotionEvents(ctx, dispatcher, promotionService)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCancelled, []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	usages, _ := promotionService.List(ctx)
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "use must be given back", usages[0].Uses, 0)
+}
@@ -0,0 +1,238 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the endpoints of the installed guest portal (PWA): the offline shell shown by the
+// service worker when neither the network nor the cache has a page, the management of the push
+// subscriptions of browsers and apps, and the engagement reports of the notifications. The subscriptions
+// are JSON, as the browser's PushSubscription.toJSON() returns them, so cross-site forms cannot send them
+// without a CORS preflight.
+
+// PushEngagements records that a device showed or the guest opened a push notification.
+// outbound.CommunicationHistory implements it.
+type PushEngagements interface {
+	RecordEngagement(ctx context.Context, id, engagement string) error
+}
+
+// PushTokens verifies the token of a push message that devices present with the engagement.
+// outbound.PushEngagementTokens implements it.
+type PushTokens interface {
+	Verify(id, token string) bool
+}
+
+// pushTokenHeader carries the token of the push message in engagement reports.
+const pushTokenHeader = "X-Push-Token"
+
+// HttpPushKeyResponse is the application server key browsers subscribe with.
+type HttpPushKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// HttpPushSubscriptionRequest is the body of a push subscription as returned by PushSubscription.toJSON(),
+// or the FCM registration token of an app.
+type HttpPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+	FCMToken string `json:"fcm_token,omitempty"`
+}
+
+// id returns the ID of the subscription of the request.
+func (req HttpPushSubscriptionRequest) id() profile.PushSubscriptionID {
+	if req.FCMToken != "" {
+		return profile.FCMSubscriptionIDOf(req.FCMToken)
+	}
+	return profile.PushSubscriptionIDOf(req.Endpoint)
+}
+
+// HttpPushSubscriptionResponse is a device of the guest that receives push notifications.
+type HttpPushSubscriptionResponse struct {
+	ID        string    `json:"id"`
+	Platform  string    `json:"platform"`
+	Device    string    `json:"device"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HttpViewOfflineResponse specifies the view data for the offline shell.
+type HttpViewOfflineResponse struct {
+	AppName string
+	Title   string
+}
+
+// HttpViewOffline defines an HTTP handler function for rendering the offline shell.
+// It is public and the same for everyone, so the service worker caches it when it is installed.
+func HttpViewOffline(e *templating.Engine) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	return HttpCachedView(e, "offline", HttpViewOfflineResponse{AppName: appName, Title: appName + " - Offline"})
+}
+
+// HttpPushKey returns the VAPID public key the browser passes to pushManager.subscribe,
+// or 404 if only apps can subscribe.
+func HttpPushKey(profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sessionID, _ := r.Context().Value(web.ContextSessionID).(string); sessionID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if profileService.PushKey() == "" {
+			http.Error(w, "Web Push is not configured", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(HttpPushKeyResponse{PublicKey: profileService.PushKey()})
+	}
+}
+
+// HttpPushSubscriptions returns the devices of the signed-in guest that receive push notifications.
+func HttpPushSubscriptions(profileService *profile.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guestID, _ := currentGuest(r.Context())
+		if sessionID, _ := r.Context().Value(web.ContextSessionID).(string); sessionID == "" || guestID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		subscriptions, err := profileService.PushSubscriptions(r.Context(), profile.GuestID(guestID))
+		if err != nil {
+			http.Error(w, "Failed to list push subscriptions", http.StatusInternalServerError)
+			return
+		}
+		resp := make([]HttpPushSubscriptionResponse, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			resp = append(resp, HttpPushSubscriptionResponse{
+				ID:        string(sub.ID),
+				Platform:  string(sub.Via()),
+				Device:    sub.UserAgent,
+				CreatedAt: sub.CreatedAt,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// HttpSubscribePush handles the POST request to register the push subscription of the signed-in guest's
+// browser, or with "fcm_token" the registration token of the guest's app.
+func HttpSubscribePush(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return pushSubscriptionAction(func(w http.ResponseWriter, r *http.Request, guestID profile.GuestID, req HttpPushSubscriptionRequest) {
+		var subscription *profile.PushSubscription
+		var err error
+		if req.FCMToken != "" {
+			subscription, err = profileService.SubscribeFCM(r.Context(), guestID, req.FCMToken, r.UserAgent())
+		} else {
+			subscription, err = profileService.SubscribePush(r.Context(), guestID, req.Endpoint, req.Keys.P256dh, req.Keys.Auth, r.UserAgent())
+		}
+		if err != nil {
+			writePushError(w, err)
+			return
+		}
+		logger.Info("push subscription registered", "guest_id", guestID, "subscription_id", subscription.ID, "platform", subscription.Platform)
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+// HttpUnsubscribePush handles the DELETE request to remove the push subscription of the endpoint in the body,
+// e.g. when the guest turns notifications off or signs out.
+func HttpUnsubscribePush(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return pushSubscriptionAction(func(w http.ResponseWriter, r *http.Request, guestID profile.GuestID, req HttpPushSubscriptionRequest) {
+		if err := profileService.UnsubscribePush(r.Context(), guestID, req.id()); err != nil {
+			writePushError(w, err)
+			return
+		}
+		logger.Info("push subscription removed", "guest_id", guestID, "subscription_id", req.id())
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HttpDeletePushSubscription handles the DELETE request to remove the device given in the path
+// from the signed-in guest's push subscriptions, e.g. a lost phone.
+func HttpDeletePushSubscription(profileService *profile.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guestID, _ := currentGuest(r.Context())
+		if sessionID, _ := r.Context().Value(web.ContextSessionID).(string); sessionID == "" || guestID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		id := profile.PushSubscriptionID(r.PathValue("id"))
+		if err := profileService.UnsubscribePush(r.Context(), profile.GuestID(guestID), id); err != nil {
+			writePushError(w, err)
+			return
+		}
+		logger.Info("push subscription removed", "guest_id", guestID, "subscription_id", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HttpPushEngagement handles the POST request of the service worker or app reporting that the push
+// notification given in the path was shown ("delivered") or opened ("clicked").
+// It needs no session, since the guest may have signed out; the token signed into the push message
+// (X-Push-Token header) is the credential, reports without a valid one are rejected with 403 Forbidden.
+func HttpPushEngagement(engagements PushEngagements, tokens PushTokens) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if !tokens.Verify(id, r.Header.Get(pushTokenHeader)) {
+			http.Error(w, "Invalid push token", http.StatusForbidden)
+			return
+		}
+		err := engagements.RecordEngagement(r.Context(), id, r.PathValue("engagement"))
+		switch {
+		case errors.Is(err, shared.ErrCommunicationNotFound):
+			http.Error(w, "push notification not found", http.StatusNotFound)
+		case errors.Is(err, shared.ErrInvalidEngagement):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case err != nil:
+			http.Error(w, "Failed to record engagement", http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+// pushSubscriptionAction checks the session and decodes the JSON body of a push subscription request.
+func pushSubscriptionAction(action func(w http.ResponseWriter, r *http.Request, guestID profile.GuestID, req HttpPushSubscriptionRequest)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, _ := r.Context().Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(r.Context())
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			http.Error(w, "expected application/json", http.StatusUnsupportedMediaType)
+			return
+		}
+		var req HttpPushSubscriptionRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8<<10)).Decode(&req); err != nil {
+			http.Error(w, "invalid push subscription", http.StatusBadRequest)
+			return
+		}
+		action(w, r, profile.GuestID(guestID), req)
+	}
+}
+
+// writePushError answers a failed push subscription change.
+func writePushError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, profile.ErrInvalidPushSubscription):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, profile.ErrPushSubscriptionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to update push subscription", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,307 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+const testVAPIDKey = "BOhaSvJka3f3g4gHiYBl36g4cpHitQN8W-N8qqynH5V3yNPHRD84B5jtUYuNPPxk7LnatQRsMGdw7OllSXSZXrI"
+
+func createTestPushService(t *testing.T) *profile.Service {
+	t.Helper()
+	svc, _ := createTestProfileService(t)
+	return svc.WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), testVAPIDKey)
+}
+
+func pushSubscriptionRequest(method, endpoint string) *http.Request {
+	body := `{"endpoint":"` + endpoint + `","expirationTime":null,"keys":{"p256dh":"` + testVAPIDKey + `","auth":"tBHItJI5svbpez7KI4CCXg"}}`
+	req := httptest.NewRequest(method, "/ui/push/subscriptions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// ============================================================================
+// HttpPushKey Tests
+// ============================================================================
+
+func Test_HttpPushKey_With_Session_Should_Return_Public_Key(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/ui/push/key", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPushKey(createTestPushService(t))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	var resp inbound.HttpPushKeyResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "public key must be returned", resp.PublicKey, testVAPIDKey)
+}
+
+// ============================================================================
+// HttpSubscribePush Tests
+// ============================================================================
+
+func Test_HttpSubscribePush_Should_Store_Subscription_Of_Guest(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	req := pushSubscriptionRequest(http.MethodPost, "https://push.example.com/send/abc123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubscribePush(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	subscriptions, _ := svc.PushSubscriptions(context.Background(), "guest-main")
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "subscription must be stored", len(subscriptions), 1)
+}
+
+func Test_HttpSubscribePush_With_Form_Body_Should_Return_415(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodPost, "/ui/push/subscriptions", strings.NewReader("endpoint=https://push.example.com/send/abc123"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubscribePush(createTestPushService(t), slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 415", rec.Code, http.StatusUnsupportedMediaType)
+}
+
+func Test_HttpSubscribePush_With_Insecure_Endpoint_Should_Return_400(t *testing.T) {
+	// Arrange
+	req := pushSubscriptionRequest(http.MethodPost, "http://push.example.com/send/abc123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubscribePush(createTestPushService(t), slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpSubscribePush_Without_Session_Should_Return_401(t *testing.T) {
+	// Arrange
+	req := pushSubscriptionRequest(http.MethodPost, "https://push.example.com/send/abc123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubscribePush(createTestPushService(t), slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+// ============================================================================
+// HttpUnsubscribePush Tests
+// ============================================================================
+
+func Test_HttpUnsubscribePush_Should_Remove_Subscription(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	_, _ = svc.SubscribePush(context.Background(), "guest-main", "https://push.example.com/send/abc123", testVAPIDKey, "tBHItJI5svbpez7KI4CCXg", "")
+	req := pushSubscriptionRequest(http.MethodDelete, "https://push.example.com/send/abc123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpUnsubscribePush(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	subscriptions, _ := svc.PushSubscriptions(context.Background(), "guest-main")
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "subscription must be removed", len(subscriptions), 0)
+}
+
+func Test_HttpUnsubscribePush_Of_Other_Guest_Should_Return_404(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	_, _ = svc.SubscribePush(context.Background(), "guest-dup", "https://push.example.com/send/abc123", testVAPIDKey, "tBHItJI5svbpez7KI4CCXg", "")
+	req := pushSubscriptionRequest(http.MethodDelete, "https://push.example.com/send/abc123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpUnsubscribePush(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+// ============================================================================
+// HttpViewOffline Tests
+// ============================================================================
+
+func Test_HttpViewOffline_Should_Link_To_Reservations(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "test-app")
+	e := templating.NewEngine(serviceWorkerTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	req := httptest.NewRequest(http.MethodGet, "/ui/offline", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewOffline(e)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must link to the reservations", strings.Contains(rec.Body.String(), "/ui/reservations"), true)
+}
+
+func Test_HttpSubscribePush_With_FCM_Token_Should_Store_App_Subscription(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	req := httptest.NewRequest(http.MethodPost, "/ui/push/subscriptions", strings.NewReader(`{"fcm_token":"fcm-token-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "HotelApp/2.1")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpSubscribePush(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	subscriptions, _ := svc.PushSubscriptions(context.Background(), "guest-main")
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "subscription must be stored", len(subscriptions), 1)
+	assert.That(t, "subscription must be delivered via FCM", subscriptions[0].Via(), profile.PushFCM)
+}
+
+// ============================================================================
+// HttpPushSubscriptions Tests
+// ============================================================================
+
+func Test_HttpPushSubscriptions_Should_List_Devices_Of_Guest(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	_, _ = svc.SubscribeFCM(context.Background(), "guest-main", "fcm-token-1", "HotelApp/2.1")
+	_, _ = svc.SubscribeFCM(context.Background(), "guest-dup", "fcm-token-2", "HotelApp/2.1")
+	req := httptest.NewRequest(http.MethodGet, "/ui/push/subscriptions", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPushSubscriptions(svc)(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	var resp []inbound.HttpPushSubscriptionResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "only the guest's device must be listed", len(resp), 1)
+	assert.That(t, "device must be the app", resp[0].Device, "HotelApp/2.1")
+	assert.That(t, "platform must be FCM", resp[0].Platform, "fcm")
+}
+
+// ============================================================================
+// HttpDeletePushSubscription Tests
+// ============================================================================
+
+func Test_HttpDeletePushSubscription_Should_Remove_Device(t *testing.T) {
+	// Arrange
+	svc := createTestPushService(t)
+	subscription, _ := svc.SubscribeFCM(context.Background(), "guest-main", "fcm-token-1", "HotelApp/2.1")
+	req := httptest.NewRequest(http.MethodDelete, "/ui/push/subscriptions/"+string(subscription.ID), nil)
+	req.SetPathValue("id", string(subscription.ID))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpDeletePushSubscription(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))(rec, addGuestContext(req, "guest-main", "john.doe@example.com"))
+
+	// Assert
+	subscriptions, _ := svc.PushSubscriptions(context.Background(), "guest-main")
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "subscription must be removed", len(subscriptions), 0)
+}
+
+// ============================================================================
+// HttpPushEngagement Tests
+// ============================================================================
+
+// mockPushEngagements records the engagements of the known push messages.
+type mockPushEngagements map[string]string
+
+func (m mockPushEngagements) RecordEngagement(ctx context.Context, id, engagement string) error {
+	if _, ok := m[id]; !ok {
+		return shared.ErrCommunicationNotFound
+	}
+	if engagement != shared.EngagementDelivered && engagement != shared.EngagementClicked {
+		return shared.ErrInvalidEngagement
+	}
+	m[id] = engagement
+	return nil
+}
+
+// pushEngagementRequest returns the engagement report of the push message with the token.
+func pushEngagementRequest(id, engagement, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/ui/push/messages/"+id+"/"+engagement, nil)
+	req.SetPathValue("id", id)
+	req.SetPathValue("engagement", engagement)
+	if token != "" {
+		req.Header.Set("X-Push-Token", token)
+	}
+	return req
+}
+
+func Test_HttpPushEngagement_Should_Record_Click_Without_Session(t *testing.T) {
+	// Arrange
+	engagements := mockPushEngagements{"msg-1": ""}
+	tokens := outbound.NewPushEngagementTokens("secret")
+	req := pushEngagementRequest("msg-1", "clicked", tokens.Sign("msg-1"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPushEngagement(engagements, tokens)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	assert.That(t, "click must be recorded", engagements["msg-1"], "clicked")
+}
+
+func Test_HttpPushEngagement_With_Unknown_Message_Should_Return_404(t *testing.T) {
+	// Arrange
+	tokens := outbound.NewPushEngagementTokens("secret")
+	req := pushEngagementRequest("msg-404", "delivered", tokens.Sign("msg-404"))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpPushEngagement(mockPushEngagements{}, tokens)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
+
+func Test_HttpPushEngagement_Without_Valid_Token_Should_Return_403(t *testing.T) {
+	// Arrange
+	engagements := mockPushEngagements{"msg-1": ""}
+	tokens := outbound.NewPushEngagementTokens("secret")
+	unsigned, tampered, foreign := httptest.NewRecorder(), httptest.NewRecorder(), httptest.NewRecorder()
+	handler := inbound.HttpPushEngagement(engagements, tokens)
+
+	// Act
+	handler(unsigned, pushEngagementRequest("msg-1", "clicked", ""))
+	handler(tampered, pushEngagementRequest("msg-1", "clicked", tokens.Sign("msg-1")[1:]+"0"))
+	handler(foreign, pushEngagementRequest("msg-1", "clicked", tokens.Sign("msg-2")))
+
+	// Assert
+	assert.That(t, "unsigned report must be rejected", unsigned.Code, http.StatusForbidden)
+	assert.That(t, "tampered token must be rejected", tampered.Code, http.StatusForbidden)
+	assert.That(t, "token of another message must be rejected", foreign.Code, http.StatusForbidden)
+	assert.That(t, "engagement must not be recorded", engagements["msg-1"], "")
+}
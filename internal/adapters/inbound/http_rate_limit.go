@@ -0,0 +1,79 @@
+package inbound
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter counts the requests of each client in fixed windows, like MCPQuota counts tool calls.
+// It guards public forms against guessing, e.g. the confirmation codes of the booking lookup.
+type RateLimiter struct {
+	limit   int
+	window  time.Duration
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a new rate limiter of limit requests per window and client; a limit of 0 is unlimited.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*quotaWindow),
+		now:     time.Now,
+	}
+}
+
+// Take uses one request of the client's limit.
+// It returns false if the limit is exhausted, with the time until the window resets.
+func (l *RateLimiter) Take(client string) (time.Duration, bool) {
+	if l.limit <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	window, ok := l.windows[client]
+	if !ok || now.Sub(window.start) >= l.window {
+		// Expired windows of all clients are dropped when a new one starts, so idle clients do not accumulate.
+		for key, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, key)
+			}
+		}
+		window = &quotaWindow{start: now}
+		l.windows[client] = window
+	}
+	if window.used >= l.limit {
+		return window.start.Add(l.window).Sub(now), false
+	}
+	window.used++
+	return 0, true
+}
+
+// WithRateLimit rejects the requests of an IP address over the limit
+// with 429 Too Many Requests and a Retry-After header.
+func WithRateLimit(limiter *RateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reset, ok := limiter.Take(remoteIP(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int((reset+time.Second-1)/time.Second)))
+			http.Error(w, "Too many requests, please try again later", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// remoteIP returns the IP address of the client without the port.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
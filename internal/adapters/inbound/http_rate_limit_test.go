@@ -0,0 +1,66 @@
+package inbound_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// RateLimiter Tests
+// ============================================================================
+
+func Test_RateLimiter_Take_Over_Limit_Should_Be_Rejected(t *testing.T) {
+	// Arrange
+	limiter := inbound.NewRateLimiter(2, time.Minute)
+	limiter.Take("203.0.113.7")
+	limiter.Take("203.0.113.7")
+
+	// Act
+	reset, ok := limiter.Take("203.0.113.7")
+	_, otherOK := limiter.Take("198.51.100.1")
+
+	// Assert
+	assert.That(t, "third request must be rejected", ok, false)
+	assert.That(t, "reset must be within the window", reset > 0 && reset <= time.Minute, true)
+	assert.That(t, "other client must be allowed", otherOK, true)
+}
+
+func Test_RateLimiter_Take_Without_Limit_Should_Allow_All(t *testing.T) {
+	// Arrange
+	limiter := inbound.NewRateLimiter(0, time.Minute)
+
+	// Act
+	_, ok := limiter.Take("203.0.113.7")
+
+	// Assert
+	assert.That(t, "request must be allowed", ok, true)
+}
+
+// ============================================================================
+// WithRateLimit Tests
+// ============================================================================
+
+func Test_WithRateLimit_Over_Limit_Should_Return_429_With_Retry_After(t *testing.T) {
+	// Arrange
+	handler := inbound.WithRateLimit(inbound.NewRateLimiter(1, time.Minute), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	first := httptest.NewRequest(http.MethodPost, "/ui/lookup", nil)
+	first.RemoteAddr = "203.0.113.7:4711"
+	handler(httptest.NewRecorder(), first)
+	second := httptest.NewRequest(http.MethodPost, "/ui/lookup", nil)
+	second.RemoteAddr = "203.0.113.7:4712"
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, second)
+
+	// Assert
+	assert.That(t, "status must be 429", rec.Code, http.StatusTooManyRequests)
+	assert.That(t, "Retry-After must be set", rec.Header().Get("Retry-After"), "60")
+}
@@ -0,0 +1,132 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// This file contains the readiness probe. Each dependency is a check, registered in main.go;
+// a critical dependency that is down takes the instance out of the load balancer (503),
+// while the others are only reported, so an outage of e.g. the identity provider does not
+// stop the bookings of signed-in guests on every replica at once.
+
+// Status of a readiness report and of its dependencies.
+const (
+	ReadinessUp       = "up"
+	ReadinessDown     = "down"
+	ReadinessDegraded = "degraded" // a non-critical dependency is down
+)
+
+// ReadinessCheck verifies that a dependency can serve requests, e.g. that a database accepts connections.
+type ReadinessCheck struct {
+	Name     string
+	Critical bool // the instance is not ready while the dependency is down
+	Check    func(ctx context.Context) error
+}
+
+// DependencyStatus is the result of a readiness check.
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessReport is the body of the readiness probe.
+type ReadinessReport struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+}
+
+// Ready reports whether every critical dependency is up.
+func (r ReadinessReport) Ready() bool {
+	return r.Status != ReadinessDown
+}
+
+// Readiness is the registry of the dependency checks of the readiness probe.
+// The checks run in parallel, each with a timeout, and the report is reused for ttl,
+// so frequent probes from several sources do not load the dependencies.
+type Readiness struct {
+	timeout time.Duration
+	ttl     time.Duration
+	mu      sync.Mutex
+	checks  []ReadinessCheck
+	last    *ReadinessReport
+}
+
+// NewReadiness creates the readiness probe with the timeout per check and the time a report is reused.
+func NewReadiness(timeout, ttl time.Duration) *Readiness {
+	return &Readiness{timeout: timeout, ttl: ttl}
+}
+
+// Register adds a check.
+func (r *Readiness) Register(check ReadinessCheck) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check)
+	r.last = nil
+}
+
+// Check runs the checks, or returns the last report if it is younger than ttl.
+// Concurrent probes wait for one run instead of starting their own; a probe that hangs up
+// does not cancel the checks, because the report is shared.
+func (r *Readiness) Check(ctx context.Context) ReadinessReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last != nil && time.Since(r.last.CheckedAt) < r.ttl {
+		return *r.last
+	}
+
+	report := ReadinessReport{Status: ReadinessUp, Dependencies: make(map[string]DependencyStatus, len(r.checks)), CheckedAt: time.Now()}
+	results := make([]DependencyStatus, len(r.checks))
+	var wg sync.WaitGroup
+	for i, check := range r.checks {
+		wg.Go(func() {
+			checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+			defer cancel()
+			start := time.Now()
+			err := check.Check(checkCtx)
+			results[i] = DependencyStatus{Status: ReadinessUp, Critical: check.Critical, LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				results[i].Status = ReadinessDown
+				results[i].Error = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, check := range r.checks {
+		report.Dependencies[check.Name] = results[i]
+		switch {
+		case results[i].Status == ReadinessUp:
+		case check.Critical:
+			report.Status = ReadinessDown
+		case report.Status == ReadinessUp:
+			report.Status = ReadinessDegraded
+		}
+	}
+	r.last = &report
+	return report
+}
+
+// HttpReadiness serves the readiness probe: the status of every dependency as JSON, with 503 while
+// a critical dependency is down or the server shuts down (ctx is done), so no new requests are routed to it.
+func HttpReadiness(ctx context.Context, readiness *Readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := readiness.Check(r.Context())
+		if ctx.Err() != nil {
+			report.Status = ReadinessDown
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !report.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
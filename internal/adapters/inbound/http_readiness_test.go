@@ -0,0 +1,156 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func readinessCheck(name string, critical bool, err error) inbound.ReadinessCheck {
+	return inbound.ReadinessCheck{Name: name, Critical: critical, Check: func(ctx context.Context) error { return err }}
+}
+
+func serveReadiness(ctx context.Context, readiness *inbound.Readiness) (*httptest.ResponseRecorder, inbound.ReadinessReport) {
+	req := httptest.NewRequest(http.MethodGet, "/readiness", nil)
+	rec := httptest.NewRecorder()
+	inbound.HttpReadiness(ctx, readiness)(rec, req)
+	var report inbound.ReadinessReport
+	_ = json.NewDecoder(rec.Body).Decode(&report)
+	return rec, report
+}
+
+// ============================================================================
+// Readiness Tests
+// ============================================================================
+
+func Test_Readiness_Should_Reuse_Report_Within_TTL(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	readiness := inbound.NewReadiness(time.Second, time.Minute)
+	readiness.Register(inbound.ReadinessCheck{Name: "kafka", Critical: true, Check: func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}})
+
+	// Act
+	readiness.Check(context.Background())
+	readiness.Check(context.Background())
+
+	// Assert
+	assert.That(t, "check must run once", calls.Load(), int32(1))
+}
+
+func Test_Readiness_With_Slow_Check_Should_Time_Out(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(10*time.Millisecond, 0)
+	readiness.Register(inbound.ReadinessCheck{Name: "kafka", Critical: true, Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	// Act
+	report := readiness.Check(context.Background())
+
+	// Assert
+	assert.That(t, "status must be down", report.Status, inbound.ReadinessDown)
+	assert.That(t, "error must be the deadline", report.Dependencies["kafka"].Error, context.DeadlineExceeded.Error())
+}
+
+// ============================================================================
+// HttpReadiness Tests
+// ============================================================================
+
+func Test_HttpReadiness_With_All_Dependencies_Up_Should_Return_200(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("reservation_database", true, nil))
+	readiness.Register(readinessCheck("oidc_issuer", false, nil))
+
+	// Act
+	rec, report := serveReadiness(context.Background(), readiness)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "status must be up", report.Status, inbound.ReadinessUp)
+	assert.That(t, "database must be up", report.Dependencies["reservation_database"].Status, inbound.ReadinessUp)
+}
+
+func Test_HttpReadiness_With_Critical_Dependency_Down_Should_Return_503(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("reservation_database", true, nil))
+	readiness.Register(readinessCheck("kafka", true, errors.New("connection refused")))
+
+	// Act
+	rec, report := serveReadiness(context.Background(), readiness)
+
+	// Assert
+	assert.That(t, "status code must be 503", rec.Code, http.StatusServiceUnavailable)
+	assert.That(t, "status must be down", report.Status, inbound.ReadinessDown)
+	assert.That(t, "kafka must be down", report.Dependencies["kafka"], inbound.DependencyStatus{Status: inbound.ReadinessDown, Critical: true, Error: "connection refused"})
+}
+
+func Test_HttpReadiness_With_Non_Critical_Dependency_Down_Should_Return_200_Degraded(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("kafka", true, nil))
+	readiness.Register(readinessCheck("oidc_issuer", false, errors.New("no such host")))
+
+	// Act
+	rec, report := serveReadiness(context.Background(), readiness)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "status must be degraded", report.Status, inbound.ReadinessDegraded)
+	assert.That(t, "issuer must be down", report.Dependencies["oidc_issuer"].Status, inbound.ReadinessDown)
+}
+
+func Test_HttpReadiness_During_Shutdown_Should_Return_503(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("kafka", true, nil))
+
+	// Act
+	rec, _ := serveReadiness(ctx, readiness)
+
+	// Assert
+	assert.That(t, "status code must be 503", rec.Code, http.StatusServiceUnavailable)
+}
+
+func Test_Route_With_Readiness_Should_Override_Probe_And_Keep_Other_Routes(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("payment_database", true, errors.New("connection refused")))
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		Readiness:          readiness,
+		ReservationService: createTestReservationService(t),
+	})
+	readinessRec := httptest.NewRecorder()
+	livenessRec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(readinessRec, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+	mux.ServeHTTP(livenessRec, httptest.NewRequest(http.MethodGet, "/liveness", nil))
+
+	// Assert
+	assert.That(t, "readiness must verify the dependencies", readinessRec.Code, http.StatusServiceUnavailable)
+	assert.That(t, "liveness must still be served", livenessRec.Code, http.StatusOK)
+}
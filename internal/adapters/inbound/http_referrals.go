@@ -0,0 +1,108 @@
+package inbound
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ReferralView represents a referral for the view.
+type ReferralView struct {
+	CreatedAt   string
+	Status      string
+	StatusClass string
+	Reward      string
+}
+
+// HttpViewReferralsResponse specifies the view data for the referral dashboard.
+type HttpViewReferralsResponse struct {
+	AppName      string
+	Title        string
+	SessionID    string
+	Code         string
+	ShareLink    string
+	Pending      int
+	Earned       int
+	EarnedCredit string
+	EarnedPoints int
+	Referrals    []ReferralView
+}
+
+// HttpViewReferrals defines an HTTP handler function for rendering the referral dashboard of the guest,
+// with the code to share and the pending and earned rewards.
+func HttpViewReferrals(e *templating.Engine, referralService *referral.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Referrals"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+		email, _ := ctx.Value(web.ContextEmail).(string)
+
+		dashboard, err := referralService.Dashboard(ctx, referral.GuestID(guestID), email)
+		if err != nil {
+			http.Error(w, "Failed to load referrals", http.StatusInternalServerError)
+			return
+		}
+
+		data := HttpViewReferralsResponse{
+			AppName:      appName,
+			Title:        title,
+			SessionID:    sessionID,
+			Code:         string(dashboard.Code),
+			ShareLink:    absoluteURL(r, "/ui/reservations/new?ref="+url.QueryEscape(string(dashboard.Code))),
+			Pending:      dashboard.Pending,
+			Earned:       dashboard.Earned,
+			EarnedPoints: dashboard.EarnedPoints,
+		}
+		locale := requestLocale(r)
+		if dashboard.EarnedCredit.Amount > 0 {
+			data.EarnedCredit = dashboard.EarnedCredit.FormatIn(locale)
+		}
+		for _, ref := range dashboard.Referrals {
+			data.Referrals = append(data.Referrals, ReferralView{
+				CreatedAt:   ref.CreatedAt.Format("2006-01-02"),
+				Status:      string(ref.Status),
+				StatusClass: referralStatusClass(ref.Status),
+				Reward:      rewardLabel(ref.Reward, locale),
+			})
+		}
+
+		HttpView(e, "referrals", data)(w, r)
+	}
+}
+
+// rewardLabel describes a reward for the views. Pending and void referrals have no reward yet.
+func rewardLabel(reward referral.Reward, locale shared.Locale) string {
+	switch reward.Kind {
+	case referral.RewardCredit:
+		return reward.Credit.FormatIn(locale) + " credit"
+	case referral.RewardPoints:
+		return fmt.Sprintf("%d points", reward.Points)
+	default:
+		return ""
+	}
+}
+
+func referralStatusClass(status referral.ReferralStatus) string {
+	switch status {
+	case referral.StatusPending:
+		return "warning"
+	case referral.StatusEarned:
+		return "success"
+	default:
+		return "secondary"
+	}
+}
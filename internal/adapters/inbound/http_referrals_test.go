@@ -0,0 +1,138 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestReferralService(reservationService *reservation.Service) *referral.Service {
+	return referral.NewService(
+		resource.NewInMemoryAccess[referral.Code, referral.ReferralCode](),
+		resource.NewInMemoryAccess[referral.ReferralID, referral.Referral](),
+		outbound.NewReservationBookingHistory(reservationService),
+		outbound.NewMockNotificationService(slog.Default(), "http://localhost:8080/ui", nil),
+		referral.DefaultPolicy(),
+	)
+}
+
+func referralBookingRequest(code string) *http.Request {
+	form := url.Values{
+		"room_id":       {"room-101"},
+		"check_in":      {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":     {time.Now().AddDate(0, 0, 9).Format("2006-01-02")},
+		"guest_name":    {"Test Guest"},
+		"guest_email":   {"test@example.com"},
+		"referral_code": {code},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return addAuthContext(req, "test-session-123", "test@example.com")
+}
+
+// ============================================================================
+// Referral Attribution Tests
+// ============================================================================
+
+func Test_HttpCreateReservation_With_Referral_Code_Should_Attribute_Booking(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	reservationService := createFormTestService(repo)
+	referralService := createTestReferralService(reservationService)
+	code, _ := referralService.CodeFor(context.Background(), "guest-referrer", "referrer@example.com")
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReferralService: referralService, ReservationService: reservationService})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, referralBookingRequest(strings.ToLower(string(code.Code))))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	dashboard, _ := referralService.Dashboard(context.Background(), "guest-referrer", "referrer@example.com")
+	assert.That(t, "referral must be pending", dashboard.Pending, 1)
+}
+
+func Test_HttpCreateReservation_With_Unknown_Referral_Code_Should_Show_Error(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	reservationService := createFormTestService(repo)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReferralService: createTestReferralService(reservationService), ReservationService: reservationService})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, referralBookingRequest("NOPE2345"))
+
+	// Assert
+	assert.That(t, "status code must be 200 (form re-rendered with error)", rec.Code, http.StatusOK)
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain error message", strings.Contains(string(body), referral.ErrUnknownCode.Error()), true)
+	assert.That(t, "no reservation must be created", repo.count(), 0)
+}
+
+// ============================================================================
+// HttpViewReferrals Tests
+// ============================================================================
+
+func Test_HttpViewReferrals_Should_Render_Code_And_Rewards(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	reservationService := createFormTestService(newMockReservationRepository())
+	referralService := createTestReferralService(reservationService)
+	ctx := context.Background()
+	code, _ := referralService.CodeFor(ctx, "user-subject-456", "test@example.com")
+	_, _ = referralService.Attribute(ctx, code.Code, "res-001", "guest-friend", "friend@example.com")
+	_, _ = referralService.CompleteStay(ctx, "res-001")
+	handler := inbound.HttpViewReferrals(e, referralService)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/referrals", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must contain the share link", strings.Contains(bodyStr, "/ui/reservations/new?ref="+string(code.Code)), true)
+	assert.That(t, "body must contain the earned credit", strings.Contains(bodyStr, "0 pending, 1 earned, $25.00 credit"), true)
+}
+
+func Test_HttpViewReferrals_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewReferrals(e, createTestReferralService(createFormTestService(newMockReservationRepository())))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/ui/referrals", nil))
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+}
@@ -0,0 +1,99 @@
+package inbound
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RequestLimitConfig configures the token buckets of the request limit per client.
+type RequestLimitConfig struct {
+	Rate  float64 // requests per second a client may send on average; 0 is unlimited
+	Burst int     // requests a client may send at once after being idle
+}
+
+// DefaultRequestLimitConfig returns 10 requests per second with bursts of 20.
+func DefaultRequestLimitConfig() RequestLimitConfig {
+	return RequestLimitConfig{
+		Rate:  10,
+		Burst: 20,
+	}
+}
+
+// RequestLimiter limits the HTTP requests of each client with a token bucket.
+// Unlike MCPQuota, which counts tool calls in windows and hints agents, it guards the endpoints
+// against being hammered: a bucket holds up to Burst tokens, refills at Rate and every request takes one.
+type RequestLimiter struct {
+	config  RequestLimitConfig
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// tokenBucket is the bucket of a client at the time it was last updated.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRequestLimiter creates a new request limiter; a burst below 1 allows single requests.
+func NewRequestLimiter(config RequestLimitConfig) *RequestLimiter {
+	config.Burst = max(config.Burst, 1)
+	return &RequestLimiter{
+		config:  config,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take uses one token of the client's bucket.
+// It returns false if the bucket is empty, with the time until the next token.
+func (l *RequestLimiter) Take(client string) (time.Duration, bool) {
+	if l.config.Rate <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	bucket, ok := l.buckets[client]
+	if !ok {
+		// Buckets that refilled completely are dropped when a new client arrives,
+		// so idle clients do not accumulate; a full bucket is the same as none.
+		for key, b := range l.buckets {
+			if l.refill(b, now) >= float64(l.config.Burst) {
+				delete(l.buckets, key)
+			}
+		}
+		bucket = &tokenBucket{tokens: float64(l.config.Burst), updated: now}
+		l.buckets[client] = bucket
+	}
+	bucket.tokens, bucket.updated = l.refill(bucket, now), now
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / l.config.Rate * float64(time.Second)), false
+	}
+	bucket.tokens--
+	return 0, true
+}
+
+// refill returns the tokens of the bucket at the time.
+func (l *RequestLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.updated).Seconds()
+	return math.Min(float64(l.config.Burst), bucket.tokens+elapsed*l.config.Rate)
+}
+
+// WithRequestLimit rejects the requests of a client (service account, user or IP address)
+// over its limit with 429 Too Many Requests and a Retry-After header.
+// It must run after the authentication, which sets the principal the client is identified by.
+func WithRequestLimit(limiter *RequestLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wait, ok := limiter.Take(requestClient(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int((wait+time.Second-1)/time.Second)))
+			http.Error(w, "Too many requests, please try again later", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,97 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// RequestLimiter Tests
+// ============================================================================
+
+func Test_RequestLimiter_Take_Over_Burst_Should_Be_Rejected(t *testing.T) {
+	// Arrange
+	limiter := inbound.NewRequestLimiter(inbound.RequestLimitConfig{Rate: 1, Burst: 2})
+	limiter.Take("subject:alice")
+	limiter.Take("subject:alice")
+
+	// Act
+	wait, ok := limiter.Take("subject:alice")
+	_, otherOK := limiter.Take("subject:bob")
+
+	// Assert
+	assert.That(t, "third request must be rejected", ok, false)
+	assert.That(t, "wait must be up to one token", wait > 0 && wait <= time.Second, true)
+	assert.That(t, "other client must be allowed", otherOK, true)
+}
+
+func Test_RequestLimiter_Take_Without_Rate_Should_Allow_All(t *testing.T) {
+	// Arrange
+	limiter := inbound.NewRequestLimiter(inbound.RequestLimitConfig{Rate: 0, Burst: 1})
+	limiter.Take("subject:alice")
+
+	// Act
+	_, ok := limiter.Take("subject:alice")
+
+	// Assert
+	assert.That(t, "request must be allowed", ok, true)
+}
+
+// ============================================================================
+// WithRequestLimit Tests
+// ============================================================================
+
+func Test_WithRequestLimit_Should_Limit_Per_Subject(t *testing.T) {
+	// Arrange
+	handler := inbound.WithRequestLimit(inbound.NewRequestLimiter(inbound.RequestLimitConfig{Rate: 0.5, Burst: 1}), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	request := func(subject string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reservations", nil)
+		return req.WithContext(shared.ContextWithPrincipal(req.Context(), shared.Principal{Type: shared.PrincipalGuest, Subject: subject}))
+	}
+	handler(httptest.NewRecorder(), request("alice"))
+	rec := httptest.NewRecorder()
+	other := httptest.NewRecorder()
+
+	// Act
+	handler(rec, request("alice"))
+	handler(other, request("bob"))
+
+	// Assert
+	assert.That(t, "status must be 429", rec.Code, http.StatusTooManyRequests)
+	assert.That(t, "Retry-After must be set", rec.Header().Get("Retry-After"), "2")
+	assert.That(t, "other subject must be allowed", other.Code, http.StatusOK)
+}
+
+func Test_Route_MCP_With_RequestLimiter_Should_Return_429_Over_Limit(t *testing.T) {
+	// Arrange
+	mux := inbound.Route(inbound.RouterConfig{
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		MCPServer:          mcp.NewServer("test-server", "1.0.0"),
+		RequestLimiter:     inbound.NewRequestLimiter(inbound.RequestLimitConfig{Rate: 1, Burst: 1}),
+		ReservationService: createTestReservationService(t),
+	})
+	initReq := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(initReq)))
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(initReq)))
+
+	// Assert
+	assert.That(t, "status code must be 429", rec.Code, http.StatusTooManyRequests)
+	assert.That(t, "Retry-After must be set", rec.Header().Get("Retry-After"), "1")
+}
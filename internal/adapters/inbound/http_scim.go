@@ -0,0 +1,414 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// SCIM 2.0 schema URNs (RFC 7643, RFC 7644).
+const (
+	scimSchemaUser      = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaList      = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError     = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimContentType     = "application/scim+json"
+	scimMaxRequestBytes = 16384
+)
+
+// ScimValue is a multi-valued attribute entry, e.g. an email or a role.
+type ScimValue struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ScimName is the name of a SCIM user.
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMeta is the resource metadata of a SCIM user.
+type ScimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// ScimUser is the subset of the SCIM core user schema used for staff provisioning.
+// UserName is the login email of the staff realm.
+type ScimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Name        *ScimName   `json:"name,omitempty"`
+	Emails      []ScimValue `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Roles       []ScimValue `json:"roles,omitempty"`
+	Meta        *ScimMeta   `json:"meta,omitempty"`
+}
+
+// ScimListResponse is the response of a user query.
+type ScimListResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []ScimUser `json:"Resources"`
+}
+
+// ScimPatchOperation is a single operation of a PATCH request.
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ScimPatchRequest is the body of a PATCH request.
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimError is the error response of the SCIM API.
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// HttpScimListUsers returns the provisioned staff accounts.
+// Only the filter `userName eq "value"` is supported, which identity providers use to look up accounts.
+func HttpScimListUsers(staffService *staff.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userName, err := parseScimFilter(r.URL.Query().Get("filter"))
+		if err != nil {
+			writeScimError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+
+		members, err := staffService.ListMembers(r.Context(), userName)
+		if err != nil {
+			writeScimError(w, http.StatusInternalServerError, "", "failed to list users")
+			return
+		}
+
+		resp := ScimListResponse{
+			Schemas:      []string{scimSchemaList},
+			TotalResults: len(members),
+			StartIndex:   1,
+			ItemsPerPage: len(members),
+			Resources:    make([]ScimUser, 0, len(members)),
+		}
+		for i := range members {
+			resp.Resources = append(resp.Resources, toScimUser(&members[i]))
+		}
+		writeScimJSON(w, http.StatusOK, resp)
+	}
+}
+
+// HttpScimGetUser returns a provisioned staff account.
+func HttpScimGetUser(staffService *staff.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		member, err := staffService.GetMember(r.Context(), staff.MemberID(r.PathValue("id")))
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+		writeScimJSON(w, http.StatusOK, toScimUser(member))
+	}
+}
+
+// HttpScimCreateUser provisions a staff account.
+func HttpScimCreateUser(staffService *staff.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var user ScimUser
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, scimMaxRequestBytes)).Decode(&user); err != nil {
+			writeScimError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+			return
+		}
+
+		member, err := staffService.Provision(r.Context(), staff.MemberID(security.GenerateID()), fromScimUser(user))
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+
+		logProvisioning(logger, "staff member provisioned", member)
+		w.Header().Set("Location", scimLocation(member.ID))
+		writeScimJSON(w, http.StatusCreated, toScimUser(member))
+	}
+}
+
+// HttpScimReplaceUser replaces the data of a staff account.
+func HttpScimReplaceUser(staffService *staff.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var user ScimUser
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, scimMaxRequestBytes)).Decode(&user); err != nil {
+			writeScimError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+			return
+		}
+
+		member, err := staffService.Replace(r.Context(), staff.MemberID(r.PathValue("id")), fromScimUser(user))
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+
+		logProvisioning(logger, "staff member updated", member)
+		writeScimJSON(w, http.StatusOK, toScimUser(member))
+	}
+}
+
+// HttpScimPatchUser applies PATCH operations to a staff account.
+// The attributes active, roles and displayName can be replaced; roles can also be added or removed.
+func HttpScimPatchUser(staffService *staff.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ScimPatchRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, scimMaxRequestBytes)).Decode(&req); err != nil {
+			writeScimError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+			return
+		}
+
+		ctx := r.Context()
+		id := staff.MemberID(r.PathValue("id"))
+		member, err := staffService.GetMember(ctx, id)
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+
+		// Apply all operations to a copy first, so an invalid operation changes nothing.
+		data := staff.MemberData{
+			ExternalID:  member.ExternalID,
+			UserName:    member.UserName,
+			DisplayName: member.DisplayName,
+			Roles:       member.Roles,
+			Active:      member.IsActive(),
+		}
+		for _, op := range req.Operations {
+			if err := applyScimPatch(&data, op); err != nil {
+				writeScimError(w, http.StatusBadRequest, "invalidValue", err.Error())
+				return
+			}
+		}
+
+		member, err = staffService.Replace(ctx, id, data)
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+
+		logProvisioning(logger, "staff member updated", member)
+		writeScimJSON(w, http.StatusOK, toScimUser(member))
+	}
+}
+
+// HttpScimDeleteUser deprovisions a staff account.
+func HttpScimDeleteUser(staffService *staff.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		member, err := staffService.Deprovision(r.Context(), staff.MemberID(r.PathValue("id")))
+		if err != nil {
+			writeScimServiceError(w, err)
+			return
+		}
+
+		logProvisioning(logger, "staff member deprovisioned", member)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// parseScimFilter returns the user name of a `userName eq "value"` filter.
+func parseScimFilter(filter string) (string, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return "", nil
+	}
+	fields := strings.SplitN(filter, " ", 3)
+	if len(fields) != 3 || !strings.EqualFold(fields[0], "userName") || !strings.EqualFold(fields[1], "eq") {
+		return "", errors.New(`only the filter userName eq "value" is supported`)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(fields[2]))
+	if err != nil {
+		return "", errors.New("filter value must be a quoted string")
+	}
+	return value, nil
+}
+
+// applyScimPatch applies a single PATCH operation to the account data.
+func applyScimPatch(data *staff.MemberData, op ScimPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	path := strings.ToLower(op.Path)
+
+	// Without a path the value holds the attributes to replace, as sent by Azure AD and Okta.
+	if path == "" && (kind == "replace" || kind == "add") {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attributes); err != nil {
+			return errors.New("value must be an object of attributes")
+		}
+		for name, value := range attributes {
+			if err := applyScimPatch(data, ScimPatchOperation{Op: kind, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case path == "active" && kind == "replace":
+		active, err := parseScimBool(op.Value)
+		if err != nil {
+			return err
+		}
+		data.Active = active
+	case path == "displayname" && (kind == "replace" || kind == "add"):
+		if err := json.Unmarshal(op.Value, &data.DisplayName); err != nil {
+			return errors.New("displayName must be a string")
+		}
+	case path == "roles" && kind == "replace":
+		roles, err := parseScimRoles(op.Value)
+		if err != nil {
+			return err
+		}
+		data.Roles = roles
+	case path == "roles" && kind == "add":
+		roles, err := parseScimRoles(op.Value)
+		if err != nil {
+			return err
+		}
+		data.Roles = append(data.Roles, roles...)
+	case path == "roles" && kind == "remove":
+		data.Roles = nil
+	default:
+		return errors.New("unsupported operation " + op.Op + " " + op.Path)
+	}
+	return nil
+}
+
+// parseScimBool accepts true/false as JSON boolean or string; some identity providers send "False".
+func parseScimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if parsed, err := strconv.ParseBool(s); err == nil {
+			return parsed, nil
+		}
+	}
+	return false, errors.New("active must be a boolean")
+}
+
+// parseScimRoles reads the role values of a multi-valued attribute.
+func parseScimRoles(value json.RawMessage) ([]staff.Role, error) {
+	var values []ScimValue
+	if err := json.Unmarshal(value, &values); err != nil {
+		return nil, errors.New(`roles must be a list of {"value": "role"}`)
+	}
+	roles := make([]staff.Role, 0, len(values))
+	for _, v := range values {
+		roles = append(roles, staff.Role(v.Value))
+	}
+	return roles, nil
+}
+
+// fromScimUser converts a SCIM user to account data. Users are active unless stated otherwise.
+func fromScimUser(user ScimUser) staff.MemberData {
+	data := staff.MemberData{
+		ExternalID:  user.ExternalID,
+		UserName:    user.UserName,
+		DisplayName: user.DisplayName,
+		Active:      user.Active == nil || *user.Active,
+	}
+	if data.DisplayName == "" && user.Name != nil {
+		data.DisplayName = user.Name.Formatted
+		if data.DisplayName == "" {
+			data.DisplayName = strings.TrimSpace(user.Name.GivenName + " " + user.Name.FamilyName)
+		}
+	}
+	for _, role := range user.Roles {
+		data.Roles = append(data.Roles, staff.Role(role.Value))
+	}
+	return data
+}
+
+// toScimUser converts a staff account to a SCIM user.
+func toScimUser(member *staff.StaffMember) ScimUser {
+	active := member.IsActive()
+	user := ScimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          string(member.ID),
+		ExternalID:  member.ExternalID,
+		UserName:    member.UserName,
+		DisplayName: member.DisplayName,
+		Emails:      []ScimValue{{Value: member.UserName, Primary: true}},
+		Active:      &active,
+		Meta: &ScimMeta{
+			ResourceType: "User",
+			Created:      member.CreatedAt,
+			LastModified: member.UpdatedAt,
+			Location:     scimLocation(member.ID),
+		},
+	}
+	for _, role := range member.Roles {
+		user.Roles = append(user.Roles, ScimValue{Value: string(role)})
+	}
+	return user
+}
+
+func scimLocation(id staff.MemberID) string {
+	return "/scim/v2/Users/" + string(id)
+}
+
+// logProvisioning writes a provisioning event to the audit log.
+func logProvisioning(logger *slog.Logger, msg string, member *staff.StaffMember) {
+	logger.Info(msg,
+		"audit", true,
+		"staff_id", member.ID,
+		"external_id", member.ExternalID,
+		"user_name", member.UserName,
+		"status", member.Status,
+		"roles", member.Roles,
+	)
+}
+
+// writeScimServiceError maps the errors of the staff service to SCIM error responses.
+func writeScimServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, staff.ErrStaffNotFound):
+		writeScimError(w, http.StatusNotFound, "", "user not found")
+	case errors.Is(err, staff.ErrUserNameTaken):
+		writeScimError(w, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, staff.ErrMissingUserName), errors.Is(err, staff.ErrUnknownRole):
+		writeScimError(w, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		writeScimError(w, http.StatusInternalServerError, "", "failed to provision user")
+	}
+}
+
+func writeScimError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeScimJSON(w, status, ScimError{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeScimJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
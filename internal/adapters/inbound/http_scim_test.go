@@ -0,0 +1,178 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestStaffService() *staff.Service {
+	return staff.NewService(resource.NewInMemoryAccess[staff.MemberID, staff.StaffMember](), staff.RolePolicy{
+		"front_desk": {"reservations:read", "reservations:write"},
+		"finance":    {"payments:read"},
+	})
+}
+
+func createScimTestMux(svc *staff.Service) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scim/v2/Users", inbound.HttpScimListUsers(svc))
+	mux.HandleFunc("POST /scim/v2/Users", inbound.HttpScimCreateUser(svc, slog.Default()))
+	mux.HandleFunc("GET /scim/v2/Users/{id}", inbound.HttpScimGetUser(svc))
+	mux.HandleFunc("PATCH /scim/v2/Users/{id}", inbound.HttpScimPatchUser(svc, slog.Default()))
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", inbound.HttpScimDeleteUser(svc, slog.Default()))
+	return mux
+}
+
+func provisionScimUser(t *testing.T, mux *http.ServeMux) inbound.ScimUser {
+	t.Helper()
+	body := `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"anna@hotel.example",
+		"externalId":"hr-42","name":{"givenName":"Anna","familyName":"Berg"},"roles":[{"value":"front_desk"}]}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("failed to provision user: %d %s", rec.Code, rec.Body.String())
+	}
+	var user inbound.ScimUser
+	_ = json.NewDecoder(rec.Body).Decode(&user)
+	return user
+}
+
+// ============================================================================
+// SCIM Users Tests
+// ============================================================================
+
+func Test_HttpScimCreateUser_Should_Provision_Active_User(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+
+	// Act
+	user := provisionScimUser(t, mux)
+
+	// Assert
+	assert.That(t, "id must be set", user.ID != "", true)
+	assert.That(t, "display name must be built from the name", user.DisplayName, "Anna Berg")
+	assert.That(t, "user must be active", *user.Active, true)
+	assert.That(t, "location must point to the user", user.Meta.Location, "/scim/v2/Users/"+user.ID)
+}
+
+func Test_HttpScimCreateUser_With_Taken_UserName_Should_Return_409(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+	provisionScimUser(t, mux)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(`{"userName":"anna@hotel.example"}`)))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+	var scimErr inbound.ScimError
+	_ = json.NewDecoder(rec.Body).Decode(&scimErr)
+	assert.That(t, "scim type must be uniqueness", scimErr.ScimType, "uniqueness")
+}
+
+func Test_HttpScimCreateUser_With_Unknown_Role_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(`{"userName":"bob@hotel.example","roles":[{"value":"owner"}]}`)))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpScimListUsers_With_UserName_Filter_Should_Return_Match(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+	provisionScimUser(t, mux)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22ANNA@hotel.example%22`, nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var list inbound.ScimListResponse
+	_ = json.NewDecoder(rec.Body).Decode(&list)
+	assert.That(t, "one user must match", list.TotalResults, 1)
+}
+
+func Test_HttpScimListUsers_With_Unsupported_Filter_Should_Return_400(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, `/scim/v2/Users?filter=displayName+co+%22A%22`, nil))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpScimPatchUser_Should_Disable_User_And_Replace_Roles(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	mux := createScimTestMux(svc)
+	user := provisionScimUser(t, mux)
+	body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[
+		{"op":"Replace","path":"active","value":"False"},
+		{"op":"replace","value":{"roles":[{"value":"finance"}]}}]}`
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/scim/v2/Users/"+user.ID, strings.NewReader(body)))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	access, _ := svc.Access(context.Background(), "anna@hotel.example")
+	assert.That(t, "access must be disabled", access.Active, false)
+	assert.That(t, "roles must be replaced", access.Roles, []staff.Role{"finance"})
+}
+
+func Test_HttpScimPatchUser_With_Unsupported_Path_Should_Change_Nothing(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	mux := createScimTestMux(svc)
+	user := provisionScimUser(t, mux)
+	body := `{"Operations":[{"op":"replace","path":"active","value":false},{"op":"replace","path":"userType","value":"x"}]}`
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/scim/v2/Users/"+user.ID, strings.NewReader(body)))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+	access, _ := svc.Access(context.Background(), "anna@hotel.example")
+	assert.That(t, "access must stay active", access.Active, true)
+}
+
+func Test_HttpScimDeleteUser_Should_Deprovision_User(t *testing.T) {
+	// Arrange
+	mux := createScimTestMux(createTestStaffService())
+	user := provisionScimUser(t, mux)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/scim/v2/Users/"+user.ID, nil))
+
+	// Assert
+	assert.That(t, "status code must be 204", rec.Code, http.StatusNoContent)
+	get := httptest.NewRecorder()
+	mux.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/scim/v2/Users/"+user.ID, nil))
+	assert.That(t, "deleted user must not be found", get.Code, http.StatusNotFound)
+}
@@ -0,0 +1,59 @@
+package inbound
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ServiceAccountRegistry resolves the scopes of service accounts and counts their requests.
+type ServiceAccountRegistry interface {
+	Scopes(clientID string) ([]string, bool)
+	RecordUsage(clientID string)
+	Usage() map[string]int64
+}
+
+// WithServiceAccount turns principals authenticated by a client-credentials token into
+// service-account principals with the scopes of the registry.
+// Unregistered clients are rejected with 403. Every service-account request is written
+// to the audit log and counted, so machine usage can be told apart from human usage.
+// It must be wrapped by WithTokenAuth.
+func WithServiceAccount(registry ServiceAccountRegistry, logger *slog.Logger, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := shared.PrincipalFromContext(r.Context())
+		if !ok || principal.ClientID == "" {
+			next(w, r)
+			return
+		}
+
+		scopes, ok := registry.Scopes(principal.ClientID)
+		if !ok {
+			logger.Warn("unregistered service account rejected", "client_id", principal.ClientID, "issuer", principal.Issuer)
+			writeBearerAuthError(w, http.StatusForbidden, "Unregistered service account")
+			return
+		}
+
+		principal.Type = shared.PrincipalService
+		principal.Scopes = scopes
+		registry.RecordUsage(principal.ClientID)
+		logger.Info("service account request",
+			"audit", true,
+			"client_id", principal.ClientID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"request_id", RequestIDFromContext(r.Context()),
+		)
+
+		next(w, r.WithContext(shared.ContextWithPrincipal(r.Context(), principal)))
+	}
+}
+
+// HttpAdminServiceAccounts returns the request count of every service account as JSON.
+func HttpAdminServiceAccounts(registry ServiceAccountRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(registry.Usage())
+	}
+}
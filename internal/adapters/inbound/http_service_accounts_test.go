@@ -0,0 +1,90 @@
+package inbound_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockServiceAccounts struct {
+	scopes map[string][]string
+	usage  map[string]int64
+}
+
+func (m *mockServiceAccounts) Scopes(clientID string) ([]string, bool) {
+	scopes, ok := m.scopes[clientID]
+	return scopes, ok
+}
+
+func (m *mockServiceAccounts) RecordUsage(clientID string) {
+	m.usage[clientID]++
+}
+
+func (m *mockServiceAccounts) Usage() map[string]int64 {
+	return m.usage
+}
+
+func requestWithPrincipal(p shared.Principal) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	return req.WithContext(shared.ContextWithPrincipal(req.Context(), p))
+}
+
+// ============================================================================
+// WithServiceAccount Tests
+// ============================================================================
+
+func Test_WithServiceAccount_With_Registered_Client_Should_Set_Service_Principal(t *testing.T) {
+	// Arrange
+	registry := &mockServiceAccounts{scopes: map[string][]string{"reporting": {"payments:read"}}, usage: map[string]int64{}}
+	var seen shared.Principal
+	handler := inbound.WithServiceAccount(registry, slog.Default(), func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = shared.PrincipalFromContext(r.Context())
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, ClientID: "reporting"}))
+
+	// Assert
+	assert.That(t, "principal must be service", seen.Type, shared.PrincipalService)
+	assert.That(t, "scope must be granted", seen.HasScope("payments:read"), true)
+	assert.That(t, "usage must be recorded", registry.usage["reporting"], int64(1))
+}
+
+func Test_WithServiceAccount_With_Unregistered_Client_Should_Return_403(t *testing.T) {
+	// Arrange
+	registry := &mockServiceAccounts{scopes: map[string][]string{}, usage: map[string]int64{}}
+	handler := inbound.WithServiceAccount(registry, slog.Default(), func(w http.ResponseWriter, r *http.Request) {})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, ClientID: "unknown"}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_WithServiceAccount_With_User_Token_Should_Keep_Principal(t *testing.T) {
+	// Arrange
+	registry := &mockServiceAccounts{scopes: map[string][]string{}, usage: map[string]int64{}}
+	var seen shared.Principal
+	handler := inbound.WithServiceAccount(registry, slog.Default(), func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = shared.PrincipalFromContext(r.Context())
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalGuest, Subject: "user-1"}))
+
+	// Assert
+	assert.That(t, "principal must stay guest", seen.Type, shared.PrincipalGuest)
+}
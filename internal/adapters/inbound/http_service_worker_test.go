@@ -138,3 +138,45 @@ func Test_HttpViewServiceWorker_With_Request_Should_Contain_Fetch_Handler(t *tes
 	bodyStr := string(body)
 	assert.That(t, "body must contain fetch event listener", containsString(bodyStr, "addEventListener('fetch'"), true)
 }
+
+func Test_HttpViewServiceWorker_With_Request_Should_Fall_Back_To_Offline_Shell(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "test-app")
+	t.Setenv("APP_VERSION", "1.0.0")
+	e := templating.NewEngine(serviceWorkerTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	handler := inbound.HttpViewServiceWorker(e)
+	req := httptest.NewRequest(http.MethodGet, "/sw.js", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must precache the offline shell", containsString(bodyStr, "'/ui/offline'"), true)
+	assert.That(t, "body must cache the reservations list", containsString(bodyStr, "'/ui/reservations'"), true)
+}
+
+func Test_HttpViewServiceWorker_With_Request_Should_Contain_Push_Handler(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "test-app")
+	t.Setenv("APP_VERSION", "1.0.0")
+	e := templating.NewEngine(serviceWorkerTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+
+	handler := inbound.HttpViewServiceWorker(e)
+	req := httptest.NewRequest(http.MethodGet, "/sw.js", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	bodyStr := string(body)
+	assert.That(t, "body must contain push event listener", containsString(bodyStr, "addEventListener('push'"), true)
+	assert.That(t, "body must contain notificationclick event listener", containsString(bodyStr, "addEventListener('notificationclick'"), true)
+}
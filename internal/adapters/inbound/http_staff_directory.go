@@ -0,0 +1,43 @@
+package inbound
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// WithStaffDirectory limits staff principals to their provisioned account.
+// Provisioned staff get the scopes of their roles; disabled and deprovisioned staff are rejected with 403.
+// Staff that was never provisioned keeps the permissions of its roles (WithRoles), so the directory can be filled gradually.
+// It must be wrapped by WithTokenAuth and run after WithServiceAccount has marked service accounts.
+func WithStaffDirectory(staffService *staff.Service, logger *slog.Logger, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := shared.PrincipalFromContext(r.Context())
+		if !ok || principal.Type != shared.PrincipalStaff {
+			next(w, r)
+			return
+		}
+
+		access, err := staffService.Access(r.Context(), principal.Email)
+		if errors.Is(err, staff.ErrStaffNotFound) {
+			next(w, r)
+			return
+		}
+		if err != nil {
+			writeBearerAuthError(w, http.StatusServiceUnavailable, "Staff directory unavailable")
+			return
+		}
+		if !access.Active {
+			logger.Warn("disabled staff member rejected", "staff_id", access.MemberID, "issuer", principal.Issuer)
+			writeBearerAuthError(w, http.StatusForbidden, "Staff access disabled")
+			return
+		}
+
+		principal.Scopes = access.Scopes
+		principal.Managed = true
+		next(w, r.WithContext(shared.ContextWithPrincipal(r.Context(), principal)))
+	}
+}
@@ -0,0 +1,67 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// ============================================================================
+// WithStaffDirectory Tests
+// ============================================================================
+
+func Test_WithStaffDirectory_With_Provisioned_Staff_Should_Limit_To_Role_Scopes(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	_, _ = svc.Provision(context.Background(), "staff-001", staff.MemberData{UserName: "anna@hotel.example", Roles: []staff.Role{"finance"}, Active: true})
+	var scopeErr error
+	handler := inbound.WithStaffDirectory(svc, slog.Default(), func(w http.ResponseWriter, r *http.Request) {
+		scopeErr = shared.RequireScope(r.Context(), "reservations:write")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Email: "anna@hotel.example"}))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "scope outside the roles must be denied", scopeErr != nil, true)
+}
+
+func Test_WithStaffDirectory_With_Disabled_Staff_Should_Return_403(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	_, _ = svc.Provision(context.Background(), "staff-001", staff.MemberData{UserName: "anna@hotel.example", Active: false})
+	called := false
+	handler := inbound.WithStaffDirectory(svc, slog.Default(), func(w http.ResponseWriter, r *http.Request) { called = true })
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Email: "anna@hotel.example"}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+	assert.That(t, "handler must not be called", called, false)
+}
+
+func Test_WithStaffDirectory_With_Unprovisioned_Staff_Should_Keep_Access(t *testing.T) {
+	// Arrange
+	var scopeErr error
+	handler := inbound.WithStaffDirectory(createTestStaffService(), slog.Default(), func(w http.ResponseWriter, r *http.Request) {
+		scopeErr = shared.RequireScope(r.Context(), "reservations:write")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, requestWithPrincipal(shared.Principal{Type: shared.PrincipalStaff, Email: "bob@hotel.example"}))
+
+	// Assert
+	assert.That(t, "scope must not be restricted", scopeErr == nil, true)
+}
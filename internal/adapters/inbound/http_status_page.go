@@ -0,0 +1,242 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+)
+
+// This file contains the data of the public status page and the admin API of its incidents.
+// The status page only shows components and their status, never the dependencies behind them,
+// their errors or latencies; those stay on /readiness and the diagnostic bundle.
+
+// StatusComponentChecks maps each status page component to the readiness checks it depends on,
+// e.g. payments to payment_database and kafka. Checks that are not registered are ignored.
+type StatusComponentChecks map[incident.Component][]string
+
+// HttpStatusComponent is a component on the status page.
+type HttpStatusComponent struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// HttpStatusIncidentUpdate is an update posted on an incident.
+type HttpStatusIncidentUpdate struct {
+	Stage   string    `json:"stage"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+// HttpStatusIncident is an incident on the status page and in the admin API.
+type HttpStatusIncident struct {
+	ID         string                     `json:"id"`
+	Title      string                     `json:"title"`
+	Impact     string                     `json:"impact"`
+	Components []string                   `json:"components"`
+	Stage      string                     `json:"stage"`
+	Updates    []HttpStatusIncidentUpdate `json:"updates"` // newest first
+	StartedAt  time.Time                  `json:"started_at"`
+	ResolvedAt *time.Time                 `json:"resolved_at,omitempty"`
+}
+
+// HttpStatusReport is the body of the status page data endpoint.
+type HttpStatusReport struct {
+	Status     string                `json:"status"`
+	Components []HttpStatusComponent `json:"components"`
+	Incidents  []HttpStatusIncident  `json:"incidents"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// HttpAdminOpenIncidentRequest specifies the body of a new incident, e.g.
+// {"title": "Payments delayed", "message": "We are investigating", "impact": "partial_outage", "components": ["payments"]}.
+type HttpAdminOpenIncidentRequest struct {
+	Title      string   `json:"title"`
+	Message    string   `json:"message"`
+	Impact     string   `json:"impact"`
+	Components []string `json:"components"`
+}
+
+// HttpAdminIncidentUpdateRequest specifies the body of an incident update, e.g.
+// {"stage": "resolved", "message": "Payments are processed again"}.
+type HttpAdminIncidentUpdateRequest struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
+
+// ComponentHealth derives the health of the status page components from a readiness report:
+// a component is in a major outage while one of its critical checks is down and degraded
+// while one of its other checks is down.
+func ComponentHealth(report ReadinessReport, checks StatusComponentChecks) map[incident.Component]incident.ComponentStatus {
+	health := make(map[incident.Component]incident.ComponentStatus, len(checks))
+	for component, names := range checks {
+		status := incident.StatusOperational
+		for _, name := range names {
+			dependency, ok := report.Dependencies[name]
+			switch {
+			case !ok || dependency.Status != ReadinessDown:
+			case dependency.Critical:
+				status = status.Worse(incident.StatusMajor)
+			default:
+				status = status.Worse(incident.StatusDegraded)
+			}
+		}
+		health[component] = status
+	}
+	return health
+}
+
+// HttpStatusPage serves the data of the public status page as JSON: the overall status, the status of
+// the booking engine, payments and notifications and the active incidents. Everyone may read it,
+// also from other origins, so a status page hosted elsewhere can poll it. If the incidents cannot
+// be read, the measured health is still served, since the page matters most during an outage.
+func HttpStatusPage(readiness *Readiness, checks StatusComponentChecks, incidentService *incident.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		updatedAt := time.Now()
+		var health map[incident.Component]incident.ComponentStatus
+		if readiness != nil {
+			report := readiness.Check(r.Context())
+			health = ComponentHealth(report, checks)
+			updatedAt = report.CheckedAt
+		}
+
+		incidents, err := incidentService.List(r.Context())
+		if err != nil {
+			logger.Warn("status page served without incidents", "error", err)
+		}
+		summary := incident.Summarize(health, incidents)
+
+		out := HttpStatusReport{
+			Status:     string(summary.Status),
+			Components: make([]HttpStatusComponent, 0, len(summary.Components)),
+			Incidents:  make([]HttpStatusIncident, 0, len(summary.Incidents)),
+			UpdatedAt:  updatedAt.UTC(),
+		}
+		for _, c := range summary.Components {
+			out.Components = append(out.Components, HttpStatusComponent{Name: string(c.Component), Status: string(c.Status)})
+		}
+		for _, i := range summary.Incidents {
+			out.Incidents = append(out.Incidents, incidentJSON(&i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=30")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+}
+
+// HttpAdminIncidents returns all incidents as JSON, newest first.
+func HttpAdminIncidents(incidentService *incident.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		incidents, err := incidentService.List(r.Context())
+		if err != nil {
+			incidentError(w, err)
+			return
+		}
+		out := make([]HttpStatusIncident, 0, len(incidents))
+		for _, i := range incidents {
+			out = append(out, incidentJSON(&i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}
+}
+
+// HttpAdminOpenIncident opens an incident from the JSON body and answers 201 Created with it.
+func HttpAdminOpenIncident(incidentService *incident.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminOpenIncidentRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		components := make([]incident.Component, 0, len(req.Components))
+		for _, c := range req.Components {
+			components = append(components, incident.Component(c))
+		}
+		opened, err := incidentService.Open(r.Context(), incident.IncidentID(security.GenerateID()), req.Title, req.Message, incident.ComponentStatus(req.Impact), components)
+		if err != nil {
+			incidentError(w, err)
+			return
+		}
+
+		logger.Info("incident opened",
+			"audit", true,
+			"incident_id", opened.ID,
+			"impact", opened.Impact,
+			"components", opened.Components,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(incidentJSON(opened))
+	}
+}
+
+// HttpAdminPostIncidentUpdate posts an update on the incident given in the path and returns the incident;
+// the stage resolved resolves it.
+func HttpAdminPostIncidentUpdate(incidentService *incident.Service, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req HttpAdminIncidentUpdateRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*1024)).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		updated, err := incidentService.Post(r.Context(), incident.IncidentID(r.PathValue("id")), incident.Stage(req.Stage), req.Message)
+		if err != nil {
+			incidentError(w, err)
+			return
+		}
+
+		logger.Info("incident updated",
+			"audit", true,
+			"incident_id", updated.ID,
+			"stage", updated.Stage,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(incidentJSON(updated))
+	}
+}
+
+// incidentJSON returns the incident for the status page and the admin API, with the newest update first.
+func incidentJSON(i *incident.Incident) HttpStatusIncident {
+	out := HttpStatusIncident{
+		ID:         string(i.ID),
+		Title:      i.Title,
+		Impact:     string(i.Impact),
+		Components: make([]string, 0, len(i.Components)),
+		Stage:      string(i.Stage),
+		Updates:    make([]HttpStatusIncidentUpdate, 0, len(i.Updates)),
+		StartedAt:  i.StartedAt.UTC(),
+	}
+	for _, c := range i.Components {
+		out.Components = append(out.Components, string(c))
+	}
+	for k := len(i.Updates) - 1; k >= 0; k-- {
+		u := i.Updates[k]
+		out.Updates = append(out.Updates, HttpStatusIncidentUpdate{Stage: string(u.Stage), Message: u.Message, At: u.At.UTC()})
+	}
+	if i.IsResolved() {
+		resolvedAt := i.ResolvedAt.UTC()
+		out.ResolvedAt = &resolvedAt
+	}
+	return out
+}
+
+// incidentError writes the HTTP error of an incident action.
+func incidentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, incident.ErrMissingTitle), errors.Is(err, incident.ErrMissingMessage), errors.Is(err, incident.ErrInvalidImpact),
+		errors.Is(err, incident.ErrUnknownComponent), errors.Is(err, incident.ErrNoComponents), errors.Is(err, incident.ErrInvalidStage):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, incident.ErrIncidentNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, incident.ErrAlreadyResolved):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "incident action failed", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,170 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestIncidentService() *incident.Service {
+	return incident.NewService(resource.NewInMemoryAccess[incident.IncidentID, incident.Incident]())
+}
+
+var testStatusComponents = inbound.StatusComponentChecks{
+	incident.ComponentBookingEngine: {"reservation_database", "kafka"},
+	incident.ComponentPayments:      {"payment_database", "kafka"},
+	incident.ComponentNotifications: {"kafka", "email_provider"},
+}
+
+func serveStatusPage(readiness *inbound.Readiness, incidentService *incident.Service) (*httptest.ResponseRecorder, inbound.HttpStatusReport) {
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	rec := httptest.NewRecorder()
+	inbound.HttpStatusPage(readiness, testStatusComponents, incidentService, slog.Default())(rec, req)
+	var report inbound.HttpStatusReport
+	_ = json.NewDecoder(rec.Body).Decode(&report)
+	return rec, report
+}
+
+// ============================================================================
+// HttpStatusPage Tests
+// ============================================================================
+
+func Test_HttpStatusPage_With_All_Checks_Up_Should_Report_Operational(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("reservation_database", true, nil))
+	readiness.Register(readinessCheck("kafka", true, nil))
+
+	// Act
+	rec, report := serveStatusPage(readiness, createTestIncidentService())
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "status must be operational", report.Status, "operational")
+	assert.That(t, "components must be listed", report.Components, []inbound.HttpStatusComponent{
+		{Name: "booking_engine", Status: "operational"},
+		{Name: "payments", Status: "operational"},
+		{Name: "notifications", Status: "operational"},
+	})
+	assert.That(t, "page must be readable from other origins", rec.Header().Get("Access-Control-Allow-Origin"), "*")
+}
+
+func Test_HttpStatusPage_With_Check_Down_Should_Not_Leak_Details(t *testing.T) {
+	// Arrange
+	readiness := inbound.NewReadiness(time.Second, 0)
+	readiness.Register(readinessCheck("payment_database", true, errors.New("dial tcp 10.0.3.7:5432: connection refused")))
+	readiness.Register(readinessCheck("email_provider", false, errors.New("smtp.internal: timeout")))
+
+	// Act
+	rec, report := serveStatusPage(readiness, createTestIncidentService())
+
+	// Assert
+	assert.That(t, "critical check down must be a major outage", report.Components[1].Status, "major_outage")
+	assert.That(t, "other check down must degrade", report.Components[2].Status, "degraded_performance")
+	assert.That(t, "status must be the worst", report.Status, "major_outage")
+	for _, leak := range []string{"10.0.3.7", "smtp.internal", "payment_database", "latency"} {
+		assert.That(t, "body must not contain "+leak, strings.Contains(rec.Body.String(), leak), false)
+	}
+}
+
+func Test_HttpStatusPage_Should_List_Active_Incidents(t *testing.T) {
+	// Arrange
+	incidentService := createTestIncidentService()
+	ctx := context.Background()
+	_, _ = incidentService.Open(ctx, "inc-001", "Confirmation emails delayed", "We are investigating", incident.StatusPartial, []incident.Component{incident.ComponentNotifications})
+	_, _ = incidentService.Post(ctx, "inc-001", incident.StageIdentified, "A provider is slow")
+
+	// Act
+	_, report := serveStatusPage(nil, incidentService)
+
+	// Assert
+	assert.That(t, "status must follow the incident", report.Status, "partial_outage")
+	assert.That(t, "notifications must show the impact", report.Components[2].Status, "partial_outage")
+	assert.That(t, "incident must be listed", len(report.Incidents), 1)
+	assert.That(t, "newest update must come first", report.Incidents[0].Updates[0].Message, "A provider is slow")
+}
+
+// ============================================================================
+// Admin Incident API Tests
+// ============================================================================
+
+func Test_HttpAdminOpenIncident_Should_Return_201(t *testing.T) {
+	// Arrange
+	incidentService := createTestIncidentService()
+	body := `{"title":"Payments delayed","message":"We are investigating","impact":"degraded_performance","components":["payments"]}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminOpenIncident(incidentService, slog.Default())(rec, req)
+
+	// Assert
+	var opened inbound.HttpStatusIncident
+	_ = json.NewDecoder(rec.Body).Decode(&opened)
+	incidents, _ := incidentService.List(context.Background())
+	assert.That(t, "status code must be 201", rec.Code, http.StatusCreated)
+	assert.That(t, "stage must be investigating", opened.Stage, "investigating")
+	assert.That(t, "incident must be stored", len(incidents), 1)
+}
+
+func Test_HttpAdminOpenIncident_With_Unknown_Component_Should_Return_400(t *testing.T) {
+	// Arrange
+	body := `{"title":"Database down","message":"We are investigating","impact":"major_outage","components":["postgres"]}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminOpenIncident(createTestIncidentService(), slog.Default())(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminPostIncidentUpdate_Resolved_Should_Remove_Incident_From_Status_Page(t *testing.T) {
+	// Arrange
+	incidentService := createTestIncidentService()
+	_, _ = incidentService.Open(context.Background(), "inc-001", "Payments delayed", "We are investigating", incident.StatusDegraded, []incident.Component{incident.ComponentPayments})
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/inc-001/updates", strings.NewReader(`{"stage":"resolved","message":"Payments are processed again"}`))
+	req.SetPathValue("id", "inc-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminPostIncidentUpdate(incidentService, slog.Default())(rec, req)
+
+	// Assert
+	var updated inbound.HttpStatusIncident
+	_ = json.NewDecoder(rec.Body).Decode(&updated)
+	_, report := serveStatusPage(nil, incidentService)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "resolution time must be set", updated.ResolvedAt != nil, true)
+	assert.That(t, "status page must be operational", report.Status, "operational")
+	assert.That(t, "status page must list no incidents", len(report.Incidents), 0)
+}
+
+func Test_HttpAdminPostIncidentUpdate_Unknown_Incident_Should_Return_404(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/inc-404/updates", strings.NewReader(`{"stage":"resolved","message":"Fixed"}`))
+	req.SetPathValue("id", "inc-404")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpAdminPostIncidentUpdate(createTestIncidentService(), slog.Default())(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,113 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// HttpViewSurveyResponse specifies the view data for the NPS survey page.
+type HttpViewSurveyResponse struct {
+	AppName       string
+	Title         string
+	SessionID     string
+	SurveyID      string
+	ReservationID string
+	Answered      bool
+	Score         int
+	Comment       string
+	Scores        []int
+}
+
+// HttpViewSurvey defines an HTTP handler function for rendering the NPS survey of a stay.
+// Only the guest the survey was sent to may see it.
+func HttpViewSurvey(e *templating.Engine, surveyService *survey.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Your Stay"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		s, err := surveyService.GetSurvey(ctx, survey.SurveyID(r.PathValue("id")))
+		if err != nil {
+			http.Error(w, "Survey not found", http.StatusNotFound)
+			return
+		}
+		if s.GuestID != survey.GuestID(guestID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+
+		data := HttpViewSurveyResponse{
+			AppName:       appName,
+			Title:         title,
+			SessionID:     sessionID,
+			SurveyID:      string(s.ID),
+			ReservationID: string(s.ReservationID),
+			Answered:      s.IsAnswered(),
+			Score:         s.Score,
+			Comment:       s.Comment,
+			Scores:        []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		}
+
+		HttpView(e, "survey", data)(w, r)
+	}
+}
+
+// HttpSubmitSurvey defines an HTTP handler function for answering the NPS survey of a stay.
+// It redirects back to the survey page, which then thanks the guest.
+func HttpSubmitSurvey(surveyService *survey.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		score, err := strconv.Atoi(r.FormValue("score"))
+		if err != nil {
+			http.Error(w, "Please select a score", http.StatusBadRequest)
+			return
+		}
+
+		id := survey.SurveyID(r.PathValue("id"))
+		if _, err := surveyService.GetSurvey(ctx, id); err != nil {
+			http.Error(w, "Survey not found", http.StatusNotFound)
+			return
+		}
+		if _, err := surveyService.SubmitAnswer(ctx, id, survey.GuestID(guestID), score, r.FormValue("comment")); err != nil {
+			switch {
+			case errors.Is(err, survey.ErrNotSurveyRecipient):
+				http.Error(w, "Access denied", http.StatusForbidden)
+			case errors.Is(err, survey.ErrInvalidScore), errors.Is(err, survey.ErrCommentTooLong):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, survey.ErrAlreadyAnswered):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, "Failed to submit survey", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		http.Redirect(w, r, "/ui/surveys/"+string(id), http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,153 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockSurveySender struct{}
+
+func (m *mockSurveySender) SendSurveyInvitation(ctx context.Context, s *survey.Survey) error {
+	return nil
+}
+
+func createTestSurveyService() *survey.Service {
+	svc := survey.NewService(resource.NewInMemoryAccess[survey.SurveyID, survey.Survey](), &mockSurveySender{}, "Test Hotel")
+	_, _ = svc.SendSurvey(context.Background(), "res-001", "guest-001", "guest@example.com")
+	return svc
+}
+
+func surveyRequest(method, subject string, form url.Values) *http.Request {
+	var req *http.Request
+	if form != nil {
+		req = httptest.NewRequest(method, "/ui/surveys/nps-res-001", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req = httptest.NewRequest(method, "/ui/surveys/nps-res-001", nil)
+	}
+	req.SetPathValue("id", "nps-res-001")
+	return addGuestContext(req, subject, subject+"@example.com")
+}
+
+// ============================================================================
+// HttpViewSurvey Tests
+// ============================================================================
+
+func Test_HttpViewSurvey_Should_Render_Form(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewSurvey(e, createTestSurveyService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodGet, "guest-001", nil))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain form", strings.Contains(rec.Body.String(), `action="/ui/surveys/nps-res-001"`), true)
+	assert.That(t, "body must contain score 10", strings.Contains(rec.Body.String(), `value="10"`), true)
+}
+
+func Test_HttpViewSurvey_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewSurvey(e, createTestSurveyService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodGet, "guest-002", nil))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
+
+func Test_HttpViewSurvey_Without_Session_Should_Redirect_To_Login(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(detailTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewSurvey(e, createTestSurveyService())
+	req := httptest.NewRequest(http.MethodGet, "/ui/surveys/nps-res-001", nil)
+	req.SetPathValue("id", "nps-res-001")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be login", rec.Header().Get("Location"), "/ui/login")
+}
+
+// ============================================================================
+// HttpSubmitSurvey Tests
+// ============================================================================
+
+func Test_HttpSubmitSurvey_Should_Store_Answer_And_Redirect(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService()
+	handler := inbound.HttpSubmitSurvey(svc)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodPost, "guest-001", url.Values{"score": {"9"}, "comment": {"Great view"}}))
+
+	// Assert
+	s, _ := svc.GetSurvey(context.Background(), "nps-res-001")
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be survey page", rec.Header().Get("Location"), "/ui/surveys/nps-res-001")
+	assert.That(t, "score must be stored", s.Score, 9)
+	assert.That(t, "comment must be stored", s.Comment, "Great view")
+}
+
+func Test_HttpSubmitSurvey_Without_Score_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpSubmitSurvey(createTestSurveyService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodPost, "guest-001", url.Values{"comment": {"No score"}}))
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpSubmitSurvey_Twice_Should_Return_409(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpSubmitSurvey(createTestSurveyService())
+	handler(httptest.NewRecorder(), surveyRequest(http.MethodPost, "guest-001", url.Values{"score": {"9"}}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodPost, "guest-001", url.Values{"score": {"2"}}))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+func Test_HttpSubmitSurvey_By_Other_Guest_Should_Return_403(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpSubmitSurvey(createTestSurveyService())
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, surveyRequest(http.MethodPost, "guest-002", url.Values{"score": {"9"}}))
+
+	// Assert
+	assert.That(t, "status code must be 403", rec.Code, http.StatusForbidden)
+}
@@ -0,0 +1,77 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// HTTPTracer starts the server span of a request, continuing the trace of the caller given in the
+// traceparent header. outbound.Tracer implements it.
+type HTTPTracer interface {
+	StartServerSpan(ctx context.Context, traceparent, name string, attrs ...string) (context.Context, shared.Span)
+}
+
+// WithTracing handles the request in a server span named after its route, e.g. "GET /ui/reservations/{id}",
+// so the spans of the services, the database and the events of the request join its trace.
+// Responses with a 5xx status mark the span as failed.
+func WithTracing(tracer HTTPTracer, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.Pattern
+		if name == "" || name[0] == '/' {
+			name = r.Method + " " + name
+		}
+		ctx, span := tracer.StartServerSpan(r.Context(), r.Header.Get("traceparent"), name,
+			"http.request.method", r.Method,
+			"http.route", r.Pattern,
+			"url.path", r.URL.Path,
+		)
+		rec := &tracingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r.WithContext(ctx))
+
+		span.SetAttributes("http.response.status_code", strconv.Itoa(rec.status))
+		if rec.status >= http.StatusInternalServerError {
+			span.End(errors.New(http.StatusText(rec.status)))
+			return
+		}
+		span.End(nil)
+	}
+}
+
+// tracingResponseWriter records the status code of the response.
+type tracingResponseWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the first status code.
+func (w *tracingResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write sends the body, implying the status 200 if none was written.
+func (w *tracingResponseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush flushes streamed responses, e.g. server-sent events.
+func (w *tracingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *tracingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
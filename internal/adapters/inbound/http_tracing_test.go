@@ -0,0 +1,83 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+var _ inbound.HTTPTracer = (*outbound.Tracer)(nil)
+
+// recordingTracer records the server span of a request.
+type recordingTracer struct {
+	traceparent string
+	name        string
+	attrs       []string
+	err         error
+	ended       bool
+}
+
+type tracerCtxKey struct{}
+
+func (t *recordingTracer) StartServerSpan(ctx context.Context, traceparent, name string, attrs ...string) (context.Context, shared.Span) {
+	t.traceparent, t.name, t.attrs = traceparent, name, attrs
+	return context.WithValue(ctx, tracerCtxKey{}, name), t
+}
+
+func (t *recordingTracer) SetAttributes(attrs ...string) { t.attrs = append(t.attrs, attrs...) }
+
+func (t *recordingTracer) End(err error) { t.err, t.ended = err, true }
+
+// ============================================================================
+// WithTracing Tests
+// ============================================================================
+
+func Test_WithTracing_Should_Name_Span_After_Route(t *testing.T) {
+	// Arrange
+	tracer := &recordingTracer{}
+	var spanInContext any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ui/reservations/{id}", inbound.WithTracing(tracer, func(w http.ResponseWriter, r *http.Request) {
+		spanInContext = r.Context().Value(tracerCtxKey{})
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/res-1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "span must be named after the route", tracer.name, "GET /ui/reservations/{id}")
+	assert.That(t, "traceparent of the caller must be passed", tracer.traceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.That(t, "handler must run in the span", spanInContext, any("GET /ui/reservations/{id}"))
+	assert.That(t, "status code must be recorded", tracer.attrs[len(tracer.attrs)-1], "404")
+	assert.That(t, "client errors must not fail the span", tracer.err, nil)
+	assert.That(t, "span must be ended", tracer.ended, true)
+}
+
+func Test_WithTracing_When_Server_Error_Should_Fail_Span(t *testing.T) {
+	// Arrange
+	tracer := &recordingTracer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/", inbound.WithTracing(tracer, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/admin/x", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "span of a route without method must get the method", tracer.name, "POST /admin/")
+	assert.That(t, "span must fail", tracer.err != nil, true)
+	assert.That(t, "response must be passed", rec.Code, http.StatusInternalServerError)
+}
@@ -1,14 +1,120 @@
 package inbound
 
 import (
+	"bytes"
+	"html"
+	"log/slog"
 	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
 
+	"github.com/andygeiss/cloud-native-utils/security"
 	"github.com/andygeiss/cloud-native-utils/templating"
 )
 
 // HttpView defines an HTTP handler function for rendering a template with data.
 // We use the templating engine from the cloud-native-utils package.
-// We do not test the HttpView function because it's already tested in the cloud-native-utils package.
+// The template is rendered into a buffer first, so a failing template (e.g. a missing field)
+// never sends half a page: the error is logged and the error page is shown instead.
 func HttpView(e *templating.Engine, name string, data any) http.HandlerFunc {
-	return e.View(name, data)
+	return func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if err := e.Render(&buf, name, data); err != nil {
+			renderViewError(w, r, e, name, data, err)
+			return
+		}
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// HttpCachedView renders a template with static data only once and serves the cached bytes afterwards.
+// Use it only for views whose data does not depend on the request (e.g. login, manifest).
+func HttpCachedView(e *templating.Engine, name string, data any) http.HandlerFunc {
+	var (
+		once     sync.Once
+		rendered []byte
+		err      error
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			var buf bytes.Buffer
+			err = e.Render(&buf, name, data)
+			rendered = buf.Bytes()
+		})
+		if err != nil {
+			renderViewError(w, r, e, name, data, err)
+			return
+		}
+		_, _ = w.Write(rendered)
+	}
+}
+
+// renderViewError is the error boundary of the views. It logs the failure with the template name,
+// the keys of the data (never the values, which may hold guest data) and a correlation ID,
+// and answers 500 with the error page showing the ID and a retry link for GET requests.
+// The correlation ID is the request ID if the route is wrapped with WithRequestID.
+// Handlers have no logger of their own, so the failure goes to the default logger.
+func renderViewError(w http.ResponseWriter, r *http.Request, e *templating.Engine, name string, data any, err error) {
+	id := RequestIDFromContext(r.Context())
+	if id == "" {
+		id = security.GenerateID()
+		w.Header().Set(requestIDHeader, id)
+	}
+	slog.Default().ErrorContext(r.Context(), "failed to render view",
+		"template", name,
+		"data_keys", viewDataKeys(data),
+		"correlation_id", id,
+		"error", err,
+	)
+
+	page := HttpViewErrorResponse{
+		AppName:       os.Getenv("APP_NAME"),
+		Title:         os.Getenv("APP_NAME") + " - Error",
+		ErrorTitle:    "This Page Could Not Be Displayed",
+		ErrorMessage:  "Something went wrong while preparing this page. Please try again.",
+		CorrelationID: id,
+	}
+	if r.Method == http.MethodGet {
+		// The error template is a text template, so the URL is escaped here.
+		page.RetryURL = html.EscapeString(r.URL.RequestURI())
+	}
+	var buf bytes.Buffer
+	if name == "error" || e.Render(&buf, "error", page) != nil {
+		http.Error(w, "The page could not be displayed. Reference: "+id, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// viewDataKeys returns the sorted field names of a struct or the keys of a map with string keys.
+func viewDataKeys(data any) []string {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	var keys []string
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, field := range reflect.VisibleFields(v.Type()) {
+			if field.IsExported() && !field.Anonymous {
+				keys = append(keys, field.Name)
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			for _, key := range v.MapKeys() {
+				keys = append(keys, key.String())
+			}
+		}
+	}
+	slices.Sort(keys)
+	return keys
 }
@@ -0,0 +1,83 @@
+package inbound
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+)
+
+// HttpViewReportUnsubscribeResponse specifies the view data for the unsubscribe page of report emails.
+type HttpViewReportUnsubscribeResponse struct {
+	AppName      string
+	Title        string
+	Token        string
+	Email        string
+	Report       string // e.g. "occupancy report of main"
+	Schedule     string
+	Unsubscribed bool
+	NotFound     bool // the link was used or the subscription deleted
+}
+
+// HttpViewReportUnsubscribe renders the page of the unsubscribe link in report emails. It asks to
+// confirm instead of unsubscribing right away, because mail scanners open the links of emails.
+func HttpViewReportUnsubscribe(e *templating.Engine, reportService *report.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Unsubscribe"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := HttpViewReportUnsubscribeResponse{AppName: appName, Title: title, Token: r.PathValue("token")}
+		sub, recipient, err := reportService.ByToken(r.Context(), data.Token)
+		if !reportUnsubscribeView(w, &data, sub, recipient, err) {
+			return
+		}
+		HttpView(e, "report_unsubscribe", data)(w, r)
+	}
+}
+
+// HttpReportUnsubscribe handles the POST request of the unsubscribe page: the recipient of the token
+// no longer gets the report; the subscription is deleted with its last recipient.
+func HttpReportUnsubscribe(e *templating.Engine, reportService *report.Service, logger *slog.Logger) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Unsubscribe"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := HttpViewReportUnsubscribeResponse{AppName: appName, Title: title, Token: r.PathValue("token")}
+		sub, recipient, err := reportService.Unsubscribe(r.Context(), data.Token)
+		if !reportUnsubscribeView(w, &data, sub, recipient, err) {
+			return
+		}
+
+		if !data.NotFound {
+			logger.Info("report subscription unsubscribed",
+				"audit", true,
+				"subscription_id", sub.ID,
+				"property_id", sub.PropertyID,
+				"recipients_left", len(sub.Recipients),
+			)
+			data.Unsubscribed = true
+		}
+		HttpView(e, "report_unsubscribe", data)(w, r)
+	}
+}
+
+// reportUnsubscribeView fills the view from the subscription of the token. An unknown token shows
+// the page with status 404; other errors are answered with 500 and false is returned.
+func reportUnsubscribeView(w http.ResponseWriter, data *HttpViewReportUnsubscribeResponse, sub *report.Subscription, recipient report.Recipient, err error) bool {
+	switch {
+	case errors.Is(err, report.ErrNotSubscribed):
+		data.NotFound = true
+		w.WriteHeader(http.StatusNotFound)
+	case err != nil:
+		http.Error(w, "Failed to load the report subscription", http.StatusInternalServerError)
+		return false
+	default:
+		data.Email = recipient.Email
+		data.Report = string(sub.Type) + " report of " + string(sub.PropertyID)
+		data.Schedule = sub.Schedule.String()
+	}
+	return true
+}
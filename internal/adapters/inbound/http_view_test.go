@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"testing/fstest"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/templating"
@@ -72,3 +73,48 @@ func Test_HttpView_With_Data_Should_Render_Data_In_Template(t *testing.T) {
 	bodyStr := string(body)
 	assert.That(t, "body must contain custom app name", containsString(bodyStr, "MyCustomApp"), true)
 }
+
+func Test_HttpView_When_Render_Fails_Should_Show_Error_Page_With_Correlation_ID(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"error.tmpl":  {Data: []byte(`{{ define "error" }}<h1>{{ .ErrorTitle }}</h1><p>{{ .CorrelationID }}</p><a href="{{ .RetryURL }}">Try Again</a>{{ end }}`)},
+		"broken.tmpl": {Data: []byte(`{{ define "broken" }}<p>partial</p>{{ .Missing }}{{ end }}`)},
+	}
+	e := templating.NewEngine(fsys)
+	e.Parse("*.tmpl")
+	handler := inbound.WithRequestID(inbound.HttpView(e, "broken", struct{ Name string }{Name: "Alex"}))
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations?page=2&sort=<b>", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.That(t, "status code must be 500", rec.Code, http.StatusInternalServerError)
+	assert.That(t, "partial output must not be sent", containsString(body, "partial"), false)
+	assert.That(t, "correlation id must be the request id", containsString(body, "<p>req-123</p>"), true)
+	assert.That(t, "retry url must be escaped", containsString(body, `href="/ui/reservations?page=2&amp;sort=&lt;b&gt;"`), true)
+}
+
+func Test_HttpView_When_Error_Page_Fails_Should_Return_Plain_Error_With_Correlation_ID(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"broken.tmpl": {Data: []byte(`{{ define "broken" }}{{ .Missing }}{{ end }}`)},
+	}
+	e := templating.NewEngine(fsys)
+	e.Parse("*.tmpl")
+	handler := inbound.HttpView(e, "broken", struct{}{})
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	id := rec.Header().Get("X-Request-ID")
+	assert.That(t, "status code must be 500", rec.Code, http.StatusInternalServerError)
+	assert.That(t, "correlation id must be generated", id != "", true)
+	assert.That(t, "body must quote the correlation id", containsString(rec.Body.String(), "Reference: "+id), true)
+}
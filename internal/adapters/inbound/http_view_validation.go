@@ -0,0 +1,260 @@
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"text/template"
+	"text/template/parse"
+)
+
+// viewModels maps every page template to the view model its handler renders it with.
+// A new view must be added here, so ValidateViews checks it at startup.
+var viewModels = map[string]any{
+	"admin_dashboard":            HttpAdminDashboardResponse{},
+	"admin_report_subscriptions": HttpAdminReportSubscriptionsResponse{},
+	"admin_reservation":          HttpAdminReservationResponse{},
+	"admin_tiers":                HttpAdminTiersResponse{},
+	"admin_webhooks":             HttpAdminWebhooksResponse{},
+	"booking_lookup":             HttpViewBookingLookupResponse{},
+	"booking_manage":             HttpViewBookingManageResponse{},
+	"content_page":               HttpViewContentPageResponse{},
+	"error":                      HttpViewErrorResponse{},
+	"event_catalog":              HttpViewEventCatalogResponse{},
+	"household":                  HttpViewHouseholdResponse{},
+	"index":                      HttpViewIndexResponse{},
+	"login":                      HttpViewLoginResponse{},
+	"manifest":                   HttpViewManifestResponse{},
+	"profile":                    HttpViewProfileResponse{},
+	"referrals":                  HttpViewReferralsResponse{},
+	"report_unsubscribe":         HttpViewReportUnsubscribeResponse{},
+	"reservation_detail":         HttpViewReservationDetailResponse{},
+	"reservation_documents":      HttpViewReservationDocumentsResponse{},
+	"reservation_financials":     HttpViewReservationFinancialsResponse{},
+	"reservation_form":           HttpViewReservationFormResponse{},
+	"reservation_print":          HttpViewReservationPrintResponse{},
+	"reservation_weather":        HttpViewReservationWeatherResponse{},
+	"reservations":               HttpViewReservationsResponse{},
+	"survey":                     HttpViewSurveyResponse{},
+	"sw":                         HttpViewServiceWorkerResponse{},
+}
+
+// ValidateViews parses the templates matching the patterns and checks every view of viewModels:
+// the template and the templates it includes must exist, and every field it reads must exist in
+// the view model. Rendering would only find a missing field on the pages where the branch is
+// taken, so the check walks the parse trees with the types of the view models instead.
+// Values whose type is unknown (results of functions, interfaces) are not checked further.
+func ValidateViews(fsys fs.FS, patterns ...string) error {
+	// The functions must match those of templating.Engine, or parsing fails.
+	tmpl, err := template.New("views").Funcs(template.FuncMap{"add_int": func(a, b int) int { return a + b }}).ParseFS(fsys, patterns...)
+	if err != nil {
+		return fmt.Errorf("failed to parse views: %w", err)
+	}
+	var errs []error
+	for name, model := range viewModels {
+		c := viewChecker{tmpl: tmpl, checked: make(map[string]bool)}
+		c.checkTemplate(name, reflect.TypeOf(model))
+		for _, err := range c.errs {
+			errs = append(errs, fmt.Errorf("view %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// viewChecker walks the parse trees of a view; a nil type means the type is unknown.
+type viewChecker struct {
+	tmpl    *template.Template
+	checked map[string]bool
+	errs    []error
+}
+
+// viewScope is the type of dot and of the variables at a node.
+type viewScope struct {
+	tree *parse.Tree
+	dot  reflect.Type
+	vars map[string]reflect.Type
+}
+
+// with returns a copy of the scope with another dot, so variables declared inside do not leak.
+func (s viewScope) with(dot reflect.Type) viewScope {
+	vars := make(map[string]reflect.Type, len(s.vars))
+	for name, typ := range s.vars {
+		vars[name] = typ
+	}
+	return viewScope{tree: s.tree, dot: dot, vars: vars}
+}
+
+func (c *viewChecker) checkTemplate(name string, dot reflect.Type) {
+	key := name + "\x00" + fmt.Sprint(dot)
+	if c.checked[key] {
+		return
+	}
+	c.checked[key] = true
+	t := c.tmpl.Lookup(name)
+	if t == nil || t.Tree == nil {
+		c.errs = append(c.errs, fmt.Errorf("template %q is not defined", name))
+		return
+	}
+	c.checkNode(viewScope{tree: t.Tree, dot: dot, vars: map[string]reflect.Type{"$": dot}}, t.Tree.Root)
+}
+
+func (c *viewChecker) checkNode(s viewScope, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.checkNode(s, child)
+		}
+	case *parse.ActionNode:
+		c.checkPipe(s, n.Pipe)
+	case *parse.IfNode:
+		c.checkPipe(s, n.Pipe)
+		c.checkNode(s.with(s.dot), n.List)
+		c.checkNode(s.with(s.dot), n.ElseList)
+	case *parse.WithNode:
+		typ := c.checkPipe(s, n.Pipe)
+		c.checkNode(s.with(typ), n.List)
+		c.checkNode(s.with(s.dot), n.ElseList)
+	case *parse.RangeNode:
+		typ := c.checkPipe(s, n.Pipe)
+		key, elem := rangeTypes(typ)
+		inner := s.with(elem)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = key
+			inner.vars[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		c.checkNode(inner, n.List)
+		c.checkNode(s.with(s.dot), n.ElseList)
+	case *parse.TemplateNode:
+		var typ reflect.Type
+		if n.Pipe != nil {
+			typ = c.checkPipe(s, n.Pipe)
+		}
+		c.checkTemplate(n.Name, typ)
+	}
+}
+
+// checkPipe checks the commands of a pipeline, declares its variables and returns its type.
+func (c *viewChecker) checkPipe(s viewScope, pipe *parse.PipeNode) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var typ reflect.Type
+	for _, cmd := range pipe.Cmds {
+		typ = nil
+		for i, arg := range cmd.Args {
+			argType := c.checkArg(s, arg)
+			if i == 0 && len(cmd.Args) == 1 {
+				typ = argType
+			}
+		}
+	}
+	if len(pipe.Decl) == 1 && !pipe.IsAssign {
+		s.vars[pipe.Decl[0].Ident[0]] = typ
+	}
+	return typ
+}
+
+// checkArg checks an argument of a command and returns its type.
+func (c *viewChecker) checkArg(s viewScope, arg parse.Node) reflect.Type {
+	switch a := arg.(type) {
+	case *parse.DotNode:
+		return s.dot
+	case *parse.FieldNode:
+		return c.checkFields(s, a, s.dot, a.Ident)
+	case *parse.VariableNode:
+		return c.checkFields(s, a, s.vars[a.Ident[0]], a.Ident[1:])
+	case *parse.ChainNode:
+		return c.checkFields(s, a, c.checkArg(s, a.Node), a.Field)
+	case *parse.PipeNode:
+		return c.checkPipe(s.with(s.dot), a)
+	}
+	return nil
+}
+
+// checkFields resolves a chain of field names on typ and records the first missing field.
+func (c *viewChecker) checkFields(s viewScope, node parse.Node, typ reflect.Type, names []string) reflect.Type {
+	for _, name := range names {
+		if typ == nil {
+			return nil
+		}
+		next, ok := viewFieldType(typ, name)
+		if !ok {
+			location, _ := s.tree.ErrorContext(node)
+			c.errs = append(c.errs, fmt.Errorf("%s: can't evaluate field %s in type %s", location, name, typ))
+			return nil
+		}
+		typ = next
+	}
+	return typ
+}
+
+// viewFieldType returns the type of a field, method result or map value, like text/template
+// resolves .Name; ok is false if the name cannot be resolved. Unknown types resolve to nil.
+func viewFieldType(typ reflect.Type, name string) (reflect.Type, bool) {
+	if method, ok := typ.MethodByName(name); ok {
+		return resultType(method.Type), true
+	}
+	if typ.Kind() != reflect.Pointer && typ.Kind() != reflect.Interface {
+		if method, ok := reflect.PointerTo(typ).MethodByName(name); ok {
+			return resultType(method.Type), true
+		}
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		field, ok := typ.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return nil, false
+		}
+		return knownType(field.Type), true
+	case reflect.Map:
+		return knownType(typ.Elem()), true
+	case reflect.Interface:
+		return nil, true
+	}
+	return nil, false
+}
+
+// resultType returns the first result of a method, or nil if it has none.
+func resultType(method reflect.Type) reflect.Type {
+	if method.NumOut() == 0 {
+		return nil
+	}
+	return knownType(method.Out(0))
+}
+
+// knownType returns nil for interfaces, whose dynamic type is only known when rendering.
+func knownType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Interface {
+		return nil
+	}
+	return typ
+}
+
+// rangeTypes returns the key and element types of a range over typ.
+func rangeTypes(typ reflect.Type) (key, elem reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), knownType(typ.Elem())
+	case reflect.Map:
+		return knownType(typ.Key()), knownType(typ.Elem())
+	case reflect.Int:
+		return typ, typ
+	}
+	return nil, nil
+}
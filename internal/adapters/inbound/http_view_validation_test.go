@@ -0,0 +1,82 @@
+package inbound_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createViewTestFS copies the test templates and replaces the given ones.
+func createViewTestFS(t *testing.T, replace map[string]string) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	matches, _ := fs.Glob(viewTestAssets, "testdata/assets/templates/*.tmpl")
+	for _, path := range matches {
+		data, _ := fs.ReadFile(viewTestAssets, path)
+		fsys[path] = &fstest.MapFile{Data: data}
+	}
+	for name, data := range replace {
+		fsys["testdata/assets/templates/"+name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	return fsys
+}
+
+// ============================================================================
+// ValidateViews Tests
+// ============================================================================
+
+func Test_ValidateViews_With_Test_Templates_Should_Succeed(t *testing.T) {
+	// Arrange & Act
+	err := inbound.ValidateViews(viewTestAssets, "testdata/assets/templates/*.tmpl")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+}
+
+func Test_ValidateViews_With_Missing_Field_In_Branch_Should_Fail(t *testing.T) {
+	// Arrange
+	fsys := createViewTestFS(t, map[string]string{
+		"survey.tmpl": `{{ define "survey" }}{{ if .Answered }}{{ .Rating }}{{ end }}{{ end }}`,
+	})
+
+	// Act
+	err := inbound.ValidateViews(fsys, "testdata/assets/templates/*.tmpl")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "err must name the view and field", strings.Contains(err.Error(), `view "survey"`) && strings.Contains(err.Error(), "can't evaluate field Rating"), true)
+}
+
+func Test_ValidateViews_With_Missing_Field_In_Range_Should_Fail(t *testing.T) {
+	// Arrange
+	fsys := createViewTestFS(t, map[string]string{
+		"reservations.tmpl": `{{ define "reservations" }}{{ range $r := .Reservations }}{{ $r.ID }}{{ .Nights }}{{ end }}{{ end }}`,
+	})
+
+	// Act
+	err := inbound.ValidateViews(fsys, "testdata/assets/templates/*.tmpl")
+
+	// Assert
+	assert.That(t, "err must name the field of the element", err != nil && strings.Contains(err.Error(), "can't evaluate field Nights"), true)
+}
+
+func Test_ValidateViews_With_Undefined_Template_Should_Fail(t *testing.T) {
+	// Arrange
+	fsys := createViewTestFS(t, map[string]string{
+		"sw.tmpl": `{{ define "sw" }}{{ template "sw_cache" . }}{{ end }}`,
+	})
+
+	// Act
+	err := inbound.ValidateViews(fsys, "testdata/assets/templates/*.tmpl")
+
+	// Assert
+	assert.That(t, "err must name the template", err != nil && strings.Contains(err.Error(), `template "sw_cache" is not defined`), true)
+}
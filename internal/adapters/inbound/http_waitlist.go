@@ -0,0 +1,191 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// WaitlistJoin is the stay the guest could not book, offered to join the waitlist for on the reservation form.
+type WaitlistJoin struct {
+	RoomID   string
+	CheckIn  string
+	CheckOut string
+}
+
+// WaitlistEntryView represents a waitlist entry for the view.
+type WaitlistEntryView struct {
+	ID          string
+	RoomName    string
+	CheckIn     string
+	CheckOut    string
+	Status      string
+	StatusClass string
+	HoldUntil   string // set while the room is held for the guest
+	BookLink    string // set while the room is held for the guest
+	Open        bool
+}
+
+// HttpViewWaitlistResponse specifies the view data for the waitlist of the guest.
+type HttpViewWaitlistResponse struct {
+	AppName   string
+	Title     string
+	SessionID string
+	Hold      string
+	Entries   []WaitlistEntryView
+}
+
+// HttpViewWaitlist defines an HTTP handler function for rendering the waitlist entries of the guest.
+// Rooms held for the guest link to the reservation form with the stay filled in.
+func HttpViewWaitlist(e *templating.Engine, waitlistService *waitlist.Service) http.HandlerFunc {
+	appName := os.Getenv("APP_NAME")
+	title := appName + " - Waitlist"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		entries, err := waitlistService.EntriesOf(ctx, waitlist.GuestID(guestID))
+		if err != nil {
+			http.Error(w, "Failed to load waitlist", http.StatusInternalServerError)
+			return
+		}
+
+		rooms := make(map[string]string)
+		for _, room := range getDefaultRooms(requestLocale(r)) {
+			rooms[room.ID] = room.Name
+		}
+		now := time.Now()
+		data := HttpViewWaitlistResponse{
+			AppName:   appName,
+			Title:     title,
+			SessionID: sessionID,
+			Hold:      waitlistService.Hold().String(),
+		}
+		for _, entry := range entries {
+			view := WaitlistEntryView{
+				ID:          string(entry.ID),
+				RoomName:    string(entry.RoomID),
+				CheckIn:     entry.CheckIn.Format("2006-01-02"),
+				CheckOut:    entry.CheckOut.Format("2006-01-02"),
+				Status:      string(entry.Status),
+				StatusClass: waitlistStatusClass(entry.Status),
+				Open:        entry.IsOpen(),
+			}
+			if name, ok := rooms[string(entry.RoomID)]; ok {
+				view.RoomName = name
+			}
+			if entry.IsHeld(now) {
+				view.HoldUntil = entry.HoldUntil.UTC().Format("2006-01-02 15:04 MST")
+				view.BookLink = "/ui/reservations/new?" + url.Values{
+					"room_id":   {string(entry.RoomID)},
+					"check_in":  {view.CheckIn},
+					"check_out": {view.CheckOut},
+				}.Encode()
+			}
+			data.Entries = append(data.Entries, view)
+		}
+
+		HttpView(e, "waitlist", data)(w, r)
+	}
+}
+
+// HttpJoinWaitlist handles the POST request to put the guest on the waitlist of a booked room.
+func HttpJoinWaitlist(waitlistService *waitlist.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, email := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		roomID := r.FormValue("room_id")
+		if _, ok := getRoomPrices()[roomID]; !ok {
+			http.Error(w, "Invalid room selected", http.StatusBadRequest)
+			return
+		}
+		checkIn, err := time.Parse("2006-01-02", r.FormValue("check_in"))
+		if err != nil {
+			http.Error(w, "Invalid check-in date format", http.StatusBadRequest)
+			return
+		}
+		checkOut, err := time.Parse("2006-01-02", r.FormValue("check_out"))
+		if err != nil {
+			http.Error(w, "Invalid check-out date format", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := waitlistService.Join(ctx, waitlist.GuestID(guestID), email, waitlist.RoomID(roomID), checkIn, checkOut); err != nil {
+			switch {
+			case errors.Is(err, waitlist.ErrInvalidDateRange):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case errors.Is(err, waitlist.ErrAlreadyWaiting), errors.Is(err, waitlist.ErrRoomAvailable):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, "Failed to join waitlist", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		http.Redirect(w, r, "/ui/waitlist", http.StatusSeeOther)
+	}
+}
+
+// HttpWithdrawWaitlist handles the POST request to take the guest off the waitlist.
+// A room held for the guest is offered to the next guest waiting for it.
+func HttpWithdrawWaitlist(waitlistService *waitlist.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		sessionID, _ := ctx.Value(web.ContextSessionID).(string)
+		guestID, _ := currentGuest(ctx)
+		if sessionID == "" || guestID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := waitlistService.Withdraw(ctx, waitlist.GuestID(guestID), waitlist.EntryID(r.PathValue("id"))); err != nil {
+			switch {
+			case errors.Is(err, waitlist.ErrEntryNotFound):
+				http.Error(w, "Waitlist entry not found", http.StatusNotFound)
+			case errors.Is(err, waitlist.ErrEntryClosed):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, "Failed to leave waitlist", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		http.Redirect(w, r, "/ui/waitlist", http.StatusSeeOther)
+	}
+}
+
+func waitlistStatusClass(status waitlist.EntryStatus) string {
+	switch status {
+	case waitlist.StatusWaiting:
+		return "warning"
+	case waitlist.StatusOffered, waitlist.StatusBooked:
+		return "success"
+	default:
+		return "secondary"
+	}
+}
@@ -0,0 +1,182 @@
+package inbound_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestWaitlistService creates a waitlist service on the reservations of repo, with room-101 booked
+// for the returned stay.
+func createTestWaitlistService(repo *mockReservationRepository) (*waitlist.Service, time.Time, time.Time) {
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	checkOut := checkIn.AddDate(0, 0, 3)
+	repo.put(shared.ReservationID("res-001"), *createTestReservation("res-001", "owner@example.com", "room-101", checkIn, checkOut))
+	waitlistService := waitlist.NewService(
+		resource.NewInMemoryAccess[waitlist.EntryID, waitlist.Entry](),
+		outbound.NewReservationRoomAvailability(outbound.NewRepositoryAvailabilityChecker(repo)),
+		outbound.NewMockNotificationService(slog.Default(), "http://localhost:8080/ui", nil),
+		time.Hour,
+	)
+	return waitlistService, checkIn, checkOut
+}
+
+func waitlistJoinRequest(checkIn, checkOut time.Time) *http.Request {
+	form := url.Values{
+		"room_id":   {"room-101"},
+		"check_in":  {checkIn.Format("2006-01-02")},
+		"check_out": {checkOut.Format("2006-01-02")},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/waitlist", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return addGuestContext(req, "guest-waiting", "waiting@example.com")
+}
+
+// ============================================================================
+// Reservation Form Tests
+// ============================================================================
+
+func Test_HttpCreateReservation_For_Booked_Room_Should_Offer_Waitlist(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	waitlistService, checkIn, checkOut := createTestWaitlistService(repo)
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{ReservationService: createFormTestService(repo), WaitlistService: waitlistService})
+	form := url.Values{
+		"room_id":     {"room-101"},
+		"check_in":    {checkIn.Format("2006-01-02")},
+		"check_out":   {checkOut.Format("2006-01-02")},
+		"guest_name":  {"Test Guest"},
+		"guest_email": {"test@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain the waitlist form", strings.Contains(string(body), `action="/ui/waitlist"`), true)
+	assert.That(t, "body must carry the check-in date", strings.Contains(string(body), checkIn.Format("2006-01-02")), true)
+}
+
+func Test_HttpViewReservationForm_Should_Prefill_Stay(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	handler := inbound.HttpViewReservationForm(e, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/ui/reservations/new?room_id=room-201&check_in=2030-06-01&check_out=2030-06-04", nil)
+	req = addAuthContext(req, "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "body must contain the stay", strings.Contains(string(body), "Stay: room-201 2030-06-01 2030-06-04"), true)
+}
+
+// ============================================================================
+// HttpJoinWaitlist Tests
+// ============================================================================
+
+func Test_HttpJoinWaitlist_For_Booked_Room_Should_Redirect_To_Waitlist(t *testing.T) {
+	// Arrange
+	waitlistService, checkIn, checkOut := createTestWaitlistService(newMockReservationRepository())
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpJoinWaitlist(waitlistService)(rec, waitlistJoinRequest(checkIn, checkOut))
+
+	// Assert
+	entries, _ := waitlistService.EntriesOf(context.Background(), "guest-waiting")
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "location must be the waitlist", rec.Header().Get("Location"), "/ui/waitlist")
+	assert.That(t, "guest must be waiting", len(entries), 1)
+	assert.That(t, "email must be recorded for the offer", entries[0].Email, "waiting@example.com")
+}
+
+func Test_HttpJoinWaitlist_For_Available_Room_Should_Return_Conflict(t *testing.T) {
+	// Arrange
+	waitlistService, _, checkOut := createTestWaitlistService(newMockReservationRepository())
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpJoinWaitlist(waitlistService)(rec, waitlistJoinRequest(checkOut, checkOut.AddDate(0, 0, 2)))
+
+	// Assert
+	assert.That(t, "status code must be 409", rec.Code, http.StatusConflict)
+}
+
+// ============================================================================
+// HttpViewWaitlist Tests
+// ============================================================================
+
+func Test_HttpViewWaitlist_With_Held_Room_Should_Link_Booking(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	waitlistService, checkIn, checkOut := createTestWaitlistService(repo)
+	ctx := context.Background()
+	_, _ = waitlistService.Join(ctx, "guest-waiting", "waiting@example.com", "room-101", checkIn, checkOut)
+	_ = repo.Delete(ctx, "res-001")
+	_, _ = waitlistService.OfferFreedRoom(ctx, "room-101", checkIn, checkOut)
+	req := addGuestContext(httptest.NewRequest(http.MethodGet, "/ui/waitlist", nil), "guest-waiting", "waiting@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpViewWaitlist(e, waitlistService)(rec, req)
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must show the offer", strings.Contains(string(body), "Standard Room 101"), true)
+	assert.That(t, "body must link the prefilled form", strings.Contains(string(body), "/ui/reservations/new?check_in="+checkIn.Format("2006-01-02")), true)
+}
+
+// ============================================================================
+// HttpWithdrawWaitlist Tests
+// ============================================================================
+
+func Test_HttpWithdrawWaitlist_Entry_Of_Other_Guest_Should_Return_NotFound(t *testing.T) {
+	// Arrange
+	waitlistService, checkIn, checkOut := createTestWaitlistService(newMockReservationRepository())
+	entry, _ := waitlistService.Join(context.Background(), "guest-waiting", "waiting@example.com", "room-101", checkIn, checkOut)
+	req := httptest.NewRequest(http.MethodPost, "/ui/waitlist/"+string(entry.ID)+"/withdraw", nil)
+	req.SetPathValue("id", string(entry.ID))
+	req = addGuestContext(req, "guest-other", "other@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	inbound.HttpWithdrawWaitlist(waitlistService)(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
+}
@@ -0,0 +1,44 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// inventoryTopics are the reservation events that change the availability of a room.
+var inventoryTopics = []string{reservation.EventTopicCreated, reservation.EventTopicCancelled, reservation.EventTopicExtended}
+
+// SubscribeInventoryEvents subscribes to the reservation events that book or release nights
+// and refreshes the inventory of the stay's room, which publishes the changed nights.
+// The room and the dates are read from the stored reservation, because cancellations carry neither.
+func SubscribeInventoryEvents(ctx context.Context, dispatcher messaging.Dispatcher, reservationService *reservation.Service, inventoryService *inventory.Service) error {
+	for _, topic := range inventoryTopics {
+		fn := func(msg messaging.Message) (messaging.MessageState, error) {
+			var evt struct {
+				ReservationID shared.ReservationID `json:"reservation_id"`
+			}
+			if err := json.Unmarshal(msg.Data, &evt); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			res, err := reservationService.GetReservation(ctx, evt.ReservationID)
+			if err != nil {
+				return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+			}
+			if _, err := inventoryService.Refresh(ctx, inventory.RoomID(res.RoomID), res.DateRange.CheckIn, res.DateRange.CheckOut); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			return messaging.MessageStateCompleted, nil
+		}
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(fn)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
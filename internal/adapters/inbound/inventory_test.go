@@ -0,0 +1,42 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// SubscribeInventoryEvents Tests
+// ============================================================================
+
+func Test_SubscribeInventoryEvents_Should_Publish_Changed_Nights(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	svc, dispatcher := createTestInventoryService(repo)
+	ctx := context.Background()
+	var received []inventory.EventChanged
+	_ = dispatcher.Subscribe(ctx, inventory.EventTopicChanged, func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		var evt inventory.EventChanged
+		_ = json.Unmarshal(msg.Data, &evt)
+		received = append(received, evt)
+		return messaging.MessageStateCompleted, nil
+	})
+	_ = inbound.SubscribeInventoryEvents(ctx, dispatcher, createFormTestService(repo), svc)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCreated, []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "every booked night must be published", len(received), 3)
+	assert.That(t, "sequences must have no gaps", received[2].Sequence, inventory.Sequence(3))
+	assert.That(t, "nights must be unavailable", received[0].Available, false)
+}
@@ -0,0 +1,33 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// SubscribeInvoices invoices every completed stay with the next number of the jurisdiction of its property.
+// Issuing is idempotent, so a redelivered event returns the stored invoice without using a number.
+func SubscribeInvoices(ctx context.Context, dispatcher messaging.Dispatcher, invoiceService *invoice.Service) error {
+	fn := func(msg messaging.Message) (messaging.MessageState, error) {
+		var evt struct {
+			ReservationID invoice.ReservationID `json:"reservation_id"`
+		}
+		if err := json.Unmarshal(msg.Data, &evt); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		if _, err := invoiceService.Issue(ctx, evt.ReservationID); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCompleted, service.Wrap(fn)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCompleted, err)
+	}
+	return nil
+}
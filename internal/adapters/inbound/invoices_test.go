@@ -0,0 +1,35 @@
+package inbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// SubscribeInvoices Tests
+// ============================================================================
+
+func Test_SubscribeInvoices_Completed_Stay_Should_Issue_Invoice(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	invoices := createInvoiceTestService(createDetailTestService(repo))
+	dispatcher := messaging.NewInternalDispatcher()
+	ctx := context.Background()
+	_ = inbound.SubscribeInvoices(ctx, dispatcher, invoices)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCompleted, []byte(`{"reservation_id":"res-001"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCompleted, []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	inv, readErr := invoices.Invoice(ctx, "res-001")
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "invoice must be issued", readErr, nil)
+	assert.That(t, "redelivery must not use another number", inv.Number, "INV-000001")
+}
@@ -0,0 +1,222 @@
+package inbound
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ledgerPaymentTopics are the payment events that move money or end a card hold.
+var ledgerPaymentTopics = []string{payment.EventTopicAuthorized, payment.EventTopicCaptured, payment.EventTopicFailed, payment.EventTopicRefunded}
+
+// SubscribeLedgerEvents subscribes to the payment and folio adjustment events and posts their movements
+// to the ledger. Payments are read from the Payment context, so a replayed or reordered event posts
+// exactly the movements that are missing, and partial refunds are told apart by their position.
+func SubscribeLedgerEvents(ctx context.Context, dispatcher messaging.Dispatcher, paymentService *payment.Service, ledgerService *ledger.Service) error {
+	for _, topic := range ledgerPaymentTopics {
+		fn := func(msg messaging.Message) (messaging.MessageState, error) {
+			var evt struct {
+				PaymentID payment.PaymentID `json:"payment_id"`
+			}
+			if err := json.Unmarshal(msg.Data, &evt); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			p, err := paymentService.GetPayment(ctx, evt.PaymentID)
+			if err != nil {
+				return messaging.MessageStateFailed, fmt.Errorf("failed to get payment: %w", err)
+			}
+			if _, err := ledgerService.RecordPayment(ctx, LedgerPaymentMovements(p)); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			return messaging.MessageStateCompleted, nil
+		}
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(fn)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+
+	fn := func(msg messaging.Message) (messaging.MessageState, error) {
+		var evt payment.EventAdjusted
+		if err := json.Unmarshal(msg.Data, &evt); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		_, err := ledgerService.RecordAdjustment(ctx, string(evt.AdjustmentID), evt.ReservationID, evt.Amount, evt.Reason)
+		if err != nil && !errors.Is(err, ledger.ErrAlreadyPosted) {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicAdjusted, service.Wrap(fn)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicAdjusted, err)
+	}
+	return nil
+}
+
+// SyncLedger returns the ledger_sync job: it posts the movements of all payments and adjustments that
+// are missing in the ledger, i.e. those from before the ledger existed or whose events were lost.
+// It returns the number of posted entries.
+func SyncLedger(paymentService *payment.Service, financialService *payment.FinancialService, ledgerService *ledger.Service) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		payments, err := paymentService.ListPayments(ctx)
+		if err != nil {
+			return 0, err
+		}
+		// Oldest first, so the journal follows the order of the movements as far as possible.
+		slices.SortFunc(payments, func(a, b payment.Payment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+		posted := 0
+		for i := range payments {
+			entries, err := ledgerService.RecordPayment(ctx, LedgerPaymentMovements(&payments[i]))
+			posted += len(entries)
+			if err != nil {
+				return posted, err
+			}
+		}
+
+		adjustments, err := financialService.ListAdjustments(ctx)
+		if err != nil {
+			return posted, err
+		}
+		slices.SortFunc(adjustments, func(a, b payment.Adjustment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+		for _, a := range adjustments {
+			_, err := ledgerService.RecordAdjustment(ctx, string(a.ID), a.ReservationID, a.Amount, a.Reason)
+			if errors.Is(err, ledger.ErrAlreadyPosted) {
+				continue
+			}
+			if err != nil {
+				return posted, err
+			}
+			posted++
+		}
+		return posted, nil
+	}
+}
+
+// LedgerPaymentMovements translates a payment into the movements the ledger records.
+func LedgerPaymentMovements(p *payment.Payment) ledger.PaymentMovements {
+	authorized := p.Status == payment.StatusAuthorized || p.Status == payment.StatusCaptured || p.Status == payment.StatusRefunded ||
+		slices.ContainsFunc(p.Attempts, func(a payment.PaymentAttempt) bool { return a.Status == payment.StatusAuthorized })
+	captured := p.Status == payment.StatusCaptured || p.Status == payment.StatusRefunded
+
+	movements := ledger.PaymentMovements{
+		PaymentID:     string(p.ID),
+		ReservationID: p.ReservationID,
+		Amount:        p.Amount,
+		GiftCard:      p.PaymentMethod == payment.MethodGiftCard,
+		Authorized:    authorized,
+		Captured:      captured,
+		Released:      authorized && p.Status == payment.StatusFailed,
+	}
+	for _, r := range p.Refunds {
+		movements.Refunds = append(movements.Refunds, r.Amount)
+	}
+	// Payments refunded before refunds were recorded count as fully refunded.
+	if p.Status == payment.StatusRefunded && len(p.Refunds) == 0 {
+		movements.Refunds = append(movements.Refunds, p.Amount)
+	}
+	return movements
+}
+
+// payoutReportColumns are the columns of a payout report in CSV, one row per settled payment.
+// The payout columns repeat on every row of the payout; amounts are in the smallest currency unit.
+var payoutReportColumns = []string{"payout_id", "arrival_date", "currency", "amount", "fees", "payment_id"}
+
+// ParsePayoutReportsCSV reads payout reports from CSV with a header row of payoutReportColumns,
+// grouping the rows by payout ID in the order the payouts first appear.
+func ParsePayoutReportsCSV(r io.Reader) ([]ledger.PayoutReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(payoutReportColumns)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	for i, column := range payoutReportColumns {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("column %d must be %s", i+1, column)
+		}
+	}
+
+	var reports []ledger.PayoutReport
+	index := make(map[ledger.PayoutID]int)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		arrival, err := time.Parse(time.DateOnly, row[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: arrival_date must be a date (YYYY-MM-DD)", line)
+		}
+		amount, err := strconv.ParseInt(row[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: amount must be in the smallest currency unit", line)
+		}
+		fees, err := strconv.ParseInt(row[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: fees must be in the smallest currency unit", line)
+		}
+		id := ledger.PayoutID(row[0])
+		i, ok := index[id]
+		if !ok {
+			i = len(reports)
+			index[id] = i
+			reports = append(reports, ledger.PayoutReport{
+				ID:          id,
+				ArrivalDate: arrival,
+				Amount:      shared.NewMoney(amount, row[2]),
+				Fees:        shared.NewMoney(fees, row[2]),
+			})
+		}
+		reports[i].PaymentIDs = append(reports[i].PaymentIDs, row[5])
+	}
+	return reports, nil
+}
+
+// CheckPayouts returns the scheduler job that alerts on captures the gateway has not paid out
+// within delay and on payouts below the captured amount. Alerts are warnings with alert=true,
+// for the log-based alerting; the job reports how many there are.
+func CheckPayouts(ledgerService *ledger.Service, delay time.Duration, logger *slog.Logger) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		alerts, err := ledgerService.CheckPayouts(ctx, now, delay)
+		if err != nil {
+			return 0, err
+		}
+		for _, late := range alerts.Late {
+			logger.Warn("payout late",
+				"alert", true,
+				"payment_id", late.PaymentID,
+				"reservation_id", late.ReservationID,
+				"amount", late.Amount.FormatAmount(),
+				"captured_at", late.CapturedAt,
+				"due_by", late.DueBy,
+			)
+		}
+		for _, short := range alerts.Short {
+			logger.Warn("payout short",
+				"alert", true,
+				"payout_id", short.PayoutID,
+				"expected", short.Expected.FormatAmount(),
+				"gross", short.Gross.FormatAmount(),
+				"difference", short.Difference.FormatAmount(),
+			)
+		}
+		return len(alerts.Late) + len(alerts.Short), nil
+	}
+}
@@ -0,0 +1,178 @@
+package inbound_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestLedgerService() *ledger.Service {
+	return ledger.NewService(
+		resource.NewInMemoryAccess[ledger.EntryKey, ledger.Entry](),
+		resource.NewInMemoryAccess[ledger.SourceKey, ledger.Sequence](),
+	).WithSettlements(resource.NewInMemoryAccess[ledger.PayoutID, ledger.Settlement]())
+}
+
+// createLedgerTestServices creates payment and financial services publishing to the dispatcher.
+func createLedgerTestServices(dispatcher messaging.Dispatcher) (*payment.Service, *payment.FinancialService) {
+	paymentService := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(dispatcher))
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "owner-subject")
+	charges := outbound.NewReservationRoomCharges(createReservationsTestService(repo))
+	financials := payment.NewFinancialService(paymentService, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), charges)
+	return paymentService, financials
+}
+
+// ============================================================================
+// SubscribeLedgerEvents Tests
+// ============================================================================
+
+func Test_SubscribeLedgerEvents_Should_Post_Payment_Movements(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	paymentService, _ := createLedgerTestServices(dispatcher)
+	ledgerService := createTestLedgerService()
+	_ = inbound.SubscribeLedgerEvents(ctx, dispatcher, paymentService, ledgerService)
+
+	// Act
+	_, _ = paymentService.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	_ = paymentService.CapturePayment(ctx, "pay-001")
+	_ = paymentService.RefundPaymentPartially(ctx, "pay-001", shared.NewMoney(2500, "USD"), "goodwill")
+
+	// Assert
+	entries, err := ledgerService.Entries(ctx, "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "must have authorization, capture and refund", len(entries), 3)
+	assert.That(t, "last entry must be the refund", entries[2].Kind, ledger.KindRefund)
+	assert.That(t, "refund must be the partial amount", entries[2].Amount(), shared.NewMoney(2500, "USD"))
+}
+
+func Test_SubscribeLedgerEvents_Should_Post_Adjustments(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dispatcher := messaging.NewInternalDispatcher()
+	paymentService, financials := createLedgerTestServices(dispatcher)
+	ledgerService := createTestLedgerService()
+	_ = inbound.SubscribeLedgerEvents(ctx, dispatcher, paymentService, ledgerService)
+
+	// Act
+	_, err := financials.AddAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(1500, "USD"), "Minibar")
+
+	// Assert
+	entries, _ := ledgerService.Entries(ctx, "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "adjustment must be posted", len(entries), 1)
+	assert.That(t, "kind must be adjustment", entries[0].Kind, ledger.KindAdjustment)
+}
+
+// ============================================================================
+// SyncLedger Tests
+// ============================================================================
+
+func Test_SyncLedger_Should_Backfill_Missing_Movements_Once(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	paymentService, financials := createLedgerTestServices(messaging.NewInternalDispatcher())
+	_, _ = paymentService.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	_ = paymentService.CapturePayment(ctx, "pay-001")
+	_, _ = financials.AddAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(-1000, "USD"), "Late check-in")
+	ledgerService := createTestLedgerService()
+	sync := inbound.SyncLedger(paymentService, financials, ledgerService)
+
+	// Act
+	first, err := sync(ctx, time.Now())
+	second, _ := sync(ctx, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "first run must post authorization, capture and adjustment", first, 3)
+	assert.That(t, "second run must post nothing", second, 0)
+}
+
+// ============================================================================
+// LedgerPaymentMovements Tests
+// ============================================================================
+
+func Test_LedgerPaymentMovements_Failed_After_Authorization_Should_Release(t *testing.T) {
+	// Arrange
+	p := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	_ = p.Authorize("tx-1")
+	_ = p.Fail("expired", "Authorization expired")
+
+	// Act
+	movements := inbound.LedgerPaymentMovements(p)
+
+	// Assert
+	assert.That(t, "must be authorized", movements.Authorized, true)
+	assert.That(t, "must be released", movements.Released, true)
+	assert.That(t, "must not be captured", movements.Captured, false)
+}
+
+// ============================================================================
+// ParsePayoutReportsCSV Tests
+// ============================================================================
+
+func Test_ParsePayoutReportsCSV_Should_Group_Rows_By_Payout(t *testing.T) {
+	// Arrange
+	csv := "payout_id,arrival_date,currency,amount,fees,payment_id\n" +
+		"po_1,2030-05-03,usd,30000,870,pay-001\n" +
+		"po_1,2030-05-03,usd,30000,870,pay-002\n" +
+		"po_2,2030-05-04,EUR,5000,145,pay-003\n"
+
+	// Act
+	reports, err := inbound.ParsePayoutReportsCSV(strings.NewReader(csv))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "must have two payouts", len(reports), 2)
+	assert.That(t, "first payout must settle two payments", reports[0].PaymentIDs, []string{"pay-001", "pay-002"})
+	assert.That(t, "currency must be upper case", reports[0].Amount.Currency, "USD")
+	assert.That(t, "fees must be read", reports[1].Fees.Amount, int64(145))
+}
+
+func Test_ParsePayoutReportsCSV_With_Wrong_Header_Should_Return_Error(t *testing.T) {
+	// Arrange
+	csv := "id,date,currency,amount,fees,payment\npo_1,2030-05-03,USD,30000,870,pay-001\n"
+
+	// Act
+	_, err := inbound.ParsePayoutReportsCSV(strings.NewReader(csv))
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// CheckPayouts Tests
+// ============================================================================
+
+func Test_CheckPayouts_Should_Count_Late_Captures(t *testing.T) {
+	// Arrange
+	svc := createTestLedgerService()
+	_, _ = svc.RecordPayment(context.Background(), ledger.PaymentMovements{
+		PaymentID: "pay-001", ReservationID: "res-001", Amount: shared.NewMoney(10000, "USD"), Captured: true,
+	})
+	job := inbound.CheckPayouts(svc, 72*time.Hour, slog.New(slog.DiscardHandler))
+
+	// Act
+	count, err := job(context.Background(), time.Now().Add(96*time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "capture must be late", count, 1)
+}
@@ -0,0 +1,59 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// SubscribeLoyaltyEvents subscribes to the reservation events that change loyalty balances:
+// a completed stay earns points for the amount the guest paid, and a cancelled reservation
+// gives back the points redeemed for it. Both steps are idempotent, so redelivered events are harmless.
+func SubscribeLoyaltyEvents(ctx context.Context, dispatcher messaging.Dispatcher, reservationService *reservation.Service, loyaltyService *loyalty.Service) error {
+	decode := func(msg messaging.Message) (shared.ReservationID, error) {
+		var evt struct {
+			ReservationID shared.ReservationID `json:"reservation_id"`
+		}
+		err := json.Unmarshal(msg.Data, &evt)
+		return evt.ReservationID, err
+	}
+
+	completed := func(msg messaging.Message) (messaging.MessageState, error) {
+		id, err := decode(msg)
+		if err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		res, err := reservationService.GetReservation(ctx, id)
+		if err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+		}
+		if _, err := loyaltyService.Accrue(ctx, loyalty.GuestID(res.GuestID), res.ID, res.TotalAmount); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCompleted, service.Wrap(completed)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCompleted, err)
+	}
+
+	cancelled := func(msg messaging.Message) (messaging.MessageState, error) {
+		id, err := decode(msg)
+		if err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		if _, err := loyaltyService.RefundRedemption(ctx, id); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCancelled, service.Wrap(cancelled)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCancelled, err)
+	}
+	return nil
+}
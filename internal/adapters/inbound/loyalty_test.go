@@ -0,0 +1,178 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// loyaltyGuest is the subject of the guest signed in by addAuthContext.
+const loyaltyGuest = loyalty.GuestID("user-subject-456")
+
+func createTestLoyaltyService() *loyalty.Service {
+	publisher := outbound.NewEventPublisher(messaging.NewInternalDispatcher())
+	return loyalty.NewService(resource.NewInMemoryAccess[loyalty.TransactionID, loyalty.Transaction](), publisher, loyalty.DefaultPolicy())
+}
+
+// loyaltyBookingRequest posts a booking of room-101 for three nights next week, redeeming the points.
+func loyaltyBookingRequest(points string) *http.Request {
+	form := url.Values{
+		"room_id":       {"room-101"},
+		"check_in":      {time.Now().AddDate(0, 0, 7).Format("2006-01-02")},
+		"check_out":     {time.Now().AddDate(0, 0, 10).Format("2006-01-02")},
+		"guest_name":    {"Test Guest"},
+		"guest_email":   {"test@example.com"},
+		"redeem_points": {points},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/ui/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return addAuthContext(req, "test-session-123", "test@example.com")
+}
+
+// ============================================================================
+// SubscribeLoyaltyEvents Tests
+// ============================================================================
+
+func Test_SubscribeLoyaltyEvents_Completed_Stay_Should_Earn_Points(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, reservation.GuestID(loyaltyGuest))
+	loyaltyService := createTestLoyaltyService()
+	dispatcher := messaging.NewInternalDispatcher()
+	ctx := context.Background()
+	_ = inbound.SubscribeLoyaltyEvents(ctx, dispatcher, createFormTestService(repo), loyaltyService)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCompleted, []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	account, _ := loyaltyService.AccountOf(ctx, loyaltyGuest)
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "three nights must earn points for their price", account.Balance, 297)
+}
+
+func Test_SubscribeLoyaltyEvents_Cancelled_Reservation_Should_Refund_Points(t *testing.T) {
+	// Arrange
+	loyaltyService := createTestLoyaltyService()
+	dispatcher := messaging.NewInternalDispatcher()
+	ctx := context.Background()
+	_, _ = loyaltyService.Accrue(ctx, loyaltyGuest, "res-000", shared.NewMoney(50000, "USD"))
+	_, _ = loyaltyService.Redeem(ctx, loyaltyGuest, "res-001", 200, shared.NewMoney(29700, "USD"))
+	_ = inbound.SubscribeLoyaltyEvents(ctx, dispatcher, createFormTestService(newMockReservationRepository()), loyaltyService)
+
+	// Act
+	err := dispatcher.Publish(ctx, messaging.NewMessage(reservation.EventTopicCancelled, []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	account, _ := loyaltyService.AccountOf(ctx, loyaltyGuest)
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "redeemed points must be given back", account.Balance, 500)
+}
+
+// ============================================================================
+// Redeeming Points When Booking Tests
+// ============================================================================
+
+func Test_HttpCreateReservation_Redeeming_Points_Should_Reduce_Total(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	loyaltyService := createTestLoyaltyService()
+	_, _ = loyaltyService.Accrue(context.Background(), loyaltyGuest, "res-000", shared.NewMoney(50000, "USD"))
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{LoyaltyService: loyaltyService, ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, loyaltyBookingRequest("300"))
+
+	// Assert
+	account, _ := loyaltyService.AccountOf(context.Background(), loyaltyGuest)
+	assert.That(t, "status code must be 303", rec.Code, http.StatusSeeOther)
+	assert.That(t, "repository must have 1 reservation", repo.count(), 1)
+	for _, res := range repo.all() {
+		assert.That(t, "points must be taken off the total", res.TotalAmount, shared.NewMoney(29400, "USD"))
+		assert.That(t, "redemption must be recorded", res.Perks.Redemption, shared.NewMoney(300, "USD"))
+	}
+	assert.That(t, "balance must be reduced", account.Balance, 200)
+}
+
+func Test_HttpCreateReservation_Redeeming_More_Points_Than_Balance_Should_Show_Error(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	loyaltyService := createTestLoyaltyService()
+	_, _ = loyaltyService.Accrue(context.Background(), loyaltyGuest, "res-000", shared.NewMoney(10000, "USD"))
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{LoyaltyService: loyaltyService, ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, loyaltyBookingRequest("500"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "error must be shown", strings.Contains(rec.Body.String(), loyalty.ErrInsufficientPoints.Error()), true)
+	assert.That(t, "balance must be shown again", strings.Contains(rec.Body.String(), "Points: 100"), true)
+	assert.That(t, "no reservation must be created", repo.count(), 0)
+}
+
+func Test_HttpCreateReservation_Failing_Booking_Should_Refund_Points(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	repo := newMockReservationRepository()
+	createSharedTestReservation(repo, "other-guest")
+	loyaltyService := createTestLoyaltyService()
+	_, _ = loyaltyService.Accrue(context.Background(), loyaltyGuest, "res-000", shared.NewMoney(50000, "USD"))
+	handler := inbound.HttpCreateReservation(e, inbound.ReservationFormConfig{LoyaltyService: loyaltyService, ReservationService: createFormTestService(repo)})
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, loyaltyBookingRequest("300"))
+
+	// Assert
+	account, _ := loyaltyService.AccountOf(context.Background(), loyaltyGuest)
+	assert.That(t, "room must be booked by the other guest", repo.count(), 1)
+	assert.That(t, "balance must be restored", account.Balance, 500)
+}
+
+// ============================================================================
+// Loyalty Widget Tests
+// ============================================================================
+
+func Test_HttpViewReservations_With_Loyalty_Should_Show_Balance(t *testing.T) {
+	// Arrange
+	e := templating.NewEngine(formTestAssets)
+	e.Parse("testdata/assets/templates/*.tmpl")
+	loyaltyService := createTestLoyaltyService()
+	_, _ = loyaltyService.Accrue(context.Background(), loyaltyGuest, "res-000", shared.NewMoney(125000, "USD"))
+	handler := inbound.HttpViewReservations(e, createFormTestService(newMockReservationRepository()), loyaltyService)
+	req := addAuthContext(httptest.NewRequest(http.MethodGet, "/ui/reservations", nil), "test-session-123", "test@example.com")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "balance and value must be shown", strings.Contains(rec.Body.String(), "Points: 1250 worth $12.50"), true)
+}
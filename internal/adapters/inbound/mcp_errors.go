@@ -0,0 +1,187 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrorCodeToolFailed is the MCP error code for a tool call that failed; the error data tells why.
+const ErrorCodeToolFailed = -32000
+
+// Machine-readable reasons of failed tool calls, so agents can branch on them instead of parsing the message.
+const (
+	MCPErrorNotFound    = "NOT_FOUND"   // the reservation, payment or rate plan does not exist
+	MCPErrorForbidden   = "FORBIDDEN"   // the caller lacks a scope, is not staff or not the owner
+	MCPErrorValidation  = "VALIDATION"  // an argument is missing or malformed; fix it before retrying
+	MCPErrorConflict    = "CONFLICT"    // the current state does not allow the operation, e.g. already cancelled
+	MCPErrorUnavailable = "UNAVAILABLE" // a dependency timed out; the same call may succeed later
+	MCPErrorInternal    = "INTERNAL"    // anything else
+)
+
+// MCPErrorData is the data of a failed tool call's error.
+type MCPErrorData struct {
+	Code      string `json:"code"`
+	Tool      string `json:"tool"`
+	Retryable bool   `json:"retryable"`
+}
+
+// mcpToolErrors collects the classified errors of the tool calls of one request, in call order.
+// The MCP server runs the calls of a request one after another, so the n-th error result
+// belongs to the n-th collected error.
+type mcpToolErrors struct {
+	mu     sync.Mutex
+	errors []MCPErrorData
+}
+
+// mcpToolErrorsKey is the context key for the collected tool errors.
+type mcpToolErrorsKey struct{}
+
+// WithToolErrorCodes returns a copy of the server whose tools classify their errors for WithMCPErrors.
+// Tools must be registered before, because the copy does not see tools added later.
+func WithToolErrorCodes(server *mcp.Server) *mcp.Server {
+	classified := mcp.NewServer(server.Name(), server.Version())
+	for _, tool := range server.Tools() {
+		handler := tool.Handler
+		name := tool.Definition.Name
+		tool.Handler = func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			result, err := handler(ctx, params)
+			if err != nil {
+				if collected, ok := ctx.Value(mcpToolErrorsKey{}).(*mcpToolErrors); ok {
+					code := classifyToolError(err)
+					collected.mu.Lock()
+					collected.errors = append(collected.errors, MCPErrorData{Code: code, Tool: name, Retryable: code == MCPErrorUnavailable})
+					collected.mu.Unlock()
+				}
+			}
+			return result, err
+		}
+		classified.RegisterTool(tool)
+	}
+	return classified
+}
+
+// WithMCPErrors answers failed tool calls with ErrorCodeToolFailed instead of a result with isError.
+// The message is the error text as before; the data carries the code (see MCPErrorData).
+// The tools must be served by a server from WithToolErrorCodes; other error results are left unchanged.
+func WithMCPErrors(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		collected := &mcpToolErrors{}
+		rec := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r.WithContext(context.WithValue(r.Context(), mcpToolErrorsKey{}, collected)))
+		if rec.status != http.StatusOK || len(collected.errors) == 0 {
+			w.WriteHeader(rec.status)
+			_, _ = w.Write(rec.body.Bytes())
+			return
+		}
+
+		var output bytes.Buffer
+		for line := range bytes.SplitSeq(rec.body.Bytes(), []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			if len(collected.errors) > 0 {
+				if patched, ok := toolErrorResponse(line, collected.errors[0]); ok {
+					collected.errors = collected.errors[1:]
+					line = patched
+				}
+			}
+			output.Write(line)
+			output.WriteByte('\n')
+		}
+		_, _ = io.Copy(w, &output)
+	}
+}
+
+// toolErrorResponse turns a tool result with isError into an error response with the data.
+// It returns false for other responses.
+func toolErrorResponse(line []byte, data MCPErrorData) ([]byte, bool) {
+	var resp struct {
+		ID     json.RawMessage      `json:"id"`
+		Result *mcp.ToolsCallResult `json:"result"`
+	}
+	if err := json.Unmarshal(line, &resp); err != nil || resp.Result == nil || !resp.Result.IsError {
+		return nil, false
+	}
+	message := ""
+	if len(resp.Result.Content) > 0 {
+		message = resp.Result.Content[0].Text
+	}
+	errResp := mcp.NewErrorResponse(resp.ID, ErrorCodeToolFailed, message)
+	errResp.Error.Data = data
+	patched, err := json.Marshal(errResp)
+	if err != nil {
+		return nil, false
+	}
+	return patched, true
+}
+
+// classifyToolError maps the error of a tool to its code.
+func classifyToolError(err error) string {
+	var parseErr *time.ParseError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, shared.ErrMissingScope),
+		errors.Is(err, shared.ErrStaffOnly),
+		errors.Is(err, reservation.ErrNotReservationOwner):
+		return MCPErrorForbidden
+	case errors.Is(err, shared.ErrInvalidID),
+		errors.As(err, &parseErr),
+		errors.Is(err, reservation.ErrMissingGuestFilter),
+		errors.Is(err, loyalty.ErrMissingGuest),
+		errors.Is(err, reservation.ErrTooManyReservations),
+		errors.Is(err, reservation.ErrTooManyQueries),
+		errors.Is(err, ErrInvalidGraphQLArgument),
+		errors.Is(err, reservation.ErrInvalidCalendarSpan),
+		errors.Is(err, reservation.ErrInvalidDateRange),
+		errors.Is(err, reservation.ErrCheckInPast),
+		errors.Is(err, reservation.ErrMinimumStay),
+		errors.Is(err, reservation.ErrNoGuests),
+		errors.Is(err, reservation.ErrExchangeRateUnavailable),
+		errors.Is(err, payment.ErrInvalidRefundAmount),
+		errors.Is(err, payment.ErrInvalidAdjustment),
+		errors.Is(err, payment.ErrCurrencyMismatch),
+		errors.Is(err, pricing.ErrInvalidStay):
+		return MCPErrorValidation
+	case errors.Is(err, reservation.ErrInvalidStateTransition),
+		errors.Is(err, reservation.ErrCannotCancelNearCheckIn),
+		errors.Is(err, reservation.ErrCannotCancelActive),
+		errors.Is(err, reservation.ErrCannotCancelCompleted),
+		errors.Is(err, reservation.ErrAlreadyCancelled),
+		errors.Is(err, payment.ErrInvalidPaymentTransition),
+		errors.Is(err, payment.ErrAlreadyAuthorized),
+		errors.Is(err, payment.ErrNotAuthorized),
+		errors.Is(err, payment.ErrAlreadyCaptured),
+		errors.Is(err, payment.ErrNotCaptured),
+		errors.Is(err, payment.ErrAlreadyRefunded),
+		errors.Is(err, payment.ErrCannotRefund),
+		errors.Is(err, payment.ErrFXSnapshotMissing),
+		errors.Is(err, payment.ErrFXSnapshotStale):
+		return MCPErrorConflict
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, reservation.ErrRoomBusy),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return MCPErrorUnavailable
+	// The repositories return plain errors with the text of resource.ErrorResourceNotFound.
+	case strings.HasSuffix(err.Error(), resource.ErrorResourceNotFound),
+		errors.Is(err, pricing.ErrNoRatePlan):
+		return MCPErrorNotFound
+	}
+	return MCPErrorInternal
+}
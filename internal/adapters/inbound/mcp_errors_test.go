@@ -0,0 +1,135 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func mcpErrorsToolCall(id, name string) string {
+	return `{"jsonrpc":"2.0","id":` + id + `,"method":"tools/call","params":{"name":"` + name + `","arguments":{}}}` + "\n"
+}
+
+func createTestMCPErrorsHandler(err error) http.HandlerFunc {
+	server := mcp.NewServer("test", "1.0.0")
+	server.RegisterTool(mcp.NewTool("fail", "Fail", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		return mcp.ToolsCallResult{}, err
+	}))
+	server.RegisterTool(mcp.NewTool("echo", "Echo", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		return mcp.ToolsCallResult{Content: []mcp.ContentBlock{mcp.NewTextContent("ok")}}, nil
+	}))
+	return inbound.WithMCPErrors(web.NewMCPHandler(inbound.WithToolErrorCodes(server)).Handler())
+}
+
+func mcpToolErrorCode(t *testing.T, err error) string {
+	t.Helper()
+	rec := postMCPAsClient(createTestMCPErrorsHandler(err), "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "fail"))
+	responses := parseMCPResponses(rec)
+	failed, ok := responses[1]["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("response must be an error: %v", responses[1])
+	}
+	return failed["data"].(map[string]any)["code"].(string)
+}
+
+// ============================================================================
+// WithMCPErrors Tests
+// ============================================================================
+
+func Test_WithMCPErrors_Failed_ToolCall_Should_Return_Error_With_Code(t *testing.T) {
+	// Arrange
+	handler := createTestMCPErrorsHandler(fmt.Errorf("%w: reservations:write", shared.ErrMissingScope))
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "fail"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	assert.That(t, "response count must be 2", len(responses), 2)
+	assert.That(t, "result must be omitted", responses[1]["result"], nil)
+	failed := responses[1]["error"].(map[string]any)
+	assert.That(t, "error code must be tool failed", failed["code"], float64(inbound.ErrorCodeToolFailed))
+	assert.That(t, "message must be the error text", failed["message"], "principal lacks the required scope: reservations:write")
+	data := failed["data"].(map[string]any)
+	assert.That(t, "data code must be FORBIDDEN", data["code"], inbound.MCPErrorForbidden)
+	assert.That(t, "data tool must be fail", data["tool"], "fail")
+	assert.That(t, "data must not be retryable", data["retryable"], false)
+}
+
+func Test_WithMCPErrors_Successful_ToolCall_Should_Be_Unchanged(t *testing.T) {
+	// Arrange
+	handler := createTestMCPErrorsHandler(errors.New("unused"))
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "echo")+mcpErrorsToolCall("2", "fail"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	assert.That(t, "response count must be 3", len(responses), 3)
+	assert.That(t, "echo must have a result", responses[1]["result"] != nil, true)
+	assert.That(t, "echo must not have an error", responses[1]["error"], nil)
+	assert.That(t, "fail must have an error", responses[2]["error"] != nil, true)
+	assert.That(t, "fail must keep its id", responses[2]["id"], float64(2))
+}
+
+func Test_WithMCPErrors_Unknown_Tool_Should_Keep_Invalid_Params(t *testing.T) {
+	// Arrange
+	handler := createTestMCPErrorsHandler(errors.New("unused"))
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "missing"))
+
+	// Assert
+	failed := parseMCPResponses(rec)[1]["error"].(map[string]any)
+	assert.That(t, "error code must be invalid params", failed["code"], float64(mcp.ErrorCodeInvalidParams))
+	assert.That(t, "error must not have data", failed["data"], nil)
+}
+
+func Test_WithMCPErrors_Should_Classify_Domain_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{"not found", errors.New(resource.ErrorResourceNotFound), inbound.MCPErrorNotFound},
+		{"not owner", reservation.ErrNotReservationOwner, inbound.MCPErrorForbidden},
+		{"invalid id", fmt.Errorf("%w: reservation ID is required", shared.ErrInvalidID), inbound.MCPErrorValidation},
+		{"already cancelled", reservation.ErrAlreadyCancelled, inbound.MCPErrorConflict},
+		{"deadline", fmt.Errorf("gateway: %w", context.DeadlineExceeded), inbound.MCPErrorUnavailable},
+		{"no rate plan", fmt.Errorf("%w: room-999", pricing.ErrNoRatePlan), inbound.MCPErrorNotFound},
+		{"room busy", fmt.Errorf("failed to lock room: %w", reservation.ErrRoomBusy), inbound.MCPErrorUnavailable},
+		{"other", errors.New("boom"), inbound.MCPErrorInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.That(t, "code must match", mcpToolErrorCode(t, tt.err), tt.code)
+		})
+	}
+}
+
+func Test_WithMCPErrors_Unavailable_Should_Be_Retryable(t *testing.T) {
+	// Arrange
+	handler := createTestMCPErrorsHandler(context.DeadlineExceeded)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "fail"))
+
+	// Assert
+	data := parseMCPResponses(rec)[1]["error"].(map[string]any)["data"].(map[string]any)
+	assert.That(t, "data must be retryable", data["retryable"], true)
+}
@@ -0,0 +1,49 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// WithMaskedToolResults returns a copy of the server whose tools mask emails, phone numbers and
+// card numbers in their results and errors (see shared.MaskPII), because agents and their logs
+// must not see more of a guest than needed. Errors keep their chain, so they are still classified.
+// Tools must be registered before, because the copy does not see tools added later.
+func WithMaskedToolResults(server *mcp.Server) *mcp.Server {
+	masked := mcp.NewServer(server.Name(), server.Version())
+	for _, tool := range server.Tools() {
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			result, err := handler(ctx, params)
+			if err != nil {
+				return result, &maskedError{err: err}
+			}
+			content := make([]mcp.ContentBlock, len(result.Content))
+			for i, block := range result.Content {
+				block.Text = shared.MaskPII(block.Text)
+				content[i] = block
+			}
+			result.Content = content
+			return result, nil
+		}
+		masked.RegisterTool(tool)
+	}
+	return masked
+}
+
+// maskedError masks the message of a tool error but keeps the error for errors.Is and errors.As.
+type maskedError struct {
+	err error
+}
+
+// Error returns the masked message.
+func (e *maskedError) Error() string {
+	return shared.MaskPII(e.err.Error())
+}
+
+// Unwrap returns the original error.
+func (e *maskedError) Unwrap() error {
+	return e.err
+}
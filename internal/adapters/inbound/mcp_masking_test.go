@@ -0,0 +1,62 @@
+package inbound_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestMCPMaskingHandler() http.HandlerFunc {
+	server := mcp.NewServer("test", "1.0.0")
+	server.RegisterTool(mcp.NewTool("guest", "Guest", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		text := `{"id":"res-1001","email":"john.doe@example.com","phone":"+1234567890","check_in":"2026-05-01"}`
+		return mcp.ToolsCallResult{Content: []mcp.ContentBlock{mcp.NewTextContent(text)}}, nil
+	}))
+	server.RegisterTool(mcp.NewTool("fail", "Fail", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		return mcp.ToolsCallResult{}, fmt.Errorf("%w: jane@example.com", reservation.ErrNotReservationOwner)
+	}))
+	return inbound.WithMCPErrors(web.NewMCPHandler(inbound.WithToolErrorCodes(inbound.WithMaskedToolResults(server))).Handler())
+}
+
+// ============================================================================
+// WithMaskedToolResults Tests
+// ============================================================================
+
+func Test_WithMaskedToolResults_Should_Mask_Personal_Data_In_Results(t *testing.T) {
+	// Arrange
+	handler := createTestMCPMaskingHandler()
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "guest"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	content := responses[1]["result"].(map[string]any)["content"].([]any)
+	text := content[0].(map[string]any)["text"]
+	assert.That(t, "personal data must be masked", text, `{"id":"res-1001","email":"j***@example.com","phone":"+12******90","check_in":"2026-05-01"}`)
+}
+
+func Test_WithMaskedToolResults_Should_Mask_Errors_And_Keep_Their_Code(t *testing.T) {
+	// Arrange
+	handler := createTestMCPMaskingHandler()
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpErrorsToolCall("1", "fail"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	failed := responses[1]["error"].(map[string]any)
+	assert.That(t, "message must be masked", failed["message"], reservation.ErrNotReservationOwner.Error()+": j***@example.com")
+	assert.That(t, "code must still be classified", failed["data"].(map[string]any)["code"], inbound.MCPErrorForbidden)
+}
@@ -0,0 +1,407 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DefaultMCPOperationTimeout bounds a tool call if no other timeout is configured.
+const DefaultMCPOperationTimeout = 5 * time.Minute
+
+// MCPOperation is a running tool call, as listed by GET /admin/mcp/operations.
+type MCPOperation struct {
+	Client    string    `json:"client"`
+	RequestID string    `json:"request_id"`
+	Tool      string    `json:"tool"`
+	StartedAt time.Time `json:"started_at"`
+	Progress  float64   `json:"progress"`
+	Total     float64   `json:"total,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// MCPOperations tracks the running tool calls, so clients can cancel them with
+// notifications/cancelled and operators can see what is running.
+// Each call gets a context that ends at the timeout or when the client cancels it.
+type MCPOperations struct {
+	mu      sync.Mutex
+	running map[string]*mcpOperation // by client and request ID
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// mcpOperation is the state of a running tool call.
+type mcpOperation struct {
+	MCPOperation
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// NewMCPOperations creates a new operations registry. A timeout of 0 does not bound the tool calls.
+func NewMCPOperations(timeout time.Duration) *MCPOperations {
+	return &MCPOperations{
+		running: make(map[string]*mcpOperation),
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// Running returns the running tool calls, oldest first.
+func (o *MCPOperations) Running() []MCPOperation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	operations := make([]MCPOperation, 0, len(o.running))
+	for _, op := range o.running {
+		operations = append(operations, op.MCPOperation)
+	}
+	slices.SortFunc(operations, func(a, b MCPOperation) int { return a.StartedAt.Compare(b.StartedAt) })
+	return operations
+}
+
+// Cancel cancels the client's tool call with the request ID.
+// It returns false if no such call is running, e.g. because it has already finished.
+func (o *MCPOperations) Cancel(client, requestID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	op, ok := o.running[client+" "+requestID]
+	if !ok {
+		return false
+	}
+	op.cancelled = true
+	op.cancel()
+	return true
+}
+
+// start registers a tool call.
+func (o *MCPOperations) start(client, requestID, tool string, cancel context.CancelFunc) *mcpOperation {
+	op := &mcpOperation{
+		MCPOperation: MCPOperation{Client: client, RequestID: requestID, Tool: tool, StartedAt: o.now()},
+		cancel:       cancel,
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.running[client+" "+requestID] = op
+	return op
+}
+
+// update records the progress of a tool call.
+func (o *MCPOperations) update(op *mcpOperation, progress, total float64, message string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	op.Progress, op.Total, op.Message = progress, total, message
+}
+
+// finish removes a tool call and returns whether the client cancelled it.
+func (o *MCPOperations) finish(op *mcpOperation) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := op.Client + " " + op.RequestID
+	if o.running[key] == op {
+		delete(o.running, key)
+	}
+	return op.cancelled
+}
+
+// mcpCall is a tool call of a request, as forwarded to the MCP server.
+type mcpCall struct {
+	id            string
+	name          string
+	progressToken json.RawMessage
+}
+
+// mcpOperationRequest is the state of one request to the MCP endpoint.
+type mcpOperationRequest struct {
+	client    string
+	stream    *mcpEventStream // nil if the response is not streamed
+	mu        sync.Mutex
+	calls     []mcpCall
+	next      int
+	cancelled map[string]bool // request IDs whose responses are dropped
+}
+
+// take returns the next tool call with the name. The MCP server runs the calls one after another
+// and only calls the handlers of known tools, so calls of unknown tools are skipped.
+func (r *mcpOperationRequest) take(name string) mcpCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := r.next; i < len(r.calls); i++ {
+		if r.calls[i].name == name {
+			r.next = i + 1
+			return r.calls[i]
+		}
+	}
+	return mcpCall{name: name}
+}
+
+// drop marks the response to the request ID to be dropped.
+func (r *mcpOperationRequest) drop(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancelled[id] = true
+}
+
+// dropped returns whether the response to the request ID is dropped.
+func (r *mcpOperationRequest) dropped(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cancelled[id]
+}
+
+// mcpOperationsKey is the context key for the state of the request.
+type mcpOperationsKey struct{}
+
+// mcpEventStream writes server-sent events to the client.
+type mcpEventStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	started bool
+}
+
+// send writes the JSON-RPC message as event and flushes it. The headers are sent with the first event.
+func (s *mcpEventStream) send(message []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	_, _ = s.w.Write([]byte("event: message\ndata: "))
+	_, _ = s.w.Write(message)
+	_, _ = s.w.Write([]byte("\n\n"))
+	_ = http.NewResponseController(s.w).Flush()
+}
+
+// WithToolOperations returns a copy of the server whose tool calls are tracked by the registry.
+// Each call gets a context that ends at the timeout or when the client cancels it, and reports
+// the progress of shared.ReportProgress to the client if it sent a progressToken.
+// Tools must be registered before, because the copy does not see tools added later.
+func WithToolOperations(server *mcp.Server, operations *MCPOperations) *mcp.Server {
+	tracked := mcp.NewServer(server.Name(), server.Version())
+	for _, tool := range server.Tools() {
+		handler := tool.Handler
+		name := tool.Definition.Name
+		tool.Handler = func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			req, ok := ctx.Value(mcpOperationsKey{}).(*mcpOperationRequest)
+			if !ok {
+				return handler(ctx, params)
+			}
+			call := req.take(name)
+			var cancel context.CancelFunc
+			if operations.timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, operations.timeout)
+			} else {
+				ctx, cancel = context.WithCancel(ctx)
+			}
+			defer cancel()
+
+			op := operations.start(req.client, call.id, name, cancel)
+			ctx = shared.ContextWithProgress(ctx, func(progress, total float64, message string) {
+				operations.update(op, progress, total, message)
+				if call.progressToken != nil && req.stream != nil {
+					req.stream.send(progressNotification(call.progressToken, progress, total, message))
+				}
+			})
+			result, err := handler(ctx, params)
+			if operations.finish(op) {
+				req.drop(call.id)
+			}
+			return result, err
+		}
+		tracked.RegisterTool(tool)
+	}
+	return tracked
+}
+
+// progressNotification returns a notifications/progress message.
+func progressNotification(token json.RawMessage, progress, total float64, message string) []byte {
+	params := map[string]any{"progressToken": token, "progress": progress}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "method": "notifications/progress", "params": params})
+	return data
+}
+
+// WithMCPOperations handles long-running tool calls of a server from WithToolOperations:
+//   - notifications/cancelled cancels the client's running call with the requestId and its response
+//     is dropped; a request with only notifications is answered with 202 Accepted.
+//   - If a tool call has a progressToken in _meta and the client accepts text/event-stream,
+//     the response is streamed: progress notifications are sent while the tools run,
+//     followed by the responses, each as a server-sent event.
+//   - The write deadline of the server is extended to the operation timeout.
+//
+// It must be the outermost MCP middleware, because the inner ones buffer the response.
+func WithMCPOperations(operations *MCPOperations, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		client := requestClient(r)
+		var forward [][]byte
+		streaming := false
+		for line := range bytes.SplitSeq(body, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var req mcp.Request
+			if err := json.Unmarshal(line, &req); err == nil {
+				switch req.Method {
+				case "notifications/cancelled":
+					var params struct {
+						RequestID json.RawMessage `json:"requestId"`
+					}
+					if err := json.Unmarshal(req.Params, &params); err == nil {
+						operations.Cancel(client, mcpRequestID(params.RequestID))
+					}
+					continue
+				case "tools/call":
+					if mcpProgressToken(req.Params) != nil {
+						streaming = true
+					}
+				}
+			}
+			forward = append(forward, line)
+		}
+		if len(forward) == 0 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		// Long-running tool calls may outlast the server's write timeout.
+		deadline := time.Time{}
+		if operations.timeout > 0 {
+			deadline = time.Now().Add(operations.timeout + time.Minute)
+		}
+		_ = http.NewResponseController(w).SetWriteDeadline(deadline)
+
+		state := &mcpOperationRequest{client: client, cancelled: make(map[string]bool)}
+		if streaming && strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			state.stream = &mcpEventStream{w: w}
+		}
+		rec := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		fr := r.Clone(context.WithValue(r.Context(), mcpOperationsKey{}, state))
+		fr.Body = io.NopCloser(bytes.NewReader(append(bytes.Join(forward, []byte("\n")), '\n')))
+		next(rec, fr)
+		if rec.status != http.StatusOK && (state.stream == nil || !state.stream.started) {
+			w.WriteHeader(rec.status)
+			_, _ = w.Write(rec.body.Bytes())
+			return
+		}
+
+		var output bytes.Buffer
+		for line := range bytes.SplitSeq(rec.body.Bytes(), []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			var resp struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if err := json.Unmarshal(line, &resp); err == nil && state.dropped(mcpRequestID(resp.ID)) {
+				continue
+			}
+			if state.stream != nil {
+				state.stream.send(line)
+				continue
+			}
+			output.Write(line)
+			output.WriteByte('\n')
+		}
+		if state.stream != nil {
+			return
+		}
+		if output.Len() == 0 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(output.Bytes())
+	}
+}
+
+// WithMCPCalls records the tool calls of the request in the order the MCP server runs them,
+// so WithToolOperations knows the request ID and progress token of each call.
+// Calls before initialize are skipped, because the server rejects them.
+func WithMCPCalls(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, ok := r.Context().Value(mcpOperationsKey{}).(*mcpOperationRequest)
+		if !ok {
+			next(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		initialized := false
+		var calls []mcpCall
+		for line := range bytes.SplitSeq(body, []byte("\n")) {
+			var req mcp.Request
+			if err := json.Unmarshal(line, &req); err != nil {
+				continue
+			}
+			switch req.Method {
+			case "initialize":
+				initialized = true
+			case "tools/call":
+				var params mcp.ToolsCallParams
+				if !initialized || json.Unmarshal(req.Params, &params) != nil {
+					continue
+				}
+				calls = append(calls, mcpCall{id: mcpRequestID(req.ID), name: params.Name, progressToken: mcpProgressToken(req.Params)})
+			}
+		}
+		state.mu.Lock()
+		state.calls = calls
+		state.mu.Unlock()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next(w, r)
+	}
+}
+
+// mcpProgressToken returns the progressToken in the _meta of the tools/call params, or nil.
+func mcpProgressToken(params json.RawMessage) json.RawMessage {
+	var p struct {
+		Meta struct {
+			ProgressToken json.RawMessage `json:"progressToken"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || len(p.Meta.ProgressToken) == 0 || string(p.Meta.ProgressToken) == "null" {
+		return nil
+	}
+	return p.Meta.ProgressToken
+}
+
+// mcpRequestID returns the JSON-RPC request ID as text: strings without quotes, numbers as sent.
+func mcpRequestID(id json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(id, &s); err == nil {
+		return s
+	}
+	return string(bytes.TrimSpace(id))
+}
+
+// HttpAdminMCPOperations returns the running MCP tool calls as JSON.
+func HttpAdminMCPOperations(operations *MCPOperations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(operations.Running())
+	}
+}
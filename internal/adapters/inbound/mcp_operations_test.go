@@ -0,0 +1,153 @@
+package inbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestMCPOperationsHandler serves a "scan" tool that reports two steps of progress
+// and a "wait" tool that signals started and blocks until its context ends.
+func createTestMCPOperationsHandler(operations *inbound.MCPOperations, started chan<- struct{}) http.HandlerFunc {
+	server := mcp.NewServer("test", "1.0.0")
+	server.RegisterTool(mcp.NewTool("scan", "Scan", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		shared.ReportProgress(ctx, 1, 2, "room 101")
+		shared.ReportProgress(ctx, 2, 2, "room 102")
+		return mcp.ToolsCallResult{Content: []mcp.ContentBlock{mcp.NewTextContent("scanned")}}, nil
+	}))
+	server.RegisterTool(mcp.NewTool("wait", "Wait", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return mcp.ToolsCallResult{}, ctx.Err()
+	}))
+	handler := web.NewMCPHandler(inbound.WithToolErrorCodes(inbound.WithToolOperations(server, operations)))
+	return inbound.WithMCPOperations(operations, inbound.WithMCPErrors(inbound.WithMCPCalls(handler.Handler())))
+}
+
+func mcpOperationsToolCall(id, name, meta string) string {
+	return `{"jsonrpc":"2.0","id":` + id + `,"method":"tools/call","params":{"name":"` + name + `","arguments":{}` + meta + `}}` + "\n"
+}
+
+// ============================================================================
+// WithMCPOperations Tests
+// ============================================================================
+
+func Test_WithMCPOperations_ProgressToken_With_EventStream_Should_Stream_Progress(t *testing.T) {
+	// Arrange
+	handler := createTestMCPOperationsHandler(inbound.NewMCPOperations(time.Minute), nil)
+	body := mcpQuotaInitialize + mcpOperationsToolCall("1", "scan", `,"_meta":{"progressToken":"scan-1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, req)
+
+	// Assert
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	assert.That(t, "content type must be event stream", rec.Result().Header.Get("Content-Type"), "text/event-stream")
+	assert.That(t, "event count must be 4", len(events), 4)
+	assert.That(t, "first event must be progress", strings.Contains(events[0], `"method":"notifications/progress"`), true)
+	assert.That(t, "progress must carry the token", strings.Contains(events[0], `"progressToken":"scan-1"`), true)
+	assert.That(t, "progress must carry the message", strings.Contains(events[1], `"message":"room 102"`), true)
+	assert.That(t, "last event must be the result", strings.HasPrefix(events[3], "event: message\ndata: {") && strings.Contains(events[3], "scanned"), true)
+}
+
+func Test_WithMCPOperations_Without_EventStream_Should_Return_JSON(t *testing.T) {
+	// Arrange
+	handler := createTestMCPOperationsHandler(inbound.NewMCPOperations(time.Minute), nil)
+	body := mcpQuotaInitialize + mcpOperationsToolCall("1", "scan", `,"_meta":{"progressToken":"scan-1"}`)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", body)
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	assert.That(t, "content type must be json", rec.Header().Get("Content-Type"), "application/json")
+	assert.That(t, "response count must be 2", len(responses), 2)
+	assert.That(t, "scan must have a result", responses[1]["result"] != nil, true)
+}
+
+func Test_WithMCPOperations_Cancelled_Notification_Should_Cancel_Running_Call(t *testing.T) {
+	// Arrange
+	operations := inbound.NewMCPOperations(time.Minute)
+	started := make(chan struct{}, 1)
+	handler := createTestMCPOperationsHandler(operations, started)
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpOperationsToolCall(`"wait-1"`, "wait", ""))
+	}()
+	<-started
+	running := operations.Running()
+
+	// Act
+	cancelled := postMCPAsClient(handler, "agent", `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"wait-1","reason":"user aborted"}}`+"\n")
+	rec := <-done
+
+	// Assert
+	assert.That(t, "one operation must be running", len(running), 1)
+	assert.That(t, "operation must be the call", running[0].RequestID+" "+running[0].Tool, "wait-1 wait")
+	assert.That(t, "notification must be accepted", cancelled.Code, http.StatusAccepted)
+	responses := parseMCPResponses(rec)
+	assert.That(t, "only initialize must be answered", len(responses), 1)
+	assert.That(t, "no operation must be running", len(operations.Running()), 0)
+}
+
+func Test_WithMCPOperations_Cancelled_Notification_Of_Other_Client_Should_Be_Ignored(t *testing.T) {
+	// Arrange
+	operations := inbound.NewMCPOperations(time.Minute)
+	started := make(chan struct{}, 1)
+	handler := createTestMCPOperationsHandler(operations, started)
+	go func() {
+		_ = postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpOperationsToolCall("1", "wait", ""))
+	}()
+	<-started
+	defer operations.Cancel("client:agent", "1")
+
+	// Act
+	_ = postMCPAsClient(handler, "intruder", `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`+"\n")
+
+	// Assert
+	assert.That(t, "operation must still be running", len(operations.Running()), 1)
+}
+
+func Test_WithMCPOperations_Timeout_Should_Fail_Call_As_Unavailable(t *testing.T) {
+	// Arrange
+	started := make(chan struct{}, 1)
+	handler := createTestMCPOperationsHandler(inbound.NewMCPOperations(10*time.Millisecond), started)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpOperationsToolCall("1", "wait", ""))
+
+	// Assert
+	failed := parseMCPResponses(rec)[1]["error"].(map[string]any)
+	assert.That(t, "data code must be UNAVAILABLE", failed["data"].(map[string]any)["code"], inbound.MCPErrorUnavailable)
+}
+
+// ============================================================================
+// MCPOperations Tests
+// ============================================================================
+
+func Test_MCPOperations_Cancel_Unknown_Call_Should_Return_False(t *testing.T) {
+	// Arrange
+	operations := inbound.NewMCPOperations(time.Minute)
+
+	// Act
+	ok := operations.Cancel("client:agent", "1")
+
+	// Assert
+	assert.That(t, "cancel must return false", ok, false)
+}
@@ -0,0 +1,260 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrorCodeRateLimited is the MCP error code for a tool call over the client's quota.
+const ErrorCodeRateLimited = -32029
+
+// MCPQuotaConfig configures the tool call quota per client.
+type MCPQuotaConfig struct {
+	Limit  int           // tool calls per window and client; 0 is unlimited
+	Window time.Duration // length of the quota window
+	WarnAt float64       // share of the limit from which results carry a warning, e.g. 0.8
+}
+
+// DefaultMCPQuotaConfig returns 120 tool calls per minute with a warning from 80%.
+func DefaultMCPQuotaConfig() MCPQuotaConfig {
+	return MCPQuotaConfig{
+		Limit:  120,
+		Window: time.Minute,
+		WarnAt: 0.8,
+	}
+}
+
+// MCPQuotaStatus is the quota of a client, sent to agents as hint in the _meta of results.
+type MCPQuotaStatus struct {
+	Limit        int    `json:"limit"`
+	Remaining    int    `json:"remaining"`
+	ResetSeconds int    `json:"reset_seconds"`
+	Warning      string `json:"warning,omitempty"`
+}
+
+// MCPQuota counts the tool calls of each client in fixed windows.
+// Only tools/call requests count; listing tools or reading resources is free.
+type MCPQuota struct {
+	config  MCPQuotaConfig
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+	now     func() time.Time
+}
+
+// quotaWindow is the current window of a client.
+type quotaWindow struct {
+	start time.Time
+	used  int
+}
+
+// NewMCPQuota creates a new tool call quota.
+func NewMCPQuota(config MCPQuotaConfig) *MCPQuota {
+	return &MCPQuota{
+		config:  config,
+		windows: make(map[string]*quotaWindow),
+		now:     time.Now,
+	}
+}
+
+// Take uses one tool call of the client's quota.
+// It returns false if the quota is exhausted; the status tells when it resets.
+func (q *MCPQuota) Take(client string) (MCPQuotaStatus, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	window := q.window(client)
+	if window.used >= q.config.Limit {
+		return q.status(window), false
+	}
+	window.used++
+	return q.status(window), true
+}
+
+// Status returns the client's quota without using it.
+func (q *MCPQuota) Status(client string) MCPQuotaStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.status(q.window(client))
+}
+
+// window returns the current window of the client. Expired windows of all clients are dropped
+// when a new one starts, so idle clients do not accumulate.
+func (q *MCPQuota) window(client string) *quotaWindow {
+	now := q.now()
+	window, ok := q.windows[client]
+	if ok && now.Sub(window.start) < q.config.Window {
+		return window
+	}
+	for key, w := range q.windows {
+		if now.Sub(w.start) >= q.config.Window {
+			delete(q.windows, key)
+		}
+	}
+	window = &quotaWindow{start: now}
+	q.windows[client] = window
+	return window
+}
+
+// status describes the window; a warning is added once WarnAt of the limit is used.
+func (q *MCPQuota) status(window *quotaWindow) MCPQuotaStatus {
+	reset := window.start.Add(q.config.Window).Sub(q.now())
+	s := MCPQuotaStatus{
+		Limit:        q.config.Limit,
+		Remaining:    q.config.Limit - window.used,
+		ResetSeconds: int((reset + time.Second - 1) / time.Second),
+	}
+	if q.config.WarnAt > 0 && float64(window.used) >= q.config.WarnAt*float64(q.config.Limit) {
+		s.Warning = fmt.Sprintf("Rate limit: %d of %d tool calls left, resets in %ds. Slow down or batch your work to avoid being rejected.", s.Remaining, s.Limit, s.ResetSeconds)
+	}
+	return s
+}
+
+// WithMCPQuota limits the tool calls per client (service account, user or IP address) and tells
+// agents how much of their quota is left, so they can throttle themselves before they are rejected:
+// every tools/call and tools/list result carries the quota in _meta, near the limit tool results
+// get an extra text block with a warning, and all responses have RateLimit-* headers.
+// Tool calls over the quota are answered with ErrorCodeRateLimited; if no other request
+// of the body remains, the response is 429 Too Many Requests with Retry-After.
+func WithMCPQuota(quota *MCPQuota, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if quota.config.Limit <= 0 {
+			next(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		client := requestClient(r)
+		statuses := make(map[string]MCPQuotaStatus) // by request ID
+		listIDs := make(map[string]bool)
+		var forward [][]byte
+		var rejected []mcp.Response
+		for line := range bytes.SplitSeq(body, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var req mcp.Request
+			if err := json.Unmarshal(line, &req); err == nil {
+				switch req.Method {
+				case "tools/call":
+					status, ok := quota.Take(client)
+					if !ok {
+						resp := mcp.NewErrorResponse(req.ID, ErrorCodeRateLimited, "Rate limit exceeded")
+						resp.Error.Data = status
+						rejected = append(rejected, resp)
+						continue
+					}
+					statuses[string(req.ID)] = status
+				case "tools/list":
+					listIDs[string(req.ID)] = true
+				}
+			}
+			forward = append(forward, line)
+		}
+
+		status := quota.Status(client)
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(status.ResetSeconds))
+
+		var output bytes.Buffer
+		if len(forward) > 0 {
+			rec := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			fr := r.Clone(r.Context())
+			fr.Body = io.NopCloser(bytes.NewReader(append(bytes.Join(forward, []byte("\n")), '\n')))
+			next(rec, fr)
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				_, _ = w.Write(rec.body.Bytes())
+				return
+			}
+			for line := range bytes.SplitSeq(rec.body.Bytes(), []byte("\n")) {
+				if len(line) == 0 {
+					continue
+				}
+				output.Write(withQuotaHint(line, statuses, listIDs, status))
+				output.WriteByte('\n')
+			}
+		}
+		for _, resp := range rejected {
+			data, _ := json.Marshal(resp)
+			output.Write(data)
+			output.WriteByte('\n')
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(forward) == 0 && len(rejected) > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(status.ResetSeconds))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		_, _ = w.Write(output.Bytes())
+	}
+}
+
+// requestClient identifies the client of the quota and the request limit:
+// the service account, else the user (subject claim), else the IP address.
+func requestClient(r *http.Request) string {
+	if principal, ok := shared.PrincipalFromContext(r.Context()); ok {
+		if principal.ClientID != "" {
+			return "client:" + principal.ClientID
+		}
+		if principal.Subject != "" {
+			return "subject:" + principal.Subject
+		}
+	}
+	return "ip:" + remoteIP(r)
+}
+
+// withQuotaHint adds the quota to the _meta of tools/call and tools/list results.
+// Tool results near the limit also get the warning as text block, which agents read like the result.
+// Other responses are returned unchanged.
+func withQuotaHint(line []byte, statuses map[string]MCPQuotaStatus, listIDs map[string]bool, current MCPQuotaStatus) []byte {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(line, &resp); err != nil || resp["result"] == nil {
+		return line
+	}
+	id := string(resp["id"])
+	status, isCall := statuses[id]
+	if !isCall && !listIDs[id] {
+		return line
+	}
+	if !isCall {
+		status = current
+	}
+
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(resp["result"], &result); err != nil {
+		return line
+	}
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(result["_meta"], &meta); err != nil || meta == nil {
+		meta = make(map[string]json.RawMessage)
+	}
+	meta["quota"], _ = json.Marshal(status)
+	result["_meta"], _ = json.Marshal(meta)
+
+	if isCall && status.Warning != "" {
+		var content []mcp.ContentBlock
+		_ = json.Unmarshal(result["content"], &content)
+		content = append(content, mcp.NewTextContent(status.Warning))
+		result["content"], _ = json.Marshal(content)
+	}
+
+	resp["result"], _ = json.Marshal(result)
+	patched, err := json.Marshal(resp)
+	if err != nil {
+		return line
+	}
+	return patched
+}
@@ -0,0 +1,231 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+const mcpQuotaInitialize = `{"jsonrpc":"2.0","id":0,"method":"initialize","params":{}}` + "\n"
+
+func mcpQuotaToolCall(id string) string {
+	return `{"jsonrpc":"2.0","id":` + id + `,"method":"tools/call","params":{"name":"echo","arguments":{}}}` + "\n"
+}
+
+func createTestMCPQuotaHandler(limit int) http.HandlerFunc {
+	server := mcp.NewServer("test", "1.0.0")
+	server.RegisterTool(mcp.NewTool("echo", "Echo", mcp.NewObjectSchema(nil, nil), func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		return mcp.ToolsCallResult{Content: []mcp.ContentBlock{mcp.NewTextContent("ok")}}, nil
+	}))
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: limit, Window: time.Minute, WarnAt: 0.5})
+	return inbound.WithMCPQuota(quota, web.NewMCPHandler(server).Handler())
+}
+
+func postMCPAsClient(handler http.HandlerFunc, clientID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req = req.WithContext(shared.ContextWithPrincipal(req.Context(), shared.Principal{Subject: "svc-" + clientID, ClientID: clientID}))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+func parseMCPResponses(rec *httptest.ResponseRecorder) []map[string]any {
+	var responses []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(rec.Body.String()), "\n") {
+		var resp map[string]any
+		_ = json.Unmarshal([]byte(line), &resp)
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// ============================================================================
+// MCPQuota Tests
+// ============================================================================
+
+func Test_MCPQuota_Take_Within_Limit_Should_Count_Down(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 3, Window: time.Minute})
+
+	// Act
+	status, ok := quota.Take("agent")
+
+	// Assert
+	assert.That(t, "call must be allowed", ok, true)
+	assert.That(t, "remaining must be 2", status.Remaining, 2)
+	assert.That(t, "reset must be within the window", status.ResetSeconds > 0 && status.ResetSeconds <= 60, true)
+	assert.That(t, "warning must be empty without WarnAt", status.Warning, "")
+}
+
+func Test_MCPQuota_Take_Over_Limit_Should_Reject(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 1, Window: time.Minute})
+	_, _ = quota.Take("agent")
+
+	// Act
+	status, ok := quota.Take("agent")
+
+	// Assert
+	assert.That(t, "call must be rejected", ok, false)
+	assert.That(t, "remaining must be 0", status.Remaining, 0)
+}
+
+func Test_MCPQuota_Take_Should_Count_Clients_Separately(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 1, Window: time.Minute})
+	_, _ = quota.Take("agent-a")
+
+	// Act
+	_, ok := quota.Take("agent-b")
+
+	// Assert
+	assert.That(t, "other client must be allowed", ok, true)
+}
+
+func Test_MCPQuota_Take_After_Window_Should_Reset(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 1, Window: 10 * time.Millisecond})
+	_, _ = quota.Take("agent")
+	time.Sleep(20 * time.Millisecond)
+
+	// Act
+	_, ok := quota.Take("agent")
+
+	// Assert
+	assert.That(t, "call must be allowed in the new window", ok, true)
+}
+
+func Test_MCPQuota_Take_Near_Limit_Should_Warn(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 10, Window: time.Minute, WarnAt: 0.8})
+	for range 7 {
+		_, _ = quota.Take("agent")
+	}
+
+	// Act
+	status, _ := quota.Take("agent")
+
+	// Assert
+	assert.That(t, "warning must be set", status.Warning != "", true)
+	assert.That(t, "warning must mention the remaining calls", strings.Contains(status.Warning, "2 of 10"), true)
+}
+
+func Test_MCPQuota_Status_Should_Not_Use_Quota(t *testing.T) {
+	// Arrange
+	quota := inbound.NewMCPQuota(inbound.MCPQuotaConfig{Limit: 2, Window: time.Minute})
+
+	// Act
+	_ = quota.Status("agent")
+	status := quota.Status("agent")
+
+	// Assert
+	assert.That(t, "remaining must be 2", status.Remaining, 2)
+}
+
+// ============================================================================
+// WithMCPQuota Tests
+// ============================================================================
+
+func Test_WithMCPQuota_ToolCall_Should_Add_Quota_Meta_And_Headers(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(10)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpQuotaToolCall("1"))
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "RateLimit-Limit must be set", rec.Header().Get("RateLimit-Limit"), "10")
+	assert.That(t, "RateLimit-Remaining must be set", rec.Header().Get("RateLimit-Remaining"), "9")
+	responses := parseMCPResponses(rec)
+	result := responses[1]["result"].(map[string]any)
+	quota := result["_meta"].(map[string]any)["quota"].(map[string]any)
+	assert.That(t, "remaining must be 9", quota["remaining"], float64(9))
+	assert.That(t, "content must not have a warning", len(result["content"].([]any)), 1)
+}
+
+func Test_WithMCPQuota_ToolCall_Near_Limit_Should_Add_Warning_Content(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(2)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpQuotaToolCall("1"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	result := responses[1]["result"].(map[string]any)
+	content := result["content"].([]any)
+	assert.That(t, "content must have the warning", len(content), 2)
+	assert.That(t, "warning must be text", strings.HasPrefix(content[1].(map[string]any)["text"].(string), "Rate limit:"), true)
+	assert.That(t, "meta must have the warning", result["_meta"].(map[string]any)["quota"].(map[string]any)["warning"] != nil, true)
+}
+
+func Test_WithMCPQuota_ToolCall_Over_Limit_Should_Return_Rate_Limited_Error(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(1)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpQuotaToolCall("1")+mcpQuotaToolCall("2"))
+
+	// Assert
+	assert.That(t, "status code must be 200 for partial success", rec.Code, http.StatusOK)
+	responses := parseMCPResponses(rec)
+	assert.That(t, "response count must be 3", len(responses), 3)
+	rejected := responses[2]["error"].(map[string]any)
+	assert.That(t, "error code must be rate limited", rejected["code"], float64(inbound.ErrorCodeRateLimited))
+	assert.That(t, "error data must have the reset", rejected["data"].(map[string]any)["reset_seconds"] != nil, true)
+}
+
+func Test_WithMCPQuota_Only_Rejected_Calls_Should_Return_429(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(1)
+	_ = postMCPAsClient(handler, "agent", mcpQuotaInitialize+mcpQuotaToolCall("1"))
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaToolCall("2"))
+
+	// Assert
+	assert.That(t, "status code must be 429", rec.Code, http.StatusTooManyRequests)
+	assert.That(t, "Retry-After must be set", rec.Header().Get("Retry-After") != "", true)
+}
+
+func Test_WithMCPQuota_Should_Count_Service_Accounts_Separately(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(1)
+	_ = postMCPAsClient(handler, "agent-a", mcpQuotaInitialize+mcpQuotaToolCall("1"))
+
+	// Act
+	rec := postMCPAsClient(handler, "agent-b", mcpQuotaInitialize+mcpQuotaToolCall("1"))
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	assert.That(t, "call of other account must succeed", responses[1]["error"] == nil, true)
+}
+
+func Test_WithMCPQuota_ToolsList_Should_Add_Quota_Meta_Without_Using_It(t *testing.T) {
+	// Arrange
+	handler := createTestMCPQuotaHandler(5)
+
+	// Act
+	rec := postMCPAsClient(handler, "agent", mcpQuotaInitialize+`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	// Assert
+	responses := parseMCPResponses(rec)
+	quota := responses[1]["result"].(map[string]any)["_meta"].(map[string]any)["quota"].(map[string]any)
+	assert.That(t, "remaining must be 5", quota["remaining"], float64(5))
+	assert.That(t, "tools must be kept", len(responses[1]["result"].(map[string]any)["tools"].([]any)), 1)
+}
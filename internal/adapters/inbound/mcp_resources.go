@@ -0,0 +1,215 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+)
+
+// ErrorCodeResourceNotFound is the MCP error code for an unknown resource URI.
+const ErrorCodeResourceNotFound = -32002
+
+// MCPResource is a read-only document that MCP clients can list and read,
+// e.g. the FAQ, so support agents can cite it.
+type MCPResource struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+	Read        func(ctx context.Context) (string, error)
+}
+
+// MCPResources is the registry of the MCP resources.
+type MCPResources struct {
+	mu        sync.RWMutex
+	resources map[string]MCPResource
+}
+
+// NewMCPResources creates an empty MCP resource registry.
+func NewMCPResources() *MCPResources {
+	return &MCPResources{resources: make(map[string]MCPResource)}
+}
+
+// Register adds the resource, replacing a resource with the same URI.
+func (a *MCPResources) Register(resource MCPResource) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.resources[resource.URI] = resource
+}
+
+// Resources returns all registered resources sorted by URI.
+func (a *MCPResources) Resources() []MCPResource {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	resources := make([]MCPResource, 0, len(a.resources))
+	for _, resource := range a.resources {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].URI < resources[j].URI })
+	return resources
+}
+
+// Resource returns the resource with the URI.
+func (a *MCPResources) Resource(uri string) (MCPResource, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	resource, ok := a.resources[uri]
+	return resource, ok
+}
+
+// WithMCPResources answers the resources/list and resources/read requests of an MCP request body
+// and forwards all other requests to next, the MCP handler of cloud-native-utils, which only supports tools.
+// The resources capability is added to the initialize response, so clients discover the resources.
+// Responses to forwarded requests come first; JSON-RPC clients match responses by ID.
+func WithMCPResources(resources *MCPResources, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+
+		var forward [][]byte
+		var answers []mcp.Response
+		for line := range bytes.SplitSeq(body, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var req mcp.Request
+			if err := json.Unmarshal(line, &req); err == nil {
+				switch req.Method {
+				case "resources/list":
+					answers = append(answers, listMCPResources(resources, req))
+					continue
+				case "resources/read":
+					answers = append(answers, readMCPResource(r.Context(), resources, req))
+					continue
+				}
+			}
+			forward = append(forward, line)
+		}
+
+		var output bytes.Buffer
+		if len(forward) > 0 {
+			// Run the MCP handler on the remaining requests and capture its responses.
+			rec := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			fr := r.Clone(r.Context())
+			fr.Body = io.NopCloser(bytes.NewReader(append(bytes.Join(forward, []byte("\n")), '\n')))
+			next(rec, fr)
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				_, _ = w.Write(rec.body.Bytes())
+				return
+			}
+			for line := range bytes.SplitSeq(rec.body.Bytes(), []byte("\n")) {
+				if len(line) == 0 {
+					continue
+				}
+				output.Write(withResourcesCapability(line))
+				output.WriteByte('\n')
+			}
+		}
+		for _, answer := range answers {
+			data, _ := json.Marshal(answer)
+			output.Write(data)
+			output.WriteByte('\n')
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(output.Bytes())
+	}
+}
+
+// bufferedResponseWriter captures the status and body of the MCP handler, so its responses can be merged.
+// Headers are written to the underlying response writer.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code.
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+// Write buffers the body.
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// listMCPResources answers a resources/list request.
+func listMCPResources(resources *MCPResources, req mcp.Request) mcp.Response {
+	type resourceDefinition struct {
+		URI         string `json:"uri"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		MimeType    string `json:"mimeType,omitempty"`
+	}
+	list := []resourceDefinition{}
+	for _, resource := range resources.Resources() {
+		list = append(list, resourceDefinition{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MimeType:    resource.MimeType,
+		})
+	}
+	return mcp.NewResponse(req.ID, map[string]any{"resources": list})
+}
+
+// readMCPResource answers a resources/read request.
+func readMCPResource(ctx context.Context, resources *MCPResources, req mcp.Request) mcp.Response {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.URI == "" {
+		return mcp.NewErrorResponse(req.ID, mcp.ErrorCodeInvalidParams, "Invalid params")
+	}
+	resource, ok := resources.Resource(params.URI)
+	if !ok {
+		return mcp.NewErrorResponse(req.ID, ErrorCodeResourceNotFound, "Resource not found")
+	}
+	text, err := resource.Read(ctx)
+	if err != nil {
+		return mcp.NewErrorResponse(req.ID, mcp.ErrorCodeInternal, err.Error())
+	}
+	return mcp.NewResponse(req.ID, map[string]any{
+		"contents": []map[string]string{{
+			"uri":      resource.URI,
+			"mimeType": resource.MimeType,
+			"text":     text,
+		}},
+	})
+}
+
+// withResourcesCapability adds the resources capability to an initialize response.
+// Other responses are returned unchanged.
+func withResourcesCapability(line []byte) []byte {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return line
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(resp["result"], &result); err != nil || result["protocolVersion"] == nil {
+		return line
+	}
+	var capabilities map[string]json.RawMessage
+	if err := json.Unmarshal(result["capabilities"], &capabilities); err != nil || capabilities == nil {
+		capabilities = make(map[string]json.RawMessage)
+	}
+	capabilities["resources"] = json.RawMessage(`{}`)
+	result["capabilities"], _ = json.Marshal(capabilities)
+	resp["result"], _ = json.Marshal(result)
+	patched, err := json.Marshal(resp)
+	if err != nil {
+		return line
+	}
+	return patched
+}
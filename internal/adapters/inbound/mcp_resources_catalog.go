@@ -0,0 +1,140 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// URIs of the MCP resources describing the rooms and the policies.
+const (
+	MCPResourceRoomCatalog        = "rooms://catalog"
+	MCPResourceCancellationPolicy = "policies://cancellation"
+)
+
+// MCPRoomCatalog is the JSON document of the rooms://catalog resource.
+type MCPRoomCatalog struct {
+	RoomTypes []MCPRoomCatalogType `json:"room_types"`
+}
+
+// MCPRoomCatalogType is a room type with its rooms.
+type MCPRoomCatalogType struct {
+	ID       string               `json:"id"`
+	BaseRate MCPRoomCatalogPrice  `json:"base_rate"` // per night, before rules of the price calendar
+	Rooms    []MCPRoomCatalogRoom `json:"rooms"`
+}
+
+// MCPRoomCatalogRoom is a room with the rate plan that prices its bookings.
+type MCPRoomCatalogRoom struct {
+	ID               string                   `json:"id"`
+	BaseRate         MCPRoomCatalogPrice      `json:"base_rate"` // per night, before seasons and surcharges
+	Seasons          []MCPRoomCatalogSeason   `json:"seasons,omitempty"`
+	WeekendSurcharge int                      `json:"weekend_surcharge_percent,omitempty"`
+	StayDiscounts    []MCPRoomCatalogDiscount `json:"stay_discounts,omitempty"`
+}
+
+// MCPRoomCatalogPrice is an amount in the smallest unit of its currency with its en-US text.
+type MCPRoomCatalogPrice struct {
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Formatted string `json:"formatted"`
+}
+
+// MCPRoomCatalogSeason is a season of a rate plan.
+type MCPRoomCatalogSeason struct {
+	Name    string `json:"name"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Percent int    `json:"percent"`
+}
+
+// MCPRoomCatalogDiscount is a stay discount of a rate plan.
+type MCPRoomCatalogDiscount struct {
+	MinNights int `json:"min_nights"`
+	Percent   int `json:"percent"`
+}
+
+// NewRoomCatalogResource creates the rooms://catalog resource listing the room types of the rates
+// and the rate plans of their rooms, read at request time, so reloaded rates and saved plans show at once.
+// Without a pricing service the rooms are listed at the base rate of their room type;
+// rooms without a rate plan cannot be booked and are left out.
+// Agents can quote typical prices from it; the exact price of a stay still comes from quote_stay.
+func NewRoomCatalogResource(rates *reservation.Rates, pricingService *pricing.Service) MCPResource {
+	return MCPResource{
+		URI:         MCPResourceRoomCatalog,
+		Name:        "Room Catalog",
+		Description: "Room types, their rooms and nightly rates including seasons, weekend surcharges and stay discounts",
+		MimeType:    "application/json",
+		Read: func(ctx context.Context) (string, error) {
+			catalog := MCPRoomCatalog{RoomTypes: []MCPRoomCatalogType{}}
+			for _, roomType := range rates.RoomTypes() {
+				entry := MCPRoomCatalogType{ID: string(roomType.ID), BaseRate: newMCPRoomCatalogPrice(roomType.BaseRate)}
+				for _, roomID := range roomType.RoomIDs {
+					room := MCPRoomCatalogRoom{ID: string(roomID), BaseRate: entry.BaseRate}
+					if pricingService != nil {
+						plan, err := pricingService.RatePlan(ctx, pricing.RoomID(roomID))
+						if errors.Is(err, pricing.ErrNoRatePlan) {
+							continue
+						}
+						if err != nil {
+							return "", err
+						}
+						room = newMCPRoomCatalogRoom(*plan)
+					}
+					entry.Rooms = append(entry.Rooms, room)
+				}
+				catalog.RoomTypes = append(catalog.RoomTypes, entry)
+			}
+			data, err := json.Marshal(catalog)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+	}
+}
+
+// newMCPRoomCatalogRoom converts a rate plan to its catalog entry.
+func newMCPRoomCatalogRoom(plan pricing.RatePlan) MCPRoomCatalogRoom {
+	room := MCPRoomCatalogRoom{ID: string(plan.RoomID), BaseRate: newMCPRoomCatalogPrice(plan.BaseRate), WeekendSurcharge: plan.WeekendSurcharge}
+	for _, season := range plan.Seasons {
+		room.Seasons = append(room.Seasons, MCPRoomCatalogSeason(season))
+	}
+	for _, discount := range plan.StayDiscounts {
+		room.StayDiscounts = append(room.StayDiscounts, MCPRoomCatalogDiscount(discount))
+	}
+	return room
+}
+
+// newMCPRoomCatalogPrice converts an amount to its catalog entry.
+func newMCPRoomCatalogPrice(m shared.Money) MCPRoomCatalogPrice {
+	return MCPRoomCatalogPrice{Amount: m.Amount, Currency: m.Currency, Formatted: m.FormatIn(shared.DefaultLocale)}
+}
+
+// NewCancellationPolicyResource creates the policies://cancellation resource describing the
+// cancellation rules as reservation.Reservation.Cancel enforces them, unlike the editable policies page.
+func NewCancellationPolicyResource() MCPResource {
+	return MCPResource{
+		URI:         MCPResourceCancellationPolicy,
+		Name:        "Cancellation Policy",
+		Description: "When reservations can be cancelled and how cancellations are requested",
+		MimeType:    "text/markdown",
+		Read: func(ctx context.Context) (string, error) {
+			hours := int(reservation.CancellationNoticePeriod.Hours())
+			var b strings.Builder
+			b.WriteString("# Cancellation Policy\n\n")
+			fmt.Fprintf(&b, "- Pending and confirmed reservations can be cancelled until %d hours before check-in (the cancellation deadline).\n", hours)
+			fmt.Fprintf(&b, "- Within %d hours of check-in a cancellation is refused; the guest has to contact the front desk.\n", hours)
+			b.WriteString("- Active reservations (checked in), completed and already cancelled reservations cannot be cancelled.\n")
+			b.WriteString("- Guests cancel their own reservations and those shared with them for managing; staff and service accounts with the `" + reservation.ScopeWrite + "` scope may cancel every reservation.\n")
+			fmt.Fprintf(&b, "- Agents cancel with `cancel_reservation`, or with `cancel_reservations` for up to %d reservations per call.\n", reservation.MaxBulkCancellations)
+			return b.String(), nil
+		},
+	}
+}
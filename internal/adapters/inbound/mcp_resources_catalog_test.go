@@ -0,0 +1,88 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func readRoomCatalog(t *testing.T, resource inbound.MCPResource) inbound.MCPRoomCatalog {
+	t.Helper()
+	text, err := resource.Read(context.Background())
+	assert.That(t, "err must be nil", err, nil)
+	var catalog inbound.MCPRoomCatalog
+	_ = json.Unmarshal([]byte(text), &catalog)
+	return catalog
+}
+
+// ============================================================================
+// NewRoomCatalogResource Tests
+// ============================================================================
+
+func Test_NewRoomCatalogResource_Without_Pricing_Should_List_Room_Types_At_Base_Rate(t *testing.T) {
+	// Arrange
+	resource := inbound.NewRoomCatalogResource(reservation.NewRates(reservation.DefaultRatePolicy()), nil)
+
+	// Act
+	catalog := readRoomCatalog(t, resource)
+
+	// Assert
+	assert.That(t, "uri must be rooms://catalog", resource.URI, "rooms://catalog")
+	assert.That(t, "all room types must be listed", len(catalog.RoomTypes), 3)
+	deluxe := catalog.RoomTypes[1]
+	assert.That(t, "room type must be deluxe", deluxe.ID, "deluxe")
+	assert.That(t, "base rate must be formatted", deluxe.BaseRate.Formatted, "$149.00")
+	assert.That(t, "rooms must be listed", len(deluxe.Rooms), 2)
+	assert.That(t, "room must have the base rate of its type", deluxe.Rooms[0].BaseRate.Amount, int64(14900))
+}
+
+func Test_NewRoomCatalogResource_With_Pricing_Should_List_Rate_Plans(t *testing.T) {
+	// Arrange
+	pricingService := createTestPricingService()
+	_, _ = pricingService.SaveRatePlan(context.Background(), pricing.RatePlan{
+		RoomID:        "room-201",
+		BaseRate:      shared.NewMoney(17900, "USD"),
+		Seasons:       []pricing.Season{{Name: "summer", From: "06-01", To: "08-31", Percent: 20}},
+		StayDiscounts: []pricing.StayDiscount{{MinNights: 7, Percent: 10}},
+	})
+	resource := inbound.NewRoomCatalogResource(reservation.NewRates(reservation.DefaultRatePolicy()), pricingService)
+
+	// Act
+	catalog := readRoomCatalog(t, resource)
+
+	// Assert
+	room := catalog.RoomTypes[1].Rooms[0]
+	assert.That(t, "room must be room-201", room.ID, "room-201")
+	assert.That(t, "base rate must be the one of the plan", room.BaseRate.Amount, int64(17900))
+	assert.That(t, "room without a plan must be left out", len(catalog.RoomTypes[1].Rooms), 1)
+	assert.That(t, "seasons must be listed", room.Seasons, []inbound.MCPRoomCatalogSeason{{Name: "summer", From: "06-01", To: "08-31", Percent: 20}})
+	assert.That(t, "stay discounts must be listed", room.StayDiscounts, []inbound.MCPRoomCatalogDiscount{{MinNights: 7, Percent: 10}})
+}
+
+// ============================================================================
+// NewCancellationPolicyResource Tests
+// ============================================================================
+
+func Test_NewCancellationPolicyResource_Should_Describe_Notice_Period(t *testing.T) {
+	// Arrange
+	resource := inbound.NewCancellationPolicyResource()
+
+	// Act
+	text, err := resource.Read(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "uri must be policies://cancellation", resource.URI, "policies://cancellation")
+	assert.That(t, "notice period must be named", strings.Contains(text, "until 24 hours before check-in"), true)
+}
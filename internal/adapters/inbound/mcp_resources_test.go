@@ -0,0 +1,141 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestMCPResourcesHandler() http.HandlerFunc {
+	resources := inbound.NewMCPResources()
+	resources.Register(inbound.MCPResource{
+		URI:      "content://faq",
+		Name:     "FAQ",
+		MimeType: "text/markdown",
+		Read: func(ctx context.Context) (string, error) {
+			return "# FAQ", nil
+		},
+	})
+	resources.Register(inbound.MCPResource{
+		URI:  "content://broken",
+		Name: "Broken",
+		Read: func(ctx context.Context) (string, error) {
+			return "", errors.New("read failed")
+		},
+	})
+	server := mcp.NewServer("test", "1.0.0")
+	return inbound.WithMCPResources(resources, web.NewMCPHandler(server).Handler())
+}
+
+func postMCP(handler http.HandlerFunc, body string) []map[string]any {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	var responses []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(rec.Body.String()), "\n") {
+		var resp map[string]any
+		_ = json.Unmarshal([]byte(line), &resp)
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// ============================================================================
+// WithMCPResources Tests
+// ============================================================================
+
+func Test_WithMCPResources_Initialize_Should_Announce_Resources_Capability(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+
+	// Act
+	responses := postMCP(handler, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+
+	// Assert
+	capabilities := responses[0]["result"].(map[string]any)["capabilities"].(map[string]any)
+	assert.That(t, "tools capability must be kept", capabilities["tools"] != nil, true)
+	assert.That(t, "resources capability must be added", capabilities["resources"] != nil, true)
+}
+
+func Test_WithMCPResources_List_Should_Return_Resources(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+
+	// Act
+	responses := postMCP(handler, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+
+	// Assert
+	resources := responses[0]["result"].(map[string]any)["resources"].([]any)
+	assert.That(t, "resources count must be 2", len(resources), 2)
+	assert.That(t, "resources must be sorted by uri", resources[1].(map[string]any)["uri"], "content://faq")
+}
+
+func Test_WithMCPResources_Read_Should_Return_Contents(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+
+	// Act
+	responses := postMCP(handler, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"content://faq"}}`)
+
+	// Assert
+	contents := responses[0]["result"].(map[string]any)["contents"].([]any)
+	content := contents[0].(map[string]any)
+	assert.That(t, "uri must match", content["uri"], "content://faq")
+	assert.That(t, "mime type must match", content["mimeType"], "text/markdown")
+	assert.That(t, "text must match", content["text"], "# FAQ")
+}
+
+func Test_WithMCPResources_Read_Unknown_URI_Should_Return_Error(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+
+	// Act
+	responses := postMCP(handler, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"content://missing"}}`)
+
+	// Assert
+	code := responses[0]["error"].(map[string]any)["code"].(float64)
+	assert.That(t, "error code must be resource not found", int(code), inbound.ErrorCodeResourceNotFound)
+}
+
+func Test_WithMCPResources_Read_Failing_Resource_Should_Return_Internal_Error(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+
+	// Act
+	responses := postMCP(handler, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"content://broken"}}`)
+
+	// Assert
+	code := responses[0]["error"].(map[string]any)["code"].(float64)
+	assert.That(t, "error code must be internal", int(code), mcp.ErrorCodeInternal)
+}
+
+func Test_WithMCPResources_Should_Forward_Other_Requests(t *testing.T) {
+	// Arrange
+	handler := createTestMCPResourcesHandler()
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}` + "\n" +
+		`{"jsonrpc":"2.0","id":2,"method":"resources/list"}` + "\n" +
+		`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`
+
+	// Act
+	responses := postMCP(handler, body)
+
+	// Assert
+	assert.That(t, "responses count must be 3", len(responses), 3)
+	assert.That(t, "tools/list must be answered by the mcp handler", responses[1]["id"], float64(3))
+	assert.That(t, "tools/list must succeed", responses[1]["error"] == nil, true)
+	assert.That(t, "resources/list must be answered last", responses[2]["id"], float64(2))
+}
@@ -0,0 +1,67 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// CheckOutStays returns the auto_complete job with overstay handling: guests still checked in after check-out
+// time on their check-out day are offered the next night or reported to the front desk, then the stays whose
+// check-out day has passed are completed. Alerts are warnings with alert=true, for the log-based alerting;
+// the job reports the offers, alerts and completions.
+func CheckOutStays(reservationService *reservation.Service, logger *slog.Logger) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		report, overstayErr := reservationService.HandleOverstays(ctx, now)
+		for _, alert := range report.Alerts {
+			logger.Warn("guest overstayed check-out",
+				"alert", true,
+				"reservation_id", alert.ReservationID,
+				"room_id", alert.RoomID,
+				"property_id", alert.PropertyID,
+				"check_out", alert.CheckOut.Format(time.DateOnly),
+				"outcome", alert.Outcome,
+			)
+		}
+		completed, err := reservationService.CompleteDepartedStays(ctx, now)
+		return len(report.Offered) + len(report.Alerts) + len(completed), errors.Join(overstayErr, err)
+	}
+}
+
+// SubscribeExtensionCharges charges the night of an accepted extension on the folio of the reservation as an
+// adjustment, so it shows on the financial summary and reaches the ledger. The adjustment ID is derived from the
+// reservation and the night, so a redelivered event charges nothing.
+func SubscribeExtensionCharges(ctx context.Context, dispatcher messaging.Dispatcher, financialService *payment.FinancialService) error {
+	fn := func(msg messaging.Message) (messaging.MessageState, error) {
+		var evt reservation.EventExtended
+		if err := json.Unmarshal(msg.Data, &evt); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		night := evt.Night.Format(time.DateOnly)
+		id := payment.AdjustmentID("extension-" + string(evt.ReservationID) + "-" + night)
+		adjustments, err := financialService.ListAdjustments(ctx)
+		if err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		if slices.ContainsFunc(adjustments, func(a payment.Adjustment) bool { return a.ID == id }) {
+			return messaging.MessageStateCompleted, nil
+		}
+		if _, err := financialService.AddAdjustment(ctx, id, evt.ReservationID, evt.Amount, "Extension night "+night); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicExtended, service.Wrap(fn)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicExtended, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package inbound_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Overstay Test Helpers
+// ============================================================================
+
+// fixedNightPrices prices every night at 99.00 USD.
+type fixedNightPrices struct{}
+
+func (fixedNightPrices) NightPrice(ctx context.Context, roomID reservation.RoomID, night time.Time) (shared.Money, error) {
+	return shared.NewMoney(9900, "USD"), nil
+}
+
+// discardExtensionOffers sends no offers.
+type discardExtensionOffers struct{}
+
+func (discardExtensionOffers) SendExtensionOffer(ctx context.Context, r *reservation.Reservation, offer reservation.Overstay) error {
+	return nil
+}
+
+// storeActiveStay stores a checked-in stay of room-101 for the guest of addAuthContext checking out in ten days and returns its check-out day.
+func storeActiveStay(repo *mockReservationRepository) time.Time {
+	checkIn := time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	res := createOwnedTestReservation("res-001", "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+	res.Status = reservation.StatusActive
+	repo.put("res-001", *res)
+	return res.DateRange.CheckOut
+}
+
+// ============================================================================
+// CheckOutStays Tests
+// ============================================================================
+
+func Test_CheckOutStays_Should_Alert_When_Next_Night_Is_Booked(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checkOut := storeActiveStay(repo)
+	next := createTestReservation("res-002", "next@example.com", "room-101", checkOut, checkOut.AddDate(0, 0, 2))
+	repo.put("res-002", *next)
+	service := createDetailTestService(repo).WithOverstays(reservation.DefaultOverstayPolicy(), fixedNightPrices{}, discardExtensionOffers{})
+	var buf bytes.Buffer
+	job := inbound.CheckOutStays(service, slog.New(slog.NewTextHandler(&buf, nil)))
+
+	// Act
+	count, err := job(context.Background(), checkOut.Add(12*time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "overstay must be counted", count, 1)
+	assert.That(t, "alert must be logged", strings.Contains(buf.String(), "alert=true"), true)
+	assert.That(t, "alert must tell the room is unavailable", strings.Contains(buf.String(), "outcome=unavailable"), true)
+}
+
+func Test_CheckOutStays_Should_Complete_Departed_Stays(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checkOut := storeActiveStay(repo)
+	service := createDetailTestService(repo).WithOverstays(reservation.DefaultOverstayPolicy(), fixedNightPrices{}, discardExtensionOffers{})
+	job := inbound.CheckOutStays(service, slog.New(slog.DiscardHandler))
+
+	// Act
+	count, err := job(context.Background(), checkOut.AddDate(0, 0, 1).Add(time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "stay must be counted", count, 1)
+	assert.That(t, "stay must be completed", repo.get("res-001").Status, reservation.StatusCompleted)
+}
+
+// ============================================================================
+// SubscribeExtensionCharges Tests
+// ============================================================================
+
+func Test_SubscribeExtensionCharges_Should_Charge_Night_Once(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	storeActiveStay(repo)
+	paymentService := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	financials := payment.NewFinancialService(paymentService, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), outbound.NewReservationRoomCharges(createDetailTestService(repo)))
+	dispatcher := messaging.NewInternalDispatcher()
+	ctx := context.Background()
+	_ = inbound.SubscribeExtensionCharges(ctx, dispatcher, financials)
+	msg := messaging.NewMessage(reservation.EventTopicExtended, []byte(`{"reservation_id":"res-001","night":"2030-06-04T00:00:00Z","amount":{"Currency":"USD","Amount":9900}}`))
+
+	// Act
+	err := dispatcher.Publish(ctx, msg)
+	_ = dispatcher.Publish(ctx, msg)
+
+	// Assert
+	adjustments, _ := financials.ListAdjustments(ctx)
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "night must be charged once", len(adjustments), 1)
+	assert.That(t, "charge must be the offered amount", adjustments[0].Amount, shared.NewMoney(9900, "USD"))
+	assert.That(t, "charge must name the night", adjustments[0].Reason, "Extension night 2030-06-04")
+}
@@ -0,0 +1,35 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// SubscribePromotionEvents subscribes to cancelled reservations to give back the use of the
+// promo code applied to them, so a cancelled booking does not count towards the usage limits.
+// Releasing is idempotent, so redelivered events are harmless.
+func SubscribePromotionEvents(ctx context.Context, dispatcher messaging.Dispatcher, promotionService *promotion.Service) error {
+	cancelled := func(msg messaging.Message) (messaging.MessageState, error) {
+		var evt struct {
+			ReservationID shared.ReservationID `json:"reservation_id"`
+		}
+		if err := json.Unmarshal(msg.Data, &evt); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		if _, err := promotionService.Release(ctx, evt.ReservationID); err != nil {
+			return messaging.MessageStateFailed, err
+		}
+		return messaging.MessageStateCompleted, nil
+	}
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCancelled, service.Wrap(cancelled)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCancelled, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+)
+
+// ReportSender sends a scheduled report to a recipient of the subscription.
+// The file is the report in the csv or xlsx format of the subscription, or nil for the html format.
+type ReportSender interface {
+	SendReport(ctx context.Context, sub *report.Subscription, r *report.Report, recipient report.Recipient, file *report.File) error
+}
+
+// SendReports returns the report_subscriptions job: it builds the report of every due subscription
+// and sends it to the recipients. A subscription is marked sent once all its emails are queued;
+// if one fails, the whole subscription is retried on the next run. It returns the number of emails.
+func SendReports(reportService *report.Service, sender ReportSender) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		due, err := reportService.Due(ctx, now)
+		if err != nil {
+			return 0, err
+		}
+		var errs []error
+		sent := 0
+		for _, sub := range due {
+			n, err := sendReport(ctx, reportService, sender, sub)
+			sent += n
+			if err == nil {
+				err = reportService.MarkSent(ctx, sub.ID, now)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return sent, errors.Join(errs...)
+	}
+}
+
+// sendReport builds the report of the subscription and sends it to each recipient.
+func sendReport(ctx context.Context, reportService *report.Service, sender ReportSender, sub report.Subscription) (int, error) {
+	r, err := reportService.Build(ctx, sub)
+	if err != nil {
+		return 0, err
+	}
+	var file *report.File
+	if sub.Format != report.FormatHTML {
+		if file, err = renderReportFile(r, sub.Format); err != nil {
+			return 0, err
+		}
+	}
+	sent := 0
+	for _, recipient := range sub.Recipients {
+		if err := sender.SendReport(ctx, &sub, r, recipient, file); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// renderReportFile renders the report table with the export writer of the format (csv or xlsx),
+// named after the type, property and first night, e.g. occupancy-main-2026-07-06.csv.
+func renderReportFile(r *report.Report, format report.Format) (*report.File, error) {
+	var buf bytes.Buffer
+	export, err := NewExportWriter(string(format), &buf)
+	if err != nil {
+		return nil, err
+	}
+	columns, rows := r.Table()
+	exportColumns := make([]ExportColumn, len(columns))
+	for i, c := range columns {
+		exportColumns[i] = ExportColumn{Name: c.Name, Numeric: c.Numeric}
+	}
+	if err := export.WriteHeader(exportColumns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := export.WriteRow(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := export.Close(); err != nil {
+		return nil, err
+	}
+	return &report.File{
+		Name:        string(r.Type) + "-" + string(r.PropertyID) + "-" + r.From.Format(time.DateOnly) + export.Extension(),
+		ContentType: export.ContentType(),
+		Content:     buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package inbound_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+)
+
+// mockReportSender records the sent reports and fails for the recipient failFor.
+type mockReportSender struct {
+	failFor    string
+	recipients []string
+	files      []*report.File
+}
+
+func (m *mockReportSender) SendReport(ctx context.Context, sub *report.Subscription, r *report.Report, recipient report.Recipient, file *report.File) error {
+	if recipient.Email == m.failFor {
+		return errors.New("mail server down")
+	}
+	m.recipients = append(m.recipients, recipient.Email)
+	m.files = append(m.files, file)
+	return nil
+}
+
+// ============================================================================
+// SendReports Tests
+// ============================================================================
+
+func Test_SendReports_Should_Send_Due_Reports_And_Schedule_Next(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService(t)
+	sub := subscribeTestReport(t, svc, "ber")
+	sender := &mockReportSender{}
+	job := inbound.SendReports(svc, sender)
+
+	// Act
+	early, _ := job(context.Background(), sub.NextRunAt.Add(-time.Minute))
+	sent, err := job(context.Background(), sub.NextRunAt)
+	again, _ := job(context.Background(), sub.NextRunAt.Add(time.Minute))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "report must not be sent early", early, 0)
+	assert.That(t, "report must be sent when due", sent, 1)
+	assert.That(t, "report must be sent once", again, 0)
+	assert.That(t, "html report must not attach a file", sender.files[0] == nil, true)
+}
+
+func Test_SendReports_With_XLSX_Format_Should_Attach_Workbook(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService(t)
+	schedule := report.Schedule{Frequency: report.FrequencyDaily, Hour: 6}
+	sub, _ := svc.Subscribe(context.Background(), "sub-1", "ber", report.TypeRevenue, schedule, report.FormatXLSX, []string{"gm@example.com"})
+	sender := &mockReportSender{}
+
+	// Act
+	_, err := inbound.SendReports(svc, sender)(context.Background(), sub.NextRunAt)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	file := sender.files[0]
+	from, _ := schedule.Period(sub.NextRunAt, time.UTC)
+	assert.That(t, "file must be named after the report", file.Name, "revenue-ber-"+from.Format(time.DateOnly)+".xlsx")
+	_, err = zip.NewReader(bytes.NewReader(file.Content), int64(len(file.Content)))
+	assert.That(t, "file must be a workbook", err, nil)
+}
+
+func Test_SendReports_When_Sending_Fails_Should_Retry_Subscription(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService(t)
+	schedule := report.Schedule{Frequency: report.FrequencyDaily, Hour: 6}
+	sub, _ := svc.Subscribe(context.Background(), "sub-1", "ber", report.TypeOccupancy, schedule, report.FormatCSV, []string{"gm@example.com", "owner@example.com"})
+	sender := &mockReportSender{failFor: "owner@example.com"}
+
+	// Act
+	sent, err := inbound.SendReports(svc, sender)(context.Background(), sub.NextRunAt)
+
+	// Assert
+	assert.That(t, "err must be returned", err != nil, true)
+	assert.That(t, "first recipient must be counted", sent, 1)
+	due, _ := svc.Due(context.Background(), sub.NextRunAt)
+	assert.That(t, "subscription must stay due", len(due), 1)
+}
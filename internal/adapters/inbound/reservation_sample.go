@@ -0,0 +1,213 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// This file contains the anonymized reservation sample for data science: a share of the reservations
+// without names, emails, phone numbers, emergency contacts or free text, whose IDs are replaced by
+// pseudonyms. Stays, lead times and prices are kept as they are, so their distributions are those of
+// the sampled reservations.
+
+// ErrInvalidSampleRate is returned for a sample rate outside (0, 1].
+var ErrInvalidSampleRate = errors.New("sample rate must be above 0 and at most 1")
+
+// reservationSampleColumns are the columns of the reservation sample.
+var reservationSampleColumns = []ExportColumn{
+	{Name: "reservation"}, // pseudonym of the reservation ID
+	{Name: "guest"},       // pseudonym of the guest account, the same for all reservations of the guest
+	{Name: "property"},
+	{Name: "room"},
+	{Name: "status"},
+	{Name: "check_in"},
+	{Name: "check_out"},
+	{Name: "nights", Numeric: true},
+	{Name: "lead_time_days", Numeric: true}, // days from the booking date to the check-in
+	{Name: "booked_on"},
+	{Name: "guests", Numeric: true},
+	{Name: "amount", Numeric: true},
+	{Name: "currency"},
+	{Name: "tier"},
+	{Name: "discount_percent", Numeric: true},
+	{Name: "promo_code"},
+	{Name: "points_value", Numeric: true},
+}
+
+// SampleStore stores the files of the scheduled reservation sample.
+type SampleStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// ReservationSampler selects and pseudonymizes the reservations of the sample.
+// Both depend only on the secret: a reservation is either in every sample or in none,
+// and a guest has the same pseudonym in every sample, so samples can be joined over time.
+type ReservationSampler struct {
+	rate   float64
+	secret []byte
+}
+
+// NewReservationSampler creates a sampler that includes about rate (0 to 1) of the reservations.
+// Changing the secret changes the sample and all pseudonyms.
+func NewReservationSampler(rate float64, secret string) (*ReservationSampler, error) {
+	if !(rate > 0 && rate <= 1) {
+		return nil, ErrInvalidSampleRate
+	}
+	return &ReservationSampler{rate: rate, secret: []byte(secret)}, nil
+}
+
+// Includes reports whether the reservation is in the sample. The keyed hash of its ID is uniform,
+// so the sample is a simple random sample that does not favor any stay, room or price.
+func (s *ReservationSampler) Includes(id reservation.ReservationID) bool {
+	sum := s.mac("sample", string(id))
+	return float64(binary.BigEndian.Uint64(sum[:8]))/math.MaxUint64 < s.rate
+}
+
+// Pseudonym returns the pseudonym of a value of the kind, e.g. g_3f9a0c2b71de84a5 for a guest.
+// Without the secret it cannot be traced back to the value.
+func (s *ReservationSampler) Pseudonym(kind, value string) string {
+	sum := s.mac(kind, value)
+	return kind[:1] + "_" + hex.EncodeToString(sum[:8])
+}
+
+// mac returns the HMAC-SHA256 of the value, separated by its kind so equal values of different kinds differ.
+func (s *ReservationSampler) mac(kind, value string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(kind + "\x00" + value))
+	return h.Sum(nil)
+}
+
+// WriteSample writes the header and a row per sampled reservation, ordered by check-in.
+// It returns the number of rows written.
+func (s *ReservationSampler) WriteSample(export ExportWriter, reservations []reservation.Reservation, propertyOf func(*reservation.Reservation) reservation.PropertyID) (int, error) {
+	if err := export.WriteHeader(reservationSampleColumns); err != nil {
+		return 0, err
+	}
+	sampled := slices.DeleteFunc(slices.Clone(reservations), func(res reservation.Reservation) bool { return !s.Includes(res.ID) })
+	// Ordered by check-in and pseudonym, so the order gives away nothing about the original IDs.
+	slices.SortFunc(sampled, func(a, b reservation.Reservation) int {
+		if c := a.DateRange.CheckIn.Compare(b.DateRange.CheckIn); c != 0 {
+			return c
+		}
+		return bytes.Compare(s.mac("reservation", string(a.ID)), s.mac("reservation", string(b.ID)))
+	})
+
+	rows := 0
+	for i := range sampled {
+		res := &sampled[i]
+		guest := string(res.GuestID)
+		if guest == "" {
+			guest = res.GuestEmail
+		}
+		bookedOn := res.CreatedAt.UTC().Truncate(24 * time.Hour)
+		leadTime := max(0, int(res.DateRange.CheckIn.UTC().Truncate(24*time.Hour).Sub(bookedOn).Hours()/24))
+		discount, points := "", ""
+		if res.Perks.DiscountPercent > 0 {
+			discount = strconv.Itoa(res.Perks.DiscountPercent)
+		}
+		if res.Perks.Redemption.Amount > 0 {
+			points = res.Perks.Redemption.Decimal()
+		}
+		if err := export.WriteRow([]string{
+			s.Pseudonym("reservation", string(res.ID)),
+			s.Pseudonym("guest", guest),
+			string(propertyOf(res)),
+			string(res.RoomID),
+			string(res.Status),
+			res.DateRange.CheckIn.Format("2006-01-02"),
+			res.DateRange.CheckOut.Format("2006-01-02"),
+			strconv.Itoa(res.Nights()),
+			strconv.Itoa(leadTime),
+			bookedOn.Format("2006-01-02"),
+			strconv.Itoa(len(res.Guests)),
+			res.TotalAmount.Decimal(),
+			res.TotalAmount.Currency,
+			res.Perks.Tier,
+			discount,
+			res.Perks.Promotion.Code,
+			points,
+		}); err != nil {
+			return rows, err
+		}
+		rows++
+	}
+	return rows, nil
+}
+
+// HttpAdminReservationSample streams the anonymized reservation sample as CSV or Excel file
+// (format: csv or xlsx, default csv).
+func HttpAdminReservationSample(reservationService *reservation.Service, sampler *ReservationSampler, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		export, err := NewExportWriter(r.URL.Query().Get("format"), w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reservations, err := reservationService.ListReservations(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to list reservations", http.StatusInternalServerError)
+			return
+		}
+
+		filename := "reservation-sample-" + time.Now().UTC().Format("2006-01-02") + export.Extension()
+		w.Header().Set("Content-Type", export.ContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		rows, err := sampler.WriteSample(export, reservations, reservationService.PropertyOf)
+		if err == nil {
+			err = export.Close()
+		}
+		if err != nil {
+			logger.Error("reservation sample failed", "error", err, "rows", rows)
+			return
+		}
+
+		logger.Info("reservation sample exported",
+			"audit", true,
+			"format", export.Extension()[1:],
+			"rows", rows,
+		)
+	}
+}
+
+// ExportReservationSample returns the reservation_sample job: it stores the anonymized reservation
+// sample as CSV below the prefix of the store, named after the day, e.g. samples/reservations-2026-10-17.csv.
+// A second run on the same day replaces the file of the day. It returns the number of rows.
+func ExportReservationSample(reservationService *reservation.Service, sampler *ReservationSampler, store SampleStore, prefix string) func(ctx context.Context, now time.Time) (int, error) {
+	return func(ctx context.Context, now time.Time) (int, error) {
+		reservations, err := reservationService.ListReservations(ctx)
+		if err != nil {
+			return 0, err
+		}
+		var buf bytes.Buffer
+		export := NewCSVExportWriter(&buf)
+		rows, err := sampler.WriteSample(export, reservations, reservationService.PropertyOf)
+		if err == nil {
+			err = export.Close()
+		}
+		if err != nil {
+			return 0, err
+		}
+		key := path.Join(prefix, "reservations-"+now.UTC().Format("2006-01-02")+export.Extension())
+		if err := store.Put(ctx, key, buf.Bytes()); err != nil {
+			return 0, err
+		}
+		return rows, nil
+	}
+}
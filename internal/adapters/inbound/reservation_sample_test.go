@@ -0,0 +1,130 @@
+package inbound_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// mockSampleStore records the files put into it.
+type mockSampleStore struct {
+	files map[string][]byte
+}
+
+func (m *mockSampleStore) Put(_ context.Context, key string, data []byte) error {
+	m.files[key] = data
+	return nil
+}
+
+func createTestSampler(t *testing.T, rate float64) *inbound.ReservationSampler {
+	t.Helper()
+	sampler, err := inbound.NewReservationSampler(rate, "test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sampler
+}
+
+// ============================================================================
+// ReservationSampler Tests
+// ============================================================================
+
+func Test_NewReservationSampler_With_Invalid_Rate_Should_Fail(t *testing.T) {
+	for _, rate := range []float64{0, -0.5, 1.5} {
+		// Act
+		_, err := inbound.NewReservationSampler(rate, "test-secret")
+
+		// Assert
+		assert.That(t, fmt.Sprintf("rate %v must be rejected", rate), err, inbound.ErrInvalidSampleRate)
+	}
+}
+
+func Test_ReservationSampler_Includes_Should_Sample_About_The_Rate(t *testing.T) {
+	// Arrange
+	sampler := createTestSampler(t, 0.1)
+
+	// Act
+	included := 0
+	for i := range 10000 {
+		if sampler.Includes(reservation.ReservationID(fmt.Sprintf("res-%05d", i))) {
+			included++
+		}
+	}
+
+	// Assert
+	assert.That(t, "about a tenth must be included", included > 850 && included < 1150, true)
+}
+
+func Test_ReservationSampler_Pseudonym_Should_Be_Deterministic_And_Keyed(t *testing.T) {
+	// Arrange
+	sampler := createTestSampler(t, 1)
+	other, _ := inbound.NewReservationSampler(1, "other-secret")
+
+	// Act
+	first := sampler.Pseudonym("guest", "user-subject-456")
+	second := sampler.Pseudonym("guest", "user-subject-456")
+
+	// Assert
+	assert.That(t, "same value must get the same pseudonym", first, second)
+	assert.That(t, "pseudonym must be prefixed with the kind", strings.HasPrefix(first, "g_"), true)
+	assert.That(t, "pseudonym must not contain the value", strings.Contains(first, "user-subject-456"), false)
+	assert.That(t, "other secret must give another pseudonym", other.Pseudonym("guest", "user-subject-456") == first, false)
+}
+
+// ============================================================================
+// HttpAdminReservationSample Tests
+// ============================================================================
+
+func Test_HttpAdminReservationSample_Should_Omit_Contact_Data_And_Keep_Stay(t *testing.T) {
+	// Arrange
+	sampler := createTestSampler(t, 1)
+	handler := inbound.HttpAdminReservationSample(createDetailTestService(createExportTestRepository()), sampler, testExportLogger)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler(rec, httptest.NewRequest(http.MethodGet, "/admin/reservations/sample", nil))
+
+	// Assert
+	body := rec.Body.String()
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "must be an attachment", strings.HasPrefix(rec.Header().Get("Content-Disposition"), "attachment; filename=reservation-sample-"), true)
+	assert.That(t, "must have header and three rows", len(lines), 4)
+	assert.That(t, "header must name the columns", strings.HasPrefix(lines[0], "reservation,guest,property,room,status,check_in,check_out,nights,lead_time_days"), true)
+	assert.That(t, "rows must be ordered by check-in", strings.Contains(lines[1], ",2030-03-10,2030-03-12,2,"), true)
+	assert.That(t, "row must keep the price", strings.Contains(lines[1], ",198.00,USD,"), true)
+	assert.That(t, "reservation ID must be pseudonymized", strings.HasPrefix(lines[1], sampler.Pseudonym("reservation", "res-001")+","), true)
+	for _, pii := range []string{"res-001", "guest@example.com", "Test Guest", "1234567890"} {
+		assert.That(t, pii+" must not be exported", strings.Contains(body, pii), false)
+	}
+}
+
+// ============================================================================
+// ExportReservationSample Tests
+// ============================================================================
+
+func Test_ExportReservationSample_Should_Store_CSV_Of_The_Day(t *testing.T) {
+	// Arrange
+	store := &mockSampleStore{files: map[string][]byte{}}
+	job := inbound.ExportReservationSample(createDetailTestService(createExportTestRepository()), createTestSampler(t, 1), store, "samples")
+
+	// Act
+	rows, err := job(context.Background(), time.Date(2030, 1, 2, 3, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "all reservations must be sampled", rows, 3)
+	assert.That(t, "file must be named after the day", strings.Count(string(store.files["samples/reservations-2030-01-02.csv"]), "\n"), 4)
+}
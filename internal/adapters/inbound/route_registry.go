@@ -0,0 +1,108 @@
+package inbound
+
+import (
+	"cmp"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// RouteAuth is the authentication a route requires.
+type RouteAuth string
+
+// The authentication schemes of the routes.
+const (
+	RouteAuthNone         RouteAuth = "none"
+	RouteAuthSession      RouteAuth = "session"       // OIDC session cookie (web.WithAuth)
+	RouteAuthBearer       RouteAuth = "bearer"        // OIDC access token (WithTokenAuth)
+	RouteAuthAdminToken   RouteAuth = "admin_token"   // ADMIN_TOKEN or the admin permission (WithAdminAccess)
+	RouteAuthScimToken    RouteAuth = "scim_token"    // SCIM_TOKEN
+	RouteAuthMetricsToken RouteAuth = "metrics_token" // METRICS_TOKEN
+	RouteAuthSignedLink   RouteAuth = "signed_link"   // the token in the path is the credential
+)
+
+// Middleware wraps a handler, e.g. WithRequestID.
+type Middleware func(next http.HandlerFunc) http.HandlerFunc
+
+// RouteInfo describes a mounted route.
+type RouteInfo struct {
+	Method  string    `json:"method"` // empty if the route matches every method
+	Path    string    `json:"path"`
+	Auth    RouteAuth `json:"auth"`
+	Handler string    `json:"handler"`
+}
+
+// RouteRegistry mounts the routes on a mux and records them, so the routes that exist in a
+// configuration can be listed for documentation and security reviews (GET /internal/routes).
+// Routes are mounted at startup only, so the registry needs no lock.
+type RouteRegistry struct {
+	mux         *http.ServeMux
+	middlewares []Middleware
+	routes      []RouteInfo
+}
+
+// NewRouteRegistry creates a registry mounting the routes on the mux.
+func NewRouteRegistry(mux *http.ServeMux) *RouteRegistry {
+	return &RouteRegistry{mux: mux}
+}
+
+// Use wraps the handlers of the routes mounted from now on in the middlewares, outside of their own
+// middlewares, e.g. WithTracing.
+func (r *RouteRegistry) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// HandleFunc mounts the handler wrapped in the middlewares, the first being the outermost,
+// and records the route with the name of the handler, e.g. inbound.HttpViewIndex.
+// The auth must match the authentication middleware of the route.
+func (r *RouteRegistry) HandleFunc(pattern string, auth RouteAuth, handler http.HandlerFunc, middlewares ...Middleware) {
+	name := handlerName(handler)
+	handler = chain(slices.Concat(r.middlewares, middlewares)...)(handler)
+	r.mux.HandleFunc(pattern, handler)
+	r.Record(pattern, auth, name)
+}
+
+// chain returns a middleware wrapping the handler in the middlewares, the first being the outermost.
+func chain(middlewares ...Middleware) Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		for _, middleware := range slices.Backward(middlewares) {
+			next = middleware(next)
+		}
+		return next
+	}
+}
+
+// Record records a route mounted on the mux by others, e.g. the probes of web.NewServeMux.
+func (r *RouteRegistry) Record(pattern string, auth RouteAuth, handler string) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	r.routes = append(r.routes, RouteInfo{Method: method, Path: path, Auth: auth, Handler: handler})
+}
+
+// Routes returns the recorded routes sorted by path and method.
+func (r *RouteRegistry) Routes() []RouteInfo {
+	routes := slices.Clone(r.routes)
+	slices.SortFunc(routes, func(a, b RouteInfo) int {
+		return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+	return routes
+}
+
+// closureSuffix matches the suffix the compiler gives to closures, e.g. HttpViewIndex.func1.
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// handlerName returns the name of the function that created the handler without the directory
+// of its package, e.g. inbound.HttpViewIndex for the closure returned by HttpViewIndex.
+func handlerName(handler http.HandlerFunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	name := closureSuffix.ReplaceAllString(fn.Name(), "")
+	return name[strings.LastIndex(name, "/")+1:]
+}
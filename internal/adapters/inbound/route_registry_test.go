@@ -0,0 +1,135 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// RouteRegistry Tests
+// ============================================================================
+
+func Test_RouteRegistry_HandleFunc_Should_Record_Route_With_Handler_Name(t *testing.T) {
+	// Arrange
+	routes := inbound.NewRouteRegistry(http.NewServeMux())
+
+	// Act
+	routes.HandleFunc("GET /metrics", inbound.RouteAuthNone, inbound.HttpMetrics(nil))
+	routes.Record("/static/", inbound.RouteAuthNone, "http.FileServerFS")
+
+	// Assert
+	assert.That(t, "routes must be sorted by path", routes.Routes(), []inbound.RouteInfo{
+		{Method: "GET", Path: "/metrics", Auth: inbound.RouteAuthNone, Handler: "inbound.HttpMetrics"},
+		{Method: "", Path: "/static/", Auth: inbound.RouteAuthNone, Handler: "http.FileServerFS"},
+	})
+}
+
+func Test_RouteRegistry_HandleFunc_Should_Apply_First_Middleware_Outermost(t *testing.T) {
+	// Arrange
+	mux := http.NewServeMux()
+	routes := inbound.NewRouteRegistry(mux)
+	var calls []string
+	middleware := func(name string) inbound.Middleware {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next(w, r)
+			}
+		}
+	}
+	routes.HandleFunc("GET /x", inbound.RouteAuthNone, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}, middleware("outer"), middleware("inner"))
+
+	// Act
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/x", nil))
+
+	// Assert
+	assert.That(t, "middlewares must wrap in order", calls, []string{"outer", "inner", "handler"})
+}
+
+// ============================================================================
+// GET /internal/routes Tests
+// ============================================================================
+
+func createInternalRoutesTestMux(t *testing.T) http.Handler {
+	t.Helper()
+	return inbound.Route(inbound.RouterConfig{
+		AdminToken:         "secret",
+		Ctx:                context.Background(),
+		EFS:                getRouterTestFS(t),
+		Logger:             slog.Default(),
+		ReservationService: createTestReservationService(t),
+	})
+}
+
+func fetchInternalRoutes(t *testing.T, mux http.Handler) []inbound.RouteInfo {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/internal/routes", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	var routes []inbound.RouteInfo
+	assert.That(t, "body must be JSON", json.Unmarshal(rec.Body.Bytes(), &routes), nil)
+	return routes
+}
+
+func Test_Route_InternalRoutes_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	mux := createInternalRoutesTestMux(t)
+	req := httptest.NewRequest(http.MethodGet, "/internal/routes", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 401", rec.Code, http.StatusUnauthorized)
+}
+
+func Test_Route_InternalRoutes_Should_List_Mounted_Routes(t *testing.T) {
+	// Arrange
+	mux := createInternalRoutesTestMux(t)
+
+	// Act
+	routes := fetchInternalRoutes(t, mux)
+
+	// Assert
+	found := make(map[string]inbound.RouteInfo)
+	for _, route := range routes {
+		found[route.Method+" "+route.Path] = route
+	}
+	assert.That(t, "reservations must be listed", found["GET /ui/reservations"], inbound.RouteInfo{Method: "GET", Path: "/ui/reservations", Auth: inbound.RouteAuthSession, Handler: "inbound.HttpViewReservations"})
+	assert.That(t, "pprof must be listed", found["GET /debug/pprof/"].Auth, inbound.RouteAuthAdminToken)
+	assert.That(t, "probes must be listed", found["GET /liveness"].Auth, inbound.RouteAuthNone)
+	_, ok := found["GET /ui/household"]
+	assert.That(t, "unconfigured routes must not be listed", ok, false)
+}
+
+func Test_Route_InternalRoutes_Admin_Routes_Without_Token_Should_Return_401(t *testing.T) {
+	// Arrange
+	mux := createInternalRoutesTestMux(t)
+	routes := fetchInternalRoutes(t, mux)
+
+	for _, route := range routes {
+		if route.Auth != inbound.RouteAuthAdminToken {
+			continue
+		}
+		// Act
+		path := strings.NewReplacer("{", "", "}", "").Replace(route.Path)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(route.Method, path, nil))
+
+		// Assert
+		assert.That(t, route.Method+" "+route.Path+" must require the admin token", rec.Code, http.StatusUnauthorized)
+	}
+}
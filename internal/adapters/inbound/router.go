@@ -5,23 +5,109 @@ import (
 	"io/fs"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/logging"
 	"github.com/andygeiss/cloud-native-utils/mcp"
 	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/cloud-native-utils/web"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
-	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
 )
 
 // RouterConfig holds all dependencies for HTTP routing.
 type RouterConfig struct {
-	Ctx                context.Context
-	EFS                fs.FS
-	Logger             *slog.Logger
-	MCPServer          *mcp.Server // Optional: nil disables MCP endpoint
-	ReservationService *reservation.Service
-	Verifier           *oidc.IDTokenVerifier // Required if MCPServer is set
+	APIVersions          *APIVersionPolicy    // Optional: nil serves the JSON API without deprecation headers or per-version metrics
+	AdminToken           string               // Optional: empty disables the admin endpoints (/debug/pprof, /admin)
+	AppAssociations      AppAssociations      // Optional: nil disables the app association files of the deep links (/.well-known)
+	AuditTrail           AuditTrail           // Optional: nil disables the audit log of reservation and payment changes (/admin/audit)
+	Blobs                BlobDownloadLinks    // Optional: nil disables the download links of generated files (/blobs, /admin/blobs)
+	Calendar             CalendarRenderer     // Optional: nil disables the calendar file of the guests' stays (/ui/reservations/calendar.ics)
+	Captcha              CaptchaVerifier      // Optional: nil shows the booking lookup without a CAPTCHA
+	Communications       CommunicationHistory // Optional: nil disables the admin reservation view (/admin/reservations/{id})
+	ConfigReloader       ConfigReloader       // Optional: nil disables the config reload endpoint (/admin/config/reload)
+	ContentPages         ContentPageRenderer  // Optional: nil disables the content pages (/ui/pages)
+	Ctx                  context.Context
+	DeadLetters          DeadLetterQueue   // Optional: nil disables the dead-lettered events of failed event handlers (/admin/dead-letters)
+	Diagnostics          *Diagnostics      // Optional: nil disables the diagnostic bundle (/internal/diagnostics/bundle)
+	DocumentService      *document.Service // Optional: nil hides the document wallet of reservations
+	EFS                  fs.FS
+	EmailPreviews        EmailPreviewer            // Optional: nil disables the localized email previews (/admin/emails/{template}/preview)
+	EventCatalog         *EventCatalog             // Optional: nil disables the event catalog (/api/events/catalog, /ui/events/catalog)
+	Faults               FaultController           // Optional: nil disables the fault injection endpoints (/admin/faults); never set in production
+	FinancialService     *payment.FinancialService // Optional: nil hides the financial summary of reservations
+	HTTPClients          HTTPClientMetrics         // Optional: nil disables the outbound HTTP client metrics (/admin/http-clients)
+	HouseholdInvitations HouseholdInvitationSender // Required if HouseholdService is set
+	HouseholdService     *household.Service        // Optional: nil disables households
+	IncidentService      *incident.Service         // Optional: nil disables the status page data (/api/status) and its incidents (/admin/incidents)
+	InventoryService     *inventory.Service        // Optional: nil disables the inventory change feed for channel managers (/api/v1/inventory)
+	InvoiceService       *invoice.Service          // Optional: nil disables the invoices of completed stays (/ui/reservations/{id}/invoice)
+	LedgerService        *ledger.Service           // Optional: nil disables the ledger reports and payouts (/admin/ledger)
+	LedgerPayoutDelay    time.Duration             // Time the gateway takes to pay out a capture before it is late
+	Logger               *slog.Logger
+	LogLevels            LogLevelController // Optional: nil disables the log level admin endpoints
+	LoyaltyService       *loyalty.Service   // Optional: nil hides the loyalty point balance and disables redeeming points when booking
+	LookupLimiter        *RateLimiter       // Optional: nil leaves the booking lookup unlimited
+	ManageLinkSender     ManageLinkSender   // Required if ManageLinks is set
+	ManageLinks          ShareLinkSigner    // Optional: nil disables the public booking lookup (/ui/lookup)
+	MCPOperations        *MCPOperations     // Optional: nil disables MCP progress notifications and cancellation (/admin/mcp/operations)
+	MCPQuota             *MCPQuota          // Optional: nil leaves MCP tool calls unlimited
+	MCPResources         *MCPResources      // Optional: nil disables MCP resources
+	MCPServer            *mcp.Server        // Optional: nil disables MCP endpoint
+	Metrics              MetricsExporter    // Optional: nil disables the Prometheus metrics (/metrics)
+	MetricsToken         string             // Optional: empty allows scrapes of /metrics without a Bearer token
+	PaymentSandbox       PaymentSandbox     // Optional: nil disables the test card switch (/admin/payment-sandbox); never set in production
+	PaymentService       *payment.Service   // Required if Warehouse is set
+	PricingService       *pricing.Service   // Optional: nil charges the fixed room prices and disables the rate plan endpoints (/admin/rate-plans)
+	ProfileService       *profile.Service   // Optional: nil disables VIP perks and the guest profile admin endpoints (/admin/duplicates, /admin/merges, /admin/tiers)
+	PromoCheckLimiter    *RateLimiter       // Optional: nil leaves the promo code check unlimited
+	PromotionService     *promotion.Service // Optional: nil disables promo codes and their admin endpoints (/admin/promotions)
+	PropertyMap          PropertyMap        // Optional: nil hides the property location on reservation details
+	Properties           *property.Catalog  // Optional: nil hides the property selection of the booking form and disables the property admin endpoint (/admin/properties)
+	PushEngagements      PushEngagements    // Optional: nil disables the engagement reports of push notifications (/ui/push/messages)
+	PushTokens           PushTokens         // Optional: verifies the engagement reports; nil disables them
+	QRCodes              QRCodeRenderer     // Optional: nil omits the QR code on printed reservations
+	Rates                *reservation.Rates // Optional: nil disables the price calendar (/api/v1/room-types/{id}/prices), capacity planning (/admin/capacity) and the preference report (/admin/room-preferences)
+	ReferralService      *referral.Service  // Optional: nil disables referral codes and the referral dashboard (/ui/referrals)
+	ReportService        *report.Service    // Optional: nil disables the report subscriptions (/admin/report-subscriptions) and their unsubscribe page (/ui/reports/unsubscribe); requires Properties
+	Readiness            *Readiness         // Optional: nil keeps the unconditional readiness probe of web.NewServeMux (/readiness)
+	RequestLimiter       *RequestLimiter    // Optional: nil leaves the requests to /api/v1, /graphql and /mcp unlimited
+	ReservationService   *reservation.Service
+	ReservationSampler   *ReservationSampler    // Optional: nil disables the anonymized reservation sample (/admin/reservations/sample)
+	Roles                *RoleAuthorizer        // Optional: nil ignores the OIDC roles of tokens and sessions
+	Scheduler            *Scheduler             // Optional: nil disables the scheduled job endpoints (/admin/jobs)
+	ScimToken            string                 // Optional: empty disables the SCIM staff provisioning API (/scim/v2)
+	ServiceAccounts      ServiceAccountRegistry // Optional: nil treats client-credentials tokens like their issuer's principal
+	ShareInvitations     ShareInvitationSender  // Required if ShareLinks is set
+	StatusComponents     StatusComponentChecks  // Readiness checks behind the status page components; empty reports them operational unless an incident affects them
+	ShareLinks           ShareLinkSigner        // Optional: nil disables reservation sharing
+	StaffService         *staff.Service         // Optional: nil leaves staff principals unrestricted by roles
+	SurveyService        *survey.Service        // Optional: nil disables NPS surveys and the admin dashboard
+	TestClock            TestClock              // Optional: nil disables the test clock (/admin/clock); never set in production
+	Tracer               HTTPTracer             // Optional: nil disables the tracing of requests
+	UnmaskedOutput       bool                   // Optional: true shows emails, phone and card numbers in MCP tool results in full; local development only
+	Verifier             TokenVerifier          // Optional: nil disables the JSON API (/api/v1); required if MCPServer is set
+	WaitlistService      *waitlist.Service      // Optional: nil disables the waitlist of booked rooms (/ui/waitlist)
+	Warehouse            WarehouseRecorder      // Optional: nil disables the data warehouse backfill (/admin/warehouse/backfill)
+	Weather              WeatherForecaster      // Optional: nil hides the weather widget
+	WebhookService       *webhook.Service       // Optional: nil disables the webhook test console and API (/admin/webhooks) and the guests' automations (/ui/profile)
 }
 
 // Route creates a new mux with the liveness and readiness probe (/liveness, /readiness),
@@ -32,64 +118,614 @@ func Route(config RouterConfig) *http.ServeMux {
 	// Embed the assets into the mux.
 	mux, serverSessions := web.NewServeMux(config.Ctx, config.EFS)
 
+	// Every route is mounted via the registry, so GET /internal/routes lists what exists.
+	// The routes of web.NewServeMux are only recorded.
+	routes := NewRouteRegistry(mux)
+	routes.Record("/static/", RouteAuthNone, "http.FileServerFS")
+	routes.Record("GET /auth/callback", RouteAuthNone, "web.IdentityProvider.Callback")
+	routes.Record("GET /auth/login", RouteAuthNone, "web.IdentityProvider.Login")
+	routes.Record("GET /auth/logout/{session_id}", RouteAuthNone, "web.IdentityProvider.Logout")
+	routes.Record("GET /health", RouteAuthNone, "web.NewServeMux")
+	routes.Record("GET /liveness", RouteAuthNone, "web.NewServeMux")
+	if config.Readiness != nil {
+		routes.Record("GET /readiness", RouteAuthNone, "HttpReadiness")
+	} else {
+		routes.Record("GET /readiness", RouteAuthNone, "web.NewServeMux")
+	}
+
+	// The middlewares of the routes; the first middleware of a route is the outermost.
+	logged := func(next http.HandlerFunc) http.HandlerFunc { return logging.WithLogging(config.Logger, next) }
+	session := func(next http.HandlerFunc) http.HandlerFunc { return web.WithAuth(serverSessions, next) }
+	bearer := func(next http.HandlerFunc) http.HandlerFunc { return WithTokenAuth(config.Verifier, next) }
+	admin := func(next http.HandlerFunc) http.HandlerFunc { return WithAdminToken(config.AdminToken, next) }
+	// Public pages only read the session for the navigation, so they are not limited by roles.
+	navigation := session
+	// Callers get the permissions of their roles, or of the role of their realm: tokens on every Bearer route,
+	// signed-in users on every session route need the UI permission.
+	if config.Roles != nil {
+		bearer = func(next http.HandlerFunc) http.HandlerFunc {
+			return WithTokenAuth(config.Verifier, WithRoles(config.Roles, next))
+		}
+		if issuers, ok := config.Verifier.(PrincipalTypeResolver); ok {
+			session = func(next http.HandlerFunc) http.HandlerFunc {
+				return web.WithAuth(serverSessions, WithUIPermission(config.Roles, issuers, next))
+			}
+		}
+	}
+	// Administrators use the admin endpoints with their token or session if their role or staff account grants
+	// the admin permission; automation keeps the admin token. Signed-in administrators send the CSRF token.
+	adminByRole := false
+	if issuers, ok := config.Verifier.(PrincipalTypeResolver); ok && config.Roles != nil {
+		adminByRole = true
+		var directory []Middleware
+		if config.StaffService != nil {
+			directory = append(directory, func(next http.HandlerFunc) http.HandlerFunc {
+				return WithStaffDirectory(config.StaffService, config.Logger, next)
+			})
+		}
+		adminBearer := chain(append([]Middleware{bearer}, directory...)...)
+		adminSession := chain(append([]Middleware{
+			func(next http.HandlerFunc) http.HandlerFunc { return web.WithAuth(serverSessions, next) },
+			func(next http.HandlerFunc) http.HandlerFunc { return WithSessionPrincipal(issuers, next) },
+			func(next http.HandlerFunc) http.HandlerFunc { return WithRoles(config.Roles, next) },
+		}, append(directory, WithCSRF)...)...)
+		admin = func(next http.HandlerFunc) http.HandlerFunc {
+			return WithAdminAccess(config.AdminToken, adminBearer, adminSession, next)
+		}
+	}
+	// JSON API routes name the permission they need; principals not restricted by scopes pass.
+	permit := func(permission string) Middleware {
+		return func(next http.HandlerFunc) http.HandlerFunc { return WithPermission(permission, next) }
+	}
+	// API and MCP requests are limited per client after authentication, so one client cannot hammer the booking system.
+	limited := func(next http.HandlerFunc) http.HandlerFunc { return next }
+	if config.RequestLimiter != nil {
+		limited = func(next http.HandlerFunc) http.HandlerFunc { return WithRequestLimit(config.RequestLimiter, next) }
+	}
+
+	// Every route mounted below handles its requests in a server span if tracing is configured.
+	if config.Tracer != nil {
+		routes.Use(func(next http.HandlerFunc) http.HandlerFunc { return WithTracing(config.Tracer, next) })
+	}
+
+	// Hash the static assets once at startup.
+	// The hashes are used to fingerprint asset URLs in the templates (cache-busting).
+	fingerprints, err := NewAssetFingerprints(config.EFS)
+	if err != nil {
+		panic(err)
+	}
+	templateFS, err := fingerprints.TemplateFS(config.EFS)
+	if err != nil {
+		panic(err)
+	}
+
 	// Create a new templating engine.
-	// We use the fs.FS to load the templates from the file system.
+	// We use the fingerprinted copy of the templates, so that every asset URL carries its content hash.
 	// We use the templating.Engine from cloud-native-utils and reuse it for all views.
-	e := templating.NewEngine(config.EFS)
+	e := templating.NewEngine(templateFS)
 
-	// Parse the templates under the assets/templates directory.
+	// Parse the templates under the assets/templates directory once at startup.
 	// Every template must have a .tmpl extension.
 	e.Parse("assets/templates/*.tmpl")
 
-	// The static assets are served from the embed.FS under the /static path directly.
-	// This is defined in the web.NewServeMux function from cloud-native-utils.
+	// Check that every view model has the fields its template reads, so a renamed field
+	// fails the startup instead of the page of a guest (see HttpView for the error boundary).
+	if err := ValidateViews(templateFS, "assets/templates/*.tmpl"); err != nil {
+		panic(err)
+	}
+
+	// The static assets are served from the embed.FS under the /static path.
+	// GET requests take precedence over the generic /static/ handler of web.NewServeMux,
+	// so fingerprinted URLs can be served with far-future cache headers.
+	routes.HandleFunc("GET /static/", RouteAuthNone, HttpStaticAssets(config.EFS, fingerprints))
+
+	// Dynamic responses are wrapped with WithCompression (gzip/deflate negotiated via Accept-Encoding).
+	// The service worker is excluded because some browsers refuse compressed service worker scripts.
 
 	// Add the index endpoint for the UI.
 	// The HttpViewIndex is handling unauthenticated and authenticated requests.
 	// The unauthenticated requests are redirected to the login page /ui/login.
 	// The authenticated requests are rendered with the index template.
-	mux.HandleFunc("GET /ui/", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewIndex(e))))
+	// The sign-in redirects here, so reservations still owned by the guest's verified email are claimed here.
+	claimLegacy := func(next http.HandlerFunc) http.HandlerFunc {
+		return WithLegacyReservationClaim(config.ReservationService, next)
+	}
+	routes.HandleFunc("GET /ui/", RouteAuthSession, HttpViewIndex(e), logged, WithCompression, session, claimLegacy)
 
 	// Add the login endpoint for the UI.
 	// This endpoint is used to forward the user to the login page of the OIDC provider.
-	mux.HandleFunc("GET /ui/login", logging.WithLogging(config.Logger, HttpViewLogin(e)))
+	routes.HandleFunc("GET /ui/login", RouteAuthNone, HttpViewLogin(e), logged, WithCompression)
 
 	// Add the error endpoint for displaying user-friendly error pages.
 	// This endpoint accepts query parameters: title, message, and details.
-	mux.HandleFunc("GET /ui/error", logging.WithLogging(config.Logger, HttpViewError(e)))
+	routes.HandleFunc("GET /ui/error", RouteAuthNone, HttpViewError(e), logged, WithCompression)
 
 	// Add the manifest endpoint for the PWA.
 	// This endpoint serves the manifest.json file for Progressive Web App support.
-	mux.HandleFunc("GET /manifest.json", logging.WithLogging(config.Logger, HttpViewManifest(e)))
+	routes.HandleFunc("GET /manifest.json", RouteAuthNone, HttpViewManifest(e), logged, WithCompression)
 
 	// Add the service worker endpoint for the PWA.
 	// This endpoint serves the sw.js file for offline caching and installability.
-	mux.HandleFunc("GET /sw.js", logging.WithLogging(config.Logger, HttpViewServiceWorker(e)))
+	routes.HandleFunc("GET /sw.js", RouteAuthNone, HttpViewServiceWorker(e), logged)
+
+	// Add the association files of the companion apps if configured.
+	// iOS and Android fetch them to open the links of the emails in the app instead of the browser.
+	if config.AppAssociations != nil {
+		routes.HandleFunc("GET /.well-known/apple-app-site-association", RouteAuthNone, HttpAppleAppSiteAssociation(config.AppAssociations), logged)
+		routes.HandleFunc("GET /.well-known/assetlinks.json", RouteAuthNone, HttpAssetLinks(config.AppAssociations), logged)
+	}
+
+	// Add the offline shell cached by the service worker.
+	// It is shown for pages that are neither reachable nor cached, e.g. a reservation detail while offline.
+	routes.HandleFunc("GET /ui/offline", RouteAuthNone, HttpViewOffline(e), logged, WithCompression)
+
+	// Add the content page endpoint (FAQ, policies, directions) if configured.
+	// The pages are public; web.WithAuth only provides the session for the navigation.
+	if config.ContentPages != nil {
+		routes.HandleFunc("GET /ui/pages/{slug}", RouteAuthNone, HttpViewContentPage(e, config.ContentPages), logged, WithCompression, navigation)
+	}
+
+	// Add the event catalog endpoints if configured.
+	// The catalog documents the published topics for consumers; it is public like the content pages.
+	if config.EventCatalog != nil {
+		routes.HandleFunc("GET /api/events/catalog", RouteAuthNone, HttpEventCatalog(config.EventCatalog), logged, WithCompression)
+		routes.HandleFunc("GET /ui/events/catalog", RouteAuthNone, HttpViewEventCatalog(e, config.EventCatalog), logged, WithCompression)
+	}
+
+	// Add the status page data endpoint if configured.
+	// It is public, so it only shows the status of the components and the active incidents, nothing internal.
+	if config.IncidentService != nil {
+		routes.HandleFunc("GET /api/status", RouteAuthNone, HttpStatusPage(config.Readiness, config.StatusComponents, config.IncidentService, config.Logger), logged, WithCompression)
+	}
+
+	// The booking path is wrapped with WithRequestID, which tags CPU profiles with the request ID.
+
+	// Members of a household may see and manage each other's reservations.
+	// The household of the signed-in guest is loaded for every reservation access check.
+	withHousehold := func(next http.HandlerFunc) http.HandlerFunc {
+		if config.HouseholdService == nil {
+			return next
+		}
+		return WithHousehold(config.HouseholdService, next)
+	}
 
 	// Add the reservations list endpoint.
-	mux.HandleFunc("GET /ui/reservations", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservations(e, config.ReservationService))))
+	routes.HandleFunc("GET /ui/reservations", RouteAuthSession, HttpViewReservations(e, config.ReservationService, config.LoyaltyService), logged, WithRequestID, WithCompression, session, withHousehold)
+
+	// Add the calendar file of the guest's stays for calendar apps.
+	if config.Calendar != nil {
+		routes.HandleFunc("GET /ui/reservations/calendar.ics", RouteAuthSession, HttpReservationsCalendar(config.ReservationService, config.Calendar), logged, WithRequestID, WithCompression, session)
+	}
 
 	// Add the new reservation form endpoint.
-	mux.HandleFunc("GET /ui/reservations/new", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservationForm(e))))
+	routes.HandleFunc("GET /ui/reservations/new", RouteAuthSession, HttpViewReservationForm(e, config.Properties, config.LoyaltyService, config.PromotionService), logged, WithRequestID, WithCompression, session)
+
+	// Add the room calendar endpoint used by the reservation form to reject booked dates.
+	routes.HandleFunc("GET /ui/rooms/{id}/calendar", RouteAuthSession, HttpRoomCalendar(config.ReservationService), logged, WithRequestID, WithCompression, session)
 
 	// Add the create reservation endpoint.
-	mux.HandleFunc("POST /ui/reservations", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpCreateReservation(e, config.ReservationService))))
+	routes.HandleFunc("POST /ui/reservations", RouteAuthSession, HttpCreateReservation(e, ReservationFormConfig{
+		LoyaltyService:     config.LoyaltyService,
+		PricingService:     config.PricingService,
+		ProfileService:     config.ProfileService,
+		PromotionService:   config.PromotionService,
+		Properties:         config.Properties,
+		ReferralService:    config.ReferralService,
+		ReservationService: config.ReservationService,
+		WaitlistService:    config.WaitlistService,
+	}), logged, WithRequestID, WithCompression, session)
 
 	// Add the reservation detail endpoint.
-	mux.HandleFunc("GET /ui/reservations/{id}", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpViewReservationDetail(e, config.ReservationService))))
+	routes.HandleFunc("GET /ui/reservations/{id}", RouteAuthSession, HttpViewReservationDetail(e, config.ReservationService, config.PropertyMap), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+
+	// Add the weather widget endpoint of the reservation detail page.
+	// Without a configured forecaster it answers 204, so the widget stays hidden.
+	routes.HandleFunc("GET /ui/reservations/{id}/weather", RouteAuthSession, HttpViewReservationWeather(e, config.ReservationService, config.Weather), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+
+	// Add the financial summary widget endpoint of the reservation detail page.
+	// Without a configured financial service it answers 204, so the widget stays hidden.
+	// The widget links the invoice of invoiced stays.
+	routes.HandleFunc("GET /ui/reservations/{id}/financials", RouteAuthSession, HttpViewReservationFinancials(e, config.ReservationService, config.FinancialService, config.InvoiceService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+	if config.InvoiceService != nil {
+		routes.HandleFunc("GET /ui/reservations/{id}/invoice", RouteAuthSession, HttpViewReservationInvoice(e, config.ReservationService, config.InvoiceService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+	}
+
+	// Add the document wallet widget endpoint of the reservation detail page and its file endpoints.
+	// Without a configured document service the widget answers 204, so it stays hidden.
+	// Co-travelers with view access download documents; upload and deletion need manage access.
+	routes.HandleFunc("GET /ui/reservations/{id}/documents", RouteAuthSession, HttpViewReservationDocuments(e, config.ReservationService, config.DocumentService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+	if config.DocumentService != nil {
+		routes.HandleFunc("POST /ui/reservations/{id}/documents", RouteAuthSession, HttpUploadReservationDocument(config.ReservationService, config.DocumentService), logged, WithRequestID, session, WithValidReservationID, withHousehold)
+		routes.HandleFunc("GET /ui/reservations/{id}/documents/{document}", RouteAuthSession, HttpDownloadReservationDocument(config.ReservationService, config.DocumentService), logged, WithRequestID, session, WithValidReservationID, withHousehold)
+		routes.HandleFunc("POST /ui/reservations/{id}/documents/{document}/delete", RouteAuthSession, HttpDeleteReservationDocument(config.ReservationService, config.DocumentService), logged, WithRequestID, session, WithValidReservationID, withHousehold)
+	}
+
+	// Add the printable reservation summary endpoint.
+	routes.HandleFunc("GET /ui/reservations/{id}/print", RouteAuthSession, HttpViewReservationPrint(e, config.ReservationService, config.QRCodes), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
 
 	// Add the cancel reservation endpoint.
-	mux.HandleFunc("POST /ui/reservations/{id}/cancel", logging.WithLogging(config.Logger, web.WithAuth(serverSessions, HttpCancelReservation(config.ReservationService))))
+	routes.HandleFunc("POST /ui/reservations/{id}/cancel", RouteAuthSession, HttpCancelReservation(config.ReservationService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+	routes.HandleFunc("POST /ui/reservations/{id}/extension/accept", RouteAuthSession, HttpAcceptExtension(config.ReservationService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+	routes.HandleFunc("POST /ui/reservations/{id}/extension/decline", RouteAuthSession, HttpDeclineExtension(config.ReservationService), logged, WithRequestID, WithCompression, session, WithValidReservationID, withHousehold)
+
+	// Add the reservation sharing endpoints if configured.
+	// Owners invite co-travelers by email; the signed invitation link binds the grant to the co-traveler's account.
+	if config.ShareLinks != nil {
+		routes.HandleFunc("POST /ui/reservations/{id}/shares", RouteAuthSession, HttpShareReservation(config.ReservationService, config.ShareLinks, config.ShareInvitations), logged, WithRequestID, WithCompression, session, WithValidReservationID)
+		routes.HandleFunc("POST /ui/reservations/{id}/shares/revoke", RouteAuthSession, HttpRevokeShare(config.ReservationService), logged, WithRequestID, WithCompression, session, WithValidReservationID)
+		routes.HandleFunc("GET /ui/shares/accept", RouteAuthSession, HttpAcceptShare(config.ReservationService, config.ShareLinks), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the public booking lookup if configured.
+	// Guests without an account find their booking by confirmation code and email or last name, and get
+	// a signed management link emailed to the address on the booking. Lookups are limited per IP address.
+	if config.ManageLinks != nil {
+		lookupLimited := func(next http.HandlerFunc) http.HandlerFunc { return next }
+		if config.LookupLimiter != nil {
+			lookupLimited = func(next http.HandlerFunc) http.HandlerFunc { return WithRateLimit(config.LookupLimiter, next) }
+		}
+		routes.HandleFunc("GET /ui/lookup", RouteAuthNone, HttpViewBookingLookup(e, config.Captcha), logged, WithCompression)
+		routes.HandleFunc("POST /ui/lookup", RouteAuthNone, HttpLookupBooking(e, config.ReservationService, config.Captcha), logged, WithRequestID, WithCompression, lookupLimited)
+		routes.HandleFunc("POST /ui/lookup/link", RouteAuthNone, HttpRequestManageLink(e, config.ReservationService, config.ManageLinks, config.ManageLinkSender, config.Captcha), logged, WithRequestID, WithCompression, lookupLimited)
+		routes.HandleFunc("GET /ui/lookup/manage", RouteAuthNone, HttpViewBookingManage(e, config.ReservationService, config.ManageLinks), logged, WithRequestID, WithCompression)
+		routes.HandleFunc("POST /ui/lookup/manage/cancel", RouteAuthNone, HttpCancelBookingByLink(config.ReservationService, config.ManageLinks), logged, WithRequestID, WithCompression)
+	}
+
+	// Add the unsubscribe page of the report emails if configured.
+	// The link carries a random token of the recipient, so the page needs no sign-in.
+	if config.ReportService != nil && config.Properties != nil {
+		routes.HandleFunc("GET /ui/reports/unsubscribe/{token}", RouteAuthNone, HttpViewReportUnsubscribe(e, config.ReportService), logged, WithRequestID, WithCompression)
+		routes.HandleFunc("POST /ui/reports/unsubscribe/{token}", RouteAuthNone, HttpReportUnsubscribe(e, config.ReportService, config.Logger), logged, WithRequestID, WithCompression)
+	}
+
+	// Add the household endpoints if configured.
+	// Admins invite members by email; the invited guest accepts on the household page after signing in.
+	if config.HouseholdService != nil {
+		routes.HandleFunc("GET /ui/household", RouteAuthSession, HttpViewHousehold(e, config.HouseholdService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household", RouteAuthSession, HttpCreateHousehold(config.HouseholdService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household/invitations", RouteAuthSession, HttpInviteHouseholdMember(config.HouseholdService, config.HouseholdInvitations), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household/invitations/accept", RouteAuthSession, HttpAcceptHouseholdInvitation(config.HouseholdService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household/invitations/cancel", RouteAuthSession, HttpCancelHouseholdInvitation(config.HouseholdService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household/members/role", RouteAuthSession, HttpSetHouseholdMemberRole(config.HouseholdService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/household/members/remove", RouteAuthSession, HttpRemoveHouseholdMember(config.HouseholdService), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the NPS survey endpoints if configured.
+	// Guests are invited after checkout and answer the survey of their stay once.
+	if config.SurveyService != nil {
+		routes.HandleFunc("GET /ui/surveys/{id}", RouteAuthSession, HttpViewSurvey(e, config.SurveyService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/surveys/{id}", RouteAuthSession, HttpSubmitSurvey(config.SurveyService), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the referral dashboard if configured.
+	// Guests share their code and follow the pending and earned rewards.
+	if config.ReferralService != nil {
+		routes.HandleFunc("GET /ui/referrals", RouteAuthSession, HttpViewReferrals(e, config.ReferralService), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the promo code check if configured.
+	// The reservation form shows the discount of the entered code before the guest books.
+	// Checks are limited per IP address like the booking lookup, so codes cannot be enumerated.
+	if config.PromotionService != nil {
+		promoCheckLimited := func(next http.HandlerFunc) http.HandlerFunc { return next }
+		if config.PromoCheckLimiter != nil {
+			promoCheckLimited = func(next http.HandlerFunc) http.HandlerFunc { return WithRateLimit(config.PromoCheckLimiter, next) }
+		}
+		routes.HandleFunc("GET /ui/promotions/check", RouteAuthSession, HttpCheckPromoCode(config.PromotionService, config.PricingService, config.ProfileService), logged, WithRequestID, session, promoCheckLimited)
+	}
+
+	// Add the waitlist endpoints if configured.
+	// Guests join from the reservation form when the room is booked and book the room once it is held for them.
+	if config.WaitlistService != nil {
+		routes.HandleFunc("GET /ui/waitlist", RouteAuthSession, HttpViewWaitlist(e, config.WaitlistService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/waitlist", RouteAuthSession, HttpJoinWaitlist(config.WaitlistService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/waitlist/{id}/withdraw", RouteAuthSession, HttpWithdrawWaitlist(config.WaitlistService), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the push subscription endpoints of the installed portal and the apps if push is configured.
+	// The browser subscribes with the VAPID key (an app with its FCM token) and registers the subscription for the signed-in guest.
+	if config.ProfileService != nil && config.ProfileService.PushEnabled() {
+		routes.HandleFunc("GET /ui/push/key", RouteAuthSession, HttpPushKey(config.ProfileService), logged, WithRequestID, session)
+		routes.HandleFunc("GET /ui/push/subscriptions", RouteAuthSession, HttpPushSubscriptions(config.ProfileService), logged, WithRequestID, session)
+		routes.HandleFunc("POST /ui/push/subscriptions", RouteAuthSession, HttpSubscribePush(config.ProfileService, config.Logger), logged, WithRequestID, session)
+		routes.HandleFunc("DELETE /ui/push/subscriptions", RouteAuthSession, HttpUnsubscribePush(config.ProfileService, config.Logger), logged, WithRequestID, session)
+		routes.HandleFunc("DELETE /ui/push/subscriptions/{id}", RouteAuthSession, HttpDeletePushSubscription(config.ProfileService, config.Logger), logged, WithRequestID, session)
+		// Devices report the engagement without a session: the guest may have signed out since subscribing.
+		if config.PushEngagements != nil && config.PushTokens != nil {
+			routes.HandleFunc("POST /ui/push/messages/{id}/{engagement}", RouteAuthNone, HttpPushEngagement(config.PushEngagements, config.PushTokens), logged, WithRequestID)
+		}
+	}
+
+	// Add the profile page with the guests' own automations if guest webhooks are enabled.
+	// Endpoints only receive events once a ping succeeded, and only those of the guest's reservations.
+	if config.WebhookService != nil && config.WebhookService.GuestEndpointsEnabled() {
+		routes.HandleFunc("GET /ui/profile", RouteAuthSession, HttpViewProfile(e, config.WebhookService), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/profile/webhooks", RouteAuthSession, HttpRegisterGuestWebhook(config.WebhookService, config.Logger), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/profile/webhooks/{id}/ping", RouteAuthSession, HttpPingGuestWebhook(config.WebhookService, config.Logger), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/profile/webhooks/{id}/disable", RouteAuthSession, HttpDisableGuestWebhook(config.WebhookService, config.Logger), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/profile/webhooks/{id}/enable", RouteAuthSession, HttpEnableGuestWebhook(config.WebhookService, config.Logger), logged, WithRequestID, WithCompression, session)
+		routes.HandleFunc("POST /ui/profile/webhooks/{id}/delete", RouteAuthSession, HttpDeleteGuestWebhook(config.WebhookService, config.Logger), logged, WithRequestID, WithCompression, session)
+	}
+
+	// Add the JSON API for reservations if a token verifier is configured.
+	// Clients authenticate with the same OIDC Bearer tokens as MCP; access rules match the UI.
+	if config.Verifier != nil {
+		// Every v1 endpoint announces its version and, once planned, its deprecation and sunset.
+		// Clients naming the version in the API-Version header instead of the path reach the same endpoints.
+		versions := config.APIVersions
+		if versions == nil {
+			versions = NewAPIVersionPolicy(APIVersions)
+		}
+		v1 := func(next http.HandlerFunc) http.HandlerFunc {
+			return WithAPIVersion(versions, "v1", next)
+		}
+		routes.HandleFunc("/api/{path...}", RouteAuthNone, HttpAPIVersionNegotiation(versions, mux))
+		routes.HandleFunc("GET /api/v1/reservations", RouteAuthBearer, HttpAPIListReservations(config.ReservationService), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(reservation.ScopeRead))
+		routes.HandleFunc("POST /api/v1/reservations", RouteAuthBearer, HttpAPICreateReservation(config.ReservationService, config.PricingService, config.ProfileService), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(reservation.ScopeWrite))
+		routes.HandleFunc("GET /api/v1/reservations/{id}", RouteAuthBearer, HttpAPIGetReservation(config.ReservationService), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(reservation.ScopeRead), withHousehold)
+		routes.HandleFunc("DELETE /api/v1/reservations/{id}", RouteAuthBearer, HttpAPICancelReservation(config.ReservationService), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(reservation.ScopeWrite), withHousehold)
+		if config.FinancialService != nil {
+			routes.HandleFunc("GET /api/v1/reservations/{id}/financials", RouteAuthBearer, HttpAPIGetReservationFinancials(config.ReservationService, config.FinancialService), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(reservation.ScopeRead), withHousehold)
+		}
+		// Guests and channel partners see the nightly rates of a month; the ETag changes with every rate change.
+		if config.Rates != nil {
+			etag := func(next http.HandlerFunc) http.HandlerFunc {
+				return WithWeakETag(RoomPricesVersion(config.Rates), next)
+			}
+			routes.HandleFunc("GET /api/v1/room-types/{id}/prices", RouteAuthBearer, HttpAPIGetRoomPrices(config.Rates), logged, WithRequestID, v1, WithCompression, bearer, limited, permit(pricing.ScopeRead), etag)
+		}
+		// Channel managers follow the availability changes per room and night and resync a room after a gap.
+		// Availability tells nobody who booked, so every authenticated client may read it, like the room calendar.
+		if config.InventoryService != nil {
+			routes.HandleFunc("GET /api/v1/inventory/changes", RouteAuthBearer, HttpAPIInventoryChanges(config.InventoryService), logged, WithRequestID, v1, WithCompression, bearer, limited)
+			routes.HandleFunc("GET /api/v1/inventory/rooms/{id}", RouteAuthBearer, HttpAPIInventorySnapshot(config.InventoryService), logged, WithRequestID, v1, WithCompression, bearer, limited)
+		}
+		// Service accounts and provisioned staff get their scopes first on routes authorized by scope.
+		var scoped []Middleware
+		if config.ServiceAccounts != nil {
+			scoped = append(scoped, func(next http.HandlerFunc) http.HandlerFunc {
+				return WithServiceAccount(config.ServiceAccounts, config.Logger, next)
+			})
+		}
+		if config.StaffService != nil {
+			scoped = append(scoped, func(next http.HandlerFunc) http.HandlerFunc {
+				return WithStaffDirectory(config.StaffService, config.Logger, next)
+			})
+		}
+		// Reporting clients read reservations, payments and rooms in one GraphQL query; GET without a query returns the schema.
+		graphQL := HttpGraphQL(NewBookingGraphQLSchema(config.ReservationService, config.PaymentService, config.Rates))
+		graphQLChain := append([]Middleware{logged, WithRequestID, WithCompression, bearer, limited, withHousehold}, scoped...)
+		routes.HandleFunc("GET /graphql", RouteAuthBearer, graphQL, graphQLChain...)
+		routes.HandleFunc("POST /graphql", RouteAuthBearer, graphQL, graphQLChain...)
+		// Lobby kiosks download the day's arrivals and sync the check-ins they queued while offline.
+		if config.ReservationService.SyncsKiosks() {
+			kioskChain := append([]Middleware{logged, WithRequestID, v1, WithCompression, bearer, limited}, scoped...)
+			routes.HandleFunc("GET /api/v1/kiosk/arrivals", RouteAuthBearer, HttpAPIKioskArrivals(config.ReservationService), kioskChain...)
+			routes.HandleFunc("POST /api/v1/kiosk/sync", RouteAuthBearer, HttpAPIKioskSync(config.ReservationService), kioskChain...)
+		}
+	}
 
 	// Add MCP endpoint if configured.
 	if config.MCPServer != nil {
-		mcpHandler := web.NewMCPHandler(config.MCPServer)
+		// Long-running tool calls report their progress and can be cancelled by the client.
+		server := config.MCPServer
+		if config.MCPOperations != nil {
+			server = WithToolOperations(server, config.MCPOperations)
+		}
+		// Personal data in results and errors is masked unless full output is configured for local development.
+		if !config.UnmaskedOutput {
+			server = WithMaskedToolResults(server)
+		}
+		// Failed tool calls are answered with an error whose data carries a machine-readable code.
+		mcpHandler := web.NewMCPHandler(WithToolErrorCodes(server))
+		chain := []Middleware{logged, WithRequestID, WithCompression}
+		auth := RouteAuthNone
 		if config.Verifier != nil {
-			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, web.WithBearerAuth(config.Verifier, mcpHandler.Handler())))
+			chain = append(chain, bearer)
+			auth = RouteAuthBearer
+		}
+		chain = append(chain, limited)
+		// Outermost after authentication, because it streams progress notifications while the inner middlewares buffer.
+		if config.MCPOperations != nil {
+			chain = append(chain, func(next http.HandlerFunc) http.HandlerFunc { return WithMCPOperations(config.MCPOperations, next) })
+		}
+		// Tools format amounts in the locale of the client (Accept-Language).
+		chain = append(chain, WithLocale)
+		// Tool calls are counted per client after authentication; results tell agents their remaining quota.
+		if config.MCPQuota != nil {
+			chain = append(chain, func(next http.HandlerFunc) http.HandlerFunc { return WithMCPQuota(config.MCPQuota, next) })
+		}
+		// Client-credentials tokens of registered service accounts get scoped permissions.
+		if config.ServiceAccounts != nil {
+			chain = append(chain, func(next http.HandlerFunc) http.HandlerFunc {
+				return WithServiceAccount(config.ServiceAccounts, config.Logger, next)
+			})
+		}
+		// Provisioned staff get the scopes of their roles; disabled staff are rejected.
+		if config.StaffService != nil {
+			chain = append(chain, func(next http.HandlerFunc) http.HandlerFunc {
+				return WithStaffDirectory(config.StaffService, config.Logger, next)
+			})
+		}
+		// The MCP server only supports tools, so resources (e.g. the FAQ) are answered by a middleware.
+		if config.MCPResources != nil {
+			chain = append(chain, func(next http.HandlerFunc) http.HandlerFunc { return WithMCPResources(config.MCPResources, next) })
+		}
+		chain = append(chain, WithMCPErrors, WithMCPCalls)
+		routes.HandleFunc("POST /mcp", auth, mcpHandler.Handler(), chain...)
+	}
+
+	// Expose the metrics of the domain services for Prometheus.
+	// Scrapes are not logged, they would drown the request log.
+	if config.Metrics != nil {
+		if config.MetricsToken != "" {
+			routes.HandleFunc("GET /metrics", RouteAuthMetricsToken, HttpMetrics(config.Metrics), func(next http.HandlerFunc) http.HandlerFunc { return WithAdminToken(config.MetricsToken, next) })
 		} else {
-			mux.Handle("POST /mcp", logging.WithLogging(config.Logger, mcpHandler.Handler()))
+			routes.HandleFunc("GET /metrics", RouteAuthNone, HttpMetrics(config.Metrics))
+		}
+	}
+
+	// Add the SCIM staff provisioning API if configured.
+	// HR's identity provider creates, updates and disables staff accounts with its own token.
+	if config.ScimToken != "" && config.StaffService != nil {
+		scim := func(next http.HandlerFunc) http.HandlerFunc { return WithAdminToken(config.ScimToken, next) }
+		routes.HandleFunc("GET /scim/v2/Users", RouteAuthScimToken, HttpScimListUsers(config.StaffService), logged, scim)
+		routes.HandleFunc("POST /scim/v2/Users", RouteAuthScimToken, HttpScimCreateUser(config.StaffService, config.Logger), logged, scim)
+		routes.HandleFunc("GET /scim/v2/Users/{id}", RouteAuthScimToken, HttpScimGetUser(config.StaffService), logged, scim)
+		routes.HandleFunc("PUT /scim/v2/Users/{id}", RouteAuthScimToken, HttpScimReplaceUser(config.StaffService, config.Logger), logged, scim)
+		routes.HandleFunc("PATCH /scim/v2/Users/{id}", RouteAuthScimToken, HttpScimPatchUser(config.StaffService, config.Logger), logged, scim)
+		routes.HandleFunc("DELETE /scim/v2/Users/{id}", RouteAuthScimToken, HttpScimDeleteUser(config.StaffService, config.Logger), logged, scim)
+	}
+
+	// Serve generated files via signed, expiring download links.
+	if config.Blobs != nil {
+		routes.HandleFunc("GET /blobs/{token}", RouteAuthSignedLink, HttpDownloadBlob(config.Blobs, config.Logger), logged, WithRequestID)
+	}
+
+	// Add the profiling, diagnostics, config reload, log level, pricing simulation and export endpoints
+	// if an admin token is configured or roles may grant the admin permission.
+	if config.AdminToken != "" || adminByRole {
+		RoutePprof(routes, admin)
+		routes.HandleFunc("GET /internal/routes", RouteAuthAdminToken, HttpInternalRoutes(routes), logged, admin)
+		if config.Diagnostics != nil {
+			routes.HandleFunc("GET /internal/diagnostics/bundle", RouteAuthAdminToken, HttpInternalDiagnosticsBundle(config.Diagnostics, config.Logger), logged, admin)
+		}
+		routes.HandleFunc("POST /admin/simulations", RouteAuthAdminToken, HttpAdminSimulatePricing(config.ReservationService), logged, admin)
+		routes.HandleFunc("GET /admin/reservations/export", RouteAuthAdminToken, HttpAdminExportReservations(config.ReservationService, config.Logger), logged, WithCompression, admin)
+		if config.ReservationSampler != nil {
+			routes.HandleFunc("GET /admin/reservations/sample", RouteAuthAdminToken, HttpAdminReservationSample(config.ReservationService, config.ReservationSampler, config.Logger), logged, WithCompression, admin)
+		}
+		if config.ConfigReloader != nil {
+			routes.HandleFunc("POST /admin/config/reload", RouteAuthAdminToken, HttpAdminReloadConfig(config.ConfigReloader, config.Logger), logged, admin)
+		}
+		if config.Faults != nil {
+			routes.HandleFunc("GET /admin/faults", RouteAuthAdminToken, HttpAdminGetFaults(config.Faults), logged, admin)
+			routes.HandleFunc("PUT /admin/faults/{target}", RouteAuthAdminToken, HttpAdminSetFault(config.Faults), logged, admin)
+			routes.HandleFunc("DELETE /admin/faults", RouteAuthAdminToken, HttpAdminResetFaults(config.Faults), logged, admin)
+		}
+		if config.PaymentSandbox != nil {
+			routes.HandleFunc("GET /admin/payment-sandbox", RouteAuthAdminToken, HttpAdminGetPaymentSandbox(config.PaymentSandbox), logged, admin)
+			routes.HandleFunc("PUT /admin/payment-sandbox", RouteAuthAdminToken, HttpAdminSetPaymentSandboxCard(config.PaymentSandbox), logged, admin)
+		}
+		if config.LogLevels != nil {
+			routes.HandleFunc("GET /admin/log-levels", RouteAuthAdminToken, HttpAdminGetLogLevels(config.LogLevels), logged, admin)
+			routes.HandleFunc("PUT /admin/log-levels/{component}", RouteAuthAdminToken, HttpAdminSetLogLevel(config.LogLevels), logged, admin)
+		}
+		if config.Rates != nil {
+			routes.HandleFunc("GET /admin/capacity", RouteAuthAdminToken, HttpAdminCapacity(config.ReservationService, config.Rates, config.Properties), logged, WithCompression, admin)
+			routes.HandleFunc("GET /admin/capacity/chart", RouteAuthAdminToken, HttpAdminCapacityChart(e, config.ReservationService, config.Rates, config.Properties), logged, WithCompression, admin)
+			routes.HandleFunc("GET /admin/room-preferences", RouteAuthAdminToken, HttpAdminRoomPreferences(config.ReservationService), logged, admin)
+		}
+		if config.Properties != nil {
+			routes.HandleFunc("GET /admin/properties", RouteAuthAdminToken, HttpAdminProperties(config.Properties), logged, admin)
+		}
+		if config.SurveyService != nil {
+			routes.HandleFunc("GET /admin/dashboard", RouteAuthAdminToken, HttpAdminDashboard(e, config.SurveyService), logged, WithCompression, admin)
+			routes.HandleFunc("GET /admin/nps", RouteAuthAdminToken, HttpAdminNPS(config.SurveyService), logged, admin)
+		}
+		if config.ProfileService != nil {
+			routes.HandleFunc("GET /admin/duplicates", RouteAuthAdminToken, HttpAdminDuplicates(config.ProfileService), logged, admin)
+			routes.HandleFunc("GET /admin/merges", RouteAuthAdminToken, HttpAdminMerges(config.ProfileService), logged, admin)
+			routes.HandleFunc("POST /admin/merges", RouteAuthAdminToken, HttpAdminMergeProfiles(config.ProfileService, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/merges/{id}/undo", RouteAuthAdminToken, HttpAdminUndoMerge(config.ProfileService, config.Logger), logged, admin)
+			routes.HandleFunc("GET /admin/tiers", RouteAuthAdminToken, HttpAdminTiers(e, config.ProfileService), logged, WithCompression, admin)
+			routes.HandleFunc("PUT /admin/tiers/{guest}", RouteAuthAdminToken, HttpAdminAssignTier(config.ProfileService, config.Logger), logged, admin)
+		}
+		if config.LedgerService != nil {
+			routes.HandleFunc("GET /admin/ledger/entries", RouteAuthAdminToken, HttpAdminLedgerEntries(config.LedgerService), logged, WithCompression, admin)
+			routes.HandleFunc("POST /admin/ledger/entries/{sequence}/reverse", RouteAuthAdminToken, HttpAdminReverseLedgerEntry(config.LedgerService, config.Logger), logged, admin)
+			routes.HandleFunc("GET /admin/ledger/accounts", RouteAuthAdminToken, HttpAdminLedgerAccounts(config.LedgerService), logged, admin)
+			routes.HandleFunc("GET /admin/ledger/trial-balance", RouteAuthAdminToken, HttpAdminLedgerTrialBalance(config.LedgerService), logged, admin)
+			routes.HandleFunc("GET /admin/ledger/verify", RouteAuthAdminToken, HttpAdminLedgerVerify(config.LedgerService), logged, admin)
+			routes.HandleFunc("POST /admin/ledger/payouts", RouteAuthAdminToken, HttpAdminRecordLedgerPayout(config.LedgerService, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/ledger/payout-reports", RouteAuthAdminToken, HttpAdminImportPayoutReports(config.LedgerService, config.Logger), logged, admin)
+			routes.HandleFunc("GET /admin/ledger/settlements", RouteAuthAdminToken, HttpAdminLedgerSettlements(config.LedgerService), logged, WithCompression, admin)
+			routes.HandleFunc("GET /admin/ledger/payouts/outstanding", RouteAuthAdminToken, HttpAdminLedgerOutstandingPayouts(config.LedgerService, config.LedgerPayoutDelay), logged, WithCompression, admin)
+			routes.HandleFunc("GET /admin/ledger/payout-alerts", RouteAuthAdminToken, HttpAdminLedgerPayoutAlerts(config.LedgerService, config.LedgerPayoutDelay), logged, admin)
+		}
+		if config.PricingService != nil {
+			routes.HandleFunc("GET /admin/rate-plans", RouteAuthAdminToken, HttpAdminRatePlans(config.PricingService), logged, admin)
+			routes.HandleFunc("PUT /admin/rate-plans/{room}", RouteAuthAdminToken, HttpAdminSaveRatePlan(config.PricingService, config.Logger), logged, admin)
+		}
+		if config.EmailPreviews != nil {
+			routes.HandleFunc("GET /admin/emails/{template}/preview", RouteAuthAdminToken, HttpAdminEmailPreview(config.EmailPreviews), logged, admin)
+		}
+		if config.Blobs != nil {
+			routes.HandleFunc("GET /admin/blobs", RouteAuthAdminToken, HttpAdminBlobs(config.Blobs), logged, admin)
+		}
+		if config.AuditTrail != nil {
+			routes.HandleFunc("GET /admin/audit", RouteAuthAdminToken, HttpAdminAudit(config.AuditTrail), logged, WithCompression, admin)
+		}
+		if config.DeadLetters != nil {
+			routes.HandleFunc("GET /admin/dead-letters", RouteAuthAdminToken, HttpAdminDeadLetters(config.DeadLetters), logged, WithCompression, admin)
+			routes.HandleFunc("POST /admin/dead-letters/{id}/replay", RouteAuthAdminToken, HttpAdminReplayDeadLetter(config.DeadLetters, config.Logger), logged, admin)
+		}
+		if config.ReportService != nil && config.Properties != nil {
+			routes.HandleFunc("GET /admin/report-subscriptions", RouteAuthAdminToken, HttpAdminReportSubscriptions(e, config.ReportService, config.Properties), logged, WithCompression, admin)
+			routes.HandleFunc("POST /admin/report-subscriptions", RouteAuthAdminToken, HttpAdminCreateReportSubscription(config.ReportService, config.Properties, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/report-subscriptions/{id}/delete", RouteAuthAdminToken, HttpAdminDeleteReportSubscription(config.ReportService, config.Logger), logged, admin)
+		}
+		if config.HTTPClients != nil {
+			routes.HandleFunc("GET /admin/http-clients", RouteAuthAdminToken, HttpAdminHTTPClients(config.HTTPClients), logged, admin)
+		}
+		if config.WebhookService != nil {
+			routes.HandleFunc("GET /admin/webhooks", RouteAuthAdminToken, HttpAdminWebhooks(e, config.WebhookService), logged, WithCompression, admin)
+			routes.HandleFunc("POST /admin/webhooks", RouteAuthAdminToken, HttpAdminRegisterWebhook(config.WebhookService, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/webhooks/{id}/test", RouteAuthAdminToken, HttpAdminSendTestWebhook(config.WebhookService, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/webhooks/deliveries/{id}/redeliver", RouteAuthAdminToken, HttpAdminRedeliverWebhook(config.WebhookService, config.Logger), logged, admin)
+			routes.HandleFunc("GET /admin/webhooks/endpoints", RouteAuthAdminToken, HttpAdminListWebhookEndpoints(config.WebhookService), logged, admin)
+			routes.HandleFunc("POST /admin/webhooks/endpoints", RouteAuthAdminToken, HttpAdminCreateWebhookEndpoint(config.WebhookService, config.Logger), logged, admin)
+			routes.HandleFunc("GET /admin/webhooks/dead-letters", RouteAuthAdminToken, HttpAdminWebhookDeadLetters(config.WebhookService), logged, admin)
+			routes.HandleFunc("POST /admin/webhooks/dead-letters/{id}/replay", RouteAuthAdminToken, HttpAdminReplayWebhookDeadLetter(config.WebhookService, config.Logger), logged, admin)
+			routes.HandleFunc("DELETE /admin/webhooks/dead-letters/{id}", RouteAuthAdminToken, HttpAdminDiscardWebhookDeadLetter(config.WebhookService, config.Logger), logged, admin)
+		}
+		if config.Communications != nil {
+			routes.HandleFunc("GET /admin/reservations/{id}", RouteAuthAdminToken, HttpAdminReservation(e, config.ReservationService, config.Communications), logged, WithCompression, admin, WithValidReservationID)
+			routes.HandleFunc("POST /admin/communications/{id}/resend", RouteAuthAdminToken, HttpAdminResendCommunication(config.Communications, config.Logger), logged, admin)
+		}
+		if config.DocumentService != nil {
+			routes.HandleFunc("GET /admin/reservations/{id}/documents", RouteAuthAdminToken, HttpAdminReservationDocuments(config.DocumentService), logged, admin, WithValidReservationID)
+			routes.HandleFunc("POST /admin/reservations/{id}/documents", RouteAuthAdminToken, HttpAdminUploadReservationDocument(config.ReservationService, config.DocumentService, config.Logger), logged, admin, WithValidReservationID)
+			routes.HandleFunc("GET /admin/reservations/{id}/documents/{document}", RouteAuthAdminToken, HttpAdminDownloadReservationDocument(config.DocumentService), logged, admin, WithValidReservationID)
+			routes.HandleFunc("DELETE /admin/reservations/{id}/documents", RouteAuthAdminToken, HttpAdminPurgeReservationDocuments(config.DocumentService, config.Logger), logged, admin, WithValidReservationID)
+		}
+		if config.ServiceAccounts != nil {
+			routes.HandleFunc("GET /admin/service-accounts", RouteAuthAdminToken, HttpAdminServiceAccounts(config.ServiceAccounts), logged, admin)
+		}
+		if config.Scheduler != nil {
+			routes.HandleFunc("GET /admin/jobs", RouteAuthAdminToken, HttpAdminJobs(config.Scheduler), logged, admin)
+			routes.HandleFunc("POST /admin/jobs/{name}/run", RouteAuthAdminToken, HttpAdminRunJob(config.Scheduler), logged, admin)
+		}
+		if config.TestClock != nil {
+			routes.HandleFunc("GET /admin/clock", RouteAuthAdminToken, HttpAdminGetClock(config.TestClock), logged, admin)
+			routes.HandleFunc("POST /admin/clock/advance", RouteAuthAdminToken, HttpAdminAdvanceClock(config.TestClock, config.Scheduler), logged, admin)
+			routes.HandleFunc("DELETE /admin/clock", RouteAuthAdminToken, HttpAdminResetClock(config.TestClock), logged, admin)
+		}
+		if config.MCPOperations != nil {
+			routes.HandleFunc("GET /admin/mcp/operations", RouteAuthAdminToken, HttpAdminMCPOperations(config.MCPOperations), logged, admin)
+		}
+		if config.IncidentService != nil {
+			routes.HandleFunc("GET /admin/incidents", RouteAuthAdminToken, HttpAdminIncidents(config.IncidentService), logged, admin)
+			routes.HandleFunc("POST /admin/incidents", RouteAuthAdminToken, HttpAdminOpenIncident(config.IncidentService, config.Logger), logged, admin)
+			routes.HandleFunc("POST /admin/incidents/{id}/updates", RouteAuthAdminToken, HttpAdminPostIncidentUpdate(config.IncidentService, config.Logger), logged, admin)
+		}
+		if config.PromotionService != nil {
+			routes.HandleFunc("GET /admin/promotions", RouteAuthAdminToken, HttpAdminPromotions(config.PromotionService), logged, admin)
+			routes.HandleFunc("POST /admin/promotions", RouteAuthAdminToken, HttpAdminCreatePromotion(config.PromotionService, config.Logger), logged, admin)
+			routes.HandleFunc("DELETE /admin/promotions/{code}", RouteAuthAdminToken, HttpAdminDeactivatePromotion(config.PromotionService, config.Logger), logged, admin)
+		}
+		if config.Warehouse != nil {
+			routes.HandleFunc("POST /admin/warehouse/backfill", RouteAuthAdminToken, HttpAdminWarehouseBackfill(config.ReservationService, config.PaymentService, config.Warehouse), logged, admin)
 		}
 	}
 
+	// web.NewServeMux already registers /readiness, so the probe that verifies the dependencies
+	// is mounted on an outer mux that forwards everything else.
+	if config.Readiness != nil {
+		outer := http.NewServeMux()
+		outer.Handle("/", mux)
+		outer.HandleFunc("GET /readiness", HttpReadiness(config.Ctx, config.Readiness))
+		return outer
+	}
 	return mux
 }
@@ -3,7 +3,6 @@ package inbound_test
 import (
 	"context"
 	"embed"
-	"errors"
 	"io"
 	"io/fs"
 	"log/slog"
@@ -41,46 +40,40 @@ func getRouterTestFS(t *testing.T) fs.FS {
 	return sub
 }
 
-// mockReservationRepository is a simple in-memory mock for testing.
+// mockReservationRepository is the in-memory reservation repository of the outbound adapters,
+// with helpers to arrange and inspect the stored reservations.
 type mockReservationRepository struct {
-	reservations map[reservation.ReservationID]reservation.Reservation
+	*outbound.InMemoryTableAccess[reservation.ReservationID, reservation.Reservation]
 }
 
 func newMockReservationRepository() *mockReservationRepository {
-	return &mockReservationRepository{
-		reservations: make(map[reservation.ReservationID]reservation.Reservation),
-	}
+	return &mockReservationRepository{InMemoryTableAccess: outbound.NewInMemoryReservationRepository()}
 }
 
-func (m *mockReservationRepository) Create(ctx context.Context, id reservation.ReservationID, res reservation.Reservation) error {
-	m.reservations[id] = res
-	return nil
+// put stores the reservation, replacing a stored one with the ID.
+func (m *mockReservationRepository) put(id reservation.ReservationID, res reservation.Reservation) {
+	_ = m.Delete(context.Background(), id)
+	_ = m.Create(context.Background(), id, res)
 }
 
-func (m *mockReservationRepository) Read(ctx context.Context, id reservation.ReservationID) (*reservation.Reservation, error) {
-	res, ok := m.reservations[id]
-	if !ok {
-		return nil, errors.New("not found")
+// get returns the stored reservation with the ID, or the zero value.
+func (m *mockReservationRepository) get(id reservation.ReservationID) reservation.Reservation {
+	res, err := m.Read(context.Background(), id)
+	if err != nil {
+		return reservation.Reservation{}
 	}
-	return &res, nil
+	return *res
 }
 
-func (m *mockReservationRepository) Update(ctx context.Context, id reservation.ReservationID, res reservation.Reservation) error {
-	m.reservations[id] = res
-	return nil
+// all returns the stored reservations, in no particular order.
+func (m *mockReservationRepository) all() []reservation.Reservation {
+	all, _ := m.ReadAll(context.Background())
+	return all
 }
 
-func (m *mockReservationRepository) Delete(ctx context.Context, id reservation.ReservationID) error {
-	delete(m.reservations, id)
-	return nil
-}
-
-func (m *mockReservationRepository) ReadAll(ctx context.Context) ([]reservation.Reservation, error) {
-	result := make([]reservation.Reservation, 0, len(m.reservations))
-	for _, res := range m.reservations {
-		result = append(result, res)
-	}
-	return result, nil
+// count returns the number of stored reservations.
+func (m *mockReservationRepository) count() int {
+	return len(m.all())
 }
 
 func createTestReservationService(t *testing.T) *reservation.Service {
@@ -324,6 +317,33 @@ func Test_Route_Unknown_Endpoint_Should_Return_404(t *testing.T) {
 	assert.That(t, "status code must be 404", rec.Code, http.StatusNotFound)
 }
 
+func Test_Route_Content_Page_Without_Session_Should_Return_200(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_NAME", "TestApp")
+	t.Setenv("APP_DESCRIPTION", "Test Description")
+
+	ctx := context.Background()
+	logger := slog.Default()
+	reservationService := createTestReservationService(t)
+	mux := inbound.Route(inbound.RouterConfig{
+		ContentPages:       createTestContentPages(),
+		Ctx:                ctx,
+		EFS:                getRouterTestFS(t),
+		Logger:             logger,
+		ReservationService: reservationService,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ui/pages/faq", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	mux.ServeHTTP(rec, req)
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "body must contain rendered markdown", strings.Contains(rec.Body.String(), "<strong>3 PM</strong>"), true)
+}
+
 // ============================================================================
 // MCP Endpoint Tests
 // ============================================================================
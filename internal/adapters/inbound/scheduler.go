@@ -0,0 +1,163 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Errors of the scheduler.
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job is already running")
+)
+
+// Job is a periodic task of the scheduler, e.g. marking no-shows.
+// Run returns how many records it changed; it must be safe to run again after a partial failure.
+type Job struct {
+	Name  string
+	Every time.Duration
+	Run   func(ctx context.Context, now time.Time) (int, error)
+}
+
+// JobStatus is the outcome of the latest run of a job.
+type JobStatus struct {
+	Name         string    `json:"name"`
+	Every        string    `json:"every"`
+	Running      bool      `json:"running"`
+	LastRun      time.Time `json:"last_run,omitzero"`
+	LastDuration string    `json:"last_duration,omitempty"`
+	LastCount    int       `json:"last_count"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// scheduledJob is a registered job with the state of its latest run.
+type scheduledJob struct {
+	job     Job
+	running sync.Mutex // held during a run, so runs of the same job never overlap
+	mu      sync.Mutex // guards status
+	status  JobStatus
+}
+
+// Scheduler runs jobs periodically in the background, like cron within the process.
+// Each job has its own ticker, so a slow job does not delay the others.
+type Scheduler struct {
+	jobs   []*scheduledJob
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewScheduler creates a new scheduler without jobs.
+func NewScheduler(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger, now: shared.Now}
+}
+
+// ParseJobIntervals parses intervals in the format "name=interval,..." (e.g. "no_show=15m").
+func ParseJobIntervals(s string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid job interval: %q", part)
+		}
+		every, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("invalid interval for %s: %q", name, value)
+		}
+		intervals[strings.TrimSpace(name)] = every
+	}
+	return intervals, nil
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, &scheduledJob{
+		job:    job,
+		status: JobStatus{Name: job.Name, Every: job.Every.String()},
+	})
+}
+
+// Start runs every job immediately and then at its interval until the context is done.
+// A run that is still going when the next is due is not overlapped; the due run is skipped.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		go func() {
+			ticker := time.NewTicker(j.job.Every)
+			defer ticker.Stop()
+			for {
+				if _, err := s.run(ctx, j); err != nil && !errors.Is(err, ErrJobRunning) {
+					s.logger.Warn("scheduled job failed", "job", j.job.Name, "error", err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+}
+
+// Run runs the job with the name now, e.g. triggered by an operator, and returns its status.
+func (s *Scheduler) Run(ctx context.Context, name string) (JobStatus, error) {
+	for _, j := range s.jobs {
+		if j.job.Name == name {
+			return s.run(ctx, j)
+		}
+	}
+	return JobStatus{}, ErrJobNotFound
+}
+
+// Jobs returns the status of every job, sorted by name.
+func (s *Scheduler) Jobs() []JobStatus {
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		statuses = append(statuses, j.status)
+		j.mu.Unlock()
+	}
+	slices.SortFunc(statuses, func(a, b JobStatus) int { return strings.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// run runs the job once unless it is running already, and records the outcome.
+func (s *Scheduler) run(ctx context.Context, j *scheduledJob) (JobStatus, error) {
+	if !j.running.TryLock() {
+		return JobStatus{}, ErrJobRunning
+	}
+	defer j.running.Unlock()
+
+	j.mu.Lock()
+	j.status.Running = true
+	j.mu.Unlock()
+	started := s.now()
+	count, err := j.job.Run(ctx, started)
+
+	j.mu.Lock()
+	j.status.Running = false
+	j.status.LastRun = started
+	j.status.LastDuration = s.now().Sub(started).String()
+	j.status.LastCount = count
+	j.status.LastError = ""
+	if err != nil {
+		j.status.LastError = err.Error()
+	}
+	status := j.status
+	j.mu.Unlock()
+
+	if count > 0 {
+		s.logger.Info("scheduled job done", "job", j.job.Name, "count", count)
+	}
+	return status, err
+}
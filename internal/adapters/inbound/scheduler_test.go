@@ -0,0 +1,125 @@
+package inbound_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+)
+
+// ============================================================================
+// ParseJobIntervals Tests
+// ============================================================================
+
+func Test_ParseJobIntervals_Should_Parse_Intervals_Per_Job(t *testing.T) {
+	// Act
+	intervals, err := inbound.ParseJobIntervals("no_show=15m, expire_pending=1m")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "no_show interval must be parsed", intervals["no_show"], 15*time.Minute)
+	assert.That(t, "expire_pending interval must be parsed", intervals["expire_pending"], time.Minute)
+}
+
+func Test_ParseJobIntervals_With_Invalid_Interval_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := inbound.ParseJobIntervals("no_show=soon")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// Scheduler Tests
+// ============================================================================
+
+func Test_Scheduler_Run_Should_Record_Status(t *testing.T) {
+	// Arrange
+	scheduler := inbound.NewScheduler(slog.Default())
+	scheduler.Register(inbound.Job{Name: "no_show", Every: time.Hour, Run: func(ctx context.Context, now time.Time) (int, error) {
+		return 2, nil
+	}})
+
+	// Act
+	status, err := scheduler.Run(context.Background(), "no_show")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "count must be recorded", status.LastCount, 2)
+	assert.That(t, "run time must be recorded", status.LastRun.IsZero(), false)
+	assert.That(t, "job must be listed", scheduler.Jobs()[0].LastCount, 2)
+}
+
+func Test_Scheduler_Run_With_Failing_Job_Should_Record_Error(t *testing.T) {
+	// Arrange
+	scheduler := inbound.NewScheduler(slog.Default())
+	scheduler.Register(inbound.Job{Name: "no_show", Every: time.Hour, Run: func(ctx context.Context, now time.Time) (int, error) {
+		return 1, errors.New("database error")
+	}})
+
+	// Act
+	status, err := scheduler.Run(context.Background(), "no_show")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "error must be recorded", status.LastError, "database error")
+	assert.That(t, "partial count must be recorded", status.LastCount, 1)
+}
+
+func Test_Scheduler_Run_With_Unknown_Job_Should_Return_ErrJobNotFound(t *testing.T) {
+	// Arrange
+	scheduler := inbound.NewScheduler(slog.Default())
+
+	// Act
+	_, err := scheduler.Run(context.Background(), "unknown")
+
+	// Assert
+	assert.That(t, "err must be ErrJobNotFound", errors.Is(err, inbound.ErrJobNotFound), true)
+}
+
+func Test_Scheduler_Run_While_Running_Should_Return_ErrJobRunning(t *testing.T) {
+	// Arrange
+	scheduler := inbound.NewScheduler(slog.Default())
+	started, release := make(chan struct{}), make(chan struct{})
+	scheduler.Register(inbound.Job{Name: "no_show", Every: time.Hour, Run: func(ctx context.Context, now time.Time) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	}})
+	go func() { _, _ = scheduler.Run(context.Background(), "no_show") }()
+	<-started
+
+	// Act
+	_, err := scheduler.Run(context.Background(), "no_show")
+	close(release)
+
+	// Assert
+	assert.That(t, "err must be ErrJobRunning", errors.Is(err, inbound.ErrJobRunning), true)
+}
+
+func Test_Scheduler_Start_Should_Run_Jobs_Periodically(t *testing.T) {
+	// Arrange
+	scheduler := inbound.NewScheduler(slog.Default())
+	var runs atomic.Int32
+	scheduler.Register(inbound.Job{Name: "expire_pending", Every: 10 * time.Millisecond, Run: func(ctx context.Context, now time.Time) (int, error) {
+		runs.Add(1)
+		return 0, nil
+	}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	scheduler.Start(ctx)
+
+	// Assert
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.That(t, "job must run repeatedly", runs.Load() >= 3, true)
+}
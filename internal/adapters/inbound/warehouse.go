@@ -0,0 +1,185 @@
+package inbound
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Tables of the data warehouse.
+const (
+	WarehouseTableReservationEvents = "reservation_events"
+	WarehouseTablePaymentEvents     = "payment_events"
+	WarehouseTableReservations      = "reservations"
+	WarehouseTablePayments          = "payments"
+)
+
+// warehouseBackfillFlushEvery is the number of records after which the backfill flushes the recorder.
+const warehouseBackfillFlushEvery = 1000
+
+// warehouseEventTables maps the event topics to the warehouse tables.
+var warehouseEventTables = map[string]string{
+	reservation.EventTopicCreated:   WarehouseTableReservationEvents,
+	reservation.EventTopicConfirmed: WarehouseTableReservationEvents,
+	reservation.EventTopicActivated: WarehouseTableReservationEvents,
+	reservation.EventTopicCompleted: WarehouseTableReservationEvents,
+	reservation.EventTopicCancelled: WarehouseTableReservationEvents,
+	reservation.EventTopicNoShow:    WarehouseTableReservationEvents,
+	reservation.EventTopicExtended:  WarehouseTableReservationEvents,
+	payment.EventTopicAuthorized:    WarehouseTablePaymentEvents,
+	payment.EventTopicCaptured:      WarehouseTablePaymentEvents,
+	payment.EventTopicFailed:        WarehouseTablePaymentEvents,
+	payment.EventTopicRefunded:      WarehouseTablePaymentEvents,
+}
+
+// warehousePersonalFields are the reservation fields that are not copied to the warehouse,
+// because they hold contact data of guests (the actor of a status change may be an email address).
+var warehousePersonalFields = []string{"GuestEmail", "Guests", "Shares", "History", "EmergencyContact"}
+
+// WarehouseRecorder buffers JSON records for a table of the data warehouse and writes them in batches.
+type WarehouseRecorder interface {
+	Record(ctx context.Context, table, key string, data []byte) error
+	Flush(ctx context.Context) error
+}
+
+// SubscribeWarehouseEvents subscribes to the reservation and payment events and records them
+// in the reservation_events and payment_events tables with their topic.
+// Events carry no ID, so the key is a hash of the topic and the payload: an event that is
+// delivered again, e.g. replayed by Kafka after a restart, replaces its earlier row.
+func SubscribeWarehouseEvents(ctx context.Context, dispatcher messaging.Dispatcher, recorder WarehouseRecorder) error {
+	for topic, table := range warehouseEventTables {
+		fn := func(msg messaging.Message) (messaging.MessageState, error) {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(msg.Data, &fields); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			fields["topic"], _ = json.Marshal(msg.Topic)
+			data, err := json.Marshal(fields)
+			if err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			sum := sha256.Sum256(append([]byte(msg.Topic+"\n"), msg.Data...))
+			if err := recorder.Record(ctx, table, hex.EncodeToString(sum[:]), data); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			return messaging.MessageStateCompleted, nil
+		}
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(fn)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// HttpAdminWarehouseBackfillResponse specifies the JSON body of a backfill report.
+type HttpAdminWarehouseBackfillResponse struct {
+	Reservations int `json:"reservations"`
+	Payments     int `json:"payments"`
+}
+
+// HttpAdminWarehouseBackfill copies the current state of all reservations and payments from the
+// databases to the reservations and payments tables, e.g. after the warehouse sink was enabled.
+// The optional tables query parameter (reservations,payments) limits the copied tables.
+// Rows are keyed by ID and update time, so running the backfill again only adds changed records.
+func HttpAdminWarehouseBackfill(reservationService *reservation.Service, paymentService *payment.Service, recorder WarehouseRecorder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tables := map[string]bool{WarehouseTableReservations: true, WarehouseTablePayments: true}
+		if raw := r.URL.Query().Get("tables"); raw != "" {
+			tables = make(map[string]bool)
+			for _, name := range strings.Split(raw, ",") {
+				name = strings.TrimSpace(name)
+				if name != WarehouseTableReservations && name != WarehouseTablePayments {
+					http.Error(w, fmt.Sprintf("unknown table %q", name), http.StatusBadRequest)
+					return
+				}
+				tables[name] = true
+			}
+		}
+
+		ctx := r.Context()
+		var resp HttpAdminWarehouseBackfillResponse
+		backfill := warehouseBackfill{ctx: ctx, recorder: recorder}
+		if tables[WarehouseTableReservations] {
+			reservations, err := reservationService.ListReservations(ctx)
+			if err != nil {
+				http.Error(w, "failed to list reservations", http.StatusInternalServerError)
+				return
+			}
+			for _, res := range reservations {
+				backfill.record(WarehouseTableReservations, string(res.ID), res.UpdatedAt.UnixNano(), res, warehousePersonalFields...)
+			}
+			resp.Reservations = len(reservations)
+		}
+		if tables[WarehouseTablePayments] {
+			payments, err := paymentService.ListPayments(ctx)
+			if err != nil {
+				http.Error(w, "failed to list payments", http.StatusInternalServerError)
+				return
+			}
+			for _, p := range payments {
+				backfill.record(WarehouseTablePayments, string(p.ID), p.UpdatedAt.UnixNano(), p)
+			}
+			resp.Payments = len(payments)
+		}
+		if err := backfill.flush(); err != nil {
+			http.Error(w, "failed to write to the warehouse: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// warehouseBackfill records snapshots and flushes the recorder periodically, so the
+// buffer of the recorder does not overflow. The first error stops recording.
+type warehouseBackfill struct {
+	ctx      context.Context
+	recorder WarehouseRecorder
+	recorded int
+	err      error
+}
+
+// record records the JSON of v without the omitted top-level fields, keyed by id and version.
+func (b *warehouseBackfill) record(table, id string, version int64, v any, omit ...string) {
+	if b.err != nil {
+		return
+	}
+	var fields map[string]json.RawMessage
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = json.Unmarshal(data, &fields)
+	}
+	if err != nil {
+		b.err = err
+		return
+	}
+	for _, name := range omit {
+		delete(fields, name)
+	}
+	data, _ = json.Marshal(fields)
+	if b.err = b.recorder.Record(b.ctx, table, fmt.Sprintf("%s@%d", id, version), data); b.err != nil {
+		return
+	}
+	b.recorded++
+	if b.recorded%warehouseBackfillFlushEvery == 0 {
+		b.err = b.recorder.Flush(b.ctx)
+	}
+}
+
+// flush flushes the recorder and returns the first error.
+func (b *warehouseBackfill) flush() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.recorder.Flush(b.ctx)
+}
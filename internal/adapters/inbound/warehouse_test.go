@@ -0,0 +1,164 @@
+package inbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/inbound"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// warehouseRecord is a record of the mock recorder.
+type warehouseRecord struct {
+	table, key string
+	fields     map[string]any
+}
+
+// mockWarehouseRecorder keeps the records in memory.
+type mockWarehouseRecorder struct {
+	records  []warehouseRecord
+	flushes  int
+	flushErr error
+}
+
+func (m *mockWarehouseRecorder) Record(ctx context.Context, table, key string, data []byte) error {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	m.records = append(m.records, warehouseRecord{table: table, key: key, fields: fields})
+	return nil
+}
+
+func (m *mockWarehouseRecorder) Flush(ctx context.Context) error {
+	m.flushes++
+	return m.flushErr
+}
+
+func createWarehouseTestPaymentService(t *testing.T) *payment.Service {
+	t.Helper()
+	paymentService := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	_, _ = paymentService.AuthorizePayment(context.Background(), "pay-001", "res-001", shared.NewMoney(20000, "USD"), "credit_card")
+	return paymentService
+}
+
+func postWarehouseBackfill(handler http.HandlerFunc, query string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/admin/warehouse/backfill"+query, nil))
+	return rec
+}
+
+// ============================================================================
+// SubscribeWarehouseEvents Tests
+// ============================================================================
+
+func Test_SubscribeWarehouseEvents_Should_Record_Events_With_Topic(t *testing.T) {
+	// Arrange
+	dispatcher := messaging.NewInternalDispatcher()
+	recorder := &mockWarehouseRecorder{}
+	ctx := context.Background()
+	_ = inbound.SubscribeWarehouseEvents(ctx, dispatcher, recorder)
+
+	// Act
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicCaptured, []byte(`{"payment_id":"pay-001"}`)))
+	_ = dispatcher.Publish(ctx, messaging.NewMessage(payment.EventTopicCaptured, []byte(`{"payment_id":"pay-001"}`)))
+
+	// Assert
+	assert.That(t, "both deliveries must be recorded", len(recorder.records), 2)
+	assert.That(t, "table must be payment_events", recorder.records[0].table, inbound.WarehouseTablePaymentEvents)
+	assert.That(t, "topic must be added", recorder.records[0].fields["topic"], any(payment.EventTopicCaptured))
+	assert.That(t, "payload must be kept", recorder.records[0].fields["payment_id"], any("pay-001"))
+	assert.That(t, "redelivery must have the same key", recorder.records[1].key, recorder.records[0].key)
+}
+
+func Test_SubscribeWarehouseEvents_With_Subscribe_Error_Should_Return_Error(t *testing.T) {
+	// Arrange
+	dispatcher := &mockDispatcher{subscribeErr: errors.New("broker down")}
+
+	// Act
+	err := inbound.SubscribeWarehouseEvents(context.Background(), dispatcher, &mockWarehouseRecorder{})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// HttpAdminWarehouseBackfill Tests
+// ============================================================================
+
+func Test_HttpAdminWarehouseBackfill_Should_Record_Snapshots_Without_Contact_Data(t *testing.T) {
+	// Arrange
+	recorder := &mockWarehouseRecorder{}
+	handler := inbound.HttpAdminWarehouseBackfill(createSimulationTestService(t), createWarehouseTestPaymentService(t), recorder)
+
+	// Act
+	rec := postWarehouseBackfill(handler, "")
+
+	// Assert
+	var resp inbound.HttpAdminWarehouseBackfillResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "response must count the records", resp, inbound.HttpAdminWarehouseBackfillResponse{Reservations: 1, Payments: 1})
+	assert.That(t, "two records must be written", len(recorder.records), 2)
+	reservationRecord := recorder.records[0]
+	assert.That(t, "table must be reservations", reservationRecord.table, inbound.WarehouseTableReservations)
+	assert.That(t, "key must be versioned", strings.HasPrefix(reservationRecord.key, "res-001@"), true)
+	_, hasEmail := reservationRecord.fields["GuestEmail"]
+	_, hasGuests := reservationRecord.fields["Guests"]
+	assert.That(t, "guest email must be omitted", hasEmail, false)
+	assert.That(t, "guests must be omitted", hasGuests, false)
+	_, hasContact := reservationRecord.fields["EmergencyContact"]
+	assert.That(t, "emergency contact must be omitted", hasContact, false)
+	assert.That(t, "recorder must be flushed", recorder.flushes, 1)
+}
+
+func Test_HttpAdminWarehouseBackfill_With_Tables_Should_Only_Record_Those(t *testing.T) {
+	// Arrange
+	recorder := &mockWarehouseRecorder{}
+	handler := inbound.HttpAdminWarehouseBackfill(createSimulationTestService(t), createWarehouseTestPaymentService(t), recorder)
+
+	// Act
+	rec := postWarehouseBackfill(handler, "?tables=payments")
+
+	// Assert
+	assert.That(t, "status code must be 200", rec.Code, http.StatusOK)
+	assert.That(t, "one record must be written", len(recorder.records), 1)
+	assert.That(t, "table must be payments", recorder.records[0].table, inbound.WarehouseTablePayments)
+}
+
+func Test_HttpAdminWarehouseBackfill_With_Unknown_Table_Should_Return_400(t *testing.T) {
+	// Arrange
+	handler := inbound.HttpAdminWarehouseBackfill(createSimulationTestService(t), createWarehouseTestPaymentService(t), &mockWarehouseRecorder{})
+
+	// Act
+	rec := postWarehouseBackfill(handler, "?tables=guests")
+
+	// Assert
+	assert.That(t, "status code must be 400", rec.Code, http.StatusBadRequest)
+}
+
+func Test_HttpAdminWarehouseBackfill_When_Flush_Fails_Should_Return_502(t *testing.T) {
+	// Arrange
+	recorder := &mockWarehouseRecorder{flushErr: errors.New("warehouse unavailable")}
+	handler := inbound.HttpAdminWarehouseBackfill(createSimulationTestService(t), createWarehouseTestPaymentService(t), recorder)
+
+	// Act
+	rec := postWarehouseBackfill(handler, "")
+
+	// Assert
+	assert.That(t, "status code must be 502", rec.Code, http.StatusBadGateway)
+}
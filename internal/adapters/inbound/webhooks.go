@@ -0,0 +1,38 @@
+package inbound
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// SubscribeWebhookEvents subscribes to the reservation and payment events and sends them to the
+// integrator endpoints subscribing to their topics, e.g. of channel managers.
+// Failed deliveries do not fail the message: they are retried by the webhook_retries job, so one
+// broken receiver does not hold up the events of the others.
+func SubscribeWebhookEvents(ctx context.Context, dispatcher messaging.Dispatcher, webhookService *webhook.Service) error {
+	for _, topic := range webhook.Topics {
+		fn := func(msg messaging.Message) (messaging.MessageState, error) {
+			if _, err := webhookService.DeliverEvent(ctx, webhookEventID(msg), msg.Topic, msg.Data); err != nil {
+				return messaging.MessageStateFailed, err
+			}
+			return messaging.MessageStateCompleted, nil
+		}
+		if err := dispatcher.Subscribe(ctx, topic, service.Wrap(fn)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// webhookEventID returns the ID of the event sent to the endpoints. Events carry no ID, so it is
+// a hash of the topic and the payload, like the warehouse key; a replayed event gets the same ID.
+func webhookEventID(msg messaging.Message) string {
+	sum := sha256.Sum256(append([]byte(msg.Topic+"\n"), msg.Data...))
+	return hex.EncodeToString(sum[:16])
+}
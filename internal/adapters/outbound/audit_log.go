@@ -0,0 +1,66 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// AuditLog stores the audit entries of the reservation and payment services in their own table.
+// Entries are only ever created; staff query them on /admin/audit.
+type AuditLog struct {
+	repo   resource.Access[string, shared.AuditEntry]
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewAuditLog creates an audit log stored in the repository.
+func NewAuditLog(repo resource.Access[string, shared.AuditEntry], logger *slog.Logger) *AuditLog {
+	return &AuditLog{repo: repo, logger: logger, now: time.Now}
+}
+
+// Record stores the entry with a new ID and the current time. The operation took effect already,
+// so a failure is logged with the entry instead of being returned; the entry is stored even if
+// the request of the operation was cancelled meanwhile.
+func (a *AuditLog) Record(ctx context.Context, entry shared.AuditEntry) {
+	entry.ID = security.GenerateID()
+	entry.At = a.now()
+	if err := a.repo.Create(context.WithoutCancel(ctx), entry.ID, entry); err != nil {
+		a.logger.Error("failed to record audit entry",
+			"audit", true,
+			"actor", entry.Actor,
+			"aggregate", entry.Aggregate,
+			"entity_id", entry.EntityID,
+			"action", entry.Action,
+			"error", err,
+		)
+	}
+}
+
+// Entries returns the entries selected by the filter, newest first.
+func (a *AuditLog) Entries(ctx context.Context, filter shared.AuditFilter) ([]shared.AuditEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	all, err := a.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit entries: %w", err)
+	}
+	var matched []shared.AuditEntry
+	for _, entry := range all {
+		if filter.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].At.After(matched[j].At) })
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, nil
+}
@@ -0,0 +1,76 @@
+package outbound_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// AuditLog Tests
+// ============================================================================
+
+func Test_AuditLog_Record_Should_Store_Entry_With_ID_And_Time(t *testing.T) {
+	// Arrange
+	auditLog := outbound.NewAuditLog(resource.NewInMemoryAccess[string, shared.AuditEntry](), slog.Default())
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalStaff, Subject: "sub-1", Email: "frontdesk@example.com"})
+	// A cancelled request must not lose the entry of an operation that took effect.
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// Act
+	auditLog.Record(ctx, shared.NewAuditEntry(ctx, "reservation", "res-001", shared.AuditCancel, []byte(`{"Status":"confirmed"}`), []byte(`{"Status":"cancelled"}`)))
+
+	// Assert
+	entries, err := auditLog.Entries(context.Background(), shared.AuditFilter{})
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "entry must be stored", len(entries), 1)
+	assert.That(t, "entry must have an ID", entries[0].ID != "", true)
+	assert.That(t, "entry must have a time", entries[0].At.IsZero(), false)
+	assert.That(t, "actor must be the staff member", entries[0].Actor, "staff:frontdesk@example.com")
+	assert.That(t, "subject must be recorded", entries[0].Subject, "sub-1")
+	assert.That(t, "state before must be kept", string(entries[0].Before), `{"Status":"confirmed"}`)
+}
+
+func Test_AuditLog_Entries_Should_Filter_Newest_First(t *testing.T) {
+	// Arrange
+	auditLog := outbound.NewAuditLog(resource.NewInMemoryAccess[string, shared.AuditEntry](), slog.Default())
+	ctx := context.Background()
+	service := shared.ContextWithPrincipal(ctx, shared.Principal{Type: shared.PrincipalService, Subject: "sub-mcp", ClientID: "mcp-agent"})
+	auditLog.Record(ctx, shared.NewAuditEntry(ctx, "reservation", "res-001", shared.AuditCreate, nil, []byte(`{}`)))
+	time.Sleep(time.Millisecond)
+	auditLog.Record(service, shared.NewAuditEntry(service, "reservation", "res-001", shared.AuditCancel, []byte(`{}`), []byte(`{}`)))
+	time.Sleep(time.Millisecond)
+	auditLog.Record(service, shared.NewAuditEntry(service, "payment", "pay-001", shared.AuditRefund, []byte(`{}`), []byte(`{}`)))
+
+	// Act
+	byEntity, err := auditLog.Entries(ctx, shared.AuditFilter{Aggregate: "reservation", EntityID: "res-001"})
+	byActor, _ := auditLog.Entries(ctx, shared.AuditFilter{Actor: "service:mcp-agent", Limit: 1})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "entries of the reservation must be returned", len(byEntity), 2)
+	assert.That(t, "newest entry must be first", byEntity[0].Action, shared.AuditCancel)
+	assert.That(t, "system must be the actor without a caller", byEntity[1].Actor, shared.ActorSystem)
+	assert.That(t, "limit must apply", len(byActor), 1)
+	assert.That(t, "newest entry of the client must be returned", byActor[0].Action, shared.AuditRefund)
+	assert.That(t, "client ID must be recorded", byActor[0].ClientID, "mcp-agent")
+}
+
+func Test_AuditLog_Entries_With_Reversed_Range_Should_Return_Error(t *testing.T) {
+	// Arrange
+	auditLog := outbound.NewAuditLog(resource.NewInMemoryAccess[string, shared.AuditEntry](), slog.Default())
+	now := time.Now()
+
+	// Act
+	_, err := auditLog.Entries(context.Background(), shared.AuditFilter{From: now, To: now.Add(-time.Hour)})
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidAuditFilter", err, shared.ErrInvalidAuditFilter)
+}
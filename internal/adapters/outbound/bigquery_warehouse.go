@@ -0,0 +1,292 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBigQueryTokenURL is the metadata server endpoint for the access token of the attached
+// service account on GKE, Cloud Run and Compute Engine.
+const DefaultBigQueryTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// bigQueryTypes maps the column types to the type names of the BigQuery REST API.
+var bigQueryTypes = map[WarehouseColumnType]string{
+	WarehouseString:    "STRING",
+	WarehouseInt:       "INTEGER",
+	WarehouseFloat:     "FLOAT",
+	WarehouseBool:      "BOOLEAN",
+	WarehouseTimestamp: "TIMESTAMP",
+}
+
+// BigQueryWarehouse implements Warehouse with the BigQuery REST API v2.
+// Rows are streamed with insertAll and the key as insertId, which BigQuery deduplicates on a best-effort basis.
+type BigQueryWarehouse struct {
+	client  *http.Client
+	apiURL  string
+	project string
+	dataset string
+	tokens  *googleTokenSource
+}
+
+// NewBigQueryWarehouse creates a new BigQuery warehouse, e.g. with the "warehouse" client of HTTPClients.
+// The apiURL is https://bigquery.googleapis.com unless an emulator is used; access tokens are fetched
+// from tokenURL (DefaultBigQueryTokenURL) and cached until shortly before they expire.
+func NewBigQueryWarehouse(client *http.Client, apiURL, tokenURL, project, dataset string) (*BigQueryWarehouse, error) {
+	if project == "" || dataset == "" {
+		return nil, errors.New("bigquery project and dataset required")
+	}
+	return &BigQueryWarehouse{
+		client:  client,
+		apiURL:  strings.TrimSuffix(apiURL, "/"),
+		project: project,
+		dataset: dataset,
+		tokens:  &googleTokenSource{client: client, url: tokenURL, now: time.Now},
+	}, nil
+}
+
+// Name returns "bigquery".
+func (w *BigQueryWarehouse) Name() string { return "bigquery" }
+
+// bigQueryField is a column of a table schema in the BigQuery API.
+type bigQueryField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Mode string `json:"mode,omitempty"`
+}
+
+// bigQueryTable is a table resource in the BigQuery API.
+type bigQueryTable struct {
+	TableReference struct {
+		ProjectID string `json:"projectId"`
+		DatasetID string `json:"datasetId"`
+		TableID   string `json:"tableId"`
+	} `json:"tableReference"`
+	Schema struct {
+		Fields []bigQueryField `json:"fields"`
+	} `json:"schema"`
+}
+
+// Columns returns the columns of the table; none if it does not exist.
+func (w *BigQueryWarehouse) Columns(ctx context.Context, table string) ([]WarehouseColumn, error) {
+	resource, err := w.table(ctx, table)
+	if err != nil || resource == nil {
+		return nil, err
+	}
+	columns := make([]WarehouseColumn, 0, len(resource.Schema.Fields))
+	for _, f := range resource.Schema.Fields {
+		if f.Name == WarehouseKeyColumn {
+			continue
+		}
+		columns = append(columns, WarehouseColumn{Name: f.Name, Type: bigQueryColumnType(f.Type)})
+	}
+	return columns, nil
+}
+
+// bigQueryColumnType returns the column type of a BigQuery type; both legacy and standard SQL names are accepted.
+func bigQueryColumnType(typ string) WarehouseColumnType {
+	switch typ {
+	case "INTEGER", "INT64":
+		return WarehouseInt
+	case "FLOAT", "FLOAT64", "NUMERIC", "BIGNUMERIC":
+		return WarehouseFloat
+	case "BOOLEAN", "BOOL":
+		return WarehouseBool
+	case "TIMESTAMP", "DATETIME":
+		return WarehouseTimestamp
+	default:
+		return WarehouseString
+	}
+}
+
+// AddColumns creates the table if it does not exist, otherwise patches its schema with the nullable columns.
+func (w *BigQueryWarehouse) AddColumns(ctx context.Context, table string, columns []WarehouseColumn) error {
+	resource, err := w.table(ctx, table)
+	if err != nil {
+		return err
+	}
+	method, target := http.MethodPatch, w.tablesURL()+"/"+table
+	if resource == nil {
+		method, target = http.MethodPost, w.tablesURL()
+		resource = &bigQueryTable{}
+		resource.TableReference.ProjectID = w.project
+		resource.TableReference.DatasetID = w.dataset
+		resource.TableReference.TableID = table
+		resource.Schema.Fields = []bigQueryField{{Name: WarehouseKeyColumn, Type: "STRING", Mode: "REQUIRED"}}
+	}
+	for _, c := range columns {
+		resource.Schema.Fields = append(resource.Schema.Fields, bigQueryField{Name: c.Name, Type: bigQueryTypes[c.Type], Mode: "NULLABLE"})
+	}
+	return w.call(ctx, method, target, resource, nil)
+}
+
+// bigQueryInsertResponse is the response of tabledata.insertAll.
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+// Insert streams the rows with insertAll. Rows rejected by BigQuery fail the batch.
+func (w *BigQueryWarehouse) Insert(ctx context.Context, table string, rows []WarehouseRow) error {
+	if !validWarehouseIdentifier(table) {
+		return fmt.Errorf("invalid bigquery table %q", table)
+	}
+	type insertRow struct {
+		InsertID string         `json:"insertId"`
+		JSON     map[string]any `json:"json"`
+	}
+	request := struct {
+		Rows []insertRow `json:"rows"`
+	}{Rows: make([]insertRow, 0, len(rows))}
+	for _, row := range rows {
+		values := make(map[string]any, len(row.Values)+1)
+		for name, value := range row.Values {
+			if t, ok := value.(time.Time); ok {
+				value = t.UTC().Format(time.RFC3339Nano)
+			}
+			values[name] = value
+		}
+		values[WarehouseKeyColumn] = row.Key
+		request.Rows = append(request.Rows, insertRow{InsertID: row.Key, JSON: values})
+	}
+
+	var response bigQueryInsertResponse
+	if err := w.call(ctx, http.MethodPost, w.tablesURL()+"/"+table+"/insertAll", request, &response); err != nil {
+		return err
+	}
+	if len(response.InsertErrors) > 0 {
+		first := response.InsertErrors[0]
+		reason := "unknown"
+		if len(first.Errors) > 0 {
+			reason = first.Errors[0].Reason + ": " + first.Errors[0].Message
+		}
+		return fmt.Errorf("bigquery rejected %d rows, first at index %d (%s)", len(response.InsertErrors), first.Index, reason)
+	}
+	return nil
+}
+
+// table returns the table resource, or nil if the table does not exist.
+func (w *BigQueryWarehouse) table(ctx context.Context, table string) (*bigQueryTable, error) {
+	if !validWarehouseIdentifier(table) {
+		return nil, fmt.Errorf("invalid bigquery table %q", table)
+	}
+	var resource bigQueryTable
+	err := w.call(ctx, http.MethodGet, w.tablesURL()+"/"+table, nil, &resource)
+	if errors.Is(err, errBigQueryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// tablesURL returns the URL of the tables of the dataset.
+func (w *BigQueryWarehouse) tablesURL() string {
+	return fmt.Sprintf("%s/bigquery/v2/projects/%s/datasets/%s/tables", w.apiURL, w.project, w.dataset)
+}
+
+// errBigQueryNotFound is returned by call for 404 Not Found.
+var errBigQueryNotFound = errors.New("bigquery resource not found")
+
+// call sends the request body as JSON and decodes the response into out, if not nil.
+func (w *BigQueryWarehouse) call(ctx context.Context, method, target string, in, out any) error {
+	token, err := w.tokens.token(ctx)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode bigquery request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to create bigquery request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call bigquery: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read bigquery response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errBigQueryNotFound
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("bigquery returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data[:min(len(data), 4096)])))
+	case out != nil:
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode bigquery response: %w", err)
+		}
+	}
+	return nil
+}
+
+// googleTokenSource fetches access tokens of the service account from the metadata server and caches them;
+// BigQuery and FCM use it.
+type googleTokenSource struct {
+	client *http.Client
+	url    string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	current string
+	expiry  time.Time
+}
+
+// token returns a cached token that is valid for at least another minute, or fetches a new one.
+func (s *googleTokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != "" && s.now().Add(time.Minute).Before(s.expiry) {
+		return s.current, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch google access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", errors.New("token endpoint returned no access token")
+	}
+	s.current = token.AccessToken
+	s.expiry = s.now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.current, nil
+}
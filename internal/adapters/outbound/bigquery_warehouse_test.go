@@ -0,0 +1,132 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createTestBigQueryServer serves a token endpoint and the tables API; handler gets the table requests.
+func createTestBigQueryServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *int) {
+	t.Helper()
+	tokens := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokens++
+			_, _ = io.WriteString(w, `{"access_token":"bq-token","expires_in":3600}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer bq-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &tokens
+}
+
+// ============================================================================
+// BigQueryWarehouse Tests
+// ============================================================================
+
+func Test_NewBigQueryWarehouse_Without_Dataset_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewBigQueryWarehouse(http.DefaultClient, "https://bigquery.googleapis.com", outbound.DefaultBigQueryTokenURL, "hotel", "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_BigQueryWarehouse_Columns_Of_Missing_Table_Should_Return_None(t *testing.T) {
+	// Arrange
+	srv, _ := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	warehouse, _ := outbound.NewBigQueryWarehouse(srv.Client(), srv.URL, srv.URL+"/token", "hotel", "analytics")
+
+	// Act
+	columns, err := warehouse.Columns(context.Background(), "payment_events")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "columns must be empty", len(columns), 0)
+}
+
+func Test_BigQueryWarehouse_AddColumns_Should_Patch_Existing_Schema(t *testing.T) {
+	// Arrange
+	var method string
+	var patched map[string]any
+	srv, tokens := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"schema":{"fields":[{"name":"_key","type":"STRING","mode":"REQUIRED"},{"name":"amount","type":"INTEGER"}]}}`)
+			return
+		}
+		method = r.Method
+		_ = json.NewDecoder(r.Body).Decode(&patched)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	warehouse, _ := outbound.NewBigQueryWarehouse(srv.Client(), srv.URL, srv.URL+"/token", "hotel", "analytics")
+
+	// Act
+	err := warehouse.AddColumns(context.Background(), "payment_events", []outbound.WarehouseColumn{{Name: "recorded_at", Type: outbound.WarehouseTimestamp}})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "schema must be patched", method, http.MethodPatch)
+	fields := patched["schema"].(map[string]any)["fields"].([]any)
+	assert.That(t, "existing and new fields must be sent", len(fields), 3)
+	assert.That(t, "new field must be nullable timestamp", fields[2], any(map[string]any{"name": "recorded_at", "type": "TIMESTAMP", "mode": "NULLABLE"}))
+	assert.That(t, "token must be fetched once", *tokens, 1)
+}
+
+func Test_BigQueryWarehouse_Insert_Should_Use_Key_As_InsertID(t *testing.T) {
+	// Arrange
+	var path string
+	var request struct {
+		Rows []struct {
+			InsertID string         `json:"insertId"`
+			JSON     map[string]any `json:"json"`
+		} `json:"rows"`
+	}
+	srv, _ := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = io.WriteString(w, `{"kind":"bigquery#tableDataInsertAllResponse"}`)
+	})
+	warehouse, _ := outbound.NewBigQueryWarehouse(srv.Client(), srv.URL, srv.URL+"/token", "hotel", "analytics")
+
+	// Act
+	err := warehouse.Insert(context.Background(), "payment_events", []outbound.WarehouseRow{{Key: "k1", Values: map[string]any{"payment_id": "pay-1"}}})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "path must be insertAll", path, "/bigquery/v2/projects/hotel/datasets/analytics/tables/payment_events/insertAll")
+	assert.That(t, "insert id must be the key", request.Rows[0].InsertID, "k1")
+	assert.That(t, "key column must be set", request.Rows[0].JSON["_key"], any("k1"))
+}
+
+func Test_BigQueryWarehouse_Insert_With_Insert_Errors_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv, _ := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"insertErrors":[{"index":0,"errors":[{"reason":"invalid","message":"no such field: note"}]}]}`)
+	})
+	warehouse, _ := outbound.NewBigQueryWarehouse(srv.Client(), srv.URL, srv.URL+"/token", "hotel", "analytics")
+
+	// Act
+	err := warehouse.Insert(context.Background(), "payment_events", []outbound.WarehouseRow{{Key: "k1"}})
+
+	// Assert
+	assert.That(t, "err must name the reason", err != nil && strings.Contains(err.Error(), "no such field: note"), true)
+}
@@ -0,0 +1,100 @@
+package outbound
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidBlobToken is returned if a download token is malformed, tampered with or expired.
+var ErrInvalidBlobToken = errors.New("invalid or expired download link")
+
+// BlobDownloads signs download tokens for blobs and resolves them.
+// A token carries the key and an expiry and is signed with HMAC-SHA256,
+// so download links work for every storage and need no server-side state.
+type BlobDownloads struct {
+	storage BlobStorage
+	secret  []byte
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewBlobDownloads creates a new download link signer for the storage.
+func NewBlobDownloads(storage BlobStorage, secret string, ttl time.Duration) *BlobDownloads {
+	return &BlobDownloads{
+		storage: storage,
+		secret:  []byte(secret),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Sign returns a URL-safe token granting the download of the blob until the TTL expires.
+func (d *BlobDownloads) Sign(key string) string {
+	payload := key + "\n" + strconv.FormatInt(d.now().Add(d.ttl).Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(d.mac(payload))
+}
+
+// Open returns the key and content of the blob of a valid, unexpired token.
+func (d *BlobDownloads) Open(ctx context.Context, token string) (string, []byte, error) {
+	key, err := d.verify(token)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := d.storage.Get(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, data, nil
+}
+
+// Keys returns the keys of the blobs below the prefix.
+func (d *BlobDownloads) Keys(ctx context.Context, prefix string) ([]string, error) {
+	blobs, err := d.storage.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		keys = append(keys, blob.Key)
+	}
+	return keys, nil
+}
+
+// verify returns the key of a valid, unexpired token.
+func (d *BlobDownloads) verify(token string) (string, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidBlobToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", ErrInvalidBlobToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, d.mac(string(payload))) {
+		return "", ErrInvalidBlobToken
+	}
+
+	key, expiresField, ok := strings.Cut(string(payload), "\n")
+	if !ok {
+		return "", ErrInvalidBlobToken
+	}
+	expires, err := strconv.ParseInt(expiresField, 10, 64)
+	if err != nil || d.now().Unix() > expires {
+		return "", ErrInvalidBlobToken
+	}
+	return key, nil
+}
+
+// mac returns the HMAC-SHA256 of the payload.
+func (d *BlobDownloads) mac(payload string) []byte {
+	h := hmac.New(sha256.New, d.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
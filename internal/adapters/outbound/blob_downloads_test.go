@@ -0,0 +1,54 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// BlobDownloads Tests
+// ============================================================================
+
+func Test_BlobDownloads_Open_Should_Return_Signed_Blob(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+	_ = storage.Put(context.Background(), "profiles/cpu.pprof", []byte("cpu"))
+	downloads := outbound.NewBlobDownloads(storage, "secret", time.Minute)
+
+	// Act
+	key, data, err := downloads.Open(context.Background(), downloads.Sign("profiles/cpu.pprof"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "key must match", key, "profiles/cpu.pprof")
+	assert.That(t, "data must match", string(data), "cpu")
+}
+
+func Test_BlobDownloads_Open_With_Foreign_Secret_Should_Fail(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+	token := outbound.NewBlobDownloads(storage, "other", time.Minute).Sign("profiles/cpu.pprof")
+	downloads := outbound.NewBlobDownloads(storage, "secret", time.Minute)
+
+	// Act
+	_, _, err := downloads.Open(context.Background(), token)
+
+	// Assert
+	assert.That(t, "error must be invalid token", err, outbound.ErrInvalidBlobToken)
+}
+
+func Test_BlobDownloads_Open_Expired_Link_Should_Fail(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+	downloads := outbound.NewBlobDownloads(storage, "secret", -time.Minute)
+
+	// Act
+	_, _, err := downloads.Open(context.Background(), downloads.Sign("profiles/cpu.pprof"))
+
+	// Assert
+	assert.That(t, "error must be invalid token", err, outbound.ErrInvalidBlobToken)
+}
@@ -0,0 +1,87 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// BlobRetention deletes blobs older than the maximum age of their key prefix,
+// e.g. profiles after a week. Blobs without a matching rule are kept.
+type BlobRetention struct {
+	storage BlobStorage
+	rules   map[string]time.Duration
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewBlobRetention creates a new retention policy for the storage.
+func NewBlobRetention(storage BlobStorage, rules map[string]time.Duration, logger *slog.Logger) *BlobRetention {
+	return &BlobRetention{storage: storage, rules: rules, logger: logger, now: time.Now}
+}
+
+// ParseBlobRetention parses rules in the format "prefix=maxAge,..." (e.g. "profiles/=168h").
+func ParseBlobRetention(s string) (map[string]time.Duration, error) {
+	rules := make(map[string]time.Duration)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		prefix, value, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(prefix) == "" {
+			return nil, fmt.Errorf("invalid retention rule: %q", part)
+		}
+		maxAge, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || maxAge <= 0 {
+			return nil, fmt.Errorf("invalid retention for %s: %q", prefix, value)
+		}
+		rules[strings.TrimSpace(prefix)] = maxAge
+	}
+	return rules, nil
+}
+
+// Start prunes immediately and then periodically until the context is done.
+func (r *BlobRetention) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := r.Prune(ctx); err != nil {
+				r.logger.Warn("blob retention failed", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Prune deletes the expired blobs of every rule and returns how many were deleted.
+func (r *BlobRetention) Prune(ctx context.Context) (int, error) {
+	deleted := 0
+	for prefix, maxAge := range r.rules {
+		blobs, err := r.storage.List(ctx, prefix)
+		if err != nil {
+			return deleted, err
+		}
+		cutoff := r.now().Add(-maxAge)
+		for _, blob := range blobs {
+			if !blob.ModifiedAt.Before(cutoff) {
+				continue
+			}
+			if err := r.storage.Delete(ctx, blob.Key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		r.logger.Info("expired blobs deleted", "count", deleted)
+	}
+	return deleted, nil
+}
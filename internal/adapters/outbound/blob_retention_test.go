@@ -0,0 +1,48 @@
+package outbound_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// BlobRetention Tests
+// ============================================================================
+
+func Test_BlobRetention_Prune_Should_Delete_Expired_Blobs_Of_Prefix(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	storage := outbound.NewLocalBlobStorage(dir)
+	ctx := context.Background()
+	_ = storage.Put(ctx, "profiles/old.pprof", []byte("old"))
+	_ = storage.Put(ctx, "profiles/new.pprof", []byte("new"))
+	_ = storage.Put(ctx, "invoices/old.pdf", []byte("pdf"))
+	lastWeek := time.Now().Add(-8 * 24 * time.Hour)
+	_ = os.Chtimes(filepath.Join(dir, "profiles", "old.pprof"), lastWeek, lastWeek)
+	_ = os.Chtimes(filepath.Join(dir, "invoices", "old.pdf"), lastWeek, lastWeek)
+	retention := outbound.NewBlobRetention(storage, map[string]time.Duration{"profiles/": 7 * 24 * time.Hour}, slog.Default())
+
+	// Act
+	deleted, err := retention.Prune(ctx)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one blob must be deleted", deleted, 1)
+	blobs, _ := storage.List(ctx, "")
+	assert.That(t, "other blobs must be kept", len(blobs), 2)
+}
+
+func Test_ParseBlobRetention_With_Invalid_Duration_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.ParseBlobRetention("profiles/=week")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
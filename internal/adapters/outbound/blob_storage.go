@@ -0,0 +1,141 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrBlobNotFound is returned if no blob is stored under the key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrInvalidBlobKey is returned for keys that are empty or leave the storage, e.g. "../x".
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// BlobInfo describes a stored blob.
+type BlobInfo struct {
+	Key        string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+// BlobStorage stores generated files like invoices, exports and profiles.
+// Keys are slash-separated paths relative to the storage, e.g. "profiles/cpu-20250101T000000Z.pprof".
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]BlobInfo, error)
+}
+
+// ValidateBlobKey checks that the key is a relative slash-separated path within the storage.
+func ValidateBlobKey(key string) error {
+	if key == "" || strings.Contains(key, `\`) || !filepath.IsLocal(filepath.FromSlash(key)) || path.Clean(key) != key {
+		return fmt.Errorf("%w: %q", ErrInvalidBlobKey, key)
+	}
+	return nil
+}
+
+// LocalBlobStorage implements BlobStorage with files below a directory.
+type LocalBlobStorage struct {
+	dir string
+}
+
+// NewLocalBlobStorage creates a new local disk blob storage.
+func NewLocalBlobStorage(dir string) *LocalBlobStorage {
+	return &LocalBlobStorage{dir: dir}
+}
+
+// Put writes the blob, creating the directories of the key if necessary.
+func (s *LocalBlobStorage) Put(ctx context.Context, key string, data []byte) error {
+	if err := ValidateBlobKey(key); err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return os.WriteFile(name, data, 0o600)
+}
+
+// Get reads the blob.
+func (s *LocalBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ValidateBlobKey(key); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return data, err
+}
+
+// Delete removes the blob; deleting a missing blob is no error.
+func (s *LocalBlobStorage) Delete(ctx context.Context, key string) error {
+	if err := ValidateBlobKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns the blobs whose key starts with the prefix, sorted by key.
+func (s *LocalBlobStorage) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	var blobs []BlobInfo
+	err := filepath.WalkDir(s.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, BlobInfo{Key: key, Size: info.Size(), ModifiedAt: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+	slices.SortFunc(blobs, func(a, b BlobInfo) int { return strings.Compare(a.Key, b.Key) })
+	return blobs, nil
+}
+
+// BlobProfileStore implements ProfileStore by saving profiles to a blob storage below a prefix.
+type BlobProfileStore struct {
+	storage BlobStorage
+	prefix  string
+}
+
+// NewBlobProfileStore creates a new profile store, e.g. with the prefix "profiles".
+func NewBlobProfileStore(storage BlobStorage, prefix string) *BlobProfileStore {
+	return &BlobProfileStore{storage: storage, prefix: strings.Trim(prefix, "/")}
+}
+
+// Save stores the profile as blob "<prefix>/<name>".
+func (s *BlobProfileStore) Save(ctx context.Context, name string, data []byte) error {
+	return s.storage.Put(ctx, path.Join(s.prefix, name), data)
+}
@@ -0,0 +1,74 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// LocalBlobStorage Tests
+// ============================================================================
+
+func Test_LocalBlobStorage_Put_Should_Create_Directories(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	storage := outbound.NewLocalBlobStorage(dir)
+
+	// Act
+	err := storage.Put(context.Background(), "exports/2025/guests.csv", []byte("data"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	data, _ := os.ReadFile(filepath.Join(dir, "exports", "2025", "guests.csv"))
+	assert.That(t, "data must match", string(data), "data")
+}
+
+func Test_LocalBlobStorage_Put_Outside_Directory_Should_Fail(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+
+	// Act
+	err := storage.Put(context.Background(), "../escape.txt", []byte("data"))
+
+	// Assert
+	assert.That(t, "error must be invalid key", errors.Is(err, outbound.ErrInvalidBlobKey), true)
+}
+
+func Test_LocalBlobStorage_List_Should_Filter_By_Prefix(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+	ctx := context.Background()
+	_ = storage.Put(ctx, "profiles/heap.pprof", []byte("heap"))
+	_ = storage.Put(ctx, "profiles/cpu.pprof", []byte("cpu"))
+	_ = storage.Put(ctx, "exports/guests.csv", []byte("csv"))
+
+	// Act
+	blobs, err := storage.List(ctx, "profiles/")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "two blobs must be listed", len(blobs), 2)
+	assert.That(t, "blobs must be sorted", blobs[0].Key, "profiles/cpu.pprof")
+	assert.That(t, "size must be set", blobs[0].Size, int64(3))
+}
+
+func Test_LocalBlobStorage_Get_Deleted_Blob_Should_Return_Not_Found(t *testing.T) {
+	// Arrange
+	storage := outbound.NewLocalBlobStorage(t.TempDir())
+	ctx := context.Background()
+	_ = storage.Put(ctx, "invoices/inv-1.pdf", []byte("pdf"))
+
+	// Act
+	err := storage.Delete(ctx, "invoices/inv-1.pdf")
+	_, getErr := storage.Get(ctx, "invoices/inv-1.pdf")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "blob must be gone", getErr, outbound.ErrBlobNotFound)
+}
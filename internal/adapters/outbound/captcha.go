@@ -0,0 +1,117 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrCaptchaFailed is returned if the CAPTCHA answer of a form is missing or was rejected by the provider.
+var ErrCaptchaFailed = errors.New("captcha verification failed")
+
+// captchaProvider describes the widget and the siteverify endpoint of a CAPTCHA provider.
+type captchaProvider struct {
+	scriptURL string // script that renders the widget
+	class     string // CSS class of the element the script turns into the widget
+	field     string // form field the widget adds the answer to
+	verifyURL string // siteverify endpoint
+}
+
+// captchaProviders are the supported providers; both answer the same siteverify protocol.
+var captchaProviders = map[string]captchaProvider{
+	"turnstile": {
+		scriptURL: "https://challenges.cloudflare.com/turnstile/v0/api.js",
+		class:     "cf-turnstile",
+		field:     "cf-turnstile-response",
+		verifyURL: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	},
+	"hcaptcha": {
+		scriptURL: "https://js.hcaptcha.com/1/api.js",
+		class:     "h-captcha",
+		field:     "h-captcha-response",
+		verifyURL: "https://api.hcaptcha.com/siteverify",
+	},
+}
+
+// SiteverifyCaptcha verifies the answers of Cloudflare Turnstile or hCaptcha widgets.
+type SiteverifyCaptcha struct {
+	client    *http.Client
+	provider  captchaProvider
+	verifyURL string
+	siteKey   string
+	secret    string
+}
+
+// NewSiteverifyCaptcha creates a new CAPTCHA verifier for "turnstile" or "hcaptcha", e.g. with the
+// "captcha" client of HTTPClients. An empty verifyURL uses the siteverify endpoint of the provider.
+func NewSiteverifyCaptcha(client *http.Client, provider, verifyURL, siteKey, secret string) (*SiteverifyCaptcha, error) {
+	p, ok := captchaProviders[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider: %q", provider)
+	}
+	if siteKey == "" || secret == "" {
+		return nil, errors.New("captcha site key and secret required")
+	}
+	if verifyURL == "" {
+		verifyURL = p.verifyURL
+	}
+	return &SiteverifyCaptcha{
+		client:    client,
+		provider:  p,
+		verifyURL: verifyURL,
+		siteKey:   siteKey,
+		secret:    secret,
+	}, nil
+}
+
+// Widget returns the script, the CSS class of the widget element and the public site key for the form.
+func (c *SiteverifyCaptcha) Widget() (scriptURL, class, siteKey string) {
+	return c.provider.scriptURL, c.provider.class, c.siteKey
+}
+
+// siteverifyResponse is the response body of the siteverify endpoint.
+type siteverifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify checks the answer the widget added to the form with the provider.
+// A missing or rejected answer wraps ErrCaptchaFailed; other errors mean the provider could not be asked.
+func (c *SiteverifyCaptcha) Verify(ctx context.Context, form url.Values, remoteIP string) error {
+	answer := form.Get(c.provider.field)
+	if answer == "" {
+		return fmt.Errorf("%w: no answer", ErrCaptchaFailed)
+	}
+	body := url.Values{"secret": {c.secret}, "response": {answer}}
+	if remoteIP != "" {
+		body.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(body.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create siteverify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("siteverify returned %d", resp.StatusCode)
+	}
+
+	var result siteverifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode siteverify response: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", ErrCaptchaFailed, strings.Join(result.ErrorCodes, ","))
+	}
+	return nil
+}
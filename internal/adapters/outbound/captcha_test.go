@@ -0,0 +1,85 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// SiteverifyCaptcha Tests
+// ============================================================================
+
+func Test_NewSiteverifyCaptcha_With_Unknown_Provider_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewSiteverifyCaptcha(http.DefaultClient, "recaptcha", "", "site-key", "secret")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_SiteverifyCaptcha_Widget_Should_Return_Provider_Widget(t *testing.T) {
+	// Arrange
+	captcha, _ := outbound.NewSiteverifyCaptcha(http.DefaultClient, "hcaptcha", "", "site-key", "secret")
+
+	// Act
+	scriptURL, class, siteKey := captcha.Widget()
+
+	// Assert
+	assert.That(t, "script must be the hCaptcha script", scriptURL, "https://js.hcaptcha.com/1/api.js")
+	assert.That(t, "class must be the hCaptcha class", class, "h-captcha")
+	assert.That(t, "site key must be set", siteKey, "site-key")
+}
+
+func Test_SiteverifyCaptcha_Verify_Should_Post_Answer_With_Secret(t *testing.T) {
+	// Arrange
+	var received url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		received = r.PostForm
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer srv.Close()
+	captcha, _ := outbound.NewSiteverifyCaptcha(srv.Client(), "turnstile", srv.URL, "site-key", "secret")
+
+	// Act
+	err := captcha.Verify(context.Background(), url.Values{"cf-turnstile-response": {"answer"}}, "203.0.113.7")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "secret must be sent", received.Get("secret"), "secret")
+	assert.That(t, "answer must be sent", received.Get("response"), "answer")
+	assert.That(t, "remote ip must be sent", received.Get("remoteip"), "203.0.113.7")
+}
+
+func Test_SiteverifyCaptcha_Verify_With_Rejected_Answer_Should_Return_ErrCaptchaFailed(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer srv.Close()
+	captcha, _ := outbound.NewSiteverifyCaptcha(srv.Client(), "turnstile", srv.URL, "site-key", "secret")
+
+	// Act
+	err := captcha.Verify(context.Background(), url.Values{"cf-turnstile-response": {"answer"}}, "")
+
+	// Assert
+	assert.That(t, "err must be ErrCaptchaFailed", errors.Is(err, outbound.ErrCaptchaFailed), true)
+}
+
+func Test_SiteverifyCaptcha_Verify_Without_Answer_Should_Return_ErrCaptchaFailed(t *testing.T) {
+	// Arrange
+	captcha, _ := outbound.NewSiteverifyCaptcha(http.DefaultClient, "turnstile", "http://127.0.0.1:0", "site-key", "secret")
+
+	// Act
+	err := captcha.Verify(context.Background(), url.Values{}, "")
+
+	// Assert
+	assert.That(t, "err must be ErrCaptchaFailed", errors.Is(err, outbound.ErrCaptchaFailed), true)
+}
@@ -0,0 +1,195 @@
+package outbound
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// clickHouseTypes maps the column types to ClickHouse types.
+var clickHouseTypes = map[WarehouseColumnType]string{
+	WarehouseString:    "String",
+	WarehouseInt:       "Int64",
+	WarehouseFloat:     "Float64",
+	WarehouseBool:      "Bool",
+	WarehouseTimestamp: "DateTime64(3, 'UTC')",
+}
+
+// ClickHouseWarehouse implements Warehouse with the ClickHouse HTTP interface.
+// Tables use the ReplacingMergeTree engine ordered by the key column, so rows written twice
+// (e.g. events redelivered after a restart) are merged in the background; query with FINAL for exact counts.
+type ClickHouseWarehouse struct {
+	client   *http.Client
+	url      string
+	database string
+	username string
+	password string
+}
+
+// NewClickHouseWarehouse creates a new ClickHouse warehouse, e.g. with the "warehouse" client of HTTPClients.
+// The url is the HTTP interface, e.g. http://clickhouse:8123.
+func NewClickHouseWarehouse(client *http.Client, rawURL, database, username, password string) (*ClickHouseWarehouse, error) {
+	if rawURL == "" {
+		return nil, errors.New("clickhouse url required")
+	}
+	if !validWarehouseIdentifier(database) {
+		return nil, fmt.Errorf("invalid clickhouse database %q", database)
+	}
+	return &ClickHouseWarehouse{
+		client:   client,
+		url:      strings.TrimSuffix(rawURL, "/"),
+		database: database,
+		username: username,
+		password: password,
+	}, nil
+}
+
+// Name returns "clickhouse".
+func (w *ClickHouseWarehouse) Name() string { return "clickhouse" }
+
+// Columns returns the columns of the table from system.columns.
+func (w *ClickHouseWarehouse) Columns(ctx context.Context, table string) ([]WarehouseColumn, error) {
+	params := url.Values{"param_database": {w.database}, "param_table": {table}}
+	body, err := w.query(ctx, params, "SELECT name, type FROM system.columns WHERE database = {database:String} AND table = {table:String} FORMAT JSONEachRow")
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []WarehouseColumn
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var column struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &column); err != nil {
+			return nil, fmt.Errorf("failed to decode clickhouse column: %w", err)
+		}
+		if column.Name == WarehouseKeyColumn {
+			continue
+		}
+		columns = append(columns, WarehouseColumn{Name: column.Name, Type: clickHouseColumnType(column.Type)})
+	}
+	return columns, scanner.Err()
+}
+
+// clickHouseColumnType returns the column type of a ClickHouse type; unknown types are read as strings.
+func clickHouseColumnType(typ string) WarehouseColumnType {
+	typ = strings.TrimSuffix(strings.TrimPrefix(typ, "Nullable("), ")")
+	switch {
+	case strings.HasPrefix(typ, "Int"), strings.HasPrefix(typ, "UInt"):
+		return WarehouseInt
+	case strings.HasPrefix(typ, "Float"), strings.HasPrefix(typ, "Decimal"):
+		return WarehouseFloat
+	case typ == "Bool":
+		return WarehouseBool
+	case strings.HasPrefix(typ, "DateTime"):
+		return WarehouseTimestamp
+	default:
+		return WarehouseString
+	}
+}
+
+// AddColumns creates the table if it does not exist and adds the nullable columns.
+func (w *ClickHouseWarehouse) AddColumns(ctx context.Context, table string, columns []WarehouseColumn) error {
+	if !validWarehouseIdentifier(table) {
+		return fmt.Errorf("invalid clickhouse table %q", table)
+	}
+	name := w.database + "." + table
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s String) ENGINE = ReplacingMergeTree ORDER BY %s", name, WarehouseKeyColumn, WarehouseKeyColumn)
+	if _, err := w.query(ctx, nil, create); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	adds := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !validWarehouseIdentifier(c.Name) {
+			return fmt.Errorf("invalid clickhouse column %q", c.Name)
+		}
+		adds = append(adds, fmt.Sprintf("ADD COLUMN IF NOT EXISTS %s Nullable(%s)", c.Name, clickHouseTypes[c.Type]))
+	}
+	_, err := w.query(ctx, nil, "ALTER TABLE "+name+" "+strings.Join(adds, ", "))
+	return err
+}
+
+// Insert writes the rows as JSONEachRow.
+func (w *ClickHouseWarehouse) Insert(ctx context.Context, table string, rows []WarehouseRow) error {
+	if !validWarehouseIdentifier(table) {
+		return fmt.Errorf("invalid clickhouse table %q", table)
+	}
+	var body bytes.Buffer
+	body.WriteString("INSERT INTO " + w.database + "." + table + " FORMAT JSONEachRow\n")
+	encoder := json.NewEncoder(&body)
+	for _, row := range rows {
+		values := make(map[string]any, len(row.Values)+1)
+		for name, value := range row.Values {
+			if t, ok := value.(time.Time); ok {
+				value = t.UTC().Format("2006-01-02 15:04:05.000")
+			}
+			values[name] = value
+		}
+		values[WarehouseKeyColumn] = row.Key
+		if err := encoder.Encode(values); err != nil {
+			return fmt.Errorf("failed to encode clickhouse row: %w", err)
+		}
+	}
+	_, err := w.post(ctx, nil, &body)
+	return err
+}
+
+// query runs a statement and returns the response body.
+func (w *ClickHouseWarehouse) query(ctx context.Context, params url.Values, statement string) ([]byte, error) {
+	return w.post(ctx, params, strings.NewReader(statement))
+}
+
+// post sends the statement in body to the HTTP interface.
+func (w *ClickHouseWarehouse) post(ctx context.Context, params url.Values, body io.Reader) ([]byte, error) {
+	target := w.url + "/"
+	if len(params) > 0 {
+		target += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clickhouse request: %w", err)
+	}
+	if w.username != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call clickhouse: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clickhouse response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("clickhouse returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data[:min(len(data), 4096)])))
+	}
+	return data, nil
+}
+
+// validWarehouseIdentifier reports whether s is a plain identifier that needs no quoting in SQL.
+func validWarehouseIdentifier(s string) bool {
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package outbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ClickHouseWarehouse Tests
+// ============================================================================
+
+func Test_NewClickHouseWarehouse_With_Invalid_Database_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewClickHouseWarehouse(http.DefaultClient, "http://clickhouse:8123", "analytics; DROP", "", "")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_ClickHouseWarehouse_Columns_Should_Map_Types_Without_Key(t *testing.T) {
+	// Arrange
+	var query, table string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		query, table = string(body), r.URL.Query().Get("param_table")
+		_, _ = io.WriteString(w, `{"name":"_key","type":"String"}`+"\n"+
+			`{"name":"amount","type":"Nullable(Int64)"}`+"\n"+
+			`{"name":"recorded_at","type":"Nullable(DateTime64(3, 'UTC'))"}`+"\n")
+	}))
+	defer srv.Close()
+	warehouse, _ := outbound.NewClickHouseWarehouse(srv.Client(), srv.URL, "analytics", "", "")
+
+	// Act
+	columns, err := warehouse.Columns(context.Background(), "payment_events")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "table must be a query parameter", table, "payment_events")
+	assert.That(t, "query must read system.columns", strings.Contains(query, "FROM system.columns"), true)
+	assert.That(t, "columns must be mapped", columns, []outbound.WarehouseColumn{
+		{Name: "amount", Type: outbound.WarehouseInt},
+		{Name: "recorded_at", Type: outbound.WarehouseTimestamp},
+	})
+}
+
+func Test_ClickHouseWarehouse_AddColumns_Should_Create_Table_And_Add_Nullable_Columns(t *testing.T) {
+	// Arrange
+	var statements []string
+	var user string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		statements = append(statements, string(body))
+		user, _, _ = r.BasicAuth()
+	}))
+	defer srv.Close()
+	warehouse, _ := outbound.NewClickHouseWarehouse(srv.Client(), srv.URL, "analytics", "sink", "secret")
+
+	// Act
+	err := warehouse.AddColumns(context.Background(), "payment_events", []outbound.WarehouseColumn{{Name: "error_code", Type: outbound.WarehouseString}})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "user must be sent", user, "sink")
+	assert.That(t, "two statements must be sent", len(statements), 2)
+	assert.That(t, "table must be replacing merge tree", strings.Contains(statements[0], "CREATE TABLE IF NOT EXISTS analytics.payment_events (_key String) ENGINE = ReplacingMergeTree"), true)
+	assert.That(t, "column must be added", statements[1], "ALTER TABLE analytics.payment_events ADD COLUMN IF NOT EXISTS error_code Nullable(String)")
+}
+
+func Test_ClickHouseWarehouse_Insert_Should_Post_JSONEachRow_With_Key(t *testing.T) {
+	// Arrange
+	var statement string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		statement = string(body)
+	}))
+	defer srv.Close()
+	warehouse, _ := outbound.NewClickHouseWarehouse(srv.Client(), srv.URL, "analytics", "", "")
+	rows := []outbound.WarehouseRow{{Key: "k1", Values: map[string]any{"recorded_at": time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)}}}
+
+	// Act
+	err := warehouse.Insert(context.Background(), "payment_events", rows)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "statement must insert json rows", statement, "INSERT INTO analytics.payment_events FORMAT JSONEachRow\n"+
+		`{"_key":"k1","recorded_at":"2030-06-01 15:00:00.000"}`+"\n")
+}
+
+func Test_ClickHouseWarehouse_Insert_With_Error_Status_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Code: 60. Table does not exist", http.StatusNotFound)
+	}))
+	defer srv.Close()
+	warehouse, _ := outbound.NewClickHouseWarehouse(srv.Client(), srv.URL, "analytics", "", "")
+
+	// Act
+	err := warehouse.Insert(context.Background(), "payment_events", []outbound.WarehouseRow{{Key: "k1"}})
+
+	// Assert
+	assert.That(t, "err must carry the message", err != nil && strings.Contains(err.Error(), "Table does not exist"), true)
+}
@@ -0,0 +1,122 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"golang.org/x/sync/singleflight"
+)
+
+// MetricAvailabilityQueries counts the availability queries, by kind and whether another caller's query served them.
+const MetricAvailabilityQueries = "hotel_availability_queries_total"
+
+// CoalescingAvailabilityChecker implements AvailabilityChecker by coalescing identical
+// concurrent queries into a single call to the wrapped checker.
+// When a popular room's calendar is open in many browsers, only one query per
+// (room, date range, current day) hits the database at a time.
+type CoalescingAvailabilityChecker struct {
+	next      reservation.AvailabilityChecker
+	logger    *slog.Logger
+	group     singleflight.Group
+	metrics   shared.Metrics
+	calls     atomic.Int64
+	coalesced atomic.Int64
+}
+
+// CoalescingStats contains the counters of a CoalescingAvailabilityChecker.
+type CoalescingStats struct {
+	Calls     int64 `json:"calls"`
+	Coalesced int64 `json:"coalesced"`
+}
+
+// NewCoalescingAvailabilityChecker creates a new coalescing decorator for an availability checker.
+// Every query is logged at debug level; configure sampling for the component, as these logs are high-volume.
+func NewCoalescingAvailabilityChecker(next reservation.AvailabilityChecker, logger *slog.Logger) *CoalescingAvailabilityChecker {
+	return &CoalescingAvailabilityChecker{
+		next:   next,
+		logger: logger,
+	}
+}
+
+// WithMetrics counts the queries, so the share of coalesced queries can be watched on /metrics.
+func (c *CoalescingAvailabilityChecker) WithMetrics(metrics shared.Metrics) *CoalescingAvailabilityChecker {
+	c.metrics = metrics
+	return c
+}
+
+// IsRoomAvailable checks if a room is available for the given date range.
+func (c *CoalescingAvailabilityChecker) IsRoomAvailable(
+	ctx context.Context,
+	roomID reservation.RoomID,
+	dateRange reservation.DateRange,
+) (bool, error) {
+	v, err := c.do("available", coalescingKey("available", roomID, dateRange), func() (any, error) {
+		// The shared call must not fail because the first caller went away.
+		return c.next.IsRoomAvailable(context.WithoutCancel(ctx), roomID, dateRange)
+	})
+	if err != nil {
+		return false, err
+	}
+	available, _ := v.(bool)
+	return available, nil
+}
+
+// GetOverlappingReservations returns all reservations that overlap with the given date range.
+// Callers receive a shared slice and must not modify the returned reservations.
+func (c *CoalescingAvailabilityChecker) GetOverlappingReservations(
+	ctx context.Context,
+	roomID reservation.RoomID,
+	dateRange reservation.DateRange,
+) ([]*reservation.Reservation, error) {
+	v, err := c.do("overlapping", coalescingKey("overlapping", roomID, dateRange), func() (any, error) {
+		return c.next.GetOverlappingReservations(context.WithoutCancel(ctx), roomID, dateRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	overlapping, _ := v.([]*reservation.Reservation)
+	return overlapping, nil
+}
+
+// Stats returns the number of calls and how many of them were served by another caller's query.
+func (c *CoalescingAvailabilityChecker) Stats() CoalescingStats {
+	return CoalescingStats{
+		Calls:     c.calls.Load(),
+		Coalesced: c.coalesced.Load(),
+	}
+}
+
+// do executes fn once per key for all concurrent callers and updates the counters.
+func (c *CoalescingAvailabilityChecker) do(kind, key string, fn func() (any, error)) (any, error) {
+	c.calls.Add(1)
+	// fn runs synchronously in the leader's goroutine, so only followers see executed == false.
+	executed := false
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		executed = true
+		return fn()
+	})
+	if !executed {
+		c.coalesced.Add(1)
+	}
+	if c.metrics != nil {
+		c.metrics.IncCounter(MetricAvailabilityQueries, "kind", kind, "coalesced", strconv.FormatBool(!executed))
+	}
+	c.logger.Debug("availability query", "key", key, "coalesced", !executed, "error", err)
+	return v, err
+}
+
+// coalescingKey builds the key for a query.
+// The current day is part of the key because availability rules depend on "today".
+func coalescingKey(kind string, roomID reservation.RoomID, dateRange reservation.DateRange) string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s",
+		kind, roomID,
+		dateRange.CheckIn.Unix(), dateRange.CheckOut.Unix(),
+		time.Now().UTC().Format("2006-01-02"),
+	)
+}
@@ -0,0 +1,132 @@
+package outbound_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// CoalescingAvailabilityChecker Tests
+// ============================================================================
+
+// blockingAvailabilityChecker blocks every call until release is closed and counts the calls.
+type blockingAvailabilityChecker struct {
+	release chan struct{}
+	calls   atomic.Int64
+	err     error
+}
+
+func (b *blockingAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	b.calls.Add(1)
+	<-b.release
+	return b.err == nil, b.err
+}
+
+func (b *blockingAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	b.calls.Add(1)
+	<-b.release
+	return []*reservation.Reservation{}, b.err
+}
+
+func Test_CoalescingAvailabilityChecker_IsRoomAvailable_Should_Delegate(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepo()
+	createTestReservationInRepo(repo, testResID001, "room-101", 7, 10)
+	checker := outbound.NewCoalescingAvailabilityChecker(outbound.NewRepositoryAvailabilityChecker(repo), slog.Default())
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 8), time.Now().AddDate(0, 0, 9))
+
+	// Act
+	available, err := checker.IsRoomAvailable(context.Background(), "room-101", dateRange)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "room must not be available", available, false)
+	assert.That(t, "calls must be 1", checker.Stats().Calls, int64(1))
+}
+
+func Test_CoalescingAvailabilityChecker_Concurrent_Identical_Queries_Should_Be_Coalesced(t *testing.T) {
+	// Arrange
+	inner := &blockingAvailabilityChecker{release: make(chan struct{})}
+	checker := outbound.NewCoalescingAvailabilityChecker(inner, slog.Default())
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	const callers = 10
+
+	// Act
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Go(func() {
+			_, _ = checker.IsRoomAvailable(context.Background(), "room-101", dateRange)
+		})
+	}
+	// Wait until all callers are registered before releasing the shared call.
+	for checker.Stats().Calls < callers {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	// Assert
+	assert.That(t, "inner checker must be called once", inner.calls.Load(), int64(1))
+	assert.That(t, "calls must be counted", checker.Stats().Calls, int64(callers))
+	assert.That(t, "coalesced must be counted", checker.Stats().Coalesced, int64(callers-1))
+}
+
+func Test_CoalescingAvailabilityChecker_Different_Rooms_Should_Not_Be_Coalesced(t *testing.T) {
+	// Arrange
+	inner := &blockingAvailabilityChecker{release: make(chan struct{})}
+	close(inner.release)
+	checker := outbound.NewCoalescingAvailabilityChecker(inner, slog.Default())
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+
+	// Act
+	_, _ = checker.GetOverlappingReservations(context.Background(), "room-101", dateRange)
+	_, _ = checker.GetOverlappingReservations(context.Background(), "room-102", dateRange)
+
+	// Assert
+	assert.That(t, "inner checker must be called twice", inner.calls.Load(), int64(2))
+	assert.That(t, "coalesced must be 0", checker.Stats().Coalesced, int64(0))
+}
+
+func Test_CoalescingAvailabilityChecker_With_Error_Should_Return_Error(t *testing.T) {
+	// Arrange
+	inner := &blockingAvailabilityChecker{release: make(chan struct{}), err: errors.New("database down")}
+	close(inner.release)
+	checker := outbound.NewCoalescingAvailabilityChecker(inner, slog.Default())
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+
+	// Act
+	available, err := checker.IsRoomAvailable(context.Background(), "room-101", dateRange)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "room must not be available", available, false)
+}
+
+func Test_CoalescingAvailabilityChecker_WithMetrics_Should_Count_Queries(t *testing.T) {
+	// Arrange
+	inner := &blockingAvailabilityChecker{release: make(chan struct{})}
+	close(inner.release)
+	metrics := outbound.NewMetrics()
+	checker := outbound.NewCoalescingAvailabilityChecker(inner, slog.Default()).WithMetrics(metrics)
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+
+	// Act
+	_, _ = checker.IsRoomAvailable(context.Background(), "room-101", dateRange)
+	var buf bytes.Buffer
+	_ = metrics.WritePrometheus(&buf)
+
+	// Assert
+	assert.That(t, "query must be counted", strings.Contains(buf.String(), `hotel_availability_queries_total{kind="available",coalesced="false"} 1`), true)
+}
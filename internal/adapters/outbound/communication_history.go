@@ -0,0 +1,143 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// CommunicationHistory records every outbound message sent to a guest with its delivery status.
+// It listens to the email queue via EmailQueue.OnStatus and keeps the messages after the queue removed them;
+// push notifications are recorded by the PushNotificationService together with the engagement devices report.
+type CommunicationHistory struct {
+	repo   resource.Access[string, shared.Communication]
+	outbox EmailOutbox
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewCommunicationHistory creates a new communication history; resent emails are enqueued in the outbox.
+func NewCommunicationHistory(repo resource.Access[string, shared.Communication], outbox EmailOutbox, logger *slog.Logger) *CommunicationHistory {
+	return &CommunicationHistory{
+		repo:   repo,
+		outbox: outbox,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Record stores the current status of the email. Its signature matches EmailQueue.OnStatus.
+func (h *CommunicationHistory) Record(ctx context.Context, email Email) {
+	c := shared.Communication{
+		ID:            email.ID,
+		Channel:       "email",
+		Template:      email.Template,
+		Recipient:     email.To,
+		Subject:       email.Subject,
+		Body:          email.Body,
+		HTMLBody:      email.HTMLBody,
+		GuestID:       email.GuestID,
+		ReservationID: email.ReservationID,
+		Status:        string(email.Status),
+		Attempts:      email.Attempts,
+		Error:         email.LastError,
+		ResendOf:      email.ResendOf,
+		QueuedAt:      email.QueuedAt,
+		SentAt:        email.SentAt,
+	}
+	h.RecordMessage(ctx, c)
+}
+
+// RecordMessage stores the current status of a message sent on another channel than email, e.g. push.
+func (h *CommunicationHistory) RecordMessage(ctx context.Context, c shared.Communication) {
+	c.UpdatedAt = h.now()
+	var err error
+	if _, readErr := h.repo.Read(ctx, c.ID); readErr != nil {
+		err = h.repo.Create(ctx, c.ID, c)
+	} else {
+		err = h.repo.Update(ctx, c.ID, c)
+	}
+	if err != nil {
+		h.logger.Warn("failed to record communication", "communication_id", c.ID, "error", err)
+	}
+}
+
+// RecordEngagement records that the device showed or the guest opened the push notification.
+func (h *CommunicationHistory) RecordEngagement(ctx context.Context, id, engagement string) error {
+	c, err := h.repo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", shared.ErrCommunicationNotFound, err)
+	}
+	if err := c.Engage(engagement, h.now()); err != nil {
+		return err
+	}
+	c.UpdatedAt = h.now()
+	if err := h.repo.Update(ctx, id, *c); err != nil {
+		return fmt.Errorf("failed to record engagement: %w", err)
+	}
+	return nil
+}
+
+// ForReservation returns the messages linked to the reservation, newest first.
+func (h *CommunicationHistory) ForReservation(ctx context.Context, reservationID string) ([]shared.Communication, error) {
+	return h.filter(ctx, func(c shared.Communication) bool { return c.ReservationID == reservationID })
+}
+
+// ForGuest returns the messages sent to the guest, newest first.
+func (h *CommunicationHistory) ForGuest(ctx context.Context, guestID string) ([]shared.Communication, error) {
+	return h.filter(ctx, func(c shared.Communication) bool { return c.GuestID == guestID })
+}
+
+// Resend queues a failed message again as a new transactional email linked to the original.
+// Staff resends bypass the backlog limit for lifecycle and marketing emails.
+func (h *CommunicationHistory) Resend(ctx context.Context, id string) (shared.Communication, error) {
+	original, err := h.repo.Read(ctx, id)
+	if err != nil {
+		return shared.Communication{}, fmt.Errorf("%w: %w", shared.ErrCommunicationNotFound, err)
+	}
+	if !original.Resendable() {
+		return shared.Communication{}, shared.ErrCommunicationNotResendable
+	}
+	email := Email{
+		ID:            security.GenerateID(),
+		To:            original.Recipient,
+		Subject:       original.Subject,
+		Body:          original.Body,
+		HTMLBody:      original.HTMLBody,
+		Template:      original.Template,
+		Priority:      EmailTransactional,
+		GuestID:       original.GuestID,
+		ReservationID: original.ReservationID,
+		ResendOf:      original.ID,
+	}
+	if err := h.outbox.Enqueue(ctx, email); err != nil {
+		return shared.Communication{}, fmt.Errorf("failed to resend communication: %w", err)
+	}
+	// The queue records the resent email via Record; without a listener only the link is known.
+	if resent, err := h.repo.Read(ctx, email.ID); err == nil {
+		return *resent, nil
+	}
+	return shared.Communication{ID: email.ID, Channel: "email", GuestID: email.GuestID, ReservationID: email.ReservationID, Status: string(EmailQueued), ResendOf: original.ID}, nil
+}
+
+// filter returns the matching messages, newest first.
+func (h *CommunicationHistory) filter(ctx context.Context, match func(shared.Communication) bool) ([]shared.Communication, error) {
+	all, err := h.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read communications: %w", err)
+	}
+	var matched []shared.Communication
+	for _, c := range all {
+		if match(c) {
+			matched = append(matched, c)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].QueuedAt.After(matched[j].QueuedAt) })
+	return matched, nil
+}
@@ -0,0 +1,82 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestCommunicationHistory returns a history recording the status changes of a queue with one attempt per email.
+func createTestCommunicationHistory(provider outbound.EmailProvider) (*outbound.CommunicationHistory, *outbound.EmailQueue) {
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, testEmailQueueConfig())
+	history := outbound.NewCommunicationHistory(resource.NewInMemoryAccess[string, shared.Communication](), queue, slog.Default())
+	queue.OnStatus(history.Record)
+	return history, queue
+}
+
+// ============================================================================
+// CommunicationHistory Tests
+// ============================================================================
+
+func Test_CommunicationHistory_Should_Record_Delivery_Status(t *testing.T) {
+	// Arrange
+	history, queue := createTestCommunicationHistory(&recordingEmailProvider{})
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Template: "reservation_confirmation", GuestID: "guest-1", ReservationID: "res-1"})
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Template: "household_invitation", GuestID: "guest-1"})
+
+	// Act
+	_, _ = queue.SendNext(ctx)
+	byReservation, err := history.ForReservation(ctx, "res-1")
+	byGuest, _ := history.ForGuest(ctx, "guest-1")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "reservation must have one message", len(byReservation), 1)
+	assert.That(t, "message must be sent", byReservation[0].Status, "sent")
+	assert.That(t, "sent time must be recorded", byReservation[0].SentAt.IsZero(), false)
+	assert.That(t, "guest must have both messages", len(byGuest), 2)
+}
+
+func Test_CommunicationHistory_Resend_Should_Queue_Failed_Message_Again(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{err: errors.New("mailbox unavailable")}
+	history, queue := createTestCommunicationHistory(provider)
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{ID: "e-1", To: "a@example.com", Template: "cancellation_notice", ReservationID: "res-1"})
+	_, _ = queue.SendNext(ctx)
+
+	// Act
+	resent, err := history.Resend(ctx, "e-1")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "resend must link the original", resent.ResendOf, "e-1")
+	assert.That(t, "resend must be queued", resent.Status, "queued")
+	messages, _ := history.ForReservation(ctx, "res-1")
+	assert.That(t, "both messages must be listed", len(messages), 2)
+}
+
+func Test_CommunicationHistory_Resend_With_Sent_Message_Should_Return_Error(t *testing.T) {
+	// Arrange
+	history, queue := createTestCommunicationHistory(&recordingEmailProvider{})
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{ID: "e-1", To: "a@example.com"})
+	_, _ = queue.SendNext(ctx)
+
+	// Act
+	_, err := history.Resend(ctx, "e-1")
+
+	// Assert
+	assert.That(t, "err must be ErrCommunicationNotResendable", errors.Is(err, shared.ErrCommunicationNotResendable), true)
+}
@@ -0,0 +1,59 @@
+package outbound_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Config File Tests
+// ============================================================================
+
+func Test_ParseConfigFile_Should_Parse_Env_File_Format(t *testing.T) {
+	// Arrange
+	content := `# VIP tiers
+VIP_GOLD_STAYS="3"
+export LOGGING_LEVEL=DEBUG
+
+STAFF_ROLES='front_desk=reservations:read'
+`
+
+	// Act
+	values, err := outbound.ParseConfigFile(content)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "values must be parsed", values, map[string]string{
+		"VIP_GOLD_STAYS": "3",
+		"LOGGING_LEVEL":  "DEBUG",
+		"STAFF_ROLES":    "front_desk=reservations:read",
+	})
+}
+
+func Test_ParseConfigFile_With_Invalid_Line_Should_Return_Error(t *testing.T) {
+	// Arrange
+	content := "VIP_GOLD_STAYS=3\nnot a setting\n"
+
+	// Act
+	_, err := outbound.ParseConfigFile(content)
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_ReadConfigFile_Should_Read_File(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.env")
+	_ = os.WriteFile(path, []byte(`REFERRAL_MONTHLY_LIMIT="2"`), 0o600)
+
+	// Act
+	values, err := outbound.ReadConfigFile(path)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "limit must be read", values["REFERRAL_MONTHLY_LIMIT"], "2")
+}
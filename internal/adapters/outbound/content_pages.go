@@ -0,0 +1,104 @@
+package outbound
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ErrContentPageNotFound is returned if no layer contains the requested content page.
+var ErrContentPageNotFound = errors.New("content page not found")
+
+// contentPageSlug restricts slugs to file-name-safe characters, so a slug can never escape the content directory.
+var contentPageSlug = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// contentPageEntry is a rendered content page.
+type contentPageEntry struct {
+	title    string
+	markdown string
+	html     string
+	layer    int
+	modTime  time.Time
+}
+
+// ContentPages provides the guest-facing content pages (FAQ, policies, directions)
+// written in markdown as <slug>.md files.
+// Pages are looked up in the layers from last to first, so a deployment can override
+// single pages of the embedded defaults with its own directory.
+// Rendered pages are cached and rendered again when the file changes.
+type ContentPages struct {
+	layers []fs.FS
+
+	mu    sync.Mutex
+	cache map[string]contentPageEntry
+}
+
+// NewContentPages creates the content pages from the given layers.
+// Later layers override earlier ones.
+func NewContentPages(layers ...fs.FS) *ContentPages {
+	return &ContentPages{
+		layers: layers,
+		cache:  make(map[string]contentPageEntry),
+	}
+}
+
+// RenderPage returns the title and the rendered HTML of the page.
+func (c *ContentPages) RenderPage(slug string) (string, string, error) {
+	entry, err := c.page(slug)
+	if err != nil {
+		return "", "", err
+	}
+	return entry.title, entry.html, nil
+}
+
+// Markdown returns the markdown source of the page.
+func (c *ContentPages) Markdown(slug string) (string, error) {
+	entry, err := c.page(slug)
+	if err != nil {
+		return "", err
+	}
+	return entry.markdown, nil
+}
+
+// page returns the cached page, or reads and renders it if it changed.
+func (c *ContentPages) page(slug string) (contentPageEntry, error) {
+	if !contentPageSlug.MatchString(slug) {
+		return contentPageEntry{}, ErrContentPageNotFound
+	}
+	name := slug + ".md"
+
+	for layer := len(c.layers) - 1; layer >= 0; layer-- {
+		info, err := fs.Stat(c.layers[layer], name)
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		entry, cached := c.cache[slug]
+		c.mu.Unlock()
+		if cached && entry.layer == layer && entry.modTime.Equal(info.ModTime()) {
+			return entry, nil
+		}
+
+		data, err := fs.ReadFile(c.layers[layer], name)
+		if err != nil {
+			return contentPageEntry{}, err
+		}
+		src := string(data)
+		entry = contentPageEntry{
+			title:    MarkdownTitle(src),
+			markdown: src,
+			html:     RenderMarkdown(src),
+			layer:    layer,
+			modTime:  info.ModTime(),
+		}
+		c.mu.Lock()
+		c.cache[slug] = entry
+		c.mu.Unlock()
+		return entry, nil
+	}
+
+	return contentPageEntry{}, ErrContentPageNotFound
+}
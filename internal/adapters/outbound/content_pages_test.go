@@ -0,0 +1,109 @@
+package outbound_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ContentPages Tests
+// ============================================================================
+
+func Test_ContentPages_RenderPage_Should_Return_Title_And_HTML(t *testing.T) {
+	// Arrange
+	pages := outbound.NewContentPages(fstest.MapFS{
+		"faq.md": {Data: []byte("# FAQ\n\nHello.")},
+	})
+
+	// Act
+	title, html, err := pages.RenderPage("faq")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "title must match", title, "FAQ")
+	assert.That(t, "html must match", html, "<h1>FAQ</h1>\n<p>Hello.</p>\n")
+}
+
+func Test_ContentPages_Should_Prefer_Later_Layer(t *testing.T) {
+	// Arrange
+	defaults := fstest.MapFS{
+		"faq.md":      {Data: []byte("# Default FAQ")},
+		"policies.md": {Data: []byte("# Default Policies")},
+	}
+	overrides := fstest.MapFS{
+		"faq.md": {Data: []byte("# Our FAQ")},
+	}
+	pages := outbound.NewContentPages(defaults, overrides)
+
+	// Act
+	faq, _, _ := pages.RenderPage("faq")
+	policies, _, _ := pages.RenderPage("policies")
+
+	// Assert
+	assert.That(t, "faq must be overridden", faq, "Our FAQ")
+	assert.That(t, "policies must fall back to default", policies, "Default Policies")
+}
+
+func Test_ContentPages_Should_Render_Again_When_File_Changed(t *testing.T) {
+	// Arrange
+	files := fstest.MapFS{
+		"faq.md": {Data: []byte("# Old"), ModTime: time.Unix(1, 0)},
+	}
+	pages := outbound.NewContentPages(files)
+	_, _, _ = pages.RenderPage("faq")
+
+	// Act
+	files["faq.md"] = &fstest.MapFile{Data: []byte("# New"), ModTime: time.Unix(2, 0)}
+	title, _, _ := pages.RenderPage("faq")
+
+	// Assert
+	assert.That(t, "title must be rendered again", title, "New")
+}
+
+func Test_ContentPages_Should_Serve_Cached_Page_When_File_Unchanged(t *testing.T) {
+	// Arrange
+	files := fstest.MapFS{
+		"faq.md": {Data: []byte("# Old"), ModTime: time.Unix(1, 0)},
+	}
+	pages := outbound.NewContentPages(files)
+	_, _, _ = pages.RenderPage("faq")
+
+	// Act
+	files["faq.md"] = &fstest.MapFile{Data: []byte("# New"), ModTime: time.Unix(1, 0)}
+	title, _, _ := pages.RenderPage("faq")
+
+	// Assert
+	assert.That(t, "title must be cached", title, "Old")
+}
+
+func Test_ContentPages_With_Invalid_Slug_Should_Return_Not_Found(t *testing.T) {
+	// Arrange
+	pages := outbound.NewContentPages(fstest.MapFS{
+		"faq.md": {Data: []byte("# FAQ")},
+	})
+
+	// Act
+	_, _, err := pages.RenderPage("../faq")
+
+	// Assert
+	assert.That(t, "error must be ErrContentPageNotFound", errors.Is(err, outbound.ErrContentPageNotFound), true)
+}
+
+func Test_ContentPages_Markdown_Should_Return_Source(t *testing.T) {
+	// Arrange
+	pages := outbound.NewContentPages(fstest.MapFS{
+		"faq.md": {Data: []byte("# FAQ\n\n- **Q**")},
+	})
+
+	// Act
+	src, err := pages.Markdown("faq")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "source must match", src, "# FAQ\n\n- **Q**")
+}
@@ -0,0 +1,86 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/pprof"
+	"time"
+)
+
+// ProfileStore persists captured profiles.
+// BlobProfileStore saves them to the local disk or an S3-compatible bucket.
+type ProfileStore interface {
+	Save(ctx context.Context, name string, data []byte) error
+}
+
+// ContinuousProfiler periodically captures CPU and heap profiles.
+// CPU samples carry the pprof labels set by the inbound request ID middleware,
+// so slow requests can be located by their X-Request-ID after the fact.
+type ContinuousProfiler struct {
+	store       ProfileStore
+	logger      *slog.Logger
+	interval    time.Duration
+	cpuDuration time.Duration
+}
+
+// NewContinuousProfiler creates a new continuous profiler.
+// Every interval a CPU profile of cpuDuration and a heap profile are captured.
+func NewContinuousProfiler(store ProfileStore, logger *slog.Logger, interval, cpuDuration time.Duration) *ContinuousProfiler {
+	return &ContinuousProfiler{
+		store:       store,
+		logger:      logger,
+		interval:    interval,
+		cpuDuration: cpuDuration,
+	}
+}
+
+// Start runs the capture loop in the background until the context is done.
+func (p *ContinuousProfiler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.Capture(ctx); err != nil {
+					p.logger.Warn("profile capture failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Capture records one CPU profile and one heap profile and saves both to the store.
+// The CPU capture is skipped if another CPU profile is running (e.g. via /debug/pprof/profile).
+func (p *ContinuousProfiler) Capture(ctx context.Context) error {
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	var cpu bytes.Buffer
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		p.logger.Info("cpu profile skipped", "reason", err.Error())
+	} else {
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.cpuDuration):
+		}
+		pprof.StopCPUProfile()
+		if err := p.store.Save(ctx, "cpu-"+stamp+".pprof", cpu.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	var heap bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	if err := p.store.Save(ctx, "heap-"+stamp+".pprof", heap.Bytes()); err != nil {
+		return err
+	}
+
+	p.logger.Info("profiles captured", "timestamp", stamp)
+	return nil
+}
@@ -0,0 +1,33 @@
+package outbound_test
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ContinuousProfiler Tests
+// ============================================================================
+
+func Test_ContinuousProfiler_Capture_Should_Write_CPU_And_Heap_Profiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	store := outbound.NewBlobProfileStore(outbound.NewLocalBlobStorage(dir), "profiles")
+	profiler := outbound.NewContinuousProfiler(store, slog.Default(), time.Hour, 10*time.Millisecond)
+
+	// Act
+	err := profiler.Capture(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	cpu, _ := filepath.Glob(filepath.Join(dir, "profiles", "cpu-*.pprof"))
+	heap, _ := filepath.Glob(filepath.Join(dir, "profiles", "heap-*.pprof"))
+	assert.That(t, "cpu profile must be written", len(cpu), 1)
+	assert.That(t, "heap profile must be written", len(heap), 1)
+}
@@ -0,0 +1,172 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// MetricDeadLetters counts the events dead-lettered because their handler failed, by topic.
+const MetricDeadLetters = "hotel_event_dead_letters_total"
+
+// DeadLetterDispatcher records the events whose handlers fail as dead letters and publishes them to
+// the dead letter topic (<topic>.dlq) with the error, so they are not lost when the handler gives up.
+// Staff list them and replay them against the handler that failed once the cause is fixed.
+// Publishing is not affected.
+type DeadLetterDispatcher struct {
+	dispatcher    messaging.Dispatcher
+	repo          resource.Access[string, shared.DeadLetter]
+	logger        *slog.Logger
+	metrics       shared.Metrics
+	now           func() time.Time
+	mu            sync.Mutex
+	subscriptions map[string]service.Function[messaging.Message, messaging.MessageState]
+	counts        map[string]int // subscriptions per topic
+}
+
+// NewDeadLetterDispatcher wraps the dispatcher with the dead letter queue stored in the repository.
+func NewDeadLetterDispatcher(dispatcher messaging.Dispatcher, repo resource.Access[string, shared.DeadLetter], logger *slog.Logger) *DeadLetterDispatcher {
+	return &DeadLetterDispatcher{
+		dispatcher:    dispatcher,
+		repo:          repo,
+		logger:        logger,
+		now:           time.Now,
+		subscriptions: make(map[string]service.Function[messaging.Message, messaging.MessageState]),
+		counts:        make(map[string]int),
+	}
+}
+
+// WithMetrics counts the dead letters per topic.
+func (d *DeadLetterDispatcher) WithMetrics(metrics shared.Metrics) *DeadLetterDispatcher {
+	d.metrics = metrics
+	return d
+}
+
+// Publish publishes the message with the wrapped dispatcher.
+func (d *DeadLetterDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the handler to the topic of the wrapped dispatcher. A failed message is
+// dead-lettered and reported as failed without an error, so the dispatcher does not retry it;
+// the replay is the retry. The handlers of a topic are told apart by the order they subscribed in,
+// which is the same on every instance started with the same configuration.
+func (d *DeadLetterDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	d.mu.Lock()
+	d.counts[topic]++
+	subscription := topic + "#" + strconv.Itoa(d.counts[topic])
+	d.subscriptions[subscription] = fn
+	d.mu.Unlock()
+
+	return d.dispatcher.Subscribe(ctx, topic, func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		state, err := fn(ctx, msg)
+		if err == nil && state != messaging.MessageStateFailed {
+			return state, nil
+		}
+		if err == nil {
+			err = errors.New("handler reported the message as failed")
+		}
+		// The handler may have outlived the timeout of the dispatcher; its failure is recorded anyway.
+		d.deadLetter(context.WithoutCancel(ctx), topic, subscription, msg.Data, err)
+		return messaging.MessageStateFailed, nil
+	})
+}
+
+// deadLetter records the failed event and publishes it to the dead letter topic.
+func (d *DeadLetterDispatcher) deadLetter(ctx context.Context, topic, subscription string, data []byte, cause error) {
+	letter := shared.DeadLetter{
+		ID:           security.GenerateID(),
+		Topic:        topic,
+		Subscription: subscription,
+		Data:         data,
+		Error:        cause.Error(),
+		Status:       shared.DeadLetterDead,
+		Attempts:     1,
+		FailedAt:     d.now(),
+	}
+	d.logger.Warn("event handler failed, event dead-lettered",
+		"dead_letter_id", letter.ID,
+		"topic", topic,
+		"subscription", subscription,
+		"error", cause,
+	)
+	if d.metrics != nil {
+		d.metrics.IncCounter(MetricDeadLetters, "topic", topic)
+	}
+	if err := d.repo.Create(ctx, letter.ID, letter); err != nil {
+		d.logger.Error("failed to record dead letter", "dead_letter_id", letter.ID, "topic", topic, "error", err)
+	}
+	envelope, _ := json.Marshal(letter)
+	if err := d.dispatcher.Publish(ctx, messaging.NewMessage(shared.DeadLetterTopic(topic), envelope)); err != nil {
+		d.logger.Error("failed to publish dead letter", "dead_letter_id", letter.ID, "topic", shared.DeadLetterTopic(topic), "error", err)
+	}
+}
+
+// DeadLetters returns the dead letters of the topic and status, newest first; empty filters match all.
+func (d *DeadLetterDispatcher) DeadLetters(ctx context.Context, topic, status string) ([]shared.DeadLetter, error) {
+	if status != "" && status != shared.DeadLetterDead && status != shared.DeadLetterReplayed {
+		return nil, shared.ErrInvalidDeadLetterStatus
+	}
+	all, err := d.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letters: %w", err)
+	}
+	var matched []shared.DeadLetter
+	for _, letter := range all {
+		if (topic == "" || letter.Topic == topic) && (status == "" || letter.Status == status) {
+			matched = append(matched, letter)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].FailedAt.After(matched[j].FailedAt) })
+	return matched, nil
+}
+
+// Replay delivers the dead letter again to the handler that failed, and only to it.
+// A successful replay marks it replayed; a failed one keeps it dead with the new error.
+// Handlers are idempotent, so replaying an event another instance replayed meanwhile is harmless.
+func (d *DeadLetterDispatcher) Replay(ctx context.Context, id string) (shared.DeadLetter, error) {
+	letter, err := d.repo.Read(ctx, id)
+	if err != nil {
+		return shared.DeadLetter{}, fmt.Errorf("%w: %w", shared.ErrDeadLetterNotFound, err)
+	}
+	if letter.Status == shared.DeadLetterReplayed {
+		return *letter, shared.ErrDeadLetterReplayed
+	}
+	d.mu.Lock()
+	fn, ok := d.subscriptions[letter.Subscription]
+	d.mu.Unlock()
+	if !ok {
+		return *letter, fmt.Errorf("%w: %s", shared.ErrDeadLetterNoHandler, letter.Subscription)
+	}
+
+	state, replayErr := fn(ctx, messaging.Message{Topic: letter.Topic, Data: letter.Data, State: messaging.MessageStateCreated})
+	if replayErr == nil && state == messaging.MessageStateFailed {
+		replayErr = errors.New("handler reported the message as failed")
+	}
+	letter.Attempts++
+	letter.ReplayedAt = d.now()
+	if replayErr != nil {
+		letter.Error = replayErr.Error()
+	} else {
+		letter.Status = shared.DeadLetterReplayed
+	}
+	if err := d.repo.Update(ctx, letter.ID, *letter); err != nil {
+		return *letter, fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	if replayErr != nil {
+		return *letter, fmt.Errorf("%w: %w", shared.ErrDeadLetterReplayFailed, replayErr)
+	}
+	return *letter, nil
+}
@@ -0,0 +1,127 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// flakyHandler fails until it is fixed and counts its calls.
+type flakyHandler struct {
+	fixed bool
+	calls int
+}
+
+func (h *flakyHandler) handle(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	h.calls++
+	if !h.fixed {
+		return messaging.MessageStateFailed, errors.New("mail server down")
+	}
+	return messaging.MessageStateCompleted, nil
+}
+
+// createTestDeadLetterDispatcher returns a dead letter queue over an internal dispatcher with a
+// working and a failing handler of payment.captured, and the dead letters published to payment.captured.dlq.
+func createTestDeadLetterDispatcher(t *testing.T) (*outbound.DeadLetterDispatcher, *flakyHandler, *[]shared.DeadLetter) {
+	t.Helper()
+	dispatcher := outbound.NewDeadLetterDispatcher(messaging.NewInternalDispatcher(), resource.NewInMemoryAccess[string, shared.DeadLetter](), slog.Default())
+	_ = dispatcher.Subscribe(context.Background(), "payment.captured", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		return messaging.MessageStateCompleted, nil
+	})
+	flaky := &flakyHandler{}
+	_ = dispatcher.Subscribe(context.Background(), "payment.captured", flaky.handle)
+	var published []shared.DeadLetter
+	_ = dispatcher.Subscribe(context.Background(), "payment.captured.dlq", func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		var letter shared.DeadLetter
+		_ = json.Unmarshal(msg.Data, &letter)
+		published = append(published, letter)
+		return messaging.MessageStateCompleted, nil
+	})
+	return dispatcher, flaky, &published
+}
+
+// ============================================================================
+// DeadLetterDispatcher Tests
+// ============================================================================
+
+func Test_DeadLetterDispatcher_Should_Dead_Letter_Failed_Handler_Only(t *testing.T) {
+	// Arrange
+	dispatcher, _, published := createTestDeadLetterDispatcher(t)
+
+	// Act
+	err := dispatcher.Publish(context.Background(), messaging.NewMessage("payment.captured", []byte(`{"reservation_id":"res-001"}`)))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	letters, _ := dispatcher.DeadLetters(context.Background(), "", "")
+	assert.That(t, "only the failed handler must be dead-lettered", len(letters), 1)
+	assert.That(t, "subscription must be the second handler", letters[0].Subscription, "payment.captured#2")
+	assert.That(t, "error must be recorded", letters[0].Error, "mail server down")
+	assert.That(t, "status must be dead", letters[0].Status, shared.DeadLetterDead)
+	assert.That(t, "dead letter must be published to the dlq topic", len(*published), 1)
+	assert.That(t, "published dead letter must carry the event", string((*published)[0].Data), `{"reservation_id":"res-001"}`)
+}
+
+func Test_DeadLetterDispatcher_Replay_Should_Mark_Replayed_After_Fix(t *testing.T) {
+	// Arrange
+	dispatcher, flaky, _ := createTestDeadLetterDispatcher(t)
+	_ = dispatcher.Publish(context.Background(), messaging.NewMessage("payment.captured", []byte(`{}`)))
+	letters, _ := dispatcher.DeadLetters(context.Background(), "payment.captured", shared.DeadLetterDead)
+	flaky.fixed = true
+
+	// Act
+	letter, err := dispatcher.Replay(context.Background(), letters[0].ID)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "status must be replayed", letter.Status, shared.DeadLetterReplayed)
+	assert.That(t, "attempts must count the replay", letter.Attempts, 2)
+	assert.That(t, "only the failed handler must run again", flaky.calls, 2)
+	_, err = dispatcher.Replay(context.Background(), letters[0].ID)
+	assert.That(t, "second replay must be refused", errors.Is(err, shared.ErrDeadLetterReplayed), true)
+}
+
+func Test_DeadLetterDispatcher_Replay_When_Failing_Again_Should_Keep_Dead(t *testing.T) {
+	// Arrange
+	dispatcher, _, _ := createTestDeadLetterDispatcher(t)
+	_ = dispatcher.Publish(context.Background(), messaging.NewMessage("payment.captured", []byte(`{}`)))
+	letters, _ := dispatcher.DeadLetters(context.Background(), "", "")
+
+	// Act
+	letter, err := dispatcher.Replay(context.Background(), letters[0].ID)
+
+	// Assert
+	assert.That(t, "error must be ErrDeadLetterReplayFailed", errors.Is(err, shared.ErrDeadLetterReplayFailed), true)
+	assert.That(t, "status must stay dead", letter.Status, shared.DeadLetterDead)
+	assert.That(t, "attempts must count the replay", letter.Attempts, 2)
+}
+
+func Test_DeadLetterDispatcher_Replay_With_Unknown_ID_Should_Fail(t *testing.T) {
+	// Arrange
+	dispatcher, _, _ := createTestDeadLetterDispatcher(t)
+
+	// Act
+	_, err := dispatcher.Replay(context.Background(), "unknown")
+
+	// Assert
+	assert.That(t, "error must be ErrDeadLetterNotFound", errors.Is(err, shared.ErrDeadLetterNotFound), true)
+}
+
+func Test_DeadLetterDispatcher_DeadLetters_With_Invalid_Status_Should_Fail(t *testing.T) {
+	// Arrange
+	dispatcher, _, _ := createTestDeadLetterDispatcher(t)
+
+	// Act
+	_, err := dispatcher.DeadLetters(context.Background(), "", "lost")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidDeadLetterStatus", err, shared.ErrInvalidDeadLetterStatus)
+}
@@ -0,0 +1,185 @@
+package outbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ErrInvalidDeepLinks is returned for malformed deep link settings.
+var ErrInvalidDeepLinks = errors.New("invalid deep links")
+
+// deepLinkScheme matches URL schemes (RFC 3986, 3.1), e.g. "hotelbooking".
+var deepLinkScheme = regexp.MustCompile(`^[a-z][a-z0-9+.-]*$`)
+
+// DeepLinkApp is the companion app of a property: the URL scheme that opens it directly and the domain
+// of its universal links (iOS) and app links (Android). Either may be empty.
+type DeepLinkApp struct {
+	Scheme string // e.g. "hotelbooking", opens hotelbooking://reservations/res-001
+	Domain string // e.g. "app.example.com", which must serve the UI and the association files
+}
+
+// DeepLink is a link into the companion app with its web fallback.
+type DeepLink struct {
+	App string // universal link if the app has a domain, else the URL of its scheme; "" without an app
+	Web string // page of the web UI, for guests without the app
+}
+
+// DeepLinks generates the links of the emails that open the companion app when it is installed.
+// Links to a domain are universal links: the phone opens the app if it is installed and the web
+// page on that domain otherwise, because the domain serves the association files of the app.
+// Links to a scheme only work with the app, so emails always show the web link next to them.
+type DeepLinks struct {
+	uiURL               string
+	uiPath              string // path of uiURL, e.g. "/ui", which the universal links keep
+	app                 DeepLinkApp
+	properties          map[reservation.PropertyID]DeepLinkApp
+	appleAppID          string
+	androidPackage      string
+	androidFingerprints []string
+}
+
+// NewDeepLinks creates links to the app for the pages below uiURL (e.g. http://localhost:8080/ui).
+// The app is used for reservations of properties without an app of their own.
+func NewDeepLinks(uiURL string, app DeepLinkApp) *DeepLinks {
+	uiURL = strings.TrimSuffix(uiURL, "/")
+	uiPath := ""
+	if u, err := url.Parse(uiURL); err == nil {
+		uiPath = strings.TrimSuffix(u.Path, "/")
+	}
+	return &DeepLinks{uiURL: uiURL, uiPath: uiPath, app: app, properties: make(map[reservation.PropertyID]DeepLinkApp)}
+}
+
+// WithProperties links the reservations of the properties to their own branded apps.
+func (d *DeepLinks) WithProperties(apps map[reservation.PropertyID]DeepLinkApp) *DeepLinks {
+	for id, app := range apps {
+		d.properties[id] = app
+	}
+	return d
+}
+
+// WithApps sets the identity of the apps for the association files: the Apple app ID
+// (team ID and bundle ID, e.g. "ABCDE12345.com.example.hotel"), and the Android package
+// with the SHA-256 fingerprints of its signing certificates. Empty values leave the file out.
+func (d *DeepLinks) WithApps(appleAppID, androidPackage string, androidFingerprints []string) *DeepLinks {
+	d.appleAppID = appleAppID
+	d.androidPackage = androidPackage
+	d.androidFingerprints = androidFingerprints
+	return d
+}
+
+// Link returns the link of the page below the UI, e.g. "/reservations/res-001", for a reservation of the property.
+func (d *DeepLinks) Link(propertyID reservation.PropertyID, path string) DeepLink {
+	app, ok := d.properties[propertyID]
+	if !ok {
+		app = d.app
+	}
+	link := DeepLink{Web: d.uiURL + path}
+	switch {
+	case app.Domain != "":
+		link.App = "https://" + app.Domain + d.uiPath + path
+	case app.Scheme != "":
+		link.App = app.Scheme + "://" + strings.TrimPrefix(path, "/")
+	}
+	return link
+}
+
+// appLinkPaths are the pages of the UI the apps open, relative to the UI.
+var appLinkPaths = []string{"/reservations/*"}
+
+// AppleAppSiteAssociation returns the apple-app-site-association file, which lets iOS open the
+// reservation pages of the domains in the app, or nil without an Apple app ID.
+func (d *DeepLinks) AppleAppSiteAssociation() []byte {
+	if d.appleAppID == "" {
+		return nil
+	}
+	type component struct {
+		Path string `json:"/"`
+	}
+	type detail struct {
+		AppIDs     []string    `json:"appIDs"`
+		Components []component `json:"components"`
+	}
+	var components []component
+	for _, path := range appLinkPaths {
+		components = append(components, component{Path: d.uiPath + path})
+	}
+	data, _ := json.Marshal(map[string]any{
+		"applinks": map[string]any{
+			"details": []detail{{AppIDs: []string{d.appleAppID}, Components: components}},
+		},
+	})
+	return data
+}
+
+// AssetLinks returns the Digital Asset Links file (assetlinks.json), which lets Android open the
+// links of the domains in the app, or nil without an Android package.
+func (d *DeepLinks) AssetLinks() []byte {
+	if d.androidPackage == "" {
+		return nil
+	}
+	type target struct {
+		Namespace    string   `json:"namespace"`
+		PackageName  string   `json:"package_name"`
+		Fingerprints []string `json:"sha256_cert_fingerprints"`
+	}
+	type statement struct {
+		Relation []string `json:"relation"`
+		Target   target   `json:"target"`
+	}
+	fingerprints := d.androidFingerprints
+	if fingerprints == nil {
+		fingerprints = []string{}
+	}
+	data, _ := json.Marshal([]statement{{
+		Relation: []string{"delegate_permission/common.handle_all_urls"},
+		Target:   target{Namespace: "android_app", PackageName: d.androidPackage, Fingerprints: fingerprints},
+	}})
+	return data
+}
+
+// ParseDeepLinkApp validates the scheme and domain of an app.
+func ParseDeepLinkApp(scheme, domain string) (DeepLinkApp, error) {
+	app := DeepLinkApp{Scheme: strings.ToLower(strings.TrimSpace(scheme)), Domain: strings.ToLower(strings.TrimSpace(domain))}
+	if app.Scheme != "" && (!deepLinkScheme.MatchString(app.Scheme) || app.Scheme == "http" || app.Scheme == "https") {
+		return DeepLinkApp{}, fmt.Errorf("%w: scheme %q", ErrInvalidDeepLinks, scheme)
+	}
+	if app.Domain != "" {
+		if u, err := url.Parse("https://" + app.Domain); err != nil || u.Host != app.Domain || u.Port() != "" {
+			return DeepLinkApp{}, fmt.Errorf("%w: domain %q", ErrInvalidDeepLinks, domain)
+		}
+	}
+	return app, nil
+}
+
+// ParseDeepLinkApps parses the apps of the properties from "property=scheme|domain;..."
+// entries, e.g. "berlin=hotelberlin|app.hotel-berlin.example". Either part may be empty.
+func ParseDeepLinkApps(s string) (map[reservation.PropertyID]DeepLinkApp, error) {
+	apps := make(map[reservation.PropertyID]DeepLinkApp)
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, value, ok := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: expected property=scheme|domain: %q", ErrInvalidDeepLinks, entry)
+		}
+		scheme, domain, _ := strings.Cut(value, "|")
+		app, err := ParseDeepLinkApp(scheme, domain)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := apps[reservation.PropertyID(id)]; dup {
+			return nil, fmt.Errorf("%w: duplicate property %q", ErrInvalidDeepLinks, id)
+		}
+		apps[reservation.PropertyID(id)] = app
+	}
+	return apps, nil
+}
@@ -0,0 +1,129 @@
+package outbound_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// DeepLinks Tests
+// ============================================================================
+
+func Test_DeepLinks_Link_With_Domain_Should_Return_Universal_Link(t *testing.T) {
+	// Arrange
+	links := outbound.NewDeepLinks("http://localhost:8080/ui/", outbound.DeepLinkApp{Scheme: "hotelbooking", Domain: "app.example.com"})
+
+	// Act
+	link := links.Link("", "/reservations/res-001")
+
+	// Assert
+	assert.That(t, "app link must be the universal link", link.App, "https://app.example.com/ui/reservations/res-001")
+	assert.That(t, "web link must be the UI", link.Web, "http://localhost:8080/ui/reservations/res-001")
+}
+
+func Test_DeepLinks_Link_Should_Use_App_Of_Property(t *testing.T) {
+	// Arrange
+	links := outbound.NewDeepLinks("http://localhost:8080/ui", outbound.DeepLinkApp{Scheme: "hotelbooking"}).
+		WithProperties(map[reservation.PropertyID]outbound.DeepLinkApp{"berlin": {Scheme: "hotelberlin"}})
+
+	// Act
+	berlin := links.Link("berlin", "/reservations/res-001")
+	other := links.Link("munich", "/reservations/res-002")
+
+	// Assert
+	assert.That(t, "property must use its own app", berlin.App, "hotelberlin://reservations/res-001")
+	assert.That(t, "other properties must use the default app", other.App, "hotelbooking://reservations/res-002")
+}
+
+func Test_DeepLinks_Link_Without_App_Should_Only_Return_Web_Link(t *testing.T) {
+	// Arrange
+	links := outbound.NewDeepLinks("http://localhost:8080/ui", outbound.DeepLinkApp{})
+
+	// Act
+	link := links.Link("", "/reservations/res-001")
+
+	// Assert
+	assert.That(t, "app link must be empty", link.App, "")
+	assert.That(t, "web link must be the UI", link.Web, "http://localhost:8080/ui/reservations/res-001")
+}
+
+func Test_DeepLinks_AppleAppSiteAssociation_Should_List_Reservation_Pages(t *testing.T) {
+	// Arrange
+	links := outbound.NewDeepLinks("http://localhost:8080/ui", outbound.DeepLinkApp{Domain: "app.example.com"}).
+		WithApps("ABCDE12345.com.example.hotel", "", nil)
+
+	// Act
+	data := links.AppleAppSiteAssociation()
+
+	// Assert
+	var file struct {
+		AppLinks struct {
+			Details []struct {
+				AppIDs     []string            `json:"appIDs"`
+				Components []map[string]string `json:"components"`
+			} `json:"details"`
+		} `json:"applinks"`
+	}
+	assert.That(t, "file must be JSON", json.Unmarshal(data, &file), nil)
+	assert.That(t, "app must be listed", file.AppLinks.Details[0].AppIDs, []string{"ABCDE12345.com.example.hotel"})
+	assert.That(t, "reservation pages must be listed", file.AppLinks.Details[0].Components[0]["/"], "/ui/reservations/*")
+	assert.That(t, "asset links must be nil without Android app", links.AssetLinks() == nil, true)
+}
+
+func Test_DeepLinks_AssetLinks_Should_List_Package_And_Fingerprints(t *testing.T) {
+	// Arrange
+	links := outbound.NewDeepLinks("http://localhost:8080/ui", outbound.DeepLinkApp{}).
+		WithApps("", "com.example.hotel", []string{"AB:CD"})
+
+	// Act
+	data := links.AssetLinks()
+
+	// Assert
+	var statements []struct {
+		Relation []string `json:"relation"`
+		Target   struct {
+			PackageName  string   `json:"package_name"`
+			Fingerprints []string `json:"sha256_cert_fingerprints"`
+		} `json:"target"`
+	}
+	assert.That(t, "file must be JSON", json.Unmarshal(data, &statements), nil)
+	assert.That(t, "relation must handle all urls", statements[0].Relation, []string{"delegate_permission/common.handle_all_urls"})
+	assert.That(t, "package must be listed", statements[0].Target.PackageName, "com.example.hotel")
+	assert.That(t, "fingerprints must be listed", statements[0].Target.Fingerprints, []string{"AB:CD"})
+	assert.That(t, "apple file must be nil without Apple app", links.AppleAppSiteAssociation() == nil, true)
+}
+
+// ============================================================================
+// ParseDeepLinkApps Tests
+// ============================================================================
+
+func Test_ParseDeepLinkApps_Should_Parse_Scheme_And_Domain(t *testing.T) {
+	// Act
+	apps, err := outbound.ParseDeepLinkApps("berlin=HotelBerlin|app.hotel-berlin.example; munich=|app.hotel-munich.example")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "berlin must have scheme and domain", apps["berlin"], outbound.DeepLinkApp{Scheme: "hotelberlin", Domain: "app.hotel-berlin.example"})
+	assert.That(t, "munich must have a domain only", apps["munich"], outbound.DeepLinkApp{Domain: "app.hotel-munich.example"})
+}
+
+func Test_ParseDeepLinkApps_With_Malformed_Entries_Should_Fail(t *testing.T) {
+	for _, value := range []string{
+		"berlin",
+		"berlin=https",
+		"berlin=hotel berlin",
+		"berlin=|app.example.com/path",
+		"berlin=hotelberlin;berlin=hotelberlin",
+	} {
+		// Act
+		_, err := outbound.ParseDeepLinkApps(value)
+
+		// Assert
+		assert.That(t, "apps "+value+" must be rejected", errors.Is(err, outbound.ErrInvalidDeepLinks), true)
+	}
+}
@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"errors"
+	"html"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrUnknownEmailTemplate is returned for previews of emails that are not localized.
+var ErrUnknownEmailTemplate = errors.New("unknown email template")
+
+// PreviewEmail renders the email of the template (reservation_confirmation, cancellation_notice,
+// payment_receipt or check_in_welcome) with sample data for a guest preferring the locale; an empty locale previews a
+// guest without a preference. It returns the subject, the language the email was written in after
+// the fallbacks and the HTML body, or the escaped plain text without templates.
+func (s *MockNotificationService) PreviewEmail(template string, locale shared.Locale) (string, string, string, error) {
+	if locale == "" {
+		locale = s.locale
+	}
+	checkIn := time.Now().AddDate(0, 0, 30).Truncate(24 * time.Hour)
+	res := &reservation.Reservation{
+		ID:          "res-preview",
+		GuestID:     "guest-preview",
+		RoomID:      "room-201",
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)),
+		TotalAmount: shared.NewMoney(44700, "USD"),
+		Guests:      []reservation.GuestInfo{reservation.NewGuestInfo("Alex Example", "alex@example.com", "")},
+	}
+
+	var email Email
+	switch template {
+	case "reservation_confirmation":
+		email = s.reservationConfirmationEmail(res, locale)
+	case "cancellation_notice":
+		email = s.cancellationNoticeEmail(res, "Change of plans", locale)
+	case "payment_receipt":
+		pay := &payment.Payment{ID: "pay-preview", ReservationID: res.ID, Amount: res.TotalAmount, PaymentMethod: "credit_card", TransactionID: "txn-preview"}
+		email = s.paymentReceiptEmail(res, pay, locale)
+	case "check_in_welcome":
+		email = s.checkInWelcomeEmail(res, locale)
+	default:
+		return "", "", "", ErrUnknownEmailTemplate
+	}
+
+	_, language := emailTextsFor(locale, s.locale)
+	body := email.HTMLBody
+	if body == "" {
+		body = "<pre>" + html.EscapeString(email.Body) + "</pre>"
+	}
+	return email.Subject, language, body, nil
+}
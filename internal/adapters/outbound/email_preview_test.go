@@ -0,0 +1,106 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createLocalizedNotificationService renders a confirmation template that shows the language and the translated labels.
+func createLocalizedNotificationService(t *testing.T) *outbound.MockNotificationService {
+	t.Helper()
+	engine := templating.NewEngine(fstest.MapFS{
+		"emails/confirmation.tmpl": {Data: []byte(`{{ define "email_reservation_confirmation" }}<html lang="{{ .Language }}"><p>{{ printf .T.Greeting .GuestName }}</p><p>{{ .T.Total }} {{ .Amount }}</p></html>{{ end }}`)},
+	})
+	engine.Parse("emails/*.tmpl")
+	return outbound.NewMockNotificationService(slog.New(slog.NewTextHandler(io.Discard, nil)), "http://localhost:8080/ui", nil).WithTemplates(engine, "Seaside Hotel")
+}
+
+// ============================================================================
+// Localized Email Tests
+// ============================================================================
+
+func Test_MockNotificationService_WithProfiles_Should_Write_In_Preferred_Language(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	profiles := profile.NewService(nil, nil, nil, profile.DefaultTierPolicy()).WithLanguages(resource.NewInMemoryAccess[profile.GuestID, profile.LanguagePreference]())
+	_, _ = profiles.SetLanguage(context.Background(), "guest-001", "de")
+	svc := createLocalizedNotificationService(t).WithOutbox(queue).WithProfiles(profiles)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, createTestReservation())
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subject must be german", provider.sent[0].Subject, "Ihre Reservierung res-001 ist bestätigt")
+	assert.That(t, "text must be german", strings.HasPrefix(provider.sent[0].Body, "Guten Tag John Doe,"), true)
+	assert.That(t, "html must be german with german amounts", provider.sent[0].HTMLBody, "<html lang=\"de\"><p>Guten Tag John Doe,</p><p>Gesamt 300,00\u00a0$</p></html>")
+}
+
+func Test_MockNotificationService_PreviewEmail_Should_Render_Language(t *testing.T) {
+	// Arrange
+	svc := createLocalizedNotificationService(t)
+
+	// Act
+	subject, language, body, err := svc.PreviewEmail("reservation_confirmation", "fr-FR")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subject must be french", subject, "Votre réservation res-preview est confirmée")
+	assert.That(t, "language must be french", language, "fr")
+	assert.That(t, "html must be french", strings.Contains(body, "Bonjour Alex Example,"), true)
+}
+
+func Test_MockNotificationService_PreviewEmail_Without_Translation_Should_Fall_Back_To_Default_Locale(t *testing.T) {
+	// Arrange
+	svc := createLocalizedNotificationService(t).WithLocale("es-ES")
+
+	// Act
+	_, language, body, _ := svc.PreviewEmail("reservation_confirmation", "ja-JP")
+
+	// Assert
+	assert.That(t, "language must be the default locale's", language, "es")
+	assert.That(t, "amount must still use the guest's locale", strings.Contains(body, "$447.00"), true)
+}
+
+func Test_MockNotificationService_PreviewEmail_Without_Any_Translation_Should_Use_English(t *testing.T) {
+	// Arrange
+	svc := createLocalizedNotificationService(t).WithLocale(shared.Locale("nl-NL"))
+
+	// Act
+	subject, language, _, _ := svc.PreviewEmail("cancellation_notice", "it-IT")
+
+	// Assert
+	assert.That(t, "language must be english", language, "en")
+	assert.That(t, "subject must be english", subject, "Your reservation res-preview was cancelled")
+}
+
+func Test_MockNotificationService_PreviewEmail_With_Unknown_Template_Should_Return_ErrUnknownEmailTemplate(t *testing.T) {
+	// Arrange
+	svc := createLocalizedNotificationService(t)
+
+	// Act
+	_, _, _, err := svc.PreviewEmail("survey_invitation", "de-DE")
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownEmailTemplate", errors.Is(err, outbound.ErrUnknownEmailTemplate), true)
+}
@@ -0,0 +1,377 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/security"
+)
+
+// EmailPriority orders the email queue: transactional before lifecycle before marketing.
+type EmailPriority string
+
+const (
+	EmailTransactional EmailPriority = "transactional" // confirmations, cancellations, invitations
+	EmailLifecycle     EmailPriority = "lifecycle"     // surveys, rewards
+	EmailMarketing     EmailPriority = "marketing"     // newsletters, offers
+)
+
+// rank returns the sort order of the priority; lower is sent first.
+func (p EmailPriority) rank() int {
+	switch p {
+	case EmailTransactional:
+		return 0
+	case EmailLifecycle:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EmailStatus is the delivery status of a queued email.
+type EmailStatus string
+
+const (
+	EmailQueued EmailStatus = "queued" // waiting to be sent, possibly after a failed attempt
+	EmailSent   EmailStatus = "sent"   // accepted by the provider
+	EmailFailed EmailStatus = "failed" // given up after the maximum attempts
+)
+
+// Email is a message in the email queue.
+// GuestID and ReservationID link it to the guest's communication history.
+type Email struct {
+	ID            string
+	To            string
+	Subject       string
+	Body          string
+	HTMLBody      string            // optional HTML alternative of Body
+	Attachments   []EmailAttachment // optional files, e.g. the calendar event of a confirmation
+	Template      string
+	Priority      EmailPriority
+	GuestID       string
+	ReservationID string
+	Status        EmailStatus
+	Attempts      int
+	LastError     string
+	ResendOf      string // the failed email this one resends, if any
+	QueuedAt      time.Time
+	NextAttemptAt time.Time
+	SentAt        time.Time
+}
+
+// EmailAttachment is a file attached to an email.
+type EmailAttachment struct {
+	Filename    string
+	ContentType string // e.g. "text/calendar; charset=utf-8; method=PUBLISH"
+	Content     []byte
+}
+
+// EmailProvider sends an email, e.g. via SMTP or an email API.
+// Providers wrap ErrEmailThrottled when the provider asks to slow down.
+type EmailProvider interface {
+	Name() string
+	Send(ctx context.Context, email Email) error
+}
+
+// EmailOutbox accepts emails for delivery; EmailQueue implements it.
+type EmailOutbox interface {
+	Enqueue(ctx context.Context, email Email) error
+}
+
+// Email queue errors.
+var (
+	ErrEmailThrottled = errors.New("email provider throttled")
+	ErrEmailQueueFull = errors.New("email queue full")
+)
+
+// EmailQueueConfig configures the send rate and retries of the email queue.
+type EmailQueueConfig struct {
+	RatePerSecond float64       // provider send limit; 0 is unlimited
+	MaxAttempts   int           // attempts before an email is marked failed
+	RetryDelay    time.Duration // delay after a failed attempt, doubled per attempt
+	MaxQueued     int           // backlog above which only transactional emails are accepted; 0 is unlimited
+}
+
+// DefaultEmailQueueConfig returns 14 emails per second, 5 attempts from 30s apart and a backlog of 10000.
+func DefaultEmailQueueConfig() EmailQueueConfig {
+	return EmailQueueConfig{
+		RatePerSecond: 14,
+		MaxAttempts:   5,
+		RetryDelay:    30 * time.Second,
+		MaxQueued:     10000,
+	}
+}
+
+// ParseEmailRateLimits parses send limits per provider in the format "provider=perSecond,..." (e.g. "log=14,smtp=5").
+func ParseEmailRateLimits(s string) (map[string]float64, error) {
+	limits := make(map[string]float64)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		provider, value, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(provider) == "" {
+			return nil, fmt.Errorf("invalid email rate limit: %q", part)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid email rate limit for %s: %q", provider, value)
+		}
+		limits[strings.TrimSpace(provider)] = rate
+	}
+	return limits, nil
+}
+
+// EmailQueue is a persistent, prioritized email queue that sends at the provider's rate limit.
+// Queued emails are stored in the repository, so they survive restarts; sent and failed emails
+// are removed from it after the status listeners were notified.
+// Delivery is at least once: an email sent right before a crash is sent again.
+type EmailQueue struct {
+	repo      resource.Access[string, Email]
+	provider  EmailProvider
+	config    EmailQueueConfig
+	logger    *slog.Logger
+	limiter   *sendLimiter
+	wake      chan struct{}
+	now       func() time.Time
+	mu        sync.RWMutex
+	listeners []func(ctx context.Context, email Email)
+}
+
+// NewEmailQueue creates a new email queue for the provider.
+func NewEmailQueue(repo resource.Access[string, Email], provider EmailProvider, config EmailQueueConfig, logger *slog.Logger) *EmailQueue {
+	return &EmailQueue{
+		repo:     repo,
+		provider: provider,
+		config:   config,
+		logger:   logger,
+		limiter:  newSendLimiter(config.RatePerSecond),
+		wake:     make(chan struct{}, 1),
+		now:      time.Now,
+	}
+}
+
+// OnStatus registers a listener for every status change, e.g. to record the communication history.
+func (q *EmailQueue) OnStatus(listener func(ctx context.Context, email Email)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.listeners = append(q.listeners, listener)
+}
+
+// Enqueue stores the email for delivery.
+// If the backlog exceeds MaxQueued, only transactional emails are accepted; others return ErrEmailQueueFull.
+func (q *EmailQueue) Enqueue(ctx context.Context, email Email) error {
+	if email.To == "" {
+		return errors.New("email without recipient")
+	}
+	if q.config.MaxQueued > 0 && email.Priority != EmailTransactional {
+		queued, err := q.repo.ReadAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read email queue: %w", err)
+		}
+		if len(queued) >= q.config.MaxQueued {
+			return ErrEmailQueueFull
+		}
+	}
+	if email.ID == "" {
+		email.ID = security.GenerateID()
+	}
+	if email.Priority == "" {
+		email.Priority = EmailTransactional
+	}
+	email.Status = EmailQueued
+	email.QueuedAt = q.now()
+	email.NextAttemptAt = email.QueuedAt
+	if err := q.repo.Create(ctx, email.ID, email); err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	q.notify(ctx, email)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start sends the queued emails at the provider's rate until the context is done.
+func (q *EmailQueue) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			if err := q.limiter.wait(ctx); err != nil {
+				return
+			}
+			sent, err := q.SendNext(ctx)
+			if err != nil {
+				q.logger.Warn("email queue failed", "error", err)
+			}
+			if sent {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.wake:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SendNext sends the due email with the highest priority, oldest first.
+// It returns false if no email is due. Rate limiting is up to the caller.
+func (q *EmailQueue) SendNext(ctx context.Context) (bool, error) {
+	email, err := q.next(ctx)
+	if err != nil || email == nil {
+		return false, err
+	}
+
+	err = q.provider.Send(ctx, *email)
+	switch {
+	case err == nil:
+		email.Status = EmailSent
+		email.SentAt = q.now()
+		email.Attempts++
+		email.LastError = ""
+	case errors.Is(err, ErrEmailThrottled):
+		// The provider asks to slow down: pause the queue, the attempt does not count.
+		q.limiter.pause(q.config.RetryDelay)
+		email.LastError = err.Error()
+		return true, q.repo.Update(ctx, email.ID, *email)
+	default:
+		email.Attempts++
+		email.LastError = err.Error()
+		if email.Attempts >= q.config.MaxAttempts {
+			email.Status = EmailFailed
+		} else {
+			email.NextAttemptAt = q.now().Add(q.config.RetryDelay << (email.Attempts - 1))
+		}
+	}
+
+	if email.Status == EmailQueued {
+		if err := q.repo.Update(ctx, email.ID, *email); err != nil {
+			return true, fmt.Errorf("failed to update queued email: %w", err)
+		}
+	} else if err := q.repo.Delete(ctx, email.ID); err != nil {
+		return true, fmt.Errorf("failed to remove email from queue: %w", err)
+	}
+	q.logger.Debug("email delivery attempted", "email_id", email.ID, "template", email.Template, "status", email.Status, "attempts", email.Attempts)
+	q.notify(ctx, *email)
+	return true, nil
+}
+
+// Pending returns the number of queued emails per priority.
+func (q *EmailQueue) Pending(ctx context.Context) (map[EmailPriority]int, error) {
+	queued, err := q.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email queue: %w", err)
+	}
+	pending := make(map[EmailPriority]int)
+	for _, email := range queued {
+		pending[email.Priority]++
+	}
+	return pending, nil
+}
+
+// next returns the due email with the highest priority, or nil.
+func (q *EmailQueue) next(ctx context.Context) (*Email, error) {
+	queued, err := q.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email queue: %w", err)
+	}
+	now := q.now()
+	queued = slices.DeleteFunc(queued, func(e Email) bool { return e.NextAttemptAt.After(now) })
+	if len(queued) == 0 {
+		return nil, nil
+	}
+	email := slices.MinFunc(queued, func(a, b Email) int {
+		if a.Priority.rank() != b.Priority.rank() {
+			return a.Priority.rank() - b.Priority.rank()
+		}
+		return a.QueuedAt.Compare(b.QueuedAt)
+	})
+	return &email, nil
+}
+
+// notify calls the status listeners.
+func (q *EmailQueue) notify(ctx context.Context, email Email) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for _, listener := range q.listeners {
+		listener(ctx, email)
+	}
+}
+
+// sendLimiter spaces sends evenly at the rate per second.
+type sendLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newSendLimiter creates a limiter; a rate of 0 is unlimited.
+func newSendLimiter(ratePerSecond float64) *sendLimiter {
+	l := &sendLimiter{}
+	if ratePerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / ratePerSecond)
+	}
+	return l
+}
+
+// wait blocks until the next send is allowed or the context is done.
+func (l *sendLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(at)):
+		return nil
+	}
+}
+
+// pause delays the next send by d.
+func (l *sendLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// LogEmailProvider implements EmailProvider by logging the emails, for development.
+type LogEmailProvider struct {
+	logger *slog.Logger
+}
+
+// NewLogEmailProvider creates a new logging email provider.
+func NewLogEmailProvider(logger *slog.Logger) *LogEmailProvider {
+	return &LogEmailProvider{logger: logger}
+}
+
+// Name returns "log".
+func (p *LogEmailProvider) Name() string { return "log" }
+
+// Send logs the email.
+func (p *LogEmailProvider) Send(ctx context.Context, email Email) error {
+	p.logger.Info("email sent", "email_id", email.ID, "to", email.To, "subject", email.Subject, "template", email.Template, "priority", email.Priority)
+	return nil
+}
@@ -0,0 +1,213 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// recordingEmailProvider records the sent emails and fails with err if set.
+type recordingEmailProvider struct {
+	mu   sync.Mutex
+	sent []outbound.Email
+	err  error
+}
+
+func (p *recordingEmailProvider) Name() string { return "test" }
+
+func (p *recordingEmailProvider) Send(ctx context.Context, email outbound.Email) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.sent = append(p.sent, email)
+	return nil
+}
+
+func (p *recordingEmailProvider) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sent)
+}
+
+// createTestEmailQueue returns an unlimited queue with one attempt per email.
+func createTestEmailQueue(repo resource.Access[string, outbound.Email], provider outbound.EmailProvider, config outbound.EmailQueueConfig) *outbound.EmailQueue {
+	return outbound.NewEmailQueue(repo, provider, config, slog.Default())
+}
+
+func testEmailQueueConfig() outbound.EmailQueueConfig {
+	return outbound.EmailQueueConfig{MaxAttempts: 1, RetryDelay: time.Minute}
+}
+
+// ============================================================================
+// EmailQueue Tests
+// ============================================================================
+
+func Test_EmailQueue_SendNext_Should_Send_Transactional_First(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{}
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, testEmailQueueConfig())
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Template: "newsletter", Priority: outbound.EmailMarketing})
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Template: "survey_invitation", Priority: outbound.EmailLifecycle})
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Template: "reservation_confirmation", Priority: outbound.EmailTransactional})
+
+	// Act
+	for range 3 {
+		_, _ = queue.SendNext(ctx)
+	}
+
+	// Assert
+	assert.That(t, "all emails must be sent", len(provider.sent), 3)
+	assert.That(t, "transactional must be first", provider.sent[0].Template, "reservation_confirmation")
+	assert.That(t, "lifecycle must be second", provider.sent[1].Template, "survey_invitation")
+	assert.That(t, "marketing must be last", provider.sent[2].Template, "newsletter")
+}
+
+func Test_EmailQueue_SendNext_With_Failure_Should_Report_Failed_Status(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{err: errors.New("mailbox unavailable")}
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, testEmailQueueConfig())
+	var statuses []outbound.EmailStatus
+	queue.OnStatus(func(ctx context.Context, email outbound.Email) { statuses = append(statuses, email.Status) })
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", GuestID: "guest-1"})
+
+	// Act
+	sent, err := queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "an email must be attempted", sent, true)
+	assert.That(t, "statuses must be reported", statuses, []outbound.EmailStatus{outbound.EmailQueued, outbound.EmailFailed})
+	pending, _ := queue.Pending(ctx)
+	assert.That(t, "failed email must leave the queue", len(pending), 0)
+}
+
+func Test_EmailQueue_SendNext_With_Failure_Should_Retry_Later(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{err: errors.New("connection reset")}
+	config := testEmailQueueConfig()
+	config.MaxAttempts = 3
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, config)
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com"})
+	_, _ = queue.SendNext(ctx)
+
+	// Act
+	sent, err := queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must not be due yet", sent, false)
+	pending, _ := queue.Pending(ctx)
+	assert.That(t, "email must stay queued", pending[outbound.EmailTransactional], 1)
+}
+
+func Test_EmailQueue_SendNext_With_Throttling_Should_Not_Count_Attempt(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[string, outbound.Email]()
+	provider := &recordingEmailProvider{err: outbound.ErrEmailThrottled}
+	queue := createTestEmailQueue(repo, provider, testEmailQueueConfig())
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{ID: "e-1", To: "a@example.com"})
+
+	// Act
+	_, err := queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	email, _ := repo.Read(ctx, "e-1")
+	assert.That(t, "email must stay queued", email.Status, outbound.EmailQueued)
+	assert.That(t, "attempt must not count", email.Attempts, 0)
+}
+
+func Test_EmailQueue_Enqueue_With_Full_Backlog_Should_Accept_Transactional_Only(t *testing.T) {
+	// Arrange
+	config := testEmailQueueConfig()
+	config.MaxQueued = 1
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), &recordingEmailProvider{}, config)
+	ctx := context.Background()
+	_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com", Priority: outbound.EmailMarketing})
+
+	// Act
+	lifecycleErr := queue.Enqueue(ctx, outbound.Email{To: "b@example.com", Priority: outbound.EmailLifecycle})
+	transactionalErr := queue.Enqueue(ctx, outbound.Email{To: "c@example.com", Priority: outbound.EmailTransactional})
+
+	// Assert
+	assert.That(t, "lifecycle email must be rejected", errors.Is(lifecycleErr, outbound.ErrEmailQueueFull), true)
+	assert.That(t, "transactional email must be accepted", transactionalErr, nil)
+}
+
+func Test_EmailQueue_Should_Resume_Queued_Emails_After_Restart(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[string, outbound.Email]()
+	ctx := context.Background()
+	_ = createTestEmailQueue(repo, &recordingEmailProvider{}, testEmailQueueConfig()).Enqueue(ctx, outbound.Email{To: "a@example.com"})
+	provider := &recordingEmailProvider{}
+	restarted := createTestEmailQueue(repo, provider, testEmailQueueConfig())
+
+	// Act
+	sent, _ := restarted.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "queued email must be sent", sent, true)
+	assert.That(t, "provider must receive the email", provider.sent[0].To, "a@example.com")
+}
+
+func Test_EmailQueue_Start_Should_Respect_Rate_Limit(t *testing.T) {
+	// Arrange
+	provider := &recordingEmailProvider{}
+	config := testEmailQueueConfig()
+	config.RatePerSecond = 20
+	queue := createTestEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for range 4 {
+		_ = queue.Enqueue(ctx, outbound.Email{To: "a@example.com"})
+	}
+	start := time.Now()
+
+	// Act
+	queue.Start(ctx)
+	for provider.count() < 4 && time.Since(start) < 2*time.Second {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Assert
+	assert.That(t, "all emails must be sent", provider.count(), 4)
+	assert.That(t, "sends must be spaced at 50ms", time.Since(start) >= 150*time.Millisecond, true)
+}
+
+// ============================================================================
+// ParseEmailRateLimits Tests
+// ============================================================================
+
+func Test_ParseEmailRateLimits_Should_Parse_Limits_Per_Provider(t *testing.T) {
+	// Act
+	limits, err := outbound.ParseEmailRateLimits("log=14, smtp=0.5")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "limits must be parsed", limits, map[string]float64{"log": 14, "smtp": 0.5})
+}
+
+func Test_ParseEmailRateLimits_With_Invalid_Rate_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := outbound.ParseEmailRateLimits("log=fast")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,194 @@
+package outbound
+
+import "github.com/andygeiss/hotel-booking/internal/domain/shared"
+
+// emailTexts are the translated texts of the confirmation, cancellation, receipt and check-in emails.
+// Texts with %s are format strings; the HTML templates fill them in with printf.
+type emailTexts struct {
+	Greeting            string // guest name
+	Footer              string // reservation ID, app name
+	ConfirmationSubject string // reservation ID
+	ConfirmationIntro   string
+	ConfirmationText    string // check-in, check-out, total
+	Room                string
+	CheckIn             string
+	CheckOut            string
+	Total               string
+	Directions          string
+	PrintConfirmation   string
+	CancellationSubject string // reservation ID
+	CancellationIntro   string // check-in, check-out
+	CancellationText    string // reservation ID, reason
+	Reason              string
+	RefundNote          string
+	ReceiptSubject      string // reservation ID
+	ReceiptIntro        string
+	ReceiptText         string // amount, payment method
+	Amount              string
+	PaymentMethod       string
+	Payment             string
+	Transaction         string
+	ViewReservation     string
+	CheckInSubject      string // reservation ID
+	CheckInIntro        string
+	CheckInText         string // room, check-out
+	OpenInApp           string
+	CalendarSummary     string // property name, room
+	CalendarDescription string // confirmation code, nights, check-out
+	OneNight            string
+	Nights              string // number of nights
+}
+
+// defaultEmailLanguage is the language of the emails if neither the guest's nor the default locale is translated.
+const defaultEmailLanguage = "en"
+
+// emailLanguages lists the translations of the emails by language.
+var emailLanguages = map[string]emailTexts{
+	"en": {
+		Greeting:            "Dear %s,",
+		Footer:              "Reservation %s. This email was sent because of your booking with %s.",
+		ConfirmationSubject: "Your reservation %s is confirmed",
+		ConfirmationIntro:   "your stay is confirmed. We look forward to welcoming you.",
+		ConfirmationText:    "your stay from %s to %s is confirmed (%s).",
+		Room:                "Room",
+		CheckIn:             "Check-in",
+		CheckOut:            "Check-out",
+		Total:               "Total",
+		Directions:          "Get directions",
+		PrintConfirmation:   "Print your confirmation",
+		CancellationSubject: "Your reservation %s was cancelled",
+		CancellationIntro:   "your reservation for %s to %s was cancelled.",
+		CancellationText:    "your reservation %s was cancelled: %s",
+		Reason:              "Reason:",
+		RefundNote:          "Any payment made for this reservation is refunded according to the cancellation policy.",
+		ReceiptSubject:      "Your payment for reservation %s",
+		ReceiptIntro:        "thank you for your payment.",
+		ReceiptText:         "we received your payment of %s (%s).",
+		Amount:              "Amount",
+		PaymentMethod:       "Payment method",
+		Payment:             "Payment",
+		Transaction:         "Transaction",
+		ViewReservation:     "View your reservation",
+		CheckInSubject:      "Welcome to your stay %s",
+		CheckInIntro:        "you are checked in. We hope you enjoy your stay.",
+		CheckInText:         "you are checked in to room %s until %s.",
+		OpenInApp:           "Open in the app",
+		CalendarSummary:     "%s: room %s",
+		CalendarDescription: "Confirmation %s, %s, check-out %s",
+		OneNight:            "1 night",
+		Nights:              "%d nights",
+	},
+	"de": {
+		Greeting:            "Guten Tag %s,",
+		Footer:              "Reservierung %s. Sie erhalten diese E-Mail aufgrund Ihrer Buchung bei %s.",
+		ConfirmationSubject: "Ihre Reservierung %s ist bestätigt",
+		ConfirmationIntro:   "Ihr Aufenthalt ist bestätigt. Wir freuen uns auf Ihren Besuch.",
+		ConfirmationText:    "Ihr Aufenthalt vom %s bis %s ist bestätigt (%s).",
+		Room:                "Zimmer",
+		CheckIn:             "Anreise",
+		CheckOut:            "Abreise",
+		Total:               "Gesamt",
+		Directions:          "Route anzeigen",
+		PrintConfirmation:   "Bestätigung drucken",
+		CancellationSubject: "Ihre Reservierung %s wurde storniert",
+		CancellationIntro:   "Ihre Reservierung vom %s bis %s wurde storniert.",
+		CancellationText:    "Ihre Reservierung %s wurde storniert: %s",
+		Reason:              "Grund:",
+		RefundNote:          "Zahlungen für diese Reservierung werden gemäß den Stornobedingungen erstattet.",
+		ReceiptSubject:      "Ihre Zahlung für die Reservierung %s",
+		ReceiptIntro:        "vielen Dank für Ihre Zahlung.",
+		ReceiptText:         "wir haben Ihre Zahlung über %s erhalten (%s).",
+		Amount:              "Betrag",
+		PaymentMethod:       "Zahlungsart",
+		Payment:             "Zahlung",
+		Transaction:         "Transaktion",
+		ViewReservation:     "Reservierung ansehen",
+		CheckInSubject:      "Willkommen zu Ihrem Aufenthalt %s",
+		CheckInIntro:        "Sie sind eingecheckt. Wir wünschen Ihnen einen angenehmen Aufenthalt.",
+		CheckInText:         "Sie sind bis zum %[2]s in Zimmer %[1]s eingecheckt.",
+		OpenInApp:           "In der App öffnen",
+		CalendarSummary:     "%s: Zimmer %s",
+		CalendarDescription: "Bestätigung %s, %s, Abreise %s",
+		OneNight:            "1 Nacht",
+		Nights:              "%d Nächte",
+	},
+	"es": {
+		Greeting:            "Hola %s,",
+		Footer:              "Reserva %s. Recibe este correo por su reserva en %s.",
+		ConfirmationSubject: "Su reserva %s está confirmada",
+		ConfirmationIntro:   "su estancia está confirmada. Esperamos darle la bienvenida.",
+		ConfirmationText:    "su estancia del %s al %s está confirmada (%s).",
+		Room:                "Habitación",
+		CheckIn:             "Entrada",
+		CheckOut:            "Salida",
+		Total:               "Total",
+		Directions:          "Cómo llegar",
+		PrintConfirmation:   "Imprimir su confirmación",
+		CancellationSubject: "Su reserva %s ha sido cancelada",
+		CancellationIntro:   "su reserva del %s al %s ha sido cancelada.",
+		CancellationText:    "su reserva %s ha sido cancelada: %s",
+		Reason:              "Motivo:",
+		RefundNote:          "Cualquier pago realizado para esta reserva se reembolsará según la política de cancelación.",
+		ReceiptSubject:      "Su pago de la reserva %s",
+		ReceiptIntro:        "gracias por su pago.",
+		ReceiptText:         "hemos recibido su pago de %s (%s).",
+		Amount:              "Importe",
+		PaymentMethod:       "Método de pago",
+		Payment:             "Pago",
+		Transaction:         "Transacción",
+		ViewReservation:     "Ver su reserva",
+		CheckInSubject:      "Bienvenido a su estancia %s",
+		CheckInIntro:        "ya ha hecho el check-in. Le deseamos una agradable estancia.",
+		CheckInText:         "ha hecho el check-in en la habitación %s hasta el %s.",
+		OpenInApp:           "Abrir en la app",
+		CalendarSummary:     "%s: habitación %s",
+		CalendarDescription: "Confirmación %s, %s, salida %s",
+		OneNight:            "1 noche",
+		Nights:              "%d noches",
+	},
+	"fr": {
+		Greeting:            "Bonjour %s,",
+		Footer:              "Réservation %s. Cet e-mail vous a été envoyé suite à votre réservation auprès de %s.",
+		ConfirmationSubject: "Votre réservation %s est confirmée",
+		ConfirmationIntro:   "votre séjour est confirmé. Nous nous réjouissons de vous accueillir.",
+		ConfirmationText:    "votre séjour du %s au %s est confirmé (%s).",
+		Room:                "Chambre",
+		CheckIn:             "Arrivée",
+		CheckOut:            "Départ",
+		Total:               "Total",
+		Directions:          "Itinéraire",
+		PrintConfirmation:   "Imprimer votre confirmation",
+		CancellationSubject: "Votre réservation %s a été annulée",
+		CancellationIntro:   "votre réservation du %s au %s a été annulée.",
+		CancellationText:    "votre réservation %s a été annulée : %s",
+		Reason:              "Motif :",
+		RefundNote:          "Tout paiement effectué pour cette réservation est remboursé selon les conditions d'annulation.",
+		ReceiptSubject:      "Votre paiement pour la réservation %s",
+		ReceiptIntro:        "merci pour votre paiement.",
+		ReceiptText:         "nous avons bien reçu votre paiement de %s (%s).",
+		Amount:              "Montant",
+		PaymentMethod:       "Moyen de paiement",
+		Payment:             "Paiement",
+		Transaction:         "Transaction",
+		ViewReservation:     "Voir votre réservation",
+		CheckInSubject:      "Bienvenue pour votre séjour %s",
+		CheckInIntro:        "votre enregistrement est terminé. Nous vous souhaitons un agréable séjour.",
+		CheckInText:         "vous êtes enregistré dans la chambre %s jusqu'au %s.",
+		OpenInApp:           "Ouvrir dans l'application",
+		CalendarSummary:     "%s : chambre %s",
+		CalendarDescription: "Confirmation %s, %s, départ %s",
+		OneNight:            "1 nuit",
+		Nights:              "%d nuits",
+	},
+}
+
+// emailTextsFor returns the texts and language of the first locale whose language is translated,
+// or English if none is. Empty locales are skipped.
+func emailTextsFor(locales ...shared.Locale) (emailTexts, string) {
+	for _, locale := range locales {
+		if texts, ok := emailLanguages[locale.Language()]; ok && locale != "" {
+			return texts, locale.Language()
+		}
+	}
+	return emailLanguages[defaultEmailLanguage], defaultEmailLanguage
+}
@@ -0,0 +1,314 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the fault injection for resilience tests outside of production.
+// The decorators wrap the payment gateway, the repositories and the event dispatcher
+// and add latency, errors or dropped events at the rates set per target.
+
+// The targets faults can be injected into.
+const (
+	FaultTargetPaymentGateway        = "payment_gateway"
+	FaultTargetReservationRepository = "reservation_repository"
+	FaultTargetPaymentRepository     = "payment_repository"
+	FaultTargetEvents                = "events"
+)
+
+// FaultTargets lists the targets in the order of the admin endpoint.
+var FaultTargets = []string{FaultTargetEvents, FaultTargetPaymentGateway, FaultTargetPaymentRepository, FaultTargetReservationRepository}
+
+// ErrInjectedFault is returned by calls failed by the fault injection.
+var ErrInjectedFault = errors.New("injected fault")
+
+// Fault is what is injected into the calls of a target. Rates are between 0 and 1.
+type Fault struct {
+	Delay       time.Duration // added to a call at LatencyRate
+	LatencyRate float64
+	ErrorRate   float64
+	DropRate    float64 // only events: published events are discarded without an error
+}
+
+// ParseFault parses a fault in the format "delay=200ms,latency=0.5,error=0.1,drop=0.2".
+// Omitted settings are zero; an empty spec is no fault.
+func ParseFault(spec string) (Fault, error) {
+	var f Fault
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Fault{}, fmt.Errorf("invalid fault setting %q: want key=value", part)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if key == "delay" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return Fault{}, fmt.Errorf("invalid fault delay %q", value)
+			}
+			f.Delay = d
+			continue
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return Fault{}, fmt.Errorf("invalid fault rate %s=%q: want 0 to 1", key, value)
+		}
+		switch key {
+		case "latency":
+			f.LatencyRate = rate
+		case "error":
+			f.ErrorRate = rate
+		case "drop":
+			f.DropRate = rate
+		default:
+			return Fault{}, fmt.Errorf("unknown fault setting %q: want delay, latency, error or drop", key)
+		}
+	}
+	if f.LatencyRate > 0 && f.Delay == 0 {
+		return Fault{}, errors.New("fault latency needs a delay")
+	}
+	return f, nil
+}
+
+// String formats the fault like ParseFault reads it; no fault is the empty string.
+func (f Fault) String() string {
+	var parts []string
+	if f.Delay > 0 {
+		parts = append(parts, "delay="+f.Delay.String())
+	}
+	for _, setting := range []struct {
+		key  string
+		rate float64
+	}{{"latency", f.LatencyRate}, {"error", f.ErrorRate}, {"drop", f.DropRate}} {
+		if setting.rate > 0 {
+			parts = append(parts, setting.key+"="+strconv.FormatFloat(setting.rate, 'g', -1, 64))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// FaultInjector holds the faults per target, which can be changed at runtime (e.g. via the admin endpoint).
+type FaultInjector struct {
+	mu     sync.RWMutex
+	faults map[string]Fault
+	random func() float64
+}
+
+// NewFaultInjector creates a fault injector with the faults in the format
+// "target:fault;..." (e.g. "payment_gateway:error=0.2;events:drop=0.1").
+func NewFaultInjector(faults string) (*FaultInjector, error) {
+	f := &FaultInjector{faults: make(map[string]Fault), random: rand.Float64}
+	for _, part := range strings.Split(faults, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		target, spec, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fault %q: want target:settings", part)
+		}
+		if err := f.SetFault(strings.TrimSpace(target), spec); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// WithRandom replaces the source of the rates, e.g. with a fixed value in tests.
+func (f *FaultInjector) WithRandom(random func() float64) *FaultInjector {
+	f.random = random
+	return f
+}
+
+// Faults returns the fault of every target, an empty string if none is injected.
+func (f *FaultInjector) Faults() map[string]string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	faults := make(map[string]string, len(FaultTargets))
+	for _, target := range FaultTargets {
+		faults[target] = f.faults[target].String()
+	}
+	return faults
+}
+
+// SetFault replaces the fault of the target; an empty spec stops injecting into it.
+func (f *FaultInjector) SetFault(target, spec string) error {
+	if !slices.Contains(FaultTargets, target) {
+		return fmt.Errorf("unknown fault target %q: want one of %s", target, strings.Join(FaultTargets, ", "))
+	}
+	fault, err := ParseFault(spec)
+	if err != nil {
+		return err
+	}
+	if fault.DropRate > 0 && target != FaultTargetEvents {
+		return fmt.Errorf("fault drop only applies to %s", FaultTargetEvents)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[target] = fault
+	return nil
+}
+
+// Reset stops injecting faults into all targets.
+func (f *FaultInjector) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.faults)
+}
+
+// inject delays the call and fails it at the rates of the target.
+func (f *FaultInjector) inject(ctx context.Context, target string) error {
+	f.mu.RLock()
+	fault := f.faults[target]
+	f.mu.RUnlock()
+	if fault.LatencyRate > 0 && f.random() < fault.LatencyRate {
+		select {
+		case <-time.After(fault.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if fault.ErrorRate > 0 && f.random() < fault.ErrorRate {
+		return fmt.Errorf("%w: %s", ErrInjectedFault, target)
+	}
+	return nil
+}
+
+// drop reports whether to discard the call at the drop rate of the target.
+func (f *FaultInjector) drop(target string) bool {
+	f.mu.RLock()
+	rate := f.faults[target].DropRate
+	f.mu.RUnlock()
+	return rate > 0 && f.random() < rate
+}
+
+// FaultyPaymentGateway injects the faults of payment_gateway into a payment gateway.
+type FaultyPaymentGateway struct {
+	gateway payment.PaymentGateway
+	faults  *FaultInjector
+}
+
+// NewFaultyPaymentGateway wraps the gateway with the fault injection.
+func NewFaultyPaymentGateway(gateway payment.PaymentGateway, faults *FaultInjector) *FaultyPaymentGateway {
+	return &FaultyPaymentGateway{gateway: gateway, faults: faults}
+}
+
+// Authorize authorizes the payment unless a fault is injected.
+func (g *FaultyPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	if err := g.faults.inject(ctx, FaultTargetPaymentGateway); err != nil {
+		return "", err
+	}
+	return g.gateway.Authorize(ctx, p)
+}
+
+// Capture captures the payment unless a fault is injected.
+func (g *FaultyPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	if err := g.faults.inject(ctx, FaultTargetPaymentGateway); err != nil {
+		return err
+	}
+	return g.gateway.Capture(ctx, transactionID, amount)
+}
+
+// Refund refunds the payment unless a fault is injected.
+func (g *FaultyPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
+	if err := g.faults.inject(ctx, FaultTargetPaymentGateway); err != nil {
+		return err
+	}
+	return g.gateway.Refund(ctx, transactionID, amount)
+}
+
+// FaultyAccess injects the faults of a repository target into a key/value store.
+type FaultyAccess[K, V any] struct {
+	access resource.Access[K, V]
+	faults *FaultInjector
+	target string
+}
+
+// NewFaultyAccess wraps the store with the fault injection of the target.
+func NewFaultyAccess[K, V any](access resource.Access[K, V], faults *FaultInjector, target string) *FaultyAccess[K, V] {
+	return &FaultyAccess[K, V]{access: access, faults: faults, target: target}
+}
+
+// Create creates the value unless a fault is injected.
+func (a *FaultyAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	if err := a.faults.inject(ctx, a.target); err != nil {
+		return err
+	}
+	return a.access.Create(ctx, key, value)
+}
+
+// Read reads the value unless a fault is injected.
+func (a *FaultyAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	if err := a.faults.inject(ctx, a.target); err != nil {
+		return nil, err
+	}
+	return a.access.Read(ctx, key)
+}
+
+// ReadAll reads all values unless a fault is injected.
+func (a *FaultyAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	if err := a.faults.inject(ctx, a.target); err != nil {
+		return nil, err
+	}
+	return a.access.ReadAll(ctx)
+}
+
+// Update updates the value unless a fault is injected.
+func (a *FaultyAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	if err := a.faults.inject(ctx, a.target); err != nil {
+		return err
+	}
+	return a.access.Update(ctx, key, value)
+}
+
+// Delete deletes the value unless a fault is injected.
+func (a *FaultyAccess[K, V]) Delete(ctx context.Context, key K) error {
+	if err := a.faults.inject(ctx, a.target); err != nil {
+		return err
+	}
+	return a.access.Delete(ctx, key)
+}
+
+// FaultyDispatcher injects the faults of events into the published messages.
+// Subscriptions are not affected, so dropped events are never delivered.
+type FaultyDispatcher struct {
+	dispatcher messaging.Dispatcher
+	faults     *FaultInjector
+}
+
+// NewFaultyDispatcher wraps the dispatcher with the fault injection.
+func NewFaultyDispatcher(dispatcher messaging.Dispatcher, faults *FaultInjector) *FaultyDispatcher {
+	return &FaultyDispatcher{dispatcher: dispatcher, faults: faults}
+}
+
+// Publish publishes the message unless it is dropped or a fault is injected.
+func (d *FaultyDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	if err := d.faults.inject(ctx, FaultTargetEvents); err != nil {
+		return err
+	}
+	if d.faults.drop(FaultTargetEvents) {
+		return nil
+	}
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes to the topic of the wrapped dispatcher.
+func (d *FaultyDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, fn)
+}
@@ -0,0 +1,140 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+var (
+	_ payment.PaymentGateway    = (*outbound.FaultyPaymentGateway)(nil)
+	_ payment.PaymentRepository = (*outbound.FaultyAccess[payment.PaymentID, payment.Payment])(nil)
+	_ messaging.Dispatcher      = (*outbound.FaultyDispatcher)(nil)
+)
+
+// recordingDispatcher records the published messages.
+type recordingDispatcher struct {
+	published []messaging.Message
+}
+
+func (d *recordingDispatcher) Publish(ctx context.Context, message messaging.Message) error {
+	d.published = append(d.published, message)
+	return nil
+}
+
+func (d *recordingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return nil
+}
+
+// ============================================================================
+// ParseFault Tests
+// ============================================================================
+
+func Test_ParseFault_Should_Parse_All_Settings(t *testing.T) {
+	// Act
+	fault, err := outbound.ParseFault("delay=200ms, latency=0.5,error=0.1,drop=1")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "fault must match", fault, outbound.Fault{Delay: 200 * time.Millisecond, LatencyRate: 0.5, ErrorRate: 0.1, DropRate: 1})
+	assert.That(t, "string must be canonical", fault.String(), "delay=200ms,latency=0.5,error=0.1,drop=1")
+}
+
+func Test_ParseFault_With_Invalid_Settings_Should_Return_Error(t *testing.T) {
+	for _, spec := range []string{"error=2", "error", "jitter=0.1", "latency=0.5", "delay=soon"} {
+		// Act
+		_, err := outbound.ParseFault(spec)
+
+		// Assert
+		assert.That(t, spec+" must be rejected", err != nil, true)
+	}
+}
+
+// ============================================================================
+// FaultInjector Tests
+// ============================================================================
+
+func Test_NewFaultInjector_Should_Set_Faults_Per_Target(t *testing.T) {
+	// Act
+	faults, err := outbound.NewFaultInjector("payment_gateway:error=0.2;events:drop=0.1")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "faults must match", faults.Faults(), map[string]string{
+		outbound.FaultTargetEvents:                "drop=0.1",
+		outbound.FaultTargetPaymentGateway:        "error=0.2",
+		outbound.FaultTargetPaymentRepository:     "",
+		outbound.FaultTargetReservationRepository: "",
+	})
+}
+
+func Test_FaultInjector_SetFault_Should_Reject_Unknown_Target_And_Drop_Outside_Events(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("")
+
+	// Act
+	unknownErr := faults.SetFault("weather", "error=1")
+	dropErr := faults.SetFault(outbound.FaultTargetPaymentGateway, "drop=1")
+
+	// Assert
+	assert.That(t, "unknown target must be rejected", unknownErr != nil, true)
+	assert.That(t, "drop must be rejected for the gateway", dropErr != nil, true)
+}
+
+func Test_FaultyPaymentGateway_Should_Fail_At_Error_Rate(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("payment_gateway:error=0.5")
+	random := 0.4
+	faults.WithRandom(func() float64 { return random })
+	gateway := outbound.NewFaultyPaymentGateway(outbound.NewMockPaymentGateway(), faults)
+	amount := shared.NewMoney(10000, "USD")
+
+	// Act
+	failedErr := gateway.Capture(context.Background(), "tx-1", amount)
+	random = 0.6
+	passedErr := gateway.Capture(context.Background(), "tx-1", amount)
+
+	// Assert
+	assert.That(t, "call below the rate must fail", errors.Is(failedErr, outbound.ErrInjectedFault), true)
+	assert.That(t, "call above the rate must reach the gateway", errors.Is(passedErr, outbound.ErrInjectedFault), false)
+}
+
+func Test_FaultyAccess_Should_Delay_At_Latency_Rate_Until_Context_Ends(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("reservation_repository:delay=1h,latency=1")
+	repo := outbound.NewFaultyAccess(resource.NewInMemoryAccess[string, string](), faults, outbound.FaultTargetReservationRepository)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := repo.Create(ctx, "res-001", "x")
+
+	// Assert
+	assert.That(t, "call must end with the context", errors.Is(err, context.DeadlineExceeded), true)
+}
+
+func Test_FaultyDispatcher_Should_Drop_Events_Without_Error(t *testing.T) {
+	// Arrange
+	faults, _ := outbound.NewFaultInjector("events:drop=1")
+	inner := &recordingDispatcher{}
+	dispatcher := outbound.NewFaultyDispatcher(inner, faults)
+
+	// Act
+	err := dispatcher.Publish(context.Background(), messaging.NewMessage("reservation.created", nil))
+	faults.Reset()
+	_ = dispatcher.Publish(context.Background(), messaging.NewMessage("reservation.confirmed", nil))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "only the event after the reset must be published", len(inner.published), 1)
+	assert.That(t, "published topic must match", inner.published[0].Topic, "reservation.confirmed")
+}
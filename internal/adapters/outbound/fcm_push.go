@@ -0,0 +1,98 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// FCMBackend implements PushBackend with the HTTP v1 API of Firebase Cloud Messaging for the apps of guests.
+// It authenticates with the service account of the instance, like BigQueryWarehouse.
+type FCMBackend struct {
+	client  *http.Client
+	apiURL  string
+	project string
+	ttl     time.Duration
+	tokens  *googleTokenSource
+}
+
+// NewFCMBackend creates a new FCM backend, e.g. with the "push" client of HTTPClients.
+// The apiURL is https://fcm.googleapis.com unless an emulator is used; access tokens are fetched
+// from tokenURL (DefaultBigQueryTokenURL, the metadata server) and cached until shortly before they expire.
+func NewFCMBackend(client *http.Client, apiURL, tokenURL, project string, ttl time.Duration) (*FCMBackend, error) {
+	if project == "" {
+		return nil, errors.New("fcm project required")
+	}
+	return &FCMBackend{
+		client:  client,
+		apiURL:  strings.TrimSuffix(apiURL, "/"),
+		project: project,
+		ttl:     ttl,
+		tokens:  &googleTokenSource{client: client, url: tokenURL, now: time.Now},
+	}, nil
+}
+
+// Platform returns profile.PushFCM.
+func (b *FCMBackend) Platform() profile.PushPlatform { return profile.PushFCM }
+
+// fcmRequest is the body of messages:send; data values must be strings.
+type fcmRequest struct {
+	Message struct {
+		Token        string            `json:"token"`
+		Notification map[string]string `json:"notification"`
+		Data         map[string]string `json:"data"`
+		Android      struct {
+			TTL string `json:"ttl"`
+		} `json:"android"`
+	} `json:"message"`
+}
+
+// Send sends the message as a notification to the registration token of the subscription.
+// The message ID, token and URL are passed as data, so the app reports the engagement and opens the page.
+func (b *FCMBackend) Send(ctx context.Context, subscription profile.PushSubscription, message PushMessage) error {
+	token, err := b.tokens.token(ctx)
+	if err != nil {
+		return err
+	}
+	var in fcmRequest
+	in.Message.Token = subscription.Token
+	in.Message.Notification = map[string]string{"title": message.Title, "body": message.Body}
+	in.Message.Data = map[string]string{"id": message.ID, "token": message.Token, "url": message.URL}
+	in.Message.Android.TTL = fmt.Sprintf("%ds", int(b.ttl.Seconds()))
+	data, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode fcm message: %w", err)
+	}
+
+	target := b.apiURL + "/v1/projects/" + url.PathEscape(b.project) + "/messages:send"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create fcm request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send fcm message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		// UNREGISTERED: the app was uninstalled or the token expired.
+		return ErrPushSubscriptionGone
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("fcm returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// ============================================================================
+// FCMBackend Tests
+// ============================================================================
+
+func Test_FCMBackend_Send_Should_Post_Notification_To_Token(t *testing.T) {
+	// Arrange
+	var path string
+	var body struct {
+		Message struct {
+			Token        string            `json:"token"`
+			Notification map[string]string `json:"notification"`
+			Data         map[string]string `json:"data"`
+		} `json:"message"`
+	}
+	srv, _ := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"name":"projects/hotel/messages/1"}`))
+	})
+	backend, _ := outbound.NewFCMBackend(srv.Client(), srv.URL, srv.URL+"/token", "hotel", time.Hour)
+	subscription := profile.PushSubscription{Platform: profile.PushFCM, Token: "fcm-token-1"}
+
+	// Act
+	err := backend.Send(context.Background(), subscription, outbound.PushMessage{ID: "msg-1", Title: "Confirmed", Body: "See you soon", URL: "http://localhost:8080/ui/reservations/res-001"})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "path must be messages:send of the project", path, "/v1/projects/hotel/messages:send")
+	assert.That(t, "token must be the registration token", body.Message.Token, "fcm-token-1")
+	assert.That(t, "title must be sent", body.Message.Notification["title"], "Confirmed")
+	assert.That(t, "message ID must be sent as data", body.Message.Data["id"], "msg-1")
+}
+
+func Test_FCMBackend_Send_To_Unregistered_Token_Should_Return_ErrPushSubscriptionGone(t *testing.T) {
+	// Arrange
+	srv, _ := createTestBigQueryServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+	})
+	backend, _ := outbound.NewFCMBackend(srv.Client(), srv.URL, srv.URL+"/token", "hotel", time.Hour)
+
+	// Act
+	err := backend.Send(context.Background(), profile.PushSubscription{Platform: profile.PushFCM, Token: "fcm-token-1"}, outbound.PushMessage{ID: "msg-1"})
+
+	// Assert
+	assert.That(t, "err must be ErrPushSubscriptionGone", errors.Is(err, outbound.ErrPushSubscriptionGone), true)
+}
@@ -0,0 +1,281 @@
+package outbound
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ErrInternalAddress is returned by public clients for destinations at internal addresses.
+var ErrInternalAddress = errors.New("destination is an internal address")
+
+// HTTPClientConfig configures the HTTP clients of the outbound adapters.
+type HTTPClientConfig struct {
+	Timeout       time.Duration // limit of a call including its retries
+	MaxRetries    int           // retries of idempotent calls; 0 disables retries
+	RetryDelay    time.Duration // delay before the first retry, doubled for every further retry
+	RetryMaxDelay time.Duration // upper bound of the retry delay, also for Retry-After
+	ProxyURL      string        // empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	CAFile        string        // optional PEM bundle trusted in addition to the system roots
+}
+
+// DefaultHTTPClientConfig returns timeouts and retries suitable for third-party APIs.
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		Timeout:       10 * time.Second,
+		MaxRetries:    2,
+		RetryDelay:    200 * time.Millisecond,
+		RetryMaxDelay: 2 * time.Second,
+	}
+}
+
+// HTTPClients creates the HTTP clients of the outbound adapters, one per destination
+// (e.g. "weather", "oidc"). All clients share one connection pool with the proxy and
+// TLS settings, retry idempotent calls with backoff and count their calls per destination.
+type HTTPClients struct {
+	config    HTTPClientConfig
+	transport *http.Transport
+	logger    *slog.Logger
+	tracer    *Tracer
+
+	mu           sync.Mutex
+	destinations map[string]*destinationMetrics
+}
+
+// destinationMetrics counts the calls to a destination.
+type destinationMetrics struct {
+	requests  atomic.Int64
+	retries   atomic.Int64
+	failures  atomic.Int64
+	latencyMs atomic.Int64
+}
+
+// NewHTTPClients creates a new HTTP client factory.
+func NewHTTPClients(config HTTPClientConfig, logger *slog.Logger) (*HTTPClients, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = 5 * time.Second
+	transport.ResponseHeaderTimeout = config.Timeout
+
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL: %q", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", config.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	return &HTTPClients{
+		config:       config,
+		transport:    transport,
+		logger:       logger,
+		destinations: make(map[string]*destinationMetrics),
+	}, nil
+}
+
+// WithTracer traces the calls of the clients returned from now on and propagates their traces
+// to the destinations. Clients returned before, e.g. the one of the tracer, are not traced.
+func (c *HTTPClients) WithTracer(tracer *Tracer) *HTTPClients {
+	c.tracer = tracer
+	return c
+}
+
+// Client returns a client for the destination. Clients of the same destination share their metrics.
+func (c *HTTPClients) Client(destination string) *http.Client {
+	return c.client(destination, c.transport)
+}
+
+// PublicClient returns a client for the destination that refuses to connect to loopback, private,
+// link-local and unspecified addresses, e.g. for URLs chosen by guests. The resolved address is checked
+// when dialing, so names pointing to internal addresses are refused too. The client bypasses the proxy,
+// which would hide the address of the destination, and has a connection pool of its own.
+func (c *HTTPClients) PublicClient(destination string) *http.Client {
+	transport := c.transport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second, Control: refuseInternalAddress}).DialContext
+	return c.client(destination, transport)
+}
+
+// refuseInternalAddress is the dialer control of public clients.
+func refuseInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return fmt.Errorf("%w: %s", ErrInternalAddress, host)
+	}
+	return nil
+}
+
+// client returns a client for the destination sending its requests with the transport.
+func (c *HTTPClients) client(destination string, base http.RoundTripper) *http.Client {
+	var transport http.RoundTripper = &retryTransport{
+		next:        base,
+		config:      c.config,
+		destination: destination,
+		metrics:     c.metrics(destination),
+		logger:      c.logger,
+	}
+	if c.tracer != nil {
+		transport = &tracingTransport{next: transport, tracer: c.tracer, destination: destination}
+	}
+	return &http.Client{Timeout: c.config.Timeout, Transport: transport}
+}
+
+// Metrics returns the calls, retries, failures and total latency of every destination.
+func (c *HTTPClients) Metrics() map[string]map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metrics := make(map[string]map[string]int64, len(c.destinations))
+	for name, m := range c.destinations {
+		metrics[name] = map[string]int64{
+			"requests":   m.requests.Load(),
+			"retries":    m.retries.Load(),
+			"failures":   m.failures.Load(),
+			"latency_ms": m.latencyMs.Load(),
+		}
+	}
+	return metrics
+}
+
+// metrics returns the metrics of a destination, creating them on first use.
+func (c *HTTPClients) metrics(destination string) *destinationMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.destinations[destination]
+	if !ok {
+		m = &destinationMetrics{}
+		c.destinations[destination] = m
+	}
+	return m
+}
+
+// retryTransport retries idempotent requests on network errors and temporary server errors.
+type retryTransport struct {
+	next        http.RoundTripper
+	config      HTTPClientConfig
+	destination string
+	metrics     *destinationMetrics
+	logger      *slog.Logger
+}
+
+// RoundTrip sends the request, retrying with exponential backoff if it is safe to do so.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	t.metrics.requests.Add(1)
+	defer func() { t.metrics.latencyMs.Add(time.Since(start).Milliseconds()) }()
+
+	delay := t.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.config.MaxRetries || !retryable(req, resp, err) {
+			if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+				t.metrics.failures.Add(1)
+			}
+			return resp, err
+		}
+
+		wait := min(delay, t.config.RetryMaxDelay)
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				wait = min(after, t.config.RetryMaxDelay)
+			}
+			_ = resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			// A RoundTripper must not modify the caller's request.
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		t.metrics.retries.Add(1)
+		t.logger.Debug("retrying http request",
+			"destination", t.destination,
+			"attempt", attempt+1,
+			"retry_in", wait.String(),
+			"status", statusOf(resp),
+			"error", err,
+		)
+		select {
+		case <-req.Context().Done():
+			t.metrics.failures.Add(1)
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether the request may be sent again.
+// Only idempotent requests are retried; a POST is idempotent if it carries an Idempotency-Key.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if req.Header.Get("Idempotency-Key") == "" {
+			return false
+		}
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		// A cancelled or expired call must not be retried.
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay requested by a Retry-After header in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// statusOf returns the status code of the response or 0 without a response.
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
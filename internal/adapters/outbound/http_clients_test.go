@@ -0,0 +1,157 @@
+package outbound_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// createTestHTTPClients returns a factory with short retry delays.
+func createTestHTTPClients(t *testing.T) *outbound.HTTPClients {
+	t.Helper()
+	config := outbound.DefaultHTTPClientConfig()
+	config.RetryDelay = time.Millisecond
+	config.RetryMaxDelay = 5 * time.Millisecond
+	clients, err := outbound.NewHTTPClients(config, slog.Default())
+	assert.That(t, "error must be nil", err, nil)
+	return clients
+}
+
+// flakyServer answers the first failures calls with 503 and all later ones with 200.
+func flakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// ============================================================================
+// HTTPClients Tests
+// ============================================================================
+
+func Test_HTTPClients_Get_With_Temporary_Failure_Should_Retry(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(t, 2)
+	clients := createTestHTTPClients(t)
+
+	// Act
+	resp, err := clients.Client("weather").Get(srv.URL)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	_ = resp.Body.Close()
+	assert.That(t, "status must be 200", resp.StatusCode, http.StatusOK)
+	assert.That(t, "server must be called 3 times", calls.Load(), int32(3))
+	assert.That(t, "retries must be counted", clients.Metrics()["weather"]["retries"], int64(2))
+	assert.That(t, "call must not count as failure", clients.Metrics()["weather"]["failures"], int64(0))
+}
+
+func Test_HTTPClients_Post_Should_Not_Retry(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(t, 1)
+	clients := createTestHTTPClients(t)
+
+	// Act
+	resp, err := clients.Client("webhook").Post(srv.URL, "application/json", strings.NewReader(`{}`))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	_ = resp.Body.Close()
+	assert.That(t, "status must be 503", resp.StatusCode, http.StatusServiceUnavailable)
+	assert.That(t, "server must be called once", calls.Load(), int32(1))
+	assert.That(t, "failure must be counted", clients.Metrics()["webhook"]["failures"], int64(1))
+}
+
+func Test_HTTPClients_Post_With_Idempotency_Key_Should_Retry_With_Body(t *testing.T) {
+	// Arrange
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	clients := createTestHTTPClients(t)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"id":1}`))
+	req.Header.Set("Idempotency-Key", "evt-1")
+
+	// Act
+	resp, err := clients.Client("webhook").Do(req)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	_ = resp.Body.Close()
+	assert.That(t, "status must be 200", resp.StatusCode, http.StatusOK)
+	assert.That(t, "body must be sent again", bodies, []string{`{"id":1}`, `{"id":1}`})
+}
+
+func Test_HTTPClients_Get_Should_Give_Up_After_Max_Retries(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(t, 10)
+	clients := createTestHTTPClients(t)
+
+	// Act
+	resp, err := clients.Client("weather").Get(srv.URL)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	_ = resp.Body.Close()
+	assert.That(t, "status must be 503", resp.StatusCode, http.StatusServiceUnavailable)
+	assert.That(t, "server must be called 3 times", calls.Load(), int32(3))
+	assert.That(t, "requests must be counted once", clients.Metrics()["weather"]["requests"], int64(1))
+}
+
+func Test_NewHTTPClients_With_Invalid_Proxy_Should_Return_Error(t *testing.T) {
+	// Arrange
+	config := outbound.DefaultHTTPClientConfig()
+	config.ProxyURL = "not a url"
+
+	// Act
+	_, err := outbound.NewHTTPClients(config, slog.Default())
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_HTTPClients_PublicClient_To_Internal_Address_Should_Be_Refused(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+	config := outbound.DefaultHTTPClientConfig()
+	config.MaxRetries = 0
+	clients, _ := outbound.NewHTTPClients(config, slog.Default())
+
+	// Act
+	_, err := clients.PublicClient("guest_webhook").Get(server.URL)
+
+	// Assert
+	assert.That(t, "error must be ErrInternalAddress", errors.Is(err, outbound.ErrInternalAddress), true)
+	assert.That(t, "server must not be called", calls.Load(), int32(0))
+}
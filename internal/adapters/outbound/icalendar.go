@@ -0,0 +1,110 @@
+package outbound
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// icalLineLimit is the length in octets after which iCalendar lines are folded (RFC 5545, 3.1).
+const icalLineLimit = 75
+
+// ICalendar renders reservations as iCalendar (RFC 5545), for the calendar feed of the guests and
+// the attachment of the confirmation email. Each stay is an all-day event from the check-in day to
+// the check-out day. Its UID is derived from the reservation ID, so a calendar updates the event
+// instead of adding a second one when it imports the feed or a later email again.
+type ICalendar struct {
+	productName string
+	uiURL       string
+	host        string // domain of the UIDs
+	location    string
+}
+
+// NewICalendar creates a renderer whose events are named after the product, e.g. the property name,
+// and link the reservation pages below uiURL (e.g. http://localhost:8080/ui).
+func NewICalendar(productName, uiURL string) *ICalendar {
+	host := "hotel-booking"
+	if u, err := url.Parse(uiURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return &ICalendar{productName: productName, uiURL: strings.TrimSuffix(uiURL, "/"), host: host}
+}
+
+// WithLocation sets the address of the property as location of the events.
+func (c *ICalendar) WithLocation(address string) *ICalendar {
+	c.location = address
+	return c
+}
+
+// Calendar returns the calendar named name with an event per reservation, whose summary and
+// description are in the language of the locale, e.g. the guest's, as in the emails.
+func (c *ICalendar) Calendar(name string, locale shared.Locale, reservations []*reservation.Reservation) []byte {
+	texts, language := emailTextsFor(locale)
+	var buf bytes.Buffer
+	line := func(property, value string) {
+		writeICalLine(&buf, property+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//"+escapeICalText(c.productName)+"//Reservations//"+strings.ToUpper(language))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escapeICalText(name))
+	for _, res := range reservations {
+		stamp := res.UpdatedAt
+		if stamp.IsZero() {
+			stamp = res.CreatedAt
+		}
+		nights := fmt.Sprintf(texts.Nights, res.Nights())
+		if res.Nights() == 1 {
+			nights = texts.OneNight
+		}
+		link := c.uiURL + "/reservations/" + string(res.ID)
+
+		line("BEGIN", "VEVENT")
+		line("UID", string(res.ID)+"@"+c.host)
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", res.DateRange.CheckIn.Format("20060102"))
+		// The end of all-day events is exclusive, so the event ends the day after the check-out.
+		line("DTEND;VALUE=DATE", res.DateRange.CheckOut.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeICalText(fmt.Sprintf(texts.CalendarSummary, c.productName, res.RoomID)))
+		line("DESCRIPTION", escapeICalText(fmt.Sprintf(texts.CalendarDescription, res.ConfirmationCode(), nights,
+			res.DateRange.CheckOut.Format("2006-01-02"))+"\n"+link))
+		if c.location != "" {
+			line("LOCATION", escapeICalText(c.location))
+		}
+		line("URL", link)
+		line("STATUS", "CONFIRMED")
+		// The stay does not make the guest busy at home, so it does not block the calendar.
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeICalText escapes a TEXT value: backslashes, semicolons, commas and line breaks.
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeICalLine writes the content line terminated by CRLF, folded after 75 octets without
+// splitting a UTF-8 character; continuation lines start with a space.
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = icalLineLimit - 1 // the leading space counts
+	}
+	buf.WriteString(line + "\r\n")
+}
@@ -0,0 +1,77 @@
+package outbound_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ICalendar Tests
+// ============================================================================
+
+func createCalendarTestReservation() *reservation.Reservation {
+	return &reservation.Reservation{
+		ID:          "res-001",
+		RoomID:      "room-101",
+		DateRange:   reservation.NewDateRange(time.Date(2030, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2030, 3, 12, 0, 0, 0, 0, time.UTC)),
+		Status:      reservation.StatusConfirmed,
+		TotalAmount: shared.NewMoney(19800, "USD"),
+		UpdatedAt:   time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func Test_ICalendar_Calendar_Should_Render_Stay_As_All_Day_Event(t *testing.T) {
+	// Arrange
+	calendar := outbound.NewICalendar("Seaside Hotel", "https://booking.example.com/ui").WithLocation("Beach Road 1, Kiel")
+
+	// Act
+	ics := string(calendar.Calendar("My stays", "en-US", []*reservation.Reservation{createCalendarTestReservation()}))
+
+	// Assert
+	assert.That(t, "calendar must be framed", strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), true)
+	assert.That(t, "calendar must end with CRLF", strings.HasSuffix(ics, "END:VCALENDAR\r\n"), true)
+	for _, line := range []string{
+		"UID:res-001@booking.example.com",
+		"DTSTAMP:20300102T030405Z",
+		"DTSTART;VALUE=DATE:20300310",
+		"DTEND;VALUE=DATE:20300313",
+		"SUMMARY:Seaside Hotel: room room-101",
+		`LOCATION:Beach Road 1\, Kiel`,
+		"URL:https://booking.example.com/ui/reservations/res-001",
+	} {
+		assert.That(t, "calendar must contain "+line, strings.Contains(ics, "\r\n"+line+"\r\n"), true)
+	}
+}
+
+func Test_ICalendar_Calendar_In_German_Should_Render_German_Texts(t *testing.T) {
+	// Arrange
+	calendar := outbound.NewICalendar("Seaside Hotel", "https://booking.example.com/ui")
+
+	// Act
+	ics := string(calendar.Calendar("Meine Aufenthalte", "de-DE", []*reservation.Reservation{createCalendarTestReservation()}))
+
+	// Assert
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.That(t, "summary must be German", strings.Contains(unfolded, "\r\nSUMMARY:Seaside Hotel: Zimmer room-101\r\n"), true)
+	assert.That(t, "description must be German", strings.Contains(unfolded, "2 Nächte\\, Abreise 2030-03-12"), true)
+}
+
+func Test_ICalendar_Calendar_Should_Fold_Long_Lines(t *testing.T) {
+	// Arrange
+	calendar := outbound.NewICalendar("Hotel "+strings.Repeat("ü", 60), "https://booking.example.com/ui")
+
+	// Act
+	ics := string(calendar.Calendar("My stays", "en-US", []*reservation.Reservation{createCalendarTestReservation()}))
+
+	// Assert
+	for line := range strings.SplitSeq(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.That(t, "line must have at most 75 octets: "+line, len(line) <= 75, true)
+	}
+	assert.That(t, "unfolded summary must be complete", strings.Contains(strings.ReplaceAll(ics, "\r\n ", ""), "SUMMARY:Hotel "+strings.Repeat("ü", 60)+": room room-101\r\n"), true)
+}
@@ -0,0 +1,151 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// TableAccess is the key/value table of an aggregate type, created at startup by Init.
+// PostgresTableAccess, SQLiteTableAccess and InMemoryTableAccess implement it.
+type TableAccess[K comparable, V any] interface {
+	resource.Access[K, V]
+	Init(ctx context.Context) error
+}
+
+// NewTableAccess creates the table in the database, or in memory if there is no database (STORAGE_BACKEND=memory).
+// The SQL dialect follows the driver the database was opened with (STORAGE_BACKEND=sqlite).
+func NewTableAccess[K comparable, V any](db *sql.DB, table string) (TableAccess[K, V], error) {
+	if db == nil {
+		return NewInMemoryTableAccess[K, V](), nil
+	}
+	if IsSQLite(db) {
+		access, err := NewSQLiteTableAccess[K, V](db, table)
+		if err != nil {
+			return nil, err
+		}
+		return access, nil
+	}
+	access, err := NewPostgresTableAccess[K, V](db, table)
+	if err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// InMemoryTableAccess is the counterpart of PostgresTableAccess in memory, for tests and local development
+// without Postgres. Values are stored JSON-encoded like in the tables, so callers never share slices or maps
+// with a stored value, and a field that does not survive the encoding is lost here too.
+// It behaves like the tables: creating an existing key fails, updating and deleting a missing key do nothing.
+type InMemoryTableAccess[K comparable, V any] struct {
+	mu     sync.RWMutex
+	values map[K][]byte
+}
+
+// NewInMemoryTableAccess creates a new, empty key/value access in memory.
+func NewInMemoryTableAccess[K comparable, V any]() *InMemoryTableAccess[K, V] {
+	return &InMemoryTableAccess[K, V]{values: make(map[K][]byte)}
+}
+
+// NewInMemoryReservationRepository creates a reservation repository in memory.
+func NewInMemoryReservationRepository() *InMemoryTableAccess[reservation.ReservationID, reservation.Reservation] {
+	return NewInMemoryTableAccess[reservation.ReservationID, reservation.Reservation]()
+}
+
+// NewInMemoryPaymentRepository creates a payment repository in memory.
+func NewInMemoryPaymentRepository() *InMemoryTableAccess[payment.PaymentID, payment.Payment] {
+	return NewInMemoryTableAccess[payment.PaymentID, payment.Payment]()
+}
+
+// Init does nothing; the map exists from the start.
+func (a *InMemoryTableAccess[K, V]) Init(ctx context.Context) error {
+	return nil
+}
+
+// Create inserts a new key/value pair.
+func (a *InMemoryTableAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.values[key]; ok {
+		return errors.New(resource.ErrorResourceAlreadyExists)
+	}
+	a.values[key] = encoded
+	return nil
+}
+
+// Read returns the value of the key.
+func (a *InMemoryTableAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.RLock()
+	encoded, ok := a.values[key]
+	a.mu.RUnlock()
+	if !ok {
+		return nil, errors.New(resource.ErrorResourceNotFound)
+	}
+	var value V
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// ReadAll returns all values, in no particular order like the tables.
+func (a *InMemoryTableAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	values := make([]V, 0, len(a.values))
+	for _, encoded := range a.values {
+		var value V
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Update replaces the value of the key.
+func (a *InMemoryTableAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.values[key]; ok {
+		a.values[key] = encoded
+	}
+	return nil
+}
+
+// Delete removes the key.
+func (a *InMemoryTableAccess[K, V]) Delete(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.values, key)
+	return nil
+}
@@ -0,0 +1,98 @@
+package outbound_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Both in-memory repositories must satisfy the ports of their contexts.
+var (
+	_ reservation.ReservationRepository = outbound.NewInMemoryReservationRepository()
+	_ payment.PaymentRepository         = outbound.NewInMemoryPaymentRepository()
+)
+
+// ============================================================================
+// InMemoryTableAccess Tests
+// ============================================================================
+
+func Test_InMemoryTableAccess_Read_Should_Not_Share_Stored_Value(t *testing.T) {
+	// Arrange
+	access := outbound.NewInMemoryTableAccess[string, []string]()
+	ctx := context.Background()
+	_ = access.Create(ctx, "k", []string{"a"})
+
+	// Act
+	first, _ := access.Read(ctx, "k")
+	(*first)[0] = "changed"
+	second, err := access.Read(ctx, "k")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "stored value must be unchanged", (*second)[0], "a")
+}
+
+func Test_InMemoryTableAccess_Create_Existing_Key_Should_Fail(t *testing.T) {
+	// Arrange
+	access := outbound.NewInMemoryTableAccess[string, string]()
+	ctx := context.Background()
+	_ = access.Create(ctx, "k", "a")
+
+	// Act
+	err := access.Create(ctx, "k", "b")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_InMemoryTableAccess_Update_Missing_Key_Should_Not_Create_It(t *testing.T) {
+	// Arrange
+	access := outbound.NewInMemoryTableAccess[string, string]()
+	ctx := context.Background()
+
+	// Act
+	err := access.Update(ctx, "k", "a")
+	_, readErr := access.Read(ctx, "k")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "key must not be found", readErr != nil, true)
+}
+
+func Test_InMemoryTableAccess_Concurrent_Writes_Should_Keep_All_Values(t *testing.T) {
+	// Arrange
+	access := outbound.NewInMemoryPaymentRepository()
+	ctx := context.Background()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := payment.PaymentID(fmt.Sprintf("pay-%d", i))
+			_ = access.Create(ctx, id, payment.Payment{ID: id})
+		}()
+	}
+	wg.Wait()
+	payments, err := access.ReadAll(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "all payments must be stored", len(payments), 50)
+}
+
+func Test_NewTableAccess_Without_Database_Should_Use_Memory(t *testing.T) {
+	// Arrange & Act
+	access, err := outbound.NewTableAccess[string, string](nil, "household_kv_store")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "init must succeed", access.Init(context.Background()), nil)
+}
@@ -0,0 +1,73 @@
+package outbound
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// LocalLocks implements the lock ports of the domain, e.g. RoomLocks, with a lock per key in the memory
+// of the process. It only serializes the work of a single server instance; use PostgresLocks for replicas.
+type LocalLocks[K ~string] struct {
+	mu   sync.Mutex
+	keys map[K]chan struct{}
+	wait time.Duration
+	busy error
+}
+
+// NewLocalRoomLocks creates new in-process room locks that wait up to wait for a locked room.
+func NewLocalRoomLocks(wait time.Duration) *LocalLocks[reservation.RoomID] {
+	return newLocalLocks[reservation.RoomID](wait, reservation.ErrRoomBusy)
+}
+
+// NewLocalPromotionLocks creates new in-process promo code locks that wait up to wait for a locked code.
+func NewLocalPromotionLocks(wait time.Duration) *LocalLocks[promotion.Code] {
+	return newLocalLocks[promotion.Code](wait, promotion.ErrCodeBusy)
+}
+
+// NewLocalLoyaltyLocks creates new in-process locks of the guests' point balances that wait up to wait for a locked guest.
+func NewLocalLoyaltyLocks(wait time.Duration) *LocalLocks[loyalty.GuestID] {
+	return newLocalLocks[loyalty.GuestID](wait, loyalty.ErrPointsBusy)
+}
+
+// NewLocalInvoiceLocks creates new in-process locks of the invoice numbers that wait up to wait for a locked jurisdiction.
+func NewLocalInvoiceLocks(wait time.Duration) *LocalLocks[invoice.JurisdictionID] {
+	return newLocalLocks[invoice.JurisdictionID](wait, invoice.ErrNumbersBusy)
+}
+
+// newLocalLocks creates new in-process locks that return busy after waiting up to wait for a locked key.
+func newLocalLocks[K ~string](wait time.Duration, busy error) *LocalLocks[K] {
+	return &LocalLocks[K]{
+		keys: make(map[K]chan struct{}),
+		wait: wait,
+		busy: busy,
+	}
+}
+
+// Lock waits until the key is locked and returns the function that releases it.
+// It returns the busy error of the locks, e.g. ErrRoomBusy, if the key is still locked after the wait.
+func (l *LocalLocks[K]) Lock(ctx context.Context, key K) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.keys[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.keys[key] = lock
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-timer.C:
+		return nil, l.busy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// LocalLocks Tests
+// ============================================================================
+
+func Test_LocalRoomLocks_Lock_When_Room_Locked_Should_Return_ErrRoomBusy(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalRoomLocks(10 * time.Millisecond)
+	_, _ = locks.Lock(context.Background(), "room-101")
+
+	// Act
+	_, err := locks.Lock(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "err must be ErrRoomBusy", errors.Is(err, reservation.ErrRoomBusy), true)
+}
+
+func Test_LocalRoomLocks_Lock_Other_Room_Should_Not_Wait(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalRoomLocks(10 * time.Millisecond)
+	_, _ = locks.Lock(context.Background(), "room-101")
+
+	// Act
+	unlock, err := locks.Lock(context.Background(), "room-102")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "unlock must be returned", unlock != nil, true)
+}
+
+func Test_LocalRoomLocks_Lock_Should_Wait_Until_Released(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalRoomLocks(time.Second)
+	unlock, _ := locks.Lock(context.Background(), "room-101")
+	locked := make(chan error)
+	go func() {
+		_, err := locks.Lock(context.Background(), "room-101")
+		locked <- err
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	unlock()
+
+	// Assert
+	assert.That(t, "err must be nil", <-locked, nil)
+}
+
+func Test_LocalRoomLocks_Lock_With_Cancelled_Context_Should_Return_Context_Error(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalRoomLocks(time.Second)
+	_, _ = locks.Lock(context.Background(), "room-101")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := locks.Lock(ctx, "room-101")
+
+	// Assert
+	assert.That(t, "err must be context.Canceled", errors.Is(err, context.Canceled), true)
+}
+
+func Test_LocalPromotionLocks_Lock_When_Code_Locked_Should_Return_ErrCodeBusy(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalPromotionLocks(10 * time.Millisecond)
+	_, _ = locks.Lock(context.Background(), "SUMMER25")
+
+	// Act
+	_, err := locks.Lock(context.Background(), "SUMMER25")
+
+	// Assert
+	assert.That(t, "err must be ErrCodeBusy", errors.Is(err, promotion.ErrCodeBusy), true)
+}
+
+func Test_LocalInvoiceLocks_Lock_When_Jurisdiction_Locked_Should_Return_ErrNumbersBusy(t *testing.T) {
+	// Arrange
+	locks := outbound.NewLocalInvoiceLocks(10 * time.Millisecond)
+	_, _ = locks.Lock(context.Background(), "de")
+
+	// Act
+	_, err := locks.Lock(context.Background(), "de")
+
+	// Assert
+	assert.That(t, "err must be ErrNumbersBusy", errors.Is(err, invoice.ErrNumbersBusy), true)
+}
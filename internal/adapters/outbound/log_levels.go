@@ -0,0 +1,257 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// LogLevels manages JSON loggers with a log level and debug sampling per component.
+// Levels can be changed at runtime (e.g. via the admin endpoint) without a restart.
+type LogLevels struct {
+	base         slog.Handler
+	defaultLevel slog.Level
+	components   map[string]*logComponent
+	mu           sync.Mutex
+	unmasked     atomic.Bool
+}
+
+// logComponent holds the runtime settings shared by all loggers of a component.
+type logComponent struct {
+	level       slog.LevelVar
+	sampleEvery atomic.Int64
+	counter     atomic.Uint64
+}
+
+// NewLogLevels creates a new log level registry writing JSON to w.
+// Levels use the format "component=LEVEL,..." (e.g. "http=WARN,availability=DEBUG"),
+// sampling uses "component=N,..." to keep only every Nth debug record of a component.
+func NewLogLevels(w io.Writer, defaultLevel, levels, sampling string) (*LogLevels, error) {
+	l := &LogLevels{
+		// The base handler accepts everything; filtering happens per component.
+		base:       slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		components: make(map[string]*logComponent),
+	}
+	if err := l.Reset(defaultLevel, levels, sampling); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reset replaces the default level, the component levels and the sampling rates at once,
+// e.g. when the configuration is reloaded. Components not listed fall back to the default
+// level without sampling, which also reverts levels changed via the admin endpoint.
+// Nothing is changed if a setting is invalid.
+func (l *LogLevels) Reset(defaultLevel, levels, sampling string) error {
+	def, err := ParseLogLevel(defaultLevel)
+	if err != nil {
+		return err
+	}
+	parsedLevels := make(map[string]slog.Level)
+	for component, value := range parseLogSettings(levels) {
+		lvl, err := ParseLogLevel(value)
+		if err != nil {
+			return err
+		}
+		parsedLevels[component] = lvl
+	}
+	parsedSampling := make(map[string]int64)
+	for component, value := range parseLogSettings(sampling) {
+		every, err := strconv.Atoi(value)
+		if err != nil || every < 1 {
+			return fmt.Errorf("invalid sampling rate for %s: %q", component, value)
+		}
+		parsedSampling[component] = int64(every)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultLevel = def
+	for name := range parsedLevels {
+		l.componentLocked(name)
+	}
+	for name := range parsedSampling {
+		l.componentLocked(name)
+	}
+	for name, c := range l.components {
+		lvl, ok := parsedLevels[name]
+		if !ok {
+			lvl = def
+		}
+		c.level.Set(lvl)
+		every, ok := parsedSampling[name]
+		if !ok {
+			every = 1
+		}
+		c.sampleEvery.Store(every)
+	}
+	return nil
+}
+
+// Logger returns a logger for the component, tagged with a "component" attribute.
+func (l *LogLevels) Logger(component string) *slog.Logger {
+	h := &componentHandler{next: l.base, component: l.component(component), unmasked: &l.unmasked}
+	return slog.New(h).With("component", component)
+}
+
+// SetMasking switches the masking of emails, phone numbers and card numbers (see shared.MaskPII).
+// Masking is on by default; switch it off for full output in local development only.
+// Attributes added via With before the switch keep their previous masking.
+func (l *LogLevels) SetMasking(enabled bool) {
+	l.unmasked.Store(!enabled)
+}
+
+// Levels returns the current level of every known component.
+func (l *LogLevels) Levels() map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]string, len(l.components))
+	for name, c := range l.components {
+		levels[name] = c.level.Level().String()
+	}
+	return levels
+}
+
+// SetLevel changes the level of a component at runtime.
+func (l *LogLevels) SetLevel(component, level string) error {
+	lvl, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	l.component(component).level.Set(lvl)
+	return nil
+}
+
+// SetSampling keeps only every Nth debug record of a component; 1 disables sampling.
+func (l *LogLevels) SetSampling(component string, every int) {
+	l.component(component).sampleEvery.Store(int64(every))
+}
+
+// component returns the settings of a component, creating them with the default level if needed.
+func (l *LogLevels) component(name string) *logComponent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.componentLocked(name)
+}
+
+// componentLocked is component for callers holding the lock.
+func (l *LogLevels) componentLocked(name string) *logComponent {
+	c, ok := l.components[name]
+	if !ok {
+		c = &logComponent{}
+		c.level.Set(l.defaultLevel)
+		c.sampleEvery.Store(1)
+		l.components[name] = c
+	}
+	return c
+}
+
+// ParseLogLevel parses DEBUG, INFO, WARN or ERROR (case-insensitive).
+func ParseLogLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level: %q", level)
+	}
+	return lvl, nil
+}
+
+// parseLogSettings parses "key=value,..." pairs and ignores malformed entries.
+func parseLogSettings(s string) map[string]string {
+	settings := make(map[string]string)
+	for part := range strings.SplitSeq(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && key != "" {
+			settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return settings
+}
+
+// componentHandler filters records by the component level, samples debug records and masks personal data.
+type componentHandler struct {
+	next      slog.Handler
+	component *logComponent
+	unmasked  *atomic.Bool
+}
+
+// Enabled reports whether the level is enabled for the component.
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.component.level.Level()
+}
+
+// Handle drops sampled-out debug records and forwards all others.
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	if every := h.component.sampleEvery.Load(); r.Level < slog.LevelInfo && every > 1 {
+		// Keep the first record and every Nth after it.
+		if (h.component.counter.Add(1)-1)%uint64(every) != 0 {
+			return nil
+		}
+		r.AddAttrs(slog.Int64("sample_every", every))
+	}
+	if !h.unmasked.Load() {
+		masked := slog.NewRecord(r.Time, r.Level, shared.MaskPII(r.Message), r.PC)
+		r.Attrs(func(a slog.Attr) bool {
+			masked.AddAttrs(maskAttr(a))
+			return true
+		})
+		r = masked
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler sharing the component settings.
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if !h.unmasked.Load() {
+		masked := make([]slog.Attr, len(attrs))
+		for i, a := range attrs {
+			masked[i] = maskAttr(a)
+		}
+		attrs = masked
+	}
+	return &componentHandler{next: h.next.WithAttrs(attrs), component: h.component, unmasked: h.unmasked}
+}
+
+// WithGroup returns a handler sharing the component settings.
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{next: h.next.WithGroup(name), component: h.component, unmasked: h.unmasked}
+}
+
+// maskAttr masks the personal data in strings, named string types, errors and stringers, also within groups.
+// Other values like numbers and times cannot contain any.
+func maskAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, shared.MaskPII(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		masked := make([]slog.Attr, len(group))
+		for i, member := range group {
+			masked[i] = maskAttr(member)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(masked...)}
+	case slog.KindAny:
+		switch value := v.Any().(type) {
+		case error:
+			return slog.String(a.Key, shared.MaskPII(value.Error()))
+		case fmt.Stringer:
+			return slog.String(a.Key, shared.MaskPII(value.String()))
+		}
+		// Named string types like IDs are logged as their string.
+		if rv := reflect.ValueOf(v.Any()); rv.Kind() == reflect.String {
+			return slog.String(a.Key, shared.MaskPII(rv.String()))
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
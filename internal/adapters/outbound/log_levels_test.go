@@ -0,0 +1,154 @@
+package outbound_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// LogLevels Tests
+// ============================================================================
+
+func Test_LogLevels_Logger_Should_Use_Component_Level(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, err := outbound.NewLogLevels(&buf, "INFO", "http=WARN,availability=DEBUG", "")
+	assert.That(t, "error must be nil", err, nil)
+
+	// Act
+	levels.Logger("http").Info("dropped")
+	levels.Logger("availability").Debug("kept")
+
+	// Assert
+	assert.That(t, "http info must be dropped", strings.Contains(buf.String(), "dropped"), false)
+	assert.That(t, "availability debug must be kept", strings.Contains(buf.String(), "kept"), true)
+	assert.That(t, "component must be logged", strings.Contains(buf.String(), `"component":"availability"`), true)
+}
+
+func Test_LogLevels_SetLevel_Should_Change_Level_At_Runtime(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "INFO", "", "")
+	logger := levels.Logger("server")
+
+	// Act
+	err := levels.SetLevel("server", "debug")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "debug must be enabled", logger.Enabled(context.Background(), slog.LevelDebug), true)
+	assert.That(t, "level must be listed", levels.Levels()["server"], "DEBUG")
+}
+
+func Test_LogLevels_SetLevel_With_Invalid_Level_Should_Return_Error(t *testing.T) {
+	// Arrange
+	levels, _ := outbound.NewLogLevels(&bytes.Buffer{}, "INFO", "", "")
+
+	// Act
+	err := levels.SetLevel("server", "LOUD")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_LogLevels_Sampling_Should_Keep_Every_Nth_Debug_Record(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "DEBUG", "", "availability=10")
+	logger := levels.Logger("availability")
+
+	// Act
+	for range 25 {
+		logger.Debug("availability query")
+	}
+	logger.Info("not sampled")
+
+	// Assert
+	assert.That(t, "debug records must be sampled", strings.Count(buf.String(), "availability query"), 3)
+	assert.That(t, "info records must not be sampled", strings.Count(buf.String(), "not sampled"), 1)
+}
+
+func Test_NewLogLevels_With_Invalid_Sampling_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewLogLevels(&bytes.Buffer{}, "INFO", "", "availability=zero")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_LogLevels_Reset_Should_Replace_All_Levels(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "INFO", "", "")
+	server, httpLogger := levels.Logger("server"), levels.Logger("http")
+	_ = levels.SetLevel("server", "DEBUG")
+
+	// Act
+	err := levels.Reset("WARN", "http=ERROR", "")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "server must fall back to the default", server.Enabled(context.Background(), slog.LevelInfo), false)
+	assert.That(t, "server warn must be enabled", server.Enabled(context.Background(), slog.LevelWarn), true)
+	assert.That(t, "http warn must be disabled", httpLogger.Enabled(context.Background(), slog.LevelWarn), false)
+}
+
+func Test_LogLevels_Reset_With_Invalid_Level_Should_Change_Nothing(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "INFO", "", "")
+	logger := levels.Logger("server")
+
+	// Act
+	err := levels.Reset("WARN", "http=LOUD", "")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "info must still be enabled", logger.Enabled(context.Background(), slog.LevelInfo), true)
+}
+
+func Test_LogLevels_Logger_Should_Mask_Personal_Data(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "INFO", "", "")
+	logger := levels.Logger("email").With("to", "john.doe@example.com")
+
+	// Act
+	logger.Info("sent to john.doe@example.com",
+		"phone", "+49 171 1234567",
+		"card", "4111 1111 1111 1111",
+		"error", errors.New("mailbox jane@example.com is full"),
+		"reservation_id", "res-1001",
+		"check_in", "2026-05-01",
+	)
+
+	// Assert
+	out := buf.String()
+	assert.That(t, "email must not be logged", strings.Contains(out, "john.doe@"), false)
+	assert.That(t, "email domain must be kept", strings.Contains(out, `j***@example.com`), true)
+	assert.That(t, "phone middle digits must be masked", strings.Contains(out, `"phone":"+49 *** *****67"`), true)
+	assert.That(t, "card must keep last four digits", strings.Contains(out, `"card":"**** **** **** 1111"`), true)
+	assert.That(t, "error must be masked", strings.Contains(out, `"error":"mailbox j***@example.com is full"`), true)
+	assert.That(t, "ids must be kept", strings.Contains(out, `"reservation_id":"res-1001"`), true)
+	assert.That(t, "dates must be kept", strings.Contains(out, `"check_in":"2026-05-01"`), true)
+}
+
+func Test_LogLevels_SetMasking_Disabled_Should_Log_Full_Output(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels, _ := outbound.NewLogLevels(&buf, "INFO", "", "")
+	levels.SetMasking(false)
+
+	// Act
+	levels.Logger("email").Info("sent", "to", "john.doe@example.com")
+
+	// Assert
+	assert.That(t, "email must be logged in full", strings.Contains(buf.String(), `"to":"john.doe@example.com"`), true)
+}
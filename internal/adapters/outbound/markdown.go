@@ -0,0 +1,150 @@
+package outbound
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// RenderMarkdown renders the subset of markdown used by the content pages to HTML.
+// It supports headings, paragraphs, unordered and ordered lists, code blocks,
+// inline code, bold, italic and links. Raw HTML is escaped, and links with
+// unsafe schemes (e.g. javascript:) are rendered as plain text, so the output is safe to embed.
+func RenderMarkdown(src string) string {
+	var b strings.Builder
+	var paragraph []string
+	list := ""
+	inCode := false
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderInline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			b.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+	openList := func(tag string) {
+		if list != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			list = tag
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if inCode {
+				b.WriteString("</code></pre>\n")
+				inCode = false
+			} else {
+				flushParagraph()
+				closeList()
+				b.WriteString("<pre><code>")
+				inCode = true
+			}
+			continue
+		}
+		if inCode {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case markdownHeading.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			m := markdownHeading.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+		case markdownUnorderedItem.MatchString(trimmed):
+			flushParagraph()
+			openList("ul")
+			b.WriteString("<li>" + renderInline(markdownUnorderedItem.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		case markdownOrderedItem.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			b.WriteString("<li>" + renderInline(markdownOrderedItem.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	if inCode {
+		b.WriteString("</code></pre>\n")
+	}
+	flushParagraph()
+	closeList()
+	return b.String()
+}
+
+// MarkdownTitle returns the text of the first level-one heading, or an empty string.
+func MarkdownTitle(src string) string {
+	for _, line := range strings.Split(src, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
+
+var (
+	markdownHeading       = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownUnorderedItem = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	markdownOrderedItem   = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	markdownInlineCode    = regexp.MustCompile("`([^`]+)`")
+	markdownLink          = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBold          = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownItalic        = regexp.MustCompile(`\*([^*]+)\*`)
+)
+
+// renderInline renders the inline elements of a line.
+// Code spans are rendered first and protected, so their content is not formatted.
+func renderInline(text string) string {
+	var codes []string
+	text = markdownInlineCode.ReplaceAllStringFunc(text, func(m string) string {
+		codes = append(codes, "<code>"+html.EscapeString(m[1:len(m)-1])+"</code>")
+		return "\x00" + string(rune('0'+len(codes)-1)) + "\x00"
+	})
+
+	text = html.EscapeString(text)
+	text = markdownLink.ReplaceAllStringFunc(text, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		label, href := parts[1], html.UnescapeString(parts[2])
+		if !isSafeLink(href) {
+			return label
+		}
+		return `<a href="` + html.EscapeString(href) + `">` + label + "</a>"
+	})
+	text = markdownBold.ReplaceAllString(text, "<strong>$1</strong>")
+	text = markdownItalic.ReplaceAllString(text, "<em>$1</em>")
+
+	for i, code := range codes {
+		text = strings.Replace(text, "\x00"+string(rune('0'+i))+"\x00", code, 1)
+	}
+	return text
+}
+
+// isSafeLink checks if the link is relative, an anchor, or uses the http, https, mailto or tel scheme.
+func isSafeLink(href string) bool {
+	lower := strings.ToLower(href)
+	if strings.HasPrefix(lower, "/") || strings.HasPrefix(lower, "#") {
+		return true
+	}
+	for _, scheme := range []string{"http://", "https://", "mailto:", "tel:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return !strings.Contains(lower, ":")
+}
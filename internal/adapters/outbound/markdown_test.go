@@ -0,0 +1,71 @@
+package outbound_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// RenderMarkdown Tests
+// ============================================================================
+
+func Test_RenderMarkdown_Should_Render_Headings_Paragraphs_And_Lists(t *testing.T) {
+	// Arrange
+	src := "# FAQ\n\n## Check-In\n\nCheck-in is from\n**3 PM**.\n\n- one\n- two\n\n1. first\n2. second\n"
+
+	// Act
+	out := outbound.RenderMarkdown(src)
+
+	// Assert
+	assert.That(t, "output must contain h1", strings.Contains(out, "<h1>FAQ</h1>"), true)
+	assert.That(t, "output must contain h2", strings.Contains(out, "<h2>Check-In</h2>"), true)
+	assert.That(t, "output must join paragraph lines", strings.Contains(out, "<p>Check-in is from <strong>3 PM</strong>.</p>"), true)
+	assert.That(t, "output must contain unordered list", strings.Contains(out, "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"), true)
+	assert.That(t, "output must contain ordered list", strings.Contains(out, "<ol>\n<li>first</li>\n<li>second</li>\n</ol>"), true)
+}
+
+func Test_RenderMarkdown_Should_Render_Inline_Elements(t *testing.T) {
+	// Arrange
+	src := "See *the* [policies](/ui/pages/policies) and `a **b**`."
+
+	// Act
+	out := outbound.RenderMarkdown(src)
+
+	// Assert
+	assert.That(t, "output must render inline elements", out, "<p>See <em>the</em> <a href=\"/ui/pages/policies\">policies</a> and <code>a **b**</code>.</p>\n")
+}
+
+func Test_RenderMarkdown_Should_Escape_HTML(t *testing.T) {
+	// Arrange
+	src := "<script>alert(1)</script>\n\n```\n<b>code</b>\n```"
+
+	// Act
+	out := outbound.RenderMarkdown(src)
+
+	// Assert
+	assert.That(t, "output must not contain script tag", strings.Contains(out, "<script>"), false)
+	assert.That(t, "output must contain escaped script tag", strings.Contains(out, "&lt;script&gt;"), true)
+	assert.That(t, "output must contain escaped code block", strings.Contains(out, "<pre><code>&lt;b&gt;code&lt;/b&gt;\n</code></pre>"), true)
+}
+
+func Test_RenderMarkdown_With_Unsafe_Link_Should_Render_Label_Only(t *testing.T) {
+	// Arrange
+	src := "[click](javascript:alert(1))"
+
+	// Act
+	out := outbound.RenderMarkdown(src)
+
+	// Assert
+	assert.That(t, "output must not contain link", strings.Contains(out, "<a "), false)
+}
+
+func Test_MarkdownTitle_Should_Return_First_Heading(t *testing.T) {
+	// Arrange & Act
+	title := outbound.MarkdownTitle("intro\n# Policies\n# Other")
+
+	// Assert
+	assert.That(t, "title must match", title, "Policies")
+}
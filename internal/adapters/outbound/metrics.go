@@ -0,0 +1,171 @@
+package outbound
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMetricBuckets are the upper bounds of histogram buckets in seconds, as the Prometheus clients use them.
+var DefaultMetricBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics keeps counters and histograms in memory and writes them in the Prometheus text format.
+// Metrics are created on first use; Describe adds the help text and the buckets of a histogram.
+// It implements shared.Metrics for the domain services, and the format is written by hand,
+// because the Prometheus client library would add a dependency tree for a few counters.
+type Metrics struct {
+	mu         sync.Mutex
+	help       map[string]string
+	buckets    map[string][]float64
+	counters   map[string]map[string]float64          // by name and labels
+	histograms map[string]map[string]*metricHistogram // by name and labels
+}
+
+// metricHistogram is a series of a histogram.
+type metricHistogram struct {
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewMetrics creates an empty metrics registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		help:       make(map[string]string),
+		buckets:    make(map[string][]float64),
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*metricHistogram),
+	}
+}
+
+// Describe sets the help text of the metric and, for histograms, the upper bounds of the buckets
+// (DefaultMetricBuckets if none). Buckets must be set before the first observation.
+func (m *Metrics) Describe(name, help string, buckets ...float64) *Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.help[name] = help
+	if len(buckets) > 0 {
+		m.buckets[name] = slices.Sorted(slices.Values(buckets))
+	}
+	return m
+}
+
+// IncCounter adds 1 to the counter with the labels.
+func (m *Metrics) IncCounter(name string, labels ...string) {
+	key := formatMetricLabels(labels)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	series, ok := m.counters[name]
+	if !ok {
+		series = make(map[string]float64)
+		m.counters[name] = series
+	}
+	series[key]++
+}
+
+// ObserveHistogram records the value in the histogram with the labels.
+func (m *Metrics) ObserveHistogram(name string, value float64, labels ...string) {
+	key := formatMetricLabels(labels)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	series, ok := m.histograms[name]
+	if !ok {
+		series = make(map[string]*metricHistogram)
+		m.histograms[name] = series
+	}
+	bounds := m.bucketsLocked(name)
+	h, ok := series[key]
+	if !ok {
+		h = &metricHistogram{counts: make([]uint64, len(bounds))}
+		series[key] = h
+	}
+	if i, _ := slices.BinarySearch(bounds, value); i < len(bounds) {
+		h.counts[i]++
+	}
+	h.sum += value
+	h.count++
+}
+
+// WritePrometheus writes all metrics in the Prometheus text exposition format, sorted by name and labels.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(m.counters)) {
+		m.writeHeaderLocked(&b, name, "counter")
+		series := m.counters[name]
+		for _, key := range slices.Sorted(maps.Keys(series)) {
+			fmt.Fprintf(&b, "%s%s %s\n", name, key, formatMetricValue(series[key]))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.histograms)) {
+		m.writeHeaderLocked(&b, name, "histogram")
+		bounds := m.bucketsLocked(name)
+		series := m.histograms[name]
+		for _, key := range slices.Sorted(maps.Keys(series)) {
+			h := series[key]
+			var cumulative uint64
+			for i, bound := range bounds {
+				cumulative += h.counts[i]
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withMetricLabel(key, "le", formatMetricValue(bound)), cumulative)
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", name, withMetricLabel(key, "le", "+Inf"), h.count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", name, key, formatMetricValue(h.sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", name, key, h.count)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeHeaderLocked writes the HELP and TYPE lines of the metric.
+func (m *Metrics) writeHeaderLocked(b *strings.Builder, name, kind string) {
+	if help, ok := m.help[name]; ok {
+		fmt.Fprintf(b, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	}
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, kind)
+}
+
+// bucketsLocked returns the upper bounds of the buckets of the histogram.
+func (m *Metrics) bucketsLocked(name string) []float64 {
+	if bounds, ok := m.buckets[name]; ok {
+		return bounds
+	}
+	return DefaultMetricBuckets
+}
+
+// formatMetricLabels formats name/value pairs as {name="value",...}; an odd last name is dropped.
+func formatMetricLabels(labels []string) string {
+	if len(labels) < 2 {
+		return ""
+	}
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		parts = append(parts, labels[i]+`="`+escape.Replace(labels[i+1])+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// withMetricLabel adds a label to formatted labels.
+func withMetricLabel(key, name, value string) string {
+	label := name + `="` + value + `"`
+	if key == "" {
+		return "{" + label + "}"
+	}
+	return strings.TrimSuffix(key, "}") + "," + label + "}"
+}
+
+// formatMetricValue formats a sample value like the Prometheus clients.
+func formatMetricValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,77 @@
+package outbound_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+var _ shared.Metrics = (*outbound.Metrics)(nil)
+
+// ============================================================================
+// Metrics Tests
+// ============================================================================
+
+func Test_Metrics_WritePrometheus_Should_Write_Counters_By_Labels(t *testing.T) {
+	// Arrange
+	metrics := outbound.NewMetrics().Describe("hotel_payment_failures_total", "Failed payments.")
+	metrics.IncCounter("hotel_payment_failures_total", "error_code", "gateway_error")
+	metrics.IncCounter("hotel_payment_failures_total", "error_code", "capture_failed")
+	metrics.IncCounter("hotel_payment_failures_total", "error_code", "gateway_error")
+	var buf bytes.Buffer
+
+	// Act
+	err := metrics.WritePrometheus(&buf)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "output must match", buf.String(), `# HELP hotel_payment_failures_total Failed payments.
+# TYPE hotel_payment_failures_total counter
+hotel_payment_failures_total{error_code="capture_failed"} 1
+hotel_payment_failures_total{error_code="gateway_error"} 2
+`)
+}
+
+func Test_Metrics_WritePrometheus_Should_Write_Cumulative_Buckets(t *testing.T) {
+	// Arrange
+	metrics := outbound.NewMetrics().Describe("hotel_saga_seconds", "Saga duration.", 1, 0.5)
+	metrics.ObserveHistogram("hotel_saga_seconds", 0.25, "outcome", "completed")
+	metrics.ObserveHistogram("hotel_saga_seconds", 0.75, "outcome", "completed")
+	metrics.ObserveHistogram("hotel_saga_seconds", 3, "outcome", "completed")
+	var buf bytes.Buffer
+
+	// Act
+	err := metrics.WritePrometheus(&buf)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "output must match", buf.String(), `# HELP hotel_saga_seconds Saga duration.
+# TYPE hotel_saga_seconds histogram
+hotel_saga_seconds_bucket{outcome="completed",le="0.5"} 1
+hotel_saga_seconds_bucket{outcome="completed",le="1"} 2
+hotel_saga_seconds_bucket{outcome="completed",le="+Inf"} 3
+hotel_saga_seconds_sum{outcome="completed"} 4
+hotel_saga_seconds_count{outcome="completed"} 3
+`)
+}
+
+func Test_Metrics_WritePrometheus_Should_Escape_Label_Values(t *testing.T) {
+	// Arrange
+	metrics := outbound.NewMetrics()
+	metrics.IncCounter("hotel_x_total", "reason", "say \"no\"\n")
+	metrics.IncCounter("hotel_y_total")
+	var buf bytes.Buffer
+
+	// Act
+	_ = metrics.WritePrometheus(&buf)
+
+	// Assert
+	assert.That(t, "output must match", buf.String(), `# TYPE hotel_x_total counter
+hotel_x_total{reason="say \"no\"\n"} 1
+# TYPE hotel_y_total counter
+hotel_y_total 1
+`)
+}
@@ -0,0 +1,221 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/migrations"
+)
+
+// migrationLockClass is the first key of the advisory lock taken while migrating ("MIGR"),
+// so replicas starting at the same time migrate one after the other.
+const migrationLockClass = 0x4d494752
+
+// Migration is a numbered change of the schema with the SQL to apply and to revert it.
+type Migration struct {
+	Version string // file name without the suffix, e.g. 0001_kv_store
+	Up      string
+	Down    string
+}
+
+// Migrator applies and reverts the embedded migrations of a database (migrations.FS)
+// and records the applied versions in its schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	database   string
+	migrations []Migration
+	sqlite     bool
+}
+
+// NewMigrator creates the migrator of the database (reservation or payment).
+func NewMigrator(db *sql.DB, database string) (*Migrator, error) {
+	list, err := LoadMigrations(migrations.FS, database)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, database: database, migrations: list, sqlite: IsSQLite(db)}, nil
+}
+
+// LoadMigrations reads the migrations of the database directory, ordered by version.
+// Every up file needs its down file, so every migration can be reverted.
+func LoadMigrations(fsys fs.FS, database string) ([]Migration, error) {
+	ups, err := fs.Glob(fsys, path.Join(database, "*.up.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(ups) == 0 {
+		return nil, fmt.Errorf("no migrations for database %q", database)
+	}
+	slices.Sort(ups)
+	list := make([]Migration, 0, len(ups))
+	for _, up := range ups {
+		version := strings.TrimSuffix(path.Base(up), ".up.sql")
+		upSQL, err := fs.ReadFile(fsys, up)
+		if err != nil {
+			return nil, err
+		}
+		downSQL, err := fs.ReadFile(fsys, path.Join(database, version+".down.sql"))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no down file: %w", version, err)
+		}
+		list = append(list, Migration{Version: version, Up: string(upSQL), Down: string(downSQL)})
+	}
+	return list, nil
+}
+
+// Up applies the migrations that were not applied yet, oldest first, each in its own transaction.
+// It returns the versions applied.
+func (m *Migrator) Up(ctx context.Context) ([]string, error) {
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var done []string
+	for _, migration := range m.migrations {
+		if slices.Contains(applied, migration.Version) {
+			continue
+		}
+		err := m.run(ctx, conn, migration.Up, "INSERT INTO schema_migrations (version, applied_at) VALUES ("+m.placeholder(1)+", "+m.placeholder(2)+")",
+			migration.Version, time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %s of the %s database: %w", migration.Version, m.database, err)
+		}
+		done = append(done, migration.Version)
+	}
+	return done, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns the versions reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]string, error) {
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var done []string
+	for _, migration := range slices.Backward(m.migrations) {
+		if len(done) == steps {
+			break
+		}
+		if !slices.Contains(applied, migration.Version) {
+			continue
+		}
+		err := m.run(ctx, conn, migration.Down, "DELETE FROM schema_migrations WHERE version = "+m.placeholder(1), migration.Version)
+		if err != nil {
+			return done, fmt.Errorf("failed to revert migration %s of the %s database: %w", migration.Version, m.database, err)
+		}
+		done = append(done, migration.Version)
+	}
+	return done, nil
+}
+
+// Pending returns the versions that are not applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]string, error) {
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, migration := range m.migrations {
+		if !slices.Contains(applied, migration.Version) {
+			pending = append(pending, migration.Version)
+		}
+	}
+	return pending, nil
+}
+
+// lock takes a connection and, in PostgreSQL, the advisory lock of the migrations on it.
+// SQLite has a single connection, so holding it is the lock.
+func (m *Migrator) lock(ctx context.Context) (*sql.Conn, func(), error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	if m.sqlite {
+		return conn, func() { _ = conn.Close() }, nil
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1, hashtext($2))", migrationLockClass, m.database); err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	unlock := func() {
+		// A lock that cannot be released is released when the connection is discarded.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1, hashtext($2))", migrationLockClass, m.database); err != nil {
+			discardConn(conn)
+			return
+		}
+		_ = conn.Close()
+	}
+	return conn, unlock, nil
+}
+
+// appliedVersions creates the version table if needed and returns the versions recorded in it.
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) ([]string, error) {
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY, applied_at TEXT NOT NULL)"); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	var versions []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// run executes the script and the statement recording it in one transaction,
+// so a failed migration leaves neither a half-changed schema nor a record.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, script, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if strings.TrimSpace(script) != "" {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// placeholder returns the n-th bind parameter in the dialect of the database.
+func (m *Migrator) placeholder(n int) string {
+	if m.sqlite {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
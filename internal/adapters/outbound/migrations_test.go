@@ -0,0 +1,79 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Migrator Tests
+// ============================================================================
+
+func Test_Migrator_Up_Should_Apply_Migrations_Once(t *testing.T) {
+	// Arrange
+	db := openTestSQLite(t)
+	ctx := context.Background()
+	migrator, _ := outbound.NewMigrator(db, "reservation")
+
+	// Act
+	first, firstErr := migrator.Up(ctx)
+	second, secondErr := migrator.Up(ctx)
+	pending, _ := migrator.Pending(ctx)
+	createErr := outbound.NewSQLiteReservationRepository(db).Create(ctx, "res-1", reservation.Reservation{ID: "res-1"})
+
+	// Assert
+	assert.That(t, "first run must succeed", firstErr, nil)
+	assert.That(t, "first run must apply the migrations in order", first[0], "0001_kv_store")
+	assert.That(t, "second run must succeed", secondErr, nil)
+	assert.That(t, "second run must apply nothing", len(second), 0)
+	assert.That(t, "nothing must be pending", len(pending), 0)
+	assert.That(t, "reservations must be stored in kv_store", createErr, nil)
+}
+
+func Test_Migrator_Down_Should_Revert_Latest_Migration(t *testing.T) {
+	// Arrange
+	db := openTestSQLite(t)
+	ctx := context.Background()
+	migrator, _ := outbound.NewMigrator(db, "reservation")
+	applied, _ := migrator.Up(ctx)
+
+	// Act
+	reverted, err := migrator.Down(ctx, 1)
+	pending, _ := migrator.Pending(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "latest migration must be reverted", reverted, []string{applied[len(applied)-1]})
+	assert.That(t, "reverted migration must be pending", pending, reverted)
+}
+
+func Test_NewMigrator_With_Unknown_Database_Should_Return_Error(t *testing.T) {
+	// Arrange
+	db := openTestSQLite(t)
+
+	// Act
+	_, err := outbound.NewMigrator(db, "unknown")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_LoadMigrations_Without_Down_File_Should_Return_Error(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"payment/0001_kv_store.up.sql":   {Data: []byte("CREATE TABLE kv_store (key TEXT)")},
+		"payment/0001_kv_store.down.sql": {Data: []byte("DROP TABLE kv_store")},
+		"payment/0002_index.up.sql":      {Data: []byte("CREATE INDEX idx ON kv_store (key)")},
+	}
+
+	// Act
+	_, err := outbound.LoadMigrations(fsys, "payment")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -1,26 +1,190 @@
 package outbound
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"html"
 	"log/slog"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/andygeiss/cloud-native-utils/templating"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
 )
 
 // MockNotificationService implements NotificationService by logging to console.
+// With an outbox, the emails are also queued for delivery; with templates, confirmations,
+// cancellations, receipts and check-in welcomes get an HTML body in addition to the plain text.
+// These four are written in the guest's preferred language (WithProfiles), falling back to
+// the language of the default locale and then to English.
 type MockNotificationService struct {
-	logger *slog.Logger
+	logger       *slog.Logger
+	uiURL        string
+	propertyMaps *PropertyMaps
+	outbox       EmailOutbox
+	locale       shared.Locale
+	templates    *templating.Engine
+	appName      string
+	reservations *reservation.Service
+	profiles     *profile.Service
+	calendar     *ICalendar
+	deepLinks    *DeepLinks
+}
+
+// emailView is the data of the HTML email templates ("email_*").
+// The values are HTML-escaped, because the templating engine is based on text/template.
+type emailView struct {
+	AppName         string
+	Subject         string
+	GuestName       string
+	ReservationID   string
+	RoomID          string
+	CheckIn         string
+	CheckOut        string
+	Amount          string
+	PrintLink       string
+	AppLink         string // opens the reservation in the companion app; "" without an app
+	WebLink         string // web fallback of the app link
+	PropertyAddress string
+	DirectionsLink  string
+	Reason          string
+	PaymentID       string
+	PaymentMethod   string
+	TransactionID   string
+	Language        string     // of the html element, e.g. "de"
+	T               emailTexts // translated texts, which are not escaped
 }
 
 // NewMockNotificationService creates a new mock notification service.
-func NewMockNotificationService(logger *slog.Logger) *MockNotificationService {
+// The uiURL (e.g. http://localhost:8080/ui) is used to link the reservation pages from the emails.
+// If propertyMaps is not nil, confirmations include the property address and directions.
+func NewMockNotificationService(logger *slog.Logger, uiURL string, propertyMaps *PropertyMaps) *MockNotificationService {
 	return &MockNotificationService{
-		logger: logger,
+		logger:       logger,
+		uiURL:        strings.TrimSuffix(uiURL, "/"),
+		propertyMaps: propertyMaps,
+		locale:       shared.DefaultLocale,
 	}
 }
 
+// WithOutbox queues the emails in the outbox, e.g. an EmailQueue, in addition to logging them.
+func (s *MockNotificationService) WithOutbox(outbox EmailOutbox) *MockNotificationService {
+	s.outbox = outbox
+	return s
+}
+
+// WithLocale formats the amounts in the emails in the locale, e.g. "de-DE" (default en-US),
+// and writes them in its language for guests without a preferred language.
+func (s *MockNotificationService) WithLocale(locale shared.Locale) *MockNotificationService {
+	s.locale = locale
+	return s
+}
+
+// escaped returns the view with HTML-escaped values.
+func (v emailView) escaped() emailView {
+	e := html.EscapeString
+	return emailView{
+		AppName: e(v.AppName), Subject: e(v.Subject), GuestName: e(v.GuestName),
+		ReservationID: e(v.ReservationID), RoomID: e(v.RoomID), CheckIn: e(v.CheckIn), CheckOut: e(v.CheckOut),
+		Amount: e(v.Amount), PrintLink: e(v.PrintLink), AppLink: e(v.AppLink), WebLink: e(v.WebLink), PropertyAddress: e(v.PropertyAddress),
+		DirectionsLink: e(v.DirectionsLink), Reason: e(v.Reason),
+		PaymentID: e(v.PaymentID), PaymentMethod: e(v.PaymentMethod), TransactionID: e(v.TransactionID),
+		Language: v.Language, T: v.T,
+	}
+}
+
+// WithTemplates renders HTML bodies with the engine, which must have parsed the email templates
+// (e.g. assets/emails/*.tmpl). The appName is shown in the header of the emails.
+func (s *MockNotificationService) WithTemplates(engine *templating.Engine, appName string) *MockNotificationService {
+	s.templates = engine
+	s.appName = appName
+	return s
+}
+
+// WithReservations queues payment receipts to the primary guest of the paid reservation.
+// Without it receipts are only logged, because payments do not know the guest's email.
+func (s *MockNotificationService) WithReservations(reservationService *reservation.Service) *MockNotificationService {
+	s.reservations = reservationService
+	return s
+}
+
+// WithProfiles writes confirmations, cancellations, receipts and check-in welcomes in the preferred language of the guest.
+func (s *MockNotificationService) WithProfiles(profileService *profile.Service) *MockNotificationService {
+	s.profiles = profileService
+	return s
+}
+
+// WithCalendar attaches the stay as iCalendar event to confirmations, so guests add it to their calendar.
+func (s *MockNotificationService) WithCalendar(calendar *ICalendar) *MockNotificationService {
+	s.calendar = calendar
+	return s
+}
+
+// WithDeepLinks adds links that open the reservation in the companion app, with the web page as fallback,
+// to confirmations, cancellations and check-in welcomes.
+func (s *MockNotificationService) WithDeepLinks(deepLinks *DeepLinks) *MockNotificationService {
+	s.deepLinks = deepLinks
+	return s
+}
+
+// appLinks sets the app link of the reservation and its web fallback on the view and returns them as
+// lines of the plain text body, or "" without an app.
+func (s *MockNotificationService) appLinks(res *reservation.Reservation, view *emailView) string {
+	if s.deepLinks == nil {
+		return ""
+	}
+	link := s.deepLinks.Link(res.PropertyID, "/reservations/"+string(res.ID))
+	if link.App == "" {
+		return ""
+	}
+	view.AppLink, view.WebLink = link.App, link.Web
+	return view.T.OpenInApp + ": " + link.App + "\n" + view.T.ViewReservation + ": " + link.Web + "\n"
+}
+
+// guestLocale returns the preferred locale of the guest, or the default locale if the guest has none.
+func (s *MockNotificationService) guestLocale(ctx context.Context, guestID reservation.GuestID) shared.Locale {
+	if s.profiles != nil {
+		if locale, ok := s.profiles.LanguageOf(ctx, profile.GuestID(guestID)); ok {
+			return locale
+		}
+	}
+	return s.locale
+}
+
+// renderHTML returns the HTML body of the email or "" without templates.
+// A failing template is logged and the email is sent as plain text, so guests still get it.
+func (s *MockNotificationService) renderHTML(name string, view emailView) string {
+	if s.templates == nil {
+		return ""
+	}
+	view.AppName = s.appName
+	var buf bytes.Buffer
+	if err := s.templates.Render(&buf, name, view.escaped()); err != nil {
+		s.logger.Warn("failed to render email template", "template", name, "error", err)
+		return ""
+	}
+	return buf.String()
+}
+
+// enqueue queues the email if an outbox is configured.
+func (s *MockNotificationService) enqueue(ctx context.Context, email Email) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.Enqueue(ctx, email)
+}
+
 // SendReservationConfirmation logs a confirmation message.
 func (s *MockNotificationService) SendReservationConfirmation(
 	ctx context.Context,
@@ -32,17 +196,73 @@ func (s *MockNotificationService) SendReservationConfirmation(
 
 	primaryGuest := res.Guests[0]
 
-	s.logger.Info("sending reservation confirmation email",
+	attrs := []any{
 		"reservation_id", res.ID,
 		"guest_email", primaryGuest.Email,
 		"guest_name", primaryGuest.Name,
 		"room_id", res.RoomID,
 		"check_in", res.DateRange.CheckIn.Format("2006-01-02"),
 		"check_out", res.DateRange.CheckOut.Format("2006-01-02"),
-		"total_amount", res.TotalAmount.FormatAmount(),
-	)
+		"total_amount", res.TotalAmount.FormatIn(s.locale),
+		"print_link", s.uiURL + "/reservations/" + string(res.ID) + "/print",
+	}
+	if s.propertyMaps != nil {
+		attrs = append(attrs,
+			"property_address", s.propertyMaps.PropertyAddress(),
+			"map_image", s.propertyMaps.StaticMapURL(600, 300),
+			"directions_link", s.propertyMaps.DirectionsURLs()["Google Maps"],
+		)
+	}
+	s.logger.Info("sending reservation confirmation email", attrs...)
+
+	return s.enqueue(ctx, s.reservationConfirmationEmail(res, s.guestLocale(ctx, res.GuestID)))
+}
+
+// reservationConfirmationEmail returns the confirmation of the reservation in the language of the locale.
+func (s *MockNotificationService) reservationConfirmationEmail(res *reservation.Reservation, locale shared.Locale) Email {
+	texts, language := emailTextsFor(locale, s.locale)
+	primaryGuest := res.Guests[0]
+	checkIn, checkOut := res.DateRange.CheckIn.Format("2006-01-02"), res.DateRange.CheckOut.Format("2006-01-02")
+	printLink := s.uiURL + "/reservations/" + string(res.ID) + "/print"
 
-	return nil
+	subject := fmt.Sprintf(texts.ConfirmationSubject, res.ID)
+	view := emailView{
+		Subject:       subject,
+		GuestName:     primaryGuest.Name,
+		ReservationID: string(res.ID),
+		RoomID:        string(res.RoomID),
+		CheckIn:       checkIn,
+		CheckOut:      checkOut,
+		Amount:        res.TotalAmount.FormatIn(locale),
+		PrintLink:     printLink,
+		Language:      language,
+		T:             texts,
+	}
+	if s.propertyMaps != nil {
+		view.PropertyAddress = s.propertyMaps.PropertyAddress()
+		view.DirectionsLink = s.propertyMaps.DirectionsURLs()["Google Maps"]
+	}
+	appLinks := s.appLinks(res, &view)
+
+	email := Email{
+		To:      primaryGuest.Email,
+		Subject: subject,
+		Body: fmt.Sprintf(texts.Greeting, primaryGuest.Name) + "\n\n" + fmt.Sprintf(texts.ConfirmationText, checkIn, checkOut, view.Amount) + "\n\n" +
+			texts.PrintConfirmation + ": " + printLink + "\n" + appLinks,
+		HTMLBody:      s.renderHTML("email_reservation_confirmation", view),
+		Template:      "reservation_confirmation",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	}
+	if s.calendar != nil {
+		email.Attachments = []EmailAttachment{{
+			Filename:    "reservation-" + string(res.ID) + ".ics",
+			ContentType: "text/calendar; charset=utf-8; method=PUBLISH",
+			Content:     s.calendar.Calendar(subject, locale, []*reservation.Reservation{res}),
+		}}
+	}
+	return email
 }
 
 // SendCancellationNotice logs a cancellation message.
@@ -64,10 +284,41 @@ func (s *MockNotificationService) SendCancellationNotice(
 		"reason", reason,
 	)
 
-	return nil
+	return s.enqueue(ctx, s.cancellationNoticeEmail(res, reason, s.guestLocale(ctx, res.GuestID)))
+}
+
+// cancellationNoticeEmail returns the cancellation notice of the reservation in the language of the locale.
+func (s *MockNotificationService) cancellationNoticeEmail(res *reservation.Reservation, reason string, locale shared.Locale) Email {
+	texts, language := emailTextsFor(locale, s.locale)
+	primaryGuest := res.Guests[0]
+
+	subject := fmt.Sprintf(texts.CancellationSubject, res.ID)
+	view := emailView{
+		Subject:       subject,
+		GuestName:     primaryGuest.Name,
+		ReservationID: string(res.ID),
+		CheckIn:       res.DateRange.CheckIn.Format("2006-01-02"),
+		CheckOut:      res.DateRange.CheckOut.Format("2006-01-02"),
+		Reason:        reason,
+		Language:      language,
+		T:             texts,
+	}
+	appLinks := s.appLinks(res, &view)
+
+	return Email{
+		To:            primaryGuest.Email,
+		Subject:       subject,
+		Body:          fmt.Sprintf(texts.Greeting, primaryGuest.Name) + "\n\n" + fmt.Sprintf(texts.CancellationText, res.ID, reason) + "\n" + appLinks,
+		HTMLBody:      s.renderHTML("email_cancellation_notice", view),
+		Template:      "cancellation_notice",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	}
 }
 
 // SendPaymentReceipt logs a payment receipt message.
+// Payments do not know the guest's email, so the receipt is only queued with WithReservations.
 func (s *MockNotificationService) SendPaymentReceipt(
 	ctx context.Context,
 	pay *payment.Payment,
@@ -75,10 +326,384 @@ func (s *MockNotificationService) SendPaymentReceipt(
 	s.logger.Info("sending payment receipt email",
 		"payment_id", pay.ID,
 		"reservation_id", pay.ReservationID,
-		"amount", pay.Amount.FormatAmount(),
+		"amount", pay.Amount.FormatIn(s.locale),
 		"payment_method", pay.PaymentMethod,
 		"transaction_id", pay.TransactionID,
 	)
 
-	return nil
+	if s.reservations == nil {
+		return nil
+	}
+	res, err := s.reservations.GetReservation(ctx, pay.ReservationID)
+	if err != nil {
+		return err
+	}
+	if len(res.Guests) == 0 {
+		return errors.New("no guests found in reservation")
+	}
+
+	return s.enqueue(ctx, s.paymentReceiptEmail(res, pay, s.guestLocale(ctx, res.GuestID)))
+}
+
+// paymentReceiptEmail returns the receipt of the payment for the reservation in the language of the locale.
+func (s *MockNotificationService) paymentReceiptEmail(res *reservation.Reservation, pay *payment.Payment, locale shared.Locale) Email {
+	texts, language := emailTextsFor(locale, s.locale)
+	primaryGuest := res.Guests[0]
+	link := s.uiURL + "/reservations/" + string(res.ID)
+
+	subject := fmt.Sprintf(texts.ReceiptSubject, res.ID)
+	view := emailView{
+		Subject:       subject,
+		GuestName:     primaryGuest.Name,
+		ReservationID: string(res.ID),
+		Amount:        pay.Amount.FormatIn(locale),
+		PrintLink:     link,
+		PaymentID:     string(pay.ID),
+		PaymentMethod: pay.PaymentMethod,
+		TransactionID: pay.TransactionID,
+		Language:      language,
+		T:             texts,
+	}
+
+	return Email{
+		To:      primaryGuest.Email,
+		Subject: subject,
+		Body: fmt.Sprintf(texts.Greeting, primaryGuest.Name) + "\n\n" + fmt.Sprintf(texts.ReceiptText, view.Amount, pay.PaymentMethod) + "\n\n" +
+			texts.ViewReservation + ": " + link + "\n",
+		HTMLBody:      s.renderHTML("email_payment_receipt", view),
+		Template:      "payment_receipt",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	}
+}
+
+// SendCheckInWelcome logs a welcome message to the guest who checked in.
+func (s *MockNotificationService) SendCheckInWelcome(
+	ctx context.Context,
+	res *reservation.Reservation,
+) error {
+	if len(res.Guests) == 0 {
+		return errors.New("no guests found in reservation")
+	}
+
+	primaryGuest := res.Guests[0]
+
+	s.logger.Info("sending check-in welcome email",
+		"reservation_id", res.ID,
+		"guest_email", primaryGuest.Email,
+		"room_id", res.RoomID,
+		"check_out", res.DateRange.CheckOut.Format("2006-01-02"),
+	)
+
+	return s.enqueue(ctx, s.checkInWelcomeEmail(res, s.guestLocale(ctx, res.GuestID)))
+}
+
+// checkInWelcomeEmail returns the check-in welcome of the reservation in the language of the locale.
+func (s *MockNotificationService) checkInWelcomeEmail(res *reservation.Reservation, locale shared.Locale) Email {
+	texts, language := emailTextsFor(locale, s.locale)
+	primaryGuest := res.Guests[0]
+	checkOut := res.DateRange.CheckOut.Format("2006-01-02")
+	link := s.uiURL + "/reservations/" + string(res.ID)
+
+	subject := fmt.Sprintf(texts.CheckInSubject, res.ID)
+	view := emailView{
+		Subject:       subject,
+		GuestName:     primaryGuest.Name,
+		ReservationID: string(res.ID),
+		RoomID:        string(res.RoomID),
+		CheckOut:      checkOut,
+		PrintLink:     link,
+		Language:      language,
+		T:             texts,
+	}
+	body := fmt.Sprintf(texts.Greeting, primaryGuest.Name) + "\n\n" + fmt.Sprintf(texts.CheckInText, res.RoomID, checkOut) + "\n\n"
+	if appLinks := s.appLinks(res, &view); appLinks != "" {
+		body += appLinks
+	} else {
+		body += texts.ViewReservation + ": " + link + "\n"
+	}
+
+	return Email{
+		To:            primaryGuest.Email,
+		Subject:       subject,
+		Body:          body,
+		HTMLBody:      s.renderHTML("email_check_in_welcome", view),
+		Template:      "check_in_welcome",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	}
+}
+
+// SendShareInvitation logs a share invitation message.
+func (s *MockNotificationService) SendShareInvitation(
+	ctx context.Context,
+	res *reservation.Reservation,
+	email string,
+	link string,
+) error {
+	s.logger.Info("sending share invitation email",
+		"reservation_id", res.ID,
+		"invitee_email", email,
+		"room_id", res.RoomID,
+		"check_in", res.DateRange.CheckIn.Format("2006-01-02"),
+		"link", link,
+	)
+
+	return s.enqueue(ctx, Email{
+		To:            email,
+		Subject:       "You are invited to a stay",
+		Body:          "You were invited to the stay from " + res.DateRange.CheckIn.Format("2006-01-02") + ".\n\nAccept the invitation: " + link + "\n",
+		Template:      "share_invitation",
+		Priority:      EmailTransactional,
+		ReservationID: string(res.ID),
+	})
+}
+
+// SendManageLink logs the management link of a reservation found by the booking lookup.
+// The link goes to the primary guest's email on file, never to an address entered in the lookup.
+func (s *MockNotificationService) SendManageLink(
+	ctx context.Context,
+	res *reservation.Reservation,
+	link string,
+) error {
+	primaryGuest := res.Guests[0]
+	s.logger.Info("sending manage link email",
+		"reservation_id", res.ID,
+		"guest_email", primaryGuest.Email,
+		"link", link,
+	)
+
+	return s.enqueue(ctx, Email{
+		To:            primaryGuest.Email,
+		Subject:       "Manage your booking " + res.ConfirmationCode(),
+		Body:          "Use this link to see and manage your booking " + res.ConfirmationCode() + ": " + link + "\n\nIf you did not ask for it, you can ignore this email.\n",
+		Template:      "manage_link",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	})
+}
+
+// SendHouseholdInvitation logs a household invitation message.
+func (s *MockNotificationService) SendHouseholdInvitation(
+	ctx context.Context,
+	h *household.Household,
+	email string,
+	link string,
+) error {
+	s.logger.Info("sending household invitation email",
+		"household_id", h.ID,
+		"household_name", h.Name,
+		"invitee_email", email,
+		"link", link,
+	)
+
+	return s.enqueue(ctx, Email{
+		To:       email,
+		Subject:  "You are invited to the household " + h.Name,
+		Body:     "You were invited to the household " + h.Name + ".\n\nAccept the invitation: " + link + "\n",
+		Template: "household_invitation",
+		Priority: EmailTransactional,
+	})
+}
+
+// SendSurveyInvitation logs an NPS survey invitation message.
+func (s *MockNotificationService) SendSurveyInvitation(
+	ctx context.Context,
+	sv *survey.Survey,
+) error {
+	s.logger.Info("sending survey invitation email",
+		"survey_id", sv.ID,
+		"reservation_id", sv.ReservationID,
+		"guest_email", sv.GuestEmail,
+		"link", s.uiURL+"/surveys/"+string(sv.ID),
+	)
+
+	return s.enqueue(ctx, Email{
+		To:            sv.GuestEmail,
+		Subject:       "How was your stay?",
+		Body:          "How likely are you to recommend us? Tell us in one click: " + s.uiURL + "/surveys/" + string(sv.ID) + "\n",
+		Template:      "survey_invitation",
+		Priority:      EmailLifecycle,
+		GuestID:       string(sv.GuestID),
+		ReservationID: string(sv.ReservationID),
+	})
+}
+
+// SendReferralReward logs a referral reward message to the referrer.
+func (s *MockNotificationService) SendReferralReward(
+	ctx context.Context,
+	code *referral.ReferralCode,
+	r *referral.Referral,
+) error {
+	s.logger.Info("sending referral reward email",
+		"referral_id", r.ID,
+		"referrer_email", code.Email,
+		"reward_kind", r.Reward.Kind,
+		"reward_credit", r.Reward.Credit.FormatIn(s.locale),
+		"reward_points", r.Reward.Points,
+		"link", s.uiURL+"/referrals",
+	)
+
+	return s.enqueue(ctx, Email{
+		To:            code.Email,
+		Subject:       "You earned a referral reward",
+		Body:          "A guest you referred completed their stay. See your rewards: " + s.uiURL + "/referrals\n",
+		Template:      "referral_reward",
+		Priority:      EmailLifecycle,
+		GuestID:       string(code.GuestID),
+		ReservationID: string(r.ReservationID),
+	})
+}
+
+// SendWaitlistOffer logs a message to the waitlisted guest that the room was freed and is held for them.
+func (s *MockNotificationService) SendWaitlistOffer(
+	ctx context.Context,
+	e *waitlist.Entry,
+) error {
+	checkIn, checkOut := e.CheckIn.Format("2006-01-02"), e.CheckOut.Format("2006-01-02")
+	link := s.uiURL + "/waitlist"
+	s.logger.Info("sending waitlist offer email",
+		"entry_id", e.ID,
+		"guest_email", e.Email,
+		"room_id", e.RoomID,
+		"hold_until", e.HoldUntil,
+		"link", link,
+	)
+
+	return s.enqueue(ctx, Email{
+		To:      e.Email,
+		Subject: "Room " + string(e.RoomID) + " is available for your dates",
+		Body: "The room you are waiting for became available from " + checkIn + " to " + checkOut + ".\n\n" +
+			"We hold it for you until " + e.HoldUntil.UTC().Format("2006-01-02 15:04 MST") + ". Book it here: " + link + "\n",
+		Template: "waitlist_offer",
+		Priority: EmailTransactional,
+		GuestID:  string(e.GuestID),
+	})
+}
+
+// SendExtensionOffer logs a message to the guest still checked in after check-out time that the room is
+// free the next night and can be kept for the offered price.
+func (s *MockNotificationService) SendExtensionOffer(
+	ctx context.Context,
+	res *reservation.Reservation,
+	offer reservation.Overstay,
+) error {
+	if len(res.Guests) == 0 {
+		return errors.New("no guests found in reservation")
+	}
+
+	primaryGuest := res.Guests[0]
+	link := s.uiURL + "/reservations/" + string(res.ID)
+	s.logger.Info("sending extension offer email",
+		"reservation_id", res.ID,
+		"guest_email", primaryGuest.Email,
+		"room_id", res.RoomID,
+		"amount", offer.Amount.FormatAmount(),
+		"expires_at", offer.ExpiresAt,
+		"link", link,
+	)
+
+	return s.enqueue(ctx, Email{
+		To:      primaryGuest.Email,
+		Subject: "Stay another night in room " + string(res.RoomID),
+		Body: "Check-out time has passed, and your room is free tonight. Stay another night for " +
+			offer.Amount.FormatIn(s.locale) + " until " + offer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST") + ".\n\n" +
+			"Accept or decline the offer here: " + link + "\n",
+		Template:      "extension_offer",
+		Priority:      EmailTransactional,
+		GuestID:       string(res.GuestID),
+		ReservationID: string(res.ID),
+	})
+}
+
+// reportView is the data of the report email template ("email_report").
+// The values are HTML-escaped like those of emailView.
+type reportView struct {
+	AppName         string
+	Subject         string
+	Title           string
+	Columns         []string
+	Rows            [][]string
+	Attachment      string // file name of a csv or xlsx report; "" for a table in the email
+	Skipped         int
+	Schedule        string
+	UnsubscribeLink string
+}
+
+// SendReport logs a scheduled report message to a recipient of the subscription. Reports of the
+// html format carry the table in the email; csv and xlsx reports attach the file.
+func (s *MockNotificationService) SendReport(
+	ctx context.Context,
+	sub *report.Subscription,
+	r *report.Report,
+	recipient report.Recipient,
+	file *report.File,
+) error {
+	link := s.uiURL + "/reports/unsubscribe/" + recipient.Token
+	s.logger.Info("sending report email",
+		"subscription_id", sub.ID,
+		"property_id", sub.PropertyID,
+		"report_type", sub.Type,
+		"format", sub.Format,
+		"recipient_email", recipient.Email,
+	)
+
+	columns, rows := r.Table()
+	var text strings.Builder
+	text.WriteString(r.Title() + "\n\n")
+	if file != nil {
+		text.WriteString("The report is attached as " + file.Name + ".\n")
+	} else {
+		table := tabwriter.NewWriter(&text, 0, 0, 2, ' ', 0)
+		names := make([]string, len(columns))
+		for i, c := range columns {
+			names[i] = c.Name
+		}
+		_, _ = fmt.Fprintln(table, strings.Join(names, "\t"))
+		for _, row := range rows {
+			_, _ = fmt.Fprintln(table, strings.Join(row, "\t"))
+		}
+		_ = table.Flush()
+	}
+	if r.Skipped > 0 {
+		text.WriteString(fmt.Sprintf("\n%d stays in another currency are not included.\n", r.Skipped))
+	}
+	text.WriteString("\nYou receive this report " + sub.Schedule.String() + ". Unsubscribe: " + link + "\n")
+
+	e := html.EscapeString
+	view := reportView{AppName: e(s.appName), Subject: e(r.Title()), Title: e(r.Title()), Skipped: r.Skipped, Schedule: e(sub.Schedule.String()), UnsubscribeLink: e(link)}
+	email := Email{
+		To:       recipient.Email,
+		Subject:  r.Title(),
+		Body:     text.String(),
+		Template: "report",
+		Priority: EmailLifecycle,
+	}
+	if file != nil {
+		view.Attachment = e(file.Name)
+		email.Attachments = []EmailAttachment{{Filename: file.Name, ContentType: file.ContentType, Content: file.Content}}
+	} else {
+		for _, c := range columns {
+			view.Columns = append(view.Columns, e(c.Name))
+		}
+		for _, row := range rows {
+			escaped := make([]string, len(row))
+			for i, cell := range row {
+				escaped[i] = e(cell)
+			}
+			view.Rows = append(view.Rows, escaped)
+		}
+	}
+	if s.templates != nil {
+		var buf bytes.Buffer
+		if err := s.templates.Render(&buf, "email_report", view); err != nil {
+			s.logger.Warn("failed to render email template", "template", "email_report", "error", err)
+		} else {
+			email.HTMLBody = buf.String()
+		}
+	}
+	return s.enqueue(ctx, email)
 }
@@ -1,17 +1,27 @@
 package outbound_test
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/templating"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
 )
 
 // ============================================================================
@@ -47,7 +57,7 @@ func createTestPayment() *payment.Payment {
 func Test_MockNotificationService_SendReservationConfirmation_Should_Succeed(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	svc := outbound.NewMockNotificationService(logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
 	ctx := context.Background()
 	res := createTestReservation()
 
@@ -58,10 +68,54 @@ func Test_MockNotificationService_SendReservationConfirmation_Should_Succeed(t *
 	assert.That(t, "error must be nil", err == nil, true)
 }
 
+func Test_MockNotificationService_SendReservationConfirmation_Should_Log_Print_Link(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "https://hotel.example.com/ui/", nil)
+	res := createTestReservation()
+
+	// Act
+	_ = svc.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "log must contain print link", strings.Contains(buf.String(), "print_link=https://hotel.example.com/ui/reservations/res-001/print"), true)
+}
+
+func Test_MockNotificationService_WithLocale_Should_Format_Amounts_In_The_Locale(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "https://hotel.example.com/ui/", nil).WithLocale("de-DE")
+	res := createTestReservation()
+
+	// Act
+	_ = svc.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "log must contain the German amount", strings.Contains(buf.String(), "total_amount=\"300,00"), true)
+}
+
+func Test_MockNotificationService_SendReservationConfirmation_Should_Log_Directions(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	maps := outbound.NewPropertyMaps(outbound.PropertyLocation{Address: "1 Harbor Road, Hamburg"}, nil)
+	svc := outbound.NewMockNotificationService(logger, "https://hotel.example.com/ui", maps)
+	res := createTestReservation()
+
+	// Act
+	_ = svc.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	assert.That(t, "log must contain address", strings.Contains(buf.String(), `property_address="1 Harbor Road, Hamburg"`), true)
+	assert.That(t, "log must contain directions", strings.Contains(buf.String(), `directions_link="https://www.google.com/maps/dir/`), true)
+}
+
 func Test_MockNotificationService_SendReservationConfirmation_No_Guests_Should_Return_Error(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	svc := outbound.NewMockNotificationService(logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
 	ctx := context.Background()
 	res := createTestReservation()
 	res.Guests = []reservation.GuestInfo{}
@@ -76,7 +130,7 @@ func Test_MockNotificationService_SendReservationConfirmation_No_Guests_Should_R
 func Test_MockNotificationService_SendCancellationNotice_Should_Succeed(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	svc := outbound.NewMockNotificationService(logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
 	ctx := context.Background()
 	res := createTestReservation()
 
@@ -90,7 +144,7 @@ func Test_MockNotificationService_SendCancellationNotice_Should_Succeed(t *testi
 func Test_MockNotificationService_SendCancellationNotice_No_Guests_Should_Return_Error(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	svc := outbound.NewMockNotificationService(logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
 	ctx := context.Background()
 	res := createTestReservation()
 	res.Guests = []reservation.GuestInfo{}
@@ -105,7 +159,7 @@ func Test_MockNotificationService_SendCancellationNotice_No_Guests_Should_Return
 func Test_MockNotificationService_SendPaymentReceipt_Should_Succeed(t *testing.T) {
 	// Arrange
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	svc := outbound.NewMockNotificationService(logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
 	ctx := context.Background()
 	pay := createTestPayment()
 
@@ -115,3 +169,344 @@ func Test_MockNotificationService_SendPaymentReceipt_Should_Succeed(t *testing.T
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
 }
+
+func Test_MockNotificationService_SendShareInvitation_Should_Succeed(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	ctx := context.Background()
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendShareInvitation(ctx, res, "friend@example.com", "http://localhost:8080/ui/shares/accept?token=abc")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+func Test_MockNotificationService_SendManageLink_Should_Log_Link_To_Primary_Guest(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendManageLink(context.Background(), res, "http://localhost:8080/ui/lookup/manage?token=abc")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain the primary guest", strings.Contains(buf.String(), "guest_email="+res.Guests[0].Email), true)
+	assert.That(t, "log must contain the link", strings.Contains(buf.String(), "/ui/lookup/manage?token=abc"), true)
+}
+
+func Test_MockNotificationService_SendSurveyInvitation_Should_Log_Survey_Link(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui/", nil)
+	sv := survey.NewSurvey("res-001", "guest-001", "john@example.com", "Test Hotel", time.Now())
+
+	// Act
+	err := svc.SendSurveyInvitation(context.Background(), sv)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain survey link", strings.Contains(buf.String(), "link=http://localhost:8080/ui/surveys/nps-res-001"), true)
+}
+
+func Test_MockNotificationService_SendReferralReward_Should_Log_Reward(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	code := &referral.ReferralCode{Code: "ABCDEF23", GuestID: "guest-001", Email: "john@example.com"}
+	r := referral.NewReferral(code, "guest-002", "res-001", time.Now())
+	_ = r.Earn(referral.Reward{Kind: referral.RewardPoints, Points: 500}, time.Now())
+
+	// Act
+	err := svc.SendReferralReward(context.Background(), code, r)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain reward points", strings.Contains(buf.String(), "reward_points=500"), true)
+}
+
+func Test_MockNotificationService_SendWaitlistOffer_Should_Log_Hold(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	entry, _ := waitlist.NewEntry("guest-001", "john@example.com", "room-101", time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9), time.Now())
+	_ = entry.Offer(time.Now(), 2*time.Hour)
+
+	// Act
+	err := svc.SendWaitlistOffer(context.Background(), entry)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain waitlist link", strings.Contains(buf.String(), "link=http://localhost:8080/ui/waitlist"), true)
+	assert.That(t, "log must contain the room", strings.Contains(buf.String(), "room_id=room-101"), true)
+}
+
+func Test_MockNotificationService_SendExtensionOffer_Should_Log_Offer(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	res := createTestReservation()
+	offer := reservation.Overstay{Outcome: reservation.OverstayOffered, Amount: shared.NewMoney(9900, "USD"), ExpiresAt: time.Now().Add(2 * time.Hour)}
+
+	// Act
+	err := svc.SendExtensionOffer(context.Background(), res, offer)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "log must contain reservation link", strings.Contains(buf.String(), "link=http://localhost:8080/ui/reservations/"+string(res.ID)), true)
+	assert.That(t, "log must contain the amount", strings.Contains(buf.String(), `amount="99.00 USD"`), true)
+}
+
+func Test_MockNotificationService_SendHouseholdInvitation_Should_Succeed(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil)
+	ctx := context.Background()
+	h, _ := household.NewHousehold("hh-001", "Doe Family", "guest-001", "john@example.com")
+
+	// Act
+	err := svc.SendHouseholdInvitation(ctx, h, "jane@example.com", "http://localhost:8080/ui/household")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+}
+
+func Test_MockNotificationService_WithOutbox_Should_Queue_Email(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), &recordingEmailProvider{}, outbound.DefaultEmailQueueConfig(), logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, createTestReservation())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	pending, _ := queue.Pending(ctx)
+	assert.That(t, "confirmation must be queued as transactional", pending[outbound.EmailTransactional], 1)
+}
+
+func Test_MockNotificationService_WithCalendar_Should_Attach_Stay_To_Confirmation(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).
+		WithOutbox(queue).
+		WithCalendar(outbound.NewICalendar("Seaside Hotel", "http://localhost:8080/ui"))
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, createTestReservation())
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must be sent", len(provider.sent), 1)
+	attachments := provider.sent[0].Attachments
+	assert.That(t, "stay must be attached", len(attachments), 1)
+	assert.That(t, "attachment must be named after the reservation", attachments[0].Filename, "reservation-res-001.ics")
+	assert.That(t, "attachment must be the event of the stay", strings.Contains(string(attachments[0].Content), "UID:res-001@localhost"), true)
+}
+
+func Test_MockNotificationService_WithCalendar_In_German_Should_Attach_German_Event(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).
+		WithLocale("de-DE").
+		WithOutbox(queue).
+		WithCalendar(outbound.NewICalendar("Seaside Hotel", "http://localhost:8080/ui"))
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, createTestReservation())
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must be sent", len(provider.sent), 1)
+	ics := string(provider.sent[0].Attachments[0].Content)
+	assert.That(t, "summary must be German", strings.Contains(ics, "\r\nSUMMARY:Seaside Hotel: Zimmer room-101\r\n"), true)
+	assert.That(t, "description must be German", strings.Contains(ics, "\r\nDESCRIPTION:Bestätigung "), true)
+}
+
+func Test_MockNotificationService_WithTemplates_Should_Add_Escaped_HTML_Body(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	engine := templating.NewEngine(fstest.MapFS{
+		"emails/confirmation.tmpl": {Data: []byte(`{{ define "email_reservation_confirmation" }}<h1>{{ .AppName }}</h1><p>{{ .GuestName }} {{ .Amount }}</p>{{ end }}`)},
+	})
+	engine.Parse("emails/*.tmpl")
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue).WithTemplates(engine, "Seaside Hotel")
+	res := createTestReservation()
+	res.Guests[0].Name = "John <b>Doe</b>"
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, res)
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must be sent", len(provider.sent), 1)
+	assert.That(t, "html body must be rendered and escaped", provider.sent[0].HTMLBody, "<h1>Seaside Hotel</h1><p>John &lt;b&gt;Doe&lt;/b&gt; $300.00</p>")
+	assert.That(t, "text body must be kept", strings.HasPrefix(provider.sent[0].Body, "Dear John <b>Doe</b>,"), true)
+}
+
+func Test_MockNotificationService_WithTemplates_Missing_Template_Should_Send_Text_Only(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	engine := templating.NewEngine(fstest.MapFS{
+		"emails/other.tmpl": {Data: []byte(`{{ define "other" }}other{{ end }}`)},
+	})
+	engine.Parse("emails/*.tmpl")
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue).WithTemplates(engine, "Seaside Hotel")
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendCancellationNotice(ctx, createTestReservation(), "guest request")
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must be sent", len(provider.sent), 1)
+	assert.That(t, "html body must be empty", provider.sent[0].HTMLBody, "")
+}
+
+func Test_MockNotificationService_WithReservations_Should_Queue_Payment_Receipt(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	_, reservations := createTestGuestDirectory(t)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue).WithReservations(reservations)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendPaymentReceipt(ctx, payment.NewPayment("pay-001", "res-001", shared.NewMoney(20000, "EUR"), "credit_card"))
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "receipt must be sent", len(provider.sent), 1)
+	assert.That(t, "receipt must go to the primary guest", provider.sent[0].To, "john@example.com")
+	assert.That(t, "template must be payment_receipt", provider.sent[0].Template, "payment_receipt")
+	assert.That(t, "body must contain the amount", strings.Contains(provider.sent[0].Body, "€200.00"), true)
+}
+
+func Test_MockNotificationService_WithDeepLinks_Should_Link_Confirmation_To_App(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	engine := templating.NewEngine(fstest.MapFS{
+		"emails/confirmation.tmpl": {Data: []byte(`{{ define "email_reservation_confirmation" }}<a href="{{ .AppLink }}">{{ .T.OpenInApp }}</a>{{ end }}`)},
+	})
+	engine.Parse("emails/*.tmpl")
+	deepLinks := outbound.NewDeepLinks("http://localhost:8080/ui", outbound.DeepLinkApp{Scheme: "hotelbooking"})
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue).WithTemplates(engine, "Seaside Hotel").WithDeepLinks(deepLinks)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReservationConfirmation(ctx, createTestReservation())
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "html body must link the app", provider.sent[0].HTMLBody, `<a href="hotelbooking://reservations/res-001">Open in the app</a>`)
+	assert.That(t, "text body must link the app", strings.Contains(provider.sent[0].Body, "Open in the app: hotelbooking://reservations/res-001\n"), true)
+	assert.That(t, "text body must link the web fallback", strings.Contains(provider.sent[0].Body, "View your reservation: http://localhost:8080/ui/reservations/res-001\n"), true)
+}
+
+func Test_MockNotificationService_SendCheckInWelcome_Should_Queue_Welcome(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendCheckInWelcome(ctx, createTestReservation())
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "welcome must be sent", len(provider.sent), 1)
+	assert.That(t, "template must be check_in_welcome", provider.sent[0].Template, "check_in_welcome")
+	assert.That(t, "body must name the room", strings.Contains(provider.sent[0].Body, "checked in to room room-101"), true)
+	assert.That(t, "body must link the reservation", strings.Contains(provider.sent[0].Body, "http://localhost:8080/ui/reservations/res-001"), true)
+}
+
+func createTestReport(format report.Format) (*report.Subscription, *report.Report) {
+	schedule := report.Schedule{Frequency: report.FrequencyWeekly, Weekday: time.Monday, Hour: 8}
+	sub, _ := report.NewSubscription("sub-1", "main", report.TypeOccupancy, schedule, format, []string{"manager@example.com"}, time.UTC, time.Now())
+	from := time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC)
+	r := report.Build(report.TypeOccupancy, "main", nil, 5, "USD", from, from.AddDate(0, 0, 1))
+	return sub, &r
+}
+
+func Test_MockNotificationService_SendReport_Should_Put_Table_And_Unsubscribe_Link_In_Email(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	engine := templating.NewEngine(fstest.MapFS{
+		"emails/report.tmpl": {Data: []byte(`{{ define "email_report" }}{{ range .Columns }}<th>{{ . }}</th>{{ end }}<a href="{{ .UnsubscribeLink }}">{{ end }}`)},
+	})
+	engine.Parse("emails/*.tmpl")
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue).WithTemplates(engine, "Seaside Hotel")
+	sub, r := createTestReport(report.FormatHTML)
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReport(ctx, sub, r, sub.Recipients[0], nil)
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "email must be sent", len(provider.sent), 1)
+	email := provider.sent[0]
+	assert.That(t, "subject must be the title", email.Subject, "Occupancy report of main, 2026-07-06 to 2026-07-06")
+	assert.That(t, "text body must contain the table", strings.Contains(email.Body, "2026-07-06  5      0           0"), true)
+	link := "http://localhost:8080/ui/reports/unsubscribe/" + sub.Recipients[0].Token
+	assert.That(t, "text body must link the unsubscribe page", strings.Contains(email.Body, link), true)
+	assert.That(t, "html body must be rendered", email.HTMLBody, `<th>Night</th><th>Rooms</th><th>Rooms Sold</th><th>Occupancy (%)</th><a href="`+link+`">`)
+	assert.That(t, "report must not be attached", len(email.Attachments), 0)
+}
+
+func Test_MockNotificationService_SendReport_With_File_Should_Attach_It(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	provider := &recordingEmailProvider{}
+	queue := outbound.NewEmailQueue(resource.NewInMemoryAccess[string, outbound.Email](), provider, outbound.DefaultEmailQueueConfig(), logger)
+	svc := outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil).WithOutbox(queue)
+	sub, r := createTestReport(report.FormatCSV)
+	file := &report.File{Name: "occupancy-main-2026-07-06.csv", ContentType: "text/csv", Content: []byte("Night\n")}
+	ctx := context.Background()
+
+	// Act
+	err := svc.SendReport(ctx, sub, r, sub.Recipients[0], file)
+	_, _ = queue.SendNext(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "report must be attached", provider.sent[0].Attachments, []outbound.EmailAttachment{{Filename: file.Name, ContentType: "text/csv", Content: []byte("Night\n")}})
+	assert.That(t, "text body must name the attachment", strings.Contains(provider.sent[0].Body, "attached as "+file.Name), true)
+}
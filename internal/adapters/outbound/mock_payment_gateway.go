@@ -6,25 +6,69 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
+// DefaultSlowCardDelay is the delay of the slow test card.
+const DefaultSlowCardDelay = 5 * time.Second
+
 // MockPaymentGateway simulates a payment gateway for testing and demonstration.
+// It is scripted by the test cards of payment.TestCards: a payment whose method is a test card number
+// behaves like that card, any other payment like the sandbox card chosen with UseTestCard.
 type MockPaymentGateway struct {
 	transactions map[string]shared.Money
 	FailureRate  float64 // 0.0 to 1.0, probability of random failures
 	ShouldFail   bool
+	SlowDelay    time.Duration // delay of the slow test card
+
+	mu   sync.RWMutex // guards card, which is changed at runtime by the admin endpoint
+	card payment.TestCard
 }
 
-// NewMockPaymentGateway creates a new mock payment gateway.
+// NewMockPaymentGateway creates a new mock payment gateway that approves all payments.
 func NewMockPaymentGateway() *MockPaymentGateway {
 	return &MockPaymentGateway{
 		ShouldFail:   false,
 		FailureRate:  0.0,
+		SlowDelay:    DefaultSlowCardDelay,
 		transactions: make(map[string]shared.Money),
+		card:         payment.TestCards[0],
+	}
+}
+
+// TestCard returns the sandbox card used for payments without a test card number.
+func (g *MockPaymentGateway) TestCard() payment.TestCard {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.card
+}
+
+// UseTestCard makes the payments without a test card number behave like the card with the number,
+// e.g. decline every booking; an empty number restores the approving card.
+func (g *MockPaymentGateway) UseTestCard(number string) error {
+	card := payment.TestCards[0]
+	if number != "" {
+		var ok bool
+		if card, ok = payment.FindTestCard(number); !ok {
+			return fmt.Errorf("%w: %q", payment.ErrUnknownTestCard, number)
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.card = card
+	return nil
+}
+
+// testCardOf returns the test card a payment is charged to.
+func (g *MockPaymentGateway) testCardOf(pay *payment.Payment) payment.TestCard {
+	if card, ok := payment.FindTestCard(pay.PaymentMethod); ok {
+		return card
 	}
+	return g.TestCard()
 }
 
 // cryptoRandFloat64 returns a random float64 in [0.0, 1.0) using crypto/rand.
@@ -43,6 +87,19 @@ func (g *MockPaymentGateway) Authorize(ctx context.Context, pay *payment.Payment
 		return "", errors.New("payment authorization failed: insufficient funds")
 	}
 
+	switch card := g.testCardOf(pay); card.Behavior {
+	case payment.TestCardDecline:
+		return "", fmt.Errorf("%w (test card %s)", payment.ErrCardDeclined, card.Number)
+	case payment.TestCardSCARequired:
+		return "", fmt.Errorf("%w (test card %s)", payment.ErrAuthenticationRequired, card.Number)
+	case payment.TestCardSlow:
+		select {
+		case <-time.After(g.SlowDelay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
 	transactionID := fmt.Sprintf("txn_%s_%d", pay.ID, pay.Amount.Amount)
 	g.transactions[transactionID] = pay.Amount
 
@@ -67,18 +124,28 @@ func (g *MockPaymentGateway) Capture(ctx context.Context, transactionID string,
 	return nil
 }
 
-// Refund simulates refunding a captured payment.
+// Refund simulates refunding a captured payment, fully or partially.
+// The transaction is closed once its amount is refunded completely.
 func (g *MockPaymentGateway) Refund(ctx context.Context, transactionID string, amount shared.Money) error {
 	if g.ShouldFail || (g.FailureRate > 0 && cryptoRandFloat64() < g.FailureRate) {
 		return errors.New("payment refund failed: gateway error")
 	}
 
-	_, exists := g.transactions[transactionID]
+	remaining, exists := g.transactions[transactionID]
 	if !exists {
 		return fmt.Errorf("transaction %s not found", transactionID)
 	}
 
-	delete(g.transactions, transactionID)
+	if amount.Currency != remaining.Currency || amount.Amount > remaining.Amount {
+		return fmt.Errorf("refund amount exceeds remaining: remaining %v, requested %v", remaining, amount)
+	}
+
+	remaining.Amount -= amount.Amount
+	if remaining.Amount == 0 {
+		delete(g.transactions, transactionID)
+	} else {
+		g.transactions[transactionID] = remaining
+	}
 
 	return nil
 }
@@ -93,9 +160,10 @@ func (g *MockPaymentGateway) SetFailureRate(rate float64) {
 	g.FailureRate = rate
 }
 
-// Reset clears all transaction state.
+// Reset clears all transaction state and restores the approving sandbox card.
 func (g *MockPaymentGateway) Reset() {
 	g.transactions = make(map[string]shared.Money)
 	g.ShouldFail = false
 	g.FailureRate = 0.0
+	_ = g.UseTestCard("")
 }
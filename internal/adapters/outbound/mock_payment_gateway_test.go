@@ -2,7 +2,9 @@ package outbound_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
@@ -128,6 +130,28 @@ func Test_MockPaymentGateway_Refund_Should_Succeed(t *testing.T) {
 	assert.That(t, "error must be nil", err == nil, true)
 }
 
+func Test_MockPaymentGateway_Partial_Refunds_Should_Be_Limited_To_Amount(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	ctx := context.Background()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+
+	txnID, authErr := gateway.Authorize(ctx, pay)
+	if authErr != nil {
+		t.Fatalf("setup failed: %v", authErr)
+	}
+
+	// Act
+	first := gateway.Refund(ctx, txnID, shared.NewMoney(4000, "USD"))
+	exceeding := gateway.Refund(ctx, txnID, shared.NewMoney(7000, "USD"))
+	rest := gateway.Refund(ctx, txnID, shared.NewMoney(6000, "USD"))
+
+	// Assert
+	assert.That(t, "first refund must succeed", first == nil, true)
+	assert.That(t, "refund above the remaining amount must fail", exceeding != nil, true)
+	assert.That(t, "refund of the remaining amount must succeed", rest == nil, true)
+}
+
 func Test_MockPaymentGateway_Refund_Unknown_Transaction_Should_Return_Error(t *testing.T) {
 	// Arrange
 	gateway := outbound.NewMockPaymentGateway()
@@ -191,3 +215,76 @@ func Test_MockPaymentGateway_SetFailureRate_Should_Affect_Behavior(t *testing.T)
 	gateway.SetFailureRate(0.5)
 	assert.That(t, "failure rate must be 0.5", gateway.FailureRate, 0.5)
 }
+
+// ============================================================================
+// Test Card Tests
+// ============================================================================
+
+func Test_MockPaymentGateway_Authorize_With_Declining_Test_Card_Should_Return_ErrCardDeclined(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "4000 0000 0000 0002")
+
+	// Act
+	_, err := gateway.Authorize(context.Background(), pay)
+
+	// Assert
+	assert.That(t, "error must be ErrCardDeclined", errors.Is(err, payment.ErrCardDeclined), true)
+}
+
+func Test_MockPaymentGateway_UseTestCard_Should_Script_Payments_Without_Card(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "default")
+
+	// Act
+	useErr := gateway.UseTestCard("4000002500003155")
+	_, err := gateway.Authorize(context.Background(), pay)
+
+	// Assert
+	assert.That(t, "switch must succeed", useErr, nil)
+	assert.That(t, "error must be ErrAuthenticationRequired", errors.Is(err, payment.ErrAuthenticationRequired), true)
+}
+
+func Test_MockPaymentGateway_UseTestCard_With_Unknown_Card_Should_Keep_Card(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+
+	// Act
+	err := gateway.UseTestCard("1234")
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownTestCard", errors.Is(err, payment.ErrUnknownTestCard), true)
+	assert.That(t, "card must still approve", gateway.TestCard().Behavior, payment.TestCardApprove)
+}
+
+func Test_MockPaymentGateway_Authorize_With_Slow_Test_Card_Should_Wait_For_Delay(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	gateway.SlowDelay = 20 * time.Millisecond
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "5555555555554444")
+	start := time.Now()
+
+	// Act
+	txnID, err := gateway.Authorize(context.Background(), pay)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "transaction ID must not be empty", txnID != "", true)
+	assert.That(t, "authorization must wait for the delay", time.Since(start) >= 20*time.Millisecond, true)
+}
+
+func Test_MockPaymentGateway_Authorize_With_Slow_Test_Card_Should_Stop_On_Cancel(t *testing.T) {
+	// Arrange
+	gateway := outbound.NewMockPaymentGateway()
+	gateway.SlowDelay = time.Hour
+	pay := payment.NewPayment("pay-001", "res-001", shared.NewMoney(10000, "USD"), "5555555555554444")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := gateway.Authorize(ctx, pay)
+
+	// Assert
+	assert.That(t, "error must be context.Canceled", errors.Is(err, context.Canceled), true)
+}
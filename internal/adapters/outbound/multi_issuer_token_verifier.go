@@ -0,0 +1,115 @@
+package outbound
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ErrUntrustedIssuer is returned if a token was issued by an issuer that is not configured.
+var ErrUntrustedIssuer = errors.New("untrusted token issuer")
+
+// TrustedIssuer configures an OIDC issuer whose tokens are accepted.
+type TrustedIssuer struct {
+	Issuer    string
+	ClientID  string
+	Principal shared.PrincipalType
+}
+
+// MultiIssuerTokenVerifier verifies tokens from several trusted issuers,
+// e.g. a public guest realm and a corporate staff realm.
+// The issuer claim only selects the verifier; the token is then fully verified by it,
+// so a token cannot claim a different issuer than the one that signed it.
+type MultiIssuerTokenVerifier struct {
+	issuers   map[string]*ResilientTokenVerifier
+	principal map[string]shared.PrincipalType
+}
+
+// NewMultiIssuerTokenVerifier creates a resilient verifier for each trusted issuer.
+func NewMultiIssuerTokenVerifier(ctx context.Context, trusted []TrustedIssuer, logger *slog.Logger, minRefreshDelay time.Duration) *MultiIssuerTokenVerifier {
+	v := &MultiIssuerTokenVerifier{
+		issuers:   make(map[string]*ResilientTokenVerifier, len(trusted)),
+		principal: make(map[string]shared.PrincipalType, len(trusted)),
+	}
+	for _, t := range trusted {
+		v.issuers[t.Issuer] = NewResilientTokenVerifier(ctx, t.Issuer, t.ClientID, logger.With("issuer", t.Issuer), minRefreshDelay)
+		v.principal[t.Issuer] = t.Principal
+	}
+	return v
+}
+
+// Start starts the periodic refresh of every issuer.
+func (v *MultiIssuerTokenVerifier) Start(ctx context.Context, interval time.Duration) {
+	for _, verifier := range v.issuers {
+		verifier.Start(ctx, interval)
+	}
+}
+
+// Verify verifies the token with the verifier of its issuer.
+func (v *MultiIssuerTokenVerifier) Verify(ctx context.Context, rawToken string) (*oidc.IDToken, error) {
+	issuer, err := unverifiedIssuer(rawToken)
+	if err != nil {
+		return nil, err
+	}
+	verifier, ok := v.issuers[issuer]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUntrustedIssuer, issuer)
+	}
+	return verifier.Verify(ctx, rawToken)
+}
+
+// PrincipalType returns the principal type configured for a trusted issuer.
+func (v *MultiIssuerTokenVerifier) PrincipalType(issuer string) (shared.PrincipalType, bool) {
+	p, ok := v.principal[issuer]
+	return p, ok
+}
+
+// unverifiedIssuer reads the "iss" claim without verifying the signature.
+// The result must only be used to select a verifier.
+func unverifiedIssuer(rawToken string) (string, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed token payload: %w", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("malformed token claims: %w", err)
+	}
+	return claims.Issuer, nil
+}
+
+// ParseTrustedIssuers parses "principal|issuer|clientID,..." entries,
+// e.g. "guest|http://localhost:8180/realms/local|hotel-booking-mcp".
+func ParseTrustedIssuers(s string) ([]TrustedIssuer, error) {
+	var trusted []TrustedIssuer
+	for entry := range strings.SplitSeq(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, "|")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid trusted issuer %q: expected principal|issuer|clientID", entry)
+		}
+		principal := shared.PrincipalType(fields[0])
+		if principal != shared.PrincipalGuest && principal != shared.PrincipalStaff {
+			return nil, fmt.Errorf("invalid principal type %q", fields[0])
+		}
+		trusted = append(trusted, TrustedIssuer{Principal: principal, Issuer: fields[1], ClientID: fields[2]})
+	}
+	return trusted, nil
+}
@@ -0,0 +1,73 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// MultiIssuerTokenVerifier Tests
+// ============================================================================
+
+func Test_MultiIssuerTokenVerifier_Verify_Should_Select_Verifier_By_Issuer(t *testing.T) {
+	// Arrange
+	guest := newTestIssuer(t)
+	staff := newTestIssuer(t)
+	verifier := outbound.NewMultiIssuerTokenVerifier(context.Background(), []outbound.TrustedIssuer{
+		{Issuer: guest.server.URL, ClientID: "guest-app", Principal: shared.PrincipalGuest},
+		{Issuer: staff.server.URL, ClientID: "staff-app", Principal: shared.PrincipalStaff},
+	}, slog.Default(), time.Minute)
+
+	// Act
+	token, err := verifier.Verify(context.Background(), staff.token(t, "staff-app"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	principal, ok := verifier.PrincipalType(token.Issuer)
+	assert.That(t, "issuer must be trusted", ok, true)
+	assert.That(t, "principal must be staff", principal, shared.PrincipalStaff)
+}
+
+func Test_MultiIssuerTokenVerifier_Verify_With_Untrusted_Issuer_Should_Return_Error(t *testing.T) {
+	// Arrange
+	trusted := newTestIssuer(t)
+	untrusted := newTestIssuer(t)
+	verifier := outbound.NewMultiIssuerTokenVerifier(context.Background(), []outbound.TrustedIssuer{
+		{Issuer: trusted.server.URL, ClientID: "app", Principal: shared.PrincipalGuest},
+	}, slog.Default(), time.Minute)
+
+	// Act
+	_, err := verifier.Verify(context.Background(), untrusted.token(t, "app"))
+
+	// Assert
+	assert.That(t, "error must be ErrUntrustedIssuer", errors.Is(err, outbound.ErrUntrustedIssuer), true)
+}
+
+func Test_ParseTrustedIssuers_Should_Parse_Entries(t *testing.T) {
+	// Arrange
+	s := "guest|http://idp/realms/guests|guest-app, staff|http://idp/realms/staff|staff-app"
+
+	// Act
+	trusted, err := outbound.ParseTrustedIssuers(s)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "must have 2 issuers", len(trusted), 2)
+	assert.That(t, "second must be staff", trusted[1].Principal, shared.PrincipalStaff)
+	assert.That(t, "second client id must match", trusted[1].ClientID, "staff-app")
+}
+
+func Test_ParseTrustedIssuers_With_Invalid_Principal_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.ParseTrustedIssuers("admin|http://idp/realms/admin|app")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,153 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrIdentityProviderUnavailable is returned if tokens cannot be verified because
+// the identity provider (discovery or JWKS endpoint) is not reachable.
+// It reports itself as temporary, so inbound adapters can answer with 503 instead of 401.
+var ErrIdentityProviderUnavailable error = temporaryError("identity provider unavailable")
+
+// temporaryError is an error that may go away when the operation is retried later.
+type temporaryError string
+
+// Error returns the error message.
+func (e temporaryError) Error() string { return string(e) }
+
+// Temporary reports that the error is temporary.
+func (e temporaryError) Temporary() bool { return true }
+
+// ResilientTokenVerifier verifies OIDC tokens without requiring the identity provider
+// at startup. The provider is discovered lazily and rediscovered periodically, which
+// also drops cached signing keys that were removed by a key rotation.
+// A failed verification triggers one rate-limited refresh and a retry, so tokens signed
+// with a new key are accepted as soon as Keycloak publishes it.
+type ResilientTokenVerifier struct {
+	ctx             context.Context
+	issuer          string
+	clientID        string
+	logger          *slog.Logger
+	minRefreshDelay time.Duration
+
+	mu          sync.RWMutex
+	verifier    *oidc.IDTokenVerifier
+	lastRefresh time.Time
+	refreshErr  error
+	group       singleflight.Group
+}
+
+// OIDCClientContext returns a context that makes the token verifiers use the client
+// for discovery and for fetching signing keys instead of http.DefaultClient.
+func OIDCClientContext(ctx context.Context, client *http.Client) context.Context {
+	return oidc.ClientContext(ctx, client)
+}
+
+// NewResilientTokenVerifier creates a new verifier for the issuer and client ID.
+// The context is kept for fetching signing keys and must live as long as the verifier.
+// minRefreshDelay limits how often failed verifications may trigger a refresh.
+func NewResilientTokenVerifier(ctx context.Context, issuer, clientID string, logger *slog.Logger, minRefreshDelay time.Duration) *ResilientTokenVerifier {
+	return &ResilientTokenVerifier{
+		ctx:             ctx,
+		issuer:          issuer,
+		clientID:        clientID,
+		logger:          logger,
+		minRefreshDelay: minRefreshDelay,
+	}
+}
+
+// Start refreshes the provider immediately and then periodically until the context is done.
+func (v *ResilientTokenVerifier) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		_ = v.Refresh(ctx)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = v.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// Refresh rediscovers the provider and replaces the verifier, including its key cache.
+// On failure the previous verifier is kept, so a short outage does not reject valid tokens.
+// Concurrent refreshes are coalesced into one.
+func (v *ResilientTokenVerifier) Refresh(ctx context.Context) error {
+	_, err, _ := v.group.Do("refresh", func() (any, error) {
+		// The provider keeps its context for fetching keys, so we use the long-lived one.
+		provider, err := oidc.NewProvider(v.ctx, v.issuer)
+
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.lastRefresh = time.Now()
+		v.refreshErr = err
+		if err != nil {
+			v.logger.Warn("oidc provider refresh failed", "issuer", v.issuer, "error", err)
+			return nil, fmt.Errorf("%w: %v", ErrIdentityProviderUnavailable, err)
+		}
+		v.verifier = provider.Verifier(&oidc.Config{ClientID: v.clientID})
+		return nil, nil
+	})
+	return err
+}
+
+// Verify parses and verifies a raw token.
+// ErrIdentityProviderUnavailable is returned if the provider cannot be reached;
+// all other errors mean the token itself is invalid.
+func (v *ResilientTokenVerifier) Verify(ctx context.Context, rawToken string) (*oidc.IDToken, error) {
+	verifier, refreshErr := v.current()
+	if verifier == nil {
+		if !v.mayRefresh() {
+			return nil, fmt.Errorf("%w: %v", ErrIdentityProviderUnavailable, refreshErr)
+		}
+		if err := v.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		verifier, _ = v.current()
+	}
+
+	token, err := verifier.Verify(ctx, rawToken)
+	if err == nil {
+		return token, nil
+	}
+
+	// The token may be signed with a key we have not seen yet, or the key endpoint failed.
+	// Refresh once (rate-limited to protect the provider from invalid tokens) and retry.
+	if !v.mayRefresh() {
+		if _, refreshErr := v.current(); refreshErr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrIdentityProviderUnavailable, refreshErr)
+		}
+		return nil, err
+	}
+	if refreshErr := v.Refresh(ctx); refreshErr != nil {
+		return nil, refreshErr
+	}
+	verifier, _ = v.current()
+	return verifier.Verify(ctx, rawToken)
+}
+
+// current returns the current verifier and the error of the last refresh.
+func (v *ResilientTokenVerifier) current() (*oidc.IDTokenVerifier, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.verifier, v.refreshErr
+}
+
+// mayRefresh reports whether enough time has passed since the last refresh.
+func (v *ResilientTokenVerifier) mayRefresh() bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return time.Since(v.lastRefresh) >= v.minRefreshDelay
+}
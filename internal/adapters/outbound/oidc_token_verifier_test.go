@@ -0,0 +1,151 @@
+package outbound_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// testIssuer serves an OIDC discovery document and a JWKS with a rotatable signing key.
+type testIssuer struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	key    *rsa.PrivateKey
+	kid    string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	issuer := &testIssuer{}
+	issuer.rotate(t, "key-1")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.server.URL,
+			"jwks_uri":                              issuer.server.URL + "/keys",
+			"authorization_endpoint":                issuer.server.URL + "/auth",
+			"token_endpoint":                        issuer.server.URL + "/token",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &issuer.key.PublicKey, KeyID: issuer.kid, Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) rotate(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.key, i.kid = key, kid
+}
+
+func (i *testIssuer) token(t *testing.T, audience string) string {
+	t.Helper()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: i.key, KeyID: i.kid}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	claims, _ := json.Marshal(map[string]any{
+		"iss": i.server.URL,
+		"aud": audience,
+		"sub": "service-account",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	})
+	jws, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	raw, _ := jws.CompactSerialize()
+	return raw
+}
+
+// ============================================================================
+// ResilientTokenVerifier Tests
+// ============================================================================
+
+func Test_ResilientTokenVerifier_Verify_Should_Discover_Provider_Lazily(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t)
+	verifier := outbound.NewResilientTokenVerifier(context.Background(), issuer.server.URL, "mcp", slog.Default(), time.Minute)
+
+	// Act
+	token, err := verifier.Verify(context.Background(), issuer.token(t, "mcp"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "subject must match", token.Subject, "service-account")
+}
+
+func Test_ResilientTokenVerifier_Verify_After_Key_Rotation_Should_Accept_New_Key(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t)
+	verifier := outbound.NewResilientTokenVerifier(context.Background(), issuer.server.URL, "mcp", slog.Default(), 0)
+	_, _ = verifier.Verify(context.Background(), issuer.token(t, "mcp"))
+	issuer.rotate(t, "key-2")
+
+	// Act
+	_, err := verifier.Verify(context.Background(), issuer.token(t, "mcp"))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
+func Test_ResilientTokenVerifier_Verify_With_Wrong_Audience_Should_Not_Be_Unavailable(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t)
+	verifier := outbound.NewResilientTokenVerifier(context.Background(), issuer.server.URL, "mcp", slog.Default(), time.Minute)
+
+	// Act
+	_, err := verifier.Verify(context.Background(), issuer.token(t, "other-client"))
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "error must not be unavailable", errors.Is(err, outbound.ErrIdentityProviderUnavailable), false)
+}
+
+func Test_ResilientTokenVerifier_Verify_Without_Provider_Should_Return_Unavailable(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	verifier := outbound.NewResilientTokenVerifier(context.Background(), server.URL, "mcp", slog.Default(), time.Minute)
+
+	// Act
+	_, err := verifier.Verify(context.Background(), "header.payload.signature")
+
+	// Assert
+	assert.That(t, "error must be unavailable", errors.Is(err, outbound.ErrIdentityProviderUnavailable), true)
+}
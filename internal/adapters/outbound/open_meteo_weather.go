@@ -0,0 +1,134 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// openMeteoForecastDays is how many days ahead Open-Meteo forecasts.
+const openMeteoForecastDays = 16
+
+// WeatherProvider fetches daily forecasts for a location.
+// Providers are pluggable; the result is cached and rate limited by WeatherForecasts.
+type WeatherProvider interface {
+	DailyForecast(ctx context.Context, loc PropertyLocation, from, to time.Time) ([]shared.DailyForecast, error)
+}
+
+// OpenMeteoWeather implements WeatherProvider with the Open-Meteo forecast API, which needs no API key.
+type OpenMeteoWeather struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewOpenMeteoWeather creates a new Open-Meteo weather provider.
+// The baseURL is https://api.open-meteo.com unless a self-hosted instance is used.
+func NewOpenMeteoWeather(client *http.Client, baseURL string) *OpenMeteoWeather {
+	return &OpenMeteoWeather{client: client, baseURL: baseURL}
+}
+
+// openMeteoResponse is the part of the Open-Meteo forecast response we use.
+type openMeteoResponse struct {
+	Daily struct {
+		Time                        []string  `json:"time"`
+		WeatherCode                 []int     `json:"weather_code"`
+		TemperatureMax              []float64 `json:"temperature_2m_max"`
+		TemperatureMin              []float64 `json:"temperature_2m_min"`
+		PrecipitationProbabilityMax []int     `json:"precipitation_probability_max"`
+	} `json:"daily"`
+}
+
+// DailyForecast returns the forecast for the days from..to that lie within the forecast horizon.
+// Stays beyond the horizon get no forecast and no error.
+func (o *OpenMeteoWeather) DailyForecast(ctx context.Context, loc PropertyLocation, from, to time.Time) ([]shared.DailyForecast, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := from.UTC().Truncate(24 * time.Hour)
+	if start.Before(today) {
+		start = today
+	}
+	end := to.UTC().Truncate(24 * time.Hour)
+	if horizon := today.AddDate(0, 0, openMeteoForecastDays-1); end.After(horizon) {
+		end = horizon
+	}
+	if end.Before(start) {
+		return nil, nil
+	}
+
+	q := url.Values{}
+	q.Set("latitude", strconv.FormatFloat(loc.Latitude, 'f', -1, 64))
+	q.Set("longitude", strconv.FormatFloat(loc.Longitude, 'f', -1, 64))
+	q.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min,precipitation_probability_max")
+	q.Set("timezone", "UTC")
+	q.Set("start_date", start.Format("2006-01-02"))
+	q.Set("end_date", end.Format("2006-01-02"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+"/v1/forecast?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch forecast: status %d", resp.StatusCode)
+	}
+
+	var body openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode forecast: %w", err)
+	}
+
+	daily := body.Daily
+	forecasts := make([]shared.DailyForecast, 0, len(daily.Time))
+	for i, day := range daily.Time {
+		date, err := time.Parse("2006-01-02", day)
+		if err != nil || i >= len(daily.WeatherCode) || i >= len(daily.TemperatureMax) || i >= len(daily.TemperatureMin) {
+			continue
+		}
+		f := shared.DailyForecast{
+			Date:     date,
+			Summary:  weatherCodeSummary(daily.WeatherCode[i]),
+			TempMinC: daily.TemperatureMin[i],
+			TempMaxC: daily.TemperatureMax[i],
+		}
+		if i < len(daily.PrecipitationProbabilityMax) {
+			f.PrecipitationChance = daily.PrecipitationProbabilityMax[i]
+		}
+		forecasts = append(forecasts, f)
+	}
+	return forecasts, nil
+}
+
+// weatherCodeSummary describes a WMO weather interpretation code.
+func weatherCodeSummary(code int) string {
+	switch {
+	case code == 0:
+		return "Clear sky"
+	case code <= 3:
+		return "Partly cloudy"
+	case code == 45 || code == 48:
+		return "Fog"
+	case code >= 51 && code <= 57:
+		return "Drizzle"
+	case code >= 61 && code <= 67:
+		return "Rain"
+	case code >= 71 && code <= 77:
+		return "Snow"
+	case code >= 80 && code <= 82:
+		return "Rain showers"
+	case code == 85 || code == 86:
+		return "Snow showers"
+	case code >= 95:
+		return "Thunderstorm"
+	default:
+		return "Unknown"
+	}
+}
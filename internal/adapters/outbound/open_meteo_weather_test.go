@@ -0,0 +1,75 @@
+package outbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// OpenMeteoWeather Tests
+// ============================================================================
+
+func Test_OpenMeteoWeather_Should_Map_Daily_Forecast(t *testing.T) {
+	// Arrange
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"daily":{"time":["2030-01-02"],"weather_code":[61],"temperature_2m_max":[12.4],"temperature_2m_min":[4.6],"precipitation_probability_max":[80]}}`))
+	}))
+	defer srv.Close()
+	weather := outbound.NewOpenMeteoWeather(srv.Client(), srv.URL)
+	from := time.Now().AddDate(0, 0, 2)
+
+	// Act
+	forecasts, err := weather.DailyForecast(context.Background(), outbound.PropertyLocation{Latitude: 53.5, Longitude: 9.9}, from, from.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must return one day", len(forecasts), 1)
+	assert.That(t, "summary must be rain", forecasts[0].Summary, "Rain")
+	assert.That(t, "max temperature must match", forecasts[0].TempMaxC, 12.4)
+	assert.That(t, "precipitation chance must match", forecasts[0].PrecipitationChance, 80)
+	assert.That(t, "query must contain location", strings.Contains(query, "latitude=53.5&longitude=9.9"), true)
+}
+
+func Test_OpenMeteoWeather_Beyond_Forecast_Horizon_Should_Not_Call_Provider(t *testing.T) {
+	// Arrange
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+	weather := outbound.NewOpenMeteoWeather(srv.Client(), srv.URL)
+	from := time.Now().AddDate(0, 1, 0)
+
+	// Act
+	forecasts, err := weather.DailyForecast(context.Background(), outbound.PropertyLocation{Latitude: 53.5, Longitude: 9.9}, from, from.AddDate(0, 0, 3))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "forecasts must be empty", len(forecasts), 0)
+	assert.That(t, "provider must not be called", called, false)
+}
+
+func Test_OpenMeteoWeather_With_Server_Error_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	weather := outbound.NewOpenMeteoWeather(srv.Client(), srv.URL)
+	from := time.Now().AddDate(0, 0, 2)
+
+	// Act
+	_, err := weather.DailyForecast(context.Background(), outbound.PropertyLocation{Latitude: 53.5, Longitude: 9.9}, from, from.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
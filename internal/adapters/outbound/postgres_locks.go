@@ -0,0 +1,111 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// The lock classes are the first key of the advisory locks, so locks taken for
+// different purposes cannot collide.
+const (
+	roomLockClass      = 0x524f4f4d // "ROOM"
+	promotionLockClass = 0x50524f4d // "PROM"
+	loyaltyLockClass   = 0x4c4f5941 // "LOYA"
+	invoiceLockClass   = 0x494e564f // "INVO"
+)
+
+// lockPoll is the interval between two attempts to take a lock that is held.
+const lockPoll = 25 * time.Millisecond
+
+// PostgresLocks implements the lock ports of the domain, e.g. RoomLocks, with session-level advisory
+// locks of PostgreSQL, so the work on a key is serialized across all server instances sharing the database.
+// Reservations, redemptions and point transactions are JSON values of key/value tables, which rules out
+// exclusion constraints and conditional inserts over their fields.
+type PostgresLocks[K ~string] struct {
+	db    *sql.DB
+	class int
+	wait  time.Duration
+	busy  error
+}
+
+// NewPostgresRoomLocks creates new room locks that wait up to wait for a locked room.
+func NewPostgresRoomLocks(db *sql.DB, wait time.Duration) *PostgresLocks[reservation.RoomID] {
+	return &PostgresLocks[reservation.RoomID]{db: db, class: roomLockClass, wait: wait, busy: reservation.ErrRoomBusy}
+}
+
+// NewPostgresPromotionLocks creates new promo code locks that wait up to wait for a locked code.
+func NewPostgresPromotionLocks(db *sql.DB, wait time.Duration) *PostgresLocks[promotion.Code] {
+	return &PostgresLocks[promotion.Code]{db: db, class: promotionLockClass, wait: wait, busy: promotion.ErrCodeBusy}
+}
+
+// NewPostgresLoyaltyLocks creates new locks of the guests' point balances that wait up to wait for a locked guest.
+func NewPostgresLoyaltyLocks(db *sql.DB, wait time.Duration) *PostgresLocks[loyalty.GuestID] {
+	return &PostgresLocks[loyalty.GuestID]{db: db, class: loyaltyLockClass, wait: wait, busy: loyalty.ErrPointsBusy}
+}
+
+// NewPostgresInvoiceLocks creates new locks of the invoice numbers that wait up to wait for a locked jurisdiction.
+func NewPostgresInvoiceLocks(db *sql.DB, wait time.Duration) *PostgresLocks[invoice.JurisdictionID] {
+	return &PostgresLocks[invoice.JurisdictionID]{db: db, class: invoiceLockClass, wait: wait, busy: invoice.ErrNumbersBusy}
+}
+
+// Lock waits until the key is locked and returns the function that releases it.
+// The lock is held by a connection taken from the pool until it is released.
+// It returns the busy error of the locks, e.g. ErrRoomBusy, if the key is still locked after the wait.
+func (l *PostgresLocks[K]) Lock(ctx context.Context, key K) (func(), error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	deadline := time.Now().Add(l.wait)
+	for {
+		var locked bool
+		err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1, hashtext($2))", l.class, string(key)).Scan(&locked)
+		if err != nil {
+			// The lock may have been taken before the query failed.
+			discardConn(conn)
+			return nil, fmt.Errorf("failed to lock %s: %w", key, err)
+		}
+		if locked {
+			return func() { l.unlock(conn, key) }, nil
+		}
+		if time.Now().After(deadline) {
+			_ = conn.Close()
+			return nil, l.busy
+		}
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockPoll):
+		}
+	}
+}
+
+// unlock releases the lock of the key and returns the connection to the pool.
+// The lock belongs to the session, so a connection that still holds it is discarded instead.
+func (l *PostgresLocks[K]) unlock(conn *sql.Conn, key K) {
+	// The request may already be cancelled, but the lock must be released anyway.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var released bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1, hashtext($2))", l.class, string(key)).Scan(&released)
+	if err != nil || !released {
+		discardConn(conn)
+		return
+	}
+	_ = conn.Close()
+}
+
+// discardConn closes the connection instead of returning it to the pool, which ends its session.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -0,0 +1,103 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// tableNamePattern restricts table names to plain SQL identifiers, because they cannot be bound as parameters.
+var tableNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// PostgresTableAccess is the counterpart of resource.PostgresAccess with a configurable table.
+// resource.PostgresAccess always uses kv_store, so a second aggregate type in the same database
+// would be returned by ReadAll of the first; each aggregate type gets its own table instead.
+type PostgresTableAccess[K comparable, V any] struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresTableAccess creates a new key/value access on the table.
+func NewPostgresTableAccess[K comparable, V any](db *sql.DB, table string) (*PostgresTableAccess[K, V], error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	return &PostgresTableAccess[K, V]{db: db, table: table}, nil
+}
+
+// Init creates the table if it does not exist yet. Existing data is kept.
+func (a *PostgresTableAccess[K, V]) Init(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+a.table+" (key TEXT PRIMARY KEY, value TEXT)")
+	return err
+}
+
+// Create inserts a new key/value pair.
+func (a *PostgresTableAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.ExecContext(ctx, "INSERT INTO "+a.table+" (key, value) VALUES ($1, $2)", key, string(encoded))
+	return err
+}
+
+// Read returns the value of the key.
+func (a *PostgresTableAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	var encoded string
+	err := a.db.QueryRowContext(ctx, "SELECT value FROM "+a.table+" WHERE key = $1", key).Scan(&encoded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(resource.ErrorResourceNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var value V
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// ReadAll returns all values of the table.
+func (a *PostgresTableAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	rows, err := a.db.QueryContext(ctx, "SELECT value FROM "+a.table)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var values []V
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, err
+		}
+		var value V
+		if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// Update replaces the value of the key.
+func (a *PostgresTableAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.ExecContext(ctx, "UPDATE "+a.table+" SET value = $1 WHERE key = $2", string(encoded), key)
+	return err
+}
+
+// Delete removes the key.
+func (a *PostgresTableAccess[K, V]) Delete(ctx context.Context, key K) error {
+	_, err := a.db.ExecContext(ctx, "DELETE FROM "+a.table+" WHERE key = $1", key)
+	return err
+}
@@ -0,0 +1,28 @@
+package outbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// PostgresTableAccess Tests
+// ============================================================================
+
+func Test_NewPostgresTableAccess_With_Valid_Table_Should_Succeed(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewPostgresTableAccess[string, string](nil, "household_kv_store")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
+func Test_NewPostgresTableAccess_With_Invalid_Table_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewPostgresTableAccess[string, string](nil, "kv_store; DROP TABLE kv_store")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,31 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// PricingNightPrices implements reservation.NightPrices on top of the pricing service,
+// so an extension night costs what the night would cost when booked.
+type PricingNightPrices struct {
+	pricingService *pricing.Service
+}
+
+// NewPricingNightPrices creates new night prices.
+func NewPricingNightPrices(pricingService *pricing.Service) *PricingNightPrices {
+	return &PricingNightPrices{
+		pricingService: pricingService,
+	}
+}
+
+// NightPrice returns the quote of a one-night stay of the room starting on the night.
+func (p *PricingNightPrices) NightPrice(ctx context.Context, roomID reservation.RoomID, night time.Time) (reservation.Money, error) {
+	quote, err := p.pricingService.Quote(ctx, pricing.RoomID(roomID), night, night.AddDate(0, 0, 1))
+	if err != nil {
+		return reservation.Money{}, err
+	}
+	return quote.Total, nil
+}
@@ -0,0 +1,42 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// PricingNightPrices Tests
+// ============================================================================
+
+func Test_PricingNightPrices_NightPrice_Should_Quote_One_Night(t *testing.T) {
+	// Arrange
+	pricingService := pricing.NewService(resource.NewInMemoryAccess[pricing.RoomID, pricing.RatePlan]())
+	pricingService.SetDefaultRates(map[pricing.RoomID]pricing.Money{"room-101": shared.NewMoney(9900, "USD")})
+	prices := outbound.NewPricingNightPrices(pricingService)
+
+	// Act
+	price, err := prices.NightPrice(context.Background(), "room-101", time.Date(2030, 6, 4, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "price must be the nightly rate", price, shared.NewMoney(9900, "USD"))
+}
+
+func Test_PricingNightPrices_NightPrice_Of_Unknown_Room_Should_Return_Error(t *testing.T) {
+	// Arrange
+	prices := outbound.NewPricingNightPrices(pricing.NewService(resource.NewInMemoryAccess[pricing.RoomID, pricing.RatePlan]()))
+
+	// Act
+	_, err := prices.NightPrice(context.Background(), "room-999", time.Date(2030, 6, 4, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
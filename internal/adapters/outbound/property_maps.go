@@ -0,0 +1,131 @@
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrUnknownMapProvider is returned if the configured static map provider is not supported.
+var ErrUnknownMapProvider = errors.New("unknown map provider")
+
+// PropertyLocation describes where the property is.
+// Coordinates of 0,0 are treated as unset; links then fall back to the address.
+type PropertyLocation struct {
+	Name      string
+	Address   string
+	Latitude  float64
+	Longitude float64
+}
+
+// hasCoordinates checks if the location has coordinates.
+func (l PropertyLocation) hasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+// coordinates returns the coordinates as "latitude,longitude".
+func (l PropertyLocation) coordinates() string {
+	return strconv.FormatFloat(l.Latitude, 'f', -1, 64) + "," + strconv.FormatFloat(l.Longitude, 'f', -1, 64)
+}
+
+// StaticMapProvider builds the URL of a static map image centered on a location.
+// Each provider brings its own API key, so providers can be swapped by configuration.
+type StaticMapProvider interface {
+	StaticMapURL(loc PropertyLocation, width, height int) string
+}
+
+// NewStaticMapProvider creates the static map provider by name ("google" or "mapbox").
+// An empty name or "none" disables static maps and returns nil.
+func NewStaticMapProvider(name, apiKey string) (StaticMapProvider, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "google":
+		return &GoogleStaticMaps{apiKey: apiKey}, nil
+	case "mapbox":
+		return &MapboxStaticMaps{accessToken: apiKey}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMapProvider, name)
+	}
+}
+
+// GoogleStaticMaps builds map images with the Google Maps Static API.
+type GoogleStaticMaps struct {
+	apiKey string
+}
+
+// StaticMapURL returns the map image URL with a marker on the location.
+func (g *GoogleStaticMaps) StaticMapURL(loc PropertyLocation, width, height int) string {
+	center := loc.Address
+	if loc.hasCoordinates() {
+		center = loc.coordinates()
+	}
+	q := url.Values{}
+	q.Set("center", center)
+	q.Set("zoom", "15")
+	q.Set("size", fmt.Sprintf("%dx%d", width, height))
+	q.Set("markers", center)
+	q.Set("key", g.apiKey)
+	return "https://maps.googleapis.com/maps/api/staticmap?" + q.Encode()
+}
+
+// MapboxStaticMaps builds map images with the Mapbox Static Images API.
+// Mapbox needs coordinates; locations with an address only get no map.
+type MapboxStaticMaps struct {
+	accessToken string
+}
+
+// StaticMapURL returns the map image URL with a pin on the location.
+func (m *MapboxStaticMaps) StaticMapURL(loc PropertyLocation, width, height int) string {
+	if !loc.hasCoordinates() {
+		return ""
+	}
+	lngLat := strconv.FormatFloat(loc.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(loc.Latitude, 'f', -1, 64)
+	return fmt.Sprintf("https://api.mapbox.com/styles/v1/mapbox/streets-v12/static/pin-l(%s)/%s,15/%dx%d?access_token=%s",
+		lngLat, lngLat, width, height, url.QueryEscape(m.accessToken))
+}
+
+// PropertyMaps provides the location, map image and directions links of the property
+// for the reservation pages and emails.
+type PropertyMaps struct {
+	location PropertyLocation
+	provider StaticMapProvider
+}
+
+// NewPropertyMaps creates the property maps. The provider may be nil to omit the map image.
+func NewPropertyMaps(location PropertyLocation, provider StaticMapProvider) *PropertyMaps {
+	return &PropertyMaps{location: location, provider: provider}
+}
+
+// PropertyName returns the name of the property.
+func (p *PropertyMaps) PropertyName() string {
+	return p.location.Name
+}
+
+// PropertyAddress returns the postal address of the property.
+func (p *PropertyMaps) PropertyAddress() string {
+	return p.location.Address
+}
+
+// StaticMapURL returns the URL of the map image, or an empty string if no provider is configured.
+func (p *PropertyMaps) StaticMapURL(width, height int) string {
+	if p.provider == nil {
+		return ""
+	}
+	return p.provider.StaticMapURL(p.location, width, height)
+}
+
+// DirectionsURLs returns "get directions" links to the property by map service name.
+// They need no API key and open the guest's preferred map app.
+func (p *PropertyMaps) DirectionsURLs() map[string]string {
+	destination := p.location.Address
+	if p.location.hasCoordinates() {
+		destination = p.location.coordinates()
+	}
+	return map[string]string{
+		"Apple Maps":    "https://maps.apple.com/?daddr=" + url.QueryEscape(destination),
+		"Google Maps":   "https://www.google.com/maps/dir/?api=1&destination=" + url.QueryEscape(destination),
+		"OpenStreetMap": "https://www.openstreetmap.org/directions?to=" + url.QueryEscape(destination),
+	}
+}
@@ -0,0 +1,97 @@
+package outbound_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// NewStaticMapProvider Tests
+// ============================================================================
+
+func Test_NewStaticMapProvider_With_None_Should_Return_Nil(t *testing.T) {
+	// Arrange & Act
+	provider, err := outbound.NewStaticMapProvider("none", "")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "provider must be nil", provider == nil, true)
+}
+
+func Test_NewStaticMapProvider_With_Unknown_Name_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewStaticMapProvider("bing", "key")
+
+	// Assert
+	assert.That(t, "error must be ErrUnknownMapProvider", errors.Is(err, outbound.ErrUnknownMapProvider), true)
+}
+
+func Test_GoogleStaticMaps_Should_Center_On_Coordinates_With_Key(t *testing.T) {
+	// Arrange
+	provider, _ := outbound.NewStaticMapProvider("google", "secret-key")
+	loc := outbound.PropertyLocation{Address: "1 Harbor Road", Latitude: 53.5461, Longitude: 9.9661}
+
+	// Act
+	mapURL := provider.StaticMapURL(loc, 600, 300)
+
+	// Assert
+	assert.That(t, "url must use google", strings.HasPrefix(mapURL, "https://maps.googleapis.com/maps/api/staticmap?"), true)
+	assert.That(t, "url must contain center", strings.Contains(mapURL, "center=53.5461%2C9.9661"), true)
+	assert.That(t, "url must contain size", strings.Contains(mapURL, "size=600x300"), true)
+	assert.That(t, "url must contain key", strings.Contains(mapURL, "key=secret-key"), true)
+}
+
+func Test_MapboxStaticMaps_Without_Coordinates_Should_Return_Empty_URL(t *testing.T) {
+	// Arrange
+	provider, _ := outbound.NewStaticMapProvider("mapbox", "token")
+
+	// Act
+	mapURL := provider.StaticMapURL(outbound.PropertyLocation{Address: "1 Harbor Road"}, 600, 300)
+
+	// Assert
+	assert.That(t, "url must be empty", mapURL, "")
+}
+
+func Test_MapboxStaticMaps_Should_Use_Longitude_First(t *testing.T) {
+	// Arrange
+	provider, _ := outbound.NewStaticMapProvider("mapbox", "token")
+	loc := outbound.PropertyLocation{Latitude: 53.5461, Longitude: 9.9661}
+
+	// Act
+	mapURL := provider.StaticMapURL(loc, 600, 300)
+
+	// Assert
+	assert.That(t, "url must contain pin at lng,lat", strings.Contains(mapURL, "pin-l(9.9661,53.5461)/9.9661,53.5461,15/600x300"), true)
+	assert.That(t, "url must contain token", strings.HasSuffix(mapURL, "access_token=token"), true)
+}
+
+// ============================================================================
+// PropertyMaps Tests
+// ============================================================================
+
+func Test_PropertyMaps_Without_Provider_Should_Return_Empty_Map_URL(t *testing.T) {
+	// Arrange
+	maps := outbound.NewPropertyMaps(outbound.PropertyLocation{Address: "1 Harbor Road"}, nil)
+
+	// Act
+	mapURL := maps.StaticMapURL(600, 300)
+
+	// Assert
+	assert.That(t, "url must be empty", mapURL, "")
+}
+
+func Test_PropertyMaps_DirectionsURLs_Should_Fall_Back_To_Address(t *testing.T) {
+	// Arrange
+	maps := outbound.NewPropertyMaps(outbound.PropertyLocation{Address: "1 Harbor Road, Hamburg"}, nil)
+
+	// Act
+	links := maps.DirectionsURLs()
+
+	// Assert
+	assert.That(t, "must offer three map services", len(links), 3)
+	assert.That(t, "google link must use address", links["Google Maps"], "https://www.google.com/maps/dir/?api=1&destination=1+Harbor+Road%2C+Hamburg")
+}
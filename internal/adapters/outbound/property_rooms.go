@@ -0,0 +1,30 @@
+package outbound
+
+import (
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// PropertyRooms implements reservation.RoomProperties on top of the property catalog.
+type PropertyRooms struct {
+	catalog *property.Catalog
+}
+
+// NewPropertyRooms creates new property rooms.
+func NewPropertyRooms(catalog *property.Catalog) *PropertyRooms {
+	return &PropertyRooms{
+		catalog: catalog,
+	}
+}
+
+// PropertyOf returns the property that owns the room.
+func (p *PropertyRooms) PropertyOf(roomID reservation.RoomID) reservation.PropertyID {
+	return p.catalog.PropertyOf(property.RoomID(roomID))
+}
+
+// Location returns the timezone of the property, or of the default property if it is unknown.
+func (p *PropertyRooms) Location(propertyID reservation.PropertyID) *time.Location {
+	return p.catalog.Location(propertyID)
+}
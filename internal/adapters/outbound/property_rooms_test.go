@@ -0,0 +1,31 @@
+package outbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// PropertyRooms Tests
+// ============================================================================
+
+func Test_PropertyRooms_PropertyOf_Should_Map_Listed_And_Unlisted_Rooms(t *testing.T) {
+	// Arrange
+	catalog, _ := property.NewCatalog(
+		property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "UTC"},
+		property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "UTC", Rooms: []property.RoomID{"room-201"}},
+	)
+	rooms := outbound.NewPropertyRooms(catalog)
+
+	// Act
+	listed := rooms.PropertyOf("room-201")
+	unlisted := rooms.PropertyOf("room-101")
+
+	// Assert
+	assert.That(t, "listed room must belong to its property", listed, reservation.PropertyID("muc"))
+	assert.That(t, "unlisted room must belong to the default property", unlisted, reservation.PropertyID("ber"))
+}
@@ -0,0 +1,277 @@
+package outbound
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/andygeiss/cloud-native-utils/security"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrPushSubscriptionGone is returned by push backends when the push service no longer knows the subscription.
+var ErrPushSubscriptionGone = errors.New("push subscription expired or unsubscribed")
+
+// PushMessage is the payload of a push notification. The service worker shows the title and body,
+// opens the URL on click and reports the engagement with the ID and token.
+type PushMessage struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+	Token string `json:"token,omitempty"`
+}
+
+// PushEngagementTokens signs the IDs of push messages with HMAC-SHA256, like the CSRF tokens of the sessions,
+// so only the devices that received a notification can report its engagement.
+type PushEngagementTokens struct {
+	secret []byte
+}
+
+// NewPushEngagementTokens creates a new signer of push message IDs.
+func NewPushEngagementTokens(secret string) *PushEngagementTokens {
+	return &PushEngagementTokens{secret: []byte(secret)}
+}
+
+// Sign returns the token of the push message ID.
+func (t *PushEngagementTokens) Sign(id string) string {
+	h := hmac.New(sha256.New, t.secret)
+	h.Write([]byte(id))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify reports whether the token was signed for the push message ID.
+func (t *PushEngagementTokens) Verify(id, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(t.Sign(id)))
+}
+
+// PushBackend delivers push messages to the subscriptions of its platform, e.g. Web Push or FCM.
+// Backends return ErrPushSubscriptionGone when the device unsubscribed.
+type PushBackend interface {
+	Platform() profile.PushPlatform
+	Send(ctx context.Context, subscription profile.PushSubscription, message PushMessage) error
+}
+
+// PushNotificationService implements orchestration.NotificationService by sending the notification with the
+// wrapped service (email) and then to every device the guest subscribed for push notifications.
+// Push is best effort: failures are logged and recorded in the communication history, never returned,
+// so a booking does not fail because a phone is offline. Subscriptions the push service reports as gone are removed.
+type PushNotificationService struct {
+	next         orchestration.NotificationService
+	uiURL        string
+	logger       *slog.Logger
+	locale       shared.Locale
+	backends     map[profile.PushPlatform]PushBackend
+	profiles     *profile.Service
+	reservations *reservation.Service
+	history      *CommunicationHistory
+	tokens       *PushEngagementTokens
+	now          func() time.Time
+}
+
+// NewPushNotificationService creates a new push notification service around next.
+// The uiURL (e.g. http://localhost:8080/ui) is used to link the reservation pages from the notifications.
+func NewPushNotificationService(next orchestration.NotificationService, uiURL string, logger *slog.Logger) *PushNotificationService {
+	return &PushNotificationService{
+		next:     next,
+		uiURL:    strings.TrimSuffix(uiURL, "/"),
+		logger:   logger,
+		locale:   shared.DefaultLocale,
+		backends: make(map[profile.PushPlatform]PushBackend),
+		now:      time.Now,
+	}
+}
+
+// WithBackends sends the notifications through the backends, one per platform.
+// Subscriptions of platforms without a backend are skipped.
+func (s *PushNotificationService) WithBackends(backends ...PushBackend) *PushNotificationService {
+	for _, backend := range backends {
+		s.backends[backend.Platform()] = backend
+	}
+	return s
+}
+
+// WithProfiles reads the push subscriptions and preferred languages of the guests from the profile service.
+// Without it nothing is pushed.
+func (s *PushNotificationService) WithProfiles(profileService *profile.Service) *PushNotificationService {
+	s.profiles = profileService
+	return s
+}
+
+// WithReservations pushes payment receipts to the guest of the paid reservation.
+func (s *PushNotificationService) WithReservations(reservationService *reservation.Service) *PushNotificationService {
+	s.reservations = reservationService
+	return s
+}
+
+// WithHistory records every push notification and its engagement in the communication history.
+func (s *PushNotificationService) WithHistory(history *CommunicationHistory) *PushNotificationService {
+	s.history = history
+	return s
+}
+
+// WithEngagementTokens signs the messages, so the devices can report the engagement.
+// Without it the reports are rejected.
+func (s *PushNotificationService) WithEngagementTokens(tokens *PushEngagementTokens) *PushNotificationService {
+	s.tokens = tokens
+	return s
+}
+
+// WithLocale writes the notifications in the language of the locale for guests without a preferred language.
+func (s *PushNotificationService) WithLocale(locale shared.Locale) *PushNotificationService {
+	s.locale = locale
+	return s
+}
+
+// SendReservationConfirmation sends the confirmation and pushes it to the guest's devices.
+func (s *PushNotificationService) SendReservationConfirmation(ctx context.Context, res *reservation.Reservation) error {
+	err := s.next.SendReservationConfirmation(ctx, res)
+	texts, locale := s.texts(ctx, res.GuestID)
+	s.push(ctx, res, "reservation_confirmation",
+		fmt.Sprintf(texts.ConfirmationSubject, res.ID),
+		fmt.Sprintf(texts.ConfirmationText, res.DateRange.CheckIn.Format("2006-01-02"), res.DateRange.CheckOut.Format("2006-01-02"), res.TotalAmount.FormatIn(locale)))
+	return err
+}
+
+// SendCancellationNotice sends the cancellation notice and pushes it to the guest's devices.
+func (s *PushNotificationService) SendCancellationNotice(ctx context.Context, res *reservation.Reservation, reason string) error {
+	err := s.next.SendCancellationNotice(ctx, res, reason)
+	texts, _ := s.texts(ctx, res.GuestID)
+	s.push(ctx, res, "cancellation_notice",
+		fmt.Sprintf(texts.CancellationSubject, res.ID),
+		fmt.Sprintf(texts.CancellationIntro, res.DateRange.CheckIn.Format("2006-01-02"), res.DateRange.CheckOut.Format("2006-01-02")))
+	return err
+}
+
+// SendPaymentReceipt sends the receipt and pushes it to the devices of the guest of the paid reservation.
+func (s *PushNotificationService) SendPaymentReceipt(ctx context.Context, pay *payment.Payment) error {
+	err := s.next.SendPaymentReceipt(ctx, pay)
+	if s.reservations == nil || !s.enabled() {
+		return err
+	}
+	res, findErr := s.reservations.GetReservation(ctx, pay.ReservationID)
+	if findErr != nil {
+		s.logger.Warn("failed to push payment receipt", "payment_id", pay.ID, "error", findErr)
+		return err
+	}
+	texts, locale := s.texts(ctx, res.GuestID)
+	s.push(ctx, res, "payment_receipt",
+		fmt.Sprintf(texts.ReceiptSubject, res.ID),
+		fmt.Sprintf(texts.ReceiptText, pay.Amount.FormatIn(locale), pay.PaymentMethod))
+	return err
+}
+
+// SendCheckInWelcome sends the check-in welcome and pushes it to the guest's devices.
+func (s *PushNotificationService) SendCheckInWelcome(ctx context.Context, res *reservation.Reservation) error {
+	err := s.next.SendCheckInWelcome(ctx, res)
+	texts, _ := s.texts(ctx, res.GuestID)
+	s.push(ctx, res, "check_in_welcome",
+		fmt.Sprintf(texts.CheckInSubject, res.ID),
+		fmt.Sprintf(texts.CheckInText, res.RoomID, res.DateRange.CheckOut.Format("2006-01-02")))
+	return err
+}
+
+// enabled reports whether guests can have push subscriptions.
+func (s *PushNotificationService) enabled() bool {
+	return s.profiles != nil && s.profiles.PushEnabled() && len(s.backends) > 0
+}
+
+// texts returns the email texts in the guest's preferred language, whose subjects and sentences
+// the notifications reuse, and the locale to format amounts in.
+func (s *PushNotificationService) texts(ctx context.Context, guestID reservation.GuestID) (emailTexts, shared.Locale) {
+	locale := s.locale
+	if s.profiles != nil {
+		if preferred, ok := s.profiles.LanguageOf(ctx, profile.GuestID(guestID)); ok {
+			locale = preferred
+		}
+	}
+	texts, _ := emailTextsFor(locale, s.locale)
+	return texts, locale
+}
+
+// push sends the notification to every subscription of the guest and records each message.
+func (s *PushNotificationService) push(ctx context.Context, res *reservation.Reservation, template, title, body string) {
+	if !s.enabled() {
+		return
+	}
+	subscriptions, err := s.profiles.PushSubscriptions(ctx, profile.GuestID(res.GuestID))
+	if err != nil {
+		s.logger.Warn("failed to read push subscriptions", "guest_id", res.GuestID, "error", err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		backend, ok := s.backends[subscription.Via()]
+		if !ok {
+			continue
+		}
+		message := PushMessage{
+			ID:    security.GenerateID(),
+			Title: title,
+			Body:  capitalize(body),
+			URL:   s.uiURL + "/reservations/" + string(res.ID),
+		}
+		if s.tokens != nil {
+			message.Token = s.tokens.Sign(message.ID)
+		}
+		c := shared.Communication{
+			ID:            message.ID,
+			Channel:       "push",
+			Template:      template,
+			Recipient:     pushDevice(subscription),
+			Subject:       message.Title,
+			Body:          message.Body,
+			GuestID:       string(res.GuestID),
+			ReservationID: string(res.ID),
+			Status:        string(EmailSent),
+			Attempts:      1,
+			QueuedAt:      s.now(),
+		}
+
+		err := backend.Send(ctx, subscription, message)
+		switch {
+		case err == nil:
+			c.SentAt = s.now()
+		case errors.Is(err, ErrPushSubscriptionGone):
+			c.Status, c.Error = string(EmailFailed), err.Error()
+			if removeErr := s.profiles.RemovePushSubscription(ctx, subscription.ID); removeErr != nil {
+				s.logger.Warn("failed to remove push subscription", "subscription_id", subscription.ID, "error", removeErr)
+			}
+		default:
+			c.Status, c.Error = string(EmailFailed), err.Error()
+			s.logger.Warn("failed to send push notification", "subscription_id", subscription.ID, "platform", subscription.Via(), "error", err)
+		}
+		if s.history != nil {
+			s.history.RecordMessage(ctx, c)
+		}
+	}
+}
+
+// pushDevice describes the subscribed device for the communication history.
+func pushDevice(subscription profile.PushSubscription) string {
+	if subscription.UserAgent == "" {
+		return string(subscription.Via())
+	}
+	return string(subscription.Via()) + ": " + subscription.UserAgent
+}
+
+// capitalize upper-cases the first letter; the email sentences follow the greeting and start lower-case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
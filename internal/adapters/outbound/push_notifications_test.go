@@ -0,0 +1,161 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// recordingPushBackend records the messages per subscription and fails with err.
+type recordingPushBackend struct {
+	platform profile.PushPlatform
+	sent     map[profile.PushSubscriptionID]outbound.PushMessage
+	err      error
+}
+
+func (b *recordingPushBackend) Platform() profile.PushPlatform { return b.platform }
+
+func (b *recordingPushBackend) Send(ctx context.Context, subscription profile.PushSubscription, message outbound.PushMessage) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.sent[subscription.ID] = message
+	return nil
+}
+
+// createTestPushNotifications returns a push notification service with an FCM backend and the guest's app subscribed.
+func createTestPushNotifications(t *testing.T, backendErr error) (*outbound.PushNotificationService, *recordingPushBackend, *profile.Service, *outbound.CommunicationHistory) {
+	t.Helper()
+	profiles := profile.NewService(nil, nil, nil, profile.DefaultTierPolicy()).
+		WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), "")
+	if _, err := profiles.SubscribeFCM(context.Background(), "guest-001", "fcm-token-1", "HotelApp/2.1"); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	backend := &recordingPushBackend{platform: profile.PushFCM, sent: make(map[profile.PushSubscriptionID]outbound.PushMessage), err: backendErr}
+	history := outbound.NewCommunicationHistory(resource.NewInMemoryAccess[string, shared.Communication](), nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := outbound.NewPushNotificationService(outbound.NewMockNotificationService(logger, "http://localhost:8080/ui", nil), "http://localhost:8080/ui", logger).
+		WithProfiles(profiles).
+		WithBackends(backend).
+		WithHistory(history)
+	return svc, backend, profiles, history
+}
+
+// ============================================================================
+// PushNotificationService Tests
+// ============================================================================
+
+func Test_PushNotificationService_SendReservationConfirmation_Should_Push_To_Guest_Devices(t *testing.T) {
+	// Arrange
+	svc, backend, _, history := createTestPushNotifications(t, nil)
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	message := backend.sent[profile.FCMSubscriptionIDOf("fcm-token-1")]
+	recorded, _ := history.ForReservation(context.Background(), "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "title must be the subject", message.Title, "Your reservation res-001 is confirmed")
+	assert.That(t, "body must start upper-case", strings.HasPrefix(message.Body, "Your stay from"), true)
+	assert.That(t, "URL must link the reservation", message.URL, "http://localhost:8080/ui/reservations/res-001")
+	assert.That(t, "push must be recorded", len(recorded), 1)
+	assert.That(t, "record must be the push message", recorded[0].ID, message.ID)
+	assert.That(t, "channel must be push", recorded[0].Channel, "push")
+	assert.That(t, "recipient must be the device", recorded[0].Recipient, "fcm: HotelApp/2.1")
+	assert.That(t, "status must be sent", recorded[0].Status, "sent")
+}
+
+func Test_PushNotificationService_WithEngagementTokens_Should_Sign_Message_ID(t *testing.T) {
+	// Arrange
+	svc, backend, _, _ := createTestPushNotifications(t, nil)
+	tokens := outbound.NewPushEngagementTokens("secret")
+	svc.WithEngagementTokens(tokens)
+
+	// Act
+	err := svc.SendReservationConfirmation(context.Background(), createTestReservation())
+
+	// Assert
+	message := backend.sent[profile.FCMSubscriptionIDOf("fcm-token-1")]
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "token must be signed for the message", tokens.Verify(message.ID, message.Token), true)
+	assert.That(t, "token must not be valid for another message", tokens.Verify("other", message.Token), false)
+	assert.That(t, "token of another secret must be invalid", outbound.NewPushEngagementTokens("other").Verify(message.ID, message.Token), false)
+}
+
+func Test_PushNotificationService_With_Gone_Subscription_Should_Remove_It(t *testing.T) {
+	// Arrange
+	svc, _, profiles, history := createTestPushNotifications(t, outbound.ErrPushSubscriptionGone)
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendCancellationNotice(context.Background(), res, "guest request")
+
+	// Assert
+	subscriptions, _ := profiles.PushSubscriptions(context.Background(), "guest-001")
+	recorded, _ := history.ForReservation(context.Background(), "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subscription must be removed", len(subscriptions), 0)
+	assert.That(t, "push must be recorded as failed", recorded[0].Status, "failed")
+	assert.That(t, "failed push must not be resendable", recorded[0].Resendable(), false)
+}
+
+func Test_PushNotificationService_With_Failing_Backend_Should_Not_Fail_Notification(t *testing.T) {
+	// Arrange
+	svc, _, profiles, _ := createTestPushNotifications(t, errors.New("fcm returned 503"))
+	res := createTestReservation()
+
+	// Act
+	err := svc.SendReservationConfirmation(context.Background(), res)
+
+	// Assert
+	subscriptions, _ := profiles.PushSubscriptions(context.Background(), "guest-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subscription must be kept", len(subscriptions), 1)
+}
+
+// ============================================================================
+// CommunicationHistory Engagement Tests
+// ============================================================================
+
+func Test_CommunicationHistory_RecordEngagement_Click_Should_Imply_Delivery(t *testing.T) {
+	// Arrange
+	svc, backend, _, history := createTestPushNotifications(t, nil)
+	_ = svc.SendReservationConfirmation(context.Background(), createTestReservation())
+	id := backend.sent[profile.FCMSubscriptionIDOf("fcm-token-1")].ID
+
+	// Act
+	err := history.RecordEngagement(context.Background(), id, shared.EngagementClicked)
+
+	// Assert
+	recorded, _ := history.ForReservation(context.Background(), "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "click must be recorded", recorded[0].ClickedAt.IsZero(), false)
+	assert.That(t, "delivery must be recorded", recorded[0].DeliveredAt.IsZero(), false)
+}
+
+func Test_CommunicationHistory_RecordEngagement_Of_Email_Should_Return_ErrCommunicationNotFound(t *testing.T) {
+	// Arrange
+	history, queue := createTestCommunicationHistory(&recordingEmailProvider{})
+	_ = queue.Enqueue(context.Background(), outbound.Email{ID: "e-1", To: "a@example.com", ReservationID: "res-1"})
+
+	// Act
+	err := history.RecordEngagement(context.Background(), "e-1", shared.EngagementDelivered)
+
+	// Assert
+	assert.That(t, "err must be ErrCommunicationNotFound", errors.Is(err, shared.ErrCommunicationNotFound), true)
+}
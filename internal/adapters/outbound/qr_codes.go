@@ -0,0 +1,433 @@
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrQRCodeDataTooLong is returned if the data does not fit into the largest supported QR code.
+var ErrQRCodeDataTooLong = errors.New("data too long for qr code")
+
+// qrVersion describes the block structure of a QR code version at error correction level M.
+type qrVersion struct {
+	ecPerBlock int
+	blocks     []int // data codewords per block
+	alignment  []int // alignment pattern center coordinates
+}
+
+// qrVersions lists versions 1-10 at error correction level M (up to 213 bytes), enough for URLs.
+var qrVersions = []qrVersion{
+	{10, []int{16}, nil},
+	{16, []int{28}, []int{6, 18}},
+	{26, []int{44}, []int{6, 22}},
+	{18, []int{32, 32}, []int{6, 26}},
+	{24, []int{43, 43}, []int{6, 30}},
+	{16, []int{27, 27, 27, 27}, []int{6, 34}},
+	{18, []int{31, 31, 31, 31}, []int{6, 22, 38}},
+	{22, []int{38, 38, 39, 39}, []int{6, 24, 42}},
+	{22, []int{36, 36, 36, 37, 37}, []int{6, 26, 46}},
+	{26, []int{43, 43, 43, 43, 44}, []int{6, 28, 50}},
+}
+
+// QRCodes renders QR codes as inline SVG images without external services,
+// so printed confirmations work offline and no reservation data leaves the server.
+type QRCodes struct {
+	moduleSize int
+}
+
+// NewQRCodes creates a new QR code renderer drawing each module with moduleSize pixels.
+func NewQRCodes(moduleSize int) *QRCodes {
+	if moduleSize < 1 {
+		moduleSize = 1
+	}
+	return &QRCodes{moduleSize: moduleSize}
+}
+
+// SVG encodes the data in byte mode and returns the QR code as SVG markup.
+func (q *QRCodes) SVG(data string) (string, error) {
+	modules, err := EncodeQRCode([]byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	// A quiet zone of 4 modules is required around the symbol.
+	size := len(modules)
+	dim := (size + 8) * q.moduleSize
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges" role="img" aria-label="QR code">`, dim, dim, size+8, size+8)
+	b.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+	for y, row := range modules {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+4, y+4)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String(), nil
+}
+
+// EncodeQRCode encodes the data as a QR code in byte mode with error correction level M
+// and returns the modules (true is dark) indexed by row and column.
+func EncodeQRCode(data []byte) ([][]bool, error) {
+	for i, v := range qrVersions {
+		version := i + 1
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+		capacity := 0
+		for _, n := range v.blocks {
+			capacity += n
+		}
+		if 4+countBits+8*len(data) > 8*capacity {
+			continue
+		}
+		codewords := qrCodewords(data, v, countBits, capacity)
+		return newQRMatrix(version, v).draw(codewords), nil
+	}
+	return nil, ErrQRCodeDataTooLong
+}
+
+// qrCodewords builds the data codewords and interleaves them with the error correction codewords.
+func qrCodewords(data []byte, v qrVersion, countBits, capacity int) []byte {
+	var bits qrBitBuffer
+	bits.append(0b0100, 4) // byte mode
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	bits.append(0, min(4, 8*capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < 8*capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	raw := bits.bytes()
+
+	divisor := qrDivisor(v.ecPerBlock)
+	dataBlocks := make([][]byte, len(v.blocks))
+	ecBlocks := make([][]byte, len(v.blocks))
+	offset := 0
+	for i, n := range v.blocks {
+		dataBlocks[i] = raw[offset : offset+n]
+		ecBlocks[i] = qrRemainder(dataBlocks[i], divisor)
+		offset += n
+	}
+
+	result := make([]byte, 0, capacity+v.ecPerBlock*len(v.blocks))
+	for i := 0; i < v.blocks[len(v.blocks)-1]; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// qrBitBuffer is a sequence of bits, most significant bit first.
+type qrBitBuffer []bool
+
+func (b *qrBitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b qrBitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
+
+// qrDivisor returns the Reed-Solomon generator polynomial of the degree, without the leading term.
+func qrDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// qrRemainder returns the Reed-Solomon error correction codewords of the data.
+func qrRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= qrMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// qrMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func qrMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrMatrix holds the modules of a QR code and marks the function patterns that data must skip.
+type qrMatrix struct {
+	size     int
+	version  int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newQRMatrix(version int, v qrVersion) *qrMatrix {
+	size := 17 + 4*version
+	m := &qrMatrix{size: size, version: version, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range size {
+		m.modules[i] = make([]bool, size)
+		m.function[i] = make([]bool, size)
+	}
+
+	// Timing patterns
+	for i := range size {
+		m.set(6, i, i%2 == 0)
+		m.set(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with separators
+	for _, c := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x < 0 || x >= size || y < 0 || y >= size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				m.set(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	// Alignment patterns, except where they would overlap the finder patterns
+	last := len(v.alignment) - 1
+	for i, cx := range v.alignment {
+		for j, cy := range v.alignment {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; they are drawn after masking.
+	m.drawFormat(0)
+
+	// Version information for versions 7 and above
+	if version >= 7 {
+		rem := version
+		for range 12 {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+		for i := range 18 {
+			bit := (bits>>i)&1 == 1
+			a, b := size-11+i%3, i/3
+			m.set(a, b, bit)
+			m.set(b, a, bit)
+		}
+	}
+	return m
+}
+
+// draw places the codewords, applies the mask with the lowest penalty and returns the modules.
+func (m *qrMatrix) draw(codewords []byte) [][]bool {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range m.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vert
+				}
+				if !m.function[y][x] && i < len(codewords)*8 {
+					m.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+
+	best, bestPenalty := 0, -1
+	for mask := range 8 {
+		m.applyMask(mask)
+		m.drawFormat(mask)
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		m.applyMask(mask)
+	}
+	m.applyMask(best)
+	m.drawFormat(best)
+	return m.modules
+}
+
+// drawFormat draws both copies of the format information for level M and the mask.
+func (m *qrMatrix) drawFormat(mask int) {
+	data := mask // level M is encoded as 0b00
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := range 6 {
+		m.set(8, i, bit(i))
+	}
+	m.set(8, 7, bit(6))
+	m.set(8, 8, bit(7))
+	m.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.set(14-i, 8, bit(i))
+	}
+	for i := range 8 {
+		m.set(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.set(8, m.size-15+i, bit(i))
+	}
+	m.set(8, m.size-8, true) // dark module
+}
+
+// applyMask inverts the data modules selected by the mask pattern. Applying it twice undoes it.
+func (m *qrMatrix) applyMask(mask int) {
+	for y := range m.size {
+		for x := range m.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !m.function[y][x] {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol by the rules of ISO/IEC 18004, lower is easier to scan.
+func (m *qrMatrix) penalty() int {
+	result := 0
+	at := func(x, y int, horizontal bool) bool {
+		if horizontal {
+			return m.modules[y][x]
+		}
+		return m.modules[x][y]
+	}
+
+	for _, horizontal := range []bool{true, false} {
+		for y := range m.size {
+			run := 1
+			for x := 1; x < m.size; x++ {
+				if at(x, y, horizontal) == at(x-1, y, horizontal) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				result += run - 2
+			}
+
+			// Finder-like patterns 1:1:3:1:1 with 4 light modules on one side
+			for x := 0; x+10 < m.size; x++ {
+				var pattern strings.Builder
+				for k := range 11 {
+					if at(x+k, y, horizontal) {
+						pattern.WriteByte('1')
+					} else {
+						pattern.WriteByte('0')
+					}
+				}
+				if p := pattern.String(); p == "10111010000" || p == "00001011101" {
+					result += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := range m.size {
+		for x := range m.size {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size {
+				c := m.modules[y][x]
+				if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+	total := m.size * m.size
+	result += abs(dark*20-total*10) / total * 10
+	return result
+}
+
+// set sets a function module at column x and row y.
+func (m *qrMatrix) set(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
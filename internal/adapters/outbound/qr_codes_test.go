@@ -0,0 +1,83 @@
+package outbound_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// EncodeQRCode Tests
+// ============================================================================
+
+func Test_EncodeQRCode_With_Short_Data_Should_Use_Version_1(t *testing.T) {
+	// Arrange
+	data := []byte("res-001")
+
+	// Act
+	modules, err := outbound.EncodeQRCode(data)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "version 1 must have 21 modules", len(modules), 21)
+}
+
+func Test_EncodeQRCode_With_URL_Should_Select_Larger_Version(t *testing.T) {
+	// Arrange
+	data := []byte("https://hotel.example.com/ui/reservations/res-0f8a3c2e-9b1d-4e7a-8c6f-2d5b9e1a7c3f")
+
+	// Act
+	modules, err := outbound.EncodeQRCode(data)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "version 5 must have 37 modules", len(modules), 37)
+}
+
+func Test_EncodeQRCode_Should_Draw_Finder_Patterns(t *testing.T) {
+	// Arrange
+	data := []byte("res-001")
+
+	// Act
+	modules, _ := outbound.EncodeQRCode(data)
+
+	// Assert
+	size := len(modules)
+	for _, corner := range [][2]int{{0, 0}, {0, size - 7}, {size - 7, 0}} {
+		y, x := corner[0], corner[1]
+		assert.That(t, "outer ring must be dark", modules[y][x] && modules[y+6][x+6], true)
+		assert.That(t, "inner ring must be light", modules[y+1][x+1], false)
+		assert.That(t, "center must be dark", modules[y+3][x+3], true)
+	}
+}
+
+func Test_EncodeQRCode_With_Too_Long_Data_Should_Return_Error(t *testing.T) {
+	// Arrange
+	data := []byte(strings.Repeat("x", 214))
+
+	// Act
+	_, err := outbound.EncodeQRCode(data)
+
+	// Assert
+	assert.That(t, "error must be ErrQRCodeDataTooLong", err, outbound.ErrQRCodeDataTooLong)
+}
+
+// ============================================================================
+// QRCodes Tests
+// ============================================================================
+
+func Test_QRCodes_SVG_Should_Return_Scaled_Image_With_Quiet_Zone(t *testing.T) {
+	// Arrange
+	qr := outbound.NewQRCodes(4)
+
+	// Act
+	svg, err := qr.SVG("res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "svg must start with svg element", strings.HasPrefix(svg, "<svg"), true)
+	assert.That(t, "svg must be (21+8)*4 pixels wide", strings.Contains(svg, `width="116"`), true)
+	assert.That(t, "svg must draw modules", strings.Contains(svg, "M4 4h1v1h-1z"), true)
+}
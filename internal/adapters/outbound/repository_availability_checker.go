@@ -2,7 +2,12 @@ package outbound
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 )
@@ -10,6 +15,7 @@ import (
 // RepositoryAvailabilityChecker implements AvailabilityChecker by querying the reservation repository.
 type RepositoryAvailabilityChecker struct {
 	reservationRepo reservation.ReservationRepository
+	holdRepo        reservation.RoomHoldRepository
 }
 
 // NewRepositoryAvailabilityChecker creates a new availability checker.
@@ -19,6 +25,12 @@ func NewRepositoryAvailabilityChecker(repo reservation.ReservationRepository) *R
 	}
 }
 
+// WithCheckoutHolds counts the active checkout holds of the repository as if they were reservations.
+func (c *RepositoryAvailabilityChecker) WithCheckoutHolds(repo reservation.RoomHoldRepository) *RepositoryAvailabilityChecker {
+	c.holdRepo = repo
+	return c
+}
+
 // IsRoomAvailable checks if a room is available for the given date range.
 func (c *RepositoryAvailabilityChecker) IsRoomAvailable(
 	ctx context.Context,
@@ -31,7 +43,17 @@ func (c *RepositoryAvailabilityChecker) IsRoomAvailable(
 	}
 
 	// Room is available if there are no overlapping reservations
-	return len(overlapping) == 0, nil
+	if len(overlapping) > 0 || c.holdRepo == nil {
+		return len(overlapping) == 0, nil
+	}
+
+	// ... nor active checkout holds
+	holds, err := c.holdRepo.ReadAll(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to read room holds: %w", err)
+	}
+	now := time.Now()
+	return !slices.ContainsFunc(holds, func(h reservation.RoomHold) bool { return h.Blocks(roomID, dateRange, now) }), nil
 }
 
 // GetOverlappingReservations returns all reservations that overlap with the given date range.
@@ -64,3 +86,30 @@ func (c *RepositoryAvailabilityChecker) GetOverlappingReservations(
 
 	return overlapping, nil
 }
+
+// OccupancyVersion returns a version string for the occupancy of a room.
+// It changes whenever a reservation for the room is created, updated, or cancelled,
+// which makes it suitable as a weak ETag for availability responses.
+func (c *RepositoryAvailabilityChecker) OccupancyVersion(ctx context.Context, roomID reservation.RoomID) (string, error) {
+	allReservations, err := c.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reservations: %w", err)
+	}
+
+	// Collect a stable fingerprint line per reservation of the room.
+	var lines []string
+	for _, res := range allReservations {
+		if res.RoomID != roomID {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s|%s|%d|%d|%d",
+			res.ID, res.Status,
+			res.DateRange.CheckIn.Unix(), res.DateRange.CheckOut.Unix(),
+			res.UpdatedAt.UnixNano(),
+		))
+	}
+	slices.Sort(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])[:16], nil
+}
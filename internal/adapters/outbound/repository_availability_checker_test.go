@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -245,3 +246,75 @@ func Test_RepositoryAvailabilityChecker_IsRoomAvailable_Cancelled_Reservation_Sh
 	assert.That(t, "error must be nil", err == nil, true)
 	assert.That(t, "room must be available when existing reservation is cancelled", available, true)
 }
+
+func Test_RepositoryAvailabilityChecker_IsRoomAvailable_With_Checkout_Hold_Should_Return_False_Until_Expiry(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepo()
+	holds := resource.NewInMemoryAccess[reservation.ReservationID, reservation.RoomHold]()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo).WithCheckoutHolds(holds)
+	ctx := context.Background()
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 10))
+	hold := reservation.RoomHold{ReservationID: testResID001, RoomID: "room-101", DateRange: dateRange, Status: reservation.HoldActive, ExpiresAt: time.Now().Add(time.Minute)}
+	_ = holds.Create(ctx, hold.ReservationID, hold)
+
+	// Act
+	held, err := checker.IsRoomAvailable(ctx, "room-101", dateRange)
+	hold.ExpiresAt = time.Now().Add(-time.Minute)
+	_ = holds.Update(ctx, hold.ReservationID, hold)
+	expired, _ := checker.IsRoomAvailable(ctx, "room-101", dateRange)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "held room must not be available", held, false)
+	assert.That(t, "room must be available after the hold expired", expired, true)
+}
+
+func Test_RepositoryAvailabilityChecker_OccupancyVersion_Should_Be_Stable_Without_Changes(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepo()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo)
+	ctx := context.Background()
+	createTestReservationInRepo(repo, testResID001, "room-101", 7, 10)
+
+	// Act
+	first, err1 := checker.OccupancyVersion(ctx, "room-101")
+	second, err2 := checker.OccupancyVersion(ctx, "room-101")
+
+	// Assert
+	assert.That(t, "first error must be nil", err1 == nil, true)
+	assert.That(t, "second error must be nil", err2 == nil, true)
+	assert.That(t, "versions must match", first, second)
+}
+
+func Test_RepositoryAvailabilityChecker_OccupancyVersion_Should_Change_On_Cancellation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepo()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo)
+	ctx := context.Background()
+	createTestReservationInRepo(repo, testResID001, "room-101", 7, 10)
+	before, _ := checker.OccupancyVersion(ctx, "room-101")
+
+	// Act
+	res := repo.reservations[testResID001]
+	res.Status = reservation.StatusCancelled
+	repo.reservations[testResID001] = res
+	after, _ := checker.OccupancyVersion(ctx, "room-101")
+
+	// Assert
+	assert.That(t, "version must change", before != after, true)
+}
+
+func Test_RepositoryAvailabilityChecker_OccupancyVersion_Should_Ignore_Other_Rooms(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepo()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo)
+	ctx := context.Background()
+	before, _ := checker.OccupancyVersion(ctx, "room-101")
+
+	// Act
+	createTestReservationInRepo(repo, testResID001, "room-202", 7, 10)
+	after, _ := checker.OccupancyVersion(ctx, "room-101")
+
+	// Assert
+	assert.That(t, "version must not change", before, after)
+}
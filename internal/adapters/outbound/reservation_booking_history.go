@@ -0,0 +1,37 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationBookingHistory implements referral.BookingHistory on top of the reservation service.
+type ReservationBookingHistory struct {
+	reservationService *reservation.Service
+}
+
+// NewReservationBookingHistory creates a new booking history.
+func NewReservationBookingHistory(reservationService *reservation.Service) *ReservationBookingHistory {
+	return &ReservationBookingHistory{
+		reservationService: reservationService,
+	}
+}
+
+// BookingsOf returns the reservations of the guest account.
+// Cancelled reservations are skipped, so a guest whose first booking failed is still a new guest.
+func (h *ReservationBookingHistory) BookingsOf(ctx context.Context, guestID referral.GuestID) ([]referral.ReservationID, error) {
+	reservations, err := h.reservationService.ListReservationsByGuest(ctx, reservation.GuestID(guestID))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []referral.ReservationID
+	for _, r := range reservations {
+		if r.Status != reservation.StatusCancelled {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,40 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationBookingHistory Tests
+// ============================================================================
+
+func Test_ReservationBookingHistory_BookingsOf_Should_Skip_Cancelled(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	ctx := context.Background()
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	first := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	second := reservation.NewDateRange(time.Now().AddDate(0, 0, 20), time.Now().AddDate(0, 0, 22))
+	_, _ = svc.CreateReservation(ctx, "res-001", "guest-001", "room-101", first, shared.NewMoney(20000, "EUR"), guests)
+	_, _ = svc.CreateReservation(ctx, "res-002", "guest-001", "room-101", second, shared.NewMoney(20000, "EUR"), guests)
+	_ = svc.CancelReservation(ctx, "res-001", "changed plans")
+	history := outbound.NewReservationBookingHistory(svc)
+
+	// Act
+	ids, err := history.BookingsOf(ctx, "guest-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "only the active booking must count", ids, []referral.ReservationID{"res-002"})
+}
@@ -0,0 +1,54 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationGuestDirectory implements profile.GuestDirectory on top of the reservation service.
+// Payments reference reservations, so moving a reservation re-links its payments as well.
+type ReservationGuestDirectory struct {
+	reservationService *reservation.Service
+}
+
+// NewReservationGuestDirectory creates a new guest directory.
+func NewReservationGuestDirectory(reservationService *reservation.Service) *ReservationGuestDirectory {
+	return &ReservationGuestDirectory{
+		reservationService: reservationService,
+	}
+}
+
+// GuestRecords returns the contact data of every reservation.
+// The primary guest provides name and phone; the contact email falls back to the primary guest's email.
+func (d *ReservationGuestDirectory) GuestRecords(ctx context.Context) ([]profile.GuestRecord, error) {
+	reservations, err := d.reservationService.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]profile.GuestRecord, 0, len(reservations))
+	for _, r := range reservations {
+		record := profile.GuestRecord{
+			ReservationID: r.ID,
+			GuestID:       profile.GuestID(r.GuestID),
+			Email:         r.GuestEmail,
+			Completed:     r.Status == reservation.StatusCompleted,
+		}
+		if len(r.Guests) > 0 {
+			record.Name = r.Guests[0].Name
+			record.Phone = r.Guests[0].PhoneNumber
+			if record.Email == "" {
+				record.Email = r.Guests[0].Email
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ReassignReservations moves the reservations from one guest account to another.
+func (d *ReservationGuestDirectory) ReassignReservations(ctx context.Context, from, to profile.GuestID, ids []profile.ReservationID) ([]profile.ReservationID, error) {
+	return d.reservationService.ReassignGuest(ctx, reservation.GuestID(from), reservation.GuestID(to), ids)
+}
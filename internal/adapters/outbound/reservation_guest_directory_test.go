@@ -0,0 +1,64 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationGuestDirectory Tests
+// ============================================================================
+
+func createTestGuestDirectory(t *testing.T) (*outbound.ReservationGuestDirectory, *reservation.Service) {
+	t.Helper()
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+
+	ctx := context.Background()
+	dateRange := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "+49 171 1234567")}
+	if _, err := svc.CreateReservation(ctx, "res-001", "guest-dup", "room-101", dateRange, shared.NewMoney(20000, "EUR"), guests); err != nil {
+		t.Fatalf("failed to create reservation: %v", err)
+	}
+	return outbound.NewReservationGuestDirectory(svc), svc
+}
+
+func Test_ReservationGuestDirectory_GuestRecords_Should_Use_Primary_Guest(t *testing.T) {
+	// Arrange
+	directory, _ := createTestGuestDirectory(t)
+
+	// Act
+	records, err := directory.GuestRecords(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must return 1 record", len(records), 1)
+	assert.That(t, "guest must match", records[0].GuestID, profile.GuestID("guest-dup"))
+	assert.That(t, "name must be the primary guest", records[0].Name, "John Doe")
+	assert.That(t, "email must fall back to the primary guest", records[0].Email, "john@example.com")
+	assert.That(t, "phone must be the primary guest", records[0].Phone, "+49 171 1234567")
+}
+
+func Test_ReservationGuestDirectory_ReassignReservations_Should_Move_Reservation(t *testing.T) {
+	// Arrange
+	directory, svc := createTestGuestDirectory(t)
+	ctx := context.Background()
+
+	// Act
+	moved, err := directory.ReassignReservations(ctx, "guest-dup", "guest-main", nil)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must move 1 reservation", moved, []profile.ReservationID{"res-001"})
+	res, _ := svc.GetReservation(ctx, "res-001")
+	assert.That(t, "reservation must be owned by survivor", res.IsOwnedBy("guest-main"), true)
+}
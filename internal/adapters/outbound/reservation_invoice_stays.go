@@ -0,0 +1,72 @@
+package outbound
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ReservationInvoiceStays implements invoice.Stays on top of the reservations and their financial summary.
+type ReservationInvoiceStays struct {
+	reservationService *reservation.Service
+	financialService   *payment.FinancialService
+}
+
+// NewReservationInvoiceStays creates new invoice stays.
+func NewReservationInvoiceStays(reservationService *reservation.Service, financialService *payment.FinancialService) *ReservationInvoiceStays {
+	return &ReservationInvoiceStays{
+		reservationService: reservationService,
+		financialService:   financialService,
+	}
+}
+
+// Stay returns the stay of the reservation. The customer is the first guest and the company the stay is billed to;
+// the adjustments and the payments, net of refunds, are taken from the financial summary. Authorized amounts
+// are not paid yet, so they are left out.
+func (s *ReservationInvoiceStays) Stay(ctx context.Context, reservationID invoice.ReservationID) (*invoice.Stay, error) {
+	res, err := s.reservationService.GetReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.financialService.Summary(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	stay := &invoice.Stay{
+		ReservationID:      res.ID,
+		PropertyID:         res.PropertyID,
+		ConfirmationNumber: res.ConfirmationNumber,
+		Customer:           invoice.Customer{Email: res.GuestEmail},
+		CheckIn:            res.DateRange.CheckIn,
+		CheckOut:           res.DateRange.CheckOut,
+		Nights:             res.DateRange.Nights(),
+		Guests:             len(res.Guests),
+		RoomCharges:        summary.RoomCharges,
+	}
+	if len(res.Guests) > 0 {
+		stay.Customer.Name = res.Guests[0].Name
+	}
+	if b := res.BusinessCustomer; b != nil {
+		stay.Customer.Company = b.Company
+		stay.Customer.VATID = b.VATID
+	}
+	for _, l := range summary.Lines {
+		line := invoice.Line{Description: l.Description, Amount: l.Amount}
+		switch l.Kind {
+		case payment.LineAdjustment:
+			stay.Adjustments = append(stay.Adjustments, line)
+		case payment.LineCapture, payment.LineGiftCard:
+			stay.Payments = append(stay.Payments, line)
+		case payment.LineRefund:
+			line.Amount = shared.NewMoney(-l.Amount.Amount, l.Amount.Currency)
+			line.Description = cmp.Or(l.Description, "Refund")
+			stay.Payments = append(stay.Payments, line)
+		}
+	}
+	return stay, nil
+}
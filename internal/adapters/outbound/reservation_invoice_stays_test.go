@@ -0,0 +1,63 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationInvoiceStays Tests
+// ============================================================================
+
+func Test_ReservationInvoiceStays_Stay_Should_Return_Customer_Charges_And_Payments(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	dispatcher := messaging.NewInternalDispatcher()
+	reservations := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(dispatcher))
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("Jane Doe", "jane@example.com", ""), reservation.NewGuestInfo("John Doe", "", "")}
+	dates := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	business, _ := reservation.NewBusinessCustomer("Acme SARL", "FR12345678901")
+	_, _ = reservations.CreateReservationWithPerks(ctx, "res-001", "guest-001", "room-101", dates, shared.NewMoney(20000, "EUR"), guests, reservation.Perks{}, reservation.ArrivalDetails{BusinessCustomer: business})
+	payments := payment.NewService(resource.NewInMemoryAccess[payment.PaymentID, payment.Payment](), outbound.NewMockPaymentGateway(), outbound.NewEventPublisher(dispatcher))
+	_, _ = payments.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(20000, "EUR"), "credit_card")
+	_ = payments.CapturePayment(ctx, "pay-001")
+	financials := payment.NewFinancialService(payments, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), outbound.NewReservationRoomCharges(reservations))
+	_, _ = financials.AddAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(1500, "EUR"), "Minibar")
+	stays := outbound.NewReservationInvoiceStays(reservations, financials)
+
+	// Act
+	stay, err := stays.Stay(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "customer must be the first guest and the company", stay.Customer, invoice.Customer{Name: "Jane Doe", Email: "jane@example.com", Company: "Acme SARL", VATID: "FR12345678901"})
+	assert.That(t, "nights must be counted", stay.Nights, 2)
+	assert.That(t, "guests must be counted", stay.Guests, 2)
+	assert.That(t, "room charges must be the total amount", stay.RoomCharges, shared.NewMoney(20000, "EUR"))
+	assert.That(t, "adjustment must be charged", stay.Adjustments, []invoice.Line{{Description: "Minibar", Amount: shared.NewMoney(1500, "EUR")}})
+	assert.That(t, "captured payment must be received", len(stay.Payments), 1)
+}
+
+func Test_ReservationInvoiceStays_Stay_With_Unknown_Reservation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	reservations := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	stays := outbound.NewReservationInvoiceStays(reservations, nil)
+
+	// Act
+	_, err := stays.Stay(context.Background(), "missing")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,36 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationOccupancy implements inventory.Occupancy on top of the room calendar of the reservation service.
+type ReservationOccupancy struct {
+	reservationService *reservation.Service
+}
+
+// NewReservationOccupancy creates a new occupancy.
+func NewReservationOccupancy(reservationService *reservation.Service) *ReservationOccupancy {
+	return &ReservationOccupancy{
+		reservationService: reservationService,
+	}
+}
+
+// Availability returns the availability of the room for days nights from the date of from.
+// Nights are booked by every reservation that is not cancelled, like in the room calendar.
+func (o *ReservationOccupancy) Availability(ctx context.Context, roomID inventory.RoomID, from time.Time, days int) ([]inventory.Availability, error) {
+	calendar, err := o.reservationService.GetRoomCalendar(ctx, reservation.RoomID(roomID), from, days)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := make([]inventory.Availability, 0, len(calendar.Days))
+	for _, day := range calendar.Days {
+		availability = append(availability, inventory.Availability{Date: day.Date, Available: day.Available})
+	}
+	return availability, nil
+}
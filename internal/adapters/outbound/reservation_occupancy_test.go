@@ -0,0 +1,39 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationOccupancy Tests
+// ============================================================================
+
+func Test_ReservationOccupancy_Availability_Should_Mark_Booked_Nights(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	ctx := context.Background()
+	from := time.Now().AddDate(0, 0, 7)
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	_, _ = svc.CreateReservation(ctx, "res-001", "guest-001", "room-101", reservation.NewDateRange(from, from.AddDate(0, 0, 2)), shared.NewMoney(20000, "EUR"), guests)
+	occupancy := outbound.NewReservationOccupancy(svc)
+
+	// Act
+	availability, err := occupancy.Availability(ctx, "room-101", from, 3)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "all nights must be returned", len(availability), 3)
+	assert.That(t, "first night must be booked", availability[0].Available, false)
+	assert.That(t, "second night must be booked", availability[1].Available, false)
+	assert.That(t, "check-out night must be available", availability[2].Available, true)
+}
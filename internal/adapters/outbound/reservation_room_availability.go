@@ -0,0 +1,27 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// ReservationRoomAvailability implements waitlist.RoomAvailability on top of an availability checker of the reservation context.
+type ReservationRoomAvailability struct {
+	checker reservation.AvailabilityChecker
+}
+
+// NewReservationRoomAvailability creates a new room availability. The checker should read the repository directly,
+// since rooms are offered right after a cancellation and a coalesced query may predate it.
+func NewReservationRoomAvailability(checker reservation.AvailabilityChecker) *ReservationRoomAvailability {
+	return &ReservationRoomAvailability{
+		checker: checker,
+	}
+}
+
+// IsRoomAvailable reports whether no reservation occupies the room for the dates.
+func (a *ReservationRoomAvailability) IsRoomAvailable(ctx context.Context, roomID waitlist.RoomID, checkIn, checkOut time.Time) (bool, error) {
+	return a.checker.IsRoomAvailable(ctx, reservation.RoomID(roomID), reservation.NewDateRange(checkIn, checkOut))
+}
@@ -0,0 +1,39 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationRoomAvailability Tests
+// ============================================================================
+
+func Test_ReservationRoomAvailability_Should_Report_Booked_Nights(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo)
+	svc := reservation.NewService(repo, checker, outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	stay := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	_, _ = svc.CreateReservation(ctx, "res-001", "guest-001", "room-101", stay, shared.NewMoney(20000, "EUR"), guests)
+	availability := outbound.NewReservationRoomAvailability(checker)
+
+	// Act
+	booked, err := availability.IsRoomAvailable(ctx, "room-101", stay.CheckIn, stay.CheckOut)
+	free, _ := availability.IsRoomAvailable(ctx, "room-101", stay.CheckOut, stay.CheckOut.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "booked nights must not be available", booked, false)
+	assert.That(t, "later nights must be available", free, true)
+}
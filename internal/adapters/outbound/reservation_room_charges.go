@@ -0,0 +1,29 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationRoomCharges implements payment.ReservationCharges on top of the reservation service.
+type ReservationRoomCharges struct {
+	reservationService *reservation.Service
+}
+
+// NewReservationRoomCharges creates a new room charges adapter.
+func NewReservationRoomCharges(reservationService *reservation.Service) *ReservationRoomCharges {
+	return &ReservationRoomCharges{
+		reservationService: reservationService,
+	}
+}
+
+// RoomCharges returns the total amount of the reservation.
+func (c *ReservationRoomCharges) RoomCharges(ctx context.Context, reservationID payment.ReservationID) (payment.Money, error) {
+	res, err := c.reservationService.GetReservation(ctx, reservationID)
+	if err != nil {
+		return payment.Money{}, err
+	}
+	return res.TotalAmount, nil
+}
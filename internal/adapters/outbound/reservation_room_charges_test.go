@@ -0,0 +1,49 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationRoomCharges Tests
+// ============================================================================
+
+func Test_ReservationRoomCharges_RoomCharges_Should_Return_Total_Amount(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	ctx := context.Background()
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	dates := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	_, _ = svc.CreateReservation(ctx, "res-001", "guest-001", "room-101", dates, shared.NewMoney(20000, "EUR"), guests)
+	charges := outbound.NewReservationRoomCharges(svc)
+
+	// Act
+	total, err := charges.RoomCharges(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "room charges must be the total amount", total, shared.NewMoney(20000, "EUR"))
+}
+
+func Test_ReservationRoomCharges_RoomCharges_With_Unknown_Reservation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	charges := outbound.NewReservationRoomCharges(svc)
+
+	// Act
+	_, err := charges.RoomCharges(context.Background(), "missing")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
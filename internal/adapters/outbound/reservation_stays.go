@@ -0,0 +1,64 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationStays implements report.Stays on top of the reservations, the room types and the property catalog.
+type ReservationStays struct {
+	reservationService *reservation.Service
+	rates              *reservation.Rates
+	catalog            *property.Catalog
+}
+
+// NewReservationStays creates new reservation stays.
+func NewReservationStays(reservationService *reservation.Service, rates *reservation.Rates, catalog *property.Catalog) *ReservationStays {
+	return &ReservationStays{
+		reservationService: reservationService,
+		rates:              rates,
+		catalog:            catalog,
+	}
+}
+
+// Stays returns the stays in the rooms of the property overlapping the nights from from to to (exclusive).
+// Cancelled reservations are left out; amounts are converted with the exchange rate taken at booking,
+// so a report stays the same however the rates move later.
+func (s *ReservationStays) Stays(ctx context.Context, propertyID report.PropertyID, from, to time.Time) ([]report.Stay, error) {
+	reservations, err := s.reservationService.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stays []report.Stay
+	for _, res := range reservations {
+		if res.Status == reservation.StatusCancelled || s.catalog.PropertyOf(property.RoomID(res.RoomID)) != propertyID {
+			continue
+		}
+		if !res.DateRange.CheckIn.Before(to) || !res.DateRange.CheckOut.After(from) {
+			continue
+		}
+		revenue := res.TotalAmount
+		if res.FX != nil {
+			revenue = res.FX.Convert(revenue)
+		}
+		stays = append(stays, report.Stay{RoomID: string(res.RoomID), CheckIn: res.DateRange.CheckIn, CheckOut: res.DateRange.CheckOut, Revenue: revenue})
+	}
+	return stays, nil
+}
+
+// Rooms returns the number of rooms of the room types in the property.
+func (s *ReservationStays) Rooms(propertyID report.PropertyID) int {
+	rooms := 0
+	for _, t := range s.rates.RoomTypes() {
+		for _, room := range t.RoomIDs {
+			if s.catalog.PropertyOf(property.RoomID(room)) == propertyID {
+				rooms++
+			}
+		}
+	}
+	return rooms
+}
@@ -0,0 +1,49 @@
+package outbound_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ReservationStays Tests
+// ============================================================================
+
+func Test_ReservationStays_Should_Return_Stays_Of_Property_In_Period(t *testing.T) {
+	// Arrange
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	svc := reservation.NewService(repo, outbound.NewRepositoryAvailabilityChecker(repo), outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	catalog, _ := property.NewCatalog(
+		property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "UTC"},
+		property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "UTC", Rooms: []property.RoomID{"room-301"}},
+	)
+	ctx := context.Background()
+	from := time.Now().AddDate(0, 0, 7)
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	_, _ = svc.CreateReservation(ctx, "res-001", "guest-001", "room-101", reservation.NewDateRange(from, from.AddDate(0, 0, 2)), shared.NewMoney(20000, "USD"), guests)
+	_, _ = svc.CreateReservation(ctx, "res-002", "guest-001", "room-102", reservation.NewDateRange(from, from.AddDate(0, 0, 2)), shared.NewMoney(20000, "USD"), guests)
+	_, _ = svc.CreateReservation(ctx, "res-003", "guest-001", "room-301", reservation.NewDateRange(from, from.AddDate(0, 0, 2)), shared.NewMoney(50000, "USD"), guests)
+	_, _ = svc.CreateReservation(ctx, "res-004", "guest-001", "room-201", reservation.NewDateRange(from.AddDate(0, 0, 9), from.AddDate(0, 0, 10)), shared.NewMoney(14900, "USD"), guests)
+	_ = svc.CancelReservation(ctx, "res-002", "changed plans")
+	stays := outbound.NewReservationStays(svc, reservation.NewRates(reservation.DefaultRatePolicy()), catalog)
+
+	// Act
+	result, err := stays.Stays(ctx, "ber", from, from.AddDate(0, 0, 7))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the stay of the property in the period must be returned", len(result), 1)
+	assert.That(t, "stay must be in room-101", result[0].RoomID, "room-101")
+	assert.That(t, "revenue must be the total amount", result[0].Revenue, shared.NewMoney(20000, "USD"))
+	assert.That(t, "rooms of the property must be counted", stays.Rooms("ber"), 4)
+	assert.That(t, "rooms of the other property must be counted", stays.Rooms("muc"), 1)
+}
@@ -0,0 +1,29 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ReservationViewers implements payment.ReservationViewers on top of the reservation service.
+type ReservationViewers struct {
+	reservationService *reservation.Service
+}
+
+// NewReservationViewers creates a new reservation viewers adapter.
+func NewReservationViewers(reservationService *reservation.Service) *ReservationViewers {
+	return &ReservationViewers{
+		reservationService: reservationService,
+	}
+}
+
+// CanView reports whether the guest owns the reservation or holds a share grant of it.
+func (v *ReservationViewers) CanView(ctx context.Context, reservationID payment.ReservationID, guestID string) (bool, error) {
+	res, err := v.reservationService.GetReservation(ctx, reservationID)
+	if err != nil {
+		return false, err
+	}
+	return res.CanView(reservation.GuestID(guestID)), nil
+}
@@ -0,0 +1,255 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// S3Config configures a bucket of an S3-compatible object storage (AWS S3, MinIO, Ceph, R2).
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3BlobStorage implements BlobStorage with an S3-compatible bucket.
+// Requests use path-style URLs and are signed with AWS Signature Version 4,
+// which every S3-compatible storage accepts, so no SDK is needed.
+type S3BlobStorage struct {
+	client   *http.Client
+	endpoint *url.URL
+	config   S3Config
+	now      func() time.Time
+}
+
+// NewS3BlobStorage creates a new S3 blob storage.
+func NewS3BlobStorage(client *http.Client, config S3Config) (*S3BlobStorage, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid S3 endpoint: %q", config.Endpoint)
+	}
+	if config.Bucket == "" || config.Region == "" {
+		return nil, fmt.Errorf("S3 bucket and region must be set")
+	}
+	return &S3BlobStorage{client: client, endpoint: endpoint, config: config, now: time.Now}, nil
+}
+
+// Put uploads the blob with the content type of its extension.
+func (s *S3BlobStorage) Put(ctx context.Context, key string, data []byte) error {
+	if err := ValidateBlobKey(key); err != nil {
+		return err
+	}
+	header := http.Header{}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, header, data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return s3Error(resp, "put", key)
+}
+
+// Get downloads the blob.
+func (s *S3BlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ValidateBlobKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+	if err := s3Error(resp, "get", key); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Delete removes the blob; S3 does not report missing blobs.
+func (s *S3BlobStorage) Delete(ctx context.Context, key string) error {
+	if err := ValidateBlobKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return s3Error(resp, "delete", key)
+}
+
+// s3ListResult is the part of the ListObjectsV2 response we use.
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// List returns the blobs whose key starts with the prefix, following continuation tokens.
+func (s *S3BlobStorage) List(ctx context.Context, prefix string) ([]BlobInfo, error) {
+	var blobs []BlobInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListResult
+		err = s3Error(resp, "list", prefix)
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", err)
+		}
+		for _, c := range result.Contents {
+			blobs = append(blobs, BlobInfo{Key: c.Key, Size: c.Size, ModifiedAt: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return blobs, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do sends a signed request for the key (or the bucket if the key is empty).
+func (s *S3BlobStorage) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	canonicalURI := s3Escape(path.Join(s.endpoint.Path, "/", s.config.Bucket, key), true)
+	canonicalQuery := s3CanonicalQuery(query)
+	target := s.endpoint.Scheme + "://" + s.endpoint.Host + canonicalURI
+	if canonicalQuery != "" {
+		target += "?" + canonicalQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.sign(req, canonicalURI, canonicalQuery, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call S3: %w", err)
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+func (s *S3BlobStorage) sign(req *http.Request, canonicalURI, canonicalQuery string, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+	names := slices.Sorted(maps.Keys(headers))
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalURI, canonicalQuery, canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.config.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// s3Error returns an error for responses other than 2xx.
+func s3Error(resp *http.Response, op, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return fmt.Errorf("failed to %s blob %q: status %d", op, key, resp.StatusCode)
+}
+
+// s3CanonicalQuery encodes the query sorted by name as required by Signature Version 4.
+func s3CanonicalQuery(query url.Values) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[name] {
+			parts = append(parts, s3Escape(name, false)+"="+s3Escape(value, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters of RFC 3986.
+func s3Escape(s string, keepSlash bool) string {
+	var sb strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '.', b == '_', b == '~':
+			sb.WriteByte(b)
+		case b == '/' && keepSlash:
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of the data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,134 @@
+package outbound_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// fakeS3 is an in-memory bucket answering path-style S3 requests.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch {
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = string(body)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Query().Get("list-type") == "2":
+		var sb strings.Builder
+		sb.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+		for k, v := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				fmt.Fprintf(&sb, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>2025-01-01T00:00:00.000Z</LastModified></Contents>", k, len(v))
+			}
+		}
+		sb.WriteString("</ListBucketResult>")
+		_, _ = w.Write([]byte(sb.String()))
+	default:
+		v, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}
+}
+
+func createTestS3BlobStorage(t *testing.T) (*outbound.S3BlobStorage, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{objects: make(map[string]string)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	storage, err := outbound.NewS3BlobStorage(srv.Client(), outbound.S3Config{
+		Endpoint:        srv.URL,
+		Region:          "eu-central-1",
+		Bucket:          "bucket",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	assert.That(t, "error must be nil", err, nil)
+	return storage, fake
+}
+
+// ============================================================================
+// S3BlobStorage Tests
+// ============================================================================
+
+func Test_S3BlobStorage_Put_And_Get_Should_Round_Trip_Signed_Requests(t *testing.T) {
+	// Arrange
+	storage, fake := createTestS3BlobStorage(t)
+	ctx := context.Background()
+
+	// Act
+	err := storage.Put(ctx, "exports/guests list.csv", []byte("a,b"))
+	data, getErr := storage.Get(ctx, "exports/guests list.csv")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "get error must be nil", getErr, nil)
+	assert.That(t, "data must match", string(data), "a,b")
+	assert.That(t, "requests must be signed", strings.HasPrefix(fake.auth[0], "AWS4-HMAC-SHA256 Credential=AKID/"), true)
+	assert.That(t, "scope must contain the region", strings.Contains(fake.auth[0], "/eu-central-1/s3/aws4_request"), true)
+}
+
+func Test_S3BlobStorage_Get_Missing_Blob_Should_Return_Not_Found(t *testing.T) {
+	// Arrange
+	storage, _ := createTestS3BlobStorage(t)
+
+	// Act
+	_, err := storage.Get(context.Background(), "missing.pdf")
+
+	// Assert
+	assert.That(t, "error must be not found", err, outbound.ErrBlobNotFound)
+}
+
+func Test_S3BlobStorage_List_Should_Parse_Objects(t *testing.T) {
+	// Arrange
+	storage, _ := createTestS3BlobStorage(t)
+	ctx := context.Background()
+	_ = storage.Put(ctx, "profiles/cpu.pprof", []byte("cpu"))
+	_ = storage.Put(ctx, "exports/guests.csv", []byte("csv"))
+
+	// Act
+	blobs, err := storage.List(ctx, "profiles/")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one blob must be listed", len(blobs), 1)
+	assert.That(t, "key must match", blobs[0].Key, "profiles/cpu.pprof")
+	assert.That(t, "modification time must be parsed", blobs[0].ModifiedAt.Year(), 2025)
+}
+
+func Test_NewS3BlobStorage_Without_Bucket_Should_Return_Error(t *testing.T) {
+	// Arrange
+	config := outbound.S3Config{Endpoint: "http://minio:9000", Region: "us-east-1"}
+
+	// Act
+	_, err := outbound.NewS3BlobStorage(http.DefaultClient, config)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
@@ -0,0 +1,132 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// SendGridEmailProvider implements EmailProvider with the SendGrid v3 mail send API.
+type SendGridEmailProvider struct {
+	client *http.Client
+	apiURL string
+	apiKey string
+	from   *mail.Address
+}
+
+// NewSendGridEmailProvider creates a new SendGrid email provider, e.g. with the "email" client of HTTPClients.
+// The apiURL is https://api.sendgrid.com unless a regional or test endpoint is used.
+func NewSendGridEmailProvider(client *http.Client, apiURL, apiKey, from string) (*SendGridEmailProvider, error) {
+	if apiKey == "" {
+		return nil, errors.New("sendgrid api key required")
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sendgrid sender %q: %w", from, err)
+	}
+	return &SendGridEmailProvider{
+		client: client,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+		from:   sender,
+	}, nil
+}
+
+// Name returns "sendgrid".
+func (p *SendGridEmailProvider) Name() string { return "sendgrid" }
+
+// sendGridAddress is an address in the SendGrid API.
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// sendGridContent is a body of the email in the SendGrid API.
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridAttachment is an attached file in the SendGrid API; the content is base64-encoded.
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+// sendGridPersonalization lists the recipients of the email in the SendGrid API.
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+// sendGridMail is the request body of POST /v3/mail/send.
+type sendGridMail struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	CustomArgs       map[string]string         `json:"custom_args,omitempty"`
+}
+
+// Send sends the email. 429 Too Many Requests wraps ErrEmailThrottled.
+// The email ID is sent as custom argument, so SendGrid events can be matched to the queue.
+func (p *SendGridEmailProvider) Send(ctx context.Context, email Email) error {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", email.To, err)
+	}
+	body := sendGridMail{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: to.Address, Name: to.Name}}}},
+		From:             sendGridAddress{Email: p.from.Address, Name: p.from.Name},
+		Subject:          email.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: email.Body}},
+	}
+	if email.HTMLBody != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/html", Value: email.HTMLBody})
+	}
+	for _, attachment := range email.Attachments {
+		body.Attachments = append(body.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Content),
+			Type:        attachment.ContentType,
+			Filename:    attachment.Filename,
+			Disposition: "attachment",
+		})
+	}
+	if email.ID != "" {
+		body.CustomArgs = map[string]string{"email_id": email.ID}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode sendgrid request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+"/v3/mail/send", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create sendgrid request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email via sendgrid: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4*1024))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("%w: sendgrid returned %d", ErrEmailThrottled, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("sendgrid returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
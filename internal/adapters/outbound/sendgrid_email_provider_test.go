@@ -0,0 +1,110 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// SendGridEmailProvider Tests
+// ============================================================================
+
+func Test_NewSendGridEmailProvider_Without_API_Key_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewSendGridEmailProvider(http.DefaultClient, "https://api.sendgrid.com", "", "booking@example.com")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_SendGridEmailProvider_Send_Should_Post_Text_And_HTML(t *testing.T) {
+	// Arrange
+	var path, authorization string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	provider, _ := outbound.NewSendGridEmailProvider(srv.Client(), srv.URL+"/", "sg-key", "Hotel Booking <booking@example.com>")
+	email := outbound.Email{ID: "email-1", To: "John Doe <john@example.com>", Subject: "Confirmed", Body: "Dear John", HTMLBody: "<p>Dear John</p>"}
+
+	// Act
+	err := provider.Send(context.Background(), email)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "path must be the mail send API", path, "/v3/mail/send")
+	assert.That(t, "api key must be sent as bearer token", authorization, "Bearer sg-key")
+	to := body["personalizations"].([]any)[0].(map[string]any)["to"].([]any)[0].(map[string]any)
+	assert.That(t, "recipient must be set", to["email"], "john@example.com")
+	assert.That(t, "sender name must be set", body["from"].(map[string]any)["name"], "Hotel Booking")
+	content := body["content"].([]any)
+	assert.That(t, "content must have text and html", len(content), 2)
+	assert.That(t, "html must be second", content[1].(map[string]any)["type"], "text/html")
+	assert.That(t, "email id must be a custom arg", body["custom_args"].(map[string]any)["email_id"], "email-1")
+}
+
+func Test_SendGridEmailProvider_Send_Should_Post_Attachments_Base64_Encoded(t *testing.T) {
+	// Arrange
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	provider, _ := outbound.NewSendGridEmailProvider(srv.Client(), srv.URL, "sg-key", "booking@example.com")
+	email := outbound.Email{To: "john@example.com", Subject: "Confirmed", Body: "Dear John",
+		Attachments: []outbound.EmailAttachment{{Filename: "reservation-res-001.ics", ContentType: "text/calendar", Content: []byte("BEGIN:VCALENDAR")}},
+	}
+
+	// Act
+	err := provider.Send(context.Background(), email)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	attachment := body["attachments"].([]any)[0].(map[string]any)
+	assert.That(t, "content must be base64 encoded", attachment["content"], "QkVHSU46VkNBTEVOREFS")
+	assert.That(t, "filename must be set", attachment["filename"], "reservation-res-001.ics")
+	assert.That(t, "disposition must be attachment", attachment["disposition"], "attachment")
+}
+
+func Test_SendGridEmailProvider_Send_With_429_Should_Return_Throttled(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	provider, _ := outbound.NewSendGridEmailProvider(srv.Client(), srv.URL, "sg-key", "booking@example.com")
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "john@example.com", Subject: "Hi", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must be throttled", errors.Is(err, outbound.ErrEmailThrottled), true)
+}
+
+func Test_SendGridEmailProvider_Send_With_Bad_Request_Should_Fail(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"message":"invalid from"}]}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	provider, _ := outbound.NewSendGridEmailProvider(srv.Client(), srv.URL, "sg-key", "booking@example.com")
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "john@example.com", Subject: "Hi", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "err must not be throttled", errors.Is(err, outbound.ErrEmailThrottled), false)
+}
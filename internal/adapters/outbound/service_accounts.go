@@ -0,0 +1,68 @@
+package outbound
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync/atomic"
+)
+
+// ServiceAccounts maps OAuth client IDs of machine clients (client-credentials flow)
+// to their scopes and counts their requests separately from human users.
+type ServiceAccounts struct {
+	scopes map[string][]string
+	usage  map[string]*atomic.Int64
+}
+
+// NewServiceAccounts creates a new service account registry from client ID to scopes.
+func NewServiceAccounts(scopes map[string][]string) *ServiceAccounts {
+	usage := make(map[string]*atomic.Int64, len(scopes))
+	for clientID := range scopes {
+		usage[clientID] = &atomic.Int64{}
+	}
+	return &ServiceAccounts{
+		scopes: maps.Clone(scopes),
+		usage:  usage,
+	}
+}
+
+// ParseServiceAccounts parses "clientID=scope scope;clientID=scope" entries,
+// e.g. "channel-manager=reservations:read reservations:write;reporting=payments:read".
+func ParseServiceAccounts(s string) (*ServiceAccounts, error) {
+	scopes := make(map[string][]string)
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		clientID, list, ok := strings.Cut(entry, "=")
+		clientID = strings.TrimSpace(clientID)
+		if !ok || clientID == "" {
+			return nil, fmt.Errorf("invalid service account %q: expected clientID=scope scope", entry)
+		}
+		scopes[clientID] = strings.Fields(list)
+	}
+	return NewServiceAccounts(scopes), nil
+}
+
+// Scopes returns the scopes of a registered service account.
+func (a *ServiceAccounts) Scopes(clientID string) ([]string, bool) {
+	scopes, ok := a.scopes[clientID]
+	return scopes, ok
+}
+
+// RecordUsage counts a request of a registered service account.
+func (a *ServiceAccounts) RecordUsage(clientID string) {
+	if counter, ok := a.usage[clientID]; ok {
+		counter.Add(1)
+	}
+}
+
+// Usage returns the request count of every registered service account.
+func (a *ServiceAccounts) Usage() map[string]int64 {
+	usage := make(map[string]int64, len(a.usage))
+	for clientID, counter := range a.usage {
+		usage[clientID] = counter.Load()
+	}
+	return usage
+}
@@ -0,0 +1,49 @@
+package outbound_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// ServiceAccounts Tests
+// ============================================================================
+
+func Test_ParseServiceAccounts_Should_Map_Client_IDs_To_Scopes(t *testing.T) {
+	// Arrange
+	s := "channel-manager=reservations:read reservations:write; reporting=payments:read"
+
+	// Act
+	accounts, err := outbound.ParseServiceAccounts(s)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	scopes, ok := accounts.Scopes("channel-manager")
+	assert.That(t, "account must be registered", ok, true)
+	assert.That(t, "scopes must match", scopes, []string{"reservations:read", "reservations:write"})
+}
+
+func Test_ParseServiceAccounts_With_Invalid_Entry_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.ParseServiceAccounts("reservations:read")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_ServiceAccounts_RecordUsage_Should_Count_Registered_Accounts_Only(t *testing.T) {
+	// Arrange
+	accounts := outbound.NewServiceAccounts(map[string][]string{"reporting": {"payments:read"}})
+
+	// Act
+	accounts.RecordUsage("reporting")
+	accounts.RecordUsage("reporting")
+	accounts.RecordUsage("unknown")
+
+	// Assert
+	usage := accounts.Usage()
+	assert.That(t, "reporting must be counted", usage["reporting"], int64(2))
+	assert.That(t, "unknown must not be listed", len(usage), 1)
+}
@@ -0,0 +1,85 @@
+package outbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrInvalidShareLink is returned if a share link token is malformed, tampered with or expired.
+var ErrInvalidShareLink = errors.New("invalid or expired share link")
+
+// ShareLinks signs and verifies the tokens of reservation share invitations.
+// A token carries the reservation ID, the invited email and an expiry,
+// and is signed with HMAC-SHA256, so no server-side state is needed.
+type ShareLinks struct {
+	secret  []byte
+	purpose string
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewShareLinks creates a new share link signer.
+func NewShareLinks(secret string, ttl time.Duration) *ShareLinks {
+	return &ShareLinks{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// WithPurpose binds the tokens to the purpose, e.g. "manage" for the management links of the booking lookup.
+// Tokens of signers with another purpose are invalid, even if they share the secret.
+func (s *ShareLinks) WithPurpose(purpose string) *ShareLinks {
+	s.purpose = purpose
+	return s
+}
+
+// Sign returns a URL-safe token for the invitation of the email to the reservation.
+func (s *ShareLinks) Sign(id shared.ReservationID, email string) string {
+	expires := strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+	payload := strings.Join([]string{string(id), email, expires}, "\n")
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify returns the reservation ID and email of a valid, unexpired token.
+func (s *ShareLinks) Verify(token string) (shared.ReservationID, string, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidShareLink
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", "", ErrInvalidShareLink
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, s.mac(string(payload))) {
+		return "", "", ErrInvalidShareLink
+	}
+
+	fields := strings.Split(string(payload), "\n")
+	if len(fields) != 3 {
+		return "", "", ErrInvalidShareLink
+	}
+	expires, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || s.now().Unix() > expires {
+		return "", "", ErrInvalidShareLink
+	}
+	return shared.ReservationID(fields[0]), fields[1], nil
+}
+
+// mac returns the HMAC-SHA256 of the payload, prefixed with the purpose if there is one.
+func (s *ShareLinks) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	if s.purpose != "" {
+		h.Write([]byte(s.purpose + "\n"))
+	}
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
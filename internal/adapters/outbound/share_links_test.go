@@ -0,0 +1,76 @@
+package outbound_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// ShareLinks Tests
+// ============================================================================
+
+func Test_ShareLinks_Verify_With_Signed_Token_Should_Return_Reservation_And_Email(t *testing.T) {
+	// Arrange
+	links := outbound.NewShareLinks("secret", time.Hour)
+	token := links.Sign("res-001", "friend@example.com")
+
+	// Act
+	id, email, err := links.Verify(token)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "reservation ID must match", id, shared.ReservationID("res-001"))
+	assert.That(t, "email must match", email, "friend@example.com")
+}
+
+func Test_ShareLinks_Verify_With_Other_Secret_Should_Return_ErrInvalidShareLink(t *testing.T) {
+	// Arrange
+	token := outbound.NewShareLinks("secret", time.Hour).Sign("res-001", "friend@example.com")
+	links := outbound.NewShareLinks("other-secret", time.Hour)
+
+	// Act
+	_, _, err := links.Verify(token)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidShareLink", errors.Is(err, outbound.ErrInvalidShareLink), true)
+}
+
+func Test_ShareLinks_Verify_With_Other_Purpose_Should_Return_ErrInvalidShareLink(t *testing.T) {
+	// Arrange
+	token := outbound.NewShareLinks("secret", time.Hour).Sign("res-001", "friend@example.com")
+	links := outbound.NewShareLinks("secret", time.Hour).WithPurpose("manage")
+
+	// Act
+	_, _, err := links.Verify(token)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidShareLink", errors.Is(err, outbound.ErrInvalidShareLink), true)
+}
+
+func Test_ShareLinks_Verify_With_Expired_Token_Should_Return_ErrInvalidShareLink(t *testing.T) {
+	// Arrange
+	links := outbound.NewShareLinks("secret", -time.Minute)
+	token := links.Sign("res-001", "friend@example.com")
+
+	// Act
+	_, _, err := links.Verify(token)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidShareLink", errors.Is(err, outbound.ErrInvalidShareLink), true)
+}
+
+func Test_ShareLinks_Verify_With_Malformed_Token_Should_Return_ErrInvalidShareLink(t *testing.T) {
+	// Arrange
+	links := outbound.NewShareLinks("secret", time.Hour)
+
+	// Act
+	_, _, err := links.Verify("not-a-token")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidShareLink", errors.Is(err, outbound.ErrInvalidShareLink), true)
+}
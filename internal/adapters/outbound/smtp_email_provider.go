@@ -0,0 +1,252 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout bounds an SMTP session if the context has no deadline.
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig configures the SMTP email provider.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // empty sends without authentication, e.g. to a local relay
+	Password string
+	From     string // sender, e.g. "Hotel Booking <booking@example.com>"
+}
+
+// SMTPEmailProvider implements EmailProvider by sending the emails to an SMTP server.
+// The connection is upgraded with STARTTLS if the server offers it; credentials are only
+// sent over TLS or to localhost (smtp.PlainAuth). Emails with an HTML body are sent as multipart/alternative,
+// emails with attachments as multipart/mixed.
+type SMTPEmailProvider struct {
+	config SMTPConfig
+	from   *mail.Address
+	now    func() time.Time
+}
+
+// NewSMTPEmailProvider creates a new SMTP email provider.
+func NewSMTPEmailProvider(config SMTPConfig) (*SMTPEmailProvider, error) {
+	if config.Host == "" {
+		return nil, errors.New("smtp host required")
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp sender %q: %w", config.From, err)
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &SMTPEmailProvider{config: config, from: from, now: time.Now}, nil
+}
+
+// Name returns "smtp".
+func (p *SMTPEmailProvider) Name() string { return "smtp" }
+
+// Send sends the email. A 421 reply (server busy, too many connections) wraps ErrEmailThrottled,
+// so the queue pauses instead of using up the attempts of every queued email. Other temporary
+// rejections, e.g. greylisting, concern only this email and are retried like any failure.
+func (p *SMTPEmailProvider) Send(ctx context.Context, email Email) error {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", email.To, err)
+	}
+	msg, err := p.message(to, email)
+	if err != nil {
+		return err
+	}
+
+	if err := p.send(ctx, to.Address, msg); err != nil {
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code == 421 {
+			return fmt.Errorf("%w: %w", ErrEmailThrottled, err)
+		}
+		return fmt.Errorf("failed to send email via smtp: %w", err)
+	}
+	return nil
+}
+
+// send delivers the message like smtp.SendMail, but dials with the context
+// and ends the session at its deadline, so a stuck server cannot block the queue.
+func (p *SMTPEmailProvider) send(ctx context.Context, to string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port)))
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: p.config.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if p.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(p.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message returns the email in RFC 5322 format with quoted-printable bodies.
+// Emails with attachments are sent as multipart/mixed with the body as first part.
+func (p *SMTPEmailProvider) message(to *mail.Address, email Email) ([]byte, error) {
+	header := textproto.MIMEHeader{}
+	header.Set("From", p.from.String())
+	header.Set("To", to.String())
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	header.Set("Date", p.now().Format(time.RFC1123Z))
+	header.Set("MIME-Version", "1.0")
+	if email.ID != "" {
+		header.Set("Message-ID", "<"+email.ID+"@"+p.config.Host+">")
+	}
+
+	textHeader, text, err := textBody(email)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	if len(email.Attachments) == 0 {
+		for key, values := range textHeader {
+			header[key] = values
+		}
+		body.Write(text)
+	} else {
+		parts := multipart.NewWriter(&body)
+		header.Set("Content-Type", "multipart/mixed; boundary="+parts.Boundary())
+		w, err := parts.CreatePart(textHeader)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(text); err != nil {
+			return nil, err
+		}
+		for _, attachment := range email.Attachments {
+			w, err := parts.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {attachment.ContentType},
+				"Content-Transfer-Encoding": {"base64"},
+				"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := writeBase64Lines(w, attachment.Content); err != nil {
+				return nil, err
+			}
+		}
+		if err := parts.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg bytes.Buffer
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if value := header.Get(key); value != "" {
+			msg.WriteString(key + ": " + value + "\r\n")
+		}
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// textBody returns the headers and content of the text of the email: plain text, or
+// multipart/alternative with the HTML body.
+func textBody(email Email) (textproto.MIMEHeader, []byte, error) {
+	var body bytes.Buffer
+	if email.HTMLBody == "" {
+		if err := writeQuotedPrintable(&body, email.Body); err != nil {
+			return nil, nil, err
+		}
+		return textproto.MIMEHeader{
+			"Content-Type":              {"text/plain; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		}, body.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, text string }{
+		{"text/plain; charset=UTF-8", email.Body},
+		{"text/html; charset=UTF-8", email.HTMLBody},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := writeQuotedPrintable(w, part.text); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, nil, err
+	}
+	return textproto.MIMEHeader{"Content-Type": {"multipart/alternative; boundary=" + parts.Boundary()}}, body.Bytes(), nil
+}
+
+// writeBase64Lines writes the data base64 encoded in lines of 76 characters (RFC 2045).
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
+
+// writeQuotedPrintable writes the text quoted-printable encoded.
+func writeQuotedPrintable(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(text)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
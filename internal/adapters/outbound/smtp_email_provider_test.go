@@ -0,0 +1,180 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// startTestSMTPServer accepts one session and sends the received message to the channel.
+// If rcptReply is set, RCPT TO is answered with it instead of 250.
+func startTestSMTPServer(t *testing.T, rcptReply string) (host string, port int, messages <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP test")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "RCPT":
+				if rcptReply != "" {
+					_ = tp.PrintfLine("%s", rcptReply)
+					continue
+				}
+				_ = tp.PrintfLine("250 OK")
+			case "DATA":
+				_ = tp.PrintfLine("354 Go ahead")
+				data, _ := tp.ReadDotBytes()
+				received <- string(data)
+				_ = tp.PrintfLine("250 OK")
+			case "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				return
+			default:
+				_ = tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, received
+}
+
+// ============================================================================
+// SMTPEmailProvider Tests
+// ============================================================================
+
+func Test_NewSMTPEmailProvider_Without_Host_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{From: "booking@example.com"})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_NewSMTPEmailProvider_With_Invalid_Sender_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: "localhost", From: "not an address"})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_SMTPEmailProvider_Send_With_HTML_Should_Send_Multipart_Alternative(t *testing.T) {
+	// Arrange
+	host, port, messages := startTestSMTPServer(t, "")
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: host, Port: port, From: "Hotel Booking <booking@example.com>"})
+	email := outbound.Email{ID: "email-1", To: "john@example.com", Subject: "Your reservation is confirmed", Body: "Dear John", HTMLBody: "<p>Dear John</p>"}
+
+	// Act
+	err := provider.Send(context.Background(), email)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	msg := <-messages
+	assert.That(t, "sender must be set", strings.Contains(msg, "From: \"Hotel Booking\" <booking@example.com>"), true)
+	assert.That(t, "subject must be set", strings.Contains(msg, "Subject: Your reservation is confirmed"), true)
+	assert.That(t, "message id must use the email id", strings.Contains(msg, "Message-ID: <email-1@"+host+">"), true)
+	assert.That(t, "message must be multipart/alternative", strings.Contains(msg, "Content-Type: multipart/alternative; boundary="), true)
+	assert.That(t, "message must have the text part", strings.Contains(msg, "Dear John"), true)
+	assert.That(t, "message must have the html part", strings.Contains(msg, "<p>Dear John</p>"), true)
+}
+
+func Test_SMTPEmailProvider_Send_With_Attachment_Should_Send_Multipart_Mixed(t *testing.T) {
+	// Arrange
+	host, port, messages := startTestSMTPServer(t, "")
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: host, Port: port, From: "booking@example.com"})
+	email := outbound.Email{To: "john@example.com", Subject: "Your reservation is confirmed", Body: "Dear John", HTMLBody: "<p>Dear John</p>",
+		Attachments: []outbound.EmailAttachment{{Filename: "reservation-res-001.ics", ContentType: "text/calendar; charset=utf-8; method=PUBLISH", Content: []byte("BEGIN:VCALENDAR\r\n")}},
+	}
+
+	// Act
+	err := provider.Send(context.Background(), email)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	msg := <-messages
+	assert.That(t, "message must be multipart/mixed", strings.Contains(msg, "Content-Type: multipart/mixed; boundary="), true)
+	assert.That(t, "text must stay multipart/alternative", strings.Contains(msg, "Content-Type: multipart/alternative; boundary="), true)
+	assert.That(t, "attachment must be named", strings.Contains(msg, `Content-Disposition: attachment; filename=reservation-res-001.ics`), true)
+	assert.That(t, "attachment must be base64 encoded", strings.Contains(msg, "QkVHSU46VkNBTEVOREFSDQo="), true)
+}
+
+func Test_SMTPEmailProvider_Send_Without_HTML_Should_Send_Plain_Text(t *testing.T) {
+	// Arrange
+	host, port, messages := startTestSMTPServer(t, "")
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: host, Port: port, From: "booking@example.com"})
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "john@example.com", Subject: "Grüße\r\nBcc: evil@example.com", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	msg := <-messages
+	assert.That(t, "message must be plain text", strings.Contains(msg, "Content-Type: text/plain; charset=UTF-8"), true)
+	assert.That(t, "subject must not inject headers", strings.Contains(msg, "\r\nBcc:"), false)
+}
+
+func Test_SMTPEmailProvider_Send_With_421_Should_Return_Throttled(t *testing.T) {
+	// Arrange
+	host, port, _ := startTestSMTPServer(t, "421 Too many connections")
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: host, Port: port, From: "booking@example.com"})
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "john@example.com", Subject: "Hi", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must be throttled", errors.Is(err, outbound.ErrEmailThrottled), true)
+}
+
+func Test_SMTPEmailProvider_Send_With_Rejected_Recipient_Should_Fail(t *testing.T) {
+	// Arrange
+	host, port, _ := startTestSMTPServer(t, "550 No such user")
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: host, Port: port, From: "booking@example.com"})
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "nobody@example.com", Subject: "Hi", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "err must not be throttled", errors.Is(err, outbound.ErrEmailThrottled), false)
+	assert.That(t, "err must contain the reply", strings.Contains(err.Error(), "550"), true)
+}
+
+func Test_SMTPEmailProvider_Send_With_Invalid_Recipient_Should_Fail(t *testing.T) {
+	// Arrange
+	provider, _ := outbound.NewSMTPEmailProvider(outbound.SMTPConfig{Host: "localhost", From: "booking@example.com"})
+
+	// Act
+	err := provider.Send(context.Background(), outbound.Email{To: "not an address", Subject: "Hi", Body: "Hello"})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,31 @@
+package outbound
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"modernc.org/sqlite"
+)
+
+// OpenSQLite opens the SQLite database file, creating it and its directory if they do not exist.
+// SQLite allows one writer at a time, so the pool has a single connection; queries wait for it
+// instead of failing with SQLITE_BUSY, and the write-ahead log lets readers of other processes proceed.
+func OpenSQLite(file string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory of %s: %w", file, err)
+	}
+	db, err := sql.Open("sqlite", "file:"+file+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// IsSQLite reports whether the database was opened with the SQLite driver.
+func IsSQLite(db *sql.DB) bool {
+	_, ok := db.Driver().(*sqlite.Driver)
+	return ok
+}
@@ -0,0 +1,113 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// SQLiteTableAccess is the counterpart of PostgresTableAccess in a SQLite database file,
+// for small deployments and demos on a single instance (STORAGE_BACKEND=sqlite).
+// The tables have the same key/value layout, so data can be copied between both.
+type SQLiteTableAccess[K comparable, V any] struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLiteTableAccess creates a new key/value access on the table.
+func NewSQLiteTableAccess[K comparable, V any](db *sql.DB, table string) (*SQLiteTableAccess[K, V], error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	return &SQLiteTableAccess[K, V]{db: db, table: table}, nil
+}
+
+// NewSQLiteReservationRepository creates the reservation repository on kv_store, like resource.PostgresAccess.
+// The table is created by the migrations (Migrator).
+func NewSQLiteReservationRepository(db *sql.DB) *SQLiteTableAccess[reservation.ReservationID, reservation.Reservation] {
+	return &SQLiteTableAccess[reservation.ReservationID, reservation.Reservation]{db: db, table: "kv_store"}
+}
+
+// NewSQLitePaymentRepository creates the payment repository on kv_store, like resource.PostgresAccess.
+// The table is created by the migrations (Migrator).
+func NewSQLitePaymentRepository(db *sql.DB) *SQLiteTableAccess[payment.PaymentID, payment.Payment] {
+	return &SQLiteTableAccess[payment.PaymentID, payment.Payment]{db: db, table: "kv_store"}
+}
+
+// Init creates the table if it does not exist yet. Existing data is kept.
+func (a *SQLiteTableAccess[K, V]) Init(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+a.table+" (key TEXT PRIMARY KEY, value TEXT)")
+	return err
+}
+
+// Create inserts a new key/value pair.
+func (a *SQLiteTableAccess[K, V]) Create(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.ExecContext(ctx, "INSERT INTO "+a.table+" (key, value) VALUES (?, ?)", key, string(encoded))
+	return err
+}
+
+// Read returns the value of the key.
+func (a *SQLiteTableAccess[K, V]) Read(ctx context.Context, key K) (*V, error) {
+	var encoded string
+	err := a.db.QueryRowContext(ctx, "SELECT value FROM "+a.table+" WHERE key = ?", key).Scan(&encoded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New(resource.ErrorResourceNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var value V
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// ReadAll returns all values of the table.
+func (a *SQLiteTableAccess[K, V]) ReadAll(ctx context.Context) ([]V, error) {
+	rows, err := a.db.QueryContext(ctx, "SELECT value FROM "+a.table)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var values []V
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, err
+		}
+		var value V
+		if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// Update replaces the value of the key.
+func (a *SQLiteTableAccess[K, V]) Update(ctx context.Context, key K, value V) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = a.db.ExecContext(ctx, "UPDATE "+a.table+" SET value = ? WHERE key = ?", string(encoded), key)
+	return err
+}
+
+// Delete removes the key.
+func (a *SQLiteTableAccess[K, V]) Delete(ctx context.Context, key K) error {
+	_, err := a.db.ExecContext(ctx, "DELETE FROM "+a.table+" WHERE key = ?", key)
+	return err
+}
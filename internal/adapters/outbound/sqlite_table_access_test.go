@@ -0,0 +1,80 @@
+package outbound_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// Both SQLite repositories must satisfy the ports of their contexts.
+var (
+	_ reservation.ReservationRepository = outbound.NewSQLiteReservationRepository(nil)
+	_ payment.PaymentRepository         = outbound.NewSQLitePaymentRepository(nil)
+)
+
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := outbound.OpenSQLite(filepath.Join(t.TempDir(), "data", "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// ============================================================================
+// SQLiteTableAccess Tests
+// ============================================================================
+
+func Test_SQLiteTableAccess_Should_Create_Read_Update_And_Delete(t *testing.T) {
+	// Arrange
+	db := openTestSQLite(t)
+	ctx := context.Background()
+	access, _ := outbound.NewTableAccess[string, []string](db, "household_kv_store")
+	_ = access.Init(ctx)
+
+	// Act
+	createErr := access.Create(ctx, "k", []string{"a"})
+	duplicateErr := access.Create(ctx, "k", []string{"b"})
+	_ = access.Update(ctx, "k", []string{"c"})
+	_ = access.Update(ctx, "missing", []string{"d"})
+	updated, readErr := access.Read(ctx, "k")
+	all, _ := access.ReadAll(ctx)
+	_ = access.Delete(ctx, "k")
+	_, missingErr := access.Read(ctx, "k")
+
+	// Assert
+	assert.That(t, "create must succeed", createErr, nil)
+	assert.That(t, "creating an existing key must fail", duplicateErr != nil, true)
+	assert.That(t, "read must succeed", readErr, nil)
+	assert.That(t, "value must be updated", (*updated)[0], "c")
+	assert.That(t, "updating a missing key must not create it", len(all), 1)
+	assert.That(t, "deleted key must not be found", missingErr != nil, true)
+}
+
+func Test_NewTableAccess_With_SQLite_Should_Return_SQLiteTableAccess(t *testing.T) {
+	// Arrange
+	db := openTestSQLite(t)
+
+	// Act
+	access, err := outbound.NewTableAccess[string, string](db, "household_kv_store")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	_, ok := access.(*outbound.SQLiteTableAccess[string, string])
+	assert.That(t, "access must be SQLiteTableAccess", ok, true)
+}
+
+func Test_NewSQLiteTableAccess_With_Invalid_Table_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewSQLiteTableAccess[string, string](nil, "kv_store; DROP TABLE kv_store")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
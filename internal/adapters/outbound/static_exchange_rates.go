@@ -0,0 +1,64 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// FXSourceStatic is the source of snapshots taken from StaticExchangeRates.
+const FXSourceStatic = "static"
+
+// StaticExchangeRates implements reservation.ExchangeRates with configured rates to the currency of record.
+// A snapshot is taken at the time of the booking, so its age tells how long ago the guest was quoted.
+type StaticExchangeRates struct {
+	currencyOfRecord string
+	rates            map[string]float64
+	now              func() time.Time
+}
+
+// NewStaticExchangeRates creates exchange rates to the currency of record,
+// e.g. {"EUR": 1.08} if one euro is 1.08 units of the currency of record.
+func NewStaticExchangeRates(currencyOfRecord string, rates map[string]float64) *StaticExchangeRates {
+	return &StaticExchangeRates{currencyOfRecord: strings.ToUpper(currencyOfRecord), rates: rates, now: time.Now}
+}
+
+// Snapshot returns the rate from a configured currency to the currency of record.
+func (r *StaticExchangeRates) Snapshot(ctx context.Context, from, to string) (shared.FXSnapshot, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return shared.NewIdentityFXSnapshot(from, r.now()), nil
+	}
+	rate, ok := r.rates[from]
+	if !ok || to != r.currencyOfRecord {
+		return shared.FXSnapshot{}, fmt.Errorf("%w: no static rate from %s to %s", reservation.ErrExchangeRateUnavailable, from, to)
+	}
+	return shared.NewFXSnapshot(from, to, rate, r.now(), FXSourceStatic), nil
+}
+
+// ParseExchangeRates parses rates to the currency of record in the format "currency=rate,..." (e.g. "EUR=1.08,GBP=1.27").
+func ParseExchangeRates(s string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		currency, value, ok := strings.Cut(part, "=")
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !ok || len(currency) != 3 {
+			return nil, fmt.Errorf("invalid exchange rate: %q", part)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %q", currency, value)
+		}
+		rates[currency] = rate
+	}
+	return rates, nil
+}
@@ -0,0 +1,75 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// StaticExchangeRates Tests
+// ============================================================================
+
+func Test_StaticExchangeRates_Snapshot_Should_Return_Configured_Rate(t *testing.T) {
+	// Arrange
+	rates := outbound.NewStaticExchangeRates("usd", map[string]float64{"EUR": 1.08})
+
+	// Act
+	snapshot, err := rates.Snapshot(context.Background(), "eur", "USD")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "from must be EUR", snapshot.From, "EUR")
+	assert.That(t, "to must be USD", snapshot.To, "USD")
+	assert.That(t, "rate must be configured", snapshot.Rate, 1.08)
+	assert.That(t, "source must be static", snapshot.Source, outbound.FXSourceStatic)
+	assert.That(t, "time must be set", snapshot.At.IsZero(), false)
+}
+
+func Test_StaticExchangeRates_Snapshot_Of_Same_Currency_Should_Be_Identity(t *testing.T) {
+	// Arrange
+	rates := outbound.NewStaticExchangeRates("USD", nil)
+
+	// Act
+	snapshot, err := rates.Snapshot(context.Background(), "USD", "USD")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "snapshot must be identity", snapshot.IsIdentity(), true)
+}
+
+func Test_StaticExchangeRates_Snapshot_Of_Unknown_Currency_Should_Return_ErrExchangeRateUnavailable(t *testing.T) {
+	// Arrange
+	rates := outbound.NewStaticExchangeRates("USD", map[string]float64{"EUR": 1.08})
+
+	// Act
+	_, err := rates.Snapshot(context.Background(), "JPY", "USD")
+
+	// Assert
+	assert.That(t, "err must be ErrExchangeRateUnavailable", errors.Is(err, reservation.ErrExchangeRateUnavailable), true)
+}
+
+// ============================================================================
+// ParseExchangeRates Tests
+// ============================================================================
+
+func Test_ParseExchangeRates_Should_Parse_Rates_Per_Currency(t *testing.T) {
+	// Act
+	rates, err := outbound.ParseExchangeRates("eur=1.08, GBP=1.27")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "rates must be parsed", rates, map[string]float64{"EUR": 1.08, "GBP": 1.27})
+}
+
+func Test_ParseExchangeRates_With_Invalid_Rate_Should_Return_Error(t *testing.T) {
+	// Act
+	_, err := outbound.ParseExchangeRates("EUR=0")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInvalidClockAdvance is returned if the test clock is to be moved by a non-positive duration.
+var ErrInvalidClockAdvance = errors.New("the test clock only moves forward")
+
+// TestClock is a virtual clock for end-to-end tests in staging: the wall clock plus an offset that
+// the test clock API advances. Set via shared.SetClock, it makes the scheduled jobs and the date rules of
+// the reservations see the advanced time, so a stay can be walked through its lifecycle in minutes.
+// The wall clock keeps running underneath, so the jobs still run at their intervals.
+type TestClock struct {
+	offset time.Duration
+	mu     sync.RWMutex // guards offset
+}
+
+// NewTestClock creates a test clock at the wall clock.
+func NewTestClock() *TestClock {
+	return &TestClock{}
+}
+
+// Now returns the wall clock plus the offset.
+func (c *TestClock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset returns how far the clock was advanced.
+func (c *TestClock) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// Advance moves the clock forward by d.
+// It does not move back, since the records written in the virtual future would be ahead of it.
+func (c *TestClock) Advance(d time.Duration) error {
+	if d <= 0 {
+		return ErrInvalidClockAdvance
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+	return nil
+}
+
+// Reset returns the clock to the wall clock, e.g. before the next scenario.
+func (c *TestClock) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = 0
+}
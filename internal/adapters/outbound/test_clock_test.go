@@ -0,0 +1,52 @@
+package outbound_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// TestClock Tests
+// ============================================================================
+
+func Test_TestClock_Advance_Should_Move_Now_Forward(t *testing.T) {
+	// Arrange
+	clock := outbound.NewTestClock()
+
+	// Act
+	err := clock.Advance(26 * time.Hour)
+	now := clock.Now()
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "offset must be the advance", clock.Offset(), 26*time.Hour)
+	assert.That(t, "now must be a day ahead", now.After(time.Now().Add(25*time.Hour)), true)
+}
+
+func Test_TestClock_Advance_Backwards_Should_Return_Error(t *testing.T) {
+	// Arrange
+	clock := outbound.NewTestClock()
+
+	// Act
+	err := clock.Advance(-time.Hour)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidClockAdvance", errors.Is(err, outbound.ErrInvalidClockAdvance), true)
+	assert.That(t, "offset must stay", clock.Offset(), time.Duration(0))
+}
+
+func Test_TestClock_Reset_Should_Return_To_Wall_Clock(t *testing.T) {
+	// Arrange
+	clock := outbound.NewTestClock()
+	_ = clock.Advance(time.Hour)
+
+	// Act
+	clock.Reset()
+
+	// Assert
+	assert.That(t, "offset must be zero", clock.Offset(), time.Duration(0))
+}
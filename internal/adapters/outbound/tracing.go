@@ -0,0 +1,606 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/cloud-native-utils/service"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// This file contains the distributed tracing without the OpenTelemetry SDK.
+// Trace contexts are propagated in the W3C traceparent format: in the header of HTTP requests
+// and in a "traceparent" field of the JSON events, because Kafka messages of the dispatcher
+// carry no headers. Spans are exported in batches to a collector with OTLP/HTTP in JSON.
+
+// TraceParentKey is the HTTP header and event field carrying the trace context.
+const TraceParentKey = "traceparent"
+
+// The span kinds of OTLP.
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanKindProducer = 4
+	spanKindConsumer = 5
+)
+
+// spanContext identifies a span within its trace.
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+	sampled bool
+}
+
+// spanContextKey is the context key of the current span.
+type spanContextKey struct{}
+
+// parseTraceParent parses a W3C traceparent, e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// Later versions may append fields, which are ignored.
+func parseTraceParent(traceparent string) (spanContext, bool) {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) < 4 || (parts[0] == "00" && len(parts) > 4) || parts[0] == "ff" {
+		return spanContext{}, false
+	}
+	if len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return spanContext{}, false
+	}
+	var sc spanContext
+	var flags [1]byte
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return spanContext{}, false
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return spanContext{}, false
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return spanContext{}, false
+	}
+	if sc.traceID == [16]byte{} || sc.spanID == [8]byte{} {
+		return spanContext{}, false
+	}
+	sc.sampled = flags[0]&1 == 1
+	return sc, true
+}
+
+// String formats the span context as a W3C traceparent.
+func (sc spanContext) String() string {
+	flags := "00"
+	if sc.sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.traceID[:]) + "-" + hex.EncodeToString(sc.spanID[:]) + "-" + flags
+}
+
+// TraceParent returns the traceparent of the current span of the context, or an empty string outside of a trace.
+func TraceParent(ctx context.Context) string {
+	sc, ok := ctx.Value(spanContextKey{}).(spanContext)
+	if !ok {
+		return ""
+	}
+	return sc.String()
+}
+
+// TracerConfig configures the sampling and the batching of the exporter.
+type TracerConfig struct {
+	ServiceName   string            // service.name of the spans
+	Headers       map[string]string // sent with every export, e.g. the API key of the backend
+	SampleRatio   float64           // share of new traces recorded; traces of callers keep their decision
+	BatchSize     int               // spans that trigger an export
+	FlushInterval time.Duration     // export at least this often
+	MaxBuffered   int               // spans kept while the collector is slow; more are dropped
+}
+
+// DefaultTracerConfig returns the batching defaults of the OpenTelemetry SDKs and records every trace.
+func DefaultTracerConfig() TracerConfig {
+	return TracerConfig{
+		ServiceName:   "hotel-booking",
+		SampleRatio:   1,
+		BatchSize:     512,
+		FlushInterval: 5 * time.Second,
+		MaxBuffered:   2048,
+	}
+}
+
+// ParseOTLPHeaders parses headers in the format of OTEL_EXPORTER_OTLP_HEADERS,
+// e.g. "x-api-key=secret,x-team=hotel"; values may be URL-encoded.
+func ParseOTLPHeaders(headers string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, part := range strings.Split(headers, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid otlp header %q: want key=value", part)
+		}
+		value, err := url.QueryUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid otlp header %q: %w", key, err)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// Tracer records spans and exports them to an OTLP/HTTP collector.
+// Spans buffered when the process stops are flushed once more; spans of a failed export are lost.
+type Tracer struct {
+	client   *http.Client
+	endpoint string
+	config   TracerConfig
+	logger   *slog.Logger
+	now      func() time.Time
+	random   func() float64
+	wake     chan struct{}
+
+	mu      sync.Mutex
+	spans   []otlpSpan
+	dropped int
+}
+
+// NewTracer creates a tracer exporting to the collector at the endpoint, e.g. http://otel-collector:4318.
+// The client must not be traced itself, e.g. a client of HTTPClients created before WithTracer.
+func NewTracer(client *http.Client, endpoint string, config TracerConfig, logger *slog.Logger) (*Tracer, error) {
+	if endpoint == "" {
+		return nil, errors.New("otlp endpoint required")
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %v: want 0 to 1", config.SampleRatio)
+	}
+	defaults := DefaultTracerConfig()
+	if config.ServiceName == "" {
+		config.ServiceName = defaults.ServiceName
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	return &Tracer{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		config:   config,
+		logger:   logger,
+		now:      time.Now,
+		random:   rand.Float64,
+		wake:     make(chan struct{}, 1),
+	}, nil
+}
+
+// WithClock replaces the clock of the spans, e.g. with a fixed time in tests.
+func (t *Tracer) WithClock(now func() time.Time) *Tracer {
+	t.now = now
+	return t
+}
+
+// StartSpan starts an internal span, e.g. of a domain service, as a child of the current span of the context.
+func (t *Tracer) StartSpan(ctx context.Context, name string, attrs ...string) (context.Context, shared.Span) {
+	return t.start(ctx, spanKindInternal, name, attrs...)
+}
+
+// StartServerSpan starts the span of an incoming request, continuing the trace of the traceparent
+// of the caller; an empty or invalid traceparent starts a new trace.
+func (t *Tracer) StartServerSpan(ctx context.Context, traceparent, name string, attrs ...string) (context.Context, shared.Span) {
+	if parent, ok := parseTraceParent(traceparent); ok {
+		ctx = context.WithValue(ctx, spanContextKey{}, parent)
+	}
+	return t.start(ctx, spanKindServer, name, attrs...)
+}
+
+// start starts a span of the kind as a child of the current span of the context.
+func (t *Tracer) start(ctx context.Context, kind int, name string, attrs ...string) (context.Context, *tracedSpan) {
+	span := &tracedSpan{tracer: t, name: name, kind: kind, start: t.now(), attrs: attrs}
+	if parent, ok := ctx.Value(spanContextKey{}).(spanContext); ok {
+		span.context.traceID = parent.traceID
+		span.context.sampled = parent.sampled
+		span.parentID = parent.spanID
+	} else {
+		span.context.traceID = randomTraceID()
+		span.context.sampled = t.config.SampleRatio > 0 && t.random() < t.config.SampleRatio
+	}
+	span.context.spanID = randomSpanID()
+	return context.WithValue(ctx, spanContextKey{}, span.context), span
+}
+
+// Start exports the spans every FlushInterval and whenever a batch is full until the context is done.
+// The remaining spans are exported once more on the way out.
+func (t *Tracer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(t.config.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+				if err := t.Flush(flushCtx); err != nil {
+					t.logger.Warn("final span export failed", "error", err)
+				}
+				cancel()
+				return
+			case <-ticker.C:
+			case <-t.wake:
+			}
+			if err := t.Flush(ctx); err != nil {
+				t.logger.Warn("span export failed", "error", err)
+			}
+		}
+	}()
+}
+
+// Flush exports the buffered spans in batches.
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	spans, dropped := t.spans, t.dropped
+	t.spans, t.dropped = nil, 0
+	t.mu.Unlock()
+
+	if dropped > 0 {
+		t.logger.Warn("spans dropped, the span buffer is full", "dropped", dropped)
+	}
+	for start := 0; start < len(spans); start += t.config.BatchSize {
+		batch := spans[start:min(start+t.config.BatchSize, len(spans))]
+		if err := t.export(ctx, batch); err != nil {
+			return fmt.Errorf("failed to export %d spans: %w", len(spans)-start, err)
+		}
+	}
+	return nil
+}
+
+// record buffers an ended span for the next export.
+func (t *Tracer) record(span otlpSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.config.MaxBuffered > 0 && len(t.spans) >= t.config.MaxBuffered {
+		t.dropped++
+		return
+	}
+	t.spans = append(t.spans, span)
+	if len(t.spans) >= t.config.BatchSize {
+		select {
+		case t.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// export posts the spans to the collector.
+func (t *Tracer) export(ctx context.Context, spans []otlpSpan) error {
+	payload := otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes([]string{"service.name", t.config.ServiceName})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "github.com/andygeiss/hotel-booking"}, Spans: spans}},
+	}}}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// tracedSpan is a span of the tracer; unsampled spans only propagate their context.
+type tracedSpan struct {
+	tracer   *Tracer
+	name     string
+	kind     int
+	context  spanContext
+	parentID [8]byte
+	start    time.Time
+
+	mu    sync.Mutex
+	attrs []string
+	ended bool
+}
+
+// SetAttributes adds attributes known after the start, e.g. the status code of a response.
+func (s *tracedSpan) SetAttributes(attrs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(slices.Clip(s.attrs), attrs...)
+}
+
+// End records the span with the error status if err is not nil. Only the first call counts.
+func (s *tracedSpan) End(err error) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	attrs := s.attrs
+	s.mu.Unlock()
+	if !s.context.sampled {
+		return
+	}
+
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.context.traceID[:]),
+		SpanID:            hex.EncodeToString(s.context.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.tracer.now().UnixNano(), 10),
+		Attributes:        otlpAttributes(attrs),
+	}
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if err != nil {
+		span.Status = otlpStatus{Code: 2, Message: err.Error()}
+	}
+	s.tracer.record(span)
+}
+
+// randomTraceID returns a random trace ID that is not zero.
+func randomTraceID() [16]byte {
+	var id [16]byte
+	for id == [16]byte{} {
+		for i := range 2 {
+			n := rand.Uint64()
+			for j := range 8 {
+				id[i*8+j] = byte(n >> (8 * j))
+			}
+		}
+	}
+	return id
+}
+
+// randomSpanID returns a random span ID that is not zero.
+func randomSpanID() [8]byte {
+	var id [8]byte
+	for id == [8]byte{} {
+		n := rand.Uint64()
+		for j := range 8 {
+			id[j] = byte(n >> (8 * j))
+		}
+	}
+	return id
+}
+
+// The OTLP/JSON encoding of the spans; IDs are hex and times are decimal strings.
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// otlpAttributes converts name/value pairs to attributes; a name without a value is skipped.
+func otlpAttributes(attrs []string) []otlpAttribute {
+	attributes := make([]otlpAttribute, 0, len(attrs)/2)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		attributes = append(attributes, otlpAttribute{Key: attrs[i], Value: otlpValue{StringValue: attrs[i+1]}})
+	}
+	return attributes
+}
+
+// TracingDispatcher traces the events: publishing starts a producer span and adds its traceparent
+// to the JSON event, and the handlers of subscriptions run in a consumer span continuing that trace.
+// The field is removed before the handlers see the event.
+type TracingDispatcher struct {
+	dispatcher messaging.Dispatcher
+	tracer     *Tracer
+}
+
+// NewTracingDispatcher wraps the dispatcher with the tracing of the events.
+func NewTracingDispatcher(dispatcher messaging.Dispatcher, tracer *Tracer) *TracingDispatcher {
+	return &TracingDispatcher{dispatcher: dispatcher, tracer: tracer}
+}
+
+// Publish publishes the message with the traceparent of a producer span.
+func (d *TracingDispatcher) Publish(ctx context.Context, message messaging.Message) (err error) {
+	ctx, span := d.tracer.start(ctx, spanKindProducer, "publish "+message.Topic,
+		"messaging.system", "kafka", "messaging.destination.name", message.Topic)
+	defer func() { span.End(err) }()
+	message.Data = injectTraceParent(message.Data, span.context.String())
+	return d.dispatcher.Publish(ctx, message)
+}
+
+// Subscribe subscribes the function in a consumer span of the trace of each event.
+func (d *TracingDispatcher) Subscribe(ctx context.Context, topic string, fn service.Function[messaging.Message, messaging.MessageState]) error {
+	return d.dispatcher.Subscribe(ctx, topic, func(ctx context.Context, message messaging.Message) (state messaging.MessageState, err error) {
+		traceparent, data := extractTraceParent(message.Data)
+		message.Data = data
+		if parent, ok := parseTraceParent(traceparent); ok {
+			ctx = context.WithValue(ctx, spanContextKey{}, parent)
+		}
+		ctx, span := d.tracer.start(ctx, spanKindConsumer, "process "+topic,
+			"messaging.system", "kafka", "messaging.destination.name", topic)
+		defer func() { span.End(err) }()
+		return fn(ctx, message)
+	})
+}
+
+// injectTraceParent adds the traceparent to a JSON object; other data is returned unchanged.
+func injectTraceParent(data []byte, traceparent string) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return data
+	}
+	fields[TraceParentKey], _ = json.Marshal(traceparent)
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+	return encoded
+}
+
+// extractTraceParent removes the traceparent from a JSON object and returns it with the remaining data.
+func extractTraceParent(data []byte) (string, []byte) {
+	if !bytes.Contains(data, []byte(`"`+TraceParentKey+`"`)) {
+		return "", data
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", data
+	}
+	var traceparent string
+	if err := json.Unmarshal(fields[TraceParentKey], &traceparent); err != nil {
+		return "", data
+	}
+	delete(fields, TraceParentKey)
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return "", data
+	}
+	return traceparent, encoded
+}
+
+// TracedAccess traces the calls of a key/value store in client spans, e.g. of a Postgres table.
+type TracedAccess[K, V any] struct {
+	access resource.Access[K, V]
+	tracer *Tracer
+	attrs  []string
+}
+
+// NewTracedAccess wraps the store with the tracing of its calls; system and table name the database,
+// e.g. "postgresql" and "reservation_db.kv_store".
+func NewTracedAccess[K, V any](access resource.Access[K, V], tracer *Tracer, system, table string) *TracedAccess[K, V] {
+	return &TracedAccess[K, V]{access: access, tracer: tracer, attrs: []string{"db.system", system, "db.collection.name", table}}
+}
+
+// Create creates the value in a client span.
+func (a *TracedAccess[K, V]) Create(ctx context.Context, key K, value V) (err error) {
+	ctx, span := a.span(ctx, "Create")
+	defer func() { span.End(err) }()
+	return a.access.Create(ctx, key, value)
+}
+
+// Read reads the value in a client span.
+func (a *TracedAccess[K, V]) Read(ctx context.Context, key K) (value *V, err error) {
+	ctx, span := a.span(ctx, "Read")
+	defer func() { span.End(err) }()
+	return a.access.Read(ctx, key)
+}
+
+// ReadAll reads all values in a client span.
+func (a *TracedAccess[K, V]) ReadAll(ctx context.Context) (values []V, err error) {
+	ctx, span := a.span(ctx, "ReadAll")
+	defer func() { span.End(err) }()
+	return a.access.ReadAll(ctx)
+}
+
+// Update updates the value in a client span.
+func (a *TracedAccess[K, V]) Update(ctx context.Context, key K, value V) (err error) {
+	ctx, span := a.span(ctx, "Update")
+	defer func() { span.End(err) }()
+	return a.access.Update(ctx, key, value)
+}
+
+// Delete deletes the value in a client span.
+func (a *TracedAccess[K, V]) Delete(ctx context.Context, key K) (err error) {
+	ctx, span := a.span(ctx, "Delete")
+	defer func() { span.End(err) }()
+	return a.access.Delete(ctx, key)
+}
+
+// span starts the client span of an operation, e.g. "Read kv_store".
+func (a *TracedAccess[K, V]) span(ctx context.Context, operation string) (context.Context, *tracedSpan) {
+	return a.tracer.start(ctx, spanKindClient, operation+" "+a.attrs[3], append([]string{"db.operation.name", operation}, a.attrs...)...)
+}
+
+// tracingTransport traces the outbound HTTP calls of a trace in client spans and propagates the trace
+// with the traceparent header. Calls outside of a trace, e.g. the exports of the tracer, are not traced.
+type tracingTransport struct {
+	next        http.RoundTripper
+	tracer      *Tracer
+	destination string
+}
+
+// RoundTrip sends the request in a client span with its traceparent.
+func (t *tracingTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	if _, ok := req.Context().Value(spanContextKey{}).(spanContext); !ok {
+		return t.next.RoundTrip(req)
+	}
+	ctx, span := t.tracer.start(req.Context(), spanKindClient, req.Method+" "+t.destination,
+		"http.request.method", req.Method, "server.address", req.URL.Host, "peer.service", t.destination)
+	defer func() {
+		if err == nil {
+			span.SetAttributes("http.response.status_code", strconv.Itoa(resp.StatusCode))
+			if resp.StatusCode >= http.StatusInternalServerError {
+				span.End(errors.New(resp.Status))
+				return
+			}
+		}
+		span.End(err)
+	}()
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(ctx)
+	req.Header.Set(TraceParentKey, span.context.String())
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,325 @@
+package outbound_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+var (
+	_ shared.Tracer                = (*outbound.Tracer)(nil)
+	_ messaging.Dispatcher         = (*outbound.TracingDispatcher)(nil)
+	_ resource.Access[string, int] = (*outbound.TracedAccess[string, int])(nil)
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// otlpCollector records the spans of the exports it receives.
+type otlpCollector struct {
+	mu      sync.Mutex
+	exports int
+	headers http.Header
+	spans   []map[string]any
+	service string
+}
+
+func (c *otlpCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []map[string]any `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exports++
+	c.headers = r.Header.Clone()
+	for _, rs := range payload.ResourceSpans {
+		c.service = rs.Resource.Attributes[0].Value.StringValue
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+}
+
+// span returns the recorded span with the name.
+func (c *otlpCollector) span(name string) map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, span := range c.spans {
+		if span["name"] == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func newTestTracer(t *testing.T, config outbound.TracerConfig) (*outbound.Tracer, *otlpCollector) {
+	t.Helper()
+	collector := &otlpCollector{}
+	server := httptest.NewServer(collector)
+	t.Cleanup(server.Close)
+	tracer, err := outbound.NewTracer(server.Client(), server.URL, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tracer, collector
+}
+
+// ============================================================================
+// Tracer Tests
+// ============================================================================
+
+func Test_Tracer_Should_Export_Spans_Of_Trace_Of_Caller(t *testing.T) {
+	// Arrange
+	config := outbound.DefaultTracerConfig()
+	config.ServiceName = "hotel-test"
+	config.Headers = map[string]string{"X-Api-Key": "secret"}
+	tracer, collector := newTestTracer(t, config)
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tracer.WithClock(func() time.Time { return start })
+
+	// Act
+	ctx, server := tracer.StartServerSpan(context.Background(), testTraceParent, "GET /ui/reservations/{id}", "http.route", "/ui/reservations/{id}")
+	_, child := tracer.StartSpan(ctx, "reservation.CancelReservation")
+	child.End(errors.New("already cancelled"))
+	server.SetAttributes("http.response.status_code", "200")
+	server.End(nil)
+	err := tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "one export must be sent", collector.exports, 1)
+	assert.That(t, "headers must be sent", collector.headers.Get("X-Api-Key"), "secret")
+	assert.That(t, "service name must be set", collector.service, "hotel-test")
+	serverSpan := collector.span("GET /ui/reservations/{id}")
+	childSpan := collector.span("reservation.CancelReservation")
+	assert.That(t, "server span must continue the trace", serverSpan["traceId"], "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.That(t, "server span must be child of the caller", serverSpan["parentSpanId"], "00f067aa0ba902b7")
+	assert.That(t, "server span must be of kind server", serverSpan["kind"], float64(2))
+	assert.That(t, "start time must be in nanoseconds", serverSpan["startTimeUnixNano"], "1777636800000000000")
+	assert.That(t, "server span must have its attributes", len(serverSpan["attributes"].([]any)), 2)
+	assert.That(t, "child span must be in the same trace", childSpan["traceId"], "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.That(t, "child span must be child of the server span", childSpan["parentSpanId"], serverSpan["spanId"])
+	assert.That(t, "failed span must have the error status", childSpan["status"], any(map[string]any{"code": float64(2), "message": "already cancelled"}))
+}
+
+func Test_Tracer_StartServerSpan_Without_TraceParent_Should_Start_New_Trace(t *testing.T) {
+	// Arrange
+	tracer, _ := newTestTracer(t, outbound.DefaultTracerConfig())
+
+	// Act
+	ctx, _ := tracer.StartServerSpan(context.Background(), "00-invalid", "GET /")
+	traceparent := outbound.TraceParent(ctx)
+
+	// Assert
+	assert.That(t, "traceparent must be set", len(traceparent), 55)
+	assert.That(t, "trace must not be the invalid one", strings.HasPrefix(traceparent, "00-invalid"), false)
+	assert.That(t, "new trace must be sampled", strings.HasSuffix(traceparent, "-01"), true)
+}
+
+func Test_Tracer_When_Not_Sampled_Should_Propagate_Without_Export(t *testing.T) {
+	// Arrange
+	config := outbound.DefaultTracerConfig()
+	config.SampleRatio = 0
+	tracer, collector := newTestTracer(t, config)
+
+	// Act
+	ctx, span := tracer.StartSpan(context.Background(), "payment.CapturePayment")
+	span.End(nil)
+	err := tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "traceparent must be unsampled", strings.HasSuffix(outbound.TraceParent(ctx), "-00"), true)
+	assert.That(t, "nothing must be exported", collector.exports, 0)
+}
+
+func Test_Tracer_Should_Keep_Sampling_Decision_Of_Caller(t *testing.T) {
+	// Arrange
+	config := outbound.DefaultTracerConfig()
+	config.SampleRatio = 0
+	tracer, collector := newTestTracer(t, config)
+
+	// Act
+	_, span := tracer.StartServerSpan(context.Background(), testTraceParent, "GET /")
+	span.End(nil)
+	_ = tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "sampled trace of the caller must be exported", collector.span("GET /") != nil, true)
+}
+
+func Test_NewTracer_With_Invalid_Sample_Ratio_Should_Fail(t *testing.T) {
+	// Arrange
+	config := outbound.DefaultTracerConfig()
+	config.SampleRatio = 1.5
+
+	// Act
+	_, err := outbound.NewTracer(http.DefaultClient, "http://collector:4318", config, slog.Default())
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+func Test_ParseOTLPHeaders_Should_Decode_Values(t *testing.T) {
+	// Act
+	headers, err := outbound.ParseOTLPHeaders("x-api-key=a%3Db, x-team=hotel")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "headers must match", headers, map[string]string{"x-api-key": "a=b", "x-team": "hotel"})
+}
+
+func Test_ParseOTLPHeaders_Without_Value_Should_Fail(t *testing.T) {
+	// Act
+	_, err := outbound.ParseOTLPHeaders("x-api-key")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// TracingDispatcher Tests
+// ============================================================================
+
+func Test_TracingDispatcher_Should_Continue_Trace_In_Subscribers(t *testing.T) {
+	// Arrange
+	tracer, collector := newTestTracer(t, outbound.DefaultTracerConfig())
+	dispatcher := outbound.NewTracingDispatcher(messaging.NewInternalDispatcher(), tracer)
+	var received []byte
+	var consumerTraceParent string
+	_ = dispatcher.Subscribe(context.Background(), "reservation.created", func(ctx context.Context, message messaging.Message) (messaging.MessageState, error) {
+		received = message.Data
+		consumerTraceParent = outbound.TraceParent(ctx)
+		return messaging.MessageStateCompleted, nil
+	})
+
+	// Act
+	err := dispatcher.Publish(context.Background(), messaging.NewMessage("reservation.created", []byte(`{"reservation_id":"res-1"}`)))
+	_ = tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "traceparent must be removed from the event", string(received), `{"reservation_id":"res-1"}`)
+	producer := collector.span("publish reservation.created")
+	consumer := collector.span("process reservation.created")
+	assert.That(t, "consumer must be in the trace of the producer", consumer["traceId"], producer["traceId"])
+	assert.That(t, "consumer must be child of the producer", consumer["parentSpanId"], producer["spanId"])
+	assert.That(t, "handler must run in the consumer span", strings.Contains(consumerTraceParent, consumer["spanId"].(string)), true)
+}
+
+func Test_TracingDispatcher_Should_Publish_Non_JSON_Unchanged(t *testing.T) {
+	// Arrange
+	tracer, _ := newTestTracer(t, outbound.DefaultTracerConfig())
+	inner := &recordingDispatcher{}
+	dispatcher := outbound.NewTracingDispatcher(inner, tracer)
+
+	// Act
+	err := dispatcher.Publish(context.Background(), messaging.NewMessage("raw", []byte("not json")))
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "data must be unchanged", string(inner.published[0].Data), "not json")
+}
+
+// ============================================================================
+// TracedAccess Tests
+// ============================================================================
+
+func Test_TracedAccess_Should_Record_Failed_Calls(t *testing.T) {
+	// Arrange
+	tracer, collector := newTestTracer(t, outbound.DefaultTracerConfig())
+	access := outbound.NewTracedAccess(resource.NewInMemoryAccess[string, int](), tracer, "postgresql", "reservation_db.kv_store")
+
+	// Act
+	_, err := access.Read(context.Background(), "missing")
+	_ = tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be passed", err != nil, true)
+	span := collector.span("Read reservation_db.kv_store")
+	assert.That(t, "span must be of kind client", span["kind"], float64(3))
+	assert.That(t, "span must have the error status", span["status"].(map[string]any)["code"], float64(2))
+}
+
+// ============================================================================
+// HTTPClients Tracing Tests
+// ============================================================================
+
+func Test_HTTPClients_WithTracer_Should_Propagate_Trace(t *testing.T) {
+	// Arrange
+	tracer, collector := newTestTracer(t, outbound.DefaultTracerConfig())
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+	clients, _ := outbound.NewHTTPClients(outbound.DefaultHTTPClientConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := clients.WithTracer(tracer).Client("weather")
+	ctx, span := tracer.StartSpan(context.Background(), "weather.Forecast")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	// Act
+	resp, err := client.Do(req)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	span.End(nil)
+	_ = tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	call := collector.span("GET weather")
+	assert.That(t, "call must be traced", call != nil, true)
+	assert.That(t, "traceparent must name the client span", strings.Contains(received, call["spanId"].(string)), true)
+}
+
+func Test_HTTPClients_WithTracer_Should_Not_Trace_Calls_Outside_Of_Traces(t *testing.T) {
+	// Arrange
+	tracer, collector := newTestTracer(t, outbound.DefaultTracerConfig())
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+	clients, _ := outbound.NewHTTPClients(outbound.DefaultHTTPClientConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := clients.WithTracer(tracer).Client("oidc")
+
+	// Act
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	_ = tracer.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "traceparent must not be sent", received, "")
+	assert.That(t, "nothing must be exported", collector.exports, 0)
+}
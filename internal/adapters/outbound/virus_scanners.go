@@ -0,0 +1,66 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+)
+
+// NoopVirusScanner accepts every document without scanning it; the documents are marked as not scanned.
+type NoopVirusScanner struct{}
+
+// Scan implements document.VirusScanner.
+func (NoopVirusScanner) Scan(ctx context.Context, name string, data []byte) (document.ScanResult, error) {
+	return document.ScanResult{Status: document.ScanSkipped}, nil
+}
+
+// HTTPVirusScanner sends the documents to a scanning service, e.g. a REST wrapper around ClamAV.
+// The file is posted as the request body with its name in the X-File-Name header; the service
+// answers {"infected": true, "threat": "Eicar-Test-Signature"} or {"infected": false}.
+type HTTPVirusScanner struct {
+	client *http.Client
+	url    string
+}
+
+// NewHTTPVirusScanner creates a new scanner for the service at url, e.g. with the "virus_scan" client of HTTPClients.
+func NewHTTPVirusScanner(client *http.Client, url string) *HTTPVirusScanner {
+	return &HTTPVirusScanner{client: client, url: url}
+}
+
+// httpScanResponse is the response body of the scanning service.
+type httpScanResponse struct {
+	Infected bool   `json:"infected"`
+	Threat   string `json:"threat"`
+}
+
+// Scan implements document.VirusScanner. Errors mean the document could not be scanned.
+func (s *HTTPVirusScanner) Scan(ctx context.Context, name string, data []byte) (document.ScanResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return document.ScanResult{}, fmt.Errorf("failed to create scan request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-File-Name", name)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return document.ScanResult{}, fmt.Errorf("failed to scan document: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return document.ScanResult{}, fmt.Errorf("scanner returned %d", resp.StatusCode)
+	}
+
+	var result httpScanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return document.ScanResult{}, fmt.Errorf("failed to decode scan response: %w", err)
+	}
+	if result.Infected {
+		return document.ScanResult{Status: document.ScanInfected, Threat: result.Threat}, nil
+	}
+	return document.ScanResult{Status: document.ScanClean}, nil
+}
@@ -0,0 +1,61 @@
+package outbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+)
+
+// Both scanners must satisfy the port of the document context.
+var (
+	_ document.VirusScanner = outbound.NoopVirusScanner{}
+	_ document.VirusScanner = outbound.NewHTTPVirusScanner(nil, "")
+)
+
+// ============================================================================
+// HTTPVirusScanner Tests
+// ============================================================================
+
+func Test_HTTPVirusScanner_Scan_Should_Post_File_And_Report_Threat(t *testing.T) {
+	// Arrange
+	var name, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name = r.Header.Get("X-File-Name")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		_, _ = w.Write([]byte(`{"infected":true,"threat":"Eicar-Test-Signature"}`))
+	}))
+	defer srv.Close()
+	scanner := outbound.NewHTTPVirusScanner(srv.Client(), srv.URL)
+
+	// Act
+	result, err := scanner.Scan(context.Background(), "eicar.pdf", []byte("X5O!P%@AP"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "status must be infected", result.Status, document.ScanInfected)
+	assert.That(t, "threat must be reported", result.Threat, "Eicar-Test-Signature")
+	assert.That(t, "file name must be sent", name, "eicar.pdf")
+	assert.That(t, "content must be sent", body, "X5O!P%@AP")
+}
+
+func Test_HTTPVirusScanner_Scan_With_Server_Error_Should_Fail(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	scanner := outbound.NewHTTPVirusScanner(srv.Client(), srv.URL)
+
+	// Act
+	_, err := scanner.Scan(context.Background(), "visa.pdf", []byte("%PDF"))
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
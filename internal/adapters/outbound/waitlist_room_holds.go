@@ -0,0 +1,26 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// WaitlistRoomHolds implements reservation.RoomHolds on top of the waitlist service,
+// so a room offered to a waitlisted guest cannot be booked by others during the hold.
+type WaitlistRoomHolds struct {
+	waitlistService *waitlist.Service
+}
+
+// NewWaitlistRoomHolds creates new room holds.
+func NewWaitlistRoomHolds(waitlistService *waitlist.Service) *WaitlistRoomHolds {
+	return &WaitlistRoomHolds{
+		waitlistService: waitlistService,
+	}
+}
+
+// IsHeldForOthers reports whether the room is offered to another guest for the date range.
+func (h *WaitlistRoomHolds) IsHeldForOthers(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange, guestID reservation.GuestID) (bool, error) {
+	return h.waitlistService.IsHeldForOthers(ctx, waitlist.RoomID(roomID), dateRange.CheckIn, dateRange.CheckOut, waitlist.GuestID(guestID))
+}
@@ -0,0 +1,58 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// mockOfferNotifier records the waitlist offers.
+type mockOfferNotifier struct {
+	sent []*waitlist.Entry
+}
+
+func (m *mockOfferNotifier) SendWaitlistOffer(ctx context.Context, e *waitlist.Entry) error {
+	m.sent = append(m.sent, e)
+	return nil
+}
+
+// ============================================================================
+// WaitlistRoomHolds Tests
+// ============================================================================
+
+func Test_WaitlistRoomHolds_Should_Refuse_Booking_Of_Room_Held_For_Waitlisted_Guest(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation]()
+	checker := outbound.NewRepositoryAvailabilityChecker(repo)
+	reservationService := reservation.NewService(repo, checker, outbound.NewEventPublisher(messaging.NewInternalDispatcher()))
+	waitlistService := waitlist.NewService(resource.NewInMemoryAccess[waitlist.EntryID, waitlist.Entry](), outbound.NewReservationRoomAvailability(checker), &mockOfferNotifier{}, time.Hour)
+	reservationService.WithRoomHolds(outbound.NewWaitlistRoomHolds(waitlistService))
+	guests := []reservation.GuestInfo{reservation.NewGuestInfo("John Doe", "john@example.com", "")}
+	stay := reservation.NewDateRange(time.Now().AddDate(0, 0, 7), time.Now().AddDate(0, 0, 9))
+	_, _ = reservationService.CreateReservation(ctx, "res-001", "guest-001", "room-101", stay, shared.NewMoney(20000, "EUR"), guests)
+	_, _ = waitlistService.Join(ctx, "guest-waiting", "waiting@example.com", "room-101", stay.CheckIn, stay.CheckOut)
+	_ = reservationService.CancelReservation(ctx, "res-001", "changed plans")
+	_, _ = waitlistService.OfferFreedRoom(ctx, "room-101", stay.CheckIn, stay.CheckOut)
+
+	// Act
+	_, otherErr := reservationService.CreateReservation(ctx, "res-002", "guest-other", "room-101", stay, shared.NewMoney(20000, "EUR"), guests)
+	_, waitingErr := reservationService.CreateReservation(ctx, "res-003", "guest-waiting", "room-101", stay, shared.NewMoney(20000, "EUR"), guests)
+
+	// Assert
+	assert.That(t, "other guest must not book the held room", errors.Is(otherErr, reservation.ErrRoomNotAvailable), true)
+	assert.That(t, "waitlisted guest must book the held room", waitingErr, nil)
+}
@@ -0,0 +1,378 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// WarehouseColumnType is the type of a warehouse column, named as in BigQuery standard SQL.
+type WarehouseColumnType string
+
+const (
+	WarehouseString    WarehouseColumnType = "STRING"
+	WarehouseInt       WarehouseColumnType = "INT64"
+	WarehouseFloat     WarehouseColumnType = "FLOAT64"
+	WarehouseBool      WarehouseColumnType = "BOOL"
+	WarehouseTimestamp WarehouseColumnType = "TIMESTAMP"
+)
+
+// WarehouseKeyColumn holds the row key; warehouses deduplicate rows by it.
+const WarehouseKeyColumn = "_key"
+
+// WarehouseColumn is a nullable column of a warehouse table.
+type WarehouseColumn struct {
+	Name string
+	Type WarehouseColumnType
+}
+
+// WarehouseRow is a flattened record. Values are string, int64, float64, bool or time.Time.
+type WarehouseRow struct {
+	Key    string
+	Values map[string]any
+}
+
+// Warehouse is the analytics target, e.g. BigQuery or ClickHouse.
+// Columns are only ever added; the key column is created with the table and not listed.
+type Warehouse interface {
+	Name() string
+	// Columns returns the columns of the table; none if the table does not exist.
+	Columns(ctx context.Context, table string) ([]WarehouseColumn, error)
+	// AddColumns adds the columns to the table, creating it if it does not exist.
+	AddColumns(ctx context.Context, table string, columns []WarehouseColumn) error
+	// Insert writes the rows. Rows with a key that was written before may be dropped.
+	Insert(ctx context.Context, table string, rows []WarehouseRow) error
+}
+
+// ErrWarehouseBufferFull is returned if the warehouse is unreachable for so long that MaxBuffered rows wait.
+var ErrWarehouseBufferFull = errors.New("warehouse buffer full")
+
+// WarehouseSinkConfig configures the batching of the warehouse sink.
+type WarehouseSinkConfig struct {
+	BatchSize     int           // rows of a table that trigger a flush
+	FlushInterval time.Duration // flush at least this often
+	MaxBuffered   int           // rows kept while the warehouse fails; more are rejected
+}
+
+// DefaultWarehouseSinkConfig returns batches of 500 rows, flushed at least every 10 seconds, and a buffer of 50000 rows.
+func DefaultWarehouseSinkConfig() WarehouseSinkConfig {
+	return WarehouseSinkConfig{
+		BatchSize:     500,
+		FlushInterval: 10 * time.Second,
+		MaxBuffered:   50000,
+	}
+}
+
+// WarehouseSink flattens JSON records into rows and writes them to the warehouse in batches per table.
+// New fields become new columns; a value that does not fit the type of its column goes to a column
+// named after the field and its type (e.g. "amount_string"), so no record is rejected by a type change.
+// Rows are buffered in memory: a batch that failed is retried with the next flush, and rows still
+// buffered when the process stops are lost; a backfill restores them.
+type WarehouseSink struct {
+	warehouse Warehouse
+	config    WarehouseSinkConfig
+	logger    *slog.Logger
+	now       func() time.Time
+	wake      chan struct{}
+
+	mu       sync.Mutex
+	batches  map[string][]WarehouseRow
+	buffered int
+
+	flushMu sync.Mutex // serializes flushes, so schema changes are not applied twice
+	schemas map[string]map[string]WarehouseColumnType
+}
+
+// NewWarehouseSink creates a new warehouse sink. A batch size or flush interval below 1 uses the default.
+func NewWarehouseSink(warehouse Warehouse, config WarehouseSinkConfig, logger *slog.Logger) *WarehouseSink {
+	defaults := DefaultWarehouseSinkConfig()
+	if config.BatchSize < 1 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	return &WarehouseSink{
+		warehouse: warehouse,
+		config:    config,
+		logger:    logger,
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+		batches:   make(map[string][]WarehouseRow),
+		schemas:   make(map[string]map[string]WarehouseColumnType),
+	}
+}
+
+// Record flattens the JSON object in data into a row of the table and buffers it.
+// Nested objects become columns joined by "_", arrays are stored as JSON strings, and the
+// column "recorded_at" is added. The row is written with the next flush.
+func (s *WarehouseSink) Record(ctx context.Context, table, key string, data []byte) error {
+	values, err := flattenWarehouseJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to flatten %s record: %w", table, err)
+	}
+	values["recorded_at"] = s.now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config.MaxBuffered > 0 && s.buffered >= s.config.MaxBuffered {
+		return ErrWarehouseBufferFull
+	}
+	s.batches[table] = append(s.batches[table], WarehouseRow{Key: key, Values: values})
+	s.buffered++
+	if len(s.batches[table]) >= s.config.BatchSize {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Start flushes the buffered rows every FlushInterval and whenever a batch is full until the context is done.
+// The remaining rows are flushed once more on the way out.
+func (s *WarehouseSink) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.config.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+				if err := s.Flush(flushCtx); err != nil {
+					s.logger.Warn("final warehouse flush failed", "error", err)
+				}
+				cancel()
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+			if err := s.Flush(ctx); err != nil {
+				s.logger.Warn("warehouse flush failed", "warehouse", s.warehouse.Name(), "error", err)
+			}
+		}
+	}()
+}
+
+// Flush writes the buffered rows of every table. Tables that fail keep their rows for the next flush.
+func (s *WarehouseSink) Flush(ctx context.Context) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	batches := s.batches
+	s.batches = make(map[string][]WarehouseRow)
+	s.mu.Unlock()
+
+	var errs []error
+	for _, table := range slices.Sorted(maps.Keys(batches)) {
+		rows := batches[table]
+		for start := 0; start < len(rows); start += s.config.BatchSize {
+			batch := rows[start:min(start+s.config.BatchSize, len(rows))]
+			if err := s.write(ctx, table, batch); err != nil {
+				errs = append(errs, fmt.Errorf("table %s: %w", table, err))
+				s.requeue(table, rows[start:])
+				break
+			}
+			s.mu.Lock()
+			s.buffered -= len(batch)
+			s.mu.Unlock()
+			s.logger.Debug("warehouse batch written", "warehouse", s.warehouse.Name(), "table", table, "rows", len(batch))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Buffered returns the number of rows waiting for a flush.
+func (s *WarehouseSink) Buffered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buffered
+}
+
+// requeue puts rows that failed in front of the rows recorded meanwhile.
+func (s *WarehouseSink) requeue(table string, rows []WarehouseRow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches[table] = append(slices.Clone(rows), s.batches[table]...)
+}
+
+// write evolves the schema of the table for the batch and inserts it.
+func (s *WarehouseSink) write(ctx context.Context, table string, batch []WarehouseRow) error {
+	schema, err := s.schema(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]WarehouseRow, 0, len(batch))
+	added := make(map[string]WarehouseColumnType)
+	for _, row := range batch {
+		values := make(map[string]any, len(row.Values))
+		for name, value := range row.Values {
+			name, value = fitWarehouseColumn(schema, added, name, value)
+			values[name] = value
+		}
+		rows = append(rows, WarehouseRow{Key: row.Key, Values: values})
+	}
+
+	if len(added) > 0 {
+		columns := make([]WarehouseColumn, 0, len(added))
+		for _, name := range slices.Sorted(maps.Keys(added)) {
+			columns = append(columns, WarehouseColumn{Name: name, Type: added[name]})
+		}
+		if err := s.warehouse.AddColumns(ctx, table, columns); err != nil {
+			// The cached schema may be stale, e.g. after another replica added the columns.
+			delete(s.schemas, table)
+			return fmt.Errorf("failed to add columns: %w", err)
+		}
+		maps.Copy(schema, added)
+		s.logger.Info("warehouse schema evolved", "warehouse", s.warehouse.Name(), "table", table, "columns", len(columns))
+	}
+	return s.warehouse.Insert(ctx, table, rows)
+}
+
+// schema returns the cached columns of the table, reading them from the warehouse once.
+func (s *WarehouseSink) schema(ctx context.Context, table string) (map[string]WarehouseColumnType, error) {
+	if schema, ok := s.schemas[table]; ok {
+		return schema, nil
+	}
+	columns, err := s.warehouse.Columns(ctx, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	schema := make(map[string]WarehouseColumnType, len(columns))
+	for _, c := range columns {
+		schema[c.Name] = c.Type
+	}
+	s.schemas[table] = schema
+	return schema, nil
+}
+
+// fitWarehouseColumn returns the column and value to store a field in. Integers fit float columns
+// and every value fits a string column; other values go to the column "<name>_<type>".
+// Columns that do not exist yet are collected in added.
+func fitWarehouseColumn(schema, added map[string]WarehouseColumnType, name string, value any) (string, any) {
+	typ := warehouseTypeOf(value)
+	existing, ok := schema[name]
+	if !ok {
+		existing, ok = added[name]
+	}
+	switch {
+	case !ok:
+		added[name] = typ
+		return name, value
+	case existing == typ:
+		return name, value
+	case existing == WarehouseFloat && typ == WarehouseInt:
+		return name, float64(value.(int64))
+	case existing == WarehouseString:
+		return name, formatWarehouseValue(value)
+	}
+	return fitWarehouseColumn(schema, added, name+"_"+strings.ToLower(string(typ)), value)
+}
+
+// warehouseTypeOf returns the column type of a flattened value.
+func warehouseTypeOf(value any) WarehouseColumnType {
+	switch value.(type) {
+	case int64:
+		return WarehouseInt
+	case float64:
+		return WarehouseFloat
+	case bool:
+		return WarehouseBool
+	case time.Time:
+		return WarehouseTimestamp
+	default:
+		return WarehouseString
+	}
+}
+
+// formatWarehouseValue formats a flattened value for a string column.
+func formatWarehouseValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// flattenWarehouseJSON flattens a JSON object into snake_case columns.
+func flattenWarehouseJSON(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	flattenWarehouseObject(values, "", object)
+	return values, nil
+}
+
+// flattenWarehouseObject adds the fields of the object to values, prefixing their columns.
+func flattenWarehouseObject(values map[string]any, prefix string, object map[string]any) {
+	for key, value := range object {
+		name := prefix + warehouseColumnName(key)
+		switch v := value.(type) {
+		case nil:
+		case map[string]any:
+			flattenWarehouseObject(values, name+"_", v)
+		case []any:
+			data, _ := json.Marshal(v)
+			values[name] = string(data)
+		case json.Number:
+			if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				values[name] = n
+			} else {
+				f, _ := v.Float64()
+				values[name] = f
+			}
+		case string:
+			// Times are encoded as RFC 3339 strings; they become timestamps, except the zero time.
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				if !t.IsZero() {
+					values[name] = t.UTC()
+				}
+				continue
+			}
+			values[name] = v
+		default:
+			values[name] = v
+		}
+	}
+}
+
+// warehouseColumnName converts a JSON field to a column name in snake_case with [a-z0-9_] only,
+// e.g. "ReservationID" to "reservation_id" and "check-in" to "check_in".
+func warehouseColumnName(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		r = unicode.ToLower(r)
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// memoryWarehouse is a Warehouse that keeps its tables in memory.
+type memoryWarehouse struct {
+	columns   map[string][]outbound.WarehouseColumn
+	rows      map[string][]outbound.WarehouseRow
+	insertErr error
+	added     int
+}
+
+func newMemoryWarehouse() *memoryWarehouse {
+	return &memoryWarehouse{columns: make(map[string][]outbound.WarehouseColumn), rows: make(map[string][]outbound.WarehouseRow)}
+}
+
+func (w *memoryWarehouse) Name() string { return "memory" }
+
+func (w *memoryWarehouse) Columns(ctx context.Context, table string) ([]outbound.WarehouseColumn, error) {
+	return w.columns[table], nil
+}
+
+func (w *memoryWarehouse) AddColumns(ctx context.Context, table string, columns []outbound.WarehouseColumn) error {
+	w.added++
+	w.columns[table] = append(w.columns[table], columns...)
+	return nil
+}
+
+func (w *memoryWarehouse) Insert(ctx context.Context, table string, rows []outbound.WarehouseRow) error {
+	if w.insertErr != nil {
+		return w.insertErr
+	}
+	w.rows[table] = append(w.rows[table], rows...)
+	return nil
+}
+
+func (w *memoryWarehouse) column(table, name string) outbound.WarehouseColumnType {
+	for _, c := range w.columns[table] {
+		if c.Name == name {
+			return c.Type
+		}
+	}
+	return ""
+}
+
+func createTestWarehouseSink(warehouse outbound.Warehouse, config outbound.WarehouseSinkConfig) *outbound.WarehouseSink {
+	return outbound.NewWarehouseSink(warehouse, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// ============================================================================
+// WarehouseSink Tests
+// ============================================================================
+
+func Test_WarehouseSink_Flush_Should_Write_Flattened_Rows(t *testing.T) {
+	// Arrange
+	warehouse := newMemoryWarehouse()
+	sink := createTestWarehouseSink(warehouse, outbound.DefaultWarehouseSinkConfig())
+	data := `{"reservation_id":"res-1","TotalAmount":{"Amount":45000,"Currency":"USD"},"check_in":"2030-06-01T15:00:00Z","guests":[1,2],"note":null}`
+
+	// Act
+	err := sink.Record(context.Background(), "reservation_events", "key-1", []byte(data))
+	flushErr := sink.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "flush err must be nil", flushErr, nil)
+	rows := warehouse.rows["reservation_events"]
+	assert.That(t, "one row must be written", len(rows), 1)
+	assert.That(t, "key must be kept", rows[0].Key, "key-1")
+	assert.That(t, "nested amount must be flattened", rows[0].Values["total_amount_amount"], any(int64(45000)))
+	assert.That(t, "nested currency must be flattened", rows[0].Values["total_amount_currency"], any("USD"))
+	assert.That(t, "time must be a timestamp", rows[0].Values["check_in"], any(time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)))
+	assert.That(t, "array must be json", rows[0].Values["guests"], any("[1,2]"))
+	_, hasNote := rows[0].Values["note"]
+	assert.That(t, "null must be omitted", hasNote, false)
+	assert.That(t, "amount column must be int", warehouse.column("reservation_events", "total_amount_amount"), outbound.WarehouseInt)
+	assert.That(t, "recorded_at column must be a timestamp", warehouse.column("reservation_events", "recorded_at"), outbound.WarehouseTimestamp)
+	assert.That(t, "buffer must be empty", sink.Buffered(), 0)
+}
+
+func Test_WarehouseSink_Flush_With_New_Field_Should_Add_Column_Once(t *testing.T) {
+	// Arrange
+	warehouse := newMemoryWarehouse()
+	sink := createTestWarehouseSink(warehouse, outbound.DefaultWarehouseSinkConfig())
+	_ = sink.Record(context.Background(), "payment_events", "k1", []byte(`{"payment_id":"pay-1"}`))
+	_ = sink.Flush(context.Background())
+
+	// Act
+	_ = sink.Record(context.Background(), "payment_events", "k2", []byte(`{"payment_id":"pay-2","error_code":"declined"}`))
+	_ = sink.Record(context.Background(), "payment_events", "k3", []byte(`{"payment_id":"pay-3"}`))
+	err := sink.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "schema must be evolved twice", warehouse.added, 2)
+	assert.That(t, "new column must be a string", warehouse.column("payment_events", "error_code"), outbound.WarehouseString)
+	assert.That(t, "all rows must be written", len(warehouse.rows["payment_events"]), 3)
+}
+
+func Test_WarehouseSink_Flush_With_Changed_Type_Should_Use_Typed_Column(t *testing.T) {
+	// Arrange
+	warehouse := newMemoryWarehouse()
+	warehouse.columns["payment_events"] = []outbound.WarehouseColumn{
+		{Name: "amount", Type: outbound.WarehouseInt},
+		{Name: "ratio", Type: outbound.WarehouseFloat},
+		{Name: "note", Type: outbound.WarehouseString},
+	}
+	sink := createTestWarehouseSink(warehouse, outbound.DefaultWarehouseSinkConfig())
+
+	// Act
+	_ = sink.Record(context.Background(), "payment_events", "k1", []byte(`{"amount":"45.00","ratio":2,"note":true}`))
+	err := sink.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	values := warehouse.rows["payment_events"][0].Values
+	assert.That(t, "string must go to a typed column", values["amount_string"], any("45.00"))
+	assert.That(t, "typed column must be added", warehouse.column("payment_events", "amount_string"), outbound.WarehouseString)
+	assert.That(t, "integer must be widened to float", values["ratio"], any(float64(2)))
+	assert.That(t, "bool must be formatted for a string column", values["note"], any("true"))
+}
+
+func Test_WarehouseSink_Flush_When_Insert_Fails_Should_Keep_Rows(t *testing.T) {
+	// Arrange
+	warehouse := newMemoryWarehouse()
+	warehouse.insertErr = errors.New("unavailable")
+	sink := createTestWarehouseSink(warehouse, outbound.DefaultWarehouseSinkConfig())
+	_ = sink.Record(context.Background(), "reservation_events", "k1", []byte(`{"reservation_id":"res-1"}`))
+
+	// Act
+	failed := sink.Flush(context.Background())
+	warehouse.insertErr = nil
+	retried := sink.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "first flush must fail", failed != nil, true)
+	assert.That(t, "retry must succeed", retried, nil)
+	assert.That(t, "row must be written once", len(warehouse.rows["reservation_events"]), 1)
+	assert.That(t, "buffer must be empty", sink.Buffered(), 0)
+}
+
+func Test_WarehouseSink_Record_When_Buffer_Full_Should_Return_ErrWarehouseBufferFull(t *testing.T) {
+	// Arrange
+	sink := createTestWarehouseSink(newMemoryWarehouse(), outbound.WarehouseSinkConfig{BatchSize: 10, MaxBuffered: 1})
+	_ = sink.Record(context.Background(), "reservation_events", "k1", []byte(`{}`))
+
+	// Act
+	err := sink.Record(context.Background(), "reservation_events", "k2", []byte(`{}`))
+
+	// Assert
+	assert.That(t, "err must be ErrWarehouseBufferFull", errors.Is(err, outbound.ErrWarehouseBufferFull), true)
+}
+
+func Test_WarehouseSink_Flush_Should_Write_Batches_Of_BatchSize(t *testing.T) {
+	// Arrange
+	warehouse := newMemoryWarehouse()
+	sink := createTestWarehouseSink(warehouse, outbound.WarehouseSinkConfig{BatchSize: 2})
+	for _, key := range []string{"k1", "k2", "k3"} {
+		_ = sink.Record(context.Background(), "reservation_events", key, []byte(`{"ReservationID":"res-1"}`))
+	}
+
+	// Act
+	err := sink.Flush(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "all rows must be written", len(warehouse.rows["reservation_events"]), 3)
+	assert.That(t, "column must be snake case", warehouse.column("reservation_events", "reservation_id"), outbound.WarehouseString)
+}
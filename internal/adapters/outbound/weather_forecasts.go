@@ -0,0 +1,104 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Errors returned by WeatherForecasts.
+var (
+	ErrWeatherRateLimited = errors.New("weather provider rate limited")
+	ErrWeatherUnavailable = errors.New("weather forecast unavailable")
+)
+
+// weatherCacheEntry is a cached forecast for a stay.
+type weatherCacheEntry struct {
+	forecasts []shared.DailyForecast
+	fetchedAt time.Time
+}
+
+// WeatherForecasts provides the forecast at the property for the stay dates.
+// Forecasts are cached per stay, and the provider is called at most once per minInterval,
+// so a popular detail page does not exhaust the provider's quota.
+// When the provider fails or is rate limited, a stale cached forecast is returned if available.
+type WeatherForecasts struct {
+	provider    WeatherProvider
+	location    PropertyLocation
+	logger      *slog.Logger
+	ttl         time.Duration
+	minInterval time.Duration
+	timeout     time.Duration
+
+	mu        sync.Mutex
+	cache     map[string]weatherCacheEntry
+	lastFetch time.Time
+}
+
+// NewWeatherForecasts creates a new cached and rate limited weather forecast source for the property location.
+func NewWeatherForecasts(provider WeatherProvider, location PropertyLocation, logger *slog.Logger, ttl, minInterval, timeout time.Duration) *WeatherForecasts {
+	return &WeatherForecasts{
+		provider:    provider,
+		location:    location,
+		logger:      logger,
+		ttl:         ttl,
+		minInterval: minInterval,
+		timeout:     timeout,
+		cache:       make(map[string]weatherCacheEntry),
+	}
+}
+
+// Forecast returns the daily forecasts between check-in and check-out.
+// It returns ErrWeatherUnavailable if the provider fails and nothing is cached.
+func (w *WeatherForecasts) Forecast(ctx context.Context, checkIn, checkOut time.Time) ([]shared.DailyForecast, error) {
+	key := checkIn.Format("2006-01-02") + "/" + checkOut.Format("2006-01-02")
+
+	w.mu.Lock()
+	entry, cached := w.cache[key]
+	if cached && time.Since(entry.fetchedAt) < w.ttl {
+		w.mu.Unlock()
+		return entry.forecasts, nil
+	}
+	if time.Since(w.lastFetch) < w.minInterval {
+		w.mu.Unlock()
+		return w.fallback(entry, cached, ErrWeatherRateLimited)
+	}
+	w.lastFetch = time.Now()
+	w.mu.Unlock()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	forecasts, err := w.provider.DailyForecast(fetchCtx, w.location, checkIn, checkOut)
+	if err != nil {
+		return w.fallback(entry, cached, err)
+	}
+
+	w.mu.Lock()
+	w.evictExpired()
+	w.cache[key] = weatherCacheEntry{forecasts: forecasts, fetchedAt: time.Now()}
+	w.mu.Unlock()
+	return forecasts, nil
+}
+
+// fallback returns the stale cached forecast, or ErrWeatherUnavailable.
+func (w *WeatherForecasts) fallback(entry weatherCacheEntry, cached bool, cause error) ([]shared.DailyForecast, error) {
+	w.logger.Debug("weather forecast not fetched", "error", cause, "stale", cached)
+	if cached {
+		return entry.forecasts, nil
+	}
+	return nil, errors.Join(ErrWeatherUnavailable, cause)
+}
+
+// evictExpired removes entries that are too old to serve even as a fallback.
+// The caller must hold the lock.
+func (w *WeatherForecasts) evictExpired() {
+	for key, entry := range w.cache {
+		if time.Since(entry.fetchedAt) > 24*time.Hour {
+			delete(w.cache, key)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+type mockWeatherProvider struct {
+	calls int
+	err   error
+}
+
+func (m *mockWeatherProvider) DailyForecast(ctx context.Context, loc outbound.PropertyLocation, from, to time.Time) ([]shared.DailyForecast, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []shared.DailyForecast{{Date: from, Summary: "Clear sky"}}, nil
+}
+
+func newTestWeatherForecasts(provider outbound.WeatherProvider, ttl, minInterval time.Duration) *outbound.WeatherForecasts {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return outbound.NewWeatherForecasts(provider, outbound.PropertyLocation{Latitude: 53.5, Longitude: 9.9}, logger, ttl, minInterval, time.Second)
+}
+
+// ============================================================================
+// WeatherForecasts Tests
+// ============================================================================
+
+func Test_WeatherForecasts_Should_Cache_Forecast_Per_Stay(t *testing.T) {
+	// Arrange
+	provider := &mockWeatherProvider{}
+	weather := newTestWeatherForecasts(provider, time.Hour, 0)
+	checkIn := time.Now().AddDate(0, 0, 2)
+
+	// Act
+	_, _ = weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+	forecasts, err := weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must return cached forecast", len(forecasts), 1)
+	assert.That(t, "provider must be called once", provider.calls, 1)
+}
+
+func Test_WeatherForecasts_When_Rate_Limited_Should_Return_Error_Without_Calling_Provider(t *testing.T) {
+	// Arrange
+	provider := &mockWeatherProvider{}
+	weather := newTestWeatherForecasts(provider, time.Hour, time.Hour)
+	checkIn := time.Now().AddDate(0, 0, 2)
+	_, _ = weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Act
+	_, err := weather.Forecast(context.Background(), checkIn.AddDate(0, 0, 1), checkIn.AddDate(0, 0, 3))
+
+	// Assert
+	assert.That(t, "error must be ErrWeatherRateLimited", errors.Is(err, outbound.ErrWeatherRateLimited), true)
+	assert.That(t, "error must be ErrWeatherUnavailable", errors.Is(err, outbound.ErrWeatherUnavailable), true)
+	assert.That(t, "provider must be called once", provider.calls, 1)
+}
+
+func Test_WeatherForecasts_When_Provider_Fails_Should_Return_Stale_Forecast(t *testing.T) {
+	// Arrange
+	provider := &mockWeatherProvider{}
+	weather := newTestWeatherForecasts(provider, 0, 0)
+	checkIn := time.Now().AddDate(0, 0, 2)
+	_, _ = weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+	provider.err = errors.New("provider down")
+
+	// Act
+	forecasts, err := weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must return stale forecast", len(forecasts), 1)
+	assert.That(t, "provider must be called twice", provider.calls, 2)
+}
+
+func Test_WeatherForecasts_When_Provider_Fails_Without_Cache_Should_Return_ErrWeatherUnavailable(t *testing.T) {
+	// Arrange
+	weather := newTestWeatherForecasts(&mockWeatherProvider{err: errors.New("provider down")}, time.Hour, 0)
+	checkIn := time.Now().AddDate(0, 0, 2)
+
+	// Act
+	_, err := weather.Forecast(context.Background(), checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "error must be ErrWeatherUnavailable", errors.Is(err, outbound.ErrWeatherUnavailable), true)
+}
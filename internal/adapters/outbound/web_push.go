@@ -0,0 +1,215 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// webPushRecordSize is the record size announced in the aes128gcm header; one record holds every message.
+const webPushRecordSize = 4096
+
+// WebPushBackend implements PushBackend with the Web Push protocol (RFC 8030): the message is encrypted
+// for the browser (RFC 8291) and the request is signed with the VAPID key of the application (RFC 8292).
+type WebPushBackend struct {
+	client    *http.Client
+	key       *ecdsa.PrivateKey
+	publicKey string // base64url, as browsers subscribe with it
+	subject   string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewWebPushBackend creates a new Web Push backend, e.g. with the "push" client of HTTPClients.PublicClient,
+// since the endpoints come from the browsers. The privateKey is the base64url P-256 scalar the VAPID key pair
+// tools print; the subject is a mailto: or https: contact of the operator the push services may reach out to.
+// Push services keep undelivered messages for ttl.
+func NewWebPushBackend(client *http.Client, privateKey, subject string, ttl time.Duration) (*WebPushBackend, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(privateKey), "="))
+	if err != nil {
+		return nil, errors.New("VAPID private key must be base64url")
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https://") {
+		return nil, errors.New("VAPID subject must be a mailto: or https: URL")
+	}
+	public, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	return &WebPushBackend{
+		client:    client,
+		key:       key,
+		publicKey: base64.RawURLEncoding.EncodeToString(public),
+		subject:   subject,
+		ttl:       ttl,
+		now:       time.Now,
+	}, nil
+}
+
+// Platform returns profile.PushWebPush.
+func (b *WebPushBackend) Platform() profile.PushPlatform { return profile.PushWebPush }
+
+// PublicKey returns the VAPID public key of the private key, which browsers must subscribe with.
+func (b *WebPushBackend) PublicKey() string { return b.publicKey }
+
+// Send encrypts the message for the browser and posts it to the endpoint of the subscription.
+func (b *WebPushBackend) Send(ctx context.Context, subscription profile.PushSubscription, message PushMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode push message: %w", err)
+	}
+	body, err := encryptWebPush(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return err
+	}
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid push endpoint: %w", err)
+	}
+	token, err := b.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+b.publicKey)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(b.ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push message: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrPushSubscriptionGone
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// vapidToken returns the ES256 JWT that identifies the application to the push service of the audience.
+func (b *WebPushBackend) vapidToken(audience string) (string, error) {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": audience,
+		"exp": b.now().Add(12 * time.Hour).Unix(),
+		"sub": b.subject,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode VAPID claims: %w", err)
+	}
+	signingInput := header + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, b.key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	// JWS wants the raw 32-byte r and s, not the ASN.1 signature.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signingInput + "." + enc.EncodeToString(signature), nil
+}
+
+// encryptWebPush encrypts the payload for the browser's p256dh key and auth secret (RFC 8291, aes128gcm).
+func encryptWebPush(payload []byte, p256dh, auth string) ([]byte, error) {
+	enc := base64.RawURLEncoding
+	uaPublicRaw, err := enc.DecodeString(strings.TrimRight(p256dh, "="))
+	if err != nil {
+		return nil, errors.New("invalid p256dh key")
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicRaw)
+	if err != nil {
+		return nil, errors.New("invalid p256dh key")
+	}
+	authSecret, err := enc.DecodeString(strings.TrimRight(auth, "="))
+	if err != nil || len(authSecret) == 0 {
+		return nil, errors.New("invalid auth secret")
+	}
+	if len(payload)+1+aes.BlockSize > webPushRecordSize-86 {
+		return nil, errors.New("push message too large")
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push key: %w", err)
+	}
+	secret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push secret: %w", err)
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate push salt: %w", err)
+	}
+
+	ikm, err := hkdf.Key(sha256.New, secret, authSecret, "WebPush: info\x00"+string(uaPublicRaw)+string(asPublic), 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push nonce: %w", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push cipher: %w", err)
+	}
+	// A single record ends with the delimiter 0x02 and needs no padding.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
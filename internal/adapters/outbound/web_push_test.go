@@ -0,0 +1,186 @@
+package outbound_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// testBrowser is the key pair and auth secret a browser creates when it subscribes.
+type testBrowser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newTestBrowser(t *testing.T) testBrowser {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate browser key: %v", err)
+	}
+	auth := make([]byte, 16)
+	_, _ = rand.Read(auth)
+	return testBrowser{key: key, auth: auth}
+}
+
+func (b testBrowser) subscription(endpoint string) profile.PushSubscription {
+	enc := base64.RawURLEncoding
+	return profile.PushSubscription{
+		ID:       profile.PushSubscriptionIDOf(endpoint),
+		Platform: profile.PushWebPush,
+		Endpoint: endpoint,
+		P256dh:   enc.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     enc.EncodeToString(b.auth),
+	}
+}
+
+// decrypt reverses the aes128gcm encryption of RFC 8291 like the browser does.
+func (b testBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	salt, idLen := body[:16], int(body[20])
+	asPublicRaw := body[21 : 21+idLen]
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicRaw)
+	if err != nil {
+		t.Fatalf("invalid sender key: %v", err)
+	}
+	secret, _ := b.key.ECDH(asPublic)
+	ikm, _ := hkdf.Key(sha256.New, secret, b.auth, "WebPush: info\x00"+string(b.key.PublicKey().Bytes())+string(asPublicRaw), 32)
+	prk, _ := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt push message: %v", err)
+	}
+	return plaintext
+}
+
+// createTestVAPIDKey returns a VAPID private key as base64url scalar.
+func createTestVAPIDKey(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate VAPID key: %v", err)
+	}
+	raw, _ := key.Bytes()
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// ============================================================================
+// WebPushBackend Tests
+// ============================================================================
+
+func Test_NewWebPushBackend_Without_Contact_Subject_Should_Fail(t *testing.T) {
+	// Arrange & Act
+	_, err := outbound.NewWebPushBackend(http.DefaultClient, createTestVAPIDKey(t), "ops@example.com", time.Hour)
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_WebPushBackend_Send_Should_Encrypt_Message_For_Browser(t *testing.T) {
+	// Arrange
+	browser := newTestBrowser(t)
+	var body []byte
+	var header http.Header
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	backend, _ := outbound.NewWebPushBackend(srv.Client(), createTestVAPIDKey(t), "mailto:ops@example.com", time.Hour)
+	message := outbound.PushMessage{ID: "msg-1", Title: "Your reservation res-001 is confirmed", URL: "/ui/reservations/res-001"}
+
+	// Act
+	err := backend.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), message)
+
+	// Assert
+	plaintext := browser.decrypt(t, body)
+	var received outbound.PushMessage
+	_ = json.Unmarshal(plaintext[:len(plaintext)-1], &received)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "record must end with the delimiter", plaintext[len(plaintext)-1], byte(0x02))
+	assert.That(t, "message must be decrypted", received, message)
+	assert.That(t, "record size must be announced", binary.BigEndian.Uint32(body[16:20]), uint32(4096))
+	assert.That(t, "content encoding must be aes128gcm", header.Get("Content-Encoding"), "aes128gcm")
+	assert.That(t, "TTL must be set", header.Get("TTL"), "3600")
+}
+
+func Test_WebPushBackend_Send_Should_Sign_VAPID_Token_For_Push_Service(t *testing.T) {
+	// Arrange
+	browser := newTestBrowser(t)
+	var authorization string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	backend, _ := outbound.NewWebPushBackend(srv.Client(), createTestVAPIDKey(t), "mailto:ops@example.com", time.Hour)
+
+	// Act
+	err := backend.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), outbound.PushMessage{ID: "msg-1"})
+
+	// Assert
+	token, key, _ := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	parts := strings.Split(token, ".")
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		Aud string `json:"aud"`
+		Sub string `json:"sub"`
+	}
+	_ = json.Unmarshal(claimsJSON, &claims)
+	publicRaw, _ := base64.RawURLEncoding.DecodeString(key)
+	publicKey, _ := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), publicRaw)
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	valid := ecdsa.Verify(publicKey, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "key must be the public VAPID key", key, backend.PublicKey())
+	assert.That(t, "audience must be the origin of the push service", claims.Aud, srv.URL)
+	assert.That(t, "subject must be the contact", claims.Sub, "mailto:ops@example.com")
+	assert.That(t, "signature must be valid", valid, true)
+}
+
+func Test_WebPushBackend_Send_To_Expired_Subscription_Should_Return_ErrPushSubscriptionGone(t *testing.T) {
+	// Arrange
+	browser := newTestBrowser(t)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+	backend, _ := outbound.NewWebPushBackend(srv.Client(), createTestVAPIDKey(t), "mailto:ops@example.com", time.Hour)
+
+	// Act
+	err := backend.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), outbound.PushMessage{ID: "msg-1"})
+
+	// Assert
+	assert.That(t, "err must be ErrPushSubscriptionGone", errors.Is(err, outbound.ErrPushSubscriptionGone), true)
+}
@@ -0,0 +1,57 @@
+package outbound
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// HTTPWebhookSender implements webhook.Sender by POSTing the payload as JSON.
+// The X-Webhook-Signature header has the format "t=<unix time>,v1=<hex HMAC-SHA256>"
+// over "<unix time>.<payload>" with the endpoint secret, so receivers can verify
+// the sender and reject replayed requests by their age.
+type HTTPWebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPWebhookSender creates a new webhook sender, e.g. with the "webhook" client of HTTPClients.
+func NewHTTPWebhookSender(client *http.Client) *HTTPWebhookSender {
+	return &HTTPWebhookSender{client: client, now: time.Now}
+}
+
+// Send POSTs the delivery and returns the response status code.
+// The delivery ID is sent as idempotency key, so the client retries temporary failures.
+func (s *HTTPWebhookSender) Send(ctx context.Context, endpoint *webhook.Endpoint, delivery *webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", string(delivery.ID))
+	req.Header.Set("X-Webhook-Delivery", string(delivery.ID))
+	req.Header.Set("X-Webhook-Event", delivery.EventID)
+	req.Header.Set("X-Webhook-Topic", delivery.Topic)
+	req.Header.Set("X-Webhook-Signature", WebhookSignature(endpoint.Secret, s.now(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// WebhookSignature returns the value of the X-Webhook-Signature header of the payload.
+func WebhookSignature(secret string, at time.Time, payload string) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(hmacSHA256([]byte(secret), timestamp+"."+payload))
+}
@@ -0,0 +1,73 @@
+package outbound_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/adapters/outbound"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// HTTPWebhookSender Tests
+// ============================================================================
+
+func Test_HTTPWebhookSender_Send_Should_Post_Signed_Payload(t *testing.T) {
+	// Arrange
+	var header http.Header
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	sender := outbound.NewHTTPWebhookSender(srv.Client())
+	endpoint := &webhook.Endpoint{ID: "ep-1", URL: srv.URL, Secret: "secret"}
+	delivery := &webhook.Delivery{ID: "d-1", EventID: "evt-1", Topic: "reservation.created", Payload: `{"id":"evt-1"}`}
+
+	// Act
+	status, err := sender.Send(context.Background(), endpoint, delivery)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "status must be 202", status, http.StatusAccepted)
+	assert.That(t, "body must be the payload", body, `{"id":"evt-1"}`)
+	assert.That(t, "topic header must be set", header.Get("X-Webhook-Topic"), "reservation.created")
+	assert.That(t, "idempotency key must be the delivery ID", header.Get("Idempotency-Key"), "d-1")
+	signature := header.Get("X-Webhook-Signature")
+	assert.That(t, "signature must have timestamp and MAC", strings.HasPrefix(signature, "t=") && strings.Contains(signature, ",v1="), true)
+}
+
+func Test_HTTPWebhookSender_Send_With_Unreachable_Endpoint_Should_Return_Error(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	sender := outbound.NewHTTPWebhookSender(http.DefaultClient)
+
+	// Act
+	status, err := sender.Send(context.Background(), &webhook.Endpoint{URL: url, Secret: "secret"}, &webhook.Delivery{ID: "d-1", Payload: `{}`})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "status must be 0", status, 0)
+}
+
+func Test_WebhookSignature_Should_Match_Known_Value(t *testing.T) {
+	// Arrange
+	at := time.Unix(1700000000, 0)
+
+	// Act
+	signature := outbound.WebhookSignature("secret", at, `{}`)
+
+	// Assert
+	assert.That(t, "signature must match", signature, "t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163")
+}
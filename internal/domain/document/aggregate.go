@@ -0,0 +1,136 @@
+// Package document contains the Document bounded context: the document wallet of a reservation.
+// Guests attach files such as visa letters or special requests, and the hotel provides files such as
+// registration forms. Files are checked against the limits and scanned before they are stored.
+package document
+
+import (
+	"errors"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DocumentID identifies a document, e.g. "doc_01J9Z3M5Q8X7T6V4R2N0B1C3D5".
+type DocumentID string
+
+// DocumentIDPrefix marks document IDs created by NewDocumentID.
+const DocumentIDPrefix = "doc_"
+
+// NewDocumentID returns a new, time-sortable document ID.
+func NewDocumentID() DocumentID {
+	return DocumentID(DocumentIDPrefix + shared.NewULID())
+}
+
+// ReservationID is the shared identifier of the reservation the document belongs to.
+type ReservationID = shared.ReservationID
+
+// Kind tells who provided the document.
+type Kind string
+
+const (
+	KindGuest Kind = "guest" // attached by the guest or a co-traveler
+	KindHotel Kind = "hotel" // provided by the hotel, e.g. a registration form
+)
+
+// ScanStatus is the result of the virus scan of the document.
+type ScanStatus string
+
+const (
+	ScanClean    ScanStatus = "clean"
+	ScanInfected ScanStatus = "infected" // never stored
+	ScanSkipped  ScanStatus = "skipped"  // no scanner is configured
+)
+
+// MaxNameLength limits the file name shown to guests and staff.
+const MaxNameLength = 120
+
+// Document errors.
+var (
+	ErrEmpty          = errors.New("document is empty")
+	ErrTooLarge       = errors.New("document exceeds the maximum size")
+	ErrTypeNotAllowed = errors.New("document type is not allowed")
+	ErrTooMany        = errors.New("reservation has the maximum number of documents")
+	ErrInfected       = errors.New("document failed the virus scan")
+	ErrScanFailed     = errors.New("document could not be scanned")
+	ErrNotFound       = errors.New("document not found")
+)
+
+// Document is a file attached to a reservation. The content is kept in the file store under Key.
+type Document struct {
+	ID            DocumentID    `json:"id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Kind          Kind          `json:"kind"`
+	Name          string        `json:"name"`
+	ContentType   string        `json:"content_type"`
+	Size          int64         `json:"size"`
+	Checksum      string        `json:"checksum"` // SHA-256 of the content, hex encoded
+	Key           string        `json:"key"`
+	Scan          ScanStatus    `json:"scan"`
+	UploadedBy    string        `json:"uploaded_by"` // guest ID, or "admin" for hotel documents
+	UploadedAt    time.Time     `json:"uploaded_at"`
+}
+
+// KeyFor returns the key of the document's content in the file store.
+// The keys of a reservation share a prefix, so its files can be listed and purged together.
+func KeyFor(reservationID ReservationID, id DocumentID) string {
+	return "documents/" + string(reservationID) + "/" + string(id)
+}
+
+// Limits restrict what guests and staff may upload.
+type Limits struct {
+	MaxSize           int64    // bytes per document
+	AllowedTypes      []string // media types, e.g. application/pdf
+	MaxPerReservation int
+}
+
+// DefaultLimits allow PDFs and photos up to 10 MiB, at most 20 per reservation.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxSize:           10 << 20,
+		AllowedTypes:      []string{"application/pdf", "image/jpeg", "image/png"},
+		MaxPerReservation: 20,
+	}
+}
+
+// Check validates the size and media type of a new document.
+func (l Limits) Check(contentType string, size int64) error {
+	switch {
+	case size == 0:
+		return ErrEmpty
+	case size > l.MaxSize:
+		return ErrTooLarge
+	case !slices.Contains(l.AllowedTypes, MediaType(contentType)):
+		return ErrTypeNotAllowed
+	}
+	return nil
+}
+
+// MediaType returns the content type without parameters in lower case, e.g. "text/plain" for
+// "text/plain; charset=utf-8".
+func MediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// CleanName returns the base name of an uploaded file without control characters,
+// shortened to MaxNameLength runes, or "document" if nothing is left.
+func CleanName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if runes := []rune(name); len(runes) > MaxNameLength {
+		name = string(runes[:MaxNameLength])
+	}
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	return name
+}
@@ -0,0 +1,57 @@
+package document_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+)
+
+// ============================================================================
+// Limits Tests
+// ============================================================================
+
+func Test_Limits_Check_Should_Accept_Allowed_Type_With_Parameters(t *testing.T) {
+	// Arrange
+	limits := document.DefaultLimits()
+
+	// Act
+	err := limits.Check("Application/PDF; charset=binary", 1024)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+}
+
+func Test_Limits_Check_Should_Reject_Empty_Large_And_Unknown_Files(t *testing.T) {
+	// Arrange
+	limits := document.DefaultLimits()
+
+	// Act
+	empty := limits.Check("application/pdf", 0)
+	large := limits.Check("application/pdf", limits.MaxSize+1)
+	unknown := limits.Check("application/x-msdownload", 1024)
+
+	// Assert
+	assert.That(t, "empty file must be rejected", empty, document.ErrEmpty)
+	assert.That(t, "large file must be rejected", large, document.ErrTooLarge)
+	assert.That(t, "unknown type must be rejected", unknown, document.ErrTypeNotAllowed)
+}
+
+// ============================================================================
+// CleanName Tests
+// ============================================================================
+
+func Test_CleanName_Should_Strip_Directories_And_Control_Characters(t *testing.T) {
+	// Arrange & Act
+	windows := document.CleanName(`C:\Users\guest\visa letter.pdf`)
+	traversal := document.CleanName("../../etc/passwd\r\n")
+	empty := document.CleanName("\x00")
+	long := document.CleanName(strings.Repeat("a", 200) + ".pdf")
+
+	// Assert
+	assert.That(t, "windows path must be reduced to the base name", windows, "visa letter.pdf")
+	assert.That(t, "traversal must be reduced to the base name", traversal, "passwd")
+	assert.That(t, "empty name must get a default", empty, "document")
+	assert.That(t, "long name must be shortened", len(long), document.MaxNameLength)
+}
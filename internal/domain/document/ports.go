@@ -0,0 +1,30 @@
+package document
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// DocumentRepository provides CRUD operations for the document metadata.
+type DocumentRepository resource.Access[DocumentID, Document]
+
+// FileStore keeps the content of the documents.
+// outbound.BlobStorage implements it.
+type FileStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// ScanResult is the verdict of a virus scanner.
+type ScanResult struct {
+	Status ScanStatus
+	Threat string // name of the threat found if infected
+}
+
+// VirusScanner scans a document before it is stored.
+// outbound.NoopVirusScanner skips the scan, outbound.HTTPVirusScanner calls a scanning service.
+type VirusScanner interface {
+	Scan(ctx context.Context, name string, data []byte) (ScanResult, error)
+}
@@ -0,0 +1,188 @@
+package document
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Upload is a file to attach to a reservation.
+type Upload struct {
+	ReservationID ReservationID
+	Kind          Kind
+	Name          string
+	ContentType   string // as detected from the content; the type declared by the client is not trusted
+	Data          []byte
+	UploadedBy    string
+}
+
+// Service handles the document wallet of the reservations.
+type Service struct {
+	documentRepo DocumentRepository
+	files        FileStore
+	scanner      VirusScanner
+	limits       Limits
+}
+
+// NewService creates a new document service.
+func NewService(repo DocumentRepository, files FileStore, scanner VirusScanner, limits Limits) *Service {
+	return &Service{
+		documentRepo: repo,
+		files:        files,
+		scanner:      scanner,
+		limits:       limits,
+	}
+}
+
+// Limits returns the limits uploads are checked against.
+func (s *Service) Limits() Limits {
+	return s.limits
+}
+
+// Upload checks the file against the limits, scans it and stores it with the reservation.
+// Infected files are rejected and never stored; a failing scanner rejects the upload, too.
+func (s *Service) Upload(ctx context.Context, u Upload) (*Document, error) {
+	if err := s.limits.Check(u.ContentType, int64(len(u.Data))); err != nil {
+		return nil, err
+	}
+	existing, err := s.List(ctx, u.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= s.limits.MaxPerReservation {
+		return nil, ErrTooMany
+	}
+
+	name := CleanName(u.Name)
+	result, err := s.scanner.Scan(ctx, name, u.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrScanFailed, err)
+	}
+	if result.Status == ScanInfected {
+		return nil, fmt.Errorf("%w: %s", ErrInfected, result.Threat)
+	}
+
+	sum := sha256.Sum256(u.Data)
+	id := NewDocumentID()
+	doc := Document{
+		ID:            id,
+		ReservationID: u.ReservationID,
+		Kind:          u.Kind,
+		Name:          name,
+		ContentType:   MediaType(u.ContentType),
+		Size:          int64(len(u.Data)),
+		Checksum:      hex.EncodeToString(sum[:]),
+		Key:           KeyFor(u.ReservationID, id),
+		Scan:          result.Status,
+		UploadedBy:    u.UploadedBy,
+		UploadedAt:    time.Now(),
+	}
+	if err := s.files.Put(ctx, doc.Key, u.Data); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+	if err := s.documentRepo.Create(ctx, doc.ID, doc); err != nil {
+		_ = s.files.Delete(ctx, doc.Key)
+		return nil, fmt.Errorf("failed to persist document: %w", err)
+	}
+	return &doc, nil
+}
+
+// List returns the documents of the reservation, oldest first.
+func (s *Service) List(ctx context.Context, reservationID ReservationID) ([]Document, error) {
+	all, err := s.documentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	var docs []Document
+	for _, doc := range all {
+		if doc.ReservationID == reservationID {
+			docs = append(docs, doc)
+		}
+	}
+	slices.SortFunc(docs, func(a, b Document) int {
+		return cmp.Or(a.UploadedAt.Compare(b.UploadedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return docs, nil
+}
+
+// Get returns a document of the reservation. Documents of other reservations are not found,
+// so access to a reservation grants access to its documents only.
+func (s *Service) Get(ctx context.Context, reservationID ReservationID, id DocumentID) (*Document, error) {
+	doc, err := s.documentRepo.Read(ctx, id)
+	if err != nil || doc.ReservationID != reservationID {
+		return nil, ErrNotFound
+	}
+	return doc, nil
+}
+
+// Open returns a document of the reservation with its content.
+func (s *Service) Open(ctx context.Context, reservationID ReservationID, id DocumentID) (*Document, []byte, error) {
+	doc, err := s.Get(ctx, reservationID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := s.files.Get(ctx, doc.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read document: %w", err)
+	}
+	return doc, data, nil
+}
+
+// Delete removes a document of the reservation and its content.
+func (s *Service) Delete(ctx context.Context, reservationID ReservationID, id DocumentID) error {
+	doc, err := s.Get(ctx, reservationID, id)
+	if err != nil {
+		return err
+	}
+	return s.delete(ctx, *doc)
+}
+
+// Purge removes all documents of the reservation and their content, e.g. when the guest's data is
+// erased or the retention period ends. It returns the number of documents removed.
+func (s *Service) Purge(ctx context.Context, reservationID ReservationID) (int, error) {
+	docs, err := s.List(ctx, reservationID)
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	purged := 0
+	for _, doc := range docs {
+		if err := s.delete(ctx, doc); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// Reservations returns the IDs of the reservations with documents, sorted.
+func (s *Service) Reservations(ctx context.Context) ([]ReservationID, error) {
+	all, err := s.documentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	var ids []ReservationID
+	for _, doc := range all {
+		ids = append(ids, doc.ReservationID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// delete removes the content before the metadata, so a failure leaves the document listed
+// and the next purge retries it instead of orphaning the file.
+func (s *Service) delete(ctx context.Context, doc Document) error {
+	if err := s.files.Delete(ctx, doc.Key); err != nil {
+		return fmt.Errorf("failed to delete document content: %w", err)
+	}
+	if err := s.documentRepo.Delete(ctx, doc.ID); err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package document_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/document"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockFileStore struct {
+	files map[string][]byte
+}
+
+func (m *mockFileStore) Put(ctx context.Context, key string, data []byte) error {
+	m.files[key] = data
+	return nil
+}
+
+func (m *mockFileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func (m *mockFileStore) Delete(ctx context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+type mockVirusScanner struct {
+	result document.ScanResult
+	err    error
+}
+
+func (m *mockVirusScanner) Scan(ctx context.Context, name string, data []byte) (document.ScanResult, error) {
+	return m.result, m.err
+}
+
+func createTestDocumentService(scanner *mockVirusScanner) (*document.Service, *mockFileStore) {
+	files := &mockFileStore{files: make(map[string][]byte)}
+	repo := resource.NewInMemoryAccess[document.DocumentID, document.Document]()
+	return document.NewService(repo, files, scanner, document.DefaultLimits()), files
+}
+
+func visaLetter(reservationID document.ReservationID) document.Upload {
+	return document.Upload{
+		ReservationID: reservationID,
+		Kind:          document.KindGuest,
+		Name:          "visa-letter.pdf",
+		ContentType:   "application/pdf",
+		Data:          []byte("%PDF-1.7 visa letter"),
+		UploadedBy:    "guest-001",
+	}
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_Upload_Should_Store_Content_And_Metadata(t *testing.T) {
+	// Arrange
+	svc, files := createTestDocumentService(&mockVirusScanner{result: document.ScanResult{Status: document.ScanClean}})
+	ctx := context.Background()
+
+	// Act
+	doc, err := svc.Upload(ctx, visaLetter("res-001"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "scan status must be recorded", doc.Scan, document.ScanClean)
+	assert.That(t, "key must be below the reservation", doc.Key, "documents/res-001/"+string(doc.ID))
+	assert.That(t, "content must be stored", string(files.files[doc.Key]), "%PDF-1.7 visa letter")
+	assert.That(t, "checksum must be the SHA-256", len(doc.Checksum), 64)
+}
+
+func Test_Service_Upload_Infected_Should_Not_Store(t *testing.T) {
+	// Arrange
+	svc, files := createTestDocumentService(&mockVirusScanner{result: document.ScanResult{Status: document.ScanInfected, Threat: "Eicar-Test-Signature"}})
+
+	// Act
+	_, err := svc.Upload(context.Background(), visaLetter("res-001"))
+
+	// Assert
+	assert.That(t, "err must be ErrInfected", errors.Is(err, document.ErrInfected), true)
+	assert.That(t, "nothing must be stored", len(files.files), 0)
+}
+
+func Test_Service_Upload_With_Failing_Scanner_Should_Reject(t *testing.T) {
+	// Arrange
+	svc, files := createTestDocumentService(&mockVirusScanner{err: errors.New("connection refused")})
+
+	// Act
+	_, err := svc.Upload(context.Background(), visaLetter("res-001"))
+
+	// Assert
+	assert.That(t, "err must be ErrScanFailed", errors.Is(err, document.ErrScanFailed), true)
+	assert.That(t, "nothing must be stored", len(files.files), 0)
+}
+
+func Test_Service_Upload_Beyond_Maximum_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestDocumentService(&mockVirusScanner{result: document.ScanResult{Status: document.ScanSkipped}})
+	ctx := context.Background()
+	for range document.DefaultLimits().MaxPerReservation {
+		_, _ = svc.Upload(ctx, visaLetter("res-001"))
+	}
+
+	// Act
+	_, err := svc.Upload(ctx, visaLetter("res-001"))
+	_, otherErr := svc.Upload(ctx, visaLetter("res-002"))
+
+	// Assert
+	assert.That(t, "err must be ErrTooMany", err, document.ErrTooMany)
+	assert.That(t, "other reservations must not count", otherErr, nil)
+}
+
+func Test_Service_Open_Of_Other_Reservation_Should_Not_Be_Found(t *testing.T) {
+	// Arrange
+	svc, _ := createTestDocumentService(&mockVirusScanner{result: document.ScanResult{Status: document.ScanSkipped}})
+	ctx := context.Background()
+	doc, _ := svc.Upload(ctx, visaLetter("res-001"))
+
+	// Act
+	_, data, err := svc.Open(ctx, "res-001", doc.ID)
+	_, _, otherErr := svc.Open(ctx, "res-002", doc.ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "content must be returned", string(data), "%PDF-1.7 visa letter")
+	assert.That(t, "document of other reservation must not be found", otherErr, document.ErrNotFound)
+}
+
+func Test_Service_Purge_Should_Remove_Documents_Of_Reservation_Only(t *testing.T) {
+	// Arrange
+	svc, files := createTestDocumentService(&mockVirusScanner{result: document.ScanResult{Status: document.ScanSkipped}})
+	ctx := context.Background()
+	_, _ = svc.Upload(ctx, visaLetter("res-001"))
+	_, _ = svc.Upload(ctx, visaLetter("res-001"))
+	_, _ = svc.Upload(ctx, visaLetter("res-002"))
+
+	// Act
+	purged, err := svc.Purge(ctx, "res-001")
+	remaining, _ := svc.Reservations(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "both documents must be purged", purged, 2)
+	assert.That(t, "content must be deleted", len(files.files), 1)
+	assert.That(t, "only the other reservation must have documents", remaining, []document.ReservationID{"res-002"})
+}
@@ -0,0 +1,240 @@
+// Package household contains the Household bounded context.
+// A household (family or organization) groups guest accounts whose members
+// may see and manage each other's reservations according to their role.
+package household
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Local ID types for this bounded context
+type HouseholdID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// MemberRole defines what a member may do in the household.
+type MemberRole string
+
+const (
+	RoleAdmin   MemberRole = "admin"   // manages members and all members' reservations
+	RoleManager MemberRole = "manager" // sees and manages all members' reservations
+	RoleViewer  MemberRole = "viewer"  // sees all members' reservations
+)
+
+// Member is a guest account belonging to the household (entity within Household aggregate).
+type Member struct {
+	GuestID  GuestID
+	Email    string
+	Role     MemberRole
+	JoinedAt time.Time
+}
+
+// Invitation is a pending membership for an email address (entity within Household aggregate).
+type Invitation struct {
+	Email     string
+	Role      MemberRole
+	CreatedAt time.Time
+}
+
+// Household is the aggregate root for grouped guest accounts.
+type Household struct {
+	ID          HouseholdID
+	Name        string
+	Members     []Member
+	Invitations []Invitation
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validation errors.
+var (
+	ErrInvalidName            = errors.New("household name required")
+	ErrInvalidRole            = errors.New("member role must be admin, manager or viewer")
+	ErrInvalidEmail           = errors.New("invitation email required")
+	ErrAlreadyMember          = errors.New("guest is already a household member")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrMemberNotFound         = errors.New("member not found")
+	ErrLastAdmin              = errors.New("household needs at least one admin")
+	ErrAdminOnly              = errors.New("operation requires a household admin")
+	ErrNotHouseholdMember     = errors.New("guest is not a household member")
+	ErrMemberOfOtherHousehold = errors.New("guest already belongs to another household")
+)
+
+// NewHousehold creates a new household with the creator as admin.
+func NewHousehold(id HouseholdID, name string, creator GuestID, email string) (*Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	now := time.Now()
+	return &Household{
+		ID:        id,
+		Name:      name,
+		Members:   []Member{{GuestID: creator, Email: email, Role: RoleAdmin, JoinedAt: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Member returns the member of the guest account.
+func (h *Household) Member(guestID GuestID) (Member, bool) {
+	i := h.memberIndex(guestID)
+	if i < 0 {
+		return Member{}, false
+	}
+	return h.Members[i], true
+}
+
+// IsAdmin checks if the guest account is an admin of the household.
+func (h *Household) IsAdmin(guestID GuestID) bool {
+	m, ok := h.Member(guestID)
+	return ok && m.Role == RoleAdmin
+}
+
+// CanAccess checks if the viewer may see (or, if manage is set, manage) reservations of the owner.
+// Both must be members; viewers may only see.
+func (h *Household) CanAccess(viewer, owner GuestID, manage bool) bool {
+	v, ok := h.Member(viewer)
+	if !ok {
+		return false
+	}
+	if _, ok := h.Member(owner); !ok {
+		return false
+	}
+	return !manage || v.Role != RoleViewer
+}
+
+// MemberIDs returns the guest accounts of all members.
+func (h *Household) MemberIDs() []GuestID {
+	ids := make([]GuestID, 0, len(h.Members))
+	for _, m := range h.Members {
+		ids = append(ids, m.GuestID)
+	}
+	return ids
+}
+
+// Invite adds a pending invitation for the email. Inviting again changes the role.
+func (h *Household) Invite(email string, role MemberRole) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return ErrInvalidEmail
+	}
+	if !validRole(role) {
+		return ErrInvalidRole
+	}
+	for _, m := range h.Members {
+		if strings.EqualFold(m.Email, email) {
+			return ErrAlreadyMember
+		}
+	}
+
+	if i := h.invitationIndex(email); i >= 0 {
+		h.Invitations[i].Role = role
+	} else {
+		h.Invitations = append(h.Invitations, Invitation{Email: email, Role: role, CreatedAt: time.Now()})
+	}
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasInvitation checks if there is a pending invitation for the email.
+func (h *Household) HasInvitation(email string) bool {
+	return h.invitationIndex(email) >= 0
+}
+
+// Invitation returns the pending invitation for the email.
+func (h *Household) Invitation(email string) (Invitation, bool) {
+	i := h.invitationIndex(email)
+	if i < 0 {
+		return Invitation{}, false
+	}
+	return h.Invitations[i], true
+}
+
+// Accept turns the invitation of the email into a membership of the guest account.
+func (h *Household) Accept(email string, guestID GuestID) error {
+	i := h.invitationIndex(email)
+	if i < 0 {
+		return ErrInvitationNotFound
+	}
+	if h.memberIndex(guestID) >= 0 {
+		return ErrAlreadyMember
+	}
+	inv := h.Invitations[i]
+	h.Invitations = slices.Delete(h.Invitations, i, i+1)
+	h.Members = append(h.Members, Member{GuestID: guestID, Email: email, Role: inv.Role, JoinedAt: time.Now()})
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+// CancelInvitation removes the pending invitation of the email.
+func (h *Household) CancelInvitation(email string) error {
+	i := h.invitationIndex(email)
+	if i < 0 {
+		return ErrInvitationNotFound
+	}
+	h.Invitations = slices.Delete(h.Invitations, i, i+1)
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetRole changes the role of a member. The last admin cannot be demoted.
+func (h *Household) SetRole(guestID GuestID, role MemberRole) error {
+	if !validRole(role) {
+		return ErrInvalidRole
+	}
+	i := h.memberIndex(guestID)
+	if i < 0 {
+		return ErrMemberNotFound
+	}
+	if h.Members[i].Role == RoleAdmin && role != RoleAdmin && h.adminCount() == 1 {
+		return ErrLastAdmin
+	}
+	h.Members[i].Role = role
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+// RemoveMember removes a member. The last admin cannot leave while other members remain.
+func (h *Household) RemoveMember(guestID GuestID) error {
+	i := h.memberIndex(guestID)
+	if i < 0 {
+		return ErrMemberNotFound
+	}
+	if h.Members[i].Role == RoleAdmin && h.adminCount() == 1 && len(h.Members) > 1 {
+		return ErrLastAdmin
+	}
+	h.Members = slices.Delete(h.Members, i, i+1)
+	h.UpdatedAt = time.Now()
+	return nil
+}
+
+func (h *Household) memberIndex(guestID GuestID) int {
+	if guestID == "" {
+		return -1
+	}
+	return slices.IndexFunc(h.Members, func(m Member) bool { return m.GuestID == guestID })
+}
+
+func (h *Household) invitationIndex(email string) int {
+	email = strings.TrimSpace(email)
+	return slices.IndexFunc(h.Invitations, func(inv Invitation) bool { return strings.EqualFold(inv.Email, email) })
+}
+
+func (h *Household) adminCount() int {
+	count := 0
+	for _, m := range h.Members {
+		if m.Role == RoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
+func validRole(role MemberRole) bool {
+	return role == RoleAdmin || role == RoleManager || role == RoleViewer
+}
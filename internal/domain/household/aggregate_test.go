@@ -0,0 +1,162 @@
+package household_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createValidHousehold(t *testing.T) *household.Household {
+	t.Helper()
+	h, err := household.NewHousehold("hh-001", "Doe Family", "sub-admin", "admin@example.com")
+	if err != nil {
+		t.Fatalf("failed to create valid household: %v", err)
+	}
+	return h
+}
+
+func addMember(t *testing.T, h *household.Household, guestID household.GuestID, email string, role household.MemberRole) {
+	t.Helper()
+	if err := h.Invite(email, role); err != nil {
+		t.Fatalf("failed to invite member: %v", err)
+	}
+	if err := h.Accept(email, guestID); err != nil {
+		t.Fatalf("failed to accept invitation: %v", err)
+	}
+}
+
+// ============================================================================
+// NewHousehold Tests
+// ============================================================================
+
+func Test_NewHousehold_Should_Make_Creator_Admin(t *testing.T) {
+	// Arrange & Act
+	h := createValidHousehold(t)
+
+	// Assert
+	assert.That(t, "creator must be admin", h.IsAdmin("sub-admin"), true)
+	assert.That(t, "household must have one member", len(h.Members), 1)
+}
+
+func Test_NewHousehold_With_Empty_Name_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := household.NewHousehold("hh-001", "  ", "sub-admin", "admin@example.com")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidName", err, household.ErrInvalidName)
+}
+
+// ============================================================================
+// Invitation Tests
+// ============================================================================
+
+func Test_Household_Accept_Should_Add_Member_With_Invited_Role(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+
+	// Act
+	addMember(t, h, "sub-kid", "Kid@Example.com", household.RoleViewer)
+
+	// Assert
+	m, ok := h.Member("sub-kid")
+	assert.That(t, "guest must be a member", ok, true)
+	assert.That(t, "role must be viewer", m.Role, household.RoleViewer)
+	assert.That(t, "invitation must be consumed", len(h.Invitations), 0)
+}
+
+func Test_Household_Invite_With_Invalid_Role_Should_Return_Error(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+
+	// Act
+	err := h.Invite("kid@example.com", "owner")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidRole", err, household.ErrInvalidRole)
+}
+
+func Test_Household_Invite_Existing_Member_Should_Return_Error(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+
+	// Act
+	err := h.Invite("ADMIN@example.com", household.RoleViewer)
+
+	// Assert
+	assert.That(t, "error must be ErrAlreadyMember", err, household.ErrAlreadyMember)
+}
+
+func Test_Household_Accept_Without_Invitation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+
+	// Act
+	err := h.Accept("stranger@example.com", "sub-stranger")
+
+	// Assert
+	assert.That(t, "error must be ErrInvitationNotFound", err, household.ErrInvitationNotFound)
+}
+
+// ============================================================================
+// Access Tests
+// ============================================================================
+
+func Test_Household_CanAccess_Should_Respect_Roles(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+	addMember(t, h, "sub-partner", "partner@example.com", household.RoleManager)
+	addMember(t, h, "sub-kid", "kid@example.com", household.RoleViewer)
+
+	// Act & Assert
+	assert.That(t, "viewer may see", h.CanAccess("sub-kid", "sub-admin", false), true)
+	assert.That(t, "viewer may not manage", h.CanAccess("sub-kid", "sub-admin", true), false)
+	assert.That(t, "manager may manage", h.CanAccess("sub-partner", "sub-kid", true), true)
+	assert.That(t, "outsider may not see", h.CanAccess("sub-stranger", "sub-admin", false), false)
+	assert.That(t, "non-member reservations are not shared", h.CanAccess("sub-admin", "sub-stranger", false), false)
+}
+
+// ============================================================================
+// Membership Management Tests
+// ============================================================================
+
+func Test_Household_SetRole_Of_Last_Admin_Should_Return_Error(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+
+	// Act
+	err := h.SetRole("sub-admin", household.RoleViewer)
+
+	// Assert
+	assert.That(t, "error must be ErrLastAdmin", err, household.ErrLastAdmin)
+}
+
+func Test_Household_RemoveMember_Last_Admin_With_Members_Should_Return_Error(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+	addMember(t, h, "sub-kid", "kid@example.com", household.RoleViewer)
+
+	// Act
+	err := h.RemoveMember("sub-admin")
+
+	// Assert
+	assert.That(t, "error must be ErrLastAdmin", err, household.ErrLastAdmin)
+}
+
+func Test_Household_RemoveMember_Should_Remove_Member(t *testing.T) {
+	// Arrange
+	h := createValidHousehold(t)
+	addMember(t, h, "sub-kid", "kid@example.com", household.RoleViewer)
+
+	// Act
+	err := h.RemoveMember("sub-kid")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	_, ok := h.Member("sub-kid")
+	assert.That(t, "guest must no longer be a member", ok, false)
+}
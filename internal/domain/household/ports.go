@@ -0,0 +1,8 @@
+package household
+
+import (
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// HouseholdRepository provides CRUD operations for households.
+type HouseholdRepository resource.Access[HouseholdID, Household]
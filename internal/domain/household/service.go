@@ -0,0 +1,141 @@
+package household
+
+import (
+	"context"
+	"fmt"
+)
+
+// Service handles household workflows.
+// A guest account belongs to at most one household.
+type Service struct {
+	householdRepo HouseholdRepository
+}
+
+// NewService creates a new household service.
+func NewService(repo HouseholdRepository) *Service {
+	return &Service{
+		householdRepo: repo,
+	}
+}
+
+// CreateHousehold creates a new household with the guest as admin.
+func (s *Service) CreateHousehold(ctx context.Context, id HouseholdID, name string, guestID GuestID, email string) (*Household, error) {
+	if existing, err := s.HouseholdOf(ctx, guestID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, ErrMemberOfOtherHousehold
+	}
+
+	household, err := NewHousehold(id, name, guestID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create household: %w", err)
+	}
+	if err := s.householdRepo.Create(ctx, id, *household); err != nil {
+		return nil, fmt.Errorf("failed to persist household: %w", err)
+	}
+	return household, nil
+}
+
+// HouseholdOf returns the household of the guest account, or nil if the guest is not a member.
+func (s *Service) HouseholdOf(ctx context.Context, guestID GuestID) (*Household, error) {
+	households, err := s.householdRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list households: %w", err)
+	}
+	for i := range households {
+		if _, ok := households[i].Member(guestID); ok {
+			return &households[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// InvitationsFor returns the households with a pending invitation for the email.
+func (s *Service) InvitationsFor(ctx context.Context, email string) ([]*Household, error) {
+	households, err := s.householdRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list households: %w", err)
+	}
+	var invited []*Household
+	for i := range households {
+		if email != "" && households[i].HasInvitation(email) {
+			invited = append(invited, &households[i])
+		}
+	}
+	return invited, nil
+}
+
+// Invite invites an email to the household. Only admins may invite.
+func (s *Service) Invite(ctx context.Context, id HouseholdID, admin GuestID, email string, role MemberRole) (*Household, error) {
+	return s.update(ctx, id, func(h *Household) error {
+		if !h.IsAdmin(admin) {
+			return ErrAdminOnly
+		}
+		return h.Invite(email, role)
+	})
+}
+
+// AcceptInvitation makes the guest account a member of the household the email was invited to.
+func (s *Service) AcceptInvitation(ctx context.Context, id HouseholdID, email string, guestID GuestID) (*Household, error) {
+	if existing, err := s.HouseholdOf(ctx, guestID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, ErrMemberOfOtherHousehold
+	}
+	return s.update(ctx, id, func(h *Household) error { return h.Accept(email, guestID) })
+}
+
+// CancelInvitation removes a pending invitation. Only admins may cancel invitations.
+func (s *Service) CancelInvitation(ctx context.Context, id HouseholdID, admin GuestID, email string) (*Household, error) {
+	return s.update(ctx, id, func(h *Household) error {
+		if !h.IsAdmin(admin) {
+			return ErrAdminOnly
+		}
+		return h.CancelInvitation(email)
+	})
+}
+
+// SetMemberRole changes the role of a member. Only admins may change roles.
+func (s *Service) SetMemberRole(ctx context.Context, id HouseholdID, admin, member GuestID, role MemberRole) (*Household, error) {
+	return s.update(ctx, id, func(h *Household) error {
+		if !h.IsAdmin(admin) {
+			return ErrAdminOnly
+		}
+		return h.SetRole(member, role)
+	})
+}
+
+// RemoveMember removes a member. Admins may remove anyone; members may only leave themselves.
+// The household is deleted when its last member leaves.
+func (s *Service) RemoveMember(ctx context.Context, id HouseholdID, actor, member GuestID) (*Household, error) {
+	household, err := s.update(ctx, id, func(h *Household) error {
+		if actor != member && !h.IsAdmin(actor) {
+			return ErrAdminOnly
+		}
+		return h.RemoveMember(member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(household.Members) == 0 {
+		if err := s.householdRepo.Delete(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to delete household: %w", err)
+		}
+	}
+	return household, nil
+}
+
+// update loads a household, applies a change and persists it.
+func (s *Service) update(ctx context.Context, id HouseholdID, change func(h *Household) error) (*Household, error) {
+	household, err := s.householdRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read household: %w", err)
+	}
+	if err := change(household); err != nil {
+		return nil, fmt.Errorf("failed to update household: %w", err)
+	}
+	if err := s.householdRepo.Update(ctx, id, *household); err != nil {
+		return nil, fmt.Errorf("failed to update household: %w", err)
+	}
+	return household, nil
+}
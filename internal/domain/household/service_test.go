@@ -0,0 +1,148 @@
+package household_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/household"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockHouseholdRepository struct {
+	households map[household.HouseholdID]household.Household
+}
+
+func newMockHouseholdRepository() *mockHouseholdRepository {
+	return &mockHouseholdRepository{
+		households: make(map[household.HouseholdID]household.Household),
+	}
+}
+
+func (m *mockHouseholdRepository) Create(ctx context.Context, id household.HouseholdID, h household.Household) error {
+	m.households[id] = h
+	return nil
+}
+
+func (m *mockHouseholdRepository) Read(ctx context.Context, id household.HouseholdID) (*household.Household, error) {
+	h, ok := m.households[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &h, nil
+}
+
+func (m *mockHouseholdRepository) Update(ctx context.Context, id household.HouseholdID, h household.Household) error {
+	m.households[id] = h
+	return nil
+}
+
+func (m *mockHouseholdRepository) Delete(ctx context.Context, id household.HouseholdID) error {
+	delete(m.households, id)
+	return nil
+}
+
+func (m *mockHouseholdRepository) ReadAll(ctx context.Context) ([]household.Household, error) {
+	result := make([]household.Household, 0, len(m.households))
+	for _, h := range m.households {
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_HouseholdOf_Without_Membership_Should_Return_Nil(t *testing.T) {
+	// Arrange
+	svc := household.NewService(newMockHouseholdRepository())
+
+	// Act
+	h, err := svc.HouseholdOf(context.Background(), "sub-stranger")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "household must be nil", h == nil, true)
+}
+
+func Test_Service_Invite_And_Accept_Should_Add_Member(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := household.NewService(newMockHouseholdRepository())
+	_, _ = svc.CreateHousehold(ctx, "hh-001", "Doe Family", "sub-admin", "admin@example.com")
+
+	// Act
+	_, inviteErr := svc.Invite(ctx, "hh-001", "sub-admin", "kid@example.com", household.RoleViewer)
+	invited, _ := svc.InvitationsFor(ctx, "kid@example.com")
+	_, acceptErr := svc.AcceptInvitation(ctx, "hh-001", "kid@example.com", "sub-kid")
+
+	// Assert
+	assert.That(t, "invite err must be nil", inviteErr == nil, true)
+	assert.That(t, "invitation must be listed", len(invited), 1)
+	assert.That(t, "accept err must be nil", acceptErr == nil, true)
+	h, _ := svc.HouseholdOf(ctx, "sub-kid")
+	assert.That(t, "guest must belong to the household", h != nil && h.ID == "hh-001", true)
+}
+
+func Test_Service_Invite_By_Non_Admin_Should_Return_ErrAdminOnly(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := household.NewService(newMockHouseholdRepository())
+	_, _ = svc.CreateHousehold(ctx, "hh-001", "Doe Family", "sub-admin", "admin@example.com")
+	_, _ = svc.Invite(ctx, "hh-001", "sub-admin", "partner@example.com", household.RoleManager)
+	_, _ = svc.AcceptInvitation(ctx, "hh-001", "partner@example.com", "sub-partner")
+
+	// Act
+	_, err := svc.Invite(ctx, "hh-001", "sub-partner", "kid@example.com", household.RoleViewer)
+
+	// Assert
+	assert.That(t, "error must be ErrAdminOnly", errors.Is(err, household.ErrAdminOnly), true)
+}
+
+func Test_Service_CreateHousehold_When_Already_Member_Should_Return_Error(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := household.NewService(newMockHouseholdRepository())
+	_, _ = svc.CreateHousehold(ctx, "hh-001", "Doe Family", "sub-admin", "admin@example.com")
+
+	// Act
+	_, err := svc.CreateHousehold(ctx, "hh-002", "Work", "sub-admin", "admin@example.com")
+
+	// Assert
+	assert.That(t, "error must be ErrMemberOfOtherHousehold", errors.Is(err, household.ErrMemberOfOtherHousehold), true)
+}
+
+func Test_Service_RemoveMember_Last_Member_Should_Delete_Household(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := newMockHouseholdRepository()
+	svc := household.NewService(repo)
+	_, _ = svc.CreateHousehold(ctx, "hh-001", "Doe Family", "sub-admin", "admin@example.com")
+
+	// Act
+	_, err := svc.RemoveMember(ctx, "hh-001", "sub-admin", "sub-admin")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "household must be deleted", len(repo.households), 0)
+}
+
+func Test_Service_RemoveMember_Other_Member_By_Non_Admin_Should_Return_ErrAdminOnly(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := household.NewService(newMockHouseholdRepository())
+	_, _ = svc.CreateHousehold(ctx, "hh-001", "Doe Family", "sub-admin", "admin@example.com")
+	_, _ = svc.Invite(ctx, "hh-001", "sub-admin", "kid@example.com", household.RoleViewer)
+	_, _ = svc.AcceptInvitation(ctx, "hh-001", "kid@example.com", "sub-kid")
+
+	// Act
+	_, err := svc.RemoveMember(ctx, "hh-001", "sub-kid", "sub-admin")
+
+	// Assert
+	assert.That(t, "error must be ErrAdminOnly", errors.Is(err, household.ErrAdminOnly), true)
+}
@@ -0,0 +1,150 @@
+// Package incident contains the Incident bounded context.
+// Ops curate the incidents shown on the public status page: which components of the hotel
+// are affected, how badly, and the updates posted until the incident is resolved.
+package incident
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Local ID types for this bounded context
+type IncidentID string
+
+// Component is a part of the hotel that guests notice, as shown on the status page.
+type Component string
+
+const (
+	ComponentBookingEngine Component = "booking_engine"
+	ComponentPayments      Component = "payments"
+	ComponentNotifications Component = "notifications"
+)
+
+// Components are the components of the status page in the order they are shown.
+var Components = []Component{ComponentBookingEngine, ComponentPayments, ComponentNotifications}
+
+// ComponentStatus is how well a component works, from operational to a major outage.
+// It is also the impact of an incident on the components it affects.
+type ComponentStatus string
+
+const (
+	StatusOperational ComponentStatus = "operational"
+	StatusDegraded    ComponentStatus = "degraded_performance"
+	StatusPartial     ComponentStatus = "partial_outage"
+	StatusMajor       ComponentStatus = "major_outage"
+)
+
+// severity orders the component states; unknown states count as operational.
+var severity = map[ComponentStatus]int{StatusOperational: 0, StatusDegraded: 1, StatusPartial: 2, StatusMajor: 3}
+
+// Worse returns the more severe of both states.
+func (s ComponentStatus) Worse(other ComponentStatus) ComponentStatus {
+	if severity[other] > severity[s] {
+		return other
+	}
+	return s
+}
+
+// Stage is where the work on an incident stands.
+type Stage string
+
+const (
+	StageInvestigating Stage = "investigating"
+	StageIdentified    Stage = "identified"
+	StageMonitoring    Stage = "monitoring"
+	StageResolved      Stage = "resolved"
+)
+
+// Update is a message posted on an incident, e.g. "Payments are processed again, we are monitoring".
+type Update struct {
+	Stage   Stage
+	Message string
+	At      time.Time
+}
+
+// Incident is the aggregate root for a disruption announced on the status page.
+// Everything on it is public; internal details belong in the runbooks, not here.
+type Incident struct {
+	ID         IncidentID
+	Title      string
+	Impact     ComponentStatus
+	Components []Component
+	Stage      Stage
+	Updates    []Update // oldest first
+	StartedAt  time.Time
+	ResolvedAt time.Time // zero while the incident is active
+}
+
+// Validation errors.
+var (
+	ErrMissingTitle     = errors.New("incident title required")
+	ErrMissingMessage   = errors.New("incident message required")
+	ErrInvalidImpact    = errors.New("impact must be degraded_performance, partial_outage or major_outage")
+	ErrUnknownComponent = errors.New("unknown status page component")
+	ErrNoComponents     = errors.New("incident must affect at least one component")
+	ErrInvalidStage     = errors.New("stage must be investigating, identified, monitoring or resolved")
+	ErrAlreadyResolved  = errors.New("incident already resolved")
+	ErrIncidentNotFound = errors.New("incident not found")
+)
+
+// NewIncident opens an incident affecting the components, with the message as its first update.
+func NewIncident(id IncidentID, title, message string, impact ComponentStatus, components []Component, at time.Time) (*Incident, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrMissingTitle
+	}
+	if severity[impact] == 0 {
+		return nil, ErrInvalidImpact
+	}
+	if len(components) == 0 {
+		return nil, ErrNoComponents
+	}
+	affected := make([]Component, 0, len(components))
+	for _, c := range components {
+		if !slices.Contains(Components, c) {
+			return nil, ErrUnknownComponent
+		}
+		if !slices.Contains(affected, c) {
+			affected = append(affected, c)
+		}
+	}
+	i := &Incident{ID: id, Title: title, Impact: impact, Components: affected, StartedAt: at}
+	if err := i.Post(StageInvestigating, message, at); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// Post adds an update and moves the incident to its stage; posting the resolved stage resolves it.
+func (i *Incident) Post(stage Stage, message string, at time.Time) error {
+	if i.IsResolved() {
+		return ErrAlreadyResolved
+	}
+	switch stage {
+	case StageInvestigating, StageIdentified, StageMonitoring, StageResolved:
+	default:
+		return ErrInvalidStage
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return ErrMissingMessage
+	}
+	i.Stage = stage
+	i.Updates = append(i.Updates, Update{Stage: stage, Message: message, At: at})
+	if stage == StageResolved {
+		i.ResolvedAt = at
+	}
+	return nil
+}
+
+// IsResolved reports whether the incident is over.
+func (i *Incident) IsResolved() bool {
+	return i.Stage == StageResolved
+}
+
+// Affects reports whether the incident affects the component.
+func (i *Incident) Affects(c Component) bool {
+	return slices.Contains(i.Components, c)
+}
@@ -0,0 +1,94 @@
+package incident_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+)
+
+// ============================================================================
+// NewIncident Tests
+// ============================================================================
+
+func Test_NewIncident_Should_Start_Investigating(t *testing.T) {
+	// Arrange
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// Act
+	i, err := incident.NewIncident("inc-001", " Payments delayed ", "We are looking into it", incident.StatusDegraded,
+		[]incident.Component{incident.ComponentPayments, incident.ComponentPayments}, at)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "title must be trimmed", i.Title, "Payments delayed")
+	assert.That(t, "stage must be investigating", i.Stage, incident.StageInvestigating)
+	assert.That(t, "message must be the first update", i.Updates[0].Message, "We are looking into it")
+	assert.That(t, "components must be listed once", len(i.Components), 1)
+}
+
+func Test_NewIncident_With_Operational_Impact_Should_Fail(t *testing.T) {
+	// Arrange
+	components := []incident.Component{incident.ComponentPayments}
+
+	// Act
+	_, err := incident.NewIncident("inc-001", "Payments delayed", "Looking into it", incident.StatusOperational, components, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidImpact", err, incident.ErrInvalidImpact)
+}
+
+func Test_NewIncident_With_Unknown_Component_Should_Fail(t *testing.T) {
+	// Arrange
+	components := []incident.Component{"postgres"}
+
+	// Act
+	_, err := incident.NewIncident("inc-001", "Database down", "Looking into it", incident.StatusMajor, components, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownComponent", err, incident.ErrUnknownComponent)
+}
+
+// ============================================================================
+// Post Tests
+// ============================================================================
+
+func Test_Incident_Post_Resolved_Should_Resolve(t *testing.T) {
+	// Arrange
+	i, _ := incident.NewIncident("inc-001", "Payments delayed", "Looking into it", incident.StatusDegraded, []incident.Component{incident.ComponentPayments}, time.Now())
+	at := time.Now().Add(time.Hour)
+
+	// Act
+	err := i.Post(incident.StageResolved, "Payments are processed again", at)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "incident must be resolved", i.IsResolved(), true)
+	assert.That(t, "resolution time must be recorded", i.ResolvedAt, at)
+	assert.That(t, "update must be added", len(i.Updates), 2)
+}
+
+func Test_Incident_Post_After_Resolution_Should_Fail(t *testing.T) {
+	// Arrange
+	i, _ := incident.NewIncident("inc-001", "Payments delayed", "Looking into it", incident.StatusDegraded, []incident.Component{incident.ComponentPayments}, time.Now())
+	_ = i.Post(incident.StageResolved, "Payments are processed again", time.Now())
+
+	// Act
+	err := i.Post(incident.StageMonitoring, "Still watching", time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrAlreadyResolved", err, incident.ErrAlreadyResolved)
+}
+
+func Test_Incident_Post_Without_Message_Should_Fail(t *testing.T) {
+	// Arrange
+	i, _ := incident.NewIncident("inc-001", "Payments delayed", "Looking into it", incident.StatusDegraded, []incident.Component{incident.ComponentPayments}, time.Now())
+
+	// Act
+	err := i.Post(incident.StageIdentified, "  ", time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrMissingMessage", err, incident.ErrMissingMessage)
+	assert.That(t, "stage must be unchanged", i.Stage, incident.StageInvestigating)
+}
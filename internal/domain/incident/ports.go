@@ -0,0 +1,8 @@
+package incident
+
+import (
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// IncidentRepository provides CRUD operations for incidents.
+type IncidentRepository resource.Access[IncidentID, Incident]
@@ -0,0 +1,89 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ComponentReport is the status of a component on the status page.
+type ComponentReport struct {
+	Component Component
+	Status    ComponentStatus
+}
+
+// Summary is what the status page shows: the overall status, the status of every component
+// and the active incidents, newest first.
+type Summary struct {
+	Status     ComponentStatus // the worst status of the components
+	Components []ComponentReport
+	Incidents  []Incident
+}
+
+// Service handles the incidents of the status page.
+type Service struct {
+	repo IncidentRepository
+}
+
+// NewService creates a new incident service.
+func NewService(repo IncidentRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Open opens an incident affecting the components.
+func (s *Service) Open(ctx context.Context, id IncidentID, title, message string, impact ComponentStatus, components []Component) (*Incident, error) {
+	incident, err := NewIncident(id, title, message, impact, components, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, incident.ID, *incident); err != nil {
+		return nil, fmt.Errorf("failed to persist incident: %w", err)
+	}
+	return incident, nil
+}
+
+// Post adds an update to an incident; the resolved stage resolves it.
+func (s *Service) Post(ctx context.Context, id IncidentID, stage Stage, message string) (*Incident, error) {
+	incident, err := s.repo.Read(ctx, id)
+	if err != nil {
+		return nil, ErrIncidentNotFound
+	}
+	if err := incident.Post(stage, message, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, incident.ID, *incident); err != nil {
+		return nil, fmt.Errorf("failed to persist incident: %w", err)
+	}
+	return incident, nil
+}
+
+// List returns all incidents, newest first.
+func (s *Service) List(ctx context.Context) ([]Incident, error) {
+	incidents, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	slices.SortFunc(incidents, func(a, b Incident) int { return b.StartedAt.Compare(a.StartedAt) })
+	return incidents, nil
+}
+
+// Summarize combines the measured health of the components with the incidents, of which only
+// the active ones count. A component is as bad as its health or the worst incident affecting it,
+// whichever is worse, so an incident opened by ops shows even while the checks still pass;
+// components without a measured health count as operational.
+func Summarize(health map[Component]ComponentStatus, incidents []Incident) Summary {
+	active := slices.DeleteFunc(slices.Clone(incidents), func(i Incident) bool { return i.IsResolved() })
+	summary := Summary{Status: StatusOperational, Incidents: active}
+	for _, c := range Components {
+		status := StatusOperational.Worse(health[c])
+		for _, i := range active {
+			if i.Affects(c) {
+				status = status.Worse(i.Impact)
+			}
+		}
+		summary.Components = append(summary.Components, ComponentReport{Component: c, Status: status})
+		summary.Status = summary.Status.Worse(status)
+	}
+	return summary
+}
@@ -0,0 +1,71 @@
+package incident_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/incident"
+)
+
+func createTestIncidentService() *incident.Service {
+	return incident.NewService(resource.NewInMemoryAccess[incident.IncidentID, incident.Incident]())
+}
+
+// ============================================================================
+// Post Tests
+// ============================================================================
+
+func Test_Service_Post_Unknown_Incident_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestIncidentService()
+
+	// Act
+	_, err := svc.Post(context.Background(), "inc-404", incident.StageResolved, "Fixed")
+
+	// Assert
+	assert.That(t, "err must be ErrIncidentNotFound", err, incident.ErrIncidentNotFound)
+}
+
+// ============================================================================
+// Summarize Tests
+// ============================================================================
+
+func Test_Summarize_Without_Incidents_Should_Report_Health(t *testing.T) {
+	// Arrange
+	health := map[incident.Component]incident.ComponentStatus{incident.ComponentPayments: incident.StatusMajor}
+
+	// Act
+	summary := incident.Summarize(health, nil)
+
+	// Assert
+	assert.That(t, "overall status must be the worst", summary.Status, incident.StatusMajor)
+	assert.That(t, "components must be listed in order", summary.Components, []incident.ComponentReport{
+		{Component: incident.ComponentBookingEngine, Status: incident.StatusOperational},
+		{Component: incident.ComponentPayments, Status: incident.StatusMajor},
+		{Component: incident.ComponentNotifications, Status: incident.StatusOperational},
+	})
+	assert.That(t, "no incidents must be listed", len(summary.Incidents), 0)
+}
+
+func Test_Summarize_Should_Apply_Active_Incidents_Only(t *testing.T) {
+	// Arrange
+	svc := createTestIncidentService()
+	ctx := context.Background()
+	_, _ = svc.Open(ctx, "inc-001", "Emails delayed", "Looking into it", incident.StatusPartial, []incident.Component{incident.ComponentNotifications})
+	_, _ = svc.Open(ctx, "inc-002", "Slow bookings", "Looking into it", incident.StatusMajor, []incident.Component{incident.ComponentBookingEngine})
+	_, _ = svc.Post(ctx, "inc-002", incident.StageResolved, "Bookings are fast again")
+
+	incidents, _ := svc.List(ctx)
+
+	// Act
+	summary := incident.Summarize(nil, incidents)
+
+	// Assert
+	assert.That(t, "overall status must follow the active incident", summary.Status, incident.StatusPartial)
+	assert.That(t, "booking engine must be operational", summary.Components[0].Status, incident.StatusOperational)
+	assert.That(t, "notifications must show the impact", summary.Components[2].Status, incident.StatusPartial)
+	assert.That(t, "only the active incident must be listed", len(summary.Incidents), 1)
+	assert.That(t, "active incident must be listed", summary.Incidents[0].ID, incident.IncidentID("inc-001"))
+}
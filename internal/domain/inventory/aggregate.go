@@ -0,0 +1,85 @@
+// Package inventory contains the Inventory bounded context.
+// It turns the occupancy of the rooms into a feed of per-room per-night availability changes
+// for channel managers, so OTAs receive deltas instead of syncing full calendars.
+// Every change has a sequence number without gaps, so consumers detect missed changes and resync.
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Local ID types for this bounded context
+type RoomID string
+type DayKey string
+type ChangeKey string
+
+// Sequence numbers the changes of the feed, starting at 1 and without gaps.
+type Sequence uint64
+
+// Key returns the repository key of the change with this sequence, zero-padded so keys sort like sequences.
+func (s Sequence) Key() ChangeKey {
+	return ChangeKey(fmt.Sprintf("%020d", s))
+}
+
+// MaxSpanDays is the longest range of nights refreshed or returned at once.
+const MaxSpanDays = 366
+
+// Page sizes of the change feed.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// ErrInvalidSpan is returned if a range of less than 1 or more than MaxSpanDays nights is requested.
+var ErrInvalidSpan = errors.New("span must be between 1 and 366 days")
+
+// Availability is the availability of a room for the night starting on Date, as derived from its reservations.
+type Availability struct {
+	Date      time.Time
+	Available bool
+}
+
+// Day is the last published availability of a room for the night starting on Date.
+// Nights without a day have never changed and are available.
+type Day struct {
+	RoomID    RoomID    `json:"room_id"`
+	Date      time.Time `json:"date"`
+	Available bool      `json:"available"`
+}
+
+// NewDayKey returns the repository key of the night of the room.
+func NewDayKey(roomID RoomID, date time.Time) DayKey {
+	return DayKey(string(roomID) + "/" + date.Format(time.DateOnly))
+}
+
+// Change is a change of the availability of a room for one night.
+// It carries the new state instead of a difference, so applying it twice does no harm.
+type Change struct {
+	Sequence  Sequence  `json:"sequence"`
+	RoomID    RoomID    `json:"room_id"`
+	Date      string    `json:"date"` // YYYY-MM-DD
+	Available bool      `json:"available"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// ChangePage is a page of the change feed after a cursor.
+type ChangePage struct {
+	Changes []Change
+	Head    Sequence // sequence of the latest change of the feed
+}
+
+// RoomSnapshot is the current availability of a room for a range of nights, consistent with the
+// change feed up to Sequence. Consumers resync by replacing their state and following the feed after it.
+type RoomSnapshot struct {
+	RoomID   RoomID
+	Sequence Sequence
+	Days     []Availability
+}
+
+// calendarDate truncates the time to its date in UTC.
+func calendarDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
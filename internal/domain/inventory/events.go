@@ -0,0 +1,66 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+)
+
+// Event topics for Kafka.
+const (
+	EventTopicChanged = "inventory.changed"
+)
+
+// ExampleEvents returns an example of every event published by this context.
+// They document the events in the event catalog.
+func ExampleEvents() []event.Event {
+	return []event.Event{
+		NewEventChanged().
+			WithSequence(42).
+			WithRoomID("room-101").
+			WithDate(time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)).
+			WithAvailable(false).
+			WithChangedAt(time.Date(2030, 5, 1, 12, 0, 0, 0, time.UTC)),
+	}
+}
+
+// EventChanged is published when the availability of a room changes for a night.
+// A consumer that receives a sequence other than its last plus one missed changes.
+type EventChanged struct {
+	Sequence  Sequence  `json:"sequence"`
+	RoomID    RoomID    `json:"room_id"`
+	Date      string    `json:"date"` // YYYY-MM-DD
+	Available bool      `json:"available"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func NewEventChanged() *EventChanged {
+	return &EventChanged{}
+}
+
+func (e *EventChanged) Topic() string { return EventTopicChanged }
+
+func (e *EventChanged) WithSequence(seq Sequence) *EventChanged {
+	e.Sequence = seq
+	return e
+}
+
+func (e *EventChanged) WithRoomID(id RoomID) *EventChanged {
+	e.RoomID = id
+	return e
+}
+
+func (e *EventChanged) WithDate(date time.Time) *EventChanged {
+	e.Date = date.Format(time.DateOnly)
+	return e
+}
+
+func (e *EventChanged) WithAvailable(available bool) *EventChanged {
+	e.Available = available
+	return e
+}
+
+func (e *EventChanged) WithChangedAt(t time.Time) *EventChanged {
+	e.ChangedAt = t
+	return e
+}
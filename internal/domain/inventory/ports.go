@@ -0,0 +1,24 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// ChangeRepository provides CRUD operations for the changes of the feed, keyed by their padded sequence.
+type ChangeRepository resource.Access[ChangeKey, Change]
+
+// DayRepository provides CRUD operations for the last published availability per room and night.
+type DayRepository resource.Access[DayKey, Day]
+
+// Occupancy provides the availability of a room derived from its reservations.
+type Occupancy interface {
+	// Availability returns the availability of the room for days nights from the date of from
+	Availability(ctx context.Context, roomID RoomID, from time.Time, days int) ([]Availability, error)
+}
+
+// EventPublisher publishes domain events.
+type EventPublisher event.EventPublisher
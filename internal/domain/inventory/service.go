@@ -0,0 +1,169 @@
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Service handles the change feed of the room inventory.
+type Service struct {
+	changeRepo ChangeRepository
+	dayRepo    DayRepository
+	occupancy  Occupancy
+	publisher  EventPublisher
+	mu         sync.Mutex // serializes the numbering of changes
+	head       Sequence
+	loaded     bool // head was read from the repository
+}
+
+// NewService creates a new inventory service.
+func NewService(changeRepo ChangeRepository, dayRepo DayRepository, occupancy Occupancy, publisher EventPublisher) *Service {
+	return &Service{
+		changeRepo: changeRepo,
+		dayRepo:    dayRepo,
+		occupancy:  occupancy,
+		publisher:  publisher,
+	}
+}
+
+// Refresh compares the occupancy of the room for the nights from the date of from up to the date
+// of to with the last published availability, and records and publishes a change per night that differs.
+// Refreshing the same nights again without a new reservation or cancellation changes nothing,
+// so replayed reservation events do not produce changes twice.
+func (s *Service) Refresh(ctx context.Context, roomID RoomID, from, to time.Time) ([]Change, error) {
+	from = calendarDate(from)
+	days := int(calendarDate(to).Sub(from).Hours() / 24)
+	if days < 1 || days > MaxSpanDays {
+		return nil, ErrInvalidSpan
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, changes, err := s.refreshLocked(ctx, roomID, from, days)
+	return changes, err
+}
+
+// Changes returns up to limit changes after the sequence, oldest first, and the head of the feed.
+// Consumers start with after 0 and continue with the sequence of the last change they received.
+func (s *Service) Changes(ctx context.Context, after Sequence, limit int) (*ChangePage, error) {
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	all, err := s.changeRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changes: %w", err)
+	}
+	page := &ChangePage{Changes: []Change{}}
+	for _, change := range all {
+		page.Head = max(page.Head, change.Sequence)
+		if change.Sequence > after {
+			page.Changes = append(page.Changes, change)
+		}
+	}
+	slices.SortFunc(page.Changes, func(a, b Change) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+	if len(page.Changes) > limit {
+		page.Changes = page.Changes[:limit]
+	}
+	return page, nil
+}
+
+// Snapshot returns the availability of the room for days nights from the date of from with the
+// sequence it is consistent with. Nights whose published availability drifted from the occupancy,
+// e.g. because they were booked before the feed existed, are refreshed first, so the snapshot
+// and the feed agree from then on.
+func (s *Service) Snapshot(ctx context.Context, roomID RoomID, from time.Time, days int) (*RoomSnapshot, error) {
+	if days < 1 || days > MaxSpanDays {
+		return nil, ErrInvalidSpan
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	availability, _, err := s.refreshLocked(ctx, roomID, calendarDate(from), days)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomSnapshot{RoomID: roomID, Sequence: s.head, Days: availability}, nil
+}
+
+// refreshLocked is Refresh for callers holding the lock. It returns the occupancy it compared.
+func (s *Service) refreshLocked(ctx context.Context, roomID RoomID, from time.Time, days int) ([]Availability, []Change, error) {
+	if err := s.loadHeadLocked(ctx); err != nil {
+		return nil, nil, err
+	}
+	availability, err := s.occupancy.Availability(ctx, roomID, from, days)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read occupancy: %w", err)
+	}
+
+	var changes []Change
+	for _, night := range availability {
+		key := NewDayKey(roomID, night.Date)
+		published, err := s.dayRepo.Read(ctx, key)
+		known := err == nil && published != nil
+		if (known && published.Available == night.Available) || (!known && night.Available) {
+			continue
+		}
+
+		change := Change{
+			Sequence:  s.head + 1,
+			RoomID:    roomID,
+			Date:      night.Date.Format(time.DateOnly),
+			Available: night.Available,
+			ChangedAt: time.Now().UTC(),
+		}
+		if err := s.changeRepo.Create(ctx, change.Sequence.Key(), change); err != nil {
+			// Another instance may have taken the sequence, so read the head again next time.
+			s.loaded = false
+			return nil, changes, fmt.Errorf("failed to record change: %w", err)
+		}
+		s.head = change.Sequence
+
+		day := Day{RoomID: roomID, Date: night.Date, Available: night.Available}
+		if known {
+			err = s.dayRepo.Update(ctx, key, day)
+		} else {
+			err = s.dayRepo.Create(ctx, key, day)
+		}
+		if err != nil {
+			return nil, changes, fmt.Errorf("failed to store availability: %w", err)
+		}
+		changes = append(changes, change)
+
+		// A change that could not be published is still in the feed; consumers see the gap and fetch it.
+		evt := NewEventChanged().
+			WithSequence(change.Sequence).
+			WithRoomID(roomID).
+			WithDate(night.Date).
+			WithAvailable(change.Available).
+			WithChangedAt(change.ChangedAt)
+		if err := s.publisher.Publish(ctx, evt); err != nil {
+			return nil, changes, fmt.Errorf("failed to publish change: %w", err)
+		}
+	}
+	return availability, changes, nil
+}
+
+// loadHeadLocked reads the sequence of the latest change once.
+func (s *Service) loadHeadLocked(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	all, err := s.changeRepo.ReadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read changes: %w", err)
+	}
+	s.head = 0
+	for _, change := range all {
+		s.head = max(s.head, change.Sequence)
+	}
+	s.loaded = true
+	return nil
+}
@@ -0,0 +1,180 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/inventory"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+// mockOccupancy marks the booked nights of a room as unavailable.
+type mockOccupancy struct {
+	booked map[string]bool // "room/YYYY-MM-DD"
+}
+
+func (m *mockOccupancy) Availability(ctx context.Context, roomID inventory.RoomID, from time.Time, days int) ([]inventory.Availability, error) {
+	availability := make([]inventory.Availability, days)
+	for i := range availability {
+		date := from.AddDate(0, 0, i)
+		availability[i] = inventory.Availability{Date: date, Available: !m.booked[string(inventory.NewDayKey(roomID, date))]}
+	}
+	return availability, nil
+}
+
+func (m *mockOccupancy) book(roomID inventory.RoomID, from time.Time, nights int, booked bool) {
+	for i := range nights {
+		m.booked[string(inventory.NewDayKey(roomID, from.AddDate(0, 0, i)))] = booked
+	}
+}
+
+type mockEventPublisher struct {
+	published []event.Event
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	m.published = append(m.published, evt)
+	return nil
+}
+
+func createTestInventoryService() (*inventory.Service, *mockOccupancy, *mockEventPublisher) {
+	occupancy := &mockOccupancy{booked: map[string]bool{}}
+	publisher := &mockEventPublisher{}
+	svc := inventory.NewService(
+		resource.NewInMemoryAccess[inventory.ChangeKey, inventory.Change](),
+		resource.NewInMemoryAccess[inventory.DayKey, inventory.Day](),
+		occupancy,
+		publisher,
+	)
+	return svc, occupancy, publisher
+}
+
+var checkIn = time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)
+
+// ============================================================================
+// Refresh Tests
+// ============================================================================
+
+func Test_Service_Refresh_Should_Record_Change_Per_Booked_Night(t *testing.T) {
+	// Arrange
+	svc, occupancy, publisher := createTestInventoryService()
+	occupancy.book("room-101", checkIn, 2, true)
+
+	// Act
+	changes, err := svc.Refresh(context.Background(), "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "two nights must change", len(changes), 2)
+	assert.That(t, "first sequence must be 1", changes[0].Sequence, inventory.Sequence(1))
+	assert.That(t, "second sequence must be 2", changes[1].Sequence, inventory.Sequence(2))
+	assert.That(t, "first date must be the check-in", changes[0].Date, "2030-06-01")
+	assert.That(t, "nights must be unavailable", changes[1].Available, false)
+	assert.That(t, "changes must be published", len(publisher.published), 2)
+	assert.That(t, "topic must be inventory.changed", publisher.published[0].Topic(), inventory.EventTopicChanged)
+}
+
+func Test_Service_Refresh_Twice_Should_Not_Repeat_Changes(t *testing.T) {
+	// Arrange
+	svc, occupancy, publisher := createTestInventoryService()
+	occupancy.book("room-101", checkIn, 2, true)
+	ctx := context.Background()
+	_, _ = svc.Refresh(ctx, "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Act
+	changes, err := svc.Refresh(ctx, "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "nothing must change", len(changes), 0)
+	assert.That(t, "nothing must be published again", len(publisher.published), 2)
+}
+
+func Test_Service_Refresh_After_Cancellation_Should_Release_Nights(t *testing.T) {
+	// Arrange
+	svc, occupancy, _ := createTestInventoryService()
+	occupancy.book("room-101", checkIn, 2, true)
+	ctx := context.Background()
+	_, _ = svc.Refresh(ctx, "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+	occupancy.book("room-101", checkIn, 2, false)
+
+	// Act
+	changes, err := svc.Refresh(ctx, "room-101", checkIn, checkIn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "two nights must change", len(changes), 2)
+	assert.That(t, "sequence must continue", changes[0].Sequence, inventory.Sequence(3))
+	assert.That(t, "nights must be available", changes[0].Available, true)
+}
+
+func Test_Service_Refresh_Unbooked_Nights_Should_Not_Change(t *testing.T) {
+	// Arrange
+	svc, _, _ := createTestInventoryService()
+
+	// Act
+	changes, err := svc.Refresh(context.Background(), "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "available nights must not change", len(changes), 0)
+}
+
+func Test_Service_Refresh_With_Empty_Range_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _, _ := createTestInventoryService()
+
+	// Act
+	_, err := svc.Refresh(context.Background(), "room-101", checkIn, checkIn)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidSpan", err, inventory.ErrInvalidSpan)
+}
+
+// ============================================================================
+// Changes Tests
+// ============================================================================
+
+func Test_Service_Changes_Should_Return_Changes_After_Cursor(t *testing.T) {
+	// Arrange
+	svc, occupancy, _ := createTestInventoryService()
+	occupancy.book("room-101", checkIn, 3, true)
+	ctx := context.Background()
+	_, _ = svc.Refresh(ctx, "room-101", checkIn, checkIn.AddDate(0, 0, 3))
+
+	// Act
+	page, err := svc.Changes(ctx, 1, 1)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "page must be limited", len(page.Changes), 1)
+	assert.That(t, "first change must follow the cursor", page.Changes[0].Sequence, inventory.Sequence(2))
+	assert.That(t, "head must be the latest change", page.Head, inventory.Sequence(3))
+}
+
+// ============================================================================
+// Snapshot Tests
+// ============================================================================
+
+func Test_Service_Snapshot_Should_Refresh_Drifted_Nights(t *testing.T) {
+	// Arrange
+	svc, occupancy, publisher := createTestInventoryService()
+	occupancy.book("room-101", checkIn, 1, true) // booked before the feed existed
+
+	// Act
+	snapshot, err := svc.Snapshot(context.Background(), "room-101", checkIn, 2)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "snapshot must cover the nights", len(snapshot.Days), 2)
+	assert.That(t, "booked night must be unavailable", snapshot.Days[0].Available, false)
+	assert.That(t, "drift must be published", len(publisher.published), 1)
+	assert.That(t, "sequence must include the drift", snapshot.Sequence, inventory.Sequence(1))
+}
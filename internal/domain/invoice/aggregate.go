@@ -0,0 +1,320 @@
+// Package invoice contains the Invoice bounded context.
+// A completed stay is invoiced by the jurisdiction of its property: the jurisdiction names the seller with its
+// tax registration numbers, numbers its invoices in its own gapless sequence, and sets the VAT rate, the city tax
+// and the legal footer. Business customers with a VAT ID of another EU member state are invoiced without VAT
+// (reverse charge). Invoices are immutable once issued.
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ReservationID identifies the invoiced stay; a stay is invoiced once, so it is also the key of its invoice.
+type ReservationID = shared.ReservationID
+
+// PropertyID is the shared identifier of the property whose jurisdiction invoices the stay.
+type PropertyID = shared.PropertyID
+
+// Local ID types for this bounded context
+type JurisdictionID string
+
+var (
+	// ErrInvalidJurisdictions is returned if the jurisdictions cannot be parsed.
+	ErrInvalidJurisdictions = errors.New("invalid invoice jurisdictions")
+	// ErrNoJurisdiction is returned if no jurisdiction invoices the property of the stay.
+	ErrNoJurisdiction = errors.New("no invoice jurisdiction for the property")
+	// ErrNotFound is returned if the stay has not been invoiced.
+	ErrNotFound = errors.New("invoice not found")
+	// ErrNumbersBusy is returned if another request numbered an invoice of the jurisdiction for longer than the lock wait.
+	ErrNumbersBusy = errors.New("an invoice of the jurisdiction is being numbered by another request, try again")
+)
+
+// ReverseChargeNote is printed on invoices whose VAT is due from the business customer.
+const ReverseChargeNote = "Reverse charge: VAT to be accounted for by the recipient (Art. 196 Directive 2006/112/EC)."
+
+// euCountries are the VAT ID prefixes of the EU member states; Greece is EL.
+var euCountries = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "EL", "ES", "FI", "FR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// CityTax is the tax a municipality levies on overnight stays, either a share of the room charges
+// without VAT or an amount per guest and night. It is not subject to VAT.
+type CityTax struct {
+	Rate     int   // basis points of the room charges without VAT, e.g. 500 for 5%
+	PerNight int64 // amount per guest and night in the smallest unit of the currency of the stay
+}
+
+// Jurisdiction is a tax jurisdiction that invoices the stays in its properties.
+type Jurisdiction struct {
+	ID         JurisdictionID
+	Country    string   // VAT ID prefix of the country, e.g. DE; EL for Greece
+	Prefix     string   // prefix of the invoice numbers, e.g. INV-DE-
+	Seller     string   // legal name and address of the seller
+	TaxNumbers []string // tax registration numbers of the seller, e.g. its VAT ID
+	VATRate    int      // basis points included in the charges, e.g. 700 for 7%
+	CityTax    CityTax
+	Properties []PropertyID // properties it invoices; none for the default jurisdiction
+	Footer     string       // legal footer, e.g. the commercial register entry
+}
+
+// Covers reports whether the jurisdiction invoices the stays in the property.
+func (j Jurisdiction) Covers(propertyID PropertyID) bool {
+	return slices.Contains(j.Properties, propertyID)
+}
+
+// JurisdictionFor returns the jurisdiction listing the property, or the first one listing none.
+func JurisdictionFor(jurisdictions []Jurisdiction, propertyID PropertyID) (Jurisdiction, bool) {
+	for _, j := range jurisdictions {
+		if j.Covers(propertyID) {
+			return j, true
+		}
+	}
+	for _, j := range jurisdictions {
+		if len(j.Properties) == 0 {
+			return j, true
+		}
+	}
+	return Jurisdiction{}, false
+}
+
+// ParseJurisdictions parses "id=country|prefix|seller|tax numbers|VAT rate|city tax|properties|footer;..." entries,
+// e.g. "de=DE|INV-DE-|Hotel GmbH, Berlin|DE123456789, 30/123/45678|7%|5%|ber muc|HRB 12345 Berlin".
+// Tax numbers are separated by commas and properties by spaces. The city tax is a percentage of the room charges
+// or an amount per guest and night, e.g. 3.50. Country and prefix are required, the other fields optional.
+func ParseJurisdictions(s string) ([]Jurisdiction, error) {
+	var jurisdictions []Jurisdiction
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, value, ok := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: expected id=country|prefix|seller|tax numbers|VAT rate|city tax|properties|footer: %q", ErrInvalidJurisdictions, entry)
+		}
+		fields := strings.Split(value, "|")
+		if len(fields) > 8 {
+			return nil, fmt.Errorf("%w: jurisdiction %q has more than eight fields", ErrInvalidJurisdictions, id)
+		}
+		fields = append(fields, make([]string, 8-len(fields))...)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		j := Jurisdiction{
+			ID:      JurisdictionID(id),
+			Country: countryCode(fields[0]),
+			Prefix:  fields[1],
+			Seller:  fields[2],
+			Footer:  fields[7],
+		}
+		if j.Country == "" || j.Prefix == "" {
+			return nil, fmt.Errorf("%w: jurisdiction %q needs a country and a number prefix", ErrInvalidJurisdictions, id)
+		}
+		for number := range strings.SplitSeq(fields[3], ",") {
+			if number = strings.TrimSpace(number); number != "" {
+				j.TaxNumbers = append(j.TaxNumbers, number)
+			}
+		}
+		var err error
+		if j.VATRate, err = parseRate(fields[4]); err != nil {
+			return nil, fmt.Errorf("%w: VAT rate of jurisdiction %q: %w", ErrInvalidJurisdictions, id, err)
+		}
+		if j.CityTax, err = parseCityTax(fields[5]); err != nil {
+			return nil, fmt.Errorf("%w: city tax of jurisdiction %q: %w", ErrInvalidJurisdictions, id, err)
+		}
+		for _, p := range strings.Fields(fields[6]) {
+			j.Properties = append(j.Properties, PropertyID(p))
+		}
+		jurisdictions = append(jurisdictions, j)
+	}
+	return jurisdictions, nil
+}
+
+// countryCode returns the VAT ID prefix of the country code; the ISO code of Greece is GR, its prefix EL.
+func countryCode(country string) string {
+	country = strings.ToUpper(country)
+	if country == "GR" {
+		return "EL"
+	}
+	return country
+}
+
+// parseRate parses a percentage like "7%" or "7.5%" into basis points; empty is zero.
+func parseRate(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	percent, ok := strings.CutSuffix(s, "%")
+	value, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+	if !ok || err != nil || value < 0 {
+		return 0, fmt.Errorf("expected a percentage, e.g. 7%%: %q", s)
+	}
+	return int(math.Round(value * 100)), nil
+}
+
+// parseCityTax parses a percentage or an amount per guest and night like "3.50"; empty is no city tax.
+func parseCityTax(s string) (CityTax, error) {
+	if strings.HasSuffix(s, "%") {
+		rate, err := parseRate(s)
+		return CityTax{Rate: rate}, err
+	}
+	if s == "" {
+		return CityTax{}, nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		return CityTax{}, fmt.Errorf("expected a percentage or an amount per guest and night, e.g. 3.50: %q", s)
+	}
+	return CityTax{PerNight: int64(math.Round(value * 100))}, nil
+}
+
+// Customer is who the stay is billed to.
+type Customer struct {
+	Name    string
+	Email   string
+	Company string // empty for private guests
+	VATID   string // normalized, e.g. FR12345678901; empty if the company gave none
+}
+
+// Line is a charge or a payment on an invoice. Charges include VAT unless the invoice is reverse charged.
+type Line struct {
+	Description string
+	Amount      shared.Money
+}
+
+// Stay is what is invoiced for a completed reservation.
+type Stay struct {
+	ReservationID      ReservationID
+	PropertyID         PropertyID
+	ConfirmationNumber string
+	Customer           Customer
+	CheckIn            time.Time
+	CheckOut           time.Time
+	Nights             int
+	Guests             int
+	RoomCharges        shared.Money
+	Adjustments        []Line // charges and credits besides the room, e.g. the minibar
+	Payments           []Line // amounts received, net of refunds
+}
+
+// Invoice is the invoice of a stay (aggregate root).
+type Invoice struct {
+	ReservationID      ReservationID
+	Number             string // prefix of the jurisdiction and its sequence, e.g. INV-DE-000042
+	Sequence           int
+	Jurisdiction       JurisdictionID
+	IssuedAt           time.Time
+	Seller             string
+	TaxNumbers         []string
+	Customer           Customer
+	ConfirmationNumber string
+	CheckIn            time.Time
+	CheckOut           time.Time
+	Charges            []Line // room and adjustments
+	CityTax            *Line  // nil if the jurisdiction levies none
+	Deposits           []Line // payments received before the invoice
+	Net                shared.Money
+	VATRate            int // basis points; zero if reverse charged
+	VAT                shared.Money
+	ReverseCharge      bool
+	Total              shared.Money // net, VAT and city tax
+	Paid               shared.Money
+	Due                shared.Money // negative if the customer is owed a refund
+	Notes              []string
+	Footer             string
+}
+
+// NewInvoice issues the invoice of the stay with the sequence of the jurisdiction.
+// The charges include the VAT of the jurisdiction, except for business customers with a VAT ID of another
+// EU member state, who account for the VAT themselves. The city tax is added to the charges.
+func NewInvoice(j Jurisdiction, sequence int, stay Stay, issuedAt time.Time) Invoice {
+	currency := stay.RoomCharges.Currency
+	room := "Room, 1 night"
+	if stay.Nights != 1 {
+		room = fmt.Sprintf("Room, %d nights", stay.Nights)
+	}
+	inv := Invoice{
+		ReservationID:      stay.ReservationID,
+		Number:             fmt.Sprintf("%s%06d", j.Prefix, sequence),
+		Sequence:           sequence,
+		Jurisdiction:       j.ID,
+		IssuedAt:           issuedAt,
+		Seller:             j.Seller,
+		TaxNumbers:         slices.Clone(j.TaxNumbers),
+		Customer:           stay.Customer,
+		ConfirmationNumber: stay.ConfirmationNumber,
+		CheckIn:            stay.CheckIn,
+		CheckOut:           stay.CheckOut,
+		Charges:            []Line{{Description: room, Amount: stay.RoomCharges}},
+		Deposits:           slices.Clone(stay.Payments),
+		ReverseCharge:      ReverseCharged(j.Country, stay.Customer.VATID),
+		Footer:             j.Footer,
+	}
+	inv.Charges = append(inv.Charges, stay.Adjustments...)
+
+	gross := int64(0)
+	for _, c := range inv.Charges {
+		gross += c.Amount.Amount
+	}
+	roomNet := stay.RoomCharges.Amount
+	if inv.ReverseCharge {
+		inv.Net = shared.NewMoney(gross, currency)
+		inv.VAT = shared.NewMoney(0, currency)
+		inv.Notes = append(inv.Notes, ReverseChargeNote)
+	} else {
+		inv.VATRate = j.VATRate
+		inv.Net = shared.NewMoney(withoutVAT(gross, j.VATRate), currency)
+		inv.VAT = shared.NewMoney(gross-inv.Net.Amount, currency)
+		roomNet = withoutVAT(roomNet, j.VATRate)
+	}
+
+	cityTax := j.CityTax.PerNight * int64(stay.Nights) * int64(max(stay.Guests, 1))
+	if j.CityTax.Rate > 0 {
+		cityTax = divRound(roomNet*int64(j.CityTax.Rate), 10000)
+	}
+	if cityTax != 0 {
+		inv.CityTax = &Line{Description: "City tax", Amount: shared.NewMoney(cityTax, currency)}
+	}
+
+	paid := int64(0)
+	for _, d := range inv.Deposits {
+		paid += d.Amount.Amount
+	}
+	inv.Total = shared.NewMoney(gross+cityTax, currency)
+	inv.Paid = shared.NewMoney(paid, currency)
+	inv.Due = shared.NewMoney(inv.Total.Amount-paid, currency)
+	return inv
+}
+
+// ReverseCharged reports whether a business customer with the VAT ID accounts for the VAT of a stay invoiced
+// in the country: both must be EU member states, and different ones.
+func ReverseCharged(country, vatID string) bool {
+	if len(vatID) < 2 {
+		return false
+	}
+	customer := vatID[:2]
+	return customer != country && slices.Contains(euCountries, customer) && slices.Contains(euCountries, country)
+}
+
+// withoutVAT returns the amount without the VAT rate (basis points) it includes.
+func withoutVAT(gross int64, rate int) int64 {
+	return divRound(gross*10000, int64(10000+rate))
+}
+
+// divRound divides and rounds half away from zero.
+func divRound(a, b int64) int64 {
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+	return (a + b/2) / b
+}
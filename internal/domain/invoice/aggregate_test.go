@@ -0,0 +1,203 @@
+package invoice_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func eur(amount int64) shared.Money {
+	return shared.NewMoney(amount, "EUR")
+}
+
+// germany invoices the Berlin property with 7% VAT and a city tax of 5% of the room charges.
+func germany() invoice.Jurisdiction {
+	return invoice.Jurisdiction{
+		ID:         "de",
+		Country:    "DE",
+		Prefix:     "INV-DE-",
+		Seller:     "Hotel GmbH, Berlin",
+		TaxNumbers: []string{"DE123456789"},
+		VATRate:    700,
+		CityTax:    invoice.CityTax{Rate: 500},
+		Properties: []invoice.PropertyID{"ber"},
+		Footer:     "HRB 12345 Berlin",
+	}
+}
+
+// createTestStay returns a two-night stay of two guests in Berlin with a deposit of the room charges.
+func createTestStay(vatID string) invoice.Stay {
+	return invoice.Stay{
+		ReservationID: "res-001",
+		PropertyID:    "ber",
+		Customer:      invoice.Customer{Name: "Jane Doe", Company: "Acme SARL", VATID: vatID},
+		Nights:        2,
+		Guests:        2,
+		RoomCharges:   eur(21400),
+		Payments:      []invoice.Line{{Description: "Deposit (card)", Amount: eur(21400)}},
+	}
+}
+
+// ============================================================================
+// ParseJurisdictions Tests
+// ============================================================================
+
+func Test_ParseJurisdictions_Should_Parse_All_Fields(t *testing.T) {
+	// Arrange
+	s := "de=DE|INV-DE-|Hotel GmbH, Berlin|DE123456789, 30/123/45678|7%|5%|ber muc|HRB 12345; gr=GR|INV-GR-|||13%|1.50"
+
+	// Act
+	jurisdictions, err := invoice.ParseJurisdictions(s)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "two jurisdictions must be parsed", len(jurisdictions), 2)
+	assert.That(t, "seller must be parsed", jurisdictions[0].Seller, "Hotel GmbH, Berlin")
+	assert.That(t, "tax numbers must be split by commas", jurisdictions[0].TaxNumbers, []string{"DE123456789", "30/123/45678"})
+	assert.That(t, "VAT rate must be in basis points", jurisdictions[0].VATRate, 700)
+	assert.That(t, "city tax rate must be in basis points", jurisdictions[0].CityTax, invoice.CityTax{Rate: 500})
+	assert.That(t, "properties must be parsed", jurisdictions[0].Properties, []invoice.PropertyID{"ber", "muc"})
+	assert.That(t, "footer must be parsed", jurisdictions[0].Footer, "HRB 12345")
+	assert.That(t, "Greece must use its VAT prefix", jurisdictions[1].Country, "EL")
+	assert.That(t, "city tax per night must be in the smallest unit", jurisdictions[1].CityTax, invoice.CityTax{PerNight: 150})
+}
+
+func Test_ParseJurisdictions_Without_Prefix_Or_With_Invalid_Rate_Should_Return_Error(t *testing.T) {
+	// Arrange
+	withoutPrefix := "de=DE"
+	invalidRate := "de=DE|INV-DE-|||seven"
+
+	// Act
+	_, prefixErr := invoice.ParseJurisdictions(withoutPrefix)
+	_, rateErr := invoice.ParseJurisdictions(invalidRate)
+
+	// Assert
+	assert.That(t, "missing prefix must be rejected", errors.Is(prefixErr, invoice.ErrInvalidJurisdictions), true)
+	assert.That(t, "invalid rate must be rejected", errors.Is(rateErr, invoice.ErrInvalidJurisdictions), true)
+}
+
+// ============================================================================
+// JurisdictionFor Tests
+// ============================================================================
+
+func Test_JurisdictionFor_Should_Prefer_Listing_Over_Default(t *testing.T) {
+	// Arrange
+	fallback := invoice.Jurisdiction{ID: "default", Country: "DE", Prefix: "INV-"}
+	jurisdictions := []invoice.Jurisdiction{fallback, germany()}
+
+	// Act
+	listed, _ := invoice.JurisdictionFor(jurisdictions, "ber")
+	other, ok := invoice.JurisdictionFor(jurisdictions, "muc")
+	_, none := invoice.JurisdictionFor([]invoice.Jurisdiction{germany()}, "muc")
+
+	// Assert
+	assert.That(t, "listed property must get its jurisdiction", listed.ID, invoice.JurisdictionID("de"))
+	assert.That(t, "other property must get the default", other.ID, invoice.JurisdictionID("default"))
+	assert.That(t, "default must be found", ok, true)
+	assert.That(t, "property without jurisdiction must not be found", none, false)
+}
+
+// ============================================================================
+// NewInvoice Tests
+// ============================================================================
+
+func Test_NewInvoice_Should_Include_VAT_City_Tax_And_Deposits(t *testing.T) {
+	// Arrange
+	stay := createTestStay("")
+	stay.Adjustments = []invoice.Line{{Description: "Minibar", Amount: eur(1070)}}
+
+	// Act
+	inv := invoice.NewInvoice(germany(), 42, stay, time.Now())
+
+	// Assert
+	assert.That(t, "number must be the prefix with the sequence", inv.Number, "INV-DE-000042")
+	assert.That(t, "charges must list the room and the adjustment", len(inv.Charges), 2)
+	assert.That(t, "net must exclude the VAT", inv.Net, eur(21000))
+	assert.That(t, "VAT must be included in the charges", inv.VAT, eur(1470))
+	assert.That(t, "city tax must be a share of the room without VAT", inv.CityTax.Amount, eur(1000))
+	assert.That(t, "total must add the city tax", inv.Total, eur(23470))
+	assert.That(t, "deposit must be paid", inv.Paid, eur(21400))
+	assert.That(t, "rest must be due", inv.Due, eur(2070))
+	assert.That(t, "tax numbers must be printed", inv.TaxNumbers, []string{"DE123456789"})
+	assert.That(t, "footer must be printed", inv.Footer, "HRB 12345 Berlin")
+	assert.That(t, "domestic invoice must not be reverse charged", inv.ReverseCharge, false)
+}
+
+func Test_NewInvoice_With_VAT_ID_Of_Other_Member_State_Should_Be_Reverse_Charged(t *testing.T) {
+	// Arrange
+	stay := createTestStay("FR12345678901")
+
+	// Act
+	inv := invoice.NewInvoice(germany(), 1, stay, time.Now())
+
+	// Assert
+	assert.That(t, "invoice must be reverse charged", inv.ReverseCharge, true)
+	assert.That(t, "VAT must be zero", inv.VAT, eur(0))
+	assert.That(t, "VAT rate must be zero", inv.VATRate, 0)
+	assert.That(t, "net must be the charges", inv.Net, eur(21400))
+	assert.That(t, "city tax must still be levied", inv.CityTax.Amount, eur(1070))
+	assert.That(t, "reverse charge must be noted", inv.Notes, []string{invoice.ReverseChargeNote})
+}
+
+func Test_NewInvoice_With_Domestic_Or_Non_EU_VAT_ID_Should_Include_VAT(t *testing.T) {
+	// Arrange
+	domestic := createTestStay("DE987654321")
+	swiss := createTestStay("CHE123456789MWST")
+
+	// Act
+	domesticInvoice := invoice.NewInvoice(germany(), 1, domestic, time.Now())
+	swissInvoice := invoice.NewInvoice(germany(), 2, swiss, time.Now())
+
+	// Assert
+	assert.That(t, "domestic VAT ID must pay VAT", domesticInvoice.ReverseCharge, false)
+	assert.That(t, "VAT ID outside the EU must pay VAT", swissInvoice.ReverseCharge, false)
+	assert.That(t, "VAT must be charged", swissInvoice.VAT, eur(1400))
+}
+
+func Test_NewInvoice_With_City_Tax_Per_Night_Should_Charge_Every_Guest_And_Night(t *testing.T) {
+	// Arrange
+	j := germany()
+	j.CityTax = invoice.CityTax{PerNight: 350}
+
+	// Act
+	inv := invoice.NewInvoice(j, 1, createTestStay(""), time.Now())
+
+	// Assert
+	assert.That(t, "two guests for two nights must pay four times", inv.CityTax.Amount, eur(1400))
+	assert.That(t, "total must add the city tax", inv.Total, eur(22800))
+}
+
+// ============================================================================
+// ReverseCharged Tests
+// ============================================================================
+
+func Test_ReverseCharged_Should_Require_Two_Different_Member_States(t *testing.T) {
+	// Arrange
+	cases := []struct {
+		country, vatID string
+		expected       bool
+	}{
+		{"DE", "FR12345678901", true},
+		{"EL", "DE123456789", true},
+		{"DE", "DE123456789", false},
+		{"DE", "GB123456789", false},
+		{"CH", "DE123456789", false},
+		{"DE", "", false},
+	}
+
+	for _, c := range cases {
+		// Act
+		got := invoice.ReverseCharged(c.country, c.vatID)
+
+		// Assert
+		assert.That(t, c.country+" invoicing "+c.vatID, got, c.expected)
+	}
+}
@@ -0,0 +1,22 @@
+package invoice
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// InvoiceRepository provides CRUD operations for the invoices, keyed by their reservation.
+type InvoiceRepository resource.Access[ReservationID, Invoice]
+
+// Stays provides what is invoiced for a reservation.
+type Stays interface {
+	// Stay returns the customer, the dates, the charges and the payments of the reservation
+	Stay(ctx context.Context, reservationID ReservationID) (*Stay, error)
+}
+
+// NumberLocks serializes the numbering of each jurisdiction's invoices across all server instances.
+type NumberLocks interface {
+	// Lock waits until the jurisdiction is locked and returns the function that releases it, or ErrNumbersBusy
+	Lock(ctx context.Context, jurisdictionID JurisdictionID) (func(), error)
+}
@@ -0,0 +1,106 @@
+package invoice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Service issues the invoices of completed stays.
+type Service struct {
+	invoiceRepo   InvoiceRepository
+	stays         Stays
+	jurisdictions []Jurisdiction
+	locks         NumberLocks
+	numbers       sync.Mutex // serializes the numbering without number locks, so no number is issued twice
+}
+
+// NewService creates a new invoice service for the jurisdictions.
+func NewService(invoiceRepo InvoiceRepository, stays Stays, jurisdictions []Jurisdiction) *Service {
+	return &Service{
+		invoiceRepo:   invoiceRepo,
+		stays:         stays,
+		jurisdictions: jurisdictions,
+	}
+}
+
+// WithNumberLocks serializes the numbering of each jurisdiction with the locks instead of the service's mutex,
+// so invoices issued on several server instances cannot get the same number.
+func (s *Service) WithNumberLocks(locks NumberLocks) *Service {
+	s.locks = locks
+	return s
+}
+
+// Invoice returns the invoice of the reservation or ErrNotFound if it has not been issued.
+func (s *Service) Invoice(ctx context.Context, reservationID ReservationID) (*Invoice, error) {
+	inv, err := s.invoiceRepo.Read(ctx, reservationID)
+	if err != nil || inv == nil {
+		return nil, ErrNotFound
+	}
+	return inv, nil
+}
+
+// Issue invoices the stay of the reservation with the next number of the jurisdiction of its property.
+// A stay is invoiced once; issuing it again returns the stored invoice. The next number follows the highest
+// number issued, so the sequence of a jurisdiction has no gaps.
+func (s *Service) Issue(ctx context.Context, reservationID ReservationID) (*Invoice, error) {
+	if stored, err := s.Invoice(ctx, reservationID); err == nil {
+		return stored, nil
+	}
+	stay, err := s.stays.Stay(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stay: %w", err)
+	}
+	j, ok := JurisdictionFor(s.jurisdictions, stay.PropertyID)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrNoJurisdiction, stay.PropertyID)
+	}
+
+	unlock, err := s.lockNumbers(ctx, j.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if stored, err := s.Invoice(ctx, reservationID); err == nil {
+		return stored, nil
+	}
+	sequence, err := s.lastSequence(ctx, j.ID)
+	if err != nil {
+		return nil, err
+	}
+	inv := NewInvoice(j, sequence+1, *stay, time.Now())
+	if err := s.invoiceRepo.Create(ctx, inv.ReservationID, inv); err != nil {
+		return nil, fmt.Errorf("failed to persist invoice: %w", err)
+	}
+	return &inv, nil
+}
+
+// lockNumbers locks the numbering of the jurisdiction with the number locks, or the service if none are configured,
+// and returns the function that releases it.
+func (s *Service) lockNumbers(ctx context.Context, jurisdictionID JurisdictionID) (func(), error) {
+	if s.locks == nil {
+		s.numbers.Lock()
+		return s.numbers.Unlock, nil
+	}
+	unlock, err := s.locks.Lock(ctx, jurisdictionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock invoice numbers of jurisdiction %s: %w", jurisdictionID, err)
+	}
+	return unlock, nil
+}
+
+// lastSequence returns the highest sequence issued by the jurisdiction, or zero if it has issued none.
+func (s *Service) lastSequence(ctx context.Context, jurisdictionID JurisdictionID) (int, error) {
+	all, err := s.invoiceRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	last := 0
+	for _, inv := range all {
+		if inv.Jurisdiction == jurisdictionID {
+			last = max(last, inv.Sequence)
+		}
+	}
+	return last, nil
+}
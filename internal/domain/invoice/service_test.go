@@ -0,0 +1,185 @@
+package invoice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/invoice"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+// mockStays returns a stay of the property for every reservation; reservations named missing are not found.
+type mockStays struct {
+	properties map[invoice.ReservationID]invoice.PropertyID
+}
+
+func (m *mockStays) Stay(ctx context.Context, reservationID invoice.ReservationID) (*invoice.Stay, error) {
+	if reservationID == "missing" {
+		return nil, errors.New("reservation not found")
+	}
+	stay := createTestStay("")
+	stay.ReservationID = reservationID
+	stay.PropertyID = m.properties[reservationID]
+	return &stay, nil
+}
+
+// mockNumberLocks records the locked jurisdictions and fails with err if set.
+type mockNumberLocks struct {
+	locked []invoice.JurisdictionID
+	err    error
+}
+
+func (m *mockNumberLocks) Lock(ctx context.Context, jurisdictionID invoice.JurisdictionID) (func(), error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.locked = append(m.locked, jurisdictionID)
+	return func() {}, nil
+}
+
+// createTestService returns a service invoicing Berlin in Germany and every other property in Austria.
+func createTestService(properties map[invoice.ReservationID]invoice.PropertyID) *invoice.Service {
+	austria := invoice.Jurisdiction{ID: "at", Country: "AT", Prefix: "INV-AT-", VATRate: 1000}
+	return invoice.NewService(
+		resource.NewInMemoryAccess[invoice.ReservationID, invoice.Invoice](),
+		&mockStays{properties: properties},
+		[]invoice.Jurisdiction{germany(), austria},
+	)
+}
+
+// ============================================================================
+// Issue Tests
+// ============================================================================
+
+func Test_Service_Issue_Should_Number_Each_Jurisdiction_In_Its_Own_Sequence(t *testing.T) {
+	// Arrange
+	svc := createTestService(map[invoice.ReservationID]invoice.PropertyID{
+		"res-1": "ber", "res-2": "vie", "res-3": "ber", "res-4": "vie", "res-5": "ber",
+	})
+	ctx := context.Background()
+
+	// Act
+	var numbers []string
+	for _, id := range []invoice.ReservationID{"res-1", "res-2", "res-3", "res-4", "res-5"} {
+		inv, err := svc.Issue(ctx, id)
+		assert.That(t, "err must be nil", err, nil)
+		numbers = append(numbers, inv.Number)
+	}
+
+	// Assert
+	assert.That(t, "numbers must be sequential per jurisdiction", numbers, []string{
+		"INV-DE-000001", "INV-AT-000001", "INV-DE-000002", "INV-AT-000002", "INV-DE-000003",
+	})
+}
+
+func Test_Service_Issue_Again_Should_Return_Stored_Invoice_Without_Using_A_Number(t *testing.T) {
+	// Arrange
+	svc := createTestService(map[invoice.ReservationID]invoice.PropertyID{"res-1": "ber", "res-2": "ber"})
+	ctx := context.Background()
+	first, _ := svc.Issue(ctx, "res-1")
+
+	// Act
+	again, err := svc.Issue(ctx, "res-1")
+	next, _ := svc.Issue(ctx, "res-2")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "invoice must keep its number", again.Number, first.Number)
+	assert.That(t, "next invoice must follow without a gap", next.Number, "INV-DE-000002")
+}
+
+func Test_Service_Issue_Concurrently_Should_Issue_Each_Number_Once(t *testing.T) {
+	// Arrange
+	properties := make(map[invoice.ReservationID]invoice.PropertyID)
+	for i := range 20 {
+		properties[invoice.ReservationID(fmt.Sprintf("res-%d", i))] = "ber"
+	}
+	svc := createTestService(properties)
+	var mu sync.Mutex
+	var sequences []int
+	var wg sync.WaitGroup
+
+	// Act
+	for id := range properties {
+		wg.Go(func() {
+			inv, err := svc.Issue(context.Background(), id)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			sequences = append(sequences, inv.Sequence)
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	// Assert
+	slices.Sort(sequences)
+	expected := make([]int, 20)
+	for i := range expected {
+		expected[i] = i + 1
+	}
+	assert.That(t, "sequences must be issued once without gaps", sequences, expected)
+}
+
+func Test_Service_Issue_With_Number_Locks_Should_Lock_Jurisdiction(t *testing.T) {
+	// Arrange
+	locks := &mockNumberLocks{}
+	svc := createTestService(map[invoice.ReservationID]invoice.PropertyID{"res-1": "vie"}).WithNumberLocks(locks)
+
+	// Act
+	_, err := svc.Issue(context.Background(), "res-1")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "jurisdiction must be locked", locks.locked, []invoice.JurisdictionID{"at"})
+}
+
+func Test_Service_Issue_When_Numbers_Are_Busy_Should_Return_ErrNumbersBusy(t *testing.T) {
+	// Arrange
+	svc := createTestService(map[invoice.ReservationID]invoice.PropertyID{"res-1": "ber"}).WithNumberLocks(&mockNumberLocks{err: invoice.ErrNumbersBusy})
+	ctx := context.Background()
+
+	// Act
+	_, err := svc.Issue(ctx, "res-1")
+
+	// Assert
+	_, readErr := svc.Invoice(ctx, "res-1")
+	assert.That(t, "err must be ErrNumbersBusy", errors.Is(err, invoice.ErrNumbersBusy), true)
+	assert.That(t, "invoice must not be issued", errors.Is(readErr, invoice.ErrNotFound), true)
+}
+
+func Test_Service_Issue_Without_Jurisdiction_Should_Return_ErrNoJurisdiction(t *testing.T) {
+	// Arrange
+	svc := invoice.NewService(
+		resource.NewInMemoryAccess[invoice.ReservationID, invoice.Invoice](),
+		&mockStays{properties: map[invoice.ReservationID]invoice.PropertyID{"res-1": "muc"}},
+		[]invoice.Jurisdiction{germany()},
+	)
+
+	// Act
+	_, err := svc.Issue(context.Background(), "res-1")
+
+	// Assert
+	assert.That(t, "err must be ErrNoJurisdiction", errors.Is(err, invoice.ErrNoJurisdiction), true)
+}
+
+func Test_Service_Issue_With_Unknown_Reservation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestService(nil)
+
+	// Act
+	_, err := svc.Issue(context.Background(), "missing")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,319 @@
+// Package ledger contains the Ledger bounded context.
+// It records every money movement of the hotel, i.e. authorizations, captures, refunds, folio adjustments,
+// gift card redemptions and gateway payouts, as balanced double-entry journal entries, so finance gets
+// account balances and a trial balance from the system itself.
+// The journal is append-only: entries are numbered without gaps and chained by hashes, so a changed or
+// deleted entry is detected, and mistakes are corrected by reversing entries instead of editing them.
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Type aliases for shared types
+type ReservationID = shared.ReservationID
+type Money = shared.Money
+
+// Local ID types for this bounded context
+type AccountID string
+type EntryKey string
+type SourceKey string
+
+// Sequence numbers the entries of the journal, starting at 1 and without gaps.
+type Sequence uint64
+
+// Key returns the repository key of the entry with this sequence, zero-padded so keys sort like sequences.
+func (s Sequence) Key() EntryKey {
+	return EntryKey(fmt.Sprintf("%020d", s))
+}
+
+// Ledger errors.
+var (
+	ErrUnknownAccount  = errors.New("unknown ledger account")
+	ErrInvalidEntry    = errors.New("entry must have a source, a currency and at least two postings with a positive debit or credit")
+	ErrUnbalancedEntry = errors.New("entry debits must equal its credits")
+	ErrAlreadyPosted   = errors.New("movement already posted")
+	ErrEntryNotFound   = errors.New("ledger entry not found")
+	ErrInvalidPayout   = errors.New("payout must have an ID, a positive amount and fees below the amount in its currency")
+)
+
+// AccountType classifies an account and decides on which side its balance grows.
+type AccountType string
+
+const (
+	AccountTypeAsset     AccountType = "asset"
+	AccountTypeLiability AccountType = "liability"
+	AccountTypeRevenue   AccountType = "revenue"
+	AccountTypeExpense   AccountType = "expense"
+	AccountTypeMemo      AccountType = "memo" // off-balance, e.g. card holds
+)
+
+// Accounts of the chart of accounts.
+const (
+	AccountCardHolds         AccountID = "card_holds"          // memo: funds held on guest cards
+	AccountCardHoldsContra   AccountID = "card_holds_contra"   // memo: counterpart of the card holds
+	AccountGatewayClearing   AccountID = "gateway_clearing"    // asset: captured funds the gateway has not paid out yet
+	AccountBank              AccountID = "bank"                // asset: payouts received from the gateway
+	AccountGuestLedger       AccountID = "guest_ledger"        // asset: owed by guests; a credit balance is guest deposits
+	AccountGiftCardLiability AccountID = "gift_card_liability" // liability: gift card balances owed to their holders
+	AccountFolioRevenue      AccountID = "folio_revenue"       // revenue: folio adjustments, credits reduce it
+	AccountGatewayFees       AccountID = "gateway_fees"        // expense: fees withheld from payouts
+)
+
+// Account is an account of the chart of accounts.
+type Account struct {
+	ID          AccountID
+	Name        string
+	Type        AccountType
+	DebitNormal bool // balance grows with debits (assets, expenses, card holds)
+}
+
+// Accounts is the chart of accounts in the order of the reports.
+var Accounts = []Account{
+	{ID: AccountBank, Name: "Bank", Type: AccountTypeAsset, DebitNormal: true},
+	{ID: AccountGatewayClearing, Name: "Gateway clearing", Type: AccountTypeAsset, DebitNormal: true},
+	{ID: AccountGuestLedger, Name: "Guest ledger", Type: AccountTypeAsset, DebitNormal: true},
+	{ID: AccountGiftCardLiability, Name: "Gift card liability", Type: AccountTypeLiability},
+	{ID: AccountFolioRevenue, Name: "Folio revenue", Type: AccountTypeRevenue},
+	{ID: AccountGatewayFees, Name: "Gateway fees", Type: AccountTypeExpense, DebitNormal: true},
+	{ID: AccountCardHolds, Name: "Card holds", Type: AccountTypeMemo, DebitNormal: true},
+	{ID: AccountCardHoldsContra, Name: "Card holds (contra)", Type: AccountTypeMemo},
+}
+
+// FindAccount returns the account of the chart of accounts with the ID.
+func FindAccount(id AccountID) (Account, bool) {
+	for _, a := range Accounts {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Account{}, false
+}
+
+// EntryKind names the money movement an entry records.
+type EntryKind string
+
+const (
+	KindAuthorization EntryKind = "authorization"
+	KindRelease       EntryKind = "release" // an authorization that was never captured
+	KindCapture       EntryKind = "capture"
+	KindGiftCard      EntryKind = "gift_card"
+	KindRefund        EntryKind = "refund"
+	KindAdjustment    EntryKind = "adjustment"
+	KindPayout        EntryKind = "payout"
+	KindReversal      EntryKind = "reversal"
+)
+
+// Posting debits or credits an account of an entry in minor units of the entry currency.
+// Exactly one of Debit and Credit is positive.
+type Posting struct {
+	Account AccountID `json:"account"`
+	Debit   int64     `json:"debit,omitempty"`
+	Credit  int64     `json:"credit,omitempty"`
+}
+
+// Entry is a journal entry: a money movement as postings whose debits equal their credits.
+// Source identifies the movement, so a movement is posted once however often its event is received.
+type Entry struct {
+	Sequence      Sequence      `json:"sequence"`
+	Kind          EntryKind     `json:"kind"`
+	Source        SourceKey     `json:"source"`
+	ReservationID ReservationID `json:"reservation_id,omitempty"`
+	Description   string        `json:"description"`
+	Currency      string        `json:"currency"`
+	Postings      []Posting     `json:"postings"`
+	PostedAt      time.Time     `json:"posted_at"`
+	PreviousHash  string        `json:"previous_hash"`
+	Hash          string        `json:"hash"`
+}
+
+// Validate checks that the entry is complete, uses known accounts and is balanced.
+func (e Entry) Validate() error {
+	if e.Source == "" || e.Currency == "" || len(e.Postings) < 2 {
+		return ErrInvalidEntry
+	}
+	var debits, credits int64
+	for _, p := range e.Postings {
+		if _, ok := FindAccount(p.Account); !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownAccount, p.Account)
+		}
+		if p.Debit < 0 || p.Credit < 0 || (p.Debit > 0) == (p.Credit > 0) {
+			return ErrInvalidEntry
+		}
+		debits += p.Debit
+		credits += p.Credit
+	}
+	if debits != credits {
+		return ErrUnbalancedEntry
+	}
+	return nil
+}
+
+// Amount returns the sum of the debits of the entry, which equals the sum of its credits.
+func (e Entry) Amount() Money {
+	var debits int64
+	for _, p := range e.Postings {
+		debits += p.Debit
+	}
+	return shared.NewMoney(debits, e.Currency)
+}
+
+// ComputeHash returns the hash of the entry's content and the hash of its predecessor.
+// Changing any field of an entry, or removing an entry, breaks the chain from there on.
+func (e Entry) ComputeHash() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n", e.Sequence, e.Kind, e.Source, e.ReservationID,
+		e.Description, e.Currency, e.PostedAt.UTC().Format(time.RFC3339Nano), e.PreviousHash)
+	for _, p := range e.Postings {
+		fmt.Fprintf(&b, "%s %d %d\n", p.Account, p.Debit, p.Credit)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Reversal returns an entry that undoes the entry, with debits and credits swapped.
+func (e Entry) Reversal(reason string) Entry {
+	postings := make([]Posting, 0, len(e.Postings))
+	for _, p := range e.Postings {
+		postings = append(postings, Posting{Account: p.Account, Debit: p.Credit, Credit: p.Debit})
+	}
+	return Entry{
+		Kind:          KindReversal,
+		Source:        SourceKey(fmt.Sprintf("reversal/%d", e.Sequence)),
+		ReservationID: e.ReservationID,
+		Description:   fmt.Sprintf("Reversal of entry %d: %s", e.Sequence, reason),
+		Currency:      e.Currency,
+		Postings:      postings,
+	}
+}
+
+// transfer returns the postings moving the amount from the credited to the debited account.
+func transfer(debit, credit AccountID, amount int64) []Posting {
+	return []Posting{{Account: debit, Debit: amount}, {Account: credit, Credit: amount}}
+}
+
+// PaymentMovements is the state of a payment as far as the ledger is concerned.
+// It is built from the Payment context by the adapter, so the ledger does not depend on it.
+type PaymentMovements struct {
+	PaymentID     string
+	ReservationID ReservationID
+	Amount        Money
+	GiftCard      bool    // redeemed from a gift card instead of charged to a card
+	Authorized    bool    // was authorized at some point
+	Captured      bool    // was captured, possibly refunded since
+	Released      bool    // the authorization ended without capture, e.g. the payment failed
+	Refunds       []Money // refunds, oldest first
+}
+
+// Entries returns the entries of every movement of the payment so far, without sequence and hashes.
+// Their sources are derived from the payment, so posting them again posts only new movements.
+//
+//   - authorization: holds the amount on the card (memo accounts)
+//   - capture:       releases the hold; the gateway owes the amount, credited to the guest
+//   - gift card:     the gift card liability is reduced instead of the gateway owing the amount
+//   - release:       releases the hold of an authorization that was never captured
+//   - refund:        the guest is debited and the gateway (or the gift card) credited
+func (m PaymentMovements) Entries() []Entry {
+	var entries []Entry
+	entry := func(kind EntryKind, source, description string, postings ...[]Posting) {
+		e := Entry{
+			Kind:          kind,
+			Source:        SourceKey("payment/" + m.PaymentID + "/" + source),
+			ReservationID: m.ReservationID,
+			Description:   description,
+			Currency:      m.Amount.Currency,
+		}
+		for _, p := range postings {
+			e.Postings = append(e.Postings, p...)
+		}
+		entries = append(entries, e)
+	}
+	amount := m.Amount.Amount
+
+	if m.Authorized {
+		entry(KindAuthorization, "authorization", "Authorization of payment "+m.PaymentID,
+			transfer(AccountCardHolds, AccountCardHoldsContra, amount))
+	}
+	switch {
+	case m.Captured && m.GiftCard:
+		postings := [][]Posting{transfer(AccountGiftCardLiability, AccountGuestLedger, amount)}
+		if m.Authorized {
+			postings = append(postings, transfer(AccountCardHoldsContra, AccountCardHolds, amount))
+		}
+		entry(KindGiftCard, "capture", "Gift card redemption of payment "+m.PaymentID, postings...)
+	case m.Captured:
+		postings := [][]Posting{transfer(AccountGatewayClearing, AccountGuestLedger, amount)}
+		if m.Authorized {
+			postings = append(postings, transfer(AccountCardHoldsContra, AccountCardHolds, amount))
+		}
+		entry(KindCapture, "capture", "Capture of payment "+m.PaymentID, postings...)
+	case m.Authorized && m.Released:
+		entry(KindRelease, "release", "Release of payment "+m.PaymentID,
+			transfer(AccountCardHoldsContra, AccountCardHolds, amount))
+	}
+	if !m.Captured {
+		return entries
+	}
+	credit := AccountGatewayClearing
+	if m.GiftCard {
+		credit = AccountGiftCardLiability
+	}
+	for i, refund := range m.Refunds {
+		entry(KindRefund, fmt.Sprintf("refund/%d", i+1), fmt.Sprintf("Refund %d of payment %s", i+1, m.PaymentID),
+			transfer(AccountGuestLedger, credit, refund.Amount))
+	}
+	return entries
+}
+
+// AdjustmentEntry returns the entry of a folio adjustment: a charge (positive) is owed by the guest
+// and earned, a credit (negative) reduces both.
+func AdjustmentEntry(adjustmentID string, reservationID ReservationID, amount Money, reason string) Entry {
+	postings := transfer(AccountGuestLedger, AccountFolioRevenue, amount.Amount)
+	if amount.Amount < 0 {
+		postings = transfer(AccountFolioRevenue, AccountGuestLedger, -amount.Amount)
+	}
+	return Entry{
+		Kind:          KindAdjustment,
+		Source:        SourceKey("adjustment/" + adjustmentID),
+		ReservationID: reservationID,
+		Description:   reason,
+		Currency:      amount.Currency,
+		Postings:      postings,
+	}
+}
+
+// Payout is a transfer of captured funds from the gateway to the property's bank account.
+// The gateway withholds its fees, so the bank receives the amount minus the fees.
+type Payout struct {
+	ID     string
+	Amount Money // gross amount settled by the gateway
+	Fees   Money // withheld by the gateway, in the currency of the amount
+}
+
+// Entry returns the entry of the payout: the gateway owes the gross amount less, the bank has the net
+// amount and the fees are expensed.
+func (p Payout) Entry() (Entry, error) {
+	if p.ID == "" || p.Amount.Amount <= 0 || p.Fees.Amount < 0 || p.Fees.Amount >= p.Amount.Amount ||
+		(p.Fees.Amount > 0 && p.Fees.Currency != p.Amount.Currency) {
+		return Entry{}, ErrInvalidPayout
+	}
+	postings := []Posting{{Account: AccountBank, Debit: p.Amount.Amount - p.Fees.Amount}}
+	if p.Fees.Amount > 0 {
+		postings = append(postings, Posting{Account: AccountGatewayFees, Debit: p.Fees.Amount})
+	}
+	postings = append(postings, Posting{Account: AccountGatewayClearing, Credit: p.Amount.Amount})
+	return Entry{
+		Kind:        KindPayout,
+		Source:      SourceKey("payout/" + p.ID),
+		Description: "Payout " + p.ID,
+		Currency:    p.Amount.Currency,
+		Postings:    postings,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package ledger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Entry Tests
+// ============================================================================
+
+func Test_Entry_Validate_Unbalanced_Should_Fail(t *testing.T) {
+	// Arrange
+	entry := ledger.Entry{
+		Source:   "manual/1",
+		Currency: "USD",
+		Postings: []ledger.Posting{
+			{Account: ledger.AccountBank, Debit: 1000},
+			{Account: ledger.AccountGatewayClearing, Credit: 900},
+		},
+	}
+
+	// Act
+	err := entry.Validate()
+
+	// Assert
+	assert.That(t, "err must be ErrUnbalancedEntry", errors.Is(err, ledger.ErrUnbalancedEntry), true)
+}
+
+func Test_Entry_Validate_Unknown_Account_Should_Fail(t *testing.T) {
+	// Arrange
+	entry := ledger.Entry{
+		Source:   "manual/1",
+		Currency: "USD",
+		Postings: []ledger.Posting{
+			{Account: "petty_cash", Debit: 1000},
+			{Account: ledger.AccountBank, Credit: 1000},
+		},
+	}
+
+	// Act
+	err := entry.Validate()
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownAccount", errors.Is(err, ledger.ErrUnknownAccount), true)
+}
+
+func Test_Entry_Reversal_Should_Swap_Debits_And_Credits(t *testing.T) {
+	// Arrange
+	entry := ledger.AdjustmentEntry("adj-001", "res-001", shared.NewMoney(3000, "USD"), "Minibar")
+	entry.Sequence = 7
+
+	// Act
+	reversal := entry.Reversal("posted twice")
+
+	// Assert
+	assert.That(t, "reversal must be balanced", reversal.Validate(), nil)
+	assert.That(t, "source must name the entry", reversal.Source, ledger.SourceKey("reversal/7"))
+	assert.That(t, "guest ledger must be credited", reversal.Postings[0], ledger.Posting{Account: ledger.AccountGuestLedger, Credit: 3000})
+}
+
+// ============================================================================
+// PaymentMovements Tests
+// ============================================================================
+
+func Test_PaymentMovements_Entries_Should_Hold_Capture_And_Refund(t *testing.T) {
+	// Arrange
+	movements := ledger.PaymentMovements{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		Amount:        shared.NewMoney(20000, "USD"),
+		Authorized:    true,
+		Captured:      true,
+		Refunds:       []shared.Money{shared.NewMoney(5000, "USD")},
+	}
+
+	// Act
+	entries := movements.Entries()
+
+	// Assert
+	assert.That(t, "must have authorization, capture and refund", len(entries), 3)
+	assert.That(t, "capture must be second", entries[1].Kind, ledger.KindCapture)
+	assert.That(t, "capture must release the hold", len(entries[1].Postings), 4)
+	assert.That(t, "refund source must be numbered", entries[2].Source, ledger.SourceKey("payment/pay-001/refund/1"))
+	for _, e := range entries {
+		assert.That(t, "entry must be balanced", e.Validate(), nil)
+	}
+}
+
+func Test_PaymentMovements_Entries_Gift_Card_Should_Reduce_Liability(t *testing.T) {
+	// Arrange
+	movements := ledger.PaymentMovements{PaymentID: "pay-002", Amount: shared.NewMoney(10000, "USD"), GiftCard: true, Captured: true}
+
+	// Act
+	entries := movements.Entries()
+
+	// Assert
+	assert.That(t, "must have the redemption only", len(entries), 1)
+	assert.That(t, "kind must be gift card", entries[0].Kind, ledger.KindGiftCard)
+	assert.That(t, "liability must be debited", entries[0].Postings[0], ledger.Posting{Account: ledger.AccountGiftCardLiability, Debit: 10000})
+}
+
+func Test_PaymentMovements_Entries_Released_Should_Not_Refund(t *testing.T) {
+	// Arrange
+	movements := ledger.PaymentMovements{PaymentID: "pay-003", Amount: shared.NewMoney(4000, "USD"), Authorized: true, Released: true}
+
+	// Act
+	entries := movements.Entries()
+
+	// Assert
+	assert.That(t, "must hold and release", len(entries), 2)
+	assert.That(t, "second must be release", entries[1].Kind, ledger.KindRelease)
+}
+
+// ============================================================================
+// Payout Tests
+// ============================================================================
+
+func Test_Payout_Entry_Should_Expense_Fees(t *testing.T) {
+	// Arrange
+	payout := ledger.Payout{ID: "po-001", Amount: shared.NewMoney(10000, "USD"), Fees: shared.NewMoney(300, "USD")}
+
+	// Act
+	entry, err := payout.Entry()
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "entry must be balanced", entry.Validate(), nil)
+	assert.That(t, "bank must receive the net amount", entry.Postings[0], ledger.Posting{Account: ledger.AccountBank, Debit: 9700})
+}
+
+func Test_Payout_Entry_With_Fees_Of_The_Whole_Amount_Should_Fail(t *testing.T) {
+	// Arrange
+	payout := ledger.Payout{ID: "po-001", Amount: shared.NewMoney(300, "USD"), Fees: shared.NewMoney(300, "USD")}
+
+	// Act
+	_, err := payout.Entry()
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidPayout", errors.Is(err, ledger.ErrInvalidPayout), true)
+}
@@ -0,0 +1,15 @@
+package ledger
+
+import (
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// EntryRepository provides CRUD operations for the journal entries, keyed by their padded sequence.
+// The service only creates and reads entries; they are never updated or deleted.
+type EntryRepository resource.Access[EntryKey, Entry]
+
+// SourceRepository records the sequence of the entry that posted a movement, so each movement is posted once.
+type SourceRepository resource.Access[SourceKey, Sequence]
+
+// SettlementRepository provides CRUD operations for the settlements of imported payouts, keyed by payout ID.
+type SettlementRepository resource.Access[PayoutID, Settlement]
@@ -0,0 +1,127 @@
+package ledger
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Balance is the sum of the postings of an account in a currency.
+// Balance is on the normal side of the account: positive if it grows with debits and has more debits,
+// or grows with credits and has more credits.
+type Balance struct {
+	Account  AccountID   `json:"account"`
+	Name     string      `json:"name"`
+	Type     AccountType `json:"type"`
+	Currency string      `json:"currency"`
+	Debits   int64       `json:"debits"`
+	Credits  int64       `json:"credits"`
+	Balance  int64       `json:"balance"`
+}
+
+// TrialBalance lists the net debit or credit balance of every account with postings in a currency.
+// The journal is consistent if the debit balances equal the credit balances.
+type TrialBalance struct {
+	Currency string    `json:"currency"`
+	Lines    []Balance `json:"lines"`
+	Debits   int64     `json:"debits"`
+	Credits  int64     `json:"credits"`
+	Balanced bool      `json:"balanced"`
+}
+
+// Verification is the result of checking the hash chain of the journal.
+type Verification struct {
+	Entries  int      `json:"entries"`
+	Head     Sequence `json:"head"`
+	Valid    bool     `json:"valid"`
+	BrokenAt Sequence `json:"broken_at,omitempty"` // first entry that is missing, changed or unbalanced
+	Problem  string   `json:"problem,omitempty"`
+}
+
+// ComputeBalances sums the postings of the entries per account and currency,
+// ordered by currency and then like the chart of accounts.
+func ComputeBalances(entries []Entry) []Balance {
+	type key struct {
+		account  AccountID
+		currency string
+	}
+	sums := make(map[key]*Balance)
+	for _, e := range entries {
+		for _, p := range e.Postings {
+			k := key{p.Account, e.Currency}
+			b, ok := sums[k]
+			if !ok {
+				account, _ := FindAccount(p.Account)
+				b = &Balance{Account: p.Account, Name: account.Name, Type: account.Type, Currency: e.Currency}
+				sums[k] = b
+			}
+			b.Debits += p.Debit
+			b.Credits += p.Credit
+		}
+	}
+
+	balances := make([]Balance, 0, len(sums))
+	for _, b := range sums {
+		b.Balance = b.Credits - b.Debits
+		if account, _ := FindAccount(b.Account); account.DebitNormal {
+			b.Balance = -b.Balance
+		}
+		balances = append(balances, *b)
+	}
+	slices.SortFunc(balances, func(a, b Balance) int {
+		return cmp.Or(cmp.Compare(a.Currency, b.Currency), cmp.Compare(accountIndex(a.Account), accountIndex(b.Account)))
+	})
+	return balances
+}
+
+// NewTrialBalances groups the balances by currency into a trial balance each.
+func NewTrialBalances(balances []Balance) []TrialBalance {
+	var trials []TrialBalance
+	for _, b := range balances {
+		if len(trials) == 0 || trials[len(trials)-1].Currency != b.Currency {
+			trials = append(trials, TrialBalance{Currency: b.Currency})
+		}
+		t := &trials[len(trials)-1]
+		t.Lines = append(t.Lines, b)
+		if net := b.Debits - b.Credits; net > 0 {
+			t.Debits += net
+		} else {
+			t.Credits -= net
+		}
+	}
+	for i := range trials {
+		trials[i].Balanced = trials[i].Debits == trials[i].Credits
+	}
+	return trials
+}
+
+// VerifyChain checks that the entries, ordered by sequence, are numbered without gaps, balanced,
+// and that every entry has the hash of its content and of its predecessor.
+func VerifyChain(entries []Entry) Verification {
+	v := Verification{Entries: len(entries), Valid: true}
+	previous := ""
+	for i, e := range entries {
+		want := Sequence(i + 1)
+		switch {
+		case e.Sequence != want:
+			v.Valid, v.BrokenAt, v.Problem = false, want, fmt.Sprintf("entry %d is missing", want)
+		case e.PreviousHash != previous:
+			v.Valid, v.BrokenAt, v.Problem = false, want, "previous hash does not match"
+		case e.Hash != e.ComputeHash():
+			v.Valid, v.BrokenAt, v.Problem = false, want, "hash does not match the content"
+		case e.Validate() != nil:
+			v.Valid, v.BrokenAt, v.Problem = false, want, "entry is not balanced"
+		}
+		if !v.Valid {
+			return v
+		}
+		v.Head = e.Sequence
+		previous = e.Hash
+	}
+	return v
+}
+
+// accountIndex returns the position of the account in the chart of accounts.
+func accountIndex(id AccountID) int {
+	return slices.IndexFunc(Accounts, func(a Account) bool { return a.ID == id })
+}
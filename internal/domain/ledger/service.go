@@ -0,0 +1,302 @@
+package ledger
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Service posts the money movements to the journal and reports on it.
+type Service struct {
+	entryRepo      EntryRepository
+	sourceRepo     SourceRepository
+	settlementRepo SettlementRepository
+	metrics        shared.Metrics
+	mu             sync.Mutex // serializes the numbering and chaining of entries
+	head           Sequence
+	headHash       string
+	loaded         bool // head was read from the repository
+	lateMu         sync.Mutex
+	lateCounted    map[string]bool // late captures counted in the metrics since the start, by payment ID
+}
+
+// NewService creates a new ledger service.
+func NewService(entryRepo EntryRepository, sourceRepo SourceRepository) *Service {
+	return &Service{
+		entryRepo:  entryRepo,
+		sourceRepo: sourceRepo,
+	}
+}
+
+// WithSettlements stores the settlements of imported payout reports, which enables their import.
+func (s *Service) WithSettlements(settlementRepo SettlementRepository) *Service {
+	s.settlementRepo = settlementRepo
+	return s
+}
+
+// WithMetrics counts the payout alerts in the metrics.
+func (s *Service) WithMetrics(metrics shared.Metrics) *Service {
+	s.metrics = metrics
+	return s
+}
+
+// Post appends the balanced entry to the journal with the next sequence, the time and the hashes.
+// It returns ErrAlreadyPosted if an entry with the source was posted before.
+func (s *Service) Post(ctx context.Context, entry Entry) (*Entry, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.loadHeadLocked(ctx); err != nil {
+		return nil, err
+	}
+	if seq, err := s.sourceRepo.Read(ctx, entry.Source); err == nil && seq != nil {
+		return nil, fmt.Errorf("%w: %s in entry %d", ErrAlreadyPosted, entry.Source, *seq)
+	}
+
+	entry.Sequence = s.head + 1
+	entry.PostedAt = time.Now().UTC()
+	entry.PreviousHash = s.headHash
+	entry.Hash = entry.ComputeHash()
+
+	// The source is claimed first, so an instance posting the same movement concurrently fails here.
+	if err := s.sourceRepo.Create(ctx, entry.Source, entry.Sequence); err != nil {
+		s.loaded = false
+		return nil, fmt.Errorf("failed to claim source: %w", err)
+	}
+	if err := s.entryRepo.Create(ctx, entry.Sequence.Key(), entry); err != nil {
+		// Another instance may have taken the sequence, so read the head again next time.
+		s.loaded = false
+		_ = s.sourceRepo.Delete(ctx, entry.Source)
+		return nil, fmt.Errorf("failed to record entry: %w", err)
+	}
+	s.head, s.headHash = entry.Sequence, entry.Hash
+	return &entry, nil
+}
+
+// RecordPayment posts the movements of the payment that were not posted yet, e.g. the capture
+// after the authorization, and returns the posted entries.
+func (s *Service) RecordPayment(ctx context.Context, movements PaymentMovements) ([]Entry, error) {
+	var posted []Entry
+	for _, entry := range movements.Entries() {
+		e, err := s.Post(ctx, entry)
+		if errors.Is(err, ErrAlreadyPosted) {
+			continue
+		}
+		if err != nil {
+			return posted, err
+		}
+		posted = append(posted, *e)
+	}
+	return posted, nil
+}
+
+// RecordAdjustment posts a folio adjustment. It returns ErrAlreadyPosted if it was posted before.
+func (s *Service) RecordAdjustment(ctx context.Context, adjustmentID string, reservationID ReservationID, amount Money, reason string) (*Entry, error) {
+	return s.Post(ctx, AdjustmentEntry(adjustmentID, reservationID, amount, reason))
+}
+
+// RecordPayout posts a payout of the gateway. It returns ErrAlreadyPosted if it was posted before.
+func (s *Service) RecordPayout(ctx context.Context, payout Payout) (*Entry, error) {
+	entry, err := payout.Entry()
+	if err != nil {
+		return nil, err
+	}
+	return s.Post(ctx, entry)
+}
+
+// Reverse posts the reversal of the entry with the sequence. An entry is reversed at most once;
+// a second reversal returns ErrAlreadyPosted.
+func (s *Service) Reverse(ctx context.Context, seq Sequence, reason string) (*Entry, error) {
+	entry, err := s.entryRepo.Read(ctx, seq.Key())
+	if err != nil || entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	return s.Post(ctx, entry.Reversal(reason))
+}
+
+// Entries returns the entries of the journal, oldest first.
+// A non-empty reservation ID returns the entries of that reservation only.
+func (s *Service) Entries(ctx context.Context, reservationID ReservationID) ([]Entry, error) {
+	all, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entries: %w", err)
+	}
+	entries := make([]Entry, 0, len(all))
+	for _, e := range all {
+		if reservationID == "" || e.ReservationID == reservationID {
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+	return entries, nil
+}
+
+// Balances returns the balances of the accounts from the entries posted before asOf; zero includes all.
+func (s *Service) Balances(ctx context.Context, asOf time.Time) ([]Balance, error) {
+	entries, err := s.Entries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if !asOf.IsZero() {
+		entries = slices.DeleteFunc(entries, func(e Entry) bool { return !e.PostedAt.Before(asOf) })
+	}
+	return ComputeBalances(entries), nil
+}
+
+// TrialBalance returns the trial balance per currency from the entries posted before asOf; zero includes all.
+func (s *Service) TrialBalance(ctx context.Context, asOf time.Time) ([]TrialBalance, error) {
+	balances, err := s.Balances(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return NewTrialBalances(balances), nil
+}
+
+// Verify checks the hash chain of the whole journal.
+func (s *Service) Verify(ctx context.Context) (*Verification, error) {
+	entries, err := s.Entries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	v := VerifyChain(entries)
+	return &v, nil
+}
+
+// ImportPayout posts the payout of the report, unless it was recorded by hand before, and links it
+// to the captured payments it settles. It returns ErrAlreadyImported if the report was imported before.
+func (s *Service) ImportPayout(ctx context.Context, report PayoutReport) (*Settlement, error) {
+	if s.settlementRepo == nil {
+		return nil, ErrSettlementsDisabled
+	}
+	if err := report.Validate(); err != nil {
+		return nil, err
+	}
+	if existing, err := s.settlementRepo.Read(ctx, report.ID); err == nil && existing != nil {
+		return existing, fmt.Errorf("%w: %s", ErrAlreadyImported, report.ID)
+	}
+
+	var sequence Sequence
+	entry, err := s.RecordPayout(ctx, report.Payout())
+	switch {
+	case errors.Is(err, ErrAlreadyPosted):
+		posted, readErr := s.sourceRepo.Read(ctx, SourceKey("payout/"+string(report.ID)))
+		if readErr != nil || posted == nil {
+			return nil, fmt.Errorf("failed to read posted payout: %w", readErr)
+		}
+		sequence = *posted
+	case err != nil:
+		return nil, err
+	default:
+		sequence = entry.Sequence
+	}
+
+	entries, err := s.Entries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	settlement := NewSettlement(report, sequence, GatewayBalances(entries), time.Now().UTC())
+	if err := s.settlementRepo.Create(ctx, report.ID, settlement); err != nil {
+		return nil, fmt.Errorf("failed to record settlement: %w", err)
+	}
+	if settlement.Status == SettlementShort && s.metrics != nil {
+		s.metrics.IncCounter(MetricPayoutAlerts, "kind", "short")
+	}
+	return &settlement, nil
+}
+
+// Settlements returns the settlements of the imported payouts, latest arrival first.
+func (s *Service) Settlements(ctx context.Context) ([]Settlement, error) {
+	if s.settlementRepo == nil {
+		return nil, ErrSettlementsDisabled
+	}
+	settlements, err := s.settlementRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settlements: %w", err)
+	}
+	slices.SortFunc(settlements, func(a, b Settlement) int {
+		return cmp.Or(b.ArrivalDate.Compare(a.ArrivalDate), cmp.Compare(b.PayoutID, a.PayoutID))
+	})
+	return settlements, nil
+}
+
+// Outstanding returns the captures no imported payout settles yet, oldest first;
+// those not paid out within delay of the capture are late.
+func (s *Service) Outstanding(ctx context.Context, now time.Time, delay time.Duration) ([]OutstandingCapture, error) {
+	settlements, err := s.Settlements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.Entries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return Outstanding(GatewayBalances(entries), settlements, now, delay), nil
+}
+
+// CheckPayouts returns the late captures and the short payouts. Each late capture is counted
+// in the metrics once per process; short payouts are counted when they are imported.
+func (s *Service) CheckPayouts(ctx context.Context, now time.Time, delay time.Duration) (*PayoutAlerts, error) {
+	outstanding, err := s.Outstanding(ctx, now, delay)
+	if err != nil {
+		return nil, err
+	}
+	settlements, err := s.Settlements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alerts := &PayoutAlerts{Late: []OutstandingCapture{}, Short: []Settlement{}}
+	for _, o := range outstanding {
+		if o.Late {
+			alerts.Late = append(alerts.Late, o)
+		}
+	}
+	for _, settlement := range settlements {
+		if settlement.Status == SettlementShort {
+			alerts.Short = append(alerts.Short, settlement)
+		}
+	}
+	if s.metrics != nil {
+		s.lateMu.Lock()
+		if s.lateCounted == nil {
+			s.lateCounted = make(map[string]bool)
+		}
+		for _, o := range alerts.Late {
+			if !s.lateCounted[o.PaymentID] {
+				s.lateCounted[o.PaymentID] = true
+				s.metrics.IncCounter(MetricPayoutAlerts, "kind", "late")
+			}
+		}
+		s.lateMu.Unlock()
+	}
+	return alerts, nil
+}
+
+// loadHeadLocked reads the sequence and hash of the latest entry once.
+func (s *Service) loadHeadLocked(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	all, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read entries: %w", err)
+	}
+	s.head, s.headHash = 0, ""
+	for _, e := range all {
+		if e.Sequence > s.head {
+			s.head, s.headHash = e.Sequence, e.Hash
+		}
+	}
+	s.loaded = true
+	return nil
+}
@@ -0,0 +1,139 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createTestService() (*ledger.Service, *resource.InMemoryAccess[ledger.EntryKey, ledger.Entry]) {
+	entries := resource.NewInMemoryAccess[ledger.EntryKey, ledger.Entry]()
+	sources := resource.NewInMemoryAccess[ledger.SourceKey, ledger.Sequence]()
+	return ledger.NewService(entries, sources), entries
+}
+
+func capturedPayment() ledger.PaymentMovements {
+	return ledger.PaymentMovements{
+		PaymentID:     "pay-001",
+		ReservationID: "res-001",
+		Amount:        shared.NewMoney(20000, "USD"),
+		Authorized:    true,
+		Captured:      true,
+	}
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_RecordPayment_Twice_Should_Post_New_Movements_Only(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	movements := capturedPayment()
+	_, _ = svc.RecordPayment(ctx, movements)
+	movements.Refunds = []shared.Money{shared.NewMoney(5000, "USD")}
+
+	// Act
+	posted, err := svc.RecordPayment(ctx, movements)
+	entries, _ := svc.Entries(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the refund must be posted", len(posted), 1)
+	assert.That(t, "refund must be entry 3", posted[0].Sequence, ledger.Sequence(3))
+	assert.That(t, "journal must have 3 entries", len(entries), 3)
+}
+
+func Test_Service_RecordAdjustment_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.RecordAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(3000, "USD"), "Minibar")
+
+	// Act
+	_, err := svc.RecordAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(3000, "USD"), "Minibar")
+
+	// Assert
+	assert.That(t, "err must be ErrAlreadyPosted", errors.Is(err, ledger.ErrAlreadyPosted), true)
+}
+
+func Test_Service_TrialBalance_Should_Balance_And_Net_Accounts(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.RecordPayment(ctx, capturedPayment())
+	_, _ = svc.RecordPayout(ctx, ledger.Payout{ID: "po-001", Amount: shared.NewMoney(20000, "USD"), Fees: shared.NewMoney(500, "USD")})
+
+	// Act
+	trials, err := svc.TrialBalance(ctx, time.Time{})
+	balances, _ := svc.Balances(ctx, time.Time{})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "must have one currency", len(trials), 1)
+	assert.That(t, "trial balance must balance", trials[0].Balanced, true)
+	assert.That(t, "bank must be first", balances[0].Account, ledger.AccountBank)
+	assert.That(t, "bank must have the net payout", balances[0].Balance, int64(19500))
+	assert.That(t, "gateway clearing must be settled", balances[1].Balance, int64(0))
+	assert.That(t, "guest ledger must hold the deposit", balances[2].Balance, int64(-20000))
+}
+
+func Test_Service_Reverse_Should_Undo_Entry_Once(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	entry, _ := svc.RecordAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(3000, "USD"), "Minibar")
+	_, _ = svc.Reverse(ctx, entry.Sequence, "wrong room")
+
+	// Act
+	_, err := svc.Reverse(ctx, entry.Sequence, "wrong room")
+	balances, _ := svc.Balances(ctx, time.Time{})
+
+	// Assert
+	assert.That(t, "err must be ErrAlreadyPosted", errors.Is(err, ledger.ErrAlreadyPosted), true)
+	assert.That(t, "guest ledger must be zero", balances[0].Balance, int64(0))
+}
+
+func Test_Service_Verify_Should_Detect_Changed_Entry(t *testing.T) {
+	// Arrange
+	svc, entries := createTestService()
+	ctx := context.Background()
+	_, _ = svc.RecordPayment(ctx, capturedPayment())
+	_, _ = svc.RecordAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(3000, "USD"), "Minibar")
+	changed, _ := entries.Read(ctx, ledger.Sequence(2).Key())
+	changed.Postings[0].Debit, changed.Postings[1].Credit = 2, 2
+	_ = entries.Update(ctx, changed.Sequence.Key(), *changed)
+
+	// Act
+	v, err := svc.Verify(ctx)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "chain must be broken", v.Valid, false)
+	assert.That(t, "entry 2 must be reported", v.BrokenAt, ledger.Sequence(2))
+}
+
+func Test_Service_Verify_Intact_Journal_Should_Be_Valid(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.RecordPayment(ctx, capturedPayment())
+
+	// Act
+	v, _ := svc.Verify(ctx)
+
+	// Assert
+	assert.That(t, "chain must be valid", v.Valid, true)
+	assert.That(t, "head must be entry 2", v.Head, ledger.Sequence(2))
+}
@@ -0,0 +1,238 @@
+package ledger
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PayoutID identifies a payout of the gateway, e.g. po_123.
+type PayoutID string
+
+// MetricPayoutAlerts counts the late captures and short payouts, by kind (late, short).
+const MetricPayoutAlerts = "hotel_ledger_payout_alerts_total"
+
+// Settlement errors.
+var (
+	ErrInvalidPayoutReport = errors.New("payout report must have an ID, an arrival date and the payments it settles")
+	ErrAlreadyImported     = errors.New("payout report already imported")
+	ErrSettlementsDisabled = errors.New("settlements are not configured")
+)
+
+// PayoutReport is a payout as reported by the gateway: what it transferred to the bank and
+// which captured payments it settles.
+type PayoutReport struct {
+	ID          PayoutID  `json:"id"`
+	ArrivalDate time.Time `json:"arrival_date"` // when the funds arrive in the bank
+	Amount      Money     `json:"amount"`       // gross amount settled
+	Fees        Money     `json:"fees"`         // withheld by the gateway
+	PaymentIDs  []string  `json:"payment_ids"`
+}
+
+// Validate checks that the report can be settled; the amounts are checked by Payout.Entry.
+func (r PayoutReport) Validate() error {
+	if r.ID == "" || r.ArrivalDate.IsZero() || len(r.PaymentIDs) == 0 {
+		return ErrInvalidPayoutReport
+	}
+	return nil
+}
+
+// Payout returns the payout to post for the report.
+func (r PayoutReport) Payout() Payout {
+	return Payout{ID: string(r.ID), Amount: r.Amount, Fees: r.Fees}
+}
+
+// SettlementStatus compares the gross amount of a payout with the amount expected from its payments.
+type SettlementStatus string
+
+const (
+	SettlementMatched SettlementStatus = "matched"
+	SettlementShort   SettlementStatus = "short" // the gateway settled less than was captured
+	SettlementOver    SettlementStatus = "over"  // the gateway settled more, e.g. a payment the ledger lacks
+)
+
+// SettledPayment is a payment settled by a payout with the amount the gateway owes for it.
+type SettledPayment struct {
+	PaymentID     string        `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id,omitempty"`
+	Expected      Money         `json:"expected"` // captured minus refunded through the gateway
+	Found         bool          `json:"found"`    // the ledger has a capture of the payment in the payout currency
+}
+
+// Settlement links an imported payout to the captured payments it settles and records
+// how far the amount received differs from the amount expected.
+type Settlement struct {
+	PayoutID    PayoutID         `json:"payout_id"`
+	Sequence    Sequence         `json:"sequence"` // entry of the payout in the journal
+	ArrivalDate time.Time        `json:"arrival_date"`
+	Expected    Money            `json:"expected"`   // sum of the settled payments
+	Gross       Money            `json:"gross"`      // as reported by the gateway
+	Fees        Money            `json:"fees"`       // as reported by the gateway
+	Received    Money            `json:"received"`   // net amount in the bank
+	Difference  Money            `json:"difference"` // gross minus expected, negative if short
+	Status      SettlementStatus `json:"status"`
+	Payments    []SettledPayment `json:"payments"`
+	ImportedAt  time.Time        `json:"imported_at"`
+}
+
+// GatewayBalance is what the gateway owes for a captured payment according to the journal.
+type GatewayBalance struct {
+	PaymentID     string
+	ReservationID ReservationID
+	Amount        Money     // captured minus refunded
+	CapturedAt    time.Time // posting of the capture
+	Sequence      Sequence  // entry of the capture
+}
+
+// PaymentID returns the ID of the payment whose movement the entry posts, or "" for other entries.
+func (e Entry) PaymentID() string {
+	rest, ok := strings.CutPrefix(string(e.Source), "payment/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
+
+// GatewayBalances returns the balances of the captured card payments in the entries, by payment ID.
+// Gift card redemptions never reach the gateway, and reversed entries do not count.
+func GatewayBalances(entries []Entry) map[string]GatewayBalance {
+	reversed := make(map[Sequence]bool)
+	for _, e := range entries {
+		if e.Kind == KindReversal {
+			if seq, ok := strings.CutPrefix(string(e.Source), "reversal/"); ok {
+				if n, err := strconv.ParseUint(seq, 10, 64); err == nil {
+					reversed[Sequence(n)] = true
+				}
+			}
+		}
+	}
+	balances := make(map[string]GatewayBalance)
+	for _, e := range entries {
+		if reversed[e.Sequence] || (e.Kind != KindCapture && e.Kind != KindRefund) {
+			continue
+		}
+		id := e.PaymentID()
+		b, ok := balances[id]
+		switch {
+		case e.Kind == KindCapture:
+			b = GatewayBalance{PaymentID: id, ReservationID: e.ReservationID, CapturedAt: e.PostedAt, Sequence: e.Sequence,
+				Amount: Money{Amount: b.Amount.Amount + e.gatewayDebit(), Currency: e.Currency}}
+		case ok && e.Currency == b.Amount.Currency:
+			b.Amount.Amount -= e.gatewayCredit()
+		default:
+			continue // refund of a gift card or of a capture that was reversed
+		}
+		balances[id] = b
+	}
+	return balances
+}
+
+// gatewayDebit returns the amount debited to the gateway clearing account by the entry.
+func (e Entry) gatewayDebit() int64 {
+	var sum int64
+	for _, p := range e.Postings {
+		if p.Account == AccountGatewayClearing {
+			sum += p.Debit
+		}
+	}
+	return sum
+}
+
+// gatewayCredit returns the amount credited to the gateway clearing account by the entry.
+func (e Entry) gatewayCredit() int64 {
+	var sum int64
+	for _, p := range e.Postings {
+		if p.Account == AccountGatewayClearing {
+			sum += p.Credit
+		}
+	}
+	return sum
+}
+
+// NewSettlement compares the report with the gateway balances of its payments.
+// Payments without a capture in the payout currency are listed as not found and expected at zero,
+// so a payout including them is over.
+func NewSettlement(report PayoutReport, sequence Sequence, balances map[string]GatewayBalance, now time.Time) Settlement {
+	currency := report.Amount.Currency
+	s := Settlement{
+		PayoutID:    report.ID,
+		Sequence:    sequence,
+		ArrivalDate: report.ArrivalDate,
+		Expected:    Money{Currency: currency},
+		Gross:       report.Amount,
+		Fees:        Money{Amount: report.Fees.Amount, Currency: currency},
+		Received:    Money{Amount: report.Amount.Amount - report.Fees.Amount, Currency: currency},
+		ImportedAt:  now,
+	}
+	for _, id := range report.PaymentIDs {
+		payment := SettledPayment{PaymentID: id, Expected: Money{Currency: currency}}
+		if b, ok := balances[id]; ok && b.Amount.Currency == currency {
+			payment.ReservationID = b.ReservationID
+			payment.Expected = b.Amount
+			payment.Found = true
+		}
+		s.Expected.Amount += payment.Expected.Amount
+		s.Payments = append(s.Payments, payment)
+	}
+	s.Difference = Money{Amount: s.Gross.Amount - s.Expected.Amount, Currency: currency}
+	switch {
+	case s.Difference.Amount < 0:
+		s.Status = SettlementShort
+	case s.Difference.Amount > 0:
+		s.Status = SettlementOver
+	default:
+		s.Status = SettlementMatched
+	}
+	return s
+}
+
+// OutstandingCapture is a captured payment that no imported payout settles yet.
+type OutstandingCapture struct {
+	PaymentID     string        `json:"payment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Sequence      Sequence      `json:"sequence"`
+	Amount        Money         `json:"amount"`
+	CapturedAt    time.Time     `json:"captured_at"`
+	DueBy         time.Time     `json:"due_by"` // capture plus the expected payout delay
+	Late          bool          `json:"late"`
+}
+
+// Outstanding returns the captures with a positive balance that none of the settlements include,
+// oldest first; those not paid out within delay of the capture are late.
+func Outstanding(balances map[string]GatewayBalance, settlements []Settlement, now time.Time, delay time.Duration) []OutstandingCapture {
+	settled := make(map[string]bool)
+	for _, s := range settlements {
+		for _, p := range s.Payments {
+			settled[p.PaymentID] = true
+		}
+	}
+	var outstanding []OutstandingCapture
+	for id, b := range balances {
+		if settled[id] || b.Amount.Amount <= 0 {
+			continue
+		}
+		due := b.CapturedAt.Add(delay)
+		outstanding = append(outstanding, OutstandingCapture{
+			PaymentID:     id,
+			ReservationID: b.ReservationID,
+			Sequence:      b.Sequence,
+			Amount:        b.Amount,
+			CapturedAt:    b.CapturedAt,
+			DueBy:         due,
+			Late:          now.After(due),
+		})
+	}
+	slices.SortFunc(outstanding, func(a, b OutstandingCapture) int {
+		return a.CapturedAt.Compare(b.CapturedAt)
+	})
+	return outstanding
+}
+
+// PayoutAlerts are the problems of the payouts finance has to follow up with the gateway.
+type PayoutAlerts struct {
+	Late  []OutstandingCapture `json:"late"`  // captures not paid out in time
+	Short []Settlement         `json:"short"` // payouts below the amount expected
+}
@@ -0,0 +1,141 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/ledger"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func createSettlementTestService(t *testing.T) *ledger.Service {
+	t.Helper()
+	svc, _ := createTestService()
+	svc.WithSettlements(resource.NewInMemoryAccess[ledger.PayoutID, ledger.Settlement]())
+	ctx := context.Background()
+	for _, id := range []string{"pay-001", "pay-002"} {
+		movements := capturedPayment()
+		movements.PaymentID = id
+		if _, err := svc.RecordPayment(ctx, movements); err != nil {
+			t.Fatalf("failed to record payment: %v", err)
+		}
+	}
+	return svc
+}
+
+func payoutReport(amount int64, paymentIDs ...string) ledger.PayoutReport {
+	return ledger.PayoutReport{
+		ID:          "po_1",
+		ArrivalDate: time.Date(2030, 5, 3, 0, 0, 0, 0, time.UTC),
+		Amount:      shared.NewMoney(amount, "USD"),
+		Fees:        shared.NewMoney(600, "USD"),
+		PaymentIDs:  paymentIDs,
+	}
+}
+
+// ============================================================================
+// Settlement Tests
+// ============================================================================
+
+func Test_GatewayBalances_Should_Net_Refunds_And_Skip_Reversed_Captures(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	refunded := capturedPayment()
+	refunded.Refunds = []shared.Money{shared.NewMoney(5000, "USD")}
+	_, _ = svc.RecordPayment(ctx, refunded)
+	reversed := capturedPayment()
+	reversed.PaymentID = "pay-002"
+	posted, _ := svc.RecordPayment(ctx, reversed)
+	_, _ = svc.Reverse(ctx, posted[len(posted)-1].Sequence, "Duplicate")
+	entries, _ := svc.Entries(ctx, "")
+
+	// Act
+	balances := ledger.GatewayBalances(entries)
+
+	// Assert
+	assert.That(t, "refund must be netted", balances["pay-001"].Amount.Amount, int64(15000))
+	_, ok := balances["pay-002"]
+	assert.That(t, "reversed capture must not count", ok, false)
+}
+
+func Test_Service_ImportPayout_Should_Match_Captured_Payments(t *testing.T) {
+	// Arrange
+	svc := createSettlementTestService(t)
+
+	// Act
+	settlement, err := svc.ImportPayout(context.Background(), payoutReport(40000, "pay-001", "pay-002"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "status must be matched", settlement.Status, ledger.SettlementMatched)
+	assert.That(t, "expected must be the captures", settlement.Expected.Amount, int64(40000))
+	assert.That(t, "received must be net of fees", settlement.Received.Amount, int64(39400))
+	assert.That(t, "payout must be posted", settlement.Sequence > 0, true)
+}
+
+func Test_Service_ImportPayout_Below_Captures_Should_Be_Short(t *testing.T) {
+	// Arrange
+	svc := createSettlementTestService(t)
+
+	// Act
+	settlement, err := svc.ImportPayout(context.Background(), payoutReport(39000, "pay-001", "pay-002"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "status must be short", settlement.Status, ledger.SettlementShort)
+	assert.That(t, "difference must be negative", settlement.Difference.Amount, int64(-1000))
+}
+
+func Test_Service_ImportPayout_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createSettlementTestService(t)
+	ctx := context.Background()
+	_, _ = svc.ImportPayout(ctx, payoutReport(20000, "pay-001"))
+
+	// Act
+	_, err := svc.ImportPayout(ctx, payoutReport(20000, "pay-001"))
+
+	// Assert
+	assert.That(t, "err must be ErrAlreadyImported", errors.Is(err, ledger.ErrAlreadyImported), true)
+}
+
+func Test_Service_ImportPayout_Recorded_By_Hand_Should_Link_Existing_Entry(t *testing.T) {
+	// Arrange
+	svc := createSettlementTestService(t)
+	ctx := context.Background()
+	report := payoutReport(20000, "pay-001")
+	entry, _ := svc.RecordPayout(ctx, report.Payout())
+
+	// Act
+	settlement, err := svc.ImportPayout(ctx, report)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "settlement must link the posted entry", settlement.Sequence, entry.Sequence)
+}
+
+func Test_Service_CheckPayouts_Should_Report_Late_Captures_Only(t *testing.T) {
+	// Arrange
+	svc := createSettlementTestService(t)
+	ctx := context.Background()
+	_, _ = svc.ImportPayout(ctx, payoutReport(20000, "pay-001"))
+
+	// Act
+	early, _ := svc.CheckPayouts(ctx, time.Now(), 72*time.Hour)
+	late, err := svc.CheckPayouts(ctx, time.Now().Add(73*time.Hour), 72*time.Hour)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be late within the delay", len(early.Late), 0)
+	assert.That(t, "unsettled capture must be late", len(late.Late), 1)
+	assert.That(t, "late capture must be the unsettled one", late.Late[0].PaymentID, "pay-002")
+}
@@ -0,0 +1,91 @@
+// Package loyalty contains the Loyalty bounded context.
+// Guests earn points for what they spent on every completed stay and redeem them when booking,
+// which reduces the amount they pay. The points of a guest are a ledger of transactions; the balance is their sum.
+package loyalty
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type TransactionID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// ReservationID is the shared identifier of the stay the points were earned or redeemed for.
+type ReservationID = shared.ReservationID
+
+// TransactionKind is what changed the balance of a guest.
+type TransactionKind string
+
+const (
+	KindEarned   TransactionKind = "earned"   // points for a completed stay
+	KindRedeemed TransactionKind = "redeemed" // points taken off the price of a booking
+	KindRefunded TransactionKind = "refunded" // redeemed points given back, because the booking was cancelled or failed
+)
+
+var (
+	// ErrInvalidPoints is returned if fewer than one point would be redeemed.
+	ErrInvalidPoints = errors.New("at least one point must be redeemed")
+	// ErrInsufficientPoints is returned if the guest redeems more points than their balance.
+	ErrInsufficientPoints = errors.New("not enough points")
+	// ErrAlreadyRedeemed is returned if points were redeemed for the reservation before.
+	ErrAlreadyRedeemed = errors.New("points were already redeemed for this reservation")
+	// ErrPointsBusy is returned if another request changed the balance of the guest for longer than the lock wait.
+	ErrPointsBusy = errors.New("points are being redeemed by another request, try again")
+)
+
+// TransactionIDFor returns the ID of the transaction of the kind for the reservation.
+// A reservation earns, redeems and refunds at most once, so repeating a step finds the stored transaction.
+func TransactionIDFor(kind TransactionKind, reservationID ReservationID) TransactionID {
+	return TransactionID(string(kind) + "-" + string(reservationID))
+}
+
+// Transaction is a change of the balance of a guest (aggregate root).
+type Transaction struct {
+	ID            TransactionID
+	GuestID       GuestID
+	ReservationID ReservationID
+	Kind          TransactionKind
+	Points        int          // earned and refunded points are positive, redeemed points negative
+	Value         shared.Money // the spend points were earned for, or the amount redeemed points took off the price
+	At            time.Time
+}
+
+// NewTransaction creates a transaction of the kind for the reservation.
+func NewTransaction(kind TransactionKind, guestID GuestID, reservationID ReservationID, points int, value shared.Money) Transaction {
+	return Transaction{
+		ID:            TransactionIDFor(kind, reservationID),
+		GuestID:       guestID,
+		ReservationID: reservationID,
+		Kind:          kind,
+		Points:        points,
+		Value:         value,
+		At:            time.Now(),
+	}
+}
+
+// Account is the point balance of a guest with the transactions that make it up (read model).
+type Account struct {
+	GuestID      GuestID
+	Balance      int
+	Transactions []Transaction // newest first
+}
+
+// NewAccount sums the transactions of the guest into their account.
+func NewAccount(guestID GuestID, transactions []Transaction) Account {
+	account := Account{GuestID: guestID, Transactions: slices.Clone(transactions)}
+	for _, tx := range account.Transactions {
+		account.Balance += tx.Points
+	}
+	slices.SortFunc(account.Transactions, func(a, b Transaction) int {
+		return cmp.Or(b.At.Compare(a.At), cmp.Compare(b.ID, a.ID))
+	})
+	return account
+}
@@ -0,0 +1,175 @@
+package loyalty
+
+import (
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Event topics for Kafka.
+const (
+	EventTopicPointsEarned   = "loyalty.points_earned"
+	EventTopicPointsRedeemed = "loyalty.points_redeemed"
+	EventTopicPointsRefunded = "loyalty.points_refunded"
+)
+
+// ExampleEvents returns an example of every event published by this context.
+// They document the events in the event catalog.
+func ExampleEvents() []event.Event {
+	at := time.Date(2030, 6, 4, 11, 0, 0, 0, time.UTC)
+	return []event.Event{
+		NewEventPointsEarned().
+			WithGuestID("guest-123").
+			WithReservationID("res-123").
+			WithPoints(447).
+			WithSpent(shared.NewMoney(44700, "USD")).
+			WithBalance(1250).
+			WithEarnedAt(at),
+		NewEventPointsRedeemed().
+			WithGuestID("guest-123").
+			WithReservationID("res-456").
+			WithPoints(500).
+			WithValue(shared.NewMoney(500, "USD")).
+			WithBalance(750).
+			WithRedeemedAt(at),
+		NewEventPointsRefunded().
+			WithGuestID("guest-123").
+			WithReservationID("res-456").
+			WithPoints(500).
+			WithBalance(1250).
+			WithRefundedAt(at),
+	}
+}
+
+// EventPointsEarned is published when a guest earns points for a completed stay.
+type EventPointsEarned struct {
+	GuestID       GuestID       `json:"guest_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Points        int           `json:"points"`
+	Spent         shared.Money  `json:"spent"`
+	Balance       int           `json:"balance"` // balance after the points were earned
+	EarnedAt      time.Time     `json:"earned_at"`
+}
+
+func NewEventPointsEarned() *EventPointsEarned {
+	return &EventPointsEarned{}
+}
+
+func (e *EventPointsEarned) Topic() string { return EventTopicPointsEarned }
+
+func (e *EventPointsEarned) WithGuestID(id GuestID) *EventPointsEarned {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventPointsEarned) WithReservationID(id ReservationID) *EventPointsEarned {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventPointsEarned) WithPoints(points int) *EventPointsEarned {
+	e.Points = points
+	return e
+}
+
+func (e *EventPointsEarned) WithSpent(spent shared.Money) *EventPointsEarned {
+	e.Spent = spent
+	return e
+}
+
+func (e *EventPointsEarned) WithBalance(balance int) *EventPointsEarned {
+	e.Balance = balance
+	return e
+}
+
+func (e *EventPointsEarned) WithEarnedAt(t time.Time) *EventPointsEarned {
+	e.EarnedAt = t
+	return e
+}
+
+// EventPointsRedeemed is published when a guest redeems points for a booking.
+type EventPointsRedeemed struct {
+	GuestID       GuestID       `json:"guest_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Points        int           `json:"points"`
+	Value         shared.Money  `json:"value"` // amount the points took off the price
+	Balance       int           `json:"balance"`
+	RedeemedAt    time.Time     `json:"redeemed_at"`
+}
+
+func NewEventPointsRedeemed() *EventPointsRedeemed {
+	return &EventPointsRedeemed{}
+}
+
+func (e *EventPointsRedeemed) Topic() string { return EventTopicPointsRedeemed }
+
+func (e *EventPointsRedeemed) WithGuestID(id GuestID) *EventPointsRedeemed {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventPointsRedeemed) WithReservationID(id ReservationID) *EventPointsRedeemed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventPointsRedeemed) WithPoints(points int) *EventPointsRedeemed {
+	e.Points = points
+	return e
+}
+
+func (e *EventPointsRedeemed) WithValue(value shared.Money) *EventPointsRedeemed {
+	e.Value = value
+	return e
+}
+
+func (e *EventPointsRedeemed) WithBalance(balance int) *EventPointsRedeemed {
+	e.Balance = balance
+	return e
+}
+
+func (e *EventPointsRedeemed) WithRedeemedAt(t time.Time) *EventPointsRedeemed {
+	e.RedeemedAt = t
+	return e
+}
+
+// EventPointsRefunded is published when redeemed points are given back, because the booking was cancelled or failed.
+type EventPointsRefunded struct {
+	GuestID       GuestID       `json:"guest_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Points        int           `json:"points"`
+	Balance       int           `json:"balance"`
+	RefundedAt    time.Time     `json:"refunded_at"`
+}
+
+func NewEventPointsRefunded() *EventPointsRefunded {
+	return &EventPointsRefunded{}
+}
+
+func (e *EventPointsRefunded) Topic() string { return EventTopicPointsRefunded }
+
+func (e *EventPointsRefunded) WithGuestID(id GuestID) *EventPointsRefunded {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventPointsRefunded) WithReservationID(id ReservationID) *EventPointsRefunded {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventPointsRefunded) WithPoints(points int) *EventPointsRefunded {
+	e.Points = points
+	return e
+}
+
+func (e *EventPointsRefunded) WithBalance(balance int) *EventPointsRefunded {
+	e.Balance = balance
+	return e
+}
+
+func (e *EventPointsRefunded) WithRefundedAt(t time.Time) *EventPointsRefunded {
+	e.RefundedAt = t
+	return e
+}
@@ -0,0 +1,20 @@
+package loyalty
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// TransactionRepository provides CRUD operations for the point transactions of all guests.
+type TransactionRepository resource.Access[TransactionID, Transaction]
+
+// EventPublisher publishes the points earned, redeemed and refunded.
+type EventPublisher event.EventPublisher
+
+// GuestLocks serializes the changes of a guest's balance across all server instances.
+type GuestLocks interface {
+	// Lock waits until the guest is locked and returns the function that releases it, or ErrPointsBusy
+	Lock(ctx context.Context, guestID GuestID) (func(), error)
+}
@@ -0,0 +1,248 @@
+package loyalty
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Policy defines how many points a stay earns and how much a point is worth.
+type Policy struct {
+	PointsPerUnit int   // points earned per whole currency unit spent (100 in the smallest unit)
+	PointValue    int64 // amount in the smallest currency unit one point takes off the price
+}
+
+// DefaultPolicy returns the default policy: one point per dollar spent, worth one cent.
+func DefaultPolicy() Policy {
+	return Policy{PointsPerUnit: 1, PointValue: 1}
+}
+
+// PointsFor returns the points a stay earns for the amount spent.
+func (p Policy) PointsFor(spent shared.Money) int {
+	return int(spent.Amount / 100 * int64(p.PointsPerUnit))
+}
+
+// ValueOf returns the amount the points take off a price in the currency.
+func (p Policy) ValueOf(points int, currency string) shared.Money {
+	return shared.NewMoney(int64(points)*p.PointValue, currency)
+}
+
+// Service handles loyalty workflows.
+type Service struct {
+	txRepo    TransactionRepository
+	publisher EventPublisher
+	policy    Policy
+	locks     GuestLocks
+	mu        sync.RWMutex // guards policy, which can be reloaded at runtime
+	ledger    sync.Mutex   // serializes changes of balances without guest locks, so points cannot be redeemed twice
+}
+
+// NewService creates a new loyalty service.
+func NewService(txRepo TransactionRepository, publisher EventPublisher, policy Policy) *Service {
+	return &Service{
+		txRepo:    txRepo,
+		publisher: publisher,
+		policy:    policy,
+	}
+}
+
+// WithGuestLocks serializes the changes of each guest's balance with the locks instead of the service's mutex,
+// so bookings on several server instances cannot redeem the same points twice.
+func (s *Service) WithGuestLocks(locks GuestLocks) *Service {
+	s.locks = locks
+	return s
+}
+
+// SetPolicy replaces the policy at runtime.
+// Points are fixed when they are earned or redeemed, so transactions keep their points and value.
+func (s *Service) SetPolicy(policy Policy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+}
+
+// Policy returns the policy in effect.
+func (s *Service) Policy() Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// AccountOf returns the balance and the transactions of the guest.
+// A guest without transactions has an empty account.
+func (s *Service) AccountOf(ctx context.Context, guestID GuestID) (*Account, error) {
+	transactions, err := s.transactionsOf(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	account := NewAccount(guestID, transactions)
+	return &account, nil
+}
+
+// Accrue credits the guest with the points the completed stay earns for the amount spent.
+// A stay earns once; accruing it again returns the stored transaction. A stay that earns no points returns nil.
+func (s *Service) Accrue(ctx context.Context, guestID GuestID, reservationID ReservationID, spent shared.Money) (*Transaction, error) {
+	points := s.Policy().PointsFor(spent)
+	if points <= 0 {
+		return nil, nil
+	}
+
+	unlock, err := s.lockGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if stored, ok := s.transaction(ctx, TransactionIDFor(KindEarned, reservationID)); ok {
+		return stored, nil
+	}
+	tx := NewTransaction(KindEarned, guestID, reservationID, points, spent)
+	balance, err := s.record(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := NewEventPointsEarned().
+		WithGuestID(guestID).
+		WithReservationID(reservationID).
+		WithPoints(points).
+		WithSpent(spent).
+		WithBalance(balance).
+		WithEarnedAt(tx.At)
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	return &tx, nil
+}
+
+// Redeem takes the points off the balance of the guest for the booking of the reservation and returns the
+// transaction, whose value is the amount to take off the price. Points worth more than the price are not redeemed.
+// A redemption whose event cannot be published is rolled back, so the guest keeps the points.
+func (s *Service) Redeem(ctx context.Context, guestID GuestID, reservationID ReservationID, points int, price shared.Money) (*Transaction, error) {
+	policy := s.Policy()
+	if policy.PointValue > 0 {
+		points = min(points, int(price.Amount/policy.PointValue))
+	}
+	if points <= 0 {
+		return nil, ErrInvalidPoints
+	}
+
+	unlock, err := s.lockGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	id := TransactionIDFor(KindRedeemed, reservationID)
+	if _, ok := s.transaction(ctx, id); ok {
+		return nil, ErrAlreadyRedeemed
+	}
+	transactions, err := s.transactionsOf(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if NewAccount(guestID, transactions).Balance < points {
+		return nil, ErrInsufficientPoints
+	}
+	tx := NewTransaction(KindRedeemed, guestID, reservationID, -points, policy.ValueOf(points, price.Currency))
+	balance, err := s.record(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := NewEventPointsRedeemed().
+		WithGuestID(guestID).
+		WithReservationID(reservationID).
+		WithPoints(points).
+		WithValue(tx.Value).
+		WithBalance(balance).
+		WithRedeemedAt(tx.At)
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		_ = s.txRepo.Delete(context.WithoutCancel(ctx), tx.ID)
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	return &tx, nil
+}
+
+// RefundRedemption gives the points redeemed for the reservation back, because its booking was cancelled or failed.
+// A reservation without redeemed points returns nil; refunding it again returns the stored transaction.
+func (s *Service) RefundRedemption(ctx context.Context, reservationID ReservationID) (*Transaction, error) {
+	redeemed, ok := s.transaction(ctx, TransactionIDFor(KindRedeemed, reservationID))
+	if !ok {
+		return nil, nil
+	}
+	unlock, err := s.lockGuest(ctx, redeemed.GuestID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if stored, ok := s.transaction(ctx, TransactionIDFor(KindRefunded, reservationID)); ok {
+		return stored, nil
+	}
+	tx := NewTransaction(KindRefunded, redeemed.GuestID, reservationID, -redeemed.Points, redeemed.Value)
+	balance, err := s.record(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := NewEventPointsRefunded().
+		WithGuestID(tx.GuestID).
+		WithReservationID(reservationID).
+		WithPoints(tx.Points).
+		WithBalance(balance).
+		WithRefundedAt(tx.At)
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	return &tx, nil
+}
+
+// lockGuest locks the balance of the guest with the guest locks, or the service if none are configured,
+// and returns the function that releases it.
+func (s *Service) lockGuest(ctx context.Context, guestID GuestID) (func(), error) {
+	if s.locks == nil {
+		s.ledger.Lock()
+		return s.ledger.Unlock, nil
+	}
+	unlock, err := s.locks.Lock(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock points of guest %s: %w", guestID, err)
+	}
+	return unlock, nil
+}
+
+// record stores the transaction and returns the new balance of its guest.
+func (s *Service) record(ctx context.Context, tx Transaction) (int, error) {
+	if err := s.txRepo.Create(ctx, tx.ID, tx); err != nil {
+		return 0, fmt.Errorf("failed to persist transaction: %w", err)
+	}
+	transactions, err := s.transactionsOf(ctx, tx.GuestID)
+	if err != nil {
+		return 0, err
+	}
+	return NewAccount(tx.GuestID, transactions).Balance, nil
+}
+
+// transaction returns the stored transaction, if there is one.
+func (s *Service) transaction(ctx context.Context, id TransactionID) (*Transaction, bool) {
+	tx, err := s.txRepo.Read(ctx, id)
+	if err != nil || tx == nil {
+		return nil, false
+	}
+	return tx, true
+}
+
+// transactionsOf returns all transactions of the guest.
+func (s *Service) transactionsOf(ctx context.Context, guestID GuestID) ([]Transaction, error) {
+	all, err := s.txRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	var transactions []Transaction
+	for _, tx := range all {
+		if tx.GuestID == guestID {
+			transactions = append(transactions, tx)
+		}
+	}
+	return transactions, nil
+}
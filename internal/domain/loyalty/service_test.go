@@ -0,0 +1,241 @@
+package loyalty_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockEventPublisher struct {
+	published []event.Event
+	err       error
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.published = append(m.published, evt)
+	return nil
+}
+
+// mockGuestLocks records the locked guests and fails with err if set.
+type mockGuestLocks struct {
+	locked []loyalty.GuestID
+	err    error
+}
+
+func (m *mockGuestLocks) Lock(ctx context.Context, guestID loyalty.GuestID) (func(), error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.locked = append(m.locked, guestID)
+	return func() {}, nil
+}
+
+func createTestService() (*loyalty.Service, *mockEventPublisher) {
+	publisher := &mockEventPublisher{}
+	svc := loyalty.NewService(resource.NewInMemoryAccess[loyalty.TransactionID, loyalty.Transaction](), publisher, loyalty.DefaultPolicy())
+	return svc, publisher
+}
+
+func usd(amount int64) shared.Money {
+	return shared.NewMoney(amount, "USD")
+}
+
+// ============================================================================
+// Accrue Tests
+// ============================================================================
+
+func Test_Service_Accrue_Should_Earn_Points_For_Spend(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+	ctx := context.Background()
+
+	// Act
+	tx, err := svc.Accrue(ctx, "guest-1", "res-001", usd(44750))
+	again, _ := svc.Accrue(ctx, "guest-1", "res-001", usd(44750))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "whole dollars must earn points", tx.Points, 447)
+	assert.That(t, "accruing again must return the stored transaction", again.ID, tx.ID)
+	assert.That(t, "balance must count the stay once", account.Balance, 447)
+	assert.That(t, "event must be published once", len(publisher.published), 1)
+	assert.That(t, "event must be points earned", publisher.published[0].Topic(), loyalty.EventTopicPointsEarned)
+}
+
+func Test_Service_Accrue_Under_One_Unit_Should_Earn_Nothing(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+
+	// Act
+	tx, err := svc.Accrue(context.Background(), "guest-1", "res-001", usd(99))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "no transaction must be recorded", tx == nil, true)
+	assert.That(t, "no event must be published", len(publisher.published), 0)
+}
+
+// ============================================================================
+// Redeem Tests
+// ============================================================================
+
+func Test_Service_Redeem_Should_Reduce_Balance(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+
+	// Act
+	tx, err := svc.Redeem(ctx, "guest-1", "res-002", 300, usd(20000))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "value must be taken off the price", tx.Value, usd(300))
+	assert.That(t, "balance must be reduced", account.Balance, 200)
+	assert.That(t, "event must be points redeemed", publisher.published[1].Topic(), loyalty.EventTopicPointsRedeemed)
+}
+
+func Test_Service_Redeem_More_Than_Price_Should_Redeem_Price(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+
+	// Act
+	tx, err := svc.Redeem(ctx, "guest-1", "res-002", 500, usd(120))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the price must be redeemed", tx.Points, -120)
+	assert.That(t, "balance must keep the rest", account.Balance, 380)
+}
+
+func Test_Service_Redeem_More_Than_Balance_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(10000))
+
+	// Act
+	_, err := svc.Redeem(ctx, "guest-1", "res-002", 101, usd(20000))
+
+	// Assert
+	assert.That(t, "err must be ErrInsufficientPoints", err, loyalty.ErrInsufficientPoints)
+}
+
+func Test_Service_Redeem_Twice_For_Reservation_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+	_, _ = svc.Redeem(ctx, "guest-1", "res-002", 100, usd(20000))
+
+	// Act
+	_, err := svc.Redeem(ctx, "guest-1", "res-002", 100, usd(20000))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be ErrAlreadyRedeemed", err, loyalty.ErrAlreadyRedeemed)
+	assert.That(t, "points must be redeemed once", account.Balance, 400)
+}
+
+func Test_Service_Redeem_Failing_To_Publish_Should_Keep_Points(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+	publisher.err = errors.New("broker down")
+
+	// Act
+	_, err := svc.Redeem(ctx, "guest-1", "res-002", 100, usd(20000))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "balance must be unchanged", account.Balance, 500)
+}
+
+func Test_Service_Redeem_With_Guest_Locks_Should_Lock_Guest(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+	locks := &mockGuestLocks{}
+	svc.WithGuestLocks(locks)
+
+	// Act
+	_, err := svc.Redeem(ctx, "guest-1", "res-002", 300, usd(20000))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "guest must be locked", locks.locked, []loyalty.GuestID{"guest-1"})
+}
+
+func Test_Service_Redeem_When_Guest_Busy_Should_Keep_Points(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+	svc.WithGuestLocks(&mockGuestLocks{err: loyalty.ErrPointsBusy})
+
+	// Act
+	_, err := svc.Redeem(ctx, "guest-1", "res-002", 300, usd(20000))
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be ErrPointsBusy", errors.Is(err, loyalty.ErrPointsBusy), true)
+	assert.That(t, "balance must be kept", account.Balance, 500)
+}
+
+// ============================================================================
+// RefundRedemption Tests
+// ============================================================================
+
+func Test_Service_RefundRedemption_Should_Restore_Balance_Once(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+	ctx := context.Background()
+	_, _ = svc.Accrue(ctx, "guest-1", "res-001", usd(50000))
+	_, _ = svc.Redeem(ctx, "guest-1", "res-002", 300, usd(20000))
+
+	// Act
+	tx, err := svc.RefundRedemption(ctx, "res-002")
+	_, _ = svc.RefundRedemption(ctx, "res-002")
+
+	// Assert
+	account, _ := svc.AccountOf(ctx, "guest-1")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "redeemed points must be refunded", tx.Points, 300)
+	assert.That(t, "balance must be restored once", account.Balance, 500)
+	assert.That(t, "event must be published once", len(publisher.published), 3)
+	assert.That(t, "newest transaction must be the refund", account.Transactions[0].Kind, loyalty.KindRefunded)
+}
+
+func Test_Service_RefundRedemption_Without_Redemption_Should_Do_Nothing(t *testing.T) {
+	// Arrange
+	svc, publisher := createTestService()
+
+	// Act
+	tx, err := svc.RefundRedemption(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "no transaction must be recorded", tx == nil, true)
+	assert.That(t, "no event must be published", len(publisher.published), 0)
+}
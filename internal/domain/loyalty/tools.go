@@ -0,0 +1,80 @@
+package loyalty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ScopeRead is the scope a service account needs to call the loyalty tools.
+const ScopeRead = "loyalty:read"
+
+// MaxToolTransactions is the number of latest transactions get_loyalty_balance returns.
+const MaxToolTransactions = 10
+
+// ErrMissingGuest is returned if get_loyalty_balance is called by staff without a guest.
+var ErrMissingGuest = errors.New("guest_id is required")
+
+// RegisterTools registers all loyalty MCP tools with the server.
+func RegisterTools(server *mcp.Server, service *Service) {
+	server.RegisterTool(shared.GuardTool(newGetLoyaltyBalanceTool(service), ScopeRead))
+}
+
+// newGetLoyaltyBalanceTool creates a tool for the point balance of a guest.
+func newGetLoyaltyBalanceTool(service *Service) mcp.Tool {
+	return mcp.NewTool(
+		"get_loyalty_balance",
+		"Get the loyalty point balance of a guest and what it is worth when booking. Returns the balance, its value in the smallest unit of the currency booked in and the latest transactions, newest first. Guests always get their own balance.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"guest_id": mcp.NewStringProperty("The guest's account ID (OIDC subject); required for staff and service accounts"),
+			},
+			nil,
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			guestID, _ := params.Arguments["guest_id"].(string)
+			if p, ok := shared.PrincipalFromContext(ctx); ok && p.Type == shared.PrincipalGuest {
+				guestID = p.Subject
+			}
+			if guestID == "" {
+				return mcp.ToolsCallResult{}, ErrMissingGuest
+			}
+
+			account, err := service.AccountOf(ctx, GuestID(guestID))
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			policy := service.Policy()
+			type transaction struct {
+				Kind          string    `json:"kind"`
+				ReservationID string    `json:"reservation_id"`
+				Points        int       `json:"points"`
+				At            time.Time `json:"at"`
+			}
+			result := struct {
+				GuestID      string        `json:"guest_id"`
+				Balance      int           `json:"balance"`
+				Value        int64         `json:"value"`       // in the smallest unit of the currency booked in
+				PointValue   int64         `json:"point_value"` // value of one point
+				Transactions []transaction `json:"transactions"`
+			}{
+				GuestID:      guestID,
+				Balance:      account.Balance,
+				Value:        int64(account.Balance) * policy.PointValue,
+				PointValue:   policy.PointValue,
+				Transactions: []transaction{},
+			}
+			for _, tx := range account.Transactions[:min(len(account.Transactions), MaxToolTransactions)] {
+				result.Transactions = append(result.Transactions, transaction{Kind: string(tx.Kind), ReservationID: string(tx.ReservationID), Points: tx.Points, At: tx.At})
+			}
+			data, _ := json.MarshalIndent(result, "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
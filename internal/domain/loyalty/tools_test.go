@@ -0,0 +1,101 @@
+package loyalty_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/loyalty"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Tools Test Helpers
+// ============================================================================
+
+func getLoyaltyBalanceTool(t *testing.T, svc *loyalty.Service) mcp.Tool {
+	t.Helper()
+	server := mcp.NewServer("test-server", "1.0.0")
+	loyalty.RegisterTools(server, svc)
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_loyalty_balance" {
+			return tool
+		}
+	}
+	t.Fatal("get_loyalty_balance must be registered")
+	return mcp.Tool{}
+}
+
+type balanceResult struct {
+	GuestID string `json:"guest_id"`
+	Balance int    `json:"balance"`
+	Value   int64  `json:"value"`
+}
+
+// ============================================================================
+// GetLoyaltyBalanceTool Tests
+// ============================================================================
+
+func Test_GetLoyaltyBalanceTool_Should_Return_Balance_Of_Guest(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	_, _ = svc.Accrue(context.Background(), "guest-1", "res-001", usd(25000))
+	tool := getLoyaltyBalanceTool(t, svc)
+	params := mcp.ToolsCallParams{Name: "get_loyalty_balance", Arguments: map[string]any{"guest_id": "guest-1"}}
+
+	// Act
+	result, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	var balance balanceResult
+	_ = json.Unmarshal([]byte(result.Content[0].Text), &balance)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "balance must be returned", balance.Balance, 250)
+	assert.That(t, "value must be returned", balance.Value, int64(250))
+}
+
+func Test_GetLoyaltyBalanceTool_As_Guest_Should_Return_Own_Balance(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	_, _ = svc.Accrue(context.Background(), "guest-1", "res-001", usd(25000))
+	tool := getLoyaltyBalanceTool(t, svc)
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest, Subject: "guest-2"})
+	params := mcp.ToolsCallParams{Name: "get_loyalty_balance", Arguments: map[string]any{"guest_id": "guest-1"}}
+
+	// Act
+	result, err := tool.Handler(ctx, params)
+
+	// Assert
+	var balance balanceResult
+	_ = json.Unmarshal([]byte(result.Content[0].Text), &balance)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "guest must get their own balance", balance.GuestID, "guest-2")
+	assert.That(t, "balance must be empty", balance.Balance, 0)
+}
+
+func Test_GetLoyaltyBalanceTool_Without_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	tool := getLoyaltyBalanceTool(t, svc)
+
+	// Act
+	_, err := tool.Handler(context.Background(), mcp.ToolsCallParams{Name: "get_loyalty_balance", Arguments: map[string]any{}})
+
+	// Assert
+	assert.That(t, "err must be ErrMissingGuest", err, loyalty.ErrMissingGuest)
+}
+
+func Test_GetLoyaltyBalanceTool_Without_Scope_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestService()
+	tool := getLoyaltyBalanceTool(t, svc)
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalService, ClientID: "agent"})
+
+	// Act
+	_, err := tool.Handler(ctx, mcp.ToolsCallParams{Name: "get_loyalty_balance", Arguments: map[string]any{"guest_id": "guest-1"}})
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
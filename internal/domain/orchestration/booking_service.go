@@ -5,8 +5,13 @@ package orchestration
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -17,16 +22,41 @@ import (
 // with proper compensation logic on failures.
 //
 // In event-driven mode:
-// - InitiateBooking creates a reservation and publishes reservation.created
-// - Payment context subscribes and processes payment, publishing payment.authorized/failed
+// - OnReservationCreated starts the saga and authorizes the payment of a new reservation
+// - Payment context publishes payment.authorized/failed
 // - Event handlers capture payment and confirm reservation
 // - Compensation is handled via event subscriptions on failure events
+//
+// With WithCaptureOnCheckIn, the payment is only authorized at booking and captured
+// when the guest checks in (reservation.activated), see OnReservationActivated.
+//
+// Every step and compensation is recorded in the booking saga of the reservation
+// if a saga log is configured via WithSagaLog.
 type BookingService struct {
 	reservationService  *reservation.Service
 	paymentService      *payment.Service
 	notificationService NotificationService
+	sagaRepo            SagaRepository
+	publisher           EventPublisher
+	metrics             shared.Metrics
+	tracer              shared.Tracer
+	captureOnCheckIn    bool
+	captureRetry        CaptureRetry
+	now                 func() time.Time
+	mu                  sync.Mutex
 }
 
+// CaptureRetry is how often a capture at check-in is attempted and how long to wait before the
+// second attempt; the wait doubles with every further attempt.
+type CaptureRetry struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// MetricSagaDuration is the histogram of the time from the start to the end of the booking sagas
+// in seconds, by outcome (completed, compensated or failed).
+const MetricSagaDuration = "hotel_booking_saga_duration_seconds"
+
 // NewBookingService creates a new orchestration service.
 func NewBookingService(
 	reservationSvc *reservation.Service,
@@ -37,9 +67,80 @@ func NewBookingService(
 		reservationService:  reservationSvc,
 		paymentService:      paymentSvc,
 		notificationService: notificationSvc,
+		now:                 time.Now,
 	}
 }
 
+// WithSagaLog records the booking sagas in the repository and publishes their lifecycle events.
+// Without it, compensations still run but leave no trace.
+func (s *BookingService) WithSagaLog(repo SagaRepository, publisher EventPublisher) *BookingService {
+	s.sagaRepo = repo
+	s.publisher = publisher
+	return s
+}
+
+// WithMetrics records the duration of the ended booking sagas in the metrics.
+// Only sagas recorded via WithSagaLog are measured, because only they know when they started.
+func (s *BookingService) WithMetrics(metrics shared.Metrics) *BookingService {
+	s.metrics = metrics
+	return s
+}
+
+// WithTracer records the booking workflows and the steps of the sagas as spans of the traces of their requests and events.
+func (s *BookingService) WithTracer(tracer shared.Tracer) *BookingService {
+	s.tracer = tracer
+	return s
+}
+
+// WithCaptureOnCheckIn confirms reservations once their payment is authorized and defers the capture
+// to the check-in, retrying a declined capture as configured. Captures that still fail cancel the stay.
+// CompleteBooking is not affected; it still captures right away.
+func (s *BookingService) WithCaptureOnCheckIn(retry CaptureRetry) *BookingService {
+	s.captureOnCheckIn = true
+	s.captureRetry = retry
+	return s
+}
+
+// CapturesOnCheckIn reports whether payments are captured at check-in instead of at authorization.
+func (s *BookingService) CapturesOnCheckIn() bool {
+	return s.captureOnCheckIn
+}
+
+// GetSaga returns the booking saga of the reservation.
+func (s *BookingService) GetSaga(ctx context.Context, reservationID shared.ReservationID) (*Saga, error) {
+	if s.sagaRepo == nil {
+		return nil, ErrSagaNotFound
+	}
+	saga, err := s.sagaRepo.Read(ctx, BookingSagaID(reservationID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSagaNotFound, err)
+	}
+	return saga, nil
+}
+
+// FailedSagas returns the compensated and failed booking sagas, the most recently updated first, at most limit.
+// They are the bookings that went wrong, so ops look at them first during an incident.
+func (s *BookingService) FailedSagas(ctx context.Context, limit int) ([]Saga, error) {
+	if s.sagaRepo == nil {
+		return nil, nil
+	}
+	sagas, err := s.sagaRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sagas: %w", err)
+	}
+	var failed []Saga
+	for _, saga := range sagas {
+		if saga.Status == SagaCompensated || saga.Status == SagaFailed {
+			failed = append(failed, saga)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].UpdatedAt.After(failed[j].UpdatedAt) })
+	if len(failed) > limit {
+		failed = failed[:limit]
+	}
+	return failed, nil
+}
+
 // InitiateBooking starts the booking saga by creating a reservation.
 // This publishes a reservation.created event that triggers payment processing.
 func (s *BookingService) InitiateBooking(
@@ -65,6 +166,9 @@ func (s *BookingService) InitiateBooking(
 
 // CompleteBooking orchestrates the full booking workflow synchronously.
 // This is used when direct method calls are preferred over events.
+// If a step fails, the completed steps are compensated in reverse order:
+// a captured payment is refunded and the reservation is cancelled.
+// Authorizations are not voided; the gateway releases them when they expire.
 func (s *BookingService) CompleteBooking(
 	ctx context.Context,
 	reservationID shared.ReservationID,
@@ -75,30 +179,53 @@ func (s *BookingService) CompleteBooking(
 	amount shared.Money,
 	guests []reservation.GuestInfo,
 	paymentMethod string,
-) (*reservation.Reservation, error) {
-	// Step 1: Create reservation
-	res, err := s.createReservationStep(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
-	if err != nil {
-		return nil, err
-	}
+) (_ *reservation.Reservation, err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.CompleteBooking", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
 
-	// Step 2: Authorize payment
-	pay, err := s.authorizePaymentStep(ctx, paymentID, reservationID, amount, paymentMethod)
-	if err != nil {
-		return nil, err
-	}
+	saga := s.startSaga(ctx, reservationID)
+	saga.PaymentID = paymentID
 
-	// Step 3: Capture payment
-	if err := s.capturePaymentStep(ctx, pay.ID, reservationID); err != nil {
-		return nil, err
+	var res *reservation.Reservation
+	steps := []sagaStep{
+		{
+			name: StepCreateReservation,
+			run: func(ctx context.Context) (err error) {
+				res, err = s.reservationService.CreateReservation(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
+				return err
+			},
+			compensate: func(ctx context.Context, reason string) error {
+				return s.cancelReservation(ctx, reservationID, reason)
+			},
+		},
+		{
+			name: StepAuthorizePayment,
+			run: func(ctx context.Context) error {
+				_, err := s.paymentService.AuthorizePayment(ctx, paymentID, reservationID, amount, paymentMethod)
+				return err
+			},
+		},
+		{
+			name: StepCapturePayment,
+			run: func(ctx context.Context) error {
+				return s.paymentService.CapturePayment(ctx, paymentID)
+			},
+			compensate: func(ctx context.Context, reason string) error {
+				return s.paymentService.RefundPayment(ctx, paymentID)
+			},
+		},
+		{
+			name: StepConfirmReservation,
+			run: func(ctx context.Context) error {
+				return s.reservationService.ConfirmReservation(ctx, reservationID)
+			},
+		},
 	}
-
-	// Step 4: Confirm reservation
-	if err := s.confirmReservationStep(ctx, reservationID, pay.ID); err != nil {
+	if err := s.runSaga(ctx, saga, steps); err != nil {
 		return nil, err
 	}
 
-	// Step 5: Send notification (best effort)
+	// Send notification (best effort)
 	_ = s.notificationService.SendReservationConfirmation(ctx, res)
 
 	return s.reservationService.GetReservation(ctx, reservationID)
@@ -109,7 +236,10 @@ func (s *BookingService) CancelBookingWithRefund(
 	ctx context.Context,
 	reservationID shared.ReservationID,
 	reason string,
-) error {
+) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.CancelBookingWithRefund", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
 	res, err := s.reservationService.GetReservation(ctx, reservationID)
 	if err != nil {
 		return fmt.Errorf("failed to get reservation: %w", err)
@@ -124,13 +254,57 @@ func (s *BookingService) CancelBookingWithRefund(
 	return nil
 }
 
+// OnReservationCreated handles the reservation.created event.
+// It starts the booking saga and authorizes the payment of the reservation at the exchange rate
+// snapshot of the event, if any. A failed authorization publishes payment.failed, which triggers the compensation.
+func (s *BookingService) OnReservationCreated(
+	ctx context.Context,
+	reservationID shared.ReservationID,
+	paymentID payment.PaymentID,
+	amount shared.Money,
+	paymentMethod string,
+	fx *shared.FXSnapshot,
+) (_ *payment.Payment, err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.OnReservationCreated", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		saga.PaymentID = paymentID
+		if !saga.Completed(StepCreateReservation) {
+			saga.CompleteStep(StepCreateReservation, s.now())
+		}
+	})
+
+	return s.paymentService.AuthorizePaymentForReservation(ctx, paymentID, reservationID, amount, paymentMethod, fx)
+}
+
 // OnPaymentAuthorized handles the payment.authorized event.
-// It captures the payment and confirms the reservation.
-func (s *BookingService) OnPaymentAuthorized(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) error {
+// It captures the payment; the payment.captured event then confirms the reservation.
+// With capture on check-in, it confirms the reservation right away and leaves the payment authorized.
+func (s *BookingService) OnPaymentAuthorized(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.OnPaymentAuthorized", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		saga.PaymentID = paymentID
+		if !saga.Completed(StepAuthorizePayment) {
+			saga.CompleteStep(StepAuthorizePayment, s.now())
+		}
+	})
+
+	if s.captureOnCheckIn {
+		return s.confirmAuthorized(ctx, reservationID)
+	}
+
 	// Capture the payment
 	if err := s.paymentService.CapturePayment(ctx, paymentID); err != nil {
 		// Compensation: cancel the reservation
-		_ = s.reservationService.CancelReservation(ctx, reservationID, "payment_capture_failed")
+		s.updateSaga(ctx, reservationID, func(saga *Saga) {
+			saga.FailStep(StepCapturePayment, err, s.now())
+			cancelErr := s.cancelReservation(ctx, reservationID, "payment_capture_failed")
+			saga.CompensateStep(StepCreateReservation, cancelErr, s.now())
+			saga.EndCompensation(s.now())
+		})
 		return fmt.Errorf("failed to capture payment: %w", err)
 	}
 
@@ -140,11 +314,40 @@ func (s *BookingService) OnPaymentAuthorized(ctx context.Context, paymentID paym
 }
 
 // OnPaymentCaptured handles the payment.captured event.
-// It confirms the reservation.
-func (s *BookingService) OnPaymentCaptured(ctx context.Context, reservationID shared.ReservationID) error {
+// It confirms the reservation; if that fails, the payment is refunded and the reservation cancelled.
+func (s *BookingService) OnPaymentCaptured(ctx context.Context, reservationID shared.ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.OnPaymentCaptured", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	// With capture on check-in the reservation was confirmed at authorization and is active by now
+	if s.captureOnCheckIn {
+		return nil
+	}
+
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		if !saga.Completed(StepCapturePayment) {
+			saga.CompleteStep(StepCapturePayment, s.now())
+		}
+	})
+
 	if err := s.reservationService.ConfirmReservation(ctx, reservationID); err != nil {
+		// Compensation: refund the payment and cancel the reservation
+		s.updateSaga(ctx, reservationID, func(saga *Saga) {
+			saga.FailStep(StepConfirmReservation, err, s.now())
+			if saga.PaymentID != "" {
+				refundErr := s.paymentService.RefundPayment(ctx, saga.PaymentID)
+				saga.CompensateStep(StepCapturePayment, refundErr, s.now())
+			}
+			cancelErr := s.cancelReservation(ctx, reservationID, "confirmation_failed")
+			saga.CompensateStep(StepCreateReservation, cancelErr, s.now())
+			saga.EndCompensation(s.now())
+		})
 		return fmt.Errorf("failed to confirm reservation: %w", err)
 	}
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		saga.CompleteStep(StepConfirmReservation, s.now())
+		saga.Complete(s.now())
+	})
 
 	res, err := s.reservationService.GetReservation(ctx, reservationID)
 	if err == nil {
@@ -154,71 +357,249 @@ func (s *BookingService) OnPaymentCaptured(ctx context.Context, reservationID sh
 	return nil
 }
 
-// OnPaymentFailed handles the payment.failed event.
-// It cancels the reservation as compensation.
-func (s *BookingService) OnPaymentFailed(ctx context.Context, reservationID shared.ReservationID, reason string) error {
-	return s.reservationService.CancelReservation(ctx, reservationID, reason)
+// OnReservationActivated handles the reservation.activated event if payments are captured on check-in.
+// It captures the authorized payment of the reservation, retrying declined captures with backoff.
+// If the capture fails for good, the stay is cancelled as compensation and payment.failed is published.
+// A payment that is captured already, e.g. on a redelivered event, completes the saga again harmlessly.
+func (s *BookingService) OnReservationActivated(ctx context.Context, reservationID shared.ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.OnReservationActivated", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	paymentID := payment.PaymentIDForReservation(reservationID)
+	if err := s.captureWithRetry(ctx, paymentID); err != nil {
+		s.updateSaga(ctx, reservationID, func(saga *Saga) {
+			saga.FailStep(StepCapturePayment, err, s.now())
+			cancelErr := s.cancelReservation(ctx, reservationID, "payment_capture_failed")
+			saga.CompensateStep(StepCreateReservation, cancelErr, s.now())
+			saga.EndCompensation(s.now())
+		})
+		return fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		saga.PaymentID = paymentID
+		if !saga.Completed(StepCapturePayment) {
+			saga.CompleteStep(StepCapturePayment, s.now())
+		}
+		saga.Complete(s.now())
+	})
+	return nil
 }
 
-// createReservationStep is a helper function to encapsulate.
-func (s *BookingService) createReservationStep(
-	ctx context.Context,
-	reservationID shared.ReservationID,
-	guestID reservation.GuestID,
-	roomID reservation.RoomID,
-	dateRange reservation.DateRange,
-	amount shared.Money,
-	guests []reservation.GuestInfo,
-) (*reservation.Reservation, error) {
-	res, err := s.reservationService.CreateReservation(ctx, reservationID, guestID, roomID, dateRange, amount, guests)
+// OnGuestCheckedIn handles the reservation.activated event after the payment of the stay is settled.
+// It sends the check-in welcome to the guest (best effort).
+func (s *BookingService) OnGuestCheckedIn(ctx context.Context, reservationID shared.ReservationID) error {
+	res, err := s.reservationService.GetReservation(ctx, reservationID)
 	if err != nil {
-		return nil, fmt.Errorf("step 1 failed (create reservation): %w", err)
+		return err
 	}
-	return res, nil
+	_ = s.notificationService.SendCheckInWelcome(ctx, res)
+	return nil
 }
 
-// authorizePaymentStep is a helper function to encapsulate.
-func (s *BookingService) authorizePaymentStep(
-	ctx context.Context,
-	paymentID payment.PaymentID,
-	reservationID shared.ReservationID,
-	amount shared.Money,
-	paymentMethod string,
-) (*payment.Payment, error) {
-	pay, err := s.paymentService.AuthorizePayment(ctx, paymentID, reservationID, amount, paymentMethod)
+// captureWithRetry captures the payment, waiting between declined attempts. Only the last attempt
+// fails the payment. Refusals that a retry cannot fix, like a stale exchange rate, end the retries early.
+func (s *BookingService) captureWithRetry(ctx context.Context, paymentID payment.PaymentID) error {
+	p, err := s.paymentService.GetPayment(ctx, paymentID)
 	if err != nil {
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "payment_authorization_failed")
-		if cancelErr != nil {
-			return nil, fmt.Errorf("step 2 failed (authorize payment) and compensation failed: %w (original error: %w)", cancelErr, err)
+		return err
+	}
+	if p.Status == payment.StatusCaptured {
+		return nil
+	}
+	if p.Status != payment.StatusAuthorized {
+		return payment.ErrNotAuthorized
+	}
+
+	attempts := max(s.captureRetry.Attempts, 1)
+	backoff := s.captureRetry.Backoff
+	for attempt := 1; attempt < attempts; attempt++ {
+		err := s.paymentService.TryCapturePayment(ctx, paymentID)
+		if err == nil || errors.Is(err, payment.ErrFXSnapshotMissing) || errors.Is(err, payment.ErrFXSnapshotStale) {
+			return err
+		}
+		if err := wait(ctx, backoff); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("step 2 failed (authorize payment): %w", err)
+		backoff *= 2
 	}
-	return pay, nil
+	return s.paymentService.CapturePayment(ctx, paymentID)
 }
 
-// capturePaymentStep is a helper function to encapsulate.
-func (s *BookingService) capturePaymentStep(ctx context.Context, paymentID payment.PaymentID, reservationID shared.ReservationID) error {
-	captureErr := s.paymentService.CapturePayment(ctx, paymentID)
-	if captureErr != nil {
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "payment_capture_failed")
-		if cancelErr != nil {
-			return fmt.Errorf("step 3 failed (capture payment) and compensation failed: %w (original error: %w)", cancelErr, captureErr)
-		}
-		return fmt.Errorf("step 3 failed (capture payment): %w", captureErr)
+// wait waits for the duration unless the context is done first.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// confirmAuthorized confirms the reservation of an authorized payment that is captured at check-in.
+// If that fails, the reservation is cancelled; the authorization is left to expire at the gateway.
+func (s *BookingService) confirmAuthorized(ctx context.Context, reservationID shared.ReservationID) error {
+	if err := s.reservationService.ConfirmReservation(ctx, reservationID); err != nil {
+		s.updateSaga(ctx, reservationID, func(saga *Saga) {
+			saga.FailStep(StepConfirmReservation, err, s.now())
+			cancelErr := s.cancelReservation(ctx, reservationID, "confirmation_failed")
+			saga.CompensateStep(StepCreateReservation, cancelErr, s.now())
+			saga.EndCompensation(s.now())
+		})
+		return fmt.Errorf("failed to confirm reservation: %w", err)
+	}
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		saga.CompleteStep(StepConfirmReservation, s.now())
+	})
+
+	res, err := s.reservationService.GetReservation(ctx, reservationID)
+	if err == nil {
+		_ = s.notificationService.SendReservationConfirmation(ctx, res)
 	}
 	return nil
 }
 
-// confirmReservationStep is a helper function to encapsulate.
-func (s *BookingService) confirmReservationStep(ctx context.Context, reservationID shared.ReservationID, paymentID payment.PaymentID) error {
-	confirmErr := s.reservationService.ConfirmReservation(ctx, reservationID)
-	if confirmErr != nil {
-		refundErr := s.paymentService.RefundPayment(ctx, paymentID)
-		cancelErr := s.reservationService.CancelReservation(ctx, reservationID, "confirmation_failed")
-		if refundErr != nil || cancelErr != nil {
-			return fmt.Errorf("step 4 failed (confirm reservation) and compensation failed (refund: %w, cancel: %w): %w", refundErr, cancelErr, confirmErr)
+// OnPaymentFailed handles the payment.failed event of a failed authorization or capture.
+// It cancels the reservation as compensation.
+func (s *BookingService) OnPaymentFailed(ctx context.Context, reservationID shared.ReservationID, reason string) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "orchestration.OnPaymentFailed", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	var cancelErr error
+	s.updateSaga(ctx, reservationID, func(saga *Saga) {
+		step := StepAuthorizePayment
+		if saga.Completed(StepAuthorizePayment) {
+			step = StepCapturePayment
+		}
+		saga.FailStep(step, errors.New(reason), s.now())
+		cancelErr = s.cancelReservation(ctx, reservationID, reason)
+		saga.CompensateStep(StepCreateReservation, cancelErr, s.now())
+		saga.EndCompensation(s.now())
+	})
+	return cancelErr
+}
+
+// cancelReservation cancels the reservation as compensation, even within the notice period or during the stay.
+// A reservation that is already cancelled, e.g. by the guest, counts as compensated.
+func (s *BookingService) cancelReservation(ctx context.Context, reservationID shared.ReservationID, reason string) error {
+	err := s.reservationService.CancelReservationOnPaymentFailed(ctx, reservationID, reason)
+	if errors.Is(err, reservation.ErrAlreadyCancelled) {
+		return nil
+	}
+	return err
+}
+
+// compensationReasons are the cancellation reasons of the reservation per failed step.
+var compensationReasons = map[string]string{
+	StepAuthorizePayment:   "payment_authorization_failed",
+	StepCapturePayment:     "payment_capture_failed",
+	StepConfirmReservation: "confirmation_failed",
+}
+
+// sagaStep is a step of the booking saga with the action that undoes it.
+type sagaStep struct {
+	name       string
+	run        func(ctx context.Context) error
+	compensate func(ctx context.Context, reason string) error // nil if there is nothing to undo
+}
+
+// runSaga runs the steps in order. If a step fails, the completed steps are compensated in reverse order.
+func (s *BookingService) runSaga(ctx context.Context, saga *Saga, steps []sagaStep) error {
+	for i, step := range steps {
+		if err := s.runStep(ctx, step); err != nil {
+			saga.FailStep(step.name, err, s.now())
+			var compensationErrs []error
+			for j := i - 1; j >= 0; j-- {
+				if steps[j].compensate == nil {
+					continue
+				}
+				compErr := steps[j].compensate(ctx, compensationReasons[step.name])
+				saga.CompensateStep(steps[j].name, compErr, s.now())
+				if compErr != nil {
+					compensationErrs = append(compensationErrs, fmt.Errorf("%s: %w", steps[j].name, compErr))
+				}
+			}
+			saga.EndCompensation(s.now())
+			s.saveSaga(ctx, saga)
+
+			if len(compensationErrs) > 0 {
+				return fmt.Errorf("step %d failed (%s) and compensation failed: %w (original error: %w)", i+1, step.name, errors.Join(compensationErrs...), err)
+			}
+			return fmt.Errorf("step %d failed (%s): %w", i+1, step.name, err)
 		}
-		return fmt.Errorf("step 4 failed (confirm reservation): %w", confirmErr)
+		saga.CompleteStep(step.name, s.now())
+		s.saveSaga(ctx, saga)
 	}
+	saga.Complete(s.now())
+	s.saveSaga(ctx, saga)
 	return nil
 }
+
+// runStep runs a step of the saga in a span of its own, e.g. "saga.capture_payment".
+func (s *BookingService) runStep(ctx context.Context, step sagaStep) error {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "saga."+step.name)
+	err := step.run(ctx)
+	span.End(err)
+	return err
+}
+
+// startSaga records a new booking saga of the reservation and publishes saga.started.
+func (s *BookingService) startSaga(ctx context.Context, reservationID shared.ReservationID) *Saga {
+	saga := NewSaga(reservationID, s.now())
+	if s.sagaRepo != nil {
+		_ = s.sagaRepo.Create(ctx, saga.ID, *saga)
+		s.publish(ctx, NewEventSagaStarted().WithSagaID(saga.ID).WithReservationID(reservationID))
+	}
+	return saga
+}
+
+// updateSaga applies the change to the booking saga of the reservation, starting it if needed, and saves it.
+// Ended sagas are not changed, so a redelivered event does not compensate twice;
+// the change still runs on a throwaway saga if no saga log is configured.
+func (s *BookingService) updateSaga(ctx context.Context, reservationID shared.ReservationID, change func(saga *Saga)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sagaRepo == nil {
+		change(NewSaga(reservationID, s.now()))
+		return
+	}
+	saga, err := s.sagaRepo.Read(ctx, BookingSagaID(reservationID))
+	if err != nil {
+		saga = s.startSaga(ctx, reservationID)
+	}
+	if saga.Done() {
+		return
+	}
+	change(saga)
+	s.saveSaga(ctx, saga)
+}
+
+// saveSaga stores the saga and publishes its lifecycle event once it ended.
+func (s *BookingService) saveSaga(ctx context.Context, saga *Saga) {
+	if s.sagaRepo == nil {
+		return
+	}
+	_ = s.sagaRepo.Update(ctx, saga.ID, *saga)
+	if saga.Done() && s.metrics != nil {
+		s.metrics.ObserveHistogram(MetricSagaDuration, saga.UpdatedAt.Sub(saga.StartedAt).Seconds(), "outcome", string(saga.Status))
+	}
+
+	switch saga.Status {
+	case SagaCompleted:
+		s.publish(ctx, NewEventSagaCompleted().WithSagaID(saga.ID).WithReservationID(saga.ReservationID).WithPaymentID(saga.PaymentID))
+	case SagaCompensated:
+		s.publish(ctx, NewEventSagaCompensated().WithSagaID(saga.ID).WithReservationID(saga.ReservationID).WithPaymentID(saga.PaymentID).WithFailedStep(saga.FailedStep).WithError(saga.Error))
+	case SagaFailed:
+		s.publish(ctx, NewEventSagaFailed().WithSagaID(saga.ID).WithReservationID(saga.ReservationID).WithPaymentID(saga.PaymentID).WithFailedStep(saga.FailedStep).WithError(saga.Error))
+	}
+}
+
+// publish publishes the saga lifecycle event (best effort).
+func (s *BookingService) publish(ctx context.Context, evt event.Event) {
+	if s.publisher != nil {
+		_ = s.publisher.Publish(ctx, evt)
+	}
+}
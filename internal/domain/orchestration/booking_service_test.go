@@ -19,10 +19,11 @@ import (
 // ============================================================================
 
 type mockReservationRepository struct {
-	reservations map[reservation.ReservationID]reservation.Reservation
-	createErr    error
-	readErr      error
-	updateErr    error
+	reservations  map[reservation.ReservationID]reservation.Reservation
+	createErr     error
+	readErr       error
+	updateErr     error
+	updateErrOnce error // fails the next update only
 }
 
 func newMockReservationRepository() *mockReservationRepository {
@@ -54,6 +55,10 @@ func (m *mockReservationRepository) Update(ctx context.Context, id reservation.R
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	if err := m.updateErrOnce; err != nil {
+		m.updateErrOnce = nil
+		return err
+	}
 	m.reservations[id] = res
 	return nil
 }
@@ -148,10 +153,13 @@ type mockPaymentGateway struct {
 	authorizeTransactionID string
 	authorizeErr           error
 	captureErr             error
+	captureErrs            []error // returned by the first captures, before captureErr
 	refundErr              error
+	authorizeCtx           context.Context // context of the last authorization
 }
 
 func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment) (string, error) {
+	m.authorizeCtx = ctx
 	if m.authorizeErr != nil {
 		return "", m.authorizeErr
 	}
@@ -159,6 +167,11 @@ func (m *mockPaymentGateway) Authorize(ctx context.Context, p *payment.Payment)
 }
 
 func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount shared.Money) error {
+	if len(m.captureErrs) > 0 {
+		err := m.captureErrs[0]
+		m.captureErrs = m.captureErrs[1:]
+		return err
+	}
 	return m.captureErr
 }
 
@@ -187,6 +200,7 @@ type mockNotificationService struct {
 	confirmationsSent int
 	cancellationsSent int
 	receiptsSent      int
+	welcomesSent      int
 	err               error
 }
 
@@ -214,6 +228,14 @@ func (m *mockNotificationService) SendPaymentReceipt(ctx context.Context, p *pay
 	return nil
 }
 
+func (m *mockNotificationService) SendCheckInWelcome(ctx context.Context, r *reservation.Reservation) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.welcomesSent++
+	return nil
+}
+
 // ============================================================================
 // Test Helpers
 // ============================================================================
@@ -580,6 +602,28 @@ func Test_BookingService_OnPaymentAuthorized_When_Capture_Fails_Should_Cancel_Re
 	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
 }
 
+func Test_BookingService_OnPaymentAuthorized_With_Capture_On_CheckIn_Should_Confirm_Without_Capture(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	svc.bookingService.WithCaptureOnCheckIn(orchestration.CaptureRetry{Attempts: 3, Backoff: time.Millisecond})
+	ctx := context.Background()
+	reservationID := shared.ReservationID("res-001")
+	paymentID := payment.PaymentIDForReservation(reservationID)
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, reservationID, paymentID, validBookingMoney(), "credit_card", nil)
+
+	// Act
+	err := svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedRes, _ := svc.reservationRepo.Read(ctx, reservationID)
+	assert.That(t, "reservation must be confirmed", storedRes.Status, reservation.StatusConfirmed)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, paymentID)
+	assert.That(t, "payment must stay authorized", storedPayment.Status, payment.StatusAuthorized)
+	assert.That(t, "confirmation must be sent", svc.notificationService.confirmationsSent, 1)
+}
+
 // ============================================================================
 // OnPaymentCaptured Tests
 // ============================================================================
@@ -663,3 +707,119 @@ func Test_BookingService_OnPaymentFailed_When_Reservation_Not_Found_Should_Retur
 	// Assert
 	assert.That(t, "error must not be nil", err != nil, true)
 }
+
+// ============================================================================
+// OnReservationActivated Tests
+// ============================================================================
+
+// checkInTestBooking books res-001 with capture on check-in and checks the guest in.
+func checkInTestBooking(svc *testServices, retry orchestration.CaptureRetry) {
+	ctx := context.Background()
+	reservationID := shared.ReservationID("res-001")
+	svc.bookingService.WithCaptureOnCheckIn(retry)
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, reservationID, payment.PaymentIDForReservation(reservationID), validBookingMoney(), "credit_card", nil)
+	_ = svc.bookingService.OnPaymentAuthorized(ctx, payment.PaymentIDForReservation(reservationID), reservationID)
+	_ = svc.reservationService.ActivateReservation(ctx, reservationID)
+}
+
+func Test_BookingService_OnReservationActivated_Should_Capture_Payment(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	checkInTestBooking(svc, orchestration.CaptureRetry{Attempts: 3, Backoff: time.Millisecond})
+	ctx := context.Background()
+
+	// Act
+	err := svc.bookingService.OnReservationActivated(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, payment.PaymentIDForReservation("res-001"))
+	assert.That(t, "payment must be captured", storedPayment.Status, payment.StatusCaptured)
+	saga, _ := svc.bookingService.GetSaga(ctx, "res-001")
+	assert.That(t, "saga must be completed", saga.Status, orchestration.SagaCompleted)
+}
+
+func Test_BookingService_OnReservationActivated_With_Declined_Capture_Should_Retry(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	checkInTestBooking(svc, orchestration.CaptureRetry{Attempts: 3, Backoff: time.Millisecond})
+	svc.paymentGateway.captureErrs = []error{errors.New("gateway timeout"), errors.New("gateway timeout")}
+	ctx := context.Background()
+
+	// Act
+	err := svc.bookingService.OnReservationActivated(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, payment.PaymentIDForReservation("res-001"))
+	assert.That(t, "payment must be captured", storedPayment.Status, payment.StatusCaptured)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must stay active", storedRes.Status, reservation.StatusActive)
+}
+
+func Test_BookingService_OnReservationActivated_When_Capture_Fails_For_Good_Should_Cancel_Stay(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	checkInTestBooking(svc, orchestration.CaptureRetry{Attempts: 2, Backoff: time.Millisecond})
+	svc.paymentGateway.captureErr = errors.New("card expired")
+	ctx := context.Background()
+
+	// Act
+	err := svc.bookingService.OnReservationActivated(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, payment.PaymentIDForReservation("res-001"))
+	assert.That(t, "payment must be failed", storedPayment.Status, payment.StatusFailed)
+	assert.That(t, "payment.failed must be published", svc.paymentPub.published[len(svc.paymentPub.published)-1].Topic(), payment.EventTopicFailed)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	saga, _ := svc.bookingService.GetSaga(ctx, "res-001")
+	assert.That(t, "saga must be compensated", saga.Status, orchestration.SagaCompensated)
+}
+
+func Test_BookingService_OnReservationActivated_Twice_Should_Capture_Once(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	checkInTestBooking(svc, orchestration.CaptureRetry{Attempts: 3, Backoff: time.Millisecond})
+	ctx := context.Background()
+	_ = svc.bookingService.OnReservationActivated(ctx, "res-001")
+
+	// Act
+	err := svc.bookingService.OnReservationActivated(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "payment.captured must be published once", len(svc.paymentPub.published), 2)
+}
+
+// ============================================================================
+// OnGuestCheckedIn Tests
+// ============================================================================
+
+func Test_BookingService_OnGuestCheckedIn_Should_Send_Welcome(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+	ctx := context.Background()
+	_, _ = svc.bookingService.InitiateBooking(ctx, "res-001", "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+
+	// Act
+	err := svc.bookingService.OnGuestCheckedIn(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "welcome must be sent", svc.notificationService.welcomesSent, 1)
+}
+
+func Test_BookingService_OnGuestCheckedIn_With_Unknown_Reservation_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+
+	// Act
+	err := svc.bookingService.OnGuestCheckedIn(context.Background(), "non-existent")
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	assert.That(t, "welcome must not be sent", svc.notificationService.welcomesSent, 0)
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/andygeiss/cloud-native-utils/messaging"
 	"github.com/andygeiss/cloud-native-utils/service"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
 )
 
 // EventHandlers manages cross-context event subscriptions.
@@ -18,18 +21,30 @@ type EventHandlers struct {
 	bookingService     *BookingService
 	reservationService *reservation.Service
 	paymentService     *payment.Service
+	surveyService      *survey.Service
+	referralService    *referral.Service
+	waitlistService    *waitlist.Service
 }
 
 // NewEventHandlers creates a new event handlers instance.
+// The survey service is optional; nil disables the NPS survey after checkout.
+// The referral service is optional; nil disables referral rewards.
+// The waitlist service is optional; nil disables offering cancelled rooms to waitlisted guests.
 func NewEventHandlers(
 	bookingSvc *BookingService,
 	reservationSvc *reservation.Service,
 	paymentSvc *payment.Service,
+	surveySvc *survey.Service,
+	referralSvc *referral.Service,
+	waitlistSvc *waitlist.Service,
 ) *EventHandlers {
 	return &EventHandlers{
 		bookingService:     bookingSvc,
 		reservationService: reservationSvc,
 		paymentService:     paymentSvc,
+		surveyService:      surveySvc,
+		referralService:    referralSvc,
+		waitlistService:    waitlistSvc,
 	}
 }
 
@@ -37,51 +52,90 @@ func NewEventHandlers(
 func (h *EventHandlers) RegisterHandlers(ctx context.Context, dispatcher messaging.Dispatcher) error {
 	// Payment context subscribes to reservation.created
 	// When a reservation is created, initiate payment authorization
-	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCreated, service.Wrap(h.handleReservationCreated)); err != nil {
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicCreated, detached(h.handleReservationCreated)); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCreated, err)
 	}
 
 	// Orchestration subscribes to payment.authorized
 	// When payment is authorized, capture it
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicAuthorized, service.Wrap(h.handlePaymentAuthorized)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicAuthorized, detached(h.handlePaymentAuthorized)); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicAuthorized, err)
 	}
 
 	// Reservation context subscribes to payment.captured
 	// When payment is captured, confirm the reservation
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicCaptured, service.Wrap(h.handlePaymentCaptured)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicCaptured, detached(h.handlePaymentCaptured)); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicCaptured, err)
 	}
 
 	// Orchestration subscribes to payment.failed
 	// When payment fails, cancel the reservation as compensation
-	if err := dispatcher.Subscribe(ctx, payment.EventTopicFailed, service.Wrap(h.handlePaymentFailed)); err != nil {
+	if err := dispatcher.Subscribe(ctx, payment.EventTopicFailed, detached(h.handlePaymentFailed)); err != nil {
 		return fmt.Errorf("failed to subscribe to %s: %w", payment.EventTopicFailed, err)
 	}
 
+	// Orchestration subscribes to reservation.activated
+	// When the guest checks in, capture the payment if payments are captured on check-in
+	// (cancelling the stay if that fails for good) and welcome the guest
+	if err := dispatcher.Subscribe(ctx, reservation.EventTopicActivated, detached(h.handleReservationActivated)); err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicActivated, err)
+	}
+
+	// Survey and referral contexts subscribe to reservation.completed
+	// When the guest checks out, send the NPS survey and issue the referral reward
+	// A single handler serves both: the Kafka dispatcher starts a reader per subscription, not per topic
+	if h.surveyService != nil || h.referralService != nil {
+		if err := dispatcher.Subscribe(ctx, reservation.EventTopicCompleted, detached(h.handleReservationCompleted)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCompleted, err)
+		}
+	}
+
+	// Referral and waitlist contexts subscribe to reservation.cancelled
+	// When a booking is cancelled, void its referral and offer the freed room to the waitlist
+	if h.referralService != nil || h.waitlistService != nil {
+		if err := dispatcher.Subscribe(ctx, reservation.EventTopicCancelled, detached(h.handleReservationCancelled)); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", reservation.EventTopicCancelled, err)
+		}
+	}
+
 	return nil
 }
 
+// detached returns the handler as function of the dispatcher. The handler runs in the context of the event,
+// e.g. its consumer span, without its cancellation: a saga step is not abandoned halfway because the
+// publishing request ended or the dispatcher's timeout expired.
+func detached(handler func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error)) service.Function[messaging.Message, messaging.MessageState] {
+	return func(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+		return handler(context.WithoutCancel(ctx), msg)
+	}
+}
+
 // handleReservationCreated processes reservation.created events.
 // It triggers payment authorization in the payment context.
-func (h *EventHandlers) handleReservationCreated(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handleReservationCreated(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt reservation.EventCreated
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
+	// Close the waitlist entries of the guest for the booked room, which releases a hold for them
+	if h.waitlistService != nil {
+		if err := h.waitlistService.MarkBooked(ctx, waitlist.GuestID(evt.GuestID), waitlist.RoomID(evt.RoomID), evt.CheckIn, evt.CheckOut); err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to close waitlist entries: %w", err)
+		}
+	}
 
-	// Generate a payment ID based on the reservation ID
-	paymentID := payment.PaymentID(fmt.Sprintf("pay-%s", evt.ReservationID))
+	// Derive the payment ID from the reservation ID, so redelivered events reuse the payment
+	paymentID := payment.PaymentIDForReservation(shared.ReservationID(evt.ReservationID))
 
-	// Authorize payment for the reservation
-	_, err := h.paymentService.AuthorizePaymentForReservation(
+	// Start the booking saga and authorize payment for the reservation
+	_, err := h.bookingService.OnReservationCreated(
 		ctx,
-		paymentID,
 		shared.ReservationID(evt.ReservationID),
+		paymentID,
 		evt.TotalAmount,
 		"default", // Payment method - could be passed in event
+		evt.FX,
 	)
 	if err != nil {
 		// The payment service already publishes payment.failed event
@@ -94,14 +148,12 @@ func (h *EventHandlers) handleReservationCreated(msg messaging.Message) (messagi
 
 // handlePaymentAuthorized processes payment.authorized events.
 // It triggers payment capture.
-func (h *EventHandlers) handlePaymentAuthorized(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentAuthorized(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventAuthorized
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
-
 	// Capture the authorized payment
 	if err := h.bookingService.OnPaymentAuthorized(ctx, evt.PaymentID, evt.ReservationID); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to handle payment authorized: %w", err)
@@ -112,14 +164,12 @@ func (h *EventHandlers) handlePaymentAuthorized(msg messaging.Message) (messagin
 
 // handlePaymentCaptured processes payment.captured events.
 // It triggers reservation confirmation.
-func (h *EventHandlers) handlePaymentCaptured(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentCaptured(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventCaptured
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
-
 	// Confirm the reservation
 	if err := h.bookingService.OnPaymentCaptured(ctx, evt.ReservationID); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to confirm reservation: %w", err)
@@ -130,14 +180,12 @@ func (h *EventHandlers) handlePaymentCaptured(msg messaging.Message) (messaging.
 
 // handlePaymentFailed processes payment.failed events.
 // It triggers reservation cancellation as compensation.
-func (h *EventHandlers) handlePaymentFailed(msg messaging.Message) (messaging.MessageState, error) {
+func (h *EventHandlers) handlePaymentFailed(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
 	var evt payment.EventFailed
 	if err := json.Unmarshal(msg.Data, &evt); err != nil {
 		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	ctx := context.Background()
-
 	// Cancel the reservation as compensation
 	reason := fmt.Sprintf("payment_failed: %s - %s", evt.ErrorCode, evt.ErrorMsg)
 	if err := h.bookingService.OnPaymentFailed(ctx, evt.ReservationID, reason); err != nil {
@@ -146,3 +194,89 @@ func (h *EventHandlers) handlePaymentFailed(msg messaging.Message) (messaging.Me
 
 	return messaging.MessageStateCompleted, nil
 }
+
+// handleReservationActivated processes reservation.activated events.
+// If payments are captured on check-in, it captures the payment of the stay; the retries block the
+// handler until they are done. Guests whose stay was cancelled because the capture failed get no welcome.
+func (h *EventHandlers) handleReservationActivated(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventActivated
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if h.bookingService.CapturesOnCheckIn() {
+		if err := h.bookingService.OnReservationActivated(ctx, evt.ReservationID); err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to capture payment: %w", err)
+		}
+	}
+	if err := h.bookingService.OnGuestCheckedIn(ctx, evt.ReservationID); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to welcome guest: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handleReservationCompleted processes reservation.completed events.
+// It sends the NPS survey to the guest of the stay and issues the reward of a referred stay.
+// Both steps are idempotent, so a redelivered event is safe.
+func (h *EventHandlers) handleReservationCompleted(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCompleted
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if h.referralService != nil {
+		if _, err := h.referralService.CompleteStay(ctx, evt.ReservationID); err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to issue referral reward: %w", err)
+		}
+	}
+	if h.surveyService == nil {
+		return messaging.MessageStateCompleted, nil
+	}
+
+	res, err := h.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	// Send the survey to the reservation owner, falling back to the primary guest's email
+	email := res.GuestEmail
+	if email == "" && len(res.Guests) > 0 {
+		email = res.Guests[0].Email
+	}
+	if _, err := h.surveyService.SendSurvey(ctx, res.ID, survey.GuestID(res.GuestID), email); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to send survey: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
+
+// handleReservationCancelled processes reservation.cancelled events.
+// It voids the referral of the cancelled booking, so the referrer earns nothing, and offers
+// the freed room to the first guests on its waitlist, who are notified and hold it for a while.
+// Both steps are idempotent: offered guests are no longer waiting, so a redelivered event offers nothing.
+func (h *EventHandlers) handleReservationCancelled(ctx context.Context, msg messaging.Message) (messaging.MessageState, error) {
+	var evt reservation.EventCancelled
+	if err := json.Unmarshal(msg.Data, &evt); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if h.referralService != nil {
+		if err := h.referralService.VoidReservation(ctx, evt.ReservationID, evt.Reason); err != nil {
+			return messaging.MessageStateFailed, fmt.Errorf("failed to void referral: %w", err)
+		}
+	}
+	if h.waitlistService == nil {
+		return messaging.MessageStateCompleted, nil
+	}
+
+	res, err := h.reservationService.GetReservation(ctx, evt.ReservationID)
+	if err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if _, err := h.waitlistService.OfferFreedRoom(ctx, waitlist.RoomID(res.RoomID), res.DateRange.CheckIn, res.DateRange.CheckOut); err != nil {
+		return messaging.MessageStateFailed, fmt.Errorf("failed to offer room to waitlist: %w", err)
+	}
+
+	return messaging.MessageStateCompleted, nil
+}
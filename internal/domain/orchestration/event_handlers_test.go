@@ -10,11 +10,15 @@ import (
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/cloud-native-utils/messaging"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/cloud-native-utils/service"
 	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
 )
 
 // ============================================================================
@@ -48,15 +52,94 @@ func (m *mockDispatcher) Shutdown(ctx context.Context) error {
 }
 
 func (m *mockDispatcher) triggerEvent(topic string, data []byte) (messaging.MessageState, error) {
+	return m.triggerEventWithContext(context.Background(), topic, data)
+}
+
+func (m *mockDispatcher) triggerEventWithContext(ctx context.Context, topic string, data []byte) (messaging.MessageState, error) {
 	handlers := m.subscriptions[topic]
 	if len(handlers) == 0 {
 		return messaging.MessageStateFailed, errors.New("no handlers for topic")
 	}
 	msg := messaging.NewMessage(topic, data)
-	ctx := context.Background()
 	return handlers[0](ctx, msg)
 }
 
+// ============================================================================
+// Mock Survey Sender
+// ============================================================================
+
+type mockSurveySender struct {
+	sent []*survey.Survey
+}
+
+func (m *mockSurveySender) SendSurveyInvitation(ctx context.Context, s *survey.Survey) error {
+	m.sent = append(m.sent, s)
+	return nil
+}
+
+// ============================================================================
+// Mock Referral Ports
+// ============================================================================
+
+type mockBookingHistory struct{}
+
+func (m *mockBookingHistory) BookingsOf(ctx context.Context, guestID referral.GuestID) ([]referral.ReservationID, error) {
+	return nil, nil
+}
+
+type mockRewardNotifier struct {
+	sent []*referral.Referral
+}
+
+func (m *mockRewardNotifier) SendReferralReward(ctx context.Context, code *referral.ReferralCode, r *referral.Referral) error {
+	m.sent = append(m.sent, r)
+	return nil
+}
+
+func createTestReferralService(notifier *mockRewardNotifier) *referral.Service {
+	return referral.NewService(
+		resource.NewInMemoryAccess[referral.Code, referral.ReferralCode](),
+		resource.NewInMemoryAccess[referral.ReferralID, referral.Referral](),
+		&mockBookingHistory{},
+		notifier,
+		referral.Policy{Reward: referral.Reward{Kind: referral.RewardPoints, Points: 500}},
+	)
+}
+
+// ============================================================================
+// Mock Waitlist Ports
+// ============================================================================
+
+type mockRoomAvailability struct {
+	available bool
+}
+
+func (m *mockRoomAvailability) IsRoomAvailable(ctx context.Context, roomID waitlist.RoomID, checkIn, checkOut time.Time) (bool, error) {
+	return m.available, nil
+}
+
+type mockOfferNotifier struct {
+	sent []*waitlist.Entry
+}
+
+func (m *mockOfferNotifier) SendWaitlistOffer(ctx context.Context, e *waitlist.Entry) error {
+	m.sent = append(m.sent, e)
+	return nil
+}
+
+// createTestWaitlistService puts guest-waiting on the waitlist of room-101 for the valid date range.
+func createTestWaitlistService(t *testing.T, notifier *mockOfferNotifier) *waitlist.Service {
+	t.Helper()
+	availability := &mockRoomAvailability{}
+	waitlistService := waitlist.NewService(resource.NewInMemoryAccess[waitlist.EntryID, waitlist.Entry](), availability, notifier, time.Hour)
+	dateRange := eventHandlerValidDateRange()
+	if _, err := waitlistService.Join(context.Background(), "guest-waiting", "waiting@example.com", "room-101", dateRange.CheckIn, dateRange.CheckOut); err != nil {
+		t.Fatalf("failed to join waitlist: %v", err)
+	}
+	availability.available = true
+	return waitlistService
+}
+
 // ============================================================================
 // Test Services Setup (reusing from booking_service_test.go)
 // ============================================================================
@@ -72,6 +155,9 @@ type eventHandlerTestServices struct {
 	paymentPub     *mockEventPublisher
 	paymentService *payment.Service
 
+	surveySender  *mockSurveySender
+	surveyService *survey.Service
+
 	notificationService *mockNotificationService
 	bookingService      *orchestration.BookingService
 	eventHandlers       *orchestration.EventHandlers
@@ -91,10 +177,14 @@ func createEventHandlerTestServices() *eventHandlerTestServices {
 	paymentPub := &mockEventPublisher{}
 	paymentService := payment.NewService(paymentRepo, paymentGateway, paymentPub)
 
+	// Survey context
+	surveySender := &mockSurveySender{}
+	surveyService := survey.NewService(resource.NewInMemoryAccess[survey.SurveyID, survey.Survey](), surveySender, "Test Hotel")
+
 	// Orchestration
 	notificationService := &mockNotificationService{}
 	bookingService := orchestration.NewBookingService(reservationService, paymentService, notificationService)
-	eventHandlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService)
+	eventHandlers := orchestration.NewEventHandlers(bookingService, reservationService, paymentService, surveyService, nil, nil)
 	dispatcher := newMockDispatcher()
 
 	return &eventHandlerTestServices{
@@ -106,6 +196,8 @@ func createEventHandlerTestServices() *eventHandlerTestServices {
 		paymentGateway:      paymentGateway,
 		paymentPub:          paymentPub,
 		paymentService:      paymentService,
+		surveySender:        surveySender,
+		surveyService:       surveyService,
 		notificationService: notificationService,
 		bookingService:      bookingService,
 		eventHandlers:       eventHandlers,
@@ -147,6 +239,8 @@ func Test_EventHandlers_RegisterHandlers_Should_Subscribe_To_All_Topics(t *testi
 	assert.That(t, "must subscribe to payment.authorized", len(svc.dispatcher.subscriptions[payment.EventTopicAuthorized]), 1)
 	assert.That(t, "must subscribe to payment.captured", len(svc.dispatcher.subscriptions[payment.EventTopicCaptured]), 1)
 	assert.That(t, "must subscribe to payment.failed", len(svc.dispatcher.subscriptions[payment.EventTopicFailed]), 1)
+	assert.That(t, "must subscribe to reservation.completed", len(svc.dispatcher.subscriptions[reservation.EventTopicCompleted]), 1)
+	assert.That(t, "must subscribe to reservation.activated", len(svc.dispatcher.subscriptions[reservation.EventTopicActivated]), 1)
 }
 
 // ============================================================================
@@ -205,6 +299,61 @@ func Test_HandleReservationCreated_Should_Trigger_Payment_Authorization(t *testi
 	assert.That(t, "payment must be authorized", storedPayment.Status, payment.StatusAuthorized)
 }
 
+func Test_HandleReservationCreated_Should_Run_In_Context_Of_Event_Without_Cancellation(t *testing.T) {
+	// Arrange
+	type spanKey struct{}
+	svc := createEventHandlerTestServices()
+	_ = svc.eventHandlers.RegisterHandlers(context.Background(), svc.dispatcher)
+
+	dateRange := eventHandlerValidDateRange()
+	evt := reservation.EventCreated{
+		ReservationID: "res-001",
+		GuestID:       "guest-001",
+		RoomID:        "room-101",
+		CheckIn:       dateRange.CheckIn,
+		CheckOut:      dateRange.CheckOut,
+		TotalAmount:   eventHandlerValidMoney(),
+	}
+	data, _ := json.Marshal(evt)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), spanKey{}, "consumer-span"))
+	cancel()
+
+	// Act
+	state, err := svc.dispatcher.triggerEventWithContext(ctx, reservation.EventTopicCreated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "gateway must get the values of the event context", svc.paymentGateway.authorizeCtx.Value(spanKey{}), any("consumer-span"))
+	assert.That(t, "gateway must not get the cancellation", svc.paymentGateway.authorizeCtx.Err(), nil)
+}
+
+func Test_HandleReservationCreated_Should_Store_FX_Snapshot_With_Payment(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+
+	dateRange := eventHandlerValidDateRange()
+	fx := shared.NewFXSnapshot("EUR", "USD", 1.08, time.Now(), "static")
+	evt := reservation.NewEventCreated().
+		WithReservationID("res-001").
+		WithRoomID("room-101").
+		WithCheckIn(dateRange.CheckIn).
+		WithCheckOut(dateRange.CheckOut).
+		WithTotalAmount(shared.NewMoney(10000, "EUR")).
+		WithFX(&fx)
+	data, _ := json.Marshal(evt)
+
+	// Act
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	storedPayment, err := svc.paymentRepo.Read(ctx, "pay-res-001")
+	assert.That(t, "payment must exist", err == nil, true)
+	assert.That(t, "payment must keep the snapshot", storedPayment.FX != nil && storedPayment.FX.Rate == 1.08, true)
+}
+
 func Test_HandleReservationCreated_With_Invalid_JSON_Should_Return_Failed(t *testing.T) {
 	// Arrange
 	svc := createEventHandlerTestServices()
@@ -442,3 +591,223 @@ type testEvent struct {
 func (e testEvent) Topic() string { return e.topic }
 
 var _ event.Event = testEvent{} // compile-time interface check
+
+// ============================================================================
+// HandleReservationCompleted Tests
+// ============================================================================
+
+func Test_HandleReservationCompleted_Should_Send_Survey(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	data, _ := json.Marshal(reservation.EventCompleted{ReservationID: "res-001"})
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicCompleted, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "survey must be sent once", len(svc.surveySender.sent), 1)
+	assert.That(t, "survey must be sent to the owner", svc.surveySender.sent[0].GuestID, survey.GuestID("guest-001"))
+}
+
+func Test_HandleReservationCompleted_Twice_Should_Send_Survey_Once(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	ctx := context.Background()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	data, _ := json.Marshal(reservation.EventCompleted{ReservationID: "res-001"})
+
+	// Act
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCompleted, data)
+	_, _ = svc.dispatcher.triggerEvent(reservation.EventTopicCompleted, data)
+
+	// Assert
+	assert.That(t, "survey must be sent once", len(svc.surveySender.sent), 1)
+}
+
+func Test_EventHandlers_Without_SurveyService_Should_Not_Subscribe_To_Completed(t *testing.T) {
+	// Arrange
+	svc := createEventHandlerTestServices()
+	handlers := orchestration.NewEventHandlers(svc.bookingService, svc.reservationService, svc.paymentService, nil, nil, nil)
+	dispatcher := newMockDispatcher()
+
+	// Act
+	err := handlers.RegisterHandlers(context.Background(), dispatcher)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must not subscribe to reservation.completed", len(dispatcher.subscriptions[reservation.EventTopicCompleted]), 0)
+}
+
+func Test_HandleReservationCompleted_Should_Issue_Referral_Reward(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	notifier := &mockRewardNotifier{}
+	referralService := createTestReferralService(notifier)
+	handlers := orchestration.NewEventHandlers(svc.bookingService, svc.reservationService, svc.paymentService, nil, referralService, nil)
+	dispatcher := newMockDispatcher()
+	_ = handlers.RegisterHandlers(ctx, dispatcher)
+	code, _ := referralService.CodeFor(ctx, "guest-referrer", "referrer@example.com")
+	_, _ = referralService.Attribute(ctx, code.Code, "res-001", "guest-001", "john@example.com")
+	data, _ := json.Marshal(reservation.EventCompleted{ReservationID: "res-001"})
+
+	// Act
+	state, err := dispatcher.triggerEvent(reservation.EventTopicCompleted, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "reward must be sent", len(notifier.sent), 1)
+	assert.That(t, "reward must be points", notifier.sent[0].Reward.Points, 500)
+}
+
+func Test_HandleReservationCancelled_Should_Void_Referral(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	notifier := &mockRewardNotifier{}
+	referralService := createTestReferralService(notifier)
+	handlers := orchestration.NewEventHandlers(svc.bookingService, svc.reservationService, svc.paymentService, nil, referralService, nil)
+	dispatcher := newMockDispatcher()
+	_ = handlers.RegisterHandlers(ctx, dispatcher)
+	code, _ := referralService.CodeFor(ctx, "guest-referrer", "referrer@example.com")
+	_, _ = referralService.Attribute(ctx, code.Code, "res-001", "guest-001", "john@example.com")
+	cancelled, _ := json.Marshal(reservation.EventCancelled{ReservationID: "res-001", Reason: "guest cancelled"})
+	completed, _ := json.Marshal(reservation.EventCompleted{ReservationID: "res-001"})
+
+	// Act
+	_, err := dispatcher.triggerEvent(reservation.EventTopicCancelled, cancelled)
+	_, _ = dispatcher.triggerEvent(reservation.EventTopicCompleted, completed)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "void referral must not be rewarded", len(notifier.sent), 0)
+}
+
+func Test_HandleReservationCancelled_Should_Offer_Room_To_Waitlist(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	notifier := &mockOfferNotifier{}
+	waitlistService := createTestWaitlistService(t, notifier)
+	handlers := orchestration.NewEventHandlers(svc.bookingService, svc.reservationService, svc.paymentService, nil, nil, waitlistService)
+	dispatcher := newMockDispatcher()
+	_ = handlers.RegisterHandlers(ctx, dispatcher)
+	_, _ = svc.reservationService.CreateReservation(
+		ctx, "res-001", "guest-001", "room-101",
+		eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests(),
+	)
+	data, _ := json.Marshal(reservation.EventCancelled{ReservationID: "res-001", Reason: "guest cancelled"})
+
+	// Act
+	state, err := dispatcher.triggerEvent(reservation.EventTopicCancelled, data)
+
+	// Assert
+	dateRange := eventHandlerValidDateRange()
+	held, _ := waitlistService.IsHeldForOthers(ctx, "room-101", dateRange.CheckIn, dateRange.CheckOut, "guest-other")
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "waiting guest must be notified", len(notifier.sent), 1)
+	assert.That(t, "room must be held for the waiting guest", held, true)
+}
+
+func Test_HandleReservationCreated_Should_Close_Waitlist_Entry_Of_Guest(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	waitlistService := createTestWaitlistService(t, &mockOfferNotifier{})
+	handlers := orchestration.NewEventHandlers(svc.bookingService, svc.reservationService, svc.paymentService, nil, nil, waitlistService)
+	dispatcher := newMockDispatcher()
+	_ = handlers.RegisterHandlers(ctx, dispatcher)
+	dateRange := eventHandlerValidDateRange()
+	data, _ := json.Marshal(reservation.EventCreated{ReservationID: "res-002", GuestID: "guest-waiting", RoomID: "room-101", CheckIn: dateRange.CheckIn, CheckOut: dateRange.CheckOut, TotalAmount: eventHandlerValidMoney()})
+
+	// Act
+	_, _ = dispatcher.triggerEvent(reservation.EventTopicCreated, data)
+
+	// Assert
+	entries, _ := waitlistService.EntriesOf(ctx, "guest-waiting")
+	assert.That(t, "entry must be booked", entries[0].Status, waitlist.StatusBooked)
+}
+
+// ============================================================================
+// HandleReservationActivated Tests
+// ============================================================================
+
+func Test_HandleReservationActivated_With_Capture_On_CheckIn_Should_Capture_Payment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithCaptureOnCheckIn(orchestration.CaptureRetry{Attempts: 2, Backoff: time.Millisecond})
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	reservationID := shared.ReservationID("res-001")
+	paymentID := payment.PaymentIDForReservation(reservationID)
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, reservationID, paymentID, eventHandlerValidMoney(), "credit_card", nil)
+	_ = svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
+	_ = svc.reservationService.ActivateReservation(ctx, reservationID)
+	data, _ := json.Marshal(reservation.EventActivated{ReservationID: reservationID})
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicActivated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, paymentID)
+	assert.That(t, "payment must be captured", storedPayment.Status, payment.StatusCaptured)
+	assert.That(t, "welcome must be sent", svc.notificationService.welcomesSent, 1)
+}
+
+func Test_HandleReservationActivated_When_Capture_Fails_Should_Not_Welcome_Guest(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	svc.bookingService.WithCaptureOnCheckIn(orchestration.CaptureRetry{Attempts: 1, Backoff: time.Millisecond})
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	reservationID := shared.ReservationID("res-001")
+	paymentID := payment.PaymentIDForReservation(reservationID)
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, reservationID, paymentID, eventHandlerValidMoney(), "credit_card", nil)
+	_ = svc.bookingService.OnPaymentAuthorized(ctx, paymentID, reservationID)
+	_ = svc.reservationService.ActivateReservation(ctx, reservationID)
+	svc.paymentGateway.captureErr = errors.New("card expired")
+	data, _ := json.Marshal(reservation.EventActivated{ReservationID: reservationID})
+
+	// Act
+	state, _ := svc.dispatcher.triggerEvent(reservation.EventTopicActivated, data)
+
+	// Assert
+	assert.That(t, "state must be failed", state, messaging.MessageStateFailed)
+	assert.That(t, "welcome must not be sent", svc.notificationService.welcomesSent, 0)
+}
+
+func Test_HandleReservationActivated_Without_Capture_On_CheckIn_Should_Welcome_Guest(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	svc := createEventHandlerTestServices()
+	_ = svc.eventHandlers.RegisterHandlers(ctx, svc.dispatcher)
+	reservationID := shared.ReservationID("res-001")
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", eventHandlerValidDateRange(), eventHandlerValidMoney(), eventHandlerValidGuests())
+	data, _ := json.Marshal(reservation.EventActivated{ReservationID: reservationID})
+
+	// Act
+	state, err := svc.dispatcher.triggerEvent(reservation.EventTopicActivated, data)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "state must be completed", state, messaging.MessageStateCompleted)
+	assert.That(t, "welcome must be sent", svc.notificationService.welcomesSent, 1)
+}
@@ -0,0 +1,157 @@
+package orchestration
+
+import (
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Event topics for Kafka.
+const (
+	EventTopicSagaStarted     = "saga.started"
+	EventTopicSagaCompleted   = "saga.completed"
+	EventTopicSagaCompensated = "saga.compensated"
+	EventTopicSagaFailed      = "saga.failed"
+)
+
+// ExampleEvents returns an example of every event published by this context.
+// They document the events in the event catalog.
+func ExampleEvents() []event.Event {
+	sagaID := BookingSagaID("res-1001")
+	return []event.Event{
+		NewEventSagaStarted().WithSagaID(sagaID).WithReservationID("res-1001"),
+		NewEventSagaCompleted().WithSagaID(sagaID).WithReservationID("res-1001").WithPaymentID("pay-2001"),
+		NewEventSagaCompensated().WithSagaID(sagaID).WithReservationID("res-1001").WithPaymentID("pay-2001").WithFailedStep(StepCapturePayment).WithError("payment capture failed: gateway timeout"),
+		NewEventSagaFailed().WithSagaID(sagaID).WithReservationID("res-1001").WithPaymentID("pay-2001").WithFailedStep(StepConfirmReservation).WithError("invalid state transition"),
+	}
+}
+
+// EventSagaStarted is published when a booking saga starts.
+type EventSagaStarted struct {
+	SagaID        SagaID               `json:"saga_id"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+}
+
+func NewEventSagaStarted() *EventSagaStarted {
+	return &EventSagaStarted{}
+}
+
+func (e *EventSagaStarted) Topic() string { return EventTopicSagaStarted }
+
+func (e *EventSagaStarted) WithSagaID(id SagaID) *EventSagaStarted {
+	e.SagaID = id
+	return e
+}
+
+func (e *EventSagaStarted) WithReservationID(id shared.ReservationID) *EventSagaStarted {
+	e.ReservationID = id
+	return e
+}
+
+// EventSagaCompleted is published when all steps of a booking saga completed.
+type EventSagaCompleted struct {
+	SagaID        SagaID               `json:"saga_id"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id"`
+}
+
+func NewEventSagaCompleted() *EventSagaCompleted {
+	return &EventSagaCompleted{}
+}
+
+func (e *EventSagaCompleted) Topic() string { return EventTopicSagaCompleted }
+
+func (e *EventSagaCompleted) WithSagaID(id SagaID) *EventSagaCompleted {
+	e.SagaID = id
+	return e
+}
+
+func (e *EventSagaCompleted) WithReservationID(id shared.ReservationID) *EventSagaCompleted {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventSagaCompleted) WithPaymentID(id payment.PaymentID) *EventSagaCompleted {
+	e.PaymentID = id
+	return e
+}
+
+// EventSagaCompensated is published when a step failed and the completed steps were undone.
+type EventSagaCompensated struct {
+	SagaID        SagaID               `json:"saga_id"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id,omitempty"`
+	FailedStep    string               `json:"failed_step"`
+	Error         string               `json:"error"`
+}
+
+func NewEventSagaCompensated() *EventSagaCompensated {
+	return &EventSagaCompensated{}
+}
+
+func (e *EventSagaCompensated) Topic() string { return EventTopicSagaCompensated }
+
+func (e *EventSagaCompensated) WithSagaID(id SagaID) *EventSagaCompensated {
+	e.SagaID = id
+	return e
+}
+
+func (e *EventSagaCompensated) WithReservationID(id shared.ReservationID) *EventSagaCompensated {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventSagaCompensated) WithPaymentID(id payment.PaymentID) *EventSagaCompensated {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventSagaCompensated) WithFailedStep(step string) *EventSagaCompensated {
+	e.FailedStep = step
+	return e
+}
+
+func (e *EventSagaCompensated) WithError(msg string) *EventSagaCompensated {
+	e.Error = msg
+	return e
+}
+
+// EventSagaFailed is published when a compensation failed, so the booking needs manual repair.
+type EventSagaFailed struct {
+	SagaID        SagaID               `json:"saga_id"`
+	ReservationID shared.ReservationID `json:"reservation_id"`
+	PaymentID     payment.PaymentID    `json:"payment_id,omitempty"`
+	FailedStep    string               `json:"failed_step"`
+	Error         string               `json:"error"`
+}
+
+func NewEventSagaFailed() *EventSagaFailed {
+	return &EventSagaFailed{}
+}
+
+func (e *EventSagaFailed) Topic() string { return EventTopicSagaFailed }
+
+func (e *EventSagaFailed) WithSagaID(id SagaID) *EventSagaFailed {
+	e.SagaID = id
+	return e
+}
+
+func (e *EventSagaFailed) WithReservationID(id shared.ReservationID) *EventSagaFailed {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventSagaFailed) WithPaymentID(id payment.PaymentID) *EventSagaFailed {
+	e.PaymentID = id
+	return e
+}
+
+func (e *EventSagaFailed) WithFailedStep(step string) *EventSagaFailed {
+	e.FailedStep = step
+	return e
+}
+
+func (e *EventSagaFailed) WithError(msg string) *EventSagaFailed {
+	e.Error = msg
+	return e
+}
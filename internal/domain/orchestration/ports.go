@@ -3,6 +3,9 @@ package orchestration
 import (
 	"context"
 
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
 )
@@ -15,4 +18,12 @@ type NotificationService interface {
 	SendCancellationNotice(ctx context.Context, r *reservation.Reservation, reason string) error
 	// SendPaymentReceipt sends a payment receipt to the guest
 	SendPaymentReceipt(ctx context.Context, p *payment.Payment) error
+	// SendCheckInWelcome welcomes the guest who checked in
+	SendCheckInWelcome(ctx context.Context, r *reservation.Reservation) error
 }
+
+// SagaRepository provides CRUD operations for the saga log.
+type SagaRepository resource.Access[SagaID, Saga]
+
+// EventPublisher publishes the saga lifecycle events.
+type EventPublisher event.EventPublisher
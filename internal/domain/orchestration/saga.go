@@ -0,0 +1,154 @@
+package orchestration
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// SagaID is a strongly-typed identifier for sagas.
+type SagaID string
+
+// BookingSagaID returns the ID of the booking saga of the reservation.
+// A reservation has one booking saga, so redelivered events continue the same saga.
+func BookingSagaID(reservationID shared.ReservationID) SagaID {
+	return SagaID("booking-" + string(reservationID))
+}
+
+// SagaStatus represents the state of a saga.
+type SagaStatus string
+
+const (
+	SagaRunning     SagaStatus = "running"
+	SagaCompleted   SagaStatus = "completed"
+	SagaCompensated SagaStatus = "compensated" // a step failed and the completed steps were undone
+	SagaFailed      SagaStatus = "failed"      // a compensation failed too; staff must intervene
+)
+
+// StepStatus represents the state of a saga step.
+type StepStatus string
+
+const (
+	StepCompleted          StepStatus = "completed"
+	StepFailed             StepStatus = "failed"
+	StepCompensated        StepStatus = "compensated"
+	StepCompensationFailed StepStatus = "compensation_failed"
+)
+
+// Steps of the booking saga, in order. With capture on check-in, the capture comes last.
+const (
+	StepCreateReservation  = "create_reservation"
+	StepAuthorizePayment   = "authorize_payment"
+	StepCapturePayment     = "capture_payment"
+	StepConfirmReservation = "confirm_reservation"
+)
+
+// ErrSagaNotFound is returned if no saga was recorded for the reservation.
+var ErrSagaNotFound = errors.New("saga not found")
+
+// SagaStep is an entry of the saga log: a step that ran or a compensation of one.
+type SagaStep struct {
+	Name   string
+	Status StepStatus
+	Error  string
+	At     time.Time
+}
+
+// Saga records the steps of a booking across the reservation and payment contexts,
+// so a failed booking can be traced and its compensations verified.
+type Saga struct {
+	ID            SagaID
+	ReservationID shared.ReservationID
+	PaymentID     payment.PaymentID
+	Status        SagaStatus
+	FailedStep    string
+	Error         string
+	Steps         []SagaStep
+	StartedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewSaga creates a running booking saga for the reservation.
+func NewSaga(reservationID shared.ReservationID, now time.Time) *Saga {
+	return &Saga{
+		ID:            BookingSagaID(reservationID),
+		ReservationID: reservationID,
+		Status:        SagaRunning,
+		StartedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Completed reports whether the step completed and has not been compensated yet.
+func (s *Saga) Completed(step string) bool {
+	done := false
+	for _, st := range s.Steps {
+		if st.Name != step {
+			continue
+		}
+		switch st.Status {
+		case StepCompleted:
+			done = true
+		case StepCompensated, StepCompensationFailed:
+			done = false
+		}
+	}
+	return done
+}
+
+// CompleteStep records a completed step.
+func (s *Saga) CompleteStep(step string, now time.Time) {
+	s.addStep(step, StepCompleted, nil, now)
+}
+
+// FailStep records a failed step; the saga compensates from here.
+func (s *Saga) FailStep(step string, err error, now time.Time) {
+	s.FailedStep = step
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.addStep(step, StepFailed, err, now)
+}
+
+// CompensateStep records the compensation of a completed step; err is the compensation's error.
+func (s *Saga) CompensateStep(step string, err error, now time.Time) {
+	status := StepCompensated
+	if err != nil {
+		status = StepCompensationFailed
+	}
+	s.addStep(step, status, err, now)
+}
+
+// Complete marks the saga as completed.
+func (s *Saga) Complete(now time.Time) {
+	s.Status = SagaCompleted
+	s.UpdatedAt = now
+}
+
+// EndCompensation marks the saga as compensated, or as failed if a compensation failed.
+func (s *Saga) EndCompensation(now time.Time) {
+	s.Status = SagaCompensated
+	for _, st := range s.Steps {
+		if st.Status == StepCompensationFailed {
+			s.Status = SagaFailed
+		}
+	}
+	s.UpdatedAt = now
+}
+
+// Done reports whether the saga has ended.
+func (s *Saga) Done() bool {
+	return s.Status != SagaRunning
+}
+
+// addStep appends an entry to the saga log.
+func (s *Saga) addStep(step string, status StepStatus, err error, now time.Time) {
+	entry := SagaStep{Name: step, Status: status, At: now}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	s.Steps = append(s.Steps, entry)
+	s.UpdatedAt = now
+}
@@ -0,0 +1,250 @@
+package orchestration_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/orchestration"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Saga Test Helpers
+// ============================================================================
+
+// createSagaTestServices creates the test services with a saga log.
+func createSagaTestServices() (*testServices, *mockEventPublisher) {
+	svc := createTestServices()
+	sagaPub := &mockEventPublisher{}
+	svc.bookingService.WithSagaLog(resource.NewInMemoryAccess[orchestration.SagaID, orchestration.Saga](), sagaPub)
+	return svc, sagaPub
+}
+
+// mockMetrics records the observations by name and labels.
+type mockMetrics struct {
+	observed []string
+}
+
+func (m *mockMetrics) IncCounter(name string, labels ...string) {}
+
+func (m *mockMetrics) ObserveHistogram(name string, value float64, labels ...string) {
+	m.observed = append(m.observed, strings.Join(append([]string{name}, labels...), " "))
+}
+
+func sagaTopics(pub *mockEventPublisher) []string {
+	var topics []string
+	for _, evt := range pub.published {
+		topics = append(topics, evt.Topic())
+	}
+	return topics
+}
+
+func completeTestBooking(svc *testServices) error {
+	_, err := svc.bookingService.CompleteBooking(
+		context.Background(), "res-001", "pay-001", "guest-001", "room-101",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(), "credit_card",
+	)
+	return err
+}
+
+// ============================================================================
+// Saga Tests
+// ============================================================================
+
+func Test_Saga_EndCompensation_Should_Be_Compensated(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	saga := orchestration.NewSaga("res-001", now)
+	saga.CompleteStep(orchestration.StepCreateReservation, now)
+	saga.FailStep(orchestration.StepAuthorizePayment, errors.New("declined"), now)
+	saga.CompensateStep(orchestration.StepCreateReservation, nil, now)
+
+	// Act
+	saga.EndCompensation(now)
+
+	// Assert
+	assert.That(t, "status must be compensated", saga.Status, orchestration.SagaCompensated)
+	assert.That(t, "failed step must be recorded", saga.FailedStep, orchestration.StepAuthorizePayment)
+	assert.That(t, "compensated step must no longer count as completed", saga.Completed(orchestration.StepCreateReservation), false)
+}
+
+func Test_Saga_EndCompensation_With_Failed_Compensation_Should_Be_Failed(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	saga := orchestration.NewSaga("res-001", now)
+	saga.CompleteStep(orchestration.StepCreateReservation, now)
+	saga.FailStep(orchestration.StepAuthorizePayment, errors.New("declined"), now)
+	saga.CompensateStep(orchestration.StepCreateReservation, errors.New("database down"), now)
+
+	// Act
+	saga.EndCompensation(now)
+
+	// Assert
+	assert.That(t, "status must be failed", saga.Status, orchestration.SagaFailed)
+}
+
+// ============================================================================
+// BookingService Saga Log Tests
+// ============================================================================
+
+func Test_BookingService_CompleteBooking_Should_Record_Completed_Saga(t *testing.T) {
+	// Arrange
+	svc, sagaPub := createSagaTestServices()
+
+	// Act
+	err := completeTestBooking(svc)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	saga, _ := svc.bookingService.GetSaga(context.Background(), "res-001")
+	assert.That(t, "saga must be completed", saga.Status, orchestration.SagaCompleted)
+	assert.That(t, "all four steps must be recorded", len(saga.Steps), 4)
+	assert.That(t, "lifecycle events must be published", sagaTopics(sagaPub), []string{orchestration.EventTopicSagaStarted, orchestration.EventTopicSagaCompleted})
+}
+
+func Test_BookingService_WithMetrics_Should_Observe_Saga_Duration_By_Outcome(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	metrics := &mockMetrics{}
+	svc.bookingService.WithMetrics(metrics)
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+
+	// Act
+	_ = completeTestBooking(svc)
+
+	// Assert
+	assert.That(t, "duration must be observed once when the saga ends", metrics.observed, []string{orchestration.MetricSagaDuration + " outcome " + string(orchestration.SagaCompensated)})
+}
+
+func Test_BookingService_CompleteBooking_When_Authorization_Fails_Should_Record_Compensation(t *testing.T) {
+	// Arrange
+	svc, sagaPub := createSagaTestServices()
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+
+	// Act
+	err := completeTestBooking(svc)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	saga, _ := svc.bookingService.GetSaga(context.Background(), "res-001")
+	assert.That(t, "saga must be compensated", saga.Status, orchestration.SagaCompensated)
+	assert.That(t, "failed step must be authorization", saga.FailedStep, orchestration.StepAuthorizePayment)
+	last := saga.Steps[len(saga.Steps)-1]
+	assert.That(t, "reservation must be compensated", last, orchestration.SagaStep{Name: orchestration.StepCreateReservation, Status: orchestration.StepCompensated, At: last.At})
+	assert.That(t, "compensated event must be published", sagaTopics(sagaPub)[1], orchestration.EventTopicSagaCompensated)
+}
+
+func Test_BookingService_CompleteBooking_When_Confirmation_Fails_Should_Refund_And_Cancel(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	ctx := context.Background()
+	// Storing the confirmation fails, the cancellation succeeds.
+	svc.reservationRepo.updateErrOnce = errors.New("database timeout")
+
+	// Act
+	err := completeTestBooking(svc)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPayment.Status, payment.StatusRefunded)
+	storedRes, _ := svc.reservationRepo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be cancelled", storedRes.Status, reservation.StatusCancelled)
+	saga, _ := svc.bookingService.GetSaga(ctx, "res-001")
+	assert.That(t, "saga must be compensated", saga.Status, orchestration.SagaCompensated)
+	assert.That(t, "failed step must be confirmation", saga.FailedStep, orchestration.StepConfirmReservation)
+}
+
+func Test_BookingService_CompleteBooking_When_Refund_Fails_Should_Record_Failed_Saga(t *testing.T) {
+	// Arrange
+	svc, sagaPub := createSagaTestServices()
+	svc.reservationRepo.updateErrOnce = errors.New("database timeout")
+	svc.paymentGateway.refundErr = errors.New("gateway down")
+
+	// Act
+	err := completeTestBooking(svc)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	saga, _ := svc.bookingService.GetSaga(context.Background(), "res-001")
+	assert.That(t, "saga must be failed", saga.Status, orchestration.SagaFailed)
+	assert.That(t, "failed event must be published", sagaTopics(sagaPub)[1], orchestration.EventTopicSagaFailed)
+}
+
+func Test_BookingService_OnPaymentFailed_Should_Compensate_Saga_Once(t *testing.T) {
+	// Arrange
+	svc, sagaPub := createSagaTestServices()
+	ctx := context.Background()
+	_, _ = svc.bookingService.InitiateBooking(ctx, "res-001", "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, "res-001", "pay-001", validBookingMoney(), "credit_card", nil)
+
+	// Act
+	err := svc.bookingService.OnPaymentFailed(ctx, "res-001", "payment_failed: card_declined")
+	redeliveryErr := svc.bookingService.OnPaymentFailed(ctx, "res-001", "payment_failed: card_declined")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "redelivery must be ignored", redeliveryErr == nil, true)
+	saga, _ := svc.bookingService.GetSaga(ctx, "res-001")
+	assert.That(t, "saga must be compensated", saga.Status, orchestration.SagaCompensated)
+	assert.That(t, "lifecycle events must be published once", sagaTopics(sagaPub), []string{orchestration.EventTopicSagaStarted, orchestration.EventTopicSagaCompensated})
+}
+
+func Test_BookingService_OnPaymentCaptured_When_Confirmation_Fails_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	ctx := context.Background()
+	reservationID := shared.ReservationID("res-001")
+	_, _ = svc.bookingService.InitiateBooking(ctx, reservationID, "guest-001", "room-101", validBookingDateRange(), validBookingMoney(), validBookingGuests())
+	_, _ = svc.bookingService.OnReservationCreated(ctx, reservationID, "pay-001", validBookingMoney(), "credit_card", nil)
+	_ = svc.bookingService.OnPaymentAuthorized(ctx, "pay-001", reservationID)
+	// The reservation was confirmed meanwhile, so confirming it again fails.
+	_ = svc.reservationService.ConfirmReservation(ctx, reservationID)
+
+	// Act
+	err := svc.bookingService.OnPaymentCaptured(ctx, reservationID)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	storedPayment, _ := svc.paymentRepo.Read(ctx, "pay-001")
+	assert.That(t, "payment must be refunded", storedPayment.Status, payment.StatusRefunded)
+	saga, _ := svc.bookingService.GetSaga(ctx, reservationID)
+	assert.That(t, "saga must be compensated", saga.Status, orchestration.SagaCompensated)
+}
+
+func Test_BookingService_GetSaga_Without_Saga_Log_Should_Return_ErrSagaNotFound(t *testing.T) {
+	// Arrange
+	svc := createTestServices()
+
+	// Act
+	_, err := svc.bookingService.GetSaga(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be ErrSagaNotFound", errors.Is(err, orchestration.ErrSagaNotFound), true)
+}
+
+func Test_BookingService_FailedSagas_Should_Return_Compensated_Sagas_Only(t *testing.T) {
+	// Arrange
+	svc, _ := createSagaTestServices()
+	_ = completeTestBooking(svc)
+	svc.paymentGateway.authorizeErr = errors.New("card declined")
+	_, _ = svc.bookingService.CompleteBooking(
+		context.Background(), "res-002", "pay-002", "guest-001", "room-102",
+		validBookingDateRange(), validBookingMoney(), validBookingGuests(), "credit_card",
+	)
+
+	// Act
+	sagas, err := svc.bookingService.FailedSagas(context.Background(), 10)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the compensated saga must be returned", len(sagas), 1)
+	assert.That(t, "saga must be of the failed booking", sagas[0].ReservationID, shared.ReservationID("res-002"))
+}
@@ -6,6 +6,7 @@ package payment
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -14,10 +15,37 @@ import (
 // Type aliases for shared types
 type ReservationID = shared.ReservationID
 type Money = shared.Money
+type FXSnapshot = shared.FXSnapshot
 
 // PaymentID is a strongly-typed identifier for payments.
 type PaymentID string
 
+// PaymentIDPrefix marks payment IDs created by NewPaymentID and PaymentIDForReservation.
+const PaymentIDPrefix = "pay_"
+
+// NewPaymentID returns a new, time-sortable payment ID, e.g. "pay_01J9Z3M5Q8X7T6V4R2N0B1C3D5".
+func NewPaymentID() PaymentID {
+	return PaymentID(PaymentIDPrefix + shared.NewULID())
+}
+
+// PaymentIDForReservation derives the ID of the booking payment from the reservation ID,
+// so redelivered reservation.created events authorize the same payment.
+// Typed reservation IDs share their ULID with the payment; older ones keep the "pay-" scheme.
+func PaymentIDForReservation(reservationID ReservationID) PaymentID {
+	if ulid, ok := strings.CutPrefix(string(reservationID), shared.ReservationIDPrefix); ok && shared.IsULID(ulid) {
+		return PaymentID(PaymentIDPrefix + ulid)
+	}
+	return PaymentID("pay-" + string(reservationID))
+}
+
+// ParsePaymentID validates an inbound payment ID, e.g. from an MCP tool call.
+func ParsePaymentID(s string) (PaymentID, error) {
+	if err := shared.ValidateID("payment", PaymentIDPrefix, s); err != nil {
+		return "", err
+	}
+	return PaymentID(s), nil
+}
+
 // PaymentStatus represents the state of a payment.
 type PaymentStatus string
 
@@ -29,6 +57,9 @@ const (
 	StatusRefunded   PaymentStatus = "refunded"
 )
 
+// MethodGiftCard is the payment method of payments redeemed from a gift card.
+const MethodGiftCard = "gift_card"
+
 // Payment is the aggregate root for payment processing.
 type Payment struct {
 	ID            PaymentID
@@ -40,6 +71,8 @@ type Payment struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	Attempts      []PaymentAttempt
+	Refunds       []Refund    // partial and full refunds, oldest first
+	FX            *FXSnapshot // rate to the currency of record at booking time; nil if not recorded
 }
 
 // Payment errors.
@@ -51,6 +84,9 @@ var (
 	ErrNotCaptured              = errors.New("payment not captured")
 	ErrAlreadyRefunded          = errors.New("payment already refunded")
 	ErrCannotRefund             = errors.New("can only refund captured payments")
+	ErrInvalidRefundAmount      = errors.New("refund must be positive, in the payment currency and at most the remaining amount")
+	ErrFXSnapshotMissing        = errors.New("no exchange rate snapshot to the currency of record")
+	ErrFXSnapshotStale          = errors.New("exchange rate snapshot is too old to capture")
 )
 
 // NewPayment creates a new payment in pending status.
@@ -125,6 +161,7 @@ func (p *Payment) Refund() error {
 		return ErrCannotRefund
 	}
 
+	p.Refunds = append(p.Refunds, NewRefund(p.RemainingAmount(), ""))
 	p.Status = StatusRefunded
 	p.UpdatedAt = time.Now()
 	p.addAttempt(StatusRefunded, "", "")
@@ -132,6 +169,50 @@ func (p *Payment) Refund() error {
 	return nil
 }
 
+// RefundPartially refunds part of a captured payment, e.g. a goodwill refund for one night.
+// Refunding the remaining amount transitions the payment to refunded status.
+func (p *Payment) RefundPartially(amount Money, reason string) error {
+	if p.Status == StatusRefunded {
+		return ErrAlreadyRefunded
+	}
+
+	if p.Status != StatusCaptured {
+		return ErrCannotRefund
+	}
+
+	remaining := p.RemainingAmount()
+	if amount.Currency != p.Amount.Currency || amount.Amount <= 0 || amount.Amount > remaining.Amount {
+		return ErrInvalidRefundAmount
+	}
+
+	p.Refunds = append(p.Refunds, NewRefund(amount, reason))
+	p.UpdatedAt = time.Now()
+	if amount.Amount == remaining.Amount {
+		p.Status = StatusRefunded
+		p.addAttempt(StatusRefunded, "", "")
+	}
+
+	return nil
+}
+
+// RefundedAmount returns the sum of the refunds.
+// Payments refunded before refunds were recorded count as fully refunded.
+func (p *Payment) RefundedAmount() Money {
+	if p.Status == StatusRefunded && len(p.Refunds) == 0 {
+		return p.Amount
+	}
+	refunded := shared.NewMoney(0, p.Amount.Currency)
+	for _, r := range p.Refunds {
+		refunded.Amount += r.Amount.Amount
+	}
+	return refunded
+}
+
+// RemainingAmount returns the captured amount that has not been refunded yet.
+func (p *Payment) RemainingAmount() Money {
+	return shared.NewMoney(p.Amount.Amount-p.RefundedAmount().Amount, p.Amount.Currency)
+}
+
 // IsSuccessful returns true if the payment was successfully captured.
 func (p *Payment) IsSuccessful() bool {
 	return p.Status == StatusCaptured
@@ -153,6 +234,12 @@ func (p *Payment) CanBeRetried() bool {
 	return failedAttempts < 3
 }
 
+// RecordFailedAttempt records a failed attempt that will be retried, without failing the payment.
+func (p *Payment) RecordFailedAttempt(errorCode, errorMsg string) {
+	p.UpdatedAt = time.Now()
+	p.addAttempt(StatusFailed, errorCode, errorMsg)
+}
+
 // addAttempt adds a payment attempt to the history.
 func (p *Payment) addAttempt(status PaymentStatus, errorCode, errorMsg string) {
 	attempt := PaymentAttempt{
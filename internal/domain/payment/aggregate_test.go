@@ -1,6 +1,8 @@
 package payment_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
@@ -25,6 +27,46 @@ func createValidPayment() *payment.Payment {
 	)
 }
 
+// ============================================================================
+// PaymentID Tests
+// ============================================================================
+
+func Test_NewPaymentID_Should_Be_Valid(t *testing.T) {
+	// Act
+	id, err := payment.ParsePaymentID(string(payment.NewPaymentID()))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "id must start with the prefix", strings.HasPrefix(string(id), payment.PaymentIDPrefix), true)
+}
+
+func Test_PaymentIDForReservation_Should_Share_The_ULID(t *testing.T) {
+	// Arrange
+	reservationID := shared.NewReservationID()
+
+	// Act
+	id := payment.PaymentIDForReservation(reservationID)
+
+	// Assert
+	assert.That(t, "id must reuse the ULID", string(id), "pay_"+strings.TrimPrefix(string(reservationID), "res_"))
+}
+
+func Test_PaymentIDForReservation_With_Legacy_ID_Should_Keep_The_Old_Scheme(t *testing.T) {
+	// Act
+	id := payment.PaymentIDForReservation("res-001")
+
+	// Assert
+	assert.That(t, "id must be derived as before", id, payment.PaymentID("pay-res-001"))
+}
+
+func Test_ParsePaymentID_With_Malformed_ID_Should_Return_ErrInvalidID(t *testing.T) {
+	// Act
+	_, err := payment.ParsePaymentID("pay_not-a-ulid")
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidID", errors.Is(err, shared.ErrInvalidID), true)
+}
+
 // ============================================================================
 // NewPayment Tests
 // ============================================================================
@@ -565,3 +607,82 @@ func Test_EventRefunded_Builder_Should_Set_All_Fields(t *testing.T) {
 	assert.That(t, "ReservationID must match", evt.ReservationID, payment.ReservationID("res-001"))
 	assert.That(t, "Amount must match", evt.Amount, validMoney())
 }
+
+// ============================================================================
+// State Transition Tests - Partial Refund
+// ============================================================================
+
+func Test_Payment_RefundPartially_Should_Keep_Captured_Status(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(2500, "USD"), "minibar error")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must remain captured", p.Status, payment.StatusCaptured)
+	assert.That(t, "refunded amount must match", p.RefundedAmount(), shared.NewMoney(2500, "USD"))
+	assert.That(t, "remaining amount must match", p.RemainingAmount(), shared.NewMoney(7500, "USD"))
+	assert.That(t, "reason must be recorded", p.Refunds[0].Reason, "minibar error")
+}
+
+func Test_Payment_RefundPartially_Of_Remaining_Amount_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+	_ = p.RefundPartially(shared.NewMoney(2500, "USD"), "")
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(7500, "USD"), "")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be refunded", p.Status, payment.StatusRefunded)
+	assert.That(t, "remaining amount must be zero", p.RemainingAmount().Amount, int64(0))
+}
+
+func Test_Payment_RefundPartially_Above_Remaining_Amount_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(10001, "USD"), "")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidRefundAmount", err, payment.ErrInvalidRefundAmount)
+}
+
+func Test_Payment_RefundPartially_In_Other_Currency_Should_Return_Error(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+
+	// Act
+	err := p.RefundPartially(shared.NewMoney(1000, "EUR"), "")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidRefundAmount", err, payment.ErrInvalidRefundAmount)
+}
+
+func Test_Payment_Refund_After_Partial_Refund_Should_Refund_Remaining_Amount(t *testing.T) {
+	// Arrange
+	p := createValidPayment()
+	_ = p.Authorize("tx-12345")
+	_ = p.Capture()
+	_ = p.RefundPartially(shared.NewMoney(2500, "USD"), "")
+
+	// Act
+	err := p.Refund()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "second refund must be the remaining amount", p.Refunds[1].Amount, shared.NewMoney(7500, "USD"))
+	assert.That(t, "refunded amount must be the full amount", p.RefundedAmount(), validMoney())
+}
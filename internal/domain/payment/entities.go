@@ -19,3 +19,19 @@ func NewPaymentAttempt(status PaymentStatus, errorCode, errorMsg string) Payment
 		ErrorMsg:    errorMsg,
 	}
 }
+
+// Refund represents a partial or full refund of a captured payment (entity within Payment aggregate).
+type Refund struct {
+	Amount     Money
+	Reason     string
+	RefundedAt time.Time
+}
+
+// NewRefund creates a new refund entity.
+func NewRefund(amount Money, reason string) Refund {
+	return Refund{
+		Amount:     amount,
+		Reason:     reason,
+		RefundedAt: time.Now(),
+	}
+}
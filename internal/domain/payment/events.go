@@ -1,19 +1,42 @@
 package payment
 
+import (
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
 // Event topics for Kafka.
 const (
 	EventTopicAuthorized = "payment.authorized"
 	EventTopicCaptured   = "payment.captured"
 	EventTopicFailed     = "payment.failed"
 	EventTopicRefunded   = "payment.refunded"
+	EventTopicAdjusted   = "payment.adjusted"
 )
 
+// ExampleEvents returns an example of every event published by this context.
+// They document the events in the event catalog.
+func ExampleEvents() []event.Event {
+	amount := shared.NewMoney(45000, "USD")
+	fx := shared.NewIdentityFXSnapshot("USD", time.Date(2030, 5, 1, 15, 0, 0, 0, time.UTC))
+	return []event.Event{
+		NewEventAuthorized().WithPaymentID("pay-2001").WithReservationID("res-1001").WithTransactionID("txn-3001").WithAmount(amount).WithFX(&fx),
+		NewEventCaptured().WithPaymentID("pay-2001").WithReservationID("res-1001").WithAmount(amount).WithFX(&fx),
+		NewEventFailed().WithPaymentID("pay-2001").WithReservationID("res-1001").WithErrorCode("card_declined").WithErrorMsg("Card was declined"),
+		NewEventRefunded().WithPaymentID("pay-2001").WithReservationID("res-1001").WithAmount(amount).WithFX(&fx),
+		NewEventAdjusted().WithAdjustmentID("adj-4001").WithReservationID("res-1001").WithAmount(shared.NewMoney(1800, "USD")).WithReason("Minibar"),
+	}
+}
+
 // EventAuthorized is published when a payment is authorized.
 type EventAuthorized struct {
 	PaymentID     PaymentID     `json:"payment_id"`
 	ReservationID ReservationID `json:"reservation_id"`
 	TransactionID string        `json:"transaction_id"`
 	Amount        Money         `json:"amount"`
+	FX            *FXSnapshot   `json:"fx,omitempty"`
 }
 
 func NewEventAuthorized() *EventAuthorized {
@@ -42,11 +65,17 @@ func (e *EventAuthorized) WithAmount(m Money) *EventAuthorized {
 	return e
 }
 
+func (e *EventAuthorized) WithFX(fx *FXSnapshot) *EventAuthorized {
+	e.FX = fx
+	return e
+}
+
 // EventCaptured is published when a payment is captured.
 type EventCaptured struct {
 	PaymentID     PaymentID     `json:"payment_id"`
 	ReservationID ReservationID `json:"reservation_id"`
 	Amount        Money         `json:"amount"`
+	FX            *FXSnapshot   `json:"fx,omitempty"`
 }
 
 func NewEventCaptured() *EventCaptured {
@@ -70,6 +99,11 @@ func (e *EventCaptured) WithAmount(m Money) *EventCaptured {
 	return e
 }
 
+func (e *EventCaptured) WithFX(fx *FXSnapshot) *EventCaptured {
+	e.FX = fx
+	return e
+}
+
 // EventFailed is published when a payment fails.
 type EventFailed struct {
 	PaymentID     PaymentID     `json:"payment_id"`
@@ -109,6 +143,7 @@ type EventRefunded struct {
 	PaymentID     PaymentID     `json:"payment_id"`
 	ReservationID ReservationID `json:"reservation_id"`
 	Amount        Money         `json:"amount"`
+	FX            *FXSnapshot   `json:"fx,omitempty"`
 }
 
 func NewEventRefunded() *EventRefunded {
@@ -131,3 +166,42 @@ func (e *EventRefunded) WithAmount(m Money) *EventRefunded {
 	e.Amount = m
 	return e
 }
+
+func (e *EventRefunded) WithFX(fx *FXSnapshot) *EventRefunded {
+	e.FX = fx
+	return e
+}
+
+// EventAdjusted is published when a charge or credit is added to a reservation's folio.
+type EventAdjusted struct {
+	AdjustmentID  AdjustmentID  `json:"adjustment_id"`
+	ReservationID ReservationID `json:"reservation_id"`
+	Amount        Money         `json:"amount"` // negative for credits
+	Reason        string        `json:"reason"`
+}
+
+func NewEventAdjusted() *EventAdjusted {
+	return &EventAdjusted{}
+}
+
+func (e *EventAdjusted) Topic() string { return EventTopicAdjusted }
+
+func (e *EventAdjusted) WithAdjustmentID(id AdjustmentID) *EventAdjusted {
+	e.AdjustmentID = id
+	return e
+}
+
+func (e *EventAdjusted) WithReservationID(id ReservationID) *EventAdjusted {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventAdjusted) WithAmount(m Money) *EventAdjusted {
+	e.Amount = m
+	return e
+}
+
+func (e *EventAdjusted) WithReason(reason string) *EventAdjusted {
+	e.Reason = reason
+	return e
+}
@@ -0,0 +1,207 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// AdjustmentID is a strongly-typed identifier for adjustments.
+type AdjustmentID string
+
+// Adjustment is a charge or credit on a reservation's folio besides the room rate,
+// e.g. a minibar charge (positive) or a goodwill credit (negative).
+type Adjustment struct {
+	ID            AdjustmentID
+	ReservationID ReservationID
+	Amount        Money
+	Reason        string
+	CreatedAt     time.Time
+}
+
+// Adjustment errors.
+var (
+	ErrInvalidAdjustment = errors.New("adjustment must be non-zero and have a reason")
+	ErrCurrencyMismatch  = errors.New("amount must be in the reservation currency")
+)
+
+// Kinds of financial summary lines.
+const (
+	LineRoomCharges   = "room_charges"
+	LineAdjustment    = "adjustment"
+	LineAuthorization = "authorization"
+	LineCapture       = "capture"
+	LineGiftCard      = "gift_card"
+	LineRefund        = "refund"
+)
+
+// FinancialLine is an entry of the financial summary. Amounts are positive except for
+// credit adjustments; the kind decides whether the line is a charge, a payment or a refund.
+type FinancialLine struct {
+	Kind        string
+	Description string
+	Amount      Money
+	At          time.Time
+}
+
+// FinancialSummary nets the charges and payments of a reservation.
+// Balance is positive if the guest still owes money and negative if the guest is owed a refund.
+// Authorized amounts are held on the guest's card but not paid yet, so they do not reduce the balance.
+type FinancialSummary struct {
+	ReservationID ReservationID
+	RoomCharges   Money
+	Adjustments   Money
+	TotalCharges  Money
+	Authorized    Money
+	Captured      Money
+	GiftCards     Money
+	Refunded      Money
+	NetPaid       Money
+	Balance       Money
+	Lines         []FinancialLine
+}
+
+// NewFinancialSummary nets the room charges, adjustments and payments of the reservation.
+// Lines are sorted chronologically with the room charges first.
+func NewFinancialSummary(reservationID ReservationID, roomCharges Money, payments []Payment, adjustments []Adjustment) FinancialSummary {
+	currency := roomCharges.Currency
+	zero := shared.NewMoney(0, currency)
+	s := FinancialSummary{
+		ReservationID: reservationID,
+		RoomCharges:   roomCharges,
+		Adjustments:   zero,
+		Authorized:    zero,
+		Captured:      zero,
+		GiftCards:     zero,
+		Refunded:      zero,
+		Lines:         []FinancialLine{{Kind: LineRoomCharges, Description: "Room charges", Amount: roomCharges}},
+	}
+
+	var lines []FinancialLine
+	for _, a := range adjustments {
+		s.Adjustments.Amount += a.Amount.Amount
+		lines = append(lines, FinancialLine{Kind: LineAdjustment, Description: a.Reason, Amount: a.Amount, At: a.CreatedAt})
+	}
+	for _, p := range payments {
+		switch {
+		case p.Status == StatusAuthorized:
+			s.Authorized.Amount += p.Amount.Amount
+			lines = append(lines, FinancialLine{Kind: LineAuthorization, Description: "Authorized (" + p.PaymentMethod + ")", Amount: p.Amount, At: p.UpdatedAt})
+		case p.Status == StatusCaptured || p.Status == StatusRefunded:
+			kind, description := LineCapture, "Captured ("+p.PaymentMethod+")"
+			if p.PaymentMethod == MethodGiftCard {
+				kind, description = LineGiftCard, "Gift card"
+				s.GiftCards.Amount += p.Amount.Amount
+			} else {
+				s.Captured.Amount += p.Amount.Amount
+			}
+			lines = append(lines, FinancialLine{Kind: kind, Description: description, Amount: p.Amount, At: p.CreatedAt})
+			s.Refunded.Amount += p.RefundedAmount().Amount
+			for _, r := range p.Refunds {
+				lines = append(lines, FinancialLine{Kind: LineRefund, Description: r.Reason, Amount: r.Amount, At: r.RefundedAt})
+			}
+			if p.Status == StatusRefunded && len(p.Refunds) == 0 {
+				lines = append(lines, FinancialLine{Kind: LineRefund, Amount: p.Amount, At: p.UpdatedAt})
+			}
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].At.Before(lines[j].At) })
+	s.Lines = append(s.Lines, lines...)
+
+	s.TotalCharges = shared.NewMoney(roomCharges.Amount+s.Adjustments.Amount, currency)
+	s.NetPaid = shared.NewMoney(s.Captured.Amount+s.GiftCards.Amount-s.Refunded.Amount, currency)
+	s.Balance = shared.NewMoney(s.TotalCharges.Amount-s.NetPaid.Amount, currency)
+	return s
+}
+
+// FinancialService records folio adjustments and builds the financial summary of reservations.
+type FinancialService struct {
+	payments       *Service
+	adjustmentRepo AdjustmentRepository
+	charges        ReservationCharges
+}
+
+// NewFinancialService creates a new financial service.
+func NewFinancialService(payments *Service, adjustmentRepo AdjustmentRepository, charges ReservationCharges) *FinancialService {
+	return &FinancialService{
+		payments:       payments,
+		adjustmentRepo: adjustmentRepo,
+		charges:        charges,
+	}
+}
+
+// AddAdjustment records a charge (positive) or credit (negative) on the reservation's folio
+// and publishes it, e.g. for the ledger.
+func (s *FinancialService) AddAdjustment(ctx context.Context, id AdjustmentID, reservationID ReservationID, amount Money, reason string) (*Adjustment, error) {
+	if amount.Amount == 0 || reason == "" {
+		return nil, ErrInvalidAdjustment
+	}
+	roomCharges, err := s.charges.RoomCharges(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read room charges: %w", err)
+	}
+	if amount.Currency != roomCharges.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	adjustment := Adjustment{
+		ID:            id,
+		ReservationID: reservationID,
+		Amount:        amount,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.adjustmentRepo.Create(ctx, id, adjustment); err != nil {
+		return nil, fmt.Errorf("failed to persist adjustment: %w", err)
+	}
+	if s.payments.auditLog != nil {
+		s.payments.auditLog.Record(ctx, shared.NewAuditEntry(ctx, "adjustment", string(id), shared.AuditAdjust, nil, shared.AuditState(adjustment)))
+	}
+
+	evt := NewEventAdjusted().
+		WithAdjustmentID(id).
+		WithReservationID(reservationID).
+		WithAmount(amount).
+		WithReason(reason)
+	if err := s.payments.publisher.Publish(ctx, evt); err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+	return &adjustment, nil
+}
+
+// Summary returns the financial summary of the reservation.
+func (s *FinancialService) Summary(ctx context.Context, reservationID ReservationID) (*FinancialSummary, error) {
+	roomCharges, err := s.charges.RoomCharges(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read room charges: %w", err)
+	}
+	payments, err := s.payments.ListPaymentsByReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.adjustmentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read adjustments: %w", err)
+	}
+	var adjustments []Adjustment
+	for _, a := range all {
+		if a.ReservationID == reservationID {
+			adjustments = append(adjustments, a)
+		}
+	}
+	summary := NewFinancialSummary(reservationID, roomCharges, payments, adjustments)
+	return &summary, nil
+}
+
+// ListAdjustments returns the adjustments of all reservations, e.g. to reconcile the ledger.
+func (s *FinancialService) ListAdjustments(ctx context.Context) ([]Adjustment, error) {
+	adjustments, err := s.adjustmentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read adjustments: %w", err)
+	}
+	return adjustments, nil
+}
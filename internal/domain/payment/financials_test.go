@@ -0,0 +1,183 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/payment"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Financials Test Helpers
+// ============================================================================
+
+type mockReservationCharges struct {
+	amount shared.Money
+	err    error
+}
+
+func (m *mockReservationCharges) RoomCharges(ctx context.Context, reservationID payment.ReservationID) (shared.Money, error) {
+	return m.amount, m.err
+}
+
+func createFinancialTestService(t *testing.T) (*payment.FinancialService, *payment.Service) {
+	t.Helper()
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, &mockEventPublisher{})
+	adjustments := resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment]()
+	charges := &mockReservationCharges{amount: shared.NewMoney(30000, "USD")}
+	return payment.NewFinancialService(service, adjustments, charges), service
+}
+
+// ============================================================================
+// NewFinancialSummary Tests
+// ============================================================================
+
+func Test_NewFinancialSummary_Without_Payments_Should_Owe_Room_Charges(t *testing.T) {
+	// Arrange & Act
+	s := payment.NewFinancialSummary("res-001", shared.NewMoney(30000, "USD"), nil, nil)
+
+	// Assert
+	assert.That(t, "balance must be the room charges", s.Balance, shared.NewMoney(30000, "USD"))
+	assert.That(t, "room charges must be the first line", s.Lines[0].Kind, payment.LineRoomCharges)
+}
+
+func Test_NewFinancialSummary_Should_Net_Captures_Gift_Cards_Refunds_And_Adjustments(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	card := payment.NewPayment("pay-001", "res-001", shared.NewMoney(20000, "USD"), "credit_card")
+	_ = card.Authorize("tx-1")
+	_ = card.Capture()
+	_ = card.RefundPartially(shared.NewMoney(5000, "USD"), "goodwill")
+	gift := payment.NewPayment("pay-002", "res-001", shared.NewMoney(10000, "USD"), payment.MethodGiftCard)
+	_ = gift.Authorize("tx-2")
+	_ = gift.Capture()
+	held := payment.NewPayment("pay-003", "res-001", shared.NewMoney(4000, "USD"), "credit_card")
+	_ = held.Authorize("tx-3")
+	adjustments := []payment.Adjustment{
+		{ID: "adj-001", ReservationID: "res-001", Amount: shared.NewMoney(3000, "USD"), Reason: "Minibar", CreatedAt: now},
+		{ID: "adj-002", ReservationID: "res-001", Amount: shared.NewMoney(-1000, "USD"), Reason: "Late check-in", CreatedAt: now},
+	}
+
+	// Act
+	s := payment.NewFinancialSummary("res-001", shared.NewMoney(30000, "USD"), []payment.Payment{*card, *gift, *held}, adjustments)
+
+	// Assert
+	assert.That(t, "adjustments must be netted", s.Adjustments, shared.NewMoney(2000, "USD"))
+	assert.That(t, "total charges must include adjustments", s.TotalCharges, shared.NewMoney(32000, "USD"))
+	assert.That(t, "authorized must match", s.Authorized, shared.NewMoney(4000, "USD"))
+	assert.That(t, "captured must exclude gift cards", s.Captured, shared.NewMoney(20000, "USD"))
+	assert.That(t, "gift cards must match", s.GiftCards, shared.NewMoney(10000, "USD"))
+	assert.That(t, "refunded must match", s.Refunded, shared.NewMoney(5000, "USD"))
+	assert.That(t, "net paid must match", s.NetPaid, shared.NewMoney(25000, "USD"))
+	assert.That(t, "balance must ignore authorizations", s.Balance, shared.NewMoney(7000, "USD"))
+	assert.That(t, "must have a line per entry", len(s.Lines), 7)
+}
+
+func Test_NewFinancialSummary_With_Overpayment_Should_Have_Negative_Balance(t *testing.T) {
+	// Arrange
+	p := payment.NewPayment("pay-001", "res-001", shared.NewMoney(30000, "USD"), "credit_card")
+	_ = p.Authorize("tx-1")
+	_ = p.Capture()
+	credit := payment.Adjustment{ID: "adj-001", ReservationID: "res-001", Amount: shared.NewMoney(-5000, "USD"), Reason: "Goodwill"}
+
+	// Act
+	s := payment.NewFinancialSummary("res-001", shared.NewMoney(30000, "USD"), []payment.Payment{*p}, []payment.Adjustment{credit})
+
+	// Assert
+	assert.That(t, "guest must be owed the credit", s.Balance, shared.NewMoney(-5000, "USD"))
+}
+
+func Test_NewFinancialSummary_With_Legacy_Refund_Should_Count_Full_Amount(t *testing.T) {
+	// Arrange
+	p := payment.NewPayment("pay-001", "res-001", shared.NewMoney(30000, "USD"), "credit_card")
+	p.Status = payment.StatusRefunded
+
+	// Act
+	s := payment.NewFinancialSummary("res-001", shared.NewMoney(30000, "USD"), []payment.Payment{*p}, nil)
+
+	// Assert
+	assert.That(t, "refunded must be the full amount", s.Refunded, shared.NewMoney(30000, "USD"))
+	assert.That(t, "balance must be the room charges", s.Balance, shared.NewMoney(30000, "USD"))
+}
+
+// ============================================================================
+// FinancialService Tests
+// ============================================================================
+
+func Test_FinancialService_Summary_Should_Include_Payments_And_Adjustments_Of_Reservation(t *testing.T) {
+	// Arrange
+	financials, service := createFinancialTestService(t)
+	ctx := context.Background()
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(30000, "USD"), "credit_card")
+	_ = service.CapturePayment(ctx, "pay-001")
+	_ = service.RefundPaymentPartially(ctx, "pay-001", shared.NewMoney(2000, "USD"), "goodwill")
+	_, _ = service.AuthorizePayment(ctx, "pay-002", "res-002", shared.NewMoney(9900, "USD"), "credit_card")
+	_, _ = financials.AddAdjustment(ctx, "adj-001", "res-001", shared.NewMoney(1500, "USD"), "Minibar")
+
+	// Act
+	s, err := financials.Summary(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "authorized must exclude other reservations", s.Authorized, shared.NewMoney(0, "USD"))
+	assert.That(t, "net paid must match", s.NetPaid, shared.NewMoney(28000, "USD"))
+	assert.That(t, "balance must match", s.Balance, shared.NewMoney(3500, "USD"))
+}
+
+func Test_FinancialService_AddAdjustment_Should_Publish_Event(t *testing.T) {
+	// Arrange
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{}, publisher)
+	charges := &mockReservationCharges{amount: shared.NewMoney(30000, "USD")}
+	financials := payment.NewFinancialService(service, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), charges)
+
+	// Act
+	_, err := financials.AddAdjustment(context.Background(), "adj-001", "res-001", shared.NewMoney(-1500, "USD"), "Late check-in")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+	evt, ok := publisher.published[0].(*payment.EventAdjusted)
+	assert.That(t, "event must be adjusted", ok, true)
+	assert.That(t, "event must carry the credit", evt.Amount, shared.NewMoney(-1500, "USD"))
+}
+
+func Test_FinancialService_AddAdjustment_Without_Reason_Should_Return_ErrInvalidAdjustment(t *testing.T) {
+	// Arrange
+	financials, _ := createFinancialTestService(t)
+
+	// Act
+	_, err := financials.AddAdjustment(context.Background(), "adj-001", "res-001", shared.NewMoney(1500, "USD"), "")
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidAdjustment", errors.Is(err, payment.ErrInvalidAdjustment), true)
+}
+
+func Test_FinancialService_AddAdjustment_In_Other_Currency_Should_Return_ErrCurrencyMismatch(t *testing.T) {
+	// Arrange
+	financials, _ := createFinancialTestService(t)
+
+	// Act
+	_, err := financials.AddAdjustment(context.Background(), "adj-001", "res-001", shared.NewMoney(1500, "EUR"), "Minibar")
+
+	// Assert
+	assert.That(t, "err must be ErrCurrencyMismatch", errors.Is(err, payment.ErrCurrencyMismatch), true)
+}
+
+func Test_FinancialService_Summary_When_Charges_Unavailable_Should_Return_Error(t *testing.T) {
+	// Arrange
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{}, &mockEventPublisher{})
+	adjustments := resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment]()
+	financials := payment.NewFinancialService(service, adjustments, &mockReservationCharges{err: errors.New("not found")})
+
+	// Act
+	_, err := financials.Summary(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -22,3 +22,17 @@ type PaymentGateway interface {
 
 // EventPublisher publishes domain events.
 type EventPublisher event.EventPublisher
+
+// AdjustmentRepository provides CRUD operations for folio adjustments.
+type AdjustmentRepository resource.Access[AdjustmentID, Adjustment]
+
+// ReservationCharges provides the room charges of a reservation, owned by the Reservation context.
+type ReservationCharges interface {
+	RoomCharges(ctx context.Context, reservationID ReservationID) (Money, error)
+}
+
+// ReservationViewers tells which guests may view a reservation, owned by the Reservation context.
+type ReservationViewers interface {
+	// CanView reports whether the guest owns the reservation or holds a share grant of it
+	CanView(ctx context.Context, reservationID ReservationID, guestID string) (bool, error)
+}
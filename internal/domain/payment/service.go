@@ -2,7 +2,11 @@ package payment
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -13,8 +17,18 @@ type Service struct {
 	paymentRepo    PaymentRepository
 	paymentGateway PaymentGateway
 	publisher      event.EventPublisher
+
+	currencyOfRecord string
+	fxMaxAge         time.Duration
+	metrics          shared.Metrics
+	tracer           shared.Tracer
+	auditLog         shared.AuditLog
+	viewers          ReservationViewers
 }
 
+// MetricPaymentFailures counts the failed authorizations and captures by error code.
+const MetricPaymentFailures = "hotel_payment_failures_total"
+
 // NewService creates a new payment Service with dependencies.
 func NewService(
 	repo PaymentRepository,
@@ -28,6 +42,63 @@ func NewService(
 	}
 }
 
+// WithFXGuard refuses to capture payments in another currency than the currency of record
+// if they have no exchange rate snapshot or if the snapshot is older than maxAge (0 only requires one),
+// so an amount is never charged at a rate the guest was not quoted.
+func (s *Service) WithFXGuard(currencyOfRecord string, maxAge time.Duration) *Service {
+	s.currencyOfRecord = strings.ToUpper(currencyOfRecord)
+	s.fxMaxAge = maxAge
+	return s
+}
+
+// WithMetrics counts the failed payments in the metrics.
+func (s *Service) WithMetrics(metrics shared.Metrics) *Service {
+	s.metrics = metrics
+	return s
+}
+
+// WithTracer records the authorizations, captures and refunds as spans of the traces of their requests and events.
+func (s *Service) WithTracer(tracer shared.Tracer) *Service {
+	s.tracer = tracer
+	return s
+}
+
+// WithReservationViewers lets guests read the payments and financial summaries of the reservations they may view
+// via the MCP tools. Without it, the tools serve staff and service accounts only.
+func (s *Service) WithReservationViewers(viewers ReservationViewers) *Service {
+	s.viewers = viewers
+	return s
+}
+
+// WithAuditLog records every authorization, capture, refund and folio adjustment with its caller
+// and the state before and after.
+func (s *Service) WithAuditLog(log shared.AuditLog) *Service {
+	s.auditLog = log
+	return s
+}
+
+// state returns the state of the payment for the audit log, or nil if no audit log is configured.
+func (s *Service) state(payment *Payment) json.RawMessage {
+	if s.auditLog == nil {
+		return nil
+	}
+	return shared.AuditState(payment)
+}
+
+// audit records the change of the payment in the audit log, if configured.
+func (s *Service) audit(ctx context.Context, action string, before json.RawMessage, after *Payment) {
+	if s.auditLog != nil {
+		s.auditLog.Record(ctx, shared.NewAuditEntry(ctx, "payment", string(after.ID), action, before, shared.AuditState(after)))
+	}
+}
+
+// countFailure counts a failed payment with its error code, if metrics are configured.
+func (s *Service) countFailure(errorCode string) {
+	if s.metrics != nil {
+		s.metrics.IncCounter(MetricPaymentFailures, "error_code", errorCode)
+	}
+}
+
 // AuthorizePayment creates a payment and authorizes it with the gateway.
 func (s *Service) AuthorizePayment(
 	ctx context.Context,
@@ -36,19 +107,37 @@ func (s *Service) AuthorizePayment(
 	amount Money,
 	method string,
 ) (*Payment, error) {
+	return s.authorizePayment(ctx, id, reservationID, amount, method, nil)
+}
+
+// authorizePayment creates a payment with the exchange rate snapshot of the reservation and authorizes it.
+func (s *Service) authorizePayment(
+	ctx context.Context,
+	id PaymentID,
+	reservationID ReservationID,
+	amount Money,
+	method string,
+	fx *FXSnapshot,
+) (_ *Payment, err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "payment.AuthorizePayment", "payment_id", string(id), "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
 	// 1. Create payment aggregate
 	payment := NewPayment(id, reservationID, amount, method)
+	payment.FX = fx
 
 	// 2. Authorize with payment gateway
 	transactionID, err := s.paymentGateway.Authorize(ctx, payment)
 	if err != nil {
 		// Mark payment as failed
 		_ = payment.Fail("gateway_error", err.Error())
+		s.countFailure("gateway_error")
 
 		// Persist failed payment
 		if persistErr := s.paymentRepo.Create(ctx, id, *payment); persistErr != nil {
 			return nil, fmt.Errorf("failed to persist failed payment: %w", persistErr)
 		}
+		s.audit(ctx, shared.AuditAuthorize, nil, payment)
 
 		// Publish failure event
 		failEvt := NewEventFailed().
@@ -71,13 +160,15 @@ func (s *Service) AuthorizePayment(
 	if err := s.paymentRepo.Create(ctx, id, *payment); err != nil {
 		return nil, fmt.Errorf("failed to persist payment: %w", err)
 	}
+	s.audit(ctx, shared.AuditAuthorize, nil, payment)
 
 	// 5. Publish success event
 	evt := NewEventAuthorized().
 		WithPaymentID(id).
 		WithReservationID(reservationID).
 		WithAmount(amount).
-		WithTransactionID(transactionID)
+		WithTransactionID(transactionID).
+		WithFX(fx)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return nil, fmt.Errorf("failed to publish event: %w", err)
@@ -87,18 +178,54 @@ func (s *Service) AuthorizePayment(
 }
 
 // CapturePayment captures an authorized payment.
-func (s *Service) CapturePayment(ctx context.Context, id PaymentID) error {
+// A capture declined by the gateway fails the payment and publishes payment.failed.
+func (s *Service) CapturePayment(ctx context.Context, id PaymentID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "payment.CapturePayment", "payment_id", string(id))
+	defer func() { span.End(err) }()
+
+	return s.capturePayment(ctx, id, true)
+}
+
+// TryCapturePayment captures an authorized payment like CapturePayment, but a capture declined by
+// the gateway is only recorded as a failed attempt and leaves the payment authorized, so it can be retried.
+func (s *Service) TryCapturePayment(ctx context.Context, id PaymentID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "payment.TryCapturePayment", "payment_id", string(id))
+	defer func() { span.End(err) }()
+
+	return s.capturePayment(ctx, id, false)
+}
+
+// capturePayment captures the payment; final decides whether a declined capture fails the payment.
+func (s *Service) capturePayment(ctx context.Context, id PaymentID, final bool) error {
 	// 1. Load payment from repository
 	payment, err := s.paymentRepo.Read(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to read payment: %w", err)
 	}
 
-	// 2. Capture with payment gateway
+	before := s.state(payment)
+
+	// 2. Refuse to capture at a missing or stale exchange rate
+	if err := s.checkFX(payment); err != nil {
+		return fmt.Errorf("payment capture refused: %w", err)
+	}
+
+	// 3. Capture with payment gateway
 	if err := s.paymentGateway.Capture(ctx, payment.TransactionID, payment.Amount); err != nil {
+		if !final {
+			payment.RecordFailedAttempt("capture_failed", err.Error())
+			if s.paymentRepo.Update(ctx, id, *payment) == nil {
+				s.audit(ctx, shared.AuditCapture, before, payment)
+			}
+			return fmt.Errorf("payment capture failed: %w", err)
+		}
+
 		// Mark as failed
 		_ = payment.Fail("capture_failed", err.Error())
-		_ = s.paymentRepo.Update(ctx, id, *payment)
+		s.countFailure("capture_failed")
+		if s.paymentRepo.Update(ctx, id, *payment) == nil {
+			s.audit(ctx, shared.AuditCapture, before, payment)
+		}
 
 		// Publish failure event
 		failEvt := NewEventFailed().
@@ -112,21 +239,23 @@ func (s *Service) CapturePayment(ctx context.Context, id PaymentID) error {
 		return fmt.Errorf("payment capture failed: %w", err)
 	}
 
-	// 3. Update payment status
+	// 4. Update payment status
 	if err := payment.Capture(); err != nil {
 		return fmt.Errorf("failed to update payment status: %w", err)
 	}
 
-	// 4. Update repository
+	// 5. Update repository
 	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
+	s.audit(ctx, shared.AuditCapture, before, payment)
 
-	// 5. Publish success event
+	// 6. Publish success event
 	evt := NewEventCaptured().
 		WithPaymentID(id).
 		WithReservationID(payment.ReservationID).
-		WithAmount(payment.Amount)
+		WithAmount(payment.Amount).
+		WithFX(payment.FX)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
@@ -136,15 +265,21 @@ func (s *Service) CapturePayment(ctx context.Context, id PaymentID) error {
 }
 
 // RefundPayment processes a refund for a captured payment.
-func (s *Service) RefundPayment(ctx context.Context, id PaymentID) error {
+func (s *Service) RefundPayment(ctx context.Context, id PaymentID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "payment.RefundPayment", "payment_id", string(id))
+	defer func() { span.End(err) }()
+
 	// 1. Load payment from repository
 	payment, err := s.paymentRepo.Read(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to read payment: %w", err)
 	}
 
-	// 2. Refund with payment gateway
-	if err := s.paymentGateway.Refund(ctx, payment.TransactionID, payment.Amount); err != nil {
+	before := s.state(payment)
+
+	// 2. Refund the remaining amount with payment gateway
+	remaining := payment.RemainingAmount()
+	if err := s.paymentGateway.Refund(ctx, payment.TransactionID, remaining); err != nil {
 		return fmt.Errorf("payment refund failed: %w", err)
 	}
 
@@ -157,12 +292,14 @@ func (s *Service) RefundPayment(ctx context.Context, id PaymentID) error {
 	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
+	s.audit(ctx, shared.AuditRefund, before, payment)
 
 	// 5. Publish event
 	evt := NewEventRefunded().
 		WithPaymentID(id).
 		WithReservationID(payment.ReservationID).
-		WithAmount(payment.Amount)
+		WithAmount(remaining).
+		WithFX(payment.FX)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
@@ -171,6 +308,75 @@ func (s *Service) RefundPayment(ctx context.Context, id PaymentID) error {
 	return nil
 }
 
+// RefundPaymentPartially refunds part of a captured payment.
+// Refunding the remaining amount marks the payment refunded.
+func (s *Service) RefundPaymentPartially(ctx context.Context, id PaymentID, amount Money, reason string) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "payment.RefundPaymentPartially", "payment_id", string(id))
+	defer func() { span.End(err) }()
+
+	// 1. Load payment from repository
+	payment, err := s.paymentRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read payment: %w", err)
+	}
+
+	before := s.state(payment)
+
+	// 2. Validate before money moves
+	if err := payment.RefundPartially(amount, reason); err != nil {
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	// 3. Refund with payment gateway
+	if err := s.paymentGateway.Refund(ctx, payment.TransactionID, amount); err != nil {
+		return fmt.Errorf("payment refund failed: %w", err)
+	}
+
+	// 4. Update repository
+	if err := s.paymentRepo.Update(ctx, id, *payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	s.audit(ctx, shared.AuditRefund, before, payment)
+
+	// 5. Publish event
+	evt := NewEventRefunded().
+		WithPaymentID(id).
+		WithReservationID(payment.ReservationID).
+		WithAmount(amount).
+		WithFX(payment.FX)
+
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// ListPayments returns all payments, e.g. to backfill the data warehouse.
+func (s *Service) ListPayments(ctx context.Context) ([]Payment, error) {
+	payments, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+	return payments, nil
+}
+
+// ListPaymentsByReservation returns the payments of the reservation, oldest first.
+func (s *Service) ListPaymentsByReservation(ctx context.Context, reservationID ReservationID) ([]Payment, error) {
+	all, err := s.paymentRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payments: %w", err)
+	}
+	var payments []Payment
+	for _, p := range all {
+		if p.ReservationID == reservationID {
+			payments = append(payments, p)
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].CreatedAt.Before(payments[j].CreatedAt) })
+	return payments, nil
+}
+
 // GetPayment retrieves a payment by ID.
 func (s *Service) GetPayment(ctx context.Context, id PaymentID) (*Payment, error) {
 	payment, err := s.paymentRepo.Read(ctx, id)
@@ -182,14 +388,32 @@ func (s *Service) GetPayment(ctx context.Context, id PaymentID) (*Payment, error
 
 // AuthorizePaymentForReservation is called when a reservation.created event is received.
 // This initiates the payment authorization process for a new reservation.
+// The exchange rate snapshot of the event, if any, is stored with the payment for the capture.
 func (s *Service) AuthorizePaymentForReservation(
 	ctx context.Context,
 	paymentID PaymentID,
 	reservationID ReservationID,
 	amount Money,
 	method string,
+	fx *FXSnapshot,
 ) (*Payment, error) {
-	return s.AuthorizePayment(ctx, paymentID, reservationID, amount, method)
+	return s.authorizePayment(ctx, paymentID, reservationID, amount, method, fx)
+}
+
+// checkFX returns ErrFXSnapshotMissing or ErrFXSnapshotStale if the guard is enabled and the payment
+// is in another currency than the currency of record without a current snapshot to that currency.
+func (s *Service) checkFX(payment *Payment) error {
+	if s.currencyOfRecord == "" || payment.Amount.Currency == s.currencyOfRecord {
+		return nil
+	}
+	fx := payment.FX
+	if fx == nil || fx.From != payment.Amount.Currency || fx.To != s.currencyOfRecord {
+		return fmt.Errorf("%w: %s to %s", ErrFXSnapshotMissing, payment.Amount.Currency, s.currencyOfRecord)
+	}
+	if fx.StaleAt(time.Now(), s.fxMaxAge) {
+		return fmt.Errorf("%w: taken at %s", ErrFXSnapshotStale, fx.At.Format(time.RFC3339))
+	}
+	return nil
 }
 
 // CapturePaymentOnAuthorization is called when a payment.authorized event is received
@@ -2,8 +2,10 @@ package payment_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
@@ -103,6 +105,29 @@ func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error
 	return nil
 }
 
+// mockMetrics counts the increments by name and error code.
+type mockMetrics struct {
+	counters map[string]int
+}
+
+func (m *mockMetrics) IncCounter(name string, labels ...string) {
+	if m.counters == nil {
+		m.counters = make(map[string]int)
+	}
+	m.counters[name+" "+labels[len(labels)-1]]++
+}
+
+func (m *mockMetrics) ObserveHistogram(name string, value float64, labels ...string) {}
+
+// mockAuditLog records the audit entries.
+type mockAuditLog struct {
+	entries []shared.AuditEntry
+}
+
+func (m *mockAuditLog) Record(ctx context.Context, entry shared.AuditEntry) {
+	m.entries = append(m.entries, entry)
+}
+
 // ============================================================================
 // Service Test Helpers
 // ============================================================================
@@ -343,6 +368,55 @@ func Test_Service_CapturePayment_When_Gateway_Fails_Should_Mark_Payment_Failed(t
 	assert.That(t, "status must be failed", storedPayment.Status, payment.StatusFailed)
 }
 
+func Test_Service_TryCapturePayment_When_Gateway_Fails_Should_Keep_Payment_Authorized(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{
+		authorizeTransactionID: "tx-12345",
+		captureErr:             errors.New("capture failed"),
+	}
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, gateway, publisher)
+
+	ctx := context.Background()
+	id := payment.PaymentID("pay-001")
+
+	_, _ = service.AuthorizePayment(ctx, id, "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	err := service.TryCapturePayment(ctx, id)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+	storedPayment, _ := repo.Read(ctx, id)
+	assert.That(t, "status must stay authorized", storedPayment.Status, payment.StatusAuthorized)
+	assert.That(t, "failed attempt must be recorded", storedPayment.Attempts[len(storedPayment.Attempts)-1].Status, payment.StatusFailed)
+	assert.That(t, "payment.failed must not be published", len(publisher.published), 1)
+}
+
+func Test_Service_WithMetrics_Should_Count_Failures_By_Error_Code(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{
+		authorizeTransactionID: "tx-12345",
+		captureErr:             errors.New("capture failed"),
+	}
+	metrics := &mockMetrics{}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{}).WithMetrics(metrics)
+	ctx := context.Background()
+
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", paymentTestMoney(), "credit_card")
+	gateway.authorizeErr = errors.New("card declined")
+	_, _ = service.AuthorizePayment(ctx, "pay-002", "res-002", paymentTestMoney(), "credit_card")
+
+	// Act
+	_ = service.CapturePayment(ctx, "pay-001")
+
+	// Assert
+	assert.That(t, "authorization failure must be counted", metrics.counters[payment.MetricPaymentFailures+" gateway_error"], 1)
+	assert.That(t, "capture failure must be counted", metrics.counters[payment.MetricPaymentFailures+" capture_failed"], 1)
+}
+
 // ============================================================================
 // RefundPayment Tests
 // ============================================================================
@@ -371,6 +445,37 @@ func Test_Service_RefundPayment_Should_Succeed(t *testing.T) {
 	assert.That(t, "status must be refunded", storedPayment.Status, payment.StatusRefunded)
 }
 
+func Test_Service_WithAuditLog_Should_Record_Capture_And_Refund(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	gateway := &mockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	auditLog := &mockAuditLog{}
+	service := createPaymentTestService(repo, gateway, &mockEventPublisher{}).WithAuditLog(auditLog)
+	mcp := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalService, Subject: "sub-mcp", ClientID: "mcp-agent"})
+	_, _ = service.AuthorizePayment(context.Background(), "pay-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	captureErr := service.CapturePayment(context.Background(), "pay-001")
+	refundErr := service.RefundPaymentPartially(mcp, "pay-001", shared.NewMoney(2500, "USD"), "Minibar credit")
+
+	// Assert
+	assert.That(t, "capture err must be nil", captureErr, nil)
+	assert.That(t, "refund err must be nil", refundErr, nil)
+	actions := []string{}
+	for _, entry := range auditLog.entries {
+		actions = append(actions, entry.Action)
+	}
+	assert.That(t, "changes must be recorded", actions, []string{shared.AuditAuthorize, shared.AuditCapture, shared.AuditRefund})
+	refund := auditLog.entries[2]
+	assert.That(t, "refund must be by the client", refund.Actor, "service:mcp-agent")
+	assert.That(t, "client ID must be recorded", refund.ClientID, "mcp-agent")
+	var before, after payment.Payment
+	_ = json.Unmarshal(refund.Before, &before)
+	_ = json.Unmarshal(refund.After, &after)
+	assert.That(t, "state before must have no refunds", len(before.Refunds), 0)
+	assert.That(t, "state after must have the refund", len(after.Refunds), 1)
+}
+
 func Test_Service_RefundPayment_When_Not_Captured_Should_Return_Error(t *testing.T) {
 	// Arrange
 	repo := newMockPaymentRepository()
@@ -482,6 +587,72 @@ func Test_Service_GetPayment_When_Not_Found_Should_Return_Error(t *testing.T) {
 // Event Handler Integration Tests
 // ============================================================================
 
+// ============================================================================
+// FX Guard Tests
+// ============================================================================
+
+// authorizeEURPayment authorizes a payment of 100.00 EUR with the snapshot, if any.
+func authorizeEURPayment(service *payment.Service, fx *shared.FXSnapshot) {
+	_, _ = service.AuthorizePaymentForReservation(context.Background(), "pay-001", "res-001", shared.NewMoney(10000, "EUR"), "credit_card", fx)
+}
+
+func Test_Service_CapturePayment_With_Current_Snapshot_Should_Capture_And_Publish_It(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	publisher := &mockEventPublisher{}
+	service := createPaymentTestService(repo, &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, publisher).WithFXGuard("USD", time.Hour)
+	fx := shared.NewFXSnapshot("EUR", "USD", 1.08, time.Now().Add(-time.Minute), "static")
+	authorizeEURPayment(service, &fx)
+
+	// Act
+	err := service.CapturePayment(context.Background(), "pay-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "status must be captured", repo.payments["pay-001"].Status, payment.StatusCaptured)
+	evt := publisher.published[len(publisher.published)-1].(*payment.EventCaptured)
+	assert.That(t, "event must carry the snapshot", evt.FX != nil && evt.FX.Rate == 1.08, true)
+}
+
+func Test_Service_CapturePayment_Without_Snapshot_Should_Return_ErrFXSnapshotMissing(t *testing.T) {
+	// Arrange
+	repo := newMockPaymentRepository()
+	service := createPaymentTestService(repo, &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, &mockEventPublisher{}).WithFXGuard("USD", time.Hour)
+	authorizeEURPayment(service, nil)
+
+	// Act
+	err := service.CapturePayment(context.Background(), "pay-001")
+
+	// Assert
+	assert.That(t, "error must be ErrFXSnapshotMissing", errors.Is(err, payment.ErrFXSnapshotMissing), true)
+	assert.That(t, "payment must stay authorized", repo.payments["pay-001"].Status, payment.StatusAuthorized)
+}
+
+func Test_Service_CapturePayment_With_Stale_Snapshot_Should_Return_ErrFXSnapshotStale(t *testing.T) {
+	// Arrange
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, &mockEventPublisher{}).WithFXGuard("USD", time.Hour)
+	fx := shared.NewFXSnapshot("EUR", "USD", 1.08, time.Now().Add(-2*time.Hour), "static")
+	authorizeEURPayment(service, &fx)
+
+	// Act
+	err := service.CapturePayment(context.Background(), "pay-001")
+
+	// Assert
+	assert.That(t, "error must be ErrFXSnapshotStale", errors.Is(err, payment.ErrFXSnapshotStale), true)
+}
+
+func Test_Service_CapturePayment_In_Currency_Of_Record_Should_Not_Need_Snapshot(t *testing.T) {
+	// Arrange
+	service := createPaymentTestService(newMockPaymentRepository(), &mockPaymentGateway{authorizeTransactionID: "tx-12345"}, &mockEventPublisher{}).WithFXGuard("USD", time.Hour)
+	_, _ = service.AuthorizePayment(context.Background(), "pay-001", "res-001", paymentTestMoney(), "credit_card")
+
+	// Act
+	err := service.CapturePayment(context.Background(), "pay-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
 func Test_Service_AuthorizePaymentForReservation_Should_Authorize(t *testing.T) {
 	// Arrange
 	repo := newMockPaymentRepository()
@@ -494,7 +665,7 @@ func Test_Service_AuthorizePaymentForReservation_Should_Authorize(t *testing.T)
 	reservationID := payment.ReservationID("res-001")
 
 	// Act
-	p, err := service.AuthorizePaymentForReservation(ctx, id, reservationID, paymentTestMoney(), "credit_card")
+	p, err := service.AuthorizePaymentForReservation(ctx, id, reservationID, paymentTestMoney(), "credit_card", nil)
 
 	// Assert
 	assert.That(t, "error must be nil", err == nil, true)
@@ -0,0 +1,53 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+)
+
+// TestCardBehavior is what the sandbox gateway does when a test card is charged.
+type TestCardBehavior string
+
+// The behaviors of the test cards.
+const (
+	TestCardApprove     TestCardBehavior = "approve"      // authorized, captured and refunded like a real card
+	TestCardDecline     TestCardBehavior = "decline"      // authorization fails with ErrCardDeclined
+	TestCardSCARequired TestCardBehavior = "sca_required" // authorization fails with ErrAuthenticationRequired
+	TestCardSlow        TestCardBehavior = "slow"         // authorized after the delay of the sandbox
+)
+
+// Errors of the sandbox gateway for the test cards.
+var (
+	ErrCardDeclined           = errors.New("card declined")
+	ErrAuthenticationRequired = errors.New("strong customer authentication required")
+	ErrUnknownTestCard        = errors.New("unknown test card")
+)
+
+// TestCard is a card number the sandbox gateway answers predictably,
+// so QA and agent demos can exercise the failure paths of the booking saga.
+type TestCard struct {
+	Number      string           `json:"number"`
+	Brand       string           `json:"brand"`
+	Behavior    TestCardBehavior `json:"behavior"`
+	Description string           `json:"description"`
+}
+
+// TestCards is the catalog of the test cards, the approving card first.
+// The numbers pass the Luhn check but are never issued, like the test numbers of real gateways.
+var TestCards = []TestCard{
+	{Number: "4242424242424242", Brand: "visa", Behavior: TestCardApprove, Description: "Authorized and captured"},
+	{Number: "4000000000000002", Brand: "visa", Behavior: TestCardDecline, Description: "Declined by the issuer; the booking is cancelled"},
+	{Number: "4000002500003155", Brand: "visa", Behavior: TestCardSCARequired, Description: "Requires 3-D Secure, which the sandbox cannot complete; the booking is cancelled"},
+	{Number: "5555555555554444", Brand: "mastercard", Behavior: TestCardSlow, Description: "Authorized after the delay of the sandbox, e.g. to test timeouts"},
+}
+
+// FindTestCard returns the test card with the number; spaces and dashes are ignored.
+func FindTestCard(number string) (TestCard, bool) {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	for _, card := range TestCards {
+		if card.Number == number {
+			return card, true
+		}
+	}
+	return TestCard{}, false
+}
@@ -3,15 +3,48 @@ package payment
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/security"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
+// Scopes a service account needs to call the payment tools.
+const (
+	ScopeRead  = "payments:read"
+	ScopeWrite = "payments:write"
+)
+
+// ErrNotPaymentOwner is returned if a guest reads a payment or financial summary of a reservation they may not view.
+var ErrNotPaymentOwner = errors.New("payment belongs to another guest")
+
+// requireViewer returns ErrNotPaymentOwner if the caller is a guest that may not view the reservation,
+// either as owner or via a share grant (see WithReservationViewers).
+// Staff and service accounts may read every payment.
+func requireViewer(ctx context.Context, service *Service, reservationID ReservationID) error {
+	p, ok := shared.PrincipalFromContext(ctx)
+	if !ok || p.Type != shared.PrincipalGuest {
+		return nil
+	}
+	if service.viewers == nil {
+		return shared.ErrStaffOnly
+	}
+	allowed, err := service.viewers.CanView(ctx, reservationID, p.Subject)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrNotPaymentOwner
+	}
+	return nil
+}
+
 // RegisterTools registers all payment MCP tools with the server.
 func RegisterTools(server *mcp.Server, service *Service) {
-	server.RegisterTool(newGetPaymentTool(service))
-	server.RegisterTool(newCapturePaymentTool(service))
-	server.RegisterTool(newRefundPaymentTool(service))
+	server.RegisterTool(shared.GuardTool(newGetPaymentTool(service), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newCapturePaymentTool(service), ScopeWrite))
+	server.RegisterTool(shared.GuardTool(newRefundPaymentTool(service), ScopeWrite))
 }
 
 // newGetPaymentTool creates a new get_payment tool.
@@ -26,11 +59,18 @@ func newGetPaymentTool(service *Service) mcp.Tool {
 			[]string{"id"},
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
-			payment, err := service.GetPayment(ctx, PaymentID(id))
+			raw, _ := params.Arguments["id"].(string)
+			id, err := ParsePaymentID(raw)
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
+			payment, err := service.GetPayment(ctx, id)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if err := requireViewer(ctx, service, payment.ReservationID); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
 			data, _ := json.MarshalIndent(payment, "", "  ")
 			return mcp.ToolsCallResult{
 				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
@@ -51,8 +91,16 @@ func newCapturePaymentTool(service *Service) mcp.Tool {
 			[]string{"id"},
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
-			err := service.CapturePayment(ctx, PaymentID(id))
+			// Moving money is reserved for staff; guests may only read their payments.
+			if err := shared.RequireStaff(ctx); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			raw, _ := params.Arguments["id"].(string)
+			id, err := ParsePaymentID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			err = service.CapturePayment(ctx, id)
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
@@ -67,16 +115,40 @@ func newCapturePaymentTool(service *Service) mcp.Tool {
 func newRefundPaymentTool(service *Service) mcp.Tool {
 	return mcp.NewTool(
 		"refund_payment",
-		"Refund a captured payment. Payment must be in 'captured' status.",
+		"Refund a captured payment. Payment must be in 'captured' status. Without amount the remaining amount is refunded.",
 		mcp.NewObjectSchema(
 			map[string]mcp.Property{
-				"id": mcp.NewStringProperty("The payment ID"),
+				"id":     mcp.NewStringProperty("The payment ID"),
+				"amount": mcp.NewNumberProperty("Optional partial amount in cents, in the payment currency"),
+				"reason": mcp.NewStringProperty("Optional reason of a partial refund"),
 			},
 			[]string{"id"},
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
-			err := service.RefundPayment(ctx, PaymentID(id))
+			// Moving money is reserved for staff; guests may only read their payments.
+			if err := shared.RequireStaff(ctx); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			raw, _ := params.Arguments["id"].(string)
+			id, err := ParsePaymentID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if amount, ok := params.Arguments["amount"].(float64); ok {
+				payment, err := service.GetPayment(ctx, id)
+				if err != nil {
+					return mcp.ToolsCallResult{}, err
+				}
+				reason, _ := params.Arguments["reason"].(string)
+				refund := NewMoney(int64(amount), payment.Amount.Currency)
+				if err := service.RefundPaymentPartially(ctx, id, refund, reason); err != nil {
+					return mcp.ToolsCallResult{}, err
+				}
+				return mcp.ToolsCallResult{
+					Content: []mcp.ContentBlock{mcp.NewTextContent("Payment partially refunded successfully: " + refund.FormatIn(shared.LocaleFromContext(ctx)))},
+				}, nil
+			}
+			err = service.RefundPayment(ctx, id)
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
@@ -86,3 +158,88 @@ func newRefundPaymentTool(service *Service) mcp.Tool {
 		},
 	)
 }
+
+// RegisterFinancialTools registers the financial summary MCP tools with the server.
+func RegisterFinancialTools(server *mcp.Server, financials *FinancialService) {
+	server.RegisterTool(shared.GuardTool(newGetFinancialSummaryTool(financials), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newAddFolioAdjustmentTool(financials), ScopeWrite))
+}
+
+// newGetFinancialSummaryTool creates a new get_financial_summary tool.
+func newGetFinancialSummaryTool(financials *FinancialService) mcp.Tool {
+	return mcp.NewTool(
+		"get_financial_summary",
+		"Get the financial summary of a reservation: room charges, adjustments, authorized, captured, gift card and refunded amounts and the outstanding balance.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"reservation_id": mcp.NewStringProperty("The reservation ID"),
+			},
+			[]string{"reservation_id"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			raw, _ := params.Arguments["reservation_id"].(string)
+			id, err := shared.ParseReservationID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if err := requireViewer(ctx, financials.payments, id); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			summary, err := financials.Summary(ctx, id)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			data, _ := json.MarshalIndent(summary, "", "  ")
+			// Agents quote the balance to guests, so it is also given in the caller's locale.
+			locale := shared.LocaleFromContext(ctx)
+			balance := "Balance: " + summary.Balance.FormatIn(locale)
+			switch {
+			case summary.Balance.Amount > 0:
+				balance += " owed by the guest"
+			case summary.Balance.Amount < 0:
+				balance = "Balance: " + NewMoney(-summary.Balance.Amount, summary.Balance.Currency).FormatIn(locale) + " owed to the guest"
+			}
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data)), mcp.NewTextContent(balance)},
+			}, nil
+		},
+	)
+}
+
+// newAddFolioAdjustmentTool creates a new add_folio_adjustment tool.
+func newAddFolioAdjustmentTool(financials *FinancialService) mcp.Tool {
+	return mcp.NewTool(
+		"add_folio_adjustment",
+		"Add a charge (positive amount, e.g. minibar) or credit (negative amount, e.g. goodwill) to a reservation's folio.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"reservation_id": mcp.NewStringProperty("The reservation ID"),
+				"amount":         mcp.NewNumberProperty("Amount in cents in the reservation currency; negative for credits"),
+				"currency":       mcp.NewStringProperty("ISO 4217 currency code of the reservation (e.g. USD)"),
+				"reason":         mcp.NewStringProperty("Reason shown on the financial summary"),
+			},
+			[]string{"reservation_id", "amount", "currency", "reason"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			// Moving money is reserved for staff; guests may only read their payments.
+			if err := shared.RequireStaff(ctx); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			raw, _ := params.Arguments["reservation_id"].(string)
+			id, err := shared.ParseReservationID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			amount, _ := params.Arguments["amount"].(float64)
+			currency, _ := params.Arguments["currency"].(string)
+			reason, _ := params.Arguments["reason"].(string)
+			adjustment, err := financials.AddAdjustment(ctx, AdjustmentID(security.GenerateID()), id, NewMoney(int64(amount), currency), reason)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent("Adjustment " + string(adjustment.ID) + " of " + adjustment.Amount.FormatIn(shared.LocaleFromContext(ctx)) + " added")},
+			}, nil
+		},
+	)
+}
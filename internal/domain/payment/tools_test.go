@@ -3,12 +3,14 @@ package payment_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/andygeiss/cloud-native-utils/assert"
 	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/cloud-native-utils/resource"
 	"github.com/andygeiss/hotel-booking/internal/domain/payment"
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
@@ -492,3 +494,275 @@ func Test_RefundPaymentTool_When_Gateway_Fails_Should_Return_Error(t *testing.T)
 	// Assert
 	assert.That(t, "error must not be nil", err != nil, true)
 }
+
+// ============================================================================
+// Staff-Only Tool Tests
+// ============================================================================
+
+func Test_CapturePaymentTool_With_Guest_Principal_Should_Return_ErrStaffOnly(t *testing.T) {
+	// Arrange
+	repo := newToolsMockPaymentRepository()
+	gateway := &toolsMockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	service := createToolsPaymentTestService(repo, gateway, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+	_, _ = service.AuthorizePayment(context.Background(), "pay-001", "res-001", toolsPaymentTestMoney(), "credit_card")
+
+	var captureTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "capture_payment" {
+			captureTool = tool
+		}
+	}
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest})
+
+	// Act
+	_, err := captureTool.Handler(ctx, mcp.ToolsCallParams{Name: "capture_payment", Arguments: map[string]any{"id": "pay-001"}})
+
+	// Assert
+	assert.That(t, "error must be ErrStaffOnly", errors.Is(err, shared.ErrStaffOnly), true)
+	p, _ := service.GetPayment(context.Background(), "pay-001")
+	assert.That(t, "status must still be authorized", p.Status, payment.StatusAuthorized)
+}
+
+// toolsMockReservationViewers lets each guest view the reservations listed for them.
+type toolsMockReservationViewers map[string][]payment.ReservationID
+
+func (m toolsMockReservationViewers) CanView(ctx context.Context, reservationID payment.ReservationID, guestID string) (bool, error) {
+	return slices.Contains(m[guestID], reservationID), nil
+}
+
+func callGetPaymentAsGuest(t *testing.T, viewers payment.ReservationViewers, guestID string) error {
+	t.Helper()
+	service := createToolsPaymentTestService(newToolsMockPaymentRepository(), &toolsMockPaymentGateway{authorizeTransactionID: "tx-12345"}, &toolsMockEventPublisher{})
+	if viewers != nil {
+		service.WithReservationViewers(viewers)
+	}
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+	_, _ = service.AuthorizePayment(context.Background(), "pay-001", "res-001", toolsPaymentTestMoney(), "credit_card")
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest, Subject: guestID})
+	_, err := findTool(server, "get_payment").Handler(ctx, mcp.ToolsCallParams{Name: "get_payment", Arguments: map[string]any{"id": "pay-001"}})
+	return err
+}
+
+func Test_GetPaymentTool_With_Guest_Of_The_Reservation_Should_Return_Payment(t *testing.T) {
+	// Arrange
+	viewers := toolsMockReservationViewers{"guest-001": {"res-001"}}
+
+	// Act
+	err := callGetPaymentAsGuest(t, viewers, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
+func Test_GetPaymentTool_With_Other_Guest_Should_Return_ErrNotPaymentOwner(t *testing.T) {
+	// Arrange
+	viewers := toolsMockReservationViewers{"guest-001": {"res-001"}}
+
+	// Act
+	err := callGetPaymentAsGuest(t, viewers, "guest-002")
+
+	// Assert
+	assert.That(t, "error must be ErrNotPaymentOwner", errors.Is(err, payment.ErrNotPaymentOwner), true)
+}
+
+func Test_GetPaymentTool_With_Guest_Without_Reservation_Viewers_Should_Return_ErrStaffOnly(t *testing.T) {
+	// Act
+	err := callGetPaymentAsGuest(t, nil, "guest-001")
+
+	// Assert
+	assert.That(t, "error must be ErrStaffOnly", errors.Is(err, shared.ErrStaffOnly), true)
+}
+
+func Test_RefundPaymentTool_With_Staff_Principal_Should_Refund_Payment(t *testing.T) {
+	// Arrange
+	repo := newToolsMockPaymentRepository()
+	gateway := &toolsMockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	service := createToolsPaymentTestService(repo, gateway, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+	_, _ = service.AuthorizePayment(context.Background(), "pay-001", "res-001", toolsPaymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(context.Background(), "pay-001")
+
+	var refundTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "refund_payment" {
+			refundTool = tool
+		}
+	}
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalStaff})
+
+	// Act
+	_, err := refundTool.Handler(ctx, mcp.ToolsCallParams{Name: "refund_payment", Arguments: map[string]any{"id": "pay-001"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+}
+
+func Test_GetPaymentTool_With_Service_Account_Without_Scope_Should_Return_ErrMissingScope(t *testing.T) {
+	// Arrange
+	service := createToolsPaymentTestService(newToolsMockPaymentRepository(), &toolsMockPaymentGateway{}, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+
+	var getTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_payment" {
+			getTool = tool
+		}
+	}
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{
+		Type:   shared.PrincipalService,
+		Scopes: []string{"reservations:read"},
+	})
+
+	// Act
+	_, err := getTool.Handler(ctx, mcp.ToolsCallParams{Name: "get_payment", Arguments: map[string]any{"id": "pay-001"}})
+
+	// Assert
+	assert.That(t, "error must be ErrMissingScope", errors.Is(err, shared.ErrMissingScope), true)
+}
+
+// ============================================================================
+// Partial Refund Tool Tests
+// ============================================================================
+
+func Test_RefundPaymentTool_With_Amount_Should_Refund_Partially(t *testing.T) {
+	// Arrange
+	repo := newToolsMockPaymentRepository()
+	gateway := &toolsMockPaymentGateway{authorizeTransactionID: "tx-12345"}
+	service := createToolsPaymentTestService(repo, gateway, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+	ctx := context.Background()
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", toolsPaymentTestMoney(), "credit_card")
+	_ = service.CapturePayment(ctx, "pay-001")
+
+	var refundTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "refund_payment" {
+			refundTool = tool
+		}
+	}
+
+	// Act
+	result, err := refundTool.Handler(ctx, mcp.ToolsCallParams{Name: "refund_payment", Arguments: map[string]any{"id": "pay-001", "amount": float64(2500), "reason": "goodwill"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must confirm partial refund", strings.Contains(result.Content[0].Text, "partially refunded"), true)
+	p, _ := service.GetPayment(ctx, "pay-001")
+	assert.That(t, "status must remain captured", p.Status, payment.StatusCaptured)
+	assert.That(t, "refunded amount must match", p.RefundedAmount().Amount, int64(2500))
+}
+
+// ============================================================================
+// Financial Tools Tests
+// ============================================================================
+
+type toolsMockReservationCharges struct{}
+
+func (m *toolsMockReservationCharges) RoomCharges(ctx context.Context, reservationID payment.ReservationID) (shared.Money, error) {
+	return shared.NewMoney(30000, "USD"), nil
+}
+
+func createToolsFinancialServer() (*mcp.Server, *payment.Service) {
+	service := createToolsPaymentTestService(newToolsMockPaymentRepository(), &toolsMockPaymentGateway{authorizeTransactionID: "tx-12345"}, &toolsMockEventPublisher{})
+	financials := payment.NewFinancialService(service, resource.NewInMemoryAccess[payment.AdjustmentID, payment.Adjustment](), &toolsMockReservationCharges{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterFinancialTools(server, financials)
+	return server, service
+}
+
+func findTool(server *mcp.Server, name string) mcp.Tool {
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == name {
+			return tool
+		}
+	}
+	return mcp.Tool{}
+}
+
+func Test_GetFinancialSummaryTool_Should_Return_Balance_JSON(t *testing.T) {
+	// Arrange
+	server, service := createToolsFinancialServer()
+	ctx := context.Background()
+	_, _ = service.AuthorizePayment(ctx, "pay-001", "res-001", shared.NewMoney(10000, "USD"), "credit_card")
+	_ = service.CapturePayment(ctx, "pay-001")
+
+	// Act
+	result, err := findTool(server, "get_financial_summary").Handler(ctx, mcp.ToolsCallParams{Name: "get_financial_summary", Arguments: map[string]any{"reservation_id": "res-001"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must contain the balance", strings.Contains(result.Content[0].Text, `"Balance": {`), true)
+	assert.That(t, "content must contain the outstanding amount", strings.Contains(result.Content[0].Text, `"Amount": 20000`), true)
+}
+
+func Test_GetFinancialSummaryTool_Should_Format_Balance_In_The_Callers_Locale(t *testing.T) {
+	// Arrange
+	server, _ := createToolsFinancialServer()
+	ctx := shared.ContextWithLocale(context.Background(), "de-DE")
+
+	// Act
+	result, err := findTool(server, "get_financial_summary").Handler(ctx, mcp.ToolsCallParams{Name: "get_financial_summary", Arguments: map[string]any{"reservation_id": "res-001"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must have 2 content blocks", len(result.Content), 2)
+	assert.That(t, "balance must be formatted in German", result.Content[1].Text, "Balance: 300,00\u00a0$ owed by the guest")
+}
+
+func Test_AddFolioAdjustmentTool_With_Guest_Principal_Should_Return_ErrStaffOnly(t *testing.T) {
+	// Arrange
+	server, _ := createToolsFinancialServer()
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest})
+
+	// Act
+	_, err := findTool(server, "add_folio_adjustment").Handler(ctx, mcp.ToolsCallParams{Name: "add_folio_adjustment", Arguments: map[string]any{"reservation_id": "res-001", "amount": float64(-1000), "currency": "USD", "reason": "Goodwill"}})
+
+	// Assert
+	assert.That(t, "error must be ErrStaffOnly", errors.Is(err, shared.ErrStaffOnly), true)
+}
+
+func Test_AddFolioAdjustmentTool_Should_Change_Balance(t *testing.T) {
+	// Arrange
+	server, _ := createToolsFinancialServer()
+	ctx := context.Background()
+
+	// Act
+	_, err := findTool(server, "add_folio_adjustment").Handler(ctx, mcp.ToolsCallParams{Name: "add_folio_adjustment", Arguments: map[string]any{"reservation_id": "res-001", "amount": float64(-1000), "currency": "USD", "reason": "Goodwill"}})
+	result, _ := findTool(server, "get_financial_summary").Handler(ctx, mcp.ToolsCallParams{Name: "get_financial_summary", Arguments: map[string]any{"reservation_id": "res-001"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "summary must contain the adjustment", strings.Contains(result.Content[0].Text, "Goodwill"), true)
+}
+
+func Test_GetPaymentTool_With_Guest_Role_Without_Scope_Should_Return_ErrMissingScope(t *testing.T) {
+	// Arrange
+	service := createToolsPaymentTestService(newToolsMockPaymentRepository(), &toolsMockPaymentGateway{}, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	payment.RegisterTools(server, service)
+
+	var getTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_payment" {
+			getTool = tool
+		}
+	}
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{
+		Type:      shared.PrincipalGuest,
+		Roles:     []shared.Role{shared.RoleGuest},
+		Scopes:    []string{"reservations:read"},
+		RoleBased: true,
+	})
+
+	// Act
+	_, err := getTool.Handler(ctx, mcp.ToolsCallParams{Name: "get_payment", Arguments: map[string]any{"id": "pay-001"}})
+
+	// Assert
+	assert.That(t, "error must be ErrMissingScope", errors.Is(err, shared.ErrMissingScope), true)
+}
@@ -0,0 +1,162 @@
+// Package pricing contains the Pricing bounded context.
+// A rate plan prices the nights of a room: a base rate, seasonal rates, a weekend surcharge
+// and length-of-stay discounts. Bookings charge the total of a quote of the stay.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type RoomID string
+
+// Money is shared across bounded contexts.
+type Money = shared.Money
+
+// Validation errors.
+var (
+	ErrNoRatePlan      = errors.New("no rate plan for the room")
+	ErrInvalidRatePlan = errors.New("invalid rate plan")
+	ErrInvalidStay     = errors.New("check-out must be after check-in")
+)
+
+// DefaultWeekendNights are the nights with the weekend surcharge if a plan names none.
+var DefaultWeekendNights = []time.Weekday{time.Friday, time.Saturday}
+
+// Season changes the rate of the nights between From and To (inclusive, "MM-DD") every year by Percent.
+// A season may span the turn of the year, e.g. from 12-20 to 01-06.
+type Season struct {
+	Name    string
+	From    string
+	To      string
+	Percent int
+}
+
+// contains reports whether the night falls into the season.
+func (s Season) contains(night time.Time) bool {
+	day := night.Format("01-02")
+	if s.From <= s.To {
+		return day >= s.From && day <= s.To
+	}
+	return day >= s.From || day <= s.To
+}
+
+// StayDiscount reduces the total of stays of at least MinNights nights by Percent.
+type StayDiscount struct {
+	MinNights int
+	Percent   int
+}
+
+// RatePlan is the aggregate root for the prices of a room.
+type RatePlan struct {
+	RoomID           RoomID
+	BaseRate         Money // per night, before seasons and surcharges
+	Seasons          []Season
+	WeekendSurcharge int            // percent added to weekend nights
+	WeekendNights    []time.Weekday // nights starting on these days; DefaultWeekendNights if empty
+	StayDiscounts    []StayDiscount // the one with the most nights the stay reaches applies
+	UpdatedAt        time.Time
+}
+
+// Validate checks the rates, seasons and discounts of the plan.
+func (p RatePlan) Validate() error {
+	if p.RoomID == "" {
+		return fmt.Errorf("%w: room required", ErrInvalidRatePlan)
+	}
+	if p.BaseRate.Amount < 0 || p.BaseRate.Currency == "" {
+		return fmt.Errorf("%w: base rate must be a non-negative amount with a currency", ErrInvalidRatePlan)
+	}
+	if p.WeekendSurcharge < -100 {
+		return fmt.Errorf("%w: weekend surcharge must be at least -100 percent", ErrInvalidRatePlan)
+	}
+	for _, s := range p.Seasons {
+		_, fromErr := time.Parse("01-02", s.From)
+		_, toErr := time.Parse("01-02", s.To)
+		if s.Name == "" || fromErr != nil || toErr != nil {
+			return fmt.Errorf("%w: season %q needs a name and dates as MM-DD", ErrInvalidRatePlan, s.Name)
+		}
+		if s.Percent < -100 {
+			return fmt.Errorf("%w: season %q must be at least -100 percent", ErrInvalidRatePlan, s.Name)
+		}
+	}
+	for _, d := range p.StayDiscounts {
+		if d.MinNights < 2 || d.Percent < 0 || d.Percent > 100 {
+			return fmt.Errorf("%w: stay discounts need at least 2 nights and 0-100 percent", ErrInvalidRatePlan)
+		}
+	}
+	return nil
+}
+
+// NightPrice is the rate of the night starting on Date.
+type NightPrice struct {
+	Date        time.Time
+	Rate        Money
+	Adjustments []string // names of the applied seasons and "weekend"
+}
+
+// Quote is the price of a stay in a room.
+type Quote struct {
+	RoomID       RoomID
+	CheckIn      time.Time
+	CheckOut     time.Time
+	Nights       []NightPrice
+	Subtotal     Money // sum of the nightly rates
+	StayDiscount Money // deducted for the length of the stay
+	Total        Money
+}
+
+// Quote prices every night of the stay and applies the stay discount.
+// Nights are the dates of the stay in UTC, as in the reservation context.
+func (p RatePlan) Quote(checkIn, checkOut time.Time) (Quote, error) {
+	first, last := stayDate(checkIn), stayDate(checkOut)
+	if !last.After(first) {
+		return Quote{}, ErrInvalidStay
+	}
+	weekend := p.WeekendNights
+	if len(weekend) == 0 {
+		weekend = DefaultWeekendNights
+	}
+
+	currency := p.BaseRate.Currency
+	quote := Quote{RoomID: p.RoomID, CheckIn: checkIn, CheckOut: checkOut}
+	var subtotal int64
+	for night := first; night.Before(last); night = night.AddDate(0, 0, 1) {
+		price := NightPrice{Date: night}
+		percent := 0
+		// Seasons do not stack: the first matching one applies.
+		if i := slices.IndexFunc(p.Seasons, func(s Season) bool { return s.contains(night) }); i >= 0 {
+			percent += p.Seasons[i].Percent
+			price.Adjustments = append(price.Adjustments, p.Seasons[i].Name)
+		}
+		if p.WeekendSurcharge != 0 && slices.Contains(weekend, night.Weekday()) {
+			percent += p.WeekendSurcharge
+			price.Adjustments = append(price.Adjustments, "weekend")
+		}
+		price.Rate = shared.NewMoney(p.BaseRate.Amount*int64(100+max(percent, -100))/100, currency)
+		subtotal += price.Rate.Amount
+		quote.Nights = append(quote.Nights, price)
+	}
+
+	discountPercent, bestNights := 0, 0
+	for _, d := range p.StayDiscounts {
+		if len(quote.Nights) >= d.MinNights && d.MinNights > bestNights {
+			discountPercent, bestNights = d.Percent, d.MinNights
+		}
+	}
+	discount := subtotal * int64(discountPercent) / 100
+	quote.Subtotal = shared.NewMoney(subtotal, currency)
+	quote.StayDiscount = shared.NewMoney(discount, currency)
+	quote.Total = shared.NewMoney(subtotal-discount, currency)
+	return quote, nil
+}
+
+// stayDate truncates the time to its date in UTC.
+func stayDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
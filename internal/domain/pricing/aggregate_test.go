@@ -0,0 +1,138 @@
+package pricing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2030, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func testRatePlan() pricing.RatePlan {
+	return pricing.RatePlan{
+		RoomID:           "room-101",
+		BaseRate:         shared.NewMoney(10000, "USD"),
+		Seasons:          []pricing.Season{{Name: "summer", From: "07-01", To: "08-31", Percent: 20}},
+		WeekendSurcharge: 10,
+		StayDiscounts:    []pricing.StayDiscount{{MinNights: 3, Percent: 5}, {MinNights: 7, Percent: 10}},
+	}
+}
+
+// ============================================================================
+// RatePlan Quote Tests
+// ============================================================================
+
+func Test_RatePlan_Quote_Should_Apply_Season_And_Weekend_Per_Night(t *testing.T) {
+	// Arrange - 2030-06-27 is a Thursday; the stay spans Thursday to Monday into the summer season
+	plan := testRatePlan()
+
+	// Act
+	quote, err := plan.Quote(day(time.June, 27), day(time.July, 1))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "four nights must be priced", len(quote.Nights), 4)
+	assert.That(t, "thursday must be the base rate", quote.Nights[0].Rate, shared.NewMoney(10000, "USD"))
+	assert.That(t, "friday must have the weekend surcharge", quote.Nights[1].Rate, shared.NewMoney(11000, "USD"))
+	assert.That(t, "friday must name the adjustment", quote.Nights[1].Adjustments, []string{"weekend"})
+	assert.That(t, "saturday must have the weekend surcharge", quote.Nights[2].Rate, shared.NewMoney(11000, "USD"))
+	assert.That(t, "sunday must be the base rate", quote.Nights[3].Rate, shared.NewMoney(10000, "USD"))
+	assert.That(t, "subtotal must be the sum", quote.Subtotal, shared.NewMoney(42000, "USD"))
+	assert.That(t, "3-night discount must apply", quote.StayDiscount, shared.NewMoney(2100, "USD"))
+	assert.That(t, "total must be discounted", quote.Total, shared.NewMoney(39900, "USD"))
+}
+
+func Test_RatePlan_Quote_In_Season_On_Weekend_Should_Add_Both(t *testing.T) {
+	// Arrange - 2030-07-05 is a Friday
+	plan := testRatePlan()
+
+	// Act
+	quote, _ := plan.Quote(day(time.July, 5), day(time.July, 6))
+
+	// Assert
+	assert.That(t, "rate must include season and weekend", quote.Nights[0].Rate, shared.NewMoney(13000, "USD"))
+	assert.That(t, "adjustments must be named", quote.Nights[0].Adjustments, []string{"summer", "weekend"})
+}
+
+func Test_RatePlan_Quote_Should_Apply_Longest_Reached_Stay_Discount(t *testing.T) {
+	// Arrange
+	plan := testRatePlan()
+	plan.Seasons, plan.WeekendSurcharge = nil, 0
+
+	// Act
+	quote, _ := plan.Quote(day(time.March, 1), day(time.March, 8))
+
+	// Assert
+	assert.That(t, "10 percent must be deducted", quote.StayDiscount, shared.NewMoney(7000, "USD"))
+	assert.That(t, "total must be discounted", quote.Total, shared.NewMoney(63000, "USD"))
+}
+
+func Test_RatePlan_Quote_With_Season_Over_Year_End_Should_Match_January(t *testing.T) {
+	// Arrange
+	plan := testRatePlan()
+	plan.Seasons = []pricing.Season{{Name: "holidays", From: "12-20", To: "01-06", Percent: 50}}
+	plan.WeekendSurcharge = 0
+
+	// Act
+	quote, _ := plan.Quote(day(time.January, 2), day(time.January, 3))
+
+	// Assert
+	assert.That(t, "holiday rate must apply", quote.Nights[0].Rate, shared.NewMoney(15000, "USD"))
+}
+
+func Test_RatePlan_Quote_Without_Nights_Should_Return_ErrInvalidStay(t *testing.T) {
+	// Arrange
+	plan := testRatePlan()
+
+	// Act
+	_, err := plan.Quote(day(time.March, 1), day(time.March, 1))
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidStay", err, pricing.ErrInvalidStay)
+}
+
+// ============================================================================
+// RatePlan Validate Tests
+// ============================================================================
+
+func Test_RatePlan_Validate_With_Valid_Plan_Should_Succeed(t *testing.T) {
+	// Arrange & Act
+	err := testRatePlan().Validate()
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+}
+
+func Test_RatePlan_Validate_With_Invalid_Season_Date_Should_Return_ErrInvalidRatePlan(t *testing.T) {
+	// Arrange
+	plan := testRatePlan()
+	plan.Seasons[0].To = "08-32"
+
+	// Act
+	err := plan.Validate()
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidRatePlan", errors.Is(err, pricing.ErrInvalidRatePlan), true)
+}
+
+func Test_RatePlan_Validate_With_One_Night_Discount_Should_Return_ErrInvalidRatePlan(t *testing.T) {
+	// Arrange
+	plan := testRatePlan()
+	plan.StayDiscounts = []pricing.StayDiscount{{MinNights: 1, Percent: 5}}
+
+	// Act
+	err := plan.Validate()
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidRatePlan", errors.Is(err, pricing.ErrInvalidRatePlan), true)
+}
@@ -0,0 +1,11 @@
+package pricing
+
+import (
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// RatePlanRepository provides CRUD operations for rate plans, keyed by room.
+type RatePlanRepository resource.Access[RoomID, RatePlan]
+
+// PriceLockRepository provides CRUD operations for the price locks of checkout sessions.
+type PriceLockRepository resource.Access[PriceLockID, PriceLock]
@@ -0,0 +1,137 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PriceLockIDPrefix is the prefix of price lock IDs.
+const PriceLockIDPrefix = "lock_"
+
+// PriceLockID is a strongly-typed identifier for price locks.
+type PriceLockID string
+
+// NewPriceLockID returns a new, time-sortable price lock ID, e.g. "lock_01J9Z3M5Q8X7T6V4R2N0B1C3D5".
+func NewPriceLockID() PriceLockID {
+	return PriceLockID(PriceLockIDPrefix + shared.NewULID())
+}
+
+// Price lock errors.
+var (
+	ErrPriceLockNotFound = errors.New("price lock not found")
+	ErrPriceLockExpired  = errors.New("price lock expired")
+	ErrPriceLockMismatch = errors.New("price lock is for another stay")
+)
+
+// PriceLock holds the quoted total of a stay for a checkout session until ExpiresAt,
+// so the guest is charged the price they were shown even if the rate plan changes meanwhile.
+type PriceLock struct {
+	ID        PriceLockID
+	SessionID string
+	RoomID    RoomID
+	CheckIn   time.Time
+	CheckOut  time.Time
+	Total     Money
+	LockedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the lock no longer holds the price at now.
+func (l PriceLock) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// Covers reports whether the lock was taken by the session for the same room and nights.
+func (l PriceLock) Covers(sessionID string, roomID RoomID, checkIn, checkOut time.Time) bool {
+	return l.SessionID == sessionID && l.RoomID == roomID &&
+		stayDate(l.CheckIn).Equal(stayDate(checkIn)) && stayDate(l.CheckOut).Equal(stayDate(checkOut))
+}
+
+// WithPriceLocks stores the quotes locked by LockPrice in the repository for ttl.
+// Without it, LocksPrices is false and checkouts charge the quote at the time of booking.
+func (s *Service) WithPriceLocks(repo PriceLockRepository, ttl time.Duration) *Service {
+	s.lockRepo = repo
+	s.lockTTL = ttl
+	return s
+}
+
+// LocksPrices reports whether quotes are locked for the checkout session.
+func (s *Service) LocksPrices() bool {
+	return s.lockRepo != nil && s.lockTTL > 0
+}
+
+// LockPrice quotes the stay and locks its total for the checkout session.
+func (s *Service) LockPrice(ctx context.Context, sessionID string, roomID RoomID, checkIn, checkOut time.Time) (*PriceLock, error) {
+	quote, err := s.Quote(ctx, roomID, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	lock := PriceLock{
+		ID:        NewPriceLockID(),
+		SessionID: sessionID,
+		RoomID:    roomID,
+		CheckIn:   checkIn,
+		CheckOut:  checkOut,
+		Total:     quote.Total,
+		LockedAt:  now,
+		ExpiresAt: now.Add(s.lockTTL),
+	}
+	if err := s.lockRepo.Create(ctx, lock.ID, lock); err != nil {
+		return nil, fmt.Errorf("failed to persist price lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// VerifyPriceLock returns the lock if it still holds the price of the stay for the session.
+// An expired lock is returned with ErrPriceLockExpired, so the caller can compare it with a new quote.
+func (s *Service) VerifyPriceLock(ctx context.Context, id PriceLockID, sessionID string, roomID RoomID, checkIn, checkOut time.Time) (*PriceLock, error) {
+	lock, err := s.lockRepo.Read(ctx, id)
+	if err != nil || lock == nil {
+		return nil, ErrPriceLockNotFound
+	}
+	if !lock.Covers(sessionID, roomID, checkIn, checkOut) {
+		return nil, ErrPriceLockMismatch
+	}
+	if lock.Expired(time.Now()) {
+		return lock, ErrPriceLockExpired
+	}
+	return lock, nil
+}
+
+// ReleasePriceLock deletes the lock once the booking was made, so it cannot be used twice.
+func (s *Service) ReleasePriceLock(ctx context.Context, id PriceLockID) error {
+	if err := s.lockRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete price lock: %w", err)
+	}
+	return nil
+}
+
+// PruneExpiredLocks deletes the locks expired at now and returns how many were deleted.
+// Locks of abandoned checkouts are never released otherwise.
+func (s *Service) PruneExpiredLocks(ctx context.Context, now time.Time) (int, error) {
+	if s.lockRepo == nil {
+		return 0, nil
+	}
+	locks, err := s.lockRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read price locks: %w", err)
+	}
+	pruned := 0
+	var errs []error
+	for _, lock := range locks {
+		if !lock.Expired(now) {
+			continue
+		}
+		if err := s.lockRepo.Delete(ctx, lock.ID); err != nil {
+			errs = append(errs, fmt.Errorf("price lock %s: %w", lock.ID, err))
+			continue
+		}
+		pruned++
+	}
+	return pruned, errors.Join(errs...)
+}
@@ -0,0 +1,153 @@
+package pricing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Price Lock Test Helpers
+// ============================================================================
+
+func createLockingTestService() (*pricing.Service, pricing.PriceLockRepository) {
+	locks := resource.NewInMemoryAccess[pricing.PriceLockID, pricing.PriceLock]()
+	service := createTestService().WithPriceLocks(locks, 15*time.Minute)
+	return service, locks
+}
+
+// ============================================================================
+// LockPrice Tests
+// ============================================================================
+
+func Test_Service_LockPrice_Should_Lock_Quote_For_Session(t *testing.T) {
+	// Arrange
+	service, locks := createLockingTestService()
+
+	// Act
+	lock, err := service.LockPrice(context.Background(), "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must be the quote", lock.Total, shared.NewMoney(29700, "USD"))
+	assert.That(t, "lock must expire after the ttl", lock.ExpiresAt.Sub(lock.LockedAt), 15*time.Minute)
+	stored, _ := locks.Read(context.Background(), lock.ID)
+	assert.That(t, "lock must be stored", stored.SessionID, "session-1")
+}
+
+func Test_Service_LocksPrices_Without_Repository_Should_Be_False(t *testing.T) {
+	// Arrange
+	service := createTestService()
+
+	// Act
+	locks := service.LocksPrices()
+
+	// Assert
+	assert.That(t, "prices must not be locked", locks, false)
+}
+
+// ============================================================================
+// VerifyPriceLock Tests
+// ============================================================================
+
+func Test_Service_VerifyPriceLock_After_Rate_Change_Should_Keep_Locked_Total(t *testing.T) {
+	// Arrange
+	service, _ := createLockingTestService()
+	ctx := context.Background()
+	lock, _ := service.LockPrice(ctx, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+	plan := testRatePlan()
+	plan.BaseRate = shared.NewMoney(20000, "USD")
+	_, _ = service.SaveRatePlan(ctx, plan)
+
+	// Act
+	verified, err := service.VerifyPriceLock(ctx, lock.ID, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must be the locked one", verified.Total, shared.NewMoney(29700, "USD"))
+}
+
+func Test_Service_VerifyPriceLock_Of_Other_Session_Should_Return_ErrPriceLockMismatch(t *testing.T) {
+	// Arrange
+	service, _ := createLockingTestService()
+	ctx := context.Background()
+	lock, _ := service.LockPrice(ctx, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Act
+	_, err := service.VerifyPriceLock(ctx, lock.ID, "session-2", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be ErrPriceLockMismatch", errors.Is(err, pricing.ErrPriceLockMismatch), true)
+}
+
+func Test_Service_VerifyPriceLock_Of_Other_Dates_Should_Return_ErrPriceLockMismatch(t *testing.T) {
+	// Arrange
+	service, _ := createLockingTestService()
+	ctx := context.Background()
+	lock, _ := service.LockPrice(ctx, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Act
+	_, err := service.VerifyPriceLock(ctx, lock.ID, "session-1", "room-101", day(time.March, 1), day(time.March, 5))
+
+	// Assert
+	assert.That(t, "err must be ErrPriceLockMismatch", errors.Is(err, pricing.ErrPriceLockMismatch), true)
+}
+
+func Test_Service_VerifyPriceLock_When_Expired_Should_Return_Lock_And_ErrPriceLockExpired(t *testing.T) {
+	// Arrange
+	service, locks := createLockingTestService()
+	ctx := context.Background()
+	expired := pricing.PriceLock{
+		ID: "lock-1", SessionID: "session-1", RoomID: "room-101",
+		CheckIn: day(time.March, 1), CheckOut: day(time.March, 4),
+		Total: shared.NewMoney(25000, "USD"), ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	_ = locks.Create(ctx, expired.ID, expired)
+
+	// Act
+	lock, err := service.VerifyPriceLock(ctx, "lock-1", "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be ErrPriceLockExpired", errors.Is(err, pricing.ErrPriceLockExpired), true)
+	assert.That(t, "expired lock must be returned", lock.Total, expired.Total)
+}
+
+func Test_Service_VerifyPriceLock_After_Release_Should_Return_ErrPriceLockNotFound(t *testing.T) {
+	// Arrange
+	service, _ := createLockingTestService()
+	ctx := context.Background()
+	lock, _ := service.LockPrice(ctx, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+	_ = service.ReleasePriceLock(ctx, lock.ID)
+
+	// Act
+	_, err := service.VerifyPriceLock(ctx, lock.ID, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be ErrPriceLockNotFound", errors.Is(err, pricing.ErrPriceLockNotFound), true)
+}
+
+// ============================================================================
+// PruneExpiredLocks Tests
+// ============================================================================
+
+func Test_Service_PruneExpiredLocks_Should_Delete_Only_Expired_Locks(t *testing.T) {
+	// Arrange
+	service, locks := createLockingTestService()
+	ctx := context.Background()
+	lock, _ := service.LockPrice(ctx, "session-1", "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Act
+	pruned, err := service.PruneExpiredLocks(ctx, lock.ExpiresAt)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "one lock must be pruned", pruned, 1)
+	remaining, _ := locks.ReadAll(ctx)
+	assert.That(t, "no lock must remain", len(remaining), 0)
+}
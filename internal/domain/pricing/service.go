@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Service handles pricing workflows.
+type Service struct {
+	repo     RatePlanRepository
+	mu       sync.RWMutex // guards defaults, which can be reloaded at runtime
+	defaults map[RoomID]Money
+	lockRepo PriceLockRepository // nil disables price locks
+	lockTTL  time.Duration
+}
+
+// NewService creates a new pricing service.
+func NewService(repo RatePlanRepository) *Service {
+	return &Service{repo: repo, defaults: make(map[RoomID]Money)}
+}
+
+// SetDefaultRates replaces the base rates of the rooms without a stored rate plan.
+// Such rooms are sold at their base rate for every night.
+func (s *Service) SetDefaultRates(rates map[RoomID]Money) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaults = rates
+}
+
+// RatePlan returns the stored rate plan of the room, or the plan of its default rate.
+func (s *Service) RatePlan(ctx context.Context, roomID RoomID) (*RatePlan, error) {
+	if plan, err := s.repo.Read(ctx, roomID); err == nil {
+		return plan, nil
+	}
+	s.mu.RLock()
+	base, ok := s.defaults[roomID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRatePlan, roomID)
+	}
+	plan := RatePlan{RoomID: roomID, BaseRate: base}
+	return &plan, nil
+}
+
+// ListRatePlans returns the rate plans of all rooms with a stored plan or a default rate, ordered by room.
+func (s *Service) ListRatePlans(ctx context.Context) ([]RatePlan, error) {
+	stored, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate plans: %w", err)
+	}
+	plans := stored
+	s.mu.RLock()
+	for roomID, base := range s.defaults {
+		if !slices.ContainsFunc(stored, func(p RatePlan) bool { return p.RoomID == roomID }) {
+			plans = append(plans, RatePlan{RoomID: roomID, BaseRate: base})
+		}
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(plans, func(a, b RatePlan) int { return strings.Compare(string(a.RoomID), string(b.RoomID)) })
+	return plans, nil
+}
+
+// SaveRatePlan validates and stores the rate plan of its room, replacing the previous one.
+// Existing reservations keep the price they were booked at.
+func (s *Service) SaveRatePlan(ctx context.Context, plan RatePlan) (*RatePlan, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	plan.UpdatedAt = time.Now()
+
+	_, err := s.repo.Read(ctx, plan.RoomID)
+	if err == nil {
+		err = s.repo.Update(ctx, plan.RoomID, plan)
+	} else {
+		err = s.repo.Create(ctx, plan.RoomID, plan)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist rate plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// Quote prices a stay in the room with its rate plan.
+func (s *Service) Quote(ctx context.Context, roomID RoomID, checkIn, checkOut time.Time) (*Quote, error) {
+	plan, err := s.RatePlan(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := plan.Quote(checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
@@ -0,0 +1,100 @@
+package pricing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Service Test Helpers
+// ============================================================================
+
+func createTestService() *pricing.Service {
+	service := pricing.NewService(resource.NewInMemoryAccess[pricing.RoomID, pricing.RatePlan]())
+	service.SetDefaultRates(map[pricing.RoomID]pricing.Money{
+		"room-101": shared.NewMoney(9900, "USD"),
+		"room-201": shared.NewMoney(14900, "USD"),
+	})
+	return service
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_Quote_Without_Stored_Plan_Should_Charge_Default_Rate(t *testing.T) {
+	// Arrange
+	service := createTestService()
+
+	// Act
+	quote, err := service.Quote(context.Background(), "room-101", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "total must be three base rates", quote.Total, shared.NewMoney(29700, "USD"))
+}
+
+func Test_Service_Quote_With_Unknown_Room_Should_Return_ErrNoRatePlan(t *testing.T) {
+	// Arrange
+	service := createTestService()
+
+	// Act
+	_, err := service.Quote(context.Background(), "room-999", day(time.March, 1), day(time.March, 4))
+
+	// Assert
+	assert.That(t, "err must be ErrNoRatePlan", errors.Is(err, pricing.ErrNoRatePlan), true)
+}
+
+func Test_Service_SaveRatePlan_Should_Replace_Default_And_Previous_Plan(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	plan := testRatePlan()
+	_, _ = service.SaveRatePlan(context.Background(), plan)
+	plan.BaseRate = shared.NewMoney(12000, "USD")
+
+	// Act
+	_, err := service.SaveRatePlan(context.Background(), plan)
+	stored, _ := service.RatePlan(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "stored plan must be the latest", stored.BaseRate, shared.NewMoney(12000, "USD"))
+	assert.That(t, "stored plan must keep the seasons", len(stored.Seasons), 1)
+}
+
+func Test_Service_SaveRatePlan_With_Invalid_Plan_Should_Not_Persist(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	plan := testRatePlan()
+	plan.WeekendSurcharge = -150
+
+	// Act
+	_, err := service.SaveRatePlan(context.Background(), plan)
+	stored, _ := service.RatePlan(context.Background(), "room-101")
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidRatePlan", errors.Is(err, pricing.ErrInvalidRatePlan), true)
+	assert.That(t, "default plan must remain", len(stored.Seasons), 0)
+}
+
+func Test_Service_ListRatePlans_Should_Merge_Stored_And_Default_Plans(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	_, _ = service.SaveRatePlan(context.Background(), testRatePlan())
+
+	// Act
+	plans, err := service.ListRatePlans(context.Background())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "both rooms must be listed", len(plans), 2)
+	assert.That(t, "stored plan must win over the default", plans[0].WeekendSurcharge, 10)
+	assert.That(t, "rooms must be ordered", plans[1].RoomID, pricing.RoomID("room-201"))
+}
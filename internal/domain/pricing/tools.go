@@ -0,0 +1,79 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ScopeRead is the scope a service account needs to call the pricing tools.
+const ScopeRead = "pricing:read"
+
+// RegisterTools registers all pricing MCP tools with the server.
+func RegisterTools(server *mcp.Server, service *Service) {
+	server.RegisterTool(shared.GuardTool(newQuoteStayTool(service), ScopeRead))
+}
+
+// newQuoteStayTool creates a tool for the price of a stay.
+func newQuoteStayTool(service *Service) mcp.Tool {
+	return mcp.NewTool(
+		"quote_stay",
+		"Get the price of a stay in a room, as charged when it is booked. Returns the rate of every night with its seasonal and weekend adjustments, the length-of-stay discount and the total in the smallest currency unit.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"room_id":   mcp.NewStringProperty("The room ID"),
+				"check_in":  mcp.NewStringProperty("Check-in date (YYYY-MM-DD)"),
+				"check_out": mcp.NewStringProperty("Check-out date (YYYY-MM-DD)"),
+			},
+			[]string{"room_id", "check_in", "check_out"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			roomID, _ := params.Arguments["room_id"].(string)
+			checkInStr, _ := params.Arguments["check_in"].(string)
+			checkOutStr, _ := params.Arguments["check_out"].(string)
+			checkIn, err := time.Parse("2006-01-02", checkInStr)
+			if err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("invalid check_in date format: %w", err)
+			}
+			checkOut, err := time.Parse("2006-01-02", checkOutStr)
+			if err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("invalid check_out date format: %w", err)
+			}
+
+			quote, err := service.Quote(ctx, RoomID(roomID), checkIn, checkOut)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			type night struct {
+				Date        string   `json:"date"`
+				Rate        int64    `json:"rate"`
+				Adjustments []string `json:"adjustments,omitempty"`
+			}
+			result := struct {
+				RoomID       string  `json:"room_id"`
+				Currency     string  `json:"currency"`
+				Nights       []night `json:"nights"`
+				Subtotal     int64   `json:"subtotal"`
+				StayDiscount int64   `json:"stay_discount"`
+				Total        int64   `json:"total"`
+			}{
+				RoomID:       roomID,
+				Currency:     quote.Total.Currency,
+				Subtotal:     quote.Subtotal.Amount,
+				StayDiscount: quote.StayDiscount.Amount,
+				Total:        quote.Total.Amount,
+			}
+			for _, n := range quote.Nights {
+				result.Nights = append(result.Nights, night{Date: n.Date.Format("2006-01-02"), Rate: n.Rate.Amount, Adjustments: n.Adjustments})
+			}
+			data, _ := json.MarshalIndent(result, "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
@@ -0,0 +1,75 @@
+package pricing_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/pricing"
+)
+
+// ============================================================================
+// Tools Test Helpers
+// ============================================================================
+
+func quoteStayTool(t *testing.T) mcp.Tool {
+	t.Helper()
+	server := mcp.NewServer("test-server", "1.0.0")
+	pricing.RegisterTools(server, createTestService())
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "quote_stay" {
+			return tool
+		}
+	}
+	t.Fatal("quote_stay must be registered")
+	return mcp.Tool{}
+}
+
+// ============================================================================
+// QuoteStayTool Tests
+// ============================================================================
+
+func Test_QuoteStayTool_Should_Return_Nights_And_Total(t *testing.T) {
+	// Arrange
+	tool := quoteStayTool(t)
+	params := mcp.ToolsCallParams{
+		Name:      "quote_stay",
+		Arguments: map[string]any{"room_id": "room-201", "check_in": "2030-03-01", "check_out": "2030-03-03"},
+	}
+
+	// Act
+	result, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	var quote struct {
+		Currency string `json:"currency"`
+		Nights   []struct {
+			Date string `json:"date"`
+			Rate int64  `json:"rate"`
+		} `json:"nights"`
+		Total int64 `json:"total"`
+	}
+	_ = json.Unmarshal([]byte(result.Content[0].Text), &quote)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "two nights must be priced", len(quote.Nights), 2)
+	assert.That(t, "first night must be dated", quote.Nights[0].Date, "2030-03-01")
+	assert.That(t, "total must be two base rates", quote.Total, int64(29800))
+	assert.That(t, "currency must be returned", quote.Currency, "USD")
+}
+
+func Test_QuoteStayTool_When_Invalid_Date_Format_Should_Return_Error(t *testing.T) {
+	// Arrange
+	tool := quoteStayTool(t)
+	params := mcp.ToolsCallParams{
+		Name:      "quote_stay",
+		Arguments: map[string]any{"room_id": "room-201", "check_in": "tomorrow", "check_out": "2030-03-03"},
+	}
+
+	// Act
+	_, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,82 @@
+// Package profile contains the Guest Profile bounded context.
+// A guest profile is the view of a guest account across its reservations.
+// The same person may end up with several accounts (typo emails, phone variants),
+// so duplicates are detected and merged by staff. Every merge is recorded and can be undone.
+package profile
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type MergeID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// ReservationID is the shared identifier of a reservation moved by a merge.
+type ReservationID = shared.ReservationID
+
+// MergeStatus represents the state of a merge.
+type MergeStatus string
+
+const (
+	StatusMerged MergeStatus = "merged"
+	StatusUndone MergeStatus = "undone"
+)
+
+// Merge is the aggregate root for the audit trail of a profile merge.
+// It records which reservations were moved, so the merge can be undone exactly.
+type Merge struct {
+	ID             MergeID
+	SurvivorID     GuestID
+	DuplicateID    GuestID
+	ReservationIDs []ReservationID
+	Reasons        []string
+	MergedBy       string
+	MergedAt       time.Time
+	UndoneAt       time.Time
+	Status         MergeStatus
+}
+
+// Validation errors.
+var (
+	ErrSameProfile     = errors.New("cannot merge a profile into itself")
+	ErrMissingProfile  = errors.New("survivor and duplicate profile are required")
+	ErrNothingToMerge  = errors.New("duplicate profile has no reservations")
+	ErrMergeNotFound   = errors.New("merge not found")
+	ErrMergeUndone     = errors.New("merge already undone")
+	ErrMergeSuperseded = errors.New("a later merge moved the same reservations")
+)
+
+// NewMerge creates the record of a merge of the duplicate into the survivor profile.
+func NewMerge(id MergeID, survivorID, duplicateID GuestID, reservationIDs []ReservationID, reasons []string, mergedBy string, at time.Time) *Merge {
+	return &Merge{
+		ID:             id,
+		SurvivorID:     survivorID,
+		DuplicateID:    duplicateID,
+		ReservationIDs: reservationIDs,
+		Reasons:        reasons,
+		MergedBy:       mergedBy,
+		MergedAt:       at,
+		Status:         StatusMerged,
+	}
+}
+
+// Undo marks the merge as undone. A merge can be undone once.
+func (m *Merge) Undo(at time.Time) error {
+	if m.Status == StatusUndone {
+		return ErrMergeUndone
+	}
+	m.Status = StatusUndone
+	m.UndoneAt = at
+	return nil
+}
+
+// IsUndone checks if the merge was undone.
+func (m *Merge) IsUndone() bool {
+	return m.Status == StatusUndone
+}
@@ -0,0 +1,36 @@
+package profile_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// ============================================================================
+// Merge Tests
+// ============================================================================
+
+func Test_NewMerge_Should_Be_Merged(t *testing.T) {
+	// Arrange & Act
+	m := profile.NewMerge("merge-001", "guest-main", "guest-dup", []profile.ReservationID{"res-003"}, nil, "admin", time.Now())
+
+	// Assert
+	assert.That(t, "status must be merged", m.Status, profile.StatusMerged)
+	assert.That(t, "merge must not be undone", m.IsUndone(), false)
+}
+
+func Test_Merge_Undo_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	m := profile.NewMerge("merge-001", "guest-main", "guest-dup", []profile.ReservationID{"res-003"}, nil, "admin", time.Now())
+	_ = m.Undo(time.Now())
+
+	// Act
+	err := m.Undo(time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrMergeUndone", errors.Is(err, profile.ErrMergeUndone), true)
+	assert.That(t, "status must be undone", m.Status, profile.StatusUndone)
+}
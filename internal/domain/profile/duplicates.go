@@ -0,0 +1,266 @@
+package profile
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultDuplicateThreshold is the minimum score of a duplicate candidate.
+// A similar name alone is not enough; it needs a matching email or phone.
+const DefaultDuplicateThreshold = 0.7
+
+// Weights of the matching signals. They are combined like independent probabilities,
+// so two weak signals add up to a strong one.
+const (
+	weightSameEmail    = 0.9
+	weightEmailDomain  = 0.8 // same mailbox, domain with a typo (gmial.com)
+	weightEmailTypo    = 0.6 // same domain, mailbox with a typo
+	weightSamePhone    = 0.6 // households share a phone, so a phone alone is not enough
+	weightSimilarName  = 0.5
+	nameSimilarityMin  = 0.85
+	phoneSignificant   = 9 // trailing digits compared, which ignores the country and trunk prefix
+	phoneMinimumDigits = 7
+)
+
+// DuplicateCandidate is a pair of profiles that probably belong to the same person.
+// Survivor is the suggested profile to keep: the one with more reservations.
+type DuplicateCandidate struct {
+	Survivor  Profile
+	Duplicate Profile
+	Score     float64
+	Reasons   []string
+}
+
+// FindDuplicates compares every pair of profiles and returns the candidates with a score
+// of at least threshold, the most likely duplicates first.
+// The comparison is quadratic, which is fine for the guest base of a property.
+func FindDuplicates(profiles []Profile, threshold float64) []DuplicateCandidate {
+	var candidates []DuplicateCandidate
+	for i := range profiles {
+		for j := i + 1; j < len(profiles); j++ {
+			score, reasons := MatchProfiles(profiles[i], profiles[j])
+			if score < threshold {
+				continue
+			}
+			survivor, duplicate := profiles[i], profiles[j]
+			if len(duplicate.ReservationIDs) > len(survivor.ReservationIDs) {
+				survivor, duplicate = duplicate, survivor
+			}
+			candidates = append(candidates, DuplicateCandidate{
+				Survivor:  survivor,
+				Duplicate: duplicate,
+				Score:     score,
+				Reasons:   reasons,
+			})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b DuplicateCandidate) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return strings.Compare(string(a.Survivor.GuestID), string(b.Survivor.GuestID))
+		}
+	})
+	return candidates
+}
+
+// MatchProfiles scores how likely two profiles belong to the same person (0 to 1)
+// and explains the score with the matching signals.
+func MatchProfiles(a, b Profile) (float64, []string) {
+	var weights []float64
+	var reasons []string
+
+	if w, reason := matchEmails(a.Emails, b.Emails); w > 0 {
+		weights = append(weights, w)
+		reasons = append(reasons, reason)
+	}
+	if matchPhones(a.Phones, b.Phones) {
+		weights = append(weights, weightSamePhone)
+		reasons = append(reasons, "same phone")
+	}
+	if matchNames(a.Names, b.Names) {
+		weights = append(weights, weightSimilarName)
+		reasons = append(reasons, "similar name")
+	}
+
+	miss := 1.0
+	for _, w := range weights {
+		miss *= 1 - w
+	}
+	return 1 - miss, reasons
+}
+
+// matchEmails returns the weight of the best email match.
+func matchEmails(as, bs []string) (float64, string) {
+	best, reason := 0.0, ""
+	for _, a := range as {
+		for _, b := range bs {
+			aLocal, aDomain := NormalizeEmail(a)
+			bLocal, bDomain := NormalizeEmail(b)
+			if aLocal == "" || bLocal == "" {
+				continue
+			}
+			switch {
+			case aLocal == bLocal && aDomain == bDomain:
+				return weightSameEmail, "same email"
+			case aLocal == bLocal && editDistance(aDomain, bDomain) <= 2 && best < weightEmailDomain:
+				best, reason = weightEmailDomain, "similar email"
+			case aDomain == bDomain && len(aLocal) >= 4 && editDistance(aLocal, bLocal) <= 1 && best < weightEmailTypo:
+				best, reason = weightEmailTypo, "similar email"
+			}
+		}
+	}
+	return best, reason
+}
+
+// matchPhones checks if any phone numbers share their significant digits.
+func matchPhones(as, bs []string) bool {
+	for _, a := range as {
+		for _, b := range bs {
+			aDigits, bDigits := NormalizePhone(a), NormalizePhone(b)
+			if aDigits != "" && aDigits == bDigits {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchNames checks if any names are similar enough.
+func matchNames(as, bs []string) bool {
+	for _, a := range as {
+		for _, b := range bs {
+			if NameSimilarity(a, b) >= nameSimilarityMin {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeEmail returns the mailbox and domain of an email in a canonical form.
+// Plus-addressing tags are dropped and Gmail ignores dots in the mailbox.
+func NormalizeEmail(email string) (local, domain string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", ""
+	}
+	local, domain = email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local, domain
+}
+
+// NormalizePhone returns the significant trailing digits of a phone number,
+// so "+49 171 1234567" and "0171/1234567" match. Short numbers return an empty string.
+func NormalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < phoneMinimumDigits {
+		return ""
+	}
+	if len(digits) > phoneSignificant {
+		digits = digits[len(digits)-phoneSignificant:]
+	}
+	return digits
+}
+
+// NameSimilarity compares two names from 0 (different) to 1 (equal),
+// ignoring case, accents, punctuation and the order of the name parts.
+func NameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	longest := max(len([]rune(a)), len([]rune(b)))
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// normalizeName lowercases the name, folds common accents and sorts the name parts.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r):
+			sb.WriteString(foldAccent(r))
+		case unicode.IsSpace(r), r == '-', r == ',', r == '.':
+			sb.WriteRune(' ')
+		}
+	}
+	parts := strings.Fields(sb.String())
+	slices.Sort(parts)
+	return strings.Join(parts, " ")
+}
+
+// foldAccent maps accented latin letters to their base letters.
+func foldAccent(r rune) string {
+	switch r {
+	case 'à', 'á', 'â', 'ã', 'å':
+		return "a"
+	case 'ä', 'æ':
+		return "ae"
+	case 'ç':
+		return "c"
+	case 'è', 'é', 'ê', 'ë':
+		return "e"
+	case 'ì', 'í', 'î', 'ï':
+		return "i"
+	case 'ñ':
+		return "n"
+	case 'ò', 'ó', 'ô', 'õ':
+		return "o"
+	case 'ö', 'ø':
+		return "oe"
+	case 'ù', 'ú', 'û':
+		return "u"
+	case 'ü':
+		return "ue"
+	case 'ß':
+		return "ss"
+	default:
+		return string(r)
+	}
+}
+
+// editDistance returns the edit distance of two strings in runes.
+// Swapping adjacent letters counts as one edit, since it is the most common typo.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prevPrev := make([]int, len(br)+1)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,127 @@
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// ============================================================================
+// Normalization Tests
+// ============================================================================
+
+func Test_NormalizeEmail_Should_Drop_Plus_Tag_And_Gmail_Dots(t *testing.T) {
+	// Arrange & Act
+	local, domain := profile.NormalizeEmail("  John.Doe+Hotel@GoogleMail.com ")
+
+	// Assert
+	assert.That(t, "local must be canonical", local, "johndoe")
+	assert.That(t, "domain must be canonical", domain, "gmail.com")
+}
+
+func Test_NormalizePhone_Should_Ignore_Country_Prefix_And_Formatting(t *testing.T) {
+	// Arrange & Act
+	international := profile.NormalizePhone("+49 171 1234567")
+	national := profile.NormalizePhone("0171/123-4567")
+
+	// Assert
+	assert.That(t, "numbers must match", international, national)
+	assert.That(t, "short numbers must be ignored", profile.NormalizePhone("112"), "")
+}
+
+func Test_NameSimilarity_Should_Ignore_Order_Case_And_Accents(t *testing.T) {
+	// Arrange & Act & Assert
+	assert.That(t, "swapped name parts must be equal", profile.NameSimilarity("Doe, John", "john doe"), 1.0)
+	assert.That(t, "umlaut must match its transcription", profile.NameSimilarity("Jürgen Müller", "Juergen Mueller"), 1.0)
+	assert.That(t, "different names must not be similar", profile.NameSimilarity("John Doe", "Jane Smith") < 0.5, true)
+}
+
+// ============================================================================
+// BuildProfiles Tests
+// ============================================================================
+
+func Test_BuildProfiles_Should_Group_Records_By_Guest(t *testing.T) {
+	// Arrange
+	records := []profile.GuestRecord{
+		{ReservationID: "res-002", GuestID: "guest-b", Email: "b@example.com", Name: "B"},
+		{ReservationID: "res-003", GuestID: "guest-a", Email: "a@example.com", Name: "A"},
+		{ReservationID: "res-001", GuestID: "guest-a", Email: "A@example.com", Name: "A", Phone: "0171 1234567"},
+	}
+
+	// Act
+	profiles := profile.BuildProfiles(records)
+
+	// Assert
+	assert.That(t, "must build 2 profiles", len(profiles), 2)
+	assert.That(t, "profiles must be sorted", profiles[0].GuestID, profile.GuestID("guest-a"))
+	assert.That(t, "emails must be deduplicated", profiles[0].Emails, []string{"a@example.com"})
+	assert.That(t, "phones must be collected", profiles[0].Phones, []string{"0171 1234567"})
+	assert.That(t, "reservations must be sorted", profiles[0].ReservationIDs, []profile.ReservationID{"res-001", "res-003"})
+}
+
+// ============================================================================
+// FindDuplicates Tests
+// ============================================================================
+
+func Test_FindDuplicates_Should_Detect_Email_Typo_With_Same_Name(t *testing.T) {
+	// Arrange
+	profiles := []profile.Profile{
+		{GuestID: "guest-a", Names: []string{"John Doe"}, Emails: []string{"john.doe@example.com"}, ReservationIDs: []profile.ReservationID{"res-001"}},
+		{GuestID: "guest-b", Names: []string{"Doe John"}, Emails: []string{"jonh.doe@example.com"}, ReservationIDs: []profile.ReservationID{"res-002", "res-003"}},
+		{GuestID: "guest-c", Names: []string{"Jane Smith"}, Emails: []string{"jane@example.com"}, ReservationIDs: []profile.ReservationID{"res-004"}},
+	}
+
+	// Act
+	candidates := profile.FindDuplicates(profiles, profile.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.That(t, "must find 1 candidate", len(candidates), 1)
+	assert.That(t, "survivor must have more reservations", candidates[0].Survivor.GuestID, profile.GuestID("guest-b"))
+	assert.That(t, "duplicate must match", candidates[0].Duplicate.GuestID, profile.GuestID("guest-a"))
+	assert.That(t, "reasons must explain the match", candidates[0].Reasons, []string{"similar email", "similar name"})
+}
+
+func Test_FindDuplicates_Should_Detect_Phone_Variant_With_Same_Name(t *testing.T) {
+	// Arrange
+	profiles := []profile.Profile{
+		{GuestID: "guest-a", Names: []string{"Jürgen Müller"}, Emails: []string{"juergen@work.example"}, Phones: []string{"+49 171 1234567"}},
+		{GuestID: "guest-b", Names: []string{"Juergen Mueller"}, Emails: []string{"jm@home.example"}, Phones: []string{"0171 1234567"}},
+	}
+
+	// Act
+	candidates := profile.FindDuplicates(profiles, profile.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.That(t, "must find 1 candidate", len(candidates), 1)
+	assert.That(t, "reasons must explain the match", candidates[0].Reasons, []string{"same phone", "similar name"})
+}
+
+func Test_FindDuplicates_Should_Not_Flag_Shared_Phone_Alone(t *testing.T) {
+	// Arrange
+	profiles := []profile.Profile{
+		{GuestID: "guest-a", Names: []string{"John Doe"}, Emails: []string{"john@example.com"}, Phones: []string{"0171 1234567"}},
+		{GuestID: "guest-b", Names: []string{"Mary Doe"}, Emails: []string{"mary@example.com"}, Phones: []string{"0171 1234567"}},
+	}
+
+	// Act
+	candidates := profile.FindDuplicates(profiles, profile.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.That(t, "household members must not be duplicates", len(candidates), 0)
+}
+
+func Test_FindDuplicates_Should_Detect_Same_Gmail_Address(t *testing.T) {
+	// Arrange
+	profiles := []profile.Profile{
+		{GuestID: "guest-a", Emails: []string{"johndoe@gmail.com"}},
+		{GuestID: "guest-b", Emails: []string{"John.Doe+travel@gmail.com"}},
+	}
+
+	// Act
+	candidates := profile.FindDuplicates(profiles, profile.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.That(t, "must find 1 candidate", len(candidates), 1)
+	assert.That(t, "score must be high", candidates[0].Score >= 0.9, true)
+}
@@ -0,0 +1,22 @@
+package profile
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Language errors.
+var (
+	ErrUnsupportedLanguage = errors.New("language is not supported")
+	ErrLanguagesDisabled   = errors.New("language preferences are not configured")
+)
+
+// LanguagePreference is the language a guest wants to be written to in, e.g. in confirmation emails.
+// It is a locale, so amounts are also formatted the way the guest reads them.
+type LanguagePreference struct {
+	GuestID   GuestID
+	Locale    shared.Locale
+	UpdatedAt time.Time
+}
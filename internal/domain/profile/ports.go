@@ -0,0 +1,26 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// MergeRepository provides CRUD operations for the merge audit trail.
+type MergeRepository resource.Access[MergeID, Merge]
+
+// TierRepository provides CRUD operations for the tiers tagged by staff.
+type TierRepository resource.Access[GuestID, TierAssignment]
+
+// LanguageRepository provides CRUD operations for the language preferences of guests.
+type LanguageRepository resource.Access[GuestID, LanguagePreference]
+
+// PushSubscriptionRepository provides CRUD operations for the push subscriptions of guests' browsers.
+type PushSubscriptionRepository resource.Access[PushSubscriptionID, PushSubscription]
+
+// GuestDirectory provides the guest records of all reservations and moves reservations
+// between guest accounts. outbound.ReservationGuestDirectory implements it.
+type GuestDirectory interface {
+	GuestRecords(ctx context.Context) ([]GuestRecord, error)
+	ReassignReservations(ctx context.Context, from, to GuestID, ids []ReservationID) ([]ReservationID, error)
+}
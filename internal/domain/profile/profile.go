@@ -0,0 +1,76 @@
+package profile
+
+import (
+	"slices"
+	"strings"
+)
+
+// GuestRecord is the contact data of a single reservation, as recorded at booking time.
+type GuestRecord struct {
+	ReservationID ReservationID
+	GuestID       GuestID
+	Email         string
+	Name          string
+	Phone         string
+	Completed     bool // the guest checked out
+}
+
+// Profile is the read model of a guest account, collected from its reservations.
+type Profile struct {
+	GuestID        GuestID
+	Names          []string
+	Emails         []string
+	Phones         []string
+	ReservationIDs []ReservationID
+	CompletedStays int
+}
+
+// BuildProfiles groups the records by guest account, sorted by guest ID.
+// Names, emails and phones are deduplicated case-insensitively in the order they were seen.
+func BuildProfiles(records []GuestRecord) []Profile {
+	byGuest := make(map[GuestID]*Profile)
+	var order []GuestID
+	for _, record := range records {
+		if record.GuestID == "" {
+			continue
+		}
+		p, ok := byGuest[record.GuestID]
+		if !ok {
+			p = &Profile{GuestID: record.GuestID}
+			byGuest[record.GuestID] = p
+			order = append(order, record.GuestID)
+		}
+		p.Names = appendUnique(p.Names, record.Name)
+		p.Emails = appendUnique(p.Emails, record.Email)
+		p.Phones = appendUnique(p.Phones, record.Phone)
+		if !slices.Contains(p.ReservationIDs, record.ReservationID) {
+			p.ReservationIDs = append(p.ReservationIDs, record.ReservationID)
+			if record.Completed {
+				p.CompletedStays++
+			}
+		}
+	}
+
+	slices.Sort(order)
+	profiles := make([]Profile, 0, len(order))
+	for _, id := range order {
+		p := byGuest[id]
+		slices.Sort(p.ReservationIDs)
+		profiles = append(profiles, *p)
+	}
+	return profiles
+}
+
+// appendUnique appends the trimmed value unless it is empty or already present.
+func appendUnique(values []string, value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,126 @@
+package profile
+
+import (
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Push errors.
+var (
+	ErrPushDisabled             = errors.New("push notifications are not configured")
+	ErrInvalidPushSubscription  = errors.New("push subscription needs an https endpoint and the p256dh and auth keys, or an FCM token")
+	ErrPushSubscriptionNotFound = errors.New("push subscription not found")
+	ErrInvalidVAPIDKey          = errors.New("VAPID public key must be an uncompressed P-256 point, base64url encoded")
+)
+
+// PushPlatform is the push service that delivers the messages of a subscription.
+type PushPlatform string
+
+const (
+	PushWebPush PushPlatform = "webpush" // browsers, via the push service of their vendor (VAPID)
+	PushFCM     PushPlatform = "fcm"     // native apps, via Firebase Cloud Messaging
+)
+
+// PushSubscriptionID identifies a push subscription. It is derived from the endpoint,
+// so a browser that subscribes again replaces its subscription instead of adding one.
+type PushSubscriptionID string
+
+// PushSubscription is a device of a guest that receives push notifications: the push endpoint of a browser
+// on which the guest installed the booking portal, as returned by PushSubscription.toJSON() in the browser,
+// or the FCM registration token of an app. The keys encrypt the messages for the browser.
+type PushSubscription struct {
+	ID        PushSubscriptionID
+	GuestID   GuestID
+	Platform  PushPlatform
+	Endpoint  string // URL of the browser vendor's push service
+	Token     string // FCM registration token
+	P256dh    string // public key of the browser, base64url
+	Auth      string // authentication secret, base64url
+	UserAgent string // browser that subscribed, shown to the guest to tell devices apart
+	CreatedAt time.Time
+}
+
+// PushSubscriptionIDOf returns the ID of the subscription of the endpoint.
+func PushSubscriptionIDOf(endpoint string) PushSubscriptionID {
+	sum := sha256.Sum256([]byte(endpoint))
+	return PushSubscriptionID(hex.EncodeToString(sum[:16]))
+}
+
+// FCMSubscriptionIDOf returns the ID of the subscription of the FCM registration token.
+func FCMSubscriptionIDOf(token string) PushSubscriptionID {
+	return PushSubscriptionIDOf("fcm:" + token)
+}
+
+// Via returns the platform of the subscription; subscriptions stored before FCM was supported are Web Push.
+func (s PushSubscription) Via() PushPlatform {
+	if s.Platform == "" {
+		return PushWebPush
+	}
+	return s.Platform
+}
+
+// NewPushSubscription creates a validated push subscription of the guest.
+func NewPushSubscription(guestID GuestID, endpoint, p256dh, auth, userAgent string, now time.Time) (*PushSubscription, error) {
+	endpoint, p256dh, auth = strings.TrimSpace(endpoint), strings.TrimSpace(p256dh), strings.TrimSpace(auth)
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" || len(endpoint) > 2048 {
+		return nil, ErrInvalidPushSubscription
+	}
+	if !isBase64URL(p256dh) || !isBase64URL(auth) {
+		return nil, ErrInvalidPushSubscription
+	}
+	return &PushSubscription{
+		ID:        PushSubscriptionIDOf(endpoint),
+		GuestID:   guestID,
+		Platform:  PushWebPush,
+		Endpoint:  endpoint,
+		P256dh:    p256dh,
+		Auth:      auth,
+		UserAgent: userAgent,
+		CreatedAt: now,
+	}, nil
+}
+
+// NewFCMSubscription creates a validated push subscription of the guest's app with its FCM registration token.
+func NewFCMSubscription(guestID GuestID, token, userAgent string, now time.Time) (*PushSubscription, error) {
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > 4096 || strings.ContainsFunc(token, func(r rune) bool { return r <= ' ' || r > '~' }) {
+		return nil, ErrInvalidPushSubscription
+	}
+	return &PushSubscription{
+		ID:        FCMSubscriptionIDOf(token),
+		GuestID:   guestID,
+		Platform:  PushFCM,
+		Token:     token,
+		UserAgent: userAgent,
+		CreatedAt: now,
+	}, nil
+}
+
+// ParseVAPIDPublicKey checks the application server key that browsers bind push subscriptions to.
+func ParseVAPIDPublicKey(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return "", ErrInvalidVAPIDKey
+	}
+	if _, err := ecdh.P256().NewPublicKey(raw); err != nil {
+		return "", ErrInvalidVAPIDKey
+	}
+	return s, nil
+}
+
+// isBase64URL reports whether s is non-empty base64url, with or without padding.
+func isBase64URL(s string) bool {
+	if s == "" || len(s) > 256 {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	return err == nil
+}
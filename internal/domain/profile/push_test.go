@@ -0,0 +1,93 @@
+package profile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+const (
+	testPushEndpoint = "https://push.example.com/send/abc123"
+	testPushP256dh   = "BOhaSvJka3f3g4gHiYBl36g4cpHitQN8W-N8qqynH5V3yNPHRD84B5jtUYuNPPxk7LnatQRsMGdw7OllSXSZXrI"
+	testPushAuth     = "tBHItJI5svbpez7KI4CCXg"
+)
+
+// ============================================================================
+// NewPushSubscription Tests
+// ============================================================================
+
+func Test_NewPushSubscription_Should_Derive_ID_From_Endpoint(t *testing.T) {
+	// Arrange
+	now := time.Now()
+
+	// Act
+	subscription, err := profile.NewPushSubscription("guest-main", " "+testPushEndpoint+" ", testPushP256dh, testPushAuth, "Firefox", now)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "endpoint must be trimmed", subscription.Endpoint, testPushEndpoint)
+	assert.That(t, "ID must be derived from the endpoint", subscription.ID, profile.PushSubscriptionIDOf(testPushEndpoint))
+}
+
+func Test_NewPushSubscription_With_Invalid_Values_Should_Return_ErrInvalidPushSubscription(t *testing.T) {
+	cases := map[string][3]string{
+		"http endpoint":  {"http://push.example.com/send/abc123", testPushP256dh, testPushAuth},
+		"relative":       {"/send/abc123", testPushP256dh, testPushAuth},
+		"missing p256dh": {testPushEndpoint, "", testPushAuth},
+		"invalid auth":   {testPushEndpoint, testPushP256dh, "not base64!"},
+	}
+	for name, values := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := profile.NewPushSubscription("guest-main", values[0], values[1], values[2], "", time.Now())
+
+			// Assert
+			assert.That(t, "err must be ErrInvalidPushSubscription", err, profile.ErrInvalidPushSubscription)
+		})
+	}
+}
+
+// ============================================================================
+// ParseVAPIDPublicKey Tests
+// ============================================================================
+
+func Test_ParseVAPIDPublicKey_With_P256_Point_Should_Return_Key(t *testing.T) {
+	// Act
+	key, err := profile.ParseVAPIDPublicKey(" " + testPushP256dh + "\n")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "key must be trimmed", key, testPushP256dh)
+}
+
+func Test_ParseVAPIDPublicKey_With_Other_Bytes_Should_Return_ErrInvalidVAPIDKey(t *testing.T) {
+	// Act
+	_, err := profile.ParseVAPIDPublicKey(testPushAuth)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidVAPIDKey", err, profile.ErrInvalidVAPIDKey)
+}
+
+// ============================================================================
+// NewFCMSubscription Tests
+// ============================================================================
+
+func Test_NewFCMSubscription_Should_Be_Delivered_Via_FCM(t *testing.T) {
+	// Act
+	subscription, err := profile.NewFCMSubscription("guest-main", "fcm-token-1", "HotelApp/2.1", time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "platform must be FCM", subscription.Via(), profile.PushFCM)
+	assert.That(t, "ID must be derived from the token", subscription.ID, profile.FCMSubscriptionIDOf("fcm-token-1"))
+}
+
+func Test_NewFCMSubscription_With_Whitespace_In_Token_Should_Return_ErrInvalidPushSubscription(t *testing.T) {
+	// Act
+	_, err := profile.NewFCMSubscription("guest-main", "fcm token", "", time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidPushSubscription", err, profile.ErrInvalidPushSubscription)
+}
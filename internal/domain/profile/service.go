@@ -0,0 +1,402 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Service handles duplicate detection, profile merges, VIP tiers, language preferences and push subscriptions.
+type Service struct {
+	directory    GuestDirectory
+	mergeRepo    MergeRepository
+	tierRepo     TierRepository
+	tierPolicy   TierPolicy
+	languageRepo LanguageRepository
+	pushRepo     PushSubscriptionRepository
+	pushKey      string       // VAPID public key, base64url
+	mu           sync.RWMutex // guards tierPolicy, which can be reloaded at runtime
+}
+
+// NewService creates a new profile service.
+func NewService(directory GuestDirectory, mergeRepo MergeRepository, tierRepo TierRepository, tierPolicy TierPolicy) *Service {
+	return &Service{
+		directory:  directory,
+		mergeRepo:  mergeRepo,
+		tierRepo:   tierRepo,
+		tierPolicy: tierPolicy,
+	}
+}
+
+// WithLanguages stores the language preferences of guests in the repository.
+// Without it guests have no preferred language and SetLanguage fails with ErrLanguagesDisabled.
+func (s *Service) WithLanguages(repo LanguageRepository) *Service {
+	s.languageRepo = repo
+	return s
+}
+
+// WithPushSubscriptions stores the push subscriptions of guests' browsers and apps in the repository.
+// Browsers bind their subscriptions to the VAPID public key, which must be the one the messages are signed with;
+// with an empty key only apps subscribe via FCM. Without a repository push is disabled.
+func (s *Service) WithPushSubscriptions(repo PushSubscriptionRepository, vapidPublicKey string) *Service {
+	s.pushRepo = repo
+	s.pushKey = vapidPublicKey
+	return s
+}
+
+// SetTierPolicy replaces the tier policy at runtime.
+// Earned tiers are derived, so the new thresholds apply to every guest at once.
+func (s *Service) SetTierPolicy(policy TierPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tierPolicy = policy
+}
+
+// currentTierPolicy returns the tier policy in effect.
+func (s *Service) currentTierPolicy() TierPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tierPolicy
+}
+
+// Profiles returns the profiles of all guest accounts with reservations.
+func (s *Service) Profiles(ctx context.Context) ([]Profile, error) {
+	records, err := s.directory.GuestRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest records: %w", err)
+	}
+	return BuildProfiles(records), nil
+}
+
+// FindDuplicates returns the probable duplicate profiles, the most likely first.
+func (s *Service) FindDuplicates(ctx context.Context, threshold float64) ([]DuplicateCandidate, error) {
+	profiles, err := s.Profiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FindDuplicates(profiles, threshold), nil
+}
+
+// MergeProfiles moves all reservations of the duplicate to the survivor and records the merge.
+// The reasons explain why staff considered the profiles the same person.
+func (s *Service) MergeProfiles(ctx context.Context, id MergeID, survivorID, duplicateID GuestID, reasons []string, mergedBy string) (*Merge, error) {
+	if survivorID == "" || duplicateID == "" {
+		return nil, ErrMissingProfile
+	}
+	if survivorID == duplicateID {
+		return nil, ErrSameProfile
+	}
+
+	moved, err := s.directory.ReassignReservations(ctx, duplicateID, survivorID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move reservations: %w", err)
+	}
+	if len(moved) == 0 {
+		return nil, ErrNothingToMerge
+	}
+
+	merge := NewMerge(id, survivorID, duplicateID, moved, reasons, mergedBy, time.Now())
+	if err := s.mergeRepo.Create(ctx, merge.ID, *merge); err != nil {
+		return nil, fmt.Errorf("failed to persist merge: %w", err)
+	}
+	return merge, nil
+}
+
+// UndoMerge moves the reservations of a merge back to the duplicate profile.
+// A merge cannot be undone while a later merge moved the survivor into another profile;
+// that merge has to be undone first.
+func (s *Service) UndoMerge(ctx context.Context, id MergeID) (*Merge, error) {
+	merge, err := s.mergeRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMergeNotFound, err)
+	}
+	if merge.IsUndone() {
+		return nil, ErrMergeUndone
+	}
+
+	merges, err := s.mergeRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merges: %w", err)
+	}
+	for _, later := range merges {
+		if !later.IsUndone() && later.DuplicateID == merge.SurvivorID && later.MergedAt.After(merge.MergedAt) {
+			return nil, ErrMergeSuperseded
+		}
+	}
+
+	if _, err := s.directory.ReassignReservations(ctx, merge.SurvivorID, merge.DuplicateID, merge.ReservationIDs); err != nil {
+		return nil, fmt.Errorf("failed to move reservations back: %w", err)
+	}
+	if err := merge.Undo(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.mergeRepo.Update(ctx, merge.ID, *merge); err != nil {
+		return nil, fmt.Errorf("failed to update merge: %w", err)
+	}
+	return merge, nil
+}
+
+// Merges returns the merge audit trail, the latest merge first.
+func (s *Service) Merges(ctx context.Context) ([]Merge, error) {
+	merges, err := s.mergeRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merges: %w", err)
+	}
+	slices.SortFunc(merges, func(a, b Merge) int { return b.MergedAt.Compare(a.MergedAt) })
+	return merges, nil
+}
+
+// TierOf returns the effective VIP tier of a guest and its perks.
+func (s *Service) TierOf(ctx context.Context, guestID GuestID) (*TierStatus, error) {
+	profiles, err := s.Profiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completedStays := 0
+	for _, p := range profiles {
+		if p.GuestID == guestID {
+			completedStays = p.CompletedStays
+			break
+		}
+	}
+
+	var assignment *TierAssignment
+	if a, err := s.tierRepo.Read(ctx, guestID); err == nil {
+		assignment = a
+	}
+	status := s.currentTierPolicy().ResolveTier(guestID, completedStays, assignment)
+	return &status, nil
+}
+
+// Tiers returns the effective tier of every guest with a tier, the highest tier first.
+func (s *Service) Tiers(ctx context.Context) ([]TierStatus, error) {
+	profiles, err := s.Profiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.tierRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tier assignments: %w", err)
+	}
+
+	stays := make(map[GuestID]int, len(profiles))
+	for _, p := range profiles {
+		stays[p.GuestID] = p.CompletedStays
+	}
+	manual := make(map[GuestID]*TierAssignment, len(assignments))
+	for i := range assignments {
+		manual[assignments[i].GuestID] = &assignments[i]
+		if _, ok := stays[assignments[i].GuestID]; !ok {
+			stays[assignments[i].GuestID] = 0
+		}
+	}
+
+	policy := s.currentTierPolicy()
+	var tiers []TierStatus
+	for guestID, completedStays := range stays {
+		status := policy.ResolveTier(guestID, completedStays, manual[guestID])
+		if status.Tier != TierNone {
+			tiers = append(tiers, status)
+		}
+	}
+	slices.SortFunc(tiers, func(a, b TierStatus) int {
+		if a.Tier.rank() != b.Tier.rank() {
+			return b.Tier.rank() - a.Tier.rank()
+		}
+		return strings.Compare(string(a.GuestID), string(b.GuestID))
+	})
+	return tiers, nil
+}
+
+// AssignTier tags a guest with a tier manually. TierNone removes the manual tag,
+// so the guest falls back to the tier earned by stays.
+func (s *Service) AssignTier(ctx context.Context, guestID GuestID, tier Tier, note, assignedBy string) (*TierStatus, error) {
+	if guestID == "" {
+		return nil, ErrMissingProfile
+	}
+	if _, err := ParseTier(string(tier)); err != nil {
+		return nil, err
+	}
+
+	_, err := s.tierRepo.Read(ctx, guestID)
+	exists := err == nil
+
+	if tier == TierNone {
+		if exists {
+			if err := s.tierRepo.Delete(ctx, guestID); err != nil {
+				return nil, fmt.Errorf("failed to delete tier assignment: %w", err)
+			}
+		}
+		return s.TierOf(ctx, guestID)
+	}
+
+	assignment := TierAssignment{
+		GuestID:    guestID,
+		Tier:       tier,
+		Note:       note,
+		AssignedBy: assignedBy,
+		AssignedAt: time.Now(),
+	}
+	if exists {
+		err = s.tierRepo.Update(ctx, guestID, assignment)
+	} else {
+		err = s.tierRepo.Create(ctx, guestID, assignment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist tier assignment: %w", err)
+	}
+	return s.TierOf(ctx, guestID)
+}
+
+// LanguageOf returns the preferred locale of the guest, or false if the guest has none.
+func (s *Service) LanguageOf(ctx context.Context, guestID GuestID) (shared.Locale, bool) {
+	if s.languageRepo == nil || guestID == "" {
+		return "", false
+	}
+	preference, err := s.languageRepo.Read(ctx, guestID)
+	if err != nil {
+		return "", false
+	}
+	return preference.Locale, true
+}
+
+// SetLanguage stores the preferred language of the guest as a supported locale, e.g. "de" as de-DE.
+// An empty tag removes the preference, so the guest is written to in the default language again.
+func (s *Service) SetLanguage(ctx context.Context, guestID GuestID, tag string) (*LanguagePreference, error) {
+	if s.languageRepo == nil {
+		return nil, ErrLanguagesDisabled
+	}
+	if guestID == "" {
+		return nil, ErrMissingProfile
+	}
+
+	_, err := s.languageRepo.Read(ctx, guestID)
+	exists := err == nil
+
+	if tag == "" {
+		if exists {
+			if err := s.languageRepo.Delete(ctx, guestID); err != nil {
+				return nil, fmt.Errorf("failed to delete language preference: %w", err)
+			}
+		}
+		return &LanguagePreference{GuestID: guestID}, nil
+	}
+
+	locale, ok := shared.ParseLocale(tag)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, tag)
+	}
+	preference := LanguagePreference{GuestID: guestID, Locale: locale, UpdatedAt: time.Now()}
+	if exists {
+		err = s.languageRepo.Update(ctx, guestID, preference)
+	} else {
+		err = s.languageRepo.Create(ctx, guestID, preference)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist language preference: %w", err)
+	}
+	return &preference, nil
+}
+
+// PushEnabled reports whether guests can subscribe to push notifications, in a browser or an app.
+func (s *Service) PushEnabled() bool {
+	return s.pushRepo != nil
+}
+
+// PushKey returns the VAPID public key browsers subscribe with.
+func (s *Service) PushKey() string {
+	return s.pushKey
+}
+
+// SubscribePush stores the push subscription of the guest's browser. A browser subscribing again,
+// also after another guest signed in on it, replaces its subscription, so a device notifies one guest only.
+// Browsers subscribe with the VAPID key, so Web Push is disabled without it.
+func (s *Service) SubscribePush(ctx context.Context, guestID GuestID, endpoint, p256dh, auth, userAgent string) (*PushSubscription, error) {
+	if s.pushRepo == nil || s.pushKey == "" {
+		return nil, ErrPushDisabled
+	}
+	if guestID == "" {
+		return nil, ErrMissingProfile
+	}
+	subscription, err := NewPushSubscription(guestID, endpoint, p256dh, auth, userAgent, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return subscription, s.savePushSubscription(ctx, subscription)
+}
+
+// SubscribeFCM stores the FCM registration token of the guest's app; like SubscribePush,
+// an app registering again is bound to the guest who registered last.
+func (s *Service) SubscribeFCM(ctx context.Context, guestID GuestID, token, userAgent string) (*PushSubscription, error) {
+	if s.pushRepo == nil {
+		return nil, ErrPushDisabled
+	}
+	if guestID == "" {
+		return nil, ErrMissingProfile
+	}
+	subscription, err := NewFCMSubscription(guestID, token, userAgent, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return subscription, s.savePushSubscription(ctx, subscription)
+}
+
+// savePushSubscription creates the subscription or replaces the one of the same device.
+func (s *Service) savePushSubscription(ctx context.Context, subscription *PushSubscription) error {
+	var err error
+	if _, readErr := s.pushRepo.Read(ctx, subscription.ID); readErr == nil {
+		err = s.pushRepo.Update(ctx, subscription.ID, *subscription)
+	} else {
+		err = s.pushRepo.Create(ctx, subscription.ID, *subscription)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to persist push subscription: %w", err)
+	}
+	return nil
+}
+
+// UnsubscribePush removes the push subscription if it belongs to the guest.
+func (s *Service) UnsubscribePush(ctx context.Context, guestID GuestID, id PushSubscriptionID) error {
+	if s.pushRepo == nil {
+		return ErrPushDisabled
+	}
+	subscription, err := s.pushRepo.Read(ctx, id)
+	if err != nil || subscription.GuestID != guestID {
+		return ErrPushSubscriptionNotFound
+	}
+	if err := s.pushRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	return nil
+}
+
+// RemovePushSubscription removes a subscription the push service no longer accepts,
+// e.g. because the guest revoked the permission or uninstalled the app.
+func (s *Service) RemovePushSubscription(ctx context.Context, id PushSubscriptionID) error {
+	if s.pushRepo == nil {
+		return ErrPushDisabled
+	}
+	if err := s.pushRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %w", ErrPushSubscriptionNotFound, err)
+	}
+	return nil
+}
+
+// PushSubscriptions returns the push subscriptions of the guest, oldest first.
+func (s *Service) PushSubscriptions(ctx context.Context, guestID GuestID) ([]PushSubscription, error) {
+	if s.pushRepo == nil {
+		return nil, nil
+	}
+	all, err := s.pushRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	subscriptions := slices.DeleteFunc(all, func(sub PushSubscription) bool { return sub.GuestID != guestID })
+	slices.SortFunc(subscriptions, func(a, b PushSubscription) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return subscriptions, nil
+}
@@ -0,0 +1,354 @@
+package profile_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockGuestDirectory struct {
+	records []profile.GuestRecord
+}
+
+func (m *mockGuestDirectory) GuestRecords(ctx context.Context) ([]profile.GuestRecord, error) {
+	return m.records, nil
+}
+
+func (m *mockGuestDirectory) ReassignReservations(ctx context.Context, from, to profile.GuestID, ids []profile.ReservationID) ([]profile.ReservationID, error) {
+	var moved []profile.ReservationID
+	for i := range m.records {
+		if m.records[i].GuestID != from || (len(ids) > 0 && !slices.Contains(ids, m.records[i].ReservationID)) {
+			continue
+		}
+		m.records[i].GuestID = to
+		moved = append(moved, m.records[i].ReservationID)
+	}
+	return moved, nil
+}
+
+func (m *mockGuestDirectory) owner(id profile.ReservationID) profile.GuestID {
+	for _, r := range m.records {
+		if r.ReservationID == id {
+			return r.GuestID
+		}
+	}
+	return ""
+}
+
+func createTestProfileService() (*profile.Service, *mockGuestDirectory) {
+	directory := &mockGuestDirectory{records: []profile.GuestRecord{
+		{ReservationID: "res-001", GuestID: "guest-main", Email: "john.doe@example.com", Name: "John Doe"},
+		{ReservationID: "res-002", GuestID: "guest-main", Email: "john.doe@example.com", Name: "John Doe"},
+		{ReservationID: "res-003", GuestID: "guest-dup", Email: "jonh.doe@example.com", Name: "John Doe"},
+		{ReservationID: "res-004", GuestID: "guest-other", Email: "jane@example.com", Name: "Jane Smith"},
+	}}
+	return profile.NewService(directory, resource.NewInMemoryAccess[profile.MergeID, profile.Merge](), resource.NewInMemoryAccess[profile.GuestID, profile.TierAssignment](), profile.DefaultTierPolicy()), directory
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_FindDuplicates_Should_Return_Candidates(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	candidates, err := svc.FindDuplicates(context.Background(), profile.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "must find 1 candidate", len(candidates), 1)
+	assert.That(t, "survivor must match", candidates[0].Survivor.GuestID, profile.GuestID("guest-main"))
+	assert.That(t, "duplicate must match", candidates[0].Duplicate.GuestID, profile.GuestID("guest-dup"))
+}
+
+func Test_Service_MergeProfiles_Should_Move_Reservations_And_Record_Merge(t *testing.T) {
+	// Arrange
+	svc, directory := createTestProfileService()
+	ctx := context.Background()
+
+	// Act
+	merge, err := svc.MergeProfiles(ctx, "merge-001", "guest-main", "guest-dup", []string{"similar email"}, "admin")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "moved reservations must be recorded", merge.ReservationIDs, []profile.ReservationID{"res-003"})
+	assert.That(t, "reservation must be owned by survivor", directory.owner("res-003"), profile.GuestID("guest-main"))
+	merges, _ := svc.Merges(ctx)
+	assert.That(t, "audit trail must contain merge", len(merges), 1)
+	assert.That(t, "reasons must be recorded", merges[0].Reasons, []string{"similar email"})
+}
+
+func Test_Service_MergeProfiles_Into_Itself_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	_, err := svc.MergeProfiles(context.Background(), "merge-001", "guest-main", "guest-main", nil, "admin")
+
+	// Assert
+	assert.That(t, "err must be ErrSameProfile", errors.Is(err, profile.ErrSameProfile), true)
+}
+
+func Test_Service_MergeProfiles_Without_Reservations_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	_, err := svc.MergeProfiles(context.Background(), "merge-001", "guest-main", "guest-unknown", nil, "admin")
+
+	// Assert
+	assert.That(t, "err must be ErrNothingToMerge", errors.Is(err, profile.ErrNothingToMerge), true)
+}
+
+func Test_Service_UndoMerge_Should_Move_Only_Merged_Reservations_Back(t *testing.T) {
+	// Arrange
+	svc, directory := createTestProfileService()
+	ctx := context.Background()
+	_, _ = svc.MergeProfiles(ctx, "merge-001", "guest-main", "guest-dup", nil, "admin")
+
+	// Act
+	merge, err := svc.UndoMerge(ctx, "merge-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "merge must be undone", merge.IsUndone(), true)
+	assert.That(t, "merged reservation must be back", directory.owner("res-003"), profile.GuestID("guest-dup"))
+	assert.That(t, "survivor reservations must stay", directory.owner("res-001"), profile.GuestID("guest-main"))
+
+	_, err = svc.UndoMerge(ctx, "merge-001")
+	assert.That(t, "second undo must fail", errors.Is(err, profile.ErrMergeUndone), true)
+}
+
+func Test_Service_UndoMerge_Superseded_By_Later_Merge_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	ctx := context.Background()
+	_, _ = svc.MergeProfiles(ctx, "merge-001", "guest-main", "guest-dup", nil, "admin")
+	time.Sleep(time.Millisecond)
+	_, _ = svc.MergeProfiles(ctx, "merge-002", "guest-other", "guest-main", nil, "admin")
+
+	// Act
+	_, err := svc.UndoMerge(ctx, "merge-001")
+
+	// Assert
+	assert.That(t, "err must be ErrMergeSuperseded", errors.Is(err, profile.ErrMergeSuperseded), true)
+}
+
+func Test_Service_UndoMerge_Unknown_Should_Return_Not_Found(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	_, err := svc.UndoMerge(context.Background(), "merge-unknown")
+
+	// Assert
+	assert.That(t, "err must be ErrMergeNotFound", errors.Is(err, profile.ErrMergeNotFound), true)
+}
+
+func Test_Service_TierOf_Should_Count_Completed_Stays(t *testing.T) {
+	// Arrange
+	svc, directory := createTestProfileService()
+	for i := range directory.records {
+		directory.records[i].Completed = directory.records[i].GuestID == "guest-main"
+	}
+	policy := profile.DefaultTierPolicy()
+	policy.GoldStays = 2
+	svc = profile.NewService(directory, resource.NewInMemoryAccess[profile.MergeID, profile.Merge](), resource.NewInMemoryAccess[profile.GuestID, profile.TierAssignment](), policy)
+
+	// Act
+	status, err := svc.TierOf(context.Background(), "guest-main")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "completed stays must be counted", status.CompletedStays, 2)
+	assert.That(t, "tier must be earned", status.Tier, profile.TierGold)
+}
+
+func Test_Service_SetTierPolicy_Should_Apply_New_Thresholds(t *testing.T) {
+	// Arrange
+	svc, directory := createTestProfileService()
+	for i := range directory.records {
+		directory.records[i].Completed = directory.records[i].GuestID == "guest-main"
+	}
+	policy := profile.DefaultTierPolicy()
+	policy.GoldStays = 2
+
+	// Act
+	before, _ := svc.TierOf(context.Background(), "guest-main")
+	svc.SetTierPolicy(policy)
+	after, err := svc.TierOf(context.Background(), "guest-main")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "tier must not be earned before", before.Tier, profile.TierNone)
+	assert.That(t, "tier must be earned after", after.Tier, profile.TierGold)
+}
+
+func Test_Service_AssignTier_Should_Tag_And_Untag_Guest(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	ctx := context.Background()
+
+	// Act
+	tagged, err := svc.AssignTier(ctx, "guest-other", profile.TierPlatinum, "Board member", "admin")
+	tiers, _ := svc.Tiers(ctx)
+	untagged, _ := svc.AssignTier(ctx, "guest-other", profile.TierNone, "", "admin")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "tier must be platinum", tagged.Tier, profile.TierPlatinum)
+	assert.That(t, "tiers must list the guest", len(tiers), 1)
+	assert.That(t, "untagged guest must be regular", untagged.Tier, profile.TierNone)
+}
+
+// ============================================================================
+// Language Preference Tests
+// ============================================================================
+
+func Test_Service_SetLanguage_Should_Store_Supported_Locale(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithLanguages(resource.NewInMemoryAccess[profile.GuestID, profile.LanguagePreference]())
+
+	// Act
+	preference, err := svc.SetLanguage(context.Background(), "guest-main", "de")
+	locale, ok := svc.LanguageOf(context.Background(), "guest-main")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "language must be resolved to its locale", preference.Locale, shared.Locale("de-DE"))
+	assert.That(t, "preference must be stored", ok, true)
+	assert.That(t, "stored locale must be de-DE", locale, shared.Locale("de-DE"))
+}
+
+func Test_Service_SetLanguage_With_Empty_Tag_Should_Remove_Preference(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithLanguages(resource.NewInMemoryAccess[profile.GuestID, profile.LanguagePreference]())
+	_, _ = svc.SetLanguage(context.Background(), "guest-main", "fr-FR")
+
+	// Act
+	_, err := svc.SetLanguage(context.Background(), "guest-main", "")
+	_, ok := svc.LanguageOf(context.Background(), "guest-main")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "preference must be removed", ok, false)
+}
+
+func Test_Service_SetLanguage_With_Unknown_Language_Should_Return_ErrUnsupportedLanguage(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithLanguages(resource.NewInMemoryAccess[profile.GuestID, profile.LanguagePreference]())
+
+	// Act
+	_, err := svc.SetLanguage(context.Background(), "guest-main", "tlh")
+
+	// Assert
+	assert.That(t, "err must be ErrUnsupportedLanguage", errors.Is(err, profile.ErrUnsupportedLanguage), true)
+}
+
+func Test_Service_SetLanguage_Without_Repository_Should_Return_ErrLanguagesDisabled(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	_, err := svc.SetLanguage(context.Background(), "guest-main", "de")
+
+	// Assert
+	assert.That(t, "err must be ErrLanguagesDisabled", errors.Is(err, profile.ErrLanguagesDisabled), true)
+}
+
+// ============================================================================
+// Push Subscription Tests
+// ============================================================================
+
+func Test_Service_SubscribePush_Again_Should_Rebind_Browser_To_New_Guest(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), testPushP256dh)
+	ctx := context.Background()
+	_, _ = svc.SubscribePush(ctx, "guest-main", testPushEndpoint, testPushP256dh, testPushAuth, "Firefox")
+
+	// Act
+	_, err := svc.SubscribePush(ctx, "guest-other", testPushEndpoint, testPushP256dh, testPushAuth, "Firefox")
+	previous, _ := svc.PushSubscriptions(ctx, "guest-main")
+	current, _ := svc.PushSubscriptions(ctx, "guest-other")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "previous guest must have no subscription", len(previous), 0)
+	assert.That(t, "new guest must have the subscription", len(current), 1)
+}
+
+func Test_Service_UnsubscribePush_Of_Other_Guest_Should_Return_ErrPushSubscriptionNotFound(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), testPushP256dh)
+	ctx := context.Background()
+	_, _ = svc.SubscribePush(ctx, "guest-main", testPushEndpoint, testPushP256dh, testPushAuth, "Firefox")
+
+	// Act
+	err := svc.UnsubscribePush(ctx, "guest-other", profile.PushSubscriptionIDOf(testPushEndpoint))
+	subscriptions, _ := svc.PushSubscriptions(ctx, "guest-main")
+
+	// Assert
+	assert.That(t, "err must be ErrPushSubscriptionNotFound", errors.Is(err, profile.ErrPushSubscriptionNotFound), true)
+	assert.That(t, "subscription must be kept", len(subscriptions), 1)
+}
+
+func Test_Service_SubscribePush_Without_Repository_Should_Return_ErrPushDisabled(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+
+	// Act
+	_, err := svc.SubscribePush(context.Background(), "guest-main", testPushEndpoint, testPushP256dh, testPushAuth, "")
+
+	// Assert
+	assert.That(t, "err must be ErrPushDisabled", errors.Is(err, profile.ErrPushDisabled), true)
+	assert.That(t, "push must be disabled", svc.PushEnabled(), false)
+}
+
+func Test_Service_SubscribePush_Without_VAPID_Key_Should_Accept_Apps_Only(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), "")
+	ctx := context.Background()
+
+	// Act
+	_, webPushErr := svc.SubscribePush(ctx, "guest-main", testPushEndpoint, testPushP256dh, testPushAuth, "Firefox")
+	_, fcmErr := svc.SubscribeFCM(ctx, "guest-main", "fcm-token-1", "HotelApp/2.1")
+
+	// Assert
+	assert.That(t, "Web Push must be disabled", errors.Is(webPushErr, profile.ErrPushDisabled), true)
+	assert.That(t, "FCM err must be nil", fcmErr, nil)
+}
+
+func Test_Service_RemovePushSubscription_Should_Delete_Any_Guests_Subscription(t *testing.T) {
+	// Arrange
+	svc, _ := createTestProfileService()
+	svc.WithPushSubscriptions(resource.NewInMemoryAccess[profile.PushSubscriptionID, profile.PushSubscription](), testPushP256dh)
+	ctx := context.Background()
+	subscription, _ := svc.SubscribeFCM(ctx, "guest-main", "fcm-token-1", "HotelApp/2.1")
+
+	// Act
+	err := svc.RemovePushSubscription(ctx, subscription.ID)
+	subscriptions, _ := svc.PushSubscriptions(ctx, "guest-main")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subscription must be removed", len(subscriptions), 0)
+}
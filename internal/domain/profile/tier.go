@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"errors"
+	"time"
+)
+
+// Tier is the VIP tier of a guest. The zero value is a regular guest.
+type Tier string
+
+const (
+	TierNone     Tier = ""
+	TierGold     Tier = "gold"
+	TierPlatinum Tier = "platinum"
+)
+
+// TierSource tells how a guest reached the tier.
+type TierSource string
+
+const (
+	TierSourceManual TierSource = "manual" // tagged by staff
+	TierSourceStays  TierSource = "stays"  // reached the completed stays threshold
+)
+
+// ErrInvalidTier is returned for unknown tiers.
+var ErrInvalidTier = errors.New("tier must be gold, platinum or empty")
+
+// ParseTier validates a tier name.
+func ParseTier(value string) (Tier, error) {
+	switch tier := Tier(value); tier {
+	case TierNone, TierGold, TierPlatinum:
+		return tier, nil
+	default:
+		return TierNone, ErrInvalidTier
+	}
+}
+
+// rank orders the tiers, so the higher of the manual and the earned tier wins.
+func (t Tier) rank() int {
+	switch t {
+	case TierPlatinum:
+		return 2
+	case TierGold:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// TierPerks are the benefits of a tier, applied when the guest books.
+type TierPerks struct {
+	LateCheckout    bool
+	UpgradePriority bool
+	DiscountPercent int
+}
+
+// TierPolicy defines the completed stays needed per tier and the perks of each tier.
+// A threshold of 0 disables earning the tier by stays; staff can still tag guests manually.
+type TierPolicy struct {
+	GoldStays     int
+	PlatinumStays int
+	Perks         map[Tier]TierPerks
+}
+
+// DefaultTierPolicy returns the default thresholds and perks.
+func DefaultTierPolicy() TierPolicy {
+	return TierPolicy{
+		GoldStays:     5,
+		PlatinumStays: 15,
+		Perks: map[Tier]TierPerks{
+			TierGold:     {LateCheckout: true, DiscountPercent: 5},
+			TierPlatinum: {LateCheckout: true, UpgradePriority: true, DiscountPercent: 10},
+		},
+	}
+}
+
+// TierFor returns the tier earned by the completed stays.
+func (p TierPolicy) TierFor(completedStays int) Tier {
+	switch {
+	case p.PlatinumStays > 0 && completedStays >= p.PlatinumStays:
+		return TierPlatinum
+	case p.GoldStays > 0 && completedStays >= p.GoldStays:
+		return TierGold
+	default:
+		return TierNone
+	}
+}
+
+// TierAssignment is the aggregate root for a tier tagged manually by staff.
+type TierAssignment struct {
+	GuestID    GuestID
+	Tier       Tier
+	Note       string
+	AssignedBy string
+	AssignedAt time.Time
+}
+
+// TierStatus is the effective tier of a guest: the higher of the manual and the earned tier.
+type TierStatus struct {
+	GuestID        GuestID
+	Tier           Tier
+	Source         TierSource
+	CompletedStays int
+	Perks          TierPerks
+}
+
+// ResolveTier combines the manual assignment (may be nil) with the tier earned by stays.
+func (p TierPolicy) ResolveTier(guestID GuestID, completedStays int, assignment *TierAssignment) TierStatus {
+	status := TierStatus{
+		GuestID:        guestID,
+		Tier:           p.TierFor(completedStays),
+		Source:         TierSourceStays,
+		CompletedStays: completedStays,
+	}
+	if assignment != nil && assignment.Tier.rank() > status.Tier.rank() {
+		status.Tier = assignment.Tier
+		status.Source = TierSourceManual
+	}
+	if status.Tier == TierNone {
+		status.Source = ""
+	}
+	status.Perks = p.Perks[status.Tier]
+	return status
+}
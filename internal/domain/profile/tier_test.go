@@ -0,0 +1,47 @@
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/profile"
+)
+
+// ============================================================================
+// Tier Tests
+// ============================================================================
+
+func Test_ParseTier_With_Unknown_Tier_Should_Return_Error(t *testing.T) {
+	// Arrange & Act
+	_, err := profile.ParseTier("diamond")
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidTier", err, profile.ErrInvalidTier)
+}
+
+func Test_TierPolicy_TierFor_Should_Use_Stay_Thresholds(t *testing.T) {
+	// Arrange
+	policy := profile.DefaultTierPolicy()
+
+	// Act & Assert
+	assert.That(t, "4 stays must be regular", policy.TierFor(4), profile.TierNone)
+	assert.That(t, "5 stays must be gold", policy.TierFor(5), profile.TierGold)
+	assert.That(t, "15 stays must be platinum", policy.TierFor(15), profile.TierPlatinum)
+}
+
+func Test_TierPolicy_ResolveTier_Should_Prefer_Higher_Tier(t *testing.T) {
+	// Arrange
+	policy := profile.DefaultTierPolicy()
+	manualGold := &profile.TierAssignment{GuestID: "guest-001", Tier: profile.TierGold}
+
+	// Act
+	earned := policy.ResolveTier("guest-001", 20, manualGold)
+	manual := policy.ResolveTier("guest-001", 1, manualGold)
+
+	// Assert
+	assert.That(t, "earned platinum must win over manual gold", earned.Tier, profile.TierPlatinum)
+	assert.That(t, "earned tier source must be stays", earned.Source, profile.TierSourceStays)
+	assert.That(t, "manual gold must win over no tier", manual.Tier, profile.TierGold)
+	assert.That(t, "manual tier source must be manual", manual.Source, profile.TierSourceManual)
+	assert.That(t, "gold perks must apply", manual.Perks.DiscountPercent, 5)
+}
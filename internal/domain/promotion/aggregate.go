@@ -0,0 +1,157 @@
+// Package promotion contains the Promotion bounded context.
+// Marketing hands out promo codes, managed by admins, that take a percentage or a fixed amount off
+// the price of a booking within their validity window and up to their usage limits.
+// Every use of a code is a redemption recorded for the reservation it was applied to.
+package promotion
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type Code string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// ReservationID is the shared identifier of the booking a code was applied to.
+type ReservationID = shared.ReservationID
+
+// DiscountKind is how a promo code reduces the price.
+type DiscountKind string
+
+const (
+	KindPercent DiscountKind = "percent" // a percentage of the price
+	KindFixed   DiscountKind = "fixed"   // a fixed amount, at most the price
+)
+
+// Code format and validation errors.
+var (
+	ErrInvalidCode    = errors.New("promo code must be 3 to 32 letters, digits or dashes")
+	ErrInvalidKind    = errors.New("discount kind must be percent or fixed")
+	ErrInvalidPercent = errors.New("percentage must be between 1 and 100")
+	ErrInvalidAmount  = errors.New("fixed discount must be positive and have a currency")
+	ErrInvalidWindow  = errors.New("promo code must end after it starts")
+	ErrInvalidLimit   = errors.New("usage limits cannot be negative")
+	ErrCodeTaken      = errors.New("promo code already exists")
+)
+
+// Errors of applying a code to a booking.
+var (
+	ErrUnknownCode       = errors.New("promo code is not valid")
+	ErrInactive          = errors.New("promo code is no longer available")
+	ErrNotYetValid       = errors.New("promo code is not valid yet")
+	ErrExpired           = errors.New("promo code has expired")
+	ErrUsageLimit        = errors.New("promo code has been used up")
+	ErrGuestUsageLimit   = errors.New("you have already used this promo code")
+	ErrCurrencyMismatch  = errors.New("promo code does not apply to prices in this currency")
+	ErrAlreadyApplied    = errors.New("a promo code was already applied to this reservation")
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrCodeBusy          = errors.New("promo code is being redeemed by another request, try again")
+)
+
+// NormalizeCode returns the code as stored: trimmed and upper case, so guests may type it in any case.
+func NormalizeCode(value string) Code {
+	return Code(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// Terms are what a promo code gives and when it may be used.
+type Terms struct {
+	Kind            DiscountKind
+	Percent         int          // for KindPercent
+	Amount          shared.Money // for KindFixed
+	ValidFrom       time.Time    // zero is valid right away
+	ValidUntil      time.Time    // zero never expires
+	MaxUses         int          // uses by all guests; 0 is unlimited
+	MaxUsesPerGuest int          // 0 is unlimited
+}
+
+// Promotion is the aggregate root for a promo code.
+// Deactivated codes are kept, so their redemptions can still be traced back to them.
+type Promotion struct {
+	Code      Code
+	Terms     Terms
+	Active    bool
+	CreatedAt time.Time
+}
+
+// NewPromotion creates an active promo code with the terms.
+func NewPromotion(code Code, terms Terms, at time.Time) (*Promotion, error) {
+	if !validCode(code) {
+		return nil, ErrInvalidCode
+	}
+	switch terms.Kind {
+	case KindPercent:
+		if terms.Percent < 1 || terms.Percent > 100 {
+			return nil, ErrInvalidPercent
+		}
+		terms.Amount = shared.Money{}
+	case KindFixed:
+		if terms.Amount.Amount <= 0 || terms.Amount.Currency == "" {
+			return nil, ErrInvalidAmount
+		}
+		terms.Percent = 0
+	default:
+		return nil, ErrInvalidKind
+	}
+	if !terms.ValidFrom.IsZero() && !terms.ValidUntil.IsZero() && !terms.ValidUntil.After(terms.ValidFrom) {
+		return nil, ErrInvalidWindow
+	}
+	if terms.MaxUses < 0 || terms.MaxUsesPerGuest < 0 {
+		return nil, ErrInvalidLimit
+	}
+	return &Promotion{Code: code, Terms: terms, Active: true, CreatedAt: at}, nil
+}
+
+// validCode reports whether a normalized code has 3 to 32 letters, digits or dashes.
+func validCode(code Code) bool {
+	if len(code) < 3 || len(code) > 32 {
+		return false
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckValidAt returns why the code cannot be used at the time, or nil if it can.
+func (p *Promotion) CheckValidAt(at time.Time) error {
+	switch {
+	case !p.Active:
+		return ErrInactive
+	case !p.Terms.ValidFrom.IsZero() && at.Before(p.Terms.ValidFrom):
+		return ErrNotYetValid
+	case !p.Terms.ValidUntil.IsZero() && !at.Before(p.Terms.ValidUntil):
+		return ErrExpired
+	default:
+		return nil
+	}
+}
+
+// DiscountOn returns the discount of the code on the price, which is never more than the price.
+// Fixed discounts only apply to prices in their currency.
+func (p *Promotion) DiscountOn(price shared.Money) (shared.Money, error) {
+	if p.Terms.Kind == KindFixed {
+		if p.Terms.Amount.Currency != price.Currency {
+			return shared.Money{}, ErrCurrencyMismatch
+		}
+		return shared.NewMoney(min(p.Terms.Amount.Amount, price.Amount), price.Currency), nil
+	}
+	return shared.NewMoney(price.Amount*int64(p.Terms.Percent)/100, price.Currency), nil
+}
+
+// Redemption is the use of a promo code for a booking (aggregate root).
+// Its ID is the reservation ID, so a reservation gets at most one code.
+type Redemption struct {
+	ReservationID ReservationID
+	Code          Code
+	GuestID       GuestID
+	Discount      shared.Money
+	At            time.Time
+}
@@ -0,0 +1,99 @@
+package promotion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// NewPromotion Tests
+// ============================================================================
+
+func Test_NewPromotion_With_Invalid_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	terms := promotion.Terms{Kind: promotion.KindPercent, Percent: 10}
+
+	// Act
+	_, err := promotion.NewPromotion(promotion.NormalizeCode("summer 25"), terms, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidCode", err, promotion.ErrInvalidCode)
+}
+
+func Test_NewPromotion_With_Percent_Over_100_Should_Fail(t *testing.T) {
+	// Arrange
+	terms := promotion.Terms{Kind: promotion.KindPercent, Percent: 101}
+
+	// Act
+	_, err := promotion.NewPromotion("SUMMER25", terms, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidPercent", err, promotion.ErrInvalidPercent)
+}
+
+func Test_NewPromotion_With_Window_Ending_Before_Start_Should_Fail(t *testing.T) {
+	// Arrange
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	terms := promotion.Terms{Kind: promotion.KindFixed, Amount: shared.NewMoney(2000, "USD"), ValidFrom: start, ValidUntil: start.AddDate(0, 0, -1)}
+
+	// Act
+	_, err := promotion.NewPromotion("WELCOME", terms, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidWindow", err, promotion.ErrInvalidWindow)
+}
+
+// ============================================================================
+// CheckValidAt Tests
+// ============================================================================
+
+func Test_Promotion_CheckValidAt_Should_Respect_Window(t *testing.T) {
+	// Arrange
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	terms := promotion.Terms{Kind: promotion.KindPercent, Percent: 10, ValidFrom: start, ValidUntil: start.AddDate(0, 1, 0)}
+	promo, _ := promotion.NewPromotion("SUMMER", terms, time.Now())
+
+	// Act
+	before := promo.CheckValidAt(start.Add(-time.Second))
+	during := promo.CheckValidAt(start)
+	after := promo.CheckValidAt(start.AddDate(0, 1, 0))
+
+	// Assert
+	assert.That(t, "code must not be valid before the window", before, promotion.ErrNotYetValid)
+	assert.That(t, "code must be valid from the start", during, nil)
+	assert.That(t, "code must expire at the end", after, promotion.ErrExpired)
+}
+
+// ============================================================================
+// DiscountOn Tests
+// ============================================================================
+
+func Test_Promotion_DiscountOn_Percent_Should_Take_Share_Of_Price(t *testing.T) {
+	// Arrange
+	promo, _ := promotion.NewPromotion("SUMMER25", promotion.Terms{Kind: promotion.KindPercent, Percent: 25}, time.Now())
+
+	// Act
+	discount, err := promo.DiscountOn(shared.NewMoney(29700, "EUR"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "discount must be a quarter", discount, shared.NewMoney(7425, "EUR"))
+}
+
+func Test_Promotion_DiscountOn_Fixed_Should_Not_Exceed_Price(t *testing.T) {
+	// Arrange
+	promo, _ := promotion.NewPromotion("WELCOME", promotion.Terms{Kind: promotion.KindFixed, Amount: shared.NewMoney(5000, "USD")}, time.Now())
+
+	// Act
+	discount, err := promo.DiscountOn(shared.NewMoney(3000, "USD"))
+	_, mismatch := promo.DiscountOn(shared.NewMoney(3000, "EUR"))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "discount must be capped at the price", discount, shared.NewMoney(3000, "USD"))
+	assert.That(t, "other currency must fail", mismatch, promotion.ErrCurrencyMismatch)
+}
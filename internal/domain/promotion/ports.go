@@ -0,0 +1,19 @@
+package promotion
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// PromotionRepository provides CRUD operations for promo codes.
+type PromotionRepository resource.Access[Code, Promotion]
+
+// RedemptionRepository provides CRUD operations for the uses of promo codes, keyed by reservation.
+type RedemptionRepository resource.Access[ReservationID, Redemption]
+
+// CodeLocks serializes the redemptions of a promo code across all server instances.
+type CodeLocks interface {
+	// Lock waits until the code is locked and returns the function that releases it, or ErrCodeBusy
+	Lock(ctx context.Context, code Code) (func(), error)
+}
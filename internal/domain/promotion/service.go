@@ -0,0 +1,193 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Usage is a promo code with the number of times it was used.
+type Usage struct {
+	Promotion Promotion
+	Uses      int
+}
+
+// Service handles promo codes and their redemptions.
+type Service struct {
+	promoRepo      PromotionRepository
+	redemptionRepo RedemptionRepository
+	codeLocks      CodeLocks
+	mu             sync.Mutex // serializes redemptions without code locks, so concurrent bookings cannot exceed the usage limits
+}
+
+// NewService creates a new promotion service.
+func NewService(promoRepo PromotionRepository, redemptionRepo RedemptionRepository) *Service {
+	return &Service{
+		promoRepo:      promoRepo,
+		redemptionRepo: redemptionRepo,
+	}
+}
+
+// WithCodeLocks serializes the redemptions of each code with the locks instead of the service's mutex,
+// so bookings on several server instances cannot exceed the usage limits together.
+func (s *Service) WithCodeLocks(locks CodeLocks) *Service {
+	s.codeLocks = locks
+	return s
+}
+
+// Create adds a promo code with the terms.
+func (s *Service) Create(ctx context.Context, code string, terms Terms) (*Promotion, error) {
+	promo, err := NewPromotion(NormalizeCode(code), terms, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := s.promoRepo.Read(ctx, promo.Code); err == nil && existing != nil {
+		return nil, ErrCodeTaken
+	}
+	if err := s.promoRepo.Create(ctx, promo.Code, *promo); err != nil {
+		return nil, fmt.Errorf("failed to persist promotion: %w", err)
+	}
+	return promo, nil
+}
+
+// Deactivate withdraws a promo code; bookings already made with it keep their discount.
+func (s *Service) Deactivate(ctx context.Context, code string) (*Promotion, error) {
+	promo, err := s.promoRepo.Read(ctx, NormalizeCode(code))
+	if err != nil || promo == nil {
+		return nil, ErrPromotionNotFound
+	}
+	promo.Active = false
+	if err := s.promoRepo.Update(ctx, promo.Code, *promo); err != nil {
+		return nil, fmt.Errorf("failed to persist promotion: %w", err)
+	}
+	return promo, nil
+}
+
+// List returns all promo codes with their uses, sorted by code.
+func (s *Service) List(ctx context.Context) ([]Usage, error) {
+	promos, err := s.promoRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+	redemptions, err := s.redemptionRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redemptions: %w", err)
+	}
+	uses := make(map[Code]int)
+	for _, r := range redemptions {
+		uses[r.Code]++
+	}
+	slices.SortFunc(promos, func(a, b Promotion) int { return strings.Compare(string(a.Code), string(b.Code)) })
+	usages := make([]Usage, 0, len(promos))
+	for _, p := range promos {
+		usages = append(usages, Usage{Promotion: p, Uses: uses[p.Code]})
+	}
+	return usages, nil
+}
+
+// Validate checks that the guest may use the code now and returns the promotion with its discount on the price.
+// The reservation form calls it to show the discount before booking; Redeem checks again.
+func (s *Service) Validate(ctx context.Context, code string, guestID GuestID, price shared.Money) (*Promotion, shared.Money, error) {
+	promo, err := s.promoRepo.Read(ctx, NormalizeCode(code))
+	if err != nil || promo == nil {
+		return nil, shared.Money{}, ErrUnknownCode
+	}
+	if err := promo.CheckValidAt(time.Now()); err != nil {
+		return nil, shared.Money{}, err
+	}
+	if err := s.checkLimits(ctx, promo, guestID); err != nil {
+		return nil, shared.Money{}, err
+	}
+	discount, err := promo.DiscountOn(price)
+	if err != nil {
+		return nil, shared.Money{}, err
+	}
+	return promo, discount, nil
+}
+
+// Redeem applies the code to the booking of the reservation and records the use.
+// The discount of the redemption is the amount to take off the price.
+func (s *Service) Redeem(ctx context.Context, code string, guestID GuestID, reservationID ReservationID, price shared.Money) (*Redemption, error) {
+	unlock, err := s.lockCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if stored, err := s.redemptionRepo.Read(ctx, reservationID); err == nil && stored != nil {
+		return nil, ErrAlreadyApplied
+	}
+	promo, discount, err := s.Validate(ctx, code, guestID, price)
+	if err != nil {
+		return nil, err
+	}
+	redemption := Redemption{ReservationID: reservationID, Code: promo.Code, GuestID: guestID, Discount: discount, At: time.Now()}
+	if err := s.redemptionRepo.Create(ctx, reservationID, redemption); err != nil {
+		return nil, fmt.Errorf("failed to persist redemption: %w", err)
+	}
+	return &redemption, nil
+}
+
+// Release gives back the use of the code applied to the reservation, because its booking failed or was cancelled.
+// A reservation without a code returns nil.
+func (s *Service) Release(ctx context.Context, reservationID ReservationID) (*Redemption, error) {
+	redemption, err := s.redemptionRepo.Read(ctx, reservationID)
+	if err != nil || redemption == nil {
+		return nil, nil
+	}
+	unlock, err := s.lockCode(ctx, redemption.Code)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if err := s.redemptionRepo.Delete(ctx, reservationID); err != nil {
+		return nil, fmt.Errorf("failed to delete redemption: %w", err)
+	}
+	return redemption, nil
+}
+
+// lockCode locks the code with the code locks, or the service if none are configured,
+// and returns the function that releases it.
+func (s *Service) lockCode(ctx context.Context, code Code) (func(), error) {
+	if s.codeLocks == nil {
+		s.mu.Lock()
+		return s.mu.Unlock, nil
+	}
+	unlock, err := s.codeLocks.Lock(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock promo code %s: %w", code, err)
+	}
+	return unlock, nil
+}
+
+// checkLimits returns an error if the code or the guest used up their uses.
+func (s *Service) checkLimits(ctx context.Context, promo *Promotion, guestID GuestID) error {
+	if promo.Terms.MaxUses == 0 && promo.Terms.MaxUsesPerGuest == 0 {
+		return nil
+	}
+	redemptions, err := s.redemptionRepo.ReadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list redemptions: %w", err)
+	}
+	uses, guestUses := 0, 0
+	for _, r := range redemptions {
+		if r.Code != promo.Code {
+			continue
+		}
+		uses++
+		if r.GuestID == guestID {
+			guestUses++
+		}
+	}
+	if promo.Terms.MaxUses > 0 && uses >= promo.Terms.MaxUses {
+		return ErrUsageLimit
+	}
+	if promo.Terms.MaxUsesPerGuest > 0 && guestUses >= promo.Terms.MaxUsesPerGuest {
+		return ErrGuestUsageLimit
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package promotion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/promotion"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// mockCodeLocks records the locked codes and fails with err if set.
+type mockCodeLocks struct {
+	locked []promotion.Code
+	err    error
+}
+
+func (m *mockCodeLocks) Lock(ctx context.Context, code promotion.Code) (func(), error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.locked = append(m.locked, code)
+	return func() {}, nil
+}
+
+func createTestPromotionService() *promotion.Service {
+	return promotion.NewService(
+		resource.NewInMemoryAccess[promotion.Code, promotion.Promotion](),
+		resource.NewInMemoryAccess[promotion.ReservationID, promotion.Redemption](),
+	)
+}
+
+func usd(amount int64) shared.Money {
+	return shared.NewMoney(amount, "USD")
+}
+
+// ============================================================================
+// Create Tests
+// ============================================================================
+
+func Test_Service_Create_Existing_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "SUMMER25", promotion.Terms{Kind: promotion.KindPercent, Percent: 25})
+
+	// Act
+	_, err := svc.Create(ctx, " summer25 ", promotion.Terms{Kind: promotion.KindPercent, Percent: 10})
+
+	// Assert
+	assert.That(t, "err must be ErrCodeTaken", err, promotion.ErrCodeTaken)
+}
+
+// ============================================================================
+// Redeem Tests
+// ============================================================================
+
+func Test_Service_Redeem_Should_Record_Discount(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "SUMMER25", promotion.Terms{Kind: promotion.KindPercent, Percent: 25})
+
+	// Act
+	redemption, err := svc.Redeem(ctx, "summer25", "guest-1", "res-001", usd(20000))
+
+	// Assert
+	usages, _ := svc.List(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "code must be normalized", redemption.Code, promotion.Code("SUMMER25"))
+	assert.That(t, "discount must be recorded", redemption.Discount, usd(5000))
+	assert.That(t, "use must be counted", usages[0].Uses, 1)
+}
+
+func Test_Service_Redeem_Beyond_Usage_Limit_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "FIRST2", promotion.Terms{Kind: promotion.KindPercent, Percent: 10, MaxUses: 2})
+	_, _ = svc.Redeem(ctx, "FIRST2", "guest-1", "res-001", usd(20000))
+	_, _ = svc.Redeem(ctx, "FIRST2", "guest-2", "res-002", usd(20000))
+
+	// Act
+	_, err := svc.Redeem(ctx, "FIRST2", "guest-3", "res-003", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be ErrUsageLimit", err, promotion.ErrUsageLimit)
+}
+
+func Test_Service_Redeem_Beyond_Guest_Limit_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "ONCE", promotion.Terms{Kind: promotion.KindPercent, Percent: 10, MaxUsesPerGuest: 1})
+	_, _ = svc.Redeem(ctx, "ONCE", "guest-1", "res-001", usd(20000))
+
+	// Act
+	_, err := svc.Redeem(ctx, "ONCE", "guest-1", "res-002", usd(20000))
+	_, other := svc.Redeem(ctx, "ONCE", "guest-2", "res-003", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be ErrGuestUsageLimit", err, promotion.ErrGuestUsageLimit)
+	assert.That(t, "other guests must still use the code", other, nil)
+}
+
+func Test_Service_Redeem_Deactivated_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "SUMMER25", promotion.Terms{Kind: promotion.KindPercent, Percent: 25})
+	_, _ = svc.Deactivate(ctx, "SUMMER25")
+
+	// Act
+	_, err := svc.Redeem(ctx, "SUMMER25", "guest-1", "res-001", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be ErrInactive", err, promotion.ErrInactive)
+}
+
+func Test_Service_Redeem_Unknown_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+
+	// Act
+	_, err := svc.Redeem(context.Background(), "NOPE", "guest-1", "res-001", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownCode", err, promotion.ErrUnknownCode)
+}
+
+func Test_Service_Redeem_With_Code_Locks_Should_Lock_Normalized_Code(t *testing.T) {
+	// Arrange
+	locks := &mockCodeLocks{}
+	svc := createTestPromotionService().WithCodeLocks(locks)
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "FIRST2", promotion.Terms{Kind: promotion.KindPercent, Percent: 10, MaxUses: 2})
+
+	// Act
+	_, err := svc.Redeem(ctx, " first2 ", "guest-1", "res-001", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "code must be locked", locks.locked, []promotion.Code{"FIRST2"})
+}
+
+func Test_Service_Redeem_When_Code_Busy_Should_Not_Record_Use(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "FIRST2", promotion.Terms{Kind: promotion.KindPercent, Percent: 10, MaxUses: 2})
+	svc.WithCodeLocks(&mockCodeLocks{err: promotion.ErrCodeBusy})
+
+	// Act
+	_, err := svc.Redeem(ctx, "FIRST2", "guest-1", "res-001", usd(20000))
+
+	// Assert
+	usages, _ := svc.List(ctx)
+	assert.That(t, "err must be ErrCodeBusy", errors.Is(err, promotion.ErrCodeBusy), true)
+	assert.That(t, "use must not be counted", usages[0].Uses, 0)
+}
+
+// ============================================================================
+// Release Tests
+// ============================================================================
+
+func Test_Service_Release_Should_Give_Use_Back(t *testing.T) {
+	// Arrange
+	svc := createTestPromotionService()
+	ctx := context.Background()
+	_, _ = svc.Create(ctx, "ONLY1", promotion.Terms{Kind: promotion.KindPercent, Percent: 10, MaxUses: 1})
+	_, _ = svc.Redeem(ctx, "ONLY1", "guest-1", "res-001", usd(20000))
+
+	// Act
+	released, err := svc.Release(ctx, "res-001")
+	_, again := svc.Redeem(ctx, "ONLY1", "guest-2", "res-002", usd(20000))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "released redemption must be returned", released.ReservationID, promotion.ReservationID("res-001"))
+	assert.That(t, "code must be usable again", again, nil)
+}
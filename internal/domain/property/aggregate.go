@@ -0,0 +1,89 @@
+// Package property contains the Property bounded context.
+// A property is a hotel of the chain with its own name, address and timezone; it owns rooms.
+// Deployments with a single hotel have one property that owns every room.
+package property
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// PropertyID is shared because Reservation records the property of its room.
+type PropertyID = shared.PropertyID
+
+// Local ID types for this bounded context
+type RoomID string
+
+// DefaultPropertyID is the ID of the property of single-hotel deployments.
+const DefaultPropertyID PropertyID = "main"
+
+// Validation errors.
+var (
+	ErrNotFound          = errors.New("property not found")
+	ErrInvalidProperties = errors.New("invalid properties")
+	ErrRoomNotInProperty = errors.New("room does not belong to the property")
+)
+
+// Property is the aggregate root for a hotel of the chain.
+type Property struct {
+	ID       PropertyID `json:"id"`
+	Name     string     `json:"name"`
+	Address  string     `json:"address,omitempty"`
+	Timezone string     `json:"timezone"` // IANA name, e.g. Europe/Berlin; "Local" is the timezone of the server
+	Rooms    []RoomID   `json:"rooms,omitempty"`
+
+	location *time.Location
+}
+
+// Location returns the timezone of the property.
+func (p Property) Location() *time.Location {
+	if p.location == nil {
+		return time.Local
+	}
+	return p.location
+}
+
+// HasRoom reports whether the property lists the room.
+func (p Property) HasRoom(room RoomID) bool {
+	return slices.Contains(p.Rooms, room)
+}
+
+// ParseProperties parses "id=name|address|timezone|room room;..." entries,
+// e.g. "ber=Hotel Berlin|Unter den Linden 1, Berlin|Europe/Berlin|room-101 room-102".
+// Address, timezone and rooms are optional; the timezone defaults to UTC.
+func ParseProperties(s string) ([]Property, error) {
+	var properties []Property
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, value, ok := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: expected id=name|address|timezone|rooms: %q", ErrInvalidProperties, entry)
+		}
+		fields := strings.Split(value, "|")
+		if len(fields) > 4 {
+			return nil, fmt.Errorf("%w: property %q has more than name, address, timezone and rooms", ErrInvalidProperties, id)
+		}
+		fields = append(fields, make([]string, 4-len(fields))...)
+		p := Property{
+			ID:       PropertyID(id),
+			Name:     strings.TrimSpace(fields[0]),
+			Address:  strings.TrimSpace(fields[1]),
+			Timezone: cmp.Or(strings.TrimSpace(fields[2]), "UTC"),
+		}
+		for _, room := range strings.Fields(fields[3]) {
+			p.Rooms = append(p.Rooms, RoomID(room))
+		}
+		properties = append(properties, p)
+	}
+	return properties, nil
+}
@@ -0,0 +1,41 @@
+package property_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+)
+
+// ============================================================================
+// ParseProperties Tests
+// ============================================================================
+
+func Test_ParseProperties_Should_Parse_Name_Address_Timezone_And_Rooms(t *testing.T) {
+	// Arrange
+	s := "ber=Hotel Berlin|Unter den Linden 1, Berlin|Europe/Berlin|room-101 room-102; muc=Hotel Munich"
+
+	// Act
+	properties, err := property.ParseProperties(s)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "two properties must be parsed", len(properties), 2)
+	assert.That(t, "address must be parsed", properties[0].Address, "Unter den Linden 1, Berlin")
+	assert.That(t, "timezone must be parsed", properties[0].Timezone, "Europe/Berlin")
+	assert.That(t, "rooms must be parsed", properties[0].Rooms, []property.RoomID{"room-101", "room-102"})
+	assert.That(t, "name must be parsed", properties[1].Name, "Hotel Munich")
+	assert.That(t, "timezone must default to UTC", properties[1].Timezone, "UTC")
+}
+
+func Test_ParseProperties_With_Too_Many_Fields_Should_Return_Error(t *testing.T) {
+	// Arrange
+	s := "ber=Hotel Berlin|Unter den Linden 1|Europe/Berlin|room-101|extra"
+
+	// Act
+	_, err := property.ParseProperties(s)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidProperties", errors.Is(err, property.ErrInvalidProperties), true)
+}
@@ -0,0 +1,94 @@
+package property
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Catalog holds the properties of the chain. The first property is the default property:
+// it owns the rooms no property lists, so a single property without rooms owns every room.
+type Catalog struct {
+	properties []Property
+	byRoom     map[RoomID]PropertyID
+}
+
+// NewCatalog validates the properties and creates the catalog.
+// IDs must be unique, names and timezones valid, and a room listed by one property only.
+func NewCatalog(properties ...Property) (*Catalog, error) {
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("%w: at least one property required", ErrInvalidProperties)
+	}
+	c := &Catalog{byRoom: make(map[RoomID]PropertyID)}
+	for _, p := range properties {
+		if p.ID == "" || p.Name == "" {
+			return nil, fmt.Errorf("%w: property %q needs an ID and a name", ErrInvalidProperties, p.ID)
+		}
+		if slices.ContainsFunc(c.properties, func(other Property) bool { return other.ID == p.ID }) {
+			return nil, fmt.Errorf("%w: duplicate property %q", ErrInvalidProperties, p.ID)
+		}
+		location, err := time.LoadLocation(p.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: timezone of property %q: %w", ErrInvalidProperties, p.ID, err)
+		}
+		p.location = location
+		p.Rooms = slices.Clone(p.Rooms)
+		for _, room := range p.Rooms {
+			if owner, ok := c.byRoom[room]; ok {
+				return nil, fmt.Errorf("%w: room %q belongs to %q and %q", ErrInvalidProperties, room, owner, p.ID)
+			}
+			c.byRoom[room] = p.ID
+		}
+		c.properties = append(c.properties, p)
+	}
+	return c, nil
+}
+
+// Properties returns all properties, the default property first.
+func (c *Catalog) Properties() []Property {
+	return slices.Clone(c.properties)
+}
+
+// Default returns the default property.
+func (c *Catalog) Default() Property {
+	return c.properties[0]
+}
+
+// Property returns the property, or ErrNotFound.
+func (c *Catalog) Property(id PropertyID) (Property, error) {
+	for _, p := range c.properties {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return Property{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// PropertyOf returns the property that owns the room: the one listing it, or the default property.
+func (c *Catalog) PropertyOf(room RoomID) PropertyID {
+	if id, ok := c.byRoom[room]; ok {
+		return id
+	}
+	return c.Default().ID
+}
+
+// Location returns the timezone of the property, or of the default property if it is unknown,
+// e.g. for reservations made before their property was configured.
+func (c *Catalog) Location(id PropertyID) *time.Location {
+	p, err := c.Property(id)
+	if err != nil {
+		return c.Default().Location()
+	}
+	return p.Location()
+}
+
+// CheckRoom returns ErrRoomNotInProperty if the room does not belong to the property.
+func (c *Catalog) CheckRoom(id PropertyID, room RoomID) error {
+	if _, err := c.Property(id); err != nil {
+		return err
+	}
+	if c.PropertyOf(room) != id {
+		return fmt.Errorf("%w: %s is not in %s", ErrRoomNotInProperty, room, id)
+	}
+	return nil
+}
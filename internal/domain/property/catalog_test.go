@@ -0,0 +1,98 @@
+package property_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+// createTestCatalog creates ber (default, Europe/Berlin) and muc (room-201, room-202, America/New_York).
+func createTestCatalog(t *testing.T) *property.Catalog {
+	t.Helper()
+	catalog, err := property.NewCatalog(
+		property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "Europe/Berlin"},
+		property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "America/New_York", Rooms: []property.RoomID{"room-201", "room-202"}},
+	)
+	if err != nil {
+		t.Fatalf("failed to create catalog: %v", err)
+	}
+	return catalog
+}
+
+// ============================================================================
+// NewCatalog Tests
+// ============================================================================
+
+func Test_NewCatalog_With_Room_In_Two_Properties_Should_Return_Error(t *testing.T) {
+	// Arrange
+	ber := property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "UTC", Rooms: []property.RoomID{"room-101"}}
+	muc := property.Property{ID: "muc", Name: "Hotel Munich", Timezone: "UTC", Rooms: []property.RoomID{"room-101"}}
+
+	// Act
+	_, err := property.NewCatalog(ber, muc)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidProperties", errors.Is(err, property.ErrInvalidProperties), true)
+}
+
+func Test_NewCatalog_With_Unknown_Timezone_Should_Return_Error(t *testing.T) {
+	// Arrange
+	ber := property.Property{ID: "ber", Name: "Hotel Berlin", Timezone: "Europe/Atlantis"}
+
+	// Act
+	_, err := property.NewCatalog(ber)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidProperties", errors.Is(err, property.ErrInvalidProperties), true)
+}
+
+// ============================================================================
+// Catalog Tests
+// ============================================================================
+
+func Test_Catalog_PropertyOf_Should_Return_Default_For_Unlisted_Room(t *testing.T) {
+	// Arrange
+	catalog := createTestCatalog(t)
+
+	// Act
+	listed := catalog.PropertyOf("room-201")
+	unlisted := catalog.PropertyOf("room-101")
+
+	// Assert
+	assert.That(t, "listed room must belong to its property", listed, property.PropertyID("muc"))
+	assert.That(t, "unlisted room must belong to the default property", unlisted, property.PropertyID("ber"))
+}
+
+func Test_Catalog_CheckRoom_Should_Reject_Room_Of_Other_Property(t *testing.T) {
+	// Arrange
+	catalog := createTestCatalog(t)
+
+	// Act
+	errOwn := catalog.CheckRoom("muc", "room-201")
+	errOther := catalog.CheckRoom("muc", "room-101")
+	errUnknown := catalog.CheckRoom("ham", "room-101")
+
+	// Assert
+	assert.That(t, "own room must be accepted", errOwn, nil)
+	assert.That(t, "room of other property must be rejected", errors.Is(errOther, property.ErrRoomNotInProperty), true)
+	assert.That(t, "unknown property must not be found", errors.Is(errUnknown, property.ErrNotFound), true)
+}
+
+func Test_Catalog_Location_Should_Fall_Back_To_Default_Property(t *testing.T) {
+	// Arrange
+	catalog := createTestCatalog(t)
+
+	// Act
+	known := catalog.Location("muc")
+	unknown := catalog.Location("")
+
+	// Assert
+	assert.That(t, "known property must use its timezone", known.String(), "America/New_York")
+	assert.That(t, "unknown property must use the default timezone", unknown.String(), "Europe/Berlin")
+}
@@ -0,0 +1,32 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ScopeRead is the scope a service account needs to call the property tools.
+const ScopeRead = "properties:read"
+
+// RegisterTools registers all property MCP tools with the server.
+func RegisterTools(server *mcp.Server, catalog *Catalog) {
+	server.RegisterTool(shared.GuardTool(newListPropertiesTool(catalog), ScopeRead))
+}
+
+// newListPropertiesTool creates a tool for listing the properties and their rooms.
+func newListPropertiesTool(catalog *Catalog) mcp.Tool {
+	return mcp.NewTool(
+		"list_properties",
+		"List the properties (hotels) of the chain with name, address, timezone and rooms. Pass the property_id to list_reservations and check_availability_bulk to scope them to a property; rooms not listed belong to the first property.",
+		mcp.NewObjectSchema(map[string]mcp.Property{}, nil),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			data, _ := json.MarshalIndent(catalog.Properties(), "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
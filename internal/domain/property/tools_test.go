@@ -0,0 +1,34 @@
+package property_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/property"
+)
+
+// ============================================================================
+// ListPropertiesTool Tests
+// ============================================================================
+
+func Test_ListPropertiesTool_Should_Return_Properties_With_Rooms(t *testing.T) {
+	// Arrange
+	server := mcp.NewServer("test-server", "1.0.0")
+	property.RegisterTools(server, createTestCatalog(t))
+	tool := server.Tools()[0]
+	params := mcp.ToolsCallParams{Name: "list_properties", Arguments: map[string]any{}}
+
+	// Act
+	result, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	var properties []property.Property
+	_ = json.Unmarshal([]byte(result.Content[0].Text), &properties)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "tool must be list_properties", tool.Definition.Name, "list_properties")
+	assert.That(t, "both properties must be listed", len(properties), 2)
+	assert.That(t, "rooms must be listed", properties[1].Rooms, []property.RoomID{"room-201", "room-202"})
+}
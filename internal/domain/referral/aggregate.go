@@ -0,0 +1,162 @@
+// Package referral contains the Referral bounded context.
+// Guests share a referral code; a new guest who books with the code is attributed to the referrer,
+// who earns a reward (account credit or points) once the referred stay is completed.
+package referral
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type Code string
+type ReferralID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// ReservationID is the shared identifier of the referred booking.
+type ReservationID = shared.ReservationID
+
+// ReferralStatus represents the state of a referral.
+type ReferralStatus string
+
+const (
+	StatusPending ReferralStatus = "pending" // referred booking made, stay not completed yet
+	StatusEarned  ReferralStatus = "earned"  // stay completed, reward issued
+	StatusVoid    ReferralStatus = "void"    // referred booking cancelled
+)
+
+// RewardKind is the kind of reward a referrer earns.
+type RewardKind string
+
+const (
+	RewardCredit RewardKind = "credit" // account credit for a future stay
+	RewardPoints RewardKind = "points" // loyalty points
+)
+
+// ErrInvalidRewardKind is returned for unknown reward kinds.
+var ErrInvalidRewardKind = errors.New("reward kind must be credit or points")
+
+// ParseRewardKind validates a reward kind name.
+func ParseRewardKind(value string) (RewardKind, error) {
+	switch kind := RewardKind(value); kind {
+	case RewardCredit, RewardPoints:
+		return kind, nil
+	default:
+		return "", ErrInvalidRewardKind
+	}
+}
+
+// Reward is the value object of a referral reward.
+type Reward struct {
+	Kind   RewardKind
+	Credit shared.Money
+	Points int
+}
+
+// codeAlphabet omits letters and digits that are easily confused (0/O, 1/I/L).
+const codeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// codeLength makes codes short enough to read out, with 31^8 possible codes.
+const codeLength = 8
+
+// ReferralCode is the aggregate root for the code a guest shares. There is one code per guest.
+type ReferralCode struct {
+	Code      Code
+	GuestID   GuestID
+	Email     string
+	CreatedAt time.Time
+}
+
+// Referral is the aggregate root for a booking made with a referral code.
+// Its ID is derived from the reservation, so a booking is attributed at most once.
+type Referral struct {
+	ID            ReferralID
+	Code          Code
+	ReferrerID    GuestID
+	RefereeID     GuestID
+	ReservationID ReservationID
+	Status        ReferralStatus
+	Reward        Reward
+	CreatedAt     time.Time
+	EarnedAt      time.Time
+	VoidReason    string
+}
+
+// Validation errors.
+var (
+	ErrUnknownCode        = errors.New("unknown referral code")
+	ErrSelfReferral       = errors.New("guests cannot refer themselves")
+	ErrAlreadyReferred    = errors.New("guest was already referred")
+	ErrReferralLimit      = errors.New("referrer reached the referral limit")
+	ErrNotNewGuest        = errors.New("referral codes are for first bookings only")
+	ErrReferralNotPending = errors.New("referral is not pending")
+)
+
+// NewCode generates a random referral code.
+// Random bytes beyond the largest multiple of the alphabet size are skipped, so every letter is equally likely.
+func NewCode() Code {
+	limit := byte(256 - 256%len(codeAlphabet))
+	code := make([]byte, 0, codeLength)
+	buf := make([]byte, codeLength)
+	for len(code) < codeLength {
+		_, _ = rand.Read(buf)
+		for _, b := range buf {
+			if b < limit && len(code) < codeLength {
+				code = append(code, codeAlphabet[int(b)%len(codeAlphabet)])
+			}
+		}
+	}
+	return Code(code)
+}
+
+// NormalizeCode uppercases the code and strips spaces and dashes, so guests can type it loosely.
+func NormalizeCode(value string) Code {
+	value = strings.ToUpper(value)
+	value = strings.NewReplacer(" ", "", "-", "").Replace(value)
+	return Code(value)
+}
+
+// ReferralIDFor returns the referral ID of a reservation.
+func ReferralIDFor(reservationID ReservationID) ReferralID {
+	return ReferralID("ref-" + string(reservationID))
+}
+
+// NewReferral creates a pending referral of the reservation.
+func NewReferral(code *ReferralCode, refereeID GuestID, reservationID ReservationID, at time.Time) *Referral {
+	return &Referral{
+		ID:            ReferralIDFor(reservationID),
+		Code:          code.Code,
+		ReferrerID:    code.GuestID,
+		RefereeID:     refereeID,
+		ReservationID: reservationID,
+		Status:        StatusPending,
+		CreatedAt:     at,
+	}
+}
+
+// Earn issues the reward after the referred stay was completed.
+func (r *Referral) Earn(reward Reward, at time.Time) error {
+	if r.Status != StatusPending {
+		return ErrReferralNotPending
+	}
+	r.Status = StatusEarned
+	r.Reward = reward
+	r.EarnedAt = at
+	return nil
+}
+
+// Void cancels the referral, e.g. because the referred booking was cancelled.
+func (r *Referral) Void(reason string) error {
+	if r.Status != StatusPending {
+		return ErrReferralNotPending
+	}
+	r.Status = StatusVoid
+	r.VoidReason = reason
+	return nil
+}
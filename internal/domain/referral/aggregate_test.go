@@ -0,0 +1,66 @@
+package referral_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Code Tests
+// ============================================================================
+
+func Test_NewCode_Should_Use_Unambiguous_Alphabet(t *testing.T) {
+	// Act
+	code := referral.NewCode()
+
+	// Assert
+	assert.That(t, "code must have 8 characters", len(code), 8)
+	assert.That(t, "code must not contain ambiguous characters", strings.ContainsAny(string(code), "01ILO"), false)
+}
+
+func Test_NormalizeCode_Should_Ignore_Case_Spaces_And_Dashes(t *testing.T) {
+	// Act
+	code := referral.NormalizeCode("abcd-ef 23")
+
+	// Assert
+	assert.That(t, "code must be normalized", code, referral.Code("ABCDEF23"))
+}
+
+// ============================================================================
+// Referral Tests
+// ============================================================================
+
+func Test_Referral_Earn_Should_Issue_Reward(t *testing.T) {
+	// Arrange
+	code := &referral.ReferralCode{Code: "ABCDEF23", GuestID: "guest-a"}
+	r := referral.NewReferral(code, "guest-b", "res-001", time.Now())
+	reward := referral.Reward{Kind: referral.RewardCredit, Credit: shared.NewMoney(2500, "EUR")}
+
+	// Act
+	err := r.Earn(reward, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "status must be earned", r.Status, referral.StatusEarned)
+	assert.That(t, "reward must be recorded", r.Reward.Credit.Amount, int64(2500))
+	assert.That(t, "id must be derived from reservation", r.ID, referral.ReferralID("ref-res-001"))
+}
+
+func Test_Referral_Earn_When_Void_Should_Fail(t *testing.T) {
+	// Arrange
+	code := &referral.ReferralCode{Code: "ABCDEF23", GuestID: "guest-a"}
+	r := referral.NewReferral(code, "guest-b", "res-001", time.Now())
+	_ = r.Void("reservation cancelled")
+
+	// Act
+	err := r.Earn(referral.Reward{Kind: referral.RewardPoints, Points: 500}, time.Now())
+
+	// Assert
+	assert.That(t, "err must be not pending", err, referral.ErrReferralNotPending)
+	assert.That(t, "status must stay void", r.Status, referral.StatusVoid)
+}
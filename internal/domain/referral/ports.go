@@ -0,0 +1,23 @@
+package referral
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// CodeRepository provides CRUD operations for referral codes, keyed by code.
+type CodeRepository resource.Access[Code, ReferralCode]
+
+// ReferralRepository provides CRUD operations for referrals.
+type ReferralRepository resource.Access[ReferralID, Referral]
+
+// BookingHistory lists the reservations of a guest, so referral codes only count for new guests.
+type BookingHistory interface {
+	BookingsOf(ctx context.Context, guestID GuestID) ([]ReservationID, error)
+}
+
+// RewardNotifier tells the referrer about an earned reward.
+type RewardNotifier interface {
+	SendReferralReward(ctx context.Context, code *ReferralCode, r *Referral) error
+}
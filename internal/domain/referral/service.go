@@ -0,0 +1,233 @@
+package referral
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// LimitWindow is the rolling window of the referral limit per referrer.
+const LimitWindow = 30 * 24 * time.Hour
+
+// Policy defines the reward of a completed referral and the anti-abuse limit.
+type Policy struct {
+	Reward       Reward
+	MonthlyLimit int // referrals per referrer within LimitWindow; 0 is unlimited
+}
+
+// DefaultPolicy returns the default reward and limit.
+func DefaultPolicy() Policy {
+	return Policy{
+		Reward:       Reward{Kind: RewardCredit, Credit: shared.NewMoney(2500, "USD")},
+		MonthlyLimit: 5,
+	}
+}
+
+// Dashboard summarizes the referrals of a guest.
+type Dashboard struct {
+	Code         Code
+	Referrals    []Referral // newest first
+	Pending      int
+	Earned       int
+	EarnedCredit shared.Money
+	EarnedPoints int
+}
+
+// Service handles referral workflows.
+type Service struct {
+	codeRepo     CodeRepository
+	referralRepo ReferralRepository
+	history      BookingHistory
+	notifier     RewardNotifier
+	policy       Policy
+	mu           sync.RWMutex // guards policy, which can be reloaded at runtime
+}
+
+// NewService creates a new referral service.
+func NewService(codeRepo CodeRepository, referralRepo ReferralRepository, history BookingHistory, notifier RewardNotifier, policy Policy) *Service {
+	return &Service{
+		codeRepo:     codeRepo,
+		referralRepo: referralRepo,
+		history:      history,
+		notifier:     notifier,
+		policy:       policy,
+	}
+}
+
+// SetPolicy replaces the reward and limit policy at runtime.
+// Rewards are fixed when a referral is earned, so earned referrals keep their reward.
+func (s *Service) SetPolicy(policy Policy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+}
+
+// currentPolicy returns the policy in effect.
+func (s *Service) currentPolicy() Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// CodeFor returns the referral code of the guest, creating it on first use.
+func (s *Service) CodeFor(ctx context.Context, guestID GuestID, email string) (*ReferralCode, error) {
+	codes, err := s.codeRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referral codes: %w", err)
+	}
+	for i := range codes {
+		if codes[i].GuestID == guestID {
+			return &codes[i], nil
+		}
+	}
+
+	// Retry on the unlikely collision with an existing code.
+	for range 3 {
+		code := &ReferralCode{Code: NewCode(), GuestID: guestID, Email: email, CreatedAt: time.Now()}
+		if err := s.codeRepo.Create(ctx, code.Code, *code); err == nil {
+			return code, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to persist referral code")
+}
+
+// Validate checks that the guest may book with the code, before the booking is made.
+func (s *Service) Validate(ctx context.Context, code Code, refereeID GuestID, email string) (*ReferralCode, error) {
+	return s.validate(ctx, code, refereeID, email, "")
+}
+
+// Attribute records that the reservation was booked with the referral code.
+// It applies the same checks as Validate; the reservation itself does not count as earlier booking.
+func (s *Service) Attribute(ctx context.Context, code Code, reservationID ReservationID, refereeID GuestID, email string) (*Referral, error) {
+	referralCode, err := s.validate(ctx, code, refereeID, email, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	referral := NewReferral(referralCode, refereeID, reservationID, time.Now())
+	if err := s.referralRepo.Create(ctx, referral.ID, *referral); err != nil {
+		return nil, fmt.Errorf("failed to persist referral: %w", err)
+	}
+	return referral, nil
+}
+
+// validate applies the anti-abuse rules: no self-referral, one referral per guest,
+// first bookings only and a rolling limit per referrer.
+func (s *Service) validate(ctx context.Context, code Code, refereeID GuestID, email string, reservationID ReservationID) (*ReferralCode, error) {
+	referralCode, err := s.codeRepo.Read(ctx, NormalizeCode(string(code)))
+	if err != nil {
+		return nil, ErrUnknownCode
+	}
+	if referralCode.GuestID == refereeID || (email != "" && strings.EqualFold(referralCode.Email, email)) {
+		return nil, ErrSelfReferral
+	}
+
+	bookings, err := s.history.BookingsOf(ctx, refereeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookings: %w", err)
+	}
+	for _, id := range bookings {
+		if id != reservationID {
+			return nil, ErrNotNewGuest
+		}
+	}
+
+	referrals, err := s.referralRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrals: %w", err)
+	}
+	recent := 0
+	since := time.Now().Add(-LimitWindow)
+	for _, r := range referrals {
+		if r.Status == StatusVoid {
+			continue
+		}
+		if r.RefereeID == refereeID {
+			return nil, ErrAlreadyReferred
+		}
+		if r.ReferrerID == referralCode.GuestID && r.CreatedAt.After(since) {
+			recent++
+		}
+	}
+	if policy := s.currentPolicy(); policy.MonthlyLimit > 0 && recent >= policy.MonthlyLimit {
+		return nil, ErrReferralLimit
+	}
+	return referralCode, nil
+}
+
+// CompleteStay issues the reward of the referral of the completed reservation, if there is one.
+// It is idempotent, because the reservation.completed event may be delivered more than once.
+func (s *Service) CompleteStay(ctx context.Context, reservationID ReservationID) (*Referral, error) {
+	referral, err := s.referralRepo.Read(ctx, ReferralIDFor(reservationID))
+	if err != nil || referral.Status != StatusPending {
+		return nil, nil
+	}
+
+	if err := referral.Earn(s.currentPolicy().Reward, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.referralRepo.Update(ctx, referral.ID, *referral); err != nil {
+		return nil, fmt.Errorf("failed to update referral: %w", err)
+	}
+
+	code, err := s.codeRepo.Read(ctx, referral.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read referral code: %w", err)
+	}
+	if err := s.notifier.SendReferralReward(ctx, code, referral); err != nil {
+		return nil, fmt.Errorf("failed to send referral reward: %w", err)
+	}
+	return referral, nil
+}
+
+// VoidReservation voids the pending referral of a cancelled reservation, if there is one.
+func (s *Service) VoidReservation(ctx context.Context, reservationID ReservationID, reason string) error {
+	referral, err := s.referralRepo.Read(ctx, ReferralIDFor(reservationID))
+	if err != nil || referral.Status != StatusPending {
+		return nil
+	}
+	if err := referral.Void(reason); err != nil {
+		return err
+	}
+	if err := s.referralRepo.Update(ctx, referral.ID, *referral); err != nil {
+		return fmt.Errorf("failed to update referral: %w", err)
+	}
+	return nil
+}
+
+// Dashboard returns the referral code of the guest with the pending and earned rewards.
+func (s *Service) Dashboard(ctx context.Context, guestID GuestID, email string) (*Dashboard, error) {
+	code, err := s.CodeFor(ctx, guestID, email)
+	if err != nil {
+		return nil, err
+	}
+	referrals, err := s.referralRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrals: %w", err)
+	}
+
+	dashboard := &Dashboard{Code: code.Code}
+	for _, r := range referrals {
+		if r.ReferrerID != guestID {
+			continue
+		}
+		dashboard.Referrals = append(dashboard.Referrals, r)
+		switch r.Status {
+		case StatusPending:
+			dashboard.Pending++
+		case StatusEarned:
+			dashboard.Earned++
+			dashboard.EarnedPoints += r.Reward.Points
+			if r.Reward.Credit.Amount > 0 {
+				dashboard.EarnedCredit = shared.NewMoney(dashboard.EarnedCredit.Amount+r.Reward.Credit.Amount, r.Reward.Credit.Currency)
+			}
+		}
+	}
+	slices.SortFunc(dashboard.Referrals, func(a, b Referral) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return dashboard, nil
+}
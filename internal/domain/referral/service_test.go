@@ -0,0 +1,203 @@
+package referral_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/referral"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockBookingHistory struct {
+	bookings map[referral.GuestID][]referral.ReservationID
+}
+
+func (m *mockBookingHistory) BookingsOf(ctx context.Context, guestID referral.GuestID) ([]referral.ReservationID, error) {
+	return m.bookings[guestID], nil
+}
+
+type mockRewardNotifier struct {
+	sent int
+}
+
+func (m *mockRewardNotifier) SendReferralReward(ctx context.Context, code *referral.ReferralCode, r *referral.Referral) error {
+	m.sent++
+	return nil
+}
+
+func createTestReferralService(history *mockBookingHistory, notifier *mockRewardNotifier, limit int) *referral.Service {
+	return referral.NewService(
+		resource.NewInMemoryAccess[referral.Code, referral.ReferralCode](),
+		resource.NewInMemoryAccess[referral.ReferralID, referral.Referral](),
+		history,
+		notifier,
+		referral.Policy{
+			Reward:       referral.Reward{Kind: referral.RewardCredit, Credit: shared.NewMoney(2500, "EUR")},
+			MonthlyLimit: limit,
+		},
+	)
+}
+
+func newHistory() *mockBookingHistory {
+	return &mockBookingHistory{bookings: map[referral.GuestID][]referral.ReservationID{}}
+}
+
+// ============================================================================
+// Code Tests
+// ============================================================================
+
+func Test_Service_CodeFor_Should_Return_Same_Code(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	first, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+
+	// Act
+	second, err := svc.CodeFor(ctx, "guest-a", "a@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "code must be stable", second.Code, first.Code)
+}
+
+// ============================================================================
+// Attribution Tests
+// ============================================================================
+
+func Test_Service_Validate_With_Unknown_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 0)
+
+	// Act
+	_, err := svc.Validate(context.Background(), "NOPE2345", "guest-b", "b@example.com")
+
+	// Assert
+	assert.That(t, "err must be unknown code", err, referral.ErrUnknownCode)
+}
+
+func Test_Service_Validate_With_Own_Code_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+
+	// Act
+	_, errSubject := svc.Validate(ctx, code.Code, "guest-a", "other@example.com")
+	_, errEmail := svc.Validate(ctx, code.Code, "guest-x", "A@example.com")
+
+	// Assert
+	assert.That(t, "same subject must be self-referral", errSubject, referral.ErrSelfReferral)
+	assert.That(t, "same email must be self-referral", errEmail, referral.ErrSelfReferral)
+}
+
+func Test_Service_Attribute_Returning_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	history := newHistory()
+	history.bookings["guest-b"] = []referral.ReservationID{"res-old", "res-new"}
+	svc := createTestReferralService(history, &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+
+	// Act
+	_, err := svc.Attribute(ctx, code.Code, "res-new", "guest-b", "b@example.com")
+
+	// Assert
+	assert.That(t, "err must be not new guest", err, referral.ErrNotNewGuest)
+}
+
+func Test_Service_Attribute_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	history := newHistory()
+	history.bookings["guest-b"] = []referral.ReservationID{"res-001"}
+	svc := createTestReferralService(history, &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+
+	// Act
+	_, err := svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+
+	// Assert
+	assert.That(t, "err must be already referred", err, referral.ErrAlreadyReferred)
+}
+
+func Test_Service_Attribute_Over_Limit_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 1)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+
+	// Act
+	_, err := svc.Attribute(ctx, code.Code, "res-002", "guest-c", "c@example.com")
+
+	// Assert
+	assert.That(t, "err must be referral limit", err, referral.ErrReferralLimit)
+}
+
+// ============================================================================
+// Reward Tests
+// ============================================================================
+
+func Test_Service_CompleteStay_Should_Earn_Reward_Once(t *testing.T) {
+	// Arrange
+	notifier := &mockRewardNotifier{}
+	svc := createTestReferralService(newHistory(), notifier, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+
+	// Act
+	earned, err := svc.CompleteStay(ctx, "res-001")
+	again, _ := svc.CompleteStay(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "referral must be earned", earned.Status, referral.StatusEarned)
+	assert.That(t, "second completion must be a no-op", again == nil, true)
+	assert.That(t, "referrer must be notified once", notifier.sent, 1)
+}
+
+func Test_Service_VoidReservation_Should_Free_The_Referee(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+
+	// Act
+	err := svc.VoidReservation(ctx, "res-001", "reservation cancelled")
+	earned, _ := svc.CompleteStay(ctx, "res-001")
+	_, errValidate := svc.Validate(ctx, code.Code, "guest-b", "b@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "void referral must not earn", earned == nil, true)
+	assert.That(t, "referee may use a code again", errValidate == nil, true)
+}
+
+func Test_Service_Dashboard_Should_Sum_Rewards(t *testing.T) {
+	// Arrange
+	svc := createTestReferralService(newHistory(), &mockRewardNotifier{}, 0)
+	ctx := context.Background()
+	code, _ := svc.CodeFor(ctx, "guest-a", "a@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-001", "guest-b", "b@example.com")
+	_, _ = svc.Attribute(ctx, code.Code, "res-002", "guest-c", "c@example.com")
+	_, _ = svc.CompleteStay(ctx, "res-001")
+
+	// Act
+	dashboard, err := svc.Dashboard(ctx, "guest-a", "a@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "code must be shown", dashboard.Code, code.Code)
+	assert.That(t, "one referral must be pending", dashboard.Pending, 1)
+	assert.That(t, "one referral must be earned", dashboard.Earned, 1)
+	assert.That(t, "earned credit must be summed", dashboard.EarnedCredit.Amount, int64(2500))
+}
@@ -0,0 +1,261 @@
+// Package report contains the Report bounded context.
+// Managers subscribe to the occupancy or revenue report of a property, which is emailed to them
+// on a schedule, e.g. every Monday morning for the past week.
+package report
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type SubscriptionID string
+
+// PropertyID is shared because the reports cover the reservations of a property.
+type PropertyID = shared.PropertyID
+
+// Type is the report a subscription sends.
+type Type string
+
+const (
+	TypeOccupancy Type = "occupancy" // rooms sold and occupancy per night
+	TypeRevenue   Type = "revenue"   // room revenue, ADR and RevPAR per night
+)
+
+// Types are the report types in the order they are offered.
+var Types = []Type{TypeOccupancy, TypeRevenue}
+
+// Frequency is how often a subscription sends its report; each report covers the period since the last one.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"   // the previous day
+	FrequencyWeekly  Frequency = "weekly"  // the previous seven days
+	FrequencyMonthly Frequency = "monthly" // the previous month
+)
+
+// Frequencies are the frequencies in the order they are offered.
+var Frequencies = []Frequency{FrequencyDaily, FrequencyWeekly, FrequencyMonthly}
+
+// Format is how the report is delivered.
+type Format string
+
+const (
+	FormatHTML Format = "html" // a table in the email
+	FormatCSV  Format = "csv"  // attached as CSV file
+	FormatXLSX Format = "xlsx" // attached as Excel workbook
+)
+
+// Formats are the formats in the order they are offered.
+var Formats = []Format{FormatHTML, FormatCSV, FormatXLSX}
+
+// Validation errors.
+var (
+	ErrInvalidType          = errors.New("report type must be occupancy or revenue")
+	ErrInvalidFormat        = errors.New("report format must be html, csv or xlsx")
+	ErrInvalidSchedule      = errors.New("schedule must be daily, weekly on a weekday or monthly on a day from 1 to 28, at an hour from 0 to 23")
+	ErrInvalidRecipient     = errors.New("recipient must be an email address")
+	ErrNoRecipients         = errors.New("subscription needs at least one recipient")
+	ErrMissingProperty      = errors.New("subscription needs a property")
+	ErrSubscriptionNotFound = errors.New("report subscription not found")
+	ErrNotSubscribed        = errors.New("not subscribed to the report")
+)
+
+// Schedule is when a report is sent, in the timezone of its property: every day, every week on
+// the weekday or every month on the day, at the full hour.
+type Schedule struct {
+	Frequency Frequency
+	Weekday   time.Weekday // of weekly reports
+	Day       int          // of the month of monthly reports, 1 to 28 so every month has it
+	Hour      int          // 0 to 23
+}
+
+// Validate checks the frequency and the fields it uses.
+func (s Schedule) Validate() error {
+	if s.Hour < 0 || s.Hour > 23 {
+		return ErrInvalidSchedule
+	}
+	switch s.Frequency {
+	case FrequencyDaily:
+	case FrequencyWeekly:
+		if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+			return ErrInvalidSchedule
+		}
+	case FrequencyMonthly:
+		if s.Day < 1 || s.Day > 28 {
+			return ErrInvalidSchedule
+		}
+	default:
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// Next returns the first time of the schedule after after, in the location.
+func (s Schedule) Next(after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	// Every valid schedule matches a day within a month and a day.
+	for range 32 {
+		at := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, 0, 0, 0, loc)
+		if at.After(after) && s.matches(day) {
+			return at
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return after.AddDate(0, 0, 1)
+}
+
+// matches reports whether the schedule sends on the day.
+func (s Schedule) matches(day time.Time) bool {
+	switch s.Frequency {
+	case FrequencyWeekly:
+		return day.Weekday() == s.Weekday
+	case FrequencyMonthly:
+		return day.Day() == s.Day
+	default:
+		return true
+	}
+}
+
+// Period returns the nights a report sent at runAt covers, from the first night to the day of
+// the run (exclusive) in the location: the previous day, seven days or month.
+func (s Schedule) Period(runAt time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := runAt.In(loc)
+	to := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	switch s.Frequency {
+	case FrequencyWeekly:
+		return to.AddDate(0, 0, -7), to
+	case FrequencyMonthly:
+		return to.AddDate(0, -1, 0), to
+	default:
+		return to.AddDate(0, 0, -1), to
+	}
+}
+
+// String describes the schedule, e.g. "weekly on Monday at 08:00".
+func (s Schedule) String() string {
+	at := fmt.Sprintf(" at %02d:00", s.Hour)
+	switch s.Frequency {
+	case FrequencyWeekly:
+		return "weekly on " + s.Weekday.String() + at
+	case FrequencyMonthly:
+		return "monthly on day " + strconv.Itoa(s.Day) + at
+	default:
+		return "daily" + at
+	}
+}
+
+// Recipient is an email address a report is sent to, with the token of its unsubscribe link.
+type Recipient struct {
+	Email string
+	Token string // random, so the link needs no signature and never expires
+}
+
+// Subscription is the aggregate root for a report emailed to managers on a schedule.
+type Subscription struct {
+	ID         SubscriptionID
+	PropertyID PropertyID
+	Type       Type
+	Schedule   Schedule
+	Format     Format
+	Recipients []Recipient
+	CreatedAt  time.Time
+	LastSentAt time.Time // zero until the first report was sent
+	NextRunAt  time.Time // when the next report is due
+}
+
+// NewSubscription creates a subscription whose first report is due at the next time of the
+// schedule after at, in the timezone of the property.
+func NewSubscription(id SubscriptionID, propertyID PropertyID, reportType Type, schedule Schedule, format Format, recipients []string, loc *time.Location, at time.Time) (*Subscription, error) {
+	if propertyID == "" {
+		return nil, ErrMissingProperty
+	}
+	if !slices.Contains(Types, reportType) {
+		return nil, ErrInvalidType
+	}
+	if !slices.Contains(Formats, format) {
+		return nil, ErrInvalidFormat
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	sub := &Subscription{ID: id, PropertyID: propertyID, Type: reportType, Schedule: schedule, Format: format, CreatedAt: at}
+	for _, email := range recipients {
+		email, err := normalizeEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		if !sub.HasRecipient(email) {
+			sub.Recipients = append(sub.Recipients, Recipient{Email: email, Token: newToken()})
+		}
+	}
+	if len(sub.Recipients) == 0 {
+		return nil, ErrNoRecipients
+	}
+	sub.NextRunAt = schedule.Next(at, loc)
+	return sub, nil
+}
+
+// HasRecipient reports whether the report is sent to the email.
+func (s *Subscription) HasRecipient(email string) bool {
+	return slices.ContainsFunc(s.Recipients, func(r Recipient) bool { return r.Email == strings.ToLower(email) })
+}
+
+// Recipient returns the recipient of the unsubscribe token.
+func (s *Subscription) Recipient(token string) (Recipient, bool) {
+	i := slices.IndexFunc(s.Recipients, func(r Recipient) bool { return r.Token == token })
+	if i < 0 {
+		return Recipient{}, false
+	}
+	return s.Recipients[i], true
+}
+
+// Unsubscribe stops sending the report to the recipient of the token.
+func (s *Subscription) Unsubscribe(token string) error {
+	i := slices.IndexFunc(s.Recipients, func(r Recipient) bool { return r.Token == token })
+	if i < 0 {
+		return ErrNotSubscribed
+	}
+	// Clone first: the slice may be shared with the stored subscription.
+	s.Recipients = slices.Delete(slices.Clone(s.Recipients), i, i+1)
+	return nil
+}
+
+// IsDue reports whether the next report is due at now.
+func (s *Subscription) IsDue(now time.Time) bool {
+	return !s.NextRunAt.After(now)
+}
+
+// MarkSent records a report sent at at and schedules the next one. Runs missed while the
+// server was down are skipped, so managers get one report of the latest period, not a backlog.
+func (s *Subscription) MarkSent(at time.Time, loc *time.Location) {
+	s.LastSentAt = at
+	s.NextRunAt = s.Schedule.Next(at, loc)
+}
+
+// normalizeEmail returns the lower-cased address, or ErrInvalidRecipient if it is not a plain address.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidRecipient
+	}
+	return email, nil
+}
+
+// newToken returns a random unsubscribe token.
+func newToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
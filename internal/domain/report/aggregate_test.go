@@ -0,0 +1,186 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+var mondayMorning = report.Schedule{Frequency: report.FrequencyWeekly, Weekday: time.Monday, Hour: 8}
+
+// ============================================================================
+// Schedule Tests
+// ============================================================================
+
+func Test_Schedule_Next_Should_Return_Next_Weekday_In_Location(t *testing.T) {
+	// Arrange
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	wednesday := time.Date(2026, 7, 8, 12, 0, 0, 0, berlin)
+
+	// Act
+	next := mondayMorning.Next(wednesday, berlin)
+
+	// Assert
+	assert.That(t, "next run must be Monday 08:00 in Berlin", next.Equal(time.Date(2026, 7, 13, 8, 0, 0, 0, berlin)), true)
+}
+
+func Test_Schedule_Next_Should_Skip_The_Hour_That_Passed(t *testing.T) {
+	// Arrange
+	schedule := report.Schedule{Frequency: report.FrequencyDaily, Hour: 8}
+	at := time.Date(2026, 7, 8, 8, 0, 0, 0, time.UTC)
+
+	// Act
+	next := schedule.Next(at, time.UTC)
+
+	// Assert
+	assert.That(t, "next run must be the next day", next, time.Date(2026, 7, 9, 8, 0, 0, 0, time.UTC))
+}
+
+func Test_Schedule_Next_Monthly_Should_Return_The_Day_Of_Next_Month(t *testing.T) {
+	// Arrange
+	schedule := report.Schedule{Frequency: report.FrequencyMonthly, Day: 1, Hour: 6}
+
+	// Act
+	next := schedule.Next(time.Date(2026, 7, 8, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	// Assert
+	assert.That(t, "next run must be the first of August", next, time.Date(2026, 8, 1, 6, 0, 0, 0, time.UTC))
+}
+
+func Test_Schedule_Period_Should_Cover_The_Previous_Week(t *testing.T) {
+	// Act
+	from, to := mondayMorning.Period(time.Date(2026, 7, 13, 8, 0, 0, 0, time.UTC), time.UTC)
+
+	// Assert
+	assert.That(t, "period must start the Monday before", from, time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "period must end on the day of the run", to, time.Date(2026, 7, 13, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_Schedule_Validate_Should_Reject_Invalid_Schedules(t *testing.T) {
+	for _, schedule := range []report.Schedule{
+		{Frequency: "hourly"},
+		{Frequency: report.FrequencyDaily, Hour: 24},
+		{Frequency: report.FrequencyWeekly, Weekday: 7},
+		{Frequency: report.FrequencyMonthly, Day: 31},
+	} {
+		assert.That(t, "schedule "+schedule.String()+" must be invalid", schedule.Validate(), report.ErrInvalidSchedule)
+	}
+}
+
+func Test_Schedule_String_Should_Describe_Schedule(t *testing.T) {
+	assert.That(t, "weekly schedule must be described", mondayMorning.String(), "weekly on Monday at 08:00")
+}
+
+// ============================================================================
+// Subscription Tests
+// ============================================================================
+
+func Test_NewSubscription_Should_Normalize_And_Dedupe_Recipients(t *testing.T) {
+	// Arrange
+	at := time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	sub, err := report.NewSubscription("sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatCSV,
+		[]string{" Manager@Example.com", "manager@example.com", "owner@example.com"}, time.UTC, at)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "duplicates must be dropped", len(sub.Recipients), 2)
+	assert.That(t, "email must be lower-cased", sub.Recipients[0].Email, "manager@example.com")
+	assert.That(t, "recipients must have distinct tokens", sub.Recipients[0].Token != sub.Recipients[1].Token, true)
+	assert.That(t, "first report must be due next Monday", sub.NextRunAt, time.Date(2026, 7, 13, 8, 0, 0, 0, time.UTC))
+}
+
+func Test_NewSubscription_Should_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		property   report.PropertyID
+		reportType report.Type
+		format     report.Format
+		recipients []string
+		err        error
+	}{
+		{"", report.TypeOccupancy, report.FormatHTML, []string{"a@example.com"}, report.ErrMissingProperty},
+		{"main", "bookings", report.FormatHTML, []string{"a@example.com"}, report.ErrInvalidType},
+		{"main", report.TypeRevenue, "pdf", []string{"a@example.com"}, report.ErrInvalidFormat},
+		{"main", report.TypeRevenue, report.FormatHTML, []string{"Manager <a@example.com>"}, report.ErrInvalidRecipient},
+		{"main", report.TypeRevenue, report.FormatHTML, nil, report.ErrNoRecipients},
+	} {
+		// Act
+		_, err := report.NewSubscription("sub-1", tc.property, tc.reportType, mondayMorning, tc.format, tc.recipients, time.UTC, time.Now())
+
+		// Assert
+		assert.That(t, "err must be "+tc.err.Error(), err, tc.err)
+	}
+}
+
+func Test_Subscription_MarkSent_Should_Skip_Missed_Runs(t *testing.T) {
+	// Arrange
+	sub, _ := report.NewSubscription("sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatHTML, []string{"a@example.com"}, time.UTC, time.Date(2026, 7, 8, 0, 0, 0, 0, time.UTC))
+	sentAt := time.Date(2026, 7, 22, 9, 0, 0, 0, time.UTC)
+
+	// Act
+	sub.MarkSent(sentAt, time.UTC)
+
+	// Assert
+	assert.That(t, "last sent must be recorded", sub.LastSentAt, sentAt)
+	assert.That(t, "next run must follow the sent time", sub.NextRunAt, time.Date(2026, 7, 27, 8, 0, 0, 0, time.UTC))
+	assert.That(t, "report must not be due before the next run", sub.IsDue(sentAt), false)
+}
+
+func Test_Subscription_Unsubscribe_With_Unknown_Token_Should_Fail(t *testing.T) {
+	// Arrange
+	sub, _ := report.NewSubscription("sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatHTML, []string{"a@example.com"}, time.UTC, time.Now())
+
+	// Act
+	err := sub.Unsubscribe("unknown")
+
+	// Assert
+	assert.That(t, "err must be ErrNotSubscribed", err, report.ErrNotSubscribed)
+	assert.That(t, "recipient must be kept", len(sub.Recipients), 1)
+}
+
+// ============================================================================
+// Build Tests
+// ============================================================================
+
+func Test_Build_Should_Spread_Revenue_Over_Nights(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC)
+	stays := []report.Stay{
+		{RoomID: "101", CheckIn: from, CheckOut: from.AddDate(0, 0, 3), Revenue: shared.NewMoney(30001, "EUR")},
+		{RoomID: "101", CheckIn: from, CheckOut: from.AddDate(0, 0, 1), Revenue: shared.NewMoney(5000, "EUR")},
+		{RoomID: "102", CheckIn: from, CheckOut: from.AddDate(0, 0, 1), Revenue: shared.NewMoney(9000, "USD")},
+	}
+
+	// Act
+	r := report.Build(report.TypeRevenue, "main", stays, 4, "EUR", from, from.AddDate(0, 0, 2))
+
+	// Assert
+	assert.That(t, "period must have two nights", len(r.Nights), 2)
+	assert.That(t, "first night must carry the remainder and the second stay", r.Nights[0].Revenue.Amount, int64(15001))
+	assert.That(t, "double-booked room must count once", r.Nights[0].Sold, 1)
+	assert.That(t, "occupancy must be a percent of the rooms", r.Nights[0].Occupancy, 25)
+	assert.That(t, "second night must carry its share", r.Nights[1].Revenue.Amount, int64(10000))
+	assert.That(t, "nights outside the period must be left out", r.Total.Revenue.Amount, int64(25001))
+	assert.That(t, "ADR must divide by rooms sold", r.Total.ADR.Amount, int64(12500))
+	assert.That(t, "RevPAR must divide by rooms available", r.Total.RevPAR.Amount, int64(3125))
+	assert.That(t, "stay in another currency must be skipped", r.Skipped, 1)
+}
+
+func Test_Report_Table_Should_End_With_Total_Row(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC)
+	r := report.Build(report.TypeOccupancy, "main", nil, 2, "EUR", from, from.AddDate(0, 0, 1))
+
+	// Act
+	columns, rows := r.Table()
+
+	// Assert
+	assert.That(t, "occupancy columns must be returned", len(columns), 4)
+	assert.That(t, "night row must come first", rows[0], []string{"2026-07-06", "2", "0", "0"})
+	assert.That(t, "total row must come last", rows[1][0], "Total")
+	assert.That(t, "title must name the period", r.Title(), "Occupancy report of main, 2026-07-06 to 2026-07-06")
+}
@@ -0,0 +1,24 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// SubscriptionRepository provides CRUD operations for report subscriptions.
+type SubscriptionRepository resource.Access[SubscriptionID, Subscription]
+
+// Stays provides the booked stays and the rooms of a property.
+type Stays interface {
+	// Stays returns the stays of the property with a night from from to to (exclusive).
+	Stays(ctx context.Context, propertyID PropertyID, from, to time.Time) ([]Stay, error)
+	// Rooms returns the number of rooms of the property.
+	Rooms(propertyID PropertyID) int
+}
+
+// Locations provides the timezones of the properties, which the schedules follow.
+type Locations interface {
+	Location(propertyID PropertyID) *time.Location
+}
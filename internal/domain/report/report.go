@@ -0,0 +1,165 @@
+package report
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Money is shared because the revenue comes from the reservation amounts.
+type Money = shared.Money
+
+// Stay is a booked stay of a property, with the revenue of the room in the currency of record.
+// Cancelled reservations are not stays.
+type Stay struct {
+	RoomID   string
+	CheckIn  time.Time
+	CheckOut time.Time
+	Revenue  Money
+}
+
+// Night is the occupancy and revenue of a property on a night, or of a whole period.
+type Night struct {
+	Date      time.Time // zero for the total of the period
+	Rooms     int       // rooms available, times the nights for a period
+	Sold      int       // room nights booked
+	Occupancy int       // percent of the rooms sold
+	Revenue   Money     // room revenue earned on the night
+	ADR       Money     // average daily rate: revenue per room sold
+	RevPAR    Money     // revenue per available room
+}
+
+// Report is the occupancy or revenue of a property for the nights from From to To (exclusive).
+type Report struct {
+	Type       Type
+	PropertyID PropertyID
+	From       time.Time
+	To         time.Time
+	Nights     []Night
+	Total      Night
+	Skipped    int // stays in another currency without an exchange rate
+}
+
+// Build aggregates the stays of a property with the rooms for the nights from from to to (exclusive).
+// The revenue of a stay is spread evenly over its nights; the remainder of the division goes to the
+// first night, so the nights add up to the amount. Stays in another currency are skipped and counted.
+func Build(reportType Type, propertyID PropertyID, stays []Stay, rooms int, currency string, from, to time.Time) Report {
+	report := Report{Type: reportType, PropertyID: propertyID, From: from, To: to}
+	index := make(map[time.Time]int)
+	for night := from; night.Before(to); night = night.AddDate(0, 0, 1) {
+		index[night] = len(report.Nights)
+		report.Nights = append(report.Nights, Night{Date: night, Rooms: rooms, Revenue: shared.NewMoney(0, currency)})
+	}
+	booked := make(map[time.Time]map[string]bool)
+	for _, stay := range stays {
+		if stay.Revenue.Currency != currency {
+			report.Skipped++
+			continue
+		}
+		checkIn, checkOut := date(stay.CheckIn), date(stay.CheckOut)
+		nights := int(checkOut.Sub(checkIn).Hours() / 24)
+		if nights <= 0 {
+			continue
+		}
+		perNight := stay.Revenue.Amount / int64(nights)
+		for night := checkIn; night.Before(checkOut); night = night.AddDate(0, 0, 1) {
+			i, ok := index[night]
+			if !ok {
+				continue
+			}
+			amount := perNight
+			if night.Equal(checkIn) {
+				amount += stay.Revenue.Amount % int64(nights)
+			}
+			report.Nights[i].Revenue.Amount += amount
+			// A double-booked room counts once, like in the capacity report.
+			if booked[night] == nil {
+				booked[night] = make(map[string]bool)
+			}
+			if !booked[night][stay.RoomID] {
+				booked[night][stay.RoomID] = true
+				report.Nights[i].Sold++
+			}
+		}
+	}
+
+	report.Total = Night{Revenue: shared.NewMoney(0, currency)}
+	for i := range report.Nights {
+		report.Nights[i].rate()
+		report.Total.Rooms += report.Nights[i].Rooms
+		report.Total.Sold += report.Nights[i].Sold
+		report.Total.Revenue.Amount += report.Nights[i].Revenue.Amount
+	}
+	report.Total.rate()
+	return report
+}
+
+// rate computes the occupancy, ADR and RevPAR from the rooms, sold rooms and revenue.
+func (n *Night) rate() {
+	n.ADR = shared.NewMoney(0, n.Revenue.Currency)
+	n.RevPAR = shared.NewMoney(0, n.Revenue.Currency)
+	if n.Rooms > 0 {
+		n.Occupancy = n.Sold * 100 / n.Rooms
+		n.RevPAR.Amount = n.Revenue.Amount / int64(n.Rooms)
+	}
+	if n.Sold > 0 {
+		n.ADR.Amount = n.Revenue.Amount / int64(n.Sold)
+	}
+}
+
+// Column is a column of the report table.
+type Column struct {
+	Name    string
+	Numeric bool
+}
+
+// Table returns the columns of the report type and a row per night, followed by the total row.
+// Amounts are decimals without currency symbol, so spreadsheets can sum them.
+func (r Report) Table() ([]Column, [][]string) {
+	var columns []Column
+	row := func(label string, n Night) []string {
+		switch r.Type {
+		case TypeRevenue:
+			return []string{label, strconv.Itoa(n.Sold), n.Revenue.Decimal(), n.ADR.Decimal(), n.RevPAR.Decimal()}
+		default:
+			return []string{label, strconv.Itoa(n.Rooms), strconv.Itoa(n.Sold), strconv.Itoa(n.Occupancy)}
+		}
+	}
+	switch r.Type {
+	case TypeRevenue:
+		currency := r.Total.Revenue.Currency
+		columns = []Column{{Name: "Night"}, {Name: "Rooms Sold", Numeric: true}, {Name: "Revenue (" + currency + ")", Numeric: true},
+			{Name: "ADR (" + currency + ")", Numeric: true}, {Name: "RevPAR (" + currency + ")", Numeric: true}}
+	default:
+		columns = []Column{{Name: "Night"}, {Name: "Rooms", Numeric: true}, {Name: "Rooms Sold", Numeric: true}, {Name: "Occupancy (%)", Numeric: true}}
+	}
+	rows := make([][]string, 0, len(r.Nights)+1)
+	for _, n := range r.Nights {
+		rows = append(rows, row(n.Date.Format(time.DateOnly), n))
+	}
+	rows = append(rows, row("Total", r.Total))
+	return columns, rows
+}
+
+// File is the report table rendered in the csv or xlsx format of a subscription, attached to its email.
+type File struct {
+	Name        string // e.g. "occupancy-main-2026-07-06.csv"
+	ContentType string
+	Content     []byte
+}
+
+// Title returns the title of the report, e.g. "Occupancy report of main, 2026-07-06 to 2026-07-12".
+func (r Report) Title() string {
+	name := "Occupancy"
+	if r.Type == TypeRevenue {
+		name = "Revenue"
+	}
+	return name + " report of " + string(r.PropertyID) + ", " + r.From.Format(time.DateOnly) + " to " + r.To.AddDate(0, 0, -1).Format(time.DateOnly)
+}
+
+// date returns the calendar day of t in UTC, like the dates of reservations.
+func date(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
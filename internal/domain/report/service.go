@@ -0,0 +1,137 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Service handles the report subscriptions and builds their reports.
+type Service struct {
+	repo      SubscriptionRepository
+	stays     Stays
+	locations Locations
+	currency  string
+}
+
+// NewService creates a new report service. Revenue is reported in the currency of record.
+func NewService(repo SubscriptionRepository, stays Stays, locations Locations, currency string) *Service {
+	return &Service{repo: repo, stays: stays, locations: locations, currency: strings.ToUpper(currency)}
+}
+
+// Subscribe creates a subscription to the report of the property.
+func (s *Service) Subscribe(ctx context.Context, id SubscriptionID, propertyID PropertyID, reportType Type, schedule Schedule, format Format, recipients []string) (*Subscription, error) {
+	sub, err := NewSubscription(id, propertyID, reportType, schedule, format, recipients, s.locations.Location(propertyID), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, sub.ID, *sub); err != nil {
+		return nil, fmt.Errorf("failed to persist report subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// List returns the subscriptions of the property, oldest first; an empty property lists all.
+func (s *Service) List(ctx context.Context, propertyID PropertyID) ([]Subscription, error) {
+	subs, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report subscriptions: %w", err)
+	}
+	subs = slices.DeleteFunc(subs, func(sub Subscription) bool { return propertyID != "" && sub.PropertyID != propertyID })
+	slices.SortFunc(subs, func(a, b Subscription) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return subs, nil
+}
+
+// Get returns the subscription, or ErrSubscriptionNotFound.
+func (s *Service) Get(ctx context.Context, id SubscriptionID) (*Subscription, error) {
+	sub, err := s.repo.Read(ctx, id)
+	if err != nil {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// Delete deletes the subscription.
+func (s *Service) Delete(ctx context.Context, id SubscriptionID) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete report subscription: %w", err)
+	}
+	return nil
+}
+
+// ByToken returns the subscription and recipient of the unsubscribe token, or ErrNotSubscribed.
+func (s *Service) ByToken(ctx context.Context, token string) (*Subscription, Recipient, error) {
+	if token == "" {
+		return nil, Recipient{}, ErrNotSubscribed
+	}
+	subs, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, Recipient{}, fmt.Errorf("failed to list report subscriptions: %w", err)
+	}
+	for _, sub := range subs {
+		if recipient, ok := sub.Recipient(token); ok {
+			return &sub, recipient, nil
+		}
+	}
+	return nil, Recipient{}, ErrNotSubscribed
+}
+
+// Unsubscribe stops sending the report to the recipient of the token.
+// The subscription is deleted with its last recipient.
+func (s *Service) Unsubscribe(ctx context.Context, token string) (*Subscription, Recipient, error) {
+	sub, recipient, err := s.ByToken(ctx, token)
+	if err != nil {
+		return nil, Recipient{}, err
+	}
+	if err := sub.Unsubscribe(token); err != nil {
+		return nil, Recipient{}, err
+	}
+	if len(sub.Recipients) == 0 {
+		err = s.repo.Delete(ctx, sub.ID)
+	} else {
+		err = s.repo.Update(ctx, sub.ID, *sub)
+	}
+	if err != nil {
+		return nil, Recipient{}, fmt.Errorf("failed to persist report subscription: %w", err)
+	}
+	return sub, recipient, nil
+}
+
+// Due returns the subscriptions whose next report is due at now.
+func (s *Service) Due(ctx context.Context, now time.Time) ([]Subscription, error) {
+	subs, err := s.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(subs, func(sub Subscription) bool { return !sub.IsDue(now) }), nil
+}
+
+// Build builds the report the subscription sends at its next run.
+// The period follows the scheduled time, so a run delayed past midnight still reports the intended period.
+func (s *Service) Build(ctx context.Context, sub Subscription) (*Report, error) {
+	from, to := sub.Schedule.Period(sub.NextRunAt, s.locations.Location(sub.PropertyID))
+	stays, err := s.stays.Stays(ctx, sub.PropertyID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stays: %w", err)
+	}
+	report := Build(sub.Type, sub.PropertyID, stays, s.stays.Rooms(sub.PropertyID), s.currency, from, to)
+	return &report, nil
+}
+
+// MarkSent records that the report of the subscription was sent at at and schedules the next one.
+func (s *Service) MarkSent(ctx context.Context, id SubscriptionID, at time.Time) error {
+	sub, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	sub.MarkSent(at, s.locations.Location(sub.PropertyID))
+	if err := s.repo.Update(ctx, sub.ID, *sub); err != nil {
+		return fmt.Errorf("failed to persist report subscription: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package report_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/report"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// mockStays returns the stays it was created with and records the requested period.
+type mockStays struct {
+	stays    []report.Stay
+	from, to time.Time
+}
+
+func (m *mockStays) Stays(ctx context.Context, propertyID report.PropertyID, from, to time.Time) ([]report.Stay, error) {
+	m.from, m.to = from, to
+	return m.stays, nil
+}
+
+func (m *mockStays) Rooms(propertyID report.PropertyID) int { return 10 }
+
+type utcLocations struct{}
+
+func (utcLocations) Location(propertyID report.PropertyID) *time.Location { return time.UTC }
+
+func createTestReportService() (*report.Service, *mockStays) {
+	stays := &mockStays{}
+	return report.NewService(resource.NewInMemoryAccess[report.SubscriptionID, report.Subscription](), stays, utcLocations{}, "eur"), stays
+}
+
+// ============================================================================
+// Subscribe Tests
+// ============================================================================
+
+func Test_Service_List_Should_Scope_To_Property(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService()
+	ctx := context.Background()
+	_, _ = svc.Subscribe(ctx, "sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatHTML, []string{"a@example.com"})
+	_, _ = svc.Subscribe(ctx, "sub-2", "annex", report.TypeRevenue, mondayMorning, report.FormatCSV, []string{"b@example.com"})
+
+	// Act
+	subs, err := svc.List(ctx, "annex")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the subscription of the property must be listed", len(subs), 1)
+	assert.That(t, "subscription must be sub-2", subs[0].ID, report.SubscriptionID("sub-2"))
+}
+
+func Test_Service_Delete_Unknown_Subscription_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService()
+
+	// Act
+	err := svc.Delete(context.Background(), "sub-404")
+
+	// Assert
+	assert.That(t, "err must be ErrSubscriptionNotFound", err, report.ErrSubscriptionNotFound)
+}
+
+// ============================================================================
+// Unsubscribe Tests
+// ============================================================================
+
+func Test_Service_Unsubscribe_Should_Delete_Subscription_With_Last_Recipient(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService()
+	ctx := context.Background()
+	sub, _ := svc.Subscribe(ctx, "sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatHTML, []string{"a@example.com", "b@example.com"})
+
+	// Act
+	_, first, err1 := svc.Unsubscribe(ctx, sub.Recipients[0].Token)
+	_, _, err2 := svc.Unsubscribe(ctx, sub.Recipients[1].Token)
+
+	// Assert
+	assert.That(t, "first unsubscribe must succeed", err1, nil)
+	assert.That(t, "recipient of the token must be returned", first.Email, "a@example.com")
+	assert.That(t, "second unsubscribe must succeed", err2, nil)
+	subs, _ := svc.List(ctx, "")
+	assert.That(t, "subscription without recipients must be deleted", len(subs), 0)
+	_, _, err := svc.Unsubscribe(ctx, sub.Recipients[0].Token)
+	assert.That(t, "used token must fail", err, report.ErrNotSubscribed)
+}
+
+// ============================================================================
+// Due and Build Tests
+// ============================================================================
+
+func Test_Service_Build_Should_Report_Period_Of_Scheduled_Run(t *testing.T) {
+	// Arrange
+	svc, stays := createTestReportService()
+	ctx := context.Background()
+	sub, _ := svc.Subscribe(ctx, "sub-1", "main", report.TypeRevenue, mondayMorning, report.FormatHTML, []string{"a@example.com"})
+	stays.stays = []report.Stay{{RoomID: "101", CheckIn: sub.NextRunAt.AddDate(0, 0, -2), CheckOut: sub.NextRunAt, Revenue: shared.NewMoney(20000, "EUR")}}
+	late := sub.NextRunAt.Add(20 * time.Hour)
+
+	// Act
+	due, _ := svc.Due(ctx, late)
+	r, err := svc.Build(ctx, due[0])
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "subscription must be due", len(due), 1)
+	assert.That(t, "period must end on the scheduled day", stays.to.Weekday(), time.Monday)
+	assert.That(t, "period must be a week", len(r.Nights), 7)
+	assert.That(t, "revenue must be in the currency of record", r.Total.Revenue, shared.NewMoney(20000, "EUR"))
+	assert.That(t, "rooms must be the rooms of the property", r.Total.Rooms, 70)
+}
+
+func Test_Service_MarkSent_Should_Schedule_Next_Run(t *testing.T) {
+	// Arrange
+	svc, _ := createTestReportService()
+	ctx := context.Background()
+	sub, _ := svc.Subscribe(ctx, "sub-1", "main", report.TypeOccupancy, mondayMorning, report.FormatHTML, []string{"a@example.com"})
+
+	// Act
+	err := svc.MarkSent(ctx, sub.ID, sub.NextRunAt)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	due, _ := svc.Due(ctx, sub.NextRunAt)
+	assert.That(t, "sent subscription must not be due", len(due), 0)
+}
@@ -6,6 +6,8 @@ package reservation
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/andygeiss/hotel-booking/internal/domain/shared"
@@ -14,8 +16,14 @@ import (
 // Type aliases for shared types
 type ReservationID = shared.ReservationID
 type Money = shared.Money
+type FXSnapshot = shared.FXSnapshot
+type PropertyID = shared.PropertyID
 
 // Local ID types for this bounded context
+
+// GuestID identifies the guest account that owns a reservation.
+// It is the stable OIDC subject ("sub" claim), not the email address,
+// because the identity provider may change a user's email.
 type GuestID string
 type RoomID string
 
@@ -28,13 +36,16 @@ const (
 	StatusActive    ReservationStatus = "active"
 	StatusCompleted ReservationStatus = "completed"
 	StatusCancelled ReservationStatus = "cancelled"
+	StatusNoShow    ReservationStatus = "no_show"
 )
 
 // Reservation is the aggregate root for booking reservations.
 type Reservation struct {
 	ID                 ReservationID
 	GuestID            GuestID
+	GuestEmail         string // contact email for display only; ownership is decided by GuestID
 	RoomID             RoomID
+	PropertyID         PropertyID // property of the room; empty for reservations made before properties were configured
 	DateRange          DateRange
 	Status             ReservationStatus
 	TotalAmount        Money
@@ -42,19 +53,45 @@ type Reservation struct {
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 	Guests             []GuestInfo
+	Shares             []ShareGrant
+	Perks              Perks
+	History            []StatusChange
+	FX                 *FXSnapshot       // rate to the currency of record at booking time; nil if not recorded
+	EmergencyContact   *EmergencyContact // nil if the guest gave none
+	ArrivalTime        string            // estimated arrival on the check-in day as HH:MM; empty if unknown
+	BusinessCustomer   *BusinessCustomer // company the stay is billed to; nil for private guests
+	ConfirmationNumber string            // number of the property's numbering scheme, e.g. BER-2025-00123; empty if none was configured
+	RoomPreferences    RoomPreferences   // weighted room location preferences of the guest; nil if none were given
+	PreferencesMet     []RoomPreference  // preferences the room fulfills, scored at booking; nil without room allocation
+	Overstays          []Overstay        // check-out days the guest stayed past check-out time, oldest first
 }
 
 // Validation errors.
 var (
-	ErrInvalidDateRange        = errors.New("check-out must be after check-in")
-	ErrCheckInPast             = errors.New("check-in date must be in the future")
-	ErrMinimumStay             = errors.New("minimum stay is 1 night")
-	ErrInvalidStateTransition  = errors.New("invalid state transition")
-	ErrCannotCancelNearCheckIn = errors.New("cannot cancel within 24 hours of check-in")
-	ErrCannotCancelActive      = errors.New("cannot cancel active reservation")
-	ErrCannotCancelCompleted   = errors.New("cannot cancel completed reservation")
-	ErrAlreadyCancelled        = errors.New("reservation already cancelled")
-	ErrNoGuests                = errors.New("at least one guest required")
+	ErrInvalidDateRange           = errors.New("check-out must be after check-in")
+	ErrCheckInPast                = errors.New("check-in date must be in the future")
+	ErrMinimumStay                = errors.New("minimum stay is 1 night")
+	ErrInvalidStateTransition     = errors.New("invalid state transition")
+	ErrCannotCancelNearCheckIn    = errors.New("cannot cancel within 24 hours of check-in")
+	ErrCannotCancelActive         = errors.New("cannot cancel active reservation")
+	ErrCannotCancelCompleted      = errors.New("cannot cancel completed reservation")
+	ErrAlreadyCancelled           = errors.New("reservation already cancelled")
+	ErrNoGuests                   = errors.New("at least one guest required")
+	ErrInvalidShareRole           = errors.New("share role must be view or manage")
+	ErrInvalidShareEmail          = errors.New("share email required")
+	ErrShareNotFound              = errors.New("share grant not found")
+	ErrShareAlreadyAccepted       = errors.New("share grant already accepted by another guest")
+	ErrCannotShareWithOwner       = errors.New("cannot share reservation with its owner")
+	ErrInvalidDiscount            = errors.New("discount must be between 0 and 100 percent")
+	ErrInvalidRedemption          = errors.New("redeemed points cannot have a negative value")
+	ErrInvalidPromotion           = errors.New("promo code discount cannot be negative")
+	ErrRoomNotAvailable           = errors.New("room is not available for the selected dates")
+	ErrExchangeRateUnavailable    = errors.New("no exchange rate to the currency of record")
+	ErrRoomBusy                   = errors.New("room is being booked by another request, try again")
+	ErrIncompleteEmergencyContact = errors.New("emergency contact needs a name and a phone number")
+	ErrInvalidArrivalTime         = errors.New("arrival time must be HH:MM")
+	ErrMissingCompany             = errors.New("VAT ID needs the name of the company")
+	ErrInvalidVATID               = errors.New("VAT ID does not match the format of its country")
 )
 
 // NewReservation creates a new reservation with validation.
@@ -70,6 +107,10 @@ func NewReservation(id ReservationID, guestID GuestID, roomID RoomID, dateRange
 		UpdatedAt:   time.Now(),
 		Guests:      guests,
 	}
+	if len(guests) > 0 {
+		r.GuestEmail = guests[0].Email
+	}
+	r.recordStatusChange("", "")
 
 	if err := r.validate(); err != nil {
 		return nil, err
@@ -84,8 +125,10 @@ func (r *Reservation) Confirm() error {
 		return fmt.Errorf("%w: cannot confirm from %s", ErrInvalidStateTransition, r.Status)
 	}
 
+	from := r.Status
 	r.Status = StatusConfirmed
 	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, "")
 	return nil
 }
 
@@ -95,8 +138,10 @@ func (r *Reservation) Activate() error {
 		return fmt.Errorf("%w: cannot activate from %s", ErrInvalidStateTransition, r.Status)
 	}
 
+	from := r.Status
 	r.Status = StatusActive
 	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, "")
 	return nil
 }
 
@@ -106,8 +151,10 @@ func (r *Reservation) Complete() error {
 		return fmt.Errorf("%w: cannot complete from %s", ErrInvalidStateTransition, r.Status)
 	}
 
+	from := r.Status
 	r.Status = StatusCompleted
 	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, "")
 	return nil
 }
 
@@ -129,28 +176,221 @@ func (r *Reservation) Cancel(reason string) error {
 		return ErrCannotCancelNearCheckIn
 	}
 
+	from := r.Status
+	r.Status = StatusCancelled
+	r.CancellationReason = reason
+	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, reason)
+	return nil
+}
+
+// Revoke cancels the reservation as the compensation of a failed payment or confirmation.
+// Unlike Cancel it ignores the notice period and also cancels active stays, because they were not paid.
+func (r *Reservation) Revoke(reason string) error {
+	switch r.Status {
+	case StatusCancelled:
+		return ErrAlreadyCancelled
+	case StatusCompleted:
+		return ErrCannotCancelCompleted
+	case StatusNoShow:
+		return fmt.Errorf("%w: cannot revoke from %s", ErrInvalidStateTransition, r.Status)
+	}
+
+	from := r.Status
 	r.Status = StatusCancelled
 	r.CancellationReason = reason
 	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, reason)
 	return nil
 }
 
+// MarkNoShow transitions the reservation from confirmed to no-show, because the guest did not check in.
+func (r *Reservation) MarkNoShow() error {
+	if r.Status != StatusConfirmed {
+		return fmt.Errorf("%w: cannot mark no-show from %s", ErrInvalidStateTransition, r.Status)
+	}
+
+	from := r.Status
+	r.Status = StatusNoShow
+	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, "")
+	return nil
+}
+
+// Expire cancels a pending reservation whose payment never arrived.
+// Unlike Cancel it ignores the notice period, since the room was never paid for.
+func (r *Reservation) Expire(reason string) error {
+	if r.Status != StatusPending {
+		return fmt.Errorf("%w: cannot expire from %s", ErrInvalidStateTransition, r.Status)
+	}
+
+	from := r.Status
+	r.Status = StatusCancelled
+	r.CancellationReason = reason
+	r.UpdatedAt = time.Now()
+	r.recordStatusChange(from, reason)
+	return nil
+}
+
+// ApplyPerks records the perks of the guest's VIP tier and deducts the discount from the total amount,
+// then the discount of the promo code and the value of the redeemed loyalty points, neither of which
+// can take off more than is left to pay.
+// Perks can only be applied to a pending reservation, i.e. before payment is authorized.
+func (r *Reservation) ApplyPerks(perks Perks) error {
+	if r.Status != StatusPending {
+		return ErrInvalidStateTransition
+	}
+	if perks.DiscountPercent < 0 || perks.DiscountPercent > 100 {
+		return ErrInvalidDiscount
+	}
+	if perks.Redemption.Amount < 0 {
+		return ErrInvalidRedemption
+	}
+	if perks.Promotion.Discount.Amount < 0 {
+		return ErrInvalidPromotion
+	}
+	perks.Discount = perks.DiscountOn(r.TotalAmount)
+	payable := r.TotalAmount.Amount - perks.Discount.Amount
+	if perks.Promotion.Code != "" {
+		perks.Promotion.Discount = shared.NewMoney(min(perks.Promotion.Discount.Amount, payable), r.TotalAmount.Currency)
+		payable -= perks.Promotion.Discount.Amount
+	}
+	perks.Redemption = shared.NewMoney(min(perks.Redemption.Amount, payable), r.TotalAmount.Currency)
+	r.TotalAmount = shared.NewMoney(payable-perks.Redemption.Amount, r.TotalAmount.Currency)
+	r.Perks = perks
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetArrivalDetails records the emergency contact, the estimated arrival time and the room preferences given by the guest.
+func (r *Reservation) SetArrivalDetails(details ArrivalDetails) {
+	r.EmergencyContact = details.EmergencyContact
+	r.ArrivalTime = details.ArrivalTime
+	r.RoomPreferences = details.RoomPreferences
+	r.BusinessCustomer = details.BusinessCustomer
+	r.UpdatedAt = time.Now()
+}
+
+// CancellationNoticePeriod is how long before check-in a reservation can be cancelled at the latest.
+const CancellationNoticePeriod = 24 * time.Hour
+
 // CanBeCancelled checks if the reservation can be cancelled based on business rules.
+// The notice period ends CancellationNoticePeriod before the check-in day begins at the property.
 func (r *Reservation) CanBeCancelled() bool {
-	if r.Status == StatusCancelled || r.Status == StatusCompleted || r.Status == StatusActive {
+	if r.Status == StatusCancelled || r.Status == StatusCompleted || r.Status == StatusActive || r.Status == StatusNoShow {
 		return false
 	}
 
-	now := time.Now()
-	hoursUntilCheckIn := r.DateRange.CheckIn.Sub(now).Hours()
-	return hoursUntilCheckIn >= 24
+	return !shared.Now().After(r.CancellationDeadline())
+}
+
+// CancellationDeadline returns the latest time the reservation can be cancelled, in the timezone of the property.
+func (r *Reservation) CancellationDeadline() time.Time {
+	return r.DateRange.CheckInStart().Add(-CancellationNoticePeriod)
+}
+
+// IsOwnedBy checks if the reservation belongs to the guest account.
+func (r *Reservation) IsOwnedBy(guestID GuestID) bool {
+	return guestID != "" && r.GuestID == guestID
+}
+
+// CanView checks if the guest account owns the reservation or holds an accepted share grant.
+func (r *Reservation) CanView(guestID GuestID) bool {
+	_, ok := r.shareRole(guestID)
+	return r.IsOwnedBy(guestID) || ok
+}
+
+// CanManage checks if the guest account owns the reservation or holds an accepted manage grant.
+func (r *Reservation) CanManage(guestID GuestID) bool {
+	role, ok := r.shareRole(guestID)
+	return r.IsOwnedBy(guestID) || (ok && role == ShareRoleManage)
+}
+
+// Share invites a co-traveler by email. Sharing again with the same email changes the role.
+func (r *Reservation) Share(email string, role ShareRole) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return ErrInvalidShareEmail
+	}
+	if role != ShareRoleView && role != ShareRoleManage {
+		return ErrInvalidShareRole
+	}
+	if strings.EqualFold(email, r.GuestEmail) {
+		return ErrCannotShareWithOwner
+	}
+
+	if i := r.shareIndex(email); i >= 0 {
+		r.Shares[i].Role = role
+	} else {
+		r.Shares = append(r.Shares, ShareGrant{Email: email, Role: role, CreatedAt: time.Now()})
+	}
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// AcceptShare binds the share grant of the invited email to the accepting guest account.
+func (r *Reservation) AcceptShare(email string, guestID GuestID) error {
+	i := r.shareIndex(email)
+	if i < 0 {
+		return ErrShareNotFound
+	}
+	if r.IsOwnedBy(guestID) {
+		return ErrCannotShareWithOwner
+	}
+
+	grant := &r.Shares[i]
+	if grant.IsAccepted() {
+		if grant.GuestID == guestID {
+			return nil
+		}
+		return ErrShareAlreadyAccepted
+	}
+	grant.GuestID = guestID
+	grant.AcceptedAt = time.Now()
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// RevokeShare removes the share grant of the email.
+func (r *Reservation) RevokeShare(email string) error {
+	i := r.shareIndex(email)
+	if i < 0 {
+		return ErrShareNotFound
+	}
+	r.Shares = slices.Delete(r.Shares, i, i+1)
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// shareIndex returns the index of the share grant of the email, or -1.
+func (r *Reservation) shareIndex(email string) int {
+	return slices.IndexFunc(r.Shares, func(g ShareGrant) bool {
+		return strings.EqualFold(g.Email, strings.TrimSpace(email))
+	})
+}
+
+// shareRole returns the role of the accepted share grant of the guest account.
+func (r *Reservation) shareRole(guestID GuestID) (ShareRole, bool) {
+	if guestID == "" {
+		return "", false
+	}
+	for _, g := range r.Shares {
+		if g.GuestID == guestID {
+			return g.Role, true
+		}
+	}
+	return "", false
 }
 
 // IsOverlapping checks if this reservation overlaps with another for the same room.
+// Rooms of different properties never overlap; a reservation without property may overlap any.
 func (r *Reservation) IsOverlapping(other *Reservation) bool {
 	if r.RoomID != other.RoomID {
 		return false
 	}
+	if r.PropertyID != "" && other.PropertyID != "" && r.PropertyID != other.PropertyID {
+		return false
+	}
 
 	if r.Status == StatusCancelled || other.Status == StatusCancelled {
 		return false
@@ -160,18 +400,14 @@ func (r *Reservation) IsOverlapping(other *Reservation) bool {
 		r.DateRange.CheckOut.After(other.DateRange.CheckIn)
 }
 
-// DaysUntilCheckIn returns the number of days until check-in.
+// DaysUntilCheckIn returns the number of days from today at the property until the check-in day.
 func (r *Reservation) DaysUntilCheckIn() int {
-	now := time.Now().Truncate(24 * time.Hour)
-	checkIn := r.DateRange.CheckIn.Truncate(24 * time.Hour)
-	days := checkIn.Sub(now).Hours() / 24
-	return int(days)
+	return daysBetween(r.DateRange.Today(shared.Now()), calendarDate(r.DateRange.CheckIn))
 }
 
 // Nights returns the number of nights for this reservation.
 func (r *Reservation) Nights() int {
-	nights := r.DateRange.CheckOut.Sub(r.DateRange.CheckIn).Hours() / 24
-	return int(nights)
+	return r.DateRange.Nights()
 }
 
 func (r *Reservation) validate() error {
@@ -186,23 +422,19 @@ func (r *Reservation) validate() error {
 	return nil
 }
 
+// validateDateRange compares the civil dates of the stay, and the check-in day with today at the property,
+// so a guest can book the current day of the hotel wherever the server runs.
 func (r *Reservation) validateDateRange() error {
-	nights := r.DateRange.CheckOut.Sub(r.DateRange.CheckIn).Hours() / 24
+	nights := r.DateRange.Nights()
 
 	if nights < 1 {
-		if r.DateRange.CheckOut.Equal(r.DateRange.CheckIn) {
+		if nights == 0 {
 			return ErrMinimumStay
 		}
 		return ErrInvalidDateRange
 	}
 
-	if !r.DateRange.CheckOut.After(r.DateRange.CheckIn) {
-		return ErrInvalidDateRange
-	}
-
-	now := time.Now().Truncate(24 * time.Hour)
-	checkIn := r.DateRange.CheckIn.Truncate(24 * time.Hour)
-	if checkIn.Before(now) {
+	if calendarDate(r.DateRange.CheckIn).Before(r.DateRange.Today(shared.Now())) {
 		return ErrCheckInPast
 	}
 
@@ -1,6 +1,9 @@
 package reservation_test
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -359,6 +362,132 @@ func Test_Reservation_Cancel_Already_Cancelled_Should_Return_Error(t *testing.T)
 	assert.That(t, "cancellation reason must be first", res.CancellationReason, "first cancellation")
 }
 
+func Test_Reservation_Revoke_From_Active_Should_Cancel(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.Activate()
+
+	// Act
+	err := res.Revoke("payment_capture_failed")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
+	assert.That(t, "reason must be recorded", res.CancellationReason, "payment_capture_failed")
+}
+
+func Test_Reservation_Revoke_From_Completed_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+	_ = res.Activate()
+	_ = res.Complete()
+
+	// Act
+	err := res.Revoke("payment_capture_failed")
+
+	// Assert
+	assert.That(t, "err must be ErrCannotCancelCompleted", errors.Is(err, reservation.ErrCannotCancelCompleted), true)
+	assert.That(t, "status must remain completed", res.Status, reservation.StatusCompleted)
+}
+
+// ============================================================================
+// State Transition Tests - No-Show and Expiry
+// ============================================================================
+
+func Test_Reservation_MarkNoShow_From_Confirmed_Should_Succeed(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+
+	// Act
+	err := res.MarkNoShow()
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be no_show", res.Status, reservation.StatusNoShow)
+	assert.That(t, "no-show must not be cancellable", res.CanBeCancelled(), false)
+}
+
+func Test_Reservation_MarkNoShow_From_Pending_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.MarkNoShow()
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	assert.That(t, "status must remain pending", res.Status, reservation.StatusPending)
+}
+
+func Test_Reservation_Expire_Within_Notice_Period_Should_Succeed(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	res.DateRange = reservation.NewDateRange(time.Now().Add(time.Hour), time.Now().Add(25*time.Hour))
+
+	// Act
+	err := res.Expire(reservation.ExpiryReason)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
+	assert.That(t, "reason must be recorded", res.CancellationReason, reservation.ExpiryReason)
+}
+
+func Test_Reservation_Expire_From_Confirmed_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+
+	// Act
+	err := res.Expire(reservation.ExpiryReason)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", errors.Is(err, reservation.ErrInvalidStateTransition), true)
+	assert.That(t, "status must remain confirmed", res.Status, reservation.StatusConfirmed)
+}
+
+// ============================================================================
+// Status History Tests
+// ============================================================================
+
+func Test_Reservation_StatusHistory_Should_Record_Every_Transition(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	_ = res.Confirm()
+	_ = res.Cancel("Guest requested")
+
+	// Assert
+	history := res.StatusHistory()
+	assert.That(t, "history must have 3 changes", len(history), 3)
+	assert.That(t, "creation must be pending", history[0].To, reservation.StatusPending)
+	assert.That(t, "confirmation must be from pending", history[1].From, reservation.StatusPending)
+	assert.That(t, "confirmation must be confirmed", history[1].To, reservation.StatusConfirmed)
+	assert.That(t, "cancellation must be from confirmed", history[2].From, reservation.StatusConfirmed)
+	assert.That(t, "cancellation must keep the reason", history[2].Reason, "Guest requested")
+}
+
+func Test_Reservation_StatusHistory_Without_Recorded_History_Should_Derive_It(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Cancel("Guest requested")
+	res.History = nil
+
+	// Act
+	history := res.StatusHistory()
+
+	// Assert
+	assert.That(t, "history must have 2 changes", len(history), 2)
+	assert.That(t, "creation must be at the creation time", history[0].At, res.CreatedAt)
+	assert.That(t, "last change must be to the current status", history[1].To, reservation.StatusCancelled)
+	assert.That(t, "last change must have an unknown origin", history[1].From, reservation.ReservationStatus(""))
+	assert.That(t, "last change must keep the reason", history[1].Reason, "Guest requested")
+}
+
 // ============================================================================
 // Business Logic Tests
 // ============================================================================
@@ -412,6 +541,30 @@ func Test_Reservation_CanBeCancelled_For_Completed_Should_Return_False(t *testin
 	assert.That(t, "should not be cancellable", canCancel, false)
 }
 
+func Test_Reservation_CanBeCancelled_With_Test_Clock_Past_Deadline_Should_Return_False(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	shared.SetClock(func() time.Time { return res.CancellationDeadline().Add(time.Minute) })
+	t.Cleanup(func() { shared.SetClock(nil) })
+
+	// Act
+	canCancel := res.CanBeCancelled()
+
+	// Assert
+	assert.That(t, "should not be cancellable after the deadline of the test clock", canCancel, false)
+}
+
+func Test_Reservation_CancellationDeadline_Should_Be_Notice_Period_Before_CheckIn(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	deadline := res.CancellationDeadline()
+
+	// Assert
+	assert.That(t, "deadline must be 24 hours before check-in", res.DateRange.CheckIn.Sub(deadline), reservation.CancellationNoticePeriod)
+}
+
 func Test_Reservation_IsOverlapping_Same_Room_Overlapping_Dates_Should_Return_True(t *testing.T) {
 	// Arrange
 	checkIn1 := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
@@ -465,6 +618,24 @@ func Test_Reservation_IsOverlapping_One_Cancelled_Should_Return_False(t *testing
 	assert.That(t, "should not be overlapping", overlapping, false)
 }
 
+func Test_Reservation_IsOverlapping_Different_Property_Should_Return_False(t *testing.T) {
+	// Arrange
+	checkIn := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	checkOut := checkIn.Add(72 * time.Hour)
+	dateRange := reservation.NewDateRange(checkIn, checkOut)
+
+	res1, _ := reservation.NewReservation("res-001", "guest-001", "room-101", dateRange, validMoney(), validGuests())
+	res2, _ := reservation.NewReservation("res-002", "guest-002", "room-101", dateRange, validMoney(), validGuests())
+	res1.PropertyID = "ber"
+	res2.PropertyID = "muc"
+
+	// Act
+	overlapping := res1.IsOverlapping(res2)
+
+	// Assert
+	assert.That(t, "should not be overlapping", overlapping, false)
+}
+
 // ============================================================================
 // Value Object Tests - DateRange
 // ============================================================================
@@ -478,8 +649,76 @@ func Test_NewDateRange_Should_Create_Valid_DateRange(t *testing.T) {
 	dateRange := reservation.NewDateRange(checkIn, checkOut)
 
 	// Assert
-	assert.That(t, "CheckIn must match", dateRange.CheckIn, checkIn)
-	assert.That(t, "CheckOut must match", dateRange.CheckOut, checkOut)
+	assert.That(t, "CheckIn must be the civil date", dateRange.CheckIn, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "CheckOut must be the civil date", dateRange.CheckOut, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "stay must have four nights", dateRange.Nights(), 4)
+}
+
+func Test_DateRange_CancellationDeadline_Should_Be_Relative_To_Property_Midnight(t *testing.T) {
+	// Arrange - midnight of 2030-03-10 in Berlin is 23:00 UTC the day before
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	res := reservation.Reservation{DateRange: reservation.NewDateRange(time.Date(2030, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2030, 3, 12, 0, 0, 0, 0, time.UTC)).In(berlin)}
+
+	// Act
+	deadline := res.CancellationDeadline()
+
+	// Assert
+	assert.That(t, "deadline must be 24 hours before midnight in Berlin", deadline.Equal(time.Date(2030, 3, 8, 23, 0, 0, 0, time.UTC)), true)
+}
+
+// ============================================================================
+// Property Timezone Tests
+// ============================================================================
+
+// Kiritimati (UTC+14) is always at least one calendar day ahead of Pago Pago (UTC-11),
+// so "today" at one property is in the past or the future at the other, whenever the test runs.
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load timezone %s: %v", name, err)
+	}
+	return location
+}
+
+func Test_NewReservation_With_Check_In_Today_At_Property_Should_Succeed(t *testing.T) {
+	// Arrange
+	kiritimati := mustLoadLocation(t, "Pacific/Kiritimati")
+	today := reservation.DateRange{}.In(kiritimati).Today(time.Now())
+	dateRange := reservation.NewDateRange(today, today.AddDate(0, 0, 2)).In(kiritimati)
+
+	// Act
+	_, err := reservation.NewReservation("res-001", "guest-001", "room-101", dateRange, validMoney(), validGuests())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+}
+
+func Test_NewReservation_With_Check_In_Before_Today_At_Property_Should_Return_Error(t *testing.T) {
+	// Arrange - today in Pago Pago is yesterday (or earlier) in Kiritimati
+	kiritimati := mustLoadLocation(t, "Pacific/Kiritimati")
+	today := reservation.DateRange{}.In(mustLoadLocation(t, "Pacific/Pago_Pago")).Today(time.Now())
+	dateRange := reservation.NewDateRange(today, today.AddDate(0, 0, 2)).In(kiritimati)
+
+	// Act
+	_, err := reservation.NewReservation("res-001", "guest-001", "room-101", dateRange, validMoney(), validGuests())
+
+	// Assert
+	assert.That(t, "err must be ErrCheckInPast", errors.Is(err, reservation.ErrCheckInPast), true)
+}
+
+func Test_Reservation_DaysUntilCheckIn_Should_Count_From_Today_At_Property(t *testing.T) {
+	// Arrange
+	pagoPago := mustLoadLocation(t, "Pacific/Pago_Pago")
+	checkIn := reservation.DateRange{}.In(pagoPago).Today(time.Now()).AddDate(0, 0, 3)
+	res := reservation.Reservation{DateRange: reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 1)).In(pagoPago)}
+
+	// Act
+	days := res.DaysUntilCheckIn()
+
+	// Assert
+	assert.That(t, "check-in must be three days ahead in Pago Pago", days, 3)
 }
 
 // ============================================================================
@@ -501,6 +740,88 @@ func Test_NewGuestInfo_Should_Create_Valid_GuestInfo(t *testing.T) {
 	assert.That(t, "PhoneNumber must match", guest.PhoneNumber, phone)
 }
 
+// ============================================================================
+// Value Object Tests - ArrivalDetails
+// ============================================================================
+
+func Test_NewArrivalDetails_Should_Normalize_Time_And_Keep_Contact(t *testing.T) {
+	// Arrange & Act
+	details, err := reservation.NewArrivalDetails(" Jane Doe ", "+1 555 0100", "spouse", "9:05")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "contact must be set", *details.EmergencyContact, reservation.EmergencyContact{Name: "Jane Doe", PhoneNumber: "+1 555 0100", Relationship: "spouse"})
+	assert.That(t, "arrival time must be HH:MM", details.ArrivalTime, "09:05")
+}
+
+func Test_NewArrivalDetails_Without_Values_Should_Return_Zero_Value(t *testing.T) {
+	// Arrange & Act
+	details, err := reservation.NewArrivalDetails("", "", "", "")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "details must be empty", details, reservation.ArrivalDetails{})
+}
+
+func Test_NewArrivalDetails_Without_Phone_Should_Return_ErrIncompleteEmergencyContact(t *testing.T) {
+	// Arrange & Act
+	_, err := reservation.NewArrivalDetails("Jane Doe", "", "", "")
+
+	// Assert
+	assert.That(t, "err must be ErrIncompleteEmergencyContact", err, reservation.ErrIncompleteEmergencyContact)
+}
+
+func Test_NewArrivalDetails_With_Invalid_Time_Should_Return_ErrInvalidArrivalTime(t *testing.T) {
+	// Arrange & Act
+	_, err := reservation.NewArrivalDetails("", "", "", "25:00")
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidArrivalTime", err, reservation.ErrInvalidArrivalTime)
+}
+
+// ============================================================================
+// Value Object Tests - BusinessCustomer
+// ============================================================================
+
+func Test_NewBusinessCustomer_Should_Normalize_VAT_ID(t *testing.T) {
+	// Arrange & Act
+	german, germanErr := reservation.NewBusinessCustomer(" Acme GmbH ", "de 123 456 789")
+	swiss, swissErr := reservation.NewBusinessCustomer("Acme AG", "CHE-123.456.789 MWST")
+
+	// Assert
+	assert.That(t, "german err must be nil", germanErr, nil)
+	assert.That(t, "german customer must be normalized", *german, reservation.BusinessCustomer{Company: "Acme GmbH", VATID: "DE123456789"})
+	assert.That(t, "swiss err must be nil", swissErr, nil)
+	assert.That(t, "swiss VAT ID must keep its suffix", swiss.VATID, "CHE123456789MWST")
+}
+
+func Test_NewBusinessCustomer_Without_Values_Should_Return_Nil(t *testing.T) {
+	// Arrange & Act
+	customer, err := reservation.NewBusinessCustomer("", " ")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "customer must be nil", customer == nil, true)
+}
+
+func Test_NewBusinessCustomer_With_Invalid_VAT_ID_Should_Return_ErrInvalidVATID(t *testing.T) {
+	// Arrange & Act
+	_, shortErr := reservation.NewBusinessCustomer("Acme GmbH", "DE12345678")
+	_, unknownErr := reservation.NewBusinessCustomer("Acme Inc", "US123456789")
+
+	// Assert
+	assert.That(t, "too short VAT ID must be rejected", shortErr, reservation.ErrInvalidVATID)
+	assert.That(t, "unknown country must be rejected", unknownErr, reservation.ErrInvalidVATID)
+}
+
+func Test_NewBusinessCustomer_Without_Company_Should_Return_ErrMissingCompany(t *testing.T) {
+	// Arrange & Act
+	_, err := reservation.NewBusinessCustomer("", "NL123456789B01")
+
+	// Assert
+	assert.That(t, "err must be ErrMissingCompany", err, reservation.ErrMissingCompany)
+}
+
 // ============================================================================
 // Value Object Tests - Money (shared)
 // ============================================================================
@@ -529,6 +850,95 @@ func Test_Money_FormatAmount_Should_Return_Formatted_String(t *testing.T) {
 	assert.That(t, "formatted must be correct", formatted, "100.50 USD")
 }
 
+func Test_Money_FormatAmount_With_Zero_Decimal_Currency_Should_Not_Divide(t *testing.T) {
+	// Arrange
+	money := shared.NewMoney(1200, "JPY")
+
+	// Act
+	formatted := money.FormatAmount()
+
+	// Assert
+	assert.That(t, "formatted must be correct", formatted, "1200 JPY")
+}
+
+func Test_Money_FormatIn_Should_Follow_The_Locale(t *testing.T) {
+	tests := []struct {
+		money  shared.Money
+		locale shared.Locale
+		want   string
+	}{
+		{shared.NewMoney(123450, "USD"), "en-US", "$1,234.50"},
+		{shared.NewMoney(123450, "EUR"), "de-DE", "1.234,50\u00a0€"},
+		{shared.NewMoney(123450, "EUR"), "fr-FR", "1\u202f234,50\u00a0€"},
+		{shared.NewMoney(123450, "EUR"), "nl-NL", "€\u00a01.234,50"},
+		{shared.NewMoney(120000, "JPY"), "ja-JP", "¥120,000"},
+		{shared.NewMoney(12345, "KWD"), "en-GB", "KWD12.345"},
+		{shared.NewMoney(-5, "USD"), "en-US", "-$0.05"},
+		{shared.NewMoney(1050, "GBP"), "de", "10,50\u00a0£"},
+		{shared.NewMoney(1050, "GBP"), "xx-YY", "£10.50"},
+	}
+	for _, tt := range tests {
+		// Act
+		formatted := tt.money.FormatIn(tt.locale)
+
+		// Assert
+		assert.That(t, "formatted must be correct for "+string(tt.locale), formatted, tt.want)
+	}
+}
+
+func Test_ResolveLocale_Should_Fall_Back_To_Language_And_Default(t *testing.T) {
+	assert.That(t, "case and separator must be normalized", shared.ResolveLocale("de_de"), shared.Locale("de-DE"))
+	assert.That(t, "unsupported region must resolve by language", shared.ResolveLocale("fr-CA"), shared.Locale("fr-FR"))
+	assert.That(t, "unknown language must resolve to the default", shared.ResolveLocale("xx"), shared.DefaultLocale)
+}
+
+// ============================================================================
+// Typed ID Tests (shared)
+// ============================================================================
+
+func Test_NewReservationID_Should_Have_Prefix_And_ULID(t *testing.T) {
+	// Act
+	id := shared.NewReservationID()
+
+	// Assert
+	_, err := shared.ParseReservationID(string(id))
+	assert.That(t, "id must start with the prefix", strings.HasPrefix(string(id), "res_"), true)
+	assert.That(t, "id must be valid", err, nil)
+}
+
+func Test_NewReservationID_Should_Sort_By_Creation(t *testing.T) {
+	// Arrange
+	ids := make([]string, 1000)
+
+	// Act
+	for i := range ids {
+		ids[i] = string(shared.NewReservationID())
+	}
+
+	// Assert
+	assert.That(t, "ids must be sorted", slices.IsSorted(ids), true)
+	assert.That(t, "ids must be unique", len(slices.Compact(ids)), 1000)
+}
+
+func Test_ParseReservationID_Should_Accept_Legacy_IDs(t *testing.T) {
+	// Act
+	id, err := shared.ParseReservationID("res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "id must be unchanged", id, reservation.ReservationID("res-001"))
+}
+
+func Test_ParseReservationID_With_Malformed_ID_Should_Return_ErrInvalidID(t *testing.T) {
+	for _, id := range []string{"", "res_01ABC", "res_01J9Z3M5Q8X7T6V4R2N0B1C3DU", "../etc/passwd", "res 001", strings.Repeat("a", 129)} {
+		// Act
+		_, err := shared.ParseReservationID(id)
+
+		// Assert
+		assert.That(t, "err must be ErrInvalidID for "+id, errors.Is(err, shared.ErrInvalidID), true)
+	}
+}
+
 // ============================================================================
 // Additional Coverage Tests
 // ============================================================================
@@ -628,3 +1038,139 @@ func Test_EventCancelled_Topic_Should_Return_Correct_Value(t *testing.T) {
 	// Assert
 	assert.That(t, "topic must be reservation.cancelled", topic, "reservation.cancelled")
 }
+
+// ============================================================================
+// Share Grant Tests
+// ============================================================================
+
+func Test_Reservation_AcceptShare_Should_Grant_Access_By_Role(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Share("viewer@example.com", reservation.ShareRoleView)
+	_ = res.Share("manager@example.com", reservation.ShareRoleManage)
+
+	// Act
+	errViewer := res.AcceptShare("VIEWER@example.com", "guest-viewer")
+	errManager := res.AcceptShare("manager@example.com", "guest-manager")
+
+	// Assert
+	assert.That(t, "viewer error must be nil", errViewer, nil)
+	assert.That(t, "manager error must be nil", errManager, nil)
+	assert.That(t, "viewer can view", res.CanView("guest-viewer"), true)
+	assert.That(t, "viewer cannot manage", res.CanManage("guest-viewer"), false)
+	assert.That(t, "manager can manage", res.CanManage("guest-manager"), true)
+	assert.That(t, "stranger cannot view", res.CanView("guest-stranger"), false)
+}
+
+func Test_Reservation_Share_With_Invalid_Role_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.Share("friend@example.com", "owner")
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidShareRole", err, reservation.ErrInvalidShareRole)
+}
+
+func Test_Reservation_AcceptShare_Accepted_By_Other_Guest_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Share("friend@example.com", reservation.ShareRoleView)
+	_ = res.AcceptShare("friend@example.com", "guest-friend")
+
+	// Act
+	err := res.AcceptShare("friend@example.com", "guest-other")
+
+	// Assert
+	assert.That(t, "error must be ErrShareAlreadyAccepted", err, reservation.ErrShareAlreadyAccepted)
+}
+
+func Test_Reservation_RevokeShare_Should_Remove_Access(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Share("friend@example.com", reservation.ShareRoleManage)
+	_ = res.AcceptShare("friend@example.com", "guest-friend")
+
+	// Act
+	err := res.RevokeShare("friend@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "friend cannot view", res.CanView("guest-friend"), false)
+}
+
+// ============================================================================
+// Perks Tests
+// ============================================================================
+
+func Test_Reservation_ApplyPerks_Should_Deduct_Discount(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.ApplyPerks(reservation.Perks{Tier: "gold", LateCheckout: true, DiscountPercent: 5})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "total must be discounted", res.TotalAmount, shared.NewMoney(9500, "USD"))
+	assert.That(t, "discount must be recorded", res.Perks.Discount, shared.NewMoney(500, "USD"))
+	assert.That(t, "late checkout must be recorded", res.Perks.LateCheckout, true)
+}
+
+func Test_Reservation_ApplyPerks_Should_Deduct_Redemption_After_Discount(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	capped := createValidReservation(t)
+
+	// Act
+	err := res.ApplyPerks(reservation.Perks{Tier: "gold", DiscountPercent: 5, Redemption: shared.NewMoney(1500, "USD")})
+	_ = capped.ApplyPerks(reservation.Perks{Redemption: shared.NewMoney(50000, "USD")})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "total must be discounted and redeemed", res.TotalAmount, shared.NewMoney(8000, "USD"))
+	assert.That(t, "redemption must be recorded", res.Perks.Redemption, shared.NewMoney(1500, "USD"))
+	assert.That(t, "redemption must not exceed the total", capped.Perks.Redemption, shared.NewMoney(10000, "USD"))
+	assert.That(t, "nothing must be left to pay", capped.TotalAmount, shared.NewMoney(0, "USD"))
+}
+
+func Test_Reservation_ApplyPerks_Should_Deduct_Promotion_Before_Redemption(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	promotion := reservation.AppliedPromotion{Code: "SUMMER25", Discount: shared.NewMoney(9000, "USD")}
+
+	// Act
+	err := res.ApplyPerks(reservation.Perks{Tier: "gold", DiscountPercent: 5, Promotion: promotion, Redemption: shared.NewMoney(1500, "USD")})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "promotion must be recorded", res.Perks.Promotion.Code, "SUMMER25")
+	assert.That(t, "promotion must not exceed what is left after the discount", res.Perks.Promotion.Discount, shared.NewMoney(9000, "USD"))
+	assert.That(t, "redemption must be capped at the rest", res.Perks.Redemption, shared.NewMoney(500, "USD"))
+	assert.That(t, "nothing must be left to pay", res.TotalAmount, shared.NewMoney(0, "USD"))
+}
+
+func Test_Reservation_ApplyPerks_After_Confirmation_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+	_ = res.Confirm()
+
+	// Act
+	err := res.ApplyPerks(reservation.Perks{Tier: "gold", DiscountPercent: 5})
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidStateTransition", err, reservation.ErrInvalidStateTransition)
+	assert.That(t, "total must be unchanged", res.TotalAmount, validMoney())
+}
+
+func Test_Reservation_ApplyPerks_With_Invalid_Discount_Should_Return_Error(t *testing.T) {
+	// Arrange
+	res := createValidReservation(t)
+
+	// Act
+	err := res.ApplyPerks(reservation.Perks{Tier: "gold", DiscountPercent: 150})
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidDiscount", err, reservation.ErrInvalidDiscount)
+}
@@ -0,0 +1,373 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoomPreference is a location of the room a guest asks for when booking.
+type RoomPreference string
+
+// Room preferences a guest may give.
+const (
+	PreferHighFloor        RoomPreference = "high_floor"
+	PreferAwayFromElevator RoomPreference = "away_from_elevator"
+	PreferAdjoining        RoomPreference = "adjoining" // next to a room of another reservation of the guest for the stay
+)
+
+// RoomPreferenceKinds are the room preferences in the order of the form and the reports.
+var RoomPreferenceKinds = []RoomPreference{PreferHighFloor, PreferAwayFromElevator, PreferAdjoining}
+
+// MaxPreferenceWeight is the weight of a preference that is important to the guest; 1 is nice to have.
+const MaxPreferenceWeight = 3
+
+// MetricRoomPreferences counts the preferences of new reservations by preference and whether the room fulfills them.
+const MetricRoomPreferences = "hotel_room_preferences_total"
+
+var (
+	// ErrInvalidRoomPreference is returned for an unknown preference or a weight out of range.
+	ErrInvalidRoomPreference = errors.New("room preference must be high_floor, away_from_elevator or adjoining with a weight from 1 to 3")
+	// ErrInvalidRoomLayout is returned if the floors, elevators or adjacency of the rooms are malformed.
+	ErrInvalidRoomLayout = errors.New("invalid room layout")
+	// ErrInvalidPreferencePeriod is returned if a preference report is requested for an empty period.
+	ErrInvalidPreferencePeriod = errors.New("preference period must end after it starts")
+)
+
+// RoomPreferences are the weights of the preferences of a guest (value object); nil means none were given.
+type RoomPreferences map[RoomPreference]int
+
+// NewRoomPreferences creates validated preferences from their weights; a weight of 0 leaves a preference out.
+func NewRoomPreferences(weights map[RoomPreference]int) (RoomPreferences, error) {
+	var prefs RoomPreferences
+	for pref, weight := range weights {
+		if !slices.Contains(RoomPreferenceKinds, pref) || weight < 0 || weight > MaxPreferenceWeight {
+			return nil, ErrInvalidRoomPreference
+		}
+		if weight == 0 {
+			continue
+		}
+		if prefs == nil {
+			prefs = make(RoomPreferences)
+		}
+		prefs[pref] = weight
+	}
+	return prefs, nil
+}
+
+// MaxScore returns the score of a room that fulfills every preference.
+func (p RoomPreferences) MaxScore() int {
+	score := 0
+	for _, weight := range p {
+		score += weight
+	}
+	return score
+}
+
+// RoomFeatures is the physical location of a room.
+type RoomFeatures struct {
+	Floor        int
+	NearElevator bool
+	Adjoining    []RoomID // rooms with a connecting door
+}
+
+// RoomLayout is the physical location of the rooms the allocation scores against the preferences.
+// Rooms missing from the layout fulfill no preference.
+type RoomLayout struct {
+	Rooms     map[RoomID]RoomFeatures
+	HighFloor int // lowest floor that counts as high
+}
+
+// DefaultRoomLayout returns the location of the rooms of the reservation form: a floor per room type,
+// the elevator next to the first room of each floor and connecting doors between the rooms of a floor.
+func DefaultRoomLayout() RoomLayout {
+	return RoomLayout{
+		Rooms: map[RoomID]RoomFeatures{
+			"room-101": {Floor: 1, NearElevator: true, Adjoining: []RoomID{"room-102"}},
+			"room-102": {Floor: 1, Adjoining: []RoomID{"room-101"}},
+			"room-201": {Floor: 2, NearElevator: true, Adjoining: []RoomID{"room-202"}},
+			"room-202": {Floor: 2, Adjoining: []RoomID{"room-201"}},
+			"room-301": {Floor: 3},
+		},
+		HighFloor: 2,
+	}
+}
+
+// ParseRoomLayout parses the rooms as "room=floor [elevator] [adjoining-room ...];..." (e.g.
+// "room-101=1 elevator room-102;room-102=1") with the lowest floor that counts as high.
+// Connecting doors work both ways, so an adjoining room needs to be listed only once.
+func ParseRoomLayout(rooms string, highFloor int) (RoomLayout, error) {
+	layout := RoomLayout{Rooms: make(map[RoomID]RoomFeatures), HighFloor: highFloor}
+	adjoining := make(map[RoomID][]RoomID)
+	for entry := range strings.SplitSeq(rooms, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, value, ok := strings.Cut(entry, "=")
+		room := RoomID(strings.TrimSpace(id))
+		fields := strings.Fields(value)
+		if !ok || room == "" || len(fields) == 0 {
+			return RoomLayout{}, fmt.Errorf("%w: expected room=floor [elevator] [adjoining-room ...]: %q", ErrInvalidRoomLayout, entry)
+		}
+		if _, ok := layout.Rooms[room]; ok {
+			return RoomLayout{}, fmt.Errorf("%w: room %q is listed twice", ErrInvalidRoomLayout, room)
+		}
+		floor, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return RoomLayout{}, fmt.Errorf("%w: floor of room %q must be an integer: %q", ErrInvalidRoomLayout, room, fields[0])
+		}
+		features := RoomFeatures{Floor: floor}
+		for _, field := range fields[1:] {
+			if field == "elevator" {
+				features.NearElevator = true
+				continue
+			}
+			if RoomID(field) == room {
+				return RoomLayout{}, fmt.Errorf("%w: room %q cannot adjoin itself", ErrInvalidRoomLayout, room)
+			}
+			adjoining[room] = append(adjoining[room], RoomID(field))
+			adjoining[RoomID(field)] = append(adjoining[RoomID(field)], room)
+		}
+		layout.Rooms[room] = features
+	}
+	for room, neighbours := range adjoining {
+		features, ok := layout.Rooms[room]
+		if !ok {
+			return RoomLayout{}, fmt.Errorf("%w: adjoining room %q is not in the layout", ErrInvalidRoomLayout, room)
+		}
+		slices.Sort(neighbours)
+		features.Adjoining = slices.Compact(neighbours)
+		layout.Rooms[room] = features
+	}
+	return layout, nil
+}
+
+// Fulfilled returns the preferences the room fulfills, in the order of RoomPreferenceKinds.
+// A room adjoins the stay if it has a connecting door to one of the booked rooms, those of the
+// guest's other reservations for the stay.
+func (l RoomLayout) Fulfilled(room RoomID, prefs RoomPreferences, booked []RoomID) []RoomPreference {
+	features, ok := l.Rooms[room]
+	if !ok {
+		return nil
+	}
+	var fulfilled []RoomPreference
+	for _, pref := range RoomPreferenceKinds {
+		if prefs[pref] == 0 {
+			continue
+		}
+		var met bool
+		switch pref {
+		case PreferHighFloor:
+			met = features.Floor >= l.HighFloor
+		case PreferAwayFromElevator:
+			met = !features.NearElevator
+		case PreferAdjoining:
+			met = slices.ContainsFunc(features.Adjoining, func(id RoomID) bool { return slices.Contains(booked, id) })
+		}
+		if met {
+			fulfilled = append(fulfilled, pref)
+		}
+	}
+	return fulfilled
+}
+
+// Score returns the sum of the weights of the preferences the room fulfills.
+func (l RoomLayout) Score(room RoomID, prefs RoomPreferences, booked []RoomID) int {
+	score := 0
+	for _, pref := range l.Fulfilled(room, prefs, booked) {
+		score += prefs[pref]
+	}
+	return score
+}
+
+// RoomAllocation is the room chosen for a stay and the preferences it fulfills.
+type RoomAllocation struct {
+	RoomID    RoomID
+	Score     int // sum of the weights of the fulfilled preferences
+	MaxScore  int // score of a room that fulfills every preference
+	Fulfilled []RoomPreference
+}
+
+// AllocateRoom chooses the candidate with the highest score. Ties go to the requested room, then to the
+// candidate listed first, so a guest whose preferences make no difference keeps the room they picked.
+func AllocateRoom(layout RoomLayout, candidates []RoomID, requested RoomID, prefs RoomPreferences, booked []RoomID) RoomAllocation {
+	best := RoomAllocation{RoomID: requested, Score: -1, MaxScore: prefs.MaxScore()}
+	for _, room := range candidates {
+		score := layout.Score(room, prefs, booked)
+		if score > best.Score || (score == best.Score && room == requested) {
+			best.RoomID, best.Score = room, score
+		}
+	}
+	best.Score = max(best.Score, 0)
+	best.Fulfilled = layout.Fulfilled(best.RoomID, prefs, booked)
+	return best
+}
+
+// WithRoomAllocation scores the rooms against the preferences of the guests: AllocateRoom offers the
+// room of the requested room's type that fulfills them best, and every new reservation records the
+// preferences its room fulfills. The room types are read from the rates on every allocation, so a
+// reload of the rate policy applies at once.
+func (s *Service) WithRoomAllocation(layout RoomLayout, rates *Rates) *Service {
+	s.roomLayout = &layout
+	s.rates = rates
+	return s
+}
+
+// AllocateRoom returns the available room of the requested room's type, and of its property, that fulfills
+// the guest's preferences best. Without preferences or allocation, or if no room of the type is available,
+// the requested room is returned; booking it then reports whether it is available.
+func (s *Service) AllocateRoom(ctx context.Context, guestID GuestID, requested RoomID, dateRange DateRange, prefs RoomPreferences) (RoomAllocation, error) {
+	if s.roomLayout == nil || len(prefs) == 0 {
+		return RoomAllocation{RoomID: requested}, nil
+	}
+	var candidates []RoomID
+	for _, roomType := range s.rates.RoomTypes() {
+		if slices.Contains(roomType.RoomIDs, requested) {
+			candidates = roomType.RoomIDs
+			break
+		}
+	}
+	var available []RoomID
+	for _, room := range candidates {
+		if s.properties != nil && s.properties.PropertyOf(room) != s.properties.PropertyOf(requested) {
+			continue
+		}
+		ok, err := s.availabilityChecker.IsRoomAvailable(ctx, room, dateRange)
+		if err != nil {
+			return RoomAllocation{}, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if ok && s.roomHolds != nil {
+			held, err := s.roomHolds.IsHeldForOthers(ctx, room, dateRange, guestID)
+			if err != nil {
+				return RoomAllocation{}, fmt.Errorf("failed to check room holds: %w", err)
+			}
+			ok = !held
+		}
+		if ok {
+			available = append(available, room)
+		}
+	}
+	if len(available) == 0 {
+		return RoomAllocation{RoomID: requested, MaxScore: prefs.MaxScore()}, nil
+	}
+	booked, err := s.bookedRooms(ctx, guestID, dateRange)
+	if err != nil {
+		return RoomAllocation{}, err
+	}
+	return AllocateRoom(*s.roomLayout, available, requested, prefs, booked), nil
+}
+
+// bookedRooms returns the rooms of the guest's reservations that overlap the stay and are not cancelled.
+func (s *Service) bookedRooms(ctx context.Context, guestID GuestID, dateRange DateRange) ([]RoomID, error) {
+	reservations, err := s.ListReservationsByGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	var rooms []RoomID
+	for _, r := range reservations {
+		if r.Status == StatusCancelled || r.Status == StatusNoShow {
+			continue
+		}
+		if r.DateRange.CheckIn.Before(dateRange.CheckOut) && dateRange.CheckIn.Before(r.DateRange.CheckOut) {
+			rooms = append(rooms, r.RoomID)
+		}
+	}
+	return rooms, nil
+}
+
+// recordPreferences records the preferences the room of the new reservation fulfills and counts them.
+func (s *Service) recordPreferences(ctx context.Context, reservation *Reservation) error {
+	if len(reservation.RoomPreferences) == 0 || s.roomLayout == nil {
+		return nil
+	}
+	booked, err := s.bookedRooms(ctx, reservation.GuestID, reservation.DateRange)
+	if err != nil {
+		return err
+	}
+	// An empty, not nil, list tells a room that fulfills none apart from a reservation that was never scored.
+	reservation.PreferencesMet = append([]RoomPreference{}, s.roomLayout.Fulfilled(reservation.RoomID, reservation.RoomPreferences, booked)...)
+	for _, pref := range RoomPreferenceKinds {
+		if reservation.RoomPreferences[pref] > 0 {
+			s.count(MetricRoomPreferences, "preference", string(pref), "fulfilled", strconv.FormatBool(slices.Contains(reservation.PreferencesMet, pref)))
+		}
+	}
+	return nil
+}
+
+// PreferenceFulfillment is how often a preference was requested and fulfilled.
+type PreferenceFulfillment struct {
+	Preference RoomPreference
+	Requested  int // reservations asking for it
+	Fulfilled  int // of those, the reservations whose room fulfills it
+	Rate       int // percent of the requests fulfilled; 0 without requests
+}
+
+// PreferenceReport is the fulfillment of the room preferences of the stays arriving from From to
+// To (exclusive), so management sees which preferences the rooms cannot meet.
+type PreferenceReport struct {
+	From         time.Time
+	To           time.Time
+	Reservations int // reservations with preferences
+	Preferences  []PreferenceFulfillment
+	WeightedRate int // percent of the requested weight fulfilled
+}
+
+// ReportPreferences aggregates the fulfillment of the preferences of the reservations arriving from
+// from to to (exclusive). Cancelled reservations and those whose room was never scored are ignored.
+func ReportPreferences(reservations []Reservation, from, to time.Time) PreferenceReport {
+	from, to = calendarDate(from), calendarDate(to)
+	report := PreferenceReport{From: from, To: to}
+	byPref := make(map[RoomPreference]*PreferenceFulfillment)
+	for _, pref := range RoomPreferenceKinds {
+		byPref[pref] = &PreferenceFulfillment{Preference: pref}
+	}
+	requestedWeight, fulfilledWeight := 0, 0
+	for _, r := range reservations {
+		checkIn := calendarDate(r.DateRange.CheckIn)
+		if r.Status == StatusCancelled || len(r.RoomPreferences) == 0 || r.PreferencesMet == nil || checkIn.Before(from) || !checkIn.Before(to) {
+			continue
+		}
+		report.Reservations++
+		for pref, weight := range r.RoomPreferences {
+			f, ok := byPref[pref]
+			if !ok {
+				continue
+			}
+			f.Requested++
+			requestedWeight += weight
+			if slices.Contains(r.PreferencesMet, pref) {
+				f.Fulfilled++
+				fulfilledWeight += weight
+			}
+		}
+	}
+	for _, pref := range RoomPreferenceKinds {
+		f := *byPref[pref]
+		if f.Requested > 0 {
+			f.Rate = f.Fulfilled * 100 / f.Requested
+		}
+		report.Preferences = append(report.Preferences, f)
+	}
+	if requestedWeight > 0 {
+		report.WeightedRate = fulfilledWeight * 100 / requestedWeight
+	}
+	return report
+}
+
+// PreferenceReport aggregates the fulfillment of the room preferences of the stays arriving from from to to (exclusive).
+func (s *Service) PreferenceReport(ctx context.Context, from, to time.Time) (*PreferenceReport, error) {
+	if !calendarDate(to).After(calendarDate(from)) {
+		return nil, ErrInvalidPreferencePeriod
+	}
+	reservations, err := s.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := ReportPreferences(reservations, from, to)
+	return &report, nil
+}
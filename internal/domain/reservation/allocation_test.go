@@ -0,0 +1,251 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Allocation Test Helpers
+// ============================================================================
+
+// bookedRoomsChecker reports the listed rooms as booked and every other room as available.
+type bookedRoomsChecker struct {
+	booked []reservation.RoomID
+}
+
+func (m *bookedRoomsChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	return !slices.Contains(m.booked, roomID), nil
+}
+
+func (m *bookedRoomsChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+// createAllocationTestService returns a service allocating the rooms of the default rates and layout.
+func createAllocationTestService(repo *mockReservationRepository, booked ...reservation.RoomID) *reservation.Service {
+	rates := reservation.NewRates(reservation.DefaultRatePolicy())
+	return reservation.NewService(repo, &bookedRoomsChecker{booked: booked}, &mockEventPublisher{}).
+		WithRoomAllocation(reservation.DefaultRoomLayout(), rates)
+}
+
+// preferenceReservation creates a confirmed reservation arriving on the day with the preferences and those its room met.
+func preferenceReservation(id reservation.ReservationID, checkIn time.Time, prefs reservation.RoomPreferences, met ...reservation.RoomPreference) reservation.Reservation {
+	return reservation.Reservation{
+		ID:              id,
+		GuestID:         "guest-001",
+		RoomID:          "room-201",
+		DateRange:       reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 2)),
+		Status:          reservation.StatusConfirmed,
+		TotalAmount:     shared.NewMoney(10000, "USD"),
+		RoomPreferences: prefs,
+		PreferencesMet:  append([]reservation.RoomPreference{}, met...),
+	}
+}
+
+// ============================================================================
+// NewRoomPreferences Tests
+// ============================================================================
+
+func Test_NewRoomPreferences_Should_Drop_Zero_Weights(t *testing.T) {
+	// Act
+	prefs, err := reservation.NewRoomPreferences(map[reservation.RoomPreference]int{
+		reservation.PreferHighFloor: 2,
+		reservation.PreferAdjoining: 0,
+	})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "only weighted preferences must be kept", prefs, reservation.RoomPreferences{reservation.PreferHighFloor: 2})
+}
+
+func Test_NewRoomPreferences_With_Invalid_Weight_Or_Preference_Should_Fail(t *testing.T) {
+	for _, weights := range []map[reservation.RoomPreference]int{
+		{reservation.PreferHighFloor: reservation.MaxPreferenceWeight + 1},
+		{reservation.PreferHighFloor: -1},
+		{"sea_view": 1},
+	} {
+		// Act
+		_, err := reservation.NewRoomPreferences(weights)
+
+		// Assert
+		assert.That(t, "preferences must be rejected", errors.Is(err, reservation.ErrInvalidRoomPreference), true)
+	}
+}
+
+// ============================================================================
+// ParseRoomLayout Tests
+// ============================================================================
+
+func Test_ParseRoomLayout_Should_Make_Connecting_Doors_Work_Both_Ways(t *testing.T) {
+	// Act
+	layout, err := reservation.ParseRoomLayout("room-401=4 elevator room-402; room-402=4", 3)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "first room must be near the elevator", layout.Rooms["room-401"].NearElevator, true)
+	assert.That(t, "floor must be parsed", layout.Rooms["room-402"].Floor, 4)
+	assert.That(t, "second room must adjoin the first", layout.Rooms["room-402"].Adjoining, []reservation.RoomID{"room-401"})
+	assert.That(t, "high floor must be kept", layout.HighFloor, 3)
+}
+
+func Test_ParseRoomLayout_With_Malformed_Entries_Should_Fail(t *testing.T) {
+	for _, rooms := range []string{
+		"room-401",
+		"room-401=fourth",
+		"room-401=4;room-401=4",
+		"room-401=4 room-401",
+		"room-401=4 room-499",
+	} {
+		// Act
+		_, err := reservation.ParseRoomLayout(rooms, 3)
+
+		// Assert
+		assert.That(t, "layout "+rooms+" must be rejected", errors.Is(err, reservation.ErrInvalidRoomLayout), true)
+	}
+}
+
+// ============================================================================
+// AllocateRoom Tests
+// ============================================================================
+
+func Test_AllocateRoom_Should_Choose_Room_With_Highest_Weighted_Score(t *testing.T) {
+	// Arrange
+	// room-201 is near the elevator; room-202 is away from it, on the same high floor.
+	prefs := reservation.RoomPreferences{reservation.PreferHighFloor: 1, reservation.PreferAwayFromElevator: 3}
+
+	// Act
+	allocation := reservation.AllocateRoom(reservation.DefaultRoomLayout(), []reservation.RoomID{"room-201", "room-202"}, "room-201", prefs, nil)
+
+	// Assert
+	assert.That(t, "room away from the elevator must be chosen", allocation.RoomID, reservation.RoomID("room-202"))
+	assert.That(t, "score must be the sum of both weights", allocation.Score, 4)
+	assert.That(t, "max score must be the sum of all weights", allocation.MaxScore, 4)
+	assert.That(t, "both preferences must be fulfilled", allocation.Fulfilled, []reservation.RoomPreference{reservation.PreferHighFloor, reservation.PreferAwayFromElevator})
+}
+
+func Test_AllocateRoom_With_Tie_Should_Keep_Requested_Room(t *testing.T) {
+	// Arrange
+	prefs := reservation.RoomPreferences{reservation.PreferHighFloor: 2}
+
+	// Act
+	allocation := reservation.AllocateRoom(reservation.DefaultRoomLayout(), []reservation.RoomID{"room-201", "room-202"}, "room-202", prefs, nil)
+
+	// Assert
+	assert.That(t, "requested room must be kept", allocation.RoomID, reservation.RoomID("room-202"))
+}
+
+func Test_AllocateRoom_Should_Prefer_Room_Adjoining_Booked_Room(t *testing.T) {
+	// Arrange
+	prefs := reservation.RoomPreferences{reservation.PreferAdjoining: 1}
+
+	// Act
+	allocation := reservation.AllocateRoom(reservation.DefaultRoomLayout(), []reservation.RoomID{"room-101", "room-201", "room-202"}, "room-101", prefs, []reservation.RoomID{"room-202"})
+
+	// Assert
+	assert.That(t, "room with a connecting door must be chosen", allocation.RoomID, reservation.RoomID("room-201"))
+	assert.That(t, "adjoining must be fulfilled", allocation.Fulfilled, []reservation.RoomPreference{reservation.PreferAdjoining})
+}
+
+// ============================================================================
+// Service Allocation Tests
+// ============================================================================
+
+func Test_Service_AllocateRoom_Should_Choose_Available_Room_Of_Same_Type(t *testing.T) {
+	// Arrange
+	// room-102 is away from the elevator but booked; the suite is of another type.
+	svc := createAllocationTestService(newMockReservationRepository(), "room-102")
+	prefs := reservation.RoomPreferences{reservation.PreferAwayFromElevator: 3}
+
+	// Act
+	allocation, err := svc.AllocateRoom(context.Background(), "guest-001", "room-101", serviceValidDateRange(), prefs)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "only available room of the type must be chosen", allocation.RoomID, reservation.RoomID("room-101"))
+	assert.That(t, "preference must not be fulfilled", len(allocation.Fulfilled), 0)
+}
+
+func Test_Service_AllocateRoom_Should_Place_Guest_Next_To_Own_Booking(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	svc := createAllocationTestService(repo, "room-201")
+	dateRange := serviceValidDateRange()
+	own := preferenceReservation("res-001", dateRange.CheckIn, nil)
+	own.DateRange = dateRange
+	_ = repo.Create(context.Background(), own.ID, own)
+	prefs := reservation.RoomPreferences{reservation.PreferAdjoining: 2}
+
+	// Act
+	allocation, err := svc.AllocateRoom(context.Background(), "guest-001", "room-202", dateRange, prefs)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "room next to the guest's room must be chosen", allocation.RoomID, reservation.RoomID("room-202"))
+	assert.That(t, "adjoining must be fulfilled", allocation.Fulfilled, []reservation.RoomPreference{reservation.PreferAdjoining})
+}
+
+func Test_Service_CreateReservation_Should_Record_Fulfilled_Preferences(t *testing.T) {
+	// Arrange
+	metrics := &mockMetrics{}
+	svc := createAllocationTestService(newMockReservationRepository()).WithMetrics(metrics)
+	prefs := reservation.RoomPreferences{reservation.PreferHighFloor: 1, reservation.PreferAwayFromElevator: 1}
+
+	// Act
+	res, err := svc.CreateReservationWithPerks(context.Background(), "res-001", "guest-001", "room-201", serviceValidDateRange(), shared.NewMoney(29800, "USD"),
+		serviceValidGuests(), reservation.Perks{}, reservation.ArrivalDetails{RoomPreferences: prefs})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "preferences must be recorded", res.RoomPreferences, prefs)
+	assert.That(t, "only high floor must be fulfilled", res.PreferencesMet, []reservation.RoomPreference{reservation.PreferHighFloor})
+	assert.That(t, "fulfilled preference must be counted", metrics.counters["hotel_room_preferences_total preference=high_floor=fulfilled=true"], 1)
+	assert.That(t, "unfulfilled preference must be counted", metrics.counters["hotel_room_preferences_total preference=away_from_elevator=fulfilled=false"], 1)
+}
+
+// ============================================================================
+// ReportPreferences Tests
+// ============================================================================
+
+func Test_ReportPreferences_Should_Report_Fulfillment_Rates(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	cancelled := preferenceReservation("res-004", from, reservation.RoomPreferences{reservation.PreferHighFloor: 1})
+	cancelled.Status = reservation.StatusCancelled
+	reservations := []reservation.Reservation{
+		preferenceReservation("res-001", from, reservation.RoomPreferences{reservation.PreferHighFloor: 3, reservation.PreferAdjoining: 1}, reservation.PreferHighFloor),
+		preferenceReservation("res-002", from.AddDate(0, 0, 3), reservation.RoomPreferences{reservation.PreferHighFloor: 1}),
+		preferenceReservation("res-003", from.AddDate(0, 1, 0), reservation.RoomPreferences{reservation.PreferHighFloor: 1}),
+		cancelled,
+	}
+
+	// Act
+	report := reservation.ReportPreferences(reservations, from, from.AddDate(0, 0, 7))
+
+	// Assert
+	assert.That(t, "reservations in the period must be counted", report.Reservations, 2)
+	assert.That(t, "high floor must be requested twice", report.Preferences[0].Requested, 2)
+	assert.That(t, "high floor must be fulfilled once", report.Preferences[0].Fulfilled, 1)
+	assert.That(t, "high floor rate must be 50%", report.Preferences[0].Rate, 50)
+	assert.That(t, "adjoining must never be fulfilled", report.Preferences[2].Rate, 0)
+	assert.That(t, "weighted rate must be 3 of 5", report.WeightedRate, 60)
+}
+
+func Test_Service_PreferenceReport_With_Empty_Period_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := svc.PreferenceReport(context.Background(), day, day)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidPreferencePeriod", err, reservation.ErrInvalidPreferencePeriod)
+}
@@ -0,0 +1,94 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Span of a room calendar in days.
+const (
+	DefaultCalendarDays = 60
+	MaxCalendarDays     = 366
+)
+
+// ErrInvalidCalendarSpan is returned if a room calendar is requested for less than 1 or more than MaxCalendarDays days.
+var ErrInvalidCalendarSpan = errors.New("calendar span must be between 1 and 366 days")
+
+// CalendarDay is the availability of a room for the night starting on Date.
+// It does not tell who booked the room, so it can be shown to every guest.
+type CalendarDay struct {
+	Date      time.Time
+	Available bool
+}
+
+// RoomCalendar is the per-night availability of a room, starting at From.
+type RoomCalendar struct {
+	RoomID RoomID
+	From   time.Time
+	Days   []CalendarDay
+}
+
+// NewRoomCalendar builds the calendar of the room for days nights from the date of from (UTC),
+// marking every night covered by one of the reservations as unavailable.
+// Cancelled reservations and reservations of other rooms are ignored.
+func NewRoomCalendar(roomID RoomID, from time.Time, days int, reservations []*Reservation) RoomCalendar {
+	from = calendarDate(from)
+	calendar := RoomCalendar{RoomID: roomID, From: from, Days: make([]CalendarDay, days)}
+	for i := range calendar.Days {
+		calendar.Days[i] = CalendarDay{Date: from.AddDate(0, 0, i), Available: true}
+	}
+	for _, r := range reservations {
+		if r.RoomID != roomID || r.Status == StatusCancelled {
+			continue
+		}
+		// The night of a day is booked if the stay starts before the next day and ends after the day.
+		checkIn, checkOut := calendarDate(r.DateRange.CheckIn), calendarDate(r.DateRange.CheckOut)
+		for i, day := range calendar.Days {
+			if day.Date.Before(checkOut) && !day.Date.Before(checkIn) {
+				calendar.Days[i].Available = false
+			}
+		}
+	}
+	return calendar
+}
+
+// calendarDate truncates the time to its date in UTC.
+func calendarDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetRoomCalendar returns the per-night availability of the room for days nights from the date of from.
+func (s *Service) GetRoomCalendar(ctx context.Context, roomID RoomID, from time.Time, days int) (*RoomCalendar, error) {
+	if days < 1 || days > MaxCalendarDays {
+		return nil, ErrInvalidCalendarSpan
+	}
+	from = calendarDate(from)
+	overlapping, err := s.availabilityChecker.GetOverlappingReservations(ctx, roomID, NewDateRange(from, from.AddDate(0, 0, days)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read occupancy: %w", err)
+	}
+	calendar := NewRoomCalendar(roomID, from, days, overlapping)
+	return &calendar, nil
+}
+
+// WithOccupancyVersions lets RoomCalendarVersion version the calendars by the occupancy of their room.
+func (s *Service) WithOccupancyVersions(versions OccupancyVersions) *Service {
+	s.occupancyVersions = versions
+	return s
+}
+
+// RoomCalendarVersion returns the version of the calendar GetRoomCalendar would build, without building it.
+// It returns an empty version if the service has no occupancy versions (see WithOccupancyVersions).
+func (s *Service) RoomCalendarVersion(ctx context.Context, roomID RoomID, from time.Time, days int) (string, error) {
+	if s.occupancyVersions == nil {
+		return "", nil
+	}
+	occupancy, err := s.occupancyVersions.OccupancyVersion(ctx, roomID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read occupancy version: %w", err)
+	}
+	return fmt.Sprintf("%s-%s-%d-%s", roomID, calendarDate(from).Format("2006-01-02"), days, occupancy), nil
+}
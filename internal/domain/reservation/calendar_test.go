@@ -0,0 +1,139 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Calendar Test Helpers
+// ============================================================================
+
+var calendarFrom = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// calendarReservation creates a reservation of room-101 from June 2nd, 14:00 to June 4th, 11:00 (2 nights).
+func calendarReservation(status reservation.ReservationStatus) *reservation.Reservation {
+	return &reservation.Reservation{
+		ID:      "res-001",
+		GuestID: "guest-001",
+		RoomID:  "room-101",
+		DateRange: reservation.NewDateRange(
+			time.Date(2026, 6, 2, 14, 0, 0, 0, time.UTC),
+			time.Date(2026, 6, 4, 11, 0, 0, 0, time.UTC),
+		),
+		Status:      status,
+		TotalAmount: shared.NewMoney(20000, "USD"),
+	}
+}
+
+// ============================================================================
+// NewRoomCalendar Tests
+// ============================================================================
+
+func Test_NewRoomCalendar_Should_Mark_Booked_Nights_As_Unavailable(t *testing.T) {
+	// Act
+	calendar := reservation.NewRoomCalendar("room-101", calendarFrom, 5, []*reservation.Reservation{calendarReservation(reservation.StatusConfirmed)})
+
+	// Assert
+	assert.That(t, "calendar must have 5 days", len(calendar.Days), 5)
+	assert.That(t, "night before check-in must be available", calendar.Days[0].Available, true)
+	assert.That(t, "night of check-in must be booked", calendar.Days[1].Available, false)
+	assert.That(t, "second night must be booked", calendar.Days[2].Available, false)
+	assert.That(t, "night of check-out must be available", calendar.Days[3].Available, true)
+	assert.That(t, "last day must be June 5th", calendar.Days[4].Date, time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_NewRoomCalendar_Should_Ignore_Cancelled_Reservations(t *testing.T) {
+	// Act
+	calendar := reservation.NewRoomCalendar("room-101", calendarFrom, 5, []*reservation.Reservation{calendarReservation(reservation.StatusCancelled)})
+
+	// Assert
+	for _, day := range calendar.Days {
+		assert.That(t, "every night must be available", day.Available, true)
+	}
+}
+
+func Test_NewRoomCalendar_Should_Ignore_Other_Rooms(t *testing.T) {
+	// Act
+	calendar := reservation.NewRoomCalendar("room-202", calendarFrom, 5, []*reservation.Reservation{calendarReservation(reservation.StatusConfirmed)})
+
+	// Assert
+	assert.That(t, "night of check-in must be available", calendar.Days[1].Available, true)
+}
+
+func Test_NewRoomCalendar_Should_Start_At_The_Date_Of_From(t *testing.T) {
+	// Act
+	calendar := reservation.NewRoomCalendar("room-101", calendarFrom.Add(15*time.Hour), 1, nil)
+
+	// Assert
+	assert.That(t, "from must be midnight", calendar.From, calendarFrom)
+}
+
+// ============================================================================
+// GetRoomCalendar Tests
+// ============================================================================
+
+func Test_Service_GetRoomCalendar_With_Invalid_Span_Should_Return_ErrInvalidCalendarSpan(t *testing.T) {
+	// Arrange
+	service := createToolsTestService(newToolsMockReservationRepository(), &toolsMockAvailabilityChecker{}, &toolsMockEventPublisher{})
+
+	for _, days := range []int{0, reservation.MaxCalendarDays + 1} {
+		// Act
+		_, err := service.GetRoomCalendar(context.Background(), "room-101", calendarFrom, days)
+
+		// Assert
+		assert.That(t, "err must be ErrInvalidCalendarSpan", errors.Is(err, reservation.ErrInvalidCalendarSpan), true)
+	}
+}
+
+func Test_Service_GetRoomCalendar_Should_Return_Calendar(t *testing.T) {
+	// Arrange
+	checker := &toolsMockAvailabilityChecker{overlapping: []*reservation.Reservation{calendarReservation(reservation.StatusConfirmed)}}
+	service := createToolsTestService(newToolsMockReservationRepository(), checker, &toolsMockEventPublisher{})
+
+	// Act
+	calendar, err := service.GetRoomCalendar(context.Background(), "room-101", calendarFrom, reservation.DefaultCalendarDays)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "calendar must have the default span", len(calendar.Days), reservation.DefaultCalendarDays)
+	assert.That(t, "night of check-in must be booked", calendar.Days[1].Available, false)
+}
+
+// staticOccupancyVersions returns the same occupancy version for every room.
+type staticOccupancyVersions string
+
+func (v staticOccupancyVersions) OccupancyVersion(_ context.Context, _ reservation.RoomID) (string, error) {
+	return string(v), nil
+}
+
+func Test_Service_RoomCalendarVersion_Without_Occupancy_Versions_Should_Return_Empty(t *testing.T) {
+	// Arrange
+	service := createToolsTestService(newToolsMockReservationRepository(), &toolsMockAvailabilityChecker{}, &toolsMockEventPublisher{})
+
+	// Act
+	version, err := service.RoomCalendarVersion(context.Background(), "room-101", calendarFrom, 7)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "version must be empty", version, "")
+}
+
+func Test_Service_RoomCalendarVersion_Should_Include_Span_And_Occupancy(t *testing.T) {
+	// Arrange
+	service := createToolsTestService(newToolsMockReservationRepository(), &toolsMockAvailabilityChecker{}, &toolsMockEventPublisher{}).
+		WithOccupancyVersions(staticOccupancyVersions("abc"))
+
+	// Act
+	version, err := service.RoomCalendarVersion(context.Background(), "room-101", calendarFrom.Add(15*time.Hour), 7)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "version must include span and occupancy", version, "room-101-2026-06-01-7-abc")
+}
@@ -0,0 +1,178 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrInvalidCapacityPeriod is returned if a capacity report is requested for an empty period.
+var ErrInvalidCapacityPeriod = errors.New("capacity period must end after it starts")
+
+// CapacitySeason is a season of the capacity report: the nights of its months in every year.
+type CapacitySeason struct {
+	Name   string
+	Months []time.Month
+}
+
+// CapacitySeasons are the meteorological seasons of the northern hemisphere, the order of the report.
+var CapacitySeasons = []CapacitySeason{
+	{Name: "winter", Months: []time.Month{time.December, time.January, time.February}},
+	{Name: "spring", Months: []time.Month{time.March, time.April, time.May}},
+	{Name: "summer", Months: []time.Month{time.June, time.July, time.August}},
+	{Name: "autumn", Months: []time.Month{time.September, time.October, time.November}},
+}
+
+// CapacityBucket is the occupancy of a room type on a group of nights, e.g. all Saturdays.
+// A night sells out when every room of the type is booked; its lead time is the number of days
+// between the booking of the last room and the night.
+type CapacityBucket struct {
+	Name            string
+	Nights          int // nights of the period in the bucket
+	RoomNights      int // nights times rooms of the type
+	SoldNights      int // room nights booked
+	Occupancy       int // percent of the room nights sold
+	SellOuts        int // nights on which every room was booked
+	SellOutLeadDays int // median lead time of the sell-outs; 0 without sell-outs
+}
+
+// RoomTypeCapacity is the occupancy of a room type in the period, in total, by weekday and by season.
+type RoomTypeCapacity struct {
+	RoomType RoomTypeID
+	Rooms    int
+	Total    CapacityBucket
+	Weekdays []CapacityBucket // Monday first
+	Seasons  []CapacityBucket // in the order of CapacitySeasons
+}
+
+// CapacityReport is the historical occupancy of the room types for the nights from From to To (exclusive),
+// so management sees which room types sell out, on which days and how early.
+type CapacityReport struct {
+	From      time.Time
+	To        time.Time
+	RoomTypes []RoomTypeCapacity
+}
+
+// PlanCapacity aggregates the occupancy of the room types for the nights from from to to (exclusive).
+// Every reservation that is not cancelled occupies its room, like in the room calendar;
+// reservations of rooms without a room type are ignored.
+func PlanCapacity(reservations []Reservation, roomTypes []RoomType, from, to time.Time) CapacityReport {
+	from, to = calendarDate(from), calendarDate(to)
+	report := CapacityReport{From: from, To: to}
+	for _, roomType := range roomTypes {
+		report.RoomTypes = append(report.RoomTypes, planRoomTypeCapacity(reservations, roomType, from, to))
+	}
+	return report
+}
+
+// capacityNight is the sales of a room type on a night.
+type capacityNight struct {
+	rooms      map[RoomID]bool // booked rooms; a double-booked room counts once
+	lastBooked time.Time       // creation of the latest reservation occupying the night
+}
+
+// planRoomTypeCapacity aggregates the occupancy of one room type.
+func planRoomTypeCapacity(reservations []Reservation, roomType RoomType, from, to time.Time) RoomTypeCapacity {
+	nights := make(map[time.Time]*capacityNight)
+	for _, r := range reservations {
+		if r.Status == StatusCancelled || !slices.Contains(roomType.RoomIDs, r.RoomID) {
+			continue
+		}
+		night, checkOut := calendarDate(r.DateRange.CheckIn), calendarDate(r.DateRange.CheckOut)
+		if night.Before(from) {
+			night = from
+		}
+		for ; night.Before(checkOut) && night.Before(to); night = night.AddDate(0, 0, 1) {
+			n, ok := nights[night]
+			if !ok {
+				n = &capacityNight{rooms: make(map[RoomID]bool)}
+				nights[night] = n
+			}
+			n.rooms[r.RoomID] = true
+			if r.CreatedAt.After(n.lastBooked) {
+				n.lastBooked = r.CreatedAt
+			}
+		}
+	}
+
+	rooms := len(roomType.RoomIDs)
+	total := capacityBuilder{CapacityBucket: CapacityBucket{Name: "total"}}
+	weekdays := make([]capacityBuilder, 7)
+	for i := range weekdays {
+		weekdays[i].Name = time.Weekday((i + 1) % 7).String()
+	}
+	seasons := make([]capacityBuilder, len(CapacitySeasons))
+	for i, season := range CapacitySeasons {
+		seasons[i].Name = season.Name
+	}
+	for night := from; night.Before(to); night = night.AddDate(0, 0, 1) {
+		sold, leadDays := 0, -1
+		n := nights[night]
+		if n != nil {
+			sold = len(n.rooms)
+		}
+		if rooms > 0 && sold == rooms {
+			leadDays = max(int(night.Sub(calendarDate(n.lastBooked)).Hours()/24), 0)
+		}
+		total.add(rooms, sold, leadDays)
+		weekdays[(int(night.Weekday())+6)%7].add(rooms, sold, leadDays)
+		for i, season := range CapacitySeasons {
+			if slices.Contains(season.Months, night.Month()) {
+				seasons[i].add(rooms, sold, leadDays)
+			}
+		}
+	}
+
+	capacity := RoomTypeCapacity{RoomType: roomType.ID, Rooms: rooms, Total: total.bucket()}
+	for _, b := range weekdays {
+		capacity.Weekdays = append(capacity.Weekdays, b.bucket())
+	}
+	for _, b := range seasons {
+		capacity.Seasons = append(capacity.Seasons, b.bucket())
+	}
+	return capacity
+}
+
+// capacityBuilder sums the nights of a bucket.
+type capacityBuilder struct {
+	CapacityBucket
+	leadDays []int
+}
+
+// add adds a night with the rooms, the sold rooms and the lead time of its sell-out (-1 if not sold out).
+func (b *capacityBuilder) add(rooms, sold, leadDays int) {
+	b.Nights++
+	b.RoomNights += rooms
+	b.SoldNights += sold
+	if leadDays >= 0 {
+		b.SellOuts++
+		b.leadDays = append(b.leadDays, leadDays)
+	}
+}
+
+// bucket returns the bucket with the occupancy and the median lead time.
+func (b *capacityBuilder) bucket() CapacityBucket {
+	bucket := b.CapacityBucket
+	if bucket.RoomNights > 0 {
+		bucket.Occupancy = bucket.SoldNights * 100 / bucket.RoomNights
+	}
+	if len(b.leadDays) > 0 {
+		slices.Sort(b.leadDays)
+		bucket.SellOutLeadDays = b.leadDays[len(b.leadDays)/2]
+	}
+	return bucket
+}
+
+// CapacityReport aggregates the occupancy of the room types for the nights from from to to (exclusive).
+func (s *Service) CapacityReport(ctx context.Context, roomTypes []RoomType, from, to time.Time) (*CapacityReport, error) {
+	if !calendarDate(to).After(calendarDate(from)) {
+		return nil, ErrInvalidCapacityPeriod
+	}
+	reservations, err := s.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := PlanCapacity(reservations, roomTypes, from, to)
+	return &report, nil
+}
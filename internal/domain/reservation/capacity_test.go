@@ -0,0 +1,113 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Capacity Test Helpers
+// ============================================================================
+
+// capacityRoomTypes has a standard type with two rooms and a suite.
+var capacityRoomTypes = []reservation.RoomType{
+	{ID: "standard", RoomIDs: []reservation.RoomID{"room-101", "room-102"}},
+	{ID: "suite", RoomIDs: []reservation.RoomID{"room-301"}},
+}
+
+// capacityReservation creates a reservation of the room for the nights from check-in, booked days before it.
+func capacityReservation(id reservation.ReservationID, roomID reservation.RoomID, checkIn time.Time, nights, bookedDaysBefore int) reservation.Reservation {
+	createdAt := checkIn.AddDate(0, 0, -bookedDaysBefore)
+	return reservation.Reservation{
+		ID:          id,
+		GuestID:     "guest-001",
+		RoomID:      roomID,
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, nights)),
+		Status:      reservation.StatusCompleted,
+		TotalAmount: shared.NewMoney(10000, "USD"),
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+}
+
+// ============================================================================
+// PlanCapacity Tests
+// ============================================================================
+
+func Test_PlanCapacity_Should_Count_Sold_Room_Nights(t *testing.T) {
+	// Arrange
+	// Saturday, July 4th to Monday, July 6th, 2026: 2 nights in a week of 7.
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	reservations := []reservation.Reservation{
+		capacityReservation("res-001", "room-101", time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC), 2, 10),
+	}
+
+	// Act
+	report := reservation.PlanCapacity(reservations, capacityRoomTypes, from, from.AddDate(0, 0, 7))
+
+	// Assert
+	standard := report.RoomTypes[0]
+	assert.That(t, "room nights must be 2 rooms times 7 nights", standard.Total.RoomNights, 14)
+	assert.That(t, "sold nights must be 2", standard.Total.SoldNights, 2)
+	assert.That(t, "occupancy must be 14%", standard.Total.Occupancy, 14)
+	assert.That(t, "saturday must be sold once", standard.Weekdays[5].SoldNights, 1)
+	assert.That(t, "nights must be in summer", standard.Seasons[2].SoldNights, 2)
+	assert.That(t, "nothing must be sold out", standard.Total.SellOuts, 0)
+}
+
+func Test_PlanCapacity_Should_Report_Sell_Outs_With_Lead_Time_Of_Last_Room(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC)
+	reservations := []reservation.Reservation{
+		capacityReservation("res-001", "room-101", saturday, 1, 30),
+		capacityReservation("res-002", "room-102", saturday, 1, 12),
+	}
+
+	// Act
+	report := reservation.PlanCapacity(reservations, capacityRoomTypes, from, from.AddDate(0, 0, 7))
+
+	// Assert
+	standard := report.RoomTypes[0]
+	assert.That(t, "saturday must sell out", standard.Weekdays[5].SellOuts, 1)
+	assert.That(t, "lead time must be that of the last room", standard.Weekdays[5].SellOutLeadDays, 12)
+	assert.That(t, "suite must not be sold", report.RoomTypes[1].Total.SoldNights, 0)
+}
+
+func Test_PlanCapacity_Should_Ignore_Cancelled_And_Double_Booked_Rooms(t *testing.T) {
+	// Arrange
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC)
+	cancelled := capacityReservation("res-002", "room-102", saturday, 1, 5)
+	cancelled.Status = reservation.StatusCancelled
+	reservations := []reservation.Reservation{
+		capacityReservation("res-001", "room-101", saturday, 1, 30),
+		capacityReservation("res-003", "room-101", saturday, 1, 20),
+		cancelled,
+	}
+
+	// Act
+	report := reservation.PlanCapacity(reservations, capacityRoomTypes, from, from.AddDate(0, 0, 7))
+
+	// Assert
+	assert.That(t, "one room must be sold", report.RoomTypes[0].Total.SoldNights, 1)
+	assert.That(t, "nothing must be sold out", report.RoomTypes[0].Total.SellOuts, 0)
+}
+
+func Test_Service_CapacityReport_With_Empty_Period_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	day := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := svc.CapacityReport(context.Background(), capacityRoomTypes, day, day)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidCapacityPeriod", errors.Is(err, reservation.ErrInvalidCapacityPeriod), true)
+}
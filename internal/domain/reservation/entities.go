@@ -1,21 +1,77 @@
 package reservation
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
 
-// DateRange represents a time period for a reservation.
+// DateRange represents the stay of a reservation as civil dates at the property (value object).
+// CheckIn and CheckOut are the calendar days, stored as midnight UTC, so the same stay has the same
+// dates on every server; Timezone places them at the property, e.g. to tell whether check-in is still ahead.
 type DateRange struct {
 	CheckIn  time.Time
 	CheckOut time.Time
+	Timezone string // IANA timezone of the property, or "Local" for the server's; empty for UTC (stays booked before properties had one)
 }
 
-// NewDateRange creates a DateRange value object.
+// NewDateRange creates a DateRange value object from the calendar days (in UTC) of check-in and check-out,
+// e.g. dates parsed from YYYY-MM-DD.
 func NewDateRange(checkIn, checkOut time.Time) DateRange {
 	return DateRange{
-		CheckIn:  checkIn,
-		CheckOut: checkOut,
+		CheckIn:  calendarDate(checkIn),
+		CheckOut: calendarDate(checkOut),
 	}
 }
 
+// In places the dates in the timezone of the property.
+func (d DateRange) In(location *time.Location) DateRange {
+	d.Timezone = location.String()
+	return d
+}
+
+// Location returns the timezone of the property, or UTC if none is set or it is unknown.
+func (d DateRange) Location() *time.Location {
+	if d.Timezone == "" {
+		return time.UTC
+	}
+	if location, ok := locations.Load(d.Timezone); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(d.Timezone, location)
+	return location
+}
+
+// locations caches the loaded timezones, since time.LoadLocation reads the zone database every time.
+var locations sync.Map
+
+// Today returns the civil date of now at the property, as midnight UTC like CheckIn and CheckOut.
+func (d DateRange) Today(now time.Time) time.Time {
+	now = now.In(d.Location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CheckInStart returns the instant the check-in day begins at the property.
+func (d DateRange) CheckInStart() time.Time {
+	checkIn := calendarDate(d.CheckIn)
+	return time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, d.Location())
+}
+
+// Nights returns the number of nights between the check-in and check-out days.
+func (d DateRange) Nights() int {
+	return daysBetween(calendarDate(d.CheckIn), calendarDate(d.CheckOut))
+}
+
+// daysBetween returns the number of days from one civil date to another (midnight UTC both).
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
 // GuestInfo represents information about a guest (entity within Reservation aggregate).
 type GuestInfo struct {
 	Name        string
@@ -31,3 +87,171 @@ func NewGuestInfo(name, email, phoneNumber string) GuestInfo {
 		PhoneNumber: phoneNumber,
 	}
 }
+
+// ShareRole defines what a co-traveler may do with a shared reservation.
+type ShareRole string
+
+const (
+	ShareRoleView   ShareRole = "view"   // see the reservation
+	ShareRoleManage ShareRole = "manage" // see and cancel the reservation
+)
+
+// ShareGrant gives a co-traveler access to a reservation (entity within Reservation aggregate).
+// It is created for an email address and bound to the co-traveler's account when the invitation is accepted.
+type ShareGrant struct {
+	Email      string
+	Role       ShareRole
+	GuestID    GuestID // empty until accepted
+	CreatedAt  time.Time
+	AcceptedAt time.Time
+}
+
+// IsAccepted returns true if the invitation was accepted by a guest account.
+func (g ShareGrant) IsAccepted() bool {
+	return g.GuestID != ""
+}
+
+// Perks are the benefits of the guest's VIP tier, the promo code and the loyalty points redeemed, applied
+// when the reservation is created (value object within Reservation aggregate). The zero value means no perks.
+type Perks struct {
+	Tier            string // e.g. "gold", "platinum"; empty for regular guests
+	LateCheckout    bool
+	UpgradePriority bool
+	DiscountPercent int
+	Discount        Money            // amount deducted from the room price
+	Promotion       AppliedPromotion // promo code, deducted after the discount
+	Redemption      Money            // value of the loyalty points redeemed, deducted after the promo code
+}
+
+// AppliedPromotion is the promo code applied to a reservation and the amount it took off (value object).
+// The zero value means no promo code.
+type AppliedPromotion struct {
+	Code     string
+	Discount Money
+}
+
+// HasPerks returns true if the guest has a VIP tier.
+func (p Perks) HasPerks() bool {
+	return p.Tier != ""
+}
+
+// ReducePrice returns true if the perks take anything off the price: a tier, a promo code or redeemed points.
+func (p Perks) ReducePrice() bool {
+	return p.HasPerks() || p.Promotion.Code != "" || p.Redemption.Amount > 0
+}
+
+// DiscountOn returns the discount of the tier on the amount.
+func (p Perks) DiscountOn(amount Money) Money {
+	return Money{Currency: amount.Currency, Amount: amount.Amount * int64(p.DiscountPercent) / 100}
+}
+
+// EmergencyContact is the person to call if something happens to a guest during the stay
+// (value object within Reservation aggregate).
+type EmergencyContact struct {
+	Name         string
+	PhoneNumber  string
+	Relationship string // optional, e.g. "spouse"
+}
+
+// ArrivalDetails are the optional details a guest gives when booking (value object).
+// The zero value means none were given.
+type ArrivalDetails struct {
+	EmergencyContact *EmergencyContact
+	ArrivalTime      string // estimated arrival on the check-in day as HH:MM in the hotel's time
+	RoomPreferences  RoomPreferences
+	BusinessCustomer *BusinessCustomer
+}
+
+// NewArrivalDetails creates validated arrival details; all arguments may be empty.
+// An emergency contact needs a name and a phone number, the arrival time must be HH:MM.
+func NewArrivalDetails(contactName, contactPhone, relationship, arrivalTime string) (ArrivalDetails, error) {
+	var details ArrivalDetails
+	contactName, contactPhone, relationship = strings.TrimSpace(contactName), strings.TrimSpace(contactPhone), strings.TrimSpace(relationship)
+	if contactName != "" || contactPhone != "" || relationship != "" {
+		if contactName == "" || contactPhone == "" {
+			return ArrivalDetails{}, ErrIncompleteEmergencyContact
+		}
+		details.EmergencyContact = &EmergencyContact{Name: contactName, PhoneNumber: contactPhone, Relationship: relationship}
+	}
+	if arrivalTime = strings.TrimSpace(arrivalTime); arrivalTime != "" {
+		t, err := time.Parse("15:04", arrivalTime)
+		if err != nil {
+			return ArrivalDetails{}, ErrInvalidArrivalTime
+		}
+		details.ArrivalTime = t.Format("15:04")
+	}
+	return details, nil
+}
+
+// BusinessCustomer is the company a stay is billed to (value object within Reservation aggregate).
+type BusinessCustomer struct {
+	Company string
+	VATID   string // normalized, e.g. DE123456789; empty if the company gave none
+}
+
+// vatIDFormats are the formats of the VAT IDs after their country prefix: the EU member states
+// (Greece as EL), Northern Ireland (XI), the United Kingdom and Switzerland (CHE, optionally with
+// its MWST, TVA or IVA suffix). Only the format is checked, not whether the ID is registered.
+var vatIDFormats = map[string]*regexp.Regexp{
+	"AT":  regexp.MustCompile(`^U\d{8}$`),
+	"BE":  regexp.MustCompile(`^[01]\d{9}$`),
+	"BG":  regexp.MustCompile(`^\d{9,10}$`),
+	"CY":  regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ":  regexp.MustCompile(`^\d{8,10}$`),
+	"DE":  regexp.MustCompile(`^\d{9}$`),
+	"DK":  regexp.MustCompile(`^\d{8}$`),
+	"EE":  regexp.MustCompile(`^\d{9}$`),
+	"EL":  regexp.MustCompile(`^\d{9}$`),
+	"ES":  regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI":  regexp.MustCompile(`^\d{8}$`),
+	"FR":  regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR":  regexp.MustCompile(`^\d{11}$`),
+	"HU":  regexp.MustCompile(`^\d{8}$`),
+	"IE":  regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT":  regexp.MustCompile(`^\d{11}$`),
+	"LT":  regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU":  regexp.MustCompile(`^\d{8}$`),
+	"LV":  regexp.MustCompile(`^\d{11}$`),
+	"MT":  regexp.MustCompile(`^\d{8}$`),
+	"NL":  regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL":  regexp.MustCompile(`^\d{10}$`),
+	"PT":  regexp.MustCompile(`^\d{9}$`),
+	"RO":  regexp.MustCompile(`^\d{2,10}$`),
+	"SE":  regexp.MustCompile(`^\d{10}01$`),
+	"SI":  regexp.MustCompile(`^\d{8}$`),
+	"SK":  regexp.MustCompile(`^\d{10}$`),
+	"XI":  regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+	"GB":  regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+	"CHE": regexp.MustCompile(`^\d{9}(MWST|TVA|IVA)?$`),
+}
+
+// NewBusinessCustomer creates a validated business customer; it returns nil if both arguments are empty.
+// A VAT ID needs the name of the company and must match the format of its country prefix;
+// it is normalized to upper case without spaces, dots and hyphens.
+func NewBusinessCustomer(company, vatID string) (*BusinessCustomer, error) {
+	company = strings.TrimSpace(company)
+	vatID = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vatID))
+	if company == "" && vatID == "" {
+		return nil, nil
+	}
+	if company == "" {
+		return nil, ErrMissingCompany
+	}
+	if vatID != "" && !validVATID(vatID) {
+		return nil, ErrInvalidVATID
+	}
+	return &BusinessCustomer{Company: company, VATID: vatID}, nil
+}
+
+// validVATID reports whether the normalized VAT ID matches the format of its country prefix.
+func validVATID(vatID string) bool {
+	for _, n := range []int{3, 2} {
+		if len(vatID) <= n {
+			continue
+		}
+		if format, ok := vatIDFormats[vatID[:n]]; ok && format.MatchString(vatID[n:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,11 @@
 package reservation
 
-import "time"
+import (
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
 
 // Event topics for Kafka.
 const (
@@ -9,16 +14,51 @@ const (
 	EventTopicActivated = "reservation.activated"
 	EventTopicCompleted = "reservation.completed"
 	EventTopicCancelled = "reservation.cancelled"
+	EventTopicNoShow    = "reservation.no_show"
+	EventTopicExtended  = "reservation.extended"
 )
 
+// ExampleEvents returns an example of every event published by this context,
+// in topic order of the lifecycle. They document the events in the event catalog.
+func ExampleEvents() []event.Event {
+	checkIn := time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)
+	fx := shared.NewIdentityFXSnapshot("USD", checkIn.AddDate(0, -1, 0))
+	return []event.Event{
+		NewEventCreated().
+			WithReservationID("res-1001").
+			WithGuestID("guest-42").
+			WithRoomID("room-101").
+			WithPropertyID("main").
+			WithCheckIn(checkIn).
+			WithCheckOut(checkIn.AddDate(0, 0, 3)).
+			WithTotalAmount(shared.NewMoney(45000, "USD")).
+			WithFX(&fx).
+			WithGuestTier("gold"),
+		NewEventConfirmed().WithReservationID("res-1001").WithGuestID("guest-42").WithGuestTier("gold"),
+		NewEventActivated().WithReservationID("res-1001"),
+		NewEventCompleted().WithReservationID("res-1001"),
+		NewEventCancelled().WithReservationID("res-1001").WithGuestID("guest-42").WithReason("change of plans").WithGuestTier("gold"),
+		NewEventNoShow().WithReservationID("res-1001").WithGuestID("guest-42"),
+		NewEventExtended().
+			WithReservationID("res-1001").
+			WithGuestID("guest-42").
+			WithRoomID("room-101").
+			WithNight(checkIn.AddDate(0, 0, 3)).
+			WithAmount(shared.NewMoney(15000, "USD")),
+	}
+}
+
 // EventCreated is published when a new reservation is created.
 type EventCreated struct {
 	ReservationID ReservationID `json:"reservation_id"`
 	GuestID       GuestID       `json:"guest_id"`
 	RoomID        RoomID        `json:"room_id"`
+	PropertyID    PropertyID    `json:"property_id,omitempty"`
 	CheckIn       time.Time     `json:"check_in"`
 	CheckOut      time.Time     `json:"check_out"`
 	TotalAmount   Money         `json:"total_amount"`
+	FX            *FXSnapshot   `json:"fx,omitempty"`
+	GuestTier     string        `json:"guest_tier,omitempty"`
 }
 
 func NewEventCreated() *EventCreated {
@@ -42,6 +82,11 @@ func (e *EventCreated) WithRoomID(id RoomID) *EventCreated {
 	return e
 }
 
+func (e *EventCreated) WithPropertyID(id PropertyID) *EventCreated {
+	e.PropertyID = id
+	return e
+}
+
 func (e *EventCreated) WithCheckIn(t time.Time) *EventCreated {
 	e.CheckIn = t
 	return e
@@ -57,10 +102,21 @@ func (e *EventCreated) WithTotalAmount(m Money) *EventCreated {
 	return e
 }
 
+func (e *EventCreated) WithFX(fx *FXSnapshot) *EventCreated {
+	e.FX = fx
+	return e
+}
+
+func (e *EventCreated) WithGuestTier(tier string) *EventCreated {
+	e.GuestTier = tier
+	return e
+}
+
 // EventConfirmed is published when a reservation is confirmed.
 type EventConfirmed struct {
 	ReservationID ReservationID `json:"reservation_id"`
 	GuestID       GuestID       `json:"guest_id"`
+	GuestTier     string        `json:"guest_tier,omitempty"`
 }
 
 func NewEventConfirmed() *EventConfirmed {
@@ -79,6 +135,11 @@ func (e *EventConfirmed) WithGuestID(id GuestID) *EventConfirmed {
 	return e
 }
 
+func (e *EventConfirmed) WithGuestTier(tier string) *EventConfirmed {
+	e.GuestTier = tier
+	return e
+}
+
 // EventActivated is published when a guest checks in.
 type EventActivated struct {
 	ReservationID ReservationID `json:"reservation_id"`
@@ -116,6 +177,7 @@ type EventCancelled struct {
 	ReservationID ReservationID `json:"reservation_id"`
 	GuestID       GuestID       `json:"guest_id"`
 	Reason        string        `json:"reason"`
+	GuestTier     string        `json:"guest_tier,omitempty"`
 }
 
 func NewEventCancelled() *EventCancelled {
@@ -138,3 +200,71 @@ func (e *EventCancelled) WithReason(reason string) *EventCancelled {
 	e.Reason = reason
 	return e
 }
+
+func (e *EventCancelled) WithGuestTier(tier string) *EventCancelled {
+	e.GuestTier = tier
+	return e
+}
+
+// EventNoShow is published when a confirmed reservation is marked as no-show after its check-in day.
+type EventNoShow struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	GuestID       GuestID       `json:"guest_id"`
+}
+
+func NewEventNoShow() *EventNoShow {
+	return &EventNoShow{}
+}
+
+func (e *EventNoShow) Topic() string { return EventTopicNoShow }
+
+func (e *EventNoShow) WithReservationID(id ReservationID) *EventNoShow {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventNoShow) WithGuestID(id GuestID) *EventNoShow {
+	e.GuestID = id
+	return e
+}
+
+// EventExtended is published when a guest who overstayed accepts the offer to extend the stay by a night.
+// Night is the added night, the former check-out day; Amount is charged on the folio.
+type EventExtended struct {
+	ReservationID ReservationID `json:"reservation_id"`
+	GuestID       GuestID       `json:"guest_id"`
+	RoomID        RoomID        `json:"room_id"`
+	Night         time.Time     `json:"night"`
+	Amount        Money         `json:"amount"`
+}
+
+func NewEventExtended() *EventExtended {
+	return &EventExtended{}
+}
+
+func (e *EventExtended) Topic() string { return EventTopicExtended }
+
+func (e *EventExtended) WithReservationID(id ReservationID) *EventExtended {
+	e.ReservationID = id
+	return e
+}
+
+func (e *EventExtended) WithGuestID(id GuestID) *EventExtended {
+	e.GuestID = id
+	return e
+}
+
+func (e *EventExtended) WithRoomID(id RoomID) *EventExtended {
+	e.RoomID = id
+	return e
+}
+
+func (e *EventExtended) WithNight(t time.Time) *EventExtended {
+	e.Night = t
+	return e
+}
+
+func (e *EventExtended) WithAmount(m Money) *EventExtended {
+	e.Amount = m
+	return e
+}
@@ -0,0 +1,53 @@
+package reservation
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ActorSystem is the actor of status changes without an authenticated caller, e.g. by the booking saga.
+const ActorSystem = shared.ActorSystem
+
+// StatusChange records one status transition of a reservation.
+// The history is persisted with the aggregate and only ever appended to.
+type StatusChange struct {
+	From   ReservationStatus // empty for the creation
+	To     ReservationStatus
+	At     time.Time
+	Actor  string // "guest:<email>", "staff:<email>", "service:<client ID>" or "system"
+	Reason string // the cancellation reason; empty for the other transitions
+}
+
+// StatusHistory returns the status changes from the creation to the current status, oldest first.
+// Reservations created before the history was recorded get a history of their creation and,
+// if the status changed since, one change to the current status with an unknown actor and origin.
+func (r *Reservation) StatusHistory() []StatusChange {
+	if len(r.History) > 0 {
+		return r.History
+	}
+	history := []StatusChange{{To: StatusPending, At: r.CreatedAt}}
+	if r.Status != StatusPending {
+		history = append(history, StatusChange{To: r.Status, At: r.UpdatedAt, Reason: r.CancellationReason})
+	}
+	return history
+}
+
+// recordStatusChange appends the transition from the previous status to the current one.
+// The actor is left empty; the service attributes the change to its caller.
+func (r *Reservation) recordStatusChange(from ReservationStatus, reason string) {
+	r.History = append(r.History, StatusChange{From: from, To: r.Status, At: r.UpdatedAt, Reason: reason})
+}
+
+// attributeStatusChange sets the actor of the latest status change if it has none.
+func (r *Reservation) attributeStatusChange(actor string) {
+	if n := len(r.History); n > 0 && r.History[n-1].Actor == "" {
+		r.History[n-1].Actor = actor
+	}
+}
+
+// actorOf returns the actor of a status change made by the caller in ctx.
+func actorOf(ctx context.Context) string {
+	return shared.ActorOf(ctx)
+}
@@ -0,0 +1,188 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// RoomHoldStatus is the state of a checkout hold.
+type RoomHoldStatus string
+
+// Room hold states. An active hold keeps the room for the guest until it expires; once the guest
+// books, it waits for the payment of the reservation and is deleted when the reservation is
+// confirmed (converted) or cancelled (released).
+const (
+	HoldActive RoomHoldStatus = "active"
+	HoldBooked RoomHoldStatus = "booked"
+)
+
+// RoomHold keeps a room for a guest between picking it on the reservation form and booking it.
+// It is keyed by the ID of the reservation the guest is about to make, so the booking finds it.
+type RoomHold struct {
+	ReservationID ReservationID
+	GuestID       GuestID
+	RoomID        RoomID
+	DateRange     DateRange
+	Status        RoomHoldStatus
+	HeldAt        time.Time
+	ExpiresAt     time.Time
+}
+
+// Expired reports whether the hold no longer keeps the room at now.
+func (h RoomHold) Expired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// Blocks reports whether the hold keeps the room for some of the nights of the date range at now.
+// Booked holds do not block, since their reservation does.
+func (h RoomHold) Blocks(roomID RoomID, dateRange DateRange, now time.Time) bool {
+	return h.Status == HoldActive && !h.Expired(now) && h.RoomID == roomID &&
+		h.DateRange.CheckIn.Before(dateRange.CheckOut) && h.DateRange.CheckOut.After(dateRange.CheckIn)
+}
+
+// covers reports whether the hold was taken by the guest for the same room and nights.
+func (h RoomHold) covers(guestID GuestID, roomID RoomID, dateRange DateRange) bool {
+	return h.GuestID == guestID && h.RoomID == roomID &&
+		h.DateRange.CheckIn.Equal(dateRange.CheckIn) && h.DateRange.CheckOut.Equal(dateRange.CheckOut)
+}
+
+// WithCheckoutHolds stores the holds taken by HoldRoom in the repository for ttl.
+// The availability checkers must count the holds of the same repository.
+func (s *Service) WithCheckoutHolds(repo RoomHoldRepository, ttl time.Duration) *Service {
+	s.holdRepo = repo
+	s.holdTTL = ttl
+	return s
+}
+
+// HoldsRooms reports whether rooms are held for the guest during checkout.
+func (s *Service) HoldsRooms() bool {
+	return s.holdRepo != nil && s.holdTTL > 0
+}
+
+// HoldRoom keeps the room for the guest while they confirm the booking, so nobody else can book it
+// meanwhile. id is the ID of the reservation the guest is going to make. Other active holds of the
+// guest are released first, since a guest checks out one stay at a time.
+func (s *Service) HoldRoom(ctx context.Context, id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange) (*RoomHold, error) {
+	if s.properties != nil {
+		dateRange = dateRange.In(s.properties.Location(s.properties.PropertyOf(roomID)))
+	}
+	if err := s.releaseHoldsOf(ctx, guestID); err != nil {
+		return nil, err
+	}
+
+	unlock, checker, err := s.lockRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	available, err := checker.IsRoomAvailable(ctx, roomID, dateRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: %s", ErrRoomNotAvailable, roomID)
+	}
+
+	now := shared.Now()
+	hold := RoomHold{
+		ReservationID: id,
+		GuestID:       guestID,
+		RoomID:        roomID,
+		DateRange:     dateRange,
+		Status:        HoldActive,
+		HeldAt:        now,
+		ExpiresAt:     now.Add(s.holdTTL),
+	}
+	if err := s.holdRepo.Create(ctx, id, hold); err != nil {
+		return nil, fmt.Errorf("failed to persist room hold: %w", err)
+	}
+	return &hold, nil
+}
+
+// releaseHoldsOf deletes the active holds of the guest.
+func (s *Service) releaseHoldsOf(ctx context.Context, guestID GuestID) error {
+	holds, err := s.holdRepo.ReadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read room holds: %w", err)
+	}
+	for _, hold := range holds {
+		if hold.GuestID != guestID || hold.Status != HoldActive {
+			continue
+		}
+		if err := s.holdRepo.Delete(ctx, hold.ReservationID); err != nil {
+			return fmt.Errorf("failed to release room hold: %w", err)
+		}
+	}
+	return nil
+}
+
+// bookHold marks the active hold of the booking as booked, so the availability check of the booking
+// does not count it. It returns the hold as it was, to be restored if the booking fails, or nil if the
+// booking has no hold still covering it. The caller holds the lock of the room.
+func (s *Service) bookHold(ctx context.Context, id ReservationID, guestID GuestID, roomID RoomID, dateRange DateRange) (*RoomHold, error) {
+	if s.holdRepo == nil {
+		return nil, nil
+	}
+	hold, err := s.holdRepo.Read(ctx, id)
+	if err != nil || hold == nil || hold.Status != HoldActive || hold.Expired(shared.Now()) || !hold.covers(guestID, roomID, dateRange) {
+		return nil, nil
+	}
+	previous := *hold
+	hold.Status = HoldBooked
+	if err := s.holdRepo.Update(ctx, id, *hold); err != nil {
+		return nil, fmt.Errorf("failed to update room hold: %w", err)
+	}
+	return &previous, nil
+}
+
+// endHold deletes the hold of the reservation once it was confirmed or cancelled.
+// Reservations booked without a hold have none, which is not an error.
+func (s *Service) endHold(ctx context.Context, id ReservationID) error {
+	if s.holdRepo == nil {
+		return nil
+	}
+	if hold, err := s.holdRepo.Read(ctx, id); err != nil || hold == nil {
+		return nil
+	}
+	if err := s.holdRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete room hold: %w", err)
+	}
+	return nil
+}
+
+// ExpireRoomHolds deletes the active holds expired at now and the booked holds whose reservation
+// is no longer pending, e.g. because the service stopped before deleting them. It returns how many
+// were deleted; the rooms of abandoned checkouts are released this way.
+func (s *Service) ExpireRoomHolds(ctx context.Context, now time.Time) (int, error) {
+	if s.holdRepo == nil {
+		return 0, nil
+	}
+	holds, err := s.holdRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read room holds: %w", err)
+	}
+	deleted := 0
+	var errs []error
+	for _, hold := range holds {
+		switch hold.Status {
+		case HoldActive:
+			if !hold.Expired(now) {
+				continue
+			}
+		case HoldBooked:
+			if r, err := s.reservationRepo.Read(ctx, hold.ReservationID); err == nil && r.Status == StatusPending {
+				continue
+			}
+		}
+		if err := s.holdRepo.Delete(ctx, hold.ReservationID); err != nil {
+			errs = append(errs, fmt.Errorf("room hold %s: %w", hold.ReservationID, err))
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
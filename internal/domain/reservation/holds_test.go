@@ -0,0 +1,215 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Room Hold Test Helpers
+// ============================================================================
+
+// holdCountingChecker reports rooms as available unless they are reserved or held, like the repository checker.
+type holdCountingChecker struct {
+	repo  *mockReservationRepository
+	holds reservation.RoomHoldRepository
+}
+
+func (c *holdCountingChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	overlapping, _ := c.GetOverlappingReservations(ctx, roomID, dateRange)
+	holds, _ := c.holds.ReadAll(ctx)
+	held := slices.ContainsFunc(holds, func(h reservation.RoomHold) bool { return h.Blocks(roomID, dateRange, time.Now()) })
+	return len(overlapping) == 0 && !held, nil
+}
+
+func (c *holdCountingChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	wanted := &reservation.Reservation{RoomID: roomID, DateRange: dateRange}
+	var overlapping []*reservation.Reservation
+	for _, res := range c.repo.reservations {
+		if wanted.IsOverlapping(&res) {
+			overlapping = append(overlapping, &res)
+		}
+	}
+	return overlapping, nil
+}
+
+// createHoldingTestService creates a reservation service that holds rooms for 15 minutes.
+func createHoldingTestService(repo *mockReservationRepository) (*reservation.Service, reservation.RoomHoldRepository) {
+	holds := resource.NewInMemoryAccess[reservation.ReservationID, reservation.RoomHold]()
+	service := reservation.NewService(repo, &holdCountingChecker{repo: repo, holds: holds}, &mockEventPublisher{}).
+		WithCheckoutHolds(holds, 15*time.Minute)
+	return service, holds
+}
+
+// ============================================================================
+// RoomHold Tests
+// ============================================================================
+
+func Test_RoomHold_Blocks_Should_Only_Count_Active_Holds_Of_Overlapping_Nights(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	dateRange := serviceValidDateRange()
+	hold := reservation.RoomHold{RoomID: "room-101", DateRange: dateRange, Status: reservation.HoldActive, ExpiresAt: now.Add(time.Minute)}
+	booked := hold
+	booked.Status = reservation.HoldBooked
+	later := reservation.NewDateRange(dateRange.CheckOut, dateRange.CheckOut.AddDate(0, 0, 2))
+
+	// Act & Assert
+	assert.That(t, "active hold must block", hold.Blocks("room-101", dateRange, now), true)
+	assert.That(t, "expired hold must not block", hold.Blocks("room-101", dateRange, now.Add(time.Hour)), false)
+	assert.That(t, "booked hold must not block", booked.Blocks("room-101", dateRange, now), false)
+	assert.That(t, "hold must not block other rooms", hold.Blocks("room-102", dateRange, now), false)
+	assert.That(t, "hold must not block the following nights", hold.Blocks("room-101", later, now), false)
+}
+
+// ============================================================================
+// HoldRoom Tests
+// ============================================================================
+
+func Test_Service_HoldRoom_Held_By_Other_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	service, _ := createHoldingTestService(newMockReservationRepository())
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+
+	// Act
+	_, holdErr := service.HoldRoom(ctx, "res-002", "guest-b", "room-101", serviceValidDateRange())
+	_, bookErr := service.CreateReservation(ctx, "res-003", "guest-b", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "hold must fail", errors.Is(holdErr, reservation.ErrRoomNotAvailable), true)
+	assert.That(t, "booking must fail", errors.Is(bookErr, reservation.ErrRoomNotAvailable), true)
+}
+
+func Test_Service_HoldRoom_Again_Should_Release_Previous_Hold_Of_Guest(t *testing.T) {
+	// Arrange
+	service, holds := createHoldingTestService(newMockReservationRepository())
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+
+	// Act
+	_, err := service.HoldRoom(ctx, "res-002", "guest-a", "room-101", serviceValidDateRange())
+
+	// Assert
+	all, _ := holds.ReadAll(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the new hold must be kept", len(all), 1)
+	assert.That(t, "kept hold must be the new one", all[0].ReservationID, reservation.ReservationID("res-002"))
+}
+
+// ============================================================================
+// Booking a Held Room Tests
+// ============================================================================
+
+func Test_Service_CreateReservation_Under_Own_Hold_Should_Book_Room(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service, holds := createHoldingTestService(repo)
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+
+	// Act
+	_, err := service.CreateReservation(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	hold, _ := holds.Read(ctx, "res-001")
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "hold must wait for the payment", hold.Status, reservation.HoldBooked)
+}
+
+func Test_Service_CreateReservation_Failing_Under_Own_Hold_Should_Keep_Hold(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service, holds := createHoldingTestService(repo)
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+	repo.createErr = errors.New("database down")
+
+	// Act
+	_, err := service.CreateReservation(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	hold, _ := holds.Read(ctx, "res-001")
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "hold must be active again", hold.Status, reservation.HoldActive)
+}
+
+func Test_Service_ConfirmReservation_Should_Convert_Hold(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service, holds := createHoldingTestService(repo)
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	err := service.ConfirmReservation(ctx, "res-001")
+
+	// Assert
+	all, _ := holds.ReadAll(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "hold must be deleted", len(all), 0)
+}
+
+func Test_Service_CancelReservation_Should_Release_Hold(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service, holds := createHoldingTestService(repo)
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	err := service.CancelReservation(ctx, "res-001", "payment failed")
+
+	// Assert
+	all, _ := holds.ReadAll(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "hold must be deleted", len(all), 0)
+}
+
+// ============================================================================
+// ExpireRoomHolds Tests
+// ============================================================================
+
+func Test_Service_ExpireRoomHolds_Should_Release_Expired_Holds(t *testing.T) {
+	// Arrange
+	service, holds := createHoldingTestService(newMockReservationRepository())
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+	_, _ = service.HoldRoom(ctx, "res-002", "guest-b", "room-102", serviceValidDateRange())
+
+	// Act
+	during, _ := service.ExpireRoomHolds(ctx, time.Now().Add(time.Minute))
+	after, err := service.ExpireRoomHolds(ctx, time.Now().Add(time.Hour))
+
+	// Assert
+	all, _ := holds.ReadAll(ctx)
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "no hold must be released during the hold", during, 0)
+	assert.That(t, "both holds must be released after the hold", after, 2)
+	assert.That(t, "holds must be deleted", len(all), 0)
+}
+
+func Test_Service_ExpireRoomHolds_Should_Keep_Hold_Of_Pending_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service, _ := createHoldingTestService(repo)
+	ctx := context.Background()
+	_, _ = service.HoldRoom(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange())
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-a", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	count, err := service.ExpireRoomHolds(ctx, time.Now().Add(time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "hold of the pending reservation must be kept", count, 0)
+}
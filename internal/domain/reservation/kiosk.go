@@ -0,0 +1,227 @@
+package reservation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrKioskSyncDisabled is returned by SyncKiosk if no operation repository is configured.
+var ErrKioskSyncDisabled = errors.New("kiosk sync is not enabled")
+
+// KioskAction is what a lobby kiosk did for a guest while it may have been offline.
+type KioskAction string
+
+// Kiosk actions.
+const (
+	KioskCheckIn KioskAction = "check_in"
+)
+
+// KioskOutcome is how the server resolved a kiosk operation.
+type KioskOutcome string
+
+// Kiosk outcomes. An applied operation changed the reservation; a conflict means another kiosk or the
+// desk got there first, so the reservation was left as it is; a rejected operation cannot be applied at all.
+const (
+	KioskApplied  KioskOutcome = "applied"
+	KioskConflict KioskOutcome = "conflict"
+	KioskRejected KioskOutcome = "rejected"
+)
+
+// KioskOperation is one action queued by a kiosk. The kiosk generates the ID when the guest acts,
+// so sending the queue again after a lost response does not apply an operation twice.
+type KioskOperation struct {
+	ID            string
+	Action        KioskAction
+	ReservationID ReservationID
+	PerformedAt   time.Time // when the guest acted at the kiosk, by the kiosk's clock
+}
+
+// KioskResult is the resolution of a kiosk operation. It is stored, and a replay of the operation
+// returns it unchanged, so every kiosk sees the same answer however often it syncs.
+type KioskResult struct {
+	KioskID       string
+	OperationID   string
+	ReservationID ReservationID
+	Outcome       KioskOutcome
+	Reason        string    // why the operation was rejected or conflicted; empty if applied
+	CheckedInAt   time.Time // when the reservation was checked in, by this or the conflicting operation; zero if unknown
+	CheckedInBy   string    // actor of the check-in, like in the status history; empty if unknown
+	ResolvedAt    time.Time
+}
+
+// WithKioskOperations lets lobby kiosks sync the check-ins they queued offline via SyncKiosk.
+// The results are stored in the repository, so replayed operations are answered the same way.
+func (s *Service) WithKioskOperations(repo KioskOperationRepository) *Service {
+	s.kioskRepo = repo
+	return s
+}
+
+// SyncsKiosks reports whether kiosks may sync their queued operations.
+func (s *Service) SyncsKiosks() bool {
+	return s.kioskRepo != nil
+}
+
+// ExpectedArrivals returns the confirmed and active reservations whose check-in day at the property is
+// the day of now, ordered by ID, for a kiosk to download before it may lose the connection.
+// Active ones are included so the kiosk knows they are checked in already. An empty propertyID means all.
+func (s *Service) ExpectedArrivals(ctx context.Context, propertyID PropertyID, now time.Time) ([]*Reservation, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	var arrivals []*Reservation
+	for _, r := range allReservations {
+		if r.Status != StatusConfirmed && r.Status != StatusActive {
+			continue
+		}
+		if !r.DateRange.Today(now).Equal(calendarDate(r.DateRange.CheckIn)) {
+			continue
+		}
+		if propertyID != "" && s.PropertyOf(&r) != propertyID {
+			continue
+		}
+		arrivals = append(arrivals, &r)
+	}
+	slices.SortFunc(arrivals, func(a, b *Reservation) int { return cmp.Compare(a.ID, b.ID) })
+	return arrivals, nil
+}
+
+// SyncKiosk applies the operations a kiosk of the property queued while offline and returns their results
+// in the order they were resolved: by PerformedAt, then by operation ID, so the same queue is always resolved
+// the same way. Operations already synced return their stored result. A check-in of a reservation checked in
+// elsewhere is a conflict and changes nothing; the first check-in to reach the server wins.
+// An empty propertyID accepts reservations of every property. Errors of the repositories abort the sync;
+// the kiosk sends the same queue again and gets the results of the operations resolved so far unchanged.
+func (s *Service) SyncKiosk(ctx context.Context, kioskID string, propertyID PropertyID, ops []KioskOperation) (results []KioskResult, err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.SyncKiosk", "kiosk_id", kioskID)
+	defer func() { span.End(err) }()
+
+	if s.kioskRepo == nil {
+		return nil, ErrKioskSyncDisabled
+	}
+	ops = slices.Clone(ops)
+	slices.SortStableFunc(ops, func(a, b KioskOperation) int {
+		return cmp.Or(a.PerformedAt.Compare(b.PerformedAt), cmp.Compare(a.ID, b.ID))
+	})
+	results = make([]KioskResult, 0, len(ops))
+	for _, op := range ops {
+		result, err := s.syncKioskOperation(ctx, kioskID, propertyID, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.ID, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// syncKioskOperation resolves one operation and stores its result, unless it was resolved before.
+func (s *Service) syncKioskOperation(ctx context.Context, kioskID string, propertyID PropertyID, op KioskOperation) (KioskResult, error) {
+	result := KioskResult{KioskID: kioskID, OperationID: op.ID, ReservationID: op.ReservationID, Outcome: KioskRejected}
+	switch {
+	case op.ID == "":
+		// Without an ID the result cannot be found again, so it is not stored.
+		result.Reason = "operation has no ID"
+		result.ResolvedAt = time.Now()
+		return result, nil
+	case op.Action != KioskCheckIn:
+		result.Reason = fmt.Sprintf("unknown action %q", op.Action)
+		return s.storeKioskResult(ctx, result)
+	case op.PerformedAt.IsZero():
+		result.Reason = "operation has no time"
+		return s.storeKioskResult(ctx, result)
+	}
+	if stored, ok := s.kioskResultOf(ctx, kioskID, op.ID); ok {
+		return stored, nil
+	}
+
+	res, err := s.reservationRepo.Read(ctx, op.ReservationID)
+	if err != nil || (propertyID != "" && s.PropertyOf(res) != propertyID) {
+		result.Reason = "reservation not found"
+		return s.storeKioskResult(ctx, result)
+	}
+
+	// Two kiosks syncing the same check-in serialize on the room, like two bookings of it.
+	unlock, _, err := s.lockRoom(ctx, res.RoomID)
+	if err != nil {
+		return KioskResult{}, err
+	}
+	defer unlock()
+	if stored, ok := s.kioskResultOf(ctx, kioskID, op.ID); ok {
+		return stored, nil
+	}
+	if res, err = s.reservationRepo.Read(ctx, op.ReservationID); err != nil {
+		return KioskResult{}, fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	switch res.Status {
+	case StatusActive, StatusCompleted:
+		result.Outcome = KioskConflict
+		result.Reason = "already checked in"
+		if change, ok := checkInOf(res); ok {
+			result.CheckedInAt = change.At
+			result.CheckedInBy = change.Actor
+		}
+	case StatusConfirmed:
+		performedOn := res.DateRange.Today(op.PerformedAt)
+		switch {
+		case performedOn.Before(calendarDate(res.DateRange.CheckIn)):
+			result.Reason = "performed before the check-in day"
+		case !performedOn.Before(calendarDate(res.DateRange.CheckOut)):
+			result.Reason = "performed after the stay"
+		default:
+			if err := s.ActivateReservation(ctx, op.ReservationID); err != nil {
+				return KioskResult{}, err
+			}
+			result.Outcome = KioskApplied
+			result.CheckedInAt = time.Now()
+			result.CheckedInBy = actorOf(ctx)
+			if res, err := s.reservationRepo.Read(ctx, op.ReservationID); err == nil {
+				if change, ok := checkInOf(res); ok {
+					result.CheckedInAt = change.At
+				}
+			}
+		}
+	default:
+		result.Reason = fmt.Sprintf("reservation is %s", res.Status)
+	}
+	return s.storeKioskResult(ctx, result)
+}
+
+// kioskResultOf returns the stored result of the operation of the kiosk, if it was resolved before.
+func (s *Service) kioskResultOf(ctx context.Context, kioskID, operationID string) (KioskResult, bool) {
+	stored, err := s.kioskRepo.Read(ctx, kioskOperationKey(kioskID, operationID))
+	if err != nil || stored == nil {
+		return KioskResult{}, false
+	}
+	return *stored, true
+}
+
+// storeKioskResult stores the result, so replays of the operation get it unchanged.
+func (s *Service) storeKioskResult(ctx context.Context, result KioskResult) (KioskResult, error) {
+	result.ResolvedAt = time.Now()
+	if err := s.kioskRepo.Create(ctx, kioskOperationKey(result.KioskID, result.OperationID), result); err != nil {
+		return KioskResult{}, fmt.Errorf("failed to persist kiosk operation: %w", err)
+	}
+	return result, nil
+}
+
+// kioskOperationKey keys the results by kiosk, since operation IDs are only unique per kiosk.
+func kioskOperationKey(kioskID, operationID string) string {
+	return kioskID + "/" + operationID
+}
+
+// checkInOf returns the status change that checked the reservation in, if it was recorded.
+func checkInOf(r *Reservation) (StatusChange, bool) {
+	for _, change := range r.StatusHistory() {
+		if change.To == StatusActive {
+			return change, true
+		}
+	}
+	return StatusChange{}, false
+}
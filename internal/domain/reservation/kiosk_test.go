@@ -0,0 +1,182 @@
+package reservation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Kiosk Test Helpers
+// ============================================================================
+
+// kioskCheckInDay is the check-in day of the sweep reservations (June 2nd).
+var kioskCheckInDay = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+// createKioskTestService creates a reservation service that syncs kiosks, with room-201 at the "annex".
+func createKioskTestService(repo *mockReservationRepository, publisher *mockEventPublisher) *reservation.Service {
+	return createTestService(repo, &mockAvailabilityChecker{available: true}, publisher).
+		WithProperties(mockRoomProperties{"room-201": "annex"}).
+		WithKioskOperations(resource.NewInMemoryAccess[string, reservation.KioskResult]())
+}
+
+// kioskContext authenticates the kiosk as a service account.
+func kioskContext() context.Context {
+	return shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalService, ClientID: "lobby-kiosk"})
+}
+
+func kioskCheckIn(id string, reservationID reservation.ReservationID, performedAt time.Time) reservation.KioskOperation {
+	return reservation.KioskOperation{ID: id, Action: reservation.KioskCheckIn, ReservationID: reservationID, PerformedAt: performedAt}
+}
+
+// ============================================================================
+// ExpectedArrivals Tests
+// ============================================================================
+
+func Test_Service_ExpectedArrivals_Should_Return_Confirmed_And_Active_Stays_Of_The_Day(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createKioskTestService(repo, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	storeSweepReservation(repo, "res-002", reservation.StatusActive, sweepNow)
+	storeSweepReservation(repo, "res-003", reservation.StatusPending, sweepNow)
+	storeSweepReservation(repo, "res-004", reservation.StatusConfirmed, sweepNow)
+	annex := repo.reservations["res-004"]
+	annex.RoomID = "room-201"
+	repo.reservations["res-004"] = annex
+
+	// Act
+	arrivals, err := service.ExpectedArrivals(context.Background(), "main", kioskCheckInDay)
+	tomorrow, _ := service.ExpectedArrivals(context.Background(), "main", kioskCheckInDay.AddDate(0, 0, 1))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "two stays must arrive", len(arrivals), 2)
+	assert.That(t, "confirmed stay must arrive first", arrivals[0].ID, reservation.ReservationID("res-001"))
+	assert.That(t, "active stay must be listed", arrivals[1].ID, reservation.ReservationID("res-002"))
+	assert.That(t, "nobody must arrive the next day", len(tomorrow), 0)
+}
+
+// ============================================================================
+// SyncKiosk Tests
+// ============================================================================
+
+func Test_Service_SyncKiosk_CheckIn_Should_Activate_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createKioskTestService(repo, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+
+	// Act
+	results, err := service.SyncKiosk(kioskContext(), "kiosk-1", "main", []reservation.KioskOperation{kioskCheckIn("op-1", "res-001", kioskCheckInDay)})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "operation must be applied", results[0].Outcome, reservation.KioskApplied)
+	assert.That(t, "check-in must be attributed to the kiosk", results[0].CheckedInBy, "service:lobby-kiosk")
+	assert.That(t, "status must be active", repo.reservations["res-001"].Status, reservation.StatusActive)
+	assert.That(t, "event must be published", publisher.published[0].Topic(), reservation.EventTopicActivated)
+}
+
+func Test_Service_SyncKiosk_Replayed_Operation_Should_Return_Stored_Result(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createKioskTestService(repo, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	ops := []reservation.KioskOperation{kioskCheckIn("op-1", "res-001", kioskCheckInDay)}
+	first, _ := service.SyncKiosk(kioskContext(), "kiosk-1", "main", ops)
+
+	// Act
+	replayed, err := service.SyncKiosk(kioskContext(), "kiosk-1", "main", ops)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "replay must return the stored result", replayed, first)
+	assert.That(t, "event must be published once", len(publisher.published), 1)
+}
+
+func Test_Service_SyncKiosk_CheckIn_Elsewhere_Should_Conflict(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createKioskTestService(repo, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	first, _ := service.SyncKiosk(kioskContext(), "kiosk-1", "main", []reservation.KioskOperation{kioskCheckIn("op-1", "res-001", kioskCheckInDay)})
+
+	// Act
+	results, err := service.SyncKiosk(kioskContext(), "kiosk-2", "main", []reservation.KioskOperation{kioskCheckIn("op-1", "res-001", kioskCheckInDay.Add(-time.Hour))})
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "operation must conflict", results[0].Outcome, reservation.KioskConflict)
+	assert.That(t, "conflict must tell when the guest was checked in", results[0].CheckedInAt.Equal(repo.reservations["res-001"].UpdatedAt), true)
+	assert.That(t, "conflict must tell who checked the guest in", results[0].CheckedInBy, first[0].CheckedInBy)
+}
+
+func Test_Service_SyncKiosk_Should_Resolve_Operations_In_Order_Performed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createKioskTestService(repo, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	ops := []reservation.KioskOperation{
+		kioskCheckIn("op-b", "res-001", kioskCheckInDay.Add(time.Minute)),
+		kioskCheckIn("op-a", "res-001", kioskCheckInDay),
+	}
+
+	// Act
+	results, err := service.SyncKiosk(kioskContext(), "kiosk-1", "main", ops)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "earlier operation must be resolved first", results[0].OperationID, "op-a")
+	assert.That(t, "earlier operation must be applied", results[0].Outcome, reservation.KioskApplied)
+	assert.That(t, "later operation must conflict", results[1].Outcome, reservation.KioskConflict)
+}
+
+func Test_Service_SyncKiosk_Invalid_Operations_Should_Be_Rejected(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createKioskTestService(repo, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	storeSweepReservation(repo, "res-002", reservation.StatusCancelled, sweepNow)
+	storeSweepReservation(repo, "res-003", reservation.StatusConfirmed, sweepNow)
+	annex := repo.reservations["res-003"]
+	annex.RoomID = "room-201"
+	repo.reservations["res-003"] = annex
+	ops := []reservation.KioskOperation{
+		kioskCheckIn("op-1", "res-001", kioskCheckInDay.AddDate(0, 0, -1)),
+		kioskCheckIn("op-2", "res-002", kioskCheckInDay),
+		kioskCheckIn("op-3", "res-003", kioskCheckInDay),
+		kioskCheckIn("op-4", "res-404", kioskCheckInDay),
+	}
+
+	// Act
+	results, err := service.SyncKiosk(kioskContext(), "kiosk-1", "main", ops)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	for _, result := range results {
+		assert.That(t, "operation "+result.OperationID+" must be rejected", result.Outcome, reservation.KioskRejected)
+	}
+	assert.That(t, "early check-in must tell why", results[0].Reason, "performed before the check-in day")
+	assert.That(t, "cancelled reservation must tell why", results[1].Reason, "reservation is cancelled")
+	assert.That(t, "reservation must stay confirmed", repo.reservations["res-001"].Status, reservation.StatusConfirmed)
+	assert.That(t, "reservation of the annex must stay confirmed", repo.reservations["res-003"].Status, reservation.StatusConfirmed)
+}
+
+func Test_Service_SyncKiosk_Without_Repository_Should_Fail(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+
+	// Act
+	_, err := service.SyncKiosk(kioskContext(), "kiosk-1", "main", nil)
+
+	// Assert
+	assert.That(t, "err must be ErrKioskSyncDisabled", err, reservation.ErrKioskSyncDisabled)
+}
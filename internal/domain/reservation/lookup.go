@@ -0,0 +1,88 @@
+package reservation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLookupFailed is returned if no reservation matches a confirmation code and email or last name.
+// It does not tell which of the two did not match, so the lookup cannot be used to probe for codes.
+var ErrLookupFailed = errors.New("no reservation matches the confirmation code and email or last name")
+
+// confirmationCodeEncoding is Crockford's base32: no I, L, O or U, so codes read out over the phone are unambiguous.
+var confirmationCodeEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// ConfirmationCode returns the code guests quote to find their reservation: the confirmation number
+// if the reservation got one (e.g. "BER-2025-00123"), otherwise the code derived from its ID (e.g. "7KQ2-M9XD").
+func (r *Reservation) ConfirmationCode() string {
+	if r.ConfirmationNumber != "" {
+		return r.ConfirmationNumber
+	}
+	return ConfirmationCodeOf(r.ID)
+}
+
+// ConfirmationCodeOf derives the confirmation code from the reservation ID, so every reservation
+// has one without storing it, including those booked by staff or channel managers.
+func ConfirmationCodeOf(id ReservationID) string {
+	sum := sha256.Sum256([]byte(id))
+	code := confirmationCodeEncoding.EncodeToString(sum[:5])
+	return code[:4] + "-" + code[4:]
+}
+
+// NormalizeConfirmationCode returns the code as ConfirmationCodeOf formats it.
+// Case, spaces and dashes are ignored, and the letters mistaken for digits are read as such.
+func NormalizeConfirmationCode(code string) string {
+	code = strings.NewReplacer("-", "", " ", "", "O", "0", "I", "1", "L", "1").Replace(strings.ToUpper(code))
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// MatchesGuest reports whether the email or last name belongs to the reservation: the contact email,
+// the email of any guest, or the last name of any guest, ignoring case.
+func (r *Reservation) MatchesGuest(emailOrLastName string) bool {
+	value := strings.TrimSpace(emailOrLastName)
+	if value == "" {
+		return false
+	}
+	if strings.Contains(value, "@") {
+		if strings.EqualFold(r.GuestEmail, value) {
+			return true
+		}
+		for _, g := range r.Guests {
+			if strings.EqualFold(g.Email, value) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, g := range r.Guests {
+		names := strings.Fields(g.Name)
+		if len(names) > 0 && strings.EqualFold(names[len(names)-1], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupReservation finds the reservation of a guest without an account by its confirmation code
+// (or confirmation number) and the guest's email or last name. Any mismatch is ErrLookupFailed.
+func (s *Service) LookupReservation(ctx context.Context, code, emailOrLastName string) (*Reservation, error) {
+	code = NormalizeConfirmationCode(code)
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	for i := range allReservations {
+		res := &allReservations[i]
+		if NormalizeConfirmationCode(res.ConfirmationCode()) == code && res.MatchesGuest(emailOrLastName) {
+			return res, nil
+		}
+	}
+	return nil, ErrLookupFailed
+}
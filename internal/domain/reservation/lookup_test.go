@@ -0,0 +1,118 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Lookup Test Helpers
+// ============================================================================
+
+// storeLookupReservation stores a reservation of Jane Smith, booked by the desk under the staff email.
+func storeLookupReservation(repo *mockReservationRepository) reservation.Reservation {
+	res := reservation.Reservation{
+		ID:         "res-001",
+		GuestID:    "guest-001",
+		GuestEmail: "frontdesk@hotel.example",
+		RoomID:     "room-101",
+		Status:     reservation.StatusConfirmed,
+		Guests: []reservation.GuestInfo{
+			reservation.NewGuestInfo("Jane van Smith", "jane@example.com", "+1234567890"),
+		},
+	}
+	repo.reservations[res.ID] = res
+	return res
+}
+
+// ============================================================================
+// ConfirmationCode Tests
+// ============================================================================
+
+func Test_ConfirmationCodeOf_Should_Be_Stable_And_Readable(t *testing.T) {
+	// Arrange
+	id := reservation.ReservationID("res-001")
+
+	// Act
+	code := reservation.ConfirmationCodeOf(id)
+
+	// Assert
+	assert.That(t, "code must be stable", reservation.ConfirmationCodeOf(id), code)
+	assert.That(t, "code must have two groups of four", len(code), 9)
+	assert.That(t, "code must not contain ambiguous letters", strings.ContainsAny(code, "ILOU"), false)
+}
+
+func Test_NormalizeConfirmationCode_Should_Ignore_Case_Dashes_And_Lookalikes(t *testing.T) {
+	// Arrange
+	code := " 7kq2 m9xo "
+
+	// Act
+	normalized := reservation.NormalizeConfirmationCode(code)
+
+	// Assert
+	assert.That(t, "code must be normalized", normalized, "7KQ2-M9X0")
+}
+
+// ============================================================================
+// LookupReservation Tests
+// ============================================================================
+
+func Test_Service_LookupReservation_By_Guest_Email_Should_Return_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	res := storeLookupReservation(repo)
+	code := strings.ToLower(strings.ReplaceAll(res.ConfirmationCode(), "-", ""))
+
+	// Act
+	found, err := service.LookupReservation(context.Background(), code, "JANE@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "reservation must be found", found.ID, res.ID)
+}
+
+func Test_Service_LookupReservation_By_Last_Name_Should_Return_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	res := storeLookupReservation(repo)
+
+	// Act
+	found, err := service.LookupReservation(context.Background(), res.ConfirmationCode(), " smith ")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "reservation must be found", found.ID, res.ID)
+}
+
+func Test_Service_LookupReservation_With_First_Name_Should_Return_ErrLookupFailed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	res := storeLookupReservation(repo)
+
+	// Act
+	_, err := service.LookupReservation(context.Background(), res.ConfirmationCode(), "Jane")
+
+	// Assert
+	assert.That(t, "err must be ErrLookupFailed", errors.Is(err, reservation.ErrLookupFailed), true)
+}
+
+func Test_Service_LookupReservation_With_Unknown_Code_Should_Return_ErrLookupFailed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	storeLookupReservation(repo)
+
+	// Act
+	_, err := service.LookupReservation(context.Background(), "0000-0000", "jane@example.com")
+
+	// Assert
+	assert.That(t, "err must be ErrLookupFailed", errors.Is(err, reservation.ErrLookupFailed), true)
+}
@@ -0,0 +1,198 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxNumberAttempts limits the numbers tried for one reservation when other instances take them first.
+const maxNumberAttempts = 10
+
+var (
+	// ErrInvalidNumberingScheme is returned if a numbering format has no {SEQ} or an unknown placeholder.
+	ErrInvalidNumberingScheme = errors.New("invalid confirmation number format")
+	// ErrNumberUnavailable is returned if no confirmation number could be claimed, e.g. because the repository is down.
+	ErrNumberUnavailable = errors.New("no confirmation number available")
+)
+
+// NumberingScheme formats the confirmation numbers of a property, e.g. "BER-{YYYY}-{SEQ:5}" for BER-2025-00123.
+// Placeholders are {YYYY} and {YY} for the year of booking and {SEQ} or {SEQ:width} for the sequence.
+// The text around the sequence is its scope: each scope counts from 1, so a prefix per property
+// gives every property its own numbers, and a year in the format restarts them every year.
+type NumberingScheme struct {
+	format string
+	width  int
+}
+
+// ParseNumberingScheme parses a numbering format; it must contain the sequence exactly once.
+func ParseNumberingScheme(format string) (NumberingScheme, error) {
+	scheme := NumberingScheme{format: strings.ToUpper(strings.TrimSpace(format))}
+	sequences := 0
+	rest := scheme.format
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return NumberingScheme{}, fmt.Errorf("%w: unclosed placeholder in %q", ErrInvalidNumberingScheme, format)
+		}
+		placeholder := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+		switch {
+		case placeholder == "YYYY" || placeholder == "YY":
+		case placeholder == "SEQ":
+			sequences++
+		case strings.HasPrefix(placeholder, "SEQ:"):
+			width, err := strconv.Atoi(placeholder[len("SEQ:"):])
+			if err != nil || width < 1 || width > 12 {
+				return NumberingScheme{}, fmt.Errorf("%w: sequence width of %q must be 1 to 12", ErrInvalidNumberingScheme, format)
+			}
+			scheme.width = width
+			sequences++
+		default:
+			return NumberingScheme{}, fmt.Errorf("%w: unknown placeholder {%s} in %q", ErrInvalidNumberingScheme, placeholder, format)
+		}
+	}
+	// Numbers are quoted in URLs and accepted where reservation IDs are, so the text is limited like legacy IDs.
+	for _, c := range scheme.render(time.Time{}, "0") {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return NumberingScheme{}, fmt.Errorf("%w: %q may only contain letters, digits, '-', '_' and '.'", ErrInvalidNumberingScheme, format)
+		}
+	}
+	if sequences != 1 {
+		return NumberingScheme{}, fmt.Errorf("%w: %q must contain {SEQ} once", ErrInvalidNumberingScheme, format)
+	}
+	return scheme, nil
+}
+
+// Scope returns the text of the number apart from the sequence at the time, e.g. "BER-2025-".
+func (n NumberingScheme) Scope(at time.Time) string {
+	return n.render(at, "")
+}
+
+// Format returns the confirmation number with the sequence at the time, e.g. "BER-2025-00123".
+func (n NumberingScheme) Format(at time.Time, seq int) string {
+	digits := strconv.Itoa(seq)
+	if len(digits) < n.width {
+		digits = strings.Repeat("0", n.width-len(digits)) + digits
+	}
+	return n.render(at, digits)
+}
+
+// render replaces the placeholders of the format.
+func (n NumberingScheme) render(at time.Time, seq string) string {
+	year := strconv.Itoa(at.UTC().Year())
+	var b strings.Builder
+	rest := n.format
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String()
+		}
+		end := start + strings.Index(rest[start:], "}")
+		b.WriteString(rest[:start])
+		switch placeholder := rest[start+1 : end]; placeholder {
+		case "YYYY":
+			b.WriteString(year)
+		case "YY":
+			b.WriteString(year[len(year)-2:])
+		default:
+			b.WriteString(seq)
+		}
+		rest = rest[end+1:]
+	}
+}
+
+// NumberClaim records that a confirmation number was given to a reservation.
+// Claims are keyed by the number, so the repository refuses a number that was taken
+// by another instance, and a number is found without reading the reservations.
+type NumberClaim struct {
+	Number        string        `json:"number"`
+	Scope         string        `json:"scope"`
+	Sequence      int           `json:"sequence"`
+	ReservationID ReservationID `json:"reservation_id"`
+	ClaimedAt     time.Time     `json:"claimed_at"`
+}
+
+// confirmationNumbers hands out the numbers of the scheme; the last sequence of each scope is
+// kept in memory and read from the claims again when another instance took a number.
+type confirmationNumbers struct {
+	scheme NumberingScheme
+	claims NumberClaimRepository
+	mu     sync.Mutex // serializes the numbering within this instance
+	heads  map[string]int
+}
+
+// WithConfirmationNumbers gives every new reservation a confirmation number of the scheme,
+// e.g. BER-2025-00123, stored with the reservation and shown instead of the derived confirmation code.
+func (s *Service) WithConfirmationNumbers(scheme NumberingScheme, claims NumberClaimRepository) *Service {
+	s.numbers = &confirmationNumbers{scheme: scheme, claims: claims, heads: make(map[string]int)}
+	return s
+}
+
+// claim takes the next number of the scope for the reservation.
+func (c *confirmationNumbers) claim(ctx context.Context, id ReservationID, at time.Time) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	scope := c.scheme.Scope(at)
+	for range maxNumberAttempts {
+		head, ok := c.heads[scope]
+		if !ok {
+			var err error
+			if head, err = c.readHead(ctx, scope); err != nil {
+				return "", err
+			}
+		}
+		claim := NumberClaim{
+			Number:        c.scheme.Format(at, head+1),
+			Scope:         scope,
+			Sequence:      head + 1,
+			ReservationID: id,
+			ClaimedAt:     at.UTC(),
+		}
+		if err := c.claims.Create(ctx, claim.Number, claim); err != nil {
+			// Another instance may have taken the number, so read the head again.
+			delete(c.heads, scope)
+			continue
+		}
+		c.heads[scope] = claim.Sequence
+		return claim.Number, nil
+	}
+	return "", fmt.Errorf("%w in scope %q", ErrNumberUnavailable, scope)
+}
+
+// readHead returns the highest sequence claimed in the scope.
+func (c *confirmationNumbers) readHead(ctx context.Context, scope string) (int, error) {
+	claims, err := c.claims.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to read claims: %w", ErrNumberUnavailable, err)
+	}
+	head := 0
+	for _, claim := range claims {
+		if claim.Scope == scope {
+			head = max(head, claim.Sequence)
+		}
+	}
+	return head, nil
+}
+
+// FindReservation returns the reservation of a reference given by staff, integrators or guests:
+// a confirmation number of the scheme or a reservation ID.
+func (s *Service) FindReservation(ctx context.Context, ref string) (*Reservation, error) {
+	ref = strings.TrimSpace(ref)
+	if s.numbers != nil {
+		if claim, err := s.numbers.claims.Read(ctx, strings.ToUpper(ref)); err == nil && claim != nil {
+			return s.GetReservation(ctx, claim.ReservationID)
+		}
+	}
+	return s.GetReservation(ctx, ReservationID(ref))
+}
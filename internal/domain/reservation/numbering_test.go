@@ -0,0 +1,173 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// NumberingScheme Tests
+// ============================================================================
+
+func Test_ParseNumberingScheme_Should_Format_Year_And_Padded_Sequence(t *testing.T) {
+	// Arrange
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	scheme, err := reservation.ParseNumberingScheme("ber-{YYYY}-{SEQ:5}")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "number must match", scheme.Format(at, 123), "BER-2025-00123")
+	assert.That(t, "scope must be the number without the sequence", scheme.Scope(at), "BER-2025-")
+}
+
+func Test_ParseNumberingScheme_With_Invalid_Format_Should_Return_Error(t *testing.T) {
+	for _, format := range []string{"BER-{YYYY}", "BER-{SEQ}-{SEQ}", "BER-{MONTH}-{SEQ}", "BER/{SEQ}", "BER-{SEQ:0}", "BER-{SEQ"} {
+		// Act
+		_, err := reservation.ParseNumberingScheme(format)
+
+		// Assert
+		assert.That(t, "err must be ErrInvalidNumberingScheme for "+format, errors.Is(err, reservation.ErrInvalidNumberingScheme), true)
+	}
+}
+
+// ============================================================================
+// WithConfirmationNumbers Tests
+// ============================================================================
+
+func createNumberingTestService(t *testing.T, repo *mockReservationRepository, claims reservation.NumberClaimRepository) *reservation.Service {
+	t.Helper()
+	scheme, err := reservation.ParseNumberingScheme("BER-{YYYY}-{SEQ:5}")
+	assert.That(t, "scheme must be valid", err, nil)
+	return createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).WithConfirmationNumbers(scheme, claims)
+}
+
+func Test_Service_CreateReservation_With_Confirmation_Numbers_Should_Number_In_Sequence(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createNumberingTestService(t, repo, resource.NewInMemoryAccess[string, reservation.NumberClaim]())
+	year := time.Now().UTC().Year()
+
+	// Act
+	first, err1 := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	second, err2 := service.CreateReservation(context.Background(), "res-002", "guest-001", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "err1 must be nil", err1, nil)
+	assert.That(t, "err2 must be nil", err2, nil)
+	assert.That(t, "first number must match", first.ConfirmationNumber, fmt.Sprintf("BER-%d-00001", year))
+	assert.That(t, "second number must match", second.ConfirmationNumber, fmt.Sprintf("BER-%d-00002", year))
+	assert.That(t, "number must be stored", repo.reservations["res-002"].ConfirmationNumber, second.ConfirmationNumber)
+	assert.That(t, "number must be the confirmation code", second.ConfirmationCode(), second.ConfirmationNumber)
+}
+
+func Test_Service_CreateReservation_With_Number_Taken_By_Other_Instance_Should_Take_Next(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	claims := resource.NewInMemoryAccess[string, reservation.NumberClaim]()
+	service := createNumberingTestService(t, repo, claims)
+	other := createNumberingTestService(t, newMockReservationRepository(), claims)
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	taken, _ := other.CreateReservation(ctx, "res-002", "guest-002", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	res, err := service.CreateReservation(ctx, "res-003", "guest-001", "room-103", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	year := time.Now().UTC().Year()
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "other instance must have taken the second number", taken.ConfirmationNumber, fmt.Sprintf("BER-%d-00002", year))
+	assert.That(t, "number must follow the taken one", res.ConfirmationNumber, fmt.Sprintf("BER-%d-00003", year))
+}
+
+// concurrentEventPublisher discards events; unlike mockEventPublisher it may be called concurrently.
+type concurrentEventPublisher struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (p *concurrentEventPublisher) Publish(ctx context.Context, evt event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	return nil
+}
+
+func Test_Service_CreateReservation_Concurrently_Should_Give_Unique_Numbers(t *testing.T) {
+	// Arrange
+	scheme, _ := reservation.ParseNumberingScheme("BER-{YYYY}-{SEQ:5}")
+	service := reservation.NewService(resource.NewInMemoryAccess[reservation.ReservationID, reservation.Reservation](), &mockAvailabilityChecker{available: true}, &concurrentEventPublisher{}).
+		WithConfirmationNumbers(scheme, resource.NewInMemoryAccess[string, reservation.NumberClaim]())
+	const bookings = 20
+	numbers := make([]string, bookings)
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range bookings {
+		wg.Go(func() {
+			id := reservation.ReservationID(fmt.Sprintf("res-%03d", i))
+			res, err := service.CreateReservation(context.Background(), id, "guest-001", reservation.RoomID(fmt.Sprintf("room-%03d", i)), serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+			if err == nil {
+				numbers[i] = res.ConfirmationNumber
+			}
+		})
+	}
+	wg.Wait()
+
+	// Assert
+	seen := make(map[string]bool)
+	for _, number := range numbers {
+		assert.That(t, "number must be assigned", number != "", true)
+		assert.That(t, "number must be unique", seen[number], false)
+		seen[number] = true
+	}
+}
+
+// ============================================================================
+// FindReservation Tests
+// ============================================================================
+
+func Test_Service_FindReservation_By_Number_Or_ID_Should_Return_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createNumberingTestService(t, repo, resource.NewInMemoryAccess[string, reservation.NumberClaim]())
+	ctx := context.Background()
+	created, _ := service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	byNumber, err1 := service.FindReservation(ctx, " "+strings.ToLower(created.ConfirmationNumber)+" ")
+	byID, err2 := service.FindReservation(ctx, "res-001")
+
+	// Assert
+	assert.That(t, "err1 must be nil", err1, nil)
+	assert.That(t, "err2 must be nil", err2, nil)
+	assert.That(t, "number must find the reservation", byNumber.ID, reservation.ReservationID("res-001"))
+	assert.That(t, "ID must find the reservation", byID.ID, reservation.ReservationID("res-001"))
+}
+
+func Test_Service_LookupReservation_By_Confirmation_Number_Should_Return_Reservation(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createNumberingTestService(t, repo, resource.NewInMemoryAccess[string, reservation.NumberClaim]())
+	ctx := context.Background()
+	created, _ := service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	found, err := service.LookupReservation(ctx, created.ConfirmationNumber, "Doe")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "reservation must be found", found.ID, reservation.ReservationID("res-001"))
+}
@@ -0,0 +1,396 @@
+package reservation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// OverstayOutcome tells how the overstay of a check-out day was handled.
+type OverstayOutcome string
+
+// Overstay outcomes. An offered night is accepted, declined or expires; the front desk is alerted
+// about every overstay that does not end in an extension.
+const (
+	OverstayOffered     OverstayOutcome = "offered"     // the next night is free and offered to the guest
+	OverstayAccepted    OverstayOutcome = "accepted"    // the guest extended the stay by the night
+	OverstayDeclined    OverstayOutcome = "declined"    // the guest declined the night
+	OverstayExpired     OverstayOutcome = "expired"     // the guest did not answer the offer in time
+	OverstayUnavailable OverstayOutcome = "unavailable" // the room is booked or held for the next night, or has no price
+)
+
+// Overstay errors.
+var (
+	ErrInvalidOverstayPolicy = errors.New("check-out time must be HH:MM and extension offers must last")
+	ErrOverstayRecorded      = errors.New("overstay of the check-out day already recorded")
+	ErrNoExtensionOffer      = errors.New("no open offer to extend the stay")
+)
+
+// Overstay records that the guest was still checked in after check-out time on the check-out day,
+// and how it was handled.
+type Overstay struct {
+	CheckOut   time.Time // check-out day that was overstayed (midnight UTC like DateRange)
+	DetectedAt time.Time
+	Outcome    OverstayOutcome
+	Amount     Money     // price of the offered night; zero if none was offered
+	ExpiresAt  time.Time // end of the offer; zero if none was offered
+	DecidedAt  time.Time // when the offer was accepted, declined or expired
+	AlertedAt  time.Time // when the front desk was alerted; zero for open and accepted offers
+}
+
+// OverstayPolicy configures when a stay counts as overstayed and how long the offer of another night lasts.
+type OverstayPolicy struct {
+	CheckOutTime time.Duration // time of day at the property, e.g. 11h for 11:00
+	OfferTTL     time.Duration // offers end at midnight of the check-out day at the latest
+}
+
+// DefaultOverstayPolicy returns check-out at 11:00 with offers lasting two hours.
+func DefaultOverstayPolicy() OverstayPolicy {
+	return OverstayPolicy{CheckOutTime: 11 * time.Hour, OfferTTL: 2 * time.Hour}
+}
+
+// NewOverstayPolicy returns the policy with check-out at checkOutTime (HH:MM at the property) and offers lasting offerTTL,
+// or ErrInvalidOverstayPolicy.
+func NewOverstayPolicy(checkOutTime string, offerTTL time.Duration) (OverstayPolicy, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(checkOutTime))
+	if err != nil || offerTTL <= 0 {
+		return OverstayPolicy{}, fmt.Errorf("%w: %q, %s", ErrInvalidOverstayPolicy, checkOutTime, offerTTL)
+	}
+	return OverstayPolicy{CheckOutTime: time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, OfferTTL: offerTTL}, nil
+}
+
+// checkOutAt returns the instant check-out time passes on the check-out day at the property.
+func (p OverstayPolicy) checkOutAt(d DateRange) time.Time {
+	day := calendarDate(d.CheckOut)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, d.Location()).Add(p.CheckOutTime)
+}
+
+// due reports whether the overstay sweep has something to do for the reservation at now: detect an overstay,
+// expire an offer or alert about a declined one. Only the check-out day counts; afterwards the stay is completed.
+func (p OverstayPolicy) due(r *Reservation, now time.Time) bool {
+	if r.Status != StatusActive || !r.DateRange.Today(now).Equal(calendarDate(r.DateRange.CheckOut)) || now.Before(p.checkOutAt(r.DateRange)) {
+		return false
+	}
+	o := r.overstay()
+	return o == nil ||
+		(o.Outcome == OverstayOffered && !now.Before(o.ExpiresAt)) ||
+		(o.Outcome == OverstayDeclined && o.AlertedAt.IsZero())
+}
+
+// nextNight returns the night after the stay, the one an extension adds.
+func nextNight(d DateRange) DateRange {
+	d.CheckIn, d.CheckOut = d.CheckOut, d.CheckOut.AddDate(0, 0, 1)
+	return d
+}
+
+// overstay returns the overstay of the current check-out day, or nil if none was recorded.
+func (r *Reservation) overstay() *Overstay {
+	day := calendarDate(r.DateRange.CheckOut)
+	for i := range r.Overstays {
+		if r.Overstays[i].CheckOut.Equal(day) {
+			return &r.Overstays[i]
+		}
+	}
+	return nil
+}
+
+// ExtensionOffer returns the open offer to stay another night, or nil if there is none at now.
+func (r *Reservation) ExtensionOffer(now time.Time) *Overstay {
+	o := r.overstay()
+	if r.Status != StatusActive || o == nil || o.Outcome != OverstayOffered || !now.Before(o.ExpiresAt) {
+		return nil
+	}
+	return o
+}
+
+// OfferExtension records the overstay of the check-out day with the offer to stay another night for amount until expiresAt.
+func (r *Reservation) OfferExtension(now time.Time, amount Money, expiresAt time.Time) error {
+	if err := r.canRecordOverstay(); err != nil {
+		return err
+	}
+	r.Overstays = append(r.Overstays, Overstay{
+		CheckOut:   calendarDate(r.DateRange.CheckOut),
+		DetectedAt: now,
+		Outcome:    OverstayOffered,
+		Amount:     amount,
+		ExpiresAt:  expiresAt,
+	})
+	r.UpdatedAt = now
+	return nil
+}
+
+// RecordUnavailableOverstay records the overstay of the check-out day when the next night cannot be offered;
+// the front desk is alerted right away.
+func (r *Reservation) RecordUnavailableOverstay(now time.Time) error {
+	if err := r.canRecordOverstay(); err != nil {
+		return err
+	}
+	r.Overstays = append(r.Overstays, Overstay{
+		CheckOut:   calendarDate(r.DateRange.CheckOut),
+		DetectedAt: now,
+		Outcome:    OverstayUnavailable,
+		AlertedAt:  now,
+	})
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *Reservation) canRecordOverstay() error {
+	if r.Status != StatusActive {
+		return fmt.Errorf("%w: cannot record an overstay of a %s reservation", ErrInvalidStateTransition, r.Status)
+	}
+	if r.overstay() != nil {
+		return ErrOverstayRecorded
+	}
+	return nil
+}
+
+// AcceptExtension moves the check-out a day later and returns the accepted offer, whose amount is charged on the folio.
+func (r *Reservation) AcceptExtension(now time.Time) (Overstay, error) {
+	o := r.ExtensionOffer(now)
+	if o == nil {
+		return Overstay{}, ErrNoExtensionOffer
+	}
+	o.Outcome, o.DecidedAt = OverstayAccepted, now
+	accepted := *o
+	r.DateRange.CheckOut = nextNight(r.DateRange).CheckOut
+	r.UpdatedAt = now
+	return accepted, nil
+}
+
+// DeclineExtension declines the offered night; the next overstay sweep alerts the front desk.
+func (r *Reservation) DeclineExtension(now time.Time) error {
+	o := r.ExtensionOffer(now)
+	if o == nil {
+		return ErrNoExtensionOffer
+	}
+	o.Outcome, o.DecidedAt = OverstayDeclined, now
+	r.UpdatedAt = now
+	return nil
+}
+
+// closeOverstay expires an offer that was not answered in time and marks the overstay as alerted.
+func (r *Reservation) closeOverstay(now time.Time) {
+	o := r.overstay()
+	if o.Outcome == OverstayOffered {
+		o.Outcome, o.DecidedAt = OverstayExpired, o.ExpiresAt
+	}
+	o.AlertedAt = now
+	r.UpdatedAt = now
+}
+
+// OverstayAlert tells the front desk and housekeeping that a guest is still in the room after
+// check-out time without extending the stay, so the room is not ready for the next guest.
+type OverstayAlert struct {
+	ReservationID ReservationID
+	RoomID        RoomID
+	PropertyID    PropertyID
+	CheckOut      time.Time
+	Outcome       OverstayOutcome // unavailable, declined or expired
+}
+
+// OverstayReport lists what an overstay sweep did: the reservations offered another night and the alerts.
+type OverstayReport struct {
+	Offered []ReservationID
+	Alerts  []OverstayAlert
+}
+
+// WithOverstays handles the guests still checked in after check-out time on their check-out day (HandleOverstays):
+// the next night is offered at the price of prices if the room is free, and the offer is sent by offers.
+func (s *Service) WithOverstays(policy OverstayPolicy, prices NightPrices, offers ExtensionOffers) *Service {
+	s.overstayPolicy = &policy
+	s.nightPrices = prices
+	s.extensionOffers = offers
+	return s
+}
+
+// HandleOverstays handles the active reservations past check-out time on their check-out day. A guest whose
+// room is free the next night is offered it; otherwise, and when an offer is declined or expires, the front desk
+// is alerted. Like the other sweeps, each reservation is handled on its own and running it twice changes nothing.
+func (s *Service) HandleOverstays(ctx context.Context, now time.Time) (report OverstayReport, err error) {
+	if s.overstayPolicy == nil {
+		return OverstayReport{}, nil
+	}
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.HandleOverstays")
+	defer func() { span.End(err) }()
+
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return OverstayReport{}, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var errs []error
+	for i := range allReservations {
+		if !s.overstayPolicy.due(&allReservations[i], now) {
+			continue
+		}
+		id := allReservations[i].ID
+		alert, err := s.handleOverstay(ctx, id, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", id, err))
+			continue
+		}
+		if alert != nil {
+			report.Alerts = append(report.Alerts, *alert)
+		} else {
+			report.Offered = append(report.Offered, id)
+		}
+	}
+	slices.Sort(report.Offered)
+	slices.SortFunc(report.Alerts, func(a, b OverstayAlert) int { return cmp.Compare(a.ReservationID, b.ReservationID) })
+
+	return report, errors.Join(errs...)
+}
+
+// handleOverstay offers the next night or records the alert, and returns the alert, or nil if the night was offered.
+func (s *Service) handleOverstay(ctx context.Context, id ReservationID, now time.Time) (*OverstayAlert, error) {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	before := s.state(reservation)
+	if reservation.overstay() != nil {
+		reservation.closeOverstay(now)
+	} else if err := s.detectOverstay(ctx, reservation, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+	s.audit(ctx, shared.AuditOverstay, before, reservation)
+
+	overstay := reservation.overstay()
+	if overstay.Outcome == OverstayOffered {
+		if err := s.extensionOffers.SendExtensionOffer(ctx, reservation, *overstay); err != nil {
+			return nil, fmt.Errorf("failed to send extension offer: %w", err)
+		}
+		return nil, nil
+	}
+	return &OverstayAlert{
+		ReservationID: id,
+		RoomID:        reservation.RoomID,
+		PropertyID:    s.PropertyOf(reservation),
+		CheckOut:      overstay.CheckOut,
+		Outcome:       overstay.Outcome,
+	}, nil
+}
+
+// detectOverstay records the overstay of the check-out day: the offer of the next night at its price if the room
+// is free, or an unavailable overstay. Offers end at midnight of the check-out day, when the stay is completed.
+func (s *Service) detectOverstay(ctx context.Context, reservation *Reservation, now time.Time) error {
+	night := nextNight(reservation.DateRange)
+	free, err := s.isNightFree(ctx, s.availabilityChecker, reservation, night)
+	if err != nil {
+		return err
+	}
+	if !free {
+		return reservation.RecordUnavailableOverstay(now)
+	}
+	price, err := s.nightPrices.NightPrice(ctx, reservation.RoomID, night.CheckIn)
+	if err != nil || price.Amount <= 0 || price.Currency != reservation.TotalAmount.Currency {
+		// A night without a price in the currency of the stay cannot be charged on its folio, so the front desk decides.
+		return reservation.RecordUnavailableOverstay(now)
+	}
+	expiresAt := now.Add(s.overstayPolicy.OfferTTL)
+	if midnight := night.CheckInStart().AddDate(0, 0, 1); expiresAt.After(midnight) {
+		expiresAt = midnight
+	}
+	return reservation.OfferExtension(now, price, expiresAt)
+}
+
+// isNightFree reports whether the room of the reservation is neither booked nor held for another guest for the night.
+func (s *Service) isNightFree(ctx context.Context, checker AvailabilityChecker, reservation *Reservation, night DateRange) (bool, error) {
+	available, err := checker.IsRoomAvailable(ctx, reservation.RoomID, night)
+	if err != nil {
+		return false, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available || s.roomHolds == nil {
+		return available, nil
+	}
+	held, err := s.roomHolds.IsHeldForOthers(ctx, reservation.RoomID, night, reservation.GuestID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check room holds: %w", err)
+	}
+	return !held, nil
+}
+
+// AcceptExtension extends the stay by the night offered to the overstaying guest and publishes reservation.extended,
+// which charges the offered amount on the folio. The room is checked again under its lock, because the offer does not hold it.
+func (s *Service) AcceptExtension(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.AcceptExtension", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+	if reservation.ExtensionOffer(shared.Now()) == nil {
+		return ErrNoExtensionOffer
+	}
+
+	unlock, checker, err := s.lockRoom(ctx, reservation.RoomID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	free, err := s.isNightFree(ctx, checker, reservation, nextNight(reservation.DateRange))
+	if err != nil {
+		return err
+	}
+	if !free {
+		return fmt.Errorf("%w: %s", ErrRoomNotAvailable, reservation.RoomID)
+	}
+
+	before := s.state(reservation)
+	accepted, err := reservation.AcceptExtension(shared.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	unlock()
+	s.audit(ctx, shared.AuditExtend, before, reservation)
+
+	evt := NewEventExtended().
+		WithReservationID(id).
+		WithGuestID(reservation.GuestID).
+		WithRoomID(reservation.RoomID).
+		WithNight(accepted.CheckOut).
+		WithAmount(accepted.Amount)
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// DeclineExtension declines the night offered to the overstaying guest; the front desk is alerted by the next sweep.
+func (s *Service) DeclineExtension(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.DeclineExtension", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	before := s.state(reservation)
+	if err := reservation.DeclineExtension(shared.Now()); err != nil {
+		return err
+	}
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	s.audit(ctx, shared.AuditDeclineExtension, before, reservation)
+
+	return nil
+}
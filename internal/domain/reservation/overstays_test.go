@@ -0,0 +1,244 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Overstay Test Helpers
+// ============================================================================
+
+// overstayNow is half an hour past check-out time on the check-out day of storeSweepReservation.
+var overstayNow = time.Date(2026, 6, 4, 11, 30, 0, 0, time.UTC)
+
+// mockNightPrices prices every night at the same price.
+type mockNightPrices struct {
+	price shared.Money
+}
+
+func (m *mockNightPrices) NightPrice(ctx context.Context, roomID reservation.RoomID, night time.Time) (shared.Money, error) {
+	return m.price, nil
+}
+
+// mockExtensionOffers records the offers sent.
+type mockExtensionOffers struct {
+	sent []reservation.Overstay
+}
+
+func (m *mockExtensionOffers) SendExtensionOffer(ctx context.Context, r *reservation.Reservation, offer reservation.Overstay) error {
+	m.sent = append(m.sent, offer)
+	return nil
+}
+
+// createOverstayService returns a service handling overstays with check-out at 11:00 and offers lasting two hours.
+func createOverstayService(repo *mockReservationRepository, checker *mockAvailabilityChecker, publisher *mockEventPublisher, offers *mockExtensionOffers) *reservation.Service {
+	return createTestService(repo, checker, publisher).
+		WithOverstays(reservation.DefaultOverstayPolicy(), &mockNightPrices{price: shared.NewMoney(9900, "USD")}, offers)
+}
+
+// storeExtensionOffer stores the active reservation of storeSweepReservation with an open offer of the night after it.
+func storeExtensionOffer(repo *mockReservationRepository, id reservation.ReservationID) {
+	storeSweepReservation(repo, id, reservation.StatusActive, sweepNow)
+	res := repo.reservations[id]
+	res.Overstays = []reservation.Overstay{{
+		CheckOut:   time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC),
+		DetectedAt: overstayNow,
+		Outcome:    reservation.OverstayOffered,
+		Amount:     shared.NewMoney(9900, "USD"),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}}
+	repo.reservations[id] = res
+}
+
+// ============================================================================
+// OverstayPolicy Tests
+// ============================================================================
+
+func Test_NewOverstayPolicy_Should_Parse_CheckOut_Time(t *testing.T) {
+	// Act
+	policy, err := reservation.NewOverstayPolicy("10:30", time.Hour)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "check-out time must be parsed", policy.CheckOutTime, 10*time.Hour+30*time.Minute)
+}
+
+func Test_NewOverstayPolicy_With_Invalid_Values_Should_Return_Error(t *testing.T) {
+	// Act
+	_, timeErr := reservation.NewOverstayPolicy("25:00", time.Hour)
+	_, ttlErr := reservation.NewOverstayPolicy("11:00", 0)
+
+	// Assert
+	assert.That(t, "invalid time must be rejected", errors.Is(timeErr, reservation.ErrInvalidOverstayPolicy), true)
+	assert.That(t, "invalid TTL must be rejected", errors.Is(ttlErr, reservation.ErrInvalidOverstayPolicy), true)
+}
+
+// ============================================================================
+// HandleOverstays Tests
+// ============================================================================
+
+func Test_Service_HandleOverstays_Should_Offer_Free_Next_Night(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	offers := &mockExtensionOffers{}
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}, offers)
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+	storeSweepReservation(repo, "res-002", reservation.StatusConfirmed, sweepNow)
+
+	// Act
+	report, err := service.HandleOverstays(context.Background(), overstayNow)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "active reservation must be offered the night", report.Offered, []reservation.ReservationID{"res-001"})
+	assert.That(t, "no alert must be raised", len(report.Alerts), 0)
+	overstays := repo.reservations["res-001"].Overstays
+	assert.That(t, "overstay must be recorded", len(overstays), 1)
+	assert.That(t, "night must be offered at its price", overstays[0].Amount, shared.NewMoney(9900, "USD"))
+	assert.That(t, "offer must last two hours", overstays[0].ExpiresAt, overstayNow.Add(2*time.Hour))
+	assert.That(t, "offer must be sent", len(offers.sent), 1)
+}
+
+func Test_Service_HandleOverstays_With_Booked_Next_Night_Should_Alert(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	offers := &mockExtensionOffers{}
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: false}, &mockEventPublisher{}, offers)
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+
+	// Act
+	report, err := service.HandleOverstays(context.Background(), overstayNow)
+	again, _ := service.HandleOverstays(context.Background(), overstayNow.Add(time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "front desk must be alerted", len(report.Alerts), 1)
+	assert.That(t, "alert must tell the room is unavailable", report.Alerts[0].Outcome, reservation.OverstayUnavailable)
+	assert.That(t, "alert must name the room", report.Alerts[0].RoomID, reservation.RoomID("room-101"))
+	assert.That(t, "no offer must be sent", len(offers.sent), 0)
+	assert.That(t, "second run must alert nothing", len(again.Alerts), 0)
+}
+
+func Test_Service_HandleOverstays_Before_CheckOut_Time_Should_Do_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}, &mockExtensionOffers{})
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+
+	// Act
+	report, err := service.HandleOverstays(context.Background(), time.Date(2026, 6, 4, 10, 59, 0, 0, time.UTC))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be offered", len(report.Offered), 0)
+	assert.That(t, "no overstay must be recorded", len(repo.reservations["res-001"].Overstays), 0)
+}
+
+func Test_Service_HandleOverstays_With_Expired_Offer_Should_Alert(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}, &mockExtensionOffers{})
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+	_, _ = service.HandleOverstays(context.Background(), overstayNow)
+
+	// Act
+	report, err := service.HandleOverstays(context.Background(), overstayNow.Add(2*time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "front desk must be alerted", len(report.Alerts), 1)
+	assert.That(t, "alert must tell the offer expired", report.Alerts[0].Outcome, reservation.OverstayExpired)
+}
+
+func Test_Service_HandleOverstays_Without_Overstay_Handling_Should_Do_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+
+	// Act
+	report, err := service.HandleOverstays(context.Background(), overstayNow)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be offered", len(report.Offered), 0)
+}
+
+// ============================================================================
+// AcceptExtension / DeclineExtension Tests
+// ============================================================================
+
+func Test_Service_AcceptExtension_Should_Extend_Stay_And_Publish_Event(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	auditLog := &mockAuditLog{}
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, publisher, &mockExtensionOffers{}).WithAuditLog(auditLog)
+	storeExtensionOffer(repo, "res-001")
+
+	// Act
+	err := service.AcceptExtension(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	res := repo.reservations["res-001"]
+	assert.That(t, "check-out must be a day later", res.DateRange.CheckOut, time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "offer must be accepted", res.Overstays[0].Outcome, reservation.OverstayAccepted)
+	evt, ok := publisher.published[0].(*reservation.EventExtended)
+	assert.That(t, "reservation.extended must be published", ok, true)
+	assert.That(t, "event must carry the added night", evt.Night, time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "event must carry the amount", evt.Amount, shared.NewMoney(9900, "USD"))
+	assert.That(t, "extension must be audited", auditLog.entries[0].Action, shared.AuditExtend)
+}
+
+func Test_Service_AcceptExtension_With_Booked_Night_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: false}, &mockEventPublisher{}, &mockExtensionOffers{})
+	storeExtensionOffer(repo, "res-001")
+
+	// Act
+	err := service.AcceptExtension(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be ErrRoomNotAvailable", errors.Is(err, reservation.ErrRoomNotAvailable), true)
+	assert.That(t, "check-out must stay", repo.reservations["res-001"].DateRange.CheckOut, time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_Service_AcceptExtension_Without_Offer_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}, &mockExtensionOffers{})
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+
+	// Act
+	err := service.AcceptExtension(context.Background(), "res-001")
+
+	// Assert
+	assert.That(t, "err must be ErrNoExtensionOffer", err, reservation.ErrNoExtensionOffer)
+}
+
+func Test_Service_DeclineExtension_Should_Alert_On_Next_Sweep(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createOverstayService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}, &mockExtensionOffers{})
+	storeExtensionOffer(repo, "res-001")
+
+	// Act
+	err := service.DeclineExtension(context.Background(), "res-001")
+	report, _ := service.HandleOverstays(context.Background(), overstayNow.Add(10*time.Minute))
+	again, _ := service.HandleOverstays(context.Background(), overstayNow.Add(20*time.Minute))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "front desk must be alerted", len(report.Alerts), 1)
+	assert.That(t, "alert must tell the offer was declined", report.Alerts[0].Outcome, reservation.OverstayDeclined)
+	assert.That(t, "second run must alert nothing", len(again.Alerts), 0)
+}
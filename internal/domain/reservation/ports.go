@@ -2,6 +2,7 @@ package reservation
 
 import (
 	"context"
+	"time"
 
 	"github.com/andygeiss/cloud-native-utils/event"
 	"github.com/andygeiss/cloud-native-utils/resource"
@@ -10,6 +11,15 @@ import (
 // ReservationRepository provides CRUD operations for reservations.
 type ReservationRepository resource.Access[ReservationID, Reservation]
 
+// NumberClaimRepository provides CRUD operations for the claimed confirmation numbers, keyed by the number.
+type NumberClaimRepository resource.Access[string, NumberClaim]
+
+// RoomHoldRepository provides CRUD operations for the checkout holds of rooms, keyed by the reservation ID.
+type RoomHoldRepository resource.Access[ReservationID, RoomHold]
+
+// KioskOperationRepository stores the results of synced kiosk operations, keyed by kiosk and operation ID.
+type KioskOperationRepository resource.Access[string, KioskResult]
+
 // AvailabilityChecker validates room availability for reservations.
 type AvailabilityChecker interface {
 	// IsRoomAvailable checks if a room is available for the given date range
@@ -18,5 +28,48 @@ type AvailabilityChecker interface {
 	GetOverlappingReservations(ctx context.Context, roomID RoomID, dateRange DateRange) ([]*Reservation, error)
 }
 
+// OccupancyVersions versions the occupancy of rooms, so unchanged room calendars are answered without building them.
+type OccupancyVersions interface {
+	// OccupancyVersion returns a version that changes whenever a reservation of the room is created, updated or cancelled
+	OccupancyVersion(ctx context.Context, roomID RoomID) (string, error)
+}
+
+// ExchangeRates provides the current exchange rate between two currencies.
+type ExchangeRates interface {
+	// Snapshot returns the rate to convert amounts in from to the currency to, or ErrExchangeRateUnavailable
+	Snapshot(ctx context.Context, from, to string) (FXSnapshot, error)
+}
+
+// RoomLocks serializes the bookings of a room across all server instances.
+type RoomLocks interface {
+	// Lock waits until the room is locked and returns the function that releases it, or ErrRoomBusy
+	Lock(ctx context.Context, roomID RoomID) (func(), error)
+}
+
+// RoomHolds tells whether a room is held for a guest, e.g. offered to the first guest on its waitlist.
+type RoomHolds interface {
+	// IsHeldForOthers reports whether the room is held for the date range for another guest than guestID
+	IsHeldForOthers(ctx context.Context, roomID RoomID, dateRange DateRange, guestID GuestID) (bool, error)
+}
+
+// RoomProperties tells which property a room belongs to and where the property is.
+type RoomProperties interface {
+	// PropertyOf returns the property that owns the room
+	PropertyOf(roomID RoomID) PropertyID
+	// Location returns the timezone of the property
+	Location(propertyID PropertyID) *time.Location
+}
+
+// NightPrices prices a single night of a room, e.g. the night offered to an overstaying guest.
+type NightPrices interface {
+	// NightPrice returns the price of the room for the night starting on the day (midnight UTC)
+	NightPrice(ctx context.Context, roomID RoomID, night time.Time) (Money, error)
+}
+
+// ExtensionOffers notifies overstaying guests of the offer to stay another night.
+type ExtensionOffers interface {
+	SendExtensionOffer(ctx context.Context, r *Reservation, offer Overstay) error
+}
+
 // EventPublisher publishes domain events.
 type EventPublisher event.EventPublisher
@@ -0,0 +1,295 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// maxCachedPriceCalendars bounds the price calendar cache; it is cleared when full.
+const maxCachedPriceCalendars = 256
+
+var (
+	// ErrRoomTypeNotFound is returned if a price calendar is requested for an unknown room type.
+	ErrRoomTypeNotFound = errors.New("room type not found")
+	// ErrInvalidRatePolicy is returned if a room type, rule or restriction of the rate configuration is malformed.
+	ErrInvalidRatePolicy = errors.New("invalid rate policy")
+)
+
+// RoomTypeID identifies a room type, e.g. "deluxe".
+type RoomTypeID string
+
+// RoomType groups rooms that are sold at the same base rate.
+type RoomType struct {
+	ID       RoomTypeID
+	RoomIDs  []RoomID
+	BaseRate Money // per night, before rules
+}
+
+// RateRestriction limits stays around a night, as channel managers know them.
+type RateRestriction struct {
+	MinStay           int  // minimum nights of a stay arriving on the day; 0 is none
+	ClosedToArrival   bool // no check-in on the day
+	ClosedToDeparture bool // no check-out on the day
+}
+
+// IsZero returns true if the restriction restricts nothing.
+func (r RateRestriction) IsZero() bool {
+	return r.MinStay <= 1 && !r.ClosedToArrival && !r.ClosedToDeparture
+}
+
+// RatePolicy is the pricing in effect: the base rates of the room types, the pricing rules
+// (the same rules the pricing simulation proposes) and the stay restrictions.
+// Restrictions of a date replace those of its weekday.
+type RatePolicy struct {
+	RoomTypes []RoomType
+	Rules     []PricingRule
+	Weekdays  map[time.Weekday]RateRestriction
+	Dates     map[string]RateRestriction // by date (2006-01-02)
+}
+
+// DefaultRatePolicy returns the rooms of the reservation form at their nightly prices, without rules or restrictions.
+func DefaultRatePolicy() RatePolicy {
+	return RatePolicy{
+		RoomTypes: []RoomType{
+			{ID: "standard", RoomIDs: []RoomID{"room-101", "room-102"}, BaseRate: shared.NewMoney(9900, "USD")},
+			{ID: "deluxe", RoomIDs: []RoomID{"room-201", "room-202"}, BaseRate: shared.NewMoney(14900, "USD")},
+			{ID: "suite", RoomIDs: []RoomID{"room-301"}, BaseRate: shared.NewMoney(24900, "USD")},
+		},
+	}
+}
+
+// ParseRatePolicy parses the room types as "type=rate room room;type=rate room" with rates in the smallest
+// currency unit, the rules as "name=percent [weekday ...] [minN];..." (e.g. "weekend=15 fri sat;long_stay=-10 min7")
+// and the restrictions as "day=[minN] [cta] [ctd];..." where day is a weekday or a date (e.g. "sat=min2;2026-12-31=min3 cta").
+func ParseRatePolicy(currency, roomTypes, rules, restrictions string) (RatePolicy, error) {
+	policy := RatePolicy{Weekdays: make(map[time.Weekday]RateRestriction), Dates: make(map[string]RateRestriction)}
+	for id, fields := range rateEntries(roomTypes) {
+		if len(fields) < 2 {
+			return RatePolicy{}, fmt.Errorf("%w: room type %q needs a rate and rooms", ErrInvalidRatePolicy, id)
+		}
+		rate, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil || rate < 0 {
+			return RatePolicy{}, fmt.Errorf("%w: rate of room type %q must be a non-negative integer: %q", ErrInvalidRatePolicy, id, fields[0])
+		}
+		roomType := RoomType{ID: RoomTypeID(id), BaseRate: shared.NewMoney(rate, currency)}
+		for _, room := range fields[1:] {
+			roomType.RoomIDs = append(roomType.RoomIDs, RoomID(room))
+		}
+		policy.RoomTypes = append(policy.RoomTypes, roomType)
+	}
+
+	for name, fields := range rateEntries(rules) {
+		if len(fields) == 0 {
+			return RatePolicy{}, fmt.Errorf("%w: rule %q needs a percent", ErrInvalidRatePolicy, name)
+		}
+		percent, err := strconv.Atoi(fields[0])
+		if err != nil || percent < -100 {
+			return RatePolicy{}, fmt.Errorf("%w: percent of rule %q must be an integer of at least -100: %q", ErrInvalidRatePolicy, name, fields[0])
+		}
+		rule := PricingRule{Name: name, Percent: percent}
+		for _, field := range fields[1:] {
+			if n, ok := strings.CutPrefix(strings.ToLower(field), "min"); ok {
+				if rule.MinNights, err = strconv.Atoi(n); err != nil || rule.MinNights < 0 {
+					return RatePolicy{}, fmt.Errorf("%w: rule %q has invalid %q", ErrInvalidRatePolicy, name, field)
+				}
+				continue
+			}
+			weekday, ok := parseWeekday(field)
+			if !ok {
+				return RatePolicy{}, fmt.Errorf("%w: rule %q has unknown weekday %q", ErrInvalidRatePolicy, name, field)
+			}
+			rule.Weekdays = append(rule.Weekdays, weekday)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+
+	for day, fields := range rateEntries(restrictions) {
+		var restriction RateRestriction
+		for _, field := range fields {
+			field = strings.ToLower(field)
+			switch n, isMin := strings.CutPrefix(field, "min"); {
+			case field == "cta":
+				restriction.ClosedToArrival = true
+			case field == "ctd":
+				restriction.ClosedToDeparture = true
+			case isMin:
+				var err error
+				if restriction.MinStay, err = strconv.Atoi(n); err != nil || restriction.MinStay < 0 {
+					return RatePolicy{}, fmt.Errorf("%w: restriction of %q has invalid %q", ErrInvalidRatePolicy, day, field)
+				}
+			default:
+				return RatePolicy{}, fmt.Errorf("%w: restriction of %q has unknown %q (expected minN, cta or ctd)", ErrInvalidRatePolicy, day, field)
+			}
+		}
+		if weekday, ok := parseWeekday(day); ok {
+			policy.Weekdays[weekday] = restriction
+		} else if _, err := time.Parse(time.DateOnly, day); err == nil {
+			policy.Dates[day] = restriction
+		} else {
+			return RatePolicy{}, fmt.Errorf("%w: restriction day %q is neither a weekday nor a date", ErrInvalidRatePolicy, day)
+		}
+	}
+	return policy, nil
+}
+
+// rateEntries yields the key and the fields of every "key=field field" entry of a ";"-separated list.
+func rateEntries(s string) func(yield func(string, []string) bool) {
+	return func(yield func(string, []string) bool) {
+		for entry := range strings.SplitSeq(s, ";") {
+			key, value, _ := strings.Cut(entry, "=")
+			if key = strings.TrimSpace(key); key == "" {
+				continue
+			}
+			if !yield(key, strings.Fields(value)) {
+				return
+			}
+		}
+	}
+}
+
+// parseWeekday parses the English name of a weekday or its first three letters.
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// RoomType returns the room type with the ID.
+func (p RatePolicy) RoomType(id RoomTypeID) (RoomType, bool) {
+	i := slices.IndexFunc(p.RoomTypes, func(t RoomType) bool { return t.ID == id })
+	if i < 0 {
+		return RoomType{}, false
+	}
+	return p.RoomTypes[i], true
+}
+
+// restriction returns the restriction of the day: that of its date, else that of its weekday.
+func (p RatePolicy) restriction(day time.Time) RateRestriction {
+	if r, ok := p.Dates[day.Format(time.DateOnly)]; ok {
+		return r
+	}
+	return p.Weekdays[day.Weekday()]
+}
+
+// DayPrice is the nightly rate of a room type for the night starting on Date.
+// Rules with MinNights above 1 (long-stay discounts) are not included, because they depend on the stay.
+type DayPrice struct {
+	Date        time.Time
+	Rate        Money
+	Rules       []string // names of the applied rules
+	Restriction RateRestriction
+}
+
+// PriceCalendar is the nightly rate of a room type for every day of a month.
+type PriceCalendar struct {
+	RoomType RoomType
+	Month    time.Time // first day of the month (UTC)
+	Days     []DayPrice
+}
+
+// NewPriceCalendar prices every night of the month of month (UTC) for the room type.
+func NewPriceCalendar(policy RatePolicy, roomType RoomType, month time.Time) PriceCalendar {
+	first := calendarDate(month)
+	month = time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	calendar := PriceCalendar{RoomType: roomType, Month: month}
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		price := DayPrice{Date: day, Restriction: policy.restriction(day)}
+		percent := 0
+		for _, rule := range policy.Rules {
+			if rule.MinNights > 1 || !rule.appliesTo(roomType.RoomIDs, day) {
+				continue
+			}
+			percent += rule.Percent
+			price.Rules = append(price.Rules, rule.Name)
+		}
+		price.Rate = shared.NewMoney(roomType.BaseRate.Amount*int64(100+max(percent, -100))/100, roomType.BaseRate.Currency)
+		calendar.Days = append(calendar.Days, price)
+	}
+	return calendar
+}
+
+// appliesTo reports whether the rule changes the rate of one of the rooms for the night, regardless of the stay.
+func (p PricingRule) appliesTo(roomIDs []RoomID, night time.Time) bool {
+	if len(p.RoomIDs) > 0 && !slices.ContainsFunc(roomIDs, func(id RoomID) bool { return slices.Contains(p.RoomIDs, id) }) {
+		return false
+	}
+	return len(p.Weekdays) == 0 || slices.Contains(p.Weekdays, night.Weekday())
+}
+
+// Rates holds the rate policy in effect and caches the price calendars computed from it.
+// Replacing the policy (a rate change) drops every cached calendar.
+type Rates struct {
+	mu        sync.RWMutex
+	policy    RatePolicy
+	version   int
+	calendars map[string]PriceCalendar // by room type and month
+}
+
+// NewRates creates the rates with the policy.
+func NewRates(policy RatePolicy) *Rates {
+	return &Rates{policy: policy, version: 1, calendars: make(map[string]PriceCalendar)}
+}
+
+// SetPolicy replaces the rate policy at runtime and invalidates the cached calendars.
+func (r *Rates) SetPolicy(policy RatePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+	r.version++
+	r.calendars = make(map[string]PriceCalendar)
+}
+
+// Version changes with every policy change, e.g. for ETags of price calendars.
+func (r *Rates) Version() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// RoomTypes returns the room types of the current policy.
+func (r *Rates) RoomTypes() []RoomType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.policy.RoomTypes)
+}
+
+// PriceCalendar returns the nightly rates of the room type for the month of month.
+func (r *Rates) PriceCalendar(id RoomTypeID, month time.Time) (PriceCalendar, error) {
+	key := string(id) + " " + calendarDate(month).Format("2006-01")
+	r.mu.RLock()
+	calendar, ok := r.calendars[key]
+	policy, version := r.policy, r.version
+	r.mu.RUnlock()
+	if ok {
+		return calendar, nil
+	}
+
+	roomType, ok := policy.RoomType(id)
+	if !ok {
+		return PriceCalendar{}, fmt.Errorf("%w: %s", ErrRoomTypeNotFound, id)
+	}
+	calendar = NewPriceCalendar(policy, roomType, month)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// The policy may have changed meanwhile; a calendar of the old one must not be cached.
+	if r.version == version {
+		if len(r.calendars) >= maxCachedPriceCalendars {
+			clear(r.calendars)
+		}
+		r.calendars[key] = calendar
+	}
+	return calendar, nil
+}
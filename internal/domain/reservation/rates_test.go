@@ -0,0 +1,159 @@
+package reservation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+)
+
+// ============================================================================
+// Rates Test Helpers
+// ============================================================================
+
+// June 2026 starts on a Monday, so June 5th and 6th are Friday and Saturday.
+var ratesMonth = time.Date(2026, 6, 17, 15, 0, 0, 0, time.UTC)
+
+func ratesTestPolicy(t *testing.T) reservation.RatePolicy {
+	t.Helper()
+	policy, err := reservation.ParseRatePolicy("USD",
+		"standard=10000 room-101 room-102;suite=20000 room-301",
+		"weekend=15 fri sat;long_stay=-10 min7",
+		"sat=min2;2026-06-06=min3 cta")
+	if err != nil {
+		t.Fatalf("failed to parse rate policy: %v", err)
+	}
+	return policy
+}
+
+// ============================================================================
+// ParseRatePolicy Tests
+// ============================================================================
+
+func Test_ParseRatePolicy_Should_Parse_Room_Types_Rules_And_Restrictions(t *testing.T) {
+	// Act
+	policy := ratesTestPolicy(t)
+
+	// Assert
+	standard, ok := policy.RoomType("standard")
+	assert.That(t, "standard must exist", ok, true)
+	assert.That(t, "standard must have 2 rooms", len(standard.RoomIDs), 2)
+	assert.That(t, "base rate must be 10000", standard.BaseRate.Amount, int64(10000))
+	assert.That(t, "rule count must be 2", len(policy.Rules), 2)
+	assert.That(t, "weekend must have 2 weekdays", policy.Rules[0].Weekdays, []time.Weekday{time.Friday, time.Saturday})
+	assert.That(t, "long stay must need 7 nights", policy.Rules[1].MinNights, 7)
+	assert.That(t, "saturday must need 2 nights", policy.Weekdays[time.Saturday].MinStay, 2)
+	assert.That(t, "date must be closed to arrival", policy.Dates["2026-06-06"].ClosedToArrival, true)
+}
+
+func Test_ParseRatePolicy_With_Invalid_Entries_Should_Return_ErrInvalidRatePolicy(t *testing.T) {
+	tests := []struct {
+		name                           string
+		roomTypes, rules, restrictions string
+	}{
+		{"room type without rooms", "standard=10000", "", ""},
+		{"negative rate", "standard=-1 room-101", "", ""},
+		{"percent below -100", "", "sale=-150", ""},
+		{"unknown weekday", "", "weekend=15 caturday", ""},
+		{"unknown restriction", "", "", "sat=max2"},
+		{"unknown day", "", "", "someday=min2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := reservation.ParseRatePolicy("USD", tt.roomTypes, tt.rules, tt.restrictions)
+			assert.That(t, "err must be ErrInvalidRatePolicy", errors.Is(err, reservation.ErrInvalidRatePolicy), true)
+		})
+	}
+}
+
+// ============================================================================
+// NewPriceCalendar Tests
+// ============================================================================
+
+func Test_NewPriceCalendar_Should_Price_Every_Day_Of_The_Month(t *testing.T) {
+	// Arrange
+	policy := ratesTestPolicy(t)
+	standard, _ := policy.RoomType("standard")
+
+	// Act
+	calendar := reservation.NewPriceCalendar(policy, standard, ratesMonth)
+
+	// Assert
+	assert.That(t, "month must start on the 1st", calendar.Month, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.That(t, "june must have 30 days", len(calendar.Days), 30)
+	assert.That(t, "monday must have the base rate", calendar.Days[0].Rate.Amount, int64(10000))
+	assert.That(t, "monday must have no rules", len(calendar.Days[0].Rules), 0)
+	assert.That(t, "friday must have the weekend rate", calendar.Days[4].Rate.Amount, int64(11500))
+	assert.That(t, "friday must list the weekend rule", calendar.Days[4].Rules, []string{"weekend"})
+	assert.That(t, "friday must be unrestricted", calendar.Days[4].Restriction.IsZero(), true)
+	assert.That(t, "june 6th must use the date restriction", calendar.Days[5].Restriction, reservation.RateRestriction{MinStay: 3, ClosedToArrival: true})
+	assert.That(t, "june 13th must use the saturday restriction", calendar.Days[12].Restriction, reservation.RateRestriction{MinStay: 2})
+}
+
+func Test_NewPriceCalendar_With_Rule_For_Other_Rooms_Should_Not_Apply_It(t *testing.T) {
+	// Arrange
+	policy := ratesTestPolicy(t)
+	policy.Rules = []reservation.PricingRule{{Name: "suite_promo", RoomIDs: []reservation.RoomID{"room-301"}, Percent: -20}}
+	standard, _ := policy.RoomType("standard")
+	suite, _ := policy.RoomType("suite")
+
+	// Act
+	standardCalendar := reservation.NewPriceCalendar(policy, standard, ratesMonth)
+	suiteCalendar := reservation.NewPriceCalendar(policy, suite, ratesMonth)
+
+	// Assert
+	assert.That(t, "standard must keep the base rate", standardCalendar.Days[0].Rate.Amount, int64(10000))
+	assert.That(t, "suite must be discounted", suiteCalendar.Days[0].Rate.Amount, int64(16000))
+}
+
+// ============================================================================
+// Rates Tests
+// ============================================================================
+
+func Test_Rates_PriceCalendar_With_Unknown_Room_Type_Should_Return_ErrRoomTypeNotFound(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRates(reservation.DefaultRatePolicy())
+
+	// Act
+	_, err := rates.PriceCalendar("penthouse", ratesMonth)
+
+	// Assert
+	assert.That(t, "err must be ErrRoomTypeNotFound", errors.Is(err, reservation.ErrRoomTypeNotFound), true)
+}
+
+func Test_Rates_SetPolicy_Should_Invalidate_Cached_Calendars(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRates(reservation.DefaultRatePolicy())
+	before, _ := rates.PriceCalendar("standard", ratesMonth)
+	version := rates.Version()
+	policy := reservation.DefaultRatePolicy()
+	policy.RoomTypes[0].BaseRate.Amount = 12900
+
+	// Act
+	rates.SetPolicy(policy)
+	after, _ := rates.PriceCalendar("standard", ratesMonth)
+
+	// Assert
+	assert.That(t, "cached rate must be the old one", before.Days[0].Rate.Amount, int64(9900))
+	assert.That(t, "rate must be the new one", after.Days[0].Rate.Amount, int64(12900))
+	assert.That(t, "version must change", rates.Version() != version, true)
+}
+
+func Test_Rates_RoomTypes_Should_Return_Room_Types_Of_Current_Policy(t *testing.T) {
+	// Arrange
+	rates := reservation.NewRates(reservation.DefaultRatePolicy())
+	policy := reservation.DefaultRatePolicy()
+	policy.RoomTypes = policy.RoomTypes[:1]
+
+	// Act
+	before := rates.RoomTypes()
+	rates.SetPolicy(policy)
+	after := rates.RoomTypes()
+
+	// Assert
+	assert.That(t, "all room types must be returned", len(before), 3)
+	assert.That(t, "reloaded room types must be returned", len(after), 1)
+	assert.That(t, "room type must be standard", after[0].ID, reservation.RoomTypeID("standard"))
+}
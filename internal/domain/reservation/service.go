@@ -2,10 +2,15 @@ package reservation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/event"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
 // Service handles reservation workflows.
@@ -13,8 +18,33 @@ type Service struct {
 	reservationRepo     ReservationRepository
 	availabilityChecker AvailabilityChecker
 	publisher           event.EventPublisher
+	exchangeRates       ExchangeRates
+	currencyOfRecord    string
+	roomLocks           RoomLocks
+	lockedChecker       AvailabilityChecker
+	roomHolds           RoomHolds
+	holdRepo            RoomHoldRepository
+	holdTTL             time.Duration
+	kioskRepo           KioskOperationRepository
+	metrics             shared.Metrics
+	tracer              shared.Tracer
+	auditLog            shared.AuditLog
+	numbers             *confirmationNumbers
+	properties          RoomProperties
+	roomLayout          *RoomLayout
+	rates               *Rates
+	overstayPolicy      *OverstayPolicy
+	nightPrices         NightPrices
+	extensionOffers     ExtensionOffers
+	occupancyVersions   OccupancyVersions
 }
 
+// Metrics recorded by the service if configured via WithMetrics.
+const (
+	MetricReservationsCreated   = "hotel_reservations_created_total"
+	MetricReservationsCancelled = "hotel_reservations_cancelled_total" // by the status before cancelling
+)
+
 // NewService creates a new reservation Service with dependencies.
 func NewService(
 	repo ReservationRepository,
@@ -28,6 +58,86 @@ func NewService(
 	}
 }
 
+// WithExchangeRates records the exchange rate to the currency of record on every new reservation
+// and in its reservation.created event, so the payment context can check it before capturing.
+// Amounts already in the currency of record get an identity snapshot without asking the rates.
+func (s *Service) WithExchangeRates(rates ExchangeRates, currencyOfRecord string) *Service {
+	s.exchangeRates = rates
+	s.currencyOfRecord = strings.ToUpper(currencyOfRecord)
+	return s
+}
+
+// WithRoomLocks holds the lock of the room from the availability check until the reservation is
+// persisted, so two concurrent bookings of the same dates cannot both pass the check.
+// The check under the lock uses checker, which must read the repository directly: a coalescing
+// checker may answer with a query that started before the previous booking was persisted.
+func (s *Service) WithRoomLocks(locks RoomLocks, checker AvailabilityChecker) *Service {
+	s.roomLocks = locks
+	s.lockedChecker = checker
+	return s
+}
+
+// WithRoomHolds refuses to book a room held for another guest, as if it were booked.
+func (s *Service) WithRoomHolds(holds RoomHolds) *Service {
+	s.roomHolds = holds
+	return s
+}
+
+// WithProperties records the property of the room on every new reservation and in its reservation.created event.
+func (s *Service) WithProperties(properties RoomProperties) *Service {
+	s.properties = properties
+	return s
+}
+
+// PropertyOf returns the property of the reservation. Reservations made before properties were configured
+// belong to the property of their room; it is empty if no properties are configured.
+func (s *Service) PropertyOf(reservation *Reservation) PropertyID {
+	if reservation.PropertyID != "" || s.properties == nil {
+		return reservation.PropertyID
+	}
+	return s.properties.PropertyOf(reservation.RoomID)
+}
+
+// WithMetrics counts the created and cancelled reservations in the metrics.
+func (s *Service) WithMetrics(metrics shared.Metrics) *Service {
+	s.metrics = metrics
+	return s
+}
+
+// WithTracer records the reservation workflows as spans of the traces of their requests and events.
+func (s *Service) WithTracer(tracer shared.Tracer) *Service {
+	s.tracer = tracer
+	return s
+}
+
+// WithAuditLog records every change of a reservation with its caller and the state before and after.
+func (s *Service) WithAuditLog(log shared.AuditLog) *Service {
+	s.auditLog = log
+	return s
+}
+
+// state returns the state of the reservation for the audit log, or nil if no audit log is configured.
+func (s *Service) state(reservation *Reservation) json.RawMessage {
+	if s.auditLog == nil {
+		return nil
+	}
+	return shared.AuditState(reservation)
+}
+
+// audit records the change of the reservation in the audit log, if configured.
+func (s *Service) audit(ctx context.Context, action string, before json.RawMessage, after *Reservation) {
+	if s.auditLog != nil {
+		s.auditLog.Record(ctx, shared.NewAuditEntry(ctx, "reservation", string(after.ID), action, before, shared.AuditState(after)))
+	}
+}
+
+// count increments the counter, if metrics are configured.
+func (s *Service) count(name string, labels ...string) {
+	if s.metrics != nil {
+		s.metrics.IncCounter(name, labels...)
+	}
+}
+
 // CreateReservation creates a new pending reservation after checking availability.
 func (s *Service) CreateReservation(
 	ctx context.Context,
@@ -38,13 +148,66 @@ func (s *Service) CreateReservation(
 	amount Money,
 	guests []GuestInfo,
 ) (*Reservation, error) {
+	return s.CreateReservationWithPerks(ctx, id, guestID, roomID, dateRange, amount, guests, Perks{}, ArrivalDetails{})
+}
+
+// CreateReservationWithPerks creates a new pending reservation and applies the perks of the guest's VIP tier,
+// the promo code and the loyalty points redeemed, so all are deducted before payment is authorized. The arrival details given by the guest are recorded as well.
+func (s *Service) CreateReservationWithPerks(
+	ctx context.Context,
+	id ReservationID,
+	guestID GuestID,
+	roomID RoomID,
+	dateRange DateRange,
+	amount Money,
+	guests []GuestInfo,
+	perks Perks,
+	arrival ArrivalDetails,
+) (_ *Reservation, err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.CreateReservation", "reservation_id", string(id), "room_id", string(roomID))
+	defer func() { span.End(err) }()
+
+	// The stay is placed in the timezone of the room's property before it is validated.
+	var propertyID PropertyID
+	if s.properties != nil {
+		propertyID = s.properties.PropertyOf(roomID)
+		dateRange = dateRange.In(s.properties.Location(propertyID))
+	}
+
 	// 1. Check room availability
-	available, err := s.availabilityChecker.IsRoomAvailable(ctx, roomID, dateRange)
+	unlock, checker, err := s.lockRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	// Released right after persisting; the deferred call covers the early returns.
+	defer unlock()
+	// The guest's own checkout hold must not make the room unavailable; it is active again if booking fails.
+	hold, err := s.bookHold(ctx, id, guestID, roomID, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	if hold != nil {
+		defer func() {
+			if err != nil {
+				_ = s.holdRepo.Update(context.WithoutCancel(ctx), id, *hold)
+			}
+		}()
+	}
+	available, err := checker.IsRoomAvailable(ctx, roomID, dateRange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check availability: %w", err)
 	}
 	if !available {
-		return nil, fmt.Errorf("room %s is not available for the selected dates", roomID)
+		return nil, fmt.Errorf("%w: %s", ErrRoomNotAvailable, roomID)
+	}
+	if s.roomHolds != nil {
+		held, err := s.roomHolds.IsHeldForOthers(ctx, roomID, dateRange, guestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check room holds: %w", err)
+		}
+		if held {
+			return nil, fmt.Errorf("%w: %s is held for another guest", ErrRoomNotAvailable, roomID)
+		}
 	}
 
 	// 2. Create reservation aggregate
@@ -52,20 +215,48 @@ func (s *Service) CreateReservation(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
+	if perks.ReducePrice() {
+		if err := reservation.ApplyPerks(perks); err != nil {
+			return nil, fmt.Errorf("failed to apply perks: %w", err)
+		}
+	}
+	reservation.SetArrivalDetails(arrival)
+	reservation.PropertyID = propertyID
+	if err := s.recordPreferences(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	if err := s.snapshotFX(ctx, reservation); err != nil {
+		return nil, err
+	}
+	reservation.attributeStatusChange(actorOf(ctx))
+	if s.numbers != nil {
+		number, err := s.numbers.claim(ctx, id, reservation.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		reservation.ConfirmationNumber = number
+	}
 
 	// 3. Persist to repository
 	if err := s.reservationRepo.Create(ctx, id, *reservation); err != nil {
 		return nil, fmt.Errorf("failed to persist reservation: %w", err)
 	}
+	unlock()
+	s.count(MetricReservationsCreated)
+	s.audit(ctx, shared.AuditCreate, nil, reservation)
 
 	// 4. Publish domain event
 	evt := NewEventCreated().
 		WithReservationID(id).
 		WithGuestID(guestID).
 		WithRoomID(roomID).
+		WithPropertyID(reservation.PropertyID).
 		WithCheckIn(dateRange.CheckIn).
 		WithCheckOut(dateRange.CheckOut).
-		WithTotalAmount(amount)
+		WithTotalAmount(reservation.TotalAmount).
+		WithFX(reservation.FX).
+		WithGuestTier(reservation.Perks.Tier)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return nil, fmt.Errorf("failed to publish event: %w", err)
@@ -74,28 +265,70 @@ func (s *Service) CreateReservation(
 	return reservation, nil
 }
 
+// lockRoom locks the room if room locks are configured and returns the function that unlocks it,
+// which may be called more than once, with the checker to use under the lock.
+func (s *Service) lockRoom(ctx context.Context, roomID RoomID) (func(), AvailabilityChecker, error) {
+	if s.roomLocks == nil {
+		return func() {}, s.availabilityChecker, nil
+	}
+	release, err := s.roomLocks.Lock(ctx, roomID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock room %s: %w", roomID, err)
+	}
+	return sync.OnceFunc(release), s.lockedChecker, nil
+}
+
+// snapshotFX records the exchange rate of the total amount to the currency of record, if configured.
+func (s *Service) snapshotFX(ctx context.Context, reservation *Reservation) error {
+	if s.exchangeRates == nil {
+		return nil
+	}
+	currency := reservation.TotalAmount.Currency
+	if currency == s.currencyOfRecord {
+		snapshot := shared.NewIdentityFXSnapshot(currency, time.Now())
+		reservation.FX = &snapshot
+		return nil
+	}
+	snapshot, err := s.exchangeRates.Snapshot(ctx, currency, s.currencyOfRecord)
+	if err != nil {
+		return fmt.Errorf("%w: %s to %s: %w", ErrExchangeRateUnavailable, currency, s.currencyOfRecord, err)
+	}
+	reservation.FX = &snapshot
+	return nil
+}
+
 // ConfirmReservation transitions a reservation to confirmed status.
-func (s *Service) ConfirmReservation(ctx context.Context, id ReservationID) error {
+func (s *Service) ConfirmReservation(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.ConfirmReservation", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
 	// 1. Load reservation from repository
 	reservation, err := s.reservationRepo.Read(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to read reservation: %w", err)
 	}
 
+	before := s.state(reservation)
+
 	// 2. Confirm reservation (aggregate business logic)
 	if err := reservation.Confirm(); err != nil {
 		return fmt.Errorf("failed to confirm reservation: %w", err)
 	}
+	reservation.attributeStatusChange(actorOf(ctx))
 
 	// 3. Update repository
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
+	s.audit(ctx, shared.AuditConfirm, before, reservation)
+	// The checkout hold became the paid reservation; holds left behind are deleted by ExpireRoomHolds.
+	_ = s.endHold(ctx, id)
 
 	// 4. Publish domain event
 	evt := NewEventConfirmed().
 		WithReservationID(id).
-		WithGuestID(reservation.GuestID)
+		WithGuestID(reservation.GuestID).
+		WithGuestTier(reservation.Perks.Tier)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
@@ -105,7 +338,15 @@ func (s *Service) ConfirmReservation(ctx context.Context, id ReservationID) erro
 }
 
 // CancelReservation cancels a reservation with business rule validation.
-func (s *Service) CancelReservation(ctx context.Context, id ReservationID, reason string) error {
+func (s *Service) CancelReservation(ctx context.Context, id ReservationID, reason string) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.CancelReservation", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
+	return s.cancel(ctx, id, reason, func(r *Reservation) error { return r.Cancel(reason) })
+}
+
+// cancel applies the cancelling transition to the reservation, stores it and publishes reservation.cancelled.
+func (s *Service) cancel(ctx context.Context, id ReservationID, reason string, transition func(r *Reservation) error) error {
 	// 1. Load reservation from repository
 	reservation, err := s.reservationRepo.Read(ctx, id)
 	if err != nil {
@@ -113,22 +354,29 @@ func (s *Service) CancelReservation(ctx context.Context, id ReservationID, reaso
 	}
 
 	guestID := reservation.GuestID
+	from := reservation.Status
+	before := s.state(reservation)
 
 	// 2. Cancel reservation (aggregate business logic validates rules)
-	if err := reservation.Cancel(reason); err != nil {
+	if err := transition(reservation); err != nil {
 		return fmt.Errorf("failed to cancel reservation: %w", err)
 	}
+	reservation.attributeStatusChange(actorOf(ctx))
 
 	// 3. Update repository
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
+	s.count(MetricReservationsCancelled, "from_status", string(from))
+	s.audit(ctx, shared.AuditCancel, before, reservation)
+	_ = s.endHold(ctx, id)
 
 	// 4. Publish domain event
 	evt := NewEventCancelled().
 		WithReservationID(id).
 		WithGuestID(guestID).
-		WithReason(reason)
+		WithReason(reason).
+		WithGuestTier(reservation.Perks.Tier)
 
 	if err := s.publisher.Publish(ctx, evt); err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
@@ -138,19 +386,25 @@ func (s *Service) CancelReservation(ctx context.Context, id ReservationID, reaso
 }
 
 // ActivateReservation transitions a reservation to active status (check-in).
-func (s *Service) ActivateReservation(ctx context.Context, id ReservationID) error {
+func (s *Service) ActivateReservation(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.ActivateReservation", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
 	reservation, err := s.reservationRepo.Read(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to read reservation: %w", err)
 	}
 
+	before := s.state(reservation)
 	if err := reservation.Activate(); err != nil {
 		return fmt.Errorf("failed to activate reservation: %w", err)
 	}
+	reservation.attributeStatusChange(actorOf(ctx))
 
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
+	s.audit(ctx, shared.AuditActivate, before, reservation)
 
 	evt := NewEventActivated().WithReservationID(id)
 	if err := s.publisher.Publish(ctx, evt); err != nil {
@@ -161,19 +415,25 @@ func (s *Service) ActivateReservation(ctx context.Context, id ReservationID) err
 }
 
 // CompleteReservation transitions a reservation to completed status (check-out).
-func (s *Service) CompleteReservation(ctx context.Context, id ReservationID) error {
+func (s *Service) CompleteReservation(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.CompleteReservation", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
 	reservation, err := s.reservationRepo.Read(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to read reservation: %w", err)
 	}
 
+	before := s.state(reservation)
 	if err := reservation.Complete(); err != nil {
 		return fmt.Errorf("failed to complete reservation: %w", err)
 	}
+	reservation.attributeStatusChange(actorOf(ctx))
 
 	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
+	s.audit(ctx, shared.AuditComplete, before, reservation)
 
 	evt := NewEventCompleted().WithReservationID(id)
 	if err := s.publisher.Publish(ctx, evt); err != nil {
@@ -209,16 +469,182 @@ func (s *Service) ListReservationsByGuest(ctx context.Context, guestID GuestID)
 	return guestReservations, nil
 }
 
+// ListReservationsByGuests retrieves all reservations owned by any of the guest accounts.
+func (s *Service) ListReservationsByGuests(ctx context.Context, guestIDs []GuestID) ([]*Reservation, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var guestReservations []*Reservation
+	for i := range allReservations {
+		if slices.Contains(guestIDs, allReservations[i].GuestID) {
+			guestReservations = append(guestReservations, &allReservations[i])
+		}
+	}
+
+	return guestReservations, nil
+}
+
+// ListReservationsByGuestEmail retrieves all reservations whose contact email matches (case-insensitive).
+// Email is display data only; use ListReservationsByGuest for access decisions.
+func (s *Service) ListReservationsByGuestEmail(ctx context.Context, email string) ([]*Reservation, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var guestReservations []*Reservation
+	for i := range allReservations {
+		if email != "" && strings.EqualFold(allReservations[i].GuestEmail, email) {
+			guestReservations = append(guestReservations, &allReservations[i])
+		}
+	}
+
+	return guestReservations, nil
+}
+
+// ListReservationsSharedWith retrieves all reservations the guest account was given access to.
+func (s *Service) ListReservationsSharedWith(ctx context.Context, guestID GuestID) ([]*Reservation, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var sharedReservations []*Reservation
+	for i := range allReservations {
+		if !allReservations[i].IsOwnedBy(guestID) && allReservations[i].CanView(guestID) {
+			sharedReservations = append(sharedReservations, &allReservations[i])
+		}
+	}
+
+	return sharedReservations, nil
+}
+
+// ShareReservation invites a co-traveler by email to view or manage a reservation.
+func (s *Service) ShareReservation(ctx context.Context, id ReservationID, email string, role ShareRole) (*Reservation, error) {
+	return s.updateShares(ctx, id, shared.AuditShare, func(r *Reservation) error { return r.Share(email, role) })
+}
+
+// AcceptShare binds a share invitation to the accepting guest account.
+func (s *Service) AcceptShare(ctx context.Context, id ReservationID, email string, guestID GuestID) (*Reservation, error) {
+	return s.updateShares(ctx, id, shared.AuditAcceptShare, func(r *Reservation) error { return r.AcceptShare(email, guestID) })
+}
+
+// RevokeShare removes the access of a co-traveler.
+func (s *Service) RevokeShare(ctx context.Context, id ReservationID, email string) (*Reservation, error) {
+	return s.updateShares(ctx, id, shared.AuditRevokeShare, func(r *Reservation) error { return r.RevokeShare(email) })
+}
+
+// updateShares loads a reservation, applies a share change and persists it.
+func (s *Service) updateShares(ctx context.Context, id ReservationID, action string, change func(r *Reservation) error) (*Reservation, error) {
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation: %w", err)
+	}
+	before := s.state(reservation)
+	if err := change(reservation); err != nil {
+		return nil, fmt.Errorf("failed to update share: %w", err)
+	}
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+	s.audit(ctx, action, before, reservation)
+	return reservation, nil
+}
+
+// ClaimLegacyReservations migrates reservations that were owned by an email address
+// (before ownership switched to the OIDC subject) to the guest account.
+// The subject of an email is only known once the guest signs in, so the migration
+// runs per guest at sign-in; callers must make sure the identity provider verified the email.
+// It returns the number of migrated reservations.
+func (s *Service) ClaimLegacyReservations(ctx context.Context, guestID GuestID, email string) (int, error) {
+	if guestID == "" || email == "" || guestID == GuestID(email) {
+		return 0, nil
+	}
+
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	claimed := 0
+	for i := range allReservations {
+		reservation := allReservations[i]
+		if reservation.GuestID != GuestID(email) {
+			continue
+		}
+		before := s.state(&reservation)
+		reservation.GuestID = guestID
+		if reservation.GuestEmail == "" {
+			reservation.GuestEmail = email
+		}
+		if err := s.reservationRepo.Update(ctx, reservation.ID, reservation); err != nil {
+			return claimed, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		s.audit(ctx, shared.AuditReassign, before, &reservation)
+		claimed++
+	}
+
+	return claimed, nil
+}
+
+// ListReservations retrieves all reservations, e.g. to build guest profiles across accounts.
+func (s *Service) ListReservations(ctx context.Context) ([]Reservation, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	return allReservations, nil
+}
+
+// ReassignGuest moves reservations from one guest account to another, e.g. when duplicate
+// guest profiles are merged. Only the given reservations that are still owned by from are moved;
+// no IDs moves all reservations of from. Payments reference the reservation, so they move along.
+// It returns the IDs of the moved reservations.
+func (s *Service) ReassignGuest(ctx context.Context, from, to GuestID, ids []ReservationID) ([]ReservationID, error) {
+	if from == "" || to == "" || from == to {
+		return nil, nil
+	}
+
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var moved []ReservationID
+	for i := range allReservations {
+		reservation := allReservations[i]
+		if reservation.GuestID != from || (len(ids) > 0 && !slices.Contains(ids, reservation.ID)) {
+			continue
+		}
+		before := s.state(&reservation)
+		reservation.GuestID = to
+		reservation.UpdatedAt = time.Now()
+		if err := s.reservationRepo.Update(ctx, reservation.ID, reservation); err != nil {
+			return moved, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		s.audit(ctx, shared.AuditReassign, before, &reservation)
+		moved = append(moved, reservation.ID)
+	}
+	slices.Sort(moved)
+
+	return moved, nil
+}
+
 // ConfirmReservationOnPaymentCaptured handles the payment.captured event.
 // This is called by the event handler when a payment is successfully captured.
 func (s *Service) ConfirmReservationOnPaymentCaptured(ctx context.Context, reservationID ReservationID) error {
 	return s.ConfirmReservation(ctx, reservationID)
 }
 
-// CancelReservationOnPaymentFailed handles the payment.failed event.
-// This is called by the event handler when a payment fails.
-func (s *Service) CancelReservationOnPaymentFailed(ctx context.Context, reservationID ReservationID, reason string) error {
-	return s.CancelReservation(ctx, reservationID, reason)
+// CancelReservationOnPaymentFailed cancels the reservation as the compensation of a failed payment
+// or confirmation. It ignores the notice period and also cancels active stays (see Reservation.Revoke).
+func (s *Service) CancelReservationOnPaymentFailed(ctx context.Context, reservationID ReservationID, reason string) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.CancelReservationOnPaymentFailed", "reservation_id", string(reservationID))
+	defer func() { span.End(err) }()
+
+	return s.cancel(ctx, reservationID, reason, func(r *Reservation) error { return r.Revoke(reason) })
 }
 
 // Event subscription helper for creating reservation events from messages.
@@ -2,7 +2,9 @@ package reservation_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -102,6 +104,108 @@ func (m *mockEventPublisher) Publish(ctx context.Context, evt event.Event) error
 	return nil
 }
 
+// mockRoomProperties maps rooms to properties; unlisted rooms belong to "main".
+type mockRoomProperties map[reservation.RoomID]reservation.PropertyID
+
+func (m mockRoomProperties) PropertyOf(roomID reservation.RoomID) reservation.PropertyID {
+	if id, ok := m[roomID]; ok {
+		return id
+	}
+	return "main"
+}
+
+func (m mockRoomProperties) Location(propertyID reservation.PropertyID) *time.Location {
+	return time.UTC
+}
+
+type mockExchangeRates struct {
+	rate  float64
+	calls int
+}
+
+func (m *mockExchangeRates) Snapshot(ctx context.Context, from, to string) (shared.FXSnapshot, error) {
+	m.calls++
+	if m.rate == 0 {
+		return shared.FXSnapshot{}, reservation.ErrExchangeRateUnavailable
+	}
+	return shared.NewFXSnapshot(from, to, m.rate, time.Now(), "mock"), nil
+}
+
+type mockRoomLocks struct {
+	locked bool
+	err    error
+}
+
+func (m *mockRoomLocks) Lock(ctx context.Context, roomID reservation.RoomID) (func(), error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.locked = true
+	return func() { m.locked = false }, nil
+}
+
+// mockRoomHolds holds every room for the guest.
+type mockRoomHolds struct {
+	guestID reservation.GuestID
+}
+
+func (m *mockRoomHolds) IsHeldForOthers(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange, guestID reservation.GuestID) (bool, error) {
+	return guestID != m.guestID, nil
+}
+
+// mockMetrics counts the increments by name and labels, e.g. "hotel_x_total from_status=pending".
+type mockMetrics struct {
+	counters map[string]int
+}
+
+func (m *mockMetrics) IncCounter(name string, labels ...string) {
+	if m.counters == nil {
+		m.counters = make(map[string]int)
+	}
+	m.counters[strings.TrimSpace(name+" "+strings.Join(labels, "="))]++
+}
+
+func (m *mockMetrics) ObserveHistogram(name string, value float64, labels ...string) {}
+
+// mockTracer records the names of the spans and the errors they ended with.
+type mockTracer struct {
+	spans  []string
+	errors []error
+}
+
+func (m *mockTracer) StartSpan(ctx context.Context, name string, attrs ...string) (context.Context, shared.Span) {
+	m.spans = append(m.spans, name)
+	return ctx, &mockSpan{tracer: m}
+}
+
+type mockSpan struct {
+	tracer *mockTracer
+}
+
+func (s *mockSpan) SetAttributes(attrs ...string) {}
+
+func (s *mockSpan) End(err error) { s.tracer.errors = append(s.tracer.errors, err) }
+
+// mockAuditLog records the audit entries.
+type mockAuditLog struct {
+	entries []shared.AuditEntry
+}
+
+func (m *mockAuditLog) Record(ctx context.Context, entry shared.AuditEntry) {
+	m.entries = append(m.entries, entry)
+}
+
+// lockObservingPublisher records whether the room was still locked when the event was published.
+type lockObservingPublisher struct {
+	locks           *mockRoomLocks
+	lockedOnPublish bool
+}
+
+func (p *lockObservingPublisher) Publish(ctx context.Context, evt event.Event) error {
+	p.lockedOnPublish = p.locks.locked
+	return nil
+}
+
 // ============================================================================
 // Service Test Helpers
 // ============================================================================
@@ -201,6 +305,193 @@ func Test_Service_CreateReservation_Should_Publish_Event(t *testing.T) {
 	assert.That(t, "one event must be published", len(publisher.published), 1)
 }
 
+func Test_Service_CreateReservation_With_Properties_Should_Record_Property_Of_Room(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher).
+		WithProperties(mockRoomProperties{"room-101": "muc"})
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	evt, _ := publisher.published[0].(*reservation.EventCreated)
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "reservation must be at the property of the room", res.PropertyID, reservation.PropertyID("muc"))
+	assert.That(t, "event must carry the property", evt.PropertyID, reservation.PropertyID("muc"))
+	assert.That(t, "stay must be in the timezone of the property", res.DateRange.Timezone, "UTC")
+}
+
+func Test_Service_PropertyOf_Without_Recorded_Property_Should_Derive_From_Room(t *testing.T) {
+	// Arrange
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithProperties(mockRoomProperties{"room-101": "muc"})
+	legacy := reservation.Reservation{ID: "res-001", RoomID: "room-101"}
+
+	// Act
+	propertyID := service.PropertyOf(&legacy)
+
+	// Assert
+	assert.That(t, "property must be derived from the room", propertyID, reservation.PropertyID("muc"))
+}
+
+func Test_Service_CreateReservationWithPerks_Should_Discount_And_Publish_Tier(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+	perks := reservation.Perks{Tier: "platinum", LateCheckout: true, UpgradePriority: true, DiscountPercent: 10}
+
+	// Act
+	res, err := service.CreateReservationWithPerks(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests(), perks, reservation.ArrivalDetails{})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "total must be discounted", res.TotalAmount, shared.NewMoney(9000, "USD"))
+	evt, ok := publisher.published[0].(*reservation.EventCreated)
+	assert.That(t, "event must be reservation.created", ok, true)
+	assert.That(t, "event must carry the tier", evt.GuestTier, "platinum")
+	assert.That(t, "event must carry the discounted total", evt.TotalAmount, shared.NewMoney(9000, "USD"))
+}
+
+func Test_Service_CreateReservationWithPerks_Should_Record_Arrival_Details(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	arrival, _ := reservation.NewArrivalDetails("Jane Doe", "+1 555 0100", "", "22:30")
+
+	// Act
+	_, err := service.CreateReservationWithPerks(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests(), reservation.Perks{}, arrival)
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	stored := repo.reservations["res-001"]
+	assert.That(t, "arrival time must be persisted", stored.ArrivalTime, "22:30")
+	assert.That(t, "emergency contact must be persisted", stored.EmergencyContact.Name, "Jane Doe")
+}
+
+func Test_Service_CreateReservation_With_Exchange_Rates_Should_Record_Snapshot(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	rates := &mockExchangeRates{rate: 1.08}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher).WithExchangeRates(rates, "usd")
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), shared.NewMoney(10000, "EUR"), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "snapshot must be recorded", res.FX != nil && res.FX.Rate == 1.08, true)
+	assert.That(t, "snapshot must be persisted", repo.reservations["res-001"].FX != nil, true)
+	assert.That(t, "amount must convert to the currency of record", res.FX.Convert(res.TotalAmount), shared.NewMoney(10800, "USD"))
+	evt := publisher.published[0].(*reservation.EventCreated)
+	assert.That(t, "event must carry the snapshot", evt.FX, res.FX)
+}
+
+func Test_Service_CreateReservation_In_Currency_Of_Record_Should_Record_Identity_Snapshot(t *testing.T) {
+	// Arrange
+	rates := &mockExchangeRates{}
+	service := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).WithExchangeRates(rates, "USD")
+
+	// Act
+	res, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "snapshot must be identity", res.FX.IsIdentity(), true)
+	assert.That(t, "rates must not be asked", rates.calls, 0)
+}
+
+func Test_Service_CreateReservation_Without_Exchange_Rate_Should_Return_ErrExchangeRateUnavailable(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).WithExchangeRates(&mockExchangeRates{}, "USD")
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), shared.NewMoney(10000, "JPY"), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be ErrExchangeRateUnavailable", errors.Is(err, reservation.ErrExchangeRateUnavailable), true)
+	assert.That(t, "reservation must not be persisted", len(repo.reservations), 0)
+}
+
+func Test_Service_CreateReservation_With_Room_Locks_Should_Check_With_Locked_Checker(t *testing.T) {
+	// Arrange
+	locks := &mockRoomLocks{}
+	publisher := &lockObservingPublisher{locks: locks}
+	service := reservation.NewService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, publisher).
+		WithRoomLocks(locks, &mockAvailabilityChecker{available: false})
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be ErrRoomNotAvailable", errors.Is(err, reservation.ErrRoomNotAvailable), true)
+	assert.That(t, "room must be unlocked", locks.locked, false)
+}
+
+func Test_Service_CreateReservation_With_Room_Locks_Should_Unlock_Before_Publishing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	locks := &mockRoomLocks{}
+	publisher := &lockObservingPublisher{locks: locks}
+	service := reservation.NewService(repo, &mockAvailabilityChecker{available: false}, publisher).
+		WithRoomLocks(locks, &mockAvailabilityChecker{available: true})
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "reservation must be persisted", len(repo.reservations), 1)
+	assert.That(t, "room must be unlocked when publishing", publisher.lockedOnPublish, false)
+}
+
+func Test_Service_CreateReservation_When_Room_Busy_Should_Return_ErrRoomBusy(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	service := createTestService(repo, checker, &mockEventPublisher{}).WithRoomLocks(&mockRoomLocks{err: reservation.ErrRoomBusy}, checker)
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be ErrRoomBusy", errors.Is(err, reservation.ErrRoomBusy), true)
+	assert.That(t, "reservation must not be persisted", len(repo.reservations), 0)
+}
+
+func Test_Service_CreateReservation_When_Room_Held_For_Other_Guest_Should_Return_ErrRoomNotAvailable(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithRoomHolds(&mockRoomHolds{guestID: "guest-waitlisted"})
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be ErrRoomNotAvailable", errors.Is(err, reservation.ErrRoomNotAvailable), true)
+	assert.That(t, "reservation must not be persisted", len(repo.reservations), 0)
+}
+
+func Test_Service_CreateReservation_When_Room_Held_For_Guest_Should_Succeed(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{}).
+		WithRoomHolds(&mockRoomHolds{guestID: "guest-001"})
+
+	// Act
+	_, err := service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "reservation must be persisted", len(repo.reservations), 1)
+}
+
 func Test_Service_CreateReservation_When_Repository_Fails_Should_Return_Error(t *testing.T) {
 	// Arrange
 	repo := newMockReservationRepository()
@@ -364,6 +655,82 @@ func Test_Service_CancelReservation_Should_Update_Status(t *testing.T) {
 	assert.That(t, "cancellation reason must match", res.CancellationReason, reason)
 }
 
+func Test_Service_WithMetrics_Should_Count_Created_And_Cancelled_Reservations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	metrics := &mockMetrics{}
+	service := createTestService(repo, checker, publisher).WithMetrics(metrics)
+	ctx := context.Background()
+
+	_, err := service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	assert.That(t, "create error must be nil", err == nil, true)
+	_, err = service.CreateReservation(ctx, "res-002", "guest-001", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	assert.That(t, "create error must be nil", err == nil, true)
+
+	// Act
+	err = service.CancelReservation(ctx, "res-001", "Guest requested")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "created reservations must be counted", metrics.counters[reservation.MetricReservationsCreated], 2)
+	assert.That(t, "cancellation must be counted by status", metrics.counters[reservation.MetricReservationsCancelled+" from_status=pending"], 1)
+}
+
+func Test_Service_WithTracer_Should_Record_Spans_With_Errors(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	tracer := &mockTracer{}
+	service := createTestService(repo, checker, publisher).WithTracer(tracer)
+	ctx := context.Background()
+
+	// Act
+	_, createErr := service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	cancelErr := service.CancelReservation(ctx, "res-missing", "Guest requested")
+
+	// Assert
+	assert.That(t, "create error must be nil", createErr == nil, true)
+	assert.That(t, "cancel error must not be nil", cancelErr != nil, true)
+	assert.That(t, "spans must be recorded", tracer.spans, []string{"reservation.CreateReservation", "reservation.CancelReservation"})
+	assert.That(t, "successful span must not fail", tracer.errors[0], nil)
+	assert.That(t, "failed span must record the error", tracer.errors[1], cancelErr)
+}
+
+func Test_Service_WithAuditLog_Should_Record_Changes_With_Caller_And_States(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	auditLog := &mockAuditLog{}
+	service := createTestService(repo, checker, publisher).WithAuditLog(auditLog)
+	staff := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalStaff, Subject: "sub-1", Email: "frontdesk@example.com"})
+	_, _ = service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	err := service.CancelReservation(staff, "res-001", "Guest requested")
+	missingErr := service.CancelReservation(staff, "res-missing", "Guest requested")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "failed change must not be recorded", missingErr != nil && len(auditLog.entries) == 2, true)
+	created, cancelled := auditLog.entries[0], auditLog.entries[1]
+	assert.That(t, "creation must be recorded", created.Action, shared.AuditCreate)
+	assert.That(t, "creation without caller must be by the system", created.Actor, shared.ActorSystem)
+	assert.That(t, "creation must have no state before", created.Before == nil, true)
+	assert.That(t, "cancellation must be recorded", cancelled.Action, shared.AuditCancel)
+	assert.That(t, "cancellation must be of the reservation", cancelled.Aggregate+"/"+cancelled.EntityID, "reservation/res-001")
+	assert.That(t, "cancellation must be by the staff member", cancelled.Actor, "staff:frontdesk@example.com")
+	assert.That(t, "subject must be recorded", cancelled.Subject, "sub-1")
+	var before, after reservation.Reservation
+	_ = json.Unmarshal(cancelled.Before, &before)
+	_ = json.Unmarshal(cancelled.After, &after)
+	assert.That(t, "state before must be pending", before.Status, reservation.StatusPending)
+	assert.That(t, "state after must be cancelled", after.Status, reservation.StatusCancelled)
+}
+
 func Test_Service_CancelReservation_Should_Publish_Event(t *testing.T) {
 	// Arrange
 	repo := newMockReservationRepository()
@@ -522,6 +889,98 @@ func Test_Service_ListReservationsByGuest_Should_Return_Guest_Reservations(t *te
 	assert.That(t, "must have 2 reservations", len(reservations), 2)
 }
 
+func Test_Service_ClaimLegacyReservations_Should_Move_Email_Owned_Reservations_To_Subject(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "john@example.com", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", "other@example.com", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	claimed, err := service.ClaimLegacyReservations(ctx, "subject-001", "john@example.com")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must claim 1 reservation", claimed, 1)
+	res, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "reservation must be owned by subject", res.IsOwnedBy("subject-001"), true)
+	assert.That(t, "email must be kept for display", res.GuestEmail, "john@example.com")
+	other, _ := repo.Read(ctx, "res-002")
+	assert.That(t, "other reservation must be unchanged", other.GuestID, reservation.GuestID("other@example.com"))
+}
+
+func Test_Service_ReassignGuest_Should_Move_Only_Given_Reservations_Of_From(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-dup", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", "guest-dup", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-003", "guest-other", "room-103", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	moved, err := service.ReassignGuest(ctx, "guest-dup", "guest-main", []reservation.ReservationID{"res-001", "res-003"})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must move res-001 only", moved, []reservation.ReservationID{"res-001"})
+	res, _ := repo.Read(ctx, "res-001")
+	assert.That(t, "res-001 must be owned by survivor", res.IsOwnedBy("guest-main"), true)
+	res2, _ := repo.Read(ctx, "res-002")
+	assert.That(t, "res-002 must be unchanged", res2.IsOwnedBy("guest-dup"), true)
+	res3, _ := repo.Read(ctx, "res-003")
+	assert.That(t, "res-003 of another guest must be unchanged", res3.IsOwnedBy("guest-other"), true)
+}
+
+func Test_Service_ReassignGuest_Without_IDs_Should_Move_All_Reservations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-002", "guest-dup", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-dup", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+
+	// Act
+	moved, err := service.ReassignGuest(ctx, "guest-dup", "guest-main", nil)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must move both reservations sorted", moved, []reservation.ReservationID{"res-001", "res-002"})
+}
+
+func Test_Service_ListReservationsSharedWith_Should_Return_Accepted_Shares_Only(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	checker := &mockAvailabilityChecker{available: true}
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, checker, publisher)
+
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", "guest-001", "room-102", serviceValidDateRange(), serviceValidMoney(), serviceValidGuests())
+	_, _ = service.ShareReservation(ctx, "res-001", "friend@example.com", reservation.ShareRoleView)
+	_, _ = service.ShareReservation(ctx, "res-002", "friend@example.com", reservation.ShareRoleView)
+	_, _ = service.AcceptShare(ctx, "res-001", "friend@example.com", "guest-friend")
+
+	// Act
+	reservations, err := service.ListReservationsSharedWith(ctx, "guest-friend")
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "must have 1 reservation", len(reservations), 1)
+	assert.That(t, "reservation must be res-001", reservations[0].ID, reservation.ReservationID("res-001"))
+}
+
 // ============================================================================
 // Event Handler Integration Tests
 // ============================================================================
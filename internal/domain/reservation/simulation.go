@@ -0,0 +1,218 @@
+package reservation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ErrInvalidScenario is returned if a pricing rule or cancellation policy of a scenario is out of range.
+var ErrInvalidScenario = errors.New("invalid simulation scenario")
+
+// PricingRule changes the nightly rate of matching nights by Percent,
+// e.g. +15 for a weekend surcharge or -10 for a long-stay discount.
+// Empty RoomIDs and Weekdays match all rooms and nights; MinNights applies to the whole stay.
+type PricingRule struct {
+	Name      string
+	RoomIDs   []RoomID
+	Weekdays  []time.Weekday
+	MinNights int
+	Percent   int
+}
+
+// matches reports whether the rule applies to the night of the reservation.
+func (p PricingRule) matches(r Reservation, night time.Time) bool {
+	if len(p.RoomIDs) > 0 && !slices.Contains(p.RoomIDs, r.RoomID) {
+		return false
+	}
+	if len(p.Weekdays) > 0 && !slices.Contains(p.Weekdays, night.Weekday()) {
+		return false
+	}
+	return r.Nights() >= p.MinNights
+}
+
+// CancellationPolicy charges FeePercent of the total for cancellations within NoticePeriod of check-in.
+// The current policy charges no fee and forbids cancellations within CancellationNoticePeriod.
+type CancellationPolicy struct {
+	NoticePeriod time.Duration
+	FeePercent   int
+}
+
+// PricingScenario is a proposed set of pricing rules and an optional cancellation policy.
+// Amounts are compared in Currency; reservations in other currencies are skipped.
+type PricingScenario struct {
+	Currency     string
+	Rules        []PricingRule
+	Cancellation *CancellationPolicy // nil keeps the current policy
+}
+
+// Validate checks that the percentages and the notice period are in range.
+func (s PricingScenario) Validate() error {
+	for _, rule := range s.Rules {
+		if rule.Name == "" || rule.Percent < -100 || rule.MinNights < 0 {
+			return fmt.Errorf("%w: rule %q needs a name, a percent of at least -100 and non-negative min nights", ErrInvalidScenario, rule.Name)
+		}
+	}
+	if c := s.Cancellation; c != nil && (c.NoticePeriod < 0 || c.FeePercent < 0 || c.FeePercent > 100) {
+		return fmt.Errorf("%w: cancellation fee must be between 0 and 100 percent with a non-negative notice period", ErrInvalidScenario)
+	}
+	return nil
+}
+
+// BookingImpact is the revenue of a booking under the current and the proposed rules.
+type BookingImpact struct {
+	ReservationID ReservationID
+	RoomID        RoomID
+	CheckIn       time.Time
+	Nights        int
+	Status        ReservationStatus
+	Current       Money
+	Proposed      Money
+	Delta         Money
+	Reasons       []string
+}
+
+// SimulationReport compares the revenue of the bookings created between From and To
+// under the current and the proposed rules. Impacts lists the affected bookings, the largest change first.
+type SimulationReport struct {
+	From            time.Time
+	To              time.Time
+	Bookings        int
+	Affected        int
+	Skipped         int // bookings in another currency
+	CurrentRevenue  Money
+	ProposedRevenue Money
+	RevenueDelta    Money
+	Impacts         []BookingImpact
+}
+
+// Simulate replays the reservations created between from and to against the scenario.
+// Guests are assumed to book and cancel as they did: the report shows the revenue the same
+// bookings would have brought, not how demand would have reacted to other prices.
+// Cancelled bookings brought no revenue so far; under a proposed cancellation policy they
+// pay the fee if they were cancelled within its notice period.
+func Simulate(reservations []Reservation, scenario PricingScenario, from, to time.Time) SimulationReport {
+	report := SimulationReport{
+		From:            from,
+		To:              to,
+		CurrentRevenue:  shared.NewMoney(0, scenario.Currency),
+		ProposedRevenue: shared.NewMoney(0, scenario.Currency),
+	}
+	for _, r := range reservations {
+		if r.CreatedAt.Before(from) || !r.CreatedAt.Before(to) {
+			continue
+		}
+		if r.TotalAmount.Currency != scenario.Currency {
+			report.Skipped++
+			continue
+		}
+		report.Bookings++
+
+		impact := simulateBooking(r, scenario)
+		report.CurrentRevenue.Amount += impact.Current.Amount
+		report.ProposedRevenue.Amount += impact.Proposed.Amount
+		if impact.Delta.Amount != 0 {
+			report.Affected++
+			report.Impacts = append(report.Impacts, impact)
+		}
+	}
+	report.RevenueDelta = shared.NewMoney(report.ProposedRevenue.Amount-report.CurrentRevenue.Amount, scenario.Currency)
+
+	slices.SortStableFunc(report.Impacts, func(a, b BookingImpact) int {
+		return cmp.Or(cmp.Compare(abs(b.Delta.Amount), abs(a.Delta.Amount)), cmp.Compare(a.ReservationID, b.ReservationID))
+	})
+	return report
+}
+
+// simulateBooking reprices the nights of the reservation and applies the proposed cancellation fee.
+func simulateBooking(r Reservation, scenario PricingScenario) BookingImpact {
+	currency := r.TotalAmount.Currency
+	impact := BookingImpact{
+		ReservationID: r.ID,
+		RoomID:        r.RoomID,
+		CheckIn:       r.DateRange.CheckIn,
+		Nights:        r.Nights(),
+		Status:        r.Status,
+		Current:       r.TotalAmount,
+	}
+
+	repriced, reasons := repriceNights(r, scenario.Rules)
+	impact.Reasons = reasons
+	impact.Proposed = shared.NewMoney(repriced, currency)
+
+	if r.Status == StatusCancelled {
+		impact.Current = shared.NewMoney(0, currency)
+		impact.Proposed = shared.NewMoney(0, currency)
+		impact.Reasons = nil
+		// The reservation was not changed after it was cancelled, so UpdatedAt is the cancellation time.
+		if c := scenario.Cancellation; c != nil && c.FeePercent > 0 && r.DateRange.CheckIn.Sub(r.UpdatedAt) < c.NoticePeriod {
+			impact.Proposed.Amount = repriced * int64(c.FeePercent) / 100
+			impact.Reasons = append(reasons, fmt.Sprintf("cancellation fee (%d%%)", c.FeePercent))
+		}
+	}
+	impact.Delta = shared.NewMoney(impact.Proposed.Amount-impact.Current.Amount, currency)
+	return impact
+}
+
+// repriceNights spreads the total amount across the nights (including VIP discounts),
+// changes each night by the matching rules and returns the new total with a reason per applied rule.
+func repriceNights(r Reservation, rules []PricingRule) (int64, []string) {
+	nights := r.Nights()
+	if nights <= 0 || len(rules) == 0 {
+		return r.TotalAmount.Amount, nil
+	}
+
+	applied := make(map[string]int) // nights per rule
+	var total int64
+	for i := range nights {
+		rate := r.TotalAmount.Amount / int64(nights)
+		if i == 0 {
+			rate += r.TotalAmount.Amount % int64(nights)
+		}
+		night := r.DateRange.CheckIn.AddDate(0, 0, i)
+		percent := 0
+		for _, rule := range rules {
+			if rule.matches(r, night) {
+				percent += rule.Percent
+				applied[rule.Name]++
+			}
+		}
+		total += rate * int64(100+max(percent, -100)) / 100
+	}
+
+	var reasons []string
+	for _, rule := range rules {
+		if n := applied[rule.Name]; n > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s (%+d%%) on %d of %d nights", rule.Name, rule.Percent, n, nights))
+			delete(applied, rule.Name)
+		}
+	}
+	return total, reasons
+}
+
+// abs returns the absolute value of the amount.
+func abs(amount int64) int64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
+
+// SimulatePricing replays the reservations created between from and to against the proposed
+// pricing rules and cancellation policy, without changing any reservation.
+func (s *Service) SimulatePricing(ctx context.Context, scenario PricingScenario, from, to time.Time) (*SimulationReport, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	reservations, err := s.ListReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := Simulate(reservations, scenario, from, to)
+	return &report, nil
+}
@@ -0,0 +1,222 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Simulation Test Helpers
+// ============================================================================
+
+var (
+	simulationFrom = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	simulationTo   = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// simulationReservation creates a reservation booked on April 10th for the weekend
+// from Friday, May 1st to Monday, May 4th (3 nights).
+func simulationReservation(id reservation.ReservationID, roomID reservation.RoomID, amount int64) reservation.Reservation {
+	checkIn := time.Date(2026, 5, 1, 14, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	return reservation.Reservation{
+		ID:          id,
+		GuestID:     "guest-001",
+		RoomID:      roomID,
+		DateRange:   reservation.NewDateRange(checkIn, checkIn.AddDate(0, 0, 3)),
+		Status:      reservation.StatusCompleted,
+		TotalAmount: shared.NewMoney(amount, "USD"),
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+}
+
+func weekendSurcharge(percent int) reservation.PricingRule {
+	return reservation.PricingRule{Name: "weekend", Weekdays: []time.Weekday{time.Friday, time.Saturday}, Percent: percent}
+}
+
+// ============================================================================
+// Simulate Tests
+// ============================================================================
+
+func Test_Simulate_Without_Rules_Should_Not_Change_Revenue(t *testing.T) {
+	// Arrange
+	reservations := []reservation.Reservation{simulationReservation("res-001", "room-101", 30000)}
+
+	// Act
+	report := reservation.Simulate(reservations, reservation.PricingScenario{Currency: "USD"}, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "bookings must be 1", report.Bookings, 1)
+	assert.That(t, "affected must be 0", report.Affected, 0)
+	assert.That(t, "current revenue must be the total", report.CurrentRevenue.Amount, int64(30000))
+	assert.That(t, "revenue delta must be 0", report.RevenueDelta.Amount, int64(0))
+}
+
+func Test_Simulate_Weekday_Rule_Should_Reprice_Matching_Nights(t *testing.T) {
+	// Arrange
+	reservations := []reservation.Reservation{simulationReservation("res-001", "room-101", 30000)}
+	scenario := reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{weekendSurcharge(20)}}
+
+	// Act
+	report := reservation.Simulate(reservations, scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "affected must be 1", report.Affected, 1)
+	assert.That(t, "proposed revenue must add 20% to Friday and Saturday", report.ProposedRevenue.Amount, int64(34000))
+	assert.That(t, "revenue delta must be 4000", report.RevenueDelta.Amount, int64(4000))
+	assert.That(t, "reason must name the rule", report.Impacts[0].Reasons[0], "weekend (+20%) on 2 of 3 nights")
+}
+
+func Test_Simulate_Rule_For_Other_Room_Should_Not_Apply(t *testing.T) {
+	// Arrange
+	reservations := []reservation.Reservation{simulationReservation("res-001", "room-101", 30000)}
+	rule := reservation.PricingRule{Name: "suite", RoomIDs: []reservation.RoomID{"room-301"}, Percent: 10}
+
+	// Act
+	report := reservation.Simulate(reservations, reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{rule}}, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "affected must be 0", report.Affected, 0)
+}
+
+func Test_Simulate_MinNights_Rule_Should_Only_Apply_To_Long_Stays(t *testing.T) {
+	// Arrange
+	reservations := []reservation.Reservation{simulationReservation("res-001", "room-101", 30000)}
+	rule := reservation.PricingRule{Name: "long stay", MinNights: 7, Percent: -10}
+
+	// Act
+	report := reservation.Simulate(reservations, reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{rule}}, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "affected must be 0", report.Affected, 0)
+}
+
+func Test_Simulate_Cancellation_Within_Notice_Should_Pay_Fee(t *testing.T) {
+	// Arrange
+	res := simulationReservation("res-001", "room-101", 30000)
+	res.Status = reservation.StatusCancelled
+	res.UpdatedAt = res.DateRange.CheckIn.Add(-48 * time.Hour)
+	scenario := reservation.PricingScenario{Currency: "USD", Cancellation: &reservation.CancellationPolicy{NoticePeriod: 72 * time.Hour, FeePercent: 50}}
+
+	// Act
+	report := reservation.Simulate([]reservation.Reservation{res}, scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "current revenue must be 0", report.CurrentRevenue.Amount, int64(0))
+	assert.That(t, "proposed revenue must be the fee", report.ProposedRevenue.Amount, int64(15000))
+	assert.That(t, "reason must name the fee", report.Impacts[0].Reasons[0], "cancellation fee (50%)")
+}
+
+func Test_Simulate_Cancellation_Before_Notice_Should_Be_Free(t *testing.T) {
+	// Arrange
+	res := simulationReservation("res-001", "room-101", 30000)
+	res.Status = reservation.StatusCancelled
+	res.UpdatedAt = res.DateRange.CheckIn.Add(-96 * time.Hour)
+	scenario := reservation.PricingScenario{Currency: "USD", Cancellation: &reservation.CancellationPolicy{NoticePeriod: 72 * time.Hour, FeePercent: 50}}
+
+	// Act
+	report := reservation.Simulate([]reservation.Reservation{res}, scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "affected must be 0", report.Affected, 0)
+	assert.That(t, "proposed revenue must be 0", report.ProposedRevenue.Amount, int64(0))
+}
+
+func Test_Simulate_Should_Skip_Bookings_Outside_Window_And_Currency(t *testing.T) {
+	// Arrange
+	old := simulationReservation("res-old", "room-101", 30000)
+	old.CreatedAt = simulationFrom.AddDate(0, -1, 0)
+	euro := simulationReservation("res-eur", "room-101", 30000)
+	euro.TotalAmount = shared.NewMoney(30000, "EUR")
+	reservations := []reservation.Reservation{old, euro, simulationReservation("res-001", "room-101", 30000)}
+
+	// Act
+	report := reservation.Simulate(reservations, reservation.PricingScenario{Currency: "USD"}, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "bookings must be 1", report.Bookings, 1)
+	assert.That(t, "skipped must be 1", report.Skipped, 1)
+}
+
+func Test_Simulate_Should_Sort_Impacts_By_Largest_Change(t *testing.T) {
+	// Arrange
+	reservations := []reservation.Reservation{
+		simulationReservation("res-001", "room-101", 30000),
+		simulationReservation("res-002", "room-301", 75000),
+	}
+	scenario := reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{weekendSurcharge(-10)}}
+
+	// Act
+	report := reservation.Simulate(reservations, scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "affected must be 2", report.Affected, 2)
+	assert.That(t, "largest change must be first", report.Impacts[0].ReservationID, shared.ReservationID("res-002"))
+	assert.That(t, "revenue delta must be negative", report.RevenueDelta.Amount, int64(-2000-5000))
+}
+
+// ============================================================================
+// PricingScenario Tests
+// ============================================================================
+
+func Test_PricingScenario_Validate_With_Invalid_Fee_Should_Fail(t *testing.T) {
+	// Arrange
+	scenario := reservation.PricingScenario{Currency: "USD", Cancellation: &reservation.CancellationPolicy{NoticePeriod: time.Hour, FeePercent: 150}}
+
+	// Act
+	err := scenario.Validate()
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidScenario", errors.Is(err, reservation.ErrInvalidScenario), true)
+}
+
+func Test_PricingScenario_Validate_With_Unnamed_Rule_Should_Fail(t *testing.T) {
+	// Arrange
+	scenario := reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{{Percent: 10}}}
+
+	// Act
+	err := scenario.Validate()
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidScenario", errors.Is(err, reservation.ErrInvalidScenario), true)
+}
+
+// ============================================================================
+// Service.SimulatePricing Tests
+// ============================================================================
+
+func Test_Service_SimulatePricing_Should_Not_Change_Reservations(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	res := simulationReservation("res-001", "room-101", 30000)
+	repo.reservations[res.ID] = res
+	svc := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	scenario := reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{weekendSurcharge(20)}}
+
+	// Act
+	report, err := svc.SimulatePricing(context.Background(), scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "revenue delta must be 4000", report.RevenueDelta.Amount, int64(4000))
+	assert.That(t, "reservation amount must be unchanged", repo.reservations[res.ID].TotalAmount.Amount, int64(30000))
+}
+
+func Test_Service_SimulatePricing_With_Invalid_Scenario_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestService(newMockReservationRepository(), &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	scenario := reservation.PricingScenario{Currency: "USD", Rules: []reservation.PricingRule{{Name: "free", Percent: -200}}}
+
+	// Act
+	_, err := svc.SimulatePricing(context.Background(), scenario, simulationFrom, simulationTo)
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidScenario", errors.Is(err, reservation.ErrInvalidScenario), true)
+}
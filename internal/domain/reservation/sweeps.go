@@ -0,0 +1,107 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ExpiryReason is the cancellation reason of pending reservations whose payment never arrived.
+const ExpiryReason = "payment not received in time"
+
+// The sweeps below are run periodically by the scheduler. Each one moves every due reservation on
+// its own, so a reservation that fails does not hold back the others; the errors are joined.
+// Running a sweep twice changes nothing the second time, because the moved reservations are no longer due.
+
+// MarkNoShows marks the confirmed reservations whose check-in day has passed at the property without a check-in as no-show.
+// The day of check-in is left alone entirely, so a guest arriving late (see ArrivalTime) is never marked.
+func (s *Service) MarkNoShows(ctx context.Context, now time.Time) ([]ReservationID, error) {
+	return s.sweep(ctx, func(r *Reservation) bool {
+		return r.Status == StatusConfirmed && r.DateRange.Today(now).After(calendarDate(r.DateRange.CheckIn))
+	}, s.MarkNoShow)
+}
+
+// CompleteDepartedStays completes the active reservations whose check-out day has passed
+// without a check-out at the desk.
+func (s *Service) CompleteDepartedStays(ctx context.Context, now time.Time) ([]ReservationID, error) {
+	return s.sweep(ctx, func(r *Reservation) bool {
+		return r.Status == StatusActive && r.DateRange.Today(now).After(calendarDate(r.DateRange.CheckOut))
+	}, s.CompleteReservation)
+}
+
+// ExpireUnpaidReservations cancels the pending reservations created more than maxAge before now,
+// releasing rooms held by bookings whose payment never arrived.
+func (s *Service) ExpireUnpaidReservations(ctx context.Context, now time.Time, maxAge time.Duration) ([]ReservationID, error) {
+	return s.sweep(ctx, func(r *Reservation) bool {
+		return r.Status == StatusPending && now.Sub(r.CreatedAt) > maxAge
+	}, s.ExpireReservation)
+}
+
+// MarkNoShow transitions a confirmed reservation to no-show.
+func (s *Service) MarkNoShow(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.MarkNoShow", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
+	reservation, err := s.reservationRepo.Read(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation: %w", err)
+	}
+
+	before := s.state(reservation)
+	if err := reservation.MarkNoShow(); err != nil {
+		return fmt.Errorf("failed to mark reservation as no-show: %w", err)
+	}
+	reservation.attributeStatusChange(actorOf(ctx))
+
+	if err := s.reservationRepo.Update(ctx, id, *reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	s.audit(ctx, shared.AuditNoShow, before, reservation)
+
+	evt := NewEventNoShow().
+		WithReservationID(id).
+		WithGuestID(reservation.GuestID)
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireReservation cancels a pending reservation whose payment never arrived.
+// It publishes reservation.cancelled like any other cancellation, so the room is released.
+func (s *Service) ExpireReservation(ctx context.Context, id ReservationID) (err error) {
+	ctx, span := shared.StartSpan(ctx, s.tracer, "reservation.ExpireReservation", "reservation_id", string(id))
+	defer func() { span.End(err) }()
+
+	return s.cancel(ctx, id, ExpiryReason, func(r *Reservation) error { return r.Expire(ExpiryReason) })
+}
+
+// sweep applies the transition to every reservation that is due and returns the IDs of the moved ones, sorted.
+func (s *Service) sweep(ctx context.Context, due func(r *Reservation) bool, transition func(ctx context.Context, id ReservationID) error) ([]ReservationID, error) {
+	allReservations, err := s.reservationRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	var moved []ReservationID
+	var errs []error
+	for i := range allReservations {
+		if !due(&allReservations[i]) {
+			continue
+		}
+		id := allReservations[i].ID
+		if err := transition(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("reservation %s: %w", id, err))
+			continue
+		}
+		moved = append(moved, id)
+	}
+	slices.Sort(moved)
+
+	return moved, errors.Join(errs...)
+}
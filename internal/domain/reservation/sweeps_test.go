@@ -0,0 +1,170 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/reservation"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// ============================================================================
+// Sweep Test Helpers
+// ============================================================================
+
+var sweepNow = time.Date(2026, 6, 3, 9, 0, 0, 0, time.UTC)
+
+// storeSweepReservation stores a reservation of room-101 from June 2nd, 14:00 to June 4th, 11:00,
+// created at the given time.
+func storeSweepReservation(repo *mockReservationRepository, id reservation.ReservationID, status reservation.ReservationStatus, createdAt time.Time) {
+	repo.reservations[id] = reservation.Reservation{
+		ID:      id,
+		GuestID: "guest-001",
+		RoomID:  "room-101",
+		DateRange: reservation.NewDateRange(
+			time.Date(2026, 6, 2, 14, 0, 0, 0, time.UTC),
+			time.Date(2026, 6, 4, 11, 0, 0, 0, time.UTC),
+		),
+		Status:      status,
+		TotalAmount: shared.NewMoney(20000, "USD"),
+		CreatedAt:   createdAt,
+	}
+}
+
+// ============================================================================
+// MarkNoShows Tests
+// ============================================================================
+
+func Test_Service_MarkNoShows_Should_Mark_Confirmed_After_CheckIn_Day(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	storeSweepReservation(repo, "res-002", reservation.StatusActive, sweepNow)
+
+	// Act
+	marked, err := service.MarkNoShows(context.Background(), sweepNow)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the confirmed reservation must be marked", marked, []reservation.ReservationID{"res-001"})
+	assert.That(t, "status must be no_show", repo.reservations["res-001"].Status, reservation.StatusNoShow)
+	assert.That(t, "active reservation must stay active", repo.reservations["res-002"].Status, reservation.StatusActive)
+	assert.That(t, "event must be published", publisher.published[0].Topic(), reservation.EventTopicNoShow)
+	history := repo.reservations["res-001"].History
+	assert.That(t, "change must be attributed to the system", history[len(history)-1].Actor, reservation.ActorSystem)
+}
+
+func Test_Service_MarkNoShows_On_CheckIn_Day_Should_Mark_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	lateEvening := time.Date(2026, 6, 2, 23, 30, 0, 0, time.UTC)
+
+	// Act
+	marked, err := service.MarkNoShows(context.Background(), lateEvening)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be marked", len(marked), 0)
+	assert.That(t, "status must stay confirmed", repo.reservations["res-001"].Status, reservation.StatusConfirmed)
+}
+
+func Test_Service_MarkNoShows_With_Failing_Update_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusConfirmed, sweepNow)
+	repo.updateErr = errors.New("database error")
+
+	// Act
+	marked, err := service.MarkNoShows(context.Background(), sweepNow)
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+	assert.That(t, "nothing must be marked", len(marked), 0)
+}
+
+// ============================================================================
+// CompleteDepartedStays Tests
+// ============================================================================
+
+func Test_Service_CompleteDepartedStays_Should_Complete_Active_After_CheckOut_Day(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+	dayAfterCheckOut := time.Date(2026, 6, 5, 0, 30, 0, 0, time.UTC)
+
+	// Act
+	completed, err := service.CompleteDepartedStays(context.Background(), dayAfterCheckOut)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "reservation must be completed", completed, []reservation.ReservationID{"res-001"})
+	assert.That(t, "status must be completed", repo.reservations["res-001"].Status, reservation.StatusCompleted)
+	assert.That(t, "event must be published", publisher.published[0].Topic(), reservation.EventTopicCompleted)
+}
+
+func Test_Service_CompleteDepartedStays_On_CheckOut_Day_Should_Complete_Nothing(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, &mockEventPublisher{})
+	storeSweepReservation(repo, "res-001", reservation.StatusActive, sweepNow)
+	checkOutDay := time.Date(2026, 6, 4, 18, 0, 0, 0, time.UTC)
+
+	// Act
+	completed, err := service.CompleteDepartedStays(context.Background(), checkOutDay)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be completed", len(completed), 0)
+}
+
+// ============================================================================
+// ExpireUnpaidReservations Tests
+// ============================================================================
+
+func Test_Service_ExpireUnpaidReservations_Should_Cancel_Old_Pending(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusPending, sweepNow.Add(-time.Hour))
+	storeSweepReservation(repo, "res-002", reservation.StatusPending, sweepNow.Add(-10*time.Minute))
+
+	// Act
+	expired, err := service.ExpireUnpaidReservations(context.Background(), sweepNow, 30*time.Minute)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "only the old reservation must expire", expired, []reservation.ReservationID{"res-001"})
+	assert.That(t, "status must be cancelled", repo.reservations["res-001"].Status, reservation.StatusCancelled)
+	assert.That(t, "reason must be recorded", repo.reservations["res-001"].CancellationReason, reservation.ExpiryReason)
+	assert.That(t, "recent reservation must stay pending", repo.reservations["res-002"].Status, reservation.StatusPending)
+	assert.That(t, "event must be published", publisher.published[0].Topic(), reservation.EventTopicCancelled)
+}
+
+func Test_Service_ExpireUnpaidReservations_Twice_Should_Expire_Once(t *testing.T) {
+	// Arrange
+	repo := newMockReservationRepository()
+	publisher := &mockEventPublisher{}
+	service := createTestService(repo, &mockAvailabilityChecker{available: true}, publisher)
+	storeSweepReservation(repo, "res-001", reservation.StatusPending, sweepNow.Add(-time.Hour))
+	ctx := context.Background()
+	_, _ = service.ExpireUnpaidReservations(ctx, sweepNow, 30*time.Minute)
+
+	// Act
+	expired, err := service.ExpireUnpaidReservations(ctx, sweepNow, 30*time.Minute)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must expire again", len(expired), 0)
+	assert.That(t, "one event must be published", len(publisher.published), 1)
+}
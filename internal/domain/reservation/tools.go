@@ -3,37 +3,101 @@ package reservation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andygeiss/cloud-native-utils/mcp"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
 )
 
+// MaxBulkCancellations limits the reservations of one cancel_reservations call.
+const MaxBulkCancellations = 100
+
+const (
+	// MaxBulkAvailabilityQueries limits the queries of one check_availability_bulk call.
+	MaxBulkAvailabilityQueries = 50
+	// bulkAvailabilityWorkers limits the queries of one check_availability_bulk call checked at the same time,
+	// so one agent does not take every connection of the reservation database.
+	bulkAvailabilityWorkers = 4
+)
+
+// Scopes a service account needs to call the reservation tools.
+const (
+	ScopeRead  = "reservations:read"
+	ScopeWrite = "reservations:write"
+)
+
+var (
+	// ErrNotReservationOwner is returned if a guest accesses a reservation of another guest account.
+	ErrNotReservationOwner = errors.New("reservation belongs to another guest")
+	// ErrMissingGuestFilter is returned if list_reservations is called by staff without a guest.
+	ErrMissingGuestFilter = errors.New("guest_id or guest_email is required")
+	// ErrTooManyReservations is returned if cancel_reservations gets more than MaxBulkCancellations IDs.
+	ErrTooManyReservations = fmt.Errorf("at most %d reservations per call", MaxBulkCancellations)
+	// ErrTooManyQueries is returned if check_availability_bulk gets no or more than MaxBulkAvailabilityQueries queries.
+	ErrTooManyQueries = fmt.Errorf("1 to %d queries per call", MaxBulkAvailabilityQueries)
+)
+
+// requireAccess returns ErrNotReservationOwner if the caller is a guest that may not view
+// (or, if manage is set, manage) the reservation, either as owner or via a share grant.
+// Staff and service accounts may access every reservation.
+func requireAccess(ctx context.Context, reservation *Reservation, manage bool) error {
+	p, ok := shared.PrincipalFromContext(ctx)
+	if !ok || p.Type != shared.PrincipalGuest {
+		return nil
+	}
+	guestID := GuestID(p.Subject)
+	if (manage && !reservation.CanManage(guestID)) || (!manage && !reservation.CanView(guestID)) {
+		return ErrNotReservationOwner
+	}
+	return nil
+}
+
 // RegisterTools registers all reservation MCP tools with the server.
 func RegisterTools(server *mcp.Server, service *Service, checker AvailabilityChecker) {
-	server.RegisterTool(newGetReservationTool(service))
-	server.RegisterTool(newListReservationsTool(service))
-	server.RegisterTool(newCancelReservationTool(service))
-	server.RegisterTool(newCheckAvailabilityTool(checker))
+	server.RegisterTool(shared.GuardTool(newGetReservationTool(service), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newGetReservationHistoryTool(service), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newListReservationsTool(service), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newCancelReservationTool(service), ScopeWrite))
+	server.RegisterTool(shared.GuardTool(newCancelReservationsTool(service), ScopeWrite))
+	server.RegisterTool(shared.GuardTool(newCheckAvailabilityTool(checker), ScopeRead))
+	server.RegisterTool(shared.GuardTool(newGetRoomCalendarTool(service), ScopeRead))
+}
+
+// RegisterTripPlanningTools registers the MCP tools that check room types instead of rooms, e.g. for trip planning agents.
+// Queries may be scoped to a property if properties is not nil.
+func RegisterTripPlanningTools(server *mcp.Server, checker AvailabilityChecker, rates *Rates, properties RoomProperties) {
+	server.RegisterTool(shared.GuardTool(newCheckAvailabilityBulkTool(checker, rates, properties), ScopeRead))
 }
 
 // newGetReservationTool creates a new tool for getting.
 func newGetReservationTool(service *Service) mcp.Tool {
 	return mcp.NewTool(
 		"get_reservation",
-		"Get reservation details by ID. Returns reservation status, guest info, dates, and amount.",
+		"Get reservation details by ID or confirmation number (e.g. BER-2025-00123). Returns reservation status, guest info, dates, and amount.",
 		mcp.NewObjectSchema(
 			map[string]mcp.Property{
-				"id": mcp.NewStringProperty("The reservation ID"),
+				"id": mcp.NewStringProperty("The reservation ID or confirmation number"),
 			},
 			[]string{"id"},
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
-			reservation, err := service.GetReservation(ctx, ReservationID(id))
+			raw, _ := params.Arguments["id"].(string)
+			id, err := shared.ParseReservationID(raw)
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
+			reservation, err := service.FindReservation(ctx, string(id))
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if err := requireAccess(ctx, reservation, false); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
 			data, _ := json.MarshalIndent(reservation, "", "  ")
 			return mcp.ToolsCallResult{
 				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
@@ -42,23 +106,76 @@ func newGetReservationTool(service *Service) mcp.Tool {
 	)
 }
 
+// newGetReservationHistoryTool creates a tool for getting the status history of a reservation.
+func newGetReservationHistoryTool(service *Service) mcp.Tool {
+	return mcp.NewTool(
+		"get_reservation_history",
+		"Get the status history of a reservation by ID or confirmation number. Returns every status change, oldest first, with the previous and new status, time, actor and reason.",
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"id": mcp.NewStringProperty("The reservation ID or confirmation number"),
+			},
+			[]string{"id"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			raw, _ := params.Arguments["id"].(string)
+			id, err := shared.ParseReservationID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			reservation, err := service.FindReservation(ctx, string(id))
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if err := requireAccess(ctx, reservation, false); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			data, _ := json.MarshalIndent(reservation.StatusHistory(), "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
+
 // newListReservationsTool creates a tool for listing reservations.
 func newListReservationsTool(service *Service) mcp.Tool {
 	return mcp.NewTool(
 		"list_reservations",
-		"List all reservations for a guest by their email address.",
+		"List all reservations of a guest by their account ID (OIDC subject) or contact email address, optionally at one property. Guests always get their own reservations.",
 		mcp.NewObjectSchema(
 			map[string]mcp.Property{
-				"guest_email": mcp.NewStringProperty("The guest's email address"),
+				"guest_id":    mcp.NewStringProperty("The guest's account ID (OIDC subject)"),
+				"guest_email": mcp.NewStringProperty("The guest's contact email address"),
+				"property_id": mcp.NewStringProperty("Only reservations at this property (see list_properties)"),
 			},
-			[]string{"guest_email"},
+			nil,
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			guestID, _ := params.Arguments["guest_id"].(string)
 			email, _ := params.Arguments["guest_email"].(string)
-			reservations, err := service.ListReservationsByGuest(ctx, GuestID(email))
+			if p, ok := shared.PrincipalFromContext(ctx); ok && p.Type == shared.PrincipalGuest {
+				guestID, email = p.Subject, ""
+			}
+
+			var reservations []*Reservation
+			var err error
+			switch {
+			case guestID != "":
+				reservations, err = service.ListReservationsByGuest(ctx, GuestID(guestID))
+			case email != "":
+				reservations, err = service.ListReservationsByGuestEmail(ctx, email)
+			default:
+				return mcp.ToolsCallResult{}, ErrMissingGuestFilter
+			}
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
+			if propertyID, _ := params.Arguments["property_id"].(string); propertyID != "" {
+				reservations = slices.DeleteFunc(reservations, func(r *Reservation) bool {
+					return service.PropertyOf(r) != PropertyID(propertyID)
+				})
+			}
 			data, _ := json.MarshalIndent(reservations, "", "  ")
 			return mcp.ToolsCallResult{
 				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
@@ -74,15 +191,26 @@ func newCancelReservationTool(service *Service) mcp.Tool {
 		"Cancel a reservation. Requires a reason. Cannot cancel within 24 hours of check-in.",
 		mcp.NewObjectSchema(
 			map[string]mcp.Property{
-				"id":     mcp.NewStringProperty("The reservation ID"),
+				"id":     mcp.NewStringProperty("The reservation ID or confirmation number"),
 				"reason": mcp.NewStringProperty("Reason for cancellation"),
 			},
 			[]string{"id", "reason"},
 		),
 		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
-			id, _ := params.Arguments["id"].(string)
+			raw, _ := params.Arguments["id"].(string)
+			id, err := shared.ParseReservationID(raw)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
 			reason, _ := params.Arguments["reason"].(string)
-			err := service.CancelReservation(ctx, ReservationID(id), reason)
+			reservation, err := service.FindReservation(ctx, string(id))
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			if err := requireAccess(ctx, reservation, true); err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			err = service.CancelReservation(ctx, reservation.ID, reason)
 			if err != nil {
 				return mcp.ToolsCallResult{}, err
 			}
@@ -93,6 +221,74 @@ func newCancelReservationTool(service *Service) mcp.Tool {
 	)
 }
 
+// bulkCancellation is the outcome of one reservation of cancel_reservations.
+type bulkCancellation struct {
+	ID        ReservationID `json:"id"`
+	Cancelled bool          `json:"cancelled"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// newCancelReservationsTool creates a tool for canceling several reservations, e.g. of a closed floor.
+// It reports its progress after every reservation and stops when the client cancels the call;
+// reservations cancelled until then stay cancelled.
+func newCancelReservationsTool(service *Service) mcp.Tool {
+	return mcp.NewTool(
+		"cancel_reservations",
+		fmt.Sprintf("Cancel up to %d reservations with the same reason. Returns the outcome per reservation; failures do not stop the others.", MaxBulkCancellations),
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"ids":    mcp.NewStringProperty("Comma-separated reservation IDs or confirmation numbers"),
+				"reason": mcp.NewStringProperty("Reason for cancellation"),
+			},
+			[]string{"ids", "reason"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			raw, _ := params.Arguments["ids"].(string)
+			reason, _ := params.Arguments["reason"].(string)
+			var ids []ReservationID
+			for part := range strings.SplitSeq(raw, ",") {
+				id, err := shared.ParseReservationID(strings.TrimSpace(part))
+				if err != nil {
+					return mcp.ToolsCallResult{}, err
+				}
+				ids = append(ids, id)
+			}
+			if len(ids) > MaxBulkCancellations {
+				return mcp.ToolsCallResult{}, ErrTooManyReservations
+			}
+
+			results := make([]bulkCancellation, 0, len(ids))
+			cancelled := 0
+			for i, id := range ids {
+				if err := ctx.Err(); err != nil {
+					return mcp.ToolsCallResult{}, fmt.Errorf("stopped after %d of %d reservations: %w", i, len(ids), err)
+				}
+				result := bulkCancellation{ID: id}
+				reservation, err := service.FindReservation(ctx, string(id))
+				if err == nil {
+					err = requireAccess(ctx, reservation, true)
+				}
+				if err == nil {
+					err = service.CancelReservation(ctx, reservation.ID, reason)
+				}
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Cancelled = true
+					cancelled++
+				}
+				results = append(results, result)
+				shared.ReportProgress(ctx, float64(i+1), float64(len(ids)), fmt.Sprintf("%d of %d reservations processed", i+1, len(ids)))
+			}
+
+			data, _ := json.MarshalIndent(map[string]any{"cancelled": cancelled, "results": results}, "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
+
 // newCheckAvailabilityTool creates a tool for checking room availability.
 func newCheckAvailabilityTool(checker AvailabilityChecker) mcp.Tool {
 	return mcp.NewTool(
@@ -138,3 +334,191 @@ func newCheckAvailabilityTool(checker AvailabilityChecker) mcp.Tool {
 		},
 	)
 }
+
+// newGetRoomCalendarTool creates a tool for the per-night availability of a room.
+func newGetRoomCalendarTool(service *Service) mcp.Tool {
+	return mcp.NewTool(
+		"get_room_calendar",
+		fmt.Sprintf("Get the availability of a room per night over a date span, e.g. to suggest free dates. Returns one entry per night with its date and whether it is available. Defaults to %d nights from today.", DefaultCalendarDays),
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"room_id": mcp.NewStringProperty("The room ID"),
+				"from":    mcp.NewStringProperty("First night (YYYY-MM-DD, default today)"),
+				"days":    mcp.NewNumberProperty(fmt.Sprintf("Number of nights (1-%d, default %d)", MaxCalendarDays, DefaultCalendarDays)),
+			},
+			[]string{"room_id"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			roomID, _ := params.Arguments["room_id"].(string)
+			from := time.Now()
+			if value, _ := params.Arguments["from"].(string); value != "" {
+				parsed, err := time.Parse("2006-01-02", value)
+				if err != nil {
+					return mcp.ToolsCallResult{}, fmt.Errorf("invalid from date format: %w", err)
+				}
+				from = parsed
+			}
+			days := DefaultCalendarDays
+			if value, ok := params.Arguments["days"].(float64); ok {
+				days = int(value)
+			}
+
+			calendar, err := service.GetRoomCalendar(ctx, RoomID(roomID), from, days)
+			if err != nil {
+				return mcp.ToolsCallResult{}, err
+			}
+			type calendarDay struct {
+				Date      string `json:"date"`
+				Available bool   `json:"available"`
+			}
+			result := struct {
+				RoomID          string        `json:"room_id"`
+				AvailableNights int           `json:"available_nights"`
+				Days            []calendarDay `json:"days"`
+			}{RoomID: roomID}
+			for _, day := range calendar.Days {
+				if day.Available {
+					result.AvailableNights++
+				}
+				result.Days = append(result.Days, calendarDay{Date: day.Date.Format("2006-01-02"), Available: day.Available})
+			}
+			data, _ := json.MarshalIndent(result, "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
+
+// bulkAvailability is the outcome of one query of check_availability_bulk.
+type bulkAvailability struct {
+	RoomType       RoomTypeID `json:"room_type"`
+	PropertyID     PropertyID `json:"property_id,omitempty"`
+	CheckIn        string     `json:"check_in"`
+	CheckOut       string     `json:"check_out"`
+	Available      bool       `json:"available"`
+	AvailableRooms []RoomID   `json:"available_rooms,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// newCheckAvailabilityBulkTool creates a tool for checking several room types and date ranges in one call,
+// e.g. the stops of a multi-city trip or the alternatives of flexible dates.
+// Queries are checked by a few workers at a time; a failed query is reported with its result
+// and does not stop the others. It reports its progress after every query.
+func newCheckAvailabilityBulkTool(checker AvailabilityChecker, rates *Rates, properties RoomProperties) mcp.Tool {
+	return mcp.NewTool(
+		"check_availability_bulk",
+		fmt.Sprintf("Check up to %d room types and date ranges in one call, e.g. for multi-city trips or flexible dates. Returns per query whether a room of the type is available and which rooms are; a failed query reports its error without failing the others.", MaxBulkAvailabilityQueries),
+		mcp.NewObjectSchema(
+			map[string]mcp.Property{
+				"queries": {
+					Type:        "array",
+					Description: `The queries, each an object with room_type (e.g. "deluxe"), check_in and check_out (YYYY-MM-DD) and optionally property_id to check only the rooms of the type at that property`,
+				},
+			},
+			[]string{"queries"},
+		),
+		func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+			queries, _ := params.Arguments["queries"].([]any)
+			if len(queries) == 0 || len(queries) > MaxBulkAvailabilityQueries {
+				return mcp.ToolsCallResult{}, ErrTooManyQueries
+			}
+
+			results := make([]bulkAvailability, len(queries))
+			var (
+				wg      sync.WaitGroup
+				mu      sync.Mutex // guards done and the progress reports
+				done    int
+				workers = make(chan struct{}, bulkAvailabilityWorkers)
+			)
+			for i, query := range queries {
+				wg.Add(1)
+				workers <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-workers }()
+					results[i] = checkBulkAvailability(ctx, checker, rates, properties, query)
+
+					mu.Lock()
+					defer mu.Unlock()
+					done++
+					shared.ReportProgress(ctx, float64(done), float64(len(queries)), fmt.Sprintf("%d of %d queries checked", done, len(queries)))
+				}()
+			}
+			wg.Wait()
+			if err := ctx.Err(); err != nil {
+				return mcp.ToolsCallResult{}, fmt.Errorf("stopped after %d of %d queries: %w", done, len(queries), err)
+			}
+
+			available := 0
+			for _, result := range results {
+				if result.Available {
+					available++
+				}
+			}
+			data, _ := json.MarshalIndent(map[string]any{"available": available, "results": results}, "", "  ")
+			return mcp.ToolsCallResult{
+				Content: []mcp.ContentBlock{mcp.NewTextContent(string(data))},
+			}, nil
+		},
+	)
+}
+
+// checkBulkAvailability checks one query of check_availability_bulk against every room of its type,
+// or of its type at the property of the query.
+func checkBulkAvailability(ctx context.Context, checker AvailabilityChecker, rates *Rates, properties RoomProperties, query any) bulkAvailability {
+	fields, _ := query.(map[string]any)
+	roomType, _ := fields["room_type"].(string)
+	propertyID, _ := fields["property_id"].(string)
+	checkInStr, _ := fields["check_in"].(string)
+	checkOutStr, _ := fields["check_out"].(string)
+	result := bulkAvailability{RoomType: RoomTypeID(roomType), PropertyID: PropertyID(propertyID), CheckIn: checkInStr, CheckOut: checkOutStr}
+
+	checkIn, err := time.Parse("2006-01-02", checkInStr)
+	if err != nil {
+		result.Error = "invalid check_in date format, use YYYY-MM-DD"
+		return result
+	}
+	checkOut, err := time.Parse("2006-01-02", checkOutStr)
+	if err != nil {
+		result.Error = "invalid check_out date format, use YYYY-MM-DD"
+		return result
+	}
+	if !checkOut.After(checkIn) {
+		result.Error = "check_out must be after check_in"
+		return result
+	}
+	var rooms []RoomID
+	for _, t := range rates.RoomTypes() {
+		if t.ID == result.RoomType {
+			rooms = t.RoomIDs
+		}
+	}
+	if rooms == nil {
+		result.Error = fmt.Sprintf("%v: %s", ErrRoomTypeNotFound, roomType)
+		return result
+	}
+	if propertyID != "" && properties != nil {
+		rooms = slices.DeleteFunc(slices.Clone(rooms), func(room RoomID) bool {
+			return properties.PropertyOf(room) != result.PropertyID
+		})
+	}
+
+	dateRange := NewDateRange(checkIn, checkOut)
+	for _, roomID := range rooms {
+		if err := ctx.Err(); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		available, err := checker.IsRoomAvailable(ctx, roomID, dateRange)
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to check room %s: %v", roomID, err)
+			return result
+		}
+		if available {
+			result.AvailableRooms = append(result.AvailableRooms, roomID)
+		}
+	}
+	result.Available = len(result.AvailableRooms) > 0
+	return result
+}
@@ -2,6 +2,7 @@ package reservation_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -72,8 +73,9 @@ func (m *toolsMockReservationRepository) ReadAll(ctx context.Context) ([]reserva
 }
 
 type toolsMockAvailabilityChecker struct {
-	available bool
-	err       error
+	available   bool
+	err         error
+	overlapping []*reservation.Reservation
 }
 
 func (m *toolsMockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
@@ -84,7 +86,7 @@ func (m *toolsMockAvailabilityChecker) IsRoomAvailable(ctx context.Context, room
 }
 
 func (m *toolsMockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
-	return nil, nil
+	return m.overlapping, nil
 }
 
 type toolsMockEventPublisher struct {
@@ -141,7 +143,7 @@ func Test_RegisterTools_Should_Register_All_Tools(t *testing.T) {
 
 	// Assert
 	tools := server.Tools()
-	assert.That(t, "must register 4 tools", len(tools), 4)
+	assert.That(t, "must register 7 tools", len(tools), 7)
 
 	// Verify tool names
 	toolNames := make(map[string]bool)
@@ -149,9 +151,12 @@ func Test_RegisterTools_Should_Register_All_Tools(t *testing.T) {
 		toolNames[tool.Definition.Name] = true
 	}
 	assert.That(t, "get_reservation must be registered", toolNames["get_reservation"], true)
+	assert.That(t, "get_reservation_history must be registered", toolNames["get_reservation_history"], true)
 	assert.That(t, "list_reservations must be registered", toolNames["list_reservations"], true)
 	assert.That(t, "cancel_reservation must be registered", toolNames["cancel_reservation"], true)
+	assert.That(t, "cancel_reservations must be registered", toolNames["cancel_reservations"], true)
 	assert.That(t, "check_availability must be registered", toolNames["check_availability"], true)
+	assert.That(t, "get_room_calendar must be registered", toolNames["get_room_calendar"], true)
 }
 
 // ============================================================================
@@ -233,6 +238,33 @@ func Test_GetReservationTool_When_Not_Found_Should_Return_Error(t *testing.T) {
 // ListReservations Tool Tests
 // ============================================================================
 
+func Test_GetReservationTool_With_Malformed_ID_Should_Return_ErrInvalidID(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	service := createToolsTestService(repo, checker, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	var getTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_reservation" {
+			getTool = tool
+		}
+	}
+	params := mcp.ToolsCallParams{
+		Name:      "get_reservation",
+		Arguments: map[string]any{"id": "res_123"},
+	}
+
+	// Act
+	_, err := getTool.Handler(context.Background(), params)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidID", errors.Is(err, shared.ErrInvalidID), true)
+	assert.That(t, "err must explain the format", strings.Contains(err.Error(), "26-character ULID"), true)
+}
+
 func Test_ListReservationsTool_Should_Return_Guest_Reservations(t *testing.T) {
 	// Arrange
 	repo := newToolsMockReservationRepository()
@@ -260,7 +292,7 @@ func Test_ListReservationsTool_Should_Return_Guest_Reservations(t *testing.T) {
 
 	params := mcp.ToolsCallParams{
 		Name:      "list_reservations",
-		Arguments: map[string]any{"guest_email": "guest-001"},
+		Arguments: map[string]any{"guest_id": "guest-001"},
 	}
 
 	// Act
@@ -273,6 +305,122 @@ func Test_ListReservationsTool_Should_Return_Guest_Reservations(t *testing.T) {
 	assert.That(t, "content must contain res-002", strings.Contains(result.Content[0].Text, "res-002"), true)
 }
 
+func Test_ListReservationsTool_With_Guest_Email_Should_Return_Guest_Reservations(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	publisher := &toolsMockEventPublisher{}
+	service := createToolsTestService(repo, checker, publisher)
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	ctx := context.Background()
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+
+	var listTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "list_reservations" {
+			listTool = tool
+		}
+	}
+
+	// Act
+	result, err := listTool.Handler(ctx, mcp.ToolsCallParams{Name: "list_reservations", Arguments: map[string]any{"guest_email": "JOHN@example.com"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must contain res-001", strings.Contains(result.Content[0].Text, "res-001"), true)
+}
+
+func Test_GetReservationTool_With_Guest_Principal_Of_Other_Guest_Should_Return_ErrNotReservationOwner(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	publisher := &toolsMockEventPublisher{}
+	service := createToolsTestService(repo, checker, publisher)
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	_, _ = service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+
+	var getTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_reservation" {
+			getTool = tool
+		}
+	}
+	ctx := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest, Subject: "guest-002"})
+
+	// Act
+	_, err := getTool.Handler(ctx, mcp.ToolsCallParams{Name: "get_reservation", Arguments: map[string]any{"id": "res-001"}})
+
+	// Assert
+	assert.That(t, "error must be ErrNotReservationOwner", errors.Is(err, reservation.ErrNotReservationOwner), true)
+}
+
+// ============================================================================
+// GetReservationHistory Tool Tests
+// ============================================================================
+
+func Test_GetReservationHistoryTool_Should_Return_Attributed_Status_Changes(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	publisher := &toolsMockEventPublisher{}
+	service := createToolsTestService(repo, checker, publisher)
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+	staff := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalStaff, Subject: "staff-1", Email: "desk@hotel.example"})
+	guest := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest, Subject: "guest-001", Email: "john@example.com"})
+	_, _ = service.CreateReservation(staff, "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+	_ = service.CancelReservation(guest, "res-001", "Change of plans")
+
+	var historyTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_reservation_history" {
+			historyTool = tool
+		}
+	}
+
+	// Act
+	result, err := historyTool.Handler(guest, mcp.ToolsCallParams{Name: "get_reservation_history", Arguments: map[string]any{"id": "res-001"}})
+
+	// Assert
+	assert.That(t, "error must be nil", err, nil)
+	var history []reservation.StatusChange
+	_ = json.Unmarshal([]byte(result.Content[0].Text), &history)
+	assert.That(t, "history must have 2 changes", len(history), 2)
+	assert.That(t, "creation must be by staff", history[0].Actor, "staff:desk@hotel.example")
+	assert.That(t, "creation must be pending", history[0].To, reservation.StatusPending)
+	assert.That(t, "cancellation must be by the guest", history[1].Actor, "guest:john@example.com")
+	assert.That(t, "cancellation must be from pending", history[1].From, reservation.StatusPending)
+	assert.That(t, "cancellation must keep the reason", history[1].Reason, "Change of plans")
+}
+
+func Test_GetReservationHistoryTool_With_Guest_Principal_Of_Other_Guest_Should_Return_ErrNotReservationOwner(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	service := createToolsTestService(repo, checker, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+	_, _ = service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+	other := shared.ContextWithPrincipal(context.Background(), shared.Principal{Type: shared.PrincipalGuest, Subject: "guest-002"})
+
+	var historyTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_reservation_history" {
+			historyTool = tool
+		}
+	}
+
+	// Act
+	_, err := historyTool.Handler(other, mcp.ToolsCallParams{Name: "get_reservation_history", Arguments: map[string]any{"id": "res-001"}})
+
+	// Assert
+	assert.That(t, "err must be ErrNotReservationOwner", errors.Is(err, reservation.ErrNotReservationOwner), true)
+}
+
 // ============================================================================
 // CancelReservation Tool Tests
 // ============================================================================
@@ -351,6 +499,84 @@ func Test_CancelReservationTool_When_Not_Found_Should_Return_Error(t *testing.T)
 	assert.That(t, "error must not be nil", err != nil, true)
 }
 
+// ============================================================================
+// CancelReservations Tool Tests
+// ============================================================================
+
+func Test_CancelReservationsTool_Should_Cancel_Each_And_Report_Progress(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	publisher := &toolsMockEventPublisher{}
+	service := createToolsTestService(repo, checker, publisher)
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	var progress []float64
+	ctx := shared.ContextWithProgress(context.Background(), func(p, total float64, message string) {
+		progress = append(progress, p/total)
+	})
+	_, _ = service.CreateReservation(ctx, "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+	_, _ = service.CreateReservation(ctx, "res-002", "guest-002", "room-102", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+
+	var cancelTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "cancel_reservations" {
+			cancelTool = tool
+			break
+		}
+	}
+	params := mcp.ToolsCallParams{
+		Name:      "cancel_reservations",
+		Arguments: map[string]any{"ids": "res-001, res-002,res-003", "reason": "Floor closed"},
+	}
+
+	// Act
+	result, err := cancelTool.Handler(ctx, params)
+
+	// Assert
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must count 2 cancellations", strings.Contains(result.Content[0].Text, `"cancelled": 2`), true)
+	assert.That(t, "content must report the missing reservation", strings.Contains(result.Content[0].Text, `"id": "res-003",
+      "cancelled": false`), true)
+	assert.That(t, "progress must be reported per reservation", len(progress), 3)
+	assert.That(t, "progress must end complete", progress[2], 1.0)
+	res, _ := service.GetReservation(ctx, "res-002")
+	assert.That(t, "status must be cancelled", res.Status, reservation.StatusCancelled)
+}
+
+func Test_CancelReservationsTool_When_Context_Cancelled_Should_Stop(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	publisher := &toolsMockEventPublisher{}
+	service := createToolsTestService(repo, checker, publisher)
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+	_, _ = service.CreateReservation(context.Background(), "res-001", "guest-001", "room-101", toolsValidDateRange(), toolsValidMoney(), toolsValidGuests())
+
+	var cancelTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "cancel_reservations" {
+			cancelTool = tool
+			break
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := cancelTool.Handler(ctx, mcp.ToolsCallParams{
+		Name:      "cancel_reservations",
+		Arguments: map[string]any{"ids": "res-001", "reason": "Floor closed"},
+	})
+
+	// Assert
+	assert.That(t, "error must be context canceled", errors.Is(err, context.Canceled), true)
+	res, _ := service.GetReservation(context.Background(), "res-001")
+	assert.That(t, "status must not be cancelled", res.Status != reservation.StatusCancelled, true)
+}
+
 // ============================================================================
 // CheckAvailability Tool Tests
 // ============================================================================
@@ -503,3 +729,165 @@ func Test_CheckAvailabilityTool_When_Checker_Fails_Should_Return_Error(t *testin
 	// Assert
 	assert.That(t, "error must not be nil", err != nil, true)
 }
+
+// ============================================================================
+// GetRoomCalendar Tool Tests
+// ============================================================================
+
+func Test_GetRoomCalendarTool_Should_Return_Availability_Per_Night(t *testing.T) {
+	// Arrange
+	booked, _ := reservation.NewReservation("res-001", "guest-001", "room-101",
+		reservation.NewDateRange(time.Date(2030, 6, 2, 14, 0, 0, 0, time.UTC), time.Date(2030, 6, 4, 11, 0, 0, 0, time.UTC)),
+		toolsValidMoney(), toolsValidGuests())
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true, overlapping: []*reservation.Reservation{booked}}
+	service := createToolsTestService(repo, checker, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	var calendarTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_room_calendar" {
+			calendarTool = tool
+		}
+	}
+	params := mcp.ToolsCallParams{
+		Name:      "get_room_calendar",
+		Arguments: map[string]any{"room_id": "room-101", "from": "2030-06-01", "days": float64(5)},
+	}
+
+	// Act
+	result, err := calendarTool.Handler(context.Background(), params)
+
+	// Assert
+	var calendar struct {
+		AvailableNights int `json:"available_nights"`
+		Days            []struct {
+			Date      string `json:"date"`
+			Available bool   `json:"available"`
+		} `json:"days"`
+	}
+	assert.That(t, "error must be nil", err == nil, true)
+	assert.That(t, "content must be json", json.Unmarshal([]byte(result.Content[0].Text), &calendar), nil)
+	assert.That(t, "must return 5 nights", len(calendar.Days), 5)
+	assert.That(t, "3 nights must be available", calendar.AvailableNights, 3)
+	assert.That(t, "night of 2030-06-02 must be booked", calendar.Days[1].Available, false)
+	assert.That(t, "night of 2030-06-04 must be available", calendar.Days[3].Available, true)
+}
+
+func Test_GetRoomCalendarTool_When_Invalid_Date_Format_Should_Return_Error(t *testing.T) {
+	// Arrange
+	repo := newToolsMockReservationRepository()
+	checker := &toolsMockAvailabilityChecker{available: true}
+	service := createToolsTestService(repo, checker, &toolsMockEventPublisher{})
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTools(server, service, checker)
+
+	var calendarTool mcp.Tool
+	for _, tool := range server.Tools() {
+		if tool.Definition.Name == "get_room_calendar" {
+			calendarTool = tool
+		}
+	}
+	params := mcp.ToolsCallParams{
+		Name:      "get_room_calendar",
+		Arguments: map[string]any{"room_id": "room-101", "from": "06/01/2030"},
+	}
+
+	// Act
+	_, err := calendarTool.Handler(context.Background(), params)
+
+	// Assert
+	assert.That(t, "error must not be nil", err != nil, true)
+}
+
+// ============================================================================
+// CheckAvailabilityBulk Tool Tests
+// ============================================================================
+
+// roomsMockAvailabilityChecker reports the booked rooms as not available and fails for the failing room.
+// It is only read, so it may be called by several workers at once.
+type roomsMockAvailabilityChecker struct {
+	booked  map[reservation.RoomID]bool
+	failing reservation.RoomID
+}
+
+func (m *roomsMockAvailabilityChecker) IsRoomAvailable(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) (bool, error) {
+	if roomID == m.failing {
+		return false, errors.New("database unavailable")
+	}
+	return !m.booked[roomID], nil
+}
+
+func (m *roomsMockAvailabilityChecker) GetOverlappingReservations(ctx context.Context, roomID reservation.RoomID, dateRange reservation.DateRange) ([]*reservation.Reservation, error) {
+	return nil, nil
+}
+
+func bulkAvailabilityTool(checker reservation.AvailabilityChecker) mcp.Tool {
+	server := mcp.NewServer("test-server", "1.0.0")
+	reservation.RegisterTripPlanningTools(server, checker, reservation.NewRates(reservation.DefaultRatePolicy()), nil)
+	return server.Tools()[0]
+}
+
+func bulkAvailabilityQuery(roomType, checkIn, checkOut string) any {
+	return map[string]any{"room_type": roomType, "check_in": checkIn, "check_out": checkOut}
+}
+
+func Test_CheckAvailabilityBulkTool_Should_Report_Each_Query(t *testing.T) {
+	// Arrange
+	checker := &roomsMockAvailabilityChecker{
+		booked:  map[reservation.RoomID]bool{"room-201": true, "room-301": true},
+		failing: "room-101",
+	}
+	tool := bulkAvailabilityTool(checker)
+	params := mcp.ToolsCallParams{
+		Name: "check_availability_bulk",
+		Arguments: map[string]any{"queries": []any{
+			bulkAvailabilityQuery("deluxe", "2030-06-01", "2030-06-04"),
+			bulkAvailabilityQuery("suite", "2030-06-04", "2030-06-06"),
+			bulkAvailabilityQuery("penthouse", "2030-06-01", "2030-06-04"),
+			bulkAvailabilityQuery("deluxe", "06/01/2030", "2030-06-04"),
+			bulkAvailabilityQuery("standard", "2030-06-01", "2030-06-04"),
+		}},
+	}
+
+	// Act
+	result, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	var response struct {
+		Available int `json:"available"`
+		Results   []struct {
+			RoomType       string   `json:"room_type"`
+			Available      bool     `json:"available"`
+			AvailableRooms []string `json:"available_rooms"`
+			Error          string   `json:"error"`
+		} `json:"results"`
+	}
+	assert.That(t, "error must be nil", err, nil)
+	assert.That(t, "content must be json", json.Unmarshal([]byte(result.Content[0].Text), &response), nil)
+	assert.That(t, "must return a result per query", len(response.Results), 5)
+	assert.That(t, "one query must be available", response.Available, 1)
+	assert.That(t, "deluxe must be available in room-202", response.Results[0].AvailableRooms, []string{"room-202"})
+	assert.That(t, "suite must not be available", response.Results[1].Available, false)
+	assert.That(t, "suite must not fail", response.Results[1].Error, "")
+	assert.That(t, "unknown room type must fail", strings.Contains(response.Results[2].Error, "room type not found"), true)
+	assert.That(t, "invalid date must fail", strings.Contains(response.Results[3].Error, "check_in"), true)
+	assert.That(t, "failed check must report the room", strings.Contains(response.Results[4].Error, "room-101"), true)
+}
+
+func Test_CheckAvailabilityBulkTool_With_Too_Many_Queries_Should_Return_Error(t *testing.T) {
+	// Arrange
+	tool := bulkAvailabilityTool(&roomsMockAvailabilityChecker{})
+	queries := make([]any, reservation.MaxBulkAvailabilityQueries+1)
+	for i := range queries {
+		queries[i] = bulkAvailabilityQuery("deluxe", "2030-06-01", "2030-06-04")
+	}
+	params := mcp.ToolsCallParams{Name: "check_availability_bulk", Arguments: map[string]any{"queries": queries}}
+
+	// Act
+	_, err := tool.Handler(context.Background(), params)
+
+	// Assert
+	assert.That(t, "error must be ErrTooManyQueries", errors.Is(err, reservation.ErrTooManyQueries), true)
+}
@@ -0,0 +1,132 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidAuditFilter is returned for an audit query whose time range ends before it starts.
+var ErrInvalidAuditFilter = errors.New("audit filter must not end before it starts")
+
+// ActorSystem is the actor of operations without an authenticated caller, e.g. by the booking saga or a scheduled job.
+const ActorSystem = "system"
+
+// Audit actions recorded by the reservation and payment services.
+const (
+	AuditCreate           = "create"
+	AuditConfirm          = "confirm"
+	AuditCancel           = "cancel"
+	AuditActivate         = "activate"
+	AuditComplete         = "complete"
+	AuditNoShow           = "no_show"
+	AuditShare            = "share"
+	AuditAcceptShare      = "accept_share"
+	AuditRevokeShare      = "revoke_share"
+	AuditReassign         = "reassign"
+	AuditOverstay         = "overstay"
+	AuditExtend           = "extend"
+	AuditDeclineExtension = "decline_extension"
+	AuditAuthorize        = "authorize"
+	AuditCapture          = "capture"
+	AuditRefund           = "refund"
+	AuditAdjust           = "adjust"
+)
+
+// AuditEntry records one state-changing operation on an aggregate: who did what, when,
+// and the state before and after it. Shared because the reservation and payment services
+// record it and the admin API queries it.
+type AuditEntry struct {
+	ID        string          `json:"id"`
+	At        time.Time       `json:"at"`
+	Actor     string          `json:"actor"`     // "guest:<email>", "staff:<email>", "service:<client ID>" or "system"
+	Subject   string          `json:"subject"`   // OIDC subject of the caller; empty for the system
+	ClientID  string          `json:"client_id"` // client ID of a service account or MCP client; empty for people
+	Aggregate string          `json:"aggregate"` // e.g. reservation or payment
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"` // one of the Audit actions, e.g. cancel
+	Before    json.RawMessage `json:"before"` // null for creations
+	After     json.RawMessage `json:"after"`
+}
+
+// AuditFilter selects audit entries; empty fields match all.
+type AuditFilter struct {
+	Aggregate string
+	EntityID  string
+	Actor     string // exact actor, e.g. staff:frontdesk@example.com
+	Action    string
+	From      time.Time // inclusive
+	To        time.Time // exclusive
+	Limit     int       // 0 returns all
+}
+
+// Matches reports whether the entry is selected by the filter.
+func (f AuditFilter) Matches(entry AuditEntry) bool {
+	return (f.Aggregate == "" || entry.Aggregate == f.Aggregate) &&
+		(f.EntityID == "" || entry.EntityID == f.EntityID) &&
+		(f.Actor == "" || entry.Actor == f.Actor) &&
+		(f.Action == "" || entry.Action == f.Action) &&
+		(f.From.IsZero() || !entry.At.Before(f.From)) &&
+		(f.To.IsZero() || entry.At.Before(f.To))
+}
+
+// Validate returns ErrInvalidAuditFilter if the time range ends before it starts.
+func (f AuditFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return ErrInvalidAuditFilter
+	}
+	return nil
+}
+
+// AuditLog records the state-changing operations of the domain services.
+// An operation that took effect must not fail because it could not be audited,
+// so Record returns no error; implementations report their failures themselves.
+// outbound.AuditLog implements it.
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry)
+}
+
+// AuditState returns the state of an aggregate as JSON for an audit entry.
+// Services take it before changing the aggregate, since the change may modify its slices in place.
+func AuditState(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// NewAuditEntry returns the entry of an operation by the caller in ctx; the audit log sets its ID and time.
+func NewAuditEntry(ctx context.Context, aggregate, entityID, action string, before, after json.RawMessage) AuditEntry {
+	entry := AuditEntry{
+		Actor:     ActorOf(ctx),
+		Aggregate: aggregate,
+		EntityID:  entityID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+	}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		entry.Subject = p.Subject
+		entry.ClientID = p.ClientID
+	}
+	return entry
+}
+
+// ActorOf returns the actor of an operation by the caller in ctx: the principal type with the email
+// of a person or the client ID of a service account, or ActorSystem without a caller.
+func ActorOf(ctx context.Context) string {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ActorSystem
+	}
+	id := p.Email
+	if p.Type == PrincipalService || id == "" {
+		id = p.ClientID
+	}
+	if id == "" {
+		id = p.Subject
+	}
+	return string(p.Type) + ":" + id
+}
@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/andygeiss/cloud-native-utils/mcp"
+)
+
+// Role is an OIDC role of the caller, read from the roles claims of its token
+// or, for UI sessions, derived from the issuer that signed it in.
+type Role string
+
+// The roles the default permissions know; ROLE_PERMISSIONS may define more.
+const (
+	RoleGuest       Role = "guest"        // books and manages their own stays
+	RoleStaff       Role = "staff"        // front desk: every reservation, payments read-only
+	RoleAdmin       Role = "admin"        // every permission
+	RoleMCPReadOnly Role = "mcp-readonly" // agents that look things up but change nothing
+	RoleMCPAdmin    Role = "mcp-admin"    // agents that may call every tool
+)
+
+// PermissionUI is the permission to use the booking UI (/ui).
+// The other permissions are the scopes of the bounded contexts, e.g. reservations:read.
+const PermissionUI = "ui:access"
+
+// PermissionAdmin is the permission to use the admin endpoints (/admin, /internal, /debug/pprof) without the admin token.
+// Unlike the scopes it is never implied: only roles and staff accounts that list it grant it.
+const PermissionAdmin = "admin:access"
+
+// RolePermissions maps every known role to the permissions it grants.
+type RolePermissions map[Role][]string
+
+// ParseRolePermissions parses "role=permission permission;role=permission" entries,
+// e.g. "staff=ui:access reservations:read;mcp-readonly=reservations:read".
+func ParseRolePermissions(s string) (RolePermissions, error) {
+	permissions := make(RolePermissions)
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		role, list, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("invalid role permissions %q: expected role=permission permission", entry)
+		}
+		permissions[Role(role)] = strings.Fields(list)
+	}
+	return permissions, nil
+}
+
+// Grant returns the sorted permissions of the roles the policy knows.
+// It returns false if it knows none of them, e.g. the default roles of the identity provider;
+// roles it does not know grant nothing.
+func (p RolePermissions) Grant(roles []Role) ([]string, bool) {
+	var granted []string
+	known := false
+	for _, role := range roles {
+		permissions, ok := p[role]
+		if !ok {
+			continue
+		}
+		known = true
+		for _, permission := range permissions {
+			if !slices.Contains(granted, permission) {
+				granted = append(granted, permission)
+			}
+		}
+	}
+	slices.Sort(granted)
+	return granted, known
+}
+
+// GuardTool returns the tool with a handler that rejects callers without the permission
+// (see RequireScope) before it reads the arguments.
+// RegisterTools wraps every tool, so the permission of a tool is declared where it is registered.
+func GuardTool(tool mcp.Tool, permission string) mcp.Tool {
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, params mcp.ToolsCallParams) (mcp.ToolsCallResult, error) {
+		if err := RequireScope(ctx, permission); err != nil {
+			return mcp.ToolsCallResult{}, err
+		}
+		return handler(ctx, params)
+	}
+	return tool
+}
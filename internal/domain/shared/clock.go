@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clock is the time source of Now; nil is the wall clock.
+var clock atomic.Pointer[func() time.Time]
+
+// Now returns the current time of the process: the wall clock, unless a test clock was set (SetClock),
+// so the scheduled jobs and the date rules of the reservations see a virtual time in staging.
+// Timestamps of records, e.g. UpdatedAt, keep the wall clock.
+func Now() time.Time {
+	if now := clock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
+
+// SetClock replaces the time source of Now, e.g. with the virtual clock of the test clock API;
+// nil restores the wall clock. Never set in production.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&now)
+}
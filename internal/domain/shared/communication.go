@@ -0,0 +1,70 @@
+package shared
+
+import (
+	"errors"
+	"time"
+)
+
+// Communication history errors.
+var (
+	ErrCommunicationNotFound      = errors.New("communication not found")
+	ErrCommunicationNotResendable = errors.New("only failed communications can be resent")
+	ErrInvalidEngagement          = errors.New("engagement must be delivered or clicked")
+)
+
+// Engagements a device reports for a push notification.
+const (
+	EngagementDelivered = "delivered" // the notification was shown
+	EngagementClicked   = "clicked"   // the guest opened it
+)
+
+// Communication is a message sent to a guest, e.g. a confirmation email, with its delivery status.
+// Shared because the notification adapters record it and the admin UI shows it.
+type Communication struct {
+	ID            string
+	Channel       string // "email" or "push"
+	Template      string // e.g. "reservation_confirmation"
+	Recipient     string // email address, or the device of a push notification
+	Subject       string
+	Body          string
+	HTMLBody      string // HTML alternative of Body, if the message had one
+	GuestID       string
+	ReservationID string
+	Status        string // "queued", "sent" or "failed"
+	Attempts      int
+	Error         string
+	ResendOf      string // the failed message this one resends, if any
+	QueuedAt      time.Time
+	SentAt        time.Time
+	DeliveredAt   time.Time // when the device showed the push notification, as reported by it
+	ClickedAt     time.Time // when the guest opened the push notification
+	UpdatedAt     time.Time
+}
+
+// Resendable reports whether staff may send the message again.
+// Push notifications are not resent: the device may be gone, and the email was sent anyway.
+func (c Communication) Resendable() bool {
+	return c.Status == "failed" && c.Channel != "push"
+}
+
+// Engage records the engagement with a push notification; repeated reports keep the first time.
+// A click implies the delivery, since some devices show notifications without waking the service worker.
+func (c *Communication) Engage(engagement string, at time.Time) error {
+	if c.Channel != "push" {
+		return ErrCommunicationNotFound
+	}
+	switch engagement {
+	case EngagementClicked:
+		if c.ClickedAt.IsZero() {
+			c.ClickedAt = at
+		}
+		fallthrough
+	case EngagementDelivered:
+		if c.DeliveredAt.IsZero() {
+			c.DeliveredAt = at
+		}
+	default:
+		return ErrInvalidEngagement
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"errors"
+	"time"
+)
+
+// Dead letter errors.
+var (
+	ErrDeadLetterNotFound      = errors.New("dead letter not found")
+	ErrDeadLetterReplayed      = errors.New("dead letter was replayed already")
+	ErrDeadLetterNoHandler     = errors.New("handler of the dead letter is not subscribed in this instance")
+	ErrDeadLetterReplayFailed  = errors.New("replay of the dead letter failed")
+	ErrInvalidDeadLetterStatus = errors.New("dead letter status must be dead or replayed")
+)
+
+// Dead letter statuses.
+const (
+	DeadLetterDead     = "dead"     // the handler failed; waits for a replay
+	DeadLetterReplayed = "replayed" // a replay succeeded
+)
+
+// DeadLetterTopicSuffix is appended to the topic of an event whose handler failed, e.g. payment.captured.dlq.
+const DeadLetterTopicSuffix = ".dlq"
+
+// DeadLetter is an event an event handler failed to process, with the error.
+// Shared because the dispatcher adapter records it and the admin API lists and replays it.
+type DeadLetter struct {
+	ID           string    `json:"id"`
+	Topic        string    `json:"topic"`        // topic of the event, e.g. payment.captured
+	Subscription string    `json:"subscription"` // handler that failed: topic and subscription order, e.g. payment.captured#1
+	Data         []byte    `json:"data"`         // payload of the event
+	Error        string    `json:"error"`        // error of the last attempt
+	Status       string    `json:"status"`       // DeadLetterDead or DeadLetterReplayed
+	Attempts     int       `json:"attempts"`     // the delivery and the replays
+	FailedAt     time.Time `json:"failed_at"`
+	ReplayedAt   time.Time `json:"replayed_at"` // of the last replay
+}
+
+// DeadLetterTopic returns the topic the dead letters of the topic are published to.
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterTopicSuffix
+}
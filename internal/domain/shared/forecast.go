@@ -0,0 +1,13 @@
+package shared
+
+import "time"
+
+// DailyForecast is the weather forecast for one day at the property.
+// Shared because the weather provider adapters and the UI exchange it.
+type DailyForecast struct {
+	Date                time.Time
+	Summary             string  // e.g. "Partly cloudy"
+	TempMinC            float64 // minimum temperature in °C
+	TempMaxC            float64 // maximum temperature in °C
+	PrecipitationChance int     // probability of precipitation in percent
+}
@@ -0,0 +1,57 @@
+package shared
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// FXSourceIdentity is the source of snapshots whose amount is already in the currency of record.
+const FXSourceIdentity = "identity"
+
+// FXSnapshot is the exchange rate used to convert an amount into the currency of record,
+// taken when the reservation was booked. Shared because the reservation records it
+// and the payment context checks it before capturing.
+type FXSnapshot struct {
+	From   string    `json:"from"` // currency of the amount (ISO 4217)
+	To     string    `json:"to"`   // currency of record (ISO 4217)
+	Rate   float64   `json:"rate"` // units of To per unit of From
+	At     time.Time `json:"at"`
+	Source string    `json:"source"` // e.g. static, identity
+}
+
+// NewFXSnapshot creates a snapshot of the rate at the given time.
+func NewFXSnapshot(from, to string, rate float64, at time.Time, source string) FXSnapshot {
+	return FXSnapshot{From: strings.ToUpper(from), To: strings.ToUpper(to), Rate: rate, At: at.UTC(), Source: source}
+}
+
+// NewIdentityFXSnapshot creates the snapshot of an amount that is already in the currency of record.
+func NewIdentityFXSnapshot(currency string, at time.Time) FXSnapshot {
+	return NewFXSnapshot(currency, currency, 1, at, FXSourceIdentity)
+}
+
+// IsIdentity reports whether the snapshot converts a currency to itself; such a snapshot never goes stale.
+func (s FXSnapshot) IsIdentity() bool {
+	return s.From == s.To
+}
+
+// Convert converts an amount in the From currency to the currency of record,
+// rounded to the minor unit of the currency of record. Other currencies are returned unchanged.
+func (s FXSnapshot) Convert(m Money) Money {
+	if m.Currency != s.From || s.IsIdentity() {
+		return m
+	}
+	to := Money{Currency: s.To}
+	major := float64(m.Amount) / math.Pow10(m.minorDigits())
+	to.Amount = int64(math.Round(major * s.Rate * math.Pow10(to.minorDigits())))
+	return to
+}
+
+// StaleAt reports whether the rate is older than maxAge at the given time.
+// Identity snapshots and a maxAge of 0 are never stale.
+func (s FXSnapshot) StaleAt(now time.Time, maxAge time.Duration) bool {
+	if s.IsIdentity() || maxAge <= 0 {
+		return false
+	}
+	return now.Sub(s.At) > maxAge
+}
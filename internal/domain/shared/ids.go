@@ -0,0 +1,115 @@
+package shared
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReservationIDPrefix marks reservation IDs created by NewReservationID.
+const ReservationIDPrefix = "res_"
+
+// ErrInvalidID is returned if an inbound ID is malformed.
+var ErrInvalidID = errors.New("invalid ID")
+
+// maxLegacyIDLength bounds IDs created before the typed constructors existed.
+const maxLegacyIDLength = 128
+
+// crockford is the Crockford base32 alphabet used by ULIDs (no I, L, O, U).
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulids generates monotonic ULIDs, so IDs created in the same millisecond still sort by creation.
+var ulids struct {
+	sync.Mutex
+	ms      uint64
+	entropy [10]byte
+}
+
+// NewULID returns a new ULID: 48 bits of Unix milliseconds and 80 random bits in 26 Crockford base32 characters.
+// ULIDs sort lexicographically by creation time, which keeps new keys together in database indexes.
+func NewULID() string {
+	ulids.Lock()
+	defer ulids.Unlock()
+
+	ms := uint64(time.Now().UnixMilli())
+	if ms > ulids.ms {
+		ulids.ms = ms
+		_, _ = rand.Read(ulids.entropy[:])
+	} else {
+		// Same millisecond (or the clock went back): increment the entropy instead of drawing new bits.
+		for i := len(ulids.entropy) - 1; i >= 0; i-- {
+			ulids.entropy[i]++
+			if ulids.entropy[i] != 0 {
+				break
+			}
+		}
+	}
+
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[0:2], uint16(ulids.ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ulids.ms))
+	copy(id[6:], ulids.entropy[:])
+
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	out := make([]byte, 26)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
+
+// IsULID returns true if s is a canonical (upper case) ULID.
+func IsULID(s string) bool {
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+	for i := range len(s) {
+		if strings.IndexByte(crockford, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NewReservationID returns a new, time-sortable reservation ID, e.g. "res_01J9Z3M5Q8X7T6V4R2N0B1C3D5".
+func NewReservationID() ReservationID {
+	return ReservationID(ReservationIDPrefix + NewULID())
+}
+
+// ParseReservationID validates an inbound reservation ID, e.g. from a URL or an MCP tool call.
+func ParseReservationID(s string) (ReservationID, error) {
+	if err := ValidateID("reservation", ReservationIDPrefix, s); err != nil {
+		return "", err
+	}
+	return ReservationID(s), nil
+}
+
+// ValidateID returns ErrInvalidID with a hint for the caller if s is not an ID of the kind.
+// IDs with the prefix must continue with a ULID. IDs without it were created before
+// the typed constructors and are accepted if they are short and URL-safe.
+func ValidateID(kind, prefix, s string) error {
+	if s == "" {
+		return fmt.Errorf("%w: %s ID is required", ErrInvalidID, kind)
+	}
+	if rest, ok := strings.CutPrefix(s, prefix); ok {
+		if !IsULID(rest) {
+			return fmt.Errorf("%w: %s ID %q must be %q followed by a 26-character ULID", ErrInvalidID, kind, s, prefix)
+		}
+		return nil
+	}
+	if len(s) > maxLegacyIDLength {
+		return fmt.Errorf("%w: %s ID is longer than %d characters", ErrInvalidID, kind, maxLegacyIDLength)
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return fmt.Errorf("%w: %s ID %q may only contain letters, digits, '-', '_' and '.'", ErrInvalidID, kind, s)
+		}
+	}
+	return nil
+}
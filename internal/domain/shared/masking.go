@@ -0,0 +1,103 @@
+package shared
+
+import (
+	"regexp"
+	"strings"
+)
+
+// This file contains the masking of personal data in free text.
+// Shared because the logs and the MCP tool outputs mask the same data the same way.
+
+var (
+	// emailPattern matches email addresses; the local part is masked.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	// cardPattern matches 13 to 19 digits, optionally grouped by spaces or dashes.
+	cardPattern = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	// phonePattern matches international ("+49 171 1234567") and national ("0171-1234567") numbers.
+	// Other digit runs like dates, amounts and IDs do not start with "+" or "0".
+	phonePattern = regexp.MustCompile(`(?:\B\+|\b0)\d[\d ()\-]*\d\b`)
+)
+
+// Minimum digits of a phone number, so "+1 555 0100" is masked but "05-01-2026" is not.
+const (
+	minInternationalPhoneDigits = 7
+	minNationalPhoneDigits      = 9
+)
+
+// MaskPII masks the personal data in s: the local part of emails ("j***@example.com"),
+// the middle digits of phone numbers ("+49 *** *****67") and all but the last four digits of
+// card numbers ("************1111"). The length of numbers and their separators are kept,
+// so masked JSON stays valid and masked values can still be told apart by their ends.
+func MaskPII(s string) string {
+	s = cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if !luhnValid(match) {
+			return match
+		}
+		return maskDigits(match, 0, 4)
+	})
+	s = emailPattern.ReplaceAllStringFunc(s, func(match string) string {
+		local, domain, _ := strings.Cut(match, "@")
+		return local[:1] + "***@" + domain
+	})
+	return phonePattern.ReplaceAllStringFunc(s, func(match string) string {
+		minDigits := minNationalPhoneDigits
+		if strings.HasPrefix(match, "+") {
+			minDigits = minInternationalPhoneDigits
+		}
+		if countDigits(match) < minDigits {
+			return match
+		}
+		return maskDigits(match, 2, 2)
+	})
+}
+
+// maskDigits replaces the digits of s with "*" except the first keepStart and the last keepEnd.
+func maskDigits(s string, keepStart, keepEnd int) string {
+	total := countDigits(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	n := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			n++
+			if n > keepStart && n <= total-keepEnd {
+				r = '*'
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// countDigits returns the number of digits in s.
+func countDigits(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// luhnValid checks the card number checksum, so other long numbers like timestamps are not masked.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
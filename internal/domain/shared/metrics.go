@@ -0,0 +1,11 @@
+package shared
+
+// Metrics records measurements of the domain services, e.g. for a Prometheus scrape.
+// Labels are name/value pairs like the attributes of slog: "error_code", "card_declined".
+// Shared because all contexts are instrumented the same way; outbound.Metrics implements it.
+type Metrics interface {
+	// IncCounter adds 1 to the counter with the labels.
+	IncCounter(name string, labels ...string)
+	// ObserveHistogram records a value in the histogram with the labels, e.g. a duration in seconds.
+	ObserveHistogram(name string, value float64, labels ...string)
+}
@@ -0,0 +1,171 @@
+package shared
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Locale is a BCP 47 language tag (e.g. "en-US", "de-DE") selecting how amounts are rendered.
+// Shared because the UI, the emails and the MCP tools format money for the same guests.
+type Locale string
+
+// DefaultLocale is used for unknown locales and if no locale was negotiated.
+const DefaultLocale Locale = "en-US"
+
+// numberFormat describes how a locale renders an amount.
+type numberFormat struct {
+	decimal     string
+	group       string
+	symbolAfter bool // "12,50 €" instead of "€12.50"
+	symbolSpace bool // non-breaking space between symbol and number
+}
+
+// numberFormats lists the supported locales.
+var numberFormats = map[Locale]numberFormat{
+	"de-CH": {decimal: ".", group: "\u2019", symbolSpace: true},
+	"de-DE": {decimal: ",", group: ".", symbolAfter: true, symbolSpace: true},
+	"en-GB": {decimal: ".", group: ","},
+	"en-US": {decimal: ".", group: ","},
+	"es-ES": {decimal: ",", group: ".", symbolAfter: true, symbolSpace: true},
+	"fr-FR": {decimal: ",", group: "\u202f", symbolAfter: true, symbolSpace: true},
+	"it-IT": {decimal: ",", group: ".", symbolAfter: true, symbolSpace: true},
+	"ja-JP": {decimal: ".", group: ","},
+	"nl-NL": {decimal: ",", group: ".", symbolSpace: true},
+}
+
+// languageLocales resolves tags without a supported region (e.g. "de", "de-AT") by their language.
+var languageLocales = map[string]Locale{
+	"de": "de-DE",
+	"en": "en-US",
+	"es": "es-ES",
+	"fr": "fr-FR",
+	"it": "it-IT",
+	"ja": "ja-JP",
+	"nl": "nl-NL",
+}
+
+// currencySymbols lists the symbols of common currencies; other currencies are shown by their code.
+var currencySymbols = map[string]string{
+	"AUD": "A$",
+	"CAD": "CA$",
+	"CHF": "CHF",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"USD": "$",
+}
+
+// currencyDigits lists the currencies whose smallest unit is not a hundredth (ISO 4217 minor units).
+var currencyDigits = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0,
+	"KRW": 0, "KWD": 3, "OMR": 3, "TND": 3, "VND": 0,
+}
+
+// ResolveLocale returns the supported locale for the tag, falling back to its language and then to DefaultLocale.
+// The tag is matched case-insensitively and may use "_" as separator (e.g. "de_de").
+func ResolveLocale(tag string) Locale {
+	if locale, ok := ParseLocale(tag); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// ParseLocale returns the supported locale for the tag like ResolveLocale,
+// but reports false instead of falling back to DefaultLocale.
+func ParseLocale(tag string) (Locale, bool) {
+	language, region, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	language = strings.ToLower(language)
+	if region, _, _ = strings.Cut(region, "-"); region != "" {
+		if locale := Locale(language + "-" + strings.ToUpper(region)); numberFormats[locale] != (numberFormat{}) {
+			return locale, true
+		}
+	}
+	locale, ok := languageLocales[language]
+	return locale, ok
+}
+
+// Language returns the language subtag of the locale, e.g. "de" for "de-DE".
+func (l Locale) Language() string {
+	language, _, _ := strings.Cut(string(l), "-")
+	return language
+}
+
+// localeKey is the context key for the locale.
+type localeKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying the locale of the caller.
+func ContextWithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored in ctx or DefaultLocale.
+func LocaleFromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// FormatIn returns the amount as the locale renders it, e.g. "$1,234.50" in en-US,
+// "1.234,50 €" in de-DE or "¥1,200" in ja-JP for zero-decimal currencies.
+// Unknown locales are resolved like ResolveLocale.
+func (m Money) FormatIn(locale Locale) string {
+	format, ok := numberFormats[locale]
+	if !ok {
+		format = numberFormats[ResolveLocale(string(locale))]
+	}
+	symbol, ok := currencySymbols[m.Currency]
+	if !ok {
+		symbol = m.Currency
+	}
+
+	// The amount is converted via uint64, so the sign of math.MinInt64 cannot overflow.
+	amount := uint64(m.Amount)
+	if m.Amount < 0 {
+		amount = -amount
+	}
+	digits := m.minorDigits()
+	unit := uint64(1)
+	for range digits {
+		unit *= 10
+	}
+	number := groupDigits(strconv.FormatUint(amount/unit, 10), format.group)
+	if digits > 0 {
+		fraction := strconv.FormatUint(amount%unit, 10)
+		number += format.decimal + strings.Repeat("0", digits-len(fraction)) + fraction
+	}
+
+	space := ""
+	if format.symbolSpace {
+		space = "\u00a0"
+	}
+	formatted := symbol + space + number
+	if format.symbolAfter {
+		formatted = number + space + symbol
+	}
+	if m.Amount < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// minorDigits returns the number of decimals of the currency (2 unless listed in currencyDigits).
+func (m Money) minorDigits() int {
+	if digits, ok := currencyDigits[m.Currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// groupDigits inserts the separator between groups of three digits.
+func groupDigits(digits, separator string) string {
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(separator)
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// PrincipalType distinguishes the kinds of authenticated callers.
+// Shared because every bounded context enforces access based on it.
+type PrincipalType string
+
+// Principal types are derived from the issuer that authenticated the caller.
+const (
+	PrincipalGuest   PrincipalType = "guest"   // public guest realm
+	PrincipalStaff   PrincipalType = "staff"   // corporate staff realm
+	PrincipalService PrincipalType = "service" // client-credentials token of a registered service account
+)
+
+// Errors returned by the authorization checks.
+var (
+	ErrStaffOnly    = errors.New("operation requires a staff principal")
+	ErrMissingScope = errors.New("principal lacks the required scope")
+)
+
+// Principal is the authenticated caller of an operation.
+type Principal struct {
+	Type      PrincipalType
+	Issuer    string
+	Subject   string
+	Email     string
+	ClientID  string   // set for client-credentials tokens only
+	Scopes    []string // permissions of a service account, a managed staff member or its roles
+	Managed   bool     // staff provisioned in the staff directory, limited to the scopes of their roles
+	Roles     []Role   // OIDC roles of the token
+	RoleBased bool     // limited to the permissions of its roles (ROLE_PERMISSIONS)
+}
+
+// HasScope returns true if the principal was granted the scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// principalKey is the context key for the principal.
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal.
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// RequireStaff returns ErrStaffOnly if the caller is an authenticated guest.
+// Service accounts are not rejected here; their access is limited by RequireScope.
+// Calls without a principal (authentication disabled, internal calls) are allowed.
+func RequireStaff(ctx context.Context) error {
+	p, ok := PrincipalFromContext(ctx)
+	if ok && p.Type == PrincipalGuest {
+		return ErrStaffOnly
+	}
+	return nil
+}
+
+// RequireScope returns ErrMissingScope if the caller is a service account, a managed staff member
+// or a principal limited by its roles without the scope. With role authorization every guest and staff
+// principal is limited by its roles; only without it are they not restricted by scopes.
+func RequireScope(ctx context.Context, scope string) error {
+	p, ok := PrincipalFromContext(ctx)
+	restricted := p.Type == PrincipalService || (p.Type == PrincipalStaff && p.Managed) || p.RoleBased
+	if ok && restricted && !p.HasScope(scope) {
+		return fmt.Errorf("%w: %s", ErrMissingScope, scope)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package shared
+
+import "context"
+
+// ProgressFunc receives the progress of a long-running operation, e.g. 40 of 120 reservations.
+// Total is 0 if it is unknown. Shared because the MCP tools of all contexts report progress the same way.
+type ProgressFunc func(progress, total float64, message string)
+
+// progressKey is the context key for the progress function.
+type progressKey struct{}
+
+// ContextWithProgress returns a copy of ctx that reports the progress of the operation to fn.
+func ContextWithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress reports the progress to the caller if it asked for it; otherwise it does nothing.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(progress, total, message)
+	}
+}
@@ -0,0 +1,32 @@
+package shared
+
+import "context"
+
+// Tracer starts spans of the domain services, so a booking can be followed from the HTTP request
+// through the services, the database and the events in a tracing backend.
+// Attributes are name/value pairs like the labels of Metrics. outbound.Tracer implements it.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attrs ...string) (context.Context, Span)
+}
+
+// Span is an operation of a trace; End records its duration and whether it failed.
+type Span interface {
+	SetAttributes(attrs ...string)
+	End(err error)
+}
+
+// StartSpan starts a span with the tracer, or does nothing if the tracer is nil,
+// so services do not have to check whether tracing is configured.
+func StartSpan(ctx context.Context, tracer Tracer, name string, attrs ...string) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return tracer.StartSpan(ctx, name, attrs...)
+}
+
+// noopSpan is the span of an unconfigured tracer.
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...string) {}
+
+func (noopSpan) End(err error) {}
@@ -4,7 +4,8 @@
 package shared
 
 import (
-	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -12,6 +13,10 @@ import (
 // Shared because Payment needs to reference it.
 type ReservationID string
 
+// PropertyID is a strongly-typed identifier for the properties (hotels) of the chain.
+// Shared because Reservation records the property of its room.
+type PropertyID string
+
 // Money represents a monetary value in the smallest currency unit (cents).
 // Shared because both Reservation and Payment use it.
 type Money struct {
@@ -27,8 +32,15 @@ func NewMoney(amount int64, currency string) Money {
 	}
 }
 
-// FormatAmount returns a human-readable amount (converts cents to dollars).
+// FormatAmount returns a locale-independent amount with the currency code (e.g. "120.50 USD", "1200 JPY").
+// Use FormatIn for amounts shown to guests.
 func (m Money) FormatAmount() string {
-	dollars := float64(m.Amount) / 100.0
-	return fmt.Sprintf("%.2f %s", dollars, m.Currency)
+	return m.Decimal() + " " + m.Currency
+}
+
+// Decimal returns the amount in the major unit without the currency (e.g. "120.50", "1200"),
+// as read by spreadsheets and other machines.
+func (m Money) Decimal() string {
+	digits := m.minorDigits()
+	return strconv.FormatFloat(float64(m.Amount)/math.Pow10(digits), 'f', digits, 64)
 }
@@ -0,0 +1,174 @@
+// Package staff contains the Staff bounded context.
+// HR provisions the staff accounts of the corporate realm (e.g. via SCIM) and assigns roles;
+// the roles grant the scopes staff principals are limited to, and disabled accounts lose access.
+package staff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Local ID types for this bounded context
+type MemberID string
+
+// Role is a staff role, e.g. front_desk. Each role grants a set of scopes.
+type Role string
+
+// MemberStatus represents the state of a staff account.
+type MemberStatus string
+
+const (
+	StatusActive   MemberStatus = "active"
+	StatusDisabled MemberStatus = "disabled" // suspended, may be enabled again
+	StatusDeleted  MemberStatus = "deleted"  // deprovisioned; kept so the account stays locked out
+)
+
+// StaffMember is the aggregate root for a provisioned staff account.
+// UserName is the login email of the staff realm and is unique among provisioned accounts.
+type StaffMember struct {
+	ID          MemberID
+	ExternalID  string // ID in the HR system
+	UserName    string
+	DisplayName string
+	Roles       []Role
+	Status      MemberStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// MemberData is the provisioned data of a staff account.
+type MemberData struct {
+	ExternalID  string
+	UserName    string
+	DisplayName string
+	Roles       []Role
+	Active      bool
+}
+
+// Validation errors.
+var (
+	ErrMissingUserName = errors.New("staff user name required")
+	ErrUserNameTaken   = errors.New("staff user name already provisioned")
+	ErrUnknownRole     = errors.New("unknown staff role")
+	ErrStaffNotFound   = errors.New("staff member not found")
+)
+
+// NewStaffMember creates a staff account from the provisioned data.
+func NewStaffMember(id MemberID, data MemberData, at time.Time) (*StaffMember, error) {
+	m := &StaffMember{ID: id, CreatedAt: at}
+	if err := m.Apply(data, at); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Apply replaces the account data, as on a full update from HR.
+func (m *StaffMember) Apply(data MemberData, at time.Time) error {
+	userName := strings.TrimSpace(data.UserName)
+	if userName == "" {
+		return ErrMissingUserName
+	}
+	m.ExternalID = data.ExternalID
+	m.UserName = userName
+	m.DisplayName = data.DisplayName
+	m.Roles = normalizeRoles(data.Roles)
+	m.Status = StatusDisabled
+	if data.Active {
+		m.Status = StatusActive
+	}
+	m.UpdatedAt = at
+	return nil
+}
+
+// SetActive enables or disables the account.
+func (m *StaffMember) SetActive(active bool, at time.Time) {
+	m.Status = StatusDisabled
+	if active {
+		m.Status = StatusActive
+	}
+	m.UpdatedAt = at
+}
+
+// AssignRoles replaces the roles of the account.
+func (m *StaffMember) AssignRoles(roles []Role, at time.Time) {
+	m.Roles = normalizeRoles(roles)
+	m.UpdatedAt = at
+}
+
+// Delete deprovisions the account. It stays stored, so the staff realm cannot sign it in again.
+func (m *StaffMember) Delete(at time.Time) {
+	m.Status = StatusDeleted
+	m.UpdatedAt = at
+}
+
+// IsActive returns true if the account may access the system.
+func (m *StaffMember) IsActive() bool {
+	return m.Status == StatusActive
+}
+
+// IsDeleted returns true if the account was deprovisioned.
+func (m *StaffMember) IsDeleted() bool {
+	return m.Status == StatusDeleted
+}
+
+// normalizeRoles trims, deduplicates and sorts the roles.
+func normalizeRoles(roles []Role) []Role {
+	normalized := make([]Role, 0, len(roles))
+	for _, role := range roles {
+		role = Role(strings.TrimSpace(string(role)))
+		if role != "" && !slices.Contains(normalized, role) {
+			normalized = append(normalized, role)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized
+}
+
+// RolePolicy maps every known role to the scopes it grants.
+type RolePolicy map[Role][]string
+
+// ParseRolePolicy parses "role=scope scope;role=scope" entries,
+// e.g. "front_desk=reservations:read reservations:write;finance=payments:read".
+func ParseRolePolicy(s string) (RolePolicy, error) {
+	policy := make(RolePolicy)
+	for entry := range strings.SplitSeq(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		role, list, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("invalid staff role %q: expected role=scope scope", entry)
+		}
+		policy[Role(role)] = strings.Fields(list)
+	}
+	return policy, nil
+}
+
+// Validate returns ErrUnknownRole for roles the policy does not define.
+func (p RolePolicy) Validate(roles []Role) error {
+	for _, role := range roles {
+		if _, ok := p[role]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownRole, role)
+		}
+	}
+	return nil
+}
+
+// Scopes returns the sorted scopes granted by the roles.
+func (p RolePolicy) Scopes(roles []Role) []string {
+	var scopes []string
+	for _, role := range roles {
+		for _, scope := range p[role] {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	slices.Sort(scopes)
+	return scopes
+}
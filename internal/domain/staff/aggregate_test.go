@@ -0,0 +1,57 @@
+package staff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+// ============================================================================
+// StaffMember Tests
+// ============================================================================
+
+func Test_NewStaffMember_Without_UserName_Should_Fail(t *testing.T) {
+	// Act
+	_, err := staff.NewStaffMember("staff-001", staff.MemberData{UserName: " "}, time.Now())
+
+	// Assert
+	assert.That(t, "err must be missing user name", err, staff.ErrMissingUserName)
+}
+
+func Test_NewStaffMember_Should_Normalize_Roles(t *testing.T) {
+	// Act
+	m, err := staff.NewStaffMember("staff-001", staff.MemberData{
+		UserName: "anna@hotel.example",
+		Roles:    []staff.Role{"finance", " front_desk", "finance"},
+		Active:   true,
+	}, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "roles must be deduplicated and sorted", m.Roles, []staff.Role{"finance", "front_desk"})
+	assert.That(t, "member must be active", m.IsActive(), true)
+}
+
+// ============================================================================
+// RolePolicy Tests
+// ============================================================================
+
+func Test_ParseRolePolicy_Should_Parse_Roles_And_Scopes(t *testing.T) {
+	// Act
+	policy, err := staff.ParseRolePolicy("front_desk=reservations:read reservations:write; finance=payments:read")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "scopes must be merged and sorted", policy.Scopes([]staff.Role{"front_desk", "finance"}),
+		[]string{"payments:read", "reservations:read", "reservations:write"})
+}
+
+func Test_ParseRolePolicy_With_Invalid_Entry_Should_Fail(t *testing.T) {
+	// Act
+	_, err := staff.ParseRolePolicy("front_desk")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
@@ -0,0 +1,8 @@
+package staff
+
+import (
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// MemberRepository provides CRUD operations for staff accounts.
+type MemberRepository resource.Access[MemberID, StaffMember]
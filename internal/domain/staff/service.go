@@ -0,0 +1,191 @@
+package staff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Access is what a staff principal may do, resolved from its provisioned account.
+type Access struct {
+	MemberID MemberID
+	Active   bool
+	Roles    []Role
+	Scopes   []string
+}
+
+// Service handles staff provisioning.
+type Service struct {
+	repo   MemberRepository
+	policy RolePolicy
+	mu     sync.RWMutex // guards policy, which can be reloaded at runtime
+}
+
+// NewService creates a new staff service.
+func NewService(repo MemberRepository, policy RolePolicy) *Service {
+	return &Service{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// SetRolePolicy replaces the roles and their scopes at runtime.
+// Scopes are resolved on every request, so staff get the new scopes of their roles immediately.
+func (s *Service) SetRolePolicy(policy RolePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+}
+
+// currentPolicy returns the role policy in effect.
+func (s *Service) currentPolicy() RolePolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// Provision creates a staff account.
+func (s *Service) Provision(ctx context.Context, id MemberID, data MemberData) (*StaffMember, error) {
+	if err := s.currentPolicy().Validate(data.Roles); err != nil {
+		return nil, err
+	}
+	member, err := NewStaffMember(id, data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUserName(ctx, member); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, member.ID, *member); err != nil {
+		return nil, fmt.Errorf("failed to persist staff member: %w", err)
+	}
+	return member, nil
+}
+
+// GetMember returns a provisioned staff account. Deprovisioned accounts are not found.
+func (s *Service) GetMember(ctx context.Context, id MemberID) (*StaffMember, error) {
+	member, err := s.repo.Read(ctx, id)
+	if err != nil || member.IsDeleted() {
+		return nil, ErrStaffNotFound
+	}
+	return member, nil
+}
+
+// ListMembers returns the provisioned staff accounts sorted by user name.
+// A non-empty userName returns the account with that user name only (case-insensitive).
+func (s *Service) ListMembers(ctx context.Context, userName string) ([]StaffMember, error) {
+	members, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staff members: %w", err)
+	}
+	members = slices.DeleteFunc(members, func(m StaffMember) bool {
+		return m.IsDeleted() || (userName != "" && !strings.EqualFold(m.UserName, userName))
+	})
+	slices.SortFunc(members, func(a, b StaffMember) int { return strings.Compare(a.UserName, b.UserName) })
+	return members, nil
+}
+
+// Replace overwrites the account data.
+func (s *Service) Replace(ctx context.Context, id MemberID, data MemberData) (*StaffMember, error) {
+	if err := s.currentPolicy().Validate(data.Roles); err != nil {
+		return nil, err
+	}
+	member, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := member.Apply(data, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkUserName(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, s.update(ctx, member)
+}
+
+// SetActive enables or disables the account.
+func (s *Service) SetActive(ctx context.Context, id MemberID, active bool) (*StaffMember, error) {
+	member, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	member.SetActive(active, time.Now())
+	return member, s.update(ctx, member)
+}
+
+// AssignRoles replaces the roles of the account.
+func (s *Service) AssignRoles(ctx context.Context, id MemberID, roles []Role) (*StaffMember, error) {
+	if err := s.currentPolicy().Validate(roles); err != nil {
+		return nil, err
+	}
+	member, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	member.AssignRoles(roles, time.Now())
+	return member, s.update(ctx, member)
+}
+
+// Deprovision deletes the account; the staff member loses access immediately.
+func (s *Service) Deprovision(ctx context.Context, id MemberID) (*StaffMember, error) {
+	member, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	member.Delete(time.Now())
+	return member, s.update(ctx, member)
+}
+
+// Access resolves the access of the staff principal with the login email.
+// Deprovisioned accounts are returned as inactive, so they stay locked out.
+// It returns ErrStaffNotFound for staff that was never provisioned.
+func (s *Service) Access(ctx context.Context, email string) (*Access, error) {
+	members, err := s.repo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staff members: %w", err)
+	}
+
+	var found *StaffMember
+	for i := range members {
+		if !strings.EqualFold(members[i].UserName, email) {
+			continue
+		}
+		// A user name provisioned again after deletion belongs to the new account.
+		if found == nil || found.IsDeleted() {
+			found = &members[i]
+		}
+	}
+	if email == "" || found == nil {
+		return nil, ErrStaffNotFound
+	}
+	return &Access{
+		MemberID: found.ID,
+		Active:   found.IsActive(),
+		Roles:    found.Roles,
+		Scopes:   s.currentPolicy().Scopes(found.Roles),
+	}, nil
+}
+
+// checkUserName returns ErrUserNameTaken if another provisioned account has the user name.
+func (s *Service) checkUserName(ctx context.Context, member *StaffMember) error {
+	others, err := s.ListMembers(ctx, member.UserName)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		if other.ID != member.ID {
+			return ErrUserNameTaken
+		}
+	}
+	return nil
+}
+
+func (s *Service) update(ctx context.Context, member *StaffMember) error {
+	if err := s.repo.Update(ctx, member.ID, *member); err != nil {
+		return fmt.Errorf("failed to update staff member: %w", err)
+	}
+	return nil
+}
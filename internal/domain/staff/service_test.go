@@ -0,0 +1,132 @@
+package staff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/staff"
+)
+
+func createTestStaffService() *staff.Service {
+	return staff.NewService(resource.NewInMemoryAccess[staff.MemberID, staff.StaffMember](), staff.RolePolicy{
+		"front_desk": {"reservations:read", "reservations:write"},
+		"finance":    {"payments:read"},
+	})
+}
+
+func anna() staff.MemberData {
+	return staff.MemberData{UserName: "anna@hotel.example", Roles: []staff.Role{"front_desk"}, Active: true}
+}
+
+// ============================================================================
+// Provisioning Tests
+// ============================================================================
+
+func Test_Service_Provision_With_Unknown_Role_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	data := anna()
+	data.Roles = []staff.Role{"owner"}
+
+	// Act
+	_, err := svc.Provision(context.Background(), "staff-001", data)
+
+	// Assert
+	assert.That(t, "err must be unknown role", errors.Is(err, staff.ErrUnknownRole), true)
+}
+
+func Test_Service_Provision_With_Taken_UserName_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	ctx := context.Background()
+	_, _ = svc.Provision(ctx, "staff-001", anna())
+	data := anna()
+	data.UserName = "ANNA@hotel.example"
+
+	// Act
+	_, err := svc.Provision(ctx, "staff-002", data)
+
+	// Assert
+	assert.That(t, "err must be user name taken", err, staff.ErrUserNameTaken)
+}
+
+func Test_Service_Deprovision_Should_Hide_Member_And_Lock_Out(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	ctx := context.Background()
+	_, _ = svc.Provision(ctx, "staff-001", anna())
+
+	// Act
+	_, err := svc.Deprovision(ctx, "staff-001")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	_, errGet := svc.GetMember(ctx, "staff-001")
+	assert.That(t, "member must not be found", errGet, staff.ErrStaffNotFound)
+	access, _ := svc.Access(ctx, "anna@hotel.example")
+	assert.That(t, "access must be inactive", access.Active, false)
+}
+
+// ============================================================================
+// Access Tests
+// ============================================================================
+
+func Test_Service_Access_Should_Grant_Scopes_Of_Roles(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	ctx := context.Background()
+	_, _ = svc.Provision(ctx, "staff-001", anna())
+	_, _ = svc.AssignRoles(ctx, "staff-001", []staff.Role{"finance"})
+
+	// Act
+	access, err := svc.Access(ctx, "Anna@Hotel.example")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "access must be active", access.Active, true)
+	assert.That(t, "scopes must follow the new role", access.Scopes, []string{"payments:read"})
+}
+
+func Test_Service_SetRolePolicy_Should_Change_Scopes_Of_Roles(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	ctx := context.Background()
+	_, _ = svc.Provision(ctx, "staff-001", anna())
+
+	// Act
+	svc.SetRolePolicy(staff.RolePolicy{"front_desk": {"reservations:read"}})
+	access, err := svc.Access(ctx, "anna@hotel.example")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "scopes must follow the new policy", access.Scopes, []string{"reservations:read"})
+}
+
+func Test_Service_Access_Of_Unprovisioned_Staff_Should_Return_Not_Found(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+
+	// Act
+	_, err := svc.Access(context.Background(), "bob@hotel.example")
+
+	// Assert
+	assert.That(t, "err must be not found", err, staff.ErrStaffNotFound)
+}
+
+func Test_Service_SetActive_False_Should_Disable_Access(t *testing.T) {
+	// Arrange
+	svc := createTestStaffService()
+	ctx := context.Background()
+	_, _ = svc.Provision(ctx, "staff-001", anna())
+
+	// Act
+	_, err := svc.SetActive(ctx, "staff-001", false)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	access, _ := svc.Access(ctx, "anna@hotel.example")
+	assert.That(t, "access must be inactive", access.Active, false)
+}
@@ -0,0 +1,120 @@
+// Package survey contains the Survey bounded context.
+// After checkout, guests are asked for a Net Promoter Score (NPS) and a comment.
+// Surveys are distinct from public reviews: answers are only reported to staff.
+package survey
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type SurveyID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// ReservationID is the shared identifier of the reservation the survey belongs to.
+type ReservationID = shared.ReservationID
+
+// SurveyStatus represents the state of a survey.
+type SurveyStatus string
+
+const (
+	StatusSent     SurveyStatus = "sent"
+	StatusAnswered SurveyStatus = "answered"
+)
+
+// Category classifies a score as promoter (9-10), passive (7-8) or detractor (0-6).
+type Category string
+
+const (
+	CategoryPromoter  Category = "promoter"
+	CategoryPassive   Category = "passive"
+	CategoryDetractor Category = "detractor"
+)
+
+// MaxCommentLength limits the comment, so a survey stays a short survey.
+const MaxCommentLength = 2000
+
+// Survey is the aggregate root for the NPS survey of a stay.
+type Survey struct {
+	ID            SurveyID
+	ReservationID ReservationID
+	GuestID       GuestID
+	GuestEmail    string
+	Property      string
+	Status        SurveyStatus
+	Score         int
+	Comment       string
+	SentAt        time.Time
+	AnsweredAt    time.Time
+}
+
+// Validation errors.
+var (
+	ErrInvalidScore       = errors.New("score must be between 0 and 10")
+	ErrCommentTooLong     = errors.New("comment is too long")
+	ErrAlreadyAnswered    = errors.New("survey already answered")
+	ErrNotSurveyRecipient = errors.New("survey belongs to another guest")
+)
+
+// SurveyIDFor returns the survey ID of a reservation; there is at most one survey per stay.
+func SurveyIDFor(reservationID ReservationID) SurveyID {
+	return SurveyID("nps-" + string(reservationID))
+}
+
+// NewSurvey creates a new survey sent to the guest of the reservation.
+func NewSurvey(reservationID ReservationID, guestID GuestID, email, property string, sentAt time.Time) *Survey {
+	return &Survey{
+		ID:            SurveyIDFor(reservationID),
+		ReservationID: reservationID,
+		GuestID:       guestID,
+		GuestEmail:    email,
+		Property:      property,
+		Status:        StatusSent,
+		SentAt:        sentAt,
+	}
+}
+
+// Answer records the score and comment of the guest. A survey can be answered once.
+func (s *Survey) Answer(guestID GuestID, score int, comment string, at time.Time) error {
+	if s.GuestID != guestID {
+		return ErrNotSurveyRecipient
+	}
+	if s.Status == StatusAnswered {
+		return ErrAlreadyAnswered
+	}
+	if score < 0 || score > 10 {
+		return ErrInvalidScore
+	}
+	comment = strings.TrimSpace(comment)
+	if len(comment) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	s.Score = score
+	s.Comment = comment
+	s.Status = StatusAnswered
+	s.AnsweredAt = at
+	return nil
+}
+
+// IsAnswered checks if the guest answered the survey.
+func (s *Survey) IsAnswered() bool {
+	return s.Status == StatusAnswered
+}
+
+// Category returns the NPS category of the score.
+func (s *Survey) Category() Category {
+	switch {
+	case s.Score >= 9:
+		return CategoryPromoter
+	case s.Score >= 7:
+		return CategoryPassive
+	default:
+		return CategoryDetractor
+	}
+}
@@ -0,0 +1,105 @@
+package survey_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// ============================================================================
+// Survey Tests
+// ============================================================================
+
+func Test_NewSurvey_Should_Be_Sent(t *testing.T) {
+	// Arrange & Act
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+
+	// Assert
+	assert.That(t, "id must be derived from reservation", s.ID, survey.SurveyID("nps-res-001"))
+	assert.That(t, "status must be sent", s.Status, survey.StatusSent)
+	assert.That(t, "survey must not be answered", s.IsAnswered(), false)
+}
+
+func Test_Survey_Answer_Should_Record_Score_And_Comment(t *testing.T) {
+	// Arrange
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+
+	// Act
+	err := s.Answer("guest-001", 9, "  Great breakfast  ", time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "status must be answered", s.Status, survey.StatusAnswered)
+	assert.That(t, "score must match", s.Score, 9)
+	assert.That(t, "comment must be trimmed", s.Comment, "Great breakfast")
+}
+
+func Test_Survey_Answer_By_Other_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+
+	// Act
+	err := s.Answer("guest-002", 9, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrNotSurveyRecipient", errors.Is(err, survey.ErrNotSurveyRecipient), true)
+}
+
+func Test_Survey_Answer_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+	_ = s.Answer("guest-001", 9, "", time.Now())
+
+	// Act
+	err := s.Answer("guest-001", 3, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrAlreadyAnswered", errors.Is(err, survey.ErrAlreadyAnswered), true)
+	assert.That(t, "score must be kept", s.Score, 9)
+}
+
+func Test_Survey_Answer_With_Invalid_Score_Should_Fail(t *testing.T) {
+	// Arrange
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+
+	// Act
+	err := s.Answer("guest-001", 11, "", time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrInvalidScore", errors.Is(err, survey.ErrInvalidScore), true)
+}
+
+func Test_Survey_Answer_With_Long_Comment_Should_Fail(t *testing.T) {
+	// Arrange
+	s := survey.NewSurvey("res-001", "guest-001", "guest@example.com", "Test Hotel", time.Now())
+
+	// Act
+	err := s.Answer("guest-001", 8, strings.Repeat("x", survey.MaxCommentLength+1), time.Now())
+
+	// Assert
+	assert.That(t, "error must be ErrCommentTooLong", errors.Is(err, survey.ErrCommentTooLong), true)
+}
+
+func Test_Survey_Category_Should_Classify_Score(t *testing.T) {
+	// Arrange
+	categories := map[int]survey.Category{
+		0:  survey.CategoryDetractor,
+		6:  survey.CategoryDetractor,
+		7:  survey.CategoryPassive,
+		8:  survey.CategoryPassive,
+		9:  survey.CategoryPromoter,
+		10: survey.CategoryPromoter,
+	}
+
+	for score, expected := range categories {
+		// Act
+		s := survey.Survey{Score: score}
+
+		// Assert
+		assert.That(t, "category must match", s.Category(), expected)
+	}
+}
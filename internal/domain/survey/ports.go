@@ -0,0 +1,15 @@
+package survey
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// SurveyRepository provides CRUD operations for surveys.
+type SurveyRepository resource.Access[SurveyID, Survey]
+
+// SurveySender invites the guest to answer the survey.
+type SurveySender interface {
+	SendSurveyInvitation(ctx context.Context, s *Survey) error
+}
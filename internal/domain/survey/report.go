@@ -0,0 +1,113 @@
+package survey
+
+import (
+	"sort"
+	"time"
+)
+
+// Defaults of the NPS report: a 90-day rolling window, sampled weekly over the last 12 weeks.
+const (
+	DefaultReportWindow = 90 * 24 * time.Hour
+	DefaultReportStep   = 7 * 24 * time.Hour
+	DefaultReportPoints = 12
+)
+
+// NPSPoint is the NPS over the window ending at End.
+// Score is the share of promoters minus the share of detractors in percent (-100 to 100).
+type NPSPoint struct {
+	End        time.Time
+	Responses  int
+	Promoters  int
+	Passives   int
+	Detractors int
+	Score      int
+}
+
+// PropertyReport is the rolling NPS trend and the recent comments of a property.
+type PropertyReport struct {
+	Property       string
+	Sent           int
+	Answered       int
+	Trend          []NPSPoint // oldest first; the last point is the current NPS
+	RecentComments []Survey   // newest first
+}
+
+// Current returns the most recent NPS point.
+func (r PropertyReport) Current() NPSPoint {
+	if len(r.Trend) == 0 {
+		return NPSPoint{}
+	}
+	return r.Trend[len(r.Trend)-1]
+}
+
+// ResponseRate returns the share of answered surveys in percent.
+func (r PropertyReport) ResponseRate() int {
+	if r.Sent == 0 {
+		return 0
+	}
+	return r.Answered * 100 / r.Sent
+}
+
+// maxRecentComments limits the comments shown per property.
+const maxRecentComments = 10
+
+// BuildReport computes the rolling NPS of every property.
+// The trend has one point per step, the last one ending at now; each point covers the answers within window.
+func BuildReport(surveys []Survey, now time.Time, window, step time.Duration, points int) []PropertyReport {
+	byProperty := make(map[string][]Survey)
+	for _, s := range surveys {
+		byProperty[s.Property] = append(byProperty[s.Property], s)
+	}
+
+	reports := make([]PropertyReport, 0, len(byProperty))
+	for property, list := range byProperty {
+		report := PropertyReport{Property: property, Sent: len(list)}
+		for i := points - 1; i >= 0; i-- {
+			end := now.Add(-time.Duration(i) * step)
+			report.Trend = append(report.Trend, npsAt(list, end, window))
+		}
+		for _, s := range list {
+			if !s.IsAnswered() {
+				continue
+			}
+			report.Answered++
+			if s.Comment != "" {
+				report.RecentComments = append(report.RecentComments, s)
+			}
+		}
+		sort.Slice(report.RecentComments, func(i, j int) bool {
+			return report.RecentComments[i].AnsweredAt.After(report.RecentComments[j].AnsweredAt)
+		})
+		if len(report.RecentComments) > maxRecentComments {
+			report.RecentComments = report.RecentComments[:maxRecentComments]
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Property < reports[j].Property })
+	return reports
+}
+
+// npsAt computes the NPS of the answers within the window ending at end.
+func npsAt(surveys []Survey, end time.Time, window time.Duration) NPSPoint {
+	point := NPSPoint{End: end}
+	start := end.Add(-window)
+	for _, s := range surveys {
+		if !s.IsAnswered() || s.AnsweredAt.After(end) || !s.AnsweredAt.After(start) {
+			continue
+		}
+		point.Responses++
+		switch s.Category() {
+		case CategoryPromoter:
+			point.Promoters++
+		case CategoryPassive:
+			point.Passives++
+		default:
+			point.Detractors++
+		}
+	}
+	if point.Responses > 0 {
+		point.Score = (point.Promoters - point.Detractors) * 100 / point.Responses
+	}
+	return point
+}
@@ -0,0 +1,91 @@
+package survey_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func answeredSurvey(property string, score int, comment string, answeredAt time.Time) survey.Survey {
+	return survey.Survey{
+		Property:   property,
+		Status:     survey.StatusAnswered,
+		Score:      score,
+		Comment:    comment,
+		AnsweredAt: answeredAt,
+	}
+}
+
+// ============================================================================
+// BuildReport Tests
+// ============================================================================
+
+func Test_BuildReport_Should_Compute_NPS(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	surveys := []survey.Survey{
+		answeredSurvey("Harbor", 10, "", now.Add(-time.Hour)),
+		answeredSurvey("Harbor", 9, "", now.Add(-time.Hour)),
+		answeredSurvey("Harbor", 8, "", now.Add(-time.Hour)),
+		answeredSurvey("Harbor", 3, "", now.Add(-time.Hour)),
+	}
+
+	// Act
+	reports := survey.BuildReport(surveys, now, 30*24*time.Hour, 7*24*time.Hour, 1)
+
+	// Assert
+	current := reports[0].Current()
+	assert.That(t, "responses must be 4", current.Responses, 4)
+	assert.That(t, "promoters must be 2", current.Promoters, 2)
+	assert.That(t, "passives must be 1", current.Passives, 1)
+	assert.That(t, "detractors must be 1", current.Detractors, 1)
+	assert.That(t, "score must be 25", current.Score, 25)
+}
+
+func Test_BuildReport_Should_Roll_Window_Over_Trend(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	surveys := []survey.Survey{
+		answeredSurvey("Harbor", 0, "", now.Add(-3*week+time.Hour)), // only in the oldest window
+		answeredSurvey("Harbor", 10, "", now.Add(-time.Hour)),       // only in the newest window
+	}
+
+	// Act
+	reports := survey.BuildReport(surveys, now, week, week, 3)
+
+	// Assert
+	trend := reports[0].Trend
+	assert.That(t, "trend must have 3 points", len(trend), 3)
+	assert.That(t, "oldest point must end two weeks ago", trend[0].End, now.Add(-2*week))
+	assert.That(t, "oldest point must be -100", trend[0].Score, -100)
+	assert.That(t, "middle point must have no responses", trend[1].Responses, 0)
+	assert.That(t, "newest point must be 100", trend[2].Score, 100)
+}
+
+func Test_BuildReport_Should_Separate_Properties_And_Sort_Comments(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	surveys := []survey.Survey{
+		answeredSurvey("Mountain", 9, "older", now.Add(-2*time.Hour)),
+		answeredSurvey("Harbor", 9, "", now.Add(-time.Hour)),
+		answeredSurvey("Mountain", 5, "newer", now.Add(-time.Hour)),
+		{Property: "Mountain", Status: survey.StatusSent},
+	}
+
+	// Act
+	reports := survey.BuildReport(surveys, now, 30*24*time.Hour, 7*24*time.Hour, 1)
+
+	// Assert
+	assert.That(t, "reports count must be 2", len(reports), 2)
+	assert.That(t, "reports must be sorted by property", reports[0].Property, "Harbor")
+	assert.That(t, "sent must count unanswered surveys", reports[1].Sent, 3)
+	assert.That(t, "answered must be 2", reports[1].Answered, 2)
+	assert.That(t, "comments must be newest first", reports[1].RecentComments[0].Comment, "newer")
+}
@@ -0,0 +1,74 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Service handles survey workflows.
+type Service struct {
+	surveyRepo SurveyRepository
+	sender     SurveySender
+	property   string
+}
+
+// NewService creates a new survey service.
+// The property is recorded on every survey, so NPS can be reported per property.
+func NewService(repo SurveyRepository, sender SurveySender, property string) *Service {
+	return &Service{
+		surveyRepo: repo,
+		sender:     sender,
+		property:   property,
+	}
+}
+
+// SendSurvey creates the survey of a completed stay and invites the guest.
+// It is idempotent, because the reservation.completed event may be delivered more than once.
+func (s *Service) SendSurvey(ctx context.Context, reservationID ReservationID, guestID GuestID, email string) (*Survey, error) {
+	if existing, err := s.surveyRepo.Read(ctx, SurveyIDFor(reservationID)); err == nil {
+		return existing, nil
+	}
+
+	survey := NewSurvey(reservationID, guestID, email, s.property, time.Now())
+	if err := s.surveyRepo.Create(ctx, survey.ID, *survey); err != nil {
+		return nil, fmt.Errorf("failed to persist survey: %w", err)
+	}
+	if err := s.sender.SendSurveyInvitation(ctx, survey); err != nil {
+		return nil, fmt.Errorf("failed to send survey invitation: %w", err)
+	}
+	return survey, nil
+}
+
+// GetSurvey retrieves a survey by ID.
+func (s *Service) GetSurvey(ctx context.Context, id SurveyID) (*Survey, error) {
+	survey, err := s.surveyRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read survey: %w", err)
+	}
+	return survey, nil
+}
+
+// SubmitAnswer records the score and comment of the guest.
+func (s *Service) SubmitAnswer(ctx context.Context, id SurveyID, guestID GuestID, score int, comment string) (*Survey, error) {
+	survey, err := s.surveyRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read survey: %w", err)
+	}
+	if err := survey.Answer(guestID, score, comment, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to answer survey: %w", err)
+	}
+	if err := s.surveyRepo.Update(ctx, id, *survey); err != nil {
+		return nil, fmt.Errorf("failed to update survey: %w", err)
+	}
+	return survey, nil
+}
+
+// Report computes the rolling NPS trend of every property, ending at now.
+func (s *Service) Report(ctx context.Context, now time.Time, window, step time.Duration, points int) ([]PropertyReport, error) {
+	surveys, err := s.surveyRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list surveys: %w", err)
+	}
+	return BuildReport(surveys, now, window, step, points), nil
+}
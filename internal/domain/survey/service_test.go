@@ -0,0 +1,129 @@
+package survey_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/survey"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockSurveySender struct {
+	sent int
+	err  error
+}
+
+func (m *mockSurveySender) SendSurveyInvitation(ctx context.Context, s *survey.Survey) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent++
+	return nil
+}
+
+func createTestSurveyService(sender *mockSurveySender) *survey.Service {
+	return survey.NewService(resource.NewInMemoryAccess[survey.SurveyID, survey.Survey](), sender, "Test Hotel")
+}
+
+// ============================================================================
+// Service Tests
+// ============================================================================
+
+func Test_Service_SendSurvey_Should_Persist_And_Invite(t *testing.T) {
+	// Arrange
+	sender := &mockSurveySender{}
+	svc := createTestSurveyService(sender)
+	ctx := context.Background()
+
+	// Act
+	s, err := svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "property must be recorded", s.Property, "Test Hotel")
+	assert.That(t, "invitation must be sent", sender.sent, 1)
+	stored, err := svc.GetSurvey(ctx, s.ID)
+	assert.That(t, "survey must be stored", err == nil && stored.GuestEmail == "guest@example.com", true)
+}
+
+func Test_Service_SendSurvey_Twice_Should_Invite_Once(t *testing.T) {
+	// Arrange
+	sender := &mockSurveySender{}
+	svc := createTestSurveyService(sender)
+	ctx := context.Background()
+	_, _ = svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+
+	// Act
+	_, err := svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "invitation must be sent once", sender.sent, 1)
+}
+
+func Test_Service_SendSurvey_When_Sender_Fails_Should_Return_Error(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService(&mockSurveySender{err: errors.New("smtp down")})
+
+	// Act
+	_, err := svc.SendSurvey(context.Background(), "res-001", "guest-001", "guest@example.com")
+
+	// Assert
+	assert.That(t, "err must not be nil", err != nil, true)
+}
+
+func Test_Service_SubmitAnswer_Should_Store_Answer(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService(&mockSurveySender{})
+	ctx := context.Background()
+	s, _ := svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+
+	// Act
+	_, err := svc.SubmitAnswer(ctx, s.ID, "guest-001", 10, "Lovely")
+
+	// Assert
+	stored, _ := svc.GetSurvey(ctx, s.ID)
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "survey must be answered", stored.IsAnswered(), true)
+	assert.That(t, "score must be stored", stored.Score, 10)
+}
+
+func Test_Service_SubmitAnswer_By_Other_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService(&mockSurveySender{})
+	ctx := context.Background()
+	s, _ := svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+
+	// Act
+	_, err := svc.SubmitAnswer(ctx, s.ID, "guest-002", 10, "")
+
+	// Assert
+	assert.That(t, "error must be ErrNotSurveyRecipient", errors.Is(err, survey.ErrNotSurveyRecipient), true)
+}
+
+func Test_Service_Report_Should_Group_By_Property(t *testing.T) {
+	// Arrange
+	svc := createTestSurveyService(&mockSurveySender{})
+	ctx := context.Background()
+	s, _ := svc.SendSurvey(ctx, "res-001", "guest-001", "guest@example.com")
+	_, _ = svc.SubmitAnswer(ctx, s.ID, "guest-001", 10, "")
+	_, _ = svc.SendSurvey(ctx, "res-002", "guest-002", "other@example.com")
+
+	// Act
+	reports, err := svc.Report(ctx, time.Now().Add(time.Minute), survey.DefaultReportWindow, survey.DefaultReportStep, survey.DefaultReportPoints)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "reports count must be 1", len(reports), 1)
+	assert.That(t, "sent must be 2", reports[0].Sent, 2)
+	assert.That(t, "answered must be 1", reports[0].Answered, 1)
+	assert.That(t, "response rate must be 50", reports[0].ResponseRate(), 50)
+	assert.That(t, "current nps must be 100", reports[0].Current().Score, 100)
+}
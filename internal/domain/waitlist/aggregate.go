@@ -0,0 +1,135 @@
+// Package waitlist contains the Waitlist bounded context.
+// Guests join the waitlist of a room for their dates when it is booked; when a cancellation frees
+// the room, the first guest waiting for it is offered the stay and the room is held for them for a while.
+package waitlist
+
+import (
+	"errors"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// Local ID types for this bounded context
+type EntryID string
+type RoomID string
+
+// GuestID identifies a guest account by its OIDC subject, as in the reservation context.
+type GuestID string
+
+// EntryIDPrefix marks entry IDs created by NewEntryID.
+const EntryIDPrefix = "wait_"
+
+// NewEntryID returns a new, time-sortable entry ID.
+func NewEntryID() EntryID {
+	return EntryID(EntryIDPrefix + shared.NewULID())
+}
+
+// EntryStatus represents the state of a waitlist entry.
+type EntryStatus string
+
+const (
+	StatusWaiting   EntryStatus = "waiting"   // room booked, guest waits for a cancellation
+	StatusOffered   EntryStatus = "offered"   // room freed and held for the guest until HoldUntil
+	StatusBooked    EntryStatus = "booked"    // guest booked the room
+	StatusExpired   EntryStatus = "expired"   // hold ran out before the guest booked
+	StatusWithdrawn EntryStatus = "withdrawn" // guest left the waitlist
+)
+
+// Validation errors.
+var (
+	ErrInvalidDateRange = errors.New("check-out must be after check-in")
+	ErrEntryNotFound    = errors.New("waitlist entry not found")
+	ErrAlreadyWaiting   = errors.New("guest is already on the waitlist for these dates")
+	ErrRoomAvailable    = errors.New("room is available, book it instead")
+	ErrEntryClosed      = errors.New("waitlist entry is no longer open")
+)
+
+// Entry is the aggregate root for a guest waiting for a room and date range.
+type Entry struct {
+	ID        EntryID
+	GuestID   GuestID
+	Email     string
+	RoomID    RoomID
+	CheckIn   time.Time
+	CheckOut  time.Time
+	Status    EntryStatus
+	CreatedAt time.Time
+	OfferedAt time.Time
+	HoldUntil time.Time
+	ClosedAt  time.Time
+}
+
+// NewEntry creates a waiting entry of the guest for the room and dates.
+func NewEntry(guestID GuestID, email string, roomID RoomID, checkIn, checkOut time.Time, at time.Time) (*Entry, error) {
+	if !checkOut.After(checkIn) {
+		return nil, ErrInvalidDateRange
+	}
+	return &Entry{
+		ID:        NewEntryID(),
+		GuestID:   guestID,
+		Email:     email,
+		RoomID:    roomID,
+		CheckIn:   checkIn,
+		CheckOut:  checkOut,
+		Status:    StatusWaiting,
+		CreatedAt: at,
+	}, nil
+}
+
+// Overlaps reports whether the entry is for the room and shares a night with the date range.
+func (e *Entry) Overlaps(roomID RoomID, checkIn, checkOut time.Time) bool {
+	return e.RoomID == roomID && e.CheckIn.Before(checkOut) && e.CheckOut.After(checkIn)
+}
+
+// IsOpen reports whether the guest is still waiting or holds an offer.
+func (e *Entry) IsOpen() bool {
+	return e.Status == StatusWaiting || e.Status == StatusOffered
+}
+
+// IsHeld reports whether the room is held for the guest at the time.
+// An offer past its hold no longer holds the room, even before it is expired.
+func (e *Entry) IsHeld(at time.Time) bool {
+	return e.Status == StatusOffered && at.Before(e.HoldUntil)
+}
+
+// Offer holds the room for the guest for the hold duration.
+func (e *Entry) Offer(at time.Time, hold time.Duration) error {
+	if e.Status != StatusWaiting {
+		return ErrEntryClosed
+	}
+	e.Status = StatusOffered
+	e.OfferedAt = at
+	e.HoldUntil = at.Add(hold)
+	return nil
+}
+
+// Book closes the entry after the guest booked the room.
+func (e *Entry) Book(at time.Time) error {
+	if !e.IsOpen() {
+		return ErrEntryClosed
+	}
+	e.Status = StatusBooked
+	e.ClosedAt = at
+	return nil
+}
+
+// Expire closes the offer after its hold ran out.
+func (e *Entry) Expire(at time.Time) error {
+	if e.Status != StatusOffered {
+		return ErrEntryClosed
+	}
+	e.Status = StatusExpired
+	e.ClosedAt = at
+	return nil
+}
+
+// Withdraw closes the entry because the guest left the waitlist.
+func (e *Entry) Withdraw(at time.Time) error {
+	if !e.IsOpen() {
+		return ErrEntryClosed
+	}
+	e.Status = StatusWithdrawn
+	e.ClosedAt = at
+	return nil
+}
@@ -0,0 +1,112 @@
+package waitlist_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// ============================================================================
+// Test Helpers
+// ============================================================================
+
+var (
+	testCheckIn  = time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	testCheckOut = time.Date(2030, 6, 4, 0, 0, 0, 0, time.UTC)
+)
+
+func createTestEntry(t *testing.T) *waitlist.Entry {
+	t.Helper()
+	entry, err := waitlist.NewEntry("guest-a", "a@example.com", "room-101", testCheckIn, testCheckOut, time.Now())
+	if err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+	return entry
+}
+
+// ============================================================================
+// Entry Tests
+// ============================================================================
+
+func Test_NewEntry_Should_Be_Waiting(t *testing.T) {
+	// Act
+	entry := createTestEntry(t)
+
+	// Assert
+	assert.That(t, "status must be waiting", entry.Status, waitlist.StatusWaiting)
+	assert.That(t, "ID must have the prefix", strings.HasPrefix(string(entry.ID), waitlist.EntryIDPrefix), true)
+	assert.That(t, "entry must be open", entry.IsOpen(), true)
+}
+
+func Test_NewEntry_With_CheckOut_Before_CheckIn_Should_Fail(t *testing.T) {
+	// Act
+	_, err := waitlist.NewEntry("guest-a", "a@example.com", "room-101", testCheckOut, testCheckIn, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidDateRange", err, waitlist.ErrInvalidDateRange)
+}
+
+func Test_Entry_Overlaps_Should_Compare_Room_And_Nights(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+
+	// Act & Assert
+	assert.That(t, "shared night must overlap", entry.Overlaps("room-101", testCheckIn.AddDate(0, 0, 2), testCheckOut.AddDate(0, 0, 2)), true)
+	assert.That(t, "check-out day must not overlap", entry.Overlaps("room-101", testCheckOut, testCheckOut.AddDate(0, 0, 1)), false)
+	assert.That(t, "other room must not overlap", entry.Overlaps("room-102", testCheckIn, testCheckOut), false)
+}
+
+func Test_Entry_Offer_Should_Hold_Room_Until_Hold_Ends(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+	now := time.Now()
+
+	// Act
+	err := entry.Offer(now, 2*time.Hour)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "status must be offered", entry.Status, waitlist.StatusOffered)
+	assert.That(t, "room must be held during the hold", entry.IsHeld(now.Add(time.Hour)), true)
+	assert.That(t, "room must not be held after the hold", entry.IsHeld(now.Add(3*time.Hour)), false)
+}
+
+func Test_Entry_Expire_Of_Waiting_Entry_Should_Fail(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+
+	// Act
+	err := entry.Expire(time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrEntryClosed", err, waitlist.ErrEntryClosed)
+}
+
+func Test_Entry_Book_Of_Offered_Entry_Should_Close_It(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+	_ = entry.Offer(time.Now(), time.Hour)
+
+	// Act
+	err := entry.Book(time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "status must be booked", entry.Status, waitlist.StatusBooked)
+	assert.That(t, "room must no longer be held", entry.IsHeld(time.Now()), false)
+}
+
+func Test_Entry_Withdraw_Of_Closed_Entry_Should_Fail(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+	_ = entry.Withdraw(time.Now())
+
+	// Act
+	err := entry.Withdraw(time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrEntryClosed", err, waitlist.ErrEntryClosed)
+}
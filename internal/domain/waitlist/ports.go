@@ -0,0 +1,21 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// EntryRepository provides CRUD operations for waitlist entries.
+type EntryRepository resource.Access[EntryID, Entry]
+
+// RoomAvailability tells whether the reservations leave a room free for a date range.
+type RoomAvailability interface {
+	IsRoomAvailable(ctx context.Context, roomID RoomID, checkIn, checkOut time.Time) (bool, error)
+}
+
+// OfferNotifier tells the guest that the room was freed and is held for them.
+type OfferNotifier interface {
+	SendWaitlistOffer(ctx context.Context, e *Entry) error
+}
@@ -0,0 +1,226 @@
+package waitlist
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+)
+
+// DefaultHold is how long a freed room is held for the guest it was offered to.
+const DefaultHold = 2 * time.Hour
+
+// Service handles waitlist workflows.
+type Service struct {
+	entryRepo    EntryRepository
+	availability RoomAvailability
+	notifier     OfferNotifier
+	hold         time.Duration
+	mu           sync.Mutex // serializes offers, so a freed room is not held for two guests
+}
+
+// NewService creates a new waitlist service. Offers hold the room for hold, or DefaultHold if it is not positive.
+func NewService(entryRepo EntryRepository, availability RoomAvailability, notifier OfferNotifier, hold time.Duration) *Service {
+	if hold <= 0 {
+		hold = DefaultHold
+	}
+	return &Service{
+		entryRepo:    entryRepo,
+		availability: availability,
+		notifier:     notifier,
+		hold:         hold,
+	}
+}
+
+// Hold returns how long offered rooms are held.
+func (s *Service) Hold() time.Duration {
+	return s.hold
+}
+
+// Join puts the guest on the waitlist of the room for the dates.
+// Guests can only wait for rooms that are booked or held for someone else.
+func (s *Service) Join(ctx context.Context, guestID GuestID, email string, roomID RoomID, checkIn, checkOut time.Time) (*Entry, error) {
+	now := shared.Now()
+	entry, err := NewEntry(guestID, email, roomID, checkIn, checkOut, now)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsOpen() && e.GuestID == guestID && e.RoomID == roomID && e.CheckIn.Equal(entry.CheckIn) && e.CheckOut.Equal(entry.CheckOut) {
+			return nil, ErrAlreadyWaiting
+		}
+	}
+	available, err := s.availability.IsRoomAvailable(ctx, roomID, entry.CheckIn, entry.CheckOut)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if available && !heldForOthers(entries, roomID, entry.CheckIn, entry.CheckOut, guestID, now) {
+		return nil, ErrRoomAvailable
+	}
+
+	if err := s.entryRepo.Create(ctx, entry.ID, *entry); err != nil {
+		return nil, fmt.Errorf("failed to persist waitlist entry: %w", err)
+	}
+	return entry, nil
+}
+
+// EntriesOf returns the waitlist entries of the guest, newest first.
+func (s *Service) EntriesOf(ctx context.Context, guestID GuestID) ([]Entry, error) {
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	entries = slices.DeleteFunc(entries, func(e Entry) bool { return e.GuestID != guestID })
+	slices.SortFunc(entries, func(a, b Entry) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return entries, nil
+}
+
+// Withdraw takes the guest off the waitlist. A room held for the guest is offered to the next guest.
+func (s *Service) Withdraw(ctx context.Context, guestID GuestID, id EntryID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.entryRepo.Read(ctx, id)
+	if err != nil || entry.GuestID != guestID {
+		return ErrEntryNotFound
+	}
+	now := shared.Now()
+	wasHeld := entry.IsHeld(now)
+	if err := entry.Withdraw(now); err != nil {
+		return err
+	}
+	if err := s.entryRepo.Update(ctx, entry.ID, *entry); err != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+	if wasHeld {
+		if _, err := s.offer(ctx, entry.RoomID, entry.CheckIn, entry.CheckOut, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OfferFreedRoom offers the room to the guests waiting for the freed dates, first come first served,
+// and holds it for them. A guest is only offered the room if it is free for all of their dates, and
+// guests whose dates overlap those of an earlier guest keep waiting. It returns the offered entries.
+func (s *Service) OfferFreedRoom(ctx context.Context, roomID RoomID, checkIn, checkOut time.Time) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offer(ctx, roomID, checkIn, checkOut, shared.Now())
+}
+
+// offer implements OfferFreedRoom; the caller holds mu.
+func (s *Service) offer(ctx context.Context, roomID RoomID, checkIn, checkOut, now time.Time) ([]Entry, error) {
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	var offered []Entry
+	for i := range entries {
+		e := &entries[i]
+		if e.Status != StatusWaiting || !e.Overlaps(roomID, checkIn, checkOut) {
+			continue
+		}
+		if heldForOthers(entries, e.RoomID, e.CheckIn, e.CheckOut, "", now) {
+			continue
+		}
+		available, err := s.availability.IsRoomAvailable(ctx, e.RoomID, e.CheckIn, e.CheckOut)
+		if err != nil {
+			return offered, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if !available {
+			continue
+		}
+
+		if err := e.Offer(now, s.hold); err != nil {
+			return offered, err
+		}
+		if err := s.entryRepo.Update(ctx, e.ID, *e); err != nil {
+			return offered, fmt.Errorf("failed to update waitlist entry: %w", err)
+		}
+		if err := s.notifier.SendWaitlistOffer(ctx, e); err != nil {
+			return offered, fmt.Errorf("failed to send waitlist offer: %w", err)
+		}
+		offered = append(offered, *e)
+	}
+	return offered, nil
+}
+
+// IsHeldForOthers reports whether the room is held for the dates for another guest than guestID.
+func (s *Service) IsHeldForOthers(ctx context.Context, roomID RoomID, checkIn, checkOut time.Time, guestID GuestID) (bool, error) {
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	return heldForOthers(entries, roomID, checkIn, checkOut, guestID, shared.Now()), nil
+}
+
+// MarkBooked closes the open entries of the guest for the room and dates after the guest booked it.
+// It is idempotent, because the reservation.created event may be delivered more than once.
+func (s *Service) MarkBooked(ctx context.Context, guestID GuestID, roomID RoomID, checkIn, checkOut time.Time) error {
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	now := shared.Now()
+	for _, e := range entries {
+		if e.GuestID != guestID || !e.IsOpen() || !e.Overlaps(roomID, checkIn, checkOut) {
+			continue
+		}
+		if err := e.Book(now); err != nil {
+			return err
+		}
+		if err := s.entryRepo.Update(ctx, e.ID, e); err != nil {
+			return fmt.Errorf("failed to update waitlist entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// ExpireOffers expires the offers whose hold ran out and offers their rooms to the next guests.
+// It returns the number of expired offers.
+func (s *Service) ExpireOffers(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.entryRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	var expired []Entry
+	for _, e := range entries {
+		if e.Status != StatusOffered || e.IsHeld(now) {
+			continue
+		}
+		if err := e.Expire(now); err != nil {
+			return len(expired), err
+		}
+		if err := s.entryRepo.Update(ctx, e.ID, e); err != nil {
+			return len(expired), fmt.Errorf("failed to update waitlist entry: %w", err)
+		}
+		expired = append(expired, e)
+	}
+	for _, e := range expired {
+		if _, err := s.offer(ctx, e.RoomID, e.CheckIn, e.CheckOut, now); err != nil {
+			return len(expired), err
+		}
+	}
+	return len(expired), nil
+}
+
+// heldForOthers reports whether one of the entries holds the room for the dates for another guest than guestID.
+func heldForOthers(entries []Entry, roomID RoomID, checkIn, checkOut time.Time, guestID GuestID, now time.Time) bool {
+	return slices.ContainsFunc(entries, func(e Entry) bool {
+		return e.GuestID != guestID && e.IsHeld(now) && e.Overlaps(roomID, checkIn, checkOut)
+	})
+}
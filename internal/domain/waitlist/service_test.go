@@ -0,0 +1,264 @@
+package waitlist_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/shared"
+	"github.com/andygeiss/hotel-booking/internal/domain/waitlist"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockRoomAvailability struct {
+	available bool
+}
+
+func (m *mockRoomAvailability) IsRoomAvailable(ctx context.Context, roomID waitlist.RoomID, checkIn, checkOut time.Time) (bool, error) {
+	return m.available, nil
+}
+
+type mockOfferNotifier struct {
+	offered []waitlist.GuestID
+}
+
+func (m *mockOfferNotifier) SendWaitlistOffer(ctx context.Context, e *waitlist.Entry) error {
+	m.offered = append(m.offered, e.GuestID)
+	return nil
+}
+
+func createTestWaitlistService(availability *mockRoomAvailability, notifier *mockOfferNotifier) *waitlist.Service {
+	return waitlist.NewService(resource.NewInMemoryAccess[waitlist.EntryID, waitlist.Entry](), availability, notifier, 2*time.Hour)
+}
+
+// joinBookedRoom puts the guests on the waitlist of room-101 for the test dates, in order.
+func joinBookedRoom(t *testing.T, svc *waitlist.Service, availability *mockRoomAvailability, guests ...waitlist.GuestID) []*waitlist.Entry {
+	t.Helper()
+	availability.available = false
+	var entries []*waitlist.Entry
+	for _, guestID := range guests {
+		entry, err := svc.Join(context.Background(), guestID, string(guestID)+"@example.com", "room-101", testCheckIn, testCheckOut)
+		if err != nil {
+			t.Fatalf("failed to join waitlist: %v", err)
+		}
+		entries = append(entries, entry)
+		time.Sleep(time.Millisecond) // keep the order of CreatedAt
+	}
+	availability.available = true
+	return entries
+}
+
+// ============================================================================
+// Join Tests
+// ============================================================================
+
+func Test_Service_Join_Available_Room_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestWaitlistService(&mockRoomAvailability{available: true}, &mockOfferNotifier{})
+
+	// Act
+	_, err := svc.Join(context.Background(), "guest-a", "a@example.com", "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	assert.That(t, "err must be ErrRoomAvailable", err, waitlist.ErrRoomAvailable)
+}
+
+func Test_Service_Join_Twice_Should_Fail(t *testing.T) {
+	// Arrange
+	svc := createTestWaitlistService(&mockRoomAvailability{}, &mockOfferNotifier{})
+	_, _ = svc.Join(context.Background(), "guest-a", "a@example.com", "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	_, err := svc.Join(context.Background(), "guest-a", "a@example.com", "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	assert.That(t, "err must be ErrAlreadyWaiting", err, waitlist.ErrAlreadyWaiting)
+}
+
+// ============================================================================
+// Offer Tests
+// ============================================================================
+
+func Test_Service_OfferFreedRoom_Should_Hold_Room_For_First_Guest(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	joinBookedRoom(t, svc, availability, "guest-a", "guest-b")
+	ctx := context.Background()
+
+	// Act
+	offered, err := svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	heldForB, _ := svc.IsHeldForOthers(ctx, "room-101", testCheckIn, testCheckOut, "guest-b")
+	heldForA, _ := svc.IsHeldForOthers(ctx, "room-101", testCheckIn, testCheckOut, "guest-a")
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one guest must be offered the room", len(offered), 1)
+	assert.That(t, "first guest must be notified", notifier.offered, []waitlist.GuestID{"guest-a"})
+	assert.That(t, "room must be held against the second guest", heldForB, true)
+	assert.That(t, "room must not be held against the first guest", heldForA, false)
+}
+
+func Test_Service_OfferFreedRoom_For_Other_Room_Should_Offer_Nothing(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	joinBookedRoom(t, svc, availability, "guest-a")
+
+	// Act
+	offered, _ := svc.OfferFreedRoom(context.Background(), "room-102", testCheckIn, testCheckOut)
+
+	// Assert
+	assert.That(t, "nobody must be offered the room", len(offered), 0)
+	assert.That(t, "nobody must be notified", len(notifier.offered), 0)
+}
+
+func Test_Service_OfferFreedRoom_Still_Booked_Should_Offer_Nothing(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	joinBookedRoom(t, svc, availability, "guest-a")
+	availability.available = false
+
+	// Act
+	offered, _ := svc.OfferFreedRoom(context.Background(), "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	assert.That(t, "nobody must be offered the room", len(offered), 0)
+}
+
+func Test_Service_OfferFreedRoom_Twice_Should_Offer_Once(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	joinBookedRoom(t, svc, availability, "guest-a", "guest-b")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	offered, _ := svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	assert.That(t, "nobody else must be offered the room", len(offered), 0)
+	assert.That(t, "only the first guest must be notified", notifier.offered, []waitlist.GuestID{"guest-a"})
+}
+
+// ============================================================================
+// Expiry Tests
+// ============================================================================
+
+func Test_Service_ExpireOffers_Should_Offer_Room_To_Next_Guest(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	joinBookedRoom(t, svc, availability, "guest-a", "guest-b")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	count, err := svc.ExpireOffers(ctx, time.Now().Add(3*time.Hour))
+
+	// Assert
+	list, _ := svc.EntriesOf(ctx, "guest-a")
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "one offer must expire", count, 1)
+	assert.That(t, "first entry must be expired", list[0].Status, waitlist.StatusExpired)
+	assert.That(t, "next guest must be notified", notifier.offered, []waitlist.GuestID{"guest-a", "guest-b"})
+}
+
+func Test_Service_ExpireOffers_During_Hold_Should_Keep_Offer(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	svc := createTestWaitlistService(availability, &mockOfferNotifier{})
+	joinBookedRoom(t, svc, availability, "guest-a")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	count, _ := svc.ExpireOffers(ctx, time.Now().Add(time.Hour))
+
+	// Assert
+	assert.That(t, "no offer must expire", count, 0)
+}
+
+func Test_Service_IsHeldForOthers_After_Test_Clock_Passed_Hold_Should_Be_False(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	svc := createTestWaitlistService(availability, &mockOfferNotifier{})
+	joinBookedRoom(t, svc, availability, "guest-a")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+	later := time.Now().Add(waitlist.DefaultHold + time.Minute)
+	shared.SetClock(func() time.Time { return later })
+	t.Cleanup(func() { shared.SetClock(nil) })
+
+	// Act
+	held, err := svc.IsHeldForOthers(ctx, "room-101", testCheckIn, testCheckOut, "guest-b")
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "hold must end by the process clock", held, false)
+}
+
+// ============================================================================
+// Withdraw and Booking Tests
+// ============================================================================
+
+func Test_Service_Withdraw_Held_Entry_Should_Offer_Room_To_Next_Guest(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	notifier := &mockOfferNotifier{}
+	svc := createTestWaitlistService(availability, notifier)
+	entries := joinBookedRoom(t, svc, availability, "guest-a", "guest-b")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	err := svc.Withdraw(ctx, "guest-a", entries[0].ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "next guest must be notified", notifier.offered, []waitlist.GuestID{"guest-a", "guest-b"})
+}
+
+func Test_Service_Withdraw_Entry_Of_Other_Guest_Should_Fail(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	svc := createTestWaitlistService(availability, &mockOfferNotifier{})
+	entries := joinBookedRoom(t, svc, availability, "guest-a")
+
+	// Act
+	err := svc.Withdraw(context.Background(), "guest-b", entries[0].ID)
+
+	// Assert
+	assert.That(t, "err must be ErrEntryNotFound", err, waitlist.ErrEntryNotFound)
+}
+
+func Test_Service_MarkBooked_Should_Close_Entry_And_Release_Hold(t *testing.T) {
+	// Arrange
+	availability := &mockRoomAvailability{}
+	svc := createTestWaitlistService(availability, &mockOfferNotifier{})
+	joinBookedRoom(t, svc, availability, "guest-a")
+	ctx := context.Background()
+	_, _ = svc.OfferFreedRoom(ctx, "room-101", testCheckIn, testCheckOut)
+
+	// Act
+	err := svc.MarkBooked(ctx, "guest-a", "room-101", testCheckIn, testCheckOut)
+
+	// Assert
+	list, _ := svc.EntriesOf(ctx, "guest-a")
+	held, _ := svc.IsHeldForOthers(ctx, "room-101", testCheckIn, testCheckOut, "guest-b")
+	assert.That(t, "err must be nil", err == nil, true)
+	assert.That(t, "entry must be booked", list[0].Status, waitlist.StatusBooked)
+	assert.That(t, "room must no longer be held", held, false)
+}
@@ -0,0 +1,226 @@
+// Package webhook contains the Webhook bounded context.
+// Integrators register endpoints that receive reservation and payment events as signed JSON;
+// every delivery is recorded with its response, so integrators can debug their receivers.
+// Failed deliveries of events are retried with backoff and dead-lettered after the last attempt.
+// Guests register endpoints of their own automations that receive the lifecycle events of
+// their own reservations only.
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Local ID types for this bounded context
+type EndpointID string
+type DeliveryID string
+
+// Topics lists the event topics an endpoint can subscribe to, in the order shown to integrators.
+var Topics = []string{
+	"reservation.created",
+	"reservation.confirmed",
+	"reservation.activated",
+	"reservation.completed",
+	"reservation.cancelled",
+	"reservation.no_show",
+	"reservation.extended",
+	"payment.authorized",
+	"payment.captured",
+	"payment.failed",
+	"payment.refunded",
+}
+
+// GuestTopics lists the topics a guest endpoint can subscribe to: the lifecycle of the guest's reservations.
+var GuestTopics = []string{
+	"reservation.created",
+	"reservation.confirmed",
+	"reservation.activated",
+	"reservation.completed",
+	"reservation.cancelled",
+}
+
+// TopicPing is the topic of the ping verifying that a guest endpoint accepts our events.
+const TopicPing = "ping"
+
+// Validation errors.
+var (
+	ErrInvalidURL         = errors.New("webhook URL must be an absolute http or https URL")
+	ErrMissingSecret      = errors.New("webhook secret must not be empty")
+	ErrUnknownTopic       = errors.New("unknown event topic")
+	ErrEndpointNotFound   = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrNotPublicURL       = errors.New("guest webhook URL must be a public https URL")
+	ErrGuestDisabled      = errors.New("guest webhooks are not enabled")
+	ErrDeadLetterNotFound = errors.New("webhook dead letter not found")
+)
+
+// Endpoint is the aggregate root for a receiver of webhook events.
+// Payloads are signed with the secret, so the receiver can verify they were sent by us.
+type Endpoint struct {
+	ID         EndpointID
+	URL        string
+	Secret     string
+	Topics     []string // subscribed topics; empty subscribes to all
+	CreatedAt  time.Time
+	GuestID    string    // OIDC subject owning a guest endpoint; empty for integrator endpoints
+	Disabled   bool      // a disabled guest endpoint keeps its settings but receives no events
+	VerifiedAt time.Time // last successful ping of a guest endpoint; zero until verified
+}
+
+// NewEndpoint creates a new endpoint after validating the URL and topics.
+func NewEndpoint(id EndpointID, rawURL, secret string, topics []string, at time.Time) (*Endpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, ErrInvalidURL
+	}
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	for _, topic := range topics {
+		if !slices.Contains(Topics, topic) {
+			return nil, ErrUnknownTopic
+		}
+	}
+	return &Endpoint{ID: id, URL: rawURL, Secret: secret, Topics: topics, CreatedAt: at}, nil
+}
+
+// NewGuestEndpoint creates an endpoint of the guest's own automation. Guests may only send
+// events to public https URLs, so they cannot reach our internal network through us, and only
+// subscribe to the lifecycle of their reservations.
+func NewGuestEndpoint(id EndpointID, guestID, rawURL, secret string, topics []string, at time.Time) (*Endpoint, error) {
+	endpoint, err := NewEndpoint(id, rawURL, secret, topics, at)
+	if err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(rawURL)
+	if u.Scheme != "https" || !isPublicHost(u.Hostname()) {
+		return nil, ErrNotPublicURL
+	}
+	for _, topic := range topics {
+		if !slices.Contains(GuestTopics, topic) {
+			return nil, ErrUnknownTopic
+		}
+	}
+	if len(topics) == 0 {
+		endpoint.Topics = slices.Clone(GuestTopics)
+	}
+	endpoint.GuestID = guestID
+	return endpoint, nil
+}
+
+// isPublicHost reports whether the host is not obviously internal: no localhost, no single-label
+// name and no loopback, private, link-local or unspecified IP address.
+// Names resolving to internal addresses are refused when dialing (outbound.HTTPClients.PublicClient).
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return strings.Contains(host, ".")
+	}
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// Active reports whether the guest endpoint receives events: it is verified and not disabled.
+func (e *Endpoint) Active() bool {
+	return !e.Disabled && !e.VerifiedAt.IsZero()
+}
+
+// Subscribes reports whether the endpoint receives events of the topic.
+func (e *Endpoint) Subscribes(topic string) bool {
+	return len(e.Topics) == 0 || slices.Contains(e.Topics, topic)
+}
+
+// Event is the JSON envelope POSTed to an endpoint.
+// Redeliveries send the same event again, so receivers can deduplicate by its ID.
+type Event struct {
+	ID        string          `json:"id"`
+	Topic     string          `json:"topic"`
+	CreatedAt time.Time       `json:"created_at"`
+	Test      bool            `json:"test,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery records one attempt to POST an event to an endpoint.
+type Delivery struct {
+	ID           DeliveryID
+	EndpointID   EndpointID
+	EventID      string
+	Topic        string
+	Payload      string
+	Test         bool
+	RedeliveryOf DeliveryID // the delivery that was sent again manually, if any
+	StatusCode   int        // 0 if no response was received
+	Latency      time.Duration
+	Error        string
+	AttemptedAt  time.Time
+}
+
+// Succeeded reports whether the endpoint answered with a 2xx status.
+func (d *Delivery) Succeeded() bool {
+	return d.Error == "" && d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// RetryPolicy configures how often a failed delivery of an event is retried.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts including the first delivery; the event is dead-lettered after the last
+	Backoff     time.Duration // wait before the first retry, doubled for every further one
+}
+
+// DefaultRetryPolicy returns 6 attempts over about half an hour: retries after 1, 2, 4, 8 and 16 minutes.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 6,
+		Backoff:     time.Minute,
+	}
+}
+
+// Delay returns the wait after the number of failed attempts.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	return p.Backoff << min(max(attempts-1, 0), 16)
+}
+
+// Retry is an event whose delivery to an endpoint failed and is sent again at NextAttemptAt.
+// After the last attempt of the policy it is dead-lettered: it is kept with DeadAt set,
+// until staff replay or discard it.
+type Retry struct {
+	ID            DeliveryID // ID of the first delivery; the retries are delivered as ID-2, ID-3, ...
+	EndpointID    EndpointID
+	EventID       string
+	Topic         string
+	Payload       string
+	Attempts      int // failed attempts so far
+	LastStatus    int // status code of the last attempt; 0 if no response was received
+	LastError     string
+	NextAttemptAt time.Time
+	DeadAt        time.Time // zero while the event is retried
+}
+
+// Dead reports whether the event was dead-lettered.
+func (r *Retry) Dead() bool {
+	return !r.DeadAt.IsZero()
+}
+
+// failed records the failed delivery and schedules the next attempt or dead-letters the event.
+// A dead letter that failed again stays dead.
+func (r *Retry) failed(delivery *Delivery, policy RetryPolicy) {
+	r.Attempts++
+	r.LastStatus = delivery.StatusCode
+	r.LastError = delivery.Error
+	if r.LastError == "" {
+		r.LastError = fmt.Sprintf("unexpected status %d", delivery.StatusCode)
+	}
+	if r.Dead() || r.Attempts >= policy.MaxAttempts {
+		r.DeadAt = delivery.AttemptedAt
+		return
+	}
+	r.NextAttemptAt = delivery.AttemptedAt.Add(policy.Delay(r.Attempts))
+}
@@ -0,0 +1,88 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// Endpoint Tests
+// ============================================================================
+
+func Test_Endpoint_Subscribes_Without_Topics_Should_Receive_All(t *testing.T) {
+	// Arrange
+	endpoint, _ := webhook.NewEndpoint("ep-1", "https://example.com", "secret", nil, time.Now())
+
+	// Act
+	subscribed := endpoint.Subscribes("payment.refunded")
+
+	// Assert
+	assert.That(t, "endpoint must receive all topics", subscribed, true)
+}
+
+func Test_Endpoint_Subscribes_With_Topics_Should_Filter(t *testing.T) {
+	// Arrange
+	endpoint, _ := webhook.NewEndpoint("ep-1", "https://example.com", "secret", []string{"reservation.created"}, time.Now())
+
+	// Act
+	subscribed := endpoint.Subscribes("payment.refunded")
+
+	// Assert
+	assert.That(t, "endpoint must not receive other topics", subscribed, false)
+}
+
+func Test_NewGuestEndpoint_With_Internal_URL_Should_Fail(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://hooks.example.com",
+		"https://localhost/hooks",
+		"https://10.0.0.5/hooks",
+		"https://169.254.169.254/latest",
+		"https://[::1]/hooks",
+		"https://reservation-db:5432",
+	} {
+		// Act
+		_, err := webhook.NewGuestEndpoint("ep-1", "guest-1", rawURL, "secret", nil, time.Now())
+
+		// Assert
+		assert.That(t, "err must be ErrNotPublicURL for "+rawURL, errors.Is(err, webhook.ErrNotPublicURL), true)
+	}
+}
+
+func Test_NewGuestEndpoint_With_Payment_Topic_Should_Fail(t *testing.T) {
+	// Act
+	_, err := webhook.NewGuestEndpoint("ep-1", "guest-1", "https://hooks.example.com", "secret", []string{"payment.captured"}, time.Now())
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownTopic", errors.Is(err, webhook.ErrUnknownTopic), true)
+}
+
+func Test_NewGuestEndpoint_Without_Topics_Should_Subscribe_To_Guest_Topics(t *testing.T) {
+	// Act
+	endpoint, err := webhook.NewGuestEndpoint("ep-1", "guest-1", "https://hooks.example.com", "secret", nil, time.Now())
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "endpoint must not receive payment events", endpoint.Subscribes("payment.captured"), false)
+	assert.That(t, "endpoint must receive reservation events", endpoint.Subscribes("reservation.cancelled"), true)
+	assert.That(t, "endpoint must not be active before verification", endpoint.Active(), false)
+}
+
+// ============================================================================
+// Sample Data Tests
+// ============================================================================
+
+func Test_SampleData_Should_Cover_All_Topics(t *testing.T) {
+	for _, topic := range webhook.Topics {
+		// Act
+		data, err := webhook.SampleData(topic)
+
+		// Assert
+		assert.That(t, "err must be nil for "+topic, err, nil)
+		assert.That(t, "data must be valid JSON for "+topic, json.Valid(data), true)
+	}
+}
@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/andygeiss/cloud-native-utils/resource"
+)
+
+// EndpointRepository provides CRUD operations for webhook endpoints.
+type EndpointRepository resource.Access[EndpointID, Endpoint]
+
+// DeliveryRepository provides CRUD operations for webhook deliveries.
+type DeliveryRepository resource.Access[DeliveryID, Delivery]
+
+// RetryRepository provides CRUD operations for the retried and dead-lettered events.
+type RetryRepository resource.Access[DeliveryID, Retry]
+
+// Sender POSTs the payload of a delivery to the endpoint and returns the response status code.
+// An error means no response was received.
+type Sender interface {
+	Send(ctx context.Context, endpoint *Endpoint, delivery *Delivery) (int, error)
+}
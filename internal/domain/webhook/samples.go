@@ -0,0 +1,38 @@
+package webhook
+
+import "encoding/json"
+
+// sampleAmount is the money value of the sample events, encoded like shared.Money.
+var sampleAmount = map[string]any{"Currency": "USD", "Amount": 45000}
+
+// samples holds example data for every topic, shaped like the domain events,
+// so integrators can test their receivers before real bookings arrive.
+var samples = map[string]map[string]any{
+	"reservation.created": {
+		"reservation_id": "res-test",
+		"guest_id":       "guest-test",
+		"room_id":        "room-101",
+		"check_in":       "2030-06-01T15:00:00Z",
+		"check_out":      "2030-06-04T11:00:00Z",
+		"total_amount":   sampleAmount,
+	},
+	"reservation.confirmed": {"reservation_id": "res-test", "guest_id": "guest-test"},
+	"reservation.activated": {"reservation_id": "res-test"},
+	"reservation.completed": {"reservation_id": "res-test"},
+	"reservation.cancelled": {"reservation_id": "res-test", "guest_id": "guest-test", "reason": "test cancellation"},
+	"reservation.no_show":   {"reservation_id": "res-test", "guest_id": "guest-test"},
+	"reservation.extended":  {"reservation_id": "res-test", "guest_id": "guest-test", "room_id": "room-101", "night": "2030-06-04T00:00:00Z", "amount": sampleAmount},
+	"payment.authorized":    {"payment_id": "pay-test", "reservation_id": "res-test", "transaction_id": "txn-test", "amount": sampleAmount},
+	"payment.captured":      {"payment_id": "pay-test", "reservation_id": "res-test", "amount": sampleAmount},
+	"payment.failed":        {"payment_id": "pay-test", "reservation_id": "res-test", "error_code": "card_declined", "error_msg": "test decline"},
+	"payment.refunded":      {"payment_id": "pay-test", "reservation_id": "res-test", "amount": sampleAmount},
+}
+
+// SampleData returns example event data of the topic.
+func SampleData(topic string) (json.RawMessage, error) {
+	sample, ok := samples[topic]
+	if !ok {
+		return nil, ErrUnknownTopic
+	}
+	return json.Marshal(sample)
+}
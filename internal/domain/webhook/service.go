@@ -0,0 +1,498 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DeliveriesPerEndpoint is the number of recent deliveries kept per endpoint; older ones are deleted.
+const DeliveriesPerEndpoint = 50
+
+// Service handles webhook endpoints and their deliveries.
+type Service struct {
+	endpointRepo      EndpointRepository
+	deliveryRepo      DeliveryRepository
+	sender            Sender
+	guestEndpointRepo EndpointRepository
+	guestSender       Sender
+	retryRepo         RetryRepository
+	retryPolicy       RetryPolicy
+}
+
+// NewService creates a new webhook service.
+func NewService(endpointRepo EndpointRepository, deliveryRepo DeliveryRepository, sender Sender) *Service {
+	return &Service{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+	}
+}
+
+// WithGuestEndpoints enables the endpoints of guests' own automations, stored apart from the
+// integrator endpoints. The sender must refuse internal addresses, because guests choose the URLs.
+func (s *Service) WithGuestEndpoints(repo EndpointRepository, sender Sender) *Service {
+	s.guestEndpointRepo = repo
+	s.guestSender = sender
+	return s
+}
+
+// GuestEndpointsEnabled reports whether guests can register endpoints.
+func (s *Service) GuestEndpointsEnabled() bool {
+	return s.guestEndpointRepo != nil
+}
+
+// WithRetries enables retrying the failed deliveries of events to integrator endpoints (DeliverEvent)
+// with the policy; without, a failed delivery is only recorded.
+func (s *Service) WithRetries(repo RetryRepository, policy RetryPolicy) *Service {
+	s.retryRepo = repo
+	s.retryPolicy = policy
+	return s
+}
+
+// RegisterEndpoint validates and stores a new endpoint.
+func (s *Service) RegisterEndpoint(ctx context.Context, id EndpointID, url, secret string, topics []string) (*Endpoint, error) {
+	endpoint, err := NewEndpoint(id, url, secret, topics, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.endpointRepo.Create(ctx, endpoint.ID, *endpoint); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// Endpoint returns the endpoint with the ID.
+func (s *Service) Endpoint(ctx context.Context, id EndpointID) (*Endpoint, error) {
+	endpoint, err := s.endpointRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEndpointNotFound, err)
+	}
+	return endpoint, nil
+}
+
+// Endpoints returns all endpoints, oldest first.
+func (s *Service) Endpoints(ctx context.Context) ([]Endpoint, error) {
+	endpoints, err := s.endpointRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	slices.SortFunc(endpoints, func(a, b Endpoint) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return endpoints, nil
+}
+
+// Deliveries returns the most recent deliveries of the endpoint, newest first.
+func (s *Service) Deliveries(ctx context.Context, endpointID EndpointID, limit int) ([]Delivery, error) {
+	all, err := s.deliveryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	var deliveries []Delivery
+	for _, d := range all {
+		if d.EndpointID == endpointID {
+			deliveries = append(deliveries, d)
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b Delivery) int { return b.AttemptedAt.Compare(a.AttemptedAt) })
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// SendTestEvent delivers an event of the topic with sample data to the endpoint.
+// The event is marked as test, so receivers can tell it apart from real bookings.
+func (s *Service) SendTestEvent(ctx context.Context, id DeliveryID, endpointID EndpointID, topic string) (*Delivery, error) {
+	endpoint, err := s.Endpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := SampleData(topic)
+	if err != nil {
+		return nil, err
+	}
+	event := Event{ID: "evt-" + string(id), Topic: topic, CreatedAt: time.Now().UTC(), Test: true, Data: data}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+	return s.deliver(ctx, s.sender, endpoint, &Delivery{
+		ID:         id,
+		EndpointID: endpoint.ID,
+		EventID:    event.ID,
+		Topic:      topic,
+		Payload:    string(payload),
+		Test:       true,
+	})
+}
+
+// Redeliver sends the payload of an earlier delivery again, unchanged.
+func (s *Service) Redeliver(ctx context.Context, id DeliveryID, deliveryID DeliveryID) (*Delivery, error) {
+	original, err := s.deliveryRepo.Read(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDeliveryNotFound, err)
+	}
+	endpoint, err := s.Endpoint(ctx, original.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliver(ctx, s.sender, endpoint, &Delivery{
+		ID:           id,
+		EndpointID:   endpoint.ID,
+		EventID:      original.EventID,
+		Topic:        original.Topic,
+		Payload:      original.Payload,
+		Test:         original.Test,
+		RedeliveryOf: original.ID,
+	})
+}
+
+// DeliverEvent sends an event to the integrator endpoints that subscribe to the topic.
+// Like DeliverGuestEvent, the delivery IDs derive from the event ID, so replayed events are not sent twice.
+// A failed delivery is retried by RetryDue if retries are enabled.
+func (s *Service) DeliverEvent(ctx context.Context, eventID, topic string, data json.RawMessage) ([]Delivery, error) {
+	endpoints, err := s.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(Event{ID: eventID, Topic: topic, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+	var deliveries []Delivery
+	for _, endpoint := range endpoints {
+		if endpoint.Disabled || !endpoint.Subscribes(topic) {
+			continue
+		}
+		id := DeliveryID(string(endpoint.ID) + "-" + eventID)
+		if _, err := s.deliveryRepo.Read(ctx, id); err == nil {
+			continue
+		}
+		delivery, err := s.deliver(ctx, s.sender, &endpoint, &Delivery{
+			ID:         id,
+			EndpointID: endpoint.ID,
+			EventID:    eventID,
+			Topic:      topic,
+			Payload:    string(payload),
+		})
+		if err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, *delivery)
+		if delivery.Succeeded() || s.retryRepo == nil {
+			continue
+		}
+		retry := Retry{ID: id, EndpointID: endpoint.ID, EventID: eventID, Topic: topic, Payload: delivery.Payload}
+		retry.failed(delivery, s.retryPolicy)
+		if err := s.retryRepo.Create(ctx, retry.ID, retry); err != nil {
+			return deliveries, fmt.Errorf("failed to persist webhook retry: %w", err)
+		}
+	}
+	return deliveries, nil
+}
+
+// RetryDue sends the events whose next attempt is due and returns the number delivered.
+// An event that fails its last attempt, or whose endpoint was removed, is dead-lettered.
+func (s *Service) RetryDue(ctx context.Context, now time.Time) (int, error) {
+	if s.retryRepo == nil {
+		return 0, nil
+	}
+	retries, err := s.retryRepo.ReadAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhook retries: %w", err)
+	}
+	delivered := 0
+	for _, retry := range retries {
+		if retry.Dead() || retry.NextAttemptAt.After(now) {
+			continue
+		}
+		endpoint, err := s.Endpoint(ctx, retry.EndpointID)
+		if err != nil {
+			// Events of removed endpoints cannot be delivered anymore, but stay visible as dead letters.
+			retry.LastStatus, retry.LastError, retry.DeadAt = 0, err.Error(), now
+			if err := s.retryRepo.Update(ctx, retry.ID, retry); err != nil {
+				return delivered, fmt.Errorf("failed to persist webhook retry: %w", err)
+			}
+			continue
+		}
+		delivery, err := s.retry(ctx, endpoint, &retry, DeliveryID(fmt.Sprintf("%s-%d", retry.ID, retry.Attempts+1)), "")
+		if err != nil {
+			return delivered, err
+		}
+		if delivery.Succeeded() {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// DeadLetters returns the dead-lettered events, most recently dead first.
+func (s *Service) DeadLetters(ctx context.Context) ([]Retry, error) {
+	if s.retryRepo == nil {
+		return nil, nil
+	}
+	retries, err := s.retryRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+	var dead []Retry
+	for _, retry := range retries {
+		if retry.Dead() {
+			dead = append(dead, retry)
+		}
+	}
+	slices.SortFunc(dead, func(a, b Retry) int { return b.DeadAt.Compare(a.DeadAt) })
+	return dead, nil
+}
+
+// ReplayDeadLetter sends a dead-lettered event once more, e.g. after the integrator fixed the receiver.
+// It is removed if the delivery succeeds; otherwise it stays dead with the new outcome.
+func (s *Service) ReplayDeadLetter(ctx context.Context, id DeliveryID, deadLetterID DeliveryID) (*Delivery, error) {
+	retry, err := s.deadLetter(ctx, deadLetterID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.Endpoint(ctx, retry.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	return s.retry(ctx, endpoint, retry, id, retry.ID)
+}
+
+// DiscardDeadLetter deletes a dead-lettered event that should not be delivered anymore.
+func (s *Service) DiscardDeadLetter(ctx context.Context, id DeliveryID) error {
+	retry, err := s.deadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.retryRepo.Delete(ctx, retry.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook dead letter: %w", err)
+	}
+	return nil
+}
+
+// deadLetter returns the dead-lettered event with the ID.
+func (s *Service) deadLetter(ctx context.Context, id DeliveryID) (*Retry, error) {
+	if s.retryRepo == nil {
+		return nil, ErrDeadLetterNotFound
+	}
+	retry, err := s.retryRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDeadLetterNotFound, err)
+	}
+	if !retry.Dead() {
+		return nil, ErrDeadLetterNotFound
+	}
+	return retry, nil
+}
+
+// retry delivers the retried event to the endpoint once more. The event is removed if the
+// delivery succeeds; otherwise it is rescheduled or dead-lettered.
+func (s *Service) retry(ctx context.Context, endpoint *Endpoint, retry *Retry, id DeliveryID, redeliveryOf DeliveryID) (*Delivery, error) {
+	delivery, err := s.deliver(ctx, s.sender, endpoint, &Delivery{
+		ID:           id,
+		EndpointID:   endpoint.ID,
+		EventID:      retry.EventID,
+		Topic:        retry.Topic,
+		Payload:      retry.Payload,
+		RedeliveryOf: redeliveryOf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Succeeded() {
+		if err := s.retryRepo.Delete(ctx, retry.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete webhook retry: %w", err)
+		}
+		return delivery, nil
+	}
+	retry.failed(delivery, s.retryPolicy)
+	if err := s.retryRepo.Update(ctx, retry.ID, *retry); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook retry: %w", err)
+	}
+	return delivery, nil
+}
+
+// deliver sends the delivery and records the outcome.
+// Failed deliveries are recorded, not returned as error; only persistence failures are.
+func (s *Service) deliver(ctx context.Context, sender Sender, endpoint *Endpoint, delivery *Delivery) (*Delivery, error) {
+	delivery.AttemptedAt = time.Now()
+	status, err := sender.Send(ctx, endpoint, delivery)
+	delivery.Latency = time.Since(delivery.AttemptedAt)
+	delivery.StatusCode = status
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	if err := s.deliveryRepo.Create(ctx, delivery.ID, *delivery); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook delivery: %w", err)
+	}
+	if err := s.prune(ctx, endpoint.ID); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// RegisterGuestEndpoint validates and stores an endpoint of the guest's own automation.
+// It receives no events until a ping succeeded (PingGuestEndpoint).
+func (s *Service) RegisterGuestEndpoint(ctx context.Context, id EndpointID, guestID, url, secret string, topics []string) (*Endpoint, error) {
+	if !s.GuestEndpointsEnabled() {
+		return nil, ErrGuestDisabled
+	}
+	endpoint, err := NewGuestEndpoint(id, guestID, url, secret, topics, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guestEndpointRepo.Create(ctx, endpoint.ID, *endpoint); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// GuestEndpoints returns the endpoints of the guest, oldest first.
+func (s *Service) GuestEndpoints(ctx context.Context, guestID string) ([]Endpoint, error) {
+	if !s.GuestEndpointsEnabled() {
+		return nil, ErrGuestDisabled
+	}
+	all, err := s.guestEndpointRepo.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	var endpoints []Endpoint
+	for _, endpoint := range all {
+		if endpoint.GuestID == guestID {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	slices.SortFunc(endpoints, func(a, b Endpoint) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return endpoints, nil
+}
+
+// GuestEndpoint returns the endpoint of the guest. Endpoints of other guests are not found,
+// so guests cannot tell whether an ID exists.
+func (s *Service) GuestEndpoint(ctx context.Context, guestID string, id EndpointID) (*Endpoint, error) {
+	if !s.GuestEndpointsEnabled() {
+		return nil, ErrGuestDisabled
+	}
+	endpoint, err := s.guestEndpointRepo.Read(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEndpointNotFound, err)
+	}
+	if endpoint.GuestID != guestID {
+		return nil, ErrEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+// PingGuestEndpoint sends a ping to the endpoint of the guest and marks it verified if it answers with 2xx.
+// A failed ping is recorded as delivery; an endpoint verified before stays verified.
+func (s *Service) PingGuestEndpoint(ctx context.Context, id DeliveryID, guestID string, endpointID EndpointID) (*Delivery, error) {
+	endpoint, err := s.GuestEndpoint(ctx, guestID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(map[string]string{"endpoint_id": string(endpoint.ID)})
+	delivery, err := s.deliverEvent(ctx, endpoint, id, Event{ID: "evt-" + string(id), Topic: TopicPing, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil || !delivery.Succeeded() {
+		return delivery, err
+	}
+	endpoint.VerifiedAt = delivery.AttemptedAt
+	if err := s.guestEndpointRepo.Update(ctx, endpoint.ID, *endpoint); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook endpoint: %w", err)
+	}
+	return delivery, nil
+}
+
+// SetGuestEndpointDisabled disables or enables the endpoint of the guest.
+func (s *Service) SetGuestEndpointDisabled(ctx context.Context, guestID string, id EndpointID, disabled bool) (*Endpoint, error) {
+	endpoint, err := s.GuestEndpoint(ctx, guestID, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Disabled = disabled
+	if err := s.guestEndpointRepo.Update(ctx, endpoint.ID, *endpoint); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// DeleteGuestEndpoint deletes the endpoint of the guest; its recorded deliveries are pruned with it.
+func (s *Service) DeleteGuestEndpoint(ctx context.Context, guestID string, id EndpointID) error {
+	endpoint, err := s.GuestEndpoint(ctx, guestID, id)
+	if err != nil {
+		return err
+	}
+	deliveries, err := s.Deliveries(ctx, endpoint.ID, 0)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		if err := s.deliveryRepo.Delete(ctx, d.ID); err != nil {
+			return fmt.Errorf("failed to delete webhook delivery: %w", err)
+		}
+	}
+	if err := s.guestEndpointRepo.Delete(ctx, endpoint.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// DeliverGuestEvent sends an event about a reservation to the active endpoints of its owner
+// that subscribe to the topic. The delivery IDs derive from the event ID, so an event that is
+// delivered again, e.g. replayed by Kafka, is not sent twice.
+func (s *Service) DeliverGuestEvent(ctx context.Context, guestID, eventID, topic string, data json.RawMessage) ([]Delivery, error) {
+	if !s.GuestEndpointsEnabled() || guestID == "" {
+		return nil, nil
+	}
+	endpoints, err := s.GuestEndpoints(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []Delivery
+	for _, endpoint := range endpoints {
+		if !endpoint.Active() || !endpoint.Subscribes(topic) {
+			continue
+		}
+		id := DeliveryID(string(endpoint.ID) + "-" + eventID)
+		if _, err := s.deliveryRepo.Read(ctx, id); err == nil {
+			continue
+		}
+		delivery, err := s.deliverEvent(ctx, &endpoint, id, Event{ID: eventID, Topic: topic, CreatedAt: time.Now().UTC(), Data: data})
+		if err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, nil
+}
+
+// deliverEvent encodes the event and delivers it to the guest endpoint.
+func (s *Service) deliverEvent(ctx context.Context, endpoint *Endpoint, id DeliveryID, event Event) (*Delivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+	return s.deliver(ctx, s.guestSender, endpoint, &Delivery{
+		ID:         id,
+		EndpointID: endpoint.ID,
+		EventID:    event.ID,
+		Topic:      event.Topic,
+		Payload:    string(payload),
+	})
+}
+
+// prune deletes the deliveries of the endpoint beyond DeliveriesPerEndpoint.
+func (s *Service) prune(ctx context.Context, endpointID EndpointID) error {
+	deliveries, err := s.Deliveries(ctx, endpointID, 0)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries[min(len(deliveries), DeliveriesPerEndpoint):] {
+		if err := s.deliveryRepo.Delete(ctx, d.ID); err != nil {
+			return fmt.Errorf("failed to delete webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
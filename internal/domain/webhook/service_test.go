@@ -0,0 +1,380 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/andygeiss/cloud-native-utils/assert"
+	"github.com/andygeiss/cloud-native-utils/resource"
+	"github.com/andygeiss/hotel-booking/internal/domain/webhook"
+)
+
+// ============================================================================
+// Mock Implementations
+// ============================================================================
+
+type mockSender struct {
+	status int
+	err    error
+	sent   []webhook.Delivery
+}
+
+func (m *mockSender) Send(ctx context.Context, endpoint *webhook.Endpoint, delivery *webhook.Delivery) (int, error) {
+	m.sent = append(m.sent, *delivery)
+	return m.status, m.err
+}
+
+func createTestWebhookService(t *testing.T, sender *mockSender) (*webhook.Service, *webhook.Endpoint) {
+	t.Helper()
+	svc := webhook.NewService(
+		resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](),
+		resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Delivery](),
+		sender,
+	)
+	endpoint, err := svc.RegisterEndpoint(context.Background(), "ep-1", "https://example.com/hooks", "secret", nil)
+	assert.That(t, "err must be nil", err, nil)
+	return svc, endpoint
+}
+
+// ============================================================================
+// Endpoint Tests
+// ============================================================================
+
+func Test_Service_RegisterEndpoint_With_Invalid_URL_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.RegisterEndpoint(context.Background(), "ep-2", "ftp://example.com", "secret", nil)
+
+	// Assert
+	assert.That(t, "err must be ErrInvalidURL", errors.Is(err, webhook.ErrInvalidURL), true)
+}
+
+func Test_Service_RegisterEndpoint_With_Unknown_Topic_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.RegisterEndpoint(context.Background(), "ep-2", "https://example.com", "secret", []string{"room.cleaned"})
+
+	// Assert
+	assert.That(t, "err must be ErrUnknownTopic", errors.Is(err, webhook.ErrUnknownTopic), true)
+}
+
+// ============================================================================
+// Test Event Tests
+// ============================================================================
+
+func Test_Service_SendTestEvent_Should_Record_Delivery(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 204}
+	svc, endpoint := createTestWebhookService(t, sender)
+
+	// Act
+	delivery, err := svc.SendTestEvent(context.Background(), "d-1", endpoint.ID, "reservation.created")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "status must be recorded", delivery.StatusCode, 204)
+	assert.That(t, "delivery must succeed", delivery.Succeeded(), true)
+	var event webhook.Event
+	_ = json.Unmarshal([]byte(sender.sent[0].Payload), &event)
+	assert.That(t, "event must be marked as test", event.Test, true)
+	assert.That(t, "event must have the topic", event.Topic, "reservation.created")
+	deliveries, _ := svc.Deliveries(context.Background(), endpoint.ID, 10)
+	assert.That(t, "delivery must be listed", len(deliveries), 1)
+}
+
+func Test_Service_SendTestEvent_With_Unreachable_Endpoint_Should_Record_Failure(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestWebhookService(t, &mockSender{err: errors.New("connection refused")})
+
+	// Act
+	delivery, err := svc.SendTestEvent(context.Background(), "d-1", endpoint.ID, "payment.captured")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "delivery must fail", delivery.Succeeded(), false)
+	assert.That(t, "error must be recorded", delivery.Error, "connection refused")
+}
+
+func Test_Service_SendTestEvent_With_Unknown_Endpoint_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.SendTestEvent(context.Background(), "d-1", "missing", "payment.captured")
+
+	// Assert
+	assert.That(t, "err must be ErrEndpointNotFound", errors.Is(err, webhook.ErrEndpointNotFound), true)
+}
+
+// ============================================================================
+// Redelivery Tests
+// ============================================================================
+
+func Test_Service_Redeliver_Should_Send_Same_Payload(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 500}
+	svc, endpoint := createTestWebhookService(t, sender)
+	original, _ := svc.SendTestEvent(context.Background(), "d-1", endpoint.ID, "reservation.cancelled")
+	sender.status = 200
+
+	// Act
+	delivery, err := svc.Redeliver(context.Background(), "d-2", original.ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "payload must be unchanged", delivery.Payload, original.Payload)
+	assert.That(t, "event ID must be unchanged", delivery.EventID, original.EventID)
+	assert.That(t, "redelivery must reference original", delivery.RedeliveryOf, original.ID)
+	assert.That(t, "redelivery must succeed", delivery.Succeeded(), true)
+}
+
+func Test_Service_Redeliver_With_Unknown_Delivery_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.Redeliver(context.Background(), "d-2", "missing")
+
+	// Assert
+	assert.That(t, "err must be ErrDeliveryNotFound", errors.Is(err, webhook.ErrDeliveryNotFound), true)
+}
+
+// ============================================================================
+// Delivery Log Tests
+// ============================================================================
+
+func Test_Service_Deliveries_Should_Keep_Recent_Deliveries_Only(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	for i := range webhook.DeliveriesPerEndpoint + 5 {
+		_, _ = svc.SendTestEvent(context.Background(), webhook.DeliveryID(fmt.Sprintf("d-%03d", i)), endpoint.ID, "reservation.confirmed")
+	}
+
+	// Assert
+	deliveries, _ := svc.Deliveries(context.Background(), endpoint.ID, 0)
+	assert.That(t, "deliveries must be pruned", len(deliveries), webhook.DeliveriesPerEndpoint)
+}
+
+// ============================================================================
+// Guest Endpoint Tests
+// ============================================================================
+
+func createTestGuestWebhookService(t *testing.T, sender *mockSender) (*webhook.Service, *webhook.Endpoint) {
+	t.Helper()
+	svc := webhook.NewService(
+		resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](),
+		resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Delivery](),
+		&mockSender{status: 200},
+	).WithGuestEndpoints(resource.NewInMemoryAccess[webhook.EndpointID, webhook.Endpoint](), sender)
+	endpoint, err := svc.RegisterGuestEndpoint(context.Background(), "gep-1", "guest-1", "https://hooks.example.com/guest", "secret", []string{"reservation.cancelled"})
+	assert.That(t, "err must be nil", err, nil)
+	return svc, endpoint
+}
+
+func Test_Service_RegisterGuestEndpoint_Without_Guest_Endpoints_Should_Fail(t *testing.T) {
+	// Arrange
+	svc, _ := createTestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.RegisterGuestEndpoint(context.Background(), "gep-1", "guest-1", "https://hooks.example.com", "secret", nil)
+
+	// Assert
+	assert.That(t, "err must be ErrGuestDisabled", errors.Is(err, webhook.ErrGuestDisabled), true)
+}
+
+func Test_Service_GuestEndpoint_Of_Other_Guest_Should_Not_Be_Found(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestGuestWebhookService(t, &mockSender{status: 200})
+
+	// Act
+	_, err := svc.SetGuestEndpointDisabled(context.Background(), "guest-2", endpoint.ID, true)
+	endpoints, _ := svc.GuestEndpoints(context.Background(), "guest-2")
+
+	// Assert
+	assert.That(t, "err must be ErrEndpointNotFound", errors.Is(err, webhook.ErrEndpointNotFound), true)
+	assert.That(t, "other guest must see no endpoints", len(endpoints), 0)
+}
+
+func Test_Service_PingGuestEndpoint_Should_Verify_Endpoint(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 204}
+	svc, endpoint := createTestGuestWebhookService(t, sender)
+
+	// Act
+	delivery, err := svc.PingGuestEndpoint(context.Background(), "d-1", "guest-1", endpoint.ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "ping must succeed", delivery.Succeeded(), true)
+	assert.That(t, "ping must be sent", sender.sent[0].Topic, webhook.TopicPing)
+	verified, _ := svc.GuestEndpoint(context.Background(), "guest-1", endpoint.ID)
+	assert.That(t, "endpoint must be active", verified.Active(), true)
+}
+
+func Test_Service_PingGuestEndpoint_With_Failed_Ping_Should_Not_Verify(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestGuestWebhookService(t, &mockSender{status: 404})
+
+	// Act
+	delivery, err := svc.PingGuestEndpoint(context.Background(), "d-1", "guest-1", endpoint.ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "ping must fail", delivery.Succeeded(), false)
+	unverified, _ := svc.GuestEndpoint(context.Background(), "guest-1", endpoint.ID)
+	assert.That(t, "endpoint must not be active", unverified.Active(), false)
+}
+
+func Test_Service_DeliverGuestEvent_Should_Send_To_Active_Endpoints_Of_Guest_Once(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 200}
+	svc, endpoint := createTestGuestWebhookService(t, sender)
+	_, _ = svc.PingGuestEndpoint(context.Background(), "d-1", "guest-1", endpoint.ID)
+	data := json.RawMessage(`{"reservation_id":"res-1"}`)
+
+	// Act
+	first, err := svc.DeliverGuestEvent(context.Background(), "guest-1", "evt-1", "reservation.cancelled", data)
+	replayed, _ := svc.DeliverGuestEvent(context.Background(), "guest-1", "evt-1", "reservation.cancelled", data)
+	unsubscribed, _ := svc.DeliverGuestEvent(context.Background(), "guest-1", "evt-2", "reservation.confirmed", data)
+	otherGuest, _ := svc.DeliverGuestEvent(context.Background(), "guest-2", "evt-3", "reservation.cancelled", data)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "event must be delivered", len(first), 1)
+	assert.That(t, "replayed event must not be sent again", len(replayed), 0)
+	assert.That(t, "unsubscribed topic must not be sent", len(unsubscribed), 0)
+	assert.That(t, "events of other guests must not be sent", len(otherGuest), 0)
+	assert.That(t, "ping and one event must be sent", len(sender.sent), 2)
+}
+
+func Test_Service_DeliverGuestEvent_To_Disabled_Endpoint_Should_Not_Send(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 200}
+	svc, endpoint := createTestGuestWebhookService(t, sender)
+	_, _ = svc.PingGuestEndpoint(context.Background(), "d-1", "guest-1", endpoint.ID)
+	_, _ = svc.SetGuestEndpointDisabled(context.Background(), "guest-1", endpoint.ID, true)
+
+	// Act
+	deliveries, err := svc.DeliverGuestEvent(context.Background(), "guest-1", "evt-1", "reservation.cancelled", json.RawMessage(`{}`))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be delivered", len(deliveries), 0)
+}
+
+func Test_Service_DeleteGuestEndpoint_Should_Delete_Deliveries(t *testing.T) {
+	// Arrange
+	svc, endpoint := createTestGuestWebhookService(t, &mockSender{status: 200})
+	_, _ = svc.PingGuestEndpoint(context.Background(), "d-1", "guest-1", endpoint.ID)
+
+	// Act
+	err := svc.DeleteGuestEndpoint(context.Background(), "guest-1", endpoint.ID)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	deliveries, _ := svc.Deliveries(context.Background(), endpoint.ID, 0)
+	assert.That(t, "deliveries must be deleted", len(deliveries), 0)
+	endpoints, _ := svc.GuestEndpoints(context.Background(), "guest-1")
+	assert.That(t, "endpoint must be deleted", len(endpoints), 0)
+}
+
+// ============================================================================
+// Event Delivery Tests
+// ============================================================================
+
+func createTestRetryingWebhookService(t *testing.T, sender *mockSender) (*webhook.Service, *webhook.Endpoint) {
+	t.Helper()
+	svc, endpoint := createTestWebhookService(t, sender)
+	svc.WithRetries(resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Retry](), webhook.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute})
+	return svc, endpoint
+}
+
+func Test_Service_DeliverEvent_Should_Send_To_Subscribed_Endpoints_Once(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 200}
+	svc, _ := createTestWebhookService(t, sender)
+	_, err := svc.RegisterEndpoint(context.Background(), "ep-2", "https://example.com/payments", "secret", []string{"payment.captured"})
+	assert.That(t, "err must be nil", err, nil)
+	data := json.RawMessage(`{"reservation_id":"res-1"}`)
+
+	// Act
+	deliveries, err := svc.DeliverEvent(context.Background(), "evt-1", "reservation.created", data)
+	_, _ = svc.DeliverEvent(context.Background(), "evt-1", "reservation.created", data)
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "one delivery must be sent", len(sender.sent), 1)
+	assert.That(t, "delivery must go to the subscribed endpoint", deliveries[0].ID, webhook.DeliveryID("ep-1-evt-1"))
+}
+
+func Test_Service_RetryDue_Should_Retry_Failed_Delivery_With_Backoff(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 503}
+	svc, _ := createTestRetryingWebhookService(t, sender)
+	_, err := svc.DeliverEvent(context.Background(), "evt-1", "reservation.created", json.RawMessage(`{}`))
+	assert.That(t, "err must be nil", err, nil)
+	sender.status = 200
+
+	// Act
+	early, err1 := svc.RetryDue(context.Background(), time.Now())
+	delivered, err2 := svc.RetryDue(context.Background(), time.Now().Add(time.Minute))
+
+	// Assert
+	assert.That(t, "err1 must be nil", err1, nil)
+	assert.That(t, "err2 must be nil", err2, nil)
+	assert.That(t, "retry must wait for the backoff", early, 0)
+	assert.That(t, "retry must be delivered", delivered, 1)
+	assert.That(t, "retry must have its own ID", sender.sent[1].ID, webhook.DeliveryID("ep-1-evt-1-2"))
+	deadLetters, _ := svc.DeadLetters(context.Background())
+	assert.That(t, "there must be no dead letters", len(deadLetters), 0)
+}
+
+func Test_Service_RetryDue_After_Last_Attempt_Should_Dead_Letter_Event(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 500}
+	svc, _ := createTestRetryingWebhookService(t, sender)
+	_, _ = svc.DeliverEvent(context.Background(), "evt-1", "reservation.created", json.RawMessage(`{}`))
+
+	// Act
+	_, _ = svc.RetryDue(context.Background(), time.Now().Add(time.Minute))
+	_, _ = svc.RetryDue(context.Background(), time.Now().Add(time.Hour))
+	delivered, err := svc.RetryDue(context.Background(), time.Now().Add(24*time.Hour))
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "nothing must be delivered", delivered, 0)
+	assert.That(t, "three attempts must be sent", len(sender.sent), 3)
+	deadLetters, _ := svc.DeadLetters(context.Background())
+	assert.That(t, "event must be dead-lettered", len(deadLetters), 1)
+	assert.That(t, "dead letter must record the attempts", deadLetters[0].Attempts, 3)
+	assert.That(t, "dead letter must record the status", deadLetters[0].LastError, "unexpected status 500")
+}
+
+func Test_Service_ReplayDeadLetter_Should_Remove_Delivered_Dead_Letter(t *testing.T) {
+	// Arrange
+	sender := &mockSender{status: 500}
+	svc, _ := createTestWebhookService(t, sender)
+	svc.WithRetries(resource.NewInMemoryAccess[webhook.DeliveryID, webhook.Retry](), webhook.RetryPolicy{MaxAttempts: 1, Backoff: time.Minute})
+	_, _ = svc.DeliverEvent(context.Background(), "evt-1", "reservation.created", json.RawMessage(`{}`))
+	sender.status = 200
+
+	// Act
+	delivery, err := svc.ReplayDeadLetter(context.Background(), "d-1", "ep-1-evt-1")
+
+	// Assert
+	assert.That(t, "err must be nil", err, nil)
+	assert.That(t, "delivery must succeed", delivery.Succeeded(), true)
+	assert.That(t, "delivery must refer to the dead letter", delivery.RedeliveryOf, webhook.DeliveryID("ep-1-evt-1"))
+	_, err = svc.ReplayDeadLetter(context.Background(), "d-2", "ep-1-evt-1")
+	assert.That(t, "err must be ErrDeadLetterNotFound", errors.Is(err, webhook.ErrDeadLetterNotFound), true)
+}
@@ -0,0 +1,14 @@
+// Package migrations embeds the schema migrations of the reservation and payment databases,
+// so the server applies them on startup (outbound.Migrator) without Docker init scripts.
+//
+// Each database has a directory of numbered pairs, NNNN_name.up.sql and NNNN_name.down.sql.
+// Migrations are applied in the order of their numbers and recorded in schema_migrations;
+// never change a released file, add the next number instead. The SQL must run on PostgreSQL and SQLite.
+package migrations
+
+import "embed"
+
+// FS holds the migrations, one directory per database.
+//
+//go:embed reservation/*.sql payment/*.sql
+var FS embed.FS
@@ -0,0 +1,7 @@
+-- Reservations, stored by key like resource.PostgresAccess from cloud-native-utils.
+CREATE TABLE IF NOT EXISTS kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_kv_store_key ON kv_store (key);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS staff_kv_store;
+DROP TABLE IF EXISTS rate_plan_kv_store;
+DROP TABLE IF EXISTS referral_kv_store;
+DROP TABLE IF EXISTS referral_code_kv_store;
+DROP TABLE IF EXISTS profile_language_kv_store;
+DROP TABLE IF EXISTS profile_tier_kv_store;
+DROP TABLE IF EXISTS profile_merge_kv_store;
+DROP TABLE IF EXISTS survey_kv_store;
+DROP TABLE IF EXISTS household_kv_store;
@@ -0,0 +1,54 @@
+-- Tables of the other aggregate types, because PostgresAccess reads all rows of kv_store.
+-- The server also creates them on startup (outbound.NewTableAccess and Init); tables added
+-- later are only created there.
+CREATE TABLE IF NOT EXISTS household_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- NPS surveys.
+CREATE TABLE IF NOT EXISTS survey_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- The audit trail of guest profile merges.
+CREATE TABLE IF NOT EXISTS profile_merge_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- VIP tiers tagged by staff.
+CREATE TABLE IF NOT EXISTS profile_tier_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- The email languages of guests.
+CREATE TABLE IF NOT EXISTS profile_language_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- Referral codes and referrals.
+CREATE TABLE IF NOT EXISTS referral_code_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+CREATE TABLE IF NOT EXISTS referral_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- Rate plans of the pricing context, keyed by room.
+CREATE TABLE IF NOT EXISTS rate_plan_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- Staff accounts provisioned via SCIM; deprovisioned accounts are kept to stay locked out.
+CREATE TABLE IF NOT EXISTS staff_kv_store (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);